@@ -40,6 +40,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common/reload"
 	"github.com/elastic/beats/v7/libbeat/esleg/eslegclient"
 	"github.com/elastic/beats/v7/libbeat/management"
+	"github.com/elastic/beats/v7/libbeat/management/inputcontrol"
 	"github.com/elastic/beats/v7/libbeat/monitoring/inputmon"
 	"github.com/elastic/beats/v7/libbeat/outputs/elasticsearch"
 	"github.com/elastic/beats/v7/libbeat/publisher/pipetool"
@@ -75,6 +76,7 @@ type Filebeat struct {
 	pipeline                 beat.PipelineConnector
 	logger                   *logp.Logger
 	otelStatusFactoryWrapper func(cfgfile.RunnerFactory) cfgfile.RunnerFactory
+	inputControl             *inputcontrol.Registry
 }
 
 type PluginFactory func(beat.Info, *logp.Logger, statestore.States) []v2.Plugin
@@ -122,11 +124,17 @@ func newBeater(b *beat.Beat, plugins PluginFactory, rawConfig *conf.C) (beat.Bea
 		return nil, err
 	}
 
+	inputControl := inputcontrol.NewRegistry(b.Info.Logger)
 	if b.API != nil {
 		if err = inputmon.AttachHandler(b.API.Router(), b.Monitoring.InputsRegistry()); err != nil {
 			return nil, fmt.Errorf("failed attach inputs api to monitoring endpoint server: %w", err)
 		}
 	}
+	if b.API != nil && b.API.InputControlEnabled() {
+		if err = inputcontrol.AttachHandler(b.API.Router(), inputControl); err != nil {
+			return nil, fmt.Errorf("failed attach input control api to monitoring endpoint server: %w", err)
+		}
+	}
 
 	// Add inputs created by the modules
 	config.Inputs = append(config.Inputs, moduleInputs...)
@@ -160,6 +168,7 @@ func newBeater(b *beat.Beat, plugins PluginFactory, rawConfig *conf.C) (beat.Bea
 		moduleRegistry: moduleRegistry,
 		pluginFactory:  plugins,
 		logger:         b.Info.Logger,
+		inputControl:   inputControl,
 	}
 
 	err = fb.setupPipelineLoaderCallback(b)
@@ -270,6 +279,8 @@ func (fb *Filebeat) Run(b *beat.Beat) error {
 			"registry.tar.gz",
 			"application/octet-stream",
 			gzipRegistry(b.Info.Logger, b.Info.Paths))
+
+		b.Manager.RegisterAction(fb.inputControl.FleetAction())
 	}
 
 	if !fb.moduleRegistry.Empty() {
@@ -414,6 +425,12 @@ func (fb *Filebeat) Run(b *beat.Beat) error {
 		inputLoader = fb.otelStatusFactoryWrapper(inputLoader)
 	}
 
+	// Inputs can't be controlled at runtime when running once, as the
+	// crawler waits for them to complete.
+	if !*once {
+		inputLoader = fb.inputControl.Factory(inputLoader)
+	}
+
 	// Create a ES connection factory for dynamic modules pipeline loading
 	var pipelineLoaderFactory fileset.PipelineLoaderFactory
 	// The pipelineFactory needs a context to control the connections to ES,
@@ -45,7 +45,9 @@ type commonInputConfig struct {
 	KeepNull             bool                    `config:"keep_null"`
 
 	PublisherPipeline struct {
-		DisableHost bool `config:"disable_host"` // Disable addition of host.name.
+		DisableHost bool          `config:"disable_host"` // Disable addition of host.name.
+		Priority    beat.Priority `config:"priority"`     // Queue lane of the events.
+		Name        string        `config:"name"`         // Named pipeline of the events.
 	} `config:"publisher_pipeline"`
 
 	// implicit event fields
@@ -187,6 +189,12 @@ func newCommonConfigEditor(
 		clientCfg.Processing.Processor = procs
 		clientCfg.Processing.KeepNull = config.KeepNull
 		clientCfg.Processing.DisableHost = config.PublisherPipeline.DisableHost
+		if config.PublisherPipeline.Priority != beat.NormalPriority {
+			clientCfg.Priority = config.PublisherPipeline.Priority
+		}
+		if config.PublisherPipeline.Name != "" {
+			clientCfg.PublisherPipeline = config.PublisherPipeline.Name
+		}
 
 		return clientCfg, nil
 	}, nil
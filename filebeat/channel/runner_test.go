@@ -193,6 +193,64 @@ func TestProcessorsForConfigIsFlat(t *testing.T) {
 	assert.Len(t, lst.(*processors.Processors).List, 2) //nolint:errcheck //Safe to ignore in tests
 }
 
+func TestPublisherPipelinePriority(t *testing.T) {
+	testCases := map[string]struct {
+		configStr string
+		clientCfg beat.ClientConfig
+		expected  beat.Priority
+	}{
+		"default": {
+			expected: beat.NormalPriority,
+		},
+		"high": {
+			configStr: "publisher_pipeline.priority: high",
+			expected:  beat.HighPriority,
+		},
+		"high set by the input": {
+			clientCfg: beat.ClientConfig{Priority: beat.HighPriority},
+			expected:  beat.HighPriority,
+		},
+		"high set by the input and normal in the config": {
+			configStr: "publisher_pipeline.priority: normal",
+			clientCfg: beat.ClientConfig{Priority: beat.HighPriority},
+			expected:  beat.HighPriority,
+		},
+	}
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			config, err := conf.NewConfigFrom(test.configStr)
+			require.NoError(t, err)
+			editor, err := newCommonConfigEditor(beat.Info{Logger: logptest.NewTestingLogger(t, "")}, config)
+			require.NoError(t, err)
+			clientCfg, err := editor(test.clientCfg)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, clientCfg.Priority)
+		})
+	}
+
+	config, err := conf.NewConfigFrom("publisher_pipeline.priority: urgent")
+	require.NoError(t, err)
+	_, err = newCommonConfigEditor(beat.Info{}, config)
+	assert.Error(t, err)
+}
+
+func TestPublisherPipelineName(t *testing.T) {
+	config, err := conf.NewConfigFrom("publisher_pipeline.name: security")
+	require.NoError(t, err)
+	editor, err := newCommonConfigEditor(beat.Info{Logger: logptest.NewTestingLogger(t, "")}, config)
+	require.NoError(t, err)
+	clientCfg, err := editor(beat.ClientConfig{})
+	require.NoError(t, err)
+	assert.Equal(t, "security", clientCfg.PublisherPipeline)
+
+	// The pipeline selected by the input is kept when none is configured.
+	editor, err = newCommonConfigEditor(beat.Info{Logger: logptest.NewTestingLogger(t, "")}, conf.NewConfig())
+	require.NoError(t, err)
+	clientCfg, err = editor(beat.ClientConfig{PublisherPipeline: "debug"})
+	require.NoError(t, err)
+	assert.Equal(t, "debug", clientCfg.PublisherPipeline)
+}
+
 // setRawIndex is a bare-bones processor to set the raw_index field to a
 // constant string in the event metadata. It is used to test order of operations
 // for processorsForConfig.
@@ -0,0 +1,181 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/v7/filebeat/beater"
+	cfg "github.com/elastic/beats/v7/filebeat/config"
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/cmd/instance"
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/beats/v7/libbeat/statestore/backend/memory"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/go-concert/unison"
+)
+
+// genTestInputCmd returns the command testing the configuration of the
+// inputs and, for inputs supporting it, that the services they collect
+// from can be reached with the configured credentials.
+func genTestInputCmd(settings instance.Settings, plugins beater.PluginFactory) *cobra.Command {
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "input [id]",
+		Short: "Test the configuration and connectivity of inputs",
+		Long: "Test the configuration of the inputs defined in filebeat.inputs, or of the input with the given id,\n" +
+			"and perform dry-run connectivity checks such as validating credentials and reading the configured\n" +
+			"resources. No data is collected.",
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			b, err := instance.NewInitializedBeat(settings)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error initializing beat: %s\n", err)
+				os.Exit(1)
+			}
+			beatConfig, err := b.BeatConfig()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading configuration: %s\n", err)
+				os.Exit(1)
+			}
+			config := cfg.DefaultConfig
+			if err := beatConfig.Unpack(&config); err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading configuration: %s\n", err)
+				os.Exit(1)
+			}
+
+			var id string
+			if len(args) != 0 {
+				id = args[0]
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			ok, err := testInputs(ctx, os.Stdout, b.Info, plugins, config.Inputs, id)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error testing inputs: %s\n", err)
+				os.Exit(1)
+			}
+			if !ok {
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "Timeout for the connectivity checks of all inputs")
+	return cmd
+}
+
+// testInputs configures and tests the enabled inputs, or only the input
+// with the given id if it is not empty, writing the results to w. It
+// returns whether all tests passed.
+func testInputs(ctx context.Context, w io.Writer, info beat.Info, plugins beater.PluginFactory, inputs []*conf.C, id string) (bool, error) {
+	log := info.Logger
+	if log == nil {
+		log = logp.NewNopLogger()
+	}
+	log = log.Named("input")
+
+	// The inputs are only configured and tested, their state is kept in
+	// memory so the registry of a running Filebeat is not touched.
+	states := memoryStates{reg: statestore.NewRegistry(memory.New())}
+	loader, err := v2.NewLoader(log, plugins(info, log, states), "type", cfg.DefaultType)
+	if err != nil {
+		return false, err
+	}
+	var group unison.TaskGroup
+	defer func() {
+		_ = group.Stop()
+	}()
+	if err := loader.Init(&group); err != nil {
+		return false, fmt.Errorf("failed to initialize the input managers: %w", err)
+	}
+
+	ok, found := true, false
+	for _, c := range inputs {
+		settings := struct {
+			ID   string `config:"id"`
+			Type string `config:"type"`
+		}{Type: cfg.DefaultType}
+		if err := c.Unpack(&settings); err != nil {
+			return false, fmt.Errorf("failed to read input configuration: %w", err)
+		}
+		if id != "" && settings.ID != id {
+			continue
+		}
+		found = true
+
+		name := settings.Type + " input"
+		if settings.ID != "" {
+			name += fmt.Sprintf(" %q", settings.ID)
+		}
+		if !c.Enabled() {
+			fmt.Fprintf(w, "%s... SKIPPED (disabled)\n", name)
+			continue
+		}
+		fmt.Fprintf(w, "%s...\n", name)
+
+		inp, err := loader.Configure(c)
+		if err != nil {
+			if v2.IsUnknownInputError(err) {
+				fmt.Fprintln(w, "  configuration... SKIPPED (testing is not supported by this input type)")
+				continue
+			}
+			fmt.Fprintf(w, "  configuration... ERROR %v\n", err)
+			ok = false
+			continue
+		}
+		fmt.Fprintln(w, "  configuration... OK")
+
+		err = inp.Test(v2.TestContext{
+			Logger:      log.With("id", settings.ID),
+			Agent:       info,
+			Cancelation: ctx,
+		})
+		if err != nil {
+			fmt.Fprintf(w, "  connectivity... ERROR %v\n", err)
+			ok = false
+			continue
+		}
+		fmt.Fprintln(w, "  connectivity... OK")
+	}
+	if id != "" && !found {
+		return false, fmt.Errorf("no input with id %q is configured", id)
+	}
+	return ok, nil
+}
+
+// memoryStates is a statestore.States holding the state of the
+// inputs in memory.
+type memoryStates struct {
+	reg *statestore.Registry
+}
+
+func (s memoryStates) StoreFor(string) (*statestore.Store, error) {
+	return s.reg.Get("filebeat")
+}
+
+func (memoryStates) CleanupInterval() time.Duration {
+	return time.Hour
+}
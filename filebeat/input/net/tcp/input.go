@@ -89,6 +89,10 @@ func newServer(config config) (*server, error) {
 func (s *server) Name() string { return "tcp" }
 
 func (s *server) Test(_ input.TestContext) error {
+	if s.SystemdSocket != "" {
+		// The socket is bound by systemd.
+		return nil
+	}
 	l, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", s.Host)
 	if err != nil {
 		return err
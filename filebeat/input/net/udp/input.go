@@ -84,6 +84,10 @@ func newServer(config config) (*server, error) {
 func (s *server) Name() string { return "udp" }
 
 func (s *server) Test(_ input.TestContext) error {
+	if s.SystemdSocket != "" {
+		// The socket is bound by systemd.
+		return nil
+	}
 	l, err := net.Listen("udp", s.Host)
 	if err != nil {
 		return err
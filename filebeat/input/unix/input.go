@@ -85,6 +85,10 @@ func newServer(config config) (*server, error) {
 func (s *server) Name() string { return "unix" }
 
 func (s *server) Test(_ input.TestContext) error {
+	if s.config.SystemdSocket != "" {
+		// The socket is bound by systemd.
+		return nil
+	}
 	l, err := net.Listen("unix", s.config.Config.Path)
 	if err != nil {
 		return err
@@ -24,7 +24,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"runtime/debug"
 	"sync"
+	"time"
 
 	"github.com/gofrs/uuid/v5"
 	"github.com/gohugoio/hashstructure"
@@ -32,6 +34,7 @@ import (
 	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/cfgfile"
+	"github.com/elastic/beats/v7/libbeat/common/backoff"
 	"github.com/elastic/beats/v7/libbeat/management/status"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -64,6 +67,42 @@ type runner struct {
 	connector          beat.PipelineConnector
 	statusReporter     status.StatusReporter
 	rootInputsRegistry *monitoring.Registry
+	config             runnerConfig
+}
+
+// runnerConfig holds the settings the runner applies to any v2 input, in
+// addition to the settings of the input type.
+type runnerConfig struct {
+	Limits  limitsConfig  `config:"limits"`
+	Restart restartConfig `config:"restart_on_panic"`
+}
+
+// restartConfig configures the restart of an input after it panicked. Only
+// the panicking input is restarted, the other inputs keep running.
+type restartConfig struct {
+	Enabled bool `config:"enabled"`
+	Backoff struct {
+		Init time.Duration `config:"init" validate:"positive"`
+		Max  time.Duration `config:"max" validate:"positive"`
+	} `config:"backoff"`
+}
+
+func defaultRunnerConfig() runnerConfig {
+	var c runnerConfig
+	c.Restart.Enabled = true
+	c.Restart.Backoff.Init = time.Second
+	c.Restart.Backoff.Max = time.Minute
+	return c
+}
+
+// panicError is returned by runInput when the input panicked.
+type panicError struct {
+	value interface{}
+	stack []byte
+}
+
+func (e *panicError) Error() string {
+	return fmt.Sprintf("input panicked: %v", e.value)
 }
 
 // RunnerFactory creates a cfgfile.RunnerFactory from an input Loader that is
@@ -114,6 +153,11 @@ func (f *factory) Create(
 		return nil, err
 	}
 
+	runnerConfig := defaultRunnerConfig()
+	if err := config.Unpack(&runnerConfig); err != nil {
+		return nil, fmt.Errorf("invalid input runner settings: %w", err)
+	}
+
 	return &runner{
 		id:                 id,
 		log:                f.log.Named(input.Name()).With("id", id),
@@ -122,6 +166,7 @@ func (f *factory) Create(
 		input:              input,
 		connector:          p,
 		rootInputsRegistry: f.rootInputsRegistry,
+		config:             runnerConfig,
 	}, nil
 }
 
@@ -148,6 +193,11 @@ func (r *runner) Start() {
 			r.log)
 		defer cancelMetrics()
 
+		var limits *limiterMetrics
+		if r.config.Limits.enabled() {
+			limits = newLimiterMetrics(reg)
+		}
+
 		ctx := v2.Context{
 			ID:              r.id,
 			IDWithoutName:   r.id,
@@ -159,16 +209,58 @@ func (r *runner) Start() {
 		}
 		ctx = ctx.WithStatusReporter(r.statusReporter)
 
-		err := r.input.Run(ctx, pc)
-		if err != nil && !errors.Is(err, context.Canceled) {
-			errMsg := fmt.Sprintf("Input '%s' failed with: %+v", name, err)
-			log.Error(errMsg)
-			ctx.UpdateStatus(status.Failed, errMsg)
-		} else {
-			log.Infof("Input '%s' stopped (goroutine)", name)
-			ctx.UpdateStatus(status.Stopped, "")
+		restarts := monitoring.NewUint(reg, "restarts_total")
+		restartBackoff := backoff.NewExpBackoff(r.sig.Done(), r.config.Restart.Backoff.Init, r.config.Restart.Backoff.Max)
+		for {
+			started := time.Now()
+			runPC := pc
+			if limits != nil {
+				runPC = withLimiter(pc, newLimiter(r.config.Limits, r.sig.Done(), limits))
+			}
+			err := runInput(r.input, ctx, runPC)
+
+			var panicErr *panicError
+			if errors.As(err, &panicErr) {
+				errMsg := fmt.Sprintf("Input '%s' panicked: %v", name, panicErr.value)
+				log.Errorw(errMsg, "stack", string(panicErr.stack))
+				if r.config.Restart.Enabled && r.sig.Err() == nil {
+					ctx.UpdateStatus(status.Degraded, errMsg)
+					// An input that ran for longer than the maximum
+					// backoff is restarted without delay increase.
+					if time.Since(started) > r.config.Restart.Backoff.Max {
+						restartBackoff.Reset()
+					}
+					if restartBackoff.Wait() {
+						restarts.Inc()
+						log.Infof("Restarting input '%s' after panic", name)
+						continue
+					}
+				}
+			}
+
+			if err != nil && !errors.Is(err, context.Canceled) {
+				errMsg := fmt.Sprintf("Input '%s' failed with: %+v", name, err)
+				log.Error(errMsg)
+				ctx.UpdateStatus(status.Failed, errMsg)
+			} else {
+				log.Infof("Input '%s' stopped (goroutine)", name)
+				ctx.UpdateStatus(status.Stopped, "")
+			}
+			return
+		}
+	}()
+}
+
+// runInput runs the input, converting a panic of its Run method into a
+// panicError so it does not crash the Beat. Panics in goroutines started by
+// the input can not be recovered.
+func runInput(input v2.Input, ctx v2.Context, pc beat.PipelineConnector) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = &panicError{value: v, stack: debug.Stack()}
 		}
 	}()
+	return input.Run(ctx, pc)
 }
 
 func (r *runner) Stop() {
@@ -23,6 +23,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -226,6 +227,75 @@ func TestRunnerFactory_CreateAndRun(t *testing.T) {
 		assert.Equal(t, status.Stopped, statusReporter.status, "runner status after Stop returns with no errors should be Stopped")
 	})
 
+	t.Run("runner restarts input after panic", func(t *testing.T) {
+		log := logptest.NewTestingLogger(t, "")
+		runs := make(chan int, 3)
+		var countRun int
+		plugins := inputest.SinglePlugin("test", inputest.ConstInputManager(&inputest.MockInput{
+			OnRun: func(ctx v2.Context, _ beat.PipelineConnector) error {
+				countRun++
+				runs <- countRun
+				if countRun < 3 {
+					panic("boom")
+				}
+				<-ctx.Cancelation.Done()
+				return nil
+			},
+		}))
+		loader := inputest.MustNewTestLoader(t, plugins, "type", "test")
+		factory := RunnerFactory(
+			log,
+			beat.Info{Logger: log},
+			monitoring.NewRegistry(),
+			loader.Loader)
+
+		runner, err := factory.Create(nil, conf.MustNewConfigFrom(map[string]interface{}{
+			"type":                     "test",
+			"restart_on_panic.backoff": map[string]interface{}{"init": "1ms", "max": "10ms"},
+		}))
+		require.NoError(t, err)
+
+		runner.Start()
+		for want := 1; want <= 3; want++ {
+			select {
+			case got := <-runs:
+				assert.Equal(t, want, got)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("input was not restarted after panic %d", want-1)
+			}
+		}
+		runner.Stop()
+	})
+
+	t.Run("runner reports failed status when restart is disabled", func(t *testing.T) {
+		log := logptest.NewTestingLogger(t, "")
+		plugins := inputest.SinglePlugin("test", inputest.ConstInputManager(&inputest.MockInput{
+			OnRun: func(_ v2.Context, _ beat.PipelineConnector) error {
+				panic("boom")
+			},
+		}))
+		loader := inputest.MustNewTestLoader(t, plugins, "type", "test")
+		factory := RunnerFactory(
+			log,
+			beat.Info{Logger: log},
+			monitoring.NewRegistry(),
+			loader.Loader)
+
+		runner, err := factory.Create(nil, conf.MustNewConfigFrom(map[string]interface{}{
+			"type":                     "test",
+			"restart_on_panic.enabled": false,
+		}))
+		require.NoError(t, err)
+
+		statusReporter := &mockStatusReporter{}
+		runner.(status.WithStatusReporter).SetStatusReporter(statusReporter)
+
+		runner.Start()
+		runner.Stop()
+		assert.Equal(t, status.Failed, statusReporter.status)
+		assert.Contains(t, statusReporter.desc, "input panicked: boom")
+	})
+
 	t.Run("fail if input type is unknown to loader", func(t *testing.T) {
 		log := logptest.NewTestingLogger(t, "")
 		plugins := inputest.SinglePlugin("test", inputest.ConstInputManager(nil))
@@ -0,0 +1,368 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package compat
+
+import (
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/acker"
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// limitsConfig bounds the events of an input that are published but not yet
+// acknowledged by the outputs. When a limit is reached, Publish blocks until
+// enough events are acknowledged. A zero value disables the limit.
+type limitsConfig struct {
+	MaxInflightEvents int              `config:"max_inflight_events" validate:"min=0"`
+	MaxInflightBytes  cfgtype.ByteSize `config:"max_inflight_bytes" validate:"min=0"`
+}
+
+func (c limitsConfig) enabled() bool {
+	return c.MaxInflightEvents > 0 || c.MaxInflightBytes > 0
+}
+
+// limiter accounts for the in-flight events of a run of an input, shared by
+// all the pipeline clients of the run. An input restarted after a panic gets
+// a new limiter, so that the events of the clients it leaked don't count.
+type limiter struct {
+	maxEvents int
+	maxBytes  int64
+	done      <-chan struct{}
+	metrics   *limiterMetrics
+
+	mu       sync.Mutex
+	events   int
+	bytes    int64
+	released chan struct{} // Closed and replaced when capacity is released.
+}
+
+// limiterMetrics are the metrics of the limiters of an input, shared by its
+// runs.
+type limiterMetrics struct {
+	inflightEvents *monitoring.Int
+	inflightBytes  *monitoring.Int
+	blockedTotal   *monitoring.Uint
+}
+
+func newLimiterMetrics(reg *monitoring.Registry) *limiterMetrics {
+	return &limiterMetrics{
+		inflightEvents: monitoring.NewInt(reg, "inflight_events"),
+		inflightBytes:  monitoring.NewInt(reg, "inflight_bytes"),
+		blockedTotal:   monitoring.NewUint(reg, "publish_blocked_total"),
+	}
+}
+
+func newLimiter(cfg limitsConfig, done <-chan struct{}, metrics *limiterMetrics) *limiter {
+	return &limiter{
+		maxEvents: cfg.MaxInflightEvents,
+		maxBytes:  int64(cfg.MaxInflightBytes),
+		done:      done,
+		metrics:   metrics,
+		released:  make(chan struct{}),
+	}
+}
+
+// fits reports whether an event of the given size can be published. An event
+// is always accepted when nothing is in flight, so an event larger than
+// max_inflight_bytes does not block the input forever.
+func (l *limiter) fits(size int64) bool {
+	if l.events == 0 {
+		return true
+	}
+	if l.maxEvents > 0 && l.events+1 > l.maxEvents {
+		return false
+	}
+	if l.maxBytes > 0 && l.bytes+size > l.maxBytes {
+		return false
+	}
+	return true
+}
+
+// acquire blocks until an event of the given size fits in the limits. It
+// returns false without acquiring anything if the input is stopped.
+func (l *limiter) acquire(size int64) bool {
+	blocked := false
+	for {
+		l.mu.Lock()
+		if l.fits(size) {
+			l.events++
+			l.bytes += size
+			l.metrics.inflightEvents.Inc()
+			l.metrics.inflightBytes.Add(size)
+			l.mu.Unlock()
+			return true
+		}
+		released := l.released
+		l.mu.Unlock()
+
+		if !blocked {
+			blocked = true
+			l.metrics.blockedTotal.Inc()
+		}
+		select {
+		case <-released:
+		case <-l.done:
+			return false
+		}
+	}
+}
+
+func (l *limiter) release(events int, size int64) {
+	if events == 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.events -= events
+	l.bytes -= size
+	l.metrics.inflightEvents.Add(-int64(events))
+	l.metrics.inflightBytes.Add(-size)
+	close(l.released)
+	l.released = make(chan struct{})
+}
+
+// withLimiter wraps the pipeline connector so the clients of the input
+// acquire capacity from l before publishing an event.
+func withLimiter(pipeline beat.PipelineConnector, l *limiter) beat.PipelineConnector {
+	return &limitedPipeline{PipelineConnector: pipeline, limiter: l}
+}
+
+type limitedPipeline struct {
+	beat.PipelineConnector
+	limiter *limiter
+}
+
+func (p *limitedPipeline) Connect() (beat.Client, error) {
+	return p.ConnectWith(beat.ClientConfig{})
+}
+
+func (p *limitedPipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
+	c := &limitedClient{limiter: p.limiter}
+	listener := &limitedClientListener{client: c}
+
+	if cfg.EventListener != nil {
+		cfg.EventListener = acker.Combine(cfg.EventListener, listener)
+	} else {
+		cfg.EventListener = listener
+	}
+	if cfg.ClientListener != nil {
+		cfg.ClientListener = &beat.CombinedClientListener{A: cfg.ClientListener, B: listener}
+	} else {
+		cfg.ClientListener = listener
+	}
+
+	client, err := p.PipelineConnector.ConnectWith(cfg)
+	if err != nil {
+		return nil, err
+	}
+	c.Client = client
+	return c, nil
+}
+
+// limitedClient publishes the events of an input once they fit in the limits.
+// The events are published one at a time so the events queued in the
+// pipeline can be matched with their acknowledgements, which are reported
+// in the publishing order.
+type limitedClient struct {
+	beat.Client
+	limiter *limiter
+
+	mu sync.Mutex // Serializes Publish.
+
+	pendingMu sync.Mutex
+	pending   []pendingEvent
+	closed    bool
+}
+
+// pendingEvent is an event published by the input. Processors can drop an
+// event or split it into several ones, so it is released once the acks
+// events queued for it by the pipeline are acknowledged.
+type pendingEvent struct {
+	acks int
+	size int64
+	open bool // The event is being published, more events can be queued for it.
+}
+
+func (c *limitedClient) Publish(e beat.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.publish(e)
+}
+
+func (c *limitedClient) PublishAll(events []beat.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, e := range events {
+		c.publish(e)
+	}
+}
+
+func (c *limitedClient) publish(e beat.Event) {
+	size := estimateEventSize(e)
+	if !c.limiter.acquire(size) {
+		// The input is stopping: publish without accounting so the
+		// pipeline decides what happens to the event.
+		c.Client.Publish(e)
+		return
+	}
+
+	c.pendingMu.Lock()
+	if c.closed {
+		c.pendingMu.Unlock()
+		c.limiter.release(1, size)
+		c.Client.Publish(e)
+		return
+	}
+	c.pending = append(c.pending, pendingEvent{size: size, open: true})
+	c.pendingMu.Unlock()
+
+	c.Client.Publish(e)
+
+	c.pendingMu.Lock()
+	if c.closed {
+		// The event was released when the client was closed.
+		c.pendingMu.Unlock()
+		return
+	}
+	last := len(c.pending) - 1
+	c.pending[last].open = false
+	done := c.pending[last].acks == 0
+	if done {
+		// The event was dropped, or all the events queued for it were
+		// already acknowledged.
+		c.pending = c.pending[:last]
+	}
+	c.pendingMu.Unlock()
+	if done {
+		c.limiter.release(1, size)
+	}
+}
+
+// queued is called by the pipeline for each event queued for the event being
+// published.
+func (c *limitedClient) queued() {
+	c.pendingMu.Lock()
+	defer c.pendingMu.Unlock()
+	if last := len(c.pending) - 1; last >= 0 && c.pending[last].open {
+		c.pending[last].acks++
+	}
+}
+
+func (c *limitedClient) ack(n int) {
+	c.pendingMu.Lock()
+	var events int
+	var size int64
+	for n > 0 && len(c.pending) != 0 {
+		p := &c.pending[0]
+		consumed := min(n, p.acks)
+		p.acks -= consumed
+		n -= consumed
+		if p.acks != 0 || p.open {
+			break
+		}
+		events++
+		size += p.size
+		c.pending = c.pending[1:]
+	}
+	c.pendingMu.Unlock()
+	c.limiter.release(events, size)
+}
+
+// close releases the events of the client that are not acknowledged yet. The
+// pipeline doesn't report their acknowledgement once the client is closed.
+func (c *limitedClient) close() {
+	c.pendingMu.Lock()
+	events := len(c.pending)
+	var size int64
+	for _, p := range c.pending {
+		size += p.size
+	}
+	c.pending = nil
+	c.closed = true
+	c.pendingMu.Unlock()
+	c.limiter.release(events, size)
+}
+
+// limitedClientListener counts the events queued by the pipeline and
+// forwards their acknowledgements to the client.
+type limitedClientListener struct {
+	client *limitedClient
+}
+
+func (l *limitedClientListener) AddEvent(beat.Event, bool) {}
+func (l *limitedClientListener) ACKEvents(n int)           { l.client.ack(n) }
+func (l *limitedClientListener) ClientClosed()             { l.client.close() }
+
+func (l *limitedClientListener) Closing()                    {}
+func (l *limitedClientListener) Closed()                     {}
+func (l *limitedClientListener) NewEvent()                   {}
+func (l *limitedClientListener) Filtered()                   {}
+func (l *limitedClientListener) Published()                  { l.client.queued() }
+func (l *limitedClientListener) DroppedOnPublish(beat.Event) {}
+
+// estimateEventSize returns an approximation of the memory used by an event,
+// based on the size of its keys and values.
+func estimateEventSize(e beat.Event) int64 {
+	return 64 + estimateSize(e.Fields) + estimateSize(e.Meta)
+}
+
+func estimateSize(v interface{}) int64 {
+	switch v := v.(type) {
+	case nil:
+		return 0
+	case string:
+		return int64(len(v))
+	case []byte:
+		return int64(len(v))
+	case mapstr.M:
+		return estimateMapSize(v)
+	case map[string]interface{}:
+		return estimateMapSize(v)
+	case []interface{}:
+		var n int64
+		for _, e := range v {
+			n += 16 + estimateSize(e)
+		}
+		return n
+	case []string:
+		var n int64
+		for _, s := range v {
+			n += 16 + int64(len(s))
+		}
+		return n
+	case []mapstr.M:
+		var n int64
+		for _, m := range v {
+			n += estimateMapSize(m)
+		}
+		return n
+	default:
+		return 16
+	}
+}
+
+func estimateMapSize(m map[string]interface{}) int64 {
+	n := int64(48)
+	for k, v := range m {
+		n += int64(len(k)) + 16 + estimateSize(v)
+	}
+	return n
+}
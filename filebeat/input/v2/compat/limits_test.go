@@ -0,0 +1,224 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package compat
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// ackPipeline is a pipeline that queues the events of its client and reports
+// the events dropped by the "drop" field and split by the "split" field like
+// the publisher pipeline does.
+type ackPipeline struct {
+	mu  sync.Mutex
+	cfg beat.ClientConfig
+}
+
+func (p *ackPipeline) Connect() (beat.Client, error) { return p.ConnectWith(beat.ClientConfig{}) }
+
+func (p *ackPipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cfg = cfg
+	return &ackClient{cfg: cfg}, nil
+}
+
+func (p *ackPipeline) Disconnect(context.Context) error { return nil }
+
+func (p *ackPipeline) ack(n int) {
+	p.mu.Lock()
+	cfg := p.cfg
+	p.mu.Unlock()
+	cfg.EventListener.ACKEvents(n)
+}
+
+type ackClient struct {
+	cfg beat.ClientConfig
+}
+
+func (c *ackClient) Publish(e beat.Event) {
+	if drop, _ := e.Fields["drop"].(bool); drop {
+		c.cfg.ClientListener.Filtered()
+		return
+	}
+	n, _ := e.Fields["split"].(int)
+	for i := 0; i < max(n, 1); i++ {
+		c.cfg.ClientListener.Published()
+	}
+}
+
+func (c *ackClient) PublishAll(events []beat.Event) {
+	for _, e := range events {
+		c.Publish(e)
+	}
+}
+
+func (c *ackClient) Close() error {
+	c.cfg.EventListener.ClientClosed()
+	return nil
+}
+
+func newTestLimiter(t *testing.T, cfg limitsConfig) (*limiter, *ackPipeline, beat.Client, context.CancelFunc) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	l := newLimiter(cfg, ctx.Done(), newLimiterMetrics(monitoring.NewRegistry()))
+	p := &ackPipeline{}
+	client, err := withLimiter(p, l).ConnectWith(beat.ClientConfig{})
+	require.NoError(t, err)
+	return l, p, client, cancel
+}
+
+func inflight(l *limiter) (int, int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.events, l.bytes
+}
+
+// publishAsync publishes e and returns a channel closed once Publish returned.
+func publishAsync(client beat.Client, e beat.Event) <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.Publish(e)
+	}()
+	return done
+}
+
+func TestLimiterMaxInflightEvents(t *testing.T) {
+	l, p, client, cancel := newTestLimiter(t, limitsConfig{MaxInflightEvents: 2})
+	defer cancel()
+
+	client.Publish(beat.Event{Fields: mapstr.M{"message": "1"}})
+	client.Publish(beat.Event{Fields: mapstr.M{"message": "2"}})
+	events, _ := inflight(l)
+	assert.Equal(t, 2, events)
+
+	done := publishAsync(client, beat.Event{Fields: mapstr.M{"message": "3"}})
+	select {
+	case <-done:
+		t.Fatal("Publish must block while the limit is reached")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	p.ack(1)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish must return once an event is acknowledged")
+	}
+	events, _ = inflight(l)
+	assert.Equal(t, 2, events)
+
+	p.ack(2)
+	events, size := inflight(l)
+	assert.Equal(t, 0, events)
+	assert.Equal(t, int64(0), size)
+}
+
+func TestLimiterMaxInflightBytes(t *testing.T) {
+	large := beat.Event{Fields: mapstr.M{"message": string(make([]byte, 2048))}}
+	l, p, client, cancel := newTestLimiter(t, limitsConfig{MaxInflightBytes: 1024})
+	defer cancel()
+
+	// An event larger than the limit is accepted when nothing is in flight.
+	client.Publish(large)
+	_, size := inflight(l)
+	assert.Equal(t, estimateEventSize(large), size)
+
+	done := publishAsync(client, beat.Event{Fields: mapstr.M{"message": "small"}})
+	select {
+	case <-done:
+		t.Fatal("Publish must block while the limit is reached")
+	case <-time.After(50 * time.Millisecond):
+	}
+	p.ack(1)
+	<-done
+	events, _ := inflight(l)
+	assert.Equal(t, 1, events)
+}
+
+func TestLimiterDroppedAndSplitEvents(t *testing.T) {
+	l, p, client, cancel := newTestLimiter(t, limitsConfig{MaxInflightEvents: 10})
+	defer cancel()
+
+	// Events dropped by the processors are released at once.
+	client.Publish(beat.Event{Fields: mapstr.M{"drop": true}})
+	events, _ := inflight(l)
+	assert.Equal(t, 0, events)
+
+	// An event split in three is released once the three are acknowledged.
+	client.Publish(beat.Event{Fields: mapstr.M{"split": 3}})
+	client.Publish(beat.Event{Fields: mapstr.M{"message": "next"}})
+	p.ack(2)
+	events, _ = inflight(l)
+	assert.Equal(t, 2, events)
+	p.ack(1)
+	events, _ = inflight(l)
+	assert.Equal(t, 1, events)
+	p.ack(1)
+	events, _ = inflight(l)
+	assert.Equal(t, 0, events)
+}
+
+func TestLimiterClientClosed(t *testing.T) {
+	l, p, client, cancel := newTestLimiter(t, limitsConfig{MaxInflightEvents: 2})
+	defer cancel()
+
+	client.Publish(beat.Event{Fields: mapstr.M{"message": "1"}})
+	client.Publish(beat.Event{Fields: mapstr.M{"message": "2"}})
+	require.NoError(t, client.Close())
+	events, size := inflight(l)
+	assert.Equal(t, 0, events, "the events of a closed client must be released")
+	assert.Zero(t, size)
+
+	// The acknowledgements reported after the client was closed are ignored.
+	p.ack(2)
+	events, _ = inflight(l)
+	assert.Equal(t, 0, events)
+
+	other, err := withLimiter(p, l).ConnectWith(beat.ClientConfig{})
+	require.NoError(t, err)
+	select {
+	case <-publishAsync(other, beat.Event{Fields: mapstr.M{"message": "3"}}):
+	case <-time.After(time.Second):
+		t.Fatal("Publish must not block on the events of a closed client")
+	}
+}
+
+func TestLimiterStop(t *testing.T) {
+	_, _, client, cancel := newTestLimiter(t, limitsConfig{MaxInflightEvents: 1})
+
+	client.Publish(beat.Event{Fields: mapstr.M{"message": "1"}})
+	done := publishAsync(client, beat.Event{Fields: mapstr.M{"message": "2"}})
+	cancel()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Publish must return when the input is stopped")
+	}
+}
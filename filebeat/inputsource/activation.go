@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package inputsource
+
+import (
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/coreos/go-systemd/v22/activation"
+)
+
+// listenFiles returns the file descriptors passed by systemd socket
+// activation. It is a variable so tests can replace it.
+var listenFiles = func() []*os.File { return activation.Files(true) }
+
+var (
+	activatedOnce  sync.Once
+	activatedFiles map[string][]*os.File
+)
+
+// activatedFile returns the socket passed by systemd with the given
+// FileDescriptorName. The files are read from the environment only once,
+// and kept open so that inputs can be restarted on the same socket.
+func activatedFile(name string) (*os.File, error) {
+	activatedOnce.Do(func() {
+		activatedFiles = make(map[string][]*os.File)
+		for _, f := range listenFiles() {
+			activatedFiles[f.Name()] = append(activatedFiles[f.Name()], f)
+		}
+	})
+
+	files := activatedFiles[name]
+	switch len(files) {
+	case 0:
+		return nil, fmt.Errorf("no socket named %q was passed by systemd", name)
+	case 1:
+		return files[0], nil
+	default:
+		return nil, fmt.Errorf("systemd passed %d sockets named %q, expected exactly one", len(files), name)
+	}
+}
+
+// ActivatedListener returns a listener for the stream socket passed by
+// systemd socket activation with the given FileDescriptorName.
+func ActivatedListener(name string) (net.Listener, error) {
+	f, err := activatedFile(name)
+	if err != nil {
+		return nil, err
+	}
+	l, err := net.FileListener(f)
+	if err != nil {
+		return nil, fmt.Errorf("socket %q passed by systemd is not a stream socket: %w", name, err)
+	}
+	return l, nil
+}
+
+// ActivatedPacketConn returns a connection for the datagram socket passed
+// by systemd socket activation with the given FileDescriptorName.
+func ActivatedPacketConn(name string) (net.PacketConn, error) {
+	f, err := activatedFile(name)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := net.FilePacketConn(f)
+	if err != nil {
+		return nil, fmt.Errorf("socket %q passed by systemd is not a datagram socket: %w", name, err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !windows
+
+package inputsource
+
+import (
+	"net"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// namedFile returns a duplicate of the socket's file descriptor with the
+// given name, the way systemd passes sockets to the Beat.
+func namedFile(t *testing.T, conn syscall.Conn, name string) *os.File {
+	t.Helper()
+	raw, err := conn.SyscallConn()
+	require.NoError(t, err)
+	var fd int
+	var dupErr error
+	err = raw.Control(func(s uintptr) { fd, dupErr = syscall.Dup(int(s)) })
+	require.NoError(t, err)
+	require.NoError(t, dupErr)
+	return os.NewFile(uintptr(fd), name)
+}
+
+func stubListenFiles(t *testing.T, files ...*os.File) {
+	orig := listenFiles
+	listenFiles = func() []*os.File { return files }
+	activatedOnce, activatedFiles = sync.Once{}, nil
+	t.Cleanup(func() {
+		for _, f := range files {
+			f.Close()
+		}
+		listenFiles = orig
+		activatedOnce, activatedFiles = sync.Once{}, nil
+	})
+}
+
+func TestActivatedSockets(t *testing.T) {
+	tcp, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer tcp.Close()
+	udp, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer udp.Close()
+
+	stubListenFiles(t,
+		namedFile(t, tcp.(*net.TCPListener), "syslog-tcp"),
+		namedFile(t, udp.(*net.UDPConn), "syslog-udp"),
+		namedFile(t, udp.(*net.UDPConn), "dup"),
+		namedFile(t, udp.(*net.UDPConn), "dup"),
+	)
+
+	t.Run("stream", func(t *testing.T) {
+		// The socket can be used again after the listener is closed, as
+		// it happens when an input is restarted.
+		for range 2 {
+			l, err := ActivatedListener("syslog-tcp")
+			require.NoError(t, err)
+			assert.Equal(t, tcp.Addr().String(), l.Addr().String())
+			require.NoError(t, l.Close())
+		}
+	})
+
+	t.Run("datagram", func(t *testing.T) {
+		conn, err := ActivatedPacketConn("syslog-udp")
+		require.NoError(t, err)
+		defer conn.Close()
+		assert.Equal(t, udp.LocalAddr().String(), conn.LocalAddr().String())
+	})
+
+	t.Run("wrong socket type", func(t *testing.T) {
+		_, err := ActivatedListener("syslog-udp")
+		assert.ErrorContains(t, err, "not a stream socket")
+	})
+
+	t.Run("unknown name", func(t *testing.T) {
+		_, err := ActivatedListener("missing")
+		assert.ErrorContains(t, err, `no socket named "missing"`)
+	})
+
+	t.Run("ambiguous name", func(t *testing.T) {
+		_, err := ActivatedPacketConn("dup")
+		assert.ErrorContains(t, err, "expected exactly one")
+	})
+}
@@ -37,6 +37,7 @@ type Config struct {
 	MaxConnections int                     `config:"max_connections"`
 	TLS            *tlscommon.ServerConfig `config:"ssl"`
 	Network        string                  `config:"network"`
+	SystemdSocket  string                  `config:"systemd_socket"`
 }
 
 const (
@@ -72,7 +72,15 @@ func (s *Server) createServer() (net.Listener, error) {
 	var l net.Listener
 	var err error
 	network := s.network()
-	if s.tlsConfig != nil {
+	if s.config.SystemdSocket != "" {
+		l, err = inputsource.ActivatedListener(s.config.SystemdSocket)
+		if err != nil {
+			return nil, err
+		}
+		if s.tlsConfig != nil {
+			l = tls.NewListener(l, s.tlsConfig.BuildServerConfig(s.config.Host))
+		}
+	} else if s.tlsConfig != nil {
 		t := s.tlsConfig.BuildServerConfig(s.config.Host)
 		l, err = tls.Listen(network, s.config.Host, t)
 		if err != nil {
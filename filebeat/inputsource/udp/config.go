@@ -32,6 +32,7 @@ type Config struct {
 	Timeout        time.Duration    `config:"timeout"`
 	ReadBuffer     cfgtype.ByteSize `config:"read_buffer" validate:"positive"`
 	Network        string           `config:"network"`
+	SystemdSocket  string           `config:"systemd_socket"`
 }
 
 const (
@@ -18,6 +18,7 @@
 package udp
 
 import (
+	"fmt"
 	"net"
 
 	"github.com/elastic/beats/v7/filebeat/inputsource"
@@ -50,6 +51,10 @@ func New(config *Config, callback inputsource.NetworkFunc, logger *logp.Logger)
 }
 
 func (u *Server) createConn() (net.PacketConn, error) {
+	if u.config.SystemdSocket != "" {
+		return u.activatedConn()
+	}
+
 	var err error
 	network := u.network()
 	udpAdddr, err := net.ResolveUDPAddr(network, u.config.Host)
@@ -72,6 +77,30 @@ func (u *Server) createConn() (net.PacketConn, error) {
 	return listener, err
 }
 
+// activatedConn returns the UDP socket passed by systemd.
+func (u *Server) activatedConn() (net.PacketConn, error) {
+	conn, err := inputsource.ActivatedPacketConn(u.config.SystemdSocket)
+	if err != nil {
+		return nil, err
+	}
+	listener, ok := conn.(*net.UDPConn)
+	if !ok {
+		conn.Close()
+		return nil, fmt.Errorf("socket %q passed by systemd is not a UDP socket", u.config.SystemdSocket)
+	}
+
+	if int(u.config.ReadBuffer) != 0 {
+		if err := listener.SetReadBuffer(int(u.config.ReadBuffer)); err != nil {
+			listener.Close()
+			return nil, err
+		}
+	}
+
+	u.localaddress = listener.LocalAddr().String()
+
+	return listener, nil
+}
+
 func (u *Server) network() string {
 	if u.config.Network != "" {
 		return u.config.Network
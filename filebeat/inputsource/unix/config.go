@@ -55,6 +55,7 @@ type Config struct {
 	LineDelimiter  string                `config:"line_delimiter"`
 	Framing        streaming.FramingType `config:"framing"`
 	SocketType     SocketType            `config:"socket_type"`
+	SystemdSocket  string                `config:"systemd_socket"`
 }
 
 // Validate validates the Config option for the unix input.
@@ -82,6 +82,18 @@ func New(log *logp.Logger, config *Config, nf inputsource.NetworkFunc) (Server,
 }
 
 func (s *streamServer) createServer() (net.Listener, error) {
+	if s.config.SystemdSocket != "" {
+		// The socket file, its ownership and mode are managed by systemd.
+		l, err := inputsource.ActivatedListener(s.config.SystemdSocket)
+		if err != nil {
+			return nil, err
+		}
+		if s.config.MaxConnections > 0 {
+			return netutil.LimitListener(l, s.config.MaxConnections), nil
+		}
+		return l, nil
+	}
+
 	if err := cleanupStaleSocket(s.config.Path); err != nil {
 		return nil, err
 	}
@@ -106,6 +118,11 @@ func (s *streamServer) createServer() (net.Listener, error) {
 }
 
 func (s *datagramServer) createConn() (net.PacketConn, error) {
+	if s.config.SystemdSocket != "" {
+		// The socket file, its ownership and mode are managed by systemd.
+		return inputsource.ActivatedPacketConn(s.config.SystemdSocket)
+	}
+
 	if err := cleanupStaleSocket(s.config.Path); err != nil {
 		return nil, err
 	}
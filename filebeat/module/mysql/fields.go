@@ -32,5 +32,5 @@ func init() {
 // AssetMysql returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/mysql.
 func AssetMysql() string {
-	return "eJzEmUuv3LoNx/fnUxDopgWSObeLosBZFOhNUDTAvQWKBOjS4Vi0zY4sOpI8j3z6C8qeR/yYRzJz4kWAHI/4/5EyKUp6CyvavUC9C1/sE0DkaOkFft99/O9vTwCGQu65iSzuBf7xBADwu5jWEhTioUEf2JUQK+pGgJUSCrYUFk8AoRIfs1xcweULRN/SE0DBZE14SabegsOajuL6xF1DL1B6aZv+LxMM+vwrGYLCSz0HoM+p3qlmrDyhydgc3uy1rbjy5I8z8vp8qghycY5yfQvie6vw4X2Kj2J9acnvFiN58l78SPrU7Qva78RFZBd6B4dxSPY1GmFxMmwYjEsBOZKhZQyDNw3Gqp+8xdzomkuPHX//AYyVLa3J3qhqpVxMjbtGr6YQsKRb/ZwcNae31wpWNlbKB860Ktw00VbyVRa5pkWgfDIIhRWMgzdnAKFPBayldRGkALV+/PphgxzJHFJCASAKLAlwjWxxaWmhFkZW12hbAg7ADgLl4kx4AxgAO0StPY2wi+Daekl+Memvl03IArmhR5PZfqWrnZ66qtbBU2y9IwPL3VTSj2hoizU7mk61uxCFHN0NQFgUlMdHAtViuOCriJa7SLfNWCG+xtiP/BHUZABUWj/Q3DK5eA7SU068vj5udwbdy3c14RxuCvftgqm4nJmqn7BahLyiGidlV7TbiB8avcbNZBM2Ffmubh39hg0GoC3lbSQzHYS89Z5czNpA/n5c7zqrgG2syEXOUWuoarzRf41+oIYiea0kgHlOIUDjec2WSgoLeIcODBcF+eOS0RVULcRqaNodiyFm5L2T+znzG4YIx74kELlp8RVbO5NO3xdFMZRWpIrAEwZxwMU3S1PoNY888wmU5ZhXlFU8XZmWIpbQ3Ub4v4piRSfNIiSRRFbxTDrHusmirpv350CIVDfi0e8gSSSS/RfnKYhd06USfuDLxGWGw+qR8XJERum+xQ4g+v2H1QXCYZ363jXvP4faPALJPeGxAeIwUY1PsRT61dn6YA0YL81u4K/XQ167+I3wP/JXuiGw09BpVyj+AZm7twzSRK75a1rBDllznuZx+XGeCtAZ4Ph9udN4Fs9xl31pqX1ICdorQFI4UutE7x2bCWxrbabd7yOw1HhfFFUicfUR3G9uLnVMie//wo/jU+PnyeDPToCdoS0F2GjPcwiuDg5/mUavyZeUNRjCAwpTMg6d8UO/NXeKckqVsuj10GKFsQun5hbaUr/TqoZKl2vsujI5Q+rRlZTlulO+O6gK9IRpVo04nVrdJifZsJjubTow2dw/dGqZTNoBnomJ5tJrhmS56/bH+7PLlNEzzbCUmSZO5jFSpjx33HnsmrSs7U9wQDXAcs3xjdZmrTBLgs+BQmBxn/ed6/FnI5McAJvG6j67Ie2z08g3IB4+pxxKRjj2vwrpV2eS61v3pzR/wP+PU35jv0eRAv76yy9QE7p+/nTm2AFC1TrjySRuHuU7gBwcD4CeYEk60VbKcm41TrvRgn14zBlR5DpVDoKkAeSi3yVfXFeGU7lWiDN4ui17MJ1K6FTeirai4dHCnQ6O6EtLQfNXkhCgVhJYYugaVlTYM1yOtg869BuCafRULfGxS0EUb2aPJJWu8bR+PTpVY2nDLYTePehA8OK8FrzVEiaB1eLMsrVn/InzrP83GDE1pTMnCH+CKsYmvDw/bzabRUM+F4eLXOpnI/lz//+3gfya/PPfFn9/NoylkxA5D8+p+NI2kjNkFlWs7fQ5nHNilpMxGN40XBGE4Y2DJ4uRddcv8ME5ef8rkCvZDdfCqVuHU8rot+MzP7iwiFzBq88njy5gdxH34f0sAkvmM2nGi8aZT+ZKgmPT0WBJ3fciDXUHmoP2Z4Q0tSe+CHVuQ30l9K/9OTIaMK1PFyq3w2+Q48Rl0kX+K/j+nVoEV2rPEkVWx5Jh7X6D0yWglxpCFI8lzeN6yrN0//UayJMXYKp+Jp5pv/tz6KJoY9KfrPVp3m2/0Zn+vu6wMROXj6/r9OmLYho476Z+ZCEzHCK7fNzG/rib/2waL1uu0/FQvr+XTLL9WXnvcefQ1CZzstDOnvHPX3mkk3YdPXh7zWVHJTNN37xckNbntDBS4+iQ4RpJHi4WVwqOxs2JPf0RAAD//yu1OTU="
+	return "eJzFWd9v2zYQfu9fQWAvLZbY7cMwIMBWtM2GBkgLDOm2R5mWThIXilRJyo771++OlGxHvyyndipgQyOL9313vDveHS/ZPWyuWLGxX+ULxpxwEq7Yp83dX7f4ZwI2NqJ0Qqsr9ju+YOyTTioJLNWGldxYoTLmcggrmNQZS4UEO8Nvba6Ni2KtUpFdMWcqwJepAJnYKy/qkilewA6cHrcp8U1mdFXWb3o40POnF8RSo4shAvTs4+1jutwATyKRbH9psKVW2d7LAXh6viAsqqcgpl8ZWiRIZTfX3j5E62sFZjPrwIMx2nSg99U+gP1BK8eFsrWCbTt4+WQNO9tb1jbGIYPsmHEpuG39UnKX15s3G1pdiMzwwL92gC6yhBXII1FRs1nfuil4BVjLMzhWz95VQ3gNlpV6jVzPuNOEcNRGSx3fR04UMLMQ9xohlZq71i8jBOmhUOCFrpRjOmUkfef9bM2Fg2QbEkSAOc2WuGTFheRLCTOS0JG64rICJiwTiiFZrRJ7wbhlPFCk3FNqgZiqKpZgZr36Gr22kQXV1qg32ieqGvBIVZLODLjKKFRxuekL+g4beOCFwO/Px8jGXB1BiKcpJrFzEip0ItArpzBabhwct2PoWQV39crvoeoFMIImB42lwH+NkTQQg1hNt9uJiTbwISeM0fXmPh7QJ5eRrVKkjxTfIIn6EIIdsMBYa9O20VHobC1czoSzeN4WBWpJuhcaVb/wbyUmGMMlvS0lj4ObLd4uOmK5SvyCdY4rbImfokQpeWm9KIWbtkYLx9xiSroVFj9Fa2/F4weLm8/9Yhf/vLv9+4+7Be4DryzuDjfwmM/Ltz+/WozsT5RiRgNTGjHg+k+25N3Hd5dvWM5t7tMzudJ25wI2Kp+gXUW6aSo6ei1QDZd3DgPGtJIbhhGdorFCRAuzNVO/ivCAphCqVzEHD084cOAB4sqXXihZNZoFh8ETMQtm/8SN4NfvvQt1xCzwu4j8LFqBWWor3Oa3mujATv2AAsnGORT8xLHlZWIcgAlH9X6w4REbbAtJvxHiyhj0lgj93JyO14cglfEKCaEvxpzKBsK4oP8nlJMTQB+jw5PxOMaSjGG0rLDgz7DkZx/QC2qn3FZJoYag2oME9asjuXURlsxKn06ZW5TJdqW4BVD94PdCyoET5GlW1Ak0sYA+ZzE8RPqoGrM15o7PSE6KOXpKhOmyl+FSawm8HdQHGP6bA9LZ64+YB/HMEKjfTK4oI0el4ul5cMw/RakNRyYewjNpPM6A1XIFh6qWLb9IqygR9v6c9lIACbF7TBvPKvJ/e3+AYTtPPbXM+7wtRzpEYvS8Xc2PFXy3PNinRaSfnVttrBbHQ7tr8cycTHJqvdehf4coRxi2n7QfhGhzhshtJDONnxbimz/BtlEzzuZ88THOqq79nhY7eMpog8UBlbnVWVJQg8A8wo41bXSj2IBhKykjavjOQYuE10mRIDyv2oJNP3+oSfD8/tMDpd8p+JHwcWbspdJYvSdY1mDxTzXP1ri02L7qp16AySAqubVnSExeOAvCt/XW0OBwn5WPouejRg1AMCfFFpcZ+WleUDuB/4WqTI8wNRy7miim4dDJiRJAzdDvaqIVbS31MB4Wi8IRYnp9etORZNxGkj1iE4ql5zQJ9kF+JNQ0dz6iB4rhpiPCvgQi4nPCzgOXeo710JIRBvaNhXDUyDPKMEtgC3Q7iyIWTeW6+6wjEo9AXpaSRkslUJ3tV17QNH7hY8gLQdnhK+u/Ggmux+r3YX6H/nd9evO6R0G7vHn9GmOPq3r/aOcws3GWVyoxdasuOvHOmN4qHsYOS6CNDj3wwNiPutFUGHuesShNgK3fOo/BsJ3DJEy6qJCGfbomEiP0qC07MzuCoK08lhouOQszA7jFNHXyjQcmV06ZhC25DQUrJ7IjvFR3kHImYn6IhGieH1qPjIjxMDiFJ3algdXzsSM0oSt7DEOjzjQDP7ivqXigFEYjKJQ4cGw1HH/gPtPfCXfcF6UDE4SfWO5caa/m8/V6PcOEG2vFZ7Eu5omO5/XflxbMCsz8l9mv80TwTGnrRGznPvkiIGAsJrPcFbLXFEIpnSx7bdC+XJtghPYlmwGJ3QN1/ZrdINL1e0xhmVDts7Dvom2fpTMP3Znfjmn/ITKBLz1fsMKxPNw931wPUhA6MpEuu4fGiMtMZLArOkqeQfAXjdvrG69W+dOh1NcTHyQ11lBPJP2+vjpBrkll/B3i8eTpSrPn/vQg/wn8PvoSAWkJuoTS97uUgZ1O3eCEAKQRp3XYxGYwTNdAHPkr3+eg3HvnS+gj9vT97o9hh5bFwqSerNVhHtpvmhWEK+ptY6ZV3L2hpqdOin7hsJrkZJYGH06ouFvGfr+a78rS6AdR+PFQ3FzFe9h6Vl5rHBTqazJ7E+3gjH/4ysNP2ml169cplx25Hij6huGsrkwMs0QX3fulKZCifVhMBOysGwJ78T958xD5"
 }
@@ -32,5 +32,5 @@ func init() {
 // AssetPostgresql returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/postgresql.
 func AssetPostgresql() string {
-	return "eJy0WG1v4zYS/p5fMcjdIduDVk7SFtv67gqkaXbPQDbZJmnvSwGVFscyEYrUkiM73l9/GFJ+iSw50R5OX2xQ4jzPvHDmkd7CI67GUFlPhUP/WR8BkCKNYzj+FBfvf70+PgKQ6HOnKlLWjOGnIwCAj1bWGmFmHVTCeWUKoDnCdh9oW8BMafTpEYCfW0dZbs1MFWMgV+MRwEyhln4c7L0FI0psseGLVhWOoXC2rpqVDjbxeh/swczZskUkcOBrF3IXVttix9A+5kHc58iHwrC+2jR2qZAq0ZMoq2d3Gb5ymAtCOYZ36bfp6d79Xnp8Pcxxa3rLlOlpZTDt5JJbh5mSLWMxPEIr4Vt3KkHz3Rym2hapR++VNRnDZKYup+iGUb+0DkHJFN5sY5Awe172YOyaJyjTTjw/pzw/JnKqhdYr6ODzTXog2D+kp61gb8KjFRrKhJRtj/BJlFU4SWfn73h/enY8zOl/W0+wDD5ynnJrDOb8MFinCmWYW0hjT+Yitco66qX2/Y/vTk8H0vpk3f9Cax37vZp6EXaT1cZEO2M7bs1mZ1L++EN6fn58kER/QcYK19YUw2heK4MQLYIyXkkEsSEMb/6muUL/1LaI2JXDmXr685vuWElBYio89jq6PmcDU3gjSgQ729jvRv9co1v1Qt9fXV9dPsDf4f3d7UeoPTr/j4E0fmUA8CQISzSUwsTEkhI+0Lu8/z2MFkxAW/sIgiC3ZSmMzEgUQBYKJCi5N+TWED7RAUcyT9huqDuBZJiB9O/XxIFN85EwUIc5iE+ERqKMyFA5Sza3Gt5YExzbuJys/ZsqI8E6wCfMa8KeeoiO8P9+R6TNPJWUncZraNMJpVGoBRqOr2g8eNm3FCYzUMRt9viftWGO8qfjhH0ylqBy6NnfPbzQm8Mw5K2qMNah7BtFm9y/UJUDfX5YVTEt8ZieeMhr5zixDWS708RZmlsGJQStPEEuDExZDtVGgqAxeJePlMl1LXFEua1GeSlJFPxwOj/YlTwJRxmP6s6mJAW1b7zkoCox5jCEu8GBgNMX7YVyPC0zcsJ4ERr84J79s8gf0UjQNhcadizxLO+WPofQvrYnc7KU5Ayv87oD0zOlPussnNIst7Id7b/AA4eR70T1QSCant+ulEj5EVdL6wYGL7SXCOKQamdQwnS1noMe3qgZCLPaEy4A94ggtLcwJ6r8eDRaLpfpjiSzrhhJm/tRE44ROsc4/q2oKjRSPaVzKnX3SEISSg/z5GNQb2ZmXSlC9sXU1hRafYneiwJDHxQlEjrPA3I9AcR63akv65az7y+mRQond1Yj/HHMk+iPY5AWY2bwiQ/cSQInW4gx/PUM/gXfnZ8kgJR3l8BcmbZwesHPC9a9Xk01gre6Dq6S5f8LBGEAnbOuG0wZQmeEzrqG7guwk2Zv05FpLgg0SobmEAfUnmrphs8q25b1X3v0LufCiZzQQW5rQ5xT5qSeUz5Mrpntw4CvgtevkAX/d19j/CvrVSiIw75yp+THBorOZtdzSGXab6Le1i5vegrzYBkans0W6KbMcAVhPhAXT1zr0yKiqrSKsF2K5JUKlAnuWIpLygMuoiAMgkLiTDXdL8jD8GLTzWoaJ07GOewVCdHA+tmvFAvN7hbjrteW2BGYkQfhEERNdiHyui5Bi9rkc3TJ7uLSukde0rZQPDcdbuOz2bAH1PX02hJ3Pq1RbxaYfOGCVFk6Rbz0PCgJ5HPMHysbTuo+WpAOdZXAUmiHOaoF21gK7VkcOhBsWehofD8qEwNCynAgnpMJ/Dw4LJQndDHpQXKyYvFQihXMBbfTZjvrCo7sFqOzLmLr7ZjlQ79kHNIFr2xJwwf6LzvfIoInPsjNEAiNxCMthd88tmQLv4ASij352v9po/dz1BdrhkYuHoiezaUqnIiBaj4F7uPyJB+IyVvSjm70GjiNC2wLm368bTe5vv0wsIX8LrSSsBC6bjrCL1c///bh+yT+ftf8ftv8nje/ZwlMbt7fJnBz+zC5vErgPxd3N5ObDwlc3d3d3iVwffshgfcXDxfXSTh/ny5uJpft3Mc4cSl3+fuaODWSbWBmune18f4bAAD//4M1OQo="
+	return "eJy1WG1v2zYQ/r5fccg2NN1c5WUbumVvSLO0C5AmXZJ2XwY4tETbRCRSJak43q/fc6TsOLLkRC1mBHBMkXfPvfC5O72gGzk/oNI4P7HSfcy/IPLK5/KAtt7Fxcu/TrewmkmXWlV6ZfQB/YYForcmq3JJY2OpFNYpPSE/lXR/jnIzobHKpUtwwE2N9cPU6LGaHJC3lcTiWMk8cwdB3gvSopANNPzx8xLLE2uqsl5pQRM/r4M8GltTNIAEDPxZVbmqFntWBK3r3Kj3oeZNblh8mjBWoXhVSOdFUT54yupLK1PhZXZAL5Pvkt21553w+HMFWEvR90gZXq60TFqxpMbKocoawqJ7RK6EazwphZ+uxjCB+MRJ5wBqyGqGuipG0vaDfgQUpLKEtu99MGD0vOxImwVOUroZeN6HPfgTqa9Ens+pBc/zZIOzf0x2G85euidXUvuhyLKmRfIOXg43aW//JZ9P9rb6Gf0n7KBZsJHjhKujZcqbyVg1UZqxhTB2RC5CK3HtOqH98NPL3d2esN5B4GfAWvh+LaceVbuMai2iGbEVs8bjvSz76cdkf39rI4juhIwZnhs96QfzFBIpSkQuOpVJEkvAtP11zhl6jbyMupFjY3V3/bzdV5nwYiSc7DR0cc96hvAM0smMl/LbtX+spJ13qr48Pj0+uqJv6PXF+VuqnLTu554w/mIFBDryskCqJnSiY0oBEsM7uvwQSoscIBDmhoRHthWF0NnQC1QbQxPpqWBuQBZ6eec3GDJ0XjYJdcWRrKYn/MsFcGLRfCU0/MB1EEikznANgmYqrfEmNTltGx0MW5o8WNg3UjrD/cFJmVZeduRDNIT/7zYkM7C08MPd+OlLOiE1JuoWtsC/orbgcdsQvDEpzzS79UulGWP229aAbdLGY5t0bO+avsDNoRjyUTXRiGbWbj0eFSg5/8ps2Jaa8caioZkZ25NbuDLWhio/hRkuJBoAO7KyMLdcbXg1V15akaOUIFwIHXBhoVkDCYfKXKRw0mhO179fh+18fjaFAFfiERTkuShdkKyR3zMQBid+Agpx2GrGa1KX2plCTs6i2OsPh6fvjy+vwfcCtxDQcB0e6N/+/dvn15sSaoy4SltapZuV4jN9evnn4Ys9mgo35aznq30fw6gbxmdwsxrPF/0jLyuY4aeiiYbIaFTvTI3HcBZMw35ll25pNxGO0K1WMV/0NyleUK52LHhhVkwesPok+lxU3gzlHbYoHeLLyrhVLoRvlq0od6wsqj3XBJbJuRJlhoO4Gc7zxWBRYb2rWVuy4yO83ZMVruCxQFyxkD3D/aisZeqrVbYbhYdQ6tFjIqWR3ZpGPDBUSFvhUYVtuqN0mleZ3PGpKXfSIgN03pxMN9ZtECiGCW5mW0OLutZ88JiBEBVZLhBSrYeCni4+ulWW+8mht0I7EVqg3l3NK5HegE6ROqnIaUUSd7vtw8EmbZ/atXCw0Dwjwou4rqjp6OM+5sNQxzDTZU1vfwmJikk0q/tzj1ISu6JmpnwGxYQCHJVY6Sur4+WrO0VH22oMipyvtfY4KdGW5c7Q1PvSHezszGazZGVoMXayk5nU7dTu2JHWsh73QpQl4qXukqkv8lbHZNILlfez5G2Yb3QkCI6+GJnKB2rBvObERA5Wyg2zwKJHEot1e0+q6/bKZJLQswuDef2fLe7V/tmizMgYGXnHF+7ZgJ7dqzigr/boV/p+H8vSp+0pMF0vGI/YeciToVMj4HAmjzyKNgP/3yIgmuBmY9uVQZe0GtetrfY/ovakPrvgVJQWyuEtqGYXB60d2dKuHlNVs+h/6tU7msLpqefqD2L0i4qiHkLeDK7ufvspPg5WP6Fx/t9tjf6HGhUSYrOtzJS8redYVp96qFLp5rsaZyqb1pzCOHhQC3uHt9KOGOE8VGPMHUieuNbVrYMqchXVtvXsT5zRGOCKpLgECPI2jkyhO8gwRdbsFwaoMPq3oxrFijPkGHY2CVHAYu8nNgv16QbitsE+MgIjiu0rt0+3Iq2qgtDU6nQq7WB1EaXihpcQHcV1k9vdhX+WB9bb55bdC0nMfHku8+UCg+fXf8A/s9xgDuihU/B7KtOb0oSbuq4ttA5VOaCZyK1MJUYqG344Hp9s6N3xKwpf9wpGYZFl4UI8BBPw8VgyAWtLG4MehjLuWBwVYo6Om+m0Ps59BXv2XkdrXkTqbanlfd/1beoLnkhJ/Qv6Hytv64IlLrSbwRHoQLmkJfQeJfMhPH5F46VYa1+7X/51vrD9F7N9T8/FC9FxuFATK6Kj6pfl63q5kvfUyUeSFjZ6irocgJuNTbe+ezY5PX/Tk0I+QGBGtyKvakb44/jV+zc/DOL39/X3d/X3fv29N6CTs9fnAzo7vzo5Oh7Q34cXZydnbwZ0fHFxfjEgABnQ68Orw9NBuH/vDs9Ojpqxj37iVG6z9yl+qlu2npFpP9XU9x+9xiEX"
 }
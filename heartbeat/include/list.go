@@ -27,6 +27,7 @@ import (
 	// Import packages that perform 'func init()'.
 	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/http"
 	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/icmp"
+	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/mail"
 	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/tcp"
 	_ "github.com/elastic/beats/v7/heartbeat/monitors/plugin"
 )
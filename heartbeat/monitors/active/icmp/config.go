@@ -21,6 +21,7 @@ import (
 	"time"
 
 	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/pathtrace"
 )
 
 type Config struct {
@@ -29,6 +30,8 @@ type Config struct {
 
 	Timeout time.Duration `config:"timeout"`
 	Wait    time.Duration `config:"wait"`
+
+	PathTrace pathtrace.Config `config:"path_trace"`
 }
 
 var DefaultConfig = Config{
@@ -36,4 +39,6 @@ var DefaultConfig = Config{
 
 	Timeout: 16 * time.Second,
 	Wait:    1 * time.Second,
+
+	PathTrace: pathtrace.DefaultConfig(),
 }
@@ -22,6 +22,7 @@ import (
 	"net"
 	"net/url"
 
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/pathtrace"
 	"github.com/elastic/beats/v7/heartbeat/monitors/plugin"
 	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers/wraputil"
 	conf "github.com/elastic/elastic-agent-libs/config"
@@ -69,6 +70,7 @@ type jobFactory struct {
 	resolver  monitors.Resolver
 	loop      ICMPLoop
 	ipVersion string
+	tracer    *pathtrace.Tracer
 }
 
 func newJobFactory(config Config, resolver monitors.Resolver, loop ICMPLoop) (*jobFactory, error) {
@@ -77,6 +79,7 @@ func newJobFactory(config Config, resolver monitors.Resolver, loop ICMPLoop) (*j
 	if err != nil {
 		return nil, err
 	}
+	jf.tracer = pathtrace.NewTracer(config.PathTrace, logp.NewLogger("icmp"))
 
 	return jf, nil
 }
@@ -92,10 +95,9 @@ func (jf *jobFactory) checkConfig() error {
 }
 
 func (jf *jobFactory) makePlugin() (plugin2 plugin.Plugin, err error) {
-	pingFactory := jf.pingIPFactory(&jf.config)
-
 	j := make([]jobs.Job, 0, len(jf.config.Hosts))
 	for _, host := range jf.config.Hosts {
+		pingFactory := jf.pingIPFactory(&jf.config, host)
 		job, err := monitors.MakeByHostJob(host, jf.config.Mode, monitors.NewStdResolver(), pingFactory)
 
 		if err != nil {
@@ -113,8 +115,8 @@ func (jf *jobFactory) makePlugin() (plugin2 plugin.Plugin, err error) {
 	return plugin.Plugin{Jobs: j, Endpoints: len(jf.config.Hosts)}, nil
 }
 
-func (jf *jobFactory) pingIPFactory(config *Config) func(*net.IPAddr) jobs.Job {
-	return monitors.MakePingIPFactory(func(event *beat.Event, ip *net.IPAddr) error {
+func (jf *jobFactory) pingIPFactory(config *Config, host string) func(*net.IPAddr) jobs.Job {
+	return monitors.MakePingIPFactory(jf.tracer.Wrap(host, func(event *beat.Event, ip *net.IPAddr) error {
 		rtt, n, err := jf.loop.ping(ip, config.Timeout, config.Wait)
 		if err != nil {
 			return err
@@ -127,5 +129,5 @@ func (jf *jobFactory) pingIPFactory(config *Config) func(*net.IPAddr) jobs.Job {
 		}
 
 		return nil
-	})
+	}))
 }
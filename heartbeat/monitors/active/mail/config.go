@@ -0,0 +1,79 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mail
+
+import (
+	"errors"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/libbeat/common/match"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+type config struct {
+	// check all ports if host does not contain port
+	Hosts []string `config:"hosts" validate:"required"`
+	Ports []uint16 `config:"ports"`
+
+	Mode monitors.IPSettings `config:",inline"`
+
+	// configure tls
+	TLS      *tlscommon.Config `config:"ssl"`
+	StartTLS bool              `config:"starttls"`
+
+	Timeout time.Duration `config:"timeout"`
+
+	// authenticate after the handshake
+	Username string `config:"username"`
+	Password string `config:"password"`
+
+	Check checkConfig `config:"check"`
+}
+
+type checkConfig struct {
+	// Banner must match the greeting of the server.
+	Banner []match.Matcher `config:"banner"`
+
+	// MaxRTT is the maximum duration of the whole session.
+	MaxRTT time.Duration `config:"max_rtt"`
+
+	// CertificateExpiry is the minimum remaining validity of the server
+	// certificate.
+	CertificateExpiry time.Duration `config:"certificate_expiry"`
+}
+
+func defaultConfig() config {
+	return config{
+		Timeout: 16 * time.Second,
+		Mode:    monitors.DefaultIPSettings,
+	}
+}
+
+func (c *config) Validate() error {
+	if c.Username != "" && c.Password == "" {
+		return errors.New("password is required if username is set")
+	}
+	if c.Check.MaxRTT < 0 {
+		return errors.New("check.max_rtt must not be negative")
+	}
+	if c.Check.CertificateExpiry < 0 {
+		return errors.New("check.certificate_expiry must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package mail implements monitors for the SMTP, IMAP and POP3 protocols.
+package mail
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlsmeta"
+	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/monitors/plugin"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers/wraputil"
+	"github.com/elastic/beats/v7/heartbeat/reason"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+func init() {
+	for name := range protocols {
+		plugin.Register(name, func(_ string, cfg *conf.C) (plugin.Plugin, error) {
+			return create(protocols[name], cfg, monitors.NewStdResolver())
+		}, "synthetics/"+name)
+	}
+}
+
+func create(
+	proto *protocol,
+	cfg *conf.C,
+	resolver monitors.Resolver,
+) (plugin.Plugin, error) {
+	jf, err := newJobFactory(proto, cfg, resolver)
+	if err != nil {
+		return plugin.Plugin{}, err
+	}
+
+	js, err := jf.makeJobs()
+	if err != nil {
+		return plugin.Plugin{}, err
+	}
+
+	return plugin.Plugin{Jobs: js, Endpoints: len(js)}, nil
+}
+
+type jobFactory struct {
+	proto     *protocol
+	config    config
+	tlsConfig *tlscommon.TLSConfig
+	resolver  monitors.Resolver
+}
+
+func newJobFactory(proto *protocol, cfg *conf.C, resolver monitors.Resolver) (*jobFactory, error) {
+	jf := &jobFactory{proto: proto, config: defaultConfig(), resolver: resolver}
+	if err := cfg.Unpack(&jf.config); err != nil {
+		return nil, err
+	}
+
+	// A TLS configuration is always loaded, as hosts can require implicit
+	// TLS with the scheme of their URL.
+	tlsCfg := jf.config.TLS
+	if tlsCfg == nil {
+		tlsCfg = &tlscommon.Config{}
+	}
+	var err error
+	jf.tlsConfig, err = tlscommon.LoadTLSConfig(tlsCfg, logp.NewLogger(proto.name))
+	if err != nil {
+		return nil, err
+	}
+	return jf, nil
+}
+
+// tlsScheme is the URL scheme of hosts using implicit TLS.
+func (jf *jobFactory) tlsScheme() string {
+	return jf.proto.name + "s"
+}
+
+// makeJobs returns one job per host and port.
+func (jf *jobFactory) makeJobs() ([]jobs.Job, error) {
+	var js []jobs.Job
+	for _, host := range jf.config.Hosts {
+		urls, err := jf.hostURLs(host)
+		if err != nil {
+			return nil, err
+		}
+		for _, u := range urls {
+			job, err := jf.makeEndpointJob(u)
+			if err != nil {
+				return nil, err
+			}
+			js = append(js, wraputil.WithURLField(u, job))
+		}
+	}
+	return js, nil
+}
+
+// hostURLs returns the URLs to check for a configured host. The host is
+// either a hostname, a host:port pair, or a URL with the protocol name as
+// scheme, or the protocol name followed by `s` for implicit TLS.
+func (jf *jobFactory) hostURLs(host string) ([]*url.URL, error) {
+	u, err := url.Parse(host)
+	if err != nil || u.Host == "" {
+		scheme := jf.proto.name
+		if jf.config.TLS != nil && jf.config.TLS.IsEnabled() && !jf.config.StartTLS {
+			scheme = jf.tlsScheme()
+		}
+		u = &url.URL{Scheme: scheme, Host: host}
+	}
+
+	switch u.Scheme {
+	case jf.proto.name:
+	case jf.tlsScheme():
+		if jf.config.StartTLS {
+			return nil, fmt.Errorf("starttls can not be used with implicit TLS in '%s'", host)
+		}
+	default:
+		return nil, fmt.Errorf("'%s' is not a supported scheme in '%s', supported schemes are %s and %s",
+			u.Scheme, host, jf.proto.name, jf.tlsScheme())
+	}
+
+	if u.Port() != "" {
+		return []*url.URL{u}, nil
+	}
+
+	ports := jf.config.Ports
+	if len(ports) == 0 {
+		port := jf.proto.port
+		if u.Scheme == jf.tlsScheme() {
+			port = jf.proto.tlsPort
+		}
+		ports = []uint16{port}
+	}
+	urls := make([]*url.URL, 0, len(ports))
+	for _, port := range ports {
+		urls = append(urls, &url.URL{
+			Scheme: u.Scheme,
+			Host:   net.JoinHostPort(u.Hostname(), strconv.Itoa(int(port))),
+		})
+	}
+	return urls, nil
+}
+
+func (jf *jobFactory) makeEndpointJob(u *url.URL) (jobs.Job, error) {
+	return monitors.MakeByHostJob(
+		u.Hostname(),
+		jf.config.Mode,
+		jf.resolver,
+		monitors.MakePingIPFactory(func(event *beat.Event, ip *net.IPAddr) error {
+			return jf.check(event, net.JoinHostPort(ip.String(), u.Port()), u)
+		}))
+}
+
+// check runs a session against dialAddr. canonicalURL determines if
+// implicit TLS is used, and the hostname to validate the server certificate
+// with.
+func (jf *jobFactory) check(event *beat.Event, dialAddr string, canonicalURL *url.URL) error {
+	implicitTLS := canonicalURL.Scheme == jf.tlsScheme()
+
+	dc := &dialchain.DialerChain{
+		Net: dialchain.CreateNetDialer(jf.config.Timeout),
+	}
+	dc.AddLayer(dialchain.ConstAddrLayer(dialAddr))
+	if implicitTLS {
+		dc.AddLayer(dialchain.TLSLayer(jf.tlsConfig, jf.config.Timeout))
+		dc.AddLayer(dialchain.ConstAddrLayer(canonicalURL.Host))
+	}
+	dialer, err := dc.Build(event)
+	if err != nil {
+		return err
+	}
+
+	start := time.Now()
+	conn, err := dialer.Dial("tcp", dialAddr)
+	if err != nil {
+		debugf("dial failed with: %v", err)
+		return reason.IOFailed(err)
+	}
+	c := newClient(conn)
+	defer c.Close()
+
+	if err := conn.SetDeadline(start.Add(jf.config.Timeout)); err != nil {
+		return reason.IOFailed(err)
+	}
+
+	fields := mapstr.M{"protocol": jf.proto.name}
+	defer func() {
+		eventext.MergeEventFields(event, mapstr.M{"mail": fields})
+	}()
+
+	err = jf.session(event, c, canonicalURL.Hostname(), fields)
+	if err != nil {
+		return sessionError(err)
+	}
+
+	rtt := time.Since(start)
+	_, _ = fields.Put("rtt.session", look.RTT(rtt))
+	if jf.config.Check.MaxRTT > 0 && rtt > jf.config.Check.MaxRTT {
+		return reason.MakeValidateError(fmt.Errorf("session took %v, more than the maximum of %v", rtt, jf.config.Check.MaxRTT))
+	}
+	if tlsConn, ok := c.conn.(*tls.Conn); ok {
+		if err := jf.checkCertificate(tlsConn.ConnectionState()); err != nil {
+			return reason.MakeValidateError(err)
+		}
+	}
+	return nil
+}
+
+// session runs the protocol steps on an established connection.
+func (jf *jobFactory) session(event *beat.Event, c *client, hostname string, fields mapstr.M) error {
+	start := time.Now()
+	banner, err := jf.proto.greet(c)
+	if err != nil {
+		return err
+	}
+	fields["banner"] = banner
+	_, _ = fields.Put("rtt.banner", look.RTT(time.Since(start)))
+	if err := jf.checkBanner(banner); err != nil {
+		return reason.MakeValidateError(err)
+	}
+
+	if err := jf.proto.hello(c); err != nil {
+		return err
+	}
+
+	if jf.config.StartTLS {
+		start := time.Now()
+		if err := jf.proto.startTLS(c); err != nil {
+			return err
+		}
+		tlsConn, err := c.upgrade(jf.tlsConfig.BuildModuleClientConfig(hostname))
+		if err != nil {
+			return reason.IOFailed(err)
+		}
+		tlsmeta.AddTLSMetadata(event.Fields, tlsConn.ConnectionState(), time.Since(start))
+		fields["starttls"] = true
+
+		if err := jf.proto.hello(c); err != nil {
+			return err
+		}
+	}
+
+	if jf.config.Username != "" {
+		if err := jf.proto.auth(c, jf.config.Username, jf.config.Password); err != nil {
+			return err
+		}
+		fields["authenticated"] = true
+	}
+
+	return jf.proto.quit(c)
+}
+
+func (jf *jobFactory) checkBanner(banner string) error {
+	for _, m := range jf.config.Check.Banner {
+		if !m.MatchString(banner) {
+			return fmt.Errorf("banner %q does not match %v", banner, m.String())
+		}
+	}
+	return nil
+}
+
+func (jf *jobFactory) checkCertificate(state tls.ConnectionState) error {
+	if jf.config.Check.CertificateExpiry <= 0 || len(state.PeerCertificates) == 0 {
+		return nil
+	}
+	notAfter := state.PeerCertificates[0].NotAfter
+	if remaining := time.Until(notAfter); remaining < jf.config.Check.CertificateExpiry {
+		return fmt.Errorf("server certificate expires at %v, in less than %v", notAfter, jf.config.Check.CertificateExpiry)
+	}
+	return nil
+}
+
+// sessionError classifies the errors of a session. Unexpected answers of
+// the server fail validation, other errors are I/O failures.
+func sessionError(err error) error {
+	var r reason.Reason
+	if errors.As(err, &r) {
+		return r
+	}
+	var respErr responseError
+	var protoErr *textproto.Error
+	if errors.As(err, &respErr) || errors.As(err, &protoErr) {
+		return reason.MakeValidateError(err)
+	}
+	return reason.IOFailed(err)
+}
+
+var debugf = logp.MakeDebug("mail")
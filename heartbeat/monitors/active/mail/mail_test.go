@@ -0,0 +1,236 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mail
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/heartbeat/monitors"
+	"github.com/elastic/beats/v7/heartbeat/monitors/stdfields"
+	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers"
+	"github.com/elastic/beats/v7/heartbeat/scheduler/schedule"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// fakeServer serves a single session. It sends the greeting, then answers
+// each command with the reply of the first matching prefix in script.
+func fakeServer(t *testing.T, greeting string, script [][2]string) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { l.Close() })
+
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		fmt.Fprintf(conn, "%s\r\n", greeting)
+		scanner := bufio.NewScanner(conn)
+		for scanner.Scan() {
+			line := scanner.Text()
+			reply := "unknown command"
+			for _, step := range script {
+				if strings.HasPrefix(line, step[0]) {
+					reply = step[1]
+					break
+				}
+			}
+			fmt.Fprintf(conn, "%s\r\n", reply)
+		}
+	}()
+	return l.Addr().String()
+}
+
+func runCheck(t *testing.T, name string, cfg mapstr.M) *beat.Event {
+	t.Helper()
+	p, err := create(protocols[name], conf.MustNewConfigFrom(cfg), monitors.NewStdResolver())
+	require.NoError(t, err)
+	require.Equal(t, 1, p.Endpoints)
+
+	sched := schedule.MustParse("@every 1s")
+	job := wrappers.WrapCommon(p.Jobs, stdfields.StdMonitorFields{ID: "test", Type: name, Schedule: sched, Timeout: 1}, nil)[0]
+
+	event := &beat.Event{}
+	_, err = job(event)
+	require.NoError(t, err)
+	return event
+}
+
+func requireField(t *testing.T, event *beat.Event, key string, expected interface{}) {
+	t.Helper()
+	v, err := event.GetValue(key)
+	require.NoError(t, err, "field %s should be set", key)
+	assert.Equal(t, expected, v, "unexpected value of field %s", key)
+}
+
+func TestSMTP(t *testing.T) {
+	addr := fakeServer(t, "220 mail.example.com ESMTP ready", [][2]string{
+		{"EHLO", "250-mail.example.com\r\n250 AUTH PLAIN"},
+		{"AUTH PLAIN AHVzZXIAc2VjcmV0", "235 Authentication successful"},
+		{"QUIT", "221 Bye"},
+	})
+
+	event := runCheck(t, "smtp", mapstr.M{
+		"hosts":        []string{addr},
+		"username":     "user",
+		"password":     "secret",
+		"check.banner": []string{"ESMTP"},
+	})
+
+	requireField(t, event, "monitor.status", "up")
+	requireField(t, event, "mail.protocol", "smtp")
+	requireField(t, event, "mail.banner", "mail.example.com ESMTP ready")
+	requireField(t, event, "mail.authenticated", true)
+	_, err := event.GetValue("mail.rtt.session.us")
+	assert.NoError(t, err, "session duration should be reported")
+}
+
+func TestIMAPBannerMismatch(t *testing.T) {
+	addr := fakeServer(t, "* OK Dovecot ready.", [][2]string{
+		{"a1 LOGOUT", "* BYE\r\na1 OK Logout completed."},
+	})
+
+	event := runCheck(t, "imap", mapstr.M{
+		"hosts":        []string{addr},
+		"check.banner": []string{"Cyrus"},
+	})
+
+	requireField(t, event, "monitor.status", "down")
+	requireField(t, event, "error.type", "validate")
+	requireField(t, event, "mail.banner", "Dovecot ready.")
+}
+
+func TestIMAPLogin(t *testing.T) {
+	addr := fakeServer(t, "* OK Dovecot ready.", [][2]string{
+		{`a1 LOGIN "user" "se\"cret"`, "* CAPABILITY IMAP4rev1\r\na1 OK Logged in"},
+		{"a2 LOGOUT", "* BYE\r\na2 OK Logout completed."},
+	})
+
+	event := runCheck(t, "imap", mapstr.M{
+		"hosts":    []string{addr},
+		"username": "user",
+		"password": `se"cret`,
+	})
+
+	requireField(t, event, "monitor.status", "up")
+	requireField(t, event, "mail.authenticated", true)
+}
+
+func TestPOP3AuthFailure(t *testing.T) {
+	addr := fakeServer(t, "+OK POP3 ready", [][2]string{
+		{"USER user", "+OK"},
+		{"PASS", "-ERR invalid password"},
+	})
+
+	event := runCheck(t, "pop3", mapstr.M{
+		"hosts":    []string{addr},
+		"username": "user",
+		"password": "wrong",
+	})
+
+	requireField(t, event, "monitor.status", "down")
+	requireField(t, event, "error.type", "validate")
+	requireField(t, event, "error.message", `unexpected response from server: "-ERR invalid password"`)
+}
+
+func TestHostURLs(t *testing.T) {
+	tests := map[string]struct {
+		cfg      mapstr.M
+		host     string
+		expected []string
+		err      string
+	}{
+		"default port": {
+			host:     "mail.example.com",
+			expected: []string{"smtp://mail.example.com:25"},
+		},
+		"explicit port": {
+			host:     "mail.example.com:587",
+			expected: []string{"smtp://mail.example.com:587"},
+		},
+		"implicit TLS scheme": {
+			host:     "smtps://mail.example.com",
+			expected: []string{"smtps://mail.example.com:465"},
+		},
+		"implicit TLS from ssl config": {
+			cfg:      mapstr.M{"ssl.enabled": true},
+			host:     "mail.example.com",
+			expected: []string{"smtps://mail.example.com:465"},
+		},
+		"starttls": {
+			cfg:      mapstr.M{"ssl.enabled": true, "starttls": true},
+			host:     "mail.example.com",
+			expected: []string{"smtp://mail.example.com:25"},
+		},
+		"ports": {
+			cfg:      mapstr.M{"ports": []uint16{25, 587}},
+			host:     "mail.example.com",
+			expected: []string{"smtp://mail.example.com:25", "smtp://mail.example.com:587"},
+		},
+		"starttls with implicit TLS": {
+			cfg:  mapstr.M{"starttls": true},
+			host: "smtps://mail.example.com",
+			err:  "starttls can not be used with implicit TLS",
+		},
+		"unsupported scheme": {
+			host: "imap://mail.example.com",
+			err:  "'imap' is not a supported scheme",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := mapstr.M{"hosts": []string{test.host}}
+			cfg.DeepUpdate(test.cfg)
+			jf, err := newJobFactory(protocols["smtp"], conf.MustNewConfigFrom(cfg), nil)
+			require.NoError(t, err)
+
+			urls, err := jf.hostURLs(test.host)
+			if test.err != "" {
+				assert.ErrorContains(t, err, test.err)
+				return
+			}
+			require.NoError(t, err)
+			var got []string
+			for _, u := range urls {
+				got = append(got, u.String())
+			}
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	_, err := newJobFactory(protocols["pop3"], conf.MustNewConfigFrom(mapstr.M{
+		"hosts":    []string{"localhost"},
+		"username": "user",
+	}), nil)
+	assert.ErrorContains(t, err, "password is required")
+}
@@ -0,0 +1,214 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mail
+
+import (
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/textproto"
+	"strings"
+)
+
+// protocol implements the steps of a mail protocol session.
+type protocol struct {
+	name string
+	// port is the default port for plain and STARTTLS connections, tlsPort
+	// the default port for implicit TLS.
+	port, tlsPort uint16
+
+	// greet reads the greeting of the server and returns its text.
+	greet func(c *client) (string, error)
+	// hello introduces the client after the greeting and after STARTTLS.
+	hello func(c *client) error
+	// startTLS asks the server to upgrade the connection to TLS.
+	startTLS func(c *client) error
+	auth     func(c *client, username, password string) error
+	quit     func(c *client) error
+}
+
+var protocols = map[string]*protocol{
+	"smtp": {
+		name: "smtp", port: 25, tlsPort: 465,
+		greet: func(c *client) (string, error) {
+			_, msg, err := c.text.ReadResponse(220)
+			return msg, err
+		},
+		hello: func(c *client) error {
+			_, _, err := c.cmd(250, "EHLO localhost")
+			return err
+		},
+		startTLS: func(c *client) error {
+			_, _, err := c.cmd(220, "STARTTLS")
+			return err
+		},
+		auth: func(c *client, username, password string) error {
+			resp := base64.StdEncoding.EncodeToString([]byte("\x00" + username + "\x00" + password))
+			_, _, err := c.cmd(235, "AUTH PLAIN %s", resp)
+			return err
+		},
+		quit: func(c *client) error {
+			_, _, err := c.cmd(221, "QUIT")
+			return err
+		},
+	},
+	"imap": {
+		name: "imap", port: 143, tlsPort: 993,
+		greet: func(c *client) (string, error) {
+			line, err := c.text.ReadLine()
+			if err != nil {
+				return "", err
+			}
+			for _, prefix := range []string{"* OK ", "* PREAUTH "} {
+				if banner, ok := strings.CutPrefix(line, prefix); ok {
+					return banner, nil
+				}
+			}
+			return "", responseError{line}
+		},
+		hello: func(*client) error { return nil },
+		startTLS: func(c *client) error {
+			return c.tagged("STARTTLS")
+		},
+		auth: func(c *client, username, password string) error {
+			return c.tagged("LOGIN %s %s", imapQuote(username), imapQuote(password))
+		},
+		quit: func(c *client) error {
+			return c.tagged("LOGOUT")
+		},
+	},
+	"pop3": {
+		name: "pop3", port: 110, tlsPort: 995,
+		greet: func(c *client) (string, error) {
+			return c.pop3Response()
+		},
+		hello: func(*client) error { return nil },
+		startTLS: func(c *client) error {
+			return c.pop3Cmd("STLS")
+		},
+		auth: func(c *client, username, password string) error {
+			if err := c.pop3Cmd("USER %s", username); err != nil {
+				return err
+			}
+			return c.pop3Cmd("PASS %s", password)
+		},
+		quit: func(c *client) error {
+			return c.pop3Cmd("QUIT")
+		},
+	},
+}
+
+// responseError is returned if the server does not answer as expected.
+type responseError struct {
+	line string
+}
+
+func (e responseError) Error() string {
+	return fmt.Sprintf("unexpected response from server: %q", e.line)
+}
+
+// client is the connection of a session.
+type client struct {
+	conn net.Conn
+	text *textproto.Conn
+	tag  int
+}
+
+func newClient(conn net.Conn) *client {
+	return &client{conn: conn, text: textproto.NewConn(conn)}
+}
+
+func (c *client) Close() error {
+	return c.text.Close()
+}
+
+// upgrade performs the TLS handshake on the connection.
+func (c *client) upgrade(config *tls.Config) (*tls.Conn, error) {
+	tlsConn := tls.Client(c.conn, config)
+	if err := tlsConn.Handshake(); err != nil {
+		return nil, err
+	}
+	c.conn = tlsConn
+	c.text = textproto.NewConn(tlsConn)
+	return tlsConn, nil
+}
+
+// cmd sends an SMTP command and reads the response.
+func (c *client) cmd(expectCode int, format string, args ...interface{}) (int, string, error) {
+	if _, err := c.text.Cmd(format, args...); err != nil {
+		return 0, "", err
+	}
+	return c.text.ReadResponse(expectCode)
+}
+
+// tagged sends an IMAP command, and reads the responses until the tagged
+// completion.
+func (c *client) tagged(format string, args ...interface{}) error {
+	c.tag++
+	tag := fmt.Sprintf("a%d", c.tag)
+	if err := c.text.PrintfLine(tag+" "+format, args...); err != nil {
+		return err
+	}
+	for {
+		line, err := c.text.ReadLine()
+		if err != nil {
+			return err
+		}
+		status, ok := strings.CutPrefix(line, tag+" ")
+		if !ok {
+			// untagged or continuation response
+			continue
+		}
+		if !strings.HasPrefix(status, "OK") {
+			return responseError{line}
+		}
+		return nil
+	}
+}
+
+// pop3Cmd sends a POP3 command and reads the status response.
+func (c *client) pop3Cmd(format string, args ...interface{}) error {
+	if err := c.text.PrintfLine(format, args...); err != nil {
+		return err
+	}
+	_, err := c.pop3Response()
+	return err
+}
+
+// pop3Response reads a POP3 status response and returns its text.
+func (c *client) pop3Response() (string, error) {
+	line, err := c.text.ReadLine()
+	if err != nil {
+		return "", err
+	}
+	if line == "+OK" {
+		return "", nil
+	}
+	text, ok := strings.CutPrefix(line, "+OK ")
+	if !ok {
+		return "", responseError{line}
+	}
+	return text, nil
+}
+
+// imapQuote returns s as an IMAP quoted string.
+func imapQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
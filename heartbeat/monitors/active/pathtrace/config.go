@@ -0,0 +1,47 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pathtrace
+
+import (
+	"time"
+)
+
+// Config configures the path trace run when a monitor fails.
+type Config struct {
+	Enabled bool `config:"enabled"`
+
+	// AfterFailures is the number of consecutive failed checks of an
+	// endpoint after which the path to the endpoint is traced.
+	AfterFailures int `config:"after_failures" validate:"min=1"`
+
+	// MaxHops is the maximum number of hops to trace.
+	MaxHops int `config:"max_hops" validate:"min=1,max=255"`
+
+	// Timeout is the time to wait for the reply of a single hop.
+	Timeout time.Duration `config:"timeout" validate:"min=1ns"`
+}
+
+// DefaultConfig returns the default path trace configuration.
+func DefaultConfig() Config {
+	return Config{
+		Enabled:       false,
+		AfterFailures: 3,
+		MaxHops:       30,
+		Timeout:       time.Second,
+	}
+}
@@ -0,0 +1,178 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package pathtrace traces the network path to an endpoint once a monitor
+// has failed repeatedly, and adds the hops to the down events.
+package pathtrace
+
+import (
+	"net"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/heartbeat/eventext"
+	"github.com/elastic/beats/v7/heartbeat/look"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Hop is a single hop on the path to the traced endpoint.
+type Hop struct {
+	// TTL is the time to live of the probe, starting at 1.
+	TTL int
+	// IP is the address of the router that answered the probe, or nil if
+	// the probe timed out.
+	IP net.IP
+	// RTT is the round trip time of the probe.
+	RTT time.Duration
+}
+
+// Result is the result of tracing the path to an endpoint.
+type Result struct {
+	Hops []Hop
+	// Reached is true if the endpoint answered the probe.
+	Reached bool
+}
+
+// traceFunc traces the path to an address.
+type traceFunc func(addr *net.IPAddr, maxHops int, timeout time.Duration) (Result, error)
+
+// Tracer counts the consecutive failures of the endpoints of a monitor and
+// traces the path to an endpoint once it has failed often enough. The path
+// is traced in the background, so a trace does not delay the checks, and the
+// last trace of the endpoint is added to its down events.
+type Tracer struct {
+	config Config
+	trace  traceFunc
+	log    *logp.Logger
+
+	mu       sync.Mutex
+	failures map[string]int
+	traces   map[string]*endpointTrace
+}
+
+// endpointTrace is the path trace of an endpoint that is down.
+type endpointTrace struct {
+	// running is true while the path is traced.
+	running bool
+	// fields are the fields of the last completed trace, nil if none.
+	fields mapstr.M
+}
+
+// NewTracer returns a Tracer for the given configuration. It returns nil if
+// path tracing is disabled.
+func NewTracer(config Config, log *logp.Logger) *Tracer {
+	if !config.Enabled {
+		return nil
+	}
+	return &Tracer{
+		config:   config,
+		trace:    icmpTrace,
+		log:      log.Named("pathtrace"),
+		failures: map[string]int{},
+		traces:   map[string]*endpointTrace{},
+	}
+}
+
+// Wrap returns a check function that calls check, and traces the path to
+// the checked IP after the configured number of consecutive failures. key
+// identifies the checked endpoint. The fields of the last trace of the
+// endpoint are added to the event of the failed checks once it completes. The
+// error returned by check is returned unchanged. If t is nil, Wrap returns
+// check.
+func (t *Tracer) Wrap(key string, check func(*beat.Event, *net.IPAddr) error) func(*beat.Event, *net.IPAddr) error {
+	if t == nil {
+		return check
+	}
+	return func(event *beat.Event, ip *net.IPAddr) error {
+		err := check(event, ip)
+		if fields := t.record(key+"/"+ip.String(), ip, err == nil); fields != nil {
+			eventext.MergeEventFields(event, mapstr.M{"path_trace": fields})
+		}
+		return err
+	}
+}
+
+// record records the result of a check. Once the endpoint failed often
+// enough, it starts tracing its path unless a trace is running, and returns
+// the fields of the last completed trace.
+func (t *Tracer) record(key string, ip *net.IPAddr, up bool) mapstr.M {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if up {
+		delete(t.failures, key)
+		delete(t.traces, key)
+		return nil
+	}
+	t.failures[key]++
+	if t.failures[key] < t.config.AfterFailures {
+		return nil
+	}
+	state, ok := t.traces[key]
+	if !ok {
+		state = &endpointTrace{}
+		t.traces[key] = state
+	}
+	if !state.running {
+		state.running = true
+		go t.run(key, ip, state)
+	}
+	if state.fields == nil {
+		return nil
+	}
+	return state.fields.Clone()
+}
+
+// run traces the path to the endpoint and stores the result in its state,
+// unless the endpoint is up again.
+func (t *Tracer) run(key string, ip *net.IPAddr, state *endpointTrace) {
+	fields := t.traceFields(ip)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	state.running = false
+	if fields != nil && t.traces[key] == state {
+		state.fields = fields
+	}
+}
+
+// traceFields traces the path to ip and returns the path_trace fields, nil
+// if tracing failed.
+func (t *Tracer) traceFields(ip *net.IPAddr) mapstr.M {
+	start := time.Now()
+	res, err := t.trace(ip, t.config.MaxHops, t.config.Timeout)
+	if err != nil {
+		t.log.Warnf("Failed to trace the path to %s: %v", ip, err)
+		return nil
+	}
+
+	hops := make([]mapstr.M, 0, len(res.Hops))
+	for _, hop := range res.Hops {
+		fields := mapstr.M{"ttl": hop.TTL}
+		if hop.IP != nil {
+			fields["ip"] = hop.IP.String()
+			fields["rtt"] = look.RTT(hop.RTT)
+		}
+		hops = append(hops, fields)
+	}
+	return mapstr.M{
+		"hops":     hops,
+		"reached":  res.Reached,
+		"duration": look.RTT(time.Since(start)),
+	}
+}
@@ -0,0 +1,200 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pathtrace
+
+import (
+	"encoding/binary"
+	"errors"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestNewTracerDisabled(t *testing.T) {
+	tracer := NewTracer(DefaultConfig(), logptest.NewTestingLogger(t, ""))
+	assert.Nil(t, tracer, "no tracer should be created if path tracing is disabled")
+
+	errDown := errors.New("down")
+	check := tracer.Wrap("host", func(*beat.Event, *net.IPAddr) error { return errDown })
+	assert.ErrorIs(t, check(&beat.Event{}, &net.IPAddr{IP: net.IPv4(10, 0, 0, 1)}), errDown)
+}
+
+func TestTracerWrap(t *testing.T) {
+	config := DefaultConfig()
+	config.Enabled = true
+	config.AfterFailures = 2
+	tracer := NewTracer(config, logptest.NewTestingLogger(t, ""))
+
+	var traces atomic.Int32
+	tracer.trace = func(addr *net.IPAddr, maxHops int, timeout time.Duration) (Result, error) {
+		traces.Add(1)
+		assert.Equal(t, config.MaxHops, maxHops)
+		assert.Equal(t, config.Timeout, timeout)
+		return Result{
+			Hops: []Hop{
+				{TTL: 1, IP: net.IPv4(192, 168, 0, 1), RTT: time.Millisecond},
+				{TTL: 2},
+				{TTL: 3, IP: addr.IP, RTT: 3 * time.Millisecond},
+			},
+			Reached: true,
+		}, nil
+	}
+
+	var checkErr error
+	check := tracer.Wrap("host", func(*beat.Event, *net.IPAddr) error { return checkErr })
+	ip := &net.IPAddr{IP: net.IPv4(10, 0, 0, 1)}
+	run := func() *beat.Event {
+		event := &beat.Event{Fields: mapstr.M{}}
+		err := check(event, ip)
+		assert.ErrorIs(t, err, checkErr, "the check error should be returned unchanged")
+		return event
+	}
+
+	// waitTrace waits for the trace running in the background.
+	waitTrace := func() {
+		require.Eventually(t, func() bool {
+			tracer.mu.Lock()
+			defer tracer.mu.Unlock()
+			state := tracer.traces["host/10.0.0.1"]
+			return state == nil || !state.running
+		}, time.Second, time.Millisecond)
+	}
+
+	checkErr = errors.New("down")
+	event := run()
+	assert.Zero(t, traces.Load(), "path should not be traced before the threshold is reached")
+	assert.NotContains(t, event.Fields, "path_trace")
+
+	event = run()
+	waitTrace()
+	require.Equal(t, int32(1), traces.Load(), "path should be traced once the threshold is reached")
+	assert.NotContains(t, event.Fields, "path_trace", "the path is traced in the background")
+
+	event = run()
+	waitTrace()
+	assert.Equal(t, int32(2), traces.Load(), "path should be traced while the endpoint is down")
+	hops, err := event.GetValue("path_trace.hops")
+	require.NoError(t, err)
+	assert.Equal(t, []mapstr.M{
+		{"ttl": 1, "ip": "192.168.0.1", "rtt": mapstr.M{"us": int64(1000)}},
+		{"ttl": 2},
+		{"ttl": 3, "ip": "10.0.0.1", "rtt": mapstr.M{"us": int64(3000)}},
+	}, hops)
+	reached, err := event.GetValue("path_trace.reached")
+	require.NoError(t, err)
+	assert.Equal(t, true, reached)
+
+	checkErr = nil
+	event = run()
+	assert.Equal(t, int32(2), traces.Load(), "path should not be traced if the endpoint is up")
+	assert.NotContains(t, event.Fields, "path_trace")
+
+	checkErr = errors.New("down")
+	event = run()
+	assert.Equal(t, int32(2), traces.Load(), "failure count should be reset when the endpoint is up")
+	assert.NotContains(t, event.Fields, "path_trace", "the traces are reset when the endpoint is up")
+}
+
+func TestProbeMatch(t *testing.T) {
+	const id, seq = 0x1234, 3
+
+	marshal := func(m icmp.Message) []byte {
+		b, err := m.Marshal(nil)
+		require.NoError(t, err)
+		return b
+	}
+
+	// the probe as quoted by a router: IPv4 header followed by the start
+	// of the ICMP echo request.
+	quoted := make([]byte, ipv4.HeaderLen+8)
+	quoted[0] = 0x45
+	quoted[ipv4.HeaderLen] = byte(ipv4.ICMPTypeEcho)
+	binary.BigEndian.PutUint16(quoted[ipv4.HeaderLen+4:], id)
+	binary.BigEndian.PutUint16(quoted[ipv4.HeaderLen+6:], seq)
+
+	tests := map[string]struct {
+		msg     []byte
+		reached bool
+		ok      bool
+	}{
+		"echo reply": {
+			msg: marshal(icmp.Message{
+				Type: ipv4.ICMPTypeEchoReply,
+				Body: &icmp.Echo{ID: id, Seq: seq},
+			}),
+			reached: true,
+			ok:      true,
+		},
+		"echo reply for other probe": {
+			msg: marshal(icmp.Message{
+				Type: ipv4.ICMPTypeEchoReply,
+				Body: &icmp.Echo{ID: id, Seq: seq + 1},
+			}),
+			reached: true,
+			ok:      false,
+		},
+		"time exceeded": {
+			msg: marshal(icmp.Message{
+				Type: ipv4.ICMPTypeTimeExceeded,
+				Body: &icmp.TimeExceeded{Data: quoted},
+			}),
+			ok: true,
+		},
+		"truncated time exceeded": {
+			msg: marshal(icmp.Message{
+				Type: ipv4.ICMPTypeTimeExceeded,
+				Body: &icmp.TimeExceeded{Data: quoted[:ipv4.HeaderLen+4]},
+			}),
+			ok: false,
+		},
+		"invalid quoted header length": {
+			msg: marshal(icmp.Message{
+				Type: ipv4.ICMPTypeTimeExceeded,
+				Body: &icmp.TimeExceeded{Data: append([]byte{0x4f}, quoted[1:]...)},
+			}),
+			ok: false,
+		},
+		"unrelated message": {
+			msg: marshal(icmp.Message{
+				Type: ipv4.ICMPTypeEcho,
+				Body: &icmp.Echo{ID: id, Seq: seq},
+			}),
+			ok: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			reached, ok := probeIPv4.match(test.msg, id, seq)
+			assert.Equal(t, test.ok, ok)
+			if ok {
+				assert.Equal(t, test.reached, reached)
+			}
+		})
+	}
+}
@@ -0,0 +1,204 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pathtrace
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net"
+	"time"
+
+	"golang.org/x/net/icmp"
+	"golang.org/x/net/ipv4"
+	"golang.org/x/net/ipv6"
+)
+
+const (
+	// iana protocol numbers
+	protocolICMP     = 1
+	protocolIPv6ICMP = 58
+
+	ipv6HeaderLen = 40
+)
+
+// probe is the protocol specific part of an ICMP trace.
+type probe struct {
+	network  string
+	listen   string
+	proto    int
+	echo     icmp.Type
+	reply    icmp.Type
+	exceeded icmp.Type
+	setTTL   func(conn *icmp.PacketConn, ttl int) error
+	// quoted returns the ICMP message quoted in an error message.
+	quoted func(data []byte) []byte
+}
+
+var (
+	probeIPv4 = probe{
+		network:  "ip4:icmp",
+		listen:   "0.0.0.0",
+		proto:    protocolICMP,
+		echo:     ipv4.ICMPTypeEcho,
+		reply:    ipv4.ICMPTypeEchoReply,
+		exceeded: ipv4.ICMPTypeTimeExceeded,
+		setTTL: func(conn *icmp.PacketConn, ttl int) error {
+			return conn.IPv4PacketConn().SetTTL(ttl)
+		},
+		quoted: func(data []byte) []byte {
+			if len(data) < ipv4.HeaderLen {
+				return nil
+			}
+			// the header length is given in 32-bit words, and can be
+			// larger than the quoted data of a malformed message.
+			hdrLen := int(data[0]&0x0f) * 4
+			if hdrLen < ipv4.HeaderLen || hdrLen > len(data) {
+				return nil
+			}
+			return data[hdrLen:]
+		},
+	}
+	probeIPv6 = probe{
+		network:  "ip6:ipv6-icmp",
+		listen:   "::",
+		proto:    protocolIPv6ICMP,
+		echo:     ipv6.ICMPTypeEchoRequest,
+		reply:    ipv6.ICMPTypeEchoReply,
+		exceeded: ipv6.ICMPTypeTimeExceeded,
+		setTTL: func(conn *icmp.PacketConn, ttl int) error {
+			return conn.IPv6PacketConn().SetHopLimit(ttl)
+		},
+		quoted: func(data []byte) []byte {
+			if len(data) < ipv6HeaderLen {
+				return nil
+			}
+			return data[ipv6HeaderLen:]
+		},
+	}
+)
+
+// icmpTrace traces the path to addr by sending ICMP echo requests with an
+// increasing TTL, and collecting the time exceeded messages of the routers
+// on the path. Tracing requires the privileges to open raw ICMP sockets.
+func icmpTrace(addr *net.IPAddr, maxHops int, timeout time.Duration) (Result, error) {
+	p := probeIPv4
+	if addr.IP.To4() == nil {
+		p = probeIPv6
+	}
+
+	conn, err := icmp.ListenPacket(p.network, p.listen)
+	if err != nil {
+		return Result{}, fmt.Errorf("path tracing requires the privileges to open raw ICMP sockets: %w", err)
+	}
+	defer conn.Close()
+
+	id := rand.Intn(0xffff)
+	var res Result
+	for ttl := 1; ttl <= maxHops; ttl++ {
+		hop, reached, err := p.send(conn, addr, id, ttl, timeout)
+		if err != nil {
+			return Result{}, err
+		}
+		res.Hops = append(res.Hops, hop)
+		if reached {
+			res.Reached = true
+			break
+		}
+	}
+	return res, nil
+}
+
+// send sends a single probe with the given TTL, and waits for the answer.
+func (p probe) send(conn *icmp.PacketConn, addr *net.IPAddr, id, ttl int, timeout time.Duration) (Hop, bool, error) {
+	hop := Hop{TTL: ttl}
+	if err := p.setTTL(conn, ttl); err != nil {
+		return hop, false, fmt.Errorf("could not set TTL: %w", err)
+	}
+
+	msg := icmp.Message{
+		Type: p.echo,
+		Body: &icmp.Echo{ID: id, Seq: ttl, Data: make([]byte, 32)},
+	}
+	encoded, err := msg.Marshal(nil)
+	if err != nil {
+		return hop, false, err
+	}
+
+	start := time.Now()
+	if err := conn.SetReadDeadline(start.Add(timeout)); err != nil {
+		return hop, false, err
+	}
+	if _, err := conn.WriteTo(encoded, addr); err != nil {
+		return hop, false, fmt.Errorf("could not send probe: %w", err)
+	}
+
+	buf := make([]byte, 1500)
+	for {
+		n, from, err := conn.ReadFrom(buf)
+		if err != nil {
+			var netErr net.Error
+			if errors.As(err, &netErr) && netErr.Timeout() {
+				// no answer for this hop
+				return hop, false, nil
+			}
+			return hop, false, err
+		}
+
+		reached, ok := p.match(buf[:n], id, ttl)
+		if !ok {
+			continue
+		}
+		hop.RTT = time.Since(start)
+		if ipAddr, ok := from.(*net.IPAddr); ok {
+			hop.IP = ipAddr.IP
+		}
+		return hop, reached, nil
+	}
+}
+
+// match checks if msg answers the probe with the given id and sequence.
+// It returns true as first value if the answer comes from the destination.
+func (p probe) match(msg []byte, id, seq int) (reached, ok bool) {
+	m, err := icmp.ParseMessage(p.proto, msg)
+	if err != nil {
+		return false, false
+	}
+
+	switch m.Type {
+	case p.reply:
+		echo, isEcho := m.Body.(*icmp.Echo)
+		return true, isEcho && echo.ID == id && echo.Seq == seq
+	case p.exceeded:
+		body, isExceeded := m.Body.(*icmp.TimeExceeded)
+		if !isExceeded {
+			return false, false
+		}
+		// the quoted message starts with the ICMP header of the probe,
+		// followed by the echo id and sequence.
+		quoted := p.quoted(body.Data)
+		if len(quoted) < 8 {
+			return false, false
+		}
+		return false, int(binary.BigEndian.Uint16(quoted[4:6])) == id &&
+			int(binary.BigEndian.Uint16(quoted[6:8])) == seq
+	default:
+		return false, false
+	}
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/elastic/beats/v7/heartbeat/monitors"
 	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain"
 	"github.com/elastic/beats/v7/heartbeat/monitors/active/dialchain/tlsmeta"
+	"github.com/elastic/beats/v7/heartbeat/monitors/active/pathtrace"
 	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
 	"github.com/elastic/beats/v7/heartbeat/monitors/plugin"
 	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers/wraputil"
@@ -82,6 +83,7 @@ type jobFactory struct {
 	endpoints     []endpoint
 	dataCheck     dataCheck
 	resolver      monitors.Resolver
+	tracer        *pathtrace.Tracer
 }
 
 func newJobFactory(commonCfg *conf.C, resolver monitors.Resolver) (*jobFactory, error) {
@@ -118,6 +120,7 @@ func (jf *jobFactory) loadConfig(commonCfg *conf.C) error {
 	}
 
 	jf.dataCheck = makeDataCheck(&jf.config)
+	jf.tracer = pathtrace.NewTracer(jf.config.PathTrace, logp.NewLogger("tcp"))
 
 	return nil
 }
@@ -160,13 +163,13 @@ func (jf *jobFactory) makeDirectEndpointJob(endpointURL *url.URL) (jobs.Job, err
 		endpointURL.Hostname(),
 		jf.config.Mode,
 		jf.resolver,
-		monitors.MakePingIPFactory(
+		monitors.MakePingIPFactory(jf.tracer.Wrap(endpointURL.String(),
 			func(event *beat.Event, ip *net.IPAddr) error {
 				// use address from resolved IP
 				ipPort := net.JoinHostPort(ip.String(), endpointURL.Port())
 
 				return jf.dial(event, ipPort, endpointURL)
-			}))
+			})))
 	if err != nil {
 		return nil, err
 	}
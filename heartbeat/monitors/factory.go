@@ -70,7 +70,8 @@ type publishSettings struct {
 	Processors    processors.PluginConfig `config:"processors"`
 
 	PublisherPipeline struct {
-		DisableHost bool `config:"disable_host"` // Disable addition of host.name.
+		DisableHost bool   `config:"disable_host"` // Disable addition of host.name.
+		Name        string `config:"name"`         // Named pipeline of the events.
 	} `config:"publisher_pipeline"`
 
 	// KeepNull determines whether published events will keep null values or omit them.
@@ -290,6 +291,9 @@ func newCommonPublishConfigs(info beat.Info, beatLocation *config.LocationWithID
 		clientCfg.Processing.Processor = procs
 		clientCfg.Processing.KeepNull = settings.KeepNull
 		clientCfg.Processing.DisableHost = settings.PublisherPipeline.DisableHost
+		if settings.PublisherPipeline.Name != "" {
+			clientCfg.PublisherPipeline = settings.PublisherPipeline.Name
+		}
 
 		return clientCfg, nil
 	}, nil
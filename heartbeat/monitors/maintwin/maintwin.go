@@ -18,20 +18,37 @@
 package maintwin
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/gorhill/cronexpr"
 	"github.com/teambition/rrule-go"
 )
 
+const (
+	// ModeSkip skips the checks of the monitor during the window.
+	ModeSkip = "skip"
+	// ModeTag runs the checks during the window, and sets
+	// `monitor.maintenance: true` on their events.
+	ModeTag = "tag"
+)
+
 var weekdayLookup = map[string]rrule.Weekday{
 	"MO": rrule.MO, "TU": rrule.TU, "WE": rrule.WE, "TH": rrule.TH, "FR": rrule.FR, "SA": rrule.SA, "SU": rrule.SU,
 }
 
 type MaintWin struct {
-	Freq       string        `config:"freq" validate:"required"`
-	Dtstart    string        `config:"dtstart" validate:"required"`
+	// Schedule is a cron expression for the start of the window. It is an
+	// alternative to the recurrence rule defined by Freq and Dtstart.
+	Schedule string `config:"schedule"`
+	// Timezone is the time zone the Schedule is evaluated in, UTC if unset.
+	Timezone string `config:"timezone"`
+	Mode     string `config:"mode"`
+
+	Freq       string        `config:"freq"`
+	Dtstart    string        `config:"dtstart"`
 	Interval   int           `config:"interval"`
 	Duration   time.Duration `config:"duration" validate:"required"`
 	Wkst       rrule.Weekday `config:"wkst"`
@@ -48,6 +65,52 @@ type MaintWin struct {
 	Byeaster   []int         `config:"byeaster"`
 }
 
+func (mw *MaintWin) Validate() error {
+	switch mw.Mode {
+	case "", ModeSkip, ModeTag:
+	default:
+		return fmt.Errorf("invalid mode %q: must be %s or %s", mw.Mode, ModeSkip, ModeTag)
+	}
+	if mw.Schedule != "" {
+		if mw.Freq != "" || mw.Dtstart != "" {
+			return errors.New("schedule can not be combined with freq and dtstart")
+		}
+		return nil
+	}
+	if mw.Freq == "" || mw.Dtstart == "" {
+		return errors.New("either schedule, or freq and dtstart are required")
+	}
+	return nil
+}
+
+// ParseWindow parses the maintenance window defined either by a cron
+// schedule or by a recurrence rule.
+func (mw *MaintWin) ParseWindow(validateDtStart bool) (ParsedMaintWin, error) {
+	pmw := ParsedMaintWin{Duration: mw.Duration, Tag: mw.Mode == ModeTag}
+	if mw.Schedule == "" {
+		r, err := mw.Parse(validateDtStart)
+		if err != nil {
+			return ParsedMaintWin{}, err
+		}
+		pmw.Rule = r
+		return pmw, nil
+	}
+
+	expr, err := cronexpr.Parse(mw.Schedule)
+	if err != nil {
+		return ParsedMaintWin{}, fmt.Errorf("invalid schedule %q: %w", mw.Schedule, err)
+	}
+	pmw.Cron, pmw.Location = expr, time.UTC
+	if mw.Timezone != "" {
+		pmw.Location, err = time.LoadLocation(mw.Timezone)
+		if err != nil {
+			return ParsedMaintWin{}, fmt.Errorf("invalid timezone %q: %w", mw.Timezone, err)
+		}
+	}
+	pmw.schedule = mw.Schedule
+	return pmw, nil
+}
+
 func (mw *MaintWin) Parse(validateDtStart bool) (r *rrule.RRule, err error) {
 
 	// validate the frequency, we don't support less than daily
@@ -106,10 +169,23 @@ func (mw *MaintWin) Parse(validateDtStart bool) (r *rrule.RRule, err error) {
 
 type ParsedMaintWin struct {
 	Rule     *rrule.RRule
+	Cron     *cronexpr.Expression
+	Location *time.Location
 	Duration time.Duration
+	// Tag is true if checks run during the window, with their events
+	// tagged as in maintenance.
+	Tag bool
+
+	schedule string
 }
 
 func (pmw ParsedMaintWin) IsActive(tOrig time.Time) bool {
+	if pmw.Cron != nil {
+		// the window is active if it started within the last Duration.
+		t := tOrig.In(pmw.Location)
+		start := pmw.Cron.Next(t.Add(-pmw.Duration))
+		return !start.IsZero() && !start.After(t)
+	}
 	if pmw.Rule == nil {
 		return false
 	}
@@ -117,3 +193,21 @@ func (pmw ParsedMaintWin) IsActive(tOrig time.Time) bool {
 	window := pmw.Rule.Before(tOrig, true)
 	return !window.IsZero() && tOrig.Before(window.Add(pmw.Duration))
 }
+
+func (pmw ParsedMaintWin) String() string {
+	if pmw.Cron != nil {
+		return fmt.Sprintf("schedule %q for %v", pmw.schedule, pmw.Duration)
+	}
+	return fmt.Sprintf("%v for %v", pmw.Rule, pmw.Duration)
+}
+
+// InMaintenance returns true if a window that tags the events of the checks
+// is active at t.
+func InMaintenance(pmws []ParsedMaintWin, t time.Time) bool {
+	for _, pmw := range pmws {
+		if pmw.Tag && pmw.IsActive(t) {
+			return true
+		}
+	}
+	return false
+}
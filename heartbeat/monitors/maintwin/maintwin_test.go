@@ -178,6 +178,105 @@ func TestMaintWin(t *testing.T) {
 	}
 }
 
+func TestMaintWinSchedule(t *testing.T) {
+	cases := []struct {
+		name            string
+		mw              MaintWin
+		positiveMatches []string
+		negativeMatches []string
+	}{
+		{
+			name: "Every Sunday at 2 AM for 2 hours",
+			mw: MaintWin{
+				Schedule: "0 2 * * 0",
+				Duration: mustParseDuration("2h"),
+			},
+			positiveMatches: []string{"2025-02-09T02:00:00Z", "2025-02-09T03:59:00Z"},
+			negativeMatches: []string{"2025-02-09T01:59:00Z", "2025-02-09T04:00:00Z", "2025-02-10T02:30:00Z"},
+		},
+		{
+			name: "Daily at 10 PM in New York for 1 hour",
+			mw: MaintWin{
+				Schedule: "0 22 * * *",
+				Timezone: "America/New_York",
+				Duration: mustParseDuration("1h"),
+			},
+			positiveMatches: []string{"2025-02-07T03:30:00Z"},
+			negativeMatches: []string{"2025-02-06T22:30:00Z"},
+		},
+		{
+			name: "Window spanning midnight",
+			mw: MaintWin{
+				Schedule: "30 23 * * *",
+				Duration: mustParseDuration("1h"),
+			},
+			positiveMatches: []string{"2025-02-06T23:45:00Z", "2025-02-07T00:15:00Z"},
+			negativeMatches: []string{"2025-02-07T00:31:00Z"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			pmw, err := c.mw.ParseWindow(true)
+			require.NoError(t, err)
+			for _, m := range c.positiveMatches {
+				pt, err := time.Parse(time.RFC3339, m)
+				require.NoError(t, err)
+				assert.True(t, pmw.IsActive(pt), "window should be active at %s", m)
+			}
+			for _, m := range c.negativeMatches {
+				pt, err := time.Parse(time.RFC3339, m)
+				require.NoError(t, err)
+				assert.False(t, pmw.IsActive(pt), "window should not be active at %s", m)
+			}
+		})
+	}
+}
+
+func TestMaintWinValidate(t *testing.T) {
+	cases := map[string]struct {
+		mw  MaintWin
+		err string
+	}{
+		"schedule":       {mw: MaintWin{Schedule: "0 2 * * 0", Mode: ModeTag}},
+		"rrule":          {mw: MaintWin{Freq: "daily", Dtstart: "2025-02-06T21:00:00Z"}},
+		"missing":        {mw: MaintWin{}, err: "either schedule, or freq and dtstart are required"},
+		"both":           {mw: MaintWin{Schedule: "0 2 * * 0", Freq: "daily"}, err: "can not be combined"},
+		"invalid mode":   {mw: MaintWin{Schedule: "0 2 * * 0", Mode: "ignore"}, err: "invalid mode"},
+		"missing dstart": {mw: MaintWin{Freq: "daily"}, err: "either schedule, or freq and dtstart are required"},
+	}
+
+	for name, c := range cases {
+		t.Run(name, func(t *testing.T) {
+			err := c.mw.Validate()
+			if c.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, c.err)
+		})
+	}
+
+	_, err := (&MaintWin{Schedule: "not a schedule"}).ParseWindow(true)
+	assert.ErrorContains(t, err, "invalid schedule")
+	_, err = (&MaintWin{Schedule: "0 2 * * 0", Timezone: "Nowhere/Special"}).ParseWindow(true)
+	assert.ErrorContains(t, err, "invalid timezone")
+}
+
+func TestInMaintenance(t *testing.T) {
+	empty := ParsedMaintWin{}
+	mw := MaintWin{Schedule: "* * * * *", Duration: mustParseDuration("2m")}
+	always, err := mw.ParseWindow(true)
+	require.NoError(t, err)
+	tagged := always
+	tagged.Tag = true
+
+	now := time.Now()
+	assert.False(t, InMaintenance(nil, now))
+	assert.False(t, InMaintenance([]ParsedMaintWin{empty, always}, now), "skip windows should not tag events")
+	assert.True(t, InMaintenance([]ParsedMaintWin{always, tagged}, now))
+}
+
 func mustParseDuration(s string) time.Duration {
 	d, err := time.ParseDuration(s)
 	if err != nil {
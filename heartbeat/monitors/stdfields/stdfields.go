@@ -81,11 +81,11 @@ func ConfigToStdMonitorFields(conf *config.C) (StdMonitorFields, error) {
 	}
 
 	for _, mw := range sFields.MaintenanceWindows {
-		parsed, err := mw.Parse(true)
+		parsed, err := mw.ParseWindow(true)
 		if err != nil {
 			return StdMonitorFields{}, fmt.Errorf("could not parse maintenance window for monitor (id:%s name:%s): %w", sFields.ID, sFields.Name, err)
 		}
-		sFields.ParsedMainteWin = append(sFields.ParsedMainteWin, maintwin.ParsedMaintWin{Rule: parsed, Duration: mw.Duration})
+		sFields.ParsedMainteWin = append(sFields.ParsedMainteWin, parsed)
 	}
 
 	return sFields, nil
@@ -28,6 +28,7 @@ import (
 
 	"github.com/elastic/beats/v7/heartbeat/eventext"
 	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
+	"github.com/elastic/beats/v7/heartbeat/monitors/maintwin"
 	"github.com/elastic/beats/v7/heartbeat/monitors/stdfields"
 	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers/monitorstate"
 	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers/summarizer"
@@ -82,6 +83,7 @@ func WrapBrowser(js []jobs.Job, stdMonFields stdfields.StdMonitorFields, mst *mo
 func addMonitorMeta(sFields stdfields.StdMonitorFields, hashURLIntoID bool) jobs.JobWrapper {
 	return func(job jobs.Job) jobs.Job {
 		return func(event *beat.Event) ([]jobs.Job, error) {
+			started := time.Now()
 			cont, err := job(event)
 
 			id := sFields.ID
@@ -111,6 +113,12 @@ func addMonitorMeta(sFields stdfields.StdMonitorFields, hashURLIntoID bool) jobs
 				fields["origin"] = sFields.Origin
 			}
 
+			// Checks still run in maintenance windows with the tag mode,
+			// so alerting can suppress the expected downtime.
+			if maintwin.InMaintenance(sFields.ParsedMainteWin, started) {
+				fields["maintenance"] = true
+			}
+
 			eventext.MergeEventFields(event, mapstr.M{"monitor": fields})
 			return cont, err
 		}
@@ -42,6 +42,7 @@ import (
 	"github.com/elastic/beats/v7/heartbeat/hbtestllext"
 	"github.com/elastic/beats/v7/heartbeat/monitors/jobs"
 	"github.com/elastic/beats/v7/heartbeat/monitors/logger"
+	"github.com/elastic/beats/v7/heartbeat/monitors/maintwin"
 	"github.com/elastic/beats/v7/heartbeat/monitors/stdfields"
 	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers/summarizer/summarizertesthelper"
 	"github.com/elastic/beats/v7/heartbeat/monitors/wrappers/wraputil"
@@ -714,3 +715,42 @@ func TestECSErrors(t *testing.T) {
 		})
 	}
 }
+
+func TestMaintenanceTag(t *testing.T) {
+	window := func(mode string) []maintwin.ParsedMaintWin {
+		mw := maintwin.MaintWin{Schedule: "* * * * *", Duration: 2 * time.Minute, Mode: mode}
+		pmw, err := mw.ParseWindow(false)
+		require.NoError(t, err)
+		return []maintwin.ParsedMaintWin{pmw}
+	}
+
+	tests := map[string]struct {
+		windows     []maintwin.ParsedMaintWin
+		maintenance bool
+	}{
+		"no window":       {maintenance: false},
+		"skip window":     {windows: window(maintwin.ModeSkip), maintenance: false},
+		"tag window":      {windows: window(maintwin.ModeTag), maintenance: true},
+		"inactive window": {windows: []maintwin.ParsedMaintWin{{Tag: true}}, maintenance: false},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sFields := testMonFields
+			sFields.ParsedMainteWin = test.windows
+			wrapped := WrapCommon([]jobs.Job{makeURLJob(t, "tcp://foo.com:80")}, sFields, nil)
+
+			results, err := jobs.ExecJobsAndConts(t, wrapped)
+			require.NoError(t, err)
+			require.Len(t, results, 1)
+
+			v, err := results[0].GetValue("monitor.maintenance")
+			if !test.maintenance {
+				assert.Error(t, err, "monitor.maintenance should not be set")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, true, v)
+		})
+	}
+}
@@ -192,7 +192,8 @@ func (s *Scheduler) Add(sched Schedule, pmws []maintwin.ParsedMaintWin, id strin
 
 		var activeMainWin *maintwin.ParsedMaintWin
 		for _, pmw := range pmws {
-			if pmw.IsActive(now) {
+			// windows that tag events do not skip the checks
+			if !pmw.Tag && pmw.IsActive(now) {
 				pmwCopy := pmw
 				activeMainWin = &pmwCopy
 				break
@@ -203,7 +204,7 @@ func (s *Scheduler) Add(sched Schedule, pmws []maintwin.ParsedMaintWin, id strin
 		if activeMainWin == nil {
 			lastRanAt = sj.run()
 		} else {
-			logp.L().Infof("Job '%s' is in maintenance window '%s' , skipping", id, activeMainWin.Rule)
+			logp.L().Infof("Job '%s' is in maintenance window '%s' , skipping", id, activeMainWin)
 			lastRanAt = now
 		}
 		s.stats.activeJobs.Dec()
@@ -17,7 +17,12 @@
 
 package api
 
-import "os"
+import (
+	"errors"
+	"os"
+
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
 
 // StateInspectorConfig holds the configuration for the state store inspector.
 type StateInspectorConfig struct {
@@ -29,14 +34,61 @@ type DebugConfig struct {
 	StateInspector StateInspectorConfig `config:"state_inspector"`
 }
 
+// InputControlConfig holds the configuration for the endpoints to control
+// inputs at runtime.
+type InputControlConfig struct {
+	Enabled bool `config:"enabled"`
+}
+
+// AuthConfig holds the credentials the requests to the API endpoint must
+// present, either with basic authentication or as an API key in the
+// "Authorization: ApiKey <key>" header. The requests are not authenticated
+// when no credentials are set.
+type AuthConfig struct {
+	Username string   `config:"username"`
+	Password string   `config:"password"`
+	APIKeys  []string `config:"api_keys"`
+}
+
 // Config is the configuration for the API endpoint.
 type Config struct {
-	Enabled            bool        `config:"enabled"`
-	Host               string      `config:"host"`
-	Port               int         `config:"port"`
-	User               string      `config:"named_pipe.user"`
-	SecurityDescriptor string      `config:"named_pipe.security_descriptor"`
-	Debug              DebugConfig `config:"debug"`
+	Enabled            bool                    `config:"enabled"`
+	Host               string                  `config:"host"`
+	Port               int                     `config:"port"`
+	User               string                  `config:"named_pipe.user"`
+	SecurityDescriptor string                  `config:"named_pipe.security_descriptor"`
+	SSL                *tlscommon.ServerConfig `config:"ssl"`
+	Auth               AuthConfig              `config:"auth"`
+	// AllowedIPs are the IP addresses and CIDR ranges of the clients
+	// allowed to send requests to the endpoint. All clients are allowed
+	// when empty.
+	AllowedIPs   []string           `config:"allowed_ips"`
+	Debug        DebugConfig        `config:"debug"`
+	InputControl InputControlConfig `config:"input_control"`
+}
+
+func (c *Config) Validate() error {
+	if (c.Auth.Username == "") != (c.Auth.Password == "") {
+		return errors.New("auth.username and auth.password must be set together")
+	}
+	for _, k := range c.Auth.APIKeys {
+		if k == "" {
+			return errors.New("auth.api_keys cannot contain empty keys")
+		}
+	}
+	if _, err := parseAllowedIPs(c.AllowedIPs); err != nil {
+		return err
+	}
+	if len(c.AllowedIPs) != 0 || c.SSL.IsEnabled() {
+		network, _, err := parse(c.Host, c.Port)
+		if err != nil {
+			return err
+		}
+		if network != "tcp" {
+			return errors.New("ssl and allowed_ips can only be used when binding to a hostname or an IP address")
+		}
+	}
+	return nil
 }
 
 // DefaultConfig is the default configuration used by the API endpoint.
@@ -0,0 +1,133 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// apiKeyScheme is the scheme of the Authorization header carrying an API key.
+const apiKeyScheme = "ApiKey "
+
+// parseAllowedIPs parses the IP addresses and CIDR ranges of the allowlist.
+func parseAllowedIPs(allowed []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(allowed))
+	for _, s := range allowed {
+		if strings.Contains(s, "/") {
+			p, err := netip.ParsePrefix(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid allowed_ips entry %q: %w", s, err)
+			}
+			prefixes = append(prefixes, p.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid allowed_ips entry %q: %w", s, err)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// guard wraps the handler of the server to reject the requests coming from
+// addresses that are not allowed and the requests that are not
+// authenticated. The handler is returned unchanged if neither the allowlist
+// nor the authentication are configured.
+func (s *Server) guard(h http.Handler) http.Handler {
+	if len(s.allowed) == 0 && !s.config.Auth.enabled() {
+		return h
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !s.allowedAddr(r.RemoteAddr) {
+			s.log.Debugf("Rejected request from %s: address not allowed", r.RemoteAddr)
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+		if !s.config.Auth.authenticated(r) {
+			if s.config.Auth.Username != "" {
+				w.Header().Set("WWW-Authenticate", `Basic realm="beat"`)
+			}
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		h.ServeHTTP(w, r)
+	})
+}
+
+// allowedAddr reports whether remoteAddr, the address of the client of a
+// request, is in the allowlist. All addresses are allowed if it is empty.
+func (s *Server) allowedAddr(remoteAddr string) bool {
+	if len(s.allowed) == 0 {
+		return true
+	}
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		return false
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range s.allowed {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// enabled reports whether the requests must be authenticated.
+func (c *AuthConfig) enabled() bool {
+	return c.Username != "" || len(c.APIKeys) != 0
+}
+
+// authenticated reports whether r presents the basic authentication
+// credentials or one of the API keys of the configuration. The credentials
+// are compared in constant time.
+func (c *AuthConfig) authenticated(r *http.Request) bool {
+	if !c.enabled() {
+		return true
+	}
+	header := r.Header.Get("Authorization")
+	if key, ok := strings.CutPrefix(header, apiKeyScheme); ok {
+		for _, k := range c.APIKeys {
+			if subtle.ConstantTimeCompare([]byte(key), []byte(k)) == 1 {
+				return true
+			}
+		}
+		return false
+	}
+	if c.Username == "" {
+		return false
+	}
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		return false
+	}
+	userOK := subtle.ConstantTimeCompare([]byte(user), []byte(c.Username)) == 1
+	passwordOK := subtle.ConstantTimeCompare([]byte(password), []byte(c.Password)) == 1
+	return userOK && passwordOK
+}
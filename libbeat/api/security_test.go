@@ -0,0 +1,140 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+func TestGuard(t *testing.T) {
+	cfg := config.MustNewConfigFrom(map[string]any{
+		"host":          "http://localhost:0",
+		"auth.username": "monitor",
+		"auth.password": "secret",
+		"auth.api_keys": []string{"key-1"},
+		"allowed_ips":   []string{"127.0.0.1", "10.0.0.0/8", "::1"},
+	})
+	s, err := New(logptest.NewTestingLogger(t, ""), cfg)
+	require.NoError(t, err)
+	defer s.Stop() //nolint:errcheck // Stop only fails on unknown states.
+	attachEchoHelloHandler(t, s)
+	h := s.guard(s.mux)
+
+	tests := map[string]struct {
+		remoteAddr string
+		auth       func(r *http.Request)
+		want       int
+	}{
+		"basic auth": {
+			remoteAddr: "127.0.0.1:40000",
+			auth:       func(r *http.Request) { r.SetBasicAuth("monitor", "secret") },
+			want:       http.StatusOK,
+		},
+		"api key": {
+			remoteAddr: "10.1.2.3:40000",
+			auth:       func(r *http.Request) { r.Header.Set("Authorization", "ApiKey key-1") },
+			want:       http.StatusOK,
+		},
+		"ipv6": {
+			remoteAddr: "[::1]:40000",
+			auth:       func(r *http.Request) { r.Header.Set("Authorization", "ApiKey key-1") },
+			want:       http.StatusOK,
+		},
+		"wrong password": {
+			remoteAddr: "127.0.0.1:40000",
+			auth:       func(r *http.Request) { r.SetBasicAuth("monitor", "guess") },
+			want:       http.StatusUnauthorized,
+		},
+		"wrong api key": {
+			remoteAddr: "127.0.0.1:40000",
+			auth:       func(r *http.Request) { r.Header.Set("Authorization", "ApiKey key-2") },
+			want:       http.StatusUnauthorized,
+		},
+		"no credentials": {
+			remoteAddr: "127.0.0.1:40000",
+			auth:       func(*http.Request) {},
+			want:       http.StatusUnauthorized,
+		},
+		"address not allowed": {
+			remoteAddr: "192.168.1.1:40000",
+			auth:       func(r *http.Request) { r.SetBasicAuth("monitor", "secret") },
+			want:       http.StatusForbidden,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/echo-hello", nil)
+			req.RemoteAddr = test.remoteAddr
+			test.auth(req)
+			resp := httptest.NewRecorder()
+			h.ServeHTTP(resp, req)
+			assert.Equal(t, test.want, resp.Code)
+			if test.want == http.StatusOK {
+				assert.Equal(t, "ehlo!", resp.Body.String())
+			}
+		})
+	}
+}
+
+func TestGuardDisabled(t *testing.T) {
+	s, err := New(logptest.NewTestingLogger(t, ""), config.MustNewConfigFrom(map[string]any{
+		"host": "http://localhost:0",
+	}))
+	require.NoError(t, err)
+	defer s.Stop() //nolint:errcheck // Stop only fails on unknown states.
+	assert.Equal(t, http.Handler(s.mux), s.guard(s.mux), "the handler is not wrapped without allowlist or authentication")
+}
+
+func TestSecurityConfig(t *testing.T) {
+	tests := map[string]struct {
+		cfg     map[string]any
+		wantErr string
+	}{
+		"username without password": {
+			cfg:     map[string]any{"auth.username": "monitor"},
+			wantErr: "auth.username and auth.password must be set together",
+		},
+		"empty api key": {
+			cfg:     map[string]any{"auth.api_keys": []string{""}},
+			wantErr: "auth.api_keys cannot contain empty keys",
+		},
+		"invalid allowed ip": {
+			cfg:     map[string]any{"allowed_ips": []string{"10.0.0.0/33"}},
+			wantErr: `invalid allowed_ips entry "10.0.0.0/33"`,
+		},
+		"allowed ips with unix socket": {
+			cfg:     map[string]any{"host": "unix:///tmp/beat.sock", "allowed_ips": []string{"127.0.0.1"}},
+			wantErr: "ssl and allowed_ips can only be used when binding to a hostname or an IP address",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := DefaultConfig
+			err := config.MustNewConfigFrom(test.cfg).Unpack(&cfg)
+			assert.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
@@ -18,9 +18,11 @@
 package api
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 	"net/http"
+	"net/netip"
 	"net/url"
 	"strconv"
 	"strings"
@@ -30,6 +32,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/statestore/inspector"
 	"github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
 )
 
 type serverState int
@@ -52,6 +55,9 @@ type Server struct {
 	httpServer *http.Server
 	state      serverState
 	inspector  *inspector.Handler
+	// allowed are the address ranges of the clients allowed to send
+	// requests, all clients are allowed if empty.
+	allowed []netip.Prefix
 }
 
 // New creates a new API Server with no routes attached.
@@ -62,20 +68,47 @@ func New(log *logp.Logger, config *config.C) (*Server, error) {
 		return nil, err
 	}
 
+	allowed, err := parseAllowedIPs(cfg.AllowedIPs)
+	if err != nil {
+		return nil, err
+	}
+
+	log = log.Named("api")
+	tlsConfig, err := tlscommon.LoadTLSServerConfig(cfg.SSL, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load the TLS configuration of the HTTP endpoint: %w", err)
+	}
+
 	l, err := makeListener(cfg)
 	if err != nil {
 		return nil, err
 	}
+	if tlsConfig != nil {
+		l = tls.NewListener(l, tlsConfig.BuildServerConfig(cfg.Host))
+	}
+
+	if exposed(l.Addr()) && !cfg.Auth.enabled() && len(allowed) == 0 {
+		log.Warnf("The HTTP endpoint is listening on %s, which is reachable from other hosts, "+
+			"without authentication or allowlist. Set http.auth or http.allowed_ips to restrict its access.", l.Addr())
+	}
 
 	return &Server{
-		mux:    http.NewServeMux(),
-		l:      l,
-		config: cfg,
-		log:    log.Named("api"),
-		state:  stateNew,
+		mux:     http.NewServeMux(),
+		l:       l,
+		config:  cfg,
+		log:     log,
+		state:   stateNew,
+		allowed: allowed,
 	}, nil
 }
 
+// exposed reports whether addr is a TCP address that is not a loopback
+// address.
+func exposed(addr net.Addr) bool {
+	tcp, ok := addr.(*net.TCPAddr)
+	return ok && !tcp.IP.IsLoopback()
+}
+
 // Start starts the HTTP server and accepting new connection.
 func (s *Server) Start() {
 	s.mutex.Lock()
@@ -86,7 +119,7 @@ func (s *Server) Start() {
 		s.state = stateStarted
 		s.log.Info("Starting stats endpoint")
 		s.wg.Add(1)
-		s.httpServer = &http.Server{Handler: s.mux} //nolint:gosec // Keep original behavior
+		s.httpServer = &http.Server{Handler: s.guard(s.mux)} //nolint:gosec // Keep original behavior
 		go func(l net.Listener) {
 			defer s.wg.Done()
 			s.log.Infof("Metrics endpoint listening on: %s (configured: %s)", l.Addr().String(), s.config.Host)
@@ -173,6 +206,12 @@ func (s *Server) SetStateInspectorRegistry(registry *statestore.Registry, name s
 	}
 }
 
+// InputControlEnabled reports whether the endpoints to control inputs at
+// runtime are enabled in config.
+func (s *Server) InputControlEnabled() bool {
+	return s.config.InputControl.Enabled
+}
+
 // Router returns the mux.Router that handles all request to the server.
 func (s *Server) Router() *http.ServeMux {
 	return s.mux
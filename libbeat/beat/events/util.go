@@ -38,10 +38,18 @@ const (
 	// FieldMetaPipeline defines the ingest node pipeline to use for this event.
 	FieldMetaPipeline = "pipeline"
 
+	// FieldMetaPipelineParameters defines the parameters to pass to the ingest node pipeline
+	// of this event. The value must be an object.
+	FieldMetaPipelineParameters = "pipeline_parameters"
+
 	// FieldMetaOpType defines the metadata key name for event operation type to use with the Elasticsearch
 	// Bulk API encoding of the event. The key's value can be an empty string, `create`, `index`, or `delete`.
 	// If empty, `create` will be used if FieldMetaID is set; otherwise `index` will be used.
 	FieldMetaOpType = "op_type"
+
+	// FieldMetaOutput defines the destination of the event within the output, like the Kafka
+	// topic. If set, it takes precedence over the destination configured in the output.
+	FieldMetaOutput = "output"
 )
 
 // GetMetaStringValue returns the value of the given event metadata string field
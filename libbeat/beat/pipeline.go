@@ -19,6 +19,7 @@ package beat
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/elastic/elastic-agent-libs/mapstr"
@@ -49,6 +50,14 @@ type Client interface {
 type ClientConfig struct {
 	PublishMode PublishMode
 
+	// Priority selects the queue lane used by the client. When the queue is
+	// full, events of high priority clients enter it before the others.
+	Priority Priority
+
+	// PublisherPipeline selects the named publisher pipeline the client
+	// publishes to. The default pipeline is used when it's empty.
+	PublisherPipeline string
+
 	Processing ProcessingConfig
 
 	// WaitClose sets the maximum duration to wait on ACK, if client still has events
@@ -173,6 +182,33 @@ const (
 	DropIfFull
 )
 
+// Priority enum sets the priority of the events of a client in the publisher
+// pipeline.
+type Priority uint8
+
+const (
+	// NormalPriority is the default priority of the events.
+	NormalPriority Priority = iota
+
+	// HighPriority events enter the queue before normal priority events when
+	// the queue is full, and can use a part of the queue reserved for them.
+	// Queues that don't support priorities handle them as normal events.
+	HighPriority
+)
+
+// Unpack sets the priority from its configuration value, "normal" or "high".
+func (p *Priority) Unpack(s string) error {
+	switch s {
+	case "", "normal":
+		*p = NormalPriority
+	case "high":
+		*p = HighPriority
+	default:
+		return fmt.Errorf("invalid priority %q, must be normal or high", s)
+	}
+	return nil
+}
+
 type CombinedClientListener struct {
 	A, B ClientListener
 }
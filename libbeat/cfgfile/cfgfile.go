@@ -146,32 +146,56 @@ func HandleFlags() error {
 
 // Load reads the configuration from a YAML file structure. If path is empty
 // this method reads from the configuration file specified by the '-c' command
-// line flag.
+// line flag. The files of the active config profiles and the matching config
+// includes are merged on top of it.
 // This function cares about the underlying fleet setting, and if beats is running with
 // the management.enabled flag, Load() will bypass reading a config file, and merely merge any overrides.
 func Load(path string, beatOverrides []ConditionalOverride) (*config.C, error) {
-	var c *config.C
-	var err error
+	return load(configFileList(path), beatOverrides, common.LoadFiles)
+}
+
+// LoadWithReplacement loads the configuration like Load, parsing the
+// replacement content instead of reading the original file. It is used to
+// preview the effective configuration before a config file is changed,
+// without writing it.
+func LoadWithReplacement(original string, replacement []byte, beatOverrides []ConditionalOverride) (*config.C, error) {
+	return load(configFileList(""), beatOverrides, func(paths ...string) (*config.C, error) {
+		return common.LoadFilesWithReplacement(original, replacement, paths...)
+	})
+}
 
+// configFileList returns the absolute paths of the config files given by
+// path, or by the '-c' command line flag if path is empty.
+func configFileList(path string) []string {
 	cfgpath := GetPathConfig()
 
-	if !management.UnderAgent() {
-		if path == "" {
-			list := []string{}
-			for _, cfg := range configfiles.List() {
-				if !filepath.IsAbs(cfg) {
-					list = append(list, filepath.Join(cfgpath, cfg))
-				} else {
-					list = append(list, cfg)
-				}
+	list := []string{}
+	if path == "" {
+		for _, cfg := range configfiles.List() {
+			if !filepath.IsAbs(cfg) {
+				list = append(list, filepath.Join(cfgpath, cfg))
+			} else {
+				list = append(list, cfg)
 			}
-			c, err = common.LoadFiles(list...)
-		} else {
-			if !filepath.IsAbs(path) {
-				path = filepath.Join(cfgpath, path)
-			}
-			c, err = common.LoadFile(path)
 		}
+	} else {
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(cfgpath, path)
+		}
+		list = append(list, path)
+	}
+	return list
+}
+
+// filesLoader loads and merges the given config files.
+type filesLoader func(paths ...string) (*config.C, error)
+
+func load(list []string, beatOverrides []ConditionalOverride, loadFiles filesLoader) (*config.C, error) {
+	var c *config.C
+	var err error
+
+	if !management.UnderAgent() {
+		c, err = loadLayered(list, GetPathConfig(), overwrites, loadFiles)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,178 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cfgfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+// Functions used to evaluate include conditions. They are variables so
+// they can be replaced in tests.
+var (
+	hostname  = os.Hostname
+	lookupEnv = os.LookupEnv
+)
+
+// defaultProfilesPath is the directory, relative to path.config, holding the
+// profile overlays.
+const defaultProfilesPath = "profiles"
+
+// layersConfig configures the config files that are layered on top of the
+// main configuration files.
+type layersConfig struct {
+	Profiles profilesConfig  `config:"config.profiles"`
+	Includes []includeConfig `config:"config.includes"`
+}
+
+// profilesConfig configures environment profiles. Each active profile is
+// loaded from <path>/<name>.yml and merged on top of the main configuration
+// in the given order.
+type profilesConfig struct {
+	Path   string   `config:"path"`
+	Active []string `config:"active"`
+}
+
+// includeConfig configures a config file that is merged on top of the main
+// configuration and profiles if its condition matches.
+type includeConfig struct {
+	Path string           `config:"path" validate:"required"`
+	When includeCondition `config:"when"`
+}
+
+// includeCondition is evaluated when the configuration is loaded. All of the
+// configured patterns must match. An empty condition always matches.
+type includeCondition struct {
+	Hostname string            `config:"hostname"`
+	Env      map[string]string `config:"env"`
+}
+
+func (c *includeCondition) Validate() error {
+	if _, err := filepath.Match(c.Hostname, ""); err != nil {
+		return fmt.Errorf("invalid hostname pattern %q: %w", c.Hostname, err)
+	}
+	for name, pattern := range c.Env {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q for environment variable %s: %w", pattern, name, err)
+		}
+	}
+	return nil
+}
+
+func (c *includeCondition) matches() (bool, error) {
+	if c.Hostname != "" {
+		host, err := hostname()
+		if err != nil {
+			return false, fmt.Errorf("failed to get hostname: %w", err)
+		}
+		if ok, _ := filepath.Match(c.Hostname, host); !ok {
+			return false, nil
+		}
+	}
+	for name, pattern := range c.Env {
+		value, found := lookupEnv(name)
+		if !found {
+			return false, nil
+		}
+		if ok, _ := filepath.Match(pattern, value); !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// loadLayered loads the given config files, followed by the files of the
+// active profiles and the includes whose conditions match. All files are
+// merged in order, the same way as multiple -c flags. Settings given by
+// overrides, like -E flags, are taken into account to select the layers.
+// Includes can be defined in the main configuration files and in profiles.
+func loadLayered(files []string, cfgpath string, overrides *config.C, loadFiles filesLoader) (*config.C, error) {
+	c, err := loadFiles(files...)
+	if err != nil {
+		return nil, err
+	}
+
+	layers, err := readLayers(c, overrides)
+	if err != nil {
+		return nil, err
+	}
+	if len(layers.Profiles.Active) > 0 {
+		dir := layers.Profiles.Path
+		if dir == "" {
+			dir = defaultProfilesPath
+		}
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(cfgpath, dir)
+		}
+		for _, name := range layers.Profiles.Active {
+			files = append(files, filepath.Join(dir, name+".yml"))
+		}
+
+		c, err = loadFiles(files...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load config profiles: %w", err)
+		}
+		if layers, err = readLayers(c, overrides); err != nil {
+			return nil, err
+		}
+	}
+
+	var included bool
+	for _, include := range layers.Includes {
+		ok, err := include.When.matches()
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		path := include.Path
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(cfgpath, path)
+		}
+		files = append(files, path)
+		included = true
+	}
+	if !included {
+		return c, nil
+	}
+
+	c, err = loadFiles(files...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config includes: %w", err)
+	}
+	return c, nil
+}
+
+func readLayers(c *config.C, overrides *config.C) (layersConfig, error) {
+	var layers layersConfig
+	merged := c
+	if overrides != nil {
+		var err error
+		if merged, err = config.MergeConfigs(c, overrides); err != nil {
+			return layers, err
+		}
+	}
+	if err := merged.Unpack(&layers); err != nil {
+		return layers, fmt.Errorf("invalid config profiles or includes: %w", err)
+	}
+	return layers, nil
+}
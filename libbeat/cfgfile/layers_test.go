@@ -0,0 +1,159 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package cfgfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestLoadLayered(t *testing.T) {
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		t.Helper()
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+
+	writeFile("beat.yml", `
+name: base
+tags: [base]
+config.profiles.active: [staging]
+config.includes:
+  - path: includes/web.yml
+    when.hostname: "web-*"
+  - path: includes/eu.yml
+    when.env.REGION: "eu-*"
+`)
+	writeFile("profiles/staging.yml", "name: staging\nlogging.level: debug\n")
+	writeFile("profiles/production.yml", "name: production\n")
+	writeFile("includes/web.yml", "web: true\n")
+	writeFile("includes/eu.yml", "region: eu\n")
+
+	stubHost := func(t *testing.T, host string, env map[string]string) {
+		origHostname, origLookupEnv := hostname, lookupEnv
+		t.Cleanup(func() { hostname, lookupEnv = origHostname, origLookupEnv })
+		hostname = func() (string, error) { return host, nil }
+		lookupEnv = func(name string) (string, bool) {
+			v, ok := env[name]
+			return v, ok
+		}
+	}
+
+	type result struct {
+		Name   string `config:"name"`
+		Level  string `config:"logging.level"`
+		Web    bool   `config:"web"`
+		Region string `config:"region"`
+	}
+
+	cases := map[string]struct {
+		host      string
+		env       map[string]string
+		overrides map[string]interface{}
+		want      result
+	}{
+		"profile only": {
+			host: "db-1",
+			want: result{Name: "staging", Level: "debug"},
+		},
+		"hostname include": {
+			host: "web-1",
+			want: result{Name: "staging", Level: "debug", Web: true},
+		},
+		"env include": {
+			host: "db-1",
+			env:  map[string]string{"REGION": "eu-west-1"},
+			want: result{Name: "staging", Level: "debug", Region: "eu"},
+		},
+		"env include does not match": {
+			host: "db-1",
+			env:  map[string]string{"REGION": "us-east-1"},
+			want: result{Name: "staging", Level: "debug"},
+		},
+		"profile from overrides": {
+			host:      "web-1",
+			overrides: map[string]interface{}{"config.profiles.active": []string{"production"}},
+			want:      result{Name: "production", Web: true},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			stubHost(t, tc.host, tc.env)
+
+			var overrides *config.C
+			if tc.overrides != nil {
+				overrides = config.MustNewConfigFrom(tc.overrides)
+			}
+
+			c, err := loadLayered([]string{filepath.Join(dir, "beat.yml")}, dir, overrides, common.LoadFiles)
+			require.NoError(t, err)
+
+			var got result
+			require.NoError(t, c.Unpack(&got))
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestLoadLayeredProfileIncludes(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "beat.yml"), []byte("config.profiles.active: [prod]\n"), 0o600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "custom"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "custom", "prod.yml"), []byte("config.includes: [{path: extra.yml}]\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "extra.yml"), []byte("extra: true\n"), 0o600))
+
+	overrides := config.MustNewConfigFrom(map[string]interface{}{"config.profiles.path": "custom"})
+	c, err := loadLayered([]string{filepath.Join(dir, "beat.yml")}, dir, overrides, common.LoadFiles)
+	require.NoError(t, err)
+
+	extra, err := c.Bool("extra", -1)
+	require.NoError(t, err)
+	assert.True(t, extra, "includes defined by profiles should be loaded")
+}
+
+func TestLoadLayeredErrors(t *testing.T) {
+	cases := map[string]string{
+		"missing profile": "config.profiles.active: [missing]\n",
+		"missing include": "config.includes: [{path: missing.yml}]\n",
+		"include path":    "config.includes: [{when.hostname: foo}]\n",
+		"bad pattern":     "config.includes: [{path: foo.yml, when.hostname: '['}]\n",
+	}
+
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "beat.yml")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+			_, err := loadLayered([]string{path}, dir, nil, common.LoadFiles)
+			assert.Error(t, err)
+		})
+	}
+}
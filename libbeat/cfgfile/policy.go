@@ -0,0 +1,274 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cfgfile
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v3"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+// PolicySchema is the JSON schema of policy files.
+//
+//go:embed policy.schema.json
+var PolicySchema []byte
+
+const (
+	policyVersion = 1
+
+	policyComment = "Managed by a policy file, changes to these settings are overwritten when the policy is applied."
+)
+
+// policyTarget describes how the inputs of a policy map to the settings of
+// a Beat.
+type policyTarget struct {
+	// inputsKey is the setting holding the inputs.
+	inputsKey string
+	// typeKey is the setting every input must have.
+	typeKey string
+}
+
+var policyTargets = map[string]policyTarget{
+	"auditbeat":  {inputsKey: "auditbeat.modules", typeKey: "module"},
+	"filebeat":   {inputsKey: "filebeat.inputs", typeKey: "type"},
+	"heartbeat":  {inputsKey: "heartbeat.monitors", typeKey: "type"},
+	"metricbeat": {inputsKey: "metricbeat.modules", typeKey: "module"},
+	"packetbeat": {inputsKey: "packetbeat.protocols", typeKey: "type"},
+	"winlogbeat": {inputsKey: "winlogbeat.event_logs", typeKey: "name"},
+}
+
+// Policy is a declarative definition of the inputs, output and global
+// processors of a Beat, applied to its main configuration file without
+// Fleet. The settings are kept as YAML nodes to preserve their order and
+// comments.
+type Policy struct {
+	Version    int         `yaml:"version"`
+	Inputs     []yaml.Node `yaml:"inputs"`
+	Outputs    yaml.Node   `yaml:"outputs"`
+	Processors []yaml.Node `yaml:"processors"`
+
+	// doc is the document the policy was parsed from, validated against
+	// PolicySchema.
+	doc yaml.Node
+}
+
+// LoadPolicy reads a policy file. Unknown settings are rejected.
+func LoadPolicy(path string) (*Policy, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read policy file: %w", err)
+	}
+	return parsePolicy(data)
+}
+
+func parsePolicy(data []byte) (*Policy, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	var p Policy
+	if err := dec.Decode(&p); err != nil {
+		return nil, fmt.Errorf("invalid policy file: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &p.doc); err != nil {
+		return nil, fmt.Errorf("invalid policy file: %w", err)
+	}
+	return &p, nil
+}
+
+// Validate checks the policy against the policy schema and the settings
+// required by the given Beat. All errors are reported.
+func (p *Policy) Validate(beatName string) error {
+	target, ok := policyTargets[beatName]
+	if !ok {
+		return fmt.Errorf("policy files are not supported by %s", beatName)
+	}
+
+	schema, err := policySchema()
+	if err != nil {
+		return err
+	}
+	doc := &p.doc
+	if doc.Kind == 0 {
+		// The policy was not parsed from a file.
+		doc = &yaml.Node{}
+		if err := doc.Encode(p); err != nil {
+			return fmt.Errorf("failed to encode the policy: %w", err)
+		}
+	}
+	errs := schema.validate("", doc)
+
+	for i := range p.Inputs {
+		input := &p.Inputs[i]
+		if input.Kind != yaml.MappingNode {
+			// Reported by the schema validation.
+			continue
+		}
+		if v := mappingValue(input, target.typeKey); v == nil || v.Kind != yaml.ScalarNode || v.Value == "" {
+			errs = append(errs, fmt.Errorf("inputs[%d] (line %d): %s is required", i, input.Line, target.typeKey))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// ApplyPolicy replaces the inputs, output and global processors of the
+// given configuration file content with the ones of a valid policy. Other
+// settings and comments are kept.
+func ApplyPolicy(beatName string, p *Policy, current []byte) ([]byte, error) {
+	target, ok := policyTargets[beatName]
+	if !ok {
+		return nil, fmt.Errorf("policy files are not supported by %s", beatName)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(current, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse the configuration file: %w", err)
+	}
+	if doc.Kind == 0 {
+		doc.Kind = yaml.DocumentNode
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, errors.New("the configuration file must contain an object")
+	}
+
+	for _, key := range []string{target.inputsKey, "output", "processors"} {
+		removeSetting(root, key)
+	}
+
+	inputs := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	for i := range p.Inputs {
+		inputs.Content = append(inputs.Content, &p.Inputs[i])
+	}
+	appendSetting(root, target.inputsKey, inputs).HeadComment = policyComment
+	appendSetting(root, "output", renderOutput(&p.Outputs))
+	if len(p.Processors) > 0 {
+		processors := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for i := range p.Processors {
+			processors.Content = append(processors.Content, &p.Processors[i])
+		}
+		appendSetting(root, "processors", processors)
+	}
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to encode the configuration file: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode the configuration file: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// renderOutput converts the single named policy output to the output
+// setting of a Beat, keyed by its type.
+func renderOutput(outputs *yaml.Node) *yaml.Node {
+	output := outputs.Content[1]
+	settings := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	var typ string
+	for i := 0; i < len(output.Content); i += 2 {
+		if output.Content[i].Value == "type" {
+			typ = output.Content[i+1].Value
+			continue
+		}
+		settings.Content = append(settings.Content, output.Content[i], output.Content[i+1])
+	}
+	rendered := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+	appendSetting(rendered, typ, settings)
+	return rendered
+}
+
+// appendSetting adds a setting to a mapping node and returns its key node.
+func appendSetting(m *yaml.Node, key string, value *yaml.Node) *yaml.Node {
+	k := &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}
+	m.Content = append(m.Content, k, value)
+	return k
+}
+
+// removeSetting removes the setting at the given dotted path from a mapping
+// node, whether it is written with dotted keys or nested objects. Objects
+// left empty are removed too.
+func removeSetting(m *yaml.Node, path string) {
+	for i := len(m.Content) - 2; i >= 0; i -= 2 {
+		key, value := m.Content[i].Value, m.Content[i+1]
+		switch {
+		case key == path || strings.HasPrefix(key, path+"."):
+			m.Content = append(m.Content[:i], m.Content[i+2:]...)
+		case strings.HasPrefix(path, key+".") && value.Kind == yaml.MappingNode:
+			removeSetting(value, strings.TrimPrefix(path, key+"."))
+			if len(value.Content) == 0 {
+				m.Content = append(m.Content[:i], m.Content[i+2:]...)
+			}
+		}
+	}
+}
+
+func mappingValue(m *yaml.Node, key string) *yaml.Node {
+	for i := 0; i < len(m.Content); i += 2 {
+		if m.Content[i].Value == key {
+			return m.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// DiffConfigs returns a unified diff of two configurations. It is empty if
+// they are equal.
+func DiffConfigs(current, next *config.C) (string, error) {
+	a, err := configYAML(current)
+	if err != nil {
+		return "", err
+	}
+	b, err := configYAML(next)
+	if err != nil {
+		return "", err
+	}
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(a),
+		B:        difflib.SplitLines(b),
+		FromFile: "current",
+		ToFile:   "policy",
+		Context:  3,
+	})
+}
+
+func configYAML(c *config.C) (string, error) {
+	var m map[string]interface{}
+	if err := c.Unpack(&m); err != nil {
+		return "", fmt.Errorf("error unpacking config: %w", err)
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(m); err != nil {
+		return "", fmt.Errorf("error converting config to YAML format: %w", err)
+	}
+	return buf.String(), enc.Close()
+}
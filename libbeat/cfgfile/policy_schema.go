@@ -0,0 +1,177 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cfgfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// schemaNode is a JSON schema, limited to the keywords used by the policy
+// schema. Unknown keywords are rejected when the schema is parsed, so the
+// schema cannot use a keyword that is silently ignored.
+type schemaNode struct {
+	Schema      string `json:"$schema"`
+	ID          string `json:"$id"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+
+	Type                 string                 `json:"type"`
+	Const                json.RawMessage        `json:"const"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	AdditionalProperties *additionalProperties  `json:"additionalProperties"`
+	MinProperties        *int                   `json:"minProperties"`
+	MaxProperties        *int                   `json:"maxProperties"`
+	Items                *schemaNode            `json:"items"`
+	MinItems             *int                   `json:"minItems"`
+	MinLength            *int                   `json:"minLength"`
+}
+
+// additionalProperties is either false, to reject the properties not
+// listed in properties, or the schema of these properties.
+type additionalProperties struct {
+	allowed bool
+	schema  *schemaNode
+}
+
+func (a *additionalProperties) UnmarshalJSON(data []byte) error {
+	var allowed bool
+	if err := json.Unmarshal(data, &allowed); err == nil {
+		a.allowed = allowed
+		return nil
+	}
+	a.allowed = true
+	return decodeSchema(data, &a.schema)
+}
+
+var policySchema = sync.OnceValues(func() (*schemaNode, error) {
+	var s *schemaNode
+	if err := decodeSchema(PolicySchema, &s); err != nil {
+		return nil, fmt.Errorf("invalid policy schema: %w", err)
+	}
+	return s, nil
+})
+
+func decodeSchema(data []byte, s **schemaNode) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	return dec.Decode(s)
+}
+
+// validate checks the YAML node against the schema and returns the errors
+// found, prefixed by the path of the invalid setting and its line.
+func (s *schemaNode) validate(path string, n *yaml.Node) []error {
+	for n.Kind == yaml.DocumentNode && len(n.Content) > 0 {
+		n = n.Content[0]
+	}
+	if n.Kind == yaml.AliasNode {
+		n = n.Alias
+	}
+	errorf := func(format string, args ...interface{}) error {
+		msg := fmt.Sprintf(format, args...)
+		if path == "" {
+			return fmt.Errorf("line %d: %s", n.Line, msg)
+		}
+		return fmt.Errorf("%s (line %d): %s", path, n.Line, msg)
+	}
+
+	if s.Const != nil {
+		var v interface{}
+		if err := n.Decode(&v); err != nil {
+			return []error{errorf("%v", err)}
+		}
+		if got, err := json.Marshal(v); err != nil || !bytes.Equal(got, s.Const) {
+			return []error{errorf("must be %s", s.Const)}
+		}
+	}
+
+	switch s.Type {
+	case "":
+	case "object":
+		if n.Kind != yaml.MappingNode {
+			return []error{errorf("must be an object")}
+		}
+		return s.validateObject(path, n, errorf)
+	case "array":
+		if n.Kind != yaml.SequenceNode {
+			return []error{errorf("must be an array")}
+		}
+		var errs []error
+		if s.MinItems != nil && len(n.Content) < *s.MinItems {
+			errs = append(errs, errorf("must have at least %d items", *s.MinItems))
+		}
+		if s.Items != nil {
+			for i, item := range n.Content {
+				errs = append(errs, s.Items.validate(fmt.Sprintf("%s[%d]", path, i), item)...)
+			}
+		}
+		return errs
+	case "string":
+		if n.Kind != yaml.ScalarNode || n.ShortTag() != "!!str" {
+			return []error{errorf("must be a string")}
+		}
+		if s.MinLength != nil && len(n.Value) < *s.MinLength {
+			return []error{errorf("must have at least %d characters", *s.MinLength)}
+		}
+	default:
+		return []error{errorf("unsupported schema type %q", s.Type)}
+	}
+	return nil
+}
+
+func (s *schemaNode) validateObject(path string, n *yaml.Node, errorf func(string, ...interface{}) error) []error {
+	var errs []error
+	count := len(n.Content) / 2
+	if s.MinProperties != nil && count < *s.MinProperties {
+		errs = append(errs, errorf("must have at least %d settings, got %d", *s.MinProperties, count))
+	}
+	if s.MaxProperties != nil && count > *s.MaxProperties {
+		errs = append(errs, errorf("must have at most %d settings, got %d", *s.MaxProperties, count))
+	}
+
+	for _, key := range s.Required {
+		if mappingValue(n, key) == nil {
+			errs = append(errs, errorf("%s is required", key))
+		}
+	}
+
+	for i := 0; i < len(n.Content); i += 2 {
+		key, value := n.Content[i].Value, n.Content[i+1]
+		settingPath := key
+		if path != "" {
+			settingPath = path + "." + key
+		}
+		if prop, ok := s.Properties[key]; ok {
+			errs = append(errs, prop.validate(settingPath, value)...)
+			continue
+		}
+		switch {
+		case s.AdditionalProperties == nil:
+		case !s.AdditionalProperties.allowed:
+			errs = append(errs, errorf("unknown setting %s", key))
+		case s.AdditionalProperties.schema != nil:
+			errs = append(errs, s.AdditionalProperties.schema.validate(settingPath, value)...)
+		}
+	}
+	return errs
+}
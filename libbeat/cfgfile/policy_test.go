@@ -0,0 +1,181 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package cfgfile
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+const testPolicy = `
+version: 1
+inputs:
+  # Web server logs
+  - type: filestream
+    id: nginx
+    paths: [/var/log/nginx/*.log]
+outputs:
+  default:
+    type: elasticsearch
+    hosts: ["https://es:9200"]
+    api_key: "${ES_API_KEY}"
+processors:
+  - add_host_metadata: ~
+`
+
+func TestPolicySchemaIsJSON(t *testing.T) {
+	var schema map[string]interface{}
+	require.NoError(t, json.Unmarshal(PolicySchema, &schema))
+	assert.Equal(t, "Beats policy", schema["title"])
+
+	// All the keywords of the schema are supported by the validation.
+	_, err := policySchema()
+	require.NoError(t, err)
+}
+
+func TestPolicyValidate(t *testing.T) {
+	p, err := parsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	assert.NoError(t, p.Validate("filebeat"))
+	assert.ErrorContains(t, p.Validate("metricbeat"), "inputs[0] (line 5): module is required")
+	assert.ErrorContains(t, p.Validate("otherbeat"), "not supported")
+
+	p, err = parsePolicy([]byte(`
+version: 2
+inputs:
+  - paths: [/var/log/*.log]
+  - plain
+outputs:
+  es:
+    type: elasticsearch
+  ls:
+    type: logstash
+processors:
+  - add_host_metadata: ~
+    drop_event: ~
+`))
+	require.NoError(t, err)
+	err = p.Validate("filebeat")
+	require.Error(t, err)
+	for _, msg := range []string{
+		"version (line 2): must be 1",
+		"inputs[0] (line 4): type is required",
+		"inputs[1] (line 5): must be an object",
+		"outputs (line 7): must have at most 1 settings, got 2",
+		"processors[0] (line 12): must have at most 1 settings, got 2",
+	} {
+		assert.ErrorContains(t, err, msg)
+	}
+
+	p, err = parsePolicy([]byte(`version: 1
+inputs: []
+`))
+	require.NoError(t, err)
+	err = p.Validate("filebeat")
+	require.Error(t, err)
+	for _, msg := range []string{
+		"line 1: outputs is required",
+		"inputs (line 2): must have at least 1 items",
+	} {
+		assert.ErrorContains(t, err, msg)
+	}
+
+	_, err = parsePolicy([]byte("version: 1\nfilebeat.inputs: []\n"))
+	assert.ErrorContains(t, err, "not found")
+}
+
+func TestApplyPolicy(t *testing.T) {
+	p, err := parsePolicy([]byte(testPolicy))
+	require.NoError(t, err)
+	require.NoError(t, p.Validate("filebeat"))
+
+	current := `# Filebeat configuration
+name: web-01
+filebeat:
+  inputs:
+    - type: log
+  config.modules.path: modules.d/*.yml
+output.logstash:
+  hosts: ["ls:5044"]
+processors:
+  - drop_fields:
+      fields: [agent]
+logging.level: info
+`
+	out, err := ApplyPolicy("filebeat", p, []byte(current))
+	require.NoError(t, err)
+	assert.Equal(t, `# Filebeat configuration
+name: web-01
+filebeat:
+  config.modules.path: modules.d/*.yml
+logging.level: info
+# `+policyComment+`
+filebeat.inputs:
+  # Web server logs
+  - type: filestream
+    id: nginx
+    paths: [/var/log/nginx/*.log]
+output:
+  elasticsearch:
+    hosts: ["https://es:9200"]
+    api_key: "${ES_API_KEY}"
+processors:
+  - add_host_metadata: ~
+`, string(out))
+
+	// Applying the policy again does not change the file.
+	again, err := ApplyPolicy("filebeat", p, out)
+	require.NoError(t, err)
+	assert.Equal(t, string(out), string(again))
+
+	empty, err := ApplyPolicy("filebeat", p, nil)
+	require.NoError(t, err)
+	c, err := config.NewConfigWithYAML(empty, "")
+	require.NoError(t, err)
+	hosts, err := c.Child("output.elasticsearch", -1)
+	require.NoError(t, err)
+	assert.True(t, hosts.HasField("hosts"))
+}
+
+func TestDiffConfigs(t *testing.T) {
+	current := config.MustNewConfigFrom(map[string]interface{}{
+		"name":                  "web-01",
+		"output.logstash.hosts": []string{"ls:5044"},
+	})
+	next := config.MustNewConfigFrom(map[string]interface{}{
+		"name":                       "web-01",
+		"output.elasticsearch.hosts": []string{"es:9200"},
+	})
+
+	diff, err := DiffConfigs(current, next)
+	require.NoError(t, err)
+	assert.Contains(t, diff, "--- current\n+++ policy\n")
+	assert.Contains(t, diff, "-  logstash:\n")
+	assert.Contains(t, diff, "+  elasticsearch:\n")
+
+	diff, err = DiffConfigs(current, current)
+	require.NoError(t, err)
+	assert.Empty(t, diff)
+}
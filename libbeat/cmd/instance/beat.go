@@ -65,6 +65,8 @@ import (
 	"github.com/elastic/beats/v7/libbeat/publisher/pipeline"
 	"github.com/elastic/beats/v7/libbeat/publisher/processing"
 	"github.com/elastic/beats/v7/libbeat/publisher/queue/diskqueue"
+	"github.com/elastic/beats/v7/libbeat/selfupdate"
+	"github.com/elastic/beats/v7/libbeat/service"
 	"github.com/elastic/beats/v7/libbeat/version"
 	"github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/file"
@@ -114,8 +116,10 @@ type beatConfig struct {
 	MaxProcs  int    `config:"max_procs"`
 	GCPercent int    `config:"gc_percent"`
 
-	Seccomp  *config.C `config:"seccomp"`
-	Features *config.C `config:"features"`
+	Seccomp    *config.C `config:"seccomp"`
+	Features   *config.C `config:"features"`
+	Service    *config.C `config:"service"`
+	SelfUpdate *config.C `config:"self_update"`
 
 	// beat internal components configurations
 	HTTP            *config.C              `config:"http"`
@@ -396,9 +400,20 @@ func (b *Beat) createBeater(bt beat.Creator) (beat.Beater, error) {
 		return nil, fmt.Errorf("error initializing publisher: %w", err)
 	}
 
-	b.Registry.MustRegisterOutput(b.MakeOutputReloader(publisher.OutputReloader()))
-
 	b.Publisher = publisher
+	outputReloader := publisher.OutputReloader()
+	if len(b.Config.Pipeline.Pipelines) > 0 {
+		named, err := pipeline.LoadNamed(b.Info, monitors, b.Config.Pipeline.Pipelines, b.makeNamedOutputFactory, settings)
+		if err != nil {
+			_ = publisher.Disconnect(context.Background())
+			return nil, fmt.Errorf("error initializing publisher pipelines: %w", err)
+		}
+		group := pipeline.NewGroup(publisher, named, b.Config.Pipeline.Pipelines)
+		log.Infof("Publisher pipelines: %v", group.Names())
+		b.Publisher = group
+		outputReloader = group.OutputReloader()
+	}
+	b.Registry.MustRegisterOutput(b.MakeOutputReloader(outputReloader))
 	beater, err := bt(&b.Beat, sub)
 	if err != nil {
 		return nil, err
@@ -424,7 +439,7 @@ func (b *Beat) launch(settings Settings, bt beat.Creator) error {
 	// After this is run, a Beat service is considered by the OS to be stopped
 	// and another instance of the process can be started.
 	// This must be the first deferred cleanup task (last to execute).
-	defer svc.NotifyTermination()
+	defer service.NotifyTermination()
 
 	// Try to acquire exclusive lock on data path to prevent another beat instance
 	// sharing same data path. This is disabled under elastic-agent.
@@ -441,6 +456,29 @@ func (b *Beat) launch(settings Settings, bt beat.Creator) error {
 		logger.Info("running under elastic-agent, per-beat lockfiles disabled")
 	}
 
+	// Updates are applied while holding the lock, so that no other instance
+	// runs from the same data path.
+	updater, restart, err := b.startSelfUpdate()
+	if err != nil {
+		return err
+	}
+	if restart {
+		if err := b.reexec(); err != nil {
+			return fmt.Errorf("could not restart %s: %w", b.Info.Beat, err)
+		}
+		// Restarting in place isn't supported on this platform, exit so
+		// that the service manager starts the new executable.
+		return fmt.Errorf("%s must be restarted to complete the self-update", b.Info.Beat)
+	}
+	if updater != nil {
+		healthy := time.AfterFunc(updater.HealthyAfter(), func() {
+			if err := updater.Confirm(); err != nil {
+				logger.Errorf("Failed to commit the self-update: %v", err)
+			}
+		})
+		defer healthy.Stop()
+	}
+
 	svc.BeforeRun()
 	defer svc.Cleanup()
 
@@ -521,16 +559,32 @@ func (b *Beat) launch(settings Settings, bt beat.Creator) error {
 	b.Manager.SetStopCallback(
 		func() {
 			stopOnce.Do(func() {
+				service.NotifyStopping()
 				b.Instrumentation.Tracer().Close()
-				// disconnect the pipeline first
-				b.Publisher.Disconnect(context.Background())
+				// disconnect the pipeline first, flushing pending events
+				// within the OS shutdown deadline if any.
+				ctx, cancel := service.ShutdownContext()
+				defer cancel()
+				b.Publisher.Disconnect(ctx)
 				beater.Stop()
 			})
 		})
 
+	serviceConfig := service.DefaultConfig()
+	if b.Config.Service != nil {
+		if err := b.Config.Service.Unpack(&serviceConfig); err != nil {
+			return fmt.Errorf("error unpacking service config: %w", err)
+		}
+	}
+	service.Configure(serviceConfig)
+
 	// Besides a manager-initiated shutdown from Agent config state,
 	// we stop the manager explicitly on SIGINT / SIGHUP / etc.
-	svc.HandleSignals(b.Manager.Stop, cancelDashboards)
+	service.HandleSignals(logger, b.Manager.Stop, cancelDashboards)
+
+	// Tell systemd the Beat is ready before the optional setup steps, a slow
+	// Kibana must not exceed the start timeout of the unit.
+	service.NotifyReady(logger, b.alive)
 
 	err = b.loadDashboards(ctxDashboards, false)
 	if err != nil {
@@ -549,6 +603,32 @@ func (b *Beat) launch(settings Settings, bt beat.Creator) error {
 	return err
 }
 
+// startSelfUpdate applies or rolls back the update staged for a Beat that
+// is not managed by Elastic Agent. It returns true if the Beat must be
+// restarted to run the new executable.
+func (b *Beat) startSelfUpdate() (*selfupdate.Updater, bool, error) {
+	if management.UnderAgent() || b.Config.SelfUpdate == nil {
+		return nil, false, nil
+	}
+	cfg := selfupdate.DefaultConfig()
+	if err := b.Config.SelfUpdate.Unpack(&cfg); err != nil {
+		return nil, false, fmt.Errorf("error unpacking self_update config: %w", err)
+	}
+	if !cfg.Enabled {
+		return nil, false, nil
+	}
+
+	updater, err := selfupdate.New(b.Info.Logger.Named("self_update"), cfg, b.Info, paths.Resolve(paths.Data, ""))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to initialize self-update: %w", err)
+	}
+	action, err := updater.Start()
+	if err != nil {
+		return nil, false, err
+	}
+	return updater, action == selfupdate.Restart, nil
+}
+
 // reexec restarts the Beat, it calls the OS-specific implementation.
 func (b *Beat) reexec() error {
 	return b.doReexec()
@@ -1033,6 +1113,14 @@ func openRegular(filename string) (*os.File, error) {
 	return f, nil
 }
 
+// alive is the liveness check of the systemd watchdog. Collecting the stats
+// runs the callbacks of the components, which take their locks, so it hangs
+// if the Beat is deadlocked.
+func (b *Beat) alive(context.Context) error {
+	monitoring.CollectStructSnapshot(b.Monitoring.StatsRegistry(), monitoring.Full, false)
+	return nil
+}
+
 func (b *Beat) loadDashboards(ctx context.Context, force bool) error {
 	if force {
 		// force implies dashboards.enabled=true
@@ -1179,6 +1267,17 @@ func (b *Beat) MakeOutputFactory(
 	}
 }
 
+// makeNamedOutputFactory returns the output factory of a named publisher
+// pipeline. Pipelines without their own output use the output of the Beat.
+func (b *Beat) makeNamedOutputFactory(
+	cfg config.Namespace,
+) func(outputs.Observer) (string, outputs.Group, error) {
+	if !cfg.IsSet() {
+		cfg = b.Config.Output
+	}
+	return b.MakeOutputFactory(cfg)
+}
+
 func (b *Beat) reloadOutputOnCertChange(cfg config.Namespace) error {
 	logger := b.Info.Logger.Named("ssl.cert.reloader")
 	// Here the output is created and we have access to the Beat struct (with the manager)
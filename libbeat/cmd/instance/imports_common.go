@@ -29,6 +29,7 @@ import (
 	_ "github.com/elastic/beats/v7/libbeat/processors/add_locale"
 	_ "github.com/elastic/beats/v7/libbeat/processors/add_observer_metadata"
 	_ "github.com/elastic/beats/v7/libbeat/processors/add_process_metadata"
+	_ "github.com/elastic/beats/v7/libbeat/processors/aggregate"
 	_ "github.com/elastic/beats/v7/libbeat/processors/communityid"
 	_ "github.com/elastic/beats/v7/libbeat/processors/convert"
 	_ "github.com/elastic/beats/v7/libbeat/processors/decode_duration"
@@ -38,12 +39,18 @@ import (
 	_ "github.com/elastic/beats/v7/libbeat/processors/dns"
 	_ "github.com/elastic/beats/v7/libbeat/processors/extract_array"
 	_ "github.com/elastic/beats/v7/libbeat/processors/fingerprint"
+	_ "github.com/elastic/beats/v7/libbeat/processors/geoip"
+	_ "github.com/elastic/beats/v7/libbeat/processors/math"
 	_ "github.com/elastic/beats/v7/libbeat/processors/move_fields"
 	_ "github.com/elastic/beats/v7/libbeat/processors/now"
+	_ "github.com/elastic/beats/v7/libbeat/processors/pseudonymize"
 	_ "github.com/elastic/beats/v7/libbeat/processors/ratelimit"
 	_ "github.com/elastic/beats/v7/libbeat/processors/registered_domain"
 	_ "github.com/elastic/beats/v7/libbeat/processors/script"
+	_ "github.com/elastic/beats/v7/libbeat/processors/sign_event"
+	_ "github.com/elastic/beats/v7/libbeat/processors/split"
 	_ "github.com/elastic/beats/v7/libbeat/processors/syslog"
+	_ "github.com/elastic/beats/v7/libbeat/processors/translate"
 	_ "github.com/elastic/beats/v7/libbeat/processors/translate_ldap_attribute"
 	_ "github.com/elastic/beats/v7/libbeat/processors/translate_sid"
 	_ "github.com/elastic/beats/v7/libbeat/processors/urldecode"
@@ -0,0 +1,163 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/v7/libbeat/cfgfile"
+	"github.com/elastic/beats/v7/libbeat/cmd/instance"
+	"github.com/elastic/beats/v7/libbeat/common/cli"
+	"github.com/elastic/elastic-agent-libs/file"
+)
+
+func genPolicyCmd(settings instance.Settings) *cobra.Command {
+	policyCmd := cobra.Command{
+		Use:   "policy",
+		Short: "Validate and apply policy files",
+	}
+
+	policyCmd.AddCommand(genValidatePolicyCmd(settings))
+	policyCmd.AddCommand(genApplyPolicyCmd(settings))
+	policyCmd.AddCommand(genPolicySchemaCmd())
+
+	return &policyCmd
+}
+
+func genValidatePolicyCmd(settings instance.Settings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate POLICY_FILE",
+		Short: "Validate a policy file",
+		Args:  cobra.ExactArgs(1),
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			if _, err := loadPolicy(settings, args[0]); err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout(), "Policy OK")
+			return nil
+		}),
+	}
+}
+
+func genApplyPolicyCmd(settings instance.Settings) *cobra.Command {
+	var dryRun bool
+	applyCmd := cobra.Command{
+		Use:   "apply POLICY_FILE",
+		Short: "Apply a policy file to the configuration file",
+		Long: "Replace the inputs, output and global processors of the configuration file " +
+			"with the ones of the policy file. The changes to the effective configuration are shown " +
+			"as a diff, and the configuration file is replaced atomically. " +
+			"The Beat must be restarted to use the new configuration.",
+		Args: cobra.ExactArgs(1),
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			return applyPolicy(cmd, settings, args[0], dryRun)
+		}),
+	}
+	applyCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Show the changes without applying them")
+	return &applyCmd
+}
+
+func genPolicySchemaCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "schema",
+		Short: "Print the JSON schema of policy files",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			_, err := cmd.OutOrStdout().Write(cfgfile.PolicySchema)
+			return err
+		}),
+	}
+}
+
+func loadPolicy(settings instance.Settings, path string) (*cfgfile.Policy, error) {
+	p, err := cfgfile.LoadPolicy(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.Validate(settings.Name); err != nil {
+		return nil, fmt.Errorf("invalid policy file %s:\n%w", path, err)
+	}
+	return p, nil
+}
+
+func applyPolicy(cmd *cobra.Command, settings instance.Settings, path string, dryRun bool) error {
+	p, err := loadPolicy(settings, path)
+	if err != nil {
+		return err
+	}
+
+	// Keep variables and keystore references unresolved in the diff.
+	settings.DisableConfigResolver = true
+	b, err := instance.NewInitializedBeat(settings)
+	if err != nil {
+		return fmt.Errorf("error initializing beat: %w", err)
+	}
+
+	target := cfgfile.GetDefaultCfgfile()
+	mode := os.FileMode(0o600)
+	current, err := os.ReadFile(target)
+	switch {
+	case err == nil:
+		if info, err := os.Stat(target); err == nil {
+			mode = info.Mode().Perm()
+		}
+	case errors.Is(err, os.ErrNotExist):
+	default:
+		return fmt.Errorf("failed to read the configuration file: %w", err)
+	}
+
+	next, err := cfgfile.ApplyPolicy(settings.Name, p, current)
+	if err != nil {
+		return err
+	}
+
+	nextCfg, err := cfgfile.LoadWithReplacement(target, next, settings.ConfigOverrides)
+	if err != nil {
+		return fmt.Errorf("failed to load the configuration with the policy: %w", err)
+	}
+	diff, err := cfgfile.DiffConfigs(b.RawConfig, nextCfg)
+	if err != nil {
+		return err
+	}
+
+	out := cmd.OutOrStdout()
+	if diff == "" {
+		fmt.Fprintln(out, "No changes to the effective configuration.")
+	} else {
+		fmt.Fprint(out, diff)
+	}
+	if dryRun {
+		return nil
+	}
+
+	// The new file is written next to the configuration file, so that it
+	// replaces the configuration file atomically.
+	tmp := target + ".new"
+	if err := os.WriteFile(tmp, next, mode); err != nil {
+		return fmt.Errorf("failed to write the configuration file: %w", err)
+	}
+	if err := file.SafeFileRotate(target, tmp); err != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("failed to replace the configuration file: %w", err)
+	}
+	fmt.Fprintf(out, "Applied policy %s to %s. Restart %s to use the new configuration.\n", path, target, settings.Name)
+	return nil
+}
@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/elastic/beats/v7/libbeat/cmd/instance"
+	"github.com/elastic/beats/v7/libbeat/common/cli"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue/diskqueue"
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func genQueueCmd(settings instance.Settings) *cobra.Command {
+	queueCmd := cobra.Command{
+		Use:   "queue",
+		Short: "Manage the disk queue",
+	}
+
+	queueCmd.AddCommand(genRekeyQueueCmd(settings))
+
+	return &queueCmd
+}
+
+func genRekeyQueueCmd(settings instance.Settings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rekey",
+		Short: "Re-encrypt the disk queue with the active encryption key",
+		Long: "Re-encrypt the segments of the disk queue with the active encryption key, " +
+			"or decrypt them if no key is active, so that previous keys can be removed. " +
+			"The Beat must be stopped while the queue is rekeyed.",
+		Run: cli.RunWith(func(cmd *cobra.Command, args []string) error {
+			b, err := instance.NewInitializedBeat(settings)
+			if err != nil {
+				return fmt.Errorf("error initializing beat: %w", err)
+			}
+			queueSettings, err := diskQueueSettings(b.RawConfig)
+			if err != nil {
+				return err
+			}
+			stats, err := diskqueue.Rekey(b.Info.Logger.Named("diskqueue"), queueSettings, b.Info.Paths)
+			if err != nil {
+				return fmt.Errorf("error rekeying the disk queue: %w", err)
+			}
+			fmt.Fprintf(cmd.OutOrStdout(), "Rekeyed %d segments, %d already used the active key, %d skipped.\n",
+				stats.Rekeyed, stats.Current, stats.Skipped)
+			return nil
+		}),
+	}
+}
+
+// diskQueueSettings returns the settings of the disk queue configured
+// globally or in the output.
+func diskQueueSettings(cfg *config.C) (diskqueue.Settings, error) {
+	var beatCfg struct {
+		Queue  config.Namespace `config:"queue"`
+		Output config.Namespace `config:"output"`
+	}
+	if err := cfg.Unpack(&beatCfg); err != nil {
+		return diskqueue.Settings{}, err
+	}
+
+	queue := beatCfg.Queue
+	if !queue.IsSet() && beatCfg.Output.IsSet() {
+		var outputCfg struct {
+			Queue config.Namespace `config:"queue"`
+		}
+		if err := beatCfg.Output.Config().Unpack(&outputCfg); err != nil {
+			return diskqueue.Settings{}, err
+		}
+		queue = outputCfg.Queue
+	}
+	if !queue.IsSet() || queue.Name() != diskqueue.QueueType {
+		return diskqueue.Settings{}, errors.New("no disk queue is configured")
+	}
+	return diskqueue.SettingsForUserConfig(queue.Config())
+}
@@ -41,6 +41,8 @@ type BeatsRootCmd struct {
 	ExportCmd     *cobra.Command
 	TestCmd       *cobra.Command
 	KeystoreCmd   *cobra.Command
+	QueueCmd      *cobra.Command
+	PolicyCmd     *cobra.Command
 }
 
 // GenRootCmdWithSettings returns the root command to use for your beat. It take the
@@ -72,6 +74,8 @@ func GenRootCmdWithSettings(beatCreator beat.Creator, settings instance.Settings
 	rootCmd.TestCmd = genTestCmd(settings, beatCreator)
 	rootCmd.SetupCmd = genSetupCmd(settings, beatCreator)
 	rootCmd.KeystoreCmd = genKeystoreCmd(settings)
+	rootCmd.QueueCmd = genQueueCmd(settings)
+	rootCmd.PolicyCmd = genPolicyCmd(settings)
 	rootCmd.VersionCmd = GenVersionCmd(settings)
 	rootCmd.CompletionCmd = genCompletionCmd(settings, rootCmd)
 
@@ -108,6 +112,8 @@ func GenRootCmdWithSettings(beatCreator beat.Creator, settings instance.Settings
 	if rootCmd.KeystoreCmd != nil {
 		rootCmd.AddCommand(rootCmd.KeystoreCmd)
 	}
+	rootCmd.AddCommand(rootCmd.QueueCmd)
+	rootCmd.AddCommand(rootCmd.PolicyCmd)
 
 	return rootCmd
 }
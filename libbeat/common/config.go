@@ -94,9 +94,29 @@ func LoadFile(path string) (*config.C, error) {
 }
 
 func LoadFiles(paths ...string) (*config.C, error) {
+	return loadFiles(LoadFile, paths)
+}
+
+// LoadFilesWithReplacement loads the files like LoadFiles, parsing content
+// instead of reading the file at path replaced. It is used to check a
+// configuration before it is written.
+func LoadFilesWithReplacement(replaced string, content []byte, paths ...string) (*config.C, error) {
+	return loadFiles(func(path string) (*config.C, error) {
+		if path != replaced {
+			return LoadFile(path)
+		}
+		c, err := yaml.NewConfig(content, configOpts...)
+		if err != nil {
+			return nil, err
+		}
+		return fromConfig(c), nil
+	}, paths)
+}
+
+func loadFiles(load func(string) (*config.C, error), paths []string) (*config.C, error) {
 	merger := cfgutil.NewCollector(nil, configOpts...)
 	for _, path := range paths {
-		cfg, err := LoadFile(path)
+		cfg, err := load(path)
 		if err := merger.Add(access(cfg), err); err != nil {
 			return nil, err
 		}
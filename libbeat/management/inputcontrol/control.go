@@ -0,0 +1,385 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package inputcontrol allows operators to start, stop, pause and resume
+// individual inputs at runtime, without changing the configuration or
+// restarting the Beat. Inputs are controlled through the local HTTP API or
+// through Fleet actions.
+package inputcontrol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/cfgfile"
+	"github.com/elastic/beats/v7/libbeat/common/diagnostics"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/libbeat/publisher/pipetool"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// State is the runtime state of a controlled input.
+type State string
+
+const (
+	StateRunning State = "running"
+	StatePaused  State = "paused"
+	StateStopped State = "stopped"
+)
+
+// Action is an operation that can be applied to a controlled input.
+type Action string
+
+const (
+	ActionStart  Action = "start"
+	ActionStop   Action = "stop"
+	ActionPause  Action = "pause"
+	ActionResume Action = "resume"
+)
+
+// DefaultDrainTimeout is the time a stop action waits for the events
+// published by an input to be acknowledged, if no timeout is given.
+const DefaultDrainTimeout = 30 * time.Second
+
+var (
+	// ErrNotFound is returned if no controlled input has the requested ID.
+	ErrNotFound = errors.New("input not found")
+
+	// ErrInvalidAction is returned for unknown actions.
+	ErrInvalidAction = errors.New("invalid action")
+
+	// ErrInvalidState is returned if an action can not be applied to the
+	// current state of the input.
+	ErrInvalidState = errors.New("invalid input state")
+)
+
+// Status reports the state of a controlled input.
+type Status struct {
+	ID            string `json:"id"`
+	State         State  `json:"state"`
+	PendingEvents int    `json:"pending_events"`
+
+	// Drained is only set by stop actions. It reports whether all events
+	// published by the input were acknowledged before the drain timeout.
+	Drained *bool `json:"drained,omitempty"`
+}
+
+// Registry keeps track of the running inputs that can be controlled at
+// runtime. Only inputs with an `id` setting are registered.
+type Registry struct {
+	log *logp.Logger
+
+	mu      sync.Mutex
+	runners map[string]*runner
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry(log *logp.Logger) *Registry {
+	return &Registry{
+		log:     log.Named("inputcontrol"),
+		runners: map[string]*runner{},
+	}
+}
+
+// Factory wraps a RunnerFactory, so that the runners it creates for inputs
+// with an ID are registered with the registry while they are running.
+func (r *Registry) Factory(f cfgfile.RunnerFactory) cfgfile.RunnerFactory {
+	return &factory{registry: r, factory: f}
+}
+
+// List returns the status of all controlled inputs, sorted by ID.
+func (r *Registry) List() []Status {
+	r.mu.Lock()
+	runners := make([]*runner, 0, len(r.runners))
+	for _, run := range r.runners {
+		runners = append(runners, run)
+	}
+	r.mu.Unlock()
+
+	list := make([]Status, len(runners))
+	for i, run := range runners {
+		list[i] = run.status()
+	}
+	slices.SortFunc(list, func(a, b Status) int { return strings.Compare(a.ID, b.ID) })
+	return list
+}
+
+// Get returns the status of the input with the given ID.
+func (r *Registry) Get(id string) (Status, error) {
+	run, err := r.lookup(id)
+	if err != nil {
+		return Status{}, err
+	}
+	return run.status(), nil
+}
+
+// Do applies action to the input with the given ID. Stop actions wait up to
+// drainTimeout for the events published by the input to be acknowledged.
+func (r *Registry) Do(ctx context.Context, id string, action Action, drainTimeout time.Duration) (Status, error) {
+	run, err := r.lookup(id)
+	if err != nil {
+		return Status{}, err
+	}
+
+	switch action {
+	case ActionStart:
+		err = run.start()
+	case ActionPause:
+		err = run.pause()
+	case ActionResume:
+		err = run.resume()
+	case ActionStop:
+		run.stop()
+		ctx, cancel := context.WithTimeout(ctx, drainTimeout)
+		defer cancel()
+		drained := run.pending.wait(ctx)
+		r.log.Infow("Applied stop action to input", "id", id, "drained", drained)
+		st := run.status()
+		st.Drained = &drained
+		return st, nil
+	default:
+		return Status{}, fmt.Errorf("%w: %q", ErrInvalidAction, action)
+	}
+	if err != nil {
+		return Status{}, err
+	}
+	r.log.Infow(fmt.Sprintf("Applied %s action to input", action), "id", id)
+	return run.status(), nil
+}
+
+func (r *Registry) lookup(id string) (*runner, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	run, ok := r.runners[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNotFound, id)
+	}
+	return run, nil
+}
+
+func (r *Registry) add(run *runner) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, exists := r.runners[run.id]; exists {
+		return false
+	}
+	r.runners[run.id] = run
+	return true
+}
+
+func (r *Registry) remove(run *runner) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.runners[run.id] == run {
+		delete(r.runners, run.id)
+	}
+}
+
+type factory struct {
+	registry *Registry
+	factory  cfgfile.RunnerFactory
+}
+
+func (f *factory) CheckConfig(cfg *conf.C) error {
+	return f.factory.CheckConfig(cfg)
+}
+
+func (f *factory) Create(p beat.PipelineConnector, cfg *conf.C) (cfgfile.Runner, error) {
+	id, err := cfg.String("id", -1)
+	if err != nil || id == "" {
+		return f.factory.Create(p, cfg)
+	}
+
+	// Keep a copy of the configuration, so the input can be recreated after
+	// it has been stopped.
+	config, err := conf.NewConfigFrom(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy input configuration: %w", err)
+	}
+
+	run := &runner{
+		id:       id,
+		log:      f.registry.log.With("id", id),
+		registry: f.registry,
+		factory:  f.factory,
+		config:   config,
+		gate:     &gate{},
+		pending:  &pendingEvents{},
+	}
+	run.state.Store(StateRunning)
+	run.connector = pipetool.WithACKer(pipetool.WithClientWrapper(p, run.gate.wrap), run.pending)
+
+	inner, err := f.factory.Create(run.connector, cfg)
+	if err != nil {
+		return inner, err
+	}
+	run.inner = inner
+	run.name = inner.String()
+	return run, nil
+}
+
+// runner wraps the runner of an input, so that it can be stopped and
+// restarted by operators independently of the owner of the runner (the
+// crawler, config reloading, autodiscover or the Elastic Agent).
+type runner struct {
+	id        string
+	name      string
+	log       *logp.Logger
+	registry  *Registry
+	factory   cfgfile.RunnerFactory
+	config    *conf.C
+	connector beat.PipelineConnector
+	gate      *gate
+	pending   *pendingEvents
+	state     atomic.Value // State, readable while a transition is in progress
+
+	mu             sync.Mutex
+	inner          cfgfile.Runner // nil if the input has been stopped
+	registered     bool
+	statusReporter status.StatusReporter
+}
+
+func (r *runner) String() string { return r.name }
+
+// Start is called by the owner of the runner.
+func (r *runner) Start() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.registered = r.registry.add(r)
+	if !r.registered {
+		r.log.Warn("Another input with the same ID is already registered, the input can not be controlled at runtime")
+	}
+	if r.inner != nil {
+		r.inner.Start()
+	}
+}
+
+// Stop is called by the owner of the runner.
+func (r *runner) Stop() {
+	r.registry.remove(r)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.registered = false
+	r.stopInner()
+}
+
+func (r *runner) SetStatusReporter(reporter status.StatusReporter) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.statusReporter = reporter
+	if withStatus, ok := r.inner.(status.WithStatusReporter); ok {
+		withStatus.SetStatusReporter(reporter)
+	}
+}
+
+func (r *runner) Diagnostics() []diagnostics.DiagnosticSetup {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if diag, ok := r.inner.(diagnostics.DiagnosticReporter); ok {
+		return diag.Diagnostics()
+	}
+	return nil
+}
+
+func (r *runner) start() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.registered {
+		return fmt.Errorf("%w: input is shutting down", ErrInvalidState)
+	}
+	if r.inner != nil {
+		r.gate.resume()
+		r.state.Store(StateRunning)
+		return nil
+	}
+
+	cfg, err := conf.NewConfigFrom(r.config)
+	if err != nil {
+		return fmt.Errorf("failed to copy input configuration: %w", err)
+	}
+	inner, err := r.factory.Create(r.connector, cfg)
+	if err != nil {
+		return fmt.Errorf("failed to create input: %w", err)
+	}
+	if withStatus, ok := inner.(status.WithStatusReporter); ok && r.statusReporter != nil {
+		withStatus.SetStatusReporter(r.statusReporter)
+	}
+	inner.Start()
+	r.inner = inner
+	r.state.Store(StateRunning)
+	return nil
+}
+
+func (r *runner) stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stopInner()
+}
+
+func (r *runner) stopInner() {
+	if r.inner == nil {
+		return
+	}
+	// Release paused publishers, otherwise the input can not shut down.
+	r.gate.resume()
+	r.inner.Stop()
+	r.inner = nil
+	r.state.Store(StateStopped)
+}
+
+func (r *runner) pause() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.inner == nil {
+		return fmt.Errorf("%w: input is stopped", ErrInvalidState)
+	}
+	r.gate.pause()
+	r.state.Store(StatePaused)
+	return nil
+}
+
+func (r *runner) resume() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.inner == nil {
+		return fmt.Errorf("%w: input is stopped", ErrInvalidState)
+	}
+	r.gate.resume()
+	r.state.Store(StateRunning)
+	return nil
+}
+
+func (r *runner) status() Status {
+	return Status{ID: r.id, State: r.state.Load().(State), PendingEvents: r.pending.count()}
+}
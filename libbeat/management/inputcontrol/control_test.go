@@ -0,0 +1,293 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package inputcontrol
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/cfgfile"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestRegistryActions(t *testing.T) {
+	registry := NewRegistry(logptest.NewTestingLogger(t, ""))
+	inputs := &testFactory{}
+	factory := registry.Factory(inputs)
+	pipeline := &testPipeline{}
+
+	run, err := factory.Create(pipeline, conf.MustNewConfigFrom(mapstr.M{"id": "my-input"}))
+	require.NoError(t, err)
+	run.Start()
+
+	require.Len(t, inputs.runners, 1, "expected one input to be created")
+	assert.True(t, inputs.runners[0].running.Load(), "input should be running")
+	assertState(t, registry, "my-input", StateRunning)
+
+	ctx := context.Background()
+	_, err = registry.Do(ctx, "my-input", ActionPause, 0)
+	require.NoError(t, err)
+	assertState(t, registry, "my-input", StatePaused)
+
+	_, err = registry.Do(ctx, "my-input", ActionResume, 0)
+	require.NoError(t, err)
+	assertState(t, registry, "my-input", StateRunning)
+
+	st, err := registry.Do(ctx, "my-input", ActionStop, time.Second)
+	require.NoError(t, err)
+	require.NotNil(t, st.Drained, "stop should report whether events were drained")
+	assert.True(t, *st.Drained, "input without events should be drained")
+	assert.Equal(t, StateStopped, st.State)
+	assert.False(t, inputs.runners[0].running.Load(), "input should be stopped")
+
+	_, err = registry.Do(ctx, "my-input", ActionPause, 0)
+	assert.ErrorIs(t, err, ErrInvalidState, "stopped inputs can not be paused")
+
+	_, err = registry.Do(ctx, "my-input", ActionStart, 0)
+	require.NoError(t, err)
+	assertState(t, registry, "my-input", StateRunning)
+	require.Len(t, inputs.runners, 2, "expected the input to be recreated")
+	assert.True(t, inputs.runners[1].running.Load(), "recreated input should be running")
+
+	_, err = registry.Do(ctx, "my-input", Action("restart"), 0)
+	assert.ErrorIs(t, err, ErrInvalidAction)
+
+	run.Stop()
+	assert.False(t, inputs.runners[1].running.Load(), "input should be stopped by its owner")
+	_, err = registry.Get("my-input")
+	assert.ErrorIs(t, err, ErrNotFound, "input should be unregistered once stopped by its owner")
+}
+
+func TestFactoryWithoutID(t *testing.T) {
+	registry := NewRegistry(logptest.NewTestingLogger(t, ""))
+	inputs := &testFactory{}
+
+	run, err := registry.Factory(inputs).Create(&testPipeline{}, conf.MustNewConfigFrom(mapstr.M{"type": "test"}))
+	require.NoError(t, err)
+	run.Start()
+	defer run.Stop()
+
+	assert.IsType(t, &testRunner{}, run, "inputs without ID should not be wrapped")
+	assert.Empty(t, registry.List())
+}
+
+func TestDuplicateID(t *testing.T) {
+	registry := NewRegistry(logptest.NewTestingLogger(t, ""))
+	factory := registry.Factory(&testFactory{})
+	cfg := conf.MustNewConfigFrom(mapstr.M{"id": "my-input"})
+
+	first, err := factory.Create(&testPipeline{}, cfg)
+	require.NoError(t, err)
+	first.Start()
+	second, err := factory.Create(&testPipeline{}, cfg)
+	require.NoError(t, err)
+	second.Start()
+
+	second.Stop()
+	_, err = registry.Get("my-input")
+	assert.NoError(t, err, "stopping the duplicate must not unregister the first input")
+
+	first.Stop()
+	assert.Empty(t, registry.List())
+}
+
+func TestPauseBlocksPublishing(t *testing.T) {
+	registry := NewRegistry(logptest.NewTestingLogger(t, ""))
+	inputs := &testFactory{}
+	pipeline := &testPipeline{}
+
+	run, err := registry.Factory(inputs).Create(pipeline, conf.MustNewConfigFrom(mapstr.M{"id": "my-input"}))
+	require.NoError(t, err)
+	run.Start()
+	defer run.Stop()
+
+	_, err = registry.Do(context.Background(), "my-input", ActionPause, 0)
+	require.NoError(t, err)
+
+	client := inputs.runners[0].client
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		client.Publish(beat.Event{})
+	}()
+
+	select {
+	case <-done:
+		t.Fatal("publishing should block while the input is paused")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Zero(t, pipeline.published.Load(), "no event should be published while paused")
+
+	_, err = registry.Do(context.Background(), "my-input", ActionResume, 0)
+	require.NoError(t, err)
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("publishing should continue after resume")
+	}
+	assert.EqualValues(t, 1, pipeline.published.Load())
+}
+
+func TestStopDrain(t *testing.T) {
+	registry := NewRegistry(logptest.NewTestingLogger(t, ""))
+	inputs := &testFactory{}
+	pipeline := &testPipeline{}
+
+	run, err := registry.Factory(inputs).Create(pipeline, conf.MustNewConfigFrom(mapstr.M{"id": "my-input"}))
+	require.NoError(t, err)
+	run.Start()
+	defer run.Stop()
+
+	inputs.runners[0].client.PublishAll([]beat.Event{{}, {}})
+	st, err := registry.Get("my-input")
+	require.NoError(t, err)
+	assert.Equal(t, 2, st.PendingEvents)
+
+	st, err = registry.Do(context.Background(), "my-input", ActionStop, 10*time.Millisecond)
+	require.NoError(t, err)
+	assert.False(t, *st.Drained, "events are not acknowledged, stop should time out")
+	assert.Equal(t, 2, st.PendingEvents)
+
+	go pipeline.ack(2)
+	st, err = registry.Do(context.Background(), "my-input", ActionStop, 5*time.Second)
+	require.NoError(t, err)
+	assert.True(t, *st.Drained, "all events should be acknowledged")
+	assert.Zero(t, st.PendingEvents)
+}
+
+func TestFleetAction(t *testing.T) {
+	registry := NewRegistry(logptest.NewTestingLogger(t, ""))
+	run, err := registry.Factory(&testFactory{}).Create(&testPipeline{}, conf.MustNewConfigFrom(mapstr.M{"id": "my-input"}))
+	require.NoError(t, err)
+	run.Start()
+	defer run.Stop()
+
+	action := registry.FleetAction()
+	assert.Equal(t, "input_control", action.Name())
+
+	result, err := action.Execute(context.Background(), map[string]interface{}{
+		"id":            "my-input",
+		"action":        "stop",
+		"drain_timeout": "1s",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"id":             "my-input",
+		"state":          "stopped",
+		"pending_events": 0,
+		"drained":        true,
+	}, result)
+
+	_, err = action.Execute(context.Background(), map[string]interface{}{"action": "stop"})
+	assert.Error(t, err, "id is required")
+
+	_, err = action.Execute(context.Background(), map[string]interface{}{"id": "unknown", "action": "stop"})
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func assertState(t *testing.T, registry *Registry, id string, want State) {
+	t.Helper()
+	st, err := registry.Get(id)
+	require.NoError(t, err)
+	assert.Equal(t, want, st.State)
+}
+
+type testFactory struct {
+	runners []*testRunner
+}
+
+func (f *testFactory) CheckConfig(*conf.C) error { return nil }
+
+func (f *testFactory) Create(p beat.PipelineConnector, _ *conf.C) (cfgfile.Runner, error) {
+	r := &testRunner{pipeline: p}
+	f.runners = append(f.runners, r)
+	return r, nil
+}
+
+type testRunner struct {
+	pipeline beat.PipelineConnector
+	client   beat.Client
+	running  atomic.Bool
+}
+
+func (r *testRunner) String() string { return "test" }
+
+func (r *testRunner) Start() {
+	r.client, _ = r.pipeline.ConnectWith(beat.ClientConfig{})
+	r.running.Store(true)
+}
+
+func (r *testRunner) Stop() {
+	_ = r.client.Close()
+	r.running.Store(false)
+}
+
+type testPipeline struct {
+	mu        sync.Mutex
+	listeners []beat.EventListener
+	published atomic.Int64
+}
+
+func (p *testPipeline) Connect() (beat.Client, error) {
+	return p.ConnectWith(beat.ClientConfig{})
+}
+
+func (p *testPipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.listeners = append(p.listeners, cfg.EventListener)
+	return &testClient{pipeline: p, listener: cfg.EventListener}, nil
+}
+
+func (p *testPipeline) Disconnect(context.Context) error { return nil }
+
+func (p *testPipeline) ack(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, l := range p.listeners {
+		l.ACKEvents(n)
+	}
+}
+
+type testClient struct {
+	pipeline *testPipeline
+	listener beat.EventListener
+}
+
+func (c *testClient) Publish(e beat.Event) {
+	c.listener.AddEvent(e, true)
+	c.pipeline.published.Add(1)
+}
+
+func (c *testClient) PublishAll(events []beat.Event) {
+	for _, e := range events {
+		c.Publish(e)
+	}
+}
+
+func (c *testClient) Close() error { return nil }
@@ -0,0 +1,75 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package inputcontrol
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/management"
+)
+
+// fleetActionName is the name of the Fleet action to control inputs.
+const fleetActionName = "input_control"
+
+// FleetAction returns a management action that applies the actions sent by
+// Fleet to the inputs of the registry. The action accepts the parameters
+// `id`, `action` and, for stop actions, `drain_timeout`.
+func (r *Registry) FleetAction() management.Action {
+	return &fleetAction{registry: r}
+}
+
+type fleetAction struct {
+	registry *Registry
+}
+
+func (a *fleetAction) Name() string { return fleetActionName }
+
+func (a *fleetAction) Execute(ctx context.Context, params map[string]interface{}) (map[string]interface{}, error) {
+	id, _ := params["id"].(string)
+	if id == "" {
+		return nil, errors.New("missing input id")
+	}
+	action, _ := params["action"].(string)
+
+	drainTimeout := DefaultDrainTimeout
+	if v, ok := params["drain_timeout"].(string); ok && v != "" {
+		var err error
+		drainTimeout, err = time.ParseDuration(v)
+		if err != nil || drainTimeout < 0 {
+			return nil, fmt.Errorf("invalid drain_timeout %q", v)
+		}
+	}
+
+	st, err := a.registry.Do(ctx, id, Action(action), drainTimeout)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]interface{}{
+		"id":             st.ID,
+		"state":          string(st.State),
+		"pending_events": st.PendingEvents,
+	}
+	if st.Drained != nil {
+		result["drained"] = *st.Drained
+	}
+	return result, nil
+}
@@ -0,0 +1,151 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package inputcontrol
+
+import (
+	"context"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+)
+
+// gate blocks publishing of events while an input is paused. Blocked
+// publishers apply backpressure to the input, while the events already
+// published continue to be processed by the pipeline.
+type gate struct {
+	mu      sync.Mutex
+	resumed chan struct{} // nil if the gate is open
+}
+
+func (g *gate) pause() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.resumed == nil {
+		g.resumed = make(chan struct{})
+	}
+}
+
+func (g *gate) resume() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.resumed != nil {
+		close(g.resumed)
+		g.resumed = nil
+	}
+}
+
+// wait blocks until the gate is open or done is closed.
+func (g *gate) wait(done <-chan struct{}) {
+	g.mu.Lock()
+	resumed := g.resumed
+	g.mu.Unlock()
+
+	if resumed == nil {
+		return
+	}
+	select {
+	case <-resumed:
+	case <-done:
+	}
+}
+
+func (g *gate) wrap(client beat.Client) beat.Client {
+	return &gatedClient{client: client, gate: g, done: make(chan struct{})}
+}
+
+type gatedClient struct {
+	client    beat.Client
+	gate      *gate
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func (c *gatedClient) Publish(event beat.Event) {
+	c.gate.wait(c.done)
+	c.client.Publish(event)
+}
+
+func (c *gatedClient) PublishAll(events []beat.Event) {
+	c.gate.wait(c.done)
+	c.client.PublishAll(events)
+}
+
+func (c *gatedClient) Close() error {
+	c.closeOnce.Do(func() { close(c.done) })
+	return c.client.Close()
+}
+
+// pendingEvents counts the events published by an input that have not been
+// acknowledged yet.
+type pendingEvents struct {
+	mu      sync.Mutex
+	n       int
+	drained chan struct{} // closed once n drops to 0
+}
+
+func (p *pendingEvents) AddEvent(_ beat.Event, published bool) {
+	if !published {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.n == 0 {
+		p.drained = make(chan struct{})
+	}
+	p.n++
+}
+
+func (p *pendingEvents) ACKEvents(n int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.n == 0 {
+		return
+	}
+	p.n -= n
+	if p.n <= 0 {
+		p.n = 0
+		close(p.drained)
+	}
+}
+
+func (p *pendingEvents) ClientClosed() {}
+
+func (p *pendingEvents) count() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.n
+}
+
+// wait blocks until all pending events are acknowledged or ctx is done. It
+// returns true if all events were acknowledged.
+func (p *pendingEvents) wait(ctx context.Context) bool {
+	p.mu.Lock()
+	if p.n == 0 {
+		p.mu.Unlock()
+		return true
+	}
+	drained := p.drained
+	p.mu.Unlock()
+
+	select {
+	case <-drained:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
@@ -0,0 +1,105 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package inputcontrol
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	contentType     = "Content-Type"
+	applicationJSON = "application/json; charset=utf-8"
+)
+
+// AttachHandler attaches the HTTP handlers to control inputs to the given
+// mux:
+//
+//	GET  /inputs/control/              lists all controlled inputs
+//	GET  /inputs/control/{id}          returns the state of an input
+//	POST /inputs/control/{id}/{action} applies start, stop, pause or resume
+//
+// Stop requests accept a drain_timeout query parameter, the maximum time to
+// wait for the events published by the input to be acknowledged.
+func AttachHandler(mux *http.ServeMux, registry *Registry) error {
+	h := &handler{registry: registry}
+	mux.HandleFunc("GET /inputs/control/{$}", h.list)
+	mux.HandleFunc("GET /inputs/control/{id}", h.get)
+	mux.HandleFunc("POST /inputs/control/{id}/{action}", h.do)
+	return nil
+}
+
+type handler struct {
+	registry *Registry
+}
+
+func (h *handler) list(w http.ResponseWriter, _ *http.Request) {
+	serveJSON(w, http.StatusOK, h.registry.List())
+}
+
+func (h *handler) get(w http.ResponseWriter, req *http.Request) {
+	st, err := h.registry.Get(req.PathValue("id"))
+	if err != nil {
+		serveError(w, err)
+		return
+	}
+	serveJSON(w, http.StatusOK, st)
+}
+
+func (h *handler) do(w http.ResponseWriter, req *http.Request) {
+	drainTimeout := DefaultDrainTimeout
+	if v := req.URL.Query().Get("drain_timeout"); v != "" {
+		var err error
+		drainTimeout, err = time.ParseDuration(v)
+		if err != nil || drainTimeout < 0 {
+			serveError(w, fmt.Errorf("%w: invalid drain_timeout %q", ErrInvalidAction, v))
+			return
+		}
+	}
+
+	st, err := h.registry.Do(req.Context(), req.PathValue("id"), Action(req.PathValue("action")), drainTimeout)
+	if err != nil {
+		serveError(w, err)
+		return
+	}
+	serveJSON(w, http.StatusOK, st)
+}
+
+func serveError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, ErrNotFound):
+		code = http.StatusNotFound
+	case errors.Is(err, ErrInvalidAction):
+		code = http.StatusBadRequest
+	case errors.Is(err, ErrInvalidState):
+		code = http.StatusConflict
+	}
+	serveJSON(w, code, map[string]string{"error": err.Error()})
+}
+
+func serveJSON(w http.ResponseWriter, code int, value any) {
+	w.Header().Set(contentType, applicationJSON)
+	w.WriteHeader(code)
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(value)
+}
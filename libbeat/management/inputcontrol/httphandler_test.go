@@ -0,0 +1,82 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package inputcontrol
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestHandler(t *testing.T) {
+	registry := NewRegistry(logptest.NewTestingLogger(t, ""))
+	run, err := registry.Factory(&testFactory{}).Create(&testPipeline{}, conf.MustNewConfigFrom(mapstr.M{"id": "my-input"}))
+	require.NoError(t, err)
+	run.Start()
+	defer run.Stop()
+
+	mux := http.NewServeMux()
+	require.NoError(t, AttachHandler(mux, registry))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	// The cases are run in order, as they change the state of the input.
+	cases := []struct {
+		method string
+		path   string
+		status int
+		body   string
+	}{
+		{method: "GET", path: "/inputs/control/", status: http.StatusOK, body: `[{"id":"my-input","state":"running","pending_events":0}]`},
+		{method: "GET", path: "/inputs/control/my-input", status: http.StatusOK, body: `{"id":"my-input","state":"running","pending_events":0}`},
+		{method: "GET", path: "/inputs/control/unknown", status: http.StatusNotFound},
+		{method: "POST", path: "/inputs/control/my-input/pause", status: http.StatusOK, body: `{"id":"my-input","state":"paused","pending_events":0}`},
+		{method: "POST", path: "/inputs/control/my-input/resume", status: http.StatusOK, body: `{"id":"my-input","state":"running","pending_events":0}`},
+		{method: "POST", path: "/inputs/control/my-input/restart", status: http.StatusBadRequest},
+		{method: "POST", path: "/inputs/control/my-input/stop?drain_timeout=foo", status: http.StatusBadRequest},
+		{method: "POST", path: "/inputs/control/my-input/stop?drain_timeout=1s", status: http.StatusOK, body: `{"id":"my-input","state":"stopped","pending_events":0,"drained":true}`},
+		{method: "POST", path: "/inputs/control/my-input/resume", status: http.StatusConflict},
+		{method: "POST", path: "/inputs/control/my-input/start", status: http.StatusOK, body: `{"id":"my-input","state":"running","pending_events":0}`},
+		{method: "POST", path: "/inputs/control/unknown/stop", status: http.StatusNotFound},
+		{method: "GET", path: "/inputs/control/my-input/stop", status: http.StatusMethodNotAllowed},
+	}
+
+	for _, tc := range cases {
+		req, err := http.NewRequest(tc.method, srv.URL+tc.path, nil)
+		require.NoError(t, err)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+
+		assert.Equal(t, tc.status, resp.StatusCode, "%s %s", tc.method, tc.path)
+		if tc.body != "" {
+			assert.Equal(t, tc.body, strings.TrimSpace(string(body)), "%s %s", tc.method, tc.path)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package outputs
+
+import (
+	"errors"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+// AutoscaleConfig configures the scaling of the output workers between the
+// configured number of workers and MaxWorkers, depending on the fill level of
+// the queue and the publish latency.
+type AutoscaleConfig struct {
+	Enabled bool `config:"enabled"`
+
+	// MaxWorkers is the maximum number of workers per host.
+	MaxWorkers int `config:"max_workers" validate:"min=1"`
+
+	// Interval is the interval at which the scaling is evaluated.
+	Interval time.Duration `config:"interval" validate:"positive,nonzero"`
+
+	// Cooldown is the minimum time between two scaling decisions.
+	Cooldown time.Duration `config:"cooldown" validate:"min=0"`
+
+	// A worker is added when the fill level of the queue is at least
+	// QueueHighWatermark, and removed when it is at most QueueLowWatermark.
+	QueueHighWatermark float64 `config:"queue_high_watermark" validate:"min=0,max=1"`
+	QueueLowWatermark  float64 `config:"queue_low_watermark" validate:"min=0,max=1"`
+
+	// MaxLatency is the publish latency above which no workers are added and
+	// a worker is removed, as more connections would overload the
+	// destination. It is not checked if zero.
+	MaxLatency time.Duration `config:"max_latency" validate:"min=0"`
+}
+
+// AutoscaleSettings are the settings of the scaling of the output workers
+// passed to the publisher pipeline in the output Group.
+type AutoscaleSettings struct {
+	AutoscaleConfig
+
+	// MinWorkers is the number of workers started with the output, and the
+	// minimum number of workers the output is scaled down to. The clients
+	// of the Group past MinWorkers are started when scaling up.
+	MinWorkers int
+}
+
+// DefaultAutoscaleConfig returns the default worker_autoscale settings.
+func DefaultAutoscaleConfig() AutoscaleConfig {
+	return AutoscaleConfig{
+		Enabled:            false,
+		MaxWorkers:         4,
+		Interval:           5 * time.Second,
+		Cooldown:           30 * time.Second,
+		QueueHighWatermark: 0.5,
+		QueueLowWatermark:  0.1,
+	}
+}
+
+func (c *AutoscaleConfig) Validate() error {
+	if c.QueueLowWatermark >= c.QueueHighWatermark {
+		return errors.New("worker_autoscale.queue_low_watermark must be lower than worker_autoscale.queue_high_watermark")
+	}
+	return nil
+}
+
+type autoscaleHostCfg struct {
+	HostWorkerCfg `config:",inline"`
+	Autoscale     AutoscaleConfig `config:"worker_autoscale"`
+}
+
+// ReadAutoscaleHostList reads a list of hosts to connect to from a
+// configuration object, like ReadHostList. If worker_autoscale is enabled and
+// the output load balances the events across its clients, each host is
+// duplicated by worker_autoscale.max_workers, and the settings of the scaling
+// are returned. The hosts are interleaved so that the workers started first
+// are spread across the hosts.
+func ReadAutoscaleHostList(cfg *config.C, loadbalance bool) ([]string, *AutoscaleSettings, error) {
+	hostCfg := autoscaleHostCfg{Autoscale: DefaultAutoscaleConfig()}
+	if err := cfg.Unpack(&hostCfg); err != nil {
+		return nil, nil, err
+	}
+	if !hostCfg.Autoscale.Enabled || !loadbalance {
+		// Without load balancing, a single worker publishes to the hosts,
+		// the workers are not scaled.
+		hosts, err := ReadHostList(cfg)
+		return hosts, nil, err
+	}
+
+	workers := hostCfg.NumWorkers()
+	if workers < 1 {
+		workers = 1
+	}
+	if hostCfg.Autoscale.MaxWorkers < workers {
+		return nil, nil, errors.New("worker_autoscale.max_workers cannot be lower than worker")
+	}
+
+	lst := hostCfg.Hosts
+	hosts := make([]string, 0, len(lst)*hostCfg.Autoscale.MaxWorkers)
+	for i := 0; i < hostCfg.Autoscale.MaxWorkers; i++ {
+		hosts = append(hosts, lst...)
+	}
+	return hosts, &AutoscaleSettings{
+		AutoscaleConfig: hostCfg.Autoscale,
+		MinWorkers:      workers * len(lst),
+	}, nil
+}
@@ -0,0 +1,70 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package outputs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestReadAutoscaleHostList(t *testing.T) {
+	cfg := config.MustNewConfigFrom(map[string]interface{}{
+		"hosts":  []string{"a", "b"},
+		"worker": 2,
+		"worker_autoscale": map[string]interface{}{
+			"enabled":     true,
+			"max_workers": 3,
+			"max_latency": "10s",
+		},
+	})
+
+	hosts, settings, err := ReadAutoscaleHostList(cfg, true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "a", "b", "a", "b"}, hosts, "the hosts are interleaved")
+	require.NotNil(t, settings)
+	assert.Equal(t, 4, settings.MinWorkers)
+	assert.Equal(t, 10*time.Second, settings.MaxLatency)
+	assert.Equal(t, 0.5, settings.QueueHighWatermark)
+
+	hosts, settings, err = ReadAutoscaleHostList(cfg, false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "a", "b", "b"}, hosts, "the workers are not scaled without load balancing")
+	assert.Nil(t, settings)
+}
+
+func TestAutoscaleConfig(t *testing.T) {
+	cfg := config.MustNewConfigFrom(map[string]interface{}{
+		"hosts":  []string{"a"},
+		"worker": 4,
+		"worker_autoscale": map[string]interface{}{
+			"enabled":     true,
+			"max_workers": 2,
+		},
+	})
+	_, _, err := ReadAutoscaleHostList(cfg, true)
+	assert.ErrorContains(t, err, "worker_autoscale.max_workers cannot be lower than worker")
+
+	c := DefaultAutoscaleConfig()
+	c.QueueLowWatermark = 0.6
+	assert.ErrorContains(t, c.Validate(), "must be lower than")
+}
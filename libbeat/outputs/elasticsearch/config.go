@@ -45,6 +45,8 @@ type ElasticsearchConfig struct {
 	AllowOlderVersion  bool              `config:"allow_older_versions"`
 	Queue              config.Namespace  `config:"queue"`
 
+	PipelineParameters pipelineParametersConfig `config:"pipeline_parameters"`
+
 	Transport httpcommon.HTTPTransportSettings `config:",inline"`
 }
 
@@ -76,6 +78,9 @@ var (
 		},
 		BulkMaxSize: defaultBulkSize,
 		Transport:   ESDefaultTransportSettings(),
+		PipelineParameters: pipelineParametersConfig{
+			Field: defaultPipelineParametersField,
+		},
 	}
 )
 
@@ -73,7 +73,7 @@ func makeES(
 		return outputs.Fail(err)
 	}
 
-	hosts, err := outputs.ReadHostList(cfg)
+	hosts, autoscale, err := outputs.ReadAutoscaleHostList(cfg, esConfig.LoadBalance)
 	if err != nil {
 		return outputs.Fail(err)
 	}
@@ -88,8 +88,13 @@ func makeES(
 		params = nil
 	}
 
+	pipelineParams, err := newPipelineParameters(esConfig.PipelineParameters)
+	if err != nil {
+		return outputs.Fail(err)
+	}
+
 	encoderFactory := newEventEncoderFactory(
-		esConfig.EscapeHTML, indexSelector, pipelineSelector)
+		esConfig.EscapeHTML, indexSelector, pipelineSelector, pipelineParams)
 
 	clients := make([]outputs.NetworkClient, len(hosts))
 	for i, host := range hosts {
@@ -130,7 +135,9 @@ func makeES(
 		clients[i] = client
 	}
 
-	return outputs.SuccessNet(esConfig.Queue, esConfig.LoadBalance, esConfig.BulkMaxSize, esConfig.MaxRetries, encoderFactory, beatInfo.Logger, beatInfo.Paths, clients)
+	grp, err := outputs.SuccessNet(esConfig.Queue, esConfig.LoadBalance, esConfig.BulkMaxSize, esConfig.MaxRetries, encoderFactory, beatInfo.Logger, beatInfo.Paths, clients)
+	grp.Autoscale = autoscale
+	return grp, err
 }
 
 func buildSelectors(
@@ -36,6 +36,7 @@ type eventEncoder struct {
 	buf              *bytes.Buffer
 	enc              eslegclient.BodyEncoder
 	pipelineSelector *outil.Selector
+	pipelineParams   *pipelineParameters
 	indexSelector    outputs.IndexSelector
 }
 
@@ -70,15 +71,17 @@ func newEventEncoderFactory(
 	escapeHTML bool,
 	indexSelector outputs.IndexSelector,
 	pipelineSelector *outil.Selector,
+	pipelineParams *pipelineParameters,
 ) queue.EncoderFactory[publisher.Event] {
 	return func() queue.Encoder[publisher.Event] {
-		return newEventEncoder(escapeHTML, indexSelector, pipelineSelector)
+		return newEventEncoder(escapeHTML, indexSelector, pipelineSelector, pipelineParams)
 	}
 }
 
 func newEventEncoder(escapeHTML bool,
 	indexSelector outputs.IndexSelector,
 	pipelineSelector *outil.Selector,
+	pipelineParams *pipelineParameters,
 ) queue.Encoder[publisher.Event] {
 	buf := bytes.NewBuffer(nil)
 	enc := eslegclient.NewJSONEncoder(buf, escapeHTML)
@@ -86,6 +89,7 @@ func newEventEncoder(escapeHTML bool,
 		buf:              buf,
 		enc:              enc,
 		pipelineSelector: pipelineSelector,
+		pipelineParams:   pipelineParams,
 		indexSelector:    indexSelector,
 	}
 }
@@ -109,6 +113,9 @@ func (pe *eventEncoder) encodeRawEvent(e *beat.Event) *encodedEvent {
 	if err != nil {
 		return &encodedEvent{err: fmt.Errorf("failed to select event pipeline: %w", err)}
 	}
+	if err := pe.pipelineParams.apply(e, pipeline); err != nil {
+		return &encodedEvent{err: fmt.Errorf("failed to set event pipeline parameters: %w", err)}
+	}
 	var index string
 	if pe.indexSelector != nil {
 		index, err = pe.indexSelector.Select(e)
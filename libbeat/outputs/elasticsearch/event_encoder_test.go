@@ -40,7 +40,7 @@ func (testIndexSelector) Select(event *beat.Event) (string, error) {
 func TestEncodeEntry(t *testing.T) {
 	indexSelector := testIndexSelector{}
 
-	encoder := newEventEncoder(true, indexSelector, nil)
+	encoder := newEventEncoder(true, indexSelector, nil, nil)
 
 	metaFields := mapstr.M{
 		events.FieldMetaOpType:   "create",
@@ -123,6 +123,7 @@ func encodeEvents(client *Client, events []publisher.Event) []publisher.Event {
 		client.conn.EscapeHTML,
 		client.indexSelector,
 		client.pipelineSelector,
+		nil,
 	)
 	for i := range events {
 		// Skip encoding if there's already encoded data present
@@ -140,6 +141,7 @@ func encodeEvent(client *Client, event publisher.Event) publisher.Event {
 		client.conn.EscapeHTML,
 		client.indexSelector,
 		client.pipelineSelector,
+		nil,
 	)
 	encoded, _ := encoder.EncodeEntry(event)
 	return encoded
@@ -0,0 +1,136 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/beat/events"
+	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const defaultPipelineParametersField = "pipeline_parameters"
+
+// pipelineParametersConfig configures the parameters that are passed to the
+// ingest pipeline of an event.
+type pipelineParametersConfig struct {
+	// Field is the top level document field the parameters are written to.
+	Field string `config:"field"`
+	// Values maps parameter names to format strings that are evaluated
+	// against each event.
+	Values map[string]string `config:"values"`
+}
+
+func (c *pipelineParametersConfig) Validate() error {
+	if c.Field == "" {
+		return errors.New("pipeline_parameters.field must not be empty")
+	}
+	if strings.Contains(c.Field, ".") {
+		return fmt.Errorf("pipeline_parameters.field %q must be a top level field name", c.Field)
+	}
+	return nil
+}
+
+// pipelineParameters resolves the ingest pipeline parameters of events.
+//
+// The bulk API only accepts the name of the pipeline, so the parameters are
+// added to the document under a single field that the pipeline can read and
+// is expected to remove.
+type pipelineParameters struct {
+	field  string
+	values map[string]*fmtstr.EventFormatString
+}
+
+func newPipelineParameters(cfg pipelineParametersConfig) (*pipelineParameters, error) {
+	p := &pipelineParameters{
+		field:  cfg.Field,
+		values: make(map[string]*fmtstr.EventFormatString, len(cfg.Values)),
+	}
+	for name, value := range cfg.Values {
+		fs, err := fmtstr.CompileEvent(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pipeline parameter %q: %w", name, err)
+		}
+		p.values[name] = fs
+	}
+	return p, nil
+}
+
+// apply adds the pipeline parameters to the fields of e. Parameters are only
+// added when the event is sent to a pipeline. Configured parameters
+// referencing fields missing from the event are omitted, and parameters set
+// in the event metadata take precedence over configured parameters.
+//
+// The fields of e are copied before they are modified since they may be
+// shared with other events.
+func (p *pipelineParameters) apply(e *beat.Event, pipeline string) error {
+	if p == nil || pipeline == "" {
+		return nil
+	}
+
+	params := mapstr.M{}
+	for name, fs := range p.values {
+		v, err := fs.Run(e)
+		if err != nil {
+			continue
+		}
+		params[name] = v
+	}
+	if e.Meta != nil {
+		v, err := e.Meta.GetValue(events.FieldMetaPipelineParameters)
+		switch {
+		case errors.Is(err, mapstr.ErrKeyNotFound):
+		case err != nil:
+			return err
+		default:
+			m, ok := tryToMapStr(v)
+			if !ok {
+				return fmt.Errorf("pipeline parameters metadata is %T, not an object", v)
+			}
+			for name, value := range m {
+				params[name] = value
+			}
+		}
+	}
+	if len(params) == 0 {
+		return nil
+	}
+
+	fields := make(mapstr.M, len(e.Fields)+1)
+	for k, v := range e.Fields {
+		fields[k] = v
+	}
+	fields[p.field] = params
+	e.Fields = fields
+	return nil
+}
+
+func tryToMapStr(v interface{}) (mapstr.M, bool) {
+	switch m := v.(type) {
+	case mapstr.M:
+		return m, true
+	case map[string]interface{}:
+		return mapstr.M(m), true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,115 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package elasticsearch
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/beat/events"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestPipelineParameters(t *testing.T) {
+	params, err := newPipelineParameters(pipelineParametersConfig{
+		Field: defaultPipelineParametersField,
+		Values: map[string]string{
+			"tenant": "%{[fields.tenant]}",
+			"region": "%{[cloud.region]}",
+			"source": "filebeat",
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		meta     mapstr.M
+		fields   mapstr.M
+		pipeline string
+		want     interface{}
+	}{
+		{
+			name:   "no pipeline",
+			fields: mapstr.M{"fields": mapstr.M{"tenant": "a"}},
+		},
+		{
+			name:     "configured values",
+			fields:   mapstr.M{"fields": mapstr.M{"tenant": "a"}},
+			pipeline: "p",
+			want:     map[string]interface{}{"tenant": "a", "source": "filebeat"},
+		},
+		{
+			name: "metadata overrides configured values",
+			meta: mapstr.M{events.FieldMetaPipelineParameters: map[string]interface{}{
+				"tenant": "b",
+				"level":  2,
+			}},
+			fields:   mapstr.M{"fields": mapstr.M{"tenant": "a"}, "cloud": mapstr.M{"region": "eu"}},
+			pipeline: "p",
+			want:     map[string]interface{}{"tenant": "b", "region": "eu", "source": "filebeat", "level": float64(2)},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			original := test.fields.Clone()
+			event := beat.Event{Meta: test.meta, Fields: test.fields}
+			encoder := newEventEncoder(false, testIndexSelector{}, nil, params)
+			if test.pipeline != "" {
+				if event.Meta == nil {
+					event.Meta = mapstr.M{}
+				}
+				event.Meta[events.FieldMetaPipeline] = test.pipeline
+			}
+
+			encoded, _ := encoder.EncodeEntry(publisher.Event{Content: event})
+			enc, ok := encoded.EncodedEvent.(*encodedEvent)
+			require.True(t, ok)
+			require.NoError(t, enc.err)
+
+			var doc map[string]interface{}
+			require.NoError(t, json.Unmarshal(enc.encoding, &doc))
+			assert.Equal(t, test.want, doc[defaultPipelineParametersField])
+			assert.Equal(t, original, test.fields, "event fields must not be modified")
+		})
+	}
+}
+
+func TestPipelineParametersInvalidMetadata(t *testing.T) {
+	params, err := newPipelineParameters(pipelineParametersConfig{Field: defaultPipelineParametersField})
+	require.NoError(t, err)
+
+	event := beat.Event{
+		Meta: mapstr.M{
+			events.FieldMetaPipeline:           "p",
+			events.FieldMetaPipelineParameters: "tenant=a",
+		},
+		Fields: mapstr.M{},
+	}
+	assert.ErrorContains(t, params.apply(&event, "p"), "not an object")
+}
+
+func TestPipelineParametersConfigValidate(t *testing.T) {
+	assert.NoError(t, (&pipelineParametersConfig{Field: "params"}).Validate())
+	assert.Error(t, (&pipelineParametersConfig{}).Validate())
+	assert.Error(t, (&pipelineParametersConfig{Field: "a.b"}).Validate())
+}
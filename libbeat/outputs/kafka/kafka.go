@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/beat/events"
 	"github.com/elastic/beats/v7/libbeat/outputs"
 	"github.com/elastic/beats/v7/libbeat/outputs/codec"
 	"github.com/elastic/beats/v7/libbeat/outputs/outil"
@@ -90,6 +91,9 @@ func makeKafka(
 //
 // When running standalone the topic selector works as expected and documented.
 // When running under Elastic-Agent, dynamic topic selection is also supported
+//
+// The topic set in the event metadata, by the `output` target of the routes,
+// takes precedence over the configured topic.
 func buildTopicSelector(cfg *config.C, logger *logp.Logger) (outil.Selector, error) {
 
 	if cfg == nil {
@@ -102,5 +106,6 @@ func buildTopicSelector(cfg *config.C, logger *logp.Logger) (outil.Selector, err
 		EnableSingleOnly: true,
 		FailEmpty:        true,
 		Case:             outil.SelectorKeepCase,
+		MetaKey:          events.FieldMetaOutput,
 	}, logger)
 }
@@ -21,6 +21,7 @@ import (
 	"testing"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/beat/events"
 	"github.com/elastic/beats/v7/libbeat/management"
 	"github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp/logptest"
@@ -86,6 +87,25 @@ func TestBuildTopicSelector(t *testing.T) {
 		})
 	}
 
+	t.Run("topic from the event metadata", func(t *testing.T) {
+		selector, err := buildTopicSelector(config.MustNewConfigFrom(mapstr.M{"topic": "%{[foo]}"}), logptest.NewTestingLogger(t, ""))
+		if err != nil {
+			t.Fatalf("could not build topic selector: %s", err)
+		}
+
+		event := beat.Event{
+			Meta:   mapstr.M{events.FieldMetaOutput: "routed"},
+			Fields: mapstr.M{"foo": "bar"},
+		}
+		topic, err := selector.Select(&event)
+		if err != nil {
+			t.Fatalf("could not use selector: %s", err)
+		}
+		if topic != "routed" {
+			t.Fatalf("expecting topic to be 'routed', got '%s' instead", topic)
+		}
+	})
+
 	t.Run("fail unpacking config", func(t *testing.T) {
 		_, err := buildTopicSelector(nil, logptest.NewTestingLogger(t, ""))
 		if err == nil {
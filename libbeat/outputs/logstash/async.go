@@ -70,7 +70,7 @@ func newAsyncClient(
 	}
 
 	if config.SlowStart {
-		c.win = newWindower(defaultStartMaxWindowSize, config.BulkMaxSize)
+		c.win = newWindower(config.SlowStartWindow, config.BulkMaxSize)
 	}
 
 	if config.TTL != 0 {
@@ -18,6 +18,7 @@
 package logstash
 
 import (
+	"errors"
 	"strings"
 	"time"
 
@@ -33,12 +34,15 @@ type Config struct {
 	LoadBalance      bool                  `config:"loadbalance"`
 	BulkMaxSize      int                   `config:"bulk_max_size"`
 	SlowStart        bool                  `config:"slow_start"`
+	SlowStartWindow  int                   `config:"slow_start_window" validate:"min=1"`
 	Timeout          time.Duration         `config:"timeout"`
 	TTL              time.Duration         `config:"ttl"               validate:"min=0"`
 	Pipelining       int                   `config:"pipelining"        validate:"min=0"`
 	CompressionLevel int                   `config:"compression_level" validate:"min=0, max=9"`
 	MaxRetries       int                   `config:"max_retries"       validate:"min=-1"`
 	TLS              *tlscommon.Config     `config:"ssl"`
+	TLS13Only        bool                  `config:"tls13_only"`
+	KeepAlive        KeepAlive             `config:"keepalive"`
 	Proxy            transport.ProxyConfig `config:",inline"`
 	Backoff          Backoff               `config:"backoff"`
 	EscapeHTML       bool                  `config:"escape_html"`
@@ -50,12 +54,29 @@ type Backoff struct {
 	Max  time.Duration
 }
 
+// KeepAlive configures the TCP keepalive and user timeout of the connections
+// to Logstash. Zero values keep the operating system defaults.
+type KeepAlive struct {
+	Enabled     bool          `config:"enabled"`
+	Idle        time.Duration `config:"idle"         validate:"min=0"`
+	Interval    time.Duration `config:"interval"     validate:"min=0"`
+	Count       int           `config:"count"        validate:"min=0"`
+	UserTimeout time.Duration `config:"user_timeout" validate:"min=0"`
+}
+
+// isDefault returns whether k leaves the keepalive settings of the default
+// dialer unchanged.
+func (k KeepAlive) isDefault() bool {
+	return k == KeepAlive{Enabled: true}
+}
+
 func DefaultConfig() Config {
 	return Config{
 		LoadBalance:      false,
 		Pipelining:       2,
 		BulkMaxSize:      2048,
 		SlowStart:        false,
+		SlowStartWindow:  defaultStartMaxWindowSize,
 		CompressionLevel: 3,
 		Timeout:          30 * time.Second,
 		MaxRetries:       3,
@@ -64,10 +85,30 @@ func DefaultConfig() Config {
 			Init: 1 * time.Second,
 			Max:  60 * time.Second,
 		},
+		KeepAlive: KeepAlive{
+			Enabled: true,
+		},
 		EscapeHTML: false,
 	}
 }
 
+func (c *Config) Validate() error {
+	if c.TLS13Only {
+		if c.TLS == nil || !c.TLS.IsEnabled() {
+			return errors.New("tls13_only requires ssl to be enabled")
+		}
+		for _, v := range c.TLS.Versions {
+			if v != tlscommon.TLSVersion13 {
+				return errors.New("tls13_only can not be used with ssl.supported_protocols other than TLSv1.3")
+			}
+		}
+	}
+	if c.KeepAlive.UserTimeout > 0 && !userTimeoutSupported {
+		return errors.New("keepalive.user_timeout is only supported on Linux")
+	}
+	return nil
+}
+
 func readConfig(cfg *config.C, indexPrefix string) (*Config, error) {
 	c := DefaultConfig()
 
@@ -84,5 +125,9 @@ func readConfig(cfg *config.C, indexPrefix string) (*Config, error) {
 		c.Index = strings.ToLower(indexPrefix)
 	}
 
+	if c.TLS13Only {
+		c.TLS.Versions = []tlscommon.TLSVersion{tlscommon.TLSVersion13}
+	}
+
 	return &c, nil
 }
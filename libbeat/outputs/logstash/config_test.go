@@ -25,7 +25,10 @@ import (
 	"github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestConfig(t *testing.T) {
@@ -43,6 +46,7 @@ func TestConfig(t *testing.T) {
 				Pipelining:       2,
 				BulkMaxSize:      2048,
 				SlowStart:        false,
+				SlowStartWindow:  10,
 				CompressionLevel: 3,
 				Timeout:          30 * time.Second,
 				MaxRetries:       3,
@@ -51,6 +55,7 @@ func TestConfig(t *testing.T) {
 					Init: 1 * time.Second,
 					Max:  60 * time.Second,
 				},
+				KeepAlive:  KeepAlive{Enabled: true},
 				EscapeHTML: false,
 				Index:      "bar",
 			},
@@ -67,6 +72,7 @@ func TestConfig(t *testing.T) {
 				BulkMaxSize:      1024,
 				Pipelining:       2,
 				SlowStart:        false,
+				SlowStartWindow:  10,
 				CompressionLevel: 3,
 				Timeout:          30 * time.Second,
 				MaxRetries:       3,
@@ -75,10 +81,20 @@ func TestConfig(t *testing.T) {
 					Init: 1 * time.Second,
 					Max:  60 * time.Second,
 				},
+				KeepAlive:  KeepAlive{Enabled: true},
 				EscapeHTML: false,
 				Index:      "beat-index",
 			},
 		},
+		"tls13 only": {
+			config: config.MustNewConfigFrom(mapstr.M{
+				"tls13_only":        true,
+				"slow_start":        true,
+				"slow_start_window": 50,
+				"keepalive.idle":    "10s",
+			}),
+			err: true,
+		},
 		"removed config setting": {
 			config: config.MustNewConfigFrom(mapstr.M{
 				"port": "8080",
@@ -99,3 +115,27 @@ func TestConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestConfigTLS13Only(t *testing.T) {
+	cfg, err := readConfig(config.MustNewConfigFrom(mapstr.M{
+		"tls13_only":                  true,
+		"ssl.certificate_authorities": []string{"ca_test.pem"},
+		"slow_start_window":           50,
+		"keepalive.idle":              "10s",
+		"keepalive.interval":          "5s",
+		"keepalive.count":             3,
+		"pipelining":                  4,
+	}), "bar")
+	require.NoError(t, err)
+	assert.Equal(t, []tlscommon.TLSVersion{tlscommon.TLSVersion13}, cfg.TLS.Versions)
+	assert.Equal(t, 50, cfg.SlowStartWindow)
+	assert.Equal(t, KeepAlive{Enabled: true, Idle: 10 * time.Second, Interval: 5 * time.Second, Count: 3}, cfg.KeepAlive)
+	assert.False(t, cfg.KeepAlive.isDefault())
+
+	_, err = readConfig(config.MustNewConfigFrom(mapstr.M{
+		"tls13_only":                  true,
+		"ssl.certificate_authorities": []string{"ca_test.pem"},
+		"ssl.supported_protocols":     []string{"TLSv1.2"},
+	}), "bar")
+	assert.ErrorContains(t, err, "tls13_only")
+}
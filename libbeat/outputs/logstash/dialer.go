@@ -0,0 +1,82 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package logstash
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+// makeDialer returns the dialer for connections to Logstash with the
+// keepalive settings of config applied. The proxy, stats and TLS layers are
+// the same as the ones of the default transport dialer.
+func makeDialer(
+	config *Config,
+	tls *tlscommon.TLSConfig,
+	observer outputs.Observer,
+	logger *logp.Logger,
+) (transport.Dialer, error) {
+	dialer, err := transport.ProxyDialer(logger, &config.Proxy, keepAliveDialer(config.Timeout, config.KeepAlive))
+	if err != nil {
+		return nil, err
+	}
+	if observer != nil {
+		dialer = transport.StatsDialer(dialer, observer)
+	}
+	if tls != nil {
+		dialer = transport.TLSDialer(dialer, tls, config.Timeout, logger)
+	}
+	return dialer, nil
+}
+
+// keepAliveDialer returns a TCP dialer applying the keepalive settings of ka
+// to new connections.
+func keepAliveDialer(timeout time.Duration, ka KeepAlive) transport.Dialer {
+	d := &net.Dialer{Timeout: timeout}
+	if ka.Enabled {
+		d.KeepAliveConfig = net.KeepAliveConfig{
+			Enable:   true,
+			Idle:     ka.Idle,
+			Interval: ka.Interval,
+			Count:    ka.Count,
+		}
+	} else {
+		d.KeepAlive = -1
+	}
+	if ka.UserTimeout > 0 {
+		d.Control = userTimeoutControl(ka.UserTimeout)
+	}
+
+	return transport.DialerFunc(func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		addresses, err := net.DefaultResolver.LookupHost(ctx, host)
+		if err != nil {
+			return nil, err
+		}
+		return transport.DialWith(ctx, d, network, host, addresses, port)
+	})
+}
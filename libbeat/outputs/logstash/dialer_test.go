@@ -0,0 +1,59 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package logstash
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeepAliveDialer(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	tests := map[string]KeepAlive{
+		"tuned":    {Enabled: true, Idle: 10 * time.Second, Interval: 5 * time.Second, Count: 3},
+		"disabled": {Enabled: false},
+	}
+	if userTimeoutSupported {
+		tests["user timeout"] = KeepAlive{Enabled: true, UserTimeout: 30 * time.Second}
+	}
+	for name, ka := range tests {
+		t.Run(name, func(t *testing.T) {
+			conn, err := keepAliveDialer(time.Second, ka).Dial("tcp", l.Addr().String())
+			require.NoError(t, err)
+			assert.NoError(t, conn.Close())
+		})
+	}
+}
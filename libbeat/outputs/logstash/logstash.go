@@ -60,7 +60,7 @@ func MakeLogstashClients(
 		return outputs.Fail(err)
 	}
 
-	hosts, err := outputs.ReadHostList(rawCfg)
+	hosts, autoscale, err := outputs.ReadAutoscaleHostList(rawCfg, config.LoadBalance)
 	if err != nil {
 		return outputs.Fail(err)
 	}
@@ -77,11 +77,24 @@ func MakeLogstashClients(
 		Stats:   observer,
 	}
 
+	var dialer transport.Dialer
+	if !config.KeepAlive.isDefault() {
+		dialer, err = makeDialer(config, tls, observer, logger)
+		if err != nil {
+			return outputs.Fail(err)
+		}
+	}
+
 	clients := make([]outputs.NetworkClient, len(hosts))
 	for i, host := range hosts {
 		var client outputs.NetworkClient
 
-		conn, err := transport.NewClient(transp, "tcp", host, defaultPort, logger)
+		var conn *transport.Client
+		if dialer != nil {
+			conn, err = transport.NewClientWithDialer(dialer, transp, "tcp", host, defaultPort)
+		} else {
+			conn, err = transport.NewClient(transp, "tcp", host, defaultPort, logger)
+		}
 		if err != nil {
 			return outputs.Fail(err)
 		}
@@ -99,5 +112,7 @@ func MakeLogstashClients(
 		clients[i] = client
 	}
 
-	return outputs.SuccessNet(config.Queue, config.LoadBalance, config.BulkMaxSize, config.MaxRetries, nil, logger, beatPaths, clients)
+	grp, err := outputs.SuccessNet(config.Queue, config.LoadBalance, config.BulkMaxSize, config.MaxRetries, nil, logger, beatPaths, clients)
+	grp.Autoscale = autoscale
+	return grp, err
 }
@@ -53,7 +53,7 @@ func newSyncClient(
 	}
 
 	if config.SlowStart {
-		c.win = newWindower(defaultStartMaxWindowSize, config.BulkMaxSize)
+		c.win = newWindower(config.SlowStartWindow, config.BulkMaxSize)
 	}
 	if c.ttl > 0 {
 		c.ticker = time.NewTicker(c.ttl)
@@ -127,7 +127,7 @@ func (c *syncClient) Publish(_ context.Context, batch publisher.Batch) error {
 
 				// reset window size on reconnect
 				if c.win != nil {
-					c.win.windowSize = int32(defaultStartMaxWindowSize)
+					c.win.reset()
 				}
 			default:
 			}
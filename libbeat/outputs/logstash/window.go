@@ -26,6 +26,7 @@ type window struct {
 	windowSize      int32
 	maxOkWindowSize int // max window size sending was successful for
 	maxWindowSize   int
+	startWindowSize int
 }
 
 func newWindower(start, max int) *window {
@@ -36,11 +37,17 @@ func newWindower(start, max int) *window {
 
 func (w *window) init(start, max int) {
 	*w = window{
-		windowSize:    int32(start),
-		maxWindowSize: max,
+		windowSize:      int32(start),
+		maxWindowSize:   max,
+		startWindowSize: start,
 	}
 }
 
+// reset sets the window size back to its start size.
+func (w *window) reset() {
+	atomic.StoreInt32(&w.windowSize, int32(w.startWindowSize))
+}
+
 func (w *window) get() int {
 	return int(atomic.LoadInt32(&w.windowSize))
 }
@@ -39,6 +39,17 @@ func TestShrinkWindowSizeNeverZero(t *testing.T) {
 	assert.Equal(t, 1, int(w.windowSize))
 }
 
+func TestResetWindowSize(t *testing.T) {
+	var w window
+	w.init(50, DefaultConfig().BulkMaxSize)
+
+	w.tryGrowWindow(1000)
+	assert.Equal(t, 75, w.get())
+
+	w.reset()
+	assert.Equal(t, 50, w.get())
+}
+
 func TestGrowWindowSizeUpToBatchSizes(t *testing.T) {
 	batchSize := 114
 	windowSize := 1024
@@ -21,6 +21,7 @@ import (
 	"fmt"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/beat/events"
 	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
 	"github.com/elastic/beats/v7/libbeat/conditions"
 	"github.com/elastic/elastic-agent-libs/config"
@@ -60,6 +61,11 @@ type fmtSelector struct {
 	selCase   SelectorCase
 }
 
+type metaSelector struct {
+	key     string
+	selCase SelectorCase
+}
+
 type mapSelector struct {
 	from      SelectorExpr
 	otherwise string
@@ -163,6 +169,10 @@ func BuildSelectorFromConfig(
 		}
 	}
 
+	if settings.MetaKey != "" {
+		sel = append([]SelectorExpr{MetaSelectorExpr(settings.MetaKey, settings.Case)}, sel...)
+	}
+
 	if settings.FailEmpty && !found {
 		if settings.EnableSingleOnly {
 			return Selector{}, fmt.Errorf("missing required '%v' or '%v' in %v",
@@ -206,6 +216,12 @@ func FmtSelectorExpr(fmt *fmtstr.EventFormatString, fallback string, selCase Sel
 	return &fmtSelector{*fmt, selCase.apply(fallback), selCase}, nil
 }
 
+// MetaSelectorExpr creates a selector expression returning the value of the
+// given event metadata field, or an empty string if it is not set.
+func MetaSelectorExpr(key string, selCase SelectorCase) SelectorExpr {
+	return &metaSelector{key, selCase}
+}
+
 // ConcatSelectorExpr combines multiple expressions that are run one after the other.
 // The first expression that returns a string wins.
 func ConcatSelectorExpr(s ...SelectorExpr) SelectorExpr {
@@ -364,6 +380,14 @@ func (s *constSelector) sel(_ *beat.Event) (string, error) {
 	return s.s, nil
 }
 
+func (s *metaSelector) sel(evt *beat.Event) (string, error) {
+	v, err := events.GetMetaStringValue(*evt, s.key)
+	if err != nil {
+		return "", nil
+	}
+	return s.selCase.apply(v), nil
+}
+
 func (s *fmtSelector) sel(evt *beat.Event) (string, error) {
 	n, err := s.f.Run(evt)
 	if err != nil {
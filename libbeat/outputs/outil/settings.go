@@ -36,6 +36,10 @@ type Settings struct {
 
 	// Case configures the case-sensitivity of generated strings.
 	Case SelectorCase
+
+	// MetaKey is the event metadata field selected before the configured
+	// selectors, if set and the event has it.
+	MetaKey string
 }
 
 // SelectorCase is used to configure a Selector output string casing.
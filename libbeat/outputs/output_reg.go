@@ -77,6 +77,11 @@ type Group struct {
 	//   and clear Content anyway. Metadata about the error should be saved in
 	//   EncodedEvent and reported when Publish is called.
 	EncoderFactory queue.EncoderFactory[publisher.Event]
+
+	// If Autoscale is set, the pipeline starts the first
+	// Autoscale.MinWorkers clients, and starts or stops the others
+	// depending on the fill level of the queue and the publish latency.
+	Autoscale *AutoscaleSettings
 }
 
 // RegisterType registers a new output type.
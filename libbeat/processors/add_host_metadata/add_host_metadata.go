@@ -20,6 +20,10 @@ package add_host_metadata
 import (
 	"context"
 	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -78,6 +82,16 @@ type addHostMetadata struct {
 	logger          *logp.Logger
 	metrics         metrics
 	hostInfoFactory hostInfoFactory
+
+	resolver       resolver
+	interfaceAddrs func() ([]string, error)
+	fqdnCache      fqdnCache
+
+	lifecycleClient    *http.Client
+	lifecycleProviders map[string]lifecycleProvider
+	lifecycleOnce      sync.Once
+	lifecycle          instanceLifecycle
+	lifecycleFound     bool
 }
 
 // New constructs a new add_host_metadata processor.
@@ -86,7 +100,10 @@ func New(cfg *config.C, log *logp.Logger) (beat.Processor, error) {
 	if err := cfg.Unpack(&c); err != nil {
 		return nil, fmt.Errorf("fail to unpack the %v configuration: %w", processorName, err)
 	}
+	return newAddHostMetadata(c, log, func() (hostInfo, error) { return sysinfo.Host() })
+}
 
+func newAddHostMetadata(c Config, log *logp.Logger, factory hostInfoFactory) (*addHostMetadata, error) {
 	p := &addHostMetadata{
 		caches: [2]hostMetadataCache{
 			{data: mapstr.NewPointer(nil)},
@@ -97,7 +114,14 @@ func New(cfg *config.C, log *logp.Logger) (beat.Processor, error) {
 		metrics: metrics{
 			FQDNLookupFailed: monitoring.NewInt(reg, "fqdn_lookup_failed"),
 		},
-		hostInfoFactory: func() (hostInfo, error) { return sysinfo.Host() },
+		hostInfoFactory: factory,
+		resolver:        net.DefaultResolver,
+		interfaceAddrs: func() ([]string, error) {
+			ips, _, err := util.GetNetInfo()
+			return ips, err
+		},
+		lifecycleClient:    &http.Client{},
+		lifecycleProviders: enabledLifecycleProviders(c.CloudLifecycle.Providers),
 	}
 	// Fetch and cache the initial host data.
 	if _, err := p.loadData(features.FQDN()); err != nil {
@@ -192,26 +216,26 @@ func (p *addHostMetadata) fetchData(useFQDN bool) (mapstr.M, error) {
 	hInfo := h.Info()
 	hostname := hInfo.Hostname
 	if useFQDN {
-		ctx, cancel := context.WithTimeout(context.Background(), 1*time.Minute)
-		defer cancel()
-
-		fqdn, err := h.FQDNWithContext(ctx)
-		if err != nil {
-			// FQDN lookup is "best effort". If it fails, we monitor the failure, fallback to
-			// the OS-reported hostname, and move on.
-			p.metrics.FQDNLookupFailed.Inc()
-			p.logger.Warnf(
-				"unable to lookup FQDN (failed attempt counter: %d): %s, using hostname = %s as FQDN",
-				p.metrics.FQDNLookupFailed.Get(),
-				err.Error(),
-				hostname,
-			)
-		} else {
+		if fqdn, err := p.lookupFQDN(h, hostname); err == nil {
 			hostname = fqdn
 		}
 	}
 
 	data := host.MapHostInfo(hInfo, hostname)
+	if p.config.OSDetails {
+		if details := osDetails(hInfo); len(details) != 0 {
+			if _, err := data.Put("host.os.details", details); err != nil {
+				return nil, fmt.Errorf("could not set host.os.details: %w", err)
+			}
+		}
+	}
+	if p.config.CloudLifecycle.Enabled {
+		if l, found := p.instanceLifecycle(); found {
+			if _, err := data.Put("host.instance", l.toMap()); err != nil {
+				return nil, fmt.Errorf("could not set host.instance: %w", err)
+			}
+		}
+	}
 	if p.config.NetInfoEnabled {
 		// IP-address and MAC-address
 		var ipList, hwList, err = util.GetNetInfo()
@@ -240,9 +264,93 @@ func (p *addHostMetadata) fetchData(useFQDN bool) (mapstr.M, error) {
 	return data, nil
 }
 
+// instanceLifecycle detects the cloud instance lifecycle once. Instances
+// cannot change their lifecycle, and hosts outside of a cloud should not pay
+// the metadata service timeout on every refresh.
+func (p *addHostMetadata) instanceLifecycle() (instanceLifecycle, bool) {
+	p.lifecycleOnce.Do(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), p.config.CloudLifecycle.Timeout)
+		defer cancel()
+		p.lifecycle, p.lifecycleFound = detectLifecycle(ctx, p.lifecycleClient, p.lifecycleProviders)
+		if p.lifecycleFound {
+			p.logger.Debugf("detected %v instance with lifecycle %v", p.lifecycle.Provider, p.lifecycle.Lifecycle)
+		} else {
+			p.logger.Debug("no cloud instance lifecycle detected")
+		}
+	})
+	return p.lifecycle, p.lifecycleFound
+}
+
+func enabledLifecycleProviders(names []string) map[string]lifecycleProvider {
+	if len(names) == 0 {
+		return lifecycleProviders
+	}
+	providers := make(map[string]lifecycleProvider, len(names))
+	for _, name := range names {
+		name = strings.ToLower(name)
+		providers[name] = lifecycleProviders[name]
+	}
+	return providers
+}
+
+// osDetails returns the OS version components and the parsed kernel version.
+func osDetails(info types.HostInfo) mapstr.M {
+	details := mapstr.M{}
+	if osInfo := info.OS; osInfo != nil {
+		if osInfo.Major != 0 || osInfo.Minor != 0 || osInfo.Patch != 0 {
+			details["major"] = osInfo.Major
+			details["minor"] = osInfo.Minor
+			details["patch"] = osInfo.Patch
+		}
+		if osInfo.Build != "" {
+			details["build"] = osInfo.Build
+		}
+	}
+	if kernel := parseKernelVersion(info.KernelVersion); len(kernel) != 0 {
+		details["kernel"] = kernel
+	}
+	return details
+}
+
+// parseKernelVersion splits a kernel version such as 5.15.0-91-generic into
+// its numeric components and the remaining build string.
+func parseKernelVersion(v string) mapstr.M {
+	end := strings.IndexFunc(v, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+	if end < 0 {
+		end = len(v)
+	}
+	parts := strings.Split(strings.Trim(v[:end], "."), ".")
+	kernel := mapstr.M{}
+	for i, key := range []string{"major", "minor", "patch"} {
+		if i >= len(parts) {
+			break
+		}
+		n, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return nil
+		}
+		kernel[key] = n
+	}
+	if len(kernel) == 0 {
+		return nil
+	}
+
+	var rest []string
+	if len(parts) > 3 {
+		rest = append(rest, strings.Join(parts[3:], "."))
+	}
+	if suffix := strings.TrimLeft(v[end:], "-+_ ."); suffix != "" {
+		rest = append(rest, suffix)
+	}
+	if len(rest) != 0 {
+		kernel["build"] = strings.Join(rest, "-")
+	}
+	return kernel
+}
+
 func (p *addHostMetadata) String() string {
-	return fmt.Sprintf("%v=[netinfo.enabled=[%v], cache.ttl=[%v]]",
-		processorName, p.config.NetInfoEnabled, p.config.CacheTTL)
+	return fmt.Sprintf("%v=[netinfo.enabled=[%v], cache.ttl=[%v], fqdn.strategy=[%v], os.details=[%v], cloud_lifecycle.enabled=[%v]]",
+		processorName, p.config.NetInfoEnabled, p.config.CacheTTL, p.config.FQDN.Strategy, p.config.OSDetails, p.config.CloudLifecycle.Enabled)
 }
 
 func skipAddingHostMetadata(event *beat.Event) bool {
@@ -21,6 +21,8 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"runtime"
 	"sync"
 	"sync/atomic"
@@ -30,9 +32,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
-	"github.com/elastic/beats/v7/libbeat/processors/util"
 	"github.com/elastic/elastic-agent-libs/logp"
-	"github.com/elastic/elastic-agent-libs/monitoring"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/features"
@@ -736,6 +736,275 @@ func TestFQDNLookup(t *testing.T) {
 	}
 }
 
+func TestFQDNStrategy(t *testing.T) {
+	tests := map[string]struct {
+		strategy string
+		resolver *mockResolver
+		addrs    []string
+		expected string
+	}{
+		"system": {
+			strategy: "system",
+			resolver: &mockResolver{},
+			expected: "system.example.com",
+		},
+		"cname": {
+			strategy: "cname",
+			resolver: &mockResolver{CNAME: "cname.example.com."},
+			expected: "cname.example.com",
+		},
+		"cname_fails": {
+			strategy: "cname",
+			resolver: &mockResolver{Err: errors.New("no such host")},
+			expected: "placeholder",
+		},
+		"reverse": {
+			strategy: "reverse",
+			resolver: &mockResolver{Addrs: map[string][]string{
+				"127.0.0.1": {"localhost."},
+				"10.0.0.5":  {"reverse.example.com."},
+			}},
+			addrs:    []string{"127.0.0.1", "fe80::1", "10.0.0.5"},
+			expected: "reverse.example.com",
+		},
+		"reverse_no_records": {
+			strategy: "reverse",
+			resolver: &mockResolver{},
+			addrs:    []string{"10.0.0.5"},
+			expected: "placeholder",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := newFQDNTestProcessor(t, map[string]interface{}{
+				"fqdn.strategy": test.strategy,
+			}, test.resolver, test.addrs)
+
+			event, err := p.Run(&beat.Event{Fields: mapstr.M{}})
+			require.NoError(t, err)
+			v, err := event.GetValue("host.name")
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, v)
+		})
+	}
+}
+
+func TestFQDNCache(t *testing.T) {
+	tests := map[string]struct {
+		config   map[string]interface{}
+		err      error
+		expected int64
+	}{
+		"success_cached": {
+			config:   map[string]interface{}{"fqdn.cache.ttl": "1h"},
+			expected: 1,
+		},
+		"success_not_cached": {
+			config:   map[string]interface{}{},
+			expected: 3,
+		},
+		"failure_cached": {
+			config:   map[string]interface{}{"fqdn.cache.failure_ttl": "1h"},
+			err:      errors.New("no such host"),
+			expected: 1,
+		},
+		"failure_not_cached": {
+			config:   map[string]interface{}{"fqdn.cache.ttl": "1h"},
+			err:      errors.New("no such host"),
+			expected: 3,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := map[string]interface{}{
+				"cache.ttl":     -1, // Refresh the host metadata on every event.
+				"fqdn.strategy": "cname",
+			}
+			for k, v := range test.config {
+				cfg[k] = v
+			}
+			r := &mockResolver{CNAME: "cname.example.com", Err: test.err}
+			p := newFQDNTestProcessor(t, cfg, r, nil)
+
+			for i := 0; i < 3; i++ {
+				_, err := p.Run(&beat.Event{Fields: mapstr.M{}})
+				require.NoError(t, err)
+			}
+			assert.Equal(t, test.expected, r.Requests.Load())
+		})
+	}
+}
+
+func TestOSDetails(t *testing.T) {
+	testConfig := conf.MustNewConfigFrom(map[string]interface{}{
+		"os.details": true,
+	})
+	factory := func() (hostInfo, error) {
+		return &mockHostInfo{
+			Hostname:      hostName,
+			OS:            &types.OSInfo{Major: 22, Minor: 4, Patch: 3, Build: "22.04.3"},
+			KernelVersion: "5.15.0-91-generic",
+		}, nil
+	}
+	p, err := newWithHostInfoFactory(testConfig, logptest.NewTestingLogger(t, ""), factory)
+	require.NoError(t, err)
+
+	event, err := p.Run(&beat.Event{Fields: mapstr.M{}})
+	require.NoError(t, err)
+	v, err := event.GetValue("host.os.details")
+	require.NoError(t, err)
+	assert.Equal(t, mapstr.M{
+		"major": 22,
+		"minor": 4,
+		"patch": 3,
+		"build": "22.04.3",
+		"kernel": mapstr.M{
+			"major": 5,
+			"minor": 15,
+			"patch": 0,
+			"build": "91-generic",
+		},
+	}, v)
+}
+
+func TestParseKernelVersion(t *testing.T) {
+	tests := map[string]mapstr.M{
+		"5.15.0-91-generic":     {"major": 5, "minor": 15, "patch": 0, "build": "91-generic"},
+		"6.1.55+":               {"major": 6, "minor": 1, "patch": 55},
+		"23.1.0":                {"major": 23, "minor": 1, "patch": 0},
+		"10.0.19045.3803":       {"major": 10, "minor": 0, "patch": 19045, "build": "3803"},
+		"4.18.0-513.el8.x86_64": {"major": 4, "minor": 18, "patch": 0, "build": "513.el8.x86_64"},
+		"":                      nil,
+		"unknown":               nil,
+	}
+	for version, expected := range tests {
+		assert.Equal(t, expected, parseKernelVersion(version), version)
+	}
+}
+
+func TestCloudLifecycle(t *testing.T) {
+	tests := map[string]struct {
+		provider string
+		fetch    lifecycleFetcher
+		handler  http.HandlerFunc
+		expected instanceLifecycle
+	}{
+		"aws_spot": {
+			provider: "aws",
+			fetch:    fetchAWSLifecycle,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodPut && r.URL.Path == "/latest/api/token":
+					_, _ = w.Write([]byte("token"))
+				case r.URL.Path == "/latest/meta-data/instance-life-cycle" && r.Header.Get("X-Aws-Ec2-Metadata-Token") == "token":
+					_, _ = w.Write([]byte("spot"))
+				default:
+					http.NotFound(w, r)
+				}
+			},
+			expected: instanceLifecycle{Provider: "aws", Lifecycle: "spot", Preemptible: true},
+		},
+		"aws_imdsv1": {
+			provider: "aws",
+			fetch:    fetchAWSLifecycle,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/latest/meta-data/instance-life-cycle" {
+					_, _ = w.Write([]byte("on-demand"))
+					return
+				}
+				http.NotFound(w, r)
+			},
+			expected: instanceLifecycle{Provider: "aws", Lifecycle: "on-demand"},
+		},
+		"azure_spot": {
+			provider: "azure",
+			fetch:    fetchAzureLifecycle,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/metadata/instance/compute/priority" && r.Header.Get("Metadata") == "true" {
+					_, _ = w.Write([]byte("Spot"))
+					return
+				}
+				http.NotFound(w, r)
+			},
+			expected: instanceLifecycle{Provider: "azure", Lifecycle: "spot", Preemptible: true},
+		},
+		"gcp_standard": {
+			provider: "gcp",
+			fetch:    fetchGCPLifecycle,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/computeMetadata/v1/instance/scheduling/provisioning-model" && r.Header.Get("Metadata-Flavor") == "Google" {
+					_, _ = w.Write([]byte("STANDARD"))
+					return
+				}
+				http.NotFound(w, r)
+			},
+			expected: instanceLifecycle{Provider: "gcp", Lifecycle: "standard"},
+		},
+		"gcp_legacy_preemptible": {
+			provider: "gcp",
+			fetch:    fetchGCPLifecycle,
+			handler: func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/computeMetadata/v1/instance/scheduling/preemptible" {
+					_, _ = w.Write([]byte("TRUE"))
+					return
+				}
+				http.NotFound(w, r)
+			},
+			expected: instanceLifecycle{Provider: "gcp", Lifecycle: "preemptible", Preemptible: true},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			srv := httptest.NewServer(test.handler)
+			defer srv.Close()
+
+			p, err := newWithHostInfoFactory(conf.MustNewConfigFrom(map[string]interface{}{
+				"cache.ttl": -1,
+			}), logptest.NewTestingLogger(t, ""), func() (hostInfo, error) {
+				return &mockHostInfo{Hostname: hostName}, nil
+			})
+			require.NoError(t, err)
+			hp := p.(*addHostMetadata)
+			hp.config.CloudLifecycle.Enabled = true
+			hp.lifecycleProviders = map[string]lifecycleProvider{
+				test.provider: {baseURL: srv.URL, fetch: test.fetch},
+				// A provider that is not available must not hide the result.
+				"unavailable": {baseURL: srv.URL + "/unavailable", fetch: test.fetch},
+			}
+
+			event, err := p.Run(&beat.Event{Fields: mapstr.M{}})
+			require.NoError(t, err)
+			v, err := event.GetValue("host.instance")
+			require.NoError(t, err)
+			assert.Equal(t, test.expected.toMap(), v)
+		})
+	}
+}
+
+func newFQDNTestProcessor(t *testing.T, cfg map[string]interface{}, r resolver, addrs []string) *addHostMetadata {
+	t.Helper()
+
+	// Create the processor with FQDN disabled so that the mocks are used
+	// for the first FQDN lookup.
+	require.NoError(t, features.UpdateFromConfig(fqdnFeatureFlagConfig(false)))
+	p, err := newWithHostInfoFactory(conf.MustNewConfigFrom(cfg), logptest.NewTestingLogger(t, ""), func() (hostInfo, error) {
+		return &mockHostInfo{Hostname: "placeholder", FQDN: "system.example.com"}, nil
+	})
+	require.NoError(t, err)
+	hp := p.(*addHostMetadata)
+	hp.resolver = r
+	hp.interfaceAddrs = func() ([]string, error) { return addrs, nil }
+
+	require.NoError(t, features.UpdateFromConfig(fqdnFeatureFlagConfig(true)))
+	t.Cleanup(func() {
+		require.NoError(t, features.UpdateFromConfig(fqdnFeatureFlagConfig(false)))
+	})
+	return hp
+}
+
 func fqdnFeatureFlagConfig(fqdnEnabled bool) *conf.C {
 	return conf.MustNewConfigFrom(map[string]interface{}{
 		"features.fqdn.enabled": fqdnEnabled,
@@ -746,6 +1015,8 @@ type mockHostInfo struct {
 	FQDN                 string
 	Hostname             string
 	FQDNErr              error
+	OS                   *types.OSInfo
+	KernelVersion        string
 	FQDNRequestCount     atomic.Int64
 	HostInfoRequestCount atomic.Int64
 }
@@ -754,9 +1025,14 @@ var _ hostInfo = &mockHostInfo{}
 
 func (m *mockHostInfo) Info() types.HostInfo {
 	m.HostInfoRequestCount.Add(1)
+	os := m.OS
+	if os == nil {
+		os = &types.OSInfo{}
+	}
 	return types.HostInfo{
-		Hostname: m.Hostname,
-		OS:       &types.OSInfo{},
+		Hostname:      m.Hostname,
+		OS:            os,
+		KernelVersion: m.KernelVersion,
 	}
 }
 
@@ -774,30 +1050,27 @@ func newWithHostInfoFactory(cfg *conf.C, log *logp.Logger, factory hostInfoFacto
 	if err := cfg.Unpack(&c); err != nil {
 		return nil, fmt.Errorf("fail to unpack the %v configuration: %w", processorName, err)
 	}
+	return newAddHostMetadata(c, log, factory)
+}
 
-	p := &addHostMetadata{
-		config: c,
-		caches: [2]hostMetadataCache{
-			{data: mapstr.NewPointer(nil)},
-			{data: mapstr.NewPointer(nil)},
-		},
-		logger: log.Named(logName),
-		metrics: metrics{
-			FQDNLookupFailed: monitoring.NewInt(reg, "fqdn_lookup_failed"),
-		},
-		hostInfoFactory: factory,
-	}
-	if _, err := p.loadData(features.FQDN()); err != nil {
-		return nil, fmt.Errorf("failed to load data: %w", err)
-	}
+type mockResolver struct {
+	CNAME    string
+	Addrs    map[string][]string
+	Err      error
+	Requests atomic.Int64
+}
 
-	if c.Geo != nil {
-		geoFields, err := util.GeoConfigToMap(*c.Geo)
-		if err != nil {
-			return nil, err
-		}
-		p.geoData = mapstr.M{"host": mapstr.M{"geo": geoFields}}
-	}
+var _ resolver = &mockResolver{}
 
-	return p, nil
+func (m *mockResolver) LookupCNAME(_ context.Context, _ string) (string, error) {
+	m.Requests.Add(1)
+	return m.CNAME, m.Err
+}
+
+func (m *mockResolver) LookupAddr(_ context.Context, addr string) ([]string, error) {
+	m.Requests.Add(1)
+	if m.Err != nil {
+		return nil, m.Err
+	}
+	return m.Addrs[addr], nil
 }
@@ -18,7 +18,9 @@
 package add_host_metadata
 
 import (
+	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/processors/util"
@@ -32,6 +34,70 @@ type Config struct {
 	Geo                 *util.GeoConfig `config:"geo"`
 	Name                string          `config:"name"`
 	ReplaceFields       bool            `config:"replace_fields"` // replace existing host fields with add_host_metadata
+	OSDetails           bool            `config:"os.details"`     // Add OS and kernel version components
+	FQDN                FQDNConfig      `config:"fqdn"`
+	CloudLifecycle      LifecycleConfig `config:"cloud_lifecycle"`
+}
+
+// FQDNConfig controls how the FQDN is resolved when the fqdn feature flag is
+// enabled.
+type FQDNConfig struct {
+	Strategy   fqdnStrategy  `config:"strategy"`
+	Timeout    time.Duration `config:"timeout" validate:"min=1ns"`
+	SuccessTTL time.Duration `config:"cache.ttl"`         // How long a resolved FQDN is reused, 0 resolves on every refresh.
+	FailureTTL time.Duration `config:"cache.failure_ttl"` // How long a failed lookup is remembered, 0 retries on every refresh.
+}
+
+// fqdnStrategy selects the method used to resolve the FQDN.
+type fqdnStrategy uint8
+
+const (
+	fqdnSystem  fqdnStrategy = iota // CNAME lookup of the hostname, then reverse lookup of its addresses.
+	fqdnCNAME                       // CNAME lookup of the hostname only.
+	fqdnReverse                     // Reverse lookup of the host interface addresses only.
+)
+
+var fqdnStrategyNames = map[fqdnStrategy]string{
+	fqdnSystem:  "system",
+	fqdnCNAME:   "cname",
+	fqdnReverse: "reverse",
+}
+
+// String returns the FQDN strategy name.
+func (s fqdnStrategy) String() string {
+	if name, found := fqdnStrategyNames[s]; found {
+		return name
+	}
+	return fmt.Sprintf("unknown (%d)", uint8(s))
+}
+
+// Unpack unpacks a string to a fqdnStrategy.
+func (s *fqdnStrategy) Unpack(v string) error {
+	for strategy, name := range fqdnStrategyNames {
+		if strings.EqualFold(v, name) {
+			*s = strategy
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid fqdn strategy '%v' (valid values are: system, cname, reverse)", v)
+}
+
+// LifecycleConfig controls the detection of spot and preemptible cloud
+// instances.
+type LifecycleConfig struct {
+	Enabled   bool          `config:"enabled"`
+	Providers []string      `config:"providers"` // Providers to query, all providers when empty.
+	Timeout   time.Duration `config:"timeout" validate:"min=1ns"`
+}
+
+// Validate validates the cloud_lifecycle settings.
+func (c *LifecycleConfig) Validate() error {
+	for _, name := range c.Providers {
+		if _, found := lifecycleProviders[strings.ToLower(name)]; !found {
+			return fmt.Errorf("unknown cloud_lifecycle provider '%v' (valid values are: aws, azure, gcp)", name)
+		}
+	}
+	return nil
 }
 
 func defaultConfig() Config {
@@ -46,6 +112,8 @@ func defaultConfig() Config {
 			CacheTTL:            5 * time.Minute,
 			ExpireUpdateTimeout: time.Second * 10,
 			ReplaceFields:       true,
+			FQDN:                defaultFQDNConfig(),
+			CloudLifecycle:      defaultLifecycleConfig(),
 		}
 	} else {
 		return Config{
@@ -53,6 +121,21 @@ func defaultConfig() Config {
 			CacheTTL:            5 * time.Minute,
 			ExpireUpdateTimeout: time.Second * 10,
 			ReplaceFields:       true,
+			FQDN:                defaultFQDNConfig(),
+			CloudLifecycle:      defaultLifecycleConfig(),
 		}
 	}
 }
+
+func defaultFQDNConfig() FQDNConfig {
+	return FQDNConfig{
+		Strategy: fqdnSystem,
+		Timeout:  time.Minute,
+	}
+}
+
+func defaultLifecycleConfig() LifecycleConfig {
+	return LifecycleConfig{
+		Timeout: 3 * time.Second,
+	}
+}
@@ -0,0 +1,122 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package add_host_metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// resolver is the subset of *net.Resolver used for FQDN lookups.
+type resolver interface {
+	LookupCNAME(ctx context.Context, host string) (string, error)
+	LookupAddr(ctx context.Context, addr string) ([]string, error)
+}
+
+// fqdnCache holds the result of the last FQDN lookup. It is only accessed
+// while holding the lock of the FQDN host metadata cache.
+type fqdnCache struct {
+	fqdn    string
+	err     error
+	expires time.Time
+}
+
+// lookupFQDN resolves the FQDN using the configured strategy. Results are
+// reused for fqdn.cache.ttl after a successful lookup and for
+// fqdn.cache.failure_ttl after a failed one.
+func (p *addHostMetadata) lookupFQDN(h hostInfo, hostname string) (string, error) {
+	now := time.Now()
+	if now.Before(p.fqdnCache.expires) {
+		return p.fqdnCache.fqdn, p.fqdnCache.err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), p.config.FQDN.Timeout)
+	defer cancel()
+
+	ttl := p.config.FQDN.SuccessTTL
+	fqdn, err := p.resolveFQDN(ctx, h, hostname)
+	if err != nil {
+		ttl = p.config.FQDN.FailureTTL
+		// FQDN lookup is "best effort". If it fails, we monitor the failure, fallback to
+		// the OS-reported hostname, and move on.
+		p.metrics.FQDNLookupFailed.Inc()
+		p.logger.Warnf(
+			"unable to lookup FQDN (failed attempt counter: %d): %s, using hostname = %s as FQDN",
+			p.metrics.FQDNLookupFailed.Get(),
+			err.Error(),
+			hostname,
+		)
+	}
+	p.fqdnCache = fqdnCache{fqdn: fqdn, err: err, expires: now.Add(ttl)}
+	return fqdn, err
+}
+
+func (p *addHostMetadata) resolveFQDN(ctx context.Context, h hostInfo, hostname string) (string, error) {
+	switch p.config.FQDN.Strategy {
+	case fqdnCNAME:
+		cname, err := p.resolver.LookupCNAME(ctx, hostname)
+		if err != nil {
+			return "", fmt.Errorf("could not get FQDN for host %q with CNAME lookup: %w", hostname, err)
+		}
+		return trimDot(cname), nil
+	case fqdnReverse:
+		return p.reverseLookupFQDN(ctx)
+	default:
+		return h.FQDNWithContext(ctx)
+	}
+}
+
+// reverseLookupFQDN returns the first name found by a reverse lookup of the
+// host's interface addresses.
+func (p *addHostMetadata) reverseLookupFQDN(ctx context.Context) (string, error) {
+	ips, err := p.interfaceAddrs()
+	if len(ips) == 0 {
+		if err == nil {
+			err = errors.New("no interface addresses found")
+		}
+		return "", fmt.Errorf("could not get FQDN with reverse lookup: %w", err)
+	}
+
+	var errs []error
+	for _, s := range ips {
+		ip := net.ParseIP(s)
+		if ip == nil || ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsUnspecified() {
+			continue
+		}
+		names, err := p.resolver.LookupAddr(ctx, s)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if len(names) != 0 {
+			return trimDot(names[0]), nil
+		}
+	}
+	if len(errs) == 0 {
+		errs = append(errs, errors.New("no PTR records found"))
+	}
+	return "", fmt.Errorf("could not get FQDN with reverse lookup: %w", errors.Join(errs...))
+}
+
+func trimDot(name string) string {
+	return strings.TrimSuffix(name, ".")
+}
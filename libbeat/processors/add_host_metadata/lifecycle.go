@@ -0,0 +1,173 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package add_host_metadata
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// instanceLifecycle describes how a cloud instance is provisioned.
+type instanceLifecycle struct {
+	Provider    string
+	Lifecycle   string // For example spot, on-demand or standard.
+	Preemptible bool
+}
+
+func (l instanceLifecycle) toMap() mapstr.M {
+	return mapstr.M{
+		"provider":    l.Provider,
+		"lifecycle":   l.Lifecycle,
+		"preemptible": l.Preemptible,
+	}
+}
+
+// lifecycleFetcher queries the metadata service of a cloud provider.
+type lifecycleFetcher func(ctx context.Context, client *http.Client, baseURL string) (instanceLifecycle, error)
+
+type lifecycleProvider struct {
+	baseURL string
+	fetch   lifecycleFetcher
+}
+
+var lifecycleProviders = map[string]lifecycleProvider{
+	"aws":   {baseURL: "http://169.254.169.254", fetch: fetchAWSLifecycle},
+	"azure": {baseURL: "http://169.254.169.254", fetch: fetchAzureLifecycle},
+	"gcp":   {baseURL: "http://metadata.google.internal", fetch: fetchGCPLifecycle},
+}
+
+// detectLifecycle queries the given providers concurrently and returns the
+// result of the first one that answers. It returns false if the host is not
+// running on any of them.
+func detectLifecycle(ctx context.Context, client *http.Client, providers map[string]lifecycleProvider) (instanceLifecycle, bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make(chan instanceLifecycle, len(providers))
+	for name, provider := range providers {
+		go func() {
+			l, err := provider.fetch(ctx, client, provider.baseURL)
+			if err != nil {
+				results <- instanceLifecycle{}
+				return
+			}
+			l.Provider = name
+			results <- l
+		}()
+	}
+	for range providers {
+		if l := <-results; l.Provider != "" {
+			return l, true
+		}
+	}
+	return instanceLifecycle{}, false
+}
+
+// fetchAWSLifecycle uses IMDSv2 when a session token can be obtained and
+// falls back to IMDSv1 otherwise.
+func fetchAWSLifecycle(ctx context.Context, client *http.Client, baseURL string) (instanceLifecycle, error) {
+	header := http.Header{}
+	token, err := metadataRequest(ctx, client, http.MethodPut, baseURL+"/latest/api/token",
+		http.Header{"X-Aws-Ec2-Metadata-Token-Ttl-Seconds": {"60"}})
+	if err == nil {
+		header.Set("X-Aws-Ec2-Metadata-Token", token)
+	}
+	lifecycle, err := metadataRequest(ctx, client, http.MethodGet, baseURL+"/latest/meta-data/instance-life-cycle", header)
+	if err != nil {
+		return instanceLifecycle{}, err
+	}
+	return instanceLifecycle{
+		Lifecycle:   lifecycle,
+		Preemptible: lifecycle == "spot",
+	}, nil
+}
+
+// fetchAzureLifecycle reads the priority of the VM, which is Regular, Low or
+// Spot.
+func fetchAzureLifecycle(ctx context.Context, client *http.Client, baseURL string) (instanceLifecycle, error) {
+	priority, err := metadataRequest(ctx, client, http.MethodGet,
+		baseURL+"/metadata/instance/compute/priority?api-version=2021-02-01&format=text",
+		http.Header{"Metadata": {"true"}})
+	if err != nil {
+		return instanceLifecycle{}, err
+	}
+	lifecycle := strings.ToLower(priority)
+	if lifecycle == "" {
+		lifecycle = "regular"
+	}
+	return instanceLifecycle{
+		Lifecycle:   lifecycle,
+		Preemptible: lifecycle == "spot" || lifecycle == "low",
+	}, nil
+}
+
+// fetchGCPLifecycle reads the provisioning model of the instance and falls
+// back to the preemptible flag of legacy preemptible VMs.
+func fetchGCPLifecycle(ctx context.Context, client *http.Client, baseURL string) (instanceLifecycle, error) {
+	header := http.Header{"Metadata-Flavor": {"Google"}}
+	model, err := metadataRequest(ctx, client, http.MethodGet,
+		baseURL+"/computeMetadata/v1/instance/scheduling/provisioning-model", header)
+	if err == nil {
+		lifecycle := strings.ToLower(model)
+		return instanceLifecycle{
+			Lifecycle:   lifecycle,
+			Preemptible: lifecycle == "spot",
+		}, nil
+	}
+	preemptible, err := metadataRequest(ctx, client, http.MethodGet,
+		baseURL+"/computeMetadata/v1/instance/scheduling/preemptible", header)
+	if err != nil {
+		return instanceLifecycle{}, err
+	}
+	if strings.EqualFold(preemptible, "true") {
+		return instanceLifecycle{Lifecycle: "preemptible", Preemptible: true}, nil
+	}
+	return instanceLifecycle{Lifecycle: "standard"}, nil
+}
+
+// maxMetadataResponseSize limits the size of metadata service responses.
+const maxMetadataResponseSize = 1024
+
+var errMetadataStatus = errors.New("unexpected metadata service response")
+
+func metadataRequest(ctx context.Context, client *http.Client, method, url string, header http.Header) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header = header
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: %v returned %v", errMetadataStatus, url, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataResponseSize))
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(body)), nil
+}
@@ -0,0 +1,295 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregate
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/gohugoio/hashstructure"
+	"github.com/jonboulle/clockwork"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	procName = "aggregate"
+	logName  = "processor." + procName
+)
+
+// errSplitUnsupported is returned when the processor is run by a caller that
+// can only handle a single resulting event.
+var errSplitUnsupported = errors.New("aggregate processor requires a processor chain that supports multiple events")
+
+func init() {
+	// Not registered as a JS plugin because the script processor can only
+	// return a single event.
+	processors.RegisterPlugin(procName, New)
+}
+
+// group accumulates the events sharing the same group_by values.
+type group struct {
+	key   uint64
+	first *beat.Event // Fields of the summary event.
+	start time.Time   // Processing time of the first event, the window start.
+
+	count     int
+	sums      map[string]float64
+	last      mapstr.M
+	firstSeen time.Time // Timestamp of the first event.
+	lastSeen  time.Time // Timestamp of the last event.
+}
+
+type processor struct {
+	config
+	log   *logp.Logger
+	clock clockwork.Clock
+
+	mu sync.Mutex
+	// groups indexes open groups by key. order holds the same groups by
+	// window start, oldest first. All windows have the same length so the
+	// front of order is always the next group to expire.
+	groups map[uint64]*list.Element
+	order  *list.List
+}
+
+// New constructs a new aggregate processor.
+func New(cfg *conf.C, log *logp.Logger) (beat.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("fail to unpack the %v processor configuration: %w", procName, err)
+	}
+
+	return newAggregate(c, log), nil
+}
+
+func newAggregate(c config, log *logp.Logger) *processor {
+	log = log.Named(logName)
+	log.Warn(cfgwarn.Beta("The " + procName + " processor is beta."))
+	return &processor{
+		config: c,
+		log:    log,
+		clock:  clockwork.NewRealClock(),
+		groups: make(map[uint64]*list.Element),
+		order:  list.New(),
+	}
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("%v=[group_by=%v, window=%v, max_groups=%d, sum_fields=%v, last_fields=%v, target_field=%v]",
+		procName, p.GroupBy, p.Window, p.MaxGroups, p.SumFields, p.LastFields, p.TargetField)
+}
+
+// Run returns an error because summary events can only be emitted by a
+// caller that supports multiple events. The publisher pipeline uses RunSplit.
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	return event, errSplitUnsupported
+}
+
+// RunSplit adds the event to its group and drops it. It returns the summary
+// events of the groups whose window has ended.
+func (p *processor) RunSplit(event *beat.Event) ([]*beat.Event, error) {
+	key, err := p.makeKey(event)
+	if err != nil {
+		return []*beat.Event{event}, fmt.Errorf("could not make key: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.clock.Now()
+	out := p.expire(now)
+
+	elem, ok := p.groups[key]
+	if !ok {
+		if p.order.Len() >= p.MaxGroups {
+			p.log.Debugw("Maximum number of groups reached, emitting the oldest group early.", "max_groups", p.MaxGroups)
+			out = append(out, p.emit(p.order.Front()))
+		}
+		elem = p.order.PushBack(p.newGroup(key, event, now))
+		p.groups[key] = elem
+	}
+	p.add(elem.Value.(*group), event)
+	return out, nil
+}
+
+// Flush emits all open groups.
+func (p *processor) Flush() []*beat.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var out []*beat.Event
+	for p.order.Len() > 0 {
+		out = append(out, p.emit(p.order.Front()))
+	}
+	return out
+}
+
+// Expires reports that the processor holds events back for a limited time,
+// so the publishing client emits the groups whose window has ended even when
+// no event is processed.
+func (p *processor) Expires() bool { return true }
+
+// Expire emits the groups whose window has ended.
+func (p *processor) Expire() []*beat.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.expire(p.clock.Now())
+}
+
+// Close discards the groups still open. The publishing client flushes its
+// processors before closing them, so groups are only left when the processor
+// is not flushed by a client, as the global processors.
+func (p *processor) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if n := p.order.Len(); n > 0 {
+		p.log.Warnw("Discarding open groups on close.", "groups", n)
+	}
+	p.groups = make(map[uint64]*list.Element)
+	p.order.Init()
+	return nil
+}
+
+func (p *processor) makeKey(event *beat.Event) (uint64, error) {
+	values := make([]string, 0, len(p.GroupBy))
+	for _, field := range p.GroupBy {
+		value, err := event.GetValue(field)
+		if err != nil {
+			if !errors.Is(err, mapstr.ErrKeyNotFound) {
+				return 0, fmt.Errorf("error getting value of field '%v': %w", field, err)
+			}
+			value = ""
+		}
+		values = append(values, fmt.Sprintf("%v", value))
+	}
+	return hashstructure.Hash(values, nil)
+}
+
+func (p *processor) expire(now time.Time) []*beat.Event {
+	var out []*beat.Event
+	for front := p.order.Front(); front != nil; front = p.order.Front() {
+		if now.Sub(front.Value.(*group).start) < p.Window {
+			break
+		}
+		out = append(out, p.emit(front))
+	}
+	return out
+}
+
+func (p *processor) newGroup(key uint64, event *beat.Event, now time.Time) *group {
+	return &group{
+		key:       key,
+		first:     event,
+		start:     now,
+		sums:      make(map[string]float64, len(p.SumFields)),
+		firstSeen: event.Timestamp,
+	}
+}
+
+func (p *processor) add(g *group, event *beat.Event) {
+	g.count++
+	g.lastSeen = event.Timestamp
+	for _, field := range p.SumFields {
+		v, err := event.GetValue(field)
+		if err != nil {
+			continue
+		}
+		if f, ok := toFloat(v); ok {
+			g.sums[field] += f
+		}
+	}
+	if len(p.LastFields) > 0 {
+		g.last = make(mapstr.M, len(p.LastFields))
+		for _, field := range p.LastFields {
+			if v, err := event.GetValue(field); err == nil {
+				g.last[field] = v
+			}
+		}
+	}
+}
+
+// emit removes the group and returns its summary event.
+func (p *processor) emit(elem *list.Element) *beat.Event {
+	g := p.order.Remove(elem).(*group)
+	delete(p.groups, g.key)
+
+	// The first event has already been reported to the input as dropped,
+	// don't let the summary report its state a second time.
+	event := g.first
+	event.Private = nil
+	for field, v := range g.last {
+		_, _ = event.PutValue(field, v)
+	}
+	stats := mapstr.M{
+		"count": g.count,
+		"first": g.firstSeen,
+		"last":  g.lastSeen,
+	}
+	if len(g.sums) > 0 {
+		sums := mapstr.M{}
+		for field, v := range g.sums {
+			_, _ = sums.Put(field, v)
+		}
+		stats["sum"] = sums
+	}
+	if _, err := event.PutValue(p.TargetField, stats); err != nil {
+		p.log.Debugw("Failed to write aggregation statistics.", "error", err)
+	}
+	return event
+}
+
+func toFloat(v any) (float64, bool) {
+	switch v := v.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,169 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregate
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func newTestAggregate(t *testing.T, cfg mapstr.M) (*processor, *clockwork.FakeClock) {
+	t.Helper()
+	c := defaultConfig()
+	require.NoError(t, conf.MustNewConfigFrom(cfg).Unpack(&c), "failed to unpack config")
+	p := newAggregate(c, logptest.NewTestingLogger(t, ""))
+	clock := clockwork.NewFakeClock()
+	p.clock = clock
+	return p, clock
+}
+
+func connEvent(ts time.Time, src, state string, bytes int) *beat.Event {
+	return &beat.Event{
+		Timestamp: ts,
+		Fields: mapstr.M{
+			"source":  mapstr.M{"ip": src},
+			"network": mapstr.M{"bytes": bytes},
+			"state":   state,
+		},
+		Private: src,
+	}
+}
+
+func TestAggregateWindow(t *testing.T) {
+	p, clock := newTestAggregate(t, mapstr.M{
+		"group_by":    []string{"source.ip"},
+		"window":      "1m",
+		"sum_fields":  []string{"network.bytes"},
+		"last_fields": []string{"state"},
+	})
+	t0 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for i, e := range []*beat.Event{
+		connEvent(t0, "10.0.0.1", "open", 10),
+		connEvent(t0.Add(time.Second), "10.0.0.2", "open", 1),
+		connEvent(t0.Add(2*time.Second), "10.0.0.1", "closed", 20),
+	} {
+		out, err := p.RunSplit(e)
+		require.NoError(t, err, "unexpected error on event %d", i)
+		assert.Empty(t, out, "events must be held back until the window ends")
+	}
+
+	clock.Advance(time.Minute)
+	out, err := p.RunSplit(connEvent(t0.Add(time.Minute), "10.0.0.3", "open", 5))
+	require.NoError(t, err, "unexpected error")
+	require.Len(t, out, 2, "expected both expired groups")
+
+	assert.Equal(t, mapstr.M{
+		"source":  mapstr.M{"ip": "10.0.0.1"},
+		"network": mapstr.M{"bytes": 10},
+		"state":   "closed",
+		"aggregate": mapstr.M{
+			"count": 2,
+			"first": t0,
+			"last":  t0.Add(2 * time.Second),
+			"sum":   mapstr.M{"network": mapstr.M{"bytes": 30.0}},
+		},
+	}, out[0].Fields, "unexpected summary event")
+	assert.Nil(t, out[0].Private, "summary events must not carry input state")
+	assert.Equal(t, 1, out[1].Fields["aggregate"].(mapstr.M)["count"], "unexpected count of second group")
+
+	flushed := p.Flush()
+	require.Len(t, flushed, 1, "flush must emit the open group")
+	assert.Equal(t, "10.0.0.3", flushed[0].Fields["source"].(mapstr.M)["ip"], "unexpected flushed group")
+	assert.Empty(t, p.Flush(), "flush must empty the processor")
+}
+
+func TestAggregateExpire(t *testing.T) {
+	p, clock := newTestAggregate(t, mapstr.M{"group_by": []string{"source.ip"}, "window": "1m"})
+	require.True(t, processors.Expires(p), "the processor must be expired by the client")
+
+	_, err := p.RunSplit(connEvent(time.Now(), "a", "", 0))
+	require.NoError(t, err, "unexpected error")
+	assert.Empty(t, p.Expire(), "the group must be held back until the window ends")
+
+	clock.Advance(time.Minute)
+	expired := p.Expire()
+	require.Len(t, expired, 1, "the group must be emitted without another event")
+	assert.Equal(t, "a", expired[0].Fields["source"].(mapstr.M)["ip"], "unexpected expired group")
+	assert.Empty(t, p.Flush(), "the expired group must not be flushed again")
+}
+
+func TestAggregateMaxGroups(t *testing.T) {
+	p, _ := newTestAggregate(t, mapstr.M{"group_by": []string{"source.ip"}, "max_groups": 2})
+	now := time.Now()
+
+	var emitted []*beat.Event
+	for _, ip := range []string{"a", "b", "a", "c"} {
+		out, err := p.RunSplit(connEvent(now, ip, "", 0))
+		require.NoError(t, err, "unexpected error")
+		emitted = append(emitted, out...)
+	}
+	require.Len(t, emitted, 1, "oldest group must be emitted when the limit is reached")
+	assert.Equal(t, "a", emitted[0].Fields["source"].(mapstr.M)["ip"], "unexpected evicted group")
+	assert.Equal(t, 2, emitted[0].Fields["aggregate"].(mapstr.M)["count"], "unexpected count of evicted group")
+	assert.Equal(t, 2, p.order.Len(), "unexpected number of open groups")
+}
+
+func TestAggregateFlushThroughList(t *testing.T) {
+	p, _ := newTestAggregate(t, mapstr.M{"group_by": []string{"source.ip"}})
+	marker := &markProcessor{}
+	list := processors.NewList(logptest.NewTestingLogger(t, ""))
+	list.AddProcessor(p)
+	list.AddProcessor(marker)
+
+	events, err := list.RunSplit(connEvent(time.Now(), "a", "", 0))
+	require.NoError(t, err, "unexpected error")
+	assert.Empty(t, events, "aggregated event must be held back")
+
+	flushed := list.Flush()
+	require.Len(t, flushed, 1, "expected flushed summary event")
+	assert.Equal(t, true, flushed[0].Fields["marked"], "processors after the aggregation must run on flushed events")
+}
+
+func TestAggregateConfig(t *testing.T) {
+	for name, cfg := range map[string]mapstr.M{
+		"no group_by":     {},
+		"zero window":     {"group_by": []string{"a"}, "window": 0},
+		"zero max_groups": {"group_by": []string{"a"}, "max_groups": 0},
+		"no target_field": {"group_by": []string{"a"}, "target_field": ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(conf.MustNewConfigFrom(cfg), logptest.NewTestingLogger(t, ""))
+			assert.Error(t, err, "expected configuration error")
+		})
+	}
+}
+
+type markProcessor struct{}
+
+func (m *markProcessor) String() string { return "mark" }
+
+func (m *markProcessor) Run(event *beat.Event) (*beat.Event, error) {
+	event.Fields["marked"] = true
+	return event, nil
+}
@@ -0,0 +1,64 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package aggregate
+
+import (
+	"errors"
+	"time"
+)
+
+type config struct {
+	// GroupBy lists the fields whose values identify a group. Missing fields
+	// are treated as empty values.
+	GroupBy []string `config:"group_by" validate:"required"`
+
+	// Window is how long a group collects events after its first event.
+	Window time.Duration `config:"window"`
+
+	// MaxGroups bounds the number of open groups. When the limit is reached
+	// the oldest group is emitted early.
+	MaxGroups int `config:"max_groups" validate:"min=1"`
+
+	// SumFields are numeric fields summed over the group.
+	SumFields []string `config:"sum_fields"`
+
+	// LastFields are copied from the last event of the group. All other
+	// fields are taken from the first event.
+	LastFields []string `config:"last_fields"`
+
+	// TargetField receives the aggregation statistics.
+	TargetField string `config:"target_field"`
+}
+
+func defaultConfig() config {
+	return config{
+		Window:      time.Minute,
+		MaxGroups:   10000,
+		TargetField: "aggregate",
+	}
+}
+
+func (c *config) Validate() error {
+	if c.Window <= 0 {
+		return errors.New("window must be greater than zero")
+	}
+	if c.TargetField == "" {
+		return errors.New("target_field must not be empty")
+	}
+	return nil
+}
@@ -78,6 +78,31 @@ func (r *WhenProcessor) Run(event *beat.Event) (*beat.Event, error) {
 	return r.p.Run(event)
 }
 
+// RunSplit executes this WhenProcessor, passing through events split by the
+// wrapped processor.
+func (r *WhenProcessor) RunSplit(event *beat.Event) ([]*beat.Event, error) {
+	if !(r.condition).Check(event) {
+		return []*beat.Event{event}, nil
+	}
+	return RunSplit(r.p, event)
+}
+
+// Flush returns the pending events of the wrapped processor.
+func (r *WhenProcessor) Flush() []*beat.Event {
+	return Flush(r.p)
+}
+
+// Expires reports whether the wrapped processor holds events back for a
+// limited time.
+func (r *WhenProcessor) Expires() bool {
+	return Expires(r.p)
+}
+
+// Expire returns the expired events of the wrapped processor.
+func (r *WhenProcessor) Expire() []*beat.Event {
+	return Expire(r.p)
+}
+
 func (r *WhenProcessor) SetPaths(paths *paths.Path) error {
 	pathSetter, ok := r.p.(PathSetter)
 	if ok {
@@ -208,6 +233,41 @@ func (p *IfThenElseProcessor) Run(event *beat.Event) (*beat.Event, error) {
 	return event, nil
 }
 
+// RunSplit is like Run but passes through events split by the then or else
+// processors.
+func (p *IfThenElseProcessor) RunSplit(event *beat.Event) ([]*beat.Event, error) {
+	if p.cond.Check(event) {
+		return p.then.RunSplit(event)
+	} else if p.els != nil {
+		return p.els.RunSplit(event)
+	}
+	return []*beat.Event{event}, nil
+}
+
+// Flush returns the pending events of the then and else processors.
+func (p *IfThenElseProcessor) Flush() []*beat.Event {
+	flushed := p.then.Flush()
+	if p.els != nil {
+		flushed = append(flushed, p.els.Flush()...)
+	}
+	return flushed
+}
+
+// Expires reports whether the then or else processors hold events back for a
+// limited time.
+func (p *IfThenElseProcessor) Expires() bool {
+	return p.then.Expires() || (p.els != nil && p.els.Expires())
+}
+
+// Expire returns the expired events of the then and else processors.
+func (p *IfThenElseProcessor) Expire() []*beat.Event {
+	expired := p.then.Expire()
+	if p.els != nil {
+		expired = append(expired, p.els.Expire()...)
+	}
+	return expired
+}
+
 func (p *IfThenElseProcessor) SetPaths(paths *paths.Path) error {
 	var err error
 	for _, proc := range p.then.List {
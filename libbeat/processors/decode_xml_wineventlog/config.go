@@ -17,14 +17,39 @@
 
 package decode_xml_wineventlog
 
+import (
+	"errors"
+	"fmt"
+)
+
 type config struct {
-	Field         string `config:"field" validate:"required"`
-	Target        string `config:"target_field"`
-	OverwriteKeys bool   `config:"overwrite_keys"`
-	MapECSFields  bool   `config:"map_ecs_fields"`
-	IgnoreMissing bool   `config:"ignore_missing"`
-	IgnoreFailure bool   `config:"ignore_failure"`
-	Language      uint32 `config:"language"`
+	Field             string             `config:"field" validate:"required"`
+	Target            string             `config:"target_field"`
+	OverwriteKeys     bool               `config:"overwrite_keys"`
+	MapECSFields      bool               `config:"map_ecs_fields"`
+	IgnoreMissing     bool               `config:"ignore_missing"`
+	IgnoreFailure     bool               `config:"ignore_failure"`
+	Language          uint32             `config:"language"`
+	Locale            string             `config:"locale"`              // Locale the rendering info of the events is normalized to.
+	Catalogs          []string           `config:"catalogs"`            // Paths of the catalog files.
+	UserDataTemplates []userDataTemplate `config:"user_data_templates"` // Mappings of the UserData schemas.
+}
+
+// userDataTemplate maps the values of a UserData schema to fields.
+type userDataTemplate struct {
+	Provider string          `config:"provider"`                     // All the providers if empty.
+	XMLName  string          `config:"xml_name" validate:"required"` // Local name of the child element of UserData.
+	Fields   []userDataField `config:"fields" validate:"required"`
+}
+
+// userDataField maps the values at Path to the field Name of user_data.
+type userDataField struct {
+	// Path is the slash separated path of the elements holding the values,
+	// relative to the child element of UserData. Its last segment can be
+	// an attribute, like Application/@Name.
+	Path string `config:"path" validate:"required"`
+	// Name is the name of the field, the last segment of Path if empty.
+	Name string `config:"name"`
 }
 
 func defaultConfig() config {
@@ -35,3 +60,17 @@ func defaultConfig() config {
 		Target:        "winlog",
 	}
 }
+
+func (c *config) Validate() error {
+	if len(c.Catalogs) != 0 && c.Locale == "" {
+		return errors.New("locale is required with catalogs, it selects the catalogs of the locale")
+	}
+	for _, t := range c.UserDataTemplates {
+		for _, f := range t.Fields {
+			if _, _, err := parsePath(f.Path); err != nil {
+				return fmt.Errorf("invalid path of user_data_templates %s: %w", t.XMLName, err)
+			}
+		}
+	}
+	return nil
+}
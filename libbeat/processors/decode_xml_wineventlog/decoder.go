@@ -20,23 +20,18 @@
 package decode_xml_wineventlog
 
 import (
-	"github.com/elastic/beats/v7/winlogbeat/sys/winevent"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
-type nonWinDecoder struct{}
+type nonWinDecoder struct {
+	renderer *renderer
+}
 
-func newDecoder(uint32, *logp.Logger) decoder {
-	return nonWinDecoder{}
+func newDecoder(_ uint32, r *renderer, _ *logp.Logger) decoder {
+	return nonWinDecoder{renderer: r}
 }
 
-func (nonWinDecoder) decode(data []byte) (mapstr.M, mapstr.M, error) {
-	evt, err := winevent.UnmarshalXML(data)
-	if err != nil {
-		return nil, nil, err
-	}
-	winevent.EnrichRawValuesWithNames(nil, &evt)
-	win, ecs := fields(evt)
-	return win, ecs, nil
+func (dec nonWinDecoder) decode(data []byte) (mapstr.M, mapstr.M, error) {
+	return decodeEvent(data, dec.renderer, nil)
 }
@@ -29,13 +29,15 @@ import (
 )
 
 type winDecoder struct {
-	locale uint32
-	cache  *metadataCache
+	locale   uint32
+	renderer *renderer
+	cache    *metadataCache
 }
 
-func newDecoder(locale uint32, logger *logp.Logger) decoder {
+func newDecoder(locale uint32, r *renderer, logger *logp.Logger) decoder {
 	return &winDecoder{
-		locale: locale,
+		locale:   locale,
+		renderer: r,
 		cache: &metadataCache{
 			store: map[string]*winevent.WinMeta{},
 			log:   logger.Named(logName),
@@ -44,14 +46,9 @@ func newDecoder(locale uint32, logger *logp.Logger) decoder {
 }
 
 func (dec *winDecoder) decode(data []byte) (mapstr.M, mapstr.M, error) {
-	evt, err := winevent.UnmarshalXML(data)
-	if err != nil {
-		return nil, nil, err
-	}
-	md := dec.cache.getPublisherMetadata(evt.Provider.Name, dec.locale)
-	winevent.EnrichRawValuesWithNames(md, &evt)
-	win, ecs := fields(evt)
-	return win, ecs, nil
+	return decodeEvent(data, dec.renderer, func(provider string) *winevent.WinMeta {
+		return dec.cache.getPublisherMetadata(provider, dec.locale)
+	})
 }
 
 type metadataCache struct {
@@ -51,6 +51,8 @@ func init() {
 				"field", "target_field",
 				"overwrite_keys", "map_ecs_fields",
 				"ignore_missing", "ignore_failure",
+				"language", "locale", "catalogs",
+				"user_data_templates",
 				"when",
 			)))
 	jsprocessor.RegisterPlugin("DecodeXMLWineventlog", New)
@@ -81,9 +83,14 @@ func New(c *conf.C, log *logp.Logger) (beat.Processor, error) {
 func newProcessor(config config, log *logp.Logger) (beat.Processor, error) {
 	log.Warn(cfgwarn.Experimental("The " + procName + " processor is experimental."))
 
+	r, err := newRenderer(config)
+	if err != nil {
+		return nil, fmt.Errorf("fail to create the "+procName+" processor renderer: %w", err)
+	}
+
 	return &processor{
 		config:  config,
-		decoder: newDecoder(config.Language, log),
+		decoder: newDecoder(config.Language, r, log),
 		log:     log.Named(logName),
 	}, nil
 }
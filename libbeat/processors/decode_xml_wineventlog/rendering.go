@@ -0,0 +1,407 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decode_xml_wineventlog
+
+import (
+	"encoding/xml"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	libxml "github.com/elastic/beats/v7/libbeat/common/encoding/xml"
+	"github.com/elastic/beats/v7/winlogbeat/sys/winevent"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+// catalogFile is the content of a catalog file. It holds the names of the
+// raw values and the message templates of the events of providers, in the
+// language of a locale.
+type catalogFile struct {
+	Locale    string            `config:"locale" validate:"required"`
+	Providers []providerCatalog `config:"providers"`
+}
+
+type providerCatalog struct {
+	Name     string           `config:"name" validate:"required"`
+	Levels   []catalogName    `config:"levels"`
+	Opcodes  []catalogName    `config:"opcodes"`
+	Tasks    []catalogName    `config:"tasks"`
+	Keywords []catalogName    `config:"keywords"` // The values are the bit masks of the keywords.
+	Messages []catalogMessage `config:"messages"`
+}
+
+type catalogName struct {
+	Value uint64 `config:"value"`
+	Name  string `config:"name" validate:"required"`
+}
+
+type catalogMessage struct {
+	EventID uint32 `config:"event_id"`
+	Message string `config:"message" validate:"required"`
+}
+
+// catalog holds the names and the message templates of the events of a
+// provider in the locale of the renderer.
+type catalog struct {
+	winevent.WinMeta
+	messages map[uint32]string // Message templates by event ID.
+}
+
+// renderer normalizes the rendering info of the events to a locale and maps
+// their UserData with templates. The nil *renderer leaves the events
+// unchanged.
+type renderer struct {
+	locale    string
+	catalogs  map[string]*catalog // By lower case provider name.
+	templates []compiledTemplate
+}
+
+type compiledTemplate struct {
+	provider, xmlName string
+	fields            []compiledField
+}
+
+type compiledField struct {
+	name string
+	path []string
+	attr string
+}
+
+// newRenderer returns the renderer of the events for c, nil if c sets no
+// locale and no UserData template.
+func newRenderer(c config) (*renderer, error) {
+	if c.Locale == "" && len(c.UserDataTemplates) == 0 {
+		return nil, nil
+	}
+	r := &renderer{locale: c.Locale, catalogs: map[string]*catalog{}}
+	for _, path := range c.Catalogs {
+		if err := r.loadCatalog(path); err != nil {
+			return nil, err
+		}
+	}
+	for _, t := range c.UserDataTemplates {
+		ct := compiledTemplate{provider: t.Provider, xmlName: t.XMLName}
+		for _, f := range t.Fields {
+			path, attr, err := parsePath(f.Path)
+			if err != nil {
+				return nil, fmt.Errorf("invalid path of user_data_templates %s: %w", t.XMLName, err)
+			}
+			name := f.Name
+			if name == "" {
+				name = attr
+				if name == "" {
+					name = path[len(path)-1]
+				}
+			}
+			ct.fields = append(ct.fields, compiledField{name: name, path: path, attr: attr})
+		}
+		r.templates = append(r.templates, ct)
+	}
+	return r, nil
+}
+
+// loadCatalog adds the catalogs of the providers of the catalog file at path,
+// relative to the configuration directory, if they are in the locale of r.
+func (r *renderer) loadCatalog(path string) error {
+	path = paths.Resolve(paths.Config, path)
+	c, err := common.LoadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to load catalog: %w", err)
+	}
+	var f catalogFile
+	if err := c.Unpack(&f); err != nil {
+		return fmt.Errorf("invalid catalog %s: %w", path, err)
+	}
+	if !strings.EqualFold(f.Locale, r.locale) {
+		return nil
+	}
+	for _, p := range f.Providers {
+		key := strings.ToLower(p.Name)
+		cat, found := r.catalogs[key]
+		if !found {
+			cat = &catalog{
+				WinMeta: winevent.WinMeta{
+					Keywords: map[int64]string{},
+					Opcodes:  map[uint8]string{},
+					Levels:   map[uint8]string{},
+					Tasks:    map[uint16]string{},
+				},
+				messages: map[uint32]string{},
+			}
+			r.catalogs[key] = cat
+		}
+		for _, n := range p.Levels {
+			cat.Levels[uint8(n.Value)] = n.Name
+		}
+		for _, n := range p.Opcodes {
+			cat.Opcodes[uint8(n.Value)] = n.Name
+		}
+		for _, n := range p.Tasks {
+			cat.Tasks[uint16(n.Value)] = n.Name
+		}
+		for _, n := range p.Keywords {
+			cat.Keywords[int64(n.Value)] = n.Name
+		}
+		for _, m := range p.Messages {
+			cat.messages[m.EventID] = m.Message
+		}
+	}
+	return nil
+}
+
+// parsePath splits a path of a UserData template into the names of its
+// elements and its attribute.
+func parsePath(path string) (elems []string, attr string, err error) {
+	elems = strings.Split(path, "/")
+	if last := elems[len(elems)-1]; strings.HasPrefix(last, "@") {
+		attr = last[1:]
+		elems = elems[:len(elems)-1]
+		if attr == "" {
+			return nil, "", fmt.Errorf("%q has an empty attribute name", path)
+		}
+	}
+	for _, e := range elems {
+		if e == "" || strings.HasPrefix(e, "@") {
+			return nil, "", fmt.Errorf("%q has an invalid element name %q", path, e)
+		}
+	}
+	return elems, attr, nil
+}
+
+// renderingXML holds the parts of an event that are not unmarshaled by
+// winevent.UnmarshalXML.
+type renderingXML struct {
+	RenderingInfo struct {
+		Culture string `xml:"Culture,attr"`
+	} `xml:"RenderingInfo"`
+	UserData struct {
+		Elements []xmlNode `xml:",any"`
+	} `xml:"UserData"`
+}
+
+// xmlNode is an arbitrary XML element.
+type xmlNode struct {
+	XMLName  xml.Name
+	Attrs    []xml.Attr `xml:",any,attr"`
+	Text     string     `xml:",chardata"`
+	Children []xmlNode  `xml:",any"`
+}
+
+// find returns the text of the descendant elements of n at path, or the
+// values of their attribute attr if it is not empty.
+func (n *xmlNode) find(path []string, attr string) []string {
+	nodes := []*xmlNode{n}
+	for _, name := range path {
+		var next []*xmlNode
+		for _, node := range nodes {
+			for i := range node.Children {
+				if node.Children[i].XMLName.Local == name {
+					next = append(next, &node.Children[i])
+				}
+			}
+		}
+		nodes = next
+	}
+	var values []string
+	for _, node := range nodes {
+		if attr == "" {
+			values = append(values, node.Text)
+			continue
+		}
+		for _, a := range node.Attrs {
+			if a.Name.Local == attr {
+				values = append(values, a.Value)
+			}
+		}
+	}
+	return values
+}
+
+// decodeEvent decodes the XML event in data. The rendering info of the event
+// is normalized by r, and the names of its raw values missing from the
+// rendering info are added from the metadata of its provider returned by
+// metadata, which can be nil.
+func decodeEvent(data []byte, r *renderer, metadata func(provider string) *winevent.WinMeta) (win, ecs mapstr.M, err error) {
+	evt, err := winevent.UnmarshalXML(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	var md *winevent.WinMeta
+	if metadata != nil {
+		md = metadata(evt.Provider.Name)
+	}
+	userData, err := r.render(data, &evt, md)
+	if err != nil {
+		return nil, nil, err
+	}
+	winevent.EnrichRawValuesWithNames(md, &evt)
+	win, ecs = fields(evt)
+	r.mapUserData(win, evt.Provider.Name, userData)
+	return win, ecs, nil
+}
+
+// render normalizes the rendering info of evt, decoded from data, to the
+// locale of r: the names and the message rendered in another locale are
+// rendered again from the raw values with the catalogs of r, and the missing
+// ones are added. The message rendered in another locale is kept if the
+// catalogs have no template for the event. It returns the child element of
+// the UserData of the event, if any.
+func (r *renderer) render(data []byte, evt *winevent.Event, md *winevent.WinMeta) (*xmlNode, error) {
+	if r == nil {
+		return nil, nil
+	}
+	var extra renderingXML
+	if err := xml.NewDecoder(libxml.NewSafeReader(data)).Decode(&extra); err != nil {
+		return nil, err
+	}
+	var userData *xmlNode
+	if len(extra.UserData.Elements) != 0 {
+		userData = &extra.UserData.Elements[0]
+	}
+	if r.locale == "" {
+		return userData, nil
+	}
+
+	cat := r.catalogs[strings.ToLower(evt.Provider.Name)]
+	culture := extra.RenderingInfo.Culture
+	if culture != "" && !strings.EqualFold(culture, r.locale) {
+		evt.Level, evt.Task, evt.Opcode, evt.Keywords = "", "", "", nil
+		if cat != nil && cat.messages[evt.EventIdentifier.ID] != "" {
+			evt.Message = ""
+		}
+	}
+	if cat == nil {
+		return userData, nil
+	}
+
+	if evt.Level == "" {
+		evt.Level = cat.Levels[evt.LevelRaw]
+	}
+	if evt.Task == "" {
+		evt.Task = cat.Tasks[evt.TaskRaw]
+	}
+	if evt.Opcode == "" && evt.OpcodeRaw != nil {
+		evt.Opcode = cat.Opcodes[*evt.OpcodeRaw]
+	}
+	if len(evt.Keywords) == 0 && len(cat.Keywords) != 0 {
+		rawKeyword := int64(evt.KeywordsRaw)
+		masks := make([]int64, 0, len(cat.Keywords))
+		for mask := range cat.Keywords {
+			masks = append(masks, mask)
+		}
+		slices.Sort(masks)
+		for _, mask := range masks {
+			if rawKeyword&mask != 0 {
+				evt.Keywords = append(evt.Keywords, cat.Keywords[mask])
+				rawKeyword &^= mask
+			}
+		}
+		if len(evt.Keywords) != 0 && rawKeyword != 0 {
+			// The keywords missing from the catalog are named as usual.
+			other := winevent.Event{KeywordsRaw: winevent.HexInt64(rawKeyword)}
+			winevent.EnrichRawValuesWithNames(md, &other)
+			evt.Keywords = append(evt.Keywords, other.Keywords...)
+		}
+	}
+	if evt.Message == "" {
+		if template, found := cat.messages[evt.EventIdentifier.ID]; found {
+			values := evt.EventData.Pairs
+			if len(values) == 0 {
+				values = evt.UserData.Pairs
+			}
+			evt.Message = formatMessage(template, values)
+		}
+	}
+	return userData, nil
+}
+
+// formatMessage replaces the insertion strings of a message template, %1 to
+// %99 optionally followed by a format specification like !s!, with the
+// values of the event. The %n, %t, %r and %% escape sequences are replaced
+// by a new line, a tab, a carriage return and a percent sign, and %0 ends
+// the message.
+func formatMessage(template string, values []winevent.KeyValue) string {
+	var sb strings.Builder
+	for {
+		i := strings.IndexByte(template, '%')
+		if i < 0 || i == len(template)-1 {
+			sb.WriteString(template)
+			return sb.String()
+		}
+		sb.WriteString(template[:i])
+		template = template[i+1:]
+		switch c := template[0]; {
+		case c == '0':
+			return sb.String()
+		case c >= '1' && c <= '9':
+			n := 1
+			for n < 2 && n < len(template) && template[n] >= '0' && template[n] <= '9' {
+				n++
+			}
+			idx, _ := strconv.Atoi(template[:n])
+			template = template[n:]
+			if strings.HasPrefix(template, "!") {
+				if end := strings.IndexByte(template[1:], '!'); end >= 0 {
+					template = template[end+2:]
+				}
+			}
+			if idx <= len(values) {
+				sb.WriteString(values[idx-1].Value)
+			}
+			continue
+		case c == 'n':
+			sb.WriteByte('\n')
+		case c == 't':
+			sb.WriteByte('\t')
+		case c == 'r':
+			sb.WriteByte('\r')
+		default:
+			sb.WriteByte(c)
+		}
+		template = template[1:]
+	}
+}
+
+// mapUserData replaces the user_data fields of win with the ones mapped by
+// the first template of r matching the provider and the child element of the
+// UserData of the event.
+func (r *renderer) mapUserData(win mapstr.M, provider string, userData *xmlNode) {
+	if r == nil || userData == nil {
+		return
+	}
+	for _, t := range r.templates {
+		if userData.XMLName.Local != t.xmlName || (t.provider != "" && !strings.EqualFold(t.provider, provider)) {
+			continue
+		}
+		fields := mapstr.M{"xml_name": userData.XMLName.Local}
+		for _, f := range t.fields {
+			switch values := userData.find(f.path, f.attr); len(values) {
+			case 0:
+			case 1:
+				_, _ = fields.Put(f.name, values[0])
+			default:
+				_, _ = fields.Put(f.name, values)
+			}
+		}
+		win["user_data"] = fields
+		return
+	}
+}
@@ -0,0 +1,188 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package decode_xml_wineventlog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/winlogbeat/sys/winevent"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	enCatalog = `
+locale: en-US
+providers:
+  - name: Contoso-App
+    tasks:
+      - {value: 7, name: File Access}
+    keywords:
+      - {value: 0x1, name: Contoso Audit}
+    messages:
+      - {event_id: 100, message: "User %1 opened %2.%0"}
+`
+	deCatalog = `
+locale: de-DE
+providers:
+  - name: Contoso-App
+    tasks:
+      - {value: 7, name: Dateizugriff}
+`
+)
+
+// contosoEvent returns an event of Contoso-App rendered in culture.
+func contosoEvent(eventID, culture, message string) string {
+	return "<Event xmlns='http://schemas.microsoft.com/win/2004/08/events/event'><System><Provider Name='Contoso-App'/>" +
+		"<EventID>" + eventID + "</EventID><Version>0</Version><Level>4</Level><Task>7</Task><Opcode>1</Opcode><Keywords>0x20000000000001</Keywords>" +
+		"<TimeCreated SystemTime='2021-03-23T09:56:13.137310000Z'/><EventRecordID>1</EventRecordID><Channel>Application</Channel><Computer>host</Computer></System>" +
+		"<EventData><Data Name='User'>alice</Data><Data Name='Path'>C:\\temp</Data></EventData>" +
+		"<RenderingInfo Culture='" + culture + "'><Message>" + message + "</Message><Level>Informationen</Level><Task>Dateizugriff</Task>" +
+		"<Opcode>Starten</Opcode><Keywords><Keyword>Überwachung erfolgreich</Keyword></Keywords></RenderingInfo></Event>"
+}
+
+func decodeWith(t *testing.T, c config, message string) mapstr.M {
+	t.Helper()
+	p, err := newProcessor(c, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+	evt, err := p.Run(&beat.Event{Fields: mapstr.M{"message": message}})
+	require.NoError(t, err)
+	return evt.Fields
+}
+
+func TestLocale(t *testing.T) {
+	dir := t.TempDir()
+	var catalogs []string
+	for name, content := range map[string]string{"en-US.yml": enCatalog, "de-DE.yml": deCatalog} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		catalogs = append(catalogs, path)
+	}
+	c := defaultConfig()
+	c.Locale = "en-us"
+	c.Catalogs = catalogs
+
+	t.Run("other_locale", func(t *testing.T) {
+		fields := decodeWith(t, c, contosoEvent("100", "de-DE", "Benutzer alice hat C:\\temp geöffnet."))
+		win := fields["winlog"].(mapstr.M)
+		assert.Equal(t, "information", win["level"])
+		assert.Equal(t, "File Access", win["task"])
+		assert.Equal(t, "Start", win["opcode"])
+		assert.Equal(t, []string{"Contoso Audit", "Audit Success"}, win["keywords"])
+		assert.Equal(t, "User alice opened C:\\temp.", win["message"])
+		assert.Equal(t, "User alice opened C:\\temp.", fields["message"])
+		assert.Equal(t, "File Access", getValue(fields, "event.action"))
+	})
+
+	t.Run("other_locale_without_template", func(t *testing.T) {
+		win := decodeWith(t, c, contosoEvent("200", "de-DE", "Unbekanntes Ereignis."))["winlog"].(mapstr.M)
+		assert.Equal(t, "information", win["level"])
+		assert.Equal(t, "File Access", win["task"])
+		assert.Equal(t, "Unbekanntes Ereignis.", win["message"], "the message is kept without a template")
+	})
+
+	t.Run("same_locale", func(t *testing.T) {
+		win := decodeWith(t, c, contosoEvent("100", "en-US", "Rendered."))["winlog"].(mapstr.M)
+		assert.Equal(t, "informationen", win["level"], "the names rendered in the locale are kept")
+		assert.Equal(t, "Dateizugriff", win["task"])
+		assert.Equal(t, "Rendered.", win["message"])
+	})
+
+	t.Run("no_locale", func(t *testing.T) {
+		win := decodeWith(t, defaultConfig(), contosoEvent("100", "de-DE", "Benutzer alice hat C:\\temp geöffnet."))["winlog"].(mapstr.M)
+		assert.Equal(t, "informationen", win["level"])
+		assert.Equal(t, "Benutzer alice hat C:\\temp geöffnet.", win["message"])
+	})
+
+	t.Run("invalid_catalog", func(t *testing.T) {
+		path := filepath.Join(dir, "invalid.yml")
+		require.NoError(t, os.WriteFile(path, []byte("providers: []\n"), 0o600))
+		c := c
+		c.Catalogs = []string{path}
+		_, err := newProcessor(c, logptest.NewTestingLogger(t, ""))
+		assert.ErrorContains(t, err, "invalid catalog")
+	})
+}
+
+func TestUserDataTemplates(t *testing.T) {
+	const message = "<Event xmlns='http://schemas.microsoft.com/win/2004/08/events/event'><System><Provider Name='Microsoft-Windows-RestartManager'/>" +
+		"<EventID>10001</EventID><Level>4</Level><Task>0</Task><Keywords>0x8000000000000000</Keywords><EventRecordID>2</EventRecordID><Channel>Application</Channel></System>" +
+		"<UserData><RmSessionEvent xmlns='http://www.microsoft.com/2005/08/Windows/Reliability/RestartManager/'><RmSessionId>0</RmSessionId>" +
+		"<Applications><Application Name='a.exe'>A</Application><Application Name='b.exe'>B</Application></Applications></RmSessionEvent></UserData></Event>"
+
+	c := defaultConfig()
+	c.UserDataTemplates = []userDataTemplate{
+		{
+			Provider: "Microsoft-Windows-Other",
+			XMLName:  "RmSessionEvent",
+			Fields:   []userDataField{{Path: "RmSessionId", Name: "other"}},
+		},
+		{
+			Provider: "microsoft-windows-restartmanager",
+			XMLName:  "RmSessionEvent",
+			Fields: []userDataField{
+				{Path: "RmSessionId", Name: "session.id"},
+				{Path: "Applications/Application/@Name"},
+				{Path: "Applications/Application", Name: "applications"},
+				{Path: "Missing"},
+			},
+		},
+	}
+	win := decodeWith(t, c, message)["winlog"].(mapstr.M)
+	assert.Equal(t, mapstr.M{
+		"xml_name":     "RmSessionEvent",
+		"session":      mapstr.M{"id": "0"},
+		"Name":         []string{"a.exe", "b.exe"},
+		"applications": []string{"A", "B"},
+	}, win["user_data"])
+
+	// Without a matching template, UserData is mapped as key-value pairs.
+	c.UserDataTemplates = c.UserDataTemplates[:1]
+	win = decodeWith(t, c, message)["winlog"].(mapstr.M)
+	assert.Equal(t, "0", getValue(win, "user_data.RmSessionId"))
+}
+
+func TestFormatMessage(t *testing.T) {
+	values := []winevent.KeyValue{{Key: "User", Value: "alice"}, {Key: "Count", Value: "3"}}
+	for template, want := range map[string]string{
+		"%1 logged on %2!d! times.":  "alice logged on 3 times.",
+		"Line%nTab%tEnd%0Ignored":    "Line\nTab\tEnd",
+		"100%% of %3 missing values": "100% of  missing values",
+		"Trailing %":                 "Trailing %",
+	} {
+		assert.Equal(t, want, formatMessage(template, values), template)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	c := defaultConfig()
+	c.Catalogs = []string{"catalog.yml"}
+	assert.ErrorContains(t, c.Validate(), "locale is required")
+
+	for _, path := range []string{"", "a//b", "a/@", "@a/b"} {
+		c := defaultConfig()
+		c.UserDataTemplates = []userDataTemplate{{XMLName: "Event", Fields: []userDataField{{Path: path}}}}
+		assert.Error(t, c.Validate(), path)
+	}
+}
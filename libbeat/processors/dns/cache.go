@@ -18,7 +18,9 @@
 package dns
 
 import (
+	"errors"
 	"math"
+	"math/rand/v2"
 	"sync"
 	"time"
 
@@ -40,6 +42,7 @@ type successCache struct {
 	data          map[string]successRecord
 	maxSize       int
 	minSuccessTTL time.Duration
+	jitter        float64
 }
 
 func (c *successCache) set(now time.Time, key string, result *result) {
@@ -55,7 +58,7 @@ func (c *successCache) set(now time.Time, key string, result *result) {
 
 	c.data[key] = successRecord{
 		data:    result.Data,
-		expires: now.Add(time.Duration(result.TTL) * time.Second),
+		expires: now.Add(jitter(time.Duration(result.TTL)*time.Second, c.jitter)),
 	}
 }
 
@@ -98,6 +101,9 @@ type failureCache struct {
 	data       map[string]failureRecord
 	maxSize    int
 	failureTTL time.Duration
+	minTTL     time.Duration // Bounds for negative caching TTLs from SOA records.
+	maxTTL     time.Duration
+	jitter     float64
 }
 
 func (c *failureCache) set(now time.Time, key string, err error) {
@@ -112,7 +118,25 @@ func (c *failureCache) set(now time.Time, key string, err error) {
 
 	c.data[key] = failureRecord{
 		error:   err,
-		expires: now.Add(c.failureTTL),
+		expires: now.Add(jitter(c.ttl(err), c.jitter)),
+	}
+}
+
+// ttl returns the TTL for a failure. Negative responses that contain an SOA
+// record are cached for the negative TTL of the zone, other failures use the
+// configured failure TTL.
+func (c *failureCache) ttl(err error) time.Duration {
+	var dnsErr *dnsError
+	if !errors.As(err, &dnsErr) || dnsErr.ttl <= 0 {
+		return c.failureTTL
+	}
+	switch {
+	case dnsErr.ttl < c.minTTL:
+		return c.minTTL
+	case dnsErr.ttl > c.maxTTL:
+		return c.maxTTL
+	default:
+		return dnsErr.ttl
 	}
 }
 
@@ -146,6 +170,7 @@ type cachedError struct {
 
 func (ce *cachedError) Error() string { return ce.err.Error() + " (from failure cache)" }
 func (ce *cachedError) Cause() error  { return ce.err }
+func (ce *cachedError) Unwrap() error { return ce.err }
 
 // lookupCache is a cache for storing and retrieving the results of
 // DNS queries. It caches the results of queries regardless of their
@@ -170,16 +195,20 @@ func newLookupCache(reg *monitoring.Registry, conf cacheConfig, resolver resolve
 
 	c := &lookupCache{
 		success: &successCache{
-			enabled:       conf.FailureCache.Enabled,
+			enabled:       conf.SuccessCache.Enabled,
 			data:          make(map[string]successRecord, conf.SuccessCache.InitialCapacity),
 			maxSize:       conf.SuccessCache.MaxCapacity,
 			minSuccessTTL: conf.SuccessCache.MinTTL,
+			jitter:        conf.SuccessCache.Jitter,
 		},
 		failure: &failureCache{
 			enabled:    conf.FailureCache.Enabled,
 			data:       make(map[string]failureRecord, conf.FailureCache.InitialCapacity),
 			maxSize:    conf.FailureCache.MaxCapacity,
 			failureTTL: conf.FailureCache.TTL,
+			minTTL:     conf.FailureCache.MinTTL,
+			maxTTL:     conf.FailureCache.MaxTTL,
+			jitter:     conf.FailureCache.Jitter,
 		},
 		resolver: resolver,
 		stats: cacheStats{
@@ -230,6 +259,14 @@ func max(a, b uint32) uint32 {
 	return b
 }
 
+// jitter brings d forward by a random amount of up to fraction*d.
+func jitter(d time.Duration, fraction float64) time.Duration {
+	if fraction <= 0 || d <= 0 {
+		return d
+	}
+	return d - time.Duration(rand.Float64()*fraction*float64(d))
+}
+
 // safeUint32 converts a float64 to a uint32, protecting against out-of-bounds
 // values. It takes the absolute value to prevent negative numbers and caps the
 // result at math.MaxUint32 to avoid integer overflows.
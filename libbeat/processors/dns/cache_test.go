@@ -37,8 +37,14 @@ func (r *stubResolver) Lookup(ip string, _ queryType) (*result, error) {
 		return nil, io.ErrUnexpectedEOF
 	case gatewayIP + "2":
 		return &result{Data: []string{gatewayName}, TTL: 0}, nil
+	case gatewayIP + "3":
+		return nil, &dnsError{err: "fake lookup returned NXDOMAIN with SOA", ttl: 5 * time.Minute}
+	case gatewayIP + "4":
+		return nil, &dnsError{err: "fake lookup returned NXDOMAIN with SOA", ttl: time.Second}
+	case gatewayIP + "5":
+		return nil, &dnsError{err: "fake lookup returned NXDOMAIN with SOA", ttl: 24 * time.Hour}
 	}
-	return nil, &dnsError{"fake lookup returned NXDOMAIN"}
+	return nil, &dnsError{err: "fake lookup returned NXDOMAIN"}
 }
 
 func TestCache(t *testing.T) {
@@ -153,3 +159,36 @@ func TestDisabledCache(t *testing.T) {
 		assert.EqualValues(t, 2, c.stats.Miss.Get())
 	}
 }
+
+func TestCacheNegativeTTL(t *testing.T) {
+	config := defaultConfig().cacheConfig
+	c, err := newLookupCache(monitoring.NewRegistry(), config, &stubResolver{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]time.Duration{
+		gatewayIP + "0": config.FailureCache.TTL,    // No SOA, failure TTL.
+		gatewayIP + "1": config.FailureCache.TTL,    // Network failure, failure TTL.
+		gatewayIP + "3": 5 * time.Minute,            // SOA negative TTL.
+		gatewayIP + "4": config.FailureCache.MinTTL, // Raised to min_ttl.
+		gatewayIP + "5": config.FailureCache.MaxTTL, // Capped at max_ttl.
+	}
+	for q, ttl := range tests {
+		_, err := c.Lookup(q, typePTR)
+		assert.Error(t, err, q)
+
+		expectedExpire := time.Now().Add(ttl).Unix()
+		gotExpire := c.failure.data[q].expires.Unix()
+		assert.InDelta(t, expectedExpire, gotExpire, 1, q)
+	}
+}
+
+func TestJitter(t *testing.T) {
+	assert.Equal(t, time.Minute, jitter(time.Minute, 0))
+	for i := 0; i < 100; i++ {
+		d := jitter(time.Minute, 0.1)
+		assert.LessOrEqual(t, d, time.Minute)
+		assert.Greater(t, d, 54*time.Second)
+	}
+}
@@ -30,15 +30,16 @@ import (
 
 // config defines the configuration options for the DNS processor.
 type config struct {
-	cacheConfig  `config:",inline"`
-	Nameservers  []string      `config:"nameservers"`              // Required on Windows. /etc/resolv.conf is used if none are given.
-	Timeout      time.Duration `config:"timeout"`                  // Per request timeout (with 2 nameservers the total timeout would be 2x).
-	Type         queryType     `config:"type" validate:"required"` // One of A, AAAA, TXT or PTR (or reverse).
-	Action       fieldAction   `config:"action"`                   // Append or replace (defaults to append) when target exists.
-	TagOnFailure []string      `config:"tag_on_failure"`           // Tags to append when a failure occurs.
-	Fields       mapstr.M      `config:"fields"`                   // Mapping of source fields to target fields.
-	Transport    string        `config:"transport"`                // Can be tls or udp.
-	reverseFlat  map[string]string
+	cacheConfig     `config:",inline"`
+	Nameservers     []string      `config:"nameservers"`              // Required on Windows. /etc/resolv.conf is used if none are given.
+	Timeout         time.Duration `config:"timeout"`                  // Per request timeout (with 2 nameservers the total timeout would be 2x).
+	Type            queryType     `config:"type" validate:"required"` // One of A, AAAA, TXT or PTR (or reverse).
+	Action          fieldAction   `config:"action"`                   // Append or replace (defaults to append) when target exists.
+	TagOnFailure    []string      `config:"tag_on_failure"`           // Tags to append when a failure occurs.
+	Fields          mapstr.M      `config:"fields"`                   // Mapping of source fields to target fields.
+	Transport       string        `config:"transport"`                // Can be tls, https or udp.
+	FailoverBackoff time.Duration `config:"failover_backoff"`         // How long a failed nameserver is tried after the others (0 disables).
+	reverseFlat     map[string]string
 }
 
 // fieldAction defines the behavior when the target field exists.
@@ -127,9 +128,18 @@ type cacheSettings struct {
 	// from the DNS record.
 	TTL time.Duration `config:"ttl"`
 
-	// Minimum TTL value for successful DNS responses.
+	// Minimum TTL value for successful DNS responses and for negative
+	// responses that contain an SOA record.
 	MinTTL time.Duration `config:"min_ttl" validate:"min=1ns"`
 
+	// Maximum TTL for negative responses that contain an SOA record. Not used
+	// for success.
+	MaxTTL time.Duration `config:"max_ttl"`
+
+	// Fraction of the TTL by which the expiration of an item is randomly
+	// brought forward, so items cached together don't expire together.
+	Jitter float64 `config:"jitter"`
+
 	// Initial capacity. How much space is allocated at initialization.
 	InitialCapacity int `config:"capacity.initial" validate:"min=0"`
 
@@ -154,10 +164,14 @@ func (c *config) Validate() error {
 	c.Transport = strings.ToLower(c.Transport)
 	switch c.Transport {
 	case "tls":
+	case "https":
 	case "udp":
 	default:
 		return fmt.Errorf("invalid transport method type '%v' specified in "+
-			"config (valid value is: tls or udp)", c.Transport)
+			"config (valid value is: tls, https or udp)", c.Transport)
+	}
+	if c.FailoverBackoff < 0 {
+		return fmt.Errorf("failover_backoff must be >= 0")
 	}
 	return nil
 }
@@ -185,6 +199,16 @@ func (c *cacheConfig) Validate() error {
 		return fmt.Errorf("failure_cache.capacity.max must be >= failure_cache.capacity.initial")
 	}
 
+	if c.FailureCache.MaxTTL < c.FailureCache.MinTTL {
+		return fmt.Errorf("failure_cache.max_ttl must be >= failure_cache.min_ttl")
+	}
+	if c.SuccessCache.Jitter < 0 || c.SuccessCache.Jitter >= 1 {
+		return fmt.Errorf("success_cache.jitter must be >= 0 and < 1")
+	}
+	if c.FailureCache.Jitter < 0 || c.FailureCache.Jitter >= 1 {
+		return fmt.Errorf("failure_cache.jitter must be >= 0 and < 1")
+	}
+
 	return nil
 }
 
@@ -200,12 +224,14 @@ func defaultConfig() config {
 			FailureCache: cacheSettings{
 				Enabled:         true,
 				MinTTL:          time.Minute,
+				MaxTTL:          time.Hour,
 				TTL:             time.Minute,
 				InitialCapacity: 1000,
 				MaxCapacity:     10000,
 			},
 		},
-		Transport: "udp",
-		Timeout:   500 * time.Millisecond,
+		Transport:       "udp",
+		Timeout:         500 * time.Millisecond,
+		FailoverBackoff: 30 * time.Second,
 	}
 }
@@ -16,12 +16,13 @@
 // under the License.
 
 // Package dns implements a processor that can perform DNS lookups by sending
-// a DNS request over UDP or TLS to a recursive nameserver. Each instance of the
-// processor is independent (no shared cache) so it's best to only define one
-// instance of the processor.
+// a DNS request over UDP, TLS or HTTPS to a recursive nameserver. Each
+// instance of the processor is independent (no shared cache) so it's best to
+// only define one instance of the processor.
 //
 // It caches DNS results in memory and honors the record's TTL. It also caches
-// failures for the configured failure TTL. The caches are simple, and they
+// failures for the configured failure TTL, or for the negative TTL of the zone
+// when the response contains an SOA record. The caches are simple, and they
 // evict a random item when the configured maximum size is reached.
 //
 // This processor can significantly slow down your pipeline's throughput if you
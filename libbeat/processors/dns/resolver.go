@@ -18,13 +18,19 @@
 package dns
 
 import (
+	"bytes"
 	"errors"
+	"fmt"
+	"io"
 	"math"
 	"net"
+	"net/http"
 	"net/netip"
+	"net/url"
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/miekg/dns"
@@ -48,11 +54,18 @@ type resolver interface {
 	Lookup(q string, qt queryType) (*result, error)
 }
 
+// exchanger sends a DNS request to a server. It is implemented by
+// *dns.Client for UDP and DNS-over-TLS and by *dohClient for DNS-over-HTTPS.
+type exchanger interface {
+	Exchange(m *dns.Msg, server string) (*dns.Msg, time.Duration, error)
+}
+
 // miekgResolver is a resolver that is implemented using github.com/miekg/dns
 // to send requests to DNS servers. It does not use the Go resolver.
 type miekgResolver struct {
-	client  *dns.Client
-	servers []string
+	client          exchanger
+	servers         []string
+	failoverBackoff time.Duration
 
 	registry     *monitoring.Registry
 	nsStatsMutex sync.RWMutex
@@ -64,13 +77,17 @@ type nameserverStats struct {
 	success         *monitoring.Int // Number of responses from server.
 	failure         *monitoring.Int // Number of failures (e.g. I/O timeout) (not NXDOMAIN).
 	requestDuration metrics.Sample  // Histogram of response times.
+	downUntil       atomic.Int64    // Unix nanoseconds until which the server is tried last.
 }
 
 // newMiekgResolver returns a new miekgResolver. It returns an error if no
 // nameserver are given and none can be read from /etc/resolv.conf.
-func newMiekgResolver(reg *monitoring.Registry, timeout time.Duration, transport string, logger *logp.Logger, servers ...string) (*miekgResolver, error) {
+func newMiekgResolver(reg *monitoring.Registry, timeout time.Duration, transport string, failoverBackoff time.Duration, logger *logp.Logger, servers ...string) (*miekgResolver, error) {
 	// Use /etc/resolv.conf if no nameservers are given. (Won't work for Windows).
 	if len(servers) == 0 {
+		if transport == "https" {
+			return nil, errors.New("nameservers must be configured for the https transport")
+		}
 		config, err := dns.ClientConfigFromFile(etcResolvConf)
 		if err != nil || len(config.Servers) == 0 {
 			return nil, errors.New("no dns servers configured")
@@ -80,6 +97,14 @@ func newMiekgResolver(reg *monitoring.Registry, timeout time.Duration, transport
 
 	// Add port if one was not specified.
 	for i, s := range servers {
+		if transport == "https" {
+			u, err := dohURL(s)
+			if err != nil {
+				return nil, err
+			}
+			servers[i] = u
+			continue
+		}
 		if isIPv6Address(s) {
 			s = "[" + s + "]" // Add brackets for IPv6 addresses.
 		}
@@ -104,30 +129,90 @@ func newMiekgResolver(reg *monitoring.Registry, timeout time.Duration, transport
 		timeout = defaultConfig().Timeout
 	}
 
-	var clientTransferType string
+	var client exchanger
 	switch transport {
 	case "tls":
-		clientTransferType = "tcp-tls"
+		client = &dns.Client{Net: "tcp-tls", Timeout: timeout}
+	case "https":
+		client = &dohClient{client: &http.Client{Timeout: timeout}}
 	default:
-		clientTransferType = "udp"
+		client = &dns.Client{Net: "udp", Timeout: timeout}
 	}
 
 	return &miekgResolver{
-		client: &dns.Client{
-			Net:     clientTransferType,
-			Timeout: timeout,
-		},
-		servers:  servers,
-		registry: reg,
-		nsStats:  map[string]*nameserverStats{},
-		logger:   logger,
+		client:          client,
+		servers:         servers,
+		failoverBackoff: failoverBackoff,
+		registry:        reg,
+		nsStats:         map[string]*nameserverStats{},
+		logger:          logger,
 	}, nil
 }
 
+// dohURL returns the DNS-over-HTTPS endpoint for a nameserver. Nameservers
+// without a scheme use the standard /dns-query path.
+func dohURL(server string) (string, error) {
+	if !strings.Contains(server, "://") {
+		if isIPv6Address(server) {
+			server = "[" + server + "]"
+		}
+		server = "https://" + server + "/dns-query"
+	}
+	u, err := url.Parse(server)
+	if err != nil {
+		return "", fmt.Errorf("invalid https nameserver '%v': %w", server, err)
+	}
+	if u.Scheme != "https" || u.Host == "" {
+		return "", fmt.Errorf("invalid https nameserver '%v': an https URL is required", server)
+	}
+	return u.String(), nil
+}
+
+// dohClient sends DNS requests over HTTPS as described in RFC 8484.
+type dohClient struct {
+	client *http.Client
+}
+
+// Exchange sends m to the DNS-over-HTTPS endpoint at server.
+func (c *dohClient) Exchange(m *dns.Msg, server string) (*dns.Msg, time.Duration, error) {
+	packed, err := m.Pack()
+	if err != nil {
+		return nil, 0, err
+	}
+	req, err := http.NewRequest(http.MethodPost, server, bytes.NewReader(packed))
+	if err != nil {
+		return nil, 0, err
+	}
+	req.Header.Set("Content-Type", "application/dns-message")
+	req.Header.Set("Accept", "application/dns-message")
+
+	start := time.Now()
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("nameserver %v returned HTTP status %v", server, resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, dns.MaxMsgSize))
+	if err != nil {
+		return nil, 0, err
+	}
+	rtt := time.Since(start)
+
+	r := new(dns.Msg)
+	if err := r.Unpack(body); err != nil {
+		return nil, 0, fmt.Errorf("invalid response from nameserver %v: %w", server, err)
+	}
+	return r, rtt, nil
+}
+
 // dnsError represents a failure response from the DNS server (like NXDOMAIN),
 // but not a communication failure to the server. The response is cacheable.
 type dnsError struct {
 	err string
+	ttl time.Duration // Negative caching TTL from the SOA record, zero if unknown.
 }
 
 func (e *dnsError) Error() string {
@@ -159,7 +244,7 @@ func (res *miekgResolver) Lookup(q string, qt queryType) (*result, error) {
 
 	// Try the nameservers until we get a response.
 	var nameserverErr error
-	for _, server := range res.servers {
+	for _, server := range res.orderedServers(time.Now()) {
 		stats := res.getOrCreateNameserverStats(server)
 
 		r, rtt, err := res.client.Exchange(m, server)
@@ -167,18 +252,30 @@ func (res *miekgResolver) Lookup(q string, qt queryType) (*result, error) {
 			// Try next server if any. Otherwise, return nameserverErr.
 			nameserverErr = err
 			stats.failure.Inc()
+			res.markFailed(stats)
 			continue
 		}
 
 		// We got a response.
 		stats.success.Inc()
 		stats.requestDuration.Update(int64(rtt))
-		if r.Rcode != dns.RcodeSuccess {
+		switch r.Rcode {
+		case dns.RcodeSuccess, dns.RcodeNameError:
+		case dns.RcodeServerFailure, dns.RcodeRefused:
+			// The server could not answer, another one might.
+			nameserverErr = &dnsError{err: "nameserver " + server + " returned " + dns.RcodeToString[r.Rcode]}
+			res.markFailed(stats)
+			continue
+		default:
 			name, found := dns.RcodeToString[r.Rcode]
 			if !found {
 				name = "response code " + strconv.Itoa(r.Rcode)
 			}
-			return nil, &dnsError{"nameserver " + server + " returned " + name}
+			return nil, &dnsError{err: "nameserver " + server + " returned " + name}
+		}
+		stats.downUntil.Store(0)
+		if r.Rcode == dns.RcodeNameError {
+			return nil, &dnsError{err: "nameserver " + server + " returned NXDOMAIN", ttl: negativeTTL(r)}
 		}
 
 		var rtn result
@@ -208,7 +305,7 @@ func (res *miekgResolver) Lookup(q string, qt queryType) (*result, error) {
 		}
 
 		if len(rtn.Data) == 0 {
-			return nil, &dnsError{"no " + qt.String() + " resource records were found in the response"}
+			return nil, &dnsError{err: "no " + qt.String() + " resource records were found in the response", ttl: negativeTTL(r)}
 		}
 
 		return &rtn, nil
@@ -222,9 +319,45 @@ func (res *miekgResolver) Lookup(q string, qt queryType) (*result, error) {
 	panic("dns resolver Lookup() should have returned a response.")
 }
 
+// orderedServers returns the nameservers in configuration order, with
+// servers that recently failed moved to the end.
+func (res *miekgResolver) orderedServers(now time.Time) []string {
+	if res.failoverBackoff <= 0 || len(res.servers) < 2 {
+		return res.servers
+	}
+	healthy := make([]string, 0, len(res.servers))
+	var failed []string
+	for _, server := range res.servers {
+		if res.getOrCreateNameserverStats(server).downUntil.Load() > now.UnixNano() {
+			failed = append(failed, server)
+			continue
+		}
+		healthy = append(healthy, server)
+	}
+	return append(healthy, failed...)
+}
+
+// markFailed moves a nameserver to the end of the server order for the
+// failover backoff period.
+func (res *miekgResolver) markFailed(stats *nameserverStats) {
+	if res.failoverBackoff > 0 {
+		stats.downUntil.Store(time.Now().Add(res.failoverBackoff).UnixNano())
+	}
+}
+
+// negativeTTL returns the negative caching TTL of a response as defined in
+// RFC 2308, the minimum of the SOA record TTL and its MINIMUM field.
+func negativeTTL(r *dns.Msg) time.Duration {
+	for _, rr := range r.Ns {
+		if soa, ok := rr.(*dns.SOA); ok {
+			return time.Duration(min(soa.Hdr.Ttl, soa.Minttl)) * time.Second
+		}
+	}
+	return 0
+}
+
 func (res *miekgResolver) getOrCreateNameserverStats(ns string) *nameserverStats {
-	// Trim port.
-	ns = ns[:strings.LastIndex(ns, ":")]
+	ns = nameserverStatsKey(ns)
 
 	// Check if stats already exist.
 	res.nsStatsMutex.RLock()
@@ -259,6 +392,16 @@ func (res *miekgResolver) getOrCreateNameserverStats(ns string) *nameserverStats
 	return stats
 }
 
+// nameserverStatsKey returns the nameserver host without port, which is used
+// as the name of its metrics registry.
+func nameserverStatsKey(ns string) string {
+	if u, err := url.Parse(ns); err == nil && u.Host != "" {
+		return u.Hostname()
+	}
+	// Trim port.
+	return ns[:strings.LastIndex(ns, ":")]
+}
+
 func min(a, b uint32) uint32 {
 	if a < b {
 		return a
@@ -20,9 +20,13 @@ package dns
 import (
 	"crypto/tls"
 	"errors"
+	"io"
 	"net"
+	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/miekg/dns"
 	"github.com/stretchr/testify/assert"
@@ -40,7 +44,7 @@ func TestNewMiekgResolverWithIPv6(t *testing.T) {
 
 	reg := monitoring.NewRegistry()
 
-	_, err := newMiekgResolver(reg.GetOrCreateRegistry(logName), 0, "udp", logp.NewNopLogger(), addr)
+	_, err := newMiekgResolver(reg.GetOrCreateRegistry(logName), 0, "udp", 0, logp.NewNopLogger(), addr)
 	assert.NoError(t, err)
 }
 
@@ -54,7 +58,7 @@ func TestMiekgResolverLookupPTR(t *testing.T) {
 	}()
 
 	reg := monitoring.NewRegistry()
-	res, err := newMiekgResolver(reg.GetOrCreateRegistry(logName), 0, "udp", logp.NewNopLogger(), addr)
+	res, err := newMiekgResolver(reg.GetOrCreateRegistry(logName), 0, "udp", 0, logp.NewNopLogger(), addr)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -105,12 +109,12 @@ func TestMiekgResolverLookupPTRTLS(t *testing.T) {
 
 	reg := monitoring.NewRegistry()
 
-	res, err := newMiekgResolver(reg.GetOrCreateRegistry(logName), 0, "tls", logp.NewNopLogger(), addr)
+	res, err := newMiekgResolver(reg.GetOrCreateRegistry(logName), 0, "tls", 0, logp.NewNopLogger(), addr)
 	if err != nil {
 		t.Fatal(err)
 	}
 	//nolint:gosec // Don't verify the self-signed cert. This is only for testing purposes.
-	res.client.TLSConfig = &tls.Config{
+	res.client.(*dns.Client).TLSConfig = &tls.Config{
 		InsecureSkipVerify: true,
 	}
 
@@ -139,6 +143,127 @@ func TestMiekgResolverLookupPTRTLS(t *testing.T) {
 	assert.Equal(t, 12, metricCount)
 }
 
+func TestMiekgResolverLookupPTRHTTPS(t *testing.T) {
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/dns-query" || r.Header.Get("Content-Type") != "application/dns-message" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		msg := new(dns.Msg)
+		if err := msg.Unpack(body); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		rw := &msgWriter{}
+		fakeDNSHandler(rw, msg)
+		packed, err := rw.msg.Pack()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/dns-message")
+		_, _ = w.Write(packed)
+	}))
+	defer srv.Close()
+
+	reg := monitoring.NewRegistry()
+	res, err := newMiekgResolver(reg.GetOrCreateRegistry(logName), 0, "https", 0, logp.NewNopLogger(), srv.URL+"/dns-query")
+	require.NoError(t, err)
+	res.client.(*dohClient).client = srv.Client()
+
+	// Success
+	ptr, err := res.Lookup("8.8.8.8", typePTR)
+	require.NoError(t, err)
+	assert.EqualValues(t, "google-public-dns-a.google.com", ptr.Data[0])
+	assert.EqualValues(t, 19273, ptr.TTL)
+
+	// NXDOMAIN
+	_, err = res.Lookup("1.1.1.1", typePTR)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "NXDOMAIN")
+	}
+}
+
+func TestDoHURL(t *testing.T) {
+	tests := map[string]string{
+		"dns.example.com":                  "https://dns.example.com/dns-query",
+		"dns.example.com:8443":             "https://dns.example.com:8443/dns-query",
+		"2001:db8::1":                      "https://[2001:db8::1]/dns-query",
+		"https://dns.example.com/resolve":  "https://dns.example.com/resolve",
+		"http://dns.example.com/dns-query": "",
+		"https:///dns-query":               "",
+	}
+	for server, want := range tests {
+		got, err := dohURL(server)
+		if want == "" {
+			assert.Error(t, err, server)
+			continue
+		}
+		if assert.NoError(t, err, server) {
+			assert.Equal(t, want, got, server)
+		}
+	}
+}
+
+func TestMiekgResolverFailover(t *testing.T) {
+	stopFailing, failingAddr, err := serveDNS(func(w dns.ResponseWriter, msg *dns.Msg) {
+		m := new(dns.Msg)
+		m.SetRcode(msg, dns.RcodeServerFailure)
+		_ = w.WriteMsg(m)
+	})
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, stopFailing())
+	}()
+	stop, addr, err := serveDNS(fakeDNSHandler)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, stop())
+	}()
+
+	reg := monitoring.NewRegistry()
+	res, err := newMiekgResolver(reg.GetOrCreateRegistry(logName), 0, "udp", time.Minute, logp.NewNopLogger(), failingAddr, addr)
+	require.NoError(t, err)
+
+	// The failing server is tried first and the lookup fails over.
+	ptr, err := res.Lookup("8.8.8.8", typePTR)
+	require.NoError(t, err)
+	assert.EqualValues(t, "google-public-dns-a.google.com", ptr.Data[0])
+
+	// The failing server is now tried last.
+	assert.Equal(t, []string{addr, failingAddr}, res.orderedServers(time.Now()))
+	assert.Equal(t, []string{failingAddr, addr}, res.orderedServers(time.Now().Add(2*time.Minute)))
+}
+
+func TestNegativeTTL(t *testing.T) {
+	m := new(dns.Msg)
+	assert.Zero(t, negativeTTL(m))
+
+	soa, err := dns.NewRR("example.com.	3600	IN	SOA	ns.example.com. hostmaster.example.com. 1 7200 900 1209600 300")
+	require.NoError(t, err)
+	m.Ns = []dns.RR{soa}
+	assert.Equal(t, 300*time.Second, negativeTTL(m))
+
+	soa.Header().Ttl = 60
+	assert.Equal(t, 60*time.Second, negativeTTL(m))
+}
+
+// msgWriter is a dns.ResponseWriter that keeps the written message.
+type msgWriter struct {
+	dns.ResponseWriter
+	msg *dns.Msg
+}
+
+func (w *msgWriter) WriteMsg(m *dns.Msg) error {
+	w.msg = m
+	return nil
+}
+
 func serveDNS(h dns.HandlerFunc) (cancel func() error, addr string, err error) {
 	// Setup listener on ephemeral port.
 
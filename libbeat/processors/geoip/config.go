@@ -0,0 +1,93 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+// config defines the configuration options for the geoip processor.
+type config struct {
+	Fields        mapstr.M         `config:"fields"`         // Mapping of source IP fields to target field prefixes.
+	Databases     []databaseConfig `config:"databases"`      // MaxMind DB files used for lookups.
+	Update        updateConfig     `config:"update"`         // Settings for downloading database updates.
+	IgnoreMissing bool             `config:"ignore_missing"` // Ignore events without the source field.
+	TagOnFailure  []string         `config:"tag_on_failure"` // Tags to append when a failure occurs.
+	reverseFlat   map[string]string
+}
+
+// databaseConfig defines a single database file and where to download
+// updates for it.
+type databaseConfig struct {
+	Path        string `config:"path" validate:"required"` // Relative paths are resolved against the data directory.
+	URL         string `config:"url"`                      // Optional download URL for the database file.
+	ChecksumURL string `config:"checksum_url"`             // SHA-256 checksum of the download, defaults to url + ".sha256".
+}
+
+// updateConfig defines how often databases with a URL are refreshed and the
+// HTTP client used to download them.
+type updateConfig struct {
+	Interval  time.Duration                    `config:"interval"`
+	Transport httpcommon.HTTPTransportSettings `config:",inline"`
+}
+
+// Validate validates the data contained in the config.
+func (c *config) Validate() error {
+	if len(c.Fields) == 0 {
+		return errors.New("at least one source field must be configured in fields")
+	}
+	if len(c.Databases) == 0 {
+		return errors.New("at least one database must be configured in databases")
+	}
+
+	// Flatten the mapping of source fields to target fields.
+	c.reverseFlat = map[string]string{}
+	for k, v := range c.Fields.Flatten() {
+		target, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("target field for geoip lookup of %v "+
+				"must be a string but got %T", k, v)
+		}
+		c.reverseFlat[k] = target
+	}
+
+	for i := range c.Databases {
+		db := &c.Databases[i]
+		if db.URL != "" && db.ChecksumURL == "" {
+			db.ChecksumURL = db.URL + ".sha256"
+		}
+	}
+	if c.Update.Interval < 0 {
+		return errors.New("update.interval must be >= 0")
+	}
+	return nil
+}
+
+func defaultConfig() config {
+	return config{
+		Update: updateConfig{
+			Interval:  24 * time.Hour,
+			Transport: httpcommon.DefaultHTTPTransportSettings(),
+		},
+	}
+}
@@ -0,0 +1,329 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/paths"
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+const (
+	procName = "geoip"
+	logName  = "processor." + procName
+)
+
+var errNoDatabase = errors.New("database not loaded")
+
+func init() {
+	// Not registered as a JS plugin because databases are loaded through
+	// SetPaths, which the script processor does not call.
+	processors.RegisterPlugin(procName, New)
+}
+
+// databaseKind is the kind of records stored in a database.
+type databaseKind uint8
+
+const (
+	kindCity databaseKind = iota // City and Country databases.
+	kindASN
+)
+
+// database is a MaxMind DB file that can be replaced while the processor is
+// running.
+type database struct {
+	databaseConfig
+	path   string            // Resolved path of the database file.
+	sum    [sha256.Size]byte // Checksum of the loaded file.
+	loaded atomic.Pointer[loadedDatabase]
+}
+
+// loadedDatabase is the currently active version of a database.
+type loadedDatabase struct {
+	*reader
+	kind databaseKind
+}
+
+type processor struct {
+	config
+	log *logp.Logger
+
+	dbs    []*database
+	client *http.Client
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// New constructs a new geoip processor. Databases are loaded and the update
+// loop is started when SetPaths is called.
+func New(cfg *conf.C, log *logp.Logger) (beat.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("fail to unpack the %v processor configuration: %w", procName, err)
+	}
+	return newGeoIP(c, log)
+}
+
+func newGeoIP(c config, log *logp.Logger) (*processor, error) {
+	log = log.Named(logName)
+	p := &processor{
+		config: c,
+		log:    log,
+	}
+	for _, dbCfg := range c.Databases {
+		p.dbs = append(p.dbs, &database{databaseConfig: dbCfg})
+		if dbCfg.URL != "" && p.client == nil {
+			client, err := c.Update.Transport.Client(httpcommon.WithLogger(log))
+			if err != nil {
+				return nil, fmt.Errorf("failed to create %v update client: %w", procName, err)
+			}
+			p.client = client
+		}
+	}
+	return p, nil
+}
+
+// SetPaths resolves the database paths against the data directory, loads
+// the databases and starts the update loop. A database that cannot be
+// loaded or downloaded is a configuration error.
+func (p *processor) SetPaths(path *paths.Path) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+
+	update := false
+	for _, db := range p.dbs {
+		db.path = path.Resolve(paths.Data, db.Path)
+		err := p.load(db)
+		if db.URL == "" {
+			if err != nil {
+				return err
+			}
+			continue
+		}
+		update = true
+		if err != nil {
+			p.log.Infow("Database not available, downloading it.", "path", db.path, "error", err)
+		}
+		if uerr := p.update(ctx, db); uerr != nil {
+			if err != nil {
+				return fmt.Errorf("failed to download %v database: %w", procName, uerr)
+			}
+			p.log.Warnw("Failed to update database, using the current database.", "path", db.path, "error", uerr)
+		}
+	}
+
+	if update && p.Update.Interval > 0 {
+		p.wg.Add(1)
+		go p.updateLoop(ctx)
+	}
+	return nil
+}
+
+func (p *processor) load(db *database) error {
+	buf, err := os.ReadFile(db.path)
+	if err != nil {
+		return fmt.Errorf("failed to read %v database: %w", procName, err)
+	}
+	return p.store(db, buf)
+}
+
+// store parses buf and makes it the active database of db.
+func (p *processor) store(db *database, buf []byte) error {
+	r, err := newReader(buf)
+	if err != nil {
+		return fmt.Errorf("failed to open %v database %v: %w", procName, db.path, err)
+	}
+	kind, err := kindOf(r.meta.DatabaseType)
+	if err != nil {
+		return fmt.Errorf("failed to open %v database %v: %w", procName, db.path, err)
+	}
+	db.sum = sha256.Sum256(buf)
+	db.loaded.Store(&loadedDatabase{reader: r, kind: kind})
+	p.log.Debugw("Loaded database.", "path", db.path, "type", r.meta.DatabaseType, "build_epoch", r.meta.BuildEpoch)
+	return nil
+}
+
+func kindOf(databaseType string) (databaseKind, error) {
+	t := strings.ToLower(databaseType)
+	switch {
+	case strings.Contains(t, "asn"):
+		return kindASN, nil
+	case strings.Contains(t, "city"), strings.Contains(t, "country"):
+		return kindCity, nil
+	default:
+		return 0, fmt.Errorf("unsupported database type %q", databaseType)
+	}
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	var tagOnce sync.Once
+	for field, target := range p.reverseFlat {
+		if err := p.processField(field, target, event); err != nil {
+			p.log.Debugf("GeoIP processor failed: %v", err)
+			tagOnce.Do(func() { _ = mapstr.AddTags(event.Fields, p.TagOnFailure) })
+		}
+	}
+	return event, nil
+}
+
+func (p *processor) processField(source, target string, event *beat.Event) error {
+	v, err := event.GetValue(source)
+	if err != nil {
+		if p.IgnoreMissing && errors.Is(err, mapstr.ErrKeyNotFound) {
+			return nil
+		}
+		return fmt.Errorf("failed to get source field %v: %w", source, err)
+	}
+	s, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("source field %v is a %T, not a string", source, v)
+	}
+	ip := net.ParseIP(s)
+	if ip == nil {
+		return fmt.Errorf("source field %v contains an invalid IP address %q", source, s)
+	}
+
+	for _, db := range p.dbs {
+		ldb := db.loaded.Load()
+		if ldb == nil {
+			return fmt.Errorf("%w: %v", errNoDatabase, db.Path)
+		}
+		rec, err := ldb.lookup(ip)
+		if err != nil {
+			return fmt.Errorf("lookup of %v in %v failed: %w", ip, db.Path, err)
+		}
+		if rec == nil {
+			continue
+		}
+
+		var fields mapstr.M
+		switch ldb.kind {
+		case kindASN:
+			fields = asFields(rec)
+		default:
+			fields = geoFields(rec)
+		}
+		for k, v := range fields.Flatten() {
+			if _, err := event.PutValue(target+"."+k, v); err != nil {
+				return fmt.Errorf("failed to set %v.%v: %w", target, k, err)
+			}
+		}
+	}
+	return nil
+}
+
+// geoFields maps a City or Country record to ECS geo fields.
+func geoFields(rec map[string]any) mapstr.M {
+	geo := mapstr.M{}
+	putString(geo, "city_name", rec, "city", "names", "en")
+	putString(geo, "continent_code", rec, "continent", "code")
+	putString(geo, "continent_name", rec, "continent", "names", "en")
+	putString(geo, "country_iso_code", rec, "country", "iso_code")
+	putString(geo, "country_name", rec, "country", "names", "en")
+	putString(geo, "postal_code", rec, "postal", "code")
+	putString(geo, "timezone", rec, "location", "time_zone")
+
+	if subs, ok := rec["subdivisions"].([]any); ok && len(subs) != 0 {
+		if sub, ok := subs[0].(map[string]any); ok {
+			if code, ok := sub["iso_code"].(string); ok {
+				if country, ok := geo["country_iso_code"].(string); ok {
+					code = country + "-" + code
+				}
+				geo["region_iso_code"] = code
+			}
+			putString(geo, "region_name", sub, "names", "en")
+		}
+	}
+
+	if loc, ok := rec["location"].(map[string]any); ok {
+		lat, latOK := loc["latitude"].(float64)
+		lon, lonOK := loc["longitude"].(float64)
+		if latOK && lonOK {
+			geo["location"] = mapstr.M{"lat": lat, "lon": lon}
+		}
+	}
+
+	if len(geo) == 0 {
+		return nil
+	}
+	return mapstr.M{"geo": geo}
+}
+
+// asFields maps an ASN record to ECS as fields.
+func asFields(rec map[string]any) mapstr.M {
+	as := mapstr.M{}
+	if n, ok := rec["autonomous_system_number"].(uint64); ok {
+		as["number"] = int64(n)
+	}
+	if org, ok := rec["autonomous_system_organization"].(string); ok {
+		as["organization"] = mapstr.M{"name": org}
+	}
+	if len(as) == 0 {
+		return nil
+	}
+	return mapstr.M{"as": as}
+}
+
+// putString copies the string at path in rec to key in m.
+func putString(m mapstr.M, key string, rec map[string]any, path ...string) {
+	var v any = rec
+	for _, k := range path {
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return
+		}
+		v = obj[k]
+	}
+	if s, ok := v.(string); ok && s != "" {
+		m[key] = s
+	}
+}
+
+// Close stops the update loop.
+func (p *processor) Close() error {
+	if p.cancel != nil {
+		p.cancel()
+	}
+	p.wg.Wait()
+	return nil
+}
+
+func (p *processor) String() string {
+	paths := make([]string, 0, len(p.dbs))
+	for _, db := range p.dbs {
+		paths = append(paths, db.Path)
+	}
+	return fmt.Sprintf("%v=[fields=%v, databases=%v, update_interval=%v]",
+		procName, p.Fields, paths, p.Update.Interval)
+}
@@ -0,0 +1,244 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+var (
+	cityRecords = map[string]map[string]any{
+		"81.2.69.0/24": {
+			"city":      map[string]any{"names": map[string]any{"en": "London"}},
+			"continent": map[string]any{"code": "EU", "names": map[string]any{"en": "Europe"}},
+			"country":   map[string]any{"iso_code": "GB", "names": map[string]any{"en": "United Kingdom"}},
+			"location": map[string]any{
+				"latitude":  51.5142,
+				"longitude": -0.0931,
+				"time_zone": "Europe/London",
+			},
+			"postal": map[string]any{"code": "EC4R"},
+			"subdivisions": []any{
+				map[string]any{"iso_code": "ENG", "names": map[string]any{"en": "England"}},
+			},
+		},
+	}
+	asnRecords = map[string]map[string]any{
+		"1.128.0.0/11": {
+			"autonomous_system_number":       uint32(1221),
+			"autonomous_system_organization": "Telstra Pty Ltd",
+		},
+	}
+)
+
+func TestGeoIP(t *testing.T) {
+	dir := t.TempDir()
+	writeDatabase(t, filepath.Join(dir, "city.mmdb"), "GeoLite2-City", cityRecords)
+	writeDatabase(t, filepath.Join(dir, "asn.mmdb"), "GeoLite2-ASN", asnRecords)
+	p := newTestProcessor(t, dir, mapstr.M{
+		"fields": mapstr.M{
+			"source.ip":      "source",
+			"destination.ip": "destination",
+		},
+		"databases": []mapstr.M{
+			{"path": "city.mmdb"},
+			{"path": "asn.mmdb"},
+		},
+		"ignore_missing": true,
+		"tag_on_failure": []string{"_geoip_lookup_failure"},
+	})
+
+	tests := map[string]struct {
+		fields mapstr.M
+		want   mapstr.M
+	}{
+		"city": {
+			fields: mapstr.M{"source": mapstr.M{"ip": "81.2.69.142"}},
+			want: mapstr.M{"source": mapstr.M{
+				"ip": "81.2.69.142",
+				"geo": mapstr.M{
+					"city_name":        "London",
+					"continent_code":   "EU",
+					"continent_name":   "Europe",
+					"country_iso_code": "GB",
+					"country_name":     "United Kingdom",
+					"location":         mapstr.M{"lat": 51.5142, "lon": -0.0931},
+					"postal_code":      "EC4R",
+					"region_iso_code":  "GB-ENG",
+					"region_name":      "England",
+					"timezone":         "Europe/London",
+				},
+			}},
+		},
+		"asn": {
+			fields: mapstr.M{"destination": mapstr.M{"ip": "1.128.0.1"}},
+			want: mapstr.M{"destination": mapstr.M{
+				"ip": "1.128.0.1",
+				"as": mapstr.M{
+					"number":       int64(1221),
+					"organization": mapstr.M{"name": "Telstra Pty Ltd"},
+				},
+			}},
+		},
+		"not found": {
+			fields: mapstr.M{"source": mapstr.M{"ip": "192.168.1.1"}},
+			want:   mapstr.M{"source": mapstr.M{"ip": "192.168.1.1"}},
+		},
+		"invalid ip": {
+			fields: mapstr.M{"source": mapstr.M{"ip": "not-an-ip"}},
+			want: mapstr.M{
+				"source": mapstr.M{"ip": "not-an-ip"},
+				"tags":   []string{"_geoip_lookup_failure"},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			event, err := p.Run(&beat.Event{Fields: test.fields})
+			require.NoError(t, err)
+			assert.Equal(t, test.want, event.Fields)
+		})
+	}
+}
+
+func TestGeoIPUpdate(t *testing.T) {
+	dir := t.TempDir()
+	db := buildDatabase(t, "GeoLite2-City", 24, cityRecords)
+	sum := sha256.Sum256(db)
+
+	var (
+		downloads atomic.Int32
+		checksum  atomic.Value
+	)
+	checksum.Store(hex.EncodeToString(sum[:]) + "  GeoLite2-City.mmdb\n")
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/city.mmdb":
+			downloads.Add(1)
+			_, _ = w.Write(db)
+		case "/city.mmdb.sha256":
+			_, _ = w.Write([]byte(checksum.Load().(string)))
+		case "/bad.sha256":
+			_, _ = w.Write([]byte("0000"))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := func(checksumURL string) mapstr.M {
+		return mapstr.M{
+			"fields": mapstr.M{"source.ip": "source"},
+			"databases": []mapstr.M{{
+				"path":         "geoip/city.mmdb",
+				"url":          srv.URL + "/city.mmdb",
+				"checksum_url": checksumURL,
+			}},
+			"update.interval": 0,
+		}
+	}
+
+	t.Run("download missing database", func(t *testing.T) {
+		p := newTestProcessor(t, dir, cfg(""))
+		assert.Equal(t, int32(1), downloads.Load(), "database should be downloaded once")
+		assert.FileExists(t, filepath.Join(dir, "geoip", "city.mmdb"))
+
+		event, err := p.Run(&beat.Event{Fields: mapstr.M{"source": mapstr.M{"ip": "81.2.69.142"}}})
+		require.NoError(t, err)
+		city, _ := event.GetValue("source.geo.city_name")
+		assert.Equal(t, "London", city)
+	})
+
+	t.Run("skip download when up to date", func(t *testing.T) {
+		newTestProcessor(t, dir, cfg(""))
+		assert.Equal(t, int32(1), downloads.Load(), "unchanged database should not be downloaded")
+	})
+
+	t.Run("checksum mismatch keeps current database", func(t *testing.T) {
+		checksum.Store(hex.EncodeToString(make([]byte, sha256.Size)))
+		defer checksum.Store(hex.EncodeToString(sum[:]))
+
+		newTestProcessor(t, dir, cfg(""))
+		got, err := os.ReadFile(filepath.Join(dir, "geoip", "city.mmdb"))
+		require.NoError(t, err)
+		assert.Equal(t, db, got, "database file should not be replaced")
+	})
+
+	t.Run("invalid checksum without database", func(t *testing.T) {
+		c, err := New(conf.MustNewConfigFrom(cfg(srv.URL+"/bad.sha256")), logptest.NewTestingLogger(t, ""))
+		require.NoError(t, err)
+		err = c.(*processor).SetPaths(&paths.Path{Data: t.TempDir()})
+		assert.ErrorContains(t, err, "invalid SHA-256 checksum")
+	})
+}
+
+func TestGeoIPMissingDatabase(t *testing.T) {
+	p, err := New(conf.MustNewConfigFrom(mapstr.M{
+		"fields":    mapstr.M{"source.ip": "source"},
+		"databases": []mapstr.M{{"path": "missing.mmdb"}},
+	}), logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+	err = p.(*processor).SetPaths(&paths.Path{Data: t.TempDir()})
+	assert.Error(t, err, "missing database without url should fail")
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]mapstr.M{
+		"no fields":    {"databases": []mapstr.M{{"path": "city.mmdb"}}},
+		"no databases": {"fields": mapstr.M{"source.ip": "source"}},
+		"no path":      {"fields": mapstr.M{"source.ip": "source"}, "databases": []mapstr.M{{"url": "http://localhost"}}},
+		"bad target":   {"fields": mapstr.M{"source.ip": 1}, "databases": []mapstr.M{{"path": "city.mmdb"}}},
+	}
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(conf.MustNewConfigFrom(cfg), logptest.NewTestingLogger(t, ""))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func newTestProcessor(t *testing.T, dataDir string, cfg mapstr.M) *processor {
+	t.Helper()
+	p, err := New(conf.MustNewConfigFrom(cfg), logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+	gp := p.(*processor)
+	require.NoError(t, gp.SetPaths(&paths.Path{Data: dataDir}), "failed to load databases")
+	t.Cleanup(func() { gp.Close() })
+	return gp
+}
+
+func writeDatabase(t *testing.T, path, databaseType string, records map[string]map[string]any) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, buildDatabase(t, databaseType, 28, records), 0o600))
+}
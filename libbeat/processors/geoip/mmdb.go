@@ -0,0 +1,368 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"net"
+)
+
+// This file implements a reader for the MaxMind DB file format used by the
+// MaxMind GeoIP2/GeoLite2 and DB-IP databases. Only the subset needed to look
+// up records by IP address is implemented. The format is described at
+// https://maxmind.github.io/MaxMind-DB/.
+
+var metadataMarker = []byte("\xAB\xCD\xEFMaxMind.com")
+
+// dataSectionSeparator is the size of the block of zero bytes between the
+// search tree and the data section.
+const dataSectionSeparator = 16
+
+var errInvalidDatabase = errors.New("invalid MaxMind DB file")
+
+// maxDecodeDepth is the maximum nesting of maps, arrays and pointers in a
+// value. It bounds the recursion of the decoder on crafted files, where
+// pointers can form cycles.
+const maxDecodeDepth = 512
+
+// Data types of the MaxMind DB data section.
+const (
+	typeExtended  = 0
+	typePointer   = 1
+	typeString    = 2
+	typeDouble    = 3
+	typeBytes     = 4
+	typeUint16    = 5
+	typeUint32    = 6
+	typeMap       = 7
+	typeInt32     = 8
+	typeUint64    = 9
+	typeUint128   = 10
+	typeArray     = 11
+	typeContainer = 12
+	typeEndMarker = 13
+	typeBool      = 14
+	typeFloat     = 15
+)
+
+// metadata holds the database metadata fields used by the reader.
+type metadata struct {
+	DatabaseType string
+	BuildEpoch   uint64
+	NodeCount    uint
+	RecordSize   uint
+	IPVersion    uint
+}
+
+// reader looks up records in an in-memory MaxMind DB file.
+type reader struct {
+	meta      metadata
+	tree      []byte
+	data      decoder
+	ipv4Start uint
+}
+
+// newReader parses the metadata of a MaxMind DB file and validates the
+// layout of its search tree.
+func newReader(buf []byte) (*reader, error) {
+	idx := bytes.LastIndex(buf, metadataMarker)
+	if idx < 0 {
+		return nil, fmt.Errorf("%w: metadata marker not found", errInvalidDatabase)
+	}
+	metaStart := idx + len(metadataMarker)
+	raw, _, err := decoder{buf: buf[metaStart:]}.decode(0)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode metadata: %w", errInvalidDatabase, err)
+	}
+	m, ok := raw.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: metadata is not a map", errInvalidDatabase)
+	}
+
+	meta := metadata{
+		DatabaseType: asString(m["database_type"]),
+		BuildEpoch:   asUint(m["build_epoch"]),
+		NodeCount:    uint(asUint(m["node_count"])),
+		RecordSize:   uint(asUint(m["record_size"])),
+		IPVersion:    uint(asUint(m["ip_version"])),
+	}
+	switch meta.RecordSize {
+	case 24, 28, 32:
+	default:
+		return nil, fmt.Errorf("%w: unsupported record size %d", errInvalidDatabase, meta.RecordSize)
+	}
+	if meta.IPVersion != 4 && meta.IPVersion != 6 {
+		return nil, fmt.Errorf("%w: unsupported ip version %d", errInvalidDatabase, meta.IPVersion)
+	}
+
+	treeSize := meta.NodeCount * meta.RecordSize / 4
+	dataStart := treeSize + dataSectionSeparator
+	if dataStart > uint(idx) {
+		return nil, fmt.Errorf("%w: search tree exceeds file size", errInvalidDatabase)
+	}
+
+	r := &reader{
+		meta: meta,
+		tree: buf[:treeSize],
+		data: decoder{buf: buf[dataStart:idx]},
+	}
+	// IPv4 addresses are stored in IPv6 trees under ::/96. Find the node
+	// for that subtree once instead of walking it on every lookup.
+	if meta.IPVersion == 6 {
+		for i := 0; i < 96 && r.ipv4Start < meta.NodeCount; i++ {
+			r.ipv4Start = r.readNode(r.ipv4Start, 0)
+		}
+	}
+	return r, nil
+}
+
+// lookup returns the record for ip or nil if the database has no record
+// for the address.
+func (r *reader) lookup(ip net.IP) (map[string]any, error) {
+	node := uint(0)
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		if r.meta.IPVersion == 6 {
+			node = r.ipv4Start
+		}
+	} else if r.meta.IPVersion == 4 {
+		return nil, fmt.Errorf("cannot look up IPv6 address %v in an IPv4 only database", ip)
+	}
+
+	bits := len(ip) * 8
+	for i := 0; i < bits && node < r.meta.NodeCount; i++ {
+		bit := uint(ip[i>>3]>>(7-uint(i&7))) & 1
+		node = r.readNode(node, bit)
+	}
+
+	switch {
+	case node == r.meta.NodeCount:
+		return nil, nil
+	case node < r.meta.NodeCount:
+		return nil, fmt.Errorf("%w: search tree is too deep", errInvalidDatabase)
+	}
+	offset := node - r.meta.NodeCount - dataSectionSeparator
+	v, _, err := r.data.decode(offset)
+	if err != nil {
+		return nil, err
+	}
+	rec, ok := v.(map[string]any)
+	if !ok {
+		return nil, fmt.Errorf("%w: record for %v is a %T, not a map", errInvalidDatabase, ip, v)
+	}
+	return rec, nil
+}
+
+// readNode returns the left (bit 0) or right (bit 1) record of a node.
+func (r *reader) readNode(node, bit uint) uint {
+	switch r.meta.RecordSize {
+	case 24:
+		b := r.tree[node*6+bit*3:]
+		return uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+	case 28:
+		b := r.tree[node*7:]
+		if bit == 0 {
+			return uint(b[3]&0xF0)<<20 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])
+		}
+		return uint(b[3]&0x0F)<<24 | uint(b[4])<<16 | uint(b[5])<<8 | uint(b[6])
+	default:
+		return uint(binary.BigEndian.Uint32(r.tree[node*8+bit*4:]))
+	}
+}
+
+// decoder decodes values from the data or metadata section. Pointers are
+// offsets relative to the start of buf.
+type decoder struct {
+	buf []byte
+}
+
+// decode decodes the value at offset and returns it together with the
+// offset of the next value.
+func (d decoder) decode(offset uint) (any, uint, error) {
+	return d.decodeValue(offset, 0)
+}
+
+func (d decoder) decodeValue(offset uint, depth int) (any, uint, error) {
+	if depth > maxDecodeDepth {
+		return nil, 0, fmt.Errorf("%w: data nested deeper than %d levels at offset %d", errInvalidDatabase, maxDecodeDepth, offset)
+	}
+	ctrl, offset, err := d.bytes(offset, 1)
+	if err != nil {
+		return nil, 0, err
+	}
+	typ := uint(ctrl[0] >> 5)
+	if typ == typeExtended {
+		var ext []byte
+		if ext, offset, err = d.bytes(offset, 1); err != nil {
+			return nil, 0, err
+		}
+		typ = 7 + uint(ext[0])
+	}
+
+	if typ == typePointer {
+		return d.decodePointer(ctrl[0], offset, depth)
+	}
+
+	size := uint(ctrl[0] & 0x1F)
+	if size >= 29 {
+		var b []byte
+		if b, offset, err = d.bytes(offset, size-28); err != nil {
+			return nil, 0, err
+		}
+		switch size {
+		case 29:
+			size = 29 + uint(b[0])
+		case 30:
+			size = 285 + (uint(b[0])<<8 | uint(b[1]))
+		default:
+			size = 65821 + (uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2]))
+		}
+	}
+
+	switch typ {
+	case typeMap:
+		m := make(map[string]any, size)
+		for i := uint(0); i < size; i++ {
+			var k, v any
+			if k, offset, err = d.decodeValue(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+			key, ok := k.(string)
+			if !ok {
+				return nil, 0, fmt.Errorf("%w: map key is a %T, not a string", errInvalidDatabase, k)
+			}
+			if v, offset, err = d.decodeValue(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+			m[key] = v
+		}
+		return m, offset, nil
+	case typeArray:
+		a := make([]any, 0, size)
+		for i := uint(0); i < size; i++ {
+			var v any
+			if v, offset, err = d.decodeValue(offset, depth+1); err != nil {
+				return nil, 0, err
+			}
+			a = append(a, v)
+		}
+		return a, offset, nil
+	case typeBool:
+		return size != 0, offset, nil
+	}
+
+	b, next, err := d.bytes(offset, size)
+	if err != nil {
+		return nil, 0, err
+	}
+	switch typ {
+	case typeString:
+		return string(b), next, nil
+	case typeBytes:
+		return bytes.Clone(b), next, nil
+	case typeDouble:
+		if size != 8 {
+			return nil, 0, fmt.Errorf("%w: invalid double size %d", errInvalidDatabase, size)
+		}
+		return math.Float64frombits(binary.BigEndian.Uint64(b)), next, nil
+	case typeFloat:
+		if size != 4 {
+			return nil, 0, fmt.Errorf("%w: invalid float size %d", errInvalidDatabase, size)
+		}
+		return float64(math.Float32frombits(binary.BigEndian.Uint32(b))), next, nil
+	case typeUint16, typeUint32, typeUint64:
+		if size > 8 {
+			return nil, 0, fmt.Errorf("%w: invalid integer size %d", errInvalidDatabase, size)
+		}
+		return uintFromBytes(b), next, nil
+	case typeInt32:
+		if size > 4 {
+			return nil, 0, fmt.Errorf("%w: invalid int32 size %d", errInvalidDatabase, size)
+		}
+		return int64(int32(uintFromBytes(b))), next, nil
+	case typeUint128:
+		return new(big.Int).SetBytes(b), next, nil
+	default:
+		return nil, 0, fmt.Errorf("%w: unsupported data type %d", errInvalidDatabase, typ)
+	}
+}
+
+// decodePointer decodes the value a pointer points to. A pointer to a
+// pointer is invalid, so every pointer followed is to a new value, within
+// maxDecodeDepth.
+func (d decoder) decodePointer(ctrl byte, offset uint, depth int) (any, uint, error) {
+	ss := uint(ctrl>>3) & 0x3
+	b, next, err := d.bytes(offset, ss+1)
+	if err != nil {
+		return nil, 0, err
+	}
+	vvv := uint(ctrl & 0x7)
+	var ptr uint
+	switch ss {
+	case 0:
+		ptr = vvv<<8 | uint(b[0])
+	case 1:
+		ptr = (vvv<<16 | uint(b[0])<<8 | uint(b[1])) + 2048
+	case 2:
+		ptr = (vvv<<24 | uint(b[0])<<16 | uint(b[1])<<8 | uint(b[2])) + 526336
+	default:
+		ptr = uint(binary.BigEndian.Uint32(b))
+	}
+	target, _, err := d.bytes(ptr, 1)
+	if err != nil {
+		return nil, 0, err
+	}
+	if target[0]>>5 == typePointer {
+		return nil, 0, fmt.Errorf("%w: pointer at offset %d points to a pointer", errInvalidDatabase, offset-1)
+	}
+	// The value after the pointer follows the pointer itself, not the
+	// value it points to.
+	v, _, err := d.decodeValue(ptr, depth+1)
+	return v, next, err
+}
+
+func (d decoder) bytes(offset, n uint) ([]byte, uint, error) {
+	end := offset + n
+	if end > uint(len(d.buf)) || end < offset {
+		return nil, 0, fmt.Errorf("%w: unexpected end of data at offset %d", errInvalidDatabase, offset)
+	}
+	return d.buf[offset:end], end, nil
+}
+
+func uintFromBytes(b []byte) uint64 {
+	var v uint64
+	for _, c := range b {
+		v = v<<8 | uint64(c)
+	}
+	return v
+}
+
+func asString(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+func asUint(v any) uint64 {
+	u, _ := v.(uint64)
+	return u
+}
@@ -0,0 +1,289 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"encoding/binary"
+	"math"
+	"net"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReaderLookup(t *testing.T) {
+	for _, recordSize := range []uint{24, 28, 32} {
+		buf := buildDatabase(t, "GeoLite2-City", recordSize, map[string]map[string]any{
+			"81.2.69.0/24":  {"city": map[string]any{"names": map[string]any{"en": "London"}}},
+			"2001:db8::/32": {"city": map[string]any{"names": map[string]any{"en": "Documentation"}}},
+		})
+		r, err := newReader(buf)
+		require.NoError(t, err, "record size %d", recordSize)
+		assert.Equal(t, "GeoLite2-City", r.meta.DatabaseType)
+		assert.Equal(t, recordSize, r.meta.RecordSize)
+
+		tests := map[string]string{
+			"81.2.69.142":      "London",
+			"2001:db8::1":      "Documentation",
+			"81.2.70.1":        "",
+			"10.0.0.1":         "",
+			"2001:db9::1":      "",
+			"::ffff:81.2.69.1": "London",
+		}
+		for ip, want := range tests {
+			rec, err := r.lookup(net.ParseIP(ip))
+			require.NoError(t, err, "record size %d, ip %s", recordSize, ip)
+			if want == "" {
+				assert.Nil(t, rec, "record size %d, ip %s", recordSize, ip)
+				continue
+			}
+			require.NotNil(t, rec, "record size %d, ip %s", recordSize, ip)
+			assert.Equal(t, want, rec["city"].(map[string]any)["names"].(map[string]any)["en"], "record size %d, ip %s", recordSize, ip)
+		}
+	}
+}
+
+func TestDecoder(t *testing.T) {
+	tests := map[string]struct {
+		value any
+		want  any
+	}{
+		"string":       {value: "hello", want: "hello"},
+		"long string":  {value: string(make([]byte, 300)), want: string(make([]byte, 300))},
+		"double":       {value: 51.5142, want: 51.5142},
+		"uint32":       {value: uint32(16509), want: uint64(16509)},
+		"uint64":       {value: uint64(math.MaxUint64), want: uint64(math.MaxUint64)},
+		"bool":         {value: true, want: true},
+		"empty map":    {value: map[string]any{}, want: map[string]any{}},
+		"array":        {value: []any{"a", uint32(1)}, want: []any{"a", uint64(1)}},
+		"nested map":   {value: map[string]any{"a": map[string]any{"b": "c"}}, want: map[string]any{"a": map[string]any{"b": "c"}}},
+		"zero integer": {value: uint32(0), want: uint64(0)},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, next, err := decoder{buf: encodeValue(test.value)}.decode(0)
+			require.NoError(t, err)
+			assert.Equal(t, test.want, got)
+			assert.Equal(t, uint(len(encodeValue(test.value))), next)
+		})
+	}
+
+	t.Run("pointer", func(t *testing.T) {
+		// The pointer at offset 6 refers to the string at offset 0.
+		buf := append(encodeValue("hello"), 0x20, 0x00)
+		got, next, err := decoder{buf: buf}.decode(6)
+		require.NoError(t, err)
+		assert.Equal(t, "hello", got)
+		assert.Equal(t, uint(8), next)
+	})
+
+	t.Run("pointer to pointer", func(t *testing.T) {
+		// The pointer at offset 0 refers to itself.
+		_, _, err := decoder{buf: []byte{0x20, 0x00}}.decode(0)
+		assert.ErrorIs(t, err, errInvalidDatabase)
+		assert.ErrorContains(t, err, "points to a pointer")
+	})
+
+	t.Run("pointer cycle", func(t *testing.T) {
+		// A map with a single entry, "a", whose value points to the map.
+		buf := []byte{0xE1, 0x41, 'a', 0x20, 0x00}
+		_, _, err := decoder{buf: buf}.decode(0)
+		assert.ErrorIs(t, err, errInvalidDatabase)
+		assert.ErrorContains(t, err, "nested deeper than")
+	})
+
+	t.Run("truncated", func(t *testing.T) {
+		buf := encodeValue("hello")
+		_, _, err := decoder{buf: buf[:3]}.decode(0)
+		assert.ErrorIs(t, err, errInvalidDatabase)
+	})
+}
+
+func TestNewReaderInvalid(t *testing.T) {
+	_, err := newReader([]byte("not a database"))
+	assert.ErrorIs(t, err, errInvalidDatabase)
+}
+
+// buildDatabase returns an IPv6 MaxMind DB file containing records for the
+// given networks. IPv4 networks are stored in the ::/96 subtree.
+func buildDatabase(t testing.TB, databaseType string, recordSize uint, networks map[string]map[string]any) []byte {
+	t.Helper()
+
+	type node struct {
+		children [2]*node
+		data     []byte // Set on leaves.
+		index    uint
+		offset   uint
+	}
+	root := &node{}
+	cidrs := make([]string, 0, len(networks))
+	for cidr := range networks {
+		cidrs = append(cidrs, cidr)
+	}
+	sort.Strings(cidrs)
+	for _, cidr := range cidrs {
+		_, network, err := net.ParseCIDR(cidr)
+		require.NoError(t, err)
+		ones, bits := network.Mask.Size()
+		ip := network.IP.To16()
+		if bits == 32 {
+			ip = append(make(net.IP, 12), network.IP.To4()...)
+			ones += 96
+		}
+		n := root
+		for i := 0; i < ones; i++ {
+			bit := (ip[i>>3] >> (7 - uint(i&7))) & 1
+			if i == ones-1 {
+				n.children[bit] = &node{data: encodeValue(networks[cidr])}
+				break
+			}
+			if n.children[bit] == nil {
+				n.children[bit] = &node{}
+			}
+			n = n.children[bit]
+		}
+	}
+
+	// Number the tree nodes in breadth first order and lay out the data.
+	var nodes, leaves []*node
+	queue := []*node{root}
+	for len(queue) != 0 {
+		n := queue[0]
+		queue = queue[1:]
+		if n.data != nil {
+			leaves = append(leaves, n)
+			continue
+		}
+		n.index = uint(len(nodes))
+		nodes = append(nodes, n)
+		for _, c := range n.children {
+			if c != nil {
+				queue = append(queue, c)
+			}
+		}
+	}
+	var data []byte
+	for _, l := range leaves {
+		l.offset = uint(len(data))
+		data = append(data, l.data...)
+	}
+
+	nodeCount := uint(len(nodes))
+	record := func(c *node) uint {
+		switch {
+		case c == nil:
+			return nodeCount
+		case c.data != nil:
+			return nodeCount + dataSectionSeparator + c.offset
+		default:
+			return c.index
+		}
+	}
+	var tree []byte
+	for _, n := range nodes {
+		left, right := record(n.children[0]), record(n.children[1])
+		switch recordSize {
+		case 24:
+			tree = append(tree, byte(left>>16), byte(left>>8), byte(left), byte(right>>16), byte(right>>8), byte(right))
+		case 28:
+			tree = append(tree, byte(left>>16), byte(left>>8), byte(left),
+				byte(left>>20)&0xF0|byte(right>>24)&0x0F, byte(right>>16), byte(right>>8), byte(right))
+		default:
+			tree = binary.BigEndian.AppendUint32(tree, uint32(left))
+			tree = binary.BigEndian.AppendUint32(tree, uint32(right))
+		}
+	}
+
+	buf := append(tree, make([]byte, dataSectionSeparator)...)
+	buf = append(buf, data...)
+	buf = append(buf, metadataMarker...)
+	return append(buf, encodeValue(map[string]any{
+		"database_type": databaseType,
+		"build_epoch":   uint64(1700000000),
+		"node_count":    uint32(nodeCount),
+		"record_size":   uint32(recordSize),
+		"ip_version":    uint32(6),
+	})...)
+}
+
+// encodeValue encodes v in the MaxMind DB data format.
+func encodeValue(v any) []byte {
+	switch v := v.(type) {
+	case string:
+		return append(encodeControl(typeString, uint(len(v))), v...)
+	case float64:
+		return binary.BigEndian.AppendUint64(encodeControl(typeDouble, 8), math.Float64bits(v))
+	case bool:
+		if v {
+			return encodeControl(typeBool, 1)
+		}
+		return encodeControl(typeBool, 0)
+	case uint32:
+		return encodeUint(typeUint32, uint64(v))
+	case uint64:
+		return encodeUint(typeUint64, v)
+	case []any:
+		b := encodeControl(typeArray, uint(len(v)))
+		for _, e := range v {
+			b = append(b, encodeValue(e)...)
+		}
+		return b
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		b := encodeControl(typeMap, uint(len(v)))
+		for _, k := range keys {
+			b = append(b, encodeValue(k)...)
+			b = append(b, encodeValue(v[k])...)
+		}
+		return b
+	default:
+		panic("unsupported type")
+	}
+}
+
+func encodeUint(typ byte, v uint64) []byte {
+	var b []byte
+	for ; v != 0; v >>= 8 {
+		b = append([]byte{byte(v)}, b...)
+	}
+	return append(encodeControl(typ, uint(len(b))), b...)
+}
+
+func encodeControl(typ byte, size uint) []byte {
+	var sizeBits byte
+	var ext []byte
+	switch {
+	case size < 29:
+		sizeBits = byte(size)
+	case size < 285:
+		sizeBits, ext = 29, []byte{byte(size - 29)}
+	default:
+		sizeBits, ext = 30, []byte{byte((size - 285) >> 8), byte(size - 285)}
+	}
+	b := []byte{typ<<5 | sizeBits}
+	if typ > 7 {
+		b = []byte{sizeBits, typ - 7}
+	}
+	return append(b, ext...)
+}
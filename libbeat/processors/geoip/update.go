@@ -0,0 +1,158 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package geoip
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// maxChecksumSize limits the size of checksum files read from checksum_url.
+const maxChecksumSize = 4096
+
+func (p *processor) updateLoop(ctx context.Context) {
+	defer p.wg.Done()
+
+	ticker := time.NewTicker(p.Update.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		for _, db := range p.dbs {
+			if db.URL == "" {
+				continue
+			}
+			if err := p.update(ctx, db); err != nil {
+				p.log.Warnw("Failed to update database, keeping the current database.", "path", db.path, "error", err)
+			}
+		}
+	}
+}
+
+// update downloads a new version of db if the published checksum differs
+// from the loaded file. The download is verified against the checksum and
+// parsed before it replaces the file on disk and the active database.
+func (p *processor) update(ctx context.Context, db *database) error {
+	want, err := p.fetchChecksum(ctx, db.ChecksumURL)
+	if err != nil {
+		return err
+	}
+	if db.loaded.Load() != nil && bytes.Equal(want, db.sum[:]) {
+		p.log.Debugw("Database is up to date.", "path", db.path)
+		return nil
+	}
+
+	body, err := p.get(ctx, db.URL, -1)
+	if err != nil {
+		return err
+	}
+	if got := sha256.Sum256(body); !bytes.Equal(want, got[:]) {
+		return fmt.Errorf("checksum mismatch for %v: expected %x, got %x", db.URL, want, got)
+	}
+	// Validate the download before replacing the current file.
+	if _, err := newReader(body); err != nil {
+		return fmt.Errorf("downloaded database from %v is invalid: %w", db.URL, err)
+	}
+	if err := writeFile(db.path, body); err != nil {
+		return err
+	}
+	if err := p.store(db, body); err != nil {
+		return err
+	}
+	p.log.Infow("Updated database.", "path", db.path, "url", db.URL)
+	return nil
+}
+
+// fetchChecksum returns the SHA-256 checksum published at url. The file may
+// contain only the hex digest or use the sha256sum format.
+func (p *processor) fetchChecksum(ctx context.Context, url string) ([]byte, error) {
+	body, err := p.get(ctx, url, maxChecksumSize)
+	if err != nil {
+		return nil, err
+	}
+	digest, _, _ := strings.Cut(strings.TrimSpace(string(body)), " ")
+	sum, err := hex.DecodeString(digest)
+	if err != nil || len(sum) != sha256.Size {
+		return nil, fmt.Errorf("invalid SHA-256 checksum at %v", url)
+	}
+	return sum, nil
+}
+
+// get returns the body of url. A limit < 0 reads the whole body.
+func (p *processor) get(ctx context.Context, url string, limit int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %v: %w", url, err)
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %v: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download %v: unexpected status %v", url, resp.Status)
+	}
+
+	var r io.Reader = resp.Body
+	if limit >= 0 {
+		r = io.LimitReader(r, limit)
+	}
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download %v: %w", url, err)
+	}
+	return body, nil
+}
+
+// writeFile atomically replaces path with data by writing to a temporary
+// file in the same directory and renaming it.
+func writeFile(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return fmt.Errorf("failed to create database directory: %w", err)
+	}
+	f, err := os.CreateTemp(dir, filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary database file: %w", err)
+	}
+	tmp := f.Name()
+	_, err = f.Write(data)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err == nil {
+		err = os.Rename(tmp, path)
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write database file %v: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,184 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package math
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+func defaultConfig() config {
+	return config{
+		IgnoreMissing: false,
+		FailOnError:   true,
+	}
+}
+
+type config struct {
+	Fields        []operation `config:"fields" validate:"required"` // Operations, applied in order.
+	Tag           string      `config:"tag"`                        // Processor ID for debug and metrics.
+	IgnoreMissing bool        `config:"ignore_missing"`             // Skip operations whose fields are missing.
+	FailOnError   bool        `config:"fail_on_error"`              // Undo all operations when one fails.
+}
+
+// operation computes a value from field and writes it to target_field. The
+// operator is applied first, then the unit conversion, then the rounding.
+type operation struct {
+	Field        string   `config:"field" validate:"required"`
+	Target       string   `config:"target_field"`
+	Operator     operator `config:"operator"`
+	Operand      *float64 `config:"operand"`
+	OperandField string   `config:"operand_field"`
+	FromUnit     *unit    `config:"from_unit"`
+	ToUnit       *unit    `config:"to_unit"`
+	Round        *int     `config:"round"`
+}
+
+func (o operation) Validate() error {
+	switch {
+	case o.Operator == noop && o.FromUnit == nil && o.ToUnit == nil && o.Round == nil:
+		return fmt.Errorf("field [%v] must have an operator, a unit conversion or round", o.Field)
+	case o.Operator == noop && (o.Operand != nil || o.OperandField != ""):
+		return fmt.Errorf("field [%v] has an operand but no operator", o.Field)
+	case o.Operator != noop && (o.Operand == nil) == (o.OperandField == ""):
+		return fmt.Errorf("field [%v] operator %v requires exactly one of operand or operand_field", o.Field, o.Operator)
+	case (o.FromUnit == nil) != (o.ToUnit == nil):
+		return fmt.Errorf("field [%v] must set both from_unit and to_unit", o.Field)
+	case o.FromUnit != nil && o.FromUnit.dimension != o.ToUnit.dimension:
+		return fmt.Errorf("field [%v] cannot convert %v to %v", o.Field, o.FromUnit.name, o.ToUnit.name)
+	case o.Operator == divide && o.Operand != nil && *o.Operand == 0:
+		return errors.New("operand of divide must not be zero")
+	case o.Round != nil && *o.Round < 0:
+		return fmt.Errorf("field [%v] round must not be negative", o.Field)
+	}
+	return nil
+}
+
+func (o operation) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "{field=%v, target_field=%v", o.Field, o.Target)
+	if o.Operator != noop {
+		fmt.Fprintf(&b, ", operator=%v", o.Operator)
+		if o.OperandField != "" {
+			fmt.Fprintf(&b, ", operand_field=%v", o.OperandField)
+		} else {
+			fmt.Fprintf(&b, ", operand=%v", *o.Operand)
+		}
+	}
+	if o.FromUnit != nil {
+		fmt.Fprintf(&b, ", from_unit=%v, to_unit=%v", o.FromUnit.name, o.ToUnit.name)
+	}
+	if o.Round != nil {
+		fmt.Fprintf(&b, ", round=%d", *o.Round)
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+type operator uint8
+
+// List of operators.
+const (
+	noop operator = iota
+	add
+	subtract
+	multiply
+	divide
+	modulo
+	power
+)
+
+var operatorNames = map[operator]string{
+	noop:     "[unset]",
+	add:      "add",
+	subtract: "subtract",
+	multiply: "multiply",
+	divide:   "divide",
+	modulo:   "modulo",
+	power:    "power",
+}
+
+func (op operator) String() string {
+	return operatorNames[op]
+}
+
+func (op operator) MarshalText() ([]byte, error) {
+	return []byte(op.String()), nil
+}
+
+func (op *operator) Unpack(s string) error {
+	s = strings.ToLower(s)
+	for o, name := range operatorNames {
+		if o != noop && s == name {
+			*op = o
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid operator: %v", s)
+}
+
+type dimension uint8
+
+const (
+	duration dimension = iota
+	dataSize
+)
+
+// unit is a unit of measure. factor converts a value in this unit to the
+// base unit of its dimension (seconds or bytes).
+type unit struct {
+	name      string
+	dimension dimension
+	factor    float64
+}
+
+var units = map[string]unit{
+	"ns": {"ns", duration, 1e-9},
+	"us": {"us", duration, 1e-6},
+	"ms": {"ms", duration, 1e-3},
+	"s":  {"s", duration, 1},
+	"m":  {"m", duration, 60},
+	"h":  {"h", duration, 3600},
+	"d":  {"d", duration, 86400},
+
+	"bit":  {"bit", dataSize, 1.0 / 8},
+	"kbit": {"kbit", dataSize, 1e3 / 8},
+	"mbit": {"mbit", dataSize, 1e6 / 8},
+	"gbit": {"gbit", dataSize, 1e9 / 8},
+	"b":    {"b", dataSize, 1},
+	"kb":   {"kb", dataSize, 1e3},
+	"mb":   {"mb", dataSize, 1e6},
+	"gb":   {"gb", dataSize, 1e9},
+	"tb":   {"tb", dataSize, 1e12},
+	"pb":   {"pb", dataSize, 1e15},
+	"kib":  {"kib", dataSize, 1 << 10},
+	"mib":  {"mib", dataSize, 1 << 20},
+	"gib":  {"gib", dataSize, 1 << 30},
+	"tib":  {"tib", dataSize, 1 << 40},
+	"pib":  {"pib", dataSize, 1 << 50},
+}
+
+func (u *unit) Unpack(s string) error {
+	v, ok := units[strings.ToLower(s)]
+	if !ok {
+		return fmt.Errorf("invalid unit: %v", s)
+	}
+	*u = v
+	return nil
+}
@@ -0,0 +1,239 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package math implements a processor that does arithmetic, unit conversion
+// and rounding on numeric fields.
+package math
+
+import (
+	"errors"
+	"fmt"
+	stdmath "math"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	jsprocessor "github.com/elastic/beats/v7/libbeat/processors/script/javascript/module/processor/registry"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const logName = "processor.math"
+
+var errSkipped = errors.New("operation skipped")
+
+func init() {
+	processors.RegisterPlugin("math", New)
+	jsprocessor.RegisterPlugin("Math", New)
+}
+
+type processor struct {
+	config
+	log *logp.Logger
+}
+
+// New constructs a new math processor.
+func New(cfg *conf.C, log *logp.Logger) (beat.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("fail to unpack the math processor configuration: %w", err)
+	}
+
+	return newMath(c, log)
+}
+
+func newMath(c config, log *logp.Logger) (*processor, error) {
+	log = log.Named(logName)
+	if c.Tag != "" {
+		log = log.With("instance_id", c.Tag)
+	}
+
+	return &processor{config: c, log: log}, nil
+}
+
+func (p *processor) String() string {
+	ops := make([]string, len(p.Fields))
+	for i, op := range p.Fields {
+		ops[i] = op.String()
+	}
+	return fmt.Sprintf("math=[tag=%v, fields=[%s], ignore_missing=%v, fail_on_error=%v]",
+		p.Tag, strings.Join(ops, ", "), p.IgnoreMissing, p.FailOnError)
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	// Operations are applied in order so that later operations can use the
+	// result of earlier ones. Keep a copy to roll back to when an operation
+	// fails part way through.
+	saved := event
+	if len(p.Fields) > 1 && p.FailOnError {
+		saved = event.Clone()
+	}
+
+	for _, op := range p.Fields {
+		err := p.apply(event, op)
+		if err == nil || errors.Is(err, errSkipped) {
+			continue
+		}
+		if p.FailOnError {
+			return saved, err
+		}
+		p.log.Debugw("Math operation failed.", "error", err)
+	}
+	return event, nil
+}
+
+func (p *processor) apply(event *beat.Event, op operation) error {
+	v, err := p.number(event, op.Field)
+	if err != nil {
+		return newMathError(op, err, p.Tag)
+	}
+
+	if op.Operator != noop {
+		operand := 0.0
+		if op.Operand != nil {
+			operand = *op.Operand
+		} else if operand, err = p.number(event, op.OperandField); err != nil {
+			return newMathError(op, err, p.Tag)
+		}
+		if v, err = calculate(op.Operator, v, operand); err != nil {
+			return newMathError(op, err, p.Tag)
+		}
+	}
+
+	if op.FromUnit != nil {
+		v = v * op.FromUnit.factor / op.ToUnit.factor
+	}
+
+	var result any = v
+	if op.Round != nil {
+		pow := stdmath.Pow10(*op.Round)
+		v = stdmath.Round(v*pow) / pow
+		result = v
+		if *op.Round == 0 {
+			result = int64(v)
+		}
+	}
+
+	target := op.Target
+	if target == "" {
+		target = op.Field
+	}
+	if _, err := event.PutValue(target, result); err != nil {
+		return newMathError(op, err, p.Tag)
+	}
+	return nil
+}
+
+// number reads a numeric value from the event. Numeric strings are accepted
+// because many inputs deliver numbers as strings.
+func (p *processor) number(event *beat.Event, field string) (float64, error) {
+	v, err := event.GetValue(field)
+	if err != nil {
+		if p.IgnoreMissing && errors.Is(err, mapstr.ErrKeyNotFound) {
+			return 0, errSkipped
+		}
+		return 0, fmt.Errorf("field [%v] is missing: %w", field, err)
+	}
+	f, err := toFloat(v)
+	if err != nil {
+		return 0, fmt.Errorf("field [%v]: %w", field, err)
+	}
+	return f, nil
+}
+
+func toFloat(value any) (float64, error) {
+	switch v := value.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int8:
+		return float64(v), nil
+	case int16:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case uint:
+		return float64(v), nil
+	case uint8:
+		return float64(v), nil
+	case uint16:
+		return float64(v), nil
+	case uint32:
+		return float64(v), nil
+	case uint64:
+		return float64(v), nil
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return 0, fmt.Errorf("value [%v] is not a number", v)
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("value [%v] of type %T is not a number", value, value)
+	}
+}
+
+func calculate(op operator, a, b float64) (float64, error) {
+	var r float64
+	switch op {
+	case add:
+		r = a + b
+	case subtract:
+		r = a - b
+	case multiply:
+		r = a * b
+	case divide:
+		if b == 0 {
+			return 0, errors.New("division by zero")
+		}
+		r = a / b
+	case modulo:
+		if b == 0 {
+			return 0, errors.New("modulo by zero")
+		}
+		r = stdmath.Mod(a, b)
+	case power:
+		r = stdmath.Pow(a, b)
+	default:
+		return 0, fmt.Errorf("unknown operator %v", op)
+	}
+	if stdmath.IsNaN(r) || stdmath.IsInf(r, 0) {
+		return 0, fmt.Errorf("result of %v is not a finite number", op)
+	}
+	return r, nil
+}
+
+func newMathError(op operation, cause error, tag string) error {
+	if errors.Is(cause, errSkipped) {
+		return cause
+	}
+	var buf strings.Builder
+	buf.WriteString("failed in processor.math")
+	if tag != "" {
+		buf.WriteString(" with instance_id=")
+		buf.WriteString(tag)
+	}
+	fmt.Fprintf(&buf, ": operation %v", op)
+	return fmt.Errorf("%v: %w", buf.String(), cause)
+}
@@ -0,0 +1,165 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package math
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestMath(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     mapstr.M
+		in      mapstr.M
+		want    mapstr.M
+		wantErr string
+	}{
+		{
+			name: "rate from two fields",
+			cfg: mapstr.M{"fields": []mapstr.M{
+				{"field": "bytes", "operator": "divide", "operand_field": "duration", "target_field": "rate"},
+			}},
+			in:   mapstr.M{"bytes": 1000, "duration": "4"},
+			want: mapstr.M{"bytes": 1000, "duration": "4", "rate": 250.0},
+		},
+		{
+			name: "unit conversion in place",
+			cfg: mapstr.M{"fields": []mapstr.M{
+				{"field": "event.duration", "from_unit": "ns", "to_unit": "ms"},
+			}},
+			in:   mapstr.M{"event": mapstr.M{"duration": int64(2500000)}},
+			want: mapstr.M{"event": mapstr.M{"duration": 2.5}},
+		},
+		{
+			name: "operator, conversion and rounding",
+			cfg: mapstr.M{"fields": []mapstr.M{
+				{"field": "bytes", "operator": "multiply", "operand": 3, "from_unit": "b", "to_unit": "kib", "round": 2, "target_field": "kib"},
+			}},
+			in:   mapstr.M{"bytes": 1000},
+			want: mapstr.M{"bytes": 1000, "kib": 2.93},
+		},
+		{
+			name: "round to integer",
+			cfg: mapstr.M{"fields": []mapstr.M{
+				{"field": "v", "round": 0},
+			}},
+			in:   mapstr.M{"v": 2.5},
+			want: mapstr.M{"v": int64(3)},
+		},
+		{
+			name: "operations are chained",
+			cfg: mapstr.M{"fields": []mapstr.M{
+				{"field": "a", "operator": "add", "operand": 1, "target_field": "b"},
+				{"field": "b", "operator": "power", "operand": 2, "target_field": "c"},
+			}},
+			in:   mapstr.M{"a": 2},
+			want: mapstr.M{"a": 2, "b": 3.0, "c": 9.0},
+		},
+		{
+			name: "missing field fails",
+			cfg: mapstr.M{"fields": []mapstr.M{
+				{"field": "a", "operator": "add", "operand": 1, "target_field": "b"},
+			}},
+			in:      mapstr.M{},
+			want:    mapstr.M{},
+			wantErr: "field [a] is missing",
+		},
+		{
+			name: "missing field ignored",
+			cfg: mapstr.M{"ignore_missing": true, "fields": []mapstr.M{
+				{"field": "a", "operator": "add", "operand": 1, "target_field": "b"},
+				{"field": "c", "operator": "add", "operand_field": "missing", "target_field": "d"},
+			}},
+			in:   mapstr.M{"c": 1},
+			want: mapstr.M{"c": 1},
+		},
+		{
+			name: "division by zero rolls back",
+			cfg: mapstr.M{"fields": []mapstr.M{
+				{"field": "a", "operator": "add", "operand": 1},
+				{"field": "a", "operator": "divide", "operand_field": "zero"},
+			}},
+			in:      mapstr.M{"a": 1, "zero": 0},
+			want:    mapstr.M{"a": 1, "zero": 0},
+			wantErr: "division by zero",
+		},
+		{
+			name: "errors ignored without fail_on_error",
+			cfg: mapstr.M{"fail_on_error": false, "fields": []mapstr.M{
+				{"field": "a", "operator": "divide", "operand_field": "zero"},
+				{"field": "a", "operator": "add", "operand": 1},
+			}},
+			in:   mapstr.M{"a": 1, "zero": 0},
+			want: mapstr.M{"a": 2.0, "zero": 0},
+		},
+		{
+			name: "not a number",
+			cfg: mapstr.M{"fields": []mapstr.M{
+				{"field": "a", "round": 1},
+			}},
+			in:      mapstr.M{"a": "abc"},
+			want:    mapstr.M{"a": "abc"},
+			wantErr: "is not a number",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := New(conf.MustNewConfigFrom(test.cfg), logptest.NewTestingLogger(t, ""))
+			require.NoError(t, err, "unexpected error creating processor")
+
+			got, err := p.Run(&beat.Event{Fields: test.in})
+			if test.wantErr != "" {
+				if assert.Error(t, err, "expected processor error") {
+					assert.Contains(t, err.Error(), test.wantErr, "unexpected error message")
+				}
+			} else {
+				assert.NoError(t, err, "unexpected processor error")
+			}
+			assert.Equal(t, test.want, got.Fields, "unexpected event fields")
+		})
+	}
+}
+
+func TestMathConfig(t *testing.T) {
+	for name, op := range map[string]mapstr.M{
+		"no operation":         {"field": "a"},
+		"operand w/o operator": {"field": "a", "operand": 1},
+		"no operand":           {"field": "a", "operator": "add"},
+		"two operands":         {"field": "a", "operator": "add", "operand": 1, "operand_field": "b"},
+		"unknown operator":     {"field": "a", "operator": "sqrt", "operand": 1},
+		"unknown unit":         {"field": "a", "from_unit": "ms", "to_unit": "fortnight"},
+		"one unit":             {"field": "a", "from_unit": "ms"},
+		"unit mismatch":        {"field": "a", "from_unit": "ms", "to_unit": "mb"},
+		"divide by zero":       {"field": "a", "operator": "divide", "operand": 0},
+		"negative round":       {"field": "a", "round": -1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(conf.MustNewConfigFrom(mapstr.M{"fields": []mapstr.M{op}}), logptest.NewTestingLogger(t, ""))
+			assert.Error(t, err, "expected configuration error")
+		})
+	}
+}
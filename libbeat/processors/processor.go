@@ -51,6 +51,79 @@ type PathSetter interface {
 	SetPaths(*paths.Path) error
 }
 
+// Splitter is implemented by processors that can turn a single event into
+// several events. Processors that contain other processors implement it as
+// well so that events produced anywhere in the chain reach the publisher.
+type Splitter interface {
+	// RunSplit behaves like Run but returns every resulting event. An empty
+	// result means the event has been dropped.
+	RunSplit(event *beat.Event) ([]*beat.Event, error)
+}
+
+// RunSplit runs the processor and returns all resulting events. Processors
+// that do not implement Splitter produce at most one event.
+func RunSplit(p beat.Processor, event *beat.Event) ([]*beat.Event, error) {
+	if s, ok := p.(Splitter); ok {
+		return s.RunSplit(event)
+	}
+	event, err := p.Run(event)
+	if event == nil {
+		return nil, err
+	}
+	return []*beat.Event{event}, err
+}
+
+// ErrEventHeld is returned by RunSplit when the processor holds the event
+// back to release it later, unlike a dropped event. The publishing client
+// keeps a held event unacknowledged until it is released and acknowledged, so
+// that it is not lost on restart. The event must keep its Private field when
+// it is released.
+var ErrEventHeld = errors.New("event held back by the processor")
+
+// Flusher is implemented by processors that hold events back, such as
+// aggregations. Flush is called before the publishing client is closed and
+// returns the events that are still pending. Processors that contain other
+// processors implement it as well, applying the processors that follow a
+// flushed processor to its events.
+type Flusher interface {
+	Flush() []*beat.Event
+}
+
+// Flush returns the pending events of a processor that implements Flusher.
+func Flush(p beat.Processor) []*beat.Event {
+	if f, ok := p.(Flusher); ok {
+		return f.Flush()
+	}
+	return nil
+}
+
+// Expirer is implemented by processors that hold events back for a limited
+// time. The publishing client calls Expire periodically, so that the held
+// events whose time is up are released without waiting for the next event.
+// Processors that contain other processors implement it as well, Expires
+// reports whether one of them holds events back for a limited time.
+type Expirer interface {
+	Expires() bool
+	Expire() []*beat.Event
+}
+
+// Expires reports whether a processor implements Expirer and holds events
+// back for a limited time.
+func Expires(p beat.Processor) bool {
+	if e, ok := p.(Expirer); ok {
+		return e.Expires()
+	}
+	return false
+}
+
+// Expire returns the expired events of a processor that implements Expirer.
+func Expire(p beat.Processor) []*beat.Event {
+	if e, ok := p.(Expirer); ok {
+		return e.Expire()
+	}
+	return nil
+}
+
 // Close closes a processor if it implements the Closer interface
 func Close(p beat.Processor) error {
 	if closer, ok := p.(Closer); ok {
@@ -182,6 +255,77 @@ func (procs *Processors) Run(event *beat.Event) (*beat.Event, error) {
 	return event, nil
 }
 
+// RunSplit executes all processors serially on the event and on every event
+// split from it. Like Run, it stops at the first error and returns the events
+// processed so far, along with the events not yet processed. It returns
+// ErrEventHeld along with the other events if a processor held an event back.
+func (procs *Processors) RunSplit(event *beat.Event) ([]*beat.Event, error) {
+	events := []*beat.Event{event}
+	held := false
+	for _, p := range procs.List {
+		next := make([]*beat.Event, 0, len(events))
+		for i, e := range events {
+			out, err := RunSplit(p, e)
+			next = append(next, out...)
+			if errors.Is(err, ErrEventHeld) {
+				held = true
+				continue
+			}
+			if err != nil {
+				return append(next, events[i+1:]...), fmt.Errorf("failed applying processor %v: %w", p, err)
+			}
+		}
+		if len(next) == 0 {
+			// Drop.
+			events = nil
+			break
+		}
+		events = next
+	}
+	if held {
+		return events, ErrEventHeld
+	}
+	return events, nil
+}
+
+// Flush collects the pending events of all processors in the list. Events
+// flushed by a processor are run through the processors that follow it.
+func (procs *Processors) Flush() []*beat.Event {
+	return procs.release(Flush)
+}
+
+// Expires reports whether one of the processors in the list holds events back
+// for a limited time.
+func (procs *Processors) Expires() bool {
+	for _, p := range procs.List {
+		if Expires(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Expire collects the expired events of all processors in the list. Events
+// expired by a processor are run through the processors that follow it.
+func (procs *Processors) Expire() []*beat.Event {
+	return procs.release(Expire)
+}
+
+func (procs *Processors) release(fn func(beat.Processor) []*beat.Event) []*beat.Event {
+	var released []*beat.Event
+	for i, p := range procs.List {
+		rest := &Processors{List: procs.List[i+1:], log: procs.log}
+		for _, e := range fn(p) {
+			events, err := rest.RunSplit(e)
+			if err != nil && !errors.Is(err, ErrEventHeld) && procs.log != nil {
+				procs.log.Debugf("Failed to process released event: %v", err)
+			}
+			released = append(released, events...)
+		}
+	}
+	return released
+}
+
 func (procs Processors) String() string {
 	var s []string
 	for _, p := range procs.List {
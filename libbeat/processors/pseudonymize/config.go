@@ -0,0 +1,74 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pseudonymize
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	methodHMACSHA256 = "hmac-sha256"
+	methodTokenize   = "tokenize"
+
+	// minKeyLength is the minimum length of the key in bytes.
+	minKeyLength = 16
+)
+
+type config struct {
+	// Fields are the fields to pseudonymize. The result replaces the field
+	// unless To is set.
+	Fields []fromTo `config:"fields" validate:"required"`
+
+	// Method is hmac-sha256 for irreversible pseudonyms or tokenize for
+	// tokens that can be reversed with the key.
+	Method string `config:"method"`
+
+	// Key is the secret used to derive the pseudonyms.
+	Key string `config:"key" validate:"required"`
+
+	IgnoreMissing bool `config:"ignore_missing"`
+	FailOnError   bool `config:"fail_on_error"`
+}
+
+type fromTo struct {
+	From string `config:"from" validate:"required"`
+	To   string `config:"to"`
+}
+
+func defaultConfig() config {
+	return config{
+		Method:      methodHMACSHA256,
+		FailOnError: true,
+	}
+}
+
+func (c *config) Validate() error {
+	switch c.Method {
+	case methodHMACSHA256, methodTokenize:
+	default:
+		return fmt.Errorf("unknown method %q, must be %s or %s", c.Method, methodHMACSHA256, methodTokenize)
+	}
+	if len(c.Key) < minKeyLength {
+		return fmt.Errorf("key must be at least %d bytes long", minKeyLength)
+	}
+	if len(c.Fields) == 0 {
+		return errors.New("fields must not be empty")
+	}
+	return nil
+}
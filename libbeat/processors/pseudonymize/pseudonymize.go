@@ -0,0 +1,165 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pseudonymize
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	"github.com/elastic/beats/v7/libbeat/processors/checks"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const procName = "pseudonymize"
+
+func init() {
+	processors.RegisterPlugin(procName,
+		checks.ConfigChecked(New,
+			checks.RequireFields("fields", "key"),
+			checks.AllowedFields("fields", "method", "key", "ignore_missing", "fail_on_error", "when")))
+}
+
+type processor struct {
+	config
+	log       *logp.Logger
+	transform func(value string) string
+}
+
+// New constructs a new pseudonymize processor.
+func New(cfg *conf.C, log *logp.Logger) (beat.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("failed to unpack the %v configuration: %w", procName, err)
+	}
+
+	p := &processor{config: c, log: log.Named(procName)}
+	key := []byte(c.Key)
+	switch c.Method {
+	case methodHMACSHA256:
+		p.transform = func(value string) string {
+			return pseudonym(key, value)
+		}
+	case methodTokenize:
+		t, err := newTokenizer(key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the %v tokenizer: %w", procName, err)
+		}
+		p.transform = t.tokenize
+	}
+	return p, nil
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	var backup *beat.Event
+	if p.FailOnError && len(p.Fields) > 1 {
+		backup = event.Clone()
+	}
+
+	for _, field := range p.Fields {
+		if err := p.pseudonymizeField(field.From, field.To, event); err != nil {
+			errMsg := fmt.Errorf("failed to pseudonymize fields in %v processor: %w", procName, err)
+			p.log.Debugw(errMsg.Error(), logp.TypeKey, logp.EventType)
+
+			if p.FailOnError {
+				if backup != nil {
+					event = backup
+				}
+				_, _ = event.PutValue("error.message", errMsg.Error())
+				return event, err
+			}
+		}
+	}
+	return event, nil
+}
+
+func (p *processor) pseudonymizeField(from, to string, event *beat.Event) error {
+	value, err := event.GetValue(from)
+	if err != nil {
+		if p.IgnoreMissing && errors.Is(err, mapstr.ErrKeyNotFound) {
+			return nil
+		}
+		return fmt.Errorf("could not fetch value for key: %s, Error: %w", from, err)
+	}
+
+	result, err := p.pseudonymizeValue(value)
+	if err != nil {
+		return fmt.Errorf("could not pseudonymize %s: %w", from, err)
+	}
+
+	target := to
+	if to == "" {
+		target = from
+	}
+	if _, err := event.PutValue(target, result); err != nil {
+		return fmt.Errorf("could not put value for key: %s, Error: %w", target, err)
+	}
+	return nil
+}
+
+// pseudonymizeValue transforms scalar values and each element of arrays of
+// scalar values. Non-string scalars are formatted as strings first.
+func (p *processor) pseudonymizeValue(value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return p.transform(v), nil
+	case []string:
+		out := make([]string, len(v))
+		for i, s := range v {
+			out[i] = p.transform(s)
+		}
+		return out, nil
+	case []interface{}:
+		out := make([]string, len(v))
+		for i, e := range v {
+			if !isScalar(e) {
+				return nil, fmt.Errorf("unsupported array element type %T", e)
+			}
+			out[i] = p.transform(fmt.Sprint(e))
+		}
+		return out, nil
+	default:
+		if !isScalar(v) {
+			return nil, fmt.Errorf("unsupported value type %T", v)
+		}
+		return p.transform(fmt.Sprint(v)), nil
+	}
+}
+
+func isScalar(v interface{}) bool {
+	switch v.(type) {
+	case string, bool,
+		int, int8, int16, int32, int64,
+		uint, uint8, uint16, uint32, uint64,
+		float32, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+func (p *processor) String() string {
+	// Never include the key.
+	return fmt.Sprintf("%v=[method=%v, fields=%+v, ignore_missing=%v, fail_on_error=%v]",
+		procName, p.Method, p.Fields, p.IgnoreMissing, p.FailOnError)
+}
@@ -0,0 +1,169 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pseudonymize
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const testKey = "0123456789abcdef0123456789abcdef"
+
+func testEvent() *beat.Event {
+	return &beat.Event{
+		Fields: mapstr.M{
+			"user":   mapstr.M{"name": "alice", "roles": []interface{}{"admin", "dev"}},
+			"source": mapstr.M{"ip": "10.0.0.1", "port": 5432},
+			"labels": mapstr.M{"team": "a"},
+		},
+	}
+}
+
+func newProcessor(t *testing.T, c mapstr.M) beat.Processor {
+	t.Helper()
+	p, err := New(conf.MustNewConfigFrom(c), logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+	return p
+}
+
+func hmacHex(value string) string {
+	h := hmac.New(sha256.New, []byte(testKey))
+	h.Write([]byte(value))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]mapstr.M{
+		"unknown method": {"key": testKey, "method": "md5", "fields": []mapstr.M{{"from": "user.name"}}},
+		"short key":      {"key": "secret", "fields": []mapstr.M{{"from": "user.name"}}},
+		"missing key":    {"fields": []mapstr.M{{"from": "user.name"}}},
+		"no fields":      {"key": testKey},
+	}
+	for name, c := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(conf.MustNewConfigFrom(c), logptest.NewTestingLogger(t, ""))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestHMAC(t *testing.T) {
+	p := newProcessor(t, mapstr.M{
+		"key": testKey,
+		"fields": []mapstr.M{
+			{"from": "user.name"},
+			{"from": "user.roles"},
+			{"from": "source.ip", "to": "source.ip_pseudonym"},
+			{"from": "source.port"},
+		},
+	})
+
+	event, err := p.Run(testEvent())
+	require.NoError(t, err)
+	assert.Equal(t, mapstr.M{
+		"user": mapstr.M{
+			"name":  hmacHex("alice"),
+			"roles": []string{hmacHex("admin"), hmacHex("dev")},
+		},
+		"source": mapstr.M{
+			"ip":           "10.0.0.1",
+			"ip_pseudonym": hmacHex("10.0.0.1"),
+			"port":         hmacHex("5432"),
+		},
+		"labels": mapstr.M{"team": "a"},
+	}, event.Fields)
+}
+
+func TestTokenize(t *testing.T) {
+	p := newProcessor(t, mapstr.M{
+		"key":    testKey,
+		"method": "tokenize",
+		"fields": []mapstr.M{{"from": "user.name"}},
+	})
+
+	first, err := p.Run(testEvent())
+	require.NoError(t, err)
+	second, err := p.Run(testEvent())
+	require.NoError(t, err)
+
+	token, err := first.GetValue("user.name")
+	require.NoError(t, err)
+	assert.NotEqual(t, "alice", token)
+	other, err := second.GetValue("user.name")
+	require.NoError(t, err)
+	assert.Equal(t, token, other, "tokens must be deterministic")
+
+	value, err := Detokenize(testKey, token.(string))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", value)
+
+	_, err = Detokenize("fedcba9876543210fedcba9876543210", token.(string))
+	assert.Error(t, err)
+}
+
+func TestMissingField(t *testing.T) {
+	c := mapstr.M{
+		"key":    testKey,
+		"fields": []mapstr.M{{"from": "user.name"}, {"from": "user.email"}},
+	}
+
+	t.Run("fail on error", func(t *testing.T) {
+		event, err := newProcessor(t, c).Run(testEvent())
+		assert.Error(t, err)
+		name, _ := event.GetValue("user.name")
+		assert.Equal(t, "alice", name, "the event must be restored")
+		msg, _ := event.GetValue("error.message")
+		assert.Contains(t, msg, "user.email")
+	})
+
+	t.Run("ignore missing", func(t *testing.T) {
+		c := c.Clone()
+		c["ignore_missing"] = true
+		event, err := newProcessor(t, c).Run(testEvent())
+		require.NoError(t, err)
+		name, _ := event.GetValue("user.name")
+		assert.Equal(t, hmacHex("alice"), name)
+	})
+}
+
+func TestNonScalarField(t *testing.T) {
+	p := newProcessor(t, mapstr.M{
+		"key":    testKey,
+		"fields": []mapstr.M{{"from": "labels"}},
+	})
+	_, err := p.Run(testEvent())
+	assert.ErrorContains(t, err, "unsupported value type")
+}
+
+func TestStringHidesKey(t *testing.T) {
+	p := newProcessor(t, mapstr.M{
+		"key":    testKey,
+		"fields": []mapstr.M{{"from": "user.name"}},
+	})
+	assert.NotContains(t, p.String(), testKey)
+}
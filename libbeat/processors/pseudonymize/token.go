@@ -0,0 +1,103 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pseudonymize
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+)
+
+// Labels used to derive independent keys for tokenization from the
+// configured key.
+const (
+	encryptionKeyLabel = "pseudonymize tokenize encryption"
+	nonceKeyLabel      = "pseudonymize tokenize nonce"
+)
+
+// pseudonym returns the hex encoded HMAC-SHA256 of value.
+func pseudonym(key []byte, value string) string {
+	return hex.EncodeToString(mac(key, []byte(value)))
+}
+
+// tokenizer encrypts values with AES-256-GCM. The nonce is derived from
+// the value, so that equal values produce equal tokens and can still be
+// joined on.
+type tokenizer struct {
+	aead     cipher.AEAD
+	nonceKey []byte
+}
+
+func newTokenizer(key []byte) (*tokenizer, error) {
+	block, err := aes.NewCipher(mac(key, []byte(encryptionKeyLabel)))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	return &tokenizer{
+		aead:     aead,
+		nonceKey: mac(key, []byte(nonceKeyLabel)),
+	}, nil
+}
+
+// tokenize returns the base64url encoded nonce and ciphertext of value.
+func (t *tokenizer) tokenize(value string) string {
+	nonce := mac(t.nonceKey, []byte(value))[:t.aead.NonceSize()]
+	token := t.aead.Seal(nonce, nonce, []byte(value), nil)
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+func (t *tokenizer) detokenize(token string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", fmt.Errorf("invalid token: %w", err)
+	}
+	if len(b) < t.aead.NonceSize() {
+		return "", errors.New("invalid token: too short")
+	}
+	nonce, ciphertext := b[:t.aead.NonceSize()], b[t.aead.NonceSize():]
+	value, err := t.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("invalid token: %w", err)
+	}
+	return string(value), nil
+}
+
+// Detokenize returns the original value of a token produced by the
+// tokenize method with the same key.
+func Detokenize(key, token string) (string, error) {
+	t, err := newTokenizer([]byte(key))
+	if err != nil {
+		return "", err
+	}
+	return t.detokenize(token)
+}
+
+func mac(key, data []byte) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write(data)
+	return h.Sum(nil)
+}
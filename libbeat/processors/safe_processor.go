@@ -78,6 +78,51 @@ func (p *SafeProcessor) Run(event *beat.Event) (*beat.Event, error) {
 	return p.Processor.Run(event)
 }
 
+// RunSplit is like Run but passes through events split by the underlying
+// processor.
+func (p *SafeProcessor) RunSplit(event *beat.Event) ([]*beat.Event, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	switch p.state {
+	case stateClosed:
+		return nil, ErrClosed
+	case stateInit:
+		if _, ok := p.Processor.(PathSetter); ok {
+			return nil, ErrPathsNotSet
+		}
+	default: // proceed
+	}
+	return RunSplit(p.Processor, event)
+}
+
+// Flush returns the pending events of the underlying processor unless it
+// has been closed.
+func (p *SafeProcessor) Flush() []*beat.Event {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.state == stateClosed {
+		return nil
+	}
+	return Flush(p.Processor)
+}
+
+// Expires reports whether the underlying processor holds events back for a
+// limited time.
+func (p *SafeProcessor) Expires() bool {
+	return Expires(p.Processor)
+}
+
+// Expire returns the expired events of the underlying processor unless it
+// has been closed.
+func (p *SafeProcessor) Expire() []*beat.Event {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.state == stateClosed {
+		return nil
+	}
+	return Expire(p.Processor)
+}
+
 // Close makes sure the underlying `Close` function is called only once.
 func (p *safeProcessorWithClose) Close() (err error) {
 	p.mu.Lock()
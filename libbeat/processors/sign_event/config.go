@@ -0,0 +1,108 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sign_event
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+)
+
+const (
+	methodHMACSHA256 = "hmac-sha256"
+	methodEd25519    = "ed25519"
+)
+
+type config struct {
+	// KeyID identifies the signing key to the verifiers. It is included in
+	// the signed data.
+	KeyID string `config:"key_id" validate:"required"`
+
+	// Method is the signature algorithm, hmac-sha256 or ed25519.
+	Method string `config:"method"`
+
+	// Key is the HMAC secret, or the Ed25519 private key as a base64
+	// encoded seed or a PEM encoded PKCS #8 key.
+	Key string `config:"key" validate:"required"`
+
+	// Fields are the fields covered by the signature.
+	Fields []string `config:"fields"`
+
+	// TargetField receives the provenance metadata.
+	TargetField string `config:"target_field"`
+}
+
+func defaultConfig() config {
+	return config{
+		Method:      methodHMACSHA256,
+		Fields:      []string{"@timestamp", "agent.id", "host.name", "event.dataset", "message"},
+		TargetField: "provenance",
+	}
+}
+
+func (c *config) Validate() error {
+	switch c.Method {
+	case methodHMACSHA256:
+	case methodEd25519:
+		if _, err := parseEd25519Key(c.Key); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("unknown method %q, must be %s or %s", c.Method, methodHMACSHA256, methodEd25519)
+	}
+	if len(c.Fields) == 0 {
+		return errors.New("fields must not be empty")
+	}
+	if c.TargetField == "" {
+		return errors.New("target_field must not be empty")
+	}
+	return nil
+}
+
+// parseEd25519Key parses a base64 encoded Ed25519 seed or private key, or a
+// PEM encoded PKCS #8 Ed25519 private key.
+func parseEd25519Key(s string) (ed25519.PrivateKey, error) {
+	if block, _ := pem.Decode([]byte(s)); block != nil {
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid ed25519 key: %w", err)
+		}
+		priv, ok := key.(ed25519.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("invalid ed25519 key: key is a %T", key)
+		}
+		return priv, nil
+	}
+
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ed25519 key: %w", err)
+	}
+	switch len(b) {
+	case ed25519.SeedSize:
+		return ed25519.NewKeyFromSeed(b), nil
+	case ed25519.PrivateKeySize:
+		return ed25519.PrivateKey(b), nil
+	default:
+		return nil, fmt.Errorf("invalid ed25519 key: length is %d bytes, must be %d or %d",
+			len(b), ed25519.SeedSize, ed25519.PrivateKeySize)
+	}
+}
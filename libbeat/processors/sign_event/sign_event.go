@@ -0,0 +1,159 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sign_event
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const procName = "sign_event"
+
+func init() {
+	processors.RegisterPlugin(procName, New)
+}
+
+type processor struct {
+	config
+	sign func(payload []byte) []byte
+}
+
+// New constructs a new sign_event processor.
+func New(cfg *conf.C, _ *logp.Logger) (beat.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("failed to unpack the %v configuration: %w", procName, err)
+	}
+
+	// Sort the fields so that verifiers can rely on their order.
+	slices.Sort(c.Fields)
+	c.Fields = slices.Compact(c.Fields)
+
+	p := &processor{config: c}
+	switch c.Method {
+	case methodHMACSHA256:
+		key := []byte(c.Key)
+		p.sign = func(payload []byte) []byte {
+			mac := hmac.New(sha256.New, key)
+			mac.Write(payload)
+			return mac.Sum(nil)
+		}
+	case methodEd25519:
+		// The key was validated when unpacking.
+		key, _ := parseEd25519Key(c.Key)
+		p.sign = func(payload []byte) []byte {
+			return ed25519.Sign(key, payload)
+		}
+	}
+	return p, nil
+}
+
+// Run adds the provenance metadata and signature to the event.
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	payload, err := canonicalPayload(event, p.KeyID, p.Fields)
+	if err != nil {
+		return event, fmt.Errorf("failed to encode the signed fields: %w", err)
+	}
+
+	_, err = event.PutValue(p.TargetField, mapstr.M{
+		"key_id":    p.KeyID,
+		"method":    p.Method,
+		"fields":    p.Fields,
+		"signature": base64.StdEncoding.EncodeToString(p.sign(payload)),
+	})
+	if err != nil {
+		return event, fmt.Errorf("failed to add the event signature: %w", err)
+	}
+	return event, nil
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("%v=[key_id=%v, method=%v, fields=%v, target_field=%v]",
+		procName, p.KeyID, p.Method, p.Fields, p.TargetField)
+}
+
+// canonicalPayload returns the signed representation of the event: a compact
+// JSON object with sorted keys and without HTML escaping, holding the key ID
+// and the values of the signed fields. Missing fields are null, and
+// timestamps are formatted as they are by the outputs.
+func canonicalPayload(event *beat.Event, keyID string, fields []string) ([]byte, error) {
+	values := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		v, err := event.GetValue(f)
+		if err != nil {
+			values[f] = nil
+			continue
+		}
+		values[f] = canonicalValue(v)
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	err := enc.Encode(map[string]interface{}{
+		"fields": values,
+		"key_id": keyID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+func canonicalValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case time.Time:
+		return common.Time(v).String()
+	case common.Time:
+		return v.String()
+	case mapstr.M:
+		return canonicalMap(v)
+	case map[string]interface{}:
+		return canonicalMap(v)
+	case []interface{}:
+		out := make([]interface{}, len(v))
+		for i, e := range v {
+			out[i] = canonicalValue(e)
+		}
+		return out
+	default:
+		return v
+	}
+}
+
+func canonicalMap(m map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		out[k] = canonicalValue(v)
+	}
+	return out
+}
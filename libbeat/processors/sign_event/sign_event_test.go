@@ -0,0 +1,132 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sign_event
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func testEvent() *beat.Event {
+	return &beat.Event{
+		Timestamp: time.Date(2026, 10, 16, 8, 30, 0, 123456789, time.UTC),
+		Fields: mapstr.M{
+			"agent":   mapstr.M{"id": "agent-1"},
+			"message": "user <admin> logged in",
+			"event":   mapstr.M{"dataset": "system.auth"},
+		},
+	}
+}
+
+func TestCanonicalPayload(t *testing.T) {
+	payload, err := canonicalPayload(testEvent(), "k1", []string{"@timestamp", "agent.id", "host.name", "message"})
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"fields":{"@timestamp":"2026-10-16T08:30:00.123Z","agent.id":"agent-1","host.name":null,"message":"user <admin> logged in"},"key_id":"k1"}`,
+		string(payload))
+}
+
+func TestSignHMAC(t *testing.T) {
+	p, err := New(conf.MustNewConfigFrom(mapstr.M{
+		"key_id": "k1",
+		"key":    "secret",
+		"fields": []string{"message", "@timestamp", "agent.id", "message"},
+	}), logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+
+	event, err := p.Run(testEvent())
+	require.NoError(t, err)
+
+	fields := []string{"@timestamp", "agent.id", "message"}
+	provenance, err := event.GetValue("provenance")
+	require.NoError(t, err)
+	m, ok := provenance.(mapstr.M)
+	require.True(t, ok)
+	assert.Equal(t, "k1", m["key_id"])
+	assert.Equal(t, methodHMACSHA256, m["method"])
+	assert.Equal(t, fields, m["fields"])
+
+	payload, err := canonicalPayload(testEvent(), "k1", fields)
+	require.NoError(t, err)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+	assert.Equal(t, base64.StdEncoding.EncodeToString(mac.Sum(nil)), m["signature"])
+
+	// A tampered event doesn't match the signature.
+	tampered := testEvent()
+	tampered.Fields["message"] = "user <guest> logged in"
+	payload, err = canonicalPayload(tampered, "k1", fields)
+	require.NoError(t, err)
+	mac = hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+	assert.NotEqual(t, base64.StdEncoding.EncodeToString(mac.Sum(nil)), m["signature"])
+}
+
+func TestSignEd25519(t *testing.T) {
+	seed := make([]byte, ed25519.SeedSize)
+	for i := range seed {
+		seed[i] = byte(i)
+	}
+	priv := ed25519.NewKeyFromSeed(seed)
+
+	p, err := New(conf.MustNewConfigFrom(mapstr.M{
+		"key_id":       "k2",
+		"method":       "ed25519",
+		"key":          base64.StdEncoding.EncodeToString(seed),
+		"target_field": "event.provenance",
+	}), logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+
+	event, err := p.Run(testEvent())
+	require.NoError(t, err)
+
+	sig, err := event.GetValue("event.provenance.signature")
+	require.NoError(t, err)
+	signature, err := base64.StdEncoding.DecodeString(sig.(string))
+	require.NoError(t, err)
+
+	payload, err := canonicalPayload(testEvent(), "k2", []string{"@timestamp", "agent.id", "event.dataset", "host.name", "message"})
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(priv.Public().(ed25519.PublicKey), payload, signature))
+}
+
+func TestConfigValidate(t *testing.T) {
+	for name, cfg := range map[string]mapstr.M{
+		"missing key":        {"key_id": "k1"},
+		"unknown method":     {"key_id": "k1", "key": "secret", "method": "rsa"},
+		"invalid ed25519":    {"key_id": "k1", "key": "c2hvcnQ=", "method": "ed25519"},
+		"empty target field": {"key_id": "k1", "key": "secret", "target_field": ""},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(conf.MustNewConfigFrom(cfg), logptest.NewTestingLogger(t, ""))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package split
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	procName = "split"
+	logName  = "processor." + procName
+)
+
+var (
+	errNotArray = errors.New("field is not an array")
+
+	// errSplitUnsupported is returned when the processor is run by a caller
+	// that can only handle a single resulting event.
+	errSplitUnsupported = errors.New("split processor requires a processor chain that supports multiple events")
+)
+
+func init() {
+	// Not registered as a JS plugin because the script processor can only
+	// return a single event.
+	processors.RegisterPlugin(procName, New)
+}
+
+type processor struct {
+	config
+	log *logp.Logger
+}
+
+// New constructs a new split processor.
+func New(cfg *conf.C, log *logp.Logger) (beat.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("fail to unpack the %v processor configuration: %w", procName, err)
+	}
+
+	return newSplit(c, log)
+}
+
+func newSplit(c config, log *logp.Logger) (*processor, error) {
+	if c.TargetField == "" {
+		c.TargetField = c.Field
+	}
+	return &processor{config: c, log: log.Named(logName)}, nil
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("%v=[field=%v, target_field=%v, index_field=%v]",
+		procName, p.Field, p.TargetField, p.IndexField)
+}
+
+// Run returns an error because a single event cannot hold the result of a
+// split. The publisher pipeline uses RunSplit.
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	return event, errSplitUnsupported
+}
+
+// RunSplit returns one event per element of the array in Field. Each event
+// is a copy of the original with the element written to TargetField.
+//
+// Only the last event keeps the original Private data. Events are ACKed in
+// order, so inputs see a single ACK once all events have been delivered.
+func (p *processor) RunSplit(event *beat.Event) ([]*beat.Event, error) {
+	events, err := p.split(event)
+	if err == nil || p.IgnoreFailure || (p.IgnoreMissing && errors.Is(err, mapstr.ErrKeyNotFound)) {
+		if events == nil {
+			events = []*beat.Event{event}
+		}
+		return events, nil
+	}
+	return []*beat.Event{event}, fmt.Errorf("%v processor failed on field [%v]: %w", procName, p.Field, err)
+}
+
+func (p *processor) split(event *beat.Event) ([]*beat.Event, error) {
+	v, err := event.GetValue(p.Field)
+	if err != nil {
+		return nil, err
+	}
+	elems, err := toSlice(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(elems) == 0 {
+		return nil, nil
+	}
+
+	// Remove the array before cloning so that it is not copied N times.
+	if err := event.Delete(p.Field); err != nil {
+		return nil, err
+	}
+	private := event.Private
+	event.Private = nil
+
+	events := make([]*beat.Event, len(elems))
+	for i, elem := range elems {
+		e := event
+		if i < len(elems)-1 {
+			e = event.Clone()
+		}
+		if err := p.put(e, elem, i); err != nil {
+			// Leave the original event as it was received.
+			_ = event.Delete(p.TargetField)
+			_ = event.Delete(p.IndexField)
+			_, _ = event.PutValue(p.Field, v)
+			event.Private = private
+			return nil, err
+		}
+		events[i] = e
+	}
+	events[len(events)-1].Private = private
+	return events, nil
+}
+
+func (p *processor) put(event *beat.Event, elem any, index int) error {
+	if _, err := event.PutValue(p.TargetField, elem); err != nil {
+		return err
+	}
+	if p.IndexField != "" {
+		if _, err := event.PutValue(p.IndexField, index); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func toSlice(v any) ([]any, error) {
+	switch v := v.(type) {
+	case []any:
+		return v, nil
+	case []mapstr.M:
+		s := make([]any, len(v))
+		for i, m := range v {
+			s[i] = m
+		}
+		return s, nil
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+		return nil, errNotArray
+	}
+	s := make([]any, rv.Len())
+	for i := range s {
+		s[i] = rv.Index(i).Interface()
+	}
+	return s, nil
+}
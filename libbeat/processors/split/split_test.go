@@ -0,0 +1,142 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package split
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	_ "github.com/elastic/beats/v7/libbeat/processors/actions"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     mapstr.M
+		in      mapstr.M
+		want    []mapstr.M
+		wantErr bool
+	}{
+		{
+			name: "records",
+			cfg:  mapstr.M{"field": "records", "target_field": "record", "index_field": "record_index"},
+			in: mapstr.M{
+				"host":    "a",
+				"records": []interface{}{mapstr.M{"id": 1}, mapstr.M{"id": 2}},
+			},
+			want: []mapstr.M{
+				{"host": "a", "record": mapstr.M{"id": 1}, "record_index": 0},
+				{"host": "a", "record": mapstr.M{"id": 2}, "record_index": 1},
+			},
+		},
+		{
+			name: "in place",
+			cfg:  mapstr.M{"field": "tags"},
+			in:   mapstr.M{"tags": []string{"x", "y"}},
+			want: []mapstr.M{{"tags": "x"}, {"tags": "y"}},
+		},
+		{
+			name: "empty array",
+			cfg:  mapstr.M{"field": "tags"},
+			in:   mapstr.M{"tags": []string{}},
+			want: []mapstr.M{{"tags": []string{}}},
+		},
+		{
+			name:    "missing field",
+			cfg:     mapstr.M{"field": "tags"},
+			in:      mapstr.M{"a": 1},
+			want:    []mapstr.M{{"a": 1}},
+			wantErr: true,
+		},
+		{
+			name: "missing field ignored",
+			cfg:  mapstr.M{"field": "tags", "ignore_missing": true},
+			in:   mapstr.M{"a": 1},
+			want: []mapstr.M{{"a": 1}},
+		},
+		{
+			name:    "not an array",
+			cfg:     mapstr.M{"field": "tags"},
+			in:      mapstr.M{"tags": "x"},
+			want:    []mapstr.M{{"tags": "x"}},
+			wantErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p, err := New(conf.MustNewConfigFrom(test.cfg), logptest.NewTestingLogger(t, ""))
+			require.NoError(t, err, "unexpected error creating processor")
+
+			events, err := processors.RunSplit(p, &beat.Event{Fields: test.in, Private: "state"})
+			if test.wantErr {
+				assert.Error(t, err, "expected processor error")
+			} else {
+				assert.NoError(t, err, "unexpected processor error")
+			}
+			got := make([]mapstr.M, len(events))
+			for i, e := range events {
+				got[i] = e.Fields
+			}
+			assert.Equal(t, test.want, got, "unexpected events")
+			for i, e := range events {
+				if i == len(events)-1 {
+					assert.Equal(t, "state", e.Private, "last event must keep the private data")
+				} else {
+					assert.Nil(t, e.Private, "only the last event may hold the private data")
+				}
+			}
+		})
+	}
+}
+
+func TestSplitInProcessorList(t *testing.T) {
+	var cfg processors.PluginConfig
+	require.NoError(t, conf.MustNewConfigFrom([]mapstr.M{
+		{"split": mapstr.M{"field": "n"}},
+		{"drop_event": mapstr.M{"when": mapstr.M{"equals": mapstr.M{"n": 2}}}},
+		{"add_fields": mapstr.M{"target": "", "fields": mapstr.M{"split": true}}},
+	}).Unpack(&cfg), "failed to unpack processors config")
+
+	list, err := processors.New(cfg, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err, "unexpected error creating processors")
+
+	events, err := processors.RunSplit(list, &beat.Event{Fields: mapstr.M{"n": []interface{}{1, 2, 3}}})
+	require.NoError(t, err, "unexpected processor error")
+	got := make([]mapstr.M, len(events))
+	for i, e := range events {
+		got[i] = e.Fields
+	}
+	assert.Equal(t, []mapstr.M{{"n": 1, "split": true}, {"n": 3, "split": true}}, got, "processors after split must run on every event")
+
+	_, err = newTestSplit(t).Run(&beat.Event{Fields: mapstr.M{"n": []interface{}{1}}})
+	assert.ErrorIs(t, err, errSplitUnsupported, "Run must not silently drop split events")
+}
+
+func newTestSplit(t *testing.T) beat.Processor {
+	p, err := New(conf.MustNewConfigFrom(mapstr.M{"field": "n"}), logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err, "unexpected error creating processor")
+	return p
+}
@@ -0,0 +1,99 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package translate
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+type missPolicy string
+
+const (
+	// missIgnore leaves the target field untouched when no dictionary entry matches.
+	missIgnore missPolicy = "ignore"
+	// missFallback writes the configured fallback value to the target field.
+	missFallback missPolicy = "fallback"
+	// missFail returns an error from the processor.
+	missFail missPolicy = "fail"
+)
+
+func (m *missPolicy) Unpack(s string) error {
+	switch p := missPolicy(s); p {
+	case missIgnore, missFallback, missFail:
+		*m = p
+		return nil
+	default:
+		return fmt.Errorf("invalid on_miss value %q, must be one of ignore, fallback or fail", s)
+	}
+}
+
+type config struct {
+	// Field is the source field whose value is looked up in the dictionary.
+	Field string `config:"field" validate:"required"`
+
+	// TargetField is where the translated value is written.
+	TargetField string `config:"target_field" validate:"required"`
+
+	// Dictionary is an inline set of translations. Keys containing dots
+	// must be provided through DictionaryPath instead.
+	Dictionary map[string]string `config:"dictionary"`
+
+	// DictionaryPath is a CSV, JSON or YAML file containing translations.
+	// Relative paths are resolved against the config directory.
+	DictionaryPath string `config:"dictionary_path"`
+
+	// RefreshInterval controls how often DictionaryPath is checked for
+	// changes. Zero disables reloading.
+	RefreshInterval time.Duration `config:"refresh_interval" validate:"min=0"`
+
+	// Regex treats dictionary keys as regular expressions. Exact matches
+	// are still preferred over pattern matches.
+	Regex bool `config:"regex"`
+
+	// OnMiss selects what to do when no dictionary entry matches. It
+	// defaults to fallback when Fallback is set and ignore otherwise.
+	OnMiss missPolicy `config:"on_miss"`
+
+	// Fallback is the value written to TargetField on a miss.
+	Fallback *string `config:"fallback"`
+
+	OverwriteKeys bool `config:"overwrite_keys"`
+	IgnoreMissing bool `config:"ignore_missing"`
+	IgnoreFailure bool `config:"ignore_failure"`
+}
+
+func defaultConfig() config {
+	return config{
+		RefreshInterval: 5 * time.Minute,
+	}
+}
+
+func (c *config) Validate() error {
+	if len(c.Dictionary) == 0 && c.DictionaryPath == "" {
+		return errors.New("one of dictionary or dictionary_path must be set")
+	}
+	if len(c.Dictionary) != 0 && c.DictionaryPath != "" {
+		return errors.New("dictionary and dictionary_path cannot be used together")
+	}
+	if c.OnMiss == missFallback && c.Fallback == nil {
+		return errors.New("on_miss: fallback requires a fallback value")
+	}
+	return nil
+}
@@ -0,0 +1,157 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package translate
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// dictionary is an immutable set of translations. A new dictionary is built
+// on every reload and swapped in atomically so lookups never take a lock.
+type dictionary struct {
+	exact    map[string]any
+	patterns []pattern // Only populated in regex mode, in match priority order.
+}
+
+type pattern struct {
+	re    *regexp.Regexp
+	value any
+}
+
+type entry struct {
+	key   string
+	value any
+}
+
+func newDictionary(entries []entry, regex bool) (*dictionary, error) {
+	d := &dictionary{exact: make(map[string]any, len(entries))}
+	for _, e := range entries {
+		d.exact[e.key] = e.value
+		if !regex {
+			continue
+		}
+		re, err := regexp.Compile(e.key)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dictionary regex %q: %w", e.key, err)
+		}
+		d.patterns = append(d.patterns, pattern{re: re, value: e.value})
+	}
+	return d, nil
+}
+
+func (d *dictionary) lookup(key string) (any, bool) {
+	if v, ok := d.exact[key]; ok {
+		return v, true
+	}
+	for _, p := range d.patterns {
+		if p.re.MatchString(key) {
+			return p.value, true
+		}
+	}
+	return nil, false
+}
+
+// inlineEntries returns the inline dictionary ordered by key so that regex
+// matching is deterministic.
+func inlineEntries(m map[string]string) []entry {
+	entries := make([]entry, 0, len(m))
+	for k, v := range m {
+		entries = append(entries, entry{key: k, value: v})
+	}
+	sortEntries(entries)
+	return entries
+}
+
+// loadFile reads dictionary entries from a CSV, JSON or YAML file. The
+// format is chosen by file extension.
+func loadFile(path string) ([]entry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".csv":
+		return readCSV(f)
+	case ".json":
+		var m map[string]any
+		if err := json.NewDecoder(f).Decode(&m); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON dictionary: %w", err)
+		}
+		return mapEntries(m), nil
+	case ".yml", ".yaml":
+		// Decode into map[any]any so that numeric keys such as status codes
+		// are accepted and compared by their string form.
+		var raw map[any]any
+		if err := yaml.NewDecoder(f).Decode(&raw); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to decode YAML dictionary: %w", err)
+		}
+		m := make(map[string]any, len(raw))
+		for k, v := range raw {
+			m[fmt.Sprint(k)] = v
+		}
+		return mapEntries(m), nil
+	default:
+		return nil, fmt.Errorf("unsupported dictionary file extension %q, must be .csv, .json, .yml or .yaml", ext)
+	}
+}
+
+// readCSV reads two column key,value records. Record order is kept so that
+// earlier rows take priority in regex mode.
+func readCSV(r io.Reader) ([]entry, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 2
+	cr.TrimLeadingSpace = true
+	cr.Comment = '#'
+	var entries []entry
+	for {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV dictionary: %w", err)
+		}
+		entries = append(entries, entry{key: rec[0], value: rec[1]})
+	}
+}
+
+func mapEntries(m map[string]any) []entry {
+	entries := make([]entry, 0, len(m))
+	for k, v := range m {
+		entries = append(entries, entry{key: k, value: v})
+	}
+	sortEntries(entries)
+	return entries
+}
+
+func sortEntries(entries []entry) {
+	sort.Slice(entries, func(i, j int) bool { return entries[i].key < entries[j].key })
+}
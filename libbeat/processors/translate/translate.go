@@ -0,0 +1,241 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package translate
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+const (
+	procName = "translate"
+	logName  = "processor." + procName
+)
+
+var (
+	errNoMatch      = errors.New("no dictionary entry matched")
+	errTargetExists = errors.New("target field already exists, set overwrite_keys to replace it")
+	errNoDictionary = errors.New("dictionary not loaded")
+)
+
+func init() {
+	// Not registered as a JS plugin because file based dictionaries are
+	// loaded through SetPaths, which the script processor does not call.
+	processors.RegisterPlugin(procName, New)
+}
+
+type processor struct {
+	config
+	log *logp.Logger
+
+	// path is the resolved dictionary_path, empty for inline dictionaries.
+	path string
+	dict atomic.Pointer[dictionary]
+
+	reloadMu  sync.Mutex
+	lastCheck atomic.Int64 // Unix nanoseconds of the last reload check.
+	modTime   time.Time    // Guarded by reloadMu.
+	now       func() time.Time
+}
+
+// New constructs a new translate processor. Inline dictionaries are ready
+// immediately, file based dictionaries are loaded when SetPaths is called.
+func New(cfg *conf.C, log *logp.Logger) (beat.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("fail to unpack the %v processor configuration: %w", procName, err)
+	}
+
+	p, err := newTranslate(c, log)
+	if err != nil {
+		return nil, err
+	}
+	if c.DictionaryPath == "" {
+		return p, nil
+	}
+	return &fileProcessor{p}, nil
+}
+
+func newTranslate(c config, log *logp.Logger) (*processor, error) {
+	if c.OnMiss == "" {
+		c.OnMiss = missIgnore
+		if c.Fallback != nil {
+			c.OnMiss = missFallback
+		}
+	}
+	p := &processor{
+		config: c,
+		log:    log.Named(logName),
+		now:    time.Now,
+	}
+	if len(c.Dictionary) != 0 {
+		d, err := newDictionary(inlineEntries(c.Dictionary), c.Regex)
+		if err != nil {
+			return nil, err
+		}
+		p.dict.Store(d)
+	}
+	return p, nil
+}
+
+// fileProcessor is a processor with a file based dictionary. It is a
+// separate type so that only processors that need paths implement
+// processors.PathSetter.
+type fileProcessor struct {
+	*processor
+}
+
+// SetPaths resolves dictionary_path against the config directory and
+// performs the initial load. A missing or invalid dictionary is a
+// configuration error.
+func (p *fileProcessor) SetPaths(path *paths.Path) error {
+	p.path = path.Resolve(paths.Config, p.DictionaryPath)
+	return p.load()
+}
+
+func (p *processor) load() error {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return fmt.Errorf("failed to stat %v dictionary: %w", procName, err)
+	}
+	entries, err := loadFile(p.path)
+	if err != nil {
+		return fmt.Errorf("failed to load %v dictionary %v: %w", procName, p.path, err)
+	}
+	d, err := newDictionary(entries, p.Regex)
+	if err != nil {
+		return err
+	}
+	p.dict.Store(d)
+	p.modTime = info.ModTime()
+	p.lastCheck.Store(p.now().UnixNano())
+	p.log.Debugw("Loaded dictionary.", "path", p.path, "entries", len(entries))
+	return nil
+}
+
+// maybeReload reloads the dictionary file if the refresh interval has elapsed
+// and the file modification time changed. Only one event pays the cost of the
+// check, others continue using the current dictionary. Reload failures are
+// logged and the previous dictionary is kept.
+func (p *processor) maybeReload() {
+	if p.path == "" || p.RefreshInterval <= 0 {
+		return
+	}
+	now := p.now()
+	if now.Sub(time.Unix(0, p.lastCheck.Load())) < p.RefreshInterval {
+		return
+	}
+	if !p.reloadMu.TryLock() {
+		return
+	}
+	defer p.reloadMu.Unlock()
+
+	p.lastCheck.Store(now.UnixNano())
+	info, err := os.Stat(p.path)
+	if err != nil {
+		p.log.Warnw("Failed to check dictionary for changes, keeping the current dictionary.", "path", p.path, "error", err)
+		return
+	}
+	if info.ModTime().Equal(p.modTime) {
+		return
+	}
+	if err := p.load(); err != nil {
+		p.log.Warnw("Failed to reload dictionary, keeping the current dictionary.", "error", err)
+	}
+}
+
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	err := p.translate(event)
+	if err == nil || p.IgnoreFailure || (p.IgnoreMissing && errors.Is(err, mapstr.ErrKeyNotFound)) {
+		return event, nil
+	}
+	return event, fmt.Errorf("%v processor failed on field [%v]: %w", procName, p.Field, err)
+}
+
+func (p *processor) translate(event *beat.Event) error {
+	p.maybeReload()
+	d := p.dict.Load()
+	if d == nil {
+		return errNoDictionary
+	}
+
+	v, err := event.GetValue(p.Field)
+	if err != nil {
+		return err
+	}
+	key, err := keyString(v)
+	if err != nil {
+		return err
+	}
+
+	value, found := d.lookup(key)
+	if !found {
+		switch p.OnMiss {
+		case missFallback:
+			value = *p.Fallback
+		case missFail:
+			return errNoMatch
+		default:
+			return nil
+		}
+	}
+
+	if !p.OverwriteKeys && p.TargetField != p.Field {
+		if _, err := event.GetValue(p.TargetField); err == nil {
+			return errTargetExists
+		}
+	}
+	// Dictionary values are shared between events, give each event its own copy.
+	if m, ok := value.(map[string]any); ok {
+		value = mapstr.M(m).Clone()
+	}
+	_, err = event.PutValue(p.TargetField, value)
+	return err
+}
+
+// keyString converts scalar field values to the string form used for
+// dictionary keys so that numeric codes can be translated.
+func keyString(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("unsupported field type %T", v)
+	}
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("%v=[field=%v, target_field=%v, dictionary_path=%v, entries=%d, regex=%v, on_miss=%v]",
+		procName, p.Field, p.TargetField, p.DictionaryPath, len(p.Dictionary), p.Regex, p.OnMiss)
+}
@@ -0,0 +1,215 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package translate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+func TestTranslateInline(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     mapstr.M
+		in      mapstr.M
+		want    mapstr.M
+		wantErr bool
+	}{
+		{
+			name: "exact match",
+			cfg:  mapstr.M{"dictionary": mapstr.M{"200": "OK", "404": "Not Found"}},
+			in:   mapstr.M{"code": 404},
+			want: mapstr.M{"code": 404, "status": "Not Found"},
+		},
+		{
+			name: "miss is ignored by default",
+			cfg:  mapstr.M{"dictionary": mapstr.M{"200": "OK"}},
+			in:   mapstr.M{"code": "500"},
+			want: mapstr.M{"code": "500"},
+		},
+		{
+			name: "fallback implies on_miss fallback",
+			cfg:  mapstr.M{"dictionary": mapstr.M{"200": "OK"}, "fallback": "unknown"},
+			in:   mapstr.M{"code": "500"},
+			want: mapstr.M{"code": "500", "status": "unknown"},
+		},
+		{
+			name:    "on_miss fail",
+			cfg:     mapstr.M{"dictionary": mapstr.M{"200": "OK"}, "on_miss": "fail"},
+			in:      mapstr.M{"code": "500"},
+			want:    mapstr.M{"code": "500"},
+			wantErr: true,
+		},
+		{
+			name: "regex keys with exact match priority",
+			cfg:  mapstr.M{"dictionary": mapstr.M{"^5\\d\\d$": "Server Error", "503": "Unavailable"}, "regex": true},
+			in:   mapstr.M{"code": "503"},
+			want: mapstr.M{"code": "503", "status": "Unavailable"},
+		},
+		{
+			name: "regex keys",
+			cfg:  mapstr.M{"dictionary": mapstr.M{"^5\\d\\d$": "Server Error", "503": "Unavailable"}, "regex": true},
+			in:   mapstr.M{"code": "502"},
+			want: mapstr.M{"code": "502", "status": "Server Error"},
+		},
+		{
+			name:    "existing target",
+			cfg:     mapstr.M{"dictionary": mapstr.M{"200": "OK"}},
+			in:      mapstr.M{"code": "200", "status": "x"},
+			want:    mapstr.M{"code": "200", "status": "x"},
+			wantErr: true,
+		},
+		{
+			name: "existing target overwritten",
+			cfg:  mapstr.M{"dictionary": mapstr.M{"200": "OK"}, "overwrite_keys": true},
+			in:   mapstr.M{"code": "200", "status": "x"},
+			want: mapstr.M{"code": "200", "status": "OK"},
+		},
+		{
+			name:    "missing field",
+			cfg:     mapstr.M{"dictionary": mapstr.M{"200": "OK"}},
+			in:      mapstr.M{},
+			want:    mapstr.M{},
+			wantErr: true,
+		},
+		{
+			name: "missing field ignored",
+			cfg:  mapstr.M{"dictionary": mapstr.M{"200": "OK"}, "ignore_missing": true},
+			in:   mapstr.M{},
+			want: mapstr.M{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			cfg := mapstr.M{"field": "code", "target_field": "status"}
+			cfg.DeepUpdate(test.cfg)
+			p, err := New(conf.MustNewConfigFrom(cfg), logptest.NewTestingLogger(t, ""))
+			require.NoError(t, err, "unexpected error creating processor")
+
+			got, err := p.Run(&beat.Event{Fields: test.in})
+			if test.wantErr {
+				assert.Error(t, err, "expected processor error")
+			} else {
+				assert.NoError(t, err, "unexpected processor error")
+			}
+			assert.Equal(t, test.want, got.Fields, "unexpected event fields")
+		})
+	}
+}
+
+func TestTranslateConfigErrors(t *testing.T) {
+	for name, cfg := range map[string]mapstr.M{
+		"no dictionary":        {"field": "a", "target_field": "b"},
+		"both dictionaries":    {"field": "a", "target_field": "b", "dictionary": mapstr.M{"x": "y"}, "dictionary_path": "d.csv"},
+		"fallback w/o value":   {"field": "a", "target_field": "b", "dictionary": mapstr.M{"x": "y"}, "on_miss": "fallback"},
+		"invalid on_miss":      {"field": "a", "target_field": "b", "dictionary": mapstr.M{"x": "y"}, "on_miss": "drop"},
+		"invalid regex":        {"field": "a", "target_field": "b", "dictionary": mapstr.M{"(": "y"}, "regex": true},
+		"missing target_field": {"field": "a", "dictionary": mapstr.M{"x": "y"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(conf.MustNewConfigFrom(cfg), logptest.NewTestingLogger(t, ""))
+			assert.Error(t, err, "expected configuration error")
+		})
+	}
+}
+
+func TestTranslateFile(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"dict.csv":  "# code,name\n10.0.0.1,gateway\n10.0.0.2, \"dns, primary\"\n",
+		"dict.json": `{"10.0.0.1": "gateway", "10.0.0.2": "dns, primary"}`,
+		"dict.yml":  "10.0.0.1: gateway\n10.0.0.2: dns, primary\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o600), "failed to write dictionary")
+
+			p := newFileProcessor(t, mapstr.M{"dictionary_path": name}, dir)
+			got, err := p.Run(&beat.Event{Fields: mapstr.M{"ip": "10.0.0.2"}})
+			require.NoError(t, err, "unexpected processor error")
+			assert.Equal(t, "dns, primary", got.Fields["host_name"], "unexpected translation")
+		})
+	}
+}
+
+func TestTranslateFileReload(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dict.csv")
+	require.NoError(t, os.WriteFile(path, []byte("10.0.0.1,gateway\n"), 0o600), "failed to write dictionary")
+
+	p := newFileProcessor(t, mapstr.M{"dictionary_path": "dict.csv", "refresh_interval": "1m", "overwrite_keys": true}, dir)
+	fp, ok := p.(*processors.SafeProcessor).Processor.(*fileProcessor)
+	require.True(t, ok, "expected file processor")
+	now := time.Now()
+	fp.now = func() time.Time { return now }
+
+	lookup := func() any {
+		got, err := p.Run(&beat.Event{Fields: mapstr.M{"ip": "10.0.0.1"}})
+		require.NoError(t, err, "unexpected processor error")
+		return got.Fields["host_name"]
+	}
+	assert.Equal(t, "gateway", lookup(), "unexpected initial translation")
+
+	require.NoError(t, os.WriteFile(path, []byte("10.0.0.1,router\n"), 0o600), "failed to rewrite dictionary")
+	require.NoError(t, os.Chtimes(path, now, now.Add(time.Hour)), "failed to change dictionary mtime")
+	assert.Equal(t, "gateway", lookup(), "dictionary reloaded before refresh_interval")
+
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, "router", lookup(), "dictionary not reloaded after refresh_interval")
+
+	// A broken file keeps the last good dictionary.
+	require.NoError(t, os.WriteFile(path, []byte("a,b,c\n"), 0o600), "failed to rewrite dictionary")
+	require.NoError(t, os.Chtimes(path, now, now.Add(2*time.Hour)), "failed to change dictionary mtime")
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, "router", lookup(), "broken dictionary replaced the last good one")
+}
+
+func TestTranslateFileMissing(t *testing.T) {
+	p, err := processors.SafeWrap(New)(conf.MustNewConfigFrom(mapstr.M{
+		"field":           "ip",
+		"target_field":    "host_name",
+		"dictionary_path": "missing.csv",
+	}), logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err, "unexpected error creating processor")
+	err = p.(processors.PathSetter).SetPaths(&paths.Path{Config: t.TempDir()})
+	assert.Error(t, err, "expected error for missing dictionary")
+}
+
+func newFileProcessor(t *testing.T, cfg mapstr.M, dir string) beat.Processor {
+	t.Helper()
+	c := mapstr.M{"field": "ip", "target_field": "host_name"}
+	c.DeepUpdate(cfg)
+	p, err := processors.SafeWrap(New)(conf.MustNewConfigFrom(c), logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err, "unexpected error creating processor")
+	setter, ok := p.(processors.PathSetter)
+	require.True(t, ok, "file based processor must implement PathSetter")
+	require.NoError(t, setter.SetPaths(&paths.Path{Config: dir}), "failed to load dictionary")
+	return p
+}
@@ -65,6 +65,10 @@ type Event struct {
 	// to free the unencoded data. The updated event will be provided to
 	// output workers when calling Publish.
 	EncodedEvent interface{}
+
+	// TraceID is set on the events sampled for delivery tracing, and is
+	// empty otherwise.
+	TraceID string
 }
 
 // EventFlags provides additional flags/option types  for used with the outputs.
@@ -0,0 +1,297 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// workerAutoscaler runs the workers of the clients of an output group whose
+// workers are scaled. The first MinWorkers clients are started with the
+// autoscaler. At each interval, a worker is started for the next client when
+// the queue fills up and the publish latency is acceptable, and the last
+// started worker is stopped when the queue is drained or the publish latency
+// exceeds max_latency. The workers are stopped once they published their
+// current batch, or after an interval with the publish call cancelled, and
+// their client is closed to release its connections.
+type workerAutoscaler struct {
+	settings outputs.AutoscaleSettings
+	logger   *logp.Logger
+	clients  []outputs.Client
+	start    func(client outputs.Client) scalableWorker
+	fill     func() float64
+	latency  *latencyTracker
+	clock    func() time.Time
+	metrics  autoscaleMetrics
+
+	mu         sync.Mutex
+	workers    []scalableWorker
+	lastScaled time.Time
+	closed     bool
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+type autoscaleMetrics struct {
+	active     *monitoring.Uint // gauge
+	scaledUp   *monitoring.Uint
+	scaledDown *monitoring.Uint
+}
+
+func newAutoscaleMetrics(reg *monitoring.Registry) autoscaleMetrics {
+	if reg == nil {
+		reg = monitoring.NewRegistry()
+	}
+	return autoscaleMetrics{
+		active:     monitoring.NewUint(reg, "active"),
+		scaledUp:   monitoring.NewUint(reg, "scaled_up"),
+		scaledDown: monitoring.NewUint(reg, "scaled_down"),
+	}
+}
+
+func newWorkerAutoscaler(
+	settings outputs.AutoscaleSettings,
+	clients []outputs.Client,
+	start func(client outputs.Client, latency *latencyTracker) scalableWorker,
+	fill func() float64,
+	reg *monitoring.Registry,
+	logger *logp.Logger,
+) *workerAutoscaler {
+	latency := &latencyTracker{}
+	a := &workerAutoscaler{
+		settings: settings,
+		logger:   logger,
+		clients:  clients,
+		start: func(client outputs.Client) scalableWorker {
+			return start(client, latency)
+		},
+		fill:    fill,
+		latency: latency,
+		clock:   time.Now,
+		metrics: newAutoscaleMetrics(reg),
+		done:    make(chan struct{}),
+	}
+	for len(a.workers) < a.minWorkers() {
+		a.workers = append(a.workers, a.start(clients[len(a.workers)]))
+	}
+	a.metrics.active.Set(uint64(len(a.workers)))
+	a.lastScaled = a.clock()
+	return a
+}
+
+// run evaluates the scaling of the workers at each interval until the
+// autoscaler is closed.
+func (a *workerAutoscaler) run() {
+	a.wg.Add(1)
+	go func() {
+		defer a.wg.Done()
+		ticker := time.NewTicker(a.settings.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-a.done:
+				return
+			case <-ticker.C:
+				a.scale()
+			}
+		}
+	}()
+}
+
+// minWorkers returns the minimum number of workers, bounded by the number of
+// clients.
+func (a *workerAutoscaler) minWorkers() int {
+	return max(min(a.settings.MinWorkers, len(a.clients)), 1)
+}
+
+// decide returns 1 if a worker must be started, -1 if a worker must be
+// stopped, and 0 otherwise.
+func (a *workerAutoscaler) decide(fill float64, latency time.Duration, active int, now time.Time) int {
+	if now.Sub(a.lastScaled) < a.settings.Cooldown {
+		return 0
+	}
+	overloaded := a.settings.MaxLatency > 0 && latency >= a.settings.MaxLatency
+	switch {
+	case overloaded && active > a.minWorkers():
+		return -1
+	case !overloaded && fill >= a.settings.QueueHighWatermark && active < len(a.clients):
+		return 1
+	case fill <= a.settings.QueueLowWatermark && active > a.minWorkers():
+		return -1
+	}
+	return 0
+}
+
+// scale starts or stops a worker depending on the fill level of the queue and
+// the average publish latency since the last evaluation. A worker is stopped
+// without holding the lock, as it may wait for a slow publish call, so Close
+// isn't blocked meanwhile.
+func (a *workerAutoscaler) scale() {
+	fill := a.fill()
+	latency := a.latency.average()
+
+	stopped := a.decideScale(fill, latency)
+	if stopped == nil {
+		return
+	}
+	stopped.stop(a.settings.Interval, a.done)
+	if err := stopped.Close(); err != nil {
+		a.logger.Debugf("Failed to close the client of a stopped output worker: %v", err)
+	}
+}
+
+// decideScale starts a worker, or removes the worker to stop from the running
+// ones and returns it.
+func (a *workerAutoscaler) decideScale(fill float64, latency time.Duration) scalableWorker {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.closed {
+		return nil
+	}
+	now := a.clock()
+	var stopped scalableWorker
+	switch a.decide(fill, latency, len(a.workers), now) {
+	case 1:
+		a.workers = append(a.workers, a.start(a.clients[len(a.workers)]))
+		a.metrics.scaledUp.Inc()
+		a.logger.Infof("Started output worker %d of %d (queue fill %.2f, publish latency %v)",
+			len(a.workers), len(a.clients), fill, latency)
+	case -1:
+		last := len(a.workers) - 1
+		stopped = a.workers[last]
+		a.workers = a.workers[:last]
+		a.metrics.scaledDown.Inc()
+		a.logger.Infof("Stopping output worker, %d of %d running (queue fill %.2f, publish latency %v)",
+			len(a.workers), len(a.clients), fill, latency)
+	default:
+		return nil
+	}
+	a.lastScaled = now
+	a.metrics.active.Set(uint64(len(a.workers)))
+	return stopped
+}
+
+// Close stops the evaluation of the scaling and closes the running workers.
+// The publish call of a worker being stopped is cancelled.
+func (a *workerAutoscaler) Close() error {
+	a.mu.Lock()
+	if a.closed {
+		a.mu.Unlock()
+		return nil
+	}
+	a.closed = true
+	close(a.done)
+	workers := a.workers
+	a.workers = nil
+	a.mu.Unlock()
+
+	a.wg.Wait()
+	for _, w := range workers {
+		w.Close()
+	}
+	return nil
+}
+
+// latencyTracker records the duration of the publish calls of the workers.
+type latencyTracker struct {
+	mu    sync.Mutex
+	total time.Duration
+	count int
+}
+
+func (t *latencyTracker) observe(d time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.total += d
+	t.count++
+}
+
+// average returns the average duration of the publish calls recorded since
+// the last call, or 0 if none was recorded.
+func (t *latencyTracker) average() time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.count == 0 {
+		return 0
+	}
+	avg := t.total / time.Duration(t.count)
+	t.total, t.count = 0, 0
+	return avg
+}
+
+// fillObserver wraps the observer of the queue to track its fill level, which
+// drives the scaling of the output workers.
+type fillObserver struct {
+	queue.Observer
+
+	maxEvents, maxBytes atomic.Int64
+	events, bytes       atomic.Int64
+}
+
+func newFillObserver(ob queue.Observer) *fillObserver {
+	return &fillObserver{Observer: ob}
+}
+
+func (o *fillObserver) MaxEvents(value int) {
+	o.maxEvents.Store(int64(value))
+	o.Observer.MaxEvents(value)
+}
+
+func (o *fillObserver) MaxBytes(value int) {
+	o.maxBytes.Store(int64(value))
+	o.Observer.MaxBytes(value)
+}
+
+func (o *fillObserver) Restore(eventCount int, byteCount int) {
+	o.events.Store(int64(eventCount))
+	o.bytes.Store(int64(byteCount))
+	o.Observer.Restore(eventCount, byteCount)
+}
+
+func (o *fillObserver) AddEvent(byteCount int) {
+	o.events.Add(1)
+	o.bytes.Add(int64(byteCount))
+	o.Observer.AddEvent(byteCount)
+}
+
+func (o *fillObserver) RemoveEvents(eventCount int, byteCount int) {
+	o.events.Add(-int64(eventCount))
+	o.bytes.Add(-int64(byteCount))
+	o.Observer.RemoveEvents(eventCount, byteCount)
+}
+
+// fill returns the fill level of the queue, between 0 and 1. The level is
+// computed from the bytes if the queue is limited in bytes, from the events
+// otherwise.
+func (o *fillObserver) fill() float64 {
+	if maxBytes := o.maxBytes.Load(); maxBytes > 0 {
+		return float64(o.bytes.Load()) / float64(maxBytes)
+	}
+	if maxEvents := o.maxEvents.Load(); maxEvents > 0 {
+		return float64(o.events.Load()) / float64(maxEvents)
+	}
+	return 0
+}
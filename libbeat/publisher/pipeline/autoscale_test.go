@@ -0,0 +1,212 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+type fakeScalableWorker struct {
+	client  outputs.Client
+	stopped bool
+	closed  bool
+}
+
+func (w *fakeScalableWorker) stop(time.Duration, <-chan struct{}) { w.stopped = true }
+func (w *fakeScalableWorker) Close() error                        { w.closed = true; return nil }
+
+func newTestAutoscaler(t *testing.T, fill *float64) (*workerAutoscaler, *[]*fakeScalableWorker, *monitoring.Registry) {
+	t.Helper()
+	clients := make([]outputs.Client, 4)
+	for i := range clients {
+		clients[i] = newMockNetworkClient(nil)
+	}
+	var started []*fakeScalableWorker
+	reg := monitoring.NewRegistry()
+	a := newWorkerAutoscaler(outputs.AutoscaleSettings{
+		AutoscaleConfig: outputs.AutoscaleConfig{
+			Enabled:            true,
+			MaxWorkers:         2,
+			Interval:           time.Second,
+			Cooldown:           time.Minute,
+			QueueHighWatermark: 0.5,
+			QueueLowWatermark:  0.1,
+			MaxLatency:         time.Second,
+		},
+		MinWorkers: 2,
+	}, clients, func(client outputs.Client, _ *latencyTracker) scalableWorker {
+		w := &fakeScalableWorker{client: client}
+		started = append(started, w)
+		return w
+	}, func() float64 { return *fill }, reg, logp.NewNopLogger())
+	return a, &started, reg
+}
+
+func TestWorkerAutoscalerDecide(t *testing.T) {
+	var fill float64
+	a, _, _ := newTestAutoscaler(t, &fill)
+	now := a.lastScaled.Add(time.Hour)
+
+	tests := map[string]struct {
+		fill    float64
+		latency time.Duration
+		active  int
+		want    int
+	}{
+		"queue filling":              {fill: 0.8, active: 2, want: 1},
+		"all workers running":        {fill: 0.8, active: 4, want: 0},
+		"queue filling, slow output": {fill: 0.8, latency: 2 * time.Second, active: 3, want: -1},
+		"slow output at min workers": {fill: 0.8, latency: 2 * time.Second, active: 2, want: 0},
+		"queue drained":              {fill: 0.05, active: 3, want: -1},
+		"queue drained at min":       {fill: 0.05, active: 2, want: 0},
+		"steady":                     {fill: 0.3, latency: 100 * time.Millisecond, active: 3, want: 0},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, a.decide(test.fill, test.latency, test.active, now))
+		})
+	}
+
+	assert.Zero(t, a.decide(0.8, 0, 2, a.lastScaled.Add(time.Second)), "no decision is taken during the cooldown")
+}
+
+func TestWorkerAutoscalerScale(t *testing.T) {
+	fill := 0.8
+	a, started, reg := newTestAutoscaler(t, &fill)
+	now := a.lastScaled
+	a.clock = func() time.Time { return now }
+	require.Len(t, *started, 2, "the minimum workers are started")
+	assert.Same(t, a.clients[1], (*started)[1].client)
+
+	now = now.Add(time.Hour)
+	a.scale()
+	require.Len(t, *started, 3)
+	assert.Same(t, a.clients[2], (*started)[2].client, "the workers are started in the order of the clients")
+	a.scale()
+	assert.Len(t, *started, 3, "no worker is started during the cooldown")
+
+	fill = 0
+	now = now.Add(time.Hour)
+	a.scale()
+	assert.Len(t, a.workers, 2)
+	last := (*started)[2]
+	assert.True(t, last.stopped && last.closed, "the last started worker is stopped and its client closed")
+
+	snapshot := monitoring.CollectFlatSnapshot(reg, monitoring.Full, false)
+	assert.Equal(t, int64(2), snapshot.Ints["active"])
+	assert.Equal(t, int64(1), snapshot.Ints["scaled_up"])
+	assert.Equal(t, int64(1), snapshot.Ints["scaled_down"])
+
+	require.NoError(t, a.Close())
+	for _, w := range (*started)[:2] {
+		assert.True(t, w.closed)
+	}
+}
+
+func TestWorkerStop(t *testing.T) {
+	workQueue := make(chan publisher.Batch)
+	published := make(chan struct{})
+	client := newMockNetworkClient(func(publisher.Batch) error {
+		close(published)
+		return nil
+	})
+	latency := &latencyTracker{}
+	w := startClientWorker(workQueue, client, makeBufLogger(t), nil, latency)
+
+	// The first batch is returned to reconnect.
+	workQueue <- randomBatch(1, 1)
+	workQueue <- randomBatch(1, 1)
+	<-published
+	w.stop(time.Minute, nil)
+	assert.Positive(t, latency.count, "the duration of the publish calls is recorded")
+
+	select {
+	case workQueue <- randomBatch(1, 1):
+		t.Fatal("a stopped worker must not read batches")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestWorkerStopCancelsPublish(t *testing.T) {
+	workQueue := make(chan publisher.Batch)
+	publishing := make(chan struct{})
+	client := &ctxClient{publish: func(ctx context.Context) error {
+		close(publishing)
+		<-ctx.Done()
+		return ctx.Err()
+	}}
+	w := startClientWorker(workQueue, client, makeBufLogger(t), nil, nil)
+	workQueue <- randomBatch(1, 1)
+	<-publishing
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		w.stop(10*time.Millisecond, nil)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the publish call in flight must be cancelled after the grace period")
+	}
+
+	// Closing abort cancels the publish call at once.
+	workQueue = make(chan publisher.Batch)
+	publishing = make(chan struct{})
+	w = startClientWorker(workQueue, client, makeBufLogger(t), nil, nil)
+	workQueue <- randomBatch(1, 1)
+	<-publishing
+	abort := make(chan struct{})
+	close(abort)
+	w.stop(time.Hour, abort)
+}
+
+// ctxClient is an outputs.Client whose publish calls get the context of the
+// worker.
+type ctxClient struct {
+	publish func(ctx context.Context) error
+}
+
+func (c *ctxClient) Publish(ctx context.Context, _ publisher.Batch) error { return c.publish(ctx) }
+func (c *ctxClient) Close() error                                         { return nil }
+func (c *ctxClient) String() string                                       { return "ctx" }
+
+func TestFillObserver(t *testing.T) {
+	ob := newFillObserver(queue.NewQueueObserver(nil))
+	ob.MaxEvents(10)
+	for i := 0; i < 6; i++ {
+		ob.AddEvent(0)
+	}
+	ob.RemoveEvents(2, 0)
+	assert.InDelta(t, 0.4, ob.fill(), 1e-9)
+
+	ob.MaxBytes(1000)
+	ob.AddEvent(500)
+	assert.InDelta(t, 0.5, ob.fill(), 1e-9, "the fill level is computed from the bytes if the queue is limited in bytes")
+}
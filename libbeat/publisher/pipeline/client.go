@@ -18,6 +18,7 @@
 package pipeline
 
 import (
+	"errors"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -49,8 +50,22 @@ type client struct {
 	observer       observer
 	eventListener  beat.EventListener
 	clientListener beat.ClientListener
+
+	// tracer logs the transit of the traced events, nil if tracing is
+	// disabled.
+	tracer *eventTracer
+
+	// held tracks the events held back by the processors, nil if none of
+	// them holds events back for a limited time. expireDone stops the
+	// periodic expiration of the held events.
+	held       *heldEvents
+	expireDone chan struct{}
 }
 
+// expireInterval is the time between the calls to Expire of the processors
+// of a client that hold events back for a limited time.
+var expireInterval = time.Second
+
 type clientCloseWaiter struct {
 	events  atomic.Uint32
 	closing atomic.Bool
@@ -77,44 +92,71 @@ func (c *client) Publish(e beat.Event) {
 }
 
 func (c *client) publish(e beat.Event) {
-	var (
-		event   = &e
-		publish = true
-	)
-
 	c.onNewEvent()
 
+	traceID := c.tracer.sample()
+	c.tracer.received(traceID, e)
+
 	if !c.isOpen.Load() {
 		// client is closing down -> report event as dropped and return
+		c.tracer.queued(traceID, false)
 		c.onDroppedOnPublish(e)
 		return
 	}
 
+	events := []*beat.Event{&e}
 	if c.processors != nil {
 		var err error
 
-		event, err = c.processors.Run(event)
-		publish = event != nil
-		if err != nil {
+		if c.held != nil {
+			c.held.wrap(&e)
+		}
+		events, err = processors.RunSplit(c.processors, &e)
+		if errors.Is(err, processors.ErrEventHeld) {
+			c.tracer.held(traceID)
+			if c.held != nil {
+				c.held.hold(e)
+			} else {
+				c.eventListener.AddEvent(e, false)
+				// Don't report its state a second time when it is released.
+				e.Private = nil
+			}
+			if len(events) == 0 {
+				return
+			}
+		} else if err != nil {
 			// If we introduce a dead-letter queue, this is where we should
 			// route the event to it.
 			c.logger.Errorf("Failed to publish event: %v", err)
 		}
 	}
 
-	if event != nil {
-		e = *event
-	}
-
-	c.eventListener.AddEvent(e, publish)
-	if !publish {
+	if len(events) == 0 {
+		c.tracer.filtered(traceID)
+		c.eventListener.AddEvent(e, false)
 		c.onFilteredOut()
 		return
 	}
 
-	e = *event
+	for i, event := range events {
+		if i > 0 {
+			// Events split from the original are accounted for like events
+			// published by the input, so that each of them is ACKed.
+			c.onNewEvent()
+		}
+		c.publishProcessed(*event, traceID)
+	}
+}
+
+func (c *client) publishProcessed(e beat.Event, traceID string) {
+	c.eventListener.AddEvent(e, true)
+	if c.held != nil {
+		c.held.unwrap(&e)
+	}
+
 	pubEvent := publisher.Event{
 		Content: e,
+		TraceID: traceID,
 		Flags:   c.eventFlags,
 	}
 
@@ -125,9 +167,13 @@ func (c *client) publish(e beat.Event) {
 		_, published = c.producer.Publish(pubEvent)
 	}
 
+	c.tracer.queued(traceID, published)
 	if published {
 		c.onPublished()
 	} else {
+		if c.held != nil {
+			c.held.dropped()
+		}
 		c.onDroppedOnPublish(e)
 	}
 }
@@ -136,10 +182,15 @@ func (c *client) Close() error {
 	// Hold the mutex so any in-progress Publish finishes before
 	// signalClose checks the pending event count.
 	c.mutex.Lock()
-	if !c.isOpen.Swap(false) {
+	if !c.isOpen.Load() {
 		c.mutex.Unlock()
 		return nil
 	}
+	if c.expireDone != nil {
+		close(c.expireDone)
+	}
+	c.flushProcessors()
+	c.isOpen.Store(false)
 	c.onClosing()
 	c.waiter.signalClose()
 	c.mutex.Unlock()
@@ -165,6 +216,48 @@ func (c *client) Close() error {
 	return nil
 }
 
+// flushProcessors publishes events held back by processors, for example
+// pending aggregations, before the client stops accepting events.
+// Must be called with the mutex held.
+func (c *client) flushProcessors() {
+	if c.processors == nil {
+		return
+	}
+	for _, event := range processors.Flush(c.processors) {
+		c.onNewEvent()
+		c.publishProcessed(*event, "")
+	}
+}
+
+// expires reports whether the processors of the client hold events back for
+// a limited time.
+func (c *client) expires() bool {
+	return c.processors != nil && processors.Expires(c.processors)
+}
+
+// expireProcessors publishes the events held back by the processors whose
+// time is up, every interval until the client is closed.
+func (c *client) expireProcessors(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.expireDone:
+			return
+		case <-ticker.C:
+		}
+
+		c.mutex.Lock()
+		if c.isOpen.Load() {
+			for _, event := range processors.Expire(c.processors) {
+				c.onNewEvent()
+				c.publishProcessed(*event, "")
+			}
+		}
+		c.mutex.Unlock()
+	}
+}
+
 func (c *client) onClosing() {
 	c.clientListener.Closing()
 }
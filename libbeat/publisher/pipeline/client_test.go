@@ -194,6 +194,140 @@ func TestClient(t *testing.T) {
 	})
 }
 
+func TestClientSplitEvents(t *testing.T) {
+	pipeline := loadTestPipeline(t, &testSplitProcessor{})
+
+	listener := &mockClientListener{}
+	c, err := pipeline.ConnectWith(beat.ClientConfig{ClientListener: listener})
+	require.NoError(t, err, "pipeline.ConnectWith failed")
+
+	cc, ok := c.(*client)
+	require.True(t, ok, "pipeline.ConnectWith return value cannot be cast to client")
+	var published []beat.Event
+	cc.producer = &testProducer{publish: func(try bool, event publisher.Event) (queue.EntryID, bool) {
+		published = append(published, event.Content)
+		return queue.EntryID(len(published)), true
+	}}
+
+	c.Publish(beat.Event{Fields: mapstr.M{"n": []interface{}{1, 2, 3}}})
+	c.Publish(beat.Event{Fields: mapstr.M{"n": []interface{}{}}})
+	require.NoError(t, c.Close())
+
+	if assert.Len(t, published, 3, "split events must all be published") {
+		for i, e := range published {
+			assert.Equal(t, mapstr.M{"n": i + 1}, e.Fields, "unexpected split event")
+		}
+	}
+	assert.Equal(t, 4, listener.eventsTotal, "split events must be counted as new events")
+	assert.Equal(t, 1, listener.eventsFiltered, "empty split must be filtered")
+	assert.Equal(t, 3, listener.eventsPublished, "split events must be counted as published")
+}
+
+func TestClientFlushOnClose(t *testing.T) {
+	pipeline := loadTestPipeline(t, &testFlushProcessor{})
+
+	listener := &mockClientListener{}
+	c, err := pipeline.ConnectWith(beat.ClientConfig{ClientListener: listener})
+	require.NoError(t, err, "pipeline.ConnectWith failed")
+
+	cc, ok := c.(*client)
+	require.True(t, ok, "pipeline.ConnectWith return value cannot be cast to client")
+	var published []beat.Event
+	cc.producer = &testProducer{publish: func(try bool, event publisher.Event) (queue.EntryID, bool) {
+		published = append(published, event.Content)
+		return queue.EntryID(len(published)), true
+	}}
+
+	c.Publish(beat.Event{Fields: mapstr.M{"n": 1}})
+	c.Publish(beat.Event{Fields: mapstr.M{"n": 2}})
+	assert.Empty(t, published, "events must be held back by the processor")
+
+	require.NoError(t, c.Close())
+	require.Len(t, published, 2, "held back events must be published on close")
+	assert.Equal(t, 4, listener.eventsTotal, "unexpected number of events")
+	assert.Equal(t, 2, listener.eventsFiltered, "unexpected number of filtered events")
+	assert.Equal(t, 2, listener.eventsPublished, "unexpected number of published events")
+}
+
+func TestClientHeldEvents(t *testing.T) {
+	defer func(d time.Duration) { expireInterval = d }(expireInterval)
+	expireInterval = time.Millisecond
+
+	proc := &testHoldProcessor{}
+	pipeline := loadTestPipeline(t, proc)
+
+	listener := &testEventListener{}
+	c, err := pipeline.ConnectWith(beat.ClientConfig{EventListener: listener})
+	require.NoError(t, err, "pipeline.ConnectWith failed")
+
+	cc, ok := c.(*client)
+	require.True(t, ok, "pipeline.ConnectWith return value cannot be cast to client")
+	require.NotNil(t, cc.held, "the events held by the processors must be tracked")
+	var mu sync.Mutex
+	var published []beat.Event
+	cc.producer = &testProducer{publish: func(try bool, event publisher.Event) (queue.EntryID, bool) {
+		mu.Lock()
+		defer mu.Unlock()
+		published = append(published, event.Content)
+		return queue.EntryID(len(published)), true
+	}}
+
+	c.Publish(beat.Event{Fields: mapstr.M{"hold": true}, Private: "a"})
+	c.Publish(beat.Event{Fields: mapstr.M{}, Private: "b"})
+	c.Publish(beat.Event{Fields: mapstr.M{}, Private: "c"})
+	assert.Equal(t, []interface{}{"a", "b", "c"}, listener.privates(), "held events must be reported in order")
+
+	// The queue acknowledges the events published after the held one.
+	cc.eventListener.ACKEvents(2)
+	assert.Zero(t, listener.ackedEvents(), "events after a held event must not be acknowledged before it")
+
+	proc.expire()
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(published) == 3
+	}, time.Second, time.Millisecond, "expired events must be published without a new event")
+	mu.Lock()
+	assert.Equal(t, "a", published[2].Private, "the held event must be published with its private")
+	mu.Unlock()
+
+	cc.eventListener.ACKEvents(1)
+	assert.Equal(t, 3, listener.ackedEvents(), "all events must be acknowledged once the held event is")
+	require.NoError(t, c.Close())
+}
+
+// loadTestPipeline returns a pipeline with a memory queue that applies proc
+// to all events.
+func loadTestPipeline(t *testing.T, proc beat.Processor) *Pipeline {
+	t.Helper()
+	var config Config
+	err := conf.MustNewConfigFrom(map[string]interface{}{
+		"queue.mem.events":           32,
+		"queue.mem.flush.min_events": 1,
+		"queue.mem.flush.timeout":    time.Millisecond,
+	}).Unpack(&config)
+	require.NoError(t, err, "failed creating config")
+
+	logger := logptest.NewTestingLogger(t, "")
+	pipeline, err := Load(
+		beat.Info{Logger: logger},
+		Monitors{
+			Metrics:   monitoring.NewRegistry(),
+			Telemetry: monitoring.NewRegistry(),
+			Logger:    logger,
+		},
+		config,
+		testProcessorSupporter{Processor: proc},
+		func(outputs.Observer) (string, outputs.Group, error) {
+			return "output_name", outputs.Group{Clients: []outputs.Client{
+				newMockClient(func(publisher.Batch) error { return nil })},
+			}, nil
+		},
+	)
+	require.NoError(t, err)
+	return pipeline
+}
+
 // TestCloseWaitsForInFlightPublish verifies that Close waits for an event
 // that is mid-Publish (past the isOpen check but before AddEvent) to be
 // tracked by the clientCloseWaiter before deciding whether to wait.
@@ -520,6 +654,127 @@ func (p *testProcessor) Run(in *beat.Event) (event *beat.Event, err error) {
 	return p.processorFn(in)
 }
 
+// testSplitProcessor returns one event per element of the "n" field.
+type testSplitProcessor struct{}
+
+func (p *testSplitProcessor) String() string {
+	return "testSplitProcessor"
+}
+
+func (p *testSplitProcessor) Run(in *beat.Event) (*beat.Event, error) {
+	return in, nil
+}
+
+func (p *testSplitProcessor) RunSplit(in *beat.Event) ([]*beat.Event, error) {
+	var out []*beat.Event
+	for _, n := range in.Fields["n"].([]interface{}) {
+		out = append(out, &beat.Event{Fields: mapstr.M{"n": n}})
+	}
+	return out, nil
+}
+
+// testFlushProcessor holds all events back until it is flushed.
+type testFlushProcessor struct {
+	pending []*beat.Event
+}
+
+func (p *testFlushProcessor) String() string {
+	return "testFlushProcessor"
+}
+
+func (p *testFlushProcessor) Run(in *beat.Event) (*beat.Event, error) {
+	p.pending = append(p.pending, in)
+	return nil, nil
+}
+
+func (p *testFlushProcessor) Flush() []*beat.Event {
+	pending := p.pending
+	p.pending = nil
+	return pending
+}
+
+// testHoldProcessor holds the events with a "hold" field back until they
+// expire.
+type testHoldProcessor struct {
+	mu      sync.Mutex
+	pending []*beat.Event
+	expired bool
+}
+
+func (p *testHoldProcessor) String() string {
+	return "testHoldProcessor"
+}
+
+func (p *testHoldProcessor) Run(in *beat.Event) (*beat.Event, error) {
+	return in, nil
+}
+
+func (p *testHoldProcessor) RunSplit(in *beat.Event) ([]*beat.Event, error) {
+	if _, ok := in.Fields["hold"]; !ok {
+		return []*beat.Event{in}, nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending = append(p.pending, in)
+	return nil, processors.ErrEventHeld
+}
+
+func (p *testHoldProcessor) Expires() bool {
+	return true
+}
+
+// expire makes the held events expire.
+func (p *testHoldProcessor) expire() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.expired = true
+}
+
+func (p *testHoldProcessor) Expire() []*beat.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.expired {
+		return nil
+	}
+	pending := p.pending
+	p.pending = nil
+	return pending
+}
+
+// testEventListener records the private fields of the events added and the
+// number of events acknowledged.
+type testEventListener struct {
+	mu    sync.Mutex
+	added []interface{}
+	acked int
+}
+
+func (l *testEventListener) AddEvent(event beat.Event, published bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.added = append(l.added, event.Private)
+}
+
+func (l *testEventListener) ACKEvents(n int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.acked += n
+}
+
+func (l *testEventListener) ClientClosed() {}
+
+func (l *testEventListener) privates() []interface{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.added
+}
+
+func (l *testEventListener) ackedEvents() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.acked
+}
+
 type processorList struct {
 	processors []beat.Processor
 }
@@ -20,6 +20,7 @@ package pipeline
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/elastic/beats/v7/libbeat/publisher"
 
@@ -31,6 +32,14 @@ import (
 type worker struct {
 	qu     chan publisher.Batch
 	cancel func()
+
+	// stopping is closed to stop the worker once it published its current
+	// batch, done is closed when the worker stopped.
+	stopping chan struct{}
+	done     chan struct{}
+
+	// latency records the duration of the publish calls, if set.
+	latency *latencyTracker
 }
 
 // clientWorker manages output client of type outputs.Client, not supporting reconnect.
@@ -49,15 +58,36 @@ type netClientWorker struct {
 	tracer *apm.Tracer
 }
 
+// scalableWorker is an outputWorker that can be stopped without cancelling
+// the batch it is publishing, so the autoscaler can remove it.
+type scalableWorker interface {
+	outputWorker
+
+	// stop stops the worker once it published its current batch, and waits
+	// for it to stop. The publish call in flight is cancelled if it doesn't
+	// complete within grace or when abort is closed, so its batch is
+	// retried by the other workers. The client is not closed.
+	stop(grace time.Duration, abort <-chan struct{})
+}
+
 func makeClientWorker(qu chan publisher.Batch, client outputs.Client, logger logger, tracer *apm.Tracer) outputWorker {
+	return startClientWorker(qu, client, logger, tracer, nil)
+}
+
+// startClientWorker starts a worker publishing the batches of qu with client.
+// If latency is set, the duration of the publish calls is recorded in it.
+func startClientWorker(qu chan publisher.Batch, client outputs.Client, logger logger, tracer *apm.Tracer, latency *latencyTracker) scalableWorker {
 	ctx, cancel := context.WithCancel(context.Background())
 	w := worker{
-		qu:     qu,
-		cancel: cancel,
+		qu:       qu,
+		cancel:   cancel,
+		stopping: make(chan struct{}),
+		done:     make(chan struct{}),
+		latency:  latency,
 	}
 
 	var c interface {
-		outputWorker
+		scalableWorker
 		run(context.Context)
 	}
 
@@ -72,7 +102,10 @@ func makeClientWorker(qu chan publisher.Batch, client outputs.Client, logger log
 		c = &clientWorker{worker: w, client: client}
 	}
 
-	go c.run(ctx)
+	go func() {
+		defer close(w.done)
+		c.run(ctx)
+	}()
 	return c
 }
 
@@ -80,6 +113,35 @@ func (w *worker) close() {
 	w.cancel()
 }
 
+func (w *worker) stop(grace time.Duration, abort <-chan struct{}) {
+	select {
+	case <-w.stopping:
+	default:
+		close(w.stopping)
+	}
+	timer := time.NewTimer(grace)
+	defer timer.Stop()
+	select {
+	case <-w.done:
+		return
+	case <-timer.C:
+	case <-abort:
+	}
+	w.cancel()
+	<-w.done
+}
+
+// publish publishes batch with client, recording the duration of the call.
+func (w *worker) publish(ctx context.Context, client outputs.Client, batch publisher.Batch) error {
+	if w.latency == nil {
+		return client.Publish(ctx, batch)
+	}
+	start := time.Now()
+	err := client.Publish(ctx, batch)
+	w.latency.observe(time.Since(start))
+	return err
+}
+
 func (w *clientWorker) Close() error {
 	w.close()
 	return w.client.Close()
@@ -94,11 +156,14 @@ func (w *clientWorker) run(ctx context.Context) {
 		case <-ctx.Done():
 			return
 
+		case <-w.stopping:
+			return
+
 		case batch := <-w.qu:
 			if batch == nil {
 				continue
 			}
-			if err := w.client.Publish(ctx, batch); err != nil {
+			if err := w.publish(ctx, w.client, batch); err != nil {
 				return
 			}
 		}
@@ -124,6 +189,9 @@ func (w *netClientWorker) run(ctx context.Context) {
 		case <-ctx.Done():
 			return
 
+		case <-w.stopping:
+			return
+
 		case batch := <-w.qu:
 			if batch == nil {
 				continue
@@ -167,7 +235,7 @@ func (w *netClientWorker) publishBatch(ctx context.Context, batch publisher.Batc
 		tx.Context.SetLabel("worker", "netclient")
 		ctx = apm.ContextWithTransaction(ctx, tx)
 	}
-	err := w.client.Publish(ctx, batch)
+	err := w.publish(ctx, w.client, batch)
 	if err != nil {
 		err = fmt.Errorf("failed to publish events: %w", err)
 		apm.CaptureError(ctx, err).Send()
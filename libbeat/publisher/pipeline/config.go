@@ -20,6 +20,7 @@ package pipeline
 import (
 	"errors"
 	"fmt"
+	"regexp"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/processors"
@@ -35,6 +36,41 @@ type Config struct {
 
 	// Event queue
 	Queue config.Namespace `config:"queue"`
+
+	// Delivery tracing of a sample of the events
+	Trace TraceConfig `config:"publisher_pipeline.trace"`
+
+	// Additional pipelines with their own queue and outputs
+	Pipelines []NamedConfig `config:"publisher_pipelines"`
+}
+
+// NamedConfig configures an additional publisher pipeline. Clients select it
+// by name, their events don't share the queue and the backpressure of the
+// default pipeline.
+type NamedConfig struct {
+	Name string `config:"name" validate:"required"`
+
+	// Queue of the pipeline, the memory queue is used by default.
+	Queue config.Namespace `config:"queue"`
+
+	// Output of the pipeline, the output of the Beat is used by default.
+	Output config.Namespace `config:"output"`
+}
+
+var pipelineNameRegexp = regexp.MustCompile(`^[a-z0-9_-]+$`)
+
+func (c *Config) Validate() error {
+	names := make(map[string]struct{}, len(c.Pipelines))
+	for _, p := range c.Pipelines {
+		if !pipelineNameRegexp.MatchString(p.Name) {
+			return fmt.Errorf("invalid publisher pipeline name %q, only lowercase letters, digits, - and _ are allowed", p.Name)
+		}
+		if _, ok := names[p.Name]; ok {
+			return fmt.Errorf("duplicate publisher pipeline name %q", p.Name)
+		}
+		names[p.Name] = struct{}{}
+	}
+	return nil
 }
 
 // validateClientConfig checks a ClientConfig can be used with (*Pipeline).ConnectWith.
@@ -0,0 +1,208 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/reload"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue/diskqueue"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+// Group connects clients to the named pipeline selected in their
+// configuration, or to the default pipeline. Each pipeline has its own
+// queue and outputs, so a pipeline that is blocked by its output doesn't
+// slow down the clients of the others.
+type Group struct {
+	def   *Pipeline
+	named map[string]*Pipeline
+	// followers are the named pipelines without their own output, their
+	// output is reloaded with the one of the default pipeline.
+	followers []*Pipeline
+}
+
+// NewGroup creates a Group of the default pipeline and the named ones
+// created from configs. The group takes ownership of the pipelines.
+func NewGroup(def *Pipeline, named map[string]*Pipeline, configs []NamedConfig) *Group {
+	g := &Group{def: def, named: named}
+	for _, c := range configs {
+		if p, ok := named[c.Name]; ok && !c.Output.IsSet() {
+			g.followers = append(g.followers, p)
+		}
+	}
+	return g
+}
+
+// Default returns the default pipeline.
+func (g *Group) Default() *Pipeline {
+	return g.def
+}
+
+// Connect creates a new client of the default pipeline.
+func (g *Group) Connect() (beat.Client, error) {
+	return g.def.Connect()
+}
+
+// ConnectWith creates a new client of the pipeline selected by
+// cfg.PublisherPipeline.
+func (g *Group) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
+	if cfg.PublisherPipeline == "" {
+		return g.def.ConnectWith(cfg)
+	}
+	p, ok := g.named[cfg.PublisherPipeline]
+	if !ok {
+		return nil, fmt.Errorf("unknown publisher pipeline %q", cfg.PublisherPipeline)
+	}
+	cfg.PublisherPipeline = ""
+	return p.ConnectWith(cfg)
+}
+
+// OutputReloader returns the reloader of the output of the default pipeline,
+// which also reloads the output of the named pipelines using it. The named
+// pipelines with their own output are not reloaded.
+func (g *Group) OutputReloader() OutputReloader {
+	reloaders := []OutputReloader{g.def.OutputReloader()}
+	for _, p := range g.followers {
+		reloaders = append(reloaders, p.OutputReloader())
+	}
+	return groupReloader(reloaders)
+}
+
+// groupReloader reloads the outputs of several pipelines with the same
+// configuration. Each pipeline gets its own output.
+type groupReloader []OutputReloader
+
+func (r groupReloader) Reload(
+	cfg *reload.ConfigWithMeta,
+	factory func(outputs.Observer, conf.Namespace) (outputs.Group, error),
+) error {
+	var errs []error
+	for _, reloader := range r {
+		if err := reloader.Reload(cfg, factory); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Disconnect stops all the pipelines concurrently, so that they all get the
+// same time to flush their pending events.
+func (g *Group) Disconnect(ctx context.Context) error {
+	var wg sync.WaitGroup
+	wg.Go(func() { _ = g.def.Disconnect(ctx) })
+	for _, p := range g.named {
+		wg.Go(func() { _ = p.Disconnect(ctx) })
+	}
+	wg.Wait()
+	return nil
+}
+
+// Names returns the sorted names of the named pipelines.
+func (g *Group) Names() []string {
+	names := make([]string, 0, len(g.named))
+	for name := range g.named {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadNamed creates the named pipelines of the configuration. The metrics
+// of each pipeline are reported in a registry named after it. makeOutput
+// creates the output of a pipeline from its output configuration, which is
+// unset if the pipeline uses the output of the Beat.
+func LoadNamed(
+	beatInfo beat.Info,
+	monitors Monitors,
+	configs []NamedConfig,
+	makeOutput func(conf.Namespace) func(outputs.Observer) (string, outputs.Group, error),
+	settings Settings,
+) (map[string]*Pipeline, error) {
+	named := make(map[string]*Pipeline, len(configs))
+	closeAll := func() {
+		for _, p := range named {
+			_ = p.Disconnect(context.Background())
+		}
+	}
+	for _, c := range configs {
+		queue, err := namedQueueConfig(beatInfo, c)
+		if err != nil {
+			closeAll()
+			return nil, err
+		}
+
+		m := monitors
+		m.Telemetry = nil
+		if monitors.Metrics != nil {
+			m.Metrics = monitors.Metrics.GetOrCreateRegistry("publisher_pipelines").GetOrCreateRegistry(c.Name)
+		}
+		if monitors.Logger != nil {
+			m.Logger = monitors.Logger.With("publisher_pipeline", c.Name)
+		}
+
+		out, err := loadOutput(m, makeOutput(c.Output))
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("error loading output of publisher pipeline %q: %w", c.Name, err)
+		}
+		p, err := New(beatInfo, m, queue, out, settings)
+		if err != nil {
+			closeAll()
+			return nil, fmt.Errorf("error creating publisher pipeline %q: %w", c.Name, err)
+		}
+		named[c.Name] = p
+	}
+	return named, nil
+}
+
+// namedQueueConfig returns the queue configuration of a named pipeline. Disk
+// queues without an explicit path get their own directory, they can't share
+// the one of the default pipeline.
+func namedQueueConfig(beatInfo beat.Info, c NamedConfig) (conf.Namespace, error) {
+	if c.Queue.Name() != diskqueue.QueueType || c.Queue.Config().HasField("path") {
+		return c.Queue, nil
+	}
+	p := beatInfo.Paths
+	if p == nil {
+		p = paths.Paths
+	}
+	disk := conf.NewConfig()
+	if err := disk.Merge(c.Queue.Config()); err != nil {
+		return conf.Namespace{}, err
+	}
+	if err := disk.SetString("path", -1, p.Resolve(paths.Data, "diskqueue_"+c.Name)); err != nil {
+		return conf.Namespace{}, err
+	}
+	cfg := conf.NewConfig()
+	if err := cfg.SetChild(diskqueue.QueueType, -1, disk); err != nil {
+		return conf.Namespace{}, err
+	}
+	var queue conf.Namespace
+	if err := cfg.Unpack(&queue); err != nil {
+		return conf.Namespace{}, err
+	}
+	return queue, nil
+}
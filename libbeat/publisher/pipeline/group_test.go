@@ -0,0 +1,214 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/reload"
+	"github.com/elastic/beats/v7/libbeat/outputs"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+// makeCountingQueue returns a queue that counts the published events and
+// records that it was closed.
+func makeCountingQueue(published *atomic.Int64, closed *atomic.Bool) queue.Queue[publisher.Event] {
+	return &testQueue{
+		close: func(bool) error {
+			closed.Store(true)
+			return nil
+		},
+		producer: func(queue.ProducerConfig) queue.Producer[publisher.Event] {
+			return &testProducer{
+				publish: func(bool, publisher.Event) (queue.EntryID, bool) {
+					return queue.EntryID(published.Add(1)), true
+				},
+			}
+		},
+	}
+}
+
+func TestGroup(t *testing.T) {
+	var (
+		defPublished, secPublished atomic.Int64
+		defClosed, secClosed       atomic.Bool
+	)
+	group := NewGroup(
+		makePipeline(t, Settings{}, makeCountingQueue(&defPublished, &defClosed)),
+		map[string]*Pipeline{
+			"security": makePipeline(t, Settings{}, makeCountingQueue(&secPublished, &secClosed)),
+		},
+		nil,
+	)
+	assert.Equal(t, []string{"security"}, group.Names())
+
+	publish := func(cfg beat.ClientConfig, n int) {
+		t.Helper()
+		client, err := group.ConnectWith(cfg)
+		require.NoError(t, err)
+		for i := 0; i < n; i++ {
+			client.Publish(beat.Event{Fields: mapstr.M{"i": i}})
+		}
+		require.NoError(t, client.Close())
+	}
+	publish(beat.ClientConfig{}, 3)
+	publish(beat.ClientConfig{PublisherPipeline: "security"}, 2)
+	assert.Equal(t, int64(3), defPublished.Load())
+	assert.Equal(t, int64(2), secPublished.Load())
+
+	_, err := group.ConnectWith(beat.ClientConfig{PublisherPipeline: "debug"})
+	assert.ErrorContains(t, err, `unknown publisher pipeline "debug"`)
+
+	// Without a group, the clients selecting a named pipeline are rejected.
+	_, err = group.Default().ConnectWith(beat.ClientConfig{PublisherPipeline: "security"})
+	assert.ErrorContains(t, err, "no publisher_pipelines are configured")
+
+	require.NoError(t, group.Disconnect(t.Context()))
+	assert.True(t, defClosed.Load())
+	assert.True(t, secClosed.Load())
+}
+
+type countingReloader struct {
+	calls int
+	err   error
+}
+
+func (r *countingReloader) Reload(*reload.ConfigWithMeta, func(outputs.Observer, conf.Namespace) (outputs.Group, error)) error {
+	r.calls++
+	return r.err
+}
+
+func TestGroupOutputReloader(t *testing.T) {
+	var (
+		published atomic.Int64
+		closed    atomic.Bool
+	)
+	named := map[string]*Pipeline{
+		"security": makePipeline(t, Settings{}, makeCountingQueue(&published, &closed)),
+		"audit":    makePipeline(t, Settings{}, makeCountingQueue(&published, &closed)),
+	}
+	var own conf.Namespace
+	require.NoError(t, conf.MustNewConfigFrom(map[string]interface{}{"console": map[string]interface{}{}}).Unpack(&own))
+	group := NewGroup(makePipeline(t, Settings{}, makeCountingQueue(&published, &closed)), named, []NamedConfig{
+		{Name: "security"},
+		{Name: "audit", Output: own},
+	})
+	assert.Equal(t, []*Pipeline{named["security"]}, group.followers, "only the pipelines using the output of the Beat follow its reloads")
+	require.NoError(t, group.Disconnect(t.Context()))
+
+	// All the outputs are reloaded, even if one fails.
+	failing, ok := &countingReloader{err: errors.New("invalid output")}, &countingReloader{}
+	err := groupReloader{failing, ok}.Reload(nil, nil)
+	assert.ErrorContains(t, err, "invalid output")
+	assert.Equal(t, 1, failing.calls)
+	assert.Equal(t, 1, ok.calls)
+}
+
+func TestPipelinesConfig(t *testing.T) {
+	tests := map[string]struct {
+		config  map[string]interface{}
+		wantErr string
+	}{
+		"valid": {
+			config: map[string]interface{}{
+				"publisher_pipelines": []map[string]interface{}{
+					{"name": "security", "queue.mem.events": 512},
+					{"name": "debug", "output.console.enabled": true},
+				},
+			},
+		},
+		"missing name": {
+			config: map[string]interface{}{
+				"publisher_pipelines": []map[string]interface{}{{"queue.mem.events": 512}},
+			},
+			wantErr: "missing required field",
+		},
+		"invalid name": {
+			config: map[string]interface{}{
+				"publisher_pipelines": []map[string]interface{}{{"name": "Security Logs"}},
+			},
+			wantErr: "invalid publisher pipeline name",
+		},
+		"duplicate name": {
+			config: map[string]interface{}{
+				"publisher_pipelines": []map[string]interface{}{{"name": "security"}, {"name": "security"}},
+			},
+			wantErr: "duplicate publisher pipeline name",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var c Config
+			err := conf.MustNewConfigFrom(tc.config).Unpack(&c)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, c.Pipelines, 2)
+			assert.Equal(t, "mem", c.Pipelines[0].Queue.Name())
+			assert.False(t, c.Pipelines[0].Output.IsSet())
+			assert.Equal(t, "console", c.Pipelines[1].Output.Name())
+		})
+	}
+}
+
+func TestNamedQueueConfig(t *testing.T) {
+	info := beat.Info{Paths: &paths.Path{Data: "/var/lib/beat"}}
+	unpack := func(cfg map[string]interface{}) NamedConfig {
+		t.Helper()
+		var c NamedConfig
+		require.NoError(t, conf.MustNewConfigFrom(cfg).Unpack(&c))
+		return c
+	}
+
+	// Disk queues get their own directory.
+	queue, err := namedQueueConfig(info, unpack(map[string]interface{}{
+		"name":                    "security",
+		"queue.disk.max_size":     "1GB",
+		"queue.disk.segment_size": "100MB",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "disk", queue.Name())
+	path, err := queue.Config().String("path", -1)
+	require.NoError(t, err)
+	assert.Equal(t, info.Paths.Resolve(paths.Data, "diskqueue_security"), path)
+	size, err := queue.Config().String("max_size", -1)
+	require.NoError(t, err)
+	assert.Equal(t, "1GB", size)
+
+	// An explicit path is kept.
+	queue, err = namedQueueConfig(info, unpack(map[string]interface{}{
+		"name":            "security",
+		"queue.disk.path": "/mnt/queue",
+	}))
+	require.NoError(t, err)
+	path, err = queue.Config().String("path", -1)
+	require.NoError(t, err)
+	assert.Equal(t, "/mnt/queue", path)
+}
@@ -0,0 +1,171 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+)
+
+// heldEvents is the event listener of a client whose processors hold events
+// back to release them later, see processors.ErrEventHeld. A held event is
+// reported to the listener of the client as published when it is held, and
+// acknowledged once it is released and acknowledged by the queue. The events
+// published after it are acknowledged only then, so that the state of the
+// input doesn't advance past a held event.
+type heldEvents struct {
+	listener beat.EventListener
+
+	mu sync.Mutex
+	// order are the events published to the listener not acknowledged yet,
+	// in the order they were reported to it.
+	order []*heldSlot
+	// queue are the events in the queue not acknowledged yet, in the order
+	// they were published to it.
+	queue []*heldSlot
+	// held are the events held back by the processors, by their private.
+	held map[*heldPrivate]*heldSlot
+}
+
+type heldSlot struct {
+	acked bool
+}
+
+// heldPrivate replaces the Private field of the events run through the
+// processors, to recognize the held events when they are released.
+type heldPrivate struct {
+	owner   *heldEvents
+	private interface{}
+}
+
+var _ beat.EventListener = (*heldEvents)(nil)
+
+func newHeldEvents(listener beat.EventListener) *heldEvents {
+	return &heldEvents{
+		listener: listener,
+		held:     make(map[*heldPrivate]*heldSlot),
+	}
+}
+
+// wrap replaces the Private field of the event before it is run through the
+// processors.
+func (h *heldEvents) wrap(e *beat.Event) {
+	e.Private = &heldPrivate{owner: h, private: e.Private}
+}
+
+// unwrap restores the Private field of the event, and returns the private
+// set by wrap, nil if the event has none. The Private field of the events
+// wrapped by another client, whose processors are shared with this client,
+// is cleared.
+func (h *heldEvents) unwrap(e *beat.Event) *heldPrivate {
+	p, ok := e.Private.(*heldPrivate)
+	if !ok {
+		return nil
+	}
+	if p.owner != h {
+		e.Private = nil
+		return nil
+	}
+	e.Private = p.private
+	return p
+}
+
+// hold reports an event held back by the processors to the listener as
+// published.
+func (h *heldEvents) hold(e beat.Event) {
+	p := h.unwrap(&e)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if p == nil {
+		// It can't be recognized when it is released.
+		h.listener.AddEvent(e, false)
+		return
+	}
+	slot := &heldSlot{}
+	h.order = append(h.order, slot)
+	h.held[p] = slot
+	h.listener.AddEvent(e, true)
+}
+
+func (h *heldEvents) AddEvent(e beat.Event, published bool) {
+	p := h.unwrap(&e)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if !published {
+		h.listener.AddEvent(e, false)
+		return
+	}
+	if slot, ok := h.held[p]; ok {
+		// A held event is released, it has been reported already.
+		delete(h.held, p)
+		h.queue = append(h.queue, slot)
+		return
+	}
+	slot := &heldSlot{}
+	h.order = append(h.order, slot)
+	h.queue = append(h.queue, slot)
+	h.listener.AddEvent(e, true)
+}
+
+func (h *heldEvents) ACKEvents(n int) {
+	h.mu.Lock()
+	n = min(n, len(h.queue))
+	for _, slot := range h.queue[:n] {
+		slot.acked = true
+	}
+	h.queue = h.queue[n:]
+	acked := h.ackedLocked()
+	h.mu.Unlock()
+
+	if acked > 0 {
+		h.listener.ACKEvents(acked)
+	}
+}
+
+// dropped resolves the last event published to the queue, which dropped it.
+func (h *heldEvents) dropped() {
+	h.mu.Lock()
+	if len(h.queue) == 0 {
+		h.mu.Unlock()
+		return
+	}
+	h.queue[len(h.queue)-1].acked = true
+	h.queue = h.queue[:len(h.queue)-1]
+	acked := h.ackedLocked()
+	h.mu.Unlock()
+
+	if acked > 0 {
+		h.listener.ACKEvents(acked)
+	}
+}
+
+// ackedLocked removes the acknowledged events at the front of order and
+// returns their number.
+func (h *heldEvents) ackedLocked() int {
+	n := 0
+	for n < len(h.order) && h.order[n].acked {
+		n++
+	}
+	h.order = h.order[n:]
+	return n
+}
+
+func (h *heldEvents) ClientClosed() {
+	h.listener.ClientClosed()
+}
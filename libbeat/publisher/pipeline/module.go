@@ -93,6 +93,10 @@ func LoadWithSettings(
 
 	name := beatInfo.Name
 
+	if settings.Trace == (TraceConfig{}) {
+		settings.Trace = config.Trace
+	}
+
 	out, err := loadOutput(monitors, makeOutput)
 	if err != nil {
 		return nil, err
@@ -53,6 +53,10 @@ type processOutputController struct {
 	queueLock       sync.Mutex
 	pendingRequests []producerRequest
 
+	// queueFill tracks the fill level of the queue, which drives the
+	// scaling of the output workers.
+	queueFill *fillObserver
+
 	// This factory will be used to create the queue when needed, unless
 	// it is overridden by output configuration when outputController.Set
 	// is called.
@@ -74,6 +78,10 @@ type processOutputController struct {
 	// configuration reloading which doesn't have access to this
 	// setting.
 	inputQueueSize int
+
+	// tracer is passed to the batches read from the queue to log the
+	// transit of the traced events.
+	tracer *eventTracer
 }
 
 type producerRequest struct {
@@ -140,10 +148,21 @@ func (c *processOutputController) Set(outGrp outputs.Group) {
 
 	// create new output group with the shared work queue
 	clients := outGrp.Clients
-	c.workers = make([]outputWorker, len(clients))
 	logger := c.beat.Logger.Named("publisher_pipeline_output")
-	for i, client := range clients {
-		c.workers[i] = makeClientWorker(c.workerChan, client, logger, c.monitors.Tracer)
+	if outGrp.Autoscale != nil && outGrp.Autoscale.MinWorkers < len(clients) && c.queueFill != nil {
+		// The workers are started and stopped by the autoscaler.
+		autoscaler := newWorkerAutoscaler(*outGrp.Autoscale, clients,
+			func(client outputs.Client, latency *latencyTracker) scalableWorker {
+				return startClientWorker(c.workerChan, client, logger, c.monitors.Tracer, latency)
+			},
+			c.queueFill.fill, c.autoscaleRegistry(), logger)
+		autoscaler.run()
+		c.workers = []outputWorker{autoscaler}
+	} else {
+		c.workers = make([]outputWorker, len(clients))
+		for i, client := range clients {
+			c.workers[i] = makeClientWorker(c.workerChan, client, logger, c.monitors.Tracer)
+		}
 	}
 
 	targetChan := c.workerChan
@@ -164,9 +183,26 @@ func (c *processOutputController) Set(outGrp outputs.Group) {
 			ch:         targetChan,
 			batchSize:  outGrp.BatchSize,
 			timeToLive: outGrp.Retry + 1,
+			tracer:     c.tracer,
 		})
 }
 
+// autoscaleRegistry returns the registry of the metrics of the scaling of the
+// output workers, under "output.workers".
+func (c *processOutputController) autoscaleRegistry() *monitoring.Registry {
+	if c.monitors.Metrics == nil {
+		return nil
+	}
+	outputMetrics := c.monitors.Metrics.GetOrCreateRegistry("output")
+	if reg := outputMetrics.GetRegistry("workers"); reg != nil {
+		if err := reg.Clear(); err != nil {
+			return nil
+		}
+		return reg
+	}
+	return outputMetrics.GetOrCreateRegistry("workers")
+}
+
 // Reload the output
 func (c *processOutputController) Reload(
 	cfg *reload.ConfigWithMeta,
@@ -279,7 +315,8 @@ func (c *processOutputController) createQueueIfNeeded(outGrp outputs.Group) {
 	if c.monitors.Metrics != nil {
 		pipelineMetrics = c.monitors.Metrics.GetOrCreateRegistry("pipeline")
 	}
-	queueObserver := queue.NewQueueObserver(pipelineMetrics)
+	c.queueFill = newFillObserver(queue.NewQueueObserver(pipelineMetrics))
+	var queueObserver queue.Observer = c.queueFill
 
 	queue, err := factory(logger, queueObserver, c.inputQueueSize, outGrp.EncoderFactory)
 	if err != nil {
@@ -77,6 +77,10 @@ type Pipeline struct {
 	closeOnce sync.Once
 
 	processors processing.Supporter
+
+	// tracer logs the transit of a sample of the events, nil if tracing is
+	// disabled.
+	tracer *eventTracer
 }
 
 // Settings is used to pass additional settings to a newly created pipeline instance.
@@ -91,6 +95,10 @@ type Settings struct {
 	Processors processing.Supporter
 
 	InputQueueSize int
+
+	// Trace configures the delivery tracing of a sample of the events.
+	// This field has no effect when running as a Beats receiver.
+	Trace TraceConfig
 }
 
 // WaitCloseMode enumerates the possible behaviors of WaitClose in a pipeline.
@@ -157,6 +165,7 @@ func New(
 		observer:         nilObserver,
 		waitCloseTimeout: settings.WaitClose,
 		processors:       settings.Processors,
+		tracer:           newEventTracer(settings.Trace, monitors.Logger),
 	}
 
 	p.forceCloseQueue = settings.WaitCloseMode == WaitOnPipelineCloseThenForce
@@ -181,6 +190,7 @@ func New(
 	if err != nil {
 		return nil, err
 	}
+	outputController.tracer = p.tracer
 	outputController.Set(out)
 	p.outputController = outputController
 
@@ -231,18 +241,24 @@ func NewForReceiver(
 // Disconnect stops the pipeline, outputs and queue.
 // If WaitClose with WaitOnPipelineClose mode is configured, Disconnect will block
 // for a duration of WaitClose, if there are still active events in the pipeline.
+// If ctx has a deadline, Disconnect blocks until the deadline instead of WaitClose,
+// which allows callers to flush pending events within a known time frame.
 // Note: clients will no longer accept new Publish calls once Disconnect is started,
 // and will no longer receive event acknowledgments once Disconnect returns.
 func (p *Pipeline) Disconnect(ctx context.Context) error {
-	// Here context does not do anything for now but it will be the responsibility of the beater to determine how long to wait before full disconnection
-	// See issue: https://github.com/elastic/beats/issues/49794
 	p.closeOnce.Do(func() {
 		log := p.monitors.Logger
 
 		log.Debug("close pipeline")
 
 		// Note: active clients are not closed / disconnected.
-		timeoutCtx, cancel := context.WithTimeout(context.Background(), p.waitCloseTimeout)
+		var timeoutCtx context.Context
+		var cancel context.CancelFunc
+		if _, ok := ctx.Deadline(); ok {
+			timeoutCtx, cancel = context.WithCancel(ctx)
+		} else {
+			timeoutCtx, cancel = context.WithTimeout(context.Background(), p.waitCloseTimeout)
+		}
 		defer cancel()
 		p.outputController.waitClose(timeoutCtx, p.forceCloseQueue)
 
@@ -272,6 +288,11 @@ func (p *Pipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
 	if err != nil {
 		return nil, err
 	}
+	if cfg.PublisherPipeline != "" {
+		// The named pipelines are selected by a Group, which is only
+		// created when publisher_pipelines are configured.
+		return nil, fmt.Errorf("unknown publisher pipeline %q, no publisher_pipelines are configured", cfg.PublisherPipeline)
+	}
 
 	switch cfg.PublishMode {
 	case beat.GuaranteedSend:
@@ -299,6 +320,7 @@ func (p *Pipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
 		eventFlags:     eventFlags,
 		canDrop:        canDrop,
 		observer:       p.observer,
+		tracer:         p.tracer,
 	}
 
 	client.isOpen.Store(true)
@@ -315,6 +337,14 @@ func (p *Pipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
 		}
 	}
 
+	if client.expires() {
+		if ackHandler == nil {
+			ackHandler = acker.Nil()
+		}
+		client.held = newHeldEvents(ackHandler)
+		ackHandler = client.held
+	}
+
 	producerCfg := queue.ProducerConfig{
 		ACK: func(count int) {
 			client.observer.eventsACKed(count)
@@ -322,6 +352,7 @@ func (p *Pipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
 				ackHandler.ACKEvents(count)
 			}
 		},
+		HighPriority: cfg.Priority == beat.HighPriority,
 	}
 
 	if ackHandler == nil {
@@ -337,6 +368,11 @@ func (p *Pipeline) ConnectWith(cfg beat.ClientConfig) (beat.Client, error) {
 		return nil, fmt.Errorf("client failed to connect because the pipeline is shutting down")
 	}
 
+	if client.held != nil {
+		client.expireDone = make(chan struct{})
+		go client.expireProcessors(expireInterval)
+	}
+
 	p.observer.clientConnected()
 	return client, nil
 }
@@ -36,6 +36,7 @@ type queueReaderRequest struct {
 	retryer    retryer
 	batchSize  int
 	timeToLive int
+	tracer     *eventTracer
 }
 
 func makeQueueReader() queueReader {
@@ -58,7 +59,7 @@ func (qr *queueReader) run(logger *logp.Logger) {
 		queueBatch, _ := req.queue.Get(req.batchSize)
 		var batch *ttlBatch
 		if queueBatch != nil {
-			batch = newBatch(req.retryer, queueBatch, req.timeToLive)
+			batch = newBatch(req.retryer, queueBatch, req.timeToLive, req.tracer)
 		}
 		select {
 		case qr.resp <- batch:
@@ -0,0 +1,118 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// TraceConfig configures the delivery tracing of a sample of the published
+// events. Each sampled event is assigned a trace ID when the input publishes
+// it, and its transit through the queue and the outputs is logged with that
+// ID, to find out where an event was delayed or lost.
+type TraceConfig struct {
+	// SampleRate is the fraction of the events to trace, between 0 and 1.
+	// Tracing is disabled if it is 0.
+	SampleRate float64 `config:"sample_rate" validate:"min=0,max=1"`
+}
+
+// eventTracer logs the transit of the traced events through the pipeline.
+// A nil eventTracer traces nothing, so callers don't need to check if
+// tracing is enabled.
+type eventTracer struct {
+	logger     *logp.Logger
+	sampleRate float64
+}
+
+func newEventTracer(cfg TraceConfig, logger *logp.Logger) *eventTracer {
+	if cfg.SampleRate <= 0 {
+		return nil
+	}
+	logger = logger.Named("event_trace")
+	logger.Infof("Tracing the delivery of %v%% of the events.", cfg.SampleRate*100)
+	return &eventTracer{logger: logger, sampleRate: cfg.SampleRate}
+}
+
+// sample returns a new trace ID if the event must be traced, and an empty
+// string otherwise.
+func (t *eventTracer) sample() string {
+	if t == nil || rand.Float64() >= t.sampleRate { //nolint:gosec // Sampling doesn't need a secure random number.
+		return ""
+	}
+	return fmt.Sprintf("%016x", rand.Uint64()) //nolint:gosec // Trace IDs don't need to be secure.
+}
+
+// received logs that an input published an event.
+func (t *eventTracer) received(traceID string, e beat.Event) {
+	if t == nil || traceID == "" {
+		return
+	}
+	dataset, _ := e.Fields.GetValue("data_stream.dataset")
+	if dataset == nil {
+		dataset, _ = e.Fields.GetValue("event.dataset")
+	}
+	t.logger.Infow("Event received from the input",
+		"trace.id", traceID,
+		"event.dataset", dataset,
+		"event.timestamp", e.Timestamp)
+}
+
+// filtered logs that the processors dropped an event.
+func (t *eventTracer) filtered(traceID string) {
+	if t == nil || traceID == "" {
+		return
+	}
+	t.logger.Infow("Event dropped by the processors", "trace.id", traceID)
+}
+
+// held logs that the processors held an event back, to release it later.
+func (t *eventTracer) held(traceID string) {
+	if t == nil || traceID == "" {
+		return
+	}
+	t.logger.Infow("Event held back by the processors", "trace.id", traceID)
+}
+
+// queued logs whether an event was added to the queue.
+func (t *eventTracer) queued(traceID string, published bool) {
+	if t == nil || traceID == "" {
+		return
+	}
+	if published {
+		t.logger.Infow("Event added to the queue", "trace.id", traceID)
+	} else {
+		t.logger.Infow("Event dropped, the queue is full or closing", "trace.id", traceID)
+	}
+}
+
+// batch logs a message for each traced event of a batch.
+func (t *eventTracer) batch(events []publisher.Event, msg string, keysAndValues ...interface{}) {
+	if t == nil {
+		return
+	}
+	for _, e := range events {
+		if e.TraceID != "" {
+			t.logger.Infow(msg, append([]interface{}{"trace.id", e.TraceID, "batch.size", len(events)}, keysAndValues...)...)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/publisher"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+func TestEventTracerSampling(t *testing.T) {
+	logger := logptest.NewTestingLogger(t, "")
+
+	assert.Nil(t, newEventTracer(TraceConfig{}, logger), "tracing must be disabled by default")
+
+	var disabled *eventTracer
+	assert.Empty(t, disabled.sample())
+
+	tracer := newEventTracer(TraceConfig{SampleRate: 1}, logger)
+	require.NotNil(t, tracer)
+	id := tracer.sample()
+	assert.Len(t, id, 16)
+	assert.NotEqual(t, id, tracer.sample())
+}
+
+func TestAcknowledgedEvents(t *testing.T) {
+	events := []publisher.Event{
+		{TraceID: "a"},
+		{},
+		{TraceID: "b"},
+		{TraceID: "c"},
+	}
+	retried := []publisher.Event{events[1], events[2]}
+
+	assert.Equal(t, []publisher.Event{events[0], events[3]}, acknowledgedEvents(events, retried))
+	assert.Empty(t, acknowledgedEvents(events, events))
+}
@@ -54,6 +54,9 @@ type ttlBatch struct {
 	// all split batches descending from the same original batch will
 	// point to the same metadata.
 	split *batchSplitData
+
+	// tracer logs the transit of the traced events of the batch.
+	tracer *eventTracer
 }
 
 type batchSplitData struct {
@@ -79,7 +82,7 @@ type batchSplitData struct {
 	anyReleased atomic.Bool
 }
 
-func newBatch(retryer retryer, original queue.Batch[publisher.Event], ttl int) *ttlBatch {
+func newBatch(retryer retryer, original queue.Batch[publisher.Event], ttl int, tracer *eventTracer) *ttlBatch {
 	if original == nil {
 		panic("empty batch")
 	}
@@ -97,7 +100,9 @@ func newBatch(retryer retryer, original queue.Batch[publisher.Event], ttl int) *
 		retryer: retryer,
 		ttl:     ttl,
 		events:  events,
+		tracer:  tracer,
 	}
+	tracer.batch(events, "Event read from the queue by the output")
 	return b
 }
 
@@ -112,6 +117,7 @@ func newBatch(retryer retryer, original queue.Batch[publisher.Event], ttl int) *
 // was Released (preserving at-least-once for partially abandoned
 // splits).
 func (b *ttlBatch) Release() {
+	b.tracer.batch(b.events, "Event abandoned on shutdown, it will be sent again if it is in the disk queue")
 	b.events = nil
 	if b.release != nil {
 		b.release()
@@ -123,12 +129,14 @@ func (b *ttlBatch) Events() []publisher.Event {
 }
 
 func (b *ttlBatch) ACK() {
+	b.tracer.batch(b.events, "Event acknowledged by the output")
 	// Help the garbage collector clean up the event data a little faster
 	b.events = nil
 	b.done()
 }
 
 func (b *ttlBatch) Drop() {
+	b.tracer.batch(b.events, "Event dropped by the output")
 	// Help the garbage collector clean up the event data a little faster
 	b.events = nil
 	b.done()
@@ -153,6 +161,7 @@ func (b *ttlBatch) SplitRetry() bool {
 		// Initialize to the number of events in the original batch
 		splitData.outstandingEvents.Add(int64(len(b.events)))
 	}
+	b.tracer.batch(b.events, "Event batch too large for the output, splitting it")
 	splitIndex := len(b.events) / 2
 	events1 := b.events[:splitIndex]
 	events2 := b.events[splitIndex:]
@@ -163,6 +172,7 @@ func (b *ttlBatch) SplitRetry() bool {
 		retryer: b.retryer,
 		ttl:     b.ttl,
 		split:   splitData,
+		tracer:  b.tracer,
 	}, false)
 	b.retryer.retry(&ttlBatch{
 		events:  events2,
@@ -171,6 +181,7 @@ func (b *ttlBatch) SplitRetry() bool {
 		retryer: b.retryer,
 		ttl:     b.ttl,
 		split:   splitData,
+		tracer:  b.tracer,
 	}, false)
 	return true
 }
@@ -218,6 +229,7 @@ func (splitData *batchSplitData) completeOriginal() {
 }
 
 func (b *ttlBatch) Retry() {
+	b.tracer.batch(b.events, "Event failed to be sent, retrying", "batch.ttl", b.ttl)
 	b.retryer.retry(b, true)
 }
 
@@ -226,10 +238,31 @@ func (b *ttlBatch) Cancelled() {
 }
 
 func (b *ttlBatch) RetryEvents(events []publisher.Event) {
+	if b.tracer != nil {
+		b.tracer.batch(acknowledgedEvents(b.events, events), "Event acknowledged by the output")
+	}
 	b.events = events
 	b.Retry()
 }
 
+// acknowledgedEvents returns the traced events of a batch that are not
+// retried.
+func acknowledgedEvents(events, retried []publisher.Event) []publisher.Event {
+	retriedIDs := map[string]bool{}
+	for _, e := range retried {
+		if e.TraceID != "" {
+			retriedIDs[e.TraceID] = true
+		}
+	}
+	var acked []publisher.Event
+	for _, e := range events {
+		if e.TraceID != "" && !retriedIDs[e.TraceID] {
+			acked = append(acked, e)
+		}
+	}
+	return acked
+}
+
 // reduceTTL reduces the time to live for all events that have no 'guaranteed'
 // sending requirements.  reduceTTL returns true if the batch is still alive.
 func (b *ttlBatch) reduceTTL() bool {
@@ -247,6 +280,8 @@ func (b *ttlBatch) reduceTTL() bool {
 	for _, event := range b.events {
 		if event.Guaranteed() {
 			events = append(events, event)
+		} else if event.TraceID != "" {
+			b.tracer.batch([]publisher.Event{event}, "Event dropped after exhausting its retries")
 		}
 	}
 	b.events = events
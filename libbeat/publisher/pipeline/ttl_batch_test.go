@@ -234,7 +234,7 @@ func TestBatchCallsDoneAndFreesEvents(t *testing.T) {
 
 func TestNewBatchFreesEvents(t *testing.T) {
 	queueBatch := &mockQueueBatch{}
-	_ = newBatch(nil, queueBatch, 0)
+	_ = newBatch(nil, queueBatch, 0, nil)
 	assert.Equal(t, 1, queueBatch.freeEntriesCalled, "Creating a new ttlBatch should call FreeEntries on the underlying queue.Batch")
 }
 
@@ -261,7 +261,7 @@ func TestTTLBatchRetryDoesNotReleaseSlots(t *testing.T) {
 	require.Equal(t, 4, queueBatch.Count())
 
 	retryer := &mockRetryer{}
-	batch := newBatch(retryer, queueBatch, 3) // ttl=3
+	batch := newBatch(retryer, queueBatch, 3, nil) // ttl=3
 
 	// Retry several times — TTL decreases via reduceTTL but slots remain
 	// reserved because the queue.Batch's Done has not been invoked.
@@ -293,7 +293,7 @@ func TestTTLBatchDropReleasesSlots(t *testing.T) {
 	queueBatch, err := q.Get(0)
 	require.NoError(t, err)
 
-	batch := newBatch(&mockRetryer{}, queueBatch, 1)
+	batch := newBatch(&mockRetryer{}, queueBatch, 1, nil)
 	batch.Drop()
 	assert.Equal(t, 2, pool.Available(), "slots must be released after Drop")
 }
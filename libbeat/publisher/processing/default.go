@@ -106,6 +106,8 @@ func MakeDefaultSupport(
 			mapstr.EventMetadata `config:",inline"`      // Fields and tags to add to each event.
 			Processors           processors.PluginConfig `config:"processors"`
 			TimeSeries           bool                    `config:"timeseries.enabled"`
+			Routes               []routeConfig           `config:"routes"`
+			FieldProjection      projectionConfig        `config:"field_projection"`
 		}{}
 		if err := beatCfg.Unpack(&cfg); err != nil {
 			return nil, err
@@ -128,6 +130,26 @@ func MakeDefaultSupport(
 			return nil, fmt.Errorf("error initializing processors: %w", err)
 		}
 
+		// routes are evaluated after all global processors, so that they can
+		// use fields added or modified by them.
+		if len(cfg.Routes) > 0 {
+			router, err := newRouter(cfg.Routes, log)
+			if err != nil {
+				return nil, fmt.Errorf("error initializing routes: %w", err)
+			}
+			processors.AddProcessor(router)
+		}
+
+		// the field projection is applied last, so that the datasets set by
+		// routes select the fields kept.
+		if len(cfg.FieldProjection.Datasets) > 0 {
+			projector, err := newProjector(cfg.FieldProjection, log)
+			if err != nil {
+				return nil, fmt.Errorf("error initializing field projection: %w", err)
+			}
+			processors.AddProcessor(projector)
+		}
+
 		return newBuilder(info, log, processors, cfg.EventMetadata, modifiers, !normalize, cfg.TimeSeries)
 	}
 }
@@ -387,8 +409,8 @@ func (b *builder) Create(cfg beat.ProcessingConfig, drop bool) (beat.Processor,
 			return nil, fmt.Errorf("failed setting paths for global processors: %w", err)
 		}
 
-		// Add the global pipeline as a function processor, so clients cannot close it
-		processors.add(newProcessor(b.processors.title, b.processors.Run))
+		// Add the global pipeline as a shared group, so clients cannot close it
+		processors.add(sharedGroup{b.processors})
 	}
 
 	// setup 9: time series metadata
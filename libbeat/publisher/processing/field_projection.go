@@ -0,0 +1,235 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package processing
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// maxReportedFields bounds the number of dropped fields reported per dataset
+// in dry run mode.
+const maxReportedFields = 1000
+
+// projectionConfig configures the top-level `field_projection` section.
+type projectionConfig struct {
+	DryRun   bool                      `config:"dry_run"`
+	Datasets []datasetProjectionConfig `config:"datasets"`
+}
+
+// datasetProjectionConfig configures the fields kept for the events of the
+// datasets matching Dataset.
+type datasetProjectionConfig struct {
+	Dataset       string   `config:"dataset" validate:"required"`
+	IncludeFields []string `config:"include_fields"`
+	ExcludeFields []string `config:"exclude_fields"`
+}
+
+func (c *datasetProjectionConfig) Validate() error {
+	if len(c.IncludeFields) == 0 && len(c.ExcludeFields) == 0 {
+		return errors.New("field projection must define at least one of include_fields or exclude_fields")
+	}
+	return nil
+}
+
+type datasetProjection struct {
+	dataset *regexp.Regexp
+	include []*regexp.Regexp
+	exclude []*regexp.Regexp
+}
+
+// projector removes the fields of the events that are not allowed for their
+// dataset, to bound the number of fields indexed per dataset. The dataset of
+// an event is read from `data_stream.dataset`, or `event.dataset` if not set.
+// The first projection whose dataset pattern matches is applied. In dry run
+// mode the events are not modified, and the fields that would be dropped are
+// logged once per dataset.
+type projector struct {
+	log         *logp.Logger
+	dryRun      bool
+	projections []datasetProjection
+
+	mu       sync.Mutex
+	reported map[string]map[string]struct{}
+}
+
+func newProjector(cfg projectionConfig, log *logp.Logger) (*projector, error) {
+	p := &projector{
+		log:      log.Named("field_projection"),
+		dryRun:   cfg.DryRun,
+		reported: map[string]map[string]struct{}{},
+	}
+	for _, c := range cfg.Datasets {
+		dataset, err := compileFieldPattern(c.Dataset)
+		if err != nil {
+			return nil, fmt.Errorf("invalid dataset pattern %q: %w", c.Dataset, err)
+		}
+		proj := datasetProjection{dataset: dataset}
+		if proj.include, err = compileFieldPatterns(c.IncludeFields); err != nil {
+			return nil, fmt.Errorf("invalid include_fields of dataset %q: %w", c.Dataset, err)
+		}
+		if proj.exclude, err = compileFieldPatterns(c.ExcludeFields); err != nil {
+			return nil, fmt.Errorf("invalid exclude_fields of dataset %q: %w", c.Dataset, err)
+		}
+		p.projections = append(p.projections, proj)
+	}
+	return p, nil
+}
+
+// compileFieldPattern compiles a pattern where `*` matches any sequence of
+// characters, dots included.
+func compileFieldPattern(pattern string) (*regexp.Regexp, error) {
+	expr := strings.ReplaceAll(regexp.QuoteMeta(pattern), `\*`, `.*`)
+	return regexp.Compile("^" + expr + "$")
+}
+
+func compileFieldPatterns(patterns []string) ([]*regexp.Regexp, error) {
+	compiled := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := compileFieldPattern(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		compiled = append(compiled, re)
+	}
+	return compiled, nil
+}
+
+// matchField reports whether field, or one of the objects holding it, matches
+// one of the patterns.
+func matchField(patterns []*regexp.Regexp, field string) bool {
+	for _, re := range patterns {
+		for path := field; ; {
+			if re.MatchString(path) {
+				return true
+			}
+			i := strings.LastIndexByte(path, '.')
+			if i < 0 {
+				break
+			}
+			path = path[:i]
+		}
+	}
+	return false
+}
+
+// keep reports whether the field is kept by the projection. The data stream
+// fields are always kept, as they select the destination of the event.
+func (p *datasetProjection) keep(field string) bool {
+	if field == "data_stream" || strings.HasPrefix(field, "data_stream.") || field == "event.dataset" {
+		return true
+	}
+	if len(p.include) > 0 && !matchField(p.include, field) {
+		return false
+	}
+	return !matchField(p.exclude, field)
+}
+
+func eventDataset(event *beat.Event) string {
+	for _, key := range []string{"data_stream.dataset", "event.dataset"} {
+		if v, err := event.GetValue(key); err == nil {
+			if s, ok := v.(string); ok && s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+func (p *projector) Run(event *beat.Event) (*beat.Event, error) {
+	dataset := eventDataset(event)
+	for i := range p.projections {
+		proj := &p.projections[i]
+		if !proj.dataset.MatchString(dataset) {
+			continue
+		}
+		var dropped []string
+		p.project(proj, event.Fields, "", !p.dryRun, &dropped)
+		if p.dryRun && len(dropped) > 0 {
+			p.report(dataset, dropped)
+		}
+		break
+	}
+	return event, nil
+}
+
+// project collects the fields of m not kept by proj in dropped, and removes
+// them if remove is set. The objects left empty are removed too.
+func (p *projector) project(proj *datasetProjection, m mapstr.M, prefix string, remove bool, dropped *[]string) {
+	for key, value := range m {
+		field := prefix + key
+		var nested mapstr.M
+		switch v := value.(type) {
+		case mapstr.M:
+			nested = v
+		case map[string]interface{}:
+			nested = v
+		}
+		if len(nested) == 0 {
+			if !proj.keep(field) {
+				*dropped = append(*dropped, field)
+				if remove {
+					delete(m, key)
+				}
+			}
+			continue
+		}
+		p.project(proj, nested, field+".", remove, dropped)
+		if remove && len(nested) == 0 {
+			delete(m, key)
+		}
+	}
+}
+
+// report logs the fields of the dataset that would be dropped, the first time
+// they are seen.
+func (p *projector) report(dataset string, dropped []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	seen, ok := p.reported[dataset]
+	if !ok {
+		seen = map[string]struct{}{}
+		p.reported[dataset] = seen
+	}
+	var fields []string
+	for _, field := range dropped {
+		if _, ok := seen[field]; ok || len(seen) >= maxReportedFields {
+			continue
+		}
+		seen[field] = struct{}{}
+		fields = append(fields, field)
+	}
+	if len(fields) > 0 {
+		p.log.Infow("Fields would be dropped by the field projection", "dataset", dataset, "fields", fields)
+	}
+}
+
+func (p *projector) String() string {
+	mode := ""
+	if p.dryRun {
+		mode = ", dry_run"
+	}
+	return fmt.Sprintf("field_projection=[datasets=%d%s]", len(p.projections), mode)
+}
@@ -0,0 +1,164 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package processing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFieldProjection(t *testing.T) {
+	datasets := []mapstr.M{
+		{
+			"dataset":        "nginx.*",
+			"include_fields": []string{"message", "http", "url.*", "labels.env"},
+			"exclude_fields": []string{"http.request.headers"},
+		},
+		{
+			"dataset":        "*",
+			"exclude_fields": []string{"kubernetes.labels.*"},
+		},
+	}
+
+	cases := map[string]struct {
+		fields     mapstr.M
+		wantFields mapstr.M
+	}{
+		"allowlist and denylist": {
+			fields: mapstr.M{
+				"data_stream": mapstr.M{"dataset": "nginx.access", "namespace": "default"},
+				"message":     "GET /",
+				"http": mapstr.M{
+					"request": mapstr.M{
+						"method":  "GET",
+						"headers": mapstr.M{"accept": "*/*"},
+					},
+				},
+				"url":    mapstr.M{"path": "/", "query": "a=b"},
+				"labels": mapstr.M{"env": "prod", "team": "web"},
+				"user_agent": map[string]interface{}{
+					"original": "curl",
+				},
+				"event": mapstr.M{"dataset": "nginx.access", "original": "raw"},
+			},
+			wantFields: mapstr.M{
+				"data_stream": mapstr.M{"dataset": "nginx.access", "namespace": "default"},
+				"message":     "GET /",
+				"http":        mapstr.M{"request": mapstr.M{"method": "GET"}},
+				"url":         mapstr.M{"path": "/", "query": "a=b"},
+				"labels":      mapstr.M{"env": "prod"},
+				"event":       mapstr.M{"dataset": "nginx.access"},
+			},
+		},
+		"event dataset and catch-all": {
+			fields: mapstr.M{
+				"event":      mapstr.M{"dataset": "system.syslog"},
+				"kubernetes": mapstr.M{"labels": mapstr.M{"app": "web"}, "pod": mapstr.M{"name": "web-1"}},
+			},
+			wantFields: mapstr.M{
+				"event":      mapstr.M{"dataset": "system.syslog"},
+				"kubernetes": mapstr.M{"pod": mapstr.M{"name": "web-1"}},
+			},
+		},
+		"no dataset": {
+			fields:     mapstr.M{"kubernetes.labels.app": "web", "message": "hello"},
+			wantFields: mapstr.M{"message": "hello"},
+		},
+	}
+
+	cfg := config.MustNewConfigFrom(mapstr.M{"field_projection": mapstr.M{"datasets": datasets}})
+	s, err := MakeDefaultSupport(false, nil)(beat.Info{Paths: tmpPaths(t)}, logptest.NewTestingLogger(t, ""), cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, s.Close()) })
+
+	prog, err := s.Create(beat.ProcessingConfig{}, false)
+	require.NoError(t, err)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := prog.Run(&beat.Event{Fields: tc.fields.Clone()})
+			require.NoError(t, err)
+			require.NotNil(t, actual)
+			assert.Equal(t, tc.wantFields, actual.Fields)
+		})
+	}
+}
+
+func TestFieldProjectionDryRun(t *testing.T) {
+	p, err := newProjector(projectionConfig{
+		DryRun: true,
+		Datasets: []datasetProjectionConfig{{
+			Dataset:       "app",
+			IncludeFields: []string{"message"},
+		}},
+	}, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+
+	fields := mapstr.M{
+		"event":   mapstr.M{"dataset": "app"},
+		"message": "hello",
+		"trace":   mapstr.M{"id": "abc"},
+	}
+	actual, err := p.Run(&beat.Event{Fields: fields.Clone()})
+	require.NoError(t, err)
+	assert.Equal(t, fields, actual.Fields, "the events are not modified in dry run mode")
+	assert.Equal(t, map[string]map[string]struct{}{"app": {"trace.id": {}}}, p.reported)
+
+	_, err = p.Run(&beat.Event{Fields: mapstr.M{"event": mapstr.M{"dataset": "other"}, "trace": "abc"}})
+	require.NoError(t, err)
+	assert.Len(t, p.reported, 1, "the events of other datasets are not projected")
+}
+
+func TestFieldProjectionConfig(t *testing.T) {
+	cases := map[string]struct {
+		dataset mapstr.M
+		wantErr string
+	}{
+		"include": {
+			dataset: mapstr.M{"dataset": "app", "include_fields": []string{"message"}},
+		},
+		"no dataset": {
+			dataset: mapstr.M{"include_fields": []string{"message"}},
+			wantErr: "missing required field",
+		},
+		"no fields": {
+			dataset: mapstr.M{"dataset": "app"},
+			wantErr: "must define at least one of include_fields or exclude_fields",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.MustNewConfigFrom(mapstr.M{"field_projection.datasets": []mapstr.M{tc.dataset}})
+			_, err := MakeDefaultSupport(false, nil)(beat.Info{Paths: tmpPaths(t)}, logptest.NewTestingLogger(t, ""), cfg)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
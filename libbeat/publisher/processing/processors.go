@@ -154,6 +154,86 @@ func (p *group) Run(event *beat.Event) (*beat.Event, error) {
 	return event, nil
 }
 
+// RunSplit is like Run but also applies the remaining processors to events
+// split from the original event, and returns all of them. It returns
+// processors.ErrEventHeld if a processor held an event back.
+func (p *group) RunSplit(event *beat.Event) ([]*beat.Event, error) {
+	if p == nil || len(p.list) == 0 {
+		return []*beat.Event{event}, nil
+	}
+
+	events := []*beat.Event{event}
+	held := false
+	for _, sub := range p.list {
+		next := make([]*beat.Event, 0, len(events))
+		var lastErr error
+		for _, e := range events {
+			out, err := processors.RunSplit(sub, e)
+			if errors.Is(err, processors.ErrEventHeld) {
+				held = true
+			} else if err != nil {
+				p.log.Debugf("Fail to apply processor %s: %s", p, err)
+				lastErr = err
+			}
+			next = append(next, out...)
+		}
+
+		if len(next) == 0 {
+			if held {
+				return nil, processors.ErrEventHeld
+			}
+			return nil, lastErr
+		}
+		events = next
+	}
+
+	if held {
+		return events, processors.ErrEventHeld
+	}
+	return events, nil
+}
+
+// Flush collects the pending events of all processors in the group. Events
+// flushed by a processor are run through the processors that follow it.
+func (p *group) Flush() []*beat.Event {
+	return p.release(processors.Flush)
+}
+
+// Expires reports whether one of the processors in the group holds events
+// back for a limited time.
+func (p *group) Expires() bool {
+	if p == nil {
+		return false
+	}
+	for _, sub := range p.list {
+		if processors.Expires(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// Expire collects the expired events of all processors in the group. Events
+// expired by a processor are run through the processors that follow it.
+func (p *group) Expire() []*beat.Event {
+	return p.release(processors.Expire)
+}
+
+func (p *group) release(fn func(beat.Processor) []*beat.Event) []*beat.Event {
+	if p == nil {
+		return nil
+	}
+	var released []*beat.Event
+	for i, sub := range p.list {
+		rest := &group{log: p.log, title: p.title, list: p.list[i+1:]}
+		for _, e := range fn(sub) {
+			events, _ := rest.RunSplit(e)
+			released = append(released, events...)
+		}
+	}
+	return released
+}
+
 func newProcessor(name string, fn func(*beat.Event) (*beat.Event, error)) *processorFn {
 	return &processorFn{name: name, fn: fn}
 }
@@ -168,6 +248,20 @@ func newAnnotateProcessor(name string, fn func(*beat.Event)) *processorFn {
 func (p *processorFn) String() string                         { return p.name }
 func (p *processorFn) Run(e *beat.Event) (*beat.Event, error) { return p.fn(e) }
 
+// sharedGroup exposes a processor group that is shared by all clients, like
+// the global processors. It hides Close so that clients cannot close it, and
+// Flush and Expire because pending events of shared processors don't belong
+// to the client that is closed or expires them.
+type sharedGroup struct {
+	group *group
+}
+
+func (p sharedGroup) String() string                         { return p.group.title }
+func (p sharedGroup) Run(e *beat.Event) (*beat.Event, error) { return p.group.Run(e) }
+func (p sharedGroup) RunSplit(e *beat.Event) ([]*beat.Event, error) {
+	return p.group.RunSplit(e)
+}
+
 func clientEventMeta(meta mapstr.M, needsCopy bool) *processorFn {
 	fn := func(event *beat.Event) { addMeta(event, meta) }
 	if needsCopy {
@@ -0,0 +1,149 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package processing
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/beat/events"
+	"github.com/elastic/beats/v7/libbeat/common/fmtstr"
+	"github.com/elastic/beats/v7/libbeat/conditions"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// routeMetaName is the metadata field holding the name of the last route
+// that matched the event.
+const routeMetaName = "route"
+
+// routeConfig configures a single entry of the top-level `routes` section.
+type routeConfig struct {
+	Name      string                    `config:"name"`
+	When      *conditions.Config        `config:"when"`
+	Index     *fmtstr.EventFormatString `config:"index"`
+	Pipeline  *fmtstr.EventFormatString `config:"pipeline"`
+	Output    *fmtstr.EventFormatString `config:"output"`
+	Dataset   string                    `config:"dataset"`
+	Namespace string                    `config:"namespace"`
+	Drop      bool                      `config:"drop"`
+	Continue  bool                      `config:"continue"`
+}
+
+func (c *routeConfig) Validate() error {
+	hasTarget := c.Index != nil || c.Pipeline != nil || c.Output != nil ||
+		c.Dataset != "" || c.Namespace != ""
+	if !hasTarget && !c.Drop {
+		return errors.New("route must define at least one of index, pipeline, output, dataset, namespace or drop")
+	}
+	if c.Drop && (hasTarget || c.Continue) {
+		return errors.New("route with drop enabled can not set targets or continue")
+	}
+	return nil
+}
+
+type route struct {
+	name string
+	cond conditions.Condition
+	cfg  routeConfig
+}
+
+// router assigns pipeline targets to events based on an ordered list of
+// routes. Routes are evaluated in order, and the first matching route is
+// applied unless it sets `continue`, in which case the next routes are
+// evaluated as well.
+type router struct {
+	log    *logp.Logger
+	routes []route
+}
+
+func newRouter(configs []routeConfig, log *logp.Logger) (*router, error) {
+	r := &router{log: log.Named("routes")}
+	for i, cfg := range configs {
+		name := cfg.Name
+		if name == "" {
+			name = fmt.Sprintf("route-%d", i)
+		}
+
+		var cond conditions.Condition
+		if cfg.When != nil {
+			var err error
+			cond, err = conditions.NewCondition(cfg.When, log)
+			if err != nil {
+				return nil, fmt.Errorf("failed to initialize condition of route %q: %w", name, err)
+			}
+		}
+		r.routes = append(r.routes, route{name: name, cond: cond, cfg: cfg})
+	}
+	return r, nil
+}
+
+func (r *router) Run(event *beat.Event) (*beat.Event, error) {
+	for _, rt := range r.routes {
+		if rt.cond != nil && !rt.cond.Check(event) {
+			continue
+		}
+
+		if rt.cfg.Drop {
+			return nil, nil
+		}
+		r.apply(rt, event)
+		if !rt.cfg.Continue {
+			break
+		}
+	}
+	return event, nil
+}
+
+func (r *router) apply(rt route, event *beat.Event) {
+	setMeta := func(key string, fs *fmtstr.EventFormatString) {
+		if fs == nil {
+			return
+		}
+		value, err := fs.Run(event)
+		if err != nil {
+			r.log.Debugf("route %q: failed to format %v: %v", rt.name, key, err)
+			return
+		}
+		if value == "" {
+			return
+		}
+		_, _ = event.PutValue("@metadata."+key, value)
+	}
+
+	setMeta(events.FieldMetaRawIndex, rt.cfg.Index)
+	setMeta(events.FieldMetaPipeline, rt.cfg.Pipeline)
+	setMeta(events.FieldMetaOutput, rt.cfg.Output)
+	if rt.cfg.Dataset != "" {
+		_, _ = event.PutValue("data_stream.dataset", rt.cfg.Dataset)
+		_, _ = event.PutValue("event.dataset", rt.cfg.Dataset)
+	}
+	if rt.cfg.Namespace != "" {
+		_, _ = event.PutValue("data_stream.namespace", rt.cfg.Namespace)
+	}
+	_, _ = event.PutValue("@metadata."+routeMetaName, rt.name)
+}
+
+func (r *router) String() string {
+	names := make([]string, len(r.routes))
+	for i, rt := range r.routes {
+		names[i] = rt.name
+	}
+	return "routes=[" + strings.Join(names, ", ") + "]"
+}
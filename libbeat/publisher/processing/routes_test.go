@@ -0,0 +1,212 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package processing
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/beat/events"
+	"github.com/elastic/beats/v7/libbeat/outputs/outil"
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestRoutes(t *testing.T) {
+	routes := []mapstr.M{
+		{
+			"name": "noise",
+			"when": mapstr.M{"equals": mapstr.M{"log.level": "debug"}},
+			"drop": true,
+		},
+		{
+			"name":     "security",
+			"when":     mapstr.M{"equals": mapstr.M{"event.category": "authentication"}},
+			"index":    "security-%{[service.name]}",
+			"pipeline": "security-pipeline",
+			"continue": true,
+		},
+		{
+			"name":      "audit",
+			"when":      mapstr.M{"has_fields": []string{"user.name"}},
+			"dataset":   "audit",
+			"namespace": "prod",
+			"output":    "audit-topic",
+		},
+		{
+			"name":  "default",
+			"index": "default-index",
+		},
+	}
+
+	cases := map[string]struct {
+		fields      mapstr.M
+		wantDropped bool
+		wantMeta    mapstr.M
+		wantFields  mapstr.M
+	}{
+		"dropped": {
+			fields:      mapstr.M{"log": mapstr.M{"level": "debug"}},
+			wantDropped: true,
+		},
+		"first match wins": {
+			fields: mapstr.M{"message": "hello"},
+			wantMeta: mapstr.M{
+				"raw_index": "default-index",
+				"route":     "default",
+			},
+			wantFields: mapstr.M{"message": "hello"},
+		},
+		"continue to next route": {
+			fields: mapstr.M{
+				"event":   mapstr.M{"category": "authentication"},
+				"service": mapstr.M{"name": "sshd"},
+				"user":    mapstr.M{"name": "root"},
+			},
+			wantMeta: mapstr.M{
+				"raw_index": "security-sshd",
+				"pipeline":  "security-pipeline",
+				"output":    "audit-topic",
+				"route":     "audit",
+			},
+			wantFields: mapstr.M{
+				"event":       mapstr.M{"category": "authentication", "dataset": "audit"},
+				"service":     mapstr.M{"name": "sshd"},
+				"user":        mapstr.M{"name": "root"},
+				"data_stream": mapstr.M{"dataset": "audit", "namespace": "prod"},
+			},
+		},
+		"format failure keeps event": {
+			fields: mapstr.M{"event": mapstr.M{"category": "authentication"}},
+			wantMeta: mapstr.M{
+				"pipeline":  "security-pipeline",
+				"raw_index": "default-index",
+				"route":     "default",
+			},
+			wantFields: mapstr.M{"event": mapstr.M{"category": "authentication"}},
+		},
+	}
+
+	cfg := config.MustNewConfigFrom(mapstr.M{"routes": routes})
+	s, err := MakeDefaultSupport(false, nil)(beat.Info{Paths: tmpPaths(t)}, logptest.NewTestingLogger(t, ""), cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, s.Close()) })
+
+	prog, err := s.Create(beat.ProcessingConfig{}, false)
+	require.NoError(t, err)
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			actual, err := prog.Run(&beat.Event{Fields: tc.fields.Clone()})
+			require.NoError(t, err)
+			if tc.wantDropped {
+				assert.Nil(t, actual, "event should have been dropped")
+				return
+			}
+			require.NotNil(t, actual, "event should not have been dropped")
+			assert.Equal(t, tc.wantMeta, actual.Meta)
+			assert.Equal(t, tc.wantFields, actual.Fields)
+		})
+	}
+}
+
+func TestRoutesConfig(t *testing.T) {
+	cases := map[string]struct {
+		route   mapstr.M
+		wantErr string
+	}{
+		"index": {
+			route: mapstr.M{"index": "foo"},
+		},
+		"drop": {
+			route: mapstr.M{"drop": true},
+		},
+		"no target": {
+			route:   mapstr.M{"name": "empty"},
+			wantErr: "route must define at least one of",
+		},
+		"output": {
+			route: mapstr.M{"output": "audit-topic"},
+		},
+		"drop with target": {
+			route:   mapstr.M{"drop": true, "index": "foo"},
+			wantErr: "route with drop enabled",
+		},
+		"drop with continue": {
+			route:   mapstr.M{"drop": true, "continue": true},
+			wantErr: "route with drop enabled",
+		},
+		"invalid condition": {
+			route:   mapstr.M{"index": "foo", "when": mapstr.M{"regexp": mapstr.M{"message": "("}}},
+			wantErr: "failed to initialize condition",
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			cfg := config.MustNewConfigFrom(mapstr.M{"routes": []mapstr.M{tc.route}})
+			_, err := MakeDefaultSupport(false, nil)(beat.Info{Paths: tmpPaths(t)}, logptest.NewTestingLogger(t, ""), cfg)
+			if tc.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.wantErr)
+		})
+	}
+}
+
+func TestRouteOutputSelectsDestination(t *testing.T) {
+	cfg := config.MustNewConfigFrom(mapstr.M{"routes": []mapstr.M{{
+		"when":   mapstr.M{"equals": mapstr.M{"event.category": "authentication"}},
+		"output": "audit-%{[service.name]}",
+	}}})
+	s, err := MakeDefaultSupport(false, nil)(beat.Info{Paths: tmpPaths(t)}, logptest.NewTestingLogger(t, ""), cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, s.Close()) })
+	prog, err := s.Create(beat.ProcessingConfig{}, false)
+	require.NoError(t, err)
+
+	// The destination selector of the Kafka output.
+	topic, err := outil.BuildSelectorFromConfig(config.MustNewConfigFrom(mapstr.M{"topic": "default"}), outil.Settings{
+		Key:              "topic",
+		MultiKey:         "topics",
+		EnableSingleOnly: true,
+		FailEmpty:        true,
+		MetaKey:          events.FieldMetaOutput,
+	}, logptest.NewTestingLogger(t, ""))
+	require.NoError(t, err)
+
+	for fields, want := range map[string]string{
+		"authentication": "audit-sshd",
+		"network":        "default",
+	} {
+		event, err := prog.Run(&beat.Event{Fields: mapstr.M{
+			"event":   mapstr.M{"category": fields},
+			"service": mapstr.M{"name": "sshd"},
+		}})
+		require.NoError(t, err)
+		got, err := topic.Select(event)
+		require.NoError(t, err)
+		assert.Equal(t, want, got, fields)
+	}
+}
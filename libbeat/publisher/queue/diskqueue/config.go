@@ -66,6 +66,24 @@ type Settings struct {
 
 	// UseCompression enables or disables LZ4 compression
 	UseCompression bool
+
+	// RetentionMaxAge and RetentionMaxSize cap how long and how much data
+	// waits in the queue when the outputs can't keep up. When a limit is
+	// exceeded, the oldest segments that were not read yet are dropped.
+	// A value of 0 disables the limit.
+	RetentionMaxAge  time.Duration
+	RetentionMaxSize uint64
+
+	// EncryptionKeys maps key IDs to the secrets segments can be encrypted
+	// with. The AES-256 key of each segment is derived from the secret and
+	// a random salt. Each segment records the ID of its key, so segments
+	// written with a previous key stay readable as long as that key is
+	// listed.
+	EncryptionKeys map[string][]byte
+
+	// EncryptionKeyID is the ID of the key new segments are encrypted with.
+	// If empty, new segments are not encrypted.
+	EncryptionKeyID string
 }
 
 // userConfig holds the parameters for a disk queue that are configurable
@@ -80,6 +98,16 @@ type userConfig struct {
 
 	RetryInterval    *time.Duration `config:"retry_interval" validate:"positive"`
 	MaxRetryInterval *time.Duration `config:"max_retry_interval" validate:"positive"`
+
+	Retention struct {
+		MaxAge  time.Duration    `config:"max_age" validate:"min=0"`
+		MaxSize cfgtype.ByteSize `config:"max_size"`
+	} `config:"retention"`
+
+	Encryption struct {
+		Keys      map[string]string `config:"keys"`
+		ActiveKey string            `config:"active_key"`
+	} `config:"encryption"`
 }
 
 func (c *userConfig) Validate() error {
@@ -112,6 +140,29 @@ func (c *userConfig) Validate() error {
 			*c.MaxRetryInterval, *c.RetryInterval)
 	}
 
+	if c.MaxSize != 0 && c.Retention.MaxSize >= c.MaxSize {
+		return fmt.Errorf(
+			"disk queue retention.max_size (%d) must be less than max_size (%d)",
+			c.Retention.MaxSize, c.MaxSize)
+	}
+
+	for id, secret := range c.Encryption.Keys {
+		if len(id) > maxEncryptionKeyIDLength {
+			return fmt.Errorf(
+				"disk queue encryption key ID %q is longer than %d bytes", id, maxEncryptionKeyIDLength)
+		}
+		if secret == "" {
+			return fmt.Errorf("disk queue encryption key %q is empty", id)
+		}
+	}
+	if c.Encryption.ActiveKey != "" {
+		if _, ok := c.Encryption.Keys[c.Encryption.ActiveKey]; !ok {
+			return fmt.Errorf(
+				"disk queue encryption active_key %q is not one of the configured keys",
+				c.Encryption.ActiveKey)
+		}
+	}
+
 	return nil
 }
 
@@ -164,6 +215,17 @@ func SettingsForUserConfig(config *config.C) (Settings, error) {
 		settings.MaxRetryInterval = *userConfig.MaxRetryInterval
 	}
 
+	settings.RetentionMaxAge = userConfig.Retention.MaxAge
+	settings.RetentionMaxSize = uint64(userConfig.Retention.MaxSize)
+
+	if len(userConfig.Encryption.Keys) > 0 {
+		settings.EncryptionKeys = make(map[string][]byte, len(userConfig.Encryption.Keys))
+		for id, secret := range userConfig.Encryption.Keys {
+			settings.EncryptionKeys[id] = []byte(secret)
+		}
+	}
+	settings.EncryptionKeyID = userConfig.Encryption.ActiveKey
+
 	return settings, nil
 }
 
@@ -194,6 +256,16 @@ func (settings Settings) maxValidFrameSize() uint64 {
 	return settings.MaxSegmentSize - segmentHeaderSize
 }
 
+func (settings Settings) retentionEnabled() bool {
+	return settings.RetentionMaxAge > 0 || settings.RetentionMaxSize > 0
+}
+
+// retentionCheckInterval returns how often the age of the queued segments
+// is checked.
+func (settings Settings) retentionCheckInterval() time.Duration {
+	return min(settings.RetentionMaxAge, time.Minute)
+}
+
 // Given a retry interval, nextRetryInterval returns the next higher level
 // of backoff.
 func (settings Settings) nextRetryInterval(
@@ -22,7 +22,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 	"github.com/elastic/elastic-agent-libs/paths"
 )
 
@@ -57,3 +60,28 @@ func TestDirectoryPath(t *testing.T) {
 		})
 	}
 }
+
+func TestEncryptionSettings(t *testing.T) {
+	settings, err := SettingsForUserConfig(config.MustNewConfigFrom(mapstr.M{
+		"max_size": "1GB",
+		"encryption": mapstr.M{
+			"keys":       mapstr.M{"2025": "old secret", "2026": "new secret"},
+			"active_key": "2026",
+		},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "2026", settings.EncryptionKeyID)
+	assert.Equal(t, map[string][]byte{
+		"2025": []byte("old secret"),
+		"2026": []byte("new secret"),
+	}, settings.EncryptionKeys)
+
+	_, err = SettingsForUserConfig(config.MustNewConfigFrom(mapstr.M{
+		"max_size": "1GB",
+		"encryption": mapstr.M{
+			"keys":       mapstr.M{"2025": "old secret"},
+			"active_key": "2026",
+		},
+	}))
+	assert.ErrorContains(t, err, "is not one of the configured keys")
+}
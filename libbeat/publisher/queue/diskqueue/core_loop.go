@@ -17,7 +17,10 @@
 
 package diskqueue
 
-import "fmt"
+import (
+	"fmt"
+	"time"
+)
 
 // This file contains the queue's "core loop" -- the central goroutine
 // that owns all queue state that is not encapsulated in one of the
@@ -31,6 +34,15 @@ func (dq *diskQueue) run() {
 	dq.maybeReadPending()
 	dq.maybeDeleteACKed()
 
+	// The age of the queued segments is checked periodically if the
+	// retention policy has a maximum age.
+	var retentionTicker <-chan time.Time
+	if dq.settings.RetentionMaxAge > 0 {
+		ticker := time.NewTicker(dq.settings.retentionCheckInterval())
+		defer ticker.Stop()
+		retentionTicker = ticker.C
+	}
+
 	for {
 		select {
 		// Endpoints used by the producer / consumer API implementation.
@@ -67,6 +79,12 @@ func (dq *diskQueue) run() {
 			// because pendingFrames hit settings.WriteAheadLimit, wake them up.
 			dq.maybeUnblockProducers()
 
+			// The queue grew, check if the retention policy must drop segments.
+			dq.maybeEnforceRetention()
+
+		case <-retentionTicker:
+			dq.maybeEnforceRetention()
+
 		// Reader loop handling
 		case readerLoopResponse := <-dq.readerLoop.responseChan:
 			dq.handleReaderLoopResponse(readerLoopResponse)
@@ -132,8 +150,12 @@ func (dq *diskQueue) handleWriterLoopResponse(response writerLoopResponse) {
 	// the same sequence as (the beginning of) segments.writing.
 	for index, segmentEntry := range response.segments {
 		// Update the segment with its new size.
-		dq.segments.writing[index].byteCount += segmentEntry.bytesWritten
-		dq.segments.writing[index].frameCount += segmentEntry.framesWritten
+		segment := dq.segments.writing[index]
+		segment.byteCount += segmentEntry.bytesWritten
+		segment.frameCount += segmentEntry.framesWritten
+		if segmentEntry.framesWritten > 0 {
+			segment.modTime = time.Now()
+		}
 	}
 
 	// If there is more than one segment in the response, then all but the
@@ -442,7 +464,11 @@ func (dq *diskQueue) enqueueWriteFrame(frame *writeFrame) {
 	// we need to create a new writing segment.
 	if segment == nil ||
 		newSegmentSize > dq.settings.MaxSegmentSize {
-		segment = &queueSegment{id: dq.segments.nextID}
+		segment = &queueSegment{
+			id:       dq.segments.nextID,
+			modTime:  time.Now(),
+			datasets: make(map[string]int),
+		}
 		dq.segments.writing = append(dq.segments.writing, segment)
 		dq.segments.nextID++
 		// Reset the on-disk size to its initial value, the file's header size
@@ -451,6 +477,9 @@ func (dq *diskQueue) enqueueWriteFrame(frame *writeFrame) {
 	}
 
 	dq.segments.writingSegmentSize = newSegmentSize
+	if segment.datasets != nil {
+		segment.datasets[frame.dataset]++
+	}
 	dq.pendingFrames = append(dq.pendingFrames, segmentedFrame{
 		frame:   frame,
 		segment: segment,
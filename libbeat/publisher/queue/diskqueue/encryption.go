@@ -0,0 +1,359 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+// Encrypted segments start their data region with the ID of the key they
+// were encrypted with, followed by the random salt the AES-256 key of the
+// segment is derived from:
+//
+//	[1 byte key ID length][key ID][16 byte salt]
+//
+// The data follows as a sequence of chunks, each sealed with AES-256-GCM:
+//
+//	[4 byte ciphertext length][ciphertext and 16 byte tag]
+//
+// The nonce of a chunk is its index in the segment, so chunks that are
+// modified, reordered or copied from another segment fail authentication.
+// Frame positions are tracked in plaintext offsets, so the prefix and the
+// chunk overhead are not visible outside of the encryption reader and
+// writer.
+
+const (
+	// maxEncryptionKeyIDLength is the longest key ID that can be recorded
+	// in a segment.
+	maxEncryptionKeyIDLength = 255
+
+	// encryptionSaltSize is the size of the random salt of each segment.
+	encryptionSaltSize = 16
+
+	// encryptionKDFIterations is the number of PBKDF2-SHA256 iterations
+	// used to derive the key of a segment from the secret.
+	encryptionKDFIterations = 600_000
+
+	// maxEncryptionChunkSize is the largest plaintext sealed in a chunk.
+	// Larger writes are split in several chunks.
+	maxEncryptionChunkSize = 1 << 20
+
+	// encryptionChunkHeaderSize is the size of the length of a chunk.
+	encryptionChunkHeaderSize = 4
+
+	// gcmTagSize is the size of the authentication tag of a chunk.
+	gcmTagSize = 16
+)
+
+var errEncryptedChunk = errors.New("segment data failed authentication")
+
+// deriveEncryptionKey derives the AES-256 key of a segment from the secret
+// and the salt of the segment.
+func deriveEncryptionKey(secret []byte, salt []byte) ([]byte, error) {
+	return pbkdf2.Key(sha256.New, string(secret), salt, encryptionKDFIterations, 32)
+}
+
+// newSegmentAEAD returns the AES-256-GCM cipher of a segment.
+func newSegmentAEAD(secret []byte, salt []byte) (cipher.AEAD, error) {
+	key, err := deriveEncryptionKey(secret, salt)
+	if err != nil {
+		return nil, fmt.Errorf("could not derive encryption key: %w", err)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// chunkNonce returns the nonce of the chunk at the given index. The key of
+// each segment is unique, so the nonces only need to be unique within a
+// segment.
+func chunkNonce(nonce []byte, index uint64) []byte {
+	clear(nonce)
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], index)
+	return nonce
+}
+
+// encryptedDataSize returns the size of the decrypted data region of the
+// encrypted segment file at the given path. An incomplete chunk at the end
+// of the file, left by an interrupted write, is not counted.
+func encryptedDataSize(path string) (uint64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return 0, err
+	}
+
+	var idLen [1]byte
+	if _, err := file.ReadAt(idLen[:], segmentHeaderSize); err != nil {
+		return 0, fmt.Errorf("could not read encryption key ID: %w", err)
+	}
+	offset := int64(segmentHeaderSize) + 1 + int64(idLen[0]) + encryptionSaltSize
+	var size uint64
+	var header [encryptionChunkHeaderSize]byte
+	for {
+		if _, err := file.ReadAt(header[:], offset); err != nil {
+			if errors.Is(err, io.EOF) {
+				return size, nil
+			}
+			return 0, err
+		}
+		length := binary.LittleEndian.Uint32(header[:])
+		if length < gcmTagSize || length > maxEncryptionChunkSize+gcmTagSize {
+			return 0, fmt.Errorf("invalid encrypted chunk length %d at offset %d", length, offset)
+		}
+		offset += encryptionChunkHeaderSize + int64(length)
+		if offset > info.Size() {
+			return size, nil
+		}
+		size += uint64(length - gcmTagSize)
+	}
+}
+
+// EncryptionReader allows reading a segment data region encrypted with
+// one of a set of secrets.
+type EncryptionReader struct {
+	src     io.ReadCloser
+	secrets map[string][]byte
+	keyID   string
+	salt    []byte
+	aead    cipher.AEAD
+
+	index  uint64
+	nonce  []byte
+	chunk  []byte
+	plain  []byte
+	header [encryptionChunkHeaderSize]byte
+}
+
+// NewEncryptionReader returns a new reader decrypting src, which must be
+// positioned at the beginning of the data region of the segment.
+func NewEncryptionReader(src io.ReadCloser, secrets map[string][]byte) (*EncryptionReader, error) {
+	r := &EncryptionReader{src: src, secrets: secrets}
+	if err := r.Reset(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reset sets up decryption again, assumes that caller has already set
+// the src to the beginning of the data region.
+func (r *EncryptionReader) Reset() error {
+	var idLen [1]byte
+	if _, err := io.ReadFull(r.src, idLen[:]); err != nil {
+		return fmt.Errorf("could not read encryption key ID: %w", err)
+	}
+	id := make([]byte, idLen[0])
+	if _, err := io.ReadFull(r.src, id); err != nil {
+		return fmt.Errorf("could not read encryption key ID: %w", err)
+	}
+	salt := make([]byte, encryptionSaltSize)
+	if _, err := io.ReadFull(r.src, salt); err != nil {
+		return fmt.Errorf("could not read encryption salt: %w", err)
+	}
+
+	// The key derivation is expensive, keep the cipher when the reader is
+	// reset to seek within the same segment.
+	if r.aead == nil || r.keyID != string(id) || string(r.salt) != string(salt) {
+		secret, ok := r.secrets[string(id)]
+		if !ok {
+			return fmt.Errorf("segment is encrypted with unknown key %q", id)
+		}
+		aead, err := newSegmentAEAD(secret, salt)
+		if err != nil {
+			return err
+		}
+		r.keyID = string(id)
+		r.salt = salt
+		r.aead = aead
+		r.nonce = make([]byte, aead.NonceSize())
+	}
+	r.index = 0
+	r.plain = nil
+	return nil
+}
+
+// KeyID returns the ID of the key the segment is encrypted with.
+func (r *EncryptionReader) KeyID() string {
+	return r.keyID
+}
+
+func (r *EncryptionReader) Read(buf []byte) (int, error) {
+	for len(r.plain) == 0 {
+		if err := r.readChunk(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(buf, r.plain)
+	r.plain = r.plain[n:]
+	return n, nil
+}
+
+// readChunk reads and decrypts the next chunk. It returns io.EOF at the end
+// of the data region, and io.ErrUnexpectedEOF if the last chunk is
+// incomplete.
+func (r *EncryptionReader) readChunk() error {
+	if _, err := io.ReadFull(r.src, r.header[:]); err != nil {
+		return err
+	}
+	length := binary.LittleEndian.Uint32(r.header[:])
+	if length < gcmTagSize || length > maxEncryptionChunkSize+gcmTagSize {
+		return fmt.Errorf("invalid encrypted chunk length %d", length)
+	}
+	if cap(r.chunk) < int(length) {
+		r.chunk = make([]byte, length)
+	}
+	chunk := r.chunk[:length]
+	if _, err := io.ReadFull(r.src, chunk); err != nil {
+		if errors.Is(err, io.EOF) {
+			return io.ErrUnexpectedEOF
+		}
+		return err
+	}
+	plain, err := r.aead.Open(chunk[:0], chunkNonce(r.nonce, r.index), chunk, nil)
+	if err != nil {
+		return errEncryptedChunk
+	}
+	r.index++
+	r.plain = plain
+	return nil
+}
+
+func (r *EncryptionReader) Close() error {
+	return r.src.Close()
+}
+
+// EncryptionWriter allows writing an encrypted segment data region.
+type EncryptionWriter struct {
+	dst   WriteCloseSyncer
+	aead  cipher.AEAD
+	index uint64
+	nonce []byte
+	buf   []byte
+
+	// pending is the part of the chunks of the last write that was not
+	// written to dst, and pendingLen the size of their plaintext.
+	pending    []byte
+	pendingLen int
+}
+
+// NewEncryptionWriter returns a new writer encrypting to dst with the key
+// derived from the given secret. The key ID and a new random salt are
+// written to dst, which must be positioned at the beginning of the data
+// region of the segment.
+func NewEncryptionWriter(dst WriteCloseSyncer, keyID string, secret []byte) (*EncryptionWriter, error) {
+	if len(keyID) > maxEncryptionKeyIDLength {
+		return nil, fmt.Errorf("encryption key ID %q is longer than %d bytes", keyID, maxEncryptionKeyIDLength)
+	}
+	salt := make([]byte, encryptionSaltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("could not generate encryption salt: %w", err)
+	}
+	aead, err := newSegmentAEAD(secret, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := make([]byte, 0, 1+len(keyID)+len(salt))
+	prefix = append(prefix, byte(len(keyID)))
+	prefix = append(prefix, keyID...)
+	prefix = append(prefix, salt...)
+	if _, err := dst.Write(prefix); err != nil {
+		return nil, fmt.Errorf("could not write encryption prefix: %w", err)
+	}
+
+	return &EncryptionWriter{
+		dst:   dst,
+		aead:  aead,
+		nonce: make([]byte, aead.NonceSize()),
+	}, nil
+}
+
+// Write seals p in one or more chunks and writes them to dst. If the
+// chunks are only partially written, Write returns 0 and the error, and
+// the rest of the chunks is written by the next call, which must retry
+// with the same data, as callbackRetryWriter does.
+func (w *EncryptionWriter) Write(p []byte) (int, error) {
+	if len(w.pending) == 0 {
+		if len(p) == 0 {
+			return 0, nil
+		}
+		w.seal(p)
+	}
+	for len(w.pending) > 0 {
+		n, err := w.dst.Write(w.pending)
+		w.pending = w.pending[n:]
+		if err != nil {
+			return 0, err
+		}
+		if n == 0 {
+			return 0, io.ErrShortWrite
+		}
+	}
+	return w.pendingLen, nil
+}
+
+// seal encrypts p in chunks of at most maxEncryptionChunkSize bytes into
+// the pending buffer.
+func (w *EncryptionWriter) seal(p []byte) {
+	w.buf = w.buf[:0]
+	w.pendingLen = len(p)
+	for len(p) > 0 {
+		n := min(len(p), maxEncryptionChunkSize)
+		w.buf = binary.LittleEndian.AppendUint32(w.buf, uint32(n+w.aead.Overhead())) //nolint:gosec // G115 n is at most maxEncryptionChunkSize
+		w.buf = w.aead.Seal(w.buf, chunkNonce(w.nonce, w.index), p[:n], nil)
+		w.index++
+		p = p[n:]
+	}
+	w.pending = w.buf
+}
+
+func (w *EncryptionWriter) Close() error {
+	return w.dst.Close()
+}
+
+func (w *EncryptionWriter) Sync() error {
+	return w.dst.Sync()
+}
+
+// encryptionKey returns the ID and secret new segments are encrypted with,
+// or an empty ID if encryption is disabled.
+func (settings Settings) encryptionKey() (string, []byte, error) {
+	if settings.EncryptionKeyID == "" {
+		return "", nil, nil
+	}
+	key, ok := settings.EncryptionKeys[settings.EncryptionKeyID]
+	if !ok {
+		return "", nil, errors.New("active encryption key is not configured")
+	}
+	return settings.EncryptionKeyID, key, nil
+}
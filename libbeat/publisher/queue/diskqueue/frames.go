@@ -42,6 +42,10 @@ type writeFrame struct {
 	// - After the writer loop has finished writing the frame to disk,
 	//   it needs to call the ACK function specified in ProducerConfig.
 	producer *diskQueueProducer
+
+	// The dataset of the event, used to account for the events dropped by
+	// the retention policy.
+	dataset string
 }
 
 // A frame that has been read from disk and is waiting to be read /
@@ -74,6 +74,7 @@ func (producer *diskQueueProducer) publish(
 		frame: &writeFrame{
 			serialized: serialized,
 			producer:   producer,
+			dataset:    eventDataset(event),
 		},
 		shouldBlock: shouldBlock,
 		// This response channel will be used by the core loop, so it must have
@@ -102,3 +103,16 @@ func (producer *diskQueueProducer) Close() {
 	producer.cancelled = true
 	close(producer.done)
 }
+
+// eventDataset returns the dataset of the event, or an empty string if it
+// has none.
+func eventDataset(event publisher.Event) string {
+	for _, key := range []string{"data_stream.dataset", "event.dataset"} {
+		if v, err := event.Content.Fields.GetValue(key); err == nil {
+			if dataset, ok := v.(string); ok && dataset != "" {
+				return dataset
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,162 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+// RekeyStats summarizes the segments processed by Rekey.
+type RekeyStats struct {
+	// Rekeyed is the number of segments that were rewritten.
+	Rekeyed int
+	// Current is the number of segments that already used the active key.
+	Current int
+	// Skipped is the number of segments written by versions of the queue
+	// that don't support encryption, which are left unchanged.
+	Skipped int
+}
+
+// Rekey rewrites the segments of the queue so that all of them are
+// encrypted with the active key of settings, or decrypted if no key is
+// active. The keys the segments are currently encrypted with must be
+// listed in settings. Each segment is replaced atomically, so an
+// interrupted run leaves every segment readable with either its previous
+// or its new key, and can be resumed by running Rekey again.
+//
+// The queue must not be open while Rekey runs.
+func Rekey(logger *logp.Logger, settings Settings, paths *paths.Path) (RekeyStats, error) {
+	var stats RekeyStats
+
+	keyID, key, err := settings.encryptionKey()
+	if err != nil {
+		return stats, err
+	}
+	segments, err := scanExistingSegments(logger, settings.directoryPath(paths))
+	if err != nil {
+		return stats, err
+	}
+
+	for _, segment := range segments {
+		if segment.schemaVersion == nil || *segment.schemaVersion < 2 {
+			logger.Warnf("Segment %d was written by an older version and can't be encrypted, skipping.", segment.id)
+			stats.Skipped++
+			continue
+		}
+		rekeyed, err := rekeySegment(settings, paths, segment, keyID, key)
+		if err != nil {
+			return stats, fmt.Errorf("couldn't rekey segment %d: %w", segment.id, err)
+		}
+		if rekeyed {
+			logger.Infof("Rekeyed segment %d.", segment.id)
+			stats.Rekeyed++
+		} else {
+			stats.Current++
+		}
+	}
+	return stats, nil
+}
+
+// rekeySegment rewrites the segment with the given key unless it is already
+// encrypted with it, and returns whether the segment was rewritten.
+func rekeySegment(settings Settings, paths *paths.Path, segment *queueSegment, keyID string, key []byte) (bool, error) {
+	path := settings.segmentPath(segment.id, paths)
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, err
+	}
+	header, err := readSegmentHeaderFromPath(path)
+	if err != nil {
+		return false, err
+	}
+
+	sr, err := segment.getReader(settings, paths)
+	if err != nil {
+		return false, err
+	}
+	defer sr.Close()
+
+	var currentKeyID string
+	if sr.er != nil {
+		currentKeyID = sr.er.KeyID()
+	}
+	if currentKeyID == keyID {
+		return false, nil
+	}
+
+	tmpPath := path + ".rekey"
+	file, err := os.OpenFile(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return false, err
+	}
+	err = writeRekeyedSegment(file, sr, header, keyID, key)
+	if err != nil {
+		os.Remove(tmpPath)
+		return false, err
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		os.Remove(tmpPath)
+		return false, err
+	}
+	// Keep the modification time, it is used by the retention policy.
+	if err := os.Chtimes(path, info.ModTime(), info.ModTime()); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+// writeRekeyedSegment writes the data of the segment read by sr to file
+// with the given key, and closes file.
+func writeRekeyedSegment(file *os.File, sr *segmentReader, header *segmentHeader, keyID string, key []byte) error {
+	sw, err := newSegmentWriter(file, header.options, keyID, key)
+	if err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := io.Copy(sw, sr); err != nil {
+		sw.Close()
+		return err
+	}
+	if err := sw.Sync(); err != nil {
+		sw.Close()
+		return err
+	}
+	if err := sw.UpdateCount(header.frameCount); err != nil {
+		sw.Close()
+		return err
+	}
+	return errors.Join(sw.Sync(), sw.Close())
+}
+
+// readSegmentHeaderFromPath reads the raw header of the segment file at the
+// given path.
+func readSegmentHeaderFromPath(path string) (*segmentHeader, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return readSegmentHeader(file)
+}
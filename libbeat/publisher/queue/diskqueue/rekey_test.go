@@ -0,0 +1,108 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+func TestRekey(t *testing.T) {
+	logger := logptest.NewTestingLogger(t, "")
+	settings := DefaultSettings()
+	settings.Path = t.TempDir()
+	settings.EncryptionKeys = map[string][]byte{
+		"key1": []byte("secret1"),
+		"key2": []byte("secret2"),
+	}
+
+	// Segment 0 is not encrypted, segments 1 and 2 are encrypted with key1.
+	data := map[segmentID][]byte{
+		0: []byte("plaintext segment"),
+		1: []byte("encrypted segment"),
+		2: []byte("encrypted and compressed segment"),
+	}
+	modTime := time.Now().Add(-time.Hour).Truncate(time.Second)
+	for id, plaintext := range data {
+		s := settings
+		if id > 0 {
+			s.EncryptionKeyID = "key1"
+		}
+		s.UseCompression = id == 2
+		sw, err := (&queueSegment{id: id}).getWriter(s, nil)
+		require.NoError(t, err)
+		_, err = sw.Write(plaintext)
+		require.NoError(t, err)
+		require.NoError(t, sw.UpdateCount(1))
+		require.NoError(t, sw.Close())
+		require.NoError(t, os.Chtimes(s.segmentPath(id, nil), modTime, modTime))
+	}
+
+	settings.EncryptionKeyID = "key2"
+	stats, err := Rekey(logger, settings, nil)
+	require.NoError(t, err)
+	assert.Equal(t, RekeyStats{Rekeyed: 3}, stats)
+
+	// All segments are readable with key2 only, and keep their frame count
+	// and modification time.
+	settings.EncryptionKeys = map[string][]byte{"key2": settings.EncryptionKeys["key2"]}
+	segments, err := scanExistingSegments(logger, settings.Path)
+	require.NoError(t, err)
+	require.Len(t, segments, 3)
+	for _, segment := range segments {
+		assert.Equal(t, uint32(1), segment.frameCount)
+		assert.True(t, modTime.Equal(segment.modTime), "modification time must be kept")
+		if segment.id != 2 {
+			// The byte count of compressed segments is the compressed size.
+			assert.Equal(t, segmentHeaderSize+uint64(len(data[segment.id])), segment.byteCount)
+		}
+
+		sr, err := segment.getReader(settings, nil)
+		require.NoError(t, err)
+		require.NotNil(t, sr.er)
+		assert.Equal(t, "key2", sr.er.KeyID())
+		got, err := io.ReadAll(sr)
+		require.NoError(t, err)
+		assert.Equal(t, data[segment.id], got)
+		require.NoError(t, sr.Close())
+	}
+
+	stats, err = Rekey(logger, settings, nil)
+	require.NoError(t, err)
+	assert.Equal(t, RekeyStats{Current: 3}, stats)
+
+	// Without an active key, segments are decrypted.
+	settings.EncryptionKeyID = ""
+	stats, err = Rekey(logger, settings, nil)
+	require.NoError(t, err)
+	assert.Equal(t, RekeyStats{Rekeyed: 3}, stats)
+	sr, err := segments[1].getReader(Settings{Path: settings.Path}, nil)
+	require.NoError(t, err)
+	defer sr.Close()
+	assert.Nil(t, sr.er)
+	got, err := io.ReadAll(sr)
+	require.NoError(t, err)
+	assert.Equal(t, data[1], got)
+}
@@ -0,0 +1,107 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import "time"
+
+// The dataset reported for dropped events that have none, or that were
+// written during a previous session.
+const unknownDataset = "unknown"
+
+// maybeEnforceRetention drops the oldest segments that were not read yet if
+// they are older than settings.RetentionMaxAge, or while the queue is larger
+// than settings.RetentionMaxSize. The dropped segments are deleted like the
+// acknowledged ones, and an alert is logged with the number of dropped events
+// per dataset.
+func (dq *diskQueue) maybeEnforceRetention() {
+	if !dq.settings.retentionEnabled() {
+		return
+	}
+	// The first segment of the reading list may be partially read or have a
+	// read request in progress, so it is never dropped. Segments that are
+	// still being written are not dropped either.
+	if len(dq.segments.reading) < 2 {
+		return
+	}
+
+	now := time.Now()
+	size := dq.currentSize()
+	var ageExceeded, sizeExceeded bool
+	dropCount := 0
+	for _, segment := range dq.segments.reading[1:] {
+		tooOld := dq.settings.RetentionMaxAge > 0 &&
+			now.Sub(segment.modTime) > dq.settings.RetentionMaxAge
+		tooLarge := dq.settings.RetentionMaxSize > 0 &&
+			size > dq.settings.RetentionMaxSize
+		if !tooOld && !tooLarge {
+			break
+		}
+		ageExceeded = ageExceeded || tooOld
+		sizeExceeded = sizeExceeded || tooLarge
+		size -= segment.byteCount
+		dropCount++
+	}
+	if dropCount == 0 {
+		return
+	}
+
+	dropped := dq.segments.reading[1 : 1+dropCount]
+	eventCount, byteCount := 0, 0
+	for _, segment := range dropped {
+		eventCount += int(segment.frameCount)
+		// As for deleted segments, the segment header isn't included in the
+		// event metrics.
+		byteCount += int(segment.byteCount - segment.headerSize()) //nolint:gosec // G115 Conversion from uint64 to int is safe here.
+	}
+	datasets := datasetCounts(dropped)
+
+	// Dropped segments are handled like acknowledged ones: the deleter loop
+	// removes their files and reports their events as removed.
+	dq.segments.acked = append(dq.segments.acked, dropped...)
+	dq.segments.reading = append(dq.segments.reading[:1], dq.segments.reading[1+dropCount:]...)
+	dq.maybeDeleteACKed()
+
+	dq.observer.DropEvents(eventCount, byteCount)
+	dq.logger.Warnw(
+		"Disk queue retention policy dropped events that were not sent to the outputs",
+		"queue.retention.max_age_exceeded", ageExceeded,
+		"queue.retention.max_size_exceeded", sizeExceeded,
+		"queue.dropped.events", eventCount,
+		"queue.dropped.bytes", byteCount,
+		"queue.dropped.datasets", datasets,
+	)
+}
+
+// datasetCounts returns the number of events per dataset in the given
+// segments.
+func datasetCounts(segments []*queueSegment) map[string]int {
+	datasets := map[string]int{}
+	for _, segment := range segments {
+		if segment.datasets == nil {
+			datasets[unknownDataset] += int(segment.frameCount)
+			continue
+		}
+		for dataset, count := range segment.datasets {
+			if dataset == "" {
+				dataset = unknownDataset
+			}
+			datasets[dataset] += count
+		}
+	}
+	return datasets
+}
@@ -0,0 +1,132 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package diskqueue
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/libbeat/publisher/queue"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestMaybeEnforceRetention(t *testing.T) {
+	now := time.Now()
+	// Each test segment holds 10 events and 1000 bytes on disk.
+	segment := func(id segmentID, age time.Duration, datasets map[string]int) *queueSegment {
+		return &queueSegment{
+			id:         id,
+			byteCount:  1000,
+			frameCount: 10,
+			modTime:    now.Add(-age),
+			datasets:   datasets,
+		}
+	}
+	ids := func(segments []*queueSegment) []segmentID {
+		var result []segmentID
+		for _, s := range segments {
+			result = append(result, s.id)
+		}
+		return result
+	}
+
+	testCases := map[string]struct {
+		settings Settings
+		reading  []*queueSegment
+
+		expectedReading []segmentID
+		expectedDropped []segmentID
+		expectedEvents  uint64
+	}{
+		"disabled": {
+			reading: []*queueSegment{
+				segment(0, time.Hour, nil),
+				segment(1, time.Hour, nil),
+			},
+			expectedReading: []segmentID{0, 1},
+		},
+		"segments older than max age are dropped": {
+			settings: Settings{RetentionMaxAge: time.Minute},
+			reading: []*queueSegment{
+				segment(0, time.Hour, nil),
+				segment(1, time.Hour, map[string]int{"system.auth": 4, "": 6}),
+				segment(2, 2*time.Minute, map[string]int{"system.auth": 10}),
+				segment(3, time.Second, nil),
+			},
+			expectedReading: []segmentID{0, 3},
+			expectedDropped: []segmentID{1, 2},
+			expectedEvents:  20,
+		},
+		"segments are dropped until the queue is smaller than max size": {
+			settings: Settings{RetentionMaxSize: 2500},
+			reading: []*queueSegment{
+				segment(0, 0, nil),
+				segment(1, 0, nil),
+				segment(2, 0, nil),
+				segment(3, 0, nil),
+			},
+			expectedReading: []segmentID{0, 3},
+			expectedDropped: []segmentID{1, 2},
+			expectedEvents:  20,
+		},
+		"the first reading segment is never dropped": {
+			settings: Settings{RetentionMaxAge: time.Minute, RetentionMaxSize: 100},
+			reading: []*queueSegment{
+				segment(0, time.Hour, nil),
+			},
+			expectedReading: []segmentID{0},
+		},
+	}
+	for name, test := range testCases {
+		t.Run(name, func(t *testing.T) {
+			reg := monitoring.NewRegistry()
+			dq := &diskQueue{
+				logger:      logptest.NewTestingLogger(t, ""),
+				observer:    queue.NewQueueObserver(reg),
+				settings:    test.settings,
+				segments:    diskQueueSegments{reading: test.reading},
+				deleterLoop: &deleterLoop{requestChan: make(chan deleterLoopRequest, 1)},
+			}
+			dq.maybeEnforceRetention()
+
+			assert.Equal(t, test.expectedReading, ids(dq.segments.reading))
+			assert.Equal(t, test.expectedDropped, ids(dq.segments.acked))
+			assertRegistryUint(t, reg, "queue.dropped.events", test.expectedEvents, "Dropped events should be reported")
+			if test.expectedDropped != nil {
+				assert.True(t, dq.deleting, "Dropped segments should be sent to the deleter loop")
+			}
+		})
+	}
+}
+
+func TestDatasetCounts(t *testing.T) {
+	segments := []*queueSegment{
+		{frameCount: 10, datasets: map[string]int{"system.auth": 4, "": 6}},
+		{frameCount: 10, datasets: map[string]int{"system.auth": 3, "nginx.access": 7}},
+		// Segments loaded from a previous session have no datasets.
+		{frameCount: 5},
+	}
+	assert.Equal(t, map[string]int{
+		"system.auth":  7,
+		"nginx.access": 7,
+		"unknown":      11,
+	}, datasetCounts(segments))
+}
@@ -27,6 +27,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/paths"
@@ -119,6 +120,16 @@ type queueSegment struct {
 	//
 	// Used to count how many frames still need to be acknowledged by consumers.
 	framesRead uint64
+
+	// The last time data was written to this segment, or the modification
+	// time of its file if it was loaded from a previous session. Used by the
+	// retention policy.
+	modTime time.Time
+
+	// The number of frames per dataset assigned to this segment during this
+	// session, used to account for the events dropped by the retention
+	// policy. It is nil for segments loaded from a previous session.
+	datasets map[string]int
 }
 
 type segmentHeader struct {
@@ -151,7 +162,7 @@ const currentSegmentVersion = 2
 const segmentHeaderSize = 12
 
 const (
-	_                  uint32 = 1 << iota // 0x1
+	ENABLE_ENCRYPTION  uint32 = 1 << iota // 0x1
 	ENABLE_COMPRESSION                    // 0x2
 	ENABLE_PROTOBUF                       // 0x4
 )
@@ -198,11 +209,24 @@ func scanExistingSegments(logger *logp.Logger, pathStr string) ([]*queueSegment,
 						"error loading segment file '%v', data may be incomplete: %v",
 						fullPath, err)
 				}
+				// Positions within encrypted segments are offsets in the
+				// decrypted data, so the byte count doesn't include the
+				// encryption prefix and the chunk overhead.
+				byteCount := uint64(file.Size())
+				if (header.options & ENABLE_ENCRYPTION) == ENABLE_ENCRYPTION {
+					dataSize, err := encryptedDataSize(fullPath)
+					if err != nil {
+						logger.Errorf("couldn't load segment file '%v': %v", fullPath, err)
+						continue
+					}
+					byteCount = segmentHeaderSize + dataSize
+				}
 				segments = append(segments, &queueSegment{
 					id:            segmentID(id),
 					schemaVersion: &header.version,
 					frameCount:    header.frameCount,
-					byteCount:     uint64(file.Size()),
+					byteCount:     byteCount,
+					modTime:       file.ModTime(),
 				})
 			}
 		}
@@ -256,8 +280,19 @@ func (segment *queueSegment) getReader(queueSettings Settings, paths *paths.Path
 		sr.serializationFormat = SerializationCBOR
 	}
 
+	var src io.ReadCloser = sr.src
+	if (header.options & ENABLE_ENCRYPTION) == ENABLE_ENCRYPTION {
+		sr.er, err = NewEncryptionReader(sr.src, queueSettings.EncryptionKeys)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf(
+				"couldn't set up decryption for segment %d: %w", segment.id, err)
+		}
+		src = sr.er
+	}
+
 	if (header.options & ENABLE_COMPRESSION) == ENABLE_COMPRESSION {
-		sr.cr = NewCompressionReader(sr.src)
+		sr.cr = NewCompressionReader(src)
 	}
 	return sr, nil
 }
@@ -273,10 +308,33 @@ func (segment *queueSegment) getWriter(queueSettings Settings, paths *paths.Path
 		return nil, err
 	}
 
+	keyID, key, err := queueSettings.encryptionKey()
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
 	if queueSettings.UseCompression {
 		options = options | ENABLE_COMPRESSION
 	}
 
+	sw, err := newSegmentWriter(file, options, keyID, key)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return sw, nil
+}
+
+// newSegmentWriter writes a segment header with the given options to file
+// and sets up the segmentWriter for them. Segments are encrypted with the
+// given key unless keyID is empty.
+func newSegmentWriter(file *os.File, options uint32, keyID string, key []byte) (*segmentWriter, error) {
+	if keyID != "" {
+		options = options | ENABLE_ENCRYPTION
+	} else {
+		options = options &^ ENABLE_ENCRYPTION
+	}
+
 	sw := &segmentWriter{}
 	sw.dst = file
 
@@ -284,8 +342,18 @@ func (segment *queueSegment) getWriter(queueSettings Settings, paths *paths.Path
 		return nil, err
 	}
 
+	var dst WriteCloseSyncer = sw.dst
+	if (options & ENABLE_ENCRYPTION) == ENABLE_ENCRYPTION {
+		var err error
+		sw.ew, err = NewEncryptionWriter(sw.dst, keyID, key)
+		if err != nil {
+			return nil, err
+		}
+		dst = sw.ew
+	}
+
 	if (options & ENABLE_COMPRESSION) == ENABLE_COMPRESSION {
-		sw.cw = NewCompressionWriter(sw.dst)
+		sw.cw = NewCompressionWriter(dst)
 	}
 
 	return sw, nil
@@ -444,6 +512,7 @@ func (segments *diskQueueSegments) sizeOnDisk() uint64 {
 // less compressable.
 type segmentReader struct {
 	src                 io.ReadSeekCloser
+	er                  *EncryptionReader
 	cr                  *CompressionReader
 	serializationFormat SerializationFormat
 }
@@ -452,6 +521,9 @@ func (r *segmentReader) Read(p []byte) (int, error) {
 	if r.cr != nil {
 		return r.cr.Read(p)
 	}
+	if r.er != nil {
+		return r.er.Read(p)
+	}
 	return r.src.Read(p)
 }
 
@@ -459,25 +531,35 @@ func (r *segmentReader) Close() error {
 	if r.cr != nil {
 		return r.cr.Close()
 	}
+	if r.er != nil {
+		return r.er.Close()
+	}
 	return r.src.Close()
 }
 
 func (r *segmentReader) Seek(offset int64, whence int) (int64, error) {
-	if r.cr != nil {
-		//can't seek before segment header
-		if (offset + int64(whence)) < segmentHeaderSize {
-			return 0, fmt.Errorf("illegal seek offset %d, whence %d", offset, whence)
-		}
-		if _, err := r.src.Seek(segmentHeaderSize, io.SeekStart); err != nil {
-			return 0, fmt.Errorf("could not seek past segment header: %w", err)
+	if r.cr == nil && r.er == nil {
+		return r.src.Seek(offset, whence)
+	}
+	//can't seek before segment header
+	if (offset + int64(whence)) < segmentHeaderSize {
+		return 0, fmt.Errorf("illegal seek offset %d, whence %d", offset, whence)
+	}
+	if _, err := r.src.Seek(segmentHeaderSize, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("could not seek past segment header: %w", err)
+	}
+	if r.er != nil {
+		if err := r.er.Reset(); err != nil {
+			return 0, fmt.Errorf("could not reset encryption: %w", err)
 		}
+	}
+	if r.cr != nil {
 		if err := r.cr.Reset(); err != nil {
 			return 0, fmt.Errorf("could not reset compression: %w", err)
 		}
-		written, err := io.CopyN(io.Discard, r.cr, (offset+int64(whence))-segmentHeaderSize)
-		return written + segmentHeaderSize, err
 	}
-	return r.src.Seek(offset, whence)
+	written, err := io.CopyN(io.Discard, r, (offset+int64(whence))-segmentHeaderSize)
+	return written + segmentHeaderSize, err
 }
 
 // segmentWriter handles writing of segments.  With Schema version 2
@@ -489,6 +571,7 @@ func (r *segmentReader) Seek(offset int64, whence int) (int64, error) {
 // data less compressable.
 type segmentWriter struct {
 	dst *os.File
+	ew  *EncryptionWriter
 	cw  *CompressionWriter
 }
 
@@ -496,6 +579,9 @@ func (w *segmentWriter) Write(p []byte) (int, error) {
 	if w.cw != nil {
 		return w.cw.Write(p)
 	}
+	if w.ew != nil {
+		return w.ew.Write(p)
+	}
 	return w.dst.Write(p)
 }
 
@@ -503,6 +589,9 @@ func (w *segmentWriter) Close() error {
 	if w.cw != nil {
 		return w.cw.Close()
 	}
+	if w.ew != nil {
+		return w.ew.Close()
+	}
 	return w.dst.Close()
 }
 
@@ -510,6 +599,9 @@ func (w *segmentWriter) Sync() error {
 	if w.cw != nil {
 		return w.cw.Sync()
 	}
+	if w.ew != nil {
+		return w.ew.Sync()
+	}
 	return w.dst.Sync()
 }
 
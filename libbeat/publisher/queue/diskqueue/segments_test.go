@@ -19,14 +19,17 @@ package diskqueue
 
 import (
 	"io"
+	"os"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestSegmentsRoundTrip(t *testing.T) {
 	tests := map[string]struct {
 		id        segmentID
+		encrypt   bool
 		compress  bool
 		plaintext []byte
 	}{
@@ -35,11 +38,22 @@ func TestSegmentsRoundTrip(t *testing.T) {
 			compress:  false,
 			plaintext: []byte("no encryption or compression"),
 		},
+		"With Encryption": {
+			id:        1,
+			encrypt:   true,
+			plaintext: []byte("encryption only"),
+		},
 		"With Compression": {
 			id:        2,
 			compress:  true,
 			plaintext: []byte("compression only"),
 		},
+		"With Encryption and Compression": {
+			id:        3,
+			encrypt:   true,
+			compress:  true,
+			plaintext: []byte("encryption and compression"),
+		},
 	}
 	dir := t.TempDir()
 	for name, tc := range tests {
@@ -47,6 +61,10 @@ func TestSegmentsRoundTrip(t *testing.T) {
 		settings := DefaultSettings()
 		settings.Path = dir
 		settings.UseCompression = tc.compress
+		if tc.encrypt {
+			settings.EncryptionKeys = map[string][]byte{"key1": []byte("secret")}
+			settings.EncryptionKeyID = "key1"
+		}
 		qs := &queueSegment{
 			id: tc.id,
 		}
@@ -83,6 +101,7 @@ func TestSegmentsRoundTrip(t *testing.T) {
 func TestSegmentReaderSeek(t *testing.T) {
 	tests := map[string]struct {
 		id         segmentID
+		encrypt    bool
 		compress   bool
 		plaintexts [][]byte
 	}{
@@ -91,17 +110,32 @@ func TestSegmentReaderSeek(t *testing.T) {
 			compress:   false,
 			plaintexts: [][]byte{[]byte("abc"), []byte("defg")},
 		},
+		"With Encryption": {
+			id:         1,
+			encrypt:    true,
+			plaintexts: [][]byte{[]byte("abc"), []byte("defg")},
+		},
 		"With Compression": {
 			id:         2,
 			compress:   true,
 			plaintexts: [][]byte{[]byte("abc"), []byte("defg")},
 		},
+		"With Encryption and Compression": {
+			id:         3,
+			encrypt:    true,
+			compress:   true,
+			plaintexts: [][]byte{[]byte("abc"), []byte("defg")},
+		},
 	}
 	dir := t.TempDir()
 	for name, tc := range tests {
 		settings := DefaultSettings()
 		settings.Path = dir
 		settings.UseCompression = tc.compress
+		if tc.encrypt {
+			settings.EncryptionKeys = map[string][]byte{"key1": []byte("secret")}
+			settings.EncryptionKeyID = "key1"
+		}
 
 		qs := &queueSegment{
 			id: tc.id,
@@ -135,6 +169,7 @@ func TestSegmentReaderSeek(t *testing.T) {
 func TestSegmentReaderSeekLocations(t *testing.T) {
 	tests := map[string]struct {
 		id         segmentID
+		encrypt    bool
 		compress   bool
 		plaintexts [][]byte
 		location   int64
@@ -145,6 +180,12 @@ func TestSegmentReaderSeekLocations(t *testing.T) {
 			plaintexts: [][]byte{[]byte("abc"), []byte("defg")},
 			location:   -1,
 		},
+		"Encryption": {
+			id:         2,
+			encrypt:    true,
+			plaintexts: [][]byte{[]byte("abc"), []byte("defg")},
+			location:   2,
+		},
 		"Compression": {
 			id:         1,
 			compress:   true,
@@ -157,6 +198,10 @@ func TestSegmentReaderSeekLocations(t *testing.T) {
 		settings := DefaultSettings()
 		settings.Path = dir
 		settings.UseCompression = tc.compress
+		if tc.encrypt {
+			settings.EncryptionKeys = map[string][]byte{"key1": []byte("secret")}
+			settings.EncryptionKeyID = "key1"
+		}
 		qs := &queueSegment{
 			id: tc.id,
 		}
@@ -175,3 +220,53 @@ func TestSegmentReaderSeekLocations(t *testing.T) {
 		assert.Error(t, err, name)
 	}
 }
+
+func TestSegmentReaderUnknownKey(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Path = t.TempDir()
+	settings.EncryptionKeys = map[string][]byte{"key1": []byte("secret")}
+	settings.EncryptionKeyID = "key1"
+
+	qs := &queueSegment{id: 0}
+	sw, err := qs.getWriter(settings, nil)
+	require.NoError(t, err)
+	_, err = sw.Write([]byte("abc"))
+	require.NoError(t, err)
+	require.NoError(t, sw.Close())
+
+	settings.EncryptionKeys = map[string][]byte{"key2": []byte("other")}
+	settings.EncryptionKeyID = "key2"
+	_, err = qs.getReader(settings, nil)
+	assert.ErrorContains(t, err, `segment is encrypted with unknown key "key1"`)
+}
+
+func TestSegmentReaderTamperedData(t *testing.T) {
+	settings := DefaultSettings()
+	settings.Path = t.TempDir()
+	settings.EncryptionKeys = map[string][]byte{"key1": []byte("secret")}
+	settings.EncryptionKeyID = "key1"
+
+	qs := &queueSegment{id: 0}
+	sw, err := qs.getWriter(settings, nil)
+	require.NoError(t, err)
+	_, err = sw.Write([]byte("abc"))
+	require.NoError(t, err)
+	require.NoError(t, sw.Close())
+
+	path := settings.segmentPath(qs.id, nil)
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	size, err := encryptedDataSize(path)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), size)
+
+	// Flip a bit of the ciphertext, the last byte before the tag.
+	data[len(data)-gcmTagSize-1] ^= 1
+	require.NoError(t, os.WriteFile(path, data, 0600))
+
+	sr, err := qs.getReader(settings, nil)
+	require.NoError(t, err)
+	defer sr.Close()
+	_, err = io.ReadAll(sr)
+	assert.ErrorIs(t, err, errEncryptedChunk)
+}
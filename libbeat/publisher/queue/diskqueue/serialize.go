@@ -60,6 +60,7 @@ type entry struct {
 	Flags     uint32
 	Meta      mapstr.M
 	Fields    mapstr.M
+	TraceID   string `struct:",omitempty"`
 }
 
 func newEventEncoder(format SerializationFormat) *eventEncoder {
@@ -107,6 +108,7 @@ func (e *eventEncoder) encode_publisher_event(event publisher.Event) ([]byte, er
 		Flags:     uint32(event.Flags),
 		Meta:      event.Content.Meta,
 		Fields:    event.Content.Fields,
+		TraceID:   event.TraceID,
 	})
 	if err != nil {
 		e.reset()
@@ -181,7 +183,8 @@ func (d *eventDecoder) decodeJSONAndCBOR() (publisher.Event, error) {
 	}
 
 	return publisher.Event{
-		Flags: publisher.EventFlags(to.Flags), //nolint:gosec // Flags field is uint32 on wire but valid values fit uint8
+		Flags:   publisher.EventFlags(to.Flags), //nolint:gosec // Flags field is uint32 on wire but valid values fit uint8
+		TraceID: to.TraceID,
 		Content: beat.Event{
 			Timestamp: time.Unix(0, to.Timestamp),
 			Fields:    to.Fields,
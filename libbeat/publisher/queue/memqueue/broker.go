@@ -21,6 +21,7 @@ import (
 	"context"
 	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/elastic/beats/v7/libbeat/publisher/queue"
@@ -62,6 +63,14 @@ type broker[T any] struct {
 	// Producers send requests to pushChan to add events to the queue.
 	pushChan chan pushRequest[T]
 
+	// High priority producers send requests to priorityPushChan. They are
+	// handled before the requests in pushChan, and can use the part of the
+	// queue reserved for them while there are high priority producers.
+	priorityPushChan chan pushRequest[T]
+
+	// The number of open high priority producers.
+	priorityProducers atomic.Int64
+
 	// Consumers send requests to getChan to read events from the queue.
 	getChan chan getRequest[T]
 
@@ -106,6 +115,15 @@ type Settings struct {
 	// If positive, the amount of time the queue will wait to fill up
 	// a batch if a Get request asks for more events than we have.
 	FlushTimeout time.Duration
+
+	// The number of events only high priority producers can add to the
+	// queue while any of them is open. If 0, a tenth of the queue is reserved.
+	PriorityReservedEvents int
+
+	// The number of high priority events accepted in a row while normal
+	// events are waiting, before a normal event is accepted. If 0, normal
+	// events wait until no high priority event is waiting.
+	PriorityMaxConsecutive int
 }
 
 type queueEntry[T any] struct {
@@ -215,6 +233,13 @@ func newQueue[T any](
 		settings.MaxGetRequest = settings.Events
 	}
 
+	if settings.PriorityReservedEvents <= 0 {
+		settings.PriorityReservedEvents = settings.Events / 10
+	}
+	if settings.PriorityReservedEvents >= settings.Events {
+		settings.PriorityReservedEvents = settings.Events - 1
+	}
+
 	if logger == nil {
 		logger = logp.NewLogger("memqueue")
 	} else {
@@ -230,9 +255,10 @@ func newQueue[T any](
 		encoderFactory: encoderFactory,
 
 		// broker API channels
-		pushChan:  make(chan pushRequest[T], chanSize),
-		getChan:   make(chan getRequest[T]),
-		closeChan: make(chan bool),
+		pushChan:         make(chan pushRequest[T], chanSize),
+		priorityPushChan: make(chan pushRequest[T], chanSize),
+		getChan:          make(chan getRequest[T]),
+		closeChan:        make(chan bool),
 
 		// internal runLoop and ackLoop channels
 		consumedChan: make(chan batchList[T]),
@@ -280,7 +306,7 @@ func (b *broker[T]) Producer(cfg queue.ProducerConfig) queue.Producer[T] {
 	if b.encoderFactory != nil {
 		encoder = b.encoderFactory()
 	}
-	return newProducer(b, cfg.ACK, encoder)
+	return newProducer(b, cfg.ACK, encoder, cfg.HighPriority)
 }
 
 func (b *broker[T]) Get(count int) (queue.Batch[T], error) {
@@ -35,18 +35,35 @@ type config struct {
 	// since it used to control buffer size in the internal buffer chain.
 	MaxGetRequest int           `config:"flush.min_events" validate:"min=0"`
 	FlushTimeout  time.Duration `config:"flush.timeout"`
+
+	Priority priorityConfig `config:"priority"`
+}
+
+type priorityConfig struct {
+	// ReservedEvents is the part of the queue only high priority events can
+	// use. If 0, a tenth of the queue is reserved.
+	ReservedEvents int `config:"reserved_events" validate:"min=0"`
+	// MaxConsecutive is the number of high priority events accepted in a row
+	// while normal events are waiting, before a normal event is accepted.
+	MaxConsecutive int `config:"max_consecutive" validate:"min=1"`
 }
 
 var defaultConfig = config{
 	Events:        DefaultEvents,
 	MaxGetRequest: 1600,
 	FlushTimeout:  10 * time.Second,
+	Priority: priorityConfig{
+		MaxConsecutive: 16,
+	},
 }
 
 func (c *config) Validate() error {
 	if c.MaxGetRequest > c.Events {
 		return errors.New("flush.min_events must be less events")
 	}
+	if c.Priority.ReservedEvents >= c.Events {
+		return errors.New("priority.reserved_events must be less than events")
+	}
 	return nil
 }
 
@@ -64,5 +81,8 @@ func SettingsForUserConfig(cfg *c.C) (Settings, error) {
 		Events:        config.Events,
 		MaxGetRequest: config.MaxGetRequest,
 		FlushTimeout:  config.FlushTimeout,
+
+		PriorityReservedEvents: config.Priority.ReservedEvents,
+		PriorityMaxConsecutive: config.Priority.MaxConsecutive,
 	}, nil
 }
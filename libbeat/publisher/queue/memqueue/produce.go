@@ -18,6 +18,8 @@
 package memqueue
 
 import (
+	"sync/atomic"
+
 	"github.com/elastic/beats/v7/libbeat/publisher/queue"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
@@ -46,6 +48,10 @@ type openState[T any] struct {
 	// reused across publishes. Publish is synchronous, so only one
 	// request is outstanding at a time.
 	resp chan queue.EntryID
+
+	// priorityProducers is set for high priority producers, to report when
+	// the producer is closed.
+	priorityProducers *atomic.Int64
 }
 
 // producerID stores the order of events within a single producer, so multiple
@@ -61,7 +67,7 @@ type produceState struct {
 
 type ackHandler func(count int)
 
-func newProducer[T any](b *broker[T], cb ackHandler, encoder queue.Encoder[T]) queue.Producer[T] {
+func newProducer[T any](b *broker[T], cb ackHandler, encoder queue.Encoder[T], highPriority bool) queue.Producer[T] {
 	openState := openState[T]{
 		log:          b.logger,
 		done:         make(chan struct{}),
@@ -70,6 +76,11 @@ func newProducer[T any](b *broker[T], cb ackHandler, encoder queue.Encoder[T]) q
 		encoder:      encoder,
 		resp:         make(chan queue.EntryID, 1),
 	}
+	if highPriority {
+		openState.events = b.priorityPushChan
+		openState.priorityProducers = &b.priorityProducers
+		b.priorityProducers.Add(1)
+	}
 
 	if cb != nil {
 		p := &ackProducer[T]{broker: b, openState: openState}
@@ -129,6 +140,9 @@ func (p *ackProducer[T]) Close() {
 
 func (st *openState[T]) Close() {
 	close(st.done)
+	if st.priorityProducers != nil {
+		st.priorityProducers.Add(-1)
+	}
 }
 
 func (st *openState[T]) publish(req pushRequest[T]) (queue.EntryID, bool) {
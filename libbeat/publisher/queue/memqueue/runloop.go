@@ -65,6 +65,11 @@ type runLoop[T any] struct {
 	// to Gets and Acks to allow pending events to complete on shutdown.
 	closing bool
 
+	// The number of high priority events accepted in a row since the last
+	// normal event, used to keep high priority producers from starving the
+	// normal ones.
+	priorityStreak int
+
 	// TODO (https://github.com/elastic/beats/issues/37893): entry IDs were a
 	// workaround for an external project that no longer exists. At this point
 	// they just complicate the API and should be removed.
@@ -98,10 +103,30 @@ func (l *runLoop[T]) run() {
 // Perform one iteration of the queue's main run loop. Broken out into a
 // standalone helper function to allow testing of loop invariants.
 func (l *runLoop[T]) runIteration() {
-	var pushChan chan pushRequest[T]
-	// Push requests are enabled if the queue isn't full or closing.
-	if l.eventCount < len(l.broker.buf) && !l.closing {
-		pushChan = l.broker.pushChan
+	var pushChan, priorityPushChan chan pushRequest[T]
+	// Push requests are enabled if the queue isn't full or closing. Normal
+	// producers can't use the part of the queue reserved for high priority
+	// producers.
+	if !l.closing {
+		if l.eventCount < len(l.broker.buf)-l.reservedEvents() {
+			pushChan = l.broker.pushChan
+		}
+		if l.eventCount < len(l.broker.buf) {
+			priorityPushChan = l.broker.priorityPushChan
+		}
+	}
+
+	// High priority events are accepted before normal events, unless too
+	// many of them were accepted in a row while normal events are waiting.
+	if l.priorityStreakExceeded(pushChan) {
+		priorityPushChan = nil
+	} else if priorityPushChan != nil {
+		select {
+		case req := <-priorityPushChan:
+			l.handlePriorityInsert(&req)
+			return
+		default:
+		}
 	}
 
 	var getChan chan getRequest[T]
@@ -141,8 +166,12 @@ func (l *runLoop[T]) runIteration() {
 		return
 
 	case req := <-pushChan: // producer pushing new event
+		l.priorityStreak = 0
 		l.handleInsert(&req)
 
+	case req := <-priorityPushChan: // high priority producer pushing new event
+		l.handlePriorityInsert(&req)
+
 	case req := <-getChan: // consumer asking for next batch
 		l.handleGetRequest(&req)
 
@@ -227,6 +256,27 @@ func (l *runLoop[T]) handleDelete(count int) {
 	l.observer.RemoveEvents(count, byteCount)
 }
 
+// reservedEvents returns the number of events normal producers can't add to
+// the queue.
+func (l *runLoop[T]) reservedEvents() int {
+	if l.broker.priorityProducers.Load() == 0 {
+		return 0
+	}
+	return l.broker.settings.PriorityReservedEvents
+}
+
+// priorityStreakExceeded reports whether normal events waiting in pushChan
+// must be accepted before the next high priority event.
+func (l *runLoop[T]) priorityStreakExceeded(pushChan chan pushRequest[T]) bool {
+	maxStreak := l.broker.settings.PriorityMaxConsecutive
+	return maxStreak > 0 && l.priorityStreak >= maxStreak && len(pushChan) > 0
+}
+
+func (l *runLoop[T]) handlePriorityInsert(req *pushRequest[T]) {
+	l.priorityStreak++
+	l.handleInsert(req)
+}
+
 func (l *runLoop[T]) handleInsert(req *pushRequest[T]) {
 	l.insert(req, l.nextEntryID)
 	// Send back the new event id.
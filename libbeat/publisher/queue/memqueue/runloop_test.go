@@ -51,7 +51,7 @@ func TestFlushSettingsDoNotBlockFullBatches(t *testing.T) {
 		},
 		10, nil)
 
-	producer := newProducer(broker, nil, nil)
+	producer := newProducer(broker, nil, nil, false)
 	rl := broker.runLoop
 	// iterLock is used to ensure distinct runIteration calls can never overlap
 	iterLock := sync.Mutex{}
@@ -100,7 +100,7 @@ func TestFlushSettingsBlockPartialBatches(t *testing.T) {
 		},
 		10, nil)
 
-	producer := newProducer(broker, nil, nil)
+	producer := newProducer(broker, nil, nil, false)
 	rl := broker.runLoop
 	for i := 0; i < 100; i++ {
 		// Pair each publish call with an iteration of the run loop so we
@@ -231,6 +231,53 @@ func TestObserverRemoveEvents(t *testing.T) {
 	assertRegistryUint(t, reg, "queue.removed.bytes", deleteCount*123, "Deleting from the queue should report the removed bytes")
 }
 
+func TestPriorityProducers(t *testing.T) {
+	logger := logptest.NewTestingLogger(t, "")
+	broker := newQueue[string](
+		logger.Named("testing"),
+		nil,
+		Settings{
+			Events:                 10,
+			MaxGetRequest:          10,
+			PriorityReservedEvents: 2,
+			PriorityMaxConsecutive: 2,
+		},
+		10, nil)
+	producer := newProducer(broker, nil, nil, true)
+	rl := broker.runLoop
+
+	push := func(ch chan pushRequest[string], events ...string) {
+		for _, e := range events {
+			ch <- pushRequest[string]{event: e, resp: make(chan queue.EntryID, 1)}
+		}
+	}
+	queued := func() []string {
+		var events []string
+		for i := 0; i < rl.eventCount; i++ {
+			events = append(events, broker.buf[i].event)
+		}
+		return events
+	}
+
+	push(broker.pushChan, "n1", "n2", "n3", "n4", "n5")
+	push(broker.priorityPushChan, "p1", "p2", "p3", "p4", "p5")
+	for i := 0; i < 8; i++ {
+		rl.runIteration()
+	}
+	// High priority events go first, but a normal event is accepted after
+	// every two of them. Normal events can't use the last two entries.
+	assert.Equal(t, []string{"p1", "p2", "n1", "p3", "p4", "n2", "p5", "n3"}, queued())
+
+	push(broker.priorityPushChan, "p6", "p7")
+	rl.runIteration()
+	rl.runIteration()
+	assert.Equal(t, []string{"p1", "p2", "n1", "p3", "p4", "n2", "p5", "n3", "p6", "p7"}, queued())
+	assert.Len(t, broker.pushChan, 2, "Normal events must wait while the queue is full")
+
+	producer.Close()
+	assert.Zero(t, rl.reservedEvents(), "No events are reserved without high priority producers")
+}
+
 func assertRegistryUint(t *testing.T, reg *monitoring.Registry, key string, expected uint64, message string) {
 	t.Helper()
 
@@ -36,6 +36,11 @@ type Observer interface {
 	AddEvent(byteCount int)
 	ConsumeEvents(eventCount int, byteCount int)
 	RemoveEvents(eventCount int, byteCount int)
+
+	// DropEvents reports events the queue discarded without sending them to
+	// the outputs. Dropped events are still reported by RemoveEvents when
+	// they are removed from the queue.
+	DropEvents(eventCount int, byteCount int)
 }
 
 type queueObserver struct {
@@ -48,6 +53,8 @@ type queueObserver struct {
 	consumedBytes  *monitoring.Uint
 	removedEvents  *monitoring.Uint
 	removedBytes   *monitoring.Uint
+	droppedEvents  *monitoring.Uint
+	droppedBytes   *monitoring.Uint
 
 	filledEvents *monitoring.Uint  // gauge
 	filledBytes  *monitoring.Uint  // gauge
@@ -88,6 +95,8 @@ func NewQueueObserver(metrics *monitoring.Registry) Observer {
 		consumedBytes:  monitoring.NewUint(queueMetrics, "consumed.bytes"),
 		removedEvents:  monitoring.NewUint(queueMetrics, "removed.events"),
 		removedBytes:   monitoring.NewUint(queueMetrics, "removed.bytes"),
+		droppedEvents:  monitoring.NewUint(queueMetrics, "dropped.events"),
+		droppedBytes:   monitoring.NewUint(queueMetrics, "dropped.bytes"),
 
 		filledEvents: monitoring.NewUint(queueMetrics, "filled.events"), // gauge
 		filledBytes:  monitoring.NewUint(queueMetrics, "filled.bytes"),  // gauge
@@ -145,6 +154,11 @@ func (ob *queueObserver) RemoveEvents(eventCount int, byteCount int) {
 	ob.updateFilledPct()
 }
 
+func (ob *queueObserver) DropEvents(eventCount int, byteCount int) {
+	ob.droppedEvents.Add(uint64(eventCount))
+	ob.droppedBytes.Add(uint64(byteCount))
+}
+
 func (ob *queueObserver) updateFilledPct() {
 	if maxBytes := ob.maxBytes.Get(); maxBytes > 0 {
 		ob.filledPct.Set(float64(ob.filledBytes.Get()) / float64(maxBytes))
@@ -159,3 +173,4 @@ func (nilObserver) Restore(_ int, _ int)       {}
 func (nilObserver) AddEvent(_ int)             {}
 func (nilObserver) ConsumeEvents(_ int, _ int) {}
 func (nilObserver) RemoveEvents(_ int, _ int)  {}
+func (nilObserver) DropEvents(_ int, _ int)    {}
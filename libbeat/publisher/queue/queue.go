@@ -78,6 +78,10 @@ type ProducerConfig struct {
 	// if ACK is set, the callback will be called with number of events produced
 	// by the producer instance and being ACKed by the queue.
 	ACK func(count int)
+
+	// HighPriority selects the high priority lane of the queue for the events
+	// of this producer. Queues that don't support priorities ignore it.
+	HighPriority bool
 }
 
 type EntryID uint64
@@ -0,0 +1,95 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package selfupdate
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Config configures the self-update of a Beat that is not managed by
+// Elastic Agent.
+type Config struct {
+	Enabled bool `config:"enabled"`
+
+	// StagingPath is the directory where new artifacts and their signed
+	// manifest are staged. It defaults to the update directory in the data
+	// path.
+	StagingPath string `config:"staging_path"`
+
+	// PublicKeys are the Ed25519 keys trusted to sign manifests and
+	// executables, as base64 encoded raw keys or PEM encoded PKIX keys.
+	PublicKeys []string `config:"public_keys"`
+
+	// VerifyExecutable requires a valid detached signature next to the
+	// running executable before the Beat starts.
+	VerifyExecutable bool `config:"verify_executable"`
+
+	// AllowDowngrade applies staged updates whose version is not newer
+	// than the running version. Without it they are rejected, so that an
+	// older signed release can't be installed back.
+	AllowDowngrade bool `config:"allow_downgrade"`
+
+	// HealthyAfter is how long an updated Beat must run before the update
+	// is committed and the previous executable removed.
+	HealthyAfter time.Duration `config:"healthy_after"`
+
+	CrashLoop CrashLoopConfig `config:"crash_loop"`
+}
+
+// CrashLoopConfig defines when an update that keeps failing is rolled back.
+type CrashLoopConfig struct {
+	// MaxRestarts is the number of starts of an uncommitted update within
+	// Window after which the previous executable is restored.
+	MaxRestarts int           `config:"max_restarts"`
+	Window      time.Duration `config:"window"`
+}
+
+// DefaultConfig returns the default self-update configuration.
+func DefaultConfig() Config {
+	return Config{
+		HealthyAfter: 5 * time.Minute,
+		CrashLoop: CrashLoopConfig{
+			MaxRestarts: 3,
+			Window:      10 * time.Minute,
+		},
+	}
+}
+
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if len(c.PublicKeys) == 0 {
+		return errors.New("public_keys must not be empty")
+	}
+	if _, err := parsePublicKeys(c.PublicKeys); err != nil {
+		return err
+	}
+	if c.HealthyAfter <= 0 {
+		return fmt.Errorf("healthy_after must be positive, got %v", c.HealthyAfter)
+	}
+	if c.CrashLoop.MaxRestarts < 1 {
+		return fmt.Errorf("crash_loop.max_restarts must be at least 1, got %d", c.CrashLoop.MaxRestarts)
+	}
+	if c.CrashLoop.Window <= 0 {
+		return fmt.Errorf("crash_loop.window must be positive, got %v", c.CrashLoop.Window)
+	}
+	return nil
+}
@@ -0,0 +1,401 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package selfupdate applies signed updates that an external process
+// staged for a Beat that is not managed by Elastic Agent, and rolls them back
+// when the updated Beat keeps crashing.
+//
+// The staging directory holds the new executable, a manifest describing it
+// and the detached signature of the manifest. On startup the Beat verifies
+// the manifest, checks if it is part of the rollout, replaces its executable
+// and restarts. The previous executable is kept until the updated Beat has
+// run for the configured time.
+package selfupdate
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/file"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/version"
+)
+
+const (
+	stateFile  = "self_update.json"
+	stagingDir = "update"
+
+	backupExt = ".prev"
+)
+
+// Action is what the Beat must do after Start.
+type Action int
+
+const (
+	// Continue starts the Beat with the running executable.
+	Continue Action = iota
+	// Restart restarts the Beat to run a new or restored executable.
+	Restart
+)
+
+// state is persisted across restarts in the data path.
+type state struct {
+	// Pending is the applied update that is not committed yet.
+	Pending *pendingUpdate `json:"pending,omitempty"`
+	// Rejected are the SHA-256 digests of the artifacts that were rolled
+	// back. They are not applied again.
+	Rejected []string `json:"rejected,omitempty"`
+}
+
+type pendingUpdate struct {
+	Version string `json:"version"`
+	SHA256  string `json:"sha256"`
+	// Backup is the path of the previous executable.
+	Backup string      `json:"backup"`
+	Starts []time.Time `json:"starts,omitempty"`
+}
+
+// Updater applies staged updates and rolls back crash looping ones.
+type Updater struct {
+	log    *logp.Logger
+	config Config
+	keys   []ed25519.PublicKey
+	id     string
+	// version of the running Beat.
+	version string
+
+	executable  string
+	stagingPath string
+	statePath   string
+
+	// digest is the hex encoded SHA-256 digest of the running executable.
+	digest string
+
+	now func() time.Time
+}
+
+// New creates an Updater for the running executable. State is kept in
+// dataPath.
+func New(log *logp.Logger, cfg Config, info beat.Info, dataPath string) (*Updater, error) {
+	keys, err := parsePublicKeys(cfg.PublicKeys)
+	if err != nil {
+		return nil, err
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the path of the executable: %w", err)
+	}
+	if exe, err = filepath.EvalSymlinks(exe); err != nil {
+		return nil, fmt.Errorf("failed to resolve the path of the executable: %w", err)
+	}
+
+	stagingPath := cfg.StagingPath
+	if stagingPath == "" {
+		stagingPath = filepath.Join(dataPath, stagingDir)
+	}
+	return &Updater{
+		log:         log,
+		config:      cfg,
+		keys:        keys,
+		id:          info.ID.String(),
+		version:     info.Version,
+		executable:  exe,
+		stagingPath: stagingPath,
+		statePath:   filepath.Join(dataPath, stateFile),
+		now:         time.Now,
+	}, nil
+}
+
+// HealthyAfter returns how long the Beat must run before Confirm is called.
+func (u *Updater) HealthyAfter() time.Duration {
+	return u.config.HealthyAfter
+}
+
+// Start is called when the Beat starts. It rolls back an update that is
+// crash looping, verifies the running executable and applies a staged
+// update. An error is only returned if the Beat must not start.
+func (u *Updater) Start() (Action, error) {
+	st, err := u.loadState()
+	if err != nil {
+		return Continue, err
+	}
+	digest, err := fileSHA256(u.executable)
+	if err != nil {
+		return Continue, fmt.Errorf("failed to hash executable: %w", err)
+	}
+	u.digest = hex.EncodeToString(digest)
+
+	if p := st.Pending; p != nil {
+		if p.SHA256 != u.digest {
+			// The executable was replaced by someone else, e.g. a package
+			// manager. The backup doesn't belong to it anymore.
+			u.log.Warnf("Executable changed while the update to %s was pending, discarding the update state.", p.Version)
+			st.Pending = nil
+		} else {
+			since := u.now().Add(-u.config.CrashLoop.Window)
+			p.Starts = slices.DeleteFunc(p.Starts, func(t time.Time) bool { return t.Before(since) })
+			p.Starts = append(p.Starts, u.now())
+			if len(p.Starts)-1 >= u.config.CrashLoop.MaxRestarts {
+				return Restart, u.rollback(st)
+			}
+		}
+		if err := u.saveState(st); err != nil {
+			return Continue, err
+		}
+	}
+
+	if u.config.VerifyExecutable {
+		if err := verifyExecutable(u.executable, u.keys); err != nil {
+			return Continue, fmt.Errorf("executable verification failed: %w", err)
+		}
+	}
+	if st.Pending != nil {
+		// Updates are not stacked, the pending one must be committed first.
+		return Continue, nil
+	}
+
+	m, err := readManifest(u.stagingPath, u.keys)
+	if err != nil {
+		// A bad staged update must not stop the running Beat.
+		u.log.Errorf("Ignoring staged update: %v", err)
+		return Continue, nil
+	}
+	if m == nil || m.SHA256 == u.digest || slices.Contains(st.Rejected, m.SHA256) {
+		return Continue, nil
+	}
+	if !u.config.AllowDowngrade && !u.isNewer(m) {
+		u.log.Errorf("Ignoring staged update to %s, it is not newer than the running version %s and allow_downgrade is not set.", m.Version, u.version)
+		return Continue, nil
+	}
+	if !u.inRollout(m) {
+		u.log.Infof("Update to %s is rolled out to %d%% of the Beats, this Beat is not included.", m.Version, m.rolloutPercentage())
+		return Continue, nil
+	}
+	if err := u.apply(m, st); err != nil {
+		u.log.Errorf("Failed to apply update to %s: %v", m.Version, err)
+		return Continue, nil
+	}
+	return Restart, nil
+}
+
+// Confirm commits the pending update once the Beat ran long enough. The
+// previous executable is removed.
+func (u *Updater) Confirm() error {
+	st, err := u.loadState()
+	if err != nil {
+		return err
+	}
+	p := st.Pending
+	if p == nil || p.SHA256 != u.digest {
+		return nil
+	}
+	if err := os.Remove(p.Backup); err != nil && !errors.Is(err, os.ErrNotExist) {
+		u.log.Warnf("Failed to remove previous executable %s: %v", p.Backup, err)
+	}
+	_ = os.Remove(p.Backup + signatureExt)
+	st.Pending = nil
+	if err := u.saveState(st); err != nil {
+		return err
+	}
+	u.log.Infof("Update to %s committed.", p.Version)
+	return nil
+}
+
+// isNewer returns whether the version of the update is newer than the running
+// version. A pre-release is older than the release of the same version.
+func (u *Updater) isNewer(m *Manifest) bool {
+	running, err := version.New(u.version)
+	if err != nil {
+		// The version of a Beat is always valid, refuse the update if
+		// it can't be compared.
+		u.log.Errorf("Failed to parse the running version %q: %v", u.version, err)
+		return false
+	}
+	staged, err := version.New(m.Version)
+	if err != nil {
+		return false
+	}
+	return !staged.LessThanOrEqual(true, running)
+}
+
+// inRollout returns whether the Beat is in the rollout of the update. The
+// Beats are assigned to a percentile from their ID and the version, so
+// raising the percentage of a release only adds Beats to it.
+func (u *Updater) inRollout(m *Manifest) bool {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(u.id))
+	_, _ = h.Write([]byte(m.Version))
+	return int(h.Sum32()%100) < m.rolloutPercentage()
+}
+
+// apply replaces the executable with the staged artifact. The previous
+// executable is kept as a backup for the rollback.
+func (u *Updater) apply(m *Manifest, st *state) error {
+	// Copy the artifact next to the executable so that it's moved in place
+	// atomically. The copy is checked rather than the staged artifact, which
+	// could be replaced after it is checked.
+	artifact := filepath.Join(u.stagingPath, m.Artifact)
+	tmp := u.executable + ".new"
+	removeTmp := func() {
+		_ = os.Remove(tmp)
+		_ = os.Remove(tmp + signatureExt)
+	}
+	if err := copyFile(tmp, artifact); err != nil {
+		removeTmp()
+		return fmt.Errorf("failed to copy artifact: %w", err)
+	}
+	if u.config.VerifyExecutable {
+		if err := copyFile(tmp+signatureExt, artifact+signatureExt); err != nil {
+			removeTmp()
+			return fmt.Errorf("failed to copy the artifact signature: %w", err)
+		}
+	}
+
+	digest, err := fileSHA256(tmp)
+	if err != nil {
+		removeTmp()
+		return fmt.Errorf("failed to hash artifact: %w", err)
+	}
+	if want, _ := hex.DecodeString(m.SHA256); !bytes.Equal(digest, want) {
+		removeTmp()
+		return fmt.Errorf("artifact %s does not match the manifest digest", artifact)
+	}
+	if u.config.VerifyExecutable {
+		// The updated Beat would refuse to start.
+		if err := verifyExecutable(tmp, u.keys); err != nil {
+			removeTmp()
+			return fmt.Errorf("artifact verification failed: %w", err)
+		}
+	}
+
+	backup := u.executable + backupExt
+	if err := os.Rename(u.executable, backup); err != nil {
+		removeTmp()
+		return fmt.Errorf("failed to back up executable: %w", err)
+	}
+	if err := os.Rename(tmp, u.executable); err != nil {
+		removeTmp()
+		if rerr := os.Rename(backup, u.executable); rerr != nil {
+			u.log.Errorf("Failed to restore executable from %s: %v", backup, rerr)
+		}
+		return fmt.Errorf("failed to replace executable: %w", err)
+	}
+	if u.config.VerifyExecutable {
+		sig := u.executable + signatureExt
+		_ = os.Rename(sig, backup+signatureExt)
+		if err := os.Rename(tmp+signatureExt, sig); err != nil {
+			u.log.Errorf("Failed to install the artifact signature: %v", err)
+		}
+	}
+
+	st.Pending = &pendingUpdate{
+		Version: m.Version,
+		SHA256:  m.SHA256,
+		Backup:  backup,
+	}
+	if err := u.saveState(st); err != nil {
+		return err
+	}
+	u.log.Infof("Updated executable from %s to %s, restarting.", u.version, m.Version)
+	return nil
+}
+
+// rollback restores the previous executable and rejects the pending update.
+func (u *Updater) rollback(st *state) error {
+	p := st.Pending
+	u.log.Errorf("Update to %s restarted %d times within %v, rolling back to %s.",
+		p.Version, len(p.Starts)-1, u.config.CrashLoop.Window, p.Backup)
+
+	// A running executable can't be overwritten on Windows, but it can be
+	// renamed.
+	failed := u.executable + ".failed"
+	if err := os.Rename(u.executable, failed); err != nil {
+		return fmt.Errorf("failed to move executable: %w", err)
+	}
+	if err := os.Rename(p.Backup, u.executable); err != nil {
+		_ = os.Rename(failed, u.executable)
+		return fmt.Errorf("failed to restore executable: %w", err)
+	}
+	_ = os.Remove(failed)
+	if _, err := os.Stat(p.Backup + signatureExt); err == nil {
+		_ = os.Rename(p.Backup+signatureExt, u.executable+signatureExt)
+	}
+
+	st.Rejected = append(st.Rejected, p.SHA256)
+	st.Pending = nil
+	return u.saveState(st)
+}
+
+func (u *Updater) loadState() (*state, error) {
+	var st state
+	data, err := os.ReadFile(u.statePath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return &st, nil
+		}
+		return nil, fmt.Errorf("failed to read self-update state: %w", err)
+	}
+	if err := json.Unmarshal(data, &st); err != nil {
+		return nil, fmt.Errorf("failed to parse self-update state %s: %w", u.statePath, err)
+	}
+	return &st, nil
+}
+
+func (u *Updater) saveState(st *state) error {
+	data, err := json.Marshal(st)
+	if err != nil {
+		return err
+	}
+	tmp := u.statePath + ".new"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write self-update state: %w", err)
+	}
+	return file.SafeFileRotate(u.statePath, tmp)
+}
+
+func copyFile(dst, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o755)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err := out.Sync(); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
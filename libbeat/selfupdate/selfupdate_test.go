@@ -0,0 +1,301 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package selfupdate
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+type testEnv struct {
+	dir     string
+	exe     string
+	staging string
+	priv    ed25519.PrivateKey
+	now     time.Time
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+	dir := t.TempDir()
+	env := &testEnv{
+		dir:     dir,
+		exe:     filepath.Join(dir, "beat"),
+		staging: filepath.Join(dir, "data", stagingDir),
+		priv:    priv,
+		now:     time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+	}
+	require.NoError(t, os.MkdirAll(env.staging, 0o755))
+	require.NoError(t, os.WriteFile(env.exe, []byte("beat 9.4.0"), 0o755))
+	return env
+}
+
+func (e *testEnv) updater(t *testing.T, cfg Config) *Updater {
+	t.Helper()
+	cfg.Enabled = true
+	cfg.PublicKeys = []string{base64.StdEncoding.EncodeToString(e.priv.Public().(ed25519.PublicKey))}
+	require.NoError(t, cfg.Validate())
+	keys, err := parsePublicKeys(cfg.PublicKeys)
+	require.NoError(t, err)
+	return &Updater{
+		log:         logptest.NewTestingLogger(t, ""),
+		config:      cfg,
+		keys:        keys,
+		id:          "0b7a3b1e-8d8c-4f8e-a3b4-7c0d9e4e5f6a",
+		version:     "9.4.0",
+		executable:  e.exe,
+		stagingPath: e.staging,
+		statePath:   filepath.Join(e.dir, "data", stateFile),
+		now:         func() time.Time { return e.now },
+	}
+}
+
+func (e *testEnv) sign(t *testing.T, path string, data []byte) {
+	t.Helper()
+	sig := base64.StdEncoding.EncodeToString(ed25519.Sign(e.priv, data))
+	require.NoError(t, os.WriteFile(path+signatureExt, []byte(sig), 0o644))
+}
+
+func (e *testEnv) stage(t *testing.T, version string, content []byte, percentage *int) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(filepath.Join(e.staging, "beat-"+version), content, 0o755))
+	digest := sha256.Sum256(content)
+	e.sign(t, filepath.Join(e.staging, "beat-"+version), digest[:])
+	m, err := json.Marshal(Manifest{
+		Version:           version,
+		Artifact:          "beat-" + version,
+		SHA256:            hex.EncodeToString(digest[:]),
+		RolloutPercentage: percentage,
+	})
+	require.NoError(t, err)
+	path := filepath.Join(e.staging, manifestFile)
+	require.NoError(t, os.WriteFile(path, m, 0o644))
+	e.sign(t, path, m)
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(b)
+}
+
+func TestUpdateAndCommit(t *testing.T) {
+	env := newTestEnv(t)
+	env.stage(t, "9.4.1", []byte("beat 9.4.1"), nil)
+
+	action, err := env.updater(t, DefaultConfig()).Start()
+	require.NoError(t, err)
+	assert.Equal(t, Restart, action)
+	assert.Equal(t, "beat 9.4.1", readFile(t, env.exe))
+	assert.Equal(t, "beat 9.4.0", readFile(t, env.exe+backupExt))
+
+	// The restarted Beat runs the update and commits it once healthy.
+	u := env.updater(t, DefaultConfig())
+	u.version = "9.4.1"
+	action, err = u.Start()
+	require.NoError(t, err)
+	assert.Equal(t, Continue, action)
+	require.NoError(t, u.Confirm())
+	assert.NoFileExists(t, env.exe+backupExt)
+
+	st, err := u.loadState()
+	require.NoError(t, err)
+	assert.Nil(t, st.Pending)
+
+	// The staged update is already applied.
+	action, err = u.Start()
+	require.NoError(t, err)
+	assert.Equal(t, Continue, action)
+}
+
+func TestRollbackOnCrashLoop(t *testing.T) {
+	env := newTestEnv(t)
+	env.stage(t, "9.4.1", []byte("beat 9.4.1"), nil)
+	cfg := DefaultConfig()
+
+	action, err := env.updater(t, cfg).Start()
+	require.NoError(t, err)
+	require.Equal(t, Restart, action)
+
+	// The updated Beat crashes before it is healthy.
+	for i := 0; i < cfg.CrashLoop.MaxRestarts; i++ {
+		action, err = env.updater(t, cfg).Start()
+		require.NoError(t, err)
+		require.Equal(t, Continue, action)
+		env.now = env.now.Add(time.Minute)
+	}
+	action, err = env.updater(t, cfg).Start()
+	require.NoError(t, err)
+	assert.Equal(t, Restart, action)
+	assert.Equal(t, "beat 9.4.0", readFile(t, env.exe))
+	assert.NoFileExists(t, env.exe+backupExt)
+
+	// The rolled back update is not applied again.
+	action, err = env.updater(t, cfg).Start()
+	require.NoError(t, err)
+	assert.Equal(t, Continue, action)
+	assert.Equal(t, "beat 9.4.0", readFile(t, env.exe))
+}
+
+func TestRestartsOutsideWindow(t *testing.T) {
+	env := newTestEnv(t)
+	env.stage(t, "9.4.1", []byte("beat 9.4.1"), nil)
+	cfg := DefaultConfig()
+
+	_, err := env.updater(t, cfg).Start()
+	require.NoError(t, err)
+	for i := 0; i < 2*cfg.CrashLoop.MaxRestarts; i++ {
+		action, err := env.updater(t, cfg).Start()
+		require.NoError(t, err)
+		require.Equal(t, Continue, action)
+		env.now = env.now.Add(cfg.CrashLoop.Window)
+	}
+	assert.Equal(t, "beat 9.4.1", readFile(t, env.exe))
+}
+
+func TestRolloutPercentage(t *testing.T) {
+	env := newTestEnv(t)
+	zero := 0
+	env.stage(t, "9.4.1", []byte("beat 9.4.1"), &zero)
+
+	action, err := env.updater(t, DefaultConfig()).Start()
+	require.NoError(t, err)
+	assert.Equal(t, Continue, action)
+	assert.Equal(t, "beat 9.4.0", readFile(t, env.exe))
+
+	// Raising the percentage only adds Beats to the rollout.
+	u := env.updater(t, DefaultConfig())
+	m := &Manifest{Version: "9.4.1"}
+	included := -1
+	for p := 0; p <= 100; p++ {
+		m.RolloutPercentage = &p
+		if u.inRollout(m) {
+			included = p
+			break
+		}
+	}
+	require.GreaterOrEqual(t, included, 1)
+	for p := included; p <= 100; p++ {
+		m.RolloutPercentage = &p
+		assert.True(t, u.inRollout(m), "percentage %d", p)
+	}
+}
+
+func TestRejectInvalidUpdates(t *testing.T) {
+	t.Run("manifest signature", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.stage(t, "9.4.1", []byte("beat 9.4.1"), nil)
+		_, other, err := ed25519.GenerateKey(nil)
+		require.NoError(t, err)
+		env.priv = other
+
+		action, err := env.updater(t, DefaultConfig()).Start()
+		require.NoError(t, err)
+		assert.Equal(t, Continue, action)
+		assert.Equal(t, "beat 9.4.0", readFile(t, env.exe))
+	})
+
+	t.Run("artifact digest", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.stage(t, "9.4.1", []byte("beat 9.4.1"), nil)
+		require.NoError(t, os.WriteFile(filepath.Join(env.staging, "beat-9.4.1"), []byte("tampered"), 0o755))
+
+		action, err := env.updater(t, DefaultConfig()).Start()
+		require.NoError(t, err)
+		assert.Equal(t, Continue, action)
+		assert.Equal(t, "beat 9.4.0", readFile(t, env.exe))
+		// The copy of the artifact that was checked is removed.
+		assert.NoFileExists(t, env.exe+".new")
+		assert.NoFileExists(t, env.exe+".new"+signatureExt)
+	})
+}
+
+func TestRejectDowngrade(t *testing.T) {
+	for _, v := range []string{"9.4.0", "9.3.9", "9.4.0-SNAPSHOT", "8.19.0"} {
+		t.Run(v, func(t *testing.T) {
+			env := newTestEnv(t)
+			env.stage(t, v, []byte("beat "+v), nil)
+
+			action, err := env.updater(t, DefaultConfig()).Start()
+			require.NoError(t, err)
+			assert.Equal(t, Continue, action)
+			assert.Equal(t, "beat 9.4.0", readFile(t, env.exe), "an update that is not newer must not be applied")
+		})
+	}
+
+	t.Run("allow_downgrade", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.stage(t, "9.3.9", []byte("beat 9.3.9"), nil)
+		cfg := DefaultConfig()
+		cfg.AllowDowngrade = true
+
+		action, err := env.updater(t, cfg).Start()
+		require.NoError(t, err)
+		assert.Equal(t, Restart, action)
+		assert.Equal(t, "beat 9.3.9", readFile(t, env.exe))
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		env := newTestEnv(t)
+		env.stage(t, "latest", []byte("beat latest"), nil)
+
+		action, err := env.updater(t, DefaultConfig()).Start()
+		require.NoError(t, err)
+		assert.Equal(t, Continue, action)
+		assert.Equal(t, "beat 9.4.0", readFile(t, env.exe))
+	})
+}
+
+func TestVerifyExecutable(t *testing.T) {
+	env := newTestEnv(t)
+	cfg := DefaultConfig()
+	cfg.VerifyExecutable = true
+
+	_, err := env.updater(t, cfg).Start()
+	require.Error(t, err)
+
+	digest := sha256.Sum256([]byte("beat 9.4.0"))
+	env.sign(t, env.exe, digest[:])
+	action, err := env.updater(t, cfg).Start()
+	require.NoError(t, err)
+	assert.Equal(t, Continue, action)
+
+	// The signature of the update replaces the one of the executable.
+	env.stage(t, "9.4.1", []byte("beat 9.4.1"), nil)
+	action, err = env.updater(t, cfg).Start()
+	require.NoError(t, err)
+	require.Equal(t, Restart, action)
+	action, err = env.updater(t, cfg).Start()
+	require.NoError(t, err)
+	assert.Equal(t, Continue, action)
+}
@@ -0,0 +1,192 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package selfupdate
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/elastic/elastic-agent-libs/version"
+)
+
+const (
+	manifestFile = "manifest.json"
+
+	// signatureExt is the extension of the detached signatures of the
+	// manifest and of the executable. A signature is the base64 encoded
+	// Ed25519 signature of the manifest, or of the SHA-256 digest of the
+	// executable.
+	signatureExt = ".sig"
+)
+
+var errBadSignature = errors.New("signature does not match any of the public keys")
+
+// Manifest describes a staged artifact. It is signed by the publisher of
+// the artifact.
+type Manifest struct {
+	Version string `json:"version"`
+	// Artifact is the file name of the executable in the staging directory.
+	Artifact string `json:"artifact"`
+	// SHA256 is the hex encoded SHA-256 digest of the artifact.
+	SHA256 string `json:"sha256"`
+	// RolloutPercentage is the share of the Beats that apply the update.
+	// All Beats apply it when it's not set.
+	RolloutPercentage *int `json:"rollout_percentage,omitempty"`
+}
+
+func (m *Manifest) validate() error {
+	if m.Version == "" {
+		return errors.New("version must not be empty")
+	}
+	if _, err := version.New(m.Version); err != nil {
+		return fmt.Errorf("invalid version %q: %w", m.Version, err)
+	}
+	if m.Artifact == "" || filepath.Base(m.Artifact) != m.Artifact {
+		return fmt.Errorf("artifact %q must be a file name", m.Artifact)
+	}
+	if b, err := hex.DecodeString(m.SHA256); err != nil || len(b) != sha256.Size {
+		return fmt.Errorf("sha256 %q is not a hex encoded SHA-256 digest", m.SHA256)
+	}
+	if p := m.RolloutPercentage; p != nil && (*p < 0 || *p > 100) {
+		return fmt.Errorf("rollout_percentage must be between 0 and 100, got %d", *p)
+	}
+	return nil
+}
+
+func (m *Manifest) rolloutPercentage() int {
+	if m.RolloutPercentage == nil {
+		return 100
+	}
+	return *m.RolloutPercentage
+}
+
+// parsePublicKeys parses base64 encoded Ed25519 public keys or PEM encoded
+// PKIX Ed25519 public keys.
+func parsePublicKeys(keys []string) ([]ed25519.PublicKey, error) {
+	parsed := make([]ed25519.PublicKey, 0, len(keys))
+	for i, s := range keys {
+		key, err := parsePublicKey(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid public key %d: %w", i, err)
+		}
+		parsed = append(parsed, key)
+	}
+	return parsed, nil
+}
+
+func parsePublicKey(s string) (ed25519.PublicKey, error) {
+	if block, _ := pem.Decode([]byte(s)); block != nil {
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			return nil, fmt.Errorf("key is a %T, must be an ed25519 key", key)
+		}
+		return pub, nil
+	}
+
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil {
+		return nil, err
+	}
+	if len(b) != ed25519.PublicKeySize {
+		return nil, fmt.Errorf("length is %d bytes, must be %d", len(b), ed25519.PublicKeySize)
+	}
+	return ed25519.PublicKey(b), nil
+}
+
+// verifySignature checks the detached signature of data stored in sigPath.
+func verifySignature(keys []ed25519.PublicKey, data []byte, sigPath string) error {
+	encoded, err := os.ReadFile(sigPath)
+	if err != nil {
+		return fmt.Errorf("failed to read signature: %w", err)
+	}
+	sig, err := base64.StdEncoding.DecodeString(string(bytes.TrimSpace(encoded)))
+	if err != nil {
+		return fmt.Errorf("failed to decode signature %s: %w", sigPath, err)
+	}
+	for _, key := range keys {
+		if ed25519.Verify(key, data, sig) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s: %w", sigPath, errBadSignature)
+}
+
+// readManifest reads and verifies the manifest staged in dir. It returns
+// nil without error if no manifest is staged.
+func readManifest(dir string, keys []ed25519.PublicKey) (*Manifest, error) {
+	path := filepath.Join(dir, manifestFile)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+	if err := verifySignature(keys, data, path+signatureExt); err != nil {
+		return nil, err
+	}
+
+	var m Manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", path, err)
+	}
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// fileSHA256 returns the SHA-256 digest of the file at path.
+func fileSHA256(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return nil, err
+	}
+	return h.Sum(nil), nil
+}
+
+// verifyExecutable checks that the executable at path has a valid detached
+// signature by one of the keys.
+func verifyExecutable(path string, keys []ed25519.PublicKey) error {
+	digest, err := fileSHA256(path)
+	if err != nil {
+		return fmt.Errorf("failed to hash executable: %w", err)
+	}
+	return verifySignature(keys, digest, path+signatureExt)
+}
@@ -0,0 +1,90 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package service integrates the Beats with the service manager of the
+// operating system.
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Config configures the integration with the service manager.
+type Config struct {
+	Windows WindowsConfig `config:"windows"`
+}
+
+// WindowsConfig configures the Windows service.
+type WindowsConfig struct {
+	// PreshutdownTimeout is the maximum time to wait for pending events to
+	// be published when the OS shuts down. It must not exceed the
+	// PreshutdownTimeout registered for the service with the service
+	// control manager.
+	PreshutdownTimeout time.Duration `config:"preshutdown_timeout" validate:"min=0"`
+}
+
+// DefaultConfig returns the default service configuration.
+func DefaultConfig() Config {
+	return Config{
+		Windows: WindowsConfig{
+			PreshutdownTimeout: 30 * time.Second,
+		},
+	}
+}
+
+var (
+	mu            sync.Mutex
+	current       = DefaultConfig()
+	flushDeadline time.Time
+)
+
+// Configure sets the service configuration. It must be called before
+// HandleSignals.
+func Configure(cfg Config) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = cfg
+}
+
+func config() Config {
+	mu.Lock()
+	defer mu.Unlock()
+	return current
+}
+
+// ShutdownContext returns the context bounding the time to flush pending
+// events when the Beat stops. If the OS is shutting down, the context
+// expires at the deadline for the flush. Otherwise it has no deadline, and
+// the publisher pipeline applies its default timeout.
+func ShutdownContext() (context.Context, context.CancelFunc) {
+	mu.Lock()
+	deadline := flushDeadline
+	mu.Unlock()
+
+	if deadline.IsZero() {
+		return context.WithCancel(context.Background())
+	}
+	return context.WithDeadline(context.Background(), deadline)
+}
+
+func setFlushDeadline(deadline time.Time) {
+	mu.Lock()
+	defer mu.Unlock()
+	flushDeadline = deadline
+}
@@ -0,0 +1,39 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !windows
+
+package service
+
+import (
+	"context"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	svc "github.com/elastic/elastic-agent-libs/service"
+)
+
+// HandleSignals calls stop and cancel when the Beat receives a termination
+// signal.
+func HandleSignals(_ *logp.Logger, stop func(), cancel context.CancelFunc) {
+	svc.HandleSignals(stop, cancel)
+}
+
+// NotifyTermination marks the service as stopped. It must be called when
+// the Beat has completed its shutdown.
+func NotifyTermination() {
+	svc.NotifyTermination()
+}
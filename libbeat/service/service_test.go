@@ -0,0 +1,42 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestShutdownContext(t *testing.T) {
+	t.Cleanup(func() { setFlushDeadline(time.Time{}) })
+
+	ctx, cancel := ShutdownContext()
+	_, ok := ctx.Deadline()
+	assert.False(t, ok, "context should not have a deadline if the OS is not shutting down")
+	cancel()
+
+	deadline := time.Now().Add(time.Minute)
+	setFlushDeadline(deadline)
+	ctx, cancel = ShutdownContext()
+	defer cancel()
+	got, ok := ctx.Deadline()
+	assert.True(t, ok, "context should have the flush deadline")
+	assert.Equal(t, deadline, got)
+}
@@ -0,0 +1,219 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package service
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"golang.org/x/sys/windows/svc"
+	"golang.org/x/sys/windows/svc/debug"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	// acceptedCmds are the service control requests handled by the Beat.
+	// Preshutdown requests are received before the OS notifies services
+	// of the shutdown, which leaves time to flush pending events.
+	acceptedCmds = svc.AcceptStop | svc.AcceptShutdown | svc.AcceptPreShutdown
+
+	// stopWaitHint is the time the service control manager waits for the
+	// next progress report while the Beat is stopping.
+	stopWaitHint = 10 * time.Second
+
+	// terminationTimeout bounds the time NotifyTermination waits for the
+	// service control manager to be notified of the stop.
+	terminationTimeout = 5 * time.Second
+
+	// couldNotConnect is the errno for
+	// ERROR_FAILED_SERVICE_CONTROLLER_CONNECT.
+	couldNotConnect syscall.Errno = 1063
+)
+
+var instance *handler // set while running as a Windows service
+
+// HandleSignals calls stop and cancel when the Beat receives a termination
+// signal, or a stop, shutdown or preshutdown request from the service
+// control manager.
+func HandleSignals(log *logp.Logger, stop func(), cancel context.CancelFunc) {
+	log = log.Named("service")
+
+	var once sync.Once
+	stopOnce := func() {
+		once.Do(func() {
+			cancel()
+			stop()
+		})
+	}
+
+	sigc := make(chan os.Signal, 1)
+	signal.Notify(sigc, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	go func() {
+		sig := <-sigc
+		log.Debugf("Received %v signal, stopping", sig)
+		stopOnce()
+	}()
+
+	go runService(log, stopOnce)
+}
+
+// NotifyTermination marks the service as stopped. It must be called when
+// the Beat has completed its shutdown.
+func NotifyTermination() {
+	mu.Lock()
+	h := instance
+	mu.Unlock()
+
+	if h != nil {
+		h.terminate()
+	}
+}
+
+func runService(log *logp.Logger, stop func()) {
+	//nolint:staticcheck // keep using the deprecated method in order to maintain the existing behavior
+	isInteractive, err := svc.IsAnInteractiveSession()
+	if err != nil {
+		log.Errorf("IsAnInteractiveSession: %v", err)
+		return
+	}
+	log.Debugf("Windows is interactive: %v", isInteractive)
+
+	// In an interactive session, debug.Run translates the console control
+	// events, like Ctrl+C, into service control requests.
+	run := svc.Run
+	if isInteractive {
+		run = debug.Run
+	}
+
+	h := newHandler(log, stop, config().Windows.PreshutdownTimeout)
+	mu.Lock()
+	instance = h
+	mu.Unlock()
+
+	defer close(h.finished)
+	err = run(os.Args[0], h)
+	if err == nil {
+		return
+	}
+
+	//nolint:errorlint // this system error is a special case
+	if errno, ok := err.(syscall.Errno); ok && errno == couldNotConnect {
+		// The process was started as an interactive process by a process
+		// that is itself a service, like Jenkins, so it tried to register
+		// the service handler. As documented for
+		// StartServiceCtrlDispatcherW, this error is returned when the
+		// program runs as a console application, there is nothing to do.
+		log.Info("Attempted to register Windows service handlers, but this is not a service. No action necessary")
+		return
+	}
+	log.Errorf("Windows service setup failed: %+v", err)
+}
+
+// handler implements svc.Handler.
+type handler struct {
+	log                *logp.Logger
+	stop               func()
+	preshutdownTimeout time.Duration
+
+	terminateOnce sync.Once
+	done          chan struct{} // closed when the Beat has stopped
+	finished      chan struct{} // closed when the service has been stopped
+}
+
+func newHandler(log *logp.Logger, stop func(), preshutdownTimeout time.Duration) *handler {
+	return &handler{
+		log:                log,
+		stop:               stop,
+		preshutdownTimeout: preshutdownTimeout,
+		done:               make(chan struct{}),
+		finished:           make(chan struct{}),
+	}
+}
+
+func (h *handler) Execute(_ []string, r <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	changes <- svc.Status{State: svc.StartPending}
+	changes <- svc.Status{State: svc.Running, Accepts: acceptedCmds}
+
+	for {
+		select {
+		case req := <-r:
+			switch req.Cmd {
+			case svc.Interrogate:
+				changes <- req.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				h.log.Info("Received Windows service stop request")
+				h.stopService(changes)
+				return false, 0
+			case svc.PreShutdown:
+				h.log.Infof("OS is shutting down, flushing pending events for up to %v", h.preshutdownTimeout)
+				setFlushDeadline(time.Now().Add(h.preshutdownTimeout))
+				h.stopService(changes)
+				return false, 0
+			default:
+				h.log.Warnf("Unexpected Windows service control request: %d", req.Cmd)
+			}
+		case <-h.done:
+			// The Beat stopped on its own.
+			changes <- svc.Status{State: svc.StopPending}
+			return false, 0
+		}
+	}
+}
+
+// stopService stops the Beat and reports progress to the service control
+// manager until the Beat has stopped, so that it is not considered hung
+// while pending events are flushed.
+func (h *handler) stopService(changes chan<- svc.Status) {
+	var checkpoint uint32
+	report := func() {
+		checkpoint++
+		changes <- svc.Status{State: svc.StopPending, CheckPoint: checkpoint, WaitHint: uint32(stopWaitHint.Milliseconds())}
+	}
+
+	report()
+	go h.stop()
+
+	ticker := time.NewTicker(stopWaitHint / 2)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.done:
+			return
+		case <-ticker.C:
+			report()
+		}
+	}
+}
+
+// terminate signals that the Beat has stopped and waits for the service
+// control manager to be notified, so that the stop is not reported as an
+// unexpected termination triggering the recovery actions.
+func (h *handler) terminate() {
+	h.terminateOnce.Do(func() { close(h.done) })
+
+	select {
+	case <-h.finished:
+	case <-time.After(terminationTimeout):
+		h.log.Warn("Timed out waiting for the Windows service to stop")
+	}
+}
@@ -0,0 +1,91 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/windows/svc"
+
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+func TestHandlerPreShutdown(t *testing.T) {
+	t.Cleanup(func() { setFlushDeadline(time.Time{}) })
+
+	stopped := make(chan struct{})
+	h := newHandler(logptest.NewTestingLogger(t, ""), func() { close(stopped) }, time.Minute)
+
+	requests := make(chan svc.ChangeRequest)
+	changes := make(chan svc.Status, 10)
+	result := make(chan uint32)
+	go func() {
+		_, code := h.Execute(nil, requests, changes)
+		result <- code
+	}()
+
+	assert.Equal(t, svc.StartPending, (<-changes).State)
+	running := <-changes
+	assert.Equal(t, svc.Running, running.State)
+	assert.Equal(t, acceptedCmds, running.Accepts)
+
+	requests <- svc.ChangeRequest{Cmd: svc.PreShutdown}
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the Beat should be stopped on preshutdown")
+	}
+	assert.Equal(t, svc.StopPending, (<-changes).State)
+
+	ctx, cancel := ShutdownContext()
+	defer cancel()
+	deadline, ok := ctx.Deadline()
+	require.True(t, ok, "the flush should be bounded by the preshutdown timeout")
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+
+	h.terminateOnce.Do(func() { close(h.done) })
+	select {
+	case code := <-result:
+		assert.Zero(t, code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the service should stop once the Beat has stopped")
+	}
+}
+
+func TestHandlerBeatStopped(t *testing.T) {
+	h := newHandler(logptest.NewTestingLogger(t, ""), func() {}, time.Minute)
+
+	changes := make(chan svc.Status, 10)
+	result := make(chan uint32)
+	go func() {
+		_, code := h.Execute(nil, make(chan svc.ChangeRequest), changes)
+		result <- code
+	}()
+
+	go h.terminate()
+	select {
+	case code := <-result:
+		assert.Zero(t, code)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the service should stop when the Beat stops on its own")
+	}
+	close(h.finished)
+}
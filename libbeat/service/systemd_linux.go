@@ -0,0 +1,148 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/daemon"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// sdNotify and sdWatchdogEnabled are variables so tests can replace them.
+var (
+	sdNotify          = daemon.SdNotify
+	sdWatchdogEnabled = daemon.SdWatchdogEnabled
+)
+
+var (
+	watchdogMu      sync.Mutex
+	watchdogDone    chan struct{}
+	watchdogStopped chan struct{}
+)
+
+// NotifyReady tells systemd that the Beat has completed its startup. It is
+// a no-op if the Beat is not run by systemd as a Type=notify service. If the
+// unit configures WatchdogSec, NotifyReady also starts sending keep-alive
+// pings at half the watchdog interval until NotifyStopping is called. A ping
+// is only sent if alive returns nil within the period, so systemd restarts a
+// Beat that hangs.
+func NotifyReady(log *logp.Logger, alive func(context.Context) error) {
+	sent, err := sdNotify(false, daemon.SdNotifyReady)
+	if err != nil {
+		log.Warnf("Failed to notify systemd that the Beat is ready: %v", err)
+		return
+	}
+	if !sent {
+		return
+	}
+	log.Debug("Notified systemd that the Beat is ready.")
+
+	interval, err := sdWatchdogEnabled(false)
+	if err != nil {
+		log.Warnf("Failed to read the systemd watchdog interval: %v", err)
+		return
+	}
+	if interval <= 0 {
+		return
+	}
+	startWatchdog(log, interval/2, alive)
+}
+
+// NotifyStopping tells systemd that the Beat is shutting down and stops the
+// watchdog pings.
+func NotifyStopping() {
+	stopWatchdog()
+	_, _ = sdNotify(false, daemon.SdNotifyStopping)
+}
+
+func startWatchdog(log *logp.Logger, period time.Duration, alive func(context.Context) error) {
+	watchdogMu.Lock()
+	defer watchdogMu.Unlock()
+	if watchdogDone != nil {
+		return
+	}
+	done, stopped := make(chan struct{}), make(chan struct{})
+	watchdogDone, watchdogStopped = done, stopped
+
+	log.Infof("Sending systemd watchdog keep-alive every %v.", period)
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(period)
+		defer ticker.Stop()
+		check := &livenessCheck{alive: alive, timeout: period}
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := check.run(); err != nil {
+					log.Errorf("Not sending systemd watchdog keep-alive, the liveness check failed: %v", err)
+					continue
+				}
+				if _, err := sdNotify(false, daemon.SdNotifyWatchdog); err != nil {
+					log.Warnf("Failed to send systemd watchdog keep-alive: %v", err)
+				}
+			}
+		}
+	}()
+}
+
+// livenessCheck runs the liveness check of the watchdog, which fails if it
+// doesn't return within timeout. A check that hangs is not started again,
+// the next runs wait for it to return.
+type livenessCheck struct {
+	alive   func(context.Context) error
+	timeout time.Duration
+	pending chan error
+}
+
+func (c *livenessCheck) run() error {
+	if c.alive == nil {
+		return nil
+	}
+	if c.pending == nil {
+		c.pending = make(chan error, 1)
+		go func(result chan<- error) {
+			ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+			defer cancel()
+			result <- c.alive(ctx)
+		}(c.pending)
+	}
+	select {
+	case err := <-c.pending:
+		c.pending = nil
+		return err
+	case <-time.After(c.timeout):
+		return fmt.Errorf("no response within %v", c.timeout)
+	}
+}
+
+func stopWatchdog() {
+	watchdogMu.Lock()
+	defer watchdogMu.Unlock()
+	if watchdogDone != nil {
+		close(watchdogDone)
+		<-watchdogStopped
+		watchdogDone, watchdogStopped = nil, nil
+	}
+}
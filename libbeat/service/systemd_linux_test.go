@@ -0,0 +1,125 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/daemon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+type notifyRecorder struct {
+	mu     sync.Mutex
+	states []string
+}
+
+func (r *notifyRecorder) notify(_ bool, state string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.states = append(r.states, state)
+	return true, nil
+}
+
+func (r *notifyRecorder) count(state string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	n := 0
+	for _, s := range r.states {
+		if s == state {
+			n++
+		}
+	}
+	return n
+}
+
+func stubSystemd(t *testing.T, watchdog time.Duration) *notifyRecorder {
+	rec := &notifyRecorder{}
+	origNotify, origWatchdog := sdNotify, sdWatchdogEnabled
+	sdNotify = rec.notify
+	sdWatchdogEnabled = func(bool) (time.Duration, error) { return watchdog, nil }
+	t.Cleanup(func() {
+		stopWatchdog()
+		sdNotify, sdWatchdogEnabled = origNotify, origWatchdog
+	})
+	return rec
+}
+
+func TestNotifyReady(t *testing.T) {
+	rec := stubSystemd(t, 0)
+
+	NotifyReady(logptest.NewTestingLogger(t, ""), nil)
+	NotifyStopping()
+
+	assert.Equal(t, []string{daemon.SdNotifyReady, daemon.SdNotifyStopping}, rec.states)
+}
+
+func TestNotifyWatchdog(t *testing.T) {
+	rec := stubSystemd(t, 20*time.Millisecond)
+
+	NotifyReady(logptest.NewTestingLogger(t, ""), func(context.Context) error { return nil })
+	require.Eventually(t, func() bool {
+		return rec.count(daemon.SdNotifyWatchdog) >= 2
+	}, 5*time.Second, 5*time.Millisecond, "watchdog keep-alive should be sent periodically")
+
+	NotifyStopping()
+	pings := rec.count(daemon.SdNotifyWatchdog)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, pings, rec.count(daemon.SdNotifyWatchdog), "no keep-alive should be sent after stopping")
+	assert.Equal(t, 1, rec.count(daemon.SdNotifyStopping))
+}
+
+func TestNotifyWatchdogNotAlive(t *testing.T) {
+	rec := stubSystemd(t, 20*time.Millisecond)
+	var checks atomic.Int32
+	NotifyReady(logptest.NewTestingLogger(t, ""), func(context.Context) error {
+		checks.Add(1)
+		return errors.New("stuck")
+	})
+	require.Eventually(t, func() bool {
+		return checks.Load() >= 2
+	}, 5*time.Second, 5*time.Millisecond, "the liveness check should run periodically")
+	assert.Zero(t, rec.count(daemon.SdNotifyWatchdog), "no keep-alive should be sent if the liveness check fails")
+}
+
+func TestNotifyWatchdogHanging(t *testing.T) {
+	rec := stubSystemd(t, 20*time.Millisecond)
+	var checks atomic.Int32
+	release := make(chan struct{})
+	NotifyReady(logptest.NewTestingLogger(t, ""), func(context.Context) error {
+		checks.Add(1)
+		<-release
+		return nil
+	})
+	time.Sleep(100 * time.Millisecond)
+	assert.Zero(t, rec.count(daemon.SdNotifyWatchdog), "no keep-alive should be sent while the liveness check hangs")
+	assert.Equal(t, int32(1), checks.Load(), "a hanging liveness check should not be started again")
+
+	close(release)
+	require.Eventually(t, func() bool {
+		return rec.count(daemon.SdNotifyWatchdog) >= 1
+	}, 5*time.Second, 5*time.Millisecond, "keep-alives should resume once the liveness check returns")
+}
@@ -0,0 +1,214 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+// Package memory provides a statestore backend that holds the stores in
+// memory. The stores are lost when the process exits, so it is meant for
+// tests and for commands that must not modify the persisted state.
+package memory
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/common/transform/typeconv"
+	"github.com/elastic/beats/v7/libbeat/statestore/backend"
+)
+
+// Registry is a backend registry that holds all accessed stores and data in
+// memory. The Stores field is accessible for introspection or custom
+// initialization. Stores should not be modified while they are in use.
+//
+// The zero value of Registry is a valid registry. The Stores field will be
+// initialized lazily if it has not been setup upfront.
+//
+// Example: Create an in memory registry:
+//
+//	reg := statestore.NewRegistry(memory.New())
+type Registry struct {
+	Stores map[string]*Store
+	mu     sync.Mutex
+}
+
+// Store implements a single in memory storage. The Store holds all
+// key-value pairs in a map[string]interface{}.
+type Store struct {
+	mu     sync.RWMutex
+	closed bool
+	Table  map[string]interface{}
+}
+
+type valueUnpacker struct {
+	from interface{}
+}
+
+var errStoreClosed = errors.New("store closed")
+var errUnknownKey = errors.New("unknown key")
+
+// New creates a new backend.Registry instance that can be used with the
+// statestore.
+func New() *Registry {
+	return &Registry{}
+}
+
+func (m *Registry) init() {
+	if m.Stores == nil {
+		m.Stores = map[string]*Store{}
+	}
+}
+
+// Access returns the Store for the given name. A new store is created and
+// registered in the Stores table, if the store name is new to the Registry.
+func (m *Registry) Access(name string) (backend.Store, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+
+	store, exists := m.Stores[name]
+	if !exists {
+		store = &Store{}
+		m.Stores[name] = store
+	} else {
+		store.Reopen()
+	}
+	return store, nil
+}
+
+// Close closes the store.
+func (m *Registry) Close() error { return nil }
+
+func (s *Store) init() {
+	if s.Table == nil {
+		s.Table = map[string]interface{}{}
+	}
+}
+
+// Reopen marks the Store as open in case it has been closed already.  All
+// key-value pairs and store operations are accessible after reopening the
+// store.
+func (s *Store) Reopen() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = false
+}
+
+// Close marks the store as closed. The Store API calls like Has, Get, Set, and
+// Remove will fail until the store is reopenned.
+func (s *Store) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closed = true
+	return nil
+}
+
+// IsClosed returns true if the store is marked as closed.
+func (s *Store) IsClosed() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.closed
+}
+
+// Has checks if the key value pair is known to the store.
+// It returns an error if the store is marked as closed.
+func (s *Store) Has(key string) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return false, errStoreClosed
+	}
+
+	s.init()
+	_, exists := s.Table[key]
+	return exists, nil
+}
+
+// Get returns a key value pair from the store. An error is returned if the
+// store has been closed, the key is unknown, or an decoding error occurred.
+func (s *Store) Get(key string, into interface{}) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return errStoreClosed
+	}
+
+	s.init()
+	val, exists := s.Table[key]
+	if !exists {
+		return errUnknownKey
+	}
+	return typeconv.Convert(into, val)
+}
+
+// Set inserts or overwrites a key-value pair.
+// An error is returned if the store is marked as closed or the value being
+// passed in can not be encoded.
+func (s *Store) Set(key string, from interface{}) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errStoreClosed
+	}
+
+	s.init()
+	var tmp interface{}
+	if err := typeconv.Convert(&tmp, from); err != nil {
+		return err
+	}
+	s.Table[key] = tmp
+	return nil
+}
+
+// Remove removes a key value pair from the store.
+// An error is returned if the store is marked as closed.
+func (s *Store) Remove(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return errStoreClosed
+	}
+
+	s.init()
+	delete(s.Table, key)
+	return nil
+}
+
+// Each iterates all key value pairs in the store calling fn.
+// The iteration stops if fn returns false or an error.
+// Each returns an error if the store is closed, or fn returns an error.
+func (s *Store) Each(fn func(string, backend.ValueDecoder) (bool, error)) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.closed {
+		return errStoreClosed
+	}
+
+	s.init()
+	for k, v := range s.Table {
+		cont, err := fn(k, valueUnpacker{v})
+		if !cont || err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d valueUnpacker) Decode(to interface{}) error {
+	return typeconv.Convert(to, d.from)
+}
+
+func (s *Store) SetID(_ string) {
+	// NOOP
+}
@@ -15,7 +15,7 @@
 // specific language governing permissions and limitations
 // under the License.
 
-package storetest
+package memory
 
 import (
 	"testing"
@@ -28,22 +28,22 @@ import (
 
 func TestCompliance(t *testing.T) {
 	storecompliance.TestBackendCompliance(t, func(testPath string) (backend.Registry, error) {
-		return NewMemoryStoreBackend(), nil
+		return New(), nil
 	})
 }
 
 func TestStore_IsClosed(t *testing.T) {
 	t.Run("false by default", func(t *testing.T) {
-		store := &MapStore{}
+		store := &Store{}
 		assert.False(t, store.IsClosed())
 	})
 	t.Run("true after close", func(t *testing.T) {
-		store := &MapStore{}
+		store := &Store{}
 		store.Close()
 		assert.True(t, store.IsClosed())
 	})
 	t.Run("true after reopen", func(t *testing.T) {
-		store := &MapStore{}
+		store := &Store{}
 		store.Close()
 		store.Reopen()
 		assert.False(t, store.IsClosed())
@@ -38,6 +38,9 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/ceph/mgr_osd_pool_stats"
 	_ "github.com/elastic/beats/v7/metricbeat/module/ceph/mgr_osd_tree"
 	_ "github.com/elastic/beats/v7/metricbeat/module/ceph/mgr_pool_disk"
+	_ "github.com/elastic/beats/v7/metricbeat/module/ceph/mgr_pool_io"
+	_ "github.com/elastic/beats/v7/metricbeat/module/ceph/mgr_rbd_mirror"
+	_ "github.com/elastic/beats/v7/metricbeat/module/ceph/mgr_rgw_usage"
 	_ "github.com/elastic/beats/v7/metricbeat/module/ceph/monitor_health"
 	_ "github.com/elastic/beats/v7/metricbeat/module/ceph/osd_df"
 	_ "github.com/elastic/beats/v7/metricbeat/module/ceph/osd_tree"
@@ -107,6 +110,7 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/linux/pageinfo"
 	_ "github.com/elastic/beats/v7/metricbeat/module/linux/pressure"
 	_ "github.com/elastic/beats/v7/metricbeat/module/linux/rapl"
+	_ "github.com/elastic/beats/v7/metricbeat/module/linux/smart"
 	_ "github.com/elastic/beats/v7/metricbeat/module/logstash"
 	_ "github.com/elastic/beats/v7/metricbeat/module/logstash/node"
 	_ "github.com/elastic/beats/v7/metricbeat/module/logstash/node_stats"
@@ -117,6 +121,7 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/dbstats"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/metrics"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/replstatus"
+	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/sharding"
 	_ "github.com/elastic/beats/v7/metricbeat/module/mongodb/status"
 	_ "github.com/elastic/beats/v7/metricbeat/module/munin"
 	_ "github.com/elastic/beats/v7/metricbeat/module/munin/node"
@@ -159,6 +164,7 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/redis/key"
 	_ "github.com/elastic/beats/v7/metricbeat/module/redis/keyspace"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system"
+	_ "github.com/elastic/beats/v7/metricbeat/module/system/cgroup"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/core"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/cpu"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/diskio"
@@ -170,6 +176,7 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/network"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/network_summary"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/ntp"
+	_ "github.com/elastic/beats/v7/metricbeat/module/system/pressure"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/process"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/process_summary"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/raid"
@@ -178,6 +185,8 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/socket_summary"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/uptime"
 	_ "github.com/elastic/beats/v7/metricbeat/module/system/users"
+	_ "github.com/elastic/beats/v7/metricbeat/module/systemd"
+	_ "github.com/elastic/beats/v7/metricbeat/module/systemd/units"
 	_ "github.com/elastic/beats/v7/metricbeat/module/traefik"
 	_ "github.com/elastic/beats/v7/metricbeat/module/traefik/health"
 	_ "github.com/elastic/beats/v7/metricbeat/module/uwsgi"
@@ -43,6 +43,7 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/scheduler"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_container"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_cronjob"
+	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_customresource"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_daemonset"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_deployment"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_horizontalpodautoscaler"
@@ -52,11 +53,13 @@ import (
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_persistentvolume"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_persistentvolumeclaim"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_pod"
+	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_poddisruptionbudget"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_replicaset"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_resourcequota"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_service"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_statefulset"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_storageclass"
+	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/state_verticalpodautoscaler"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/system"
 	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes/volume"
 )
@@ -32,11 +32,12 @@ import (
 // Connector configures and establishes a beat.Client for publishing events
 // to the publisher pipeline.
 type Connector struct {
-	pipeline   beat.PipelineConnector
-	processors *processors.Processors
-	eventMeta  mapstr.EventMetadata
-	keepNull   bool
-	logger     *logp.Logger
+	pipeline          beat.PipelineConnector
+	processors        *processors.Processors
+	eventMeta         mapstr.EventMetadata
+	keepNull          bool
+	publisherPipeline string
+	logger            *logp.Logger
 }
 
 type connectorConfig struct {
@@ -48,6 +49,10 @@ type connectorConfig struct {
 	KeepNull bool `config:"keep_null"`
 
 	mapstr.EventMetadata `config:",inline"` // Fields and tags to add to events.
+
+	PublisherPipeline struct {
+		Name string `config:"name"` // Named pipeline of the events.
+	} `config:"publisher_pipeline"`
 }
 
 type metricSetRegister interface {
@@ -70,11 +75,12 @@ func NewConnector(
 	}
 
 	return &Connector{
-		pipeline:   pipeline,
-		processors: processors,
-		eventMeta:  config.EventMetadata,
-		keepNull:   config.KeepNull,
-		logger:     beatInfo.Logger,
+		pipeline:          pipeline,
+		processors:        processors,
+		eventMeta:         config.EventMetadata,
+		keepNull:          config.KeepNull,
+		publisherPipeline: config.PublisherPipeline.Name,
+		logger:            beatInfo.Logger,
 	}, nil
 }
 
@@ -112,6 +118,7 @@ func (c *Connector) addProcessors(procs []beat.Processor) {
 
 func (c *Connector) Connect() (beat.Client, error) {
 	return c.pipeline.ConnectWith(beat.ClientConfig{
+		PublisherPipeline: c.publisherPipeline,
 		Processing: beat.ProcessingConfig{
 			EventMetadata: c.eventMeta,
 			Processor:     c.processors,
@@ -32,5 +32,5 @@ func init() {
 // AssetCeph returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/ceph.
 func AssetCeph() string {
-	return "eJzEm89v47oRx+/5KwY5tUDitlcfCuwvYIM2m2Czix6KQkuTI4k1RRIkFT//9w+kZNnWb9u0V6f3bO93PhoOh8Mh8whr3C6Bos7vABx3Apdw/wl1fn8HwNBSw7XjSi7hn3cAAP4rKBQrBd4B2FwZl1AlU54tISXC+k8NCiQWl5AR/xt0jsvMLuG/99aK+we4z53T9/+7A0g5CmaXQfkRJCmwYfGP22qvYlSp6096iPzzy/+jX0CVdIRLCy5HKNAZTv1/EwcbNAiWGqKRQWpUAZ++vH5d1AKHGEcoorQOTcK4XTdf9mGNoPlnQOfYT7unDXMIRN4JF2QlcLHaOrRHv9lxCSWz1hcjaP75sFOFoAoqDQ6sqVs/T5UpiFtCF2AH6ZQjIirgD68YB660yKKy/bTIzkdrR1qORLg8Rqx1lE6PNvWOhgiRWEdc2e+vNW43yrDTXPZS6UKlO+a2JqR4gTRHurYL1IrmkcbumWh4R2O5klNmjSolW7wTUWIk4404BO15ALGH4u1oCI5B2rHZsd0fm/1xNiNiO/pjsdk3bOePRVjVal+MBoQhacrpwiBhyUlZZDgzTcNV/gFvFFxuVJnlunSg0YBFqoZip2bdGO7w5rDB6hm0wbNKJ9oHBNJY43voQq60Pc15V+OpvDQHqOBWC0JxEVbXyBg7cdAZyLJYDWThhkGt/o/UxVpBOxS1/CwUQxxXvSCWEoEsSYUibiC+NRqKsv3tqbhdhB0lw8wQhuwqg7YTnxi0huE6g9ZQzBi0BuX3DVqDOzxoOlsw4sjNM6bOwNsdqV11tggbgJujkeMNwhBciPKbw7n95mAIzBf9N+cqm43BAFYoeDCUdJj4DyNNzdcsVNZ4+LtxBKrKzoQ61/pbrjYWcrWBgsgt6MwCMQhc1lAqnXz1Dl/kYq8eIDtd7ynLFmkp+lP3SimBpE01YfzJelHoiB5alEjMVax64QnTZZEoy2KtEq1g8Mo+Eqa2ezuSUl8bxoemqoCa8Pz5OgrF5e+Aevo2CmWwIFojS3R2a7LvX54/vL5++TzIF3PHHrTaNUZTFGYmidWyszjc/2t2uCt0Y72UPq7LGzxHZMNdnhPYlGV+f5NeQvXy9tlXZmHdlHTffVVptbnuTve5pIe0vN3emBdHHu6pP0B9GNsFVUXBXSKIQ0m3SXHeHPoUVKBW8Ymu6F/7K6NEa7G91OYHLzLbZOs9Zaz3lPPf80ybnfeU3SZqE8tKVU3L6WbVVEQrVbUpYwdyQBys+Wa08l492XDl5OXPnCxBeWC2UMFRuoSrxBDXz17tA0fDJ4jA099e/C6sU173ue2QYd9762nIjL6mf2rXLuFjaNmHhtBA6+XQ6EETLYJVr+ZQzjE83g+bb/i7f1GlMex75WC7qfvOEWz/J3S5xo2357AziBevkj1C56yPYTZFKSj6lE4mUpI7NbuSOLkdf6xfZz9GZqe2/Rml7uSBy08o6+7P7sTi+aW/Lu44By47J/laOWPC6v7V16vob/6vj1Pmq4PXaKarU9dps+FINZrVcJ46bVQQ65JSM+Kwf5lj3QVq6n15gbDJUcKGWOjT3hm/ZOHu2v1GCpx6XeuUwaqmWQiVRT3B/rfK9gfYbYA5Z+qHcAW3NCrdM7c0Hp61Lird29uPeHBXvzhxKeDkpDsj+Ih1nbl20D1I2PS29PQTZ1/IK8vAL8VQWpIhcFn54rgVeIXNaNcHoTWnGLYVr5NuGmuDuweG75xiQgWxEa8aNHa9xgNwITAjIvwfcElFyRByxh7AWgbo6GJkjfPhOtf5F7TymxCpzhjelSgHXNZcK7olVRW4I1RnXheLwbY/Mhrh01kiyyLSLLLtgwcQivq0UnUseWiBDw/eULF6rRPJ1ijWGiTD3ix46XaoyXheKOS6yfx5s9SnDKxKukZ34yTYsjuYDr2Zq1oeSYjKsgfIlXUPYJRyI3lxq/Hcjs9syKGuUM4FM9h/PneWk2rDO2EQ3HvAoiYmzOjVFqgqCtLvC2pKmycb5FneP6f7JvPc1SuIQ4/4fvXUAzvP08chWOwK7kzhH9wO3Og465iQ28qidVyISt2HgVTuL/943KJ9gL8/SvXX/lxueEHMNiFpyiV321iO97uiytu+gjVIGJf1TYlwj7u2O5jcDY4Ewhm3NnNsJD3QkNnYFzX3d2VDSuDOr3elYLBCKLUfJaY2/YfY1ynnvCMqZQjKDZrg6zmFXEpc3rkkfSGPJgZlna/Chrpa9FuprLPARunvjbb2br+wPrFdS8GD3WBFPexiDJqsD4Ru+VcMHZZ5m17PGffG3LdwaB2mSX1Vbp63bvAXCxf46ArNv/XqiOvPAAAA//+0W4ni"
+	return "eJzEnFtv2zrWhu/zKxZy9X2Ao9lzm4sBekIbzE4TNC32xWCgTYvLEnckkiWpuP73g0VJPlIHW5I3EBSFLb/vw8XzIu07eMXNPSSosxsAJ1yO93D7AXV2ewPA0SZGaCeUvId/3QAA0FtQKF7meANgM2VcnCi5Euk9rFhu6VWDOTKL95AyegadEzK19/CfW2vz2wXcZs7p2//eAKwE5tzee+U7kKzALQu95DaaVIwqdf1KgIj+/qQP/QmJko4JacFlCAU6IxL6P3OwRoNgE8M0clgZVcCHT89folpgH+MAJS+tQxNzYV+3b4awOtDor0XnME4AYZh9IPbGRM6WOUbLjUN78EzDlSuZHr3RgUZ/7xpV8KqgVj6ANfXR4ytlCubu4RSggXTKsXxSwO+kOA1caZFPyvbDIr8crcGqPxNnyHKX3RxjXdDWTpTOb23qDQ3L89g65spwvF5xs1aGnxeyp0oXKt2usDUkThSYZJi82gi1SrIgy/l198g0vKGxQsk+W6NKyaM3lpc4kflWHLz2MICpq+LloAoOQRqIpkWdeIfbZriddXC06ne1zVC1XV4XflarY9HZIAxbrUQSGWQ8PmsUaR+Z+uGq+ACZgsuMKtNMlw40GrCYKMk7WddGOLw6rHe9gJYKGSsdazSxxWQo8TkhFErb84I3G4+XHwRUCKtzlmDkZ9eJMRpx0CnIslii6WZQy78wcXYuilp+EIphTqgjyQrEJixHHq9yxVxL+9ZoEpRuJO4pQkPJMTWMI5+l0hrxnkrbMsxTaVuKAZW2Rfn7Km2L215pOo04c+zqI6ZOgXwDEntkfgNwdbTttqMbzrfyq8O53eagDYwW/VfnKrcbgxYsv67GyP8bUw0PhesBeE79yhr3n+tGSFQp3UTuL5laW8jUGgomN6BTC8wgCFlDqVVv0U/4Jl7s1RVk+9d7yvJoVebhoXupVI5Mnmf+YEFZDiei+44SmZnFlYR7rMsiVpbbiUJ91BhImVpC33avISn13DDUNFUFtG2eP547oYT8O6AevnZCGSyY1shjnV6b7Nunx3fPz58+tvJNuWP3WsdrjMasSE3ckmo7O41iEe9783ZLdF25lBDX+ATPAVl7lucMNmU57W9WY6ieXj7SyszPmzLZZV/VqkoZ18AXkO7TisPN0dB2RHAP4QZKvd5GiSoK4eKcOZTJJi4u60MfvArUKjTQFbbDlGmdb8Z6viORwZZH5ZRTlVN2mR6WU05Uzj2dk7asVJW0tKNbtFJ5VYqJG7JHbF3zDUjlPRNZ+8qJ5C/sLF65pbckuUDpYqFiw1yYvdoHdhl88CLw8I8nIJX9SmoL2z7DLvcWSMh0FpP+6tDew3tSqBJCLamXfdO9JNoErqTmUA4x7s6HDTf+RgVVGv2+V9oh1j25r+Hef5BQj/lxH3YGcUzv9bNkQGhoZ93n8Z11kgVFSOliIqHG8DyjuSMZ3w+Z5FBalu7mbfyllXF+B+uXe37se2SSpWjg2agCXYal3R0Fn1uc/SIRRzTDcOV15x1lI2qZo+RJYAEGdS4SRhFXBtAwW5qwK40IkdL2omhVJ6nmcDBotwmlP8bnTPaPcwkl6O9Hn5HlXB8NPB1G85e0HvSDDNYpg3wmiGqmqyya3Tc13QUscaUMbtteWxak+mhs2Homwm9sXQepTprtMQqZ5CUXMm0wbZBxTHb7q99O0gqvSWLvRSnoxoVxm2gaT6+178wgYUmGd06gaUco2K/Y52VnqpNH9ksUZVHXyy4D7FR3aKgGI524GdP7/srFyrCEqq85u6YWDQnTLBFuEyT7WSrHZooW9bHKYJ9nAb+BWEEpLboOpDHt6Ml/9gJrgwnd70B+haFv63VzTEGLGbPkcSGMUWbMeubb+49QqdBYsZd+qF6koa9gKdoJlirBWA2d7us68jBBB2oZVrMEL7X52gj0e52+c4bPA324fXVUKH6x9OOuIhU/LMcCrGTaZsqBMvCXKo1keRCgeW7E8UY1fzdCFuxGJsi73eiZK/SpISg5sy6mByNeVmu96HQfV1UKV+Uyx05zUSA49ooSnPKR8JVCHlvHBc1f1c7ODkWbddHjg0SfOmXt40vHxmqJbo0ULe/s0DrQRhTMbLY2fuO19z4FBPnwcNbNP6KlEdtEKJ0Rw4N5iOzbVi0ItZJfc7EN8iHu87f5E5qGxE8j6Tou7eF4dv4s8u7j0wt8Zg7XbAOcYaHkhXtiGp7oWvJd/bGxKcMKJhjfASPqt89/NMVpHbEzZd3Jm2d4fFHWgSmlpBmYWvXONOjXdaw7wM6nJGqNZorocTT4s0Tr7OgpoRHqdlkxkSMfYcOWVYPrtEtx/BT3+dP3fpNrdHBXGlltAXuRdDm+3M8/Bphco9wJireq3L1IP0ssMcpRpi4bStWy9/RSPc3LPxPRZusNR9o1PrBEGiK0UQlaizzo6zfBUSacvci1Cm+BjvlbVV4NMtFSSP92VAhrcUK7I73GrFBSODX4LPjsC9WH+vX5FUVh4Eyz3ey37uP7otH1HZN6g9+M2I9P4ZsNJ8E5a244Bfji9fpcd0V/XU5e8n+/77P3d9mms676QL+tz9lM5urTM/2mtHGIS80p5x1eZp8eMfaVl5bd6wwlrJmFkHZjPmaZc+pLO+6+4vokanUqHeUqPW8y7fH/XaX1RKJWJwBdU1IIrhA2mZTuUdhkOjxr3aR0Ly/fp4Ob/atvYwF7O935hL/TpvpYsvGmE1i+mn6Ko40VXS4DOpatjy6FrGJxuKXomujEVDEgEkmZKsGDRtMON1u3E9nGj+ObSDBOcmbtDL6ksQCR55iy3JekPqZByDhfgLUc0CVRkG3XR4YGv72JD0L2TcS7wpvKywK7vxh6Taqq4XZQ7dYjIZtZ2bbWXXw6jWVZDMXqsbaHN051Crmqj8Qpiyb81ekgxhUOnToiVdVirbGfgGrwprjQsh3xSMiPdb3j59WGPmVgWSav6K48CB75tg6HY+5oDHLuGBCV5QufWVuAUcp1jIsbjZfe2RsM2XavLxM5NyiD5hcFqTZuhCEXFAGLmtGdP5/oSFRRsHAsElPaLF6jSLPjTlkFJNSZB0TEx8GLQ0C8ceeoXTZRPXjHU8HGCn+J47zLqC96CFt1QetEnlfqlIeWyv3fP+82aBfw251U/x9kqQ8nYvrmqzw+RB8ReNoVVdGmJTZd+KHkD+Ujql/iaA5F2gZ3gx0N4fwaIZxGkoDabE++jj6yR+x+7cAPCcLRr6eUOYclQqmplrhayyDKPMs5CkSlDH6huEXLxeuQhdyKuQzNtDyaGcoNye2pr7CBoezmGGWSG5qdlzOvP7E+8Cal0HrXZr4sRqtlfaX/smXp4EzZdr8bZOla3x5yjrlZEwjQNpFd6w6M1hV+c2ZEjGZI/r0uD7j+NwA3xo1s"
 }
@@ -0,0 +1,108 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mgr_pool_io
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/ceph/mgrprom"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("ceph", "mgr_pool_io", New,
+		mb.WithHostParser(mgrprom.HostParser),
+	)
+}
+
+// poolMetrics maps the per-pool metrics of the Ceph Manager prometheus module
+// to event fields.
+var poolMetrics = map[string]string{
+	"ceph_pool_rd":                  "read.ops",
+	"ceph_pool_rd_bytes":            "read.bytes",
+	"ceph_pool_wr":                  "write.ops",
+	"ceph_pool_wr_bytes":            "write.bytes",
+	"ceph_pool_stored":              "stored.bytes",
+	"ceph_pool_stored_raw":          "stored_raw.bytes",
+	"ceph_pool_objects":             "objects",
+	"ceph_pool_dirty":               "dirty.objects",
+	"ceph_pool_max_avail":           "max_avail.bytes",
+	"ceph_pool_percent_used":        "used.pct",
+	"ceph_pool_quota_bytes":         "quota.bytes",
+	"ceph_pool_quota_objects":       "quota.objects",
+	"ceph_pool_num_bytes_recovered": "recovered.bytes",
+}
+
+type MetricSet struct {
+	*mgrprom.MetricSet
+}
+
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	metricSet, err := mgrprom.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{metricSet}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	families, err := m.FetchFamilies()
+	if err != nil {
+		return fmt.Errorf("error fetching metrics: %w", err)
+	}
+
+	for _, event := range eventsMapping(families) {
+		if !reporter.Event(mb.Event{MetricSetFields: event}) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func eventsMapping(families mgrprom.Families) []mapstr.M {
+	key := mgrprom.LabelKey("pool_id")
+
+	names := map[string]string{}
+	types := map[string]string{}
+	for _, metric := range families.Samples("ceph_pool_metadata") {
+		id := key(metric)
+		names[id] = mgrprom.Label(metric, "name")
+		types[id] = mgrprom.Label(metric, "type")
+	}
+
+	var events []mapstr.M
+	for _, group := range families.Group(poolMetrics, key) {
+		event := group.Fields
+		if id, err := strconv.ParseInt(group.Key, 10, 64); err == nil {
+			event.Put("pool.id", id)
+		}
+		if name := names[group.Key]; name != "" {
+			event.Put("pool.name", name)
+		}
+		if typ := types[group.Key]; typ != "" {
+			event.Put("pool.type", typ)
+		}
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mgr_pool_io
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	_ "github.com/elastic/beats/v7/metricbeat/module/ceph"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetchEventContents(t *testing.T) {
+	response, err := os.ReadFile(filepath.Join("..", "_meta", "testdata", "prometheus_sample_response.txt"))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		w.WriteHeader(200)
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":     "ceph",
+		"metricsets": []string{"mgr_pool_io"},
+		"hosts":      []string{server.URL},
+	}
+
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	if len(errs) > 0 {
+		t.Fatalf("Expected 0 error, had %d. %v\n", len(errs), errs)
+	}
+	require.Len(t, events, 2)
+
+	event := events[1].MetricSetFields
+	t.Logf("%s/%s event: %+v", f.Module().Name(), f.Name(), event.StringToPrint())
+
+	assertField(t, event, "pool.id", int64(2))
+	assertField(t, event, "pool.name", "rbd")
+	assertField(t, event, "pool.type", "replicated")
+	assertField(t, event, "read.ops", int64(58213))
+	assertField(t, event, "write.bytes", int64(11811160064))
+	assertField(t, event, "stored_raw.bytes", int64(32212254720))
+	assertField(t, event, "used.pct", 0.24225)
+}
+
+func assertField(t *testing.T, event mapstr.M, key string, expected interface{}) {
+	t.Helper()
+	value, err := event.GetValue(key)
+	if assert.NoError(t, err, key) {
+		assert.Equal(t, expected, value, key)
+	}
+}
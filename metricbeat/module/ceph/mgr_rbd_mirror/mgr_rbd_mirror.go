@@ -0,0 +1,124 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mgr_rbd_mirror
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/ceph/mgrprom"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("ceph", "mgr_rbd_mirror", New,
+		mb.WithHostParser(mgrprom.HostParser),
+	)
+}
+
+const (
+	remoteTimestamp = "ceph_rbd_mirror_snapshot_image_remote_timestamp"
+	localTimestamp  = "ceph_rbd_mirror_snapshot_image_local_timestamp"
+)
+
+// imageMetrics maps the per-image performance counters of the rbd-mirror
+// daemon to event fields.
+var imageMetrics = map[string]string{
+	"ceph_rbd_mirror_snapshot_image_snapshots":       "snapshot.count",
+	"ceph_rbd_mirror_snapshot_image_sync_bytes":      "snapshot.sync.bytes",
+	"ceph_rbd_mirror_snapshot_image_last_sync_time":  "snapshot.last_sync.duration.sec",
+	"ceph_rbd_mirror_snapshot_image_last_sync_bytes": "snapshot.last_sync.bytes",
+	"ceph_rbd_mirror_image_replay":                   "journal.replay.entries",
+	"ceph_rbd_mirror_image_replay_bytes":             "journal.replay.bytes",
+}
+
+type MetricSet struct {
+	*mgrprom.MetricSet
+}
+
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	metricSet, err := mgrprom.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{metricSet}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	families, err := m.FetchFamilies()
+	if err != nil {
+		return fmt.Errorf("error fetching metrics: %w", err)
+	}
+
+	for _, event := range eventsMapping(families) {
+		if !reporter.Event(mb.Event{MetricSetFields: event}) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// imageKey keys samples by mirrored image as pool/namespace/image.
+func imageKey(metric *prometheus.OpenMetric) string {
+	pool, image := mgrprom.Label(metric, "pool"), mgrprom.Label(metric, "image")
+	if pool == "" || image == "" {
+		return ""
+	}
+	return pool + "/" + mgrprom.Label(metric, "namespace") + "/" + image
+}
+
+func eventsMapping(families mgrprom.Families) []mapstr.M {
+	remote := families.Values(remoteTimestamp, imageKey)
+	local := families.Values(localTimestamp, imageKey)
+
+	var events []mapstr.M
+	for _, group := range families.Group(imageMetrics, imageKey) {
+		event := group.Fields
+		parts := strings.SplitN(group.Key, "/", 3)
+		event.Put("pool", parts[0])
+		if parts[1] != "" {
+			event.Put("namespace", parts[1])
+		}
+		event.Put("image", parts[2])
+
+		if _, ok := event["snapshot"]; ok {
+			event.Put("mode", "snapshot")
+		} else {
+			event.Put("mode", "journal")
+		}
+
+		// The lag is the time between the latest snapshot of the primary
+		// image and the latest snapshot synced to the local image.
+		remoteTS, hasRemote := remote[group.Key]
+		localTS, hasLocal := local[group.Key]
+		if hasRemote && hasLocal {
+			lag := remoteTS - localTS
+			if lag < 0 {
+				lag = 0
+			}
+			event.Put("snapshot.lag.sec", mgrprom.Number(lag))
+		}
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,86 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mgr_rbd_mirror
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	_ "github.com/elastic/beats/v7/metricbeat/module/ceph"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetchEventContents(t *testing.T) {
+	response, err := os.ReadFile(filepath.Join("..", "_meta", "testdata", "prometheus_sample_response.txt"))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		w.WriteHeader(200)
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":     "ceph",
+		"metricsets": []string{"mgr_rbd_mirror"},
+		"hosts":      []string{server.URL},
+	}
+
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	if len(errs) > 0 {
+		t.Fatalf("Expected 0 error, had %d. %v\n", len(errs), errs)
+	}
+	require.Len(t, events, 2)
+
+	snapshot := events[0].MetricSetFields
+	t.Logf("%s/%s event: %+v", f.Module().Name(), f.Name(), snapshot.StringToPrint())
+
+	assertField(t, snapshot, "pool", "rbd")
+	assertField(t, snapshot, "image", "vm-disk-1")
+	assertField(t, snapshot, "mode", "snapshot")
+	assertField(t, snapshot, "snapshot.count", int64(48))
+	assertField(t, snapshot, "snapshot.last_sync.duration.sec", 12.5)
+	assertField(t, snapshot, "snapshot.lag.sec", int64(300))
+
+	_, err = snapshot.GetValue("namespace")
+	assert.Error(t, err)
+
+	journal := events[1].MetricSetFields
+	assertField(t, journal, "pool", "rbd")
+	assertField(t, journal, "namespace", "prod")
+	assertField(t, journal, "image", "db-volume")
+	assertField(t, journal, "mode", "journal")
+	assertField(t, journal, "journal.replay.entries", int64(91234))
+}
+
+func assertField(t *testing.T, event mapstr.M, key string, expected interface{}) {
+	t.Helper()
+	value, err := event.GetValue(key)
+	if assert.NoError(t, err, key) {
+		assert.Equal(t, expected, value, key)
+	}
+}
@@ -0,0 +1,110 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mgr_rgw_usage
+
+import (
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/ceph/mgrprom"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("ceph", "mgr_rgw_usage", New,
+		mb.WithHostParser(mgrprom.HostParser),
+	)
+}
+
+// rgwMetrics maps the RADOS Gateway performance counters to event fields.
+var rgwMetrics = map[string]string{
+	"ceph_rgw_req":        "requests.count",
+	"ceph_rgw_failed_req": "requests.failed",
+	"ceph_rgw_get":        "get.count",
+	"ceph_rgw_get_b":      "get.bytes",
+	"ceph_rgw_put":        "put.count",
+	"ceph_rgw_put_b":      "put.bytes",
+	"ceph_rgw_qlen":       "queue.length",
+	"ceph_rgw_qactive":    "queue.active",
+	"ceph_rgw_cache_hit":  "cache.hits",
+	"ceph_rgw_cache_miss": "cache.misses",
+}
+
+type MetricSet struct {
+	*mgrprom.MetricSet
+}
+
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	metricSet, err := mgrprom.NewMetricSet(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{metricSet}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	families, err := m.FetchFamilies()
+	if err != nil {
+		return fmt.Errorf("error fetching metrics: %w", err)
+	}
+
+	for _, event := range eventsMapping(families) {
+		if !reporter.Event(mb.Event{MetricSetFields: event}) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// daemonKey keys samples by RGW daemon. Releases exporting labeled
+// performance counters identify the daemon by instance_id instead of
+// ceph_daemon.
+func daemonKey(metric *prometheus.OpenMetric) string {
+	if daemon := mgrprom.Label(metric, "ceph_daemon"); daemon != "" {
+		return daemon
+	}
+	return mgrprom.Label(metric, "instance_id")
+}
+
+func eventsMapping(families mgrprom.Families) []mapstr.M {
+	hosts := map[string]string{}
+	versions := map[string]string{}
+	for _, metric := range families.Samples("ceph_rgw_metadata") {
+		daemon := daemonKey(metric)
+		hosts[daemon] = mgrprom.Label(metric, "hostname")
+		versions[daemon] = mgrprom.Label(metric, "ceph_version")
+	}
+
+	var events []mapstr.M
+	for _, group := range families.Group(rgwMetrics, daemonKey) {
+		event := group.Fields
+		event.Put("daemon", group.Key)
+		if host := hosts[group.Key]; host != "" {
+			event.Put("hostname", host)
+		}
+		if version := versions[group.Key]; version != "" {
+			event.Put("version", version)
+		}
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mgr_rgw_usage
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	_ "github.com/elastic/beats/v7/metricbeat/module/ceph"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetchEventContents(t *testing.T) {
+	response, err := os.ReadFile(filepath.Join("..", "_meta", "testdata", "prometheus_sample_response.txt"))
+	require.NoError(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		w.WriteHeader(200)
+		w.Write(response)
+	}))
+	defer server.Close()
+
+	config := map[string]interface{}{
+		"module":     "ceph",
+		"metricsets": []string{"mgr_rgw_usage"},
+		"hosts":      []string{server.URL},
+	}
+
+	f := mbtest.NewReportingMetricSetV2Error(t, config)
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	if len(errs) > 0 {
+		t.Fatalf("Expected 0 error, had %d. %v\n", len(errs), errs)
+	}
+	require.Len(t, events, 1)
+
+	event := events[0].MetricSetFields
+	t.Logf("%s/%s event: %+v", f.Module().Name(), f.Name(), event.StringToPrint())
+
+	assertField(t, event, "daemon", "rgw.objstore.node1.abcdef")
+	assertField(t, event, "hostname", "node1")
+	assertField(t, event, "requests.count", int64(18230))
+	assertField(t, event, "requests.failed", int64(12))
+	assertField(t, event, "get.bytes", int64(734003200))
+	assertField(t, event, "queue.active", int64(2))
+	assertField(t, event, "cache.misses", int64(2210))
+}
+
+func assertField(t *testing.T, event mapstr.M, key string, expected interface{}) {
+	t.Helper()
+	value, err := event.GetValue(key)
+	if assert.NoError(t, err, key) {
+		assert.Equal(t, expected, value, key)
+	}
+}
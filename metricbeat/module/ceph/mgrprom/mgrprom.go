@@ -0,0 +1,188 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package mgrprom
+
+import (
+	"math"
+	"sort"
+
+	"github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	defaultScheme = "http"
+	defaultPath   = "/metrics"
+)
+
+// HostParser parses the URL of the Ceph Manager prometheus module or of
+// ceph-exporter.
+var HostParser = parse.URLHostParserBuilder{
+	DefaultScheme: defaultScheme,
+	DefaultPath:   defaultPath,
+}.Build()
+
+// MetricSet can be used to build metricsets that read the metrics exposed by
+// the Ceph Manager prometheus module or by ceph-exporter.
+type MetricSet struct {
+	mb.BaseMetricSet
+	prometheus prometheus.Prometheus
+}
+
+var _ mb.MetricSet = new(MetricSet)
+
+// NewMetricSet creates a metricset that scrapes the prometheus endpoint
+// configured in hosts.
+func NewMetricSet(base mb.BaseMetricSet) (*MetricSet, error) {
+	client, err := prometheus.NewPrometheusClient(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{
+		BaseMetricSet: base,
+		prometheus:    client,
+	}, nil
+}
+
+// FetchFamilies scrapes the endpoint and returns its metric families indexed
+// by name.
+func (m *MetricSet) FetchFamilies() (Families, error) {
+	families, err := m.prometheus.GetFamilies()
+	if err != nil {
+		return nil, err
+	}
+	indexed := make(Families, len(families))
+	for _, family := range families {
+		indexed[family.GetName()] = family
+	}
+	return indexed, nil
+}
+
+// Families holds metric families indexed by name.
+type Families map[string]*prometheus.MetricFamily
+
+// Samples returns the samples of the named metric family.
+func (f Families) Samples(name string) []*prometheus.OpenMetric {
+	family, ok := f[name]
+	if !ok {
+		return nil
+	}
+	return family.Metric
+}
+
+// Label returns the value of the named label of the sample.
+func Label(metric *prometheus.OpenMetric, name string) string {
+	for _, label := range metric.GetLabel() {
+		if label.Name == name {
+			return label.Value
+		}
+	}
+	return ""
+}
+
+// Value returns the value of a gauge, counter or untyped sample. It returns
+// false for other sample types and for values that are not finite.
+func Value(metric *prometheus.OpenMetric) (float64, bool) {
+	var v float64
+	switch {
+	case metric.GetGauge() != nil:
+		v = metric.GetGauge().GetValue()
+	case metric.GetCounter() != nil:
+		v = metric.GetCounter().GetValue()
+	case metric.GetUnknown() != nil:
+		v = metric.GetUnknown().GetValue()
+	default:
+		return 0, false
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return 0, false
+	}
+	return v, true
+}
+
+// KeyFunc returns the key of the event a sample belongs to, or an empty
+// string if the sample must be skipped.
+type KeyFunc func(metric *prometheus.OpenMetric) string
+
+// LabelKey returns a KeyFunc keying samples by the value of the label.
+func LabelKey(name string) KeyFunc {
+	return func(metric *prometheus.OpenMetric) string {
+		return Label(metric, name)
+	}
+}
+
+// Values returns the values of the named metric family by key.
+func (f Families) Values(name string, key KeyFunc) map[string]float64 {
+	values := map[string]float64{}
+	for _, metric := range f.Samples(name) {
+		k := key(metric)
+		if k == "" {
+			continue
+		}
+		if v, ok := Value(metric); ok {
+			values[k] = v
+		}
+	}
+	return values
+}
+
+// Group holds the fields collected for one key.
+type Group struct {
+	Key    string
+	Fields mapstr.M
+}
+
+// Group collects the values of the metric families in fields, which maps
+// family names to event fields, into one group per key. Groups are returned
+// sorted by key.
+func (f Families) Group(fields map[string]string, key KeyFunc) []Group {
+	groups := map[string]mapstr.M{}
+	for name, field := range fields {
+		for k, v := range f.Values(name, key) {
+			group, ok := groups[k]
+			if !ok {
+				group = mapstr.M{}
+				groups[k] = group
+			}
+			group.Put(field, Number(v))
+		}
+	}
+
+	keys := make([]string, 0, len(groups))
+	for k := range groups {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	result := make([]Group, 0, len(keys))
+	for _, k := range keys {
+		result = append(result, Group{Key: k, Fields: groups[k]})
+	}
+	return result
+}
+
+// Number returns v as an int64 if it is integral, so that counters are
+// reported as integers, and as a float64 otherwise.
+func Number(v float64) interface{} {
+	if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+		return int64(v)
+	}
+	return v
+}
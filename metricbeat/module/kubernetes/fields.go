@@ -32,5 +32,5 @@ func init() {
 // AssetKubernetes returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/kubernetes.
 func AssetKubernetes() string {
-	return "eJzsfU9z2ziT9z2fAuXLm7zlUe05tfVUZZzn2ckmk9Ha8cxha0uBSEjCmAQ4AGhbU/vht/CHIEgCJCWCsmNLh6mJbXX/0Gg0Gt2Nxk/gDu3fg7tyjRhBAvE3AAgsMvQeXHy2P7x4A0CKeMJwITAl78E/3gAAQP0HIEeC4UR+m6EMQY7egy18AwBHQmCy5e/Bf19wnl1cgoudEMXF/8jf7SgTq4SSDd6+BxuYcfQGgA1GWcrfKwY/AQJz1IInP2JfSA6MloX5iQee/HwiG8pyKH8MIEkBF1BgLnDCAd2AgqYc5JDALUrBeu/wWRgKFRpLsIIEC8wRu0fM/saHqgdZS4Aflp+AJujIsvo0ZVp9XEm14eXwT8oW94hxTEnjLyqYd2j/QFna+l0PWPmRKG8MSskBGA4LPwhM5gYhOfSDYOivEnGxYIjTkiUoHo5rTRmlwEu7DYCX6zkxhMh3YCS0iA8AKLLgbZKVXCB2qZjyAibo0krnXS+ue8TW8WD98u3bEnRItnkmNI0oCsWzQ7LLkwhExEoyij8NBoNiATos2lhStl+xMuLS/AOJHWJA7FDFA5QccZCyPWgzaoO5w6TNbQKSz5ik0sQb6gNTkheUICLisb+qSIIdJGmGydYVSi+a9v4xEYm0look2NBqZkaYiehW2xC0KLrDbENQkmtssBMhVIvER7jNPEdiRyPqo1qYHqKdQVMeUQ3tiNtUK7YFowni3MvRp4g+n8OllxTlgqOk8/uKZkrLdda2e52BXC1vAUcJJWkbmeNcoJyyvdzWcYqIWKz3tXvY5ZtRsvX8UjuH70Hoyw1UP8s/ApiAiqfBMATxHjNRwuyUCA3LIYCblC9ogcgioWXH+g1Ca7D+WuZrxKTFlQTBBmfI/gFl4WnkAjKB0ghKc6MVBnBMEqRMjFHuiod3ATxAkeyiqT+6R0TwBcd/Iz3di3WZ3CGx+P/BwdH1nyjxyV7/YjV+Cv6QQ9EQgEQAUswFw+tSnX4wCehQGDsv81nV9abMpcI81Li5As6PARtThV1EQxA8bgsYMNodzl3DDZTx1vs0uDO+jNRpB1pgH+EFJdzvWh6j0k+jy0GJqMH1qLfyLxBMdnqwl5Xfof5nXR9GLt0D06U5vkCSAusMLsaI5ERLpJrV0etjpoVR4xC0clm4Vw+TDEsZ1i52F0QQQJC55gcgN+T7PKl4Lk1U+XlE5jJLS6aCVYvyKN1qsK2cv4qmVPlcqk6OE0aH/CsXyXQRdLEQ12HQPxwH5oRWyNqbDApEkn3D5FyCHeaCbhnMgcYUxp+UjMnlMF2Qn8gmw9udGFYlSY2VhGCyjWwDqmWYCHyP1LeBYdRvE5BI0oWehCgGoQ7XmqnlAArFxcselikWC7V1RmGv6Pm8hCZDhiQ0lEbkWZFsM69NFhEQk2nBaUe6ll6U2LRyx1cC534nJYWi/YsB1+BGEgQdgs6ZdPRmMBTWWd6CksMt8ggiNGwXivpucB36APVRbQySMh/hYeJDDFwmHgvdZhM8q1WfEW5e9bmyaiflfkUZMsInkAT3rwZeSKgUTAj2CMgj4WrFQOkASwuMpmhReDepGhdPYIbS1SajMPSHlS9ZIJZ045dHjUHKF3IAK5ry3+bsIaiAmcIOYJbRBAq4zpD8Xu9gM5xj8eONNkUbTFCq4duwZW0K38qfBCUC8AaURH0Xpe8W4NOm9XX5N+rXHECGQI45lxuoPILIP/wuiX5X//zOBRRopX9g7A4yX1tTsZNeiWSbAkqA2EGhAF0CscNVYhY84CwD65oNIgIzlO39ObOMbseHBAfk/YVu5XllQw80lfAe4gz6F+Z0cxk6fIFxVmHoDAfG66GSjx0sSGABEyz2w0e86i9fg3z0OhsvG2mKX4Nc1JYzXixYGoZwCHqa/+E/YYCo2+w3pQf1agkOyIlxM9TvGMXDJVmNgRTQzjkgKQXxQGqmKKLFSl6L0W7r4UCeZT63/7mJRAsiOOBn7gH/6qA/0AkOaAB49n7wmDFPcIWNQgx5wwbFc3OI3eljnTQ5eGFL+Prmpn8B26QpZXeYbDkKxxRfhkT+0AMFHInxpu15rvPQUE605oO6VMAt2sAy80SyD8v/+4deh04lIxDg1CibPRkiXUIbwmXtDqViE7FC53UcG68pFaoKhe+5QPnBJ8jX4sn65eSesM5Hbb+MzNHq6Y7cJzlG3noOkG6aidEsQ0zfoZiUbrqyxMyNjDjJplOWlZ+ynPzU9alx61IVt2BRqvxvPF5fYY7G1T7/TUlEvp/IhkEuWJmIkqEu8eddgmvDR0zfm9KW72p5q/KsgBeICGn9zmW6ExD+OGW6EkIOHyMg+GLPE09WKGzLBaqzji0UVqXDJcGPABU02YUUvFnkFm3l9hXLHSbkepqVpbUlO4I6Fw0vwZrRO0RASh+kG6MuR5Zc7TmXZi9Qi99j+z13iSJWrtmqRwO7Kr9q1a51BiB35uMQz1AgZYvWnAK3gQk4Hv9Ji+Jas9Kuw+2UxU0c5wnLbe3YVIGxKZdU34w3hjlq8eKhewpF8lZzx9MiXcM53xSYkuiI+OZXc7ecPI6ed0fxBIW6M+iSG/P+q0RlvJsVcmBIHhB0je50x+YX+iDPzvvKZwE7yJV/YzjZKl3j71AG1giR6scdodgh+05kThyCbDDBfBfFOeuMgW4UDjWWlBKkEzpY+c8Fo1smHTc1c5CT/yf0iBJKpO/PdISmVwTHjhqmaQxD8oflBtNUV1EciyhFhdhFhWTK1jXlY2ExJBiOYnRrYMQxv4r6IeAaKeiEZhlKBPVfMz7uDiBOlFsWc5dROZaKcne0nsiFuz53CGZit4+KyFJV2A6EFFs0B7LXyAMpuvEYlo2k2YHisLsAgiliC8xXOeQicN19TWmGYPs2/lAzhl3djSHpRlcx4QLK8y7mBoSl8KYNsn3f8ODo7rcdcrvdmMueNncPzDK0v1FGHjIEtojIw5Puz1Pd8jBGvMEBK4svJ+Jzu1sQOCByHNbQgF70TsKVpKK5AIYSylK9Idf2S+Ac6Z8VkAmclBlk5g6v3PFoomxw6kGovilgXnhQdu1WX4x8gxkXK8OKBNrTHH4t41sFUI5T8QA1D/mz8C3WDM4OSLIYwFMHCHkn6a8xCPQoxmvDr5qO0QSU1r0w8D0iHnEktNivBPUhqHdXyFttOsIx615014rSWHBWC9s9Zo7k/m1f2HBcP0dPAD+k9P0clVms2rQwVFAmdJ8WzD1z0beAZm0gs2E0Bw87nOyUcLRtwLy2jF5IcfM1X6X7IQkDSsZicTJVMIUCTp+xXw0lADmnCVa7wgMWu9411DdvfhN6uPNn9YChzoSAPoM1Ig3bMFqKgQop9q2UGlA1L6u4KbX/MGSNSmxqZfA72vHzeaN4qv5jcRkrkvJMqReBXgAPcGg1VmnHVfS2Sr+btkquQPqznCWOmDa+JfivEgGVZ8MbLL1N6gDxRHisGUfZZpVhchcRzPUXaccZ4hKNabkV2kYwuafZPUpXHoxzWaeKp08ufXYKFji+5nxYfrJNuYz29ExX3O5skrfb1aSHcVzj4RqsHqbzrdeK8gGij7tgbz99HODtxkemnPmcC+QqfHG+O36+Ox74xL87rjzWH/3a+PmWlv9vzre0Op94t7TO9106kM/3XULQz7c3Bm5vECSk9kSz3ezxhavgNUoQvlfxftXYiVS3kaSMASYCsQ1MkLpD2PkpwNLXFNXlpUt7zU+H9+RGS4X0dxhKBLiHWYnA93/73isaxJivLnG0bMaO+9FweqIh25jYS1ewbwwSnmMhXp+OfXtCHbPJpvNVtuozctb+db7FdrCIzhfY3E9HPK/j7ppTrBJog9KGdZouNjWu59K/pkYU6mFj/c+SBKNvx9hwnEt/faaeROH9YZjBEBMwcqWD8eGtMSseHBYG+5Sr88nhOwgYuYuA1y3IEfsMOMTsvUoh+ncjG2Jo3EidkoUoaPpDJiHOMYTqc1QM4TmcvOzp/nzuFs9pXr555+VVZf+eTbarA+w5tog7pEnyC2+MnBRl1bCN6wu7qoyZpnWXKK7iSZDsncZRmNe93Ux3Kfk905fNRJqQNhSBd0XjpkPPeb8O8Ge59M7dGfvXY97ooXiaJQleYbCxsZbCg24lj1cvP3usBfPQySH/gOtuUrfEp1h8L79aQ2uXbeojZ0Zd9h6o6ingFq1mLAjQsEaXJ6xOgydcnOC0nXrcTwmsONf5FK2Yz9efO6kdxC3YtOCU3e/OfcxA1CYd5z5mPazPfczOfczOfczOfczqz7mP2bmP2bmP2ZQpOPcxew19zPietB2Po5Nad+Ua6bOfOQHuSXJkfouVGeJxN989SZYS1rUkba38IW9gd0HFXHEBgCOew+7iOqGOBmAP6mpwNKa8QTVzg3mOyXYOJ8xwAQ6bkD92LNKoXa164I7QkAGkJ1SXrz0DGa8z1nolO5SW2bSnBpzIlaX3wz02cMow0ksOx4V7eh7F7kYrlNTyLmVb3NJpWxKLZ7ghSsHoBneCCnG4+mg7HlWZRRzsByFQXghDVx5EqzXcqhSctf/MqPckTtParzZhPR39wDlQ68V2DtT6AJ4DtedA7WFCPgdqz4Ha5hDOgdpzoHYMunOg9hyoPQdqPSM8PzgRGsf5wYnAiM8PTuTP+cEJbiMm0ZZ0gUgq13JBoyhHvctUM2EYAMmgM2ap53rMot0GvAGSIZQrDgv1gkV+2gB03UPK4gAGx4SshWdQU08hyy4+H8keBDE1oCutMUigDs/FgGIqa2sYFe3KxCZZyQVigFOwge3ommM4K0hPlTCrA6UGyoSTjAm1qg3IG1odGHekkzIPj2nozDx1BDF1vDuMg/DW+RFTzP+mDee4i+r15YAYWamj2wd35HhVX1rwR9o928CESHvNThIBb2uxUAYwweKdf5tVUaV4W+wO8vCVJv/ARgyuO0jFCLy1zvEDxEL9j0AsxwQKlL7rcXpgGm6E5U8yjERZI1RM/PJtnMwEZD23GzARaNvJhhwBRvMJNO1xBNN+IMUFM2n+vqkMkHo2pXpkyd7pMQ5UpgyimUrw1sK/Ui85yNm9YpDvvlBa/AyTO7rZXIJ/MqY6ZSzLLLv0Mra/Nt95J1dErSaST15kSKD0spbYFSSEiuuSKA7yIPLbb79+xlmG0ndqUlH4epF6kKdmsJpbqup1Hq9oH6De/+vBKujjhizFVA96/HBDD4WAKFd2vsjBOgNSmYK3KiHwrsoY+LaYfszoEQtP4h5EWYdyjiQDFbu32VA1a84q6E6T/0L3IX0WBoMc6j5hqLuAphu6RniQ/bla3qrO6VyzHIx5ngiSYYdScLJ+4lrk86cxh4bvXoAe7m5XzcvT466nzIwg2AspYZT8Sdex3ExNLYqTOaXG4srgGAqcTGbgpeM48eYxOP/KGMOnJgEKmuEWJXusSQS+R96DTFDpAgcYTUpFZ6wV7iqJcxbgK17yApG004hsdDlK411JI1nMgZ9urbnqaSxPwLZn/2yw/adKkFdRWp05dyFIx8D3AFdjf6w0IBoOKXS97RkYrCT+BYIeZ2IvKQ+yTxFMM0zCnId07qMhYFnDjYq9VMVXEkkVBZeu7gbizJmJMf/T/8/u/9ixQZRT0uyvMKUo9KOid6Ou/p/YMtabU5HhBI4/xQ5sON7RGSZHVuwPt/sYE4sJHwHqkHvdZLESCygQqwcShJgijllPd99pAA31Rm7gIHj9Z/ZY0tOH9gOhleR00+vwGoLpZJ4yus8nPkrsuEI1wShrvoCeTp8T6j0/e5FqLr54zEyhsGGdGHnw/1+vWnysh/bBasQVJSlW8XFTLfZWsBJdgg3MOJLn+ZLcEfpAwqGxKmnbzbsdjNtBuNRU5VZ3HMQTmHpHV+zCwmRDD5z2ITM6KaDniLQ2CZVptajf8gIl4SkeVsxYGLumapINjQXLZ0PDwIrU+0xtdFCaTxdQfYeF4b/lMTgraApLQVWbp1hZlAD1PgO/RuIUB17P0+TN9/F/sdDBkqbgQw0+eEae0Yz8svxQr8TjnMUchtvbTlK3FswcPuK8zGu46vVsaTzC0HA4jh0VGiaHQjM5hJPAqwo+WvD6cz2z7gwtgJ09IQTwSOfnsPVgdvrjVkNSOQxT/ZFRgpOWQ7opXa4VnjEhRb8TPO5A7Q8ynta6EuflXxUo6bu5VdDODaOjFWdZd523Ibp2eG6E660CfTOcxbyxxCCMVlRnDhiaRT8MXiYJQt0YZlwkigvnmzLrorEVD4c8hjLexEgNtUnHg82LJwrqQvM8UDBSNnIhqewkFOYF/Go5haKvDVxVhvakyPxcW5g8l+ljTWPNAXAkBCbbQ+dz3jBWQskGb0umMoYWqkpOuPYKvL3pHMHqcAeDWYYyzNvFZbGE6HB49lJ0sTobTo/86EM3sR9Lcoq2OvWzHHp8jiGxeUuto9V5uLuyfRpfFdzTB8KB2GHu2SxrdHeYhGd0MjpJ3Vy5CSEDb9F2AS6uGCX/SdcX4RAF5quEEsFolnkd4giQf3uoao8sI/D2QrASXVyCCxWNurgElIGLfyeUoH9cRKmfO0wdjd98vD5W5nweEbq5zcbmERCkiez1zPuAxxQVrXGdxkJ1Jxyt5P/zAjY84vhHAcul8okXI08E0xPIXRneEvyoCcstz/pb/iKl2LH0fod+ZMWmqwG1aDGvnHkViZbTrzShp+KtKtTqC5JPxeTwCAOz+06zdG1KIFA9BxHp4a+YKtCf/5tkGb7KIbvluoenTUpicvu9AexJlcUKZYPPUMBJF0itCoY4L9lMm4CCpV/eWBpGR0sxxfzuFHA/Yn43GSwtxYpuVhLzjFB/K8VvG4n3+IweTk8h0+Wnj5NFahqxrcYkg6YjNs3Wbp1s0DEpyZj1uM4zPPOVwEpn3X3vp67NpRsA9QagfHfIkJPCk8dbVToXvJnpyMQ8mTowiAlxJ/NkWP0468kKeN05eqpy2PYUmlLYev6qolh7H3viPD7VQL9qb0SNrv8dXicaPctUq8ey5tJkdy57Y7l2QmaFo57h8b0HbSMJ5RplSCzuEePdU+mE5MNnTRgYwl1Pt5C/4AIRcU+zMo/l9dZkgaZbZyEYzdVf/qROfj89df337xqeJBG4H9K3ZseVrxoevSrgu+l36CD0JT6YJJSpe/OCOnMSOFRQBrdolWQw0JRvBPcbTQQoIjZs29EnMKa+NqSXSQZxPptyKurPVkWXv1/16KcewmoKg58xSVFaCSPMytwVWRmtmbAiruuLQtXyir8qpNwUAT9tqBJsq3xCf9kPigSQJEJxm9nW1/L3q4DB8mbCgjmupsjs0tAm5UotDBulany9u2af3WPpO8rFCrfdFFMecnwsWcKTpMGnZWDWZwpiS8aTij9mvE/egmkulF9XF8qXplnMYnHsPfKY6KZFp6p7JzNF1tsTXnHz4b0cRnvCS+gSrm5WhYW9hV7dkf7nPU7UDWnpE3/YbDDBYq//9YVycQludqVQjS8oA7cEPRZI/v2HVL1QSYm6RD2gPCtPSQSIck/6GsEUE9V+K9lhdF9dlcZEt1C1AXxloKESRcdCVuVj024LOYayukXDI90XmrGQ04UavjzwnK7+NO6DXOt/PN2Nn+NxPdlVnxHY6Fp1h51LaFtEUNWX1nCqeofV4EBPbry+wCkP0vPP7abMsn3FbVCaTtsTVSPwV0kbNeTTTItDM87DqrNdw702WP9LYR26jNuW0iEINAddQYBS8HYHWap8Bo7Sd+M68085qTUHGqzHFxNaIbkjNLnpfYEuwXc51O9yrN/lYL8H9g/PwI8Yn67N0S3+JBxYFBlGHAjaPX70/zN8XJHmAI+oOBi3XAy1J+9ncGNw9AS0dOe60LloBI9PRCBGYAY+La3Km/H7WaJH/YVJUYpqZBUx8PHrTXgJWJbHD7PDMHDcyyhMV2uYQZJMEusXClPws6FjFSrAdMoSrwbWoWHrxYi6tDhJRXSv2gD6ioE8RU/RiYrNLz46MQIilaj8IZBGEdOmzOI59hXFaJ59nxCGonX+Sw3d2iXbN0oahRszgrb3d4KjRkN41oc66rQxs39aO37WPW34fCEhgic4dnTuQI0F+GTnjyEVrE8Hcyuhcw45vvp0Zl20GuiAfR46WGneCGCtwHs77j7NJLth+Cf38xpowt5eweg95piGivwPyC7WlGqvz0URShqp7NrK05PqoIOBztFpKop/uicwxwmUB2azu5kUlj/XaRJla6wC0ZPyPr9WjQdTtIFlJmrZqG6+JAWGS3x/pDHtA16JesYslvbrN9Fsg8U4KyDUWXJCyUPd2i7oEKrerb5Q8bg58DxHaB/Z8o/ygJK2odEtb0HJpQYclwBS3w2aaR+gPqqNQVJvPeQw8SEGLhPPq7NtNsEgf/UZselUHyntK8qQETmBJPhMbwMlJDRUqTcS6EiQWh1QGmA5U8nefEoUKm0D46Q2VCEHxktWPwBgSuX8A7Y7XCe1D16YMK5vbsaJ4oGyO0y23OMqviyJ/KEHao7nIyRTwK32FcK6MiU96TydIS1VgFPdEeVPyk6GSHHz4mq6ZpE8lWbB4ZM56F2pOKWGXm6biM/i+koNh4j2EQbPchX+C2fIOKaqgHegphkclEZ+oSKqK9cHZeTpIwheuniUNxWUjDuIwvtCTz0E1TQrXYVulrgDKRBL/L2WDhjKUhORZpZugCmwUKfF4ARjQtOeewhTpjigOyCq+3urJyswCMeyMtR/NokB5l8MoTFgQm8IxUWj/dcRcH4ANTaj+L8AAAD//z++IGY="
+	return "eJztXUtz3DiSvs+vQOiy9kR1xVz2sI6NiXDLPdtaP1oj2e7DxkYJIlEqtFlkDR96dOyPXyTeJAE+imCVLJcODllSIb9MJBKJzETiJ/SNPL1B36pbkqekJMVfECppmZA36Oy9/uEZ+2lMiiinu5Jm6Rv0d/YDhMwfoC0pcxrBp3OSEFywz99h9r+ClCVN74o36H/OiiI5W6CzTVnuzv4XfrfJ8nIVZema3r1Ba5wUhP10TUkSF284gZ9QirekAQ++yqcdUMizaid/4oAHXxfpOsu3GH6McBqjomTfFyWDirI12mUxg45TfEdidPtk0VnKERQaPaCChHe0IPk9yfVvXKg6kDUE+PbyAokBLVmqr7pM1ZctqSa8Lf4jy5dstIKRrf2Fgsnm/SHL48bvOsDCF6C8liiBApIUlm4QNJ0bBFDoBpGTf1WkKJc5KbIqj0g4HFdiZKY6zrGbAIrqdk4MvuFbMKJsFx4A4sOiV1FSMTT5ghMtdjgiCy2d15242CTehoP16+fPl6g1ZJNmlMUBRcFptoZs00xLkpYrIBR+GiQGTgK1SDSxxPnTKq8CLs3fSblhy5L9o2igqmDWjRFCTUJNMN9o2qQ2Acl7NhqYeDl6z5Rsd1nKJBaO/LkaEm3YtpOwLdAWSiea5v4xEQlYSz4kYjuhBDHATAS32nJAjaLNZhMCl1xtg50IQS0S18BN4mwT3mQB9ZEvTMegLaazIqAaao6boyqyuzyLSFE4KboU0eVz2ONFu2pZkKj1ezVmnFW3SdPutRg5v/zCnCFmxuImMsu5INssf4JtncZsnS1vn4x72KabZOmd45fCOXyDfB+uofoZ/gjRFCmaEkMfxHualxVODolQkuwDuI6LJdu2U2YBq5b164VWI/2p2jJfFiwuDMj0JCH6D7LcP43MIc+Z+xJAaa6FwqCCphHhJkYqt6LhXAAPuIw2wdSf3DO1KJYF/ZOI6V7eVtE3Ui7/6mUuu/2DRC7Zi1+shk/B78CKgIAAAYrZSSentxU//TCtcOuQH3tRbWdV1+tqCwrzYHAXHHixD9iQKmwj6oPgcFsMWbfRblFuG274upL7NPomfRnQaQuaZx8pmONRuF3LfVT6OLrslQhnrkO9uX9BMJMRZ3ah/A7+za05jCzsA9NCHl8gQqCdweUQkRxoiahZHbw+ZloYBkeZKZelcOphlFCQoXGx2yC8ALzEBT2ECzl8lycVzqUJKj+HyGxicZXzYNWy2ku3amSV86fGBJXfgupsaZRnff6VjWS6CNpYUtthED8cBuaAVkjbmwSzA3X0VDM5C7Rh/8vucrxFApMff1TlOSyH6YK8SNcJvduU/aoEo7Ezd8rOnoFtgFqGUUnvCf80koS6bQIpo3gpJiGIQTDhWjm1DFPJqTjJ4yqm5ZJvnUHI8/FcXkKdYE4AGnNxw9FUQzaJG5OVlpim04LTlnT1eEFi09wdX5V063ZSYrbWxp1yr2FA1BrQOpMO3gz6wjrsYFoV+I44BOFj24bCP+tdhy5AXaPWmMxy18D9g/cRsIk4LHSTjPespr4GuHnq61yrHcj9nHEohZ/i1Lt/1fCyvwPB+GAPgDwQrlAMtiK7SWpgWUyWO+cmZXAVEU5IvFonGfb9ofIl2Q4ateOXe/EA8mUOFlZjwv/l2aPMSpxw7AgnSRbhEjPBwec6mU3olpnB747bmKyZ7sUCvg5bGlP4Cn7ilQiia1Sl/LMkfr1EF+vGx+Fv+K8ZdabYW1oUsIHCEQT+8AYGveH/vYGkJVmJH0i7Q+THbrNyA14JkGVnxJR9lm2B8KcL9i1ViVn0QJME3RoyjFXKLPeTO2eWZHfDQ4I98v7AxmJO5zobaSrxPaYJdi/M6ebSd/gyo3dahb4zHHwN1EMuH80sivAOR7R86j/iqb/8EeQj1tlw2YAp/hHkwrec4WKhYBj8Iehp/of7hGFGD7HNfuZ6YFaLlyErxp2TbscoHC4gNQSSRzvngMQVxAGpnqIIFiv5UYx2Uw978izzuf3PTSRCEF6Gn7kH/NFCP9IJ9miAzfLz9IOH8DzBFZYK0ecNSxTPzSG2py9vpcnNpL2MJXx1fd29gHXSNMu/QVUn8ccUX4ZEfheMQhnrcNP2PNe5j5UDrXmvLu0YzTWuEkcke1z+3826CZ0CIeShVCubPRgiUULrw6XtTpaV64AVOj/GsfGKSY1XoRRPRUm2o0+QP4on65aTfcI6HbXdMpJHq+MduQ9yjPziOEDaaaY8SxKSizsUk9JN53oweSMjTLLpkGXlhywnP3R9ati6VE7NW5QK/4aj9YmNNqz2+c8sDUj3Il3nuCjzKiordjBpDf68S3B1+CgX96aE5YOkBeRZUbGDKkNm/U5luhMQfj9lugBhix8DIPigzxNHKxTW5QLqrKMLhXnpcJXSR0R2WbTxKXi9yC3Yyu0qlhsnZDPN3NLqkp0ysy4aLtBtnn1jcxBnD+DG8MuRVcH3nIXcC/jid9h+x12igJVruupRwlblV43atRYDsDPvh3iGAildtGYVuPVMwP74D1oU15iVZh1uqyxuIp8HLLfVvPECY1kuyT8Zjoc5avHCoTuGIjmrucNpkajhnG8KZEl0QHzzq7ldTh5Gz9tcHKFQdwZdsmPeTF+rcDcrgDECBwRRozvdsfk1e4Cz85PyWdAGF9y/kZR0la70d5gPdkuYEOSPW0LRLLtOZFYcIl3TlBabIM5Ziwe4jsNwcF5idoYSCR3K/WfGx10OjhufOVyk/1YKjthHwffPRYSmUwT7co3jOIQh+V1TYwOKKop9EcVkV26CQpJl62LkfWHlEMUJYnQNsNQyv3z0MeBqKegIQk4RO3kEW9bknkbcLQu5y/Acixq5za0jcmGvzw3BSbl5CopIj8qxjYQUWjQjyQvknhTdcAyXtaTZSHHoXYBgZqiWtFhtMbTOcOrhbZaxv2vexu9rxrAx3RiidnSVpuy8B+ddZksFCD2C/qZ219EiMDq6+5lhsLrdyMueOneP5DLUv+FGHvL5dySFw5Poz6NueUgjXqNAucWHiXjf7BYEX0Mjx34N9ehF5yScwyiCCkMQZXksNmRjvyAMIX62w3lJoyrBubzDCzteFnEbHDsQ8k+WeLtzoGzbra4Y+ZrmRbmSpFJPe5rx1zI+K4DAJ6eBDA34mf8Wa4JnBwQkevCYAGHRSvoLDCV5LIdrw0cxjtQEptK6FwZllB3iiLLd06rMXAjM7oqLRpsOf8y6E90VH2koOK2FzR4ze1L/zD6mwnHdFB0BfJ/Sd1PkZlG1acnJLstL0aeFFo656FpAszaQWefZFj1saLThwhG2gUHUltEJKWy+5hO4HzAwVFQNxGJlqjBbq3j6jH2UIyHMHPeI8l3hgZabzjXUNW9uEzre+dN6wFZjc0LMeA6DNSANWzNanAAPKXatFANIzcsqbErtv+SwUiXWRhncjnb4fN4gmrz/WFjCfEg4U4pFIBbAA+5bjSrtuAreVumrbKtkC6Q7y1nRgGnjLyllpyzE82yULQ6oCbOAOCI82oyTZL1KaPotIJirD2DHGfeARrbc8m0jNL3PknsSrxwY57JOiqZLLl12Cu9oeM2BPMR9XXs6pitsdzagbXc16SAc1njYBquD6HzrVY08QvRhF+yXi3c9tO34yJQzn3WBnIcvTnfHT3fHPV/h745zj/V7vzZ+uqXl/pvTLa3WV7hbWqf7Li3Ip/suPuin2xs9tzeY/wPaE8x2548vXAWvSEToPY/388ZOqbqNBDJmJ6iS5Gt2Bud3CFs/hVhUmpXq8tJCX/MT4T3YaDNINuQ5c3jRPU7Y2fXmbzedoiF57qpLHCyboXw/SkpHYlnHxF66gn3OcVpsaVn+eDr2+Yg6ppNNp6ts6mvgrP3jdItttIhOF9jsr5Z4foy7a1axiqcNShPWYbrYGFzPpX+NQeTrYaP9TyYgX/RtHxtOt+Cvz9STyL8/9BPoI2IT6lqihlJveGvIioevEWGwiy0/n4zfQWz2unYRw94PKcgB+4zNX6/Z+yGF6N6NdIihdiN1ShaCjfRdJiFOMQT1tVcM4TmcvPTp/nTuLp/TvHx2zssPlf17NtmuFrDn2CJuTJPkF94Yma0O1bCtEBd2eRlzFpsuUQWPJ8F1HtM4Cnqwqd5usrsUfE72ZZORJiIMhedd0bDp0FPerwX8WS69U3fG7vW4rfVQPMyStCX4owQba2vJz3Qjebx6+dljIZiHVg75O1x3k7olHmPxvfxqDaFduqkPzAy/7N1T1QMJ+tWMBQEC1uDyhNVh8PiLE6y2U4+21EYHVqzrfHyskM/XnzqpjaLmbVpwyO53pz5mBtupj5kL4KmPGTn1MRsl5FMfs1MfszoLpz5mpz5mQ9Cd+pid+pid+pg5OCye0qbjsXdSC3Lr4uwnT4Bs7D3zW3mVMFEH3XwZmEuAdQVDays/5g3sNqiQK84DcMBz2G1cB9RRD+xeXfVyI8sbeDM3vN1CL7wZnDBJBVlkfP7YvkiDdrXqgDtAQ3qQHlBdPnUwMlxntPWKNiRmejfpqQErcqXH++4eGzhkGOklh+P8PT33InctFAq0vD2yLm5ptS0JRdPfEIWtP4hRzELVNbblUVVJQGbfliXZ7ko5LhxE1RpuVArO2n9m0HsSh2ntZ0xYR0c/G9YpUGuwnQK1LoCnQO0pUDtOyKdA7SlQW2fhFKg9BWqHoDsFak+B2lOg1sHh6cEJHx+nByc8HJ8enBCm4Zk+OFHoiEmwJc1ONDGs5V0WRDnMLqNmQhKA2EqbZ9BzwTOQ8IPMCYRswHPkL1hsDxuANj2kNA4kcUzIWjiYmnoKuWzjcw3ZgSCkBrSlNQQJFuG5EFBkZa2BocZWJjZKKgiroSJDa9yMrlmGU0E6VsLMBEollAknGRlq5RuQM7Taw3egk3Lh56nvzDyVg5A63mZjFF6TH5HF/H9pwtnvorq5HBAiK7V3++CWHM/NpQV3pN2xDUyItBtyMAh6ZcSSQbCblq/d2yyPKoXbYpmf57/S5GZsAHNtJjkh9Eo7xw+YlvwbZuO2NIWG4q+9KHOCY38jLHeSYSBKg5ATccu3djKDeKc/og1txe5a2ZA9wAg6nqY9lmCaD6TYYCbN32eeAeLPpqhHlvSdHulAJdwgyqlErzT8c/6SA8zueY6LzYcs2/2Mo2/Zer1Av+Q575RxWSXJwklY/1p+5jWsCKMmQGe7S5gtiRdGYuc4TbPyqko5BTiI/Pbbx/c0SZhe8Ukl/utF/EEeQ2A1t1T56zxO0cJjC7D/G2Y59GEsg5gM08PZ9T0UYjiecmXnAzBrMcQzBa94QuC1yhi4tphuzOSRlo7EvYE8ZR3CHAEBHrvX2VA+a9YqaE+T+0L3mD4LvUEOfp/Q111AjOu7RjjK/kBqkpMRJHtjngeCJMnB/fxD9RMXIp8/jdnHvn0Bur+7nZqX4+M2UyY58PZCYm51+kdmFwRNcjPFaEGczCk1FucSR1/gZDIB5ziWEy8fg3OvjCF0zBBolyW0MZI+1rDT9D1xHmS8Suc5wIiheHRGW+G2klhngWJVVFBLEbcakQ0uR6m9KyklSyFU4RrXaC5/GssRsO3YP2tkf+EJchWlFZlzGwI4Bq4HuGr7o9KAYDhA6GLbkzCY++5eIORxJvIwci/5mLnu7Jzrp9ync+/kAJo0XvPYiyq+AiQqCg6u7hqzQ7OZiSHfdP+3/Y3Wraoos62jFGuSceSDmvd6pJGsP0gK+gef+Yk7Nz+pv2l+Vnioa6abVe2lFW1mb0ntfTz5Pk/d0sLfvEH/sfz35d8OYJO7311rMtjxepHLoZhS/nd5IYb0QHFiOMTbWUNwhH1E6731gNYQ6gm+JUnRCnl2hDtroc69MH7gNBVKuT4W0EWD2Yo1vausjJl71ai/4/E/jzfLR92bMafNtf5itcW7HXRwEX9+9tezcTL4KDp58LbojHcAHUvraTX6gKfisor5rSX0UyBravoBahuOmXuY1vvITCl+f8fHu+YtTg7sARonfMfcJDw8Wte3JlzcSSJ73kzqb2s0JObsD3WY1KJpJqvEAp1mDCNeiIwSzTu6mE8DKEev5UBHweuOTYaSnghOjoRWpYebXotWH0wrw55kT9uJj69bXo0ZMMia32FHR+MJde3vnUgFFVfceaaQf79ODAxw/p9TLd4Z1t5qjWBnx5jyPKCsin1V5hVZMD86KQjELav0W5o9pP4UgCpOadcXjMZtIbwUo4JLvx/EA5h6S1f0wqLpOhs57X1mdFLiwhKpMQnKtGrUr9gZOvJPcb9ihsLYNlWTbGgoWC4b6ge2i53PcQcHJei0AZm7ejn9E8J9yS6LcVVmvJ1dqGyxZ/QuA984bx7uEAk7orVwf9XQ0WUWo7cGvDcWOKMZ+fXyrVmJ+zmLW+xv4z1J3RowGR26rbYG7jpnRycwHn5o1J+vCwqNpmOhyVzpQeCpwrYGvO6c9qw7QwNga0/wAdzT+Rm3HuROv99qiJTDMNUfGSQ4sBzgprip1qMFM0lLnuVB2emaTZ4oW0uV77pEv6XyRXqImOvX03baWov+6DKyaGx5M1PdJ3hnfWwQmdtRQAdkJoQFDwWTRwzpeHQT7aobJDMTqfwwVrEe52RY5UQ4vyPlkkdOvOxMapbJCcgX62pc9WHC9yTvahseAJUk4UKHMBTY8RdNOl6UEEirkib0z3b4bCa0Fjk/5gUvphdddNVfmSyoigD6WZNGfEbNOJfbxBjVUKjm1A2Fa6pyKKxza0cT7yzqoZgakhp3BzmGBUzdyfLDes+pZYB5wq8r5O9Qgb23ukvzepJONTfTzANCKzxh7VW1/WNtzpy4F0YjOzkHDEGiG0ZRRREh7Vx8WCScSlGsq6SNRm8WYx71G+4UgYbq4rnR7qMjm29Dczy0NVA2sJB4lR0u0cOGRhu9nHxVBDVcqtLwoMjcVBuYaLspVKhpNBSg9zrUro6dz3nTFFZC0YLKi2xse4VeXbdCbCacneMkIQktmpckQgnRovDspWhjtTacDvllD+0C1VCS42PzqC5U3rXdleMdiZq7sk5g84ujDDfciaWFY7PsrAwIiO6brBToQIZekbslOoNKuf/Obs/8IWharKDCN8+SxBnwCAD5twdVQ68JoVdnkGs4W6Aznm1g37AlffafaZaSv58FuQcyTh1lXGR/fVTmfB4R2jV6tc3DI0iZuemY9x6PKSha6ToNhWpPOFnB98UODygEm3IU0FR0+cnAE8H0Qsi2DL9AAyE+MGx52t9yF9uHzpV2O/QDbx7ZGmBEy0yAdOZ5phGmn2tCx80NdeGgKwk6FZNFww9M7zv1KxhTEj38WbNAD9iGVIHu+o5p4UZg2b52Nj4tXqWyRrUzQTnphhxHWaPTl1AQhf6rHeTr6zWhoYUnXpC7lIT2lmJMi2+HgPuO0ZkMNqvKVbZeAeYZof5Wlb+tAe/+FRs0PoRMLy/eTRapbCi8GpLsn45YNg3+YmX79yk5CXmvzHpOcr6rXOCs2+9WmjtmPGvCNwDuu2M2lUY2cLzlV0C8HUYsmWC2jdHyqYeJCXEn+fStpnS4i2j2HB3rWldzCuWVLjN/6nKX7is0cR6Pxegn4Y1w7vRUO2/dWdHoWaaaP/o6lybbc9mdUVETMisc/pykpOQUMviLCSRNQ995eC8GVvce2p7uDn5RlCQt77Ok2obyes2wSIxrshBQGNG+fjPJNZ6SsPkq4MEQnnvOXWt22DUsSaNTBVwdK8YyIZpR4IjZbt7/qcysOfEcKjLI8K2iBHuaSw+gfi0GQXwQHbZt6RN8+f7bq5dsbLqdTTn56M9WRS+/nnfop2BhNYXAzxSugCph+EnJdO5Kas2EFXFlLryr5RV+VYDc+ADusTFPsK22E95JeMuHQDCEL24z2/pi3HkMljMT5s1x1UWml4YwKed8YegoVe3j7TVbcxUmrVCTtp606qBP54q6bzK2fjw8lgzwYGh0cemZ9ZmC2EB4UnHfjH2RGjBlY6Qr1RjpUjY9XC737YcUEt206JS6Pz1TZL054YqaC++iH+0BmykBXNF0FW5Eqqpd2evnF+hICJ1+wCd+u4Z2sOWT+N8HtpgW6HpTlbyBG5uDLyl53BH4+7cxf2k9S3kzoB7lWTlKIgyrU2qirtjwNOVtZKMNJfeq5Q/jlT8FoAP43EBjLgqXhYxpkVec0m0V3027Flq3mM2RR9wleJZ31xvXDi6z+J3m8GfBoffCwZQ90I2knanR3Z5gwh3ofMfpOTYGl3B4UGK/bUKtvRkOxKJBqLlNxVHygg9TkGw0GbUWSR3ohuCk3Dwt+y4j7I9X1UIaxJJmd2BBAZuv8OOjvLLRvunrBWjlBaR8iyWESh48K6XPi3f0m7Dn1aZjIqCmx56LtO5AITp2QMwiu+UPYYSC+DHjXep4maqhghQV9LAhqbgdxo/Q4Gk7FlfDxWtedApm19U1+CLQhf8Zb2LZUP23f5/T3f3ahe4r8Z/jXdnfH9fR7uoPwOZZvaHguVaw3EUMONRR/FRrtHKAuV1XCTN9klqvNM0MiyKwf1VZzXGbZlqsMYMYl/n6hV1JrP/kWPu6hjWlNAaBoCBKxKCcaYPzmB8KC+i4OugJwSlucJ1Rr38L4+1LwuZQurTsowt0A6zya183wOyNZ/9wML4Hf6L4UrxFAHDwjuk+5NfM/f/GML7/tr/Rx3RmDmiw3mJytKM3XryWODoyFqLFvi/wNYDGBfPG85T56ReXWuUl/26S5FF8YFIYWnGmBkPvPl37l4AmuT+bLYKeeF6S4Xh1ixN4p3OKWD+wcdDPchytUB6iU5a4Yqw1hi4ITnnXkUkqIh7V8aBXBCBMOkUnFJlfXeOEOO0rUblj3LUqVbZ1h3Ps1YjBPPsuIYw/JQ0IeVxLDpre3wGOGjXhaR9qr9PGzP6pcfy0e1rz+XxCtCEe6tjRamIwFODRzh99KmhOB3MroXUO2f96wcy6qDXQAvs8dFBp3gBgjcxqM7E6zSTbedaj+3k1NH5vb5dn9xSi955bXCPKR8xIxuuzUfiqAnj5xMrRPHvUwUAUYYhROP34iRFgax0OzHJ3kzUK7rCmrIS4pTzTOCmx/1G9kBCTNYYn67Vs+LNDaYwklfD+SG3au72Se5KXIJ85GlQ5x35hKaWvksfBfaxE07BJmtW5pXy9fCtp8JqSBYKnLNnWdwNTcMOO6JAzpTiBb7P1WhzW2frj+uY+sOuXPZZhRfkJW52lzZtPPDYSZdstSWPTSUffYZSrG077T/ZZ30YsWqCE2rDhOUg4v/FOQyY4yCQ9cnPGO7py12f2SXIASvhy9KeWmB8kD15s891EtftVD0XjULRAaGyl60JjbU2WKs7gA37l3eekctepWW87eJ4B6tM42Qso2lU9df/T6hokaCbL88svvIuLm1oDlqgiP1olu41bQFn0Px8D+cZ8dZtVaTyzWFV2Fu5U1E1khFMIJrO9kEmBIMqvExBVG9MrfZuFI0+B4lHeJxjJZvdMVbvdoWZKdmUMPlM2C8eeKcnjLDOVRmw7h/fWjmCuEL1Ls1wl6htuxpA5aoB/dkatn8GuqzveA1nAXc86sE3Z7LY0XcmCkAObZuAG1kBBygEaY+M89qpu298mL91Ld4sfDyXzhpEdKXML57Fl3rak3TLX8aqnoiShbrCIwcxaCxOt8i3dCadE816e90zNH4R11e3uW615DQO2e6Ptc7+4jzumzVUB0Zr9yiz5Z71K7ALUNWqNycx5Ob1/8D4CNpFW17I2Ga/NUF8Dlpz6Ammfg4ciRJ7iVD1X3ikK+DufYRsIdCBIoQ5QiukkOdP96fmUyGcmzeidUuuztvA1ULKiaFjaXDfD+sjfumdl4L4MYVxdXw8TBYRGoFedI63zsiTyu2BUptIHSGbHfsnj+vN4O1YVNFgqDyXj3fyR5QdDxKk5cdXTKIE8lfrt70nuSdj4tXXv20ltHa7VkvPed9+gXQPbgz+fVfgPmhDpmPJuCj0NJmwm+ku+X6iITBuR/jhL+9Euw8ALFQ/3prySsZnYtZ6LrLMgsgUrX5sfmxHZw3waK5diEDCz8IqcuAyROTvMm5K5LO44006ZYo/umMFDuL9fxGR5mLAsa066zyYhwPyDERkCxvWed3g0wn8dAOc7UGPJxf8DTDm10Q=="
 }
@@ -0,0 +1,150 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package state_customresource
+
+import (
+	"fmt"
+	"strings"
+
+	p "github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	k8smod "github.com/elastic/beats/v7/metricbeat/module/kubernetes"
+)
+
+// knownLabels are the labels set by the kube-state-metrics custom resource
+// state feature, and the labels conventionally used for the object identity.
+// Any other label is stored under labels.
+var knownLabels = map[string]p.LabelMap{
+	"customresource_group":   p.KeyLabel("group"),
+	"customresource_version": p.KeyLabel("version"),
+	"customresource_kind":    p.KeyLabel("kind"),
+	"namespace":              p.KeyLabel(mb.ModuleDataKey + ".namespace"),
+	"name":                   p.KeyLabel("name"),
+}
+
+func init() {
+	mb.Registry.MustAddMetricSet("kubernetes", "state_customresource",
+		NewCustomResourceMetricSet,
+		mb.WithHostParser(p.HostParser))
+}
+
+type config struct {
+	// MetricPrefixes are the prefixes of the metric families collected by
+	// the metricset. They are trimmed from the metric names.
+	MetricPrefixes []string `config:"customresource_metric_prefixes" validate:"required"`
+}
+
+func defaultConfig() config {
+	return config{
+		MetricPrefixes: []string{"kube_customresource_"},
+	}
+}
+
+// CustomResourceMetricSet is a prometheus based MetricSet that collects the
+// metrics kube-state-metrics generates from its custom resource state
+// configuration. As the metric names are defined by the user, the mapping is
+// built from the metric families on each fetch.
+type CustomResourceMetricSet struct {
+	mb.BaseMetricSet
+	prometheus p.Prometheus
+	prefixes   []string
+	mod        k8smod.Module
+}
+
+// NewCustomResourceMetricSet returns a prometheus based metricset for custom resources
+func NewCustomResourceMetricSet(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+	prometheus, err := p.NewPrometheusClient(base)
+	if err != nil {
+		return nil, err
+	}
+	mod, ok := base.Module().(k8smod.Module)
+	if !ok {
+		return nil, fmt.Errorf("must be child of kubernetes module")
+	}
+	return &CustomResourceMetricSet{
+		BaseMetricSet: base,
+		prometheus:    prometheus,
+		prefixes:      config.MetricPrefixes,
+		mod:           mod,
+	}, nil
+}
+
+// Fetch prometheus metrics and treats those prefixed by mb.ModuleDataKey as
+// module rooted fields at the event that gets reported
+func (m *CustomResourceMetricSet) Fetch(reporter mb.ReporterV2) {
+	families, err := m.mod.GetStateMetricsFamilies(m.prometheus)
+	if err != nil {
+		m.Logger().Error(err)
+		reporter.Error(err)
+		return
+	}
+	events, err := m.prometheus.ProcessMetrics(families, buildMapping(families, m.prefixes))
+	if err != nil {
+		m.Logger().Error(err)
+		reporter.Error(err)
+		return
+	}
+
+	for _, event := range events {
+		event[mb.NamespaceKey] = "customresource"
+		reported := reporter.Event(mb.TransformMapStrToEvent("kubernetes", event, nil))
+		if !reported {
+			m.Logger().Debug("error trying to emit event")
+			return
+		}
+	}
+}
+
+// buildMapping maps every family matching one of the prefixes to
+// metrics.<name without prefix>. All labels are key labels, so each object
+// gets its own event.
+func buildMapping(families []*p.MetricFamily, prefixes []string) *p.MetricsMapping {
+	mapping := &p.MetricsMapping{
+		Metrics: map[string]p.MetricMap{},
+		Labels:  map[string]p.LabelMap{},
+	}
+	for _, family := range families {
+		name := family.GetName()
+		for _, prefix := range prefixes {
+			trimmed, ok := strings.CutPrefix(name, prefix)
+			if !ok || trimmed == "" {
+				continue
+			}
+			mapping.Metrics[name] = p.Metric("metrics." + trimmed)
+			for _, metric := range family.GetMetric() {
+				for _, label := range metric.GetLabel() {
+					labelName := label.Name
+					if _, ok := mapping.Labels[labelName]; ok {
+						continue
+					}
+					if l, ok := knownLabels[labelName]; ok {
+						mapping.Labels[labelName] = l
+					} else {
+						mapping.Labels[labelName] = p.KeyLabel("labels." + labelName)
+					}
+				}
+			}
+			break
+		}
+	}
+	return mapping
+}
@@ -0,0 +1,83 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package state_customresource
+
+import (
+	"testing"
+
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	k "github.com/elastic/beats/v7/metricbeat/helper/kubernetes/ktest"
+	p "github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/helper/prometheus/ptest"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+
+	_ "github.com/elastic/beats/v7/metricbeat/module/kubernetes"
+)
+
+// The shared KSM files do not contain custom resource metrics, this
+// metricset has its own metrics files.
+var filesFolder = "./_meta/test/KSM"
+var expectedFolder = "./_meta/test"
+
+const name = "state_customresource"
+
+func TestEventMapping(t *testing.T) {
+	testCases, err := k.GetTestCases(filesFolder, expectedFolder)
+	require.Equal(t, err, nil)
+	ptest.TestMetricSet(t, "kubernetes", name, testCases)
+}
+
+func TestData(t *testing.T) {
+	mbtest.TestDataFiles(t, "kubernetes", name)
+}
+
+func TestBuildMapping(t *testing.T) {
+	family := func(name string, labelNames ...string) *p.MetricFamily {
+		metric := &p.OpenMetric{}
+		for _, l := range labelNames {
+			metric.Label = append(metric.Label, &labels.Label{Name: l})
+		}
+		return &p.MetricFamily{Name: &name, Metric: []*p.OpenMetric{metric}}
+	}
+	families := []*p.MetricFamily{
+		family("kube_customresource_certificate_ready", "customresource_kind", "name", "issuer_name"),
+		family("crs_backup_last_success", "namespace", "schedule"),
+		family("kube_pod_info", "pod"),
+		family("kube_customresource_"),
+	}
+
+	mapping := buildMapping(families, []string{"kube_customresource_", "crs_"})
+
+	assert.Len(t, mapping.Metrics, 2)
+	assert.Equal(t, "metrics.certificate_ready", mapping.Metrics["kube_customresource_certificate_ready"].GetField())
+	assert.Equal(t, "metrics.backup_last_success", mapping.Metrics["crs_backup_last_success"].GetField())
+
+	assert.Len(t, mapping.Labels, 5)
+	assert.Equal(t, "kind", mapping.Labels["customresource_kind"].GetField())
+	assert.Equal(t, "name", mapping.Labels["name"].GetField())
+	assert.Equal(t, "labels.issuer_name", mapping.Labels["issuer_name"].GetField())
+	assert.Equal(t, "labels.schedule", mapping.Labels["schedule"].GetField())
+	for _, l := range mapping.Labels {
+		assert.True(t, l.IsKey())
+	}
+}
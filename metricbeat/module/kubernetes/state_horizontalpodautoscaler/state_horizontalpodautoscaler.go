@@ -33,14 +33,26 @@ var mapping = &p.MetricsMapping{
 		"kube_horizontalpodautoscaler_status_current_replicas": p.Metric("replicas.current"),
 		"kube_horizontalpodautoscaler_status_desired_replicas": p.Metric("replicas.desired"),
 		"kube_horizontalpodautoscaler_status_condition":        p.LabelMetric("status.condition", "condition", p.OpLowercaseValue()),
+		// Target and current values of each metric used by the autoscaler. There is
+		// one event per metric, keyed by the metric_name label.
+		"kube_horizontalpodautoscaler_spec_target_metric":   p.Metric("metric.target", p.OpFilterMap("metric_target_type", targetTypes)),
+		"kube_horizontalpodautoscaler_status_target_metric": p.Metric("metric.current", p.OpFilterMap("metric_target_type", targetTypes)),
 	},
 
 	Labels: map[string]p.LabelMap{
 		"horizontalpodautoscaler": p.KeyLabel("name"),
 		"namespace":               p.KeyLabel(mb.ModuleDataKey + ".namespace"),
+		"metric_name":             p.KeyLabel("metric.name"),
 	},
 }
 
+// targetTypes maps the metric_target_type label to the field of the value.
+var targetTypes = map[string]string{
+	"value":       "value",
+	"average":     "average",
+	"utilization": "utilization",
+}
+
 // Register metricset
 func init() {
 	kubernetes.Init(util.HorizontalPodAutoscalerResource, mapping)
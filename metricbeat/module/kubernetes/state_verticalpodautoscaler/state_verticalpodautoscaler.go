@@ -0,0 +1,77 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package state_verticalpodautoscaler
+
+import (
+	"github.com/elastic/beats/v7/metricbeat/helper/kubernetes"
+	p "github.com/elastic/beats/v7/metricbeat/helper/prometheus"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/kubernetes/util"
+)
+
+// VerticalPodAutoscaler metrics were removed from kube-state-metrics in v2.9.0.
+// Newer versions expose them through the custom resource state configuration,
+// with the names of the built-in metrics prefixed by kube_customresource_.
+// Both the built-in and the custom resource state names are mapped.
+var metricPrefixes = []string{
+	"kube_verticalpodautoscaler_",
+	"kube_customresource_verticalpodautoscaler_",
+}
+
+// resources maps the resource label of the recommendations and policies to
+// the field of the value.
+var resources = map[string]string{
+	"cpu":    "cpu.cores",
+	"memory": "memory.bytes",
+}
+
+// metrics stores the state metrics we want to fetch, without their prefix
+var metrics = map[string]p.MetricMap{
+	"spec_updatepolicy_updatemode":                                  p.LabelMetric("update_mode", "update_mode", p.OpLowercaseValue()),
+	"spec_resourcepolicy_container_policies_minallowed":             p.Metric("policy.min_allowed", p.OpFilterMap("resource", resources)),
+	"spec_resourcepolicy_container_policies_maxallowed":             p.Metric("policy.max_allowed", p.OpFilterMap("resource", resources)),
+	"status_recommendation_containerrecommendations_target":         p.Metric("recommendation.target", p.OpFilterMap("resource", resources)),
+	"status_recommendation_containerrecommendations_lowerbound":     p.Metric("recommendation.lower_bound", p.OpFilterMap("resource", resources)),
+	"status_recommendation_containerrecommendations_upperbound":     p.Metric("recommendation.upper_bound", p.OpFilterMap("resource", resources)),
+	"status_recommendation_containerrecommendations_uncappedtarget": p.Metric("recommendation.uncapped_target", p.OpFilterMap("resource", resources)),
+}
+
+// mapping stores the state metrics we want to fetch and will be used by this metricset
+var mapping = &p.MetricsMapping{
+	Metrics: map[string]p.MetricMap{},
+
+	Labels: map[string]p.LabelMap{
+		"verticalpodautoscaler": p.KeyLabel("name"),
+		"namespace":             p.KeyLabel(mb.ModuleDataKey + ".namespace"),
+		// Recommendations and policies are reported per container.
+		"container":          p.KeyLabel("container.name"),
+		"target_api_version": p.Label("target.api_version"),
+		"target_kind":        p.Label("target.kind"),
+		"target_name":        p.Label("target.name"),
+	},
+}
+
+// Register metricset
+func init() {
+	for _, prefix := range metricPrefixes {
+		for name, metric := range metrics {
+			mapping.Metrics[prefix+name] = metric
+		}
+	}
+	kubernetes.Init(util.VerticalPodAutoscalerResource, mapping)
+}
@@ -132,6 +132,8 @@ const (
 	StorageClassResource            = "storageclass"
 	NamespaceResource               = "state_namespace"
 	HorizontalPodAutoscalerResource = "horizontalpodautoscaler"
+	VerticalPodAutoscalerResource   = "verticalpodautoscaler"
+	PodDisruptionBudgetResource     = "poddisruptionbudget"
 )
 
 func NewWatchers() *Watchers {
@@ -254,7 +256,7 @@ func getExtraWatchers(resourceName string, addResourceMetadata *metadata.AddReso
 		return []string{}
 	case NamespaceResource:
 		return []string{}
-	case HorizontalPodAutoscalerResource:
+	case HorizontalPodAutoscalerResource, VerticalPodAutoscalerResource, PodDisruptionBudgetResource:
 		extra := []string{}
 		if addResourceMetadata.Namespace.Enabled() {
 			extra = append(extra, NamespaceResource)
@@ -32,5 +32,5 @@ func init() {
 // AssetLinux returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/linux.
 func AssetLinux() string {
-	return "eJzUnG2P2zYSx9/nUwwWOKApEmc3adN2Xxyw1y0OwWWvizzggDvcCWNqZLGmSIUP67if/kBSsmVbsuUnZddviq5l8jd/zgyHIykvYUrzaxBcuq/PACy3gq7h4r3//4tnAJoEoaFrGJPFZwApGaZ5abmS1/DXZwAQfwuFSp2gZwAZJ5Ga6/DVS5BY0HJ4/7Hzkq5hopUrq7+0jLkc18yNpQIKspozU33ZnKM5D1NSWo1suvimbT7/Wber/nSw+E/b4OsgTRjjigL1fOW7LpwdU/tPNRyoDGSWLGDAWLTcWM7Mi3ANpYBMK2Pg1/vPwJQmszZWG3QTPNVqnW1JLpSctHy5A95/SmRTsiYMX1IKqSOwaikrZMgFd5o6wQi1mCdnwltykLSa0xLUKihwSqCVKiBTGiTNQMkNXRugcYQzUNZsXILNqQFtcSy6lcuUk+kZcIxjjIzJnBBzMISa5ZR2ml/T8IlULct8OhczRBJQaMJ0HjQiZuNC4to6r4dnA1Ia0jbxTknnkO6frhiT9uFcr+ksJ00guLHV5PV/IgNsQX1AwY+E3Bx+Q1GbowWGUioLY4KgYos2iwQY/CHRZCxqewYJg8+DUGrqSi8fZznkGNZ5TFDNu8w08XJNhv/ZcM6FiMpn0nNsHBsjb9s1vM+ONH1xZOyoID0hk5SkE0OsdSfJhMJ1bXdK9yknkAv/81NCNaWBMGcKJWkwxJRM47LPvG9+ceRiHPnkk9IDZzRqNWOmuaWB7QhzntqQlfUYdCGWtNyYDdqGXT0WYFjljyMPilfAo/HcbmwkJ8P+mx88qp5pVbQzjtarJ6ULtNewSbZiAM6Qr+MdCY4PpHFCYHlBYEqSNlQjq17TqnhMiIb0A6Xb4nVA1aPLnFL2aMJwuq85/YHC1xGKD5PEb0znQfcjw3dcRvme+2XwlD0jtp085NAzc4c5QJCc2Pwk0EOG5XGO4b/jjBI/7JmcIs4Qwb1zFFwIHsPPPA9GvHv1+3F6j51pPwEfRH9PmpG0Hl5l4Xwb2FOnuZxUBeAKcvcm9N0YZTrjqc3BWS74n+inDUYvr3o+gtt4uUHrdLxEMeZ0qNZ9RcwNPKBwfhZgQpmwtFeXl39Z6rFRak5NcY46c3XYra0Ji7Y9vx/QmPjHx7tGE2LPXkOJvjA0OeqzHLI+hoHjLP7M7AytbyxtLLx1vqNhuN/4qvHjbLtgnDybNJ8l/+JoC0aBC4wHJdDylv7C8Rj3YWlYjnLiVbFKQYbG1gkyWN+tUuaESAxDeY5ey/KE7rNMPFCEI2R0pxwfCMb+XOwB5DZME46eiVQpJSxHfhbcqGRI0gFNE4ZuTIFfE09ce3Y/zNSV59U0daXgDP353GeQNT+skQoq1EqT5mTZ8n1sW4fxIcWVn23LnVHKwxNoNWMZduCD0+bE+1wyNTMs01HQbt/FqgvqWJjvWs3o8pWjw3gOcepdgCnXxOzwgHFe0d3dKyeZJhoOzM8WdPPFRbynsU07SygGXN3l4SzSamICedF3pQPtcEvdTbtz2eMFCWUZZ5wkm49K1t2ZNAwFpUlbqdqkLmNVugs7zl3TwpKhHgAnNIIbEGpGuvE34DINidI0nMeXm8ZqN5mIuG0uxo35pTvJx+X8NhLEub+JBLX5uZtQm492Z+9SU8a/XsPFf4IK/73Ylt0/+QNBGAWYktZv9Y0s7/cprO5EeJDKgZ0JJxrZMG6j/bFjQ7DKojhv2O3a0BsGVXenSqVEtyM6Q2lrp6k3d9ePe2DfxV3YM3haFELFWmRpxQ7ybVGzg7tntHSUeStn4BUv2lopa9q7fD+tf+ADchHq5309RVNslHxb/poCxs6CVLbVafoZZJwuhTvzNrnLHvVAmqmi4H3dPqUMnbBt7b7e6EeE7G2cPvYyM6U7mRcqz3A9lw+e5UNGn2FZl/3fOtUfof8nP2/TmEea2j/7nN4L85iceATgzSIR9hdzuBqtY/FzatZjKotbZy8DuDzsVNC75+EpyrCRd0Mod+DZZG8KP9OWrQxTzAnTEQvdmTPQfPRrspinblZxG2+2ecpEY5LzXpTDSDZG70xKNrE1pZz5IVq6Qp6Ky0ydoy/UMva2dtDYpek8WftBN1APeW7RYlyrV6VW7FWYwU8QB/NnnRB7cWHHc1A63agGdm0it3c3navaxtyDO7Df3cTO1u1qQ20XVhPt4nJ9713F63C6noSBQhMBy52cGp/JXv/v8vv7m7//lnx89+/ftqNdDY521Rft9eBor/uivRkc7U1ftB8GR/uhL9qPg6P92Bft7eBob/ui/TQ42k990X4eHO3nvmi/DI72S++UO/x2cNW1H9RQUqVkRt+37vhq/AdtFOvxj0m8YkrzmdLrpV/jkqTA0u/z1fUXxmouJ1tPpJvWfcBZ3aHgSkIoIBpVhd+l/fArlcuivtJkzOqT+EfUV/FOWz2mPygLEXj8ucSzVS93hEM9K92L6kTxAlCmwPtWYqx0I6MKGl1dthyXup862X4+2qHyyvM0OWqqbxcDl9UDKWjBi2TBw4FFM62eSwlCxLr31/vPoR8DCJZk/bzqjMtUzdqbGwtr3z5paw3/aud72fvm8kkbbHOfbXInU+3Pz3sYHvo+Iz/5aKN12JkKe9gXxgUcGyWcrcOz8UwY02rxTNi+VrebVDUMnmqwVvcO9ovXps1PMGRXbe4dtU2rn2Lgri31vrHbNP/Jhe+2u6jVd5kT4rFF8NJGIUAq+ZKnYruJYHjhhEVJyhlxYGAHKR5ZYJ9Min3jPYjx2OL9dI5xYBoIqjyqNLC/JO0GcvVkN3Ou9oz32tZHFuv72do7oGtrH1sw77m0+0ZsbfajitZ+Nnca9NQ3a66O26hrCR5Z4J5Egn3i+clvzt2OcECYP+1NuRnui5u3WIrTNO5WjfoX2nDvHw1oKlV4s348h3fSkoAPN/fv+/bpNv/th2M09sNV/3SCzSmA1O9/gdJ8wmV4FifTqhjB79J7i6x+xQ18caR59ZZ4jjqdee/sbFykGovRDG3Hs/8HvNN2H550jU8F+YX3Y9ePB91+uLmL9qSqQC67kf5QTpzuhd0POIOywcWUk5b04t24wLWG1MpWlpfDqXV/f7lTLA80rFaeqp9UV0NKddVDqqvBpbrqJxWyKU5oQLnihLslq8CGla2atQb7fwAAAP//JlMIag=="
+	return "eJzcnHFv2zYWwP/Pp3gocLh1aL1k3bo2fxyQS3qH4pYtaNoNuMOdSlPPFheKVEkqrvfpD4+kbNmRZDm21XmYgaGx/N7vPT4+PlIkn8Mdzs9BClV+PgFwwkk8hyc/0r+fnAAYlMgsnsMYHTsBSNFyIwontDqHv50AQPgt5DotJZ4ATATK1J77r56DYjkuxdN/bl7gOUyNLov4lwaZS7l2bh3mkKMzgtv4ZV1HXQ/XSjnD+N3imyZ9AA/tAuhkoU+T8HWQOowt85yZ+cp3bTgbVNMnigM9ATVJFjBgHXPCOsHtM/8MpsC40dbC5c0H4NqgPVkR1AhdB0+NXmdbkkutpg1fboCnT8H4HTrrxReYQloiOL10K0yYkKI02AqGzMh5ciC8JQcqZwQuQZ2GnN0hGK1zmGgDCmegFdp20CDhAJQVm1DgMqw5z7GxbPfcRJcqPQCOLTlHayellHOwyAzPMG01v6IRU6UNHgCnCjGLqIBJgyydex8hd6EhWc1lhDlvh1QWjUsoKPEQrvupzMdoqDtXbTrL0CBIYV1UXv0vMEAH6j2TYkdI2OxRlzEHnCmlHYwRfG/BtBUrxENi0Dpm3G50DQ9AiHmQWt+VBblP8Awy5tt5jBD1LjNNeNygFb/XgnPhRE2Z9GRTpn7EwPFActeoQTE7MvipROtGOZop2qRAk1jkJ03em0i9IruX695nCGoRf6QSokoLXmcKBRqwyLVKQ7PPKDY/lViGfkTJJ8V7wXHUaMbMCIcD2+F17tuQlfYYtCGWtMLaB7Q1uxq5VxtgWM/vRu49HoFH47lDeyjsv5Pw4PWJ0Xkz42pYABUAOXPn8JBsxQA2Y8LtF5zdo2FTBCdyBFugcgSzFjWNHg8J0aK5x3TUyBzCZUCve4V7dbuXOKDf14L+kY6Pvx+x+2lCA9Nh0EkyfCVUcN9TCn6X1eC7e2wzuc+hB+b2OkCimrpsL9BDdsuI+cjAoN4qOCbU2w8DHDUEcAqOXEgpQtazT6nHwdtvft7N3+PSzvdHf4OGo3IEryd+fuvZ09IINY0F4ApyKy18NWYqnYnUZVA6IcXvjJzmjV4+9XQEV+Fxy1xpwiOa89JQuekrYmHhnsmSfAJcauvntGenp39Z+uNk3Sl3Nj9Z98ce6sxVsV1FJtWjzfl9HaNHs/zr9rq2CLH2dRNFnaRgVBjajJmDTLJuveCgBYSC0uKoB4tQ00PACCo3ovygbRNMqQ7mmg9KfCqxAyNnC4x7LZkTEg+AcUMKgGdMTckrTmuYMOuqBOmtb/cSLTwkljNlD4C2nKFTlgkTCj+F9D6DjN0jjGmlgQBUF6b1U89E6RQTnjFxENzgSZ+kPZpB5ldjcvY5IeIqsvthpmVxWJ+mZSEFZ7QuQxlkLQ4rpBxzbeaHyJZ+iTvKh5Q51jN3EmiyQwKNGknMDmlzSjGX3NkZK9IRyVqXsLGxqoI6TIcaHlih9iqqQIfxHILqTYCpMMjd8IBBr5x38E0M4nBgpM37jYqL8E6jg806ZHLA1l1OzrwyMMglE3nflva0wzV1O+3GZg8PJDiZCC5Q8fmo4K6V1nImMU2aStU6dRGq0k3YQXdFC0uGSgCb4gguQOoZmtrfQKjUJ0pbCx4qN60z5XQqw7C5kBvyS3uSD835ZVwQdH8RF1TmZ+UUm2K0PXsXBifi8zk8+Y8PhP8+Oemw8H0mbBid6EWDo6G+luVpnGLxTQSBxAAuLTWzVjXjRlsOCE47JltbcS/dbtOAXjMovp0qtJajVuTSYtq40tSbu+3HPbCvfYjQfCCleQGTUlMXS2tWbCDv6jUbuGNgP458dQ5cc7qnamWmAeixvPuJD3bPhKQCc+tIMUhLFZh+Wf6KAsalA3oF1hQ0/QyypSlkab+sPfoeDdd5LvqGfYoTVkrXtNw3RJe9CuqB1JO8VuaFl2es+NJZnmKBBr04Jn3xVL+D/9+T3roxo1bEL5raP1BO74W5S07cAfBikQj7O3O4Gq2ZmdaNi5WRp+ztZqEeNyvogRXWPMiNtFFHqHYIXbqBKEhTKwa9rGMZveHkfnXmADS31CYLPXFYyoQLL9uIMjEsyUQvymFcNmYUTFrVsQ2mgpOIWnav+IhKqIk+2ZTZH7Eu1CC7KSNXKOMyTefJ2g/agXq454o5Ftrqm8Jo/o3XQAqCdTTX8X2PQC1N0LVJ0Ww5iFxdXzz4rou5Bzd9rq4vfMDB1eqC2iasOtqT0/Wxt1fQ9SSkD+V94Fmp7ixlsm//d/r1zcU/3yS3b//9phvtbHC0s75o3w6O9m1ftBeDo73oi/bd4Gjf9UX7fnC07/uivRwc7WVftB8GR/uhL9qrwdFe9UV7PTja675oZ8MPB2dt40EFRS/y7OjrNYnBV3r8Gz4o1sMfk/DEHc5n2qTtjyQ5KwqhpvH5J9bRe7snJ1tZ947NqhUK2rXgC4haVUGjNIlfqVwq+wqD1q7uxN+hvgpv2iqZNFGWEkgvzaSILR7uoJkK8KJ8FqdEz4CpFIQ+6a4fKmhelCOrcxydnTZMl9p3nXTPjzZ4eWU/Db2srl4X00JQ2JDCHJCTHBAcOGbv4r4U74hQ99JWFlqPAQaOXij7PTgwEyrVs1G3tS+P2lorPrv5Vva+OD3u5s0o22SlSmljyjaG+yX+ESkfPVg6bE2FPezzcoGNrZali9j1PWF0mKjaE7at1c0mxQWDY+2s8d3Bdv21bvPLY7e5d6+tW/3i9NjN3rrv1s0/uu4b4bsMo+1Xf7QevLRRSlBaPRep7DYRrMhL6ZhCXVr5yI7tXfHyT+qKbfu7d8aL0z+pNx6bBrxX/lBpYHuXNBso9NFW3kJv2d+FPtpBXOitO7TQxzt4C/3oHiv0kQ7aQrcadOyDtdC7DdRCH/0gLfSuA7TQxz84C72vgVnoIx+U6929MsqwQu5n4W7VqF+Z8+/+GW1wKbShHS7jObxVDiW8u7j5se863cO7H3bxMd1wEq9OoM02BFKd/wJtxFQoRqS09DmCnxUVtSr+Slj4VKIR8ZR4xkw6oz7aunCRGpaPZsw527vjbLDgxm/2DbuCKAJIdrU96OrdxbV3LKQ6Z0K1I/2mS4l7Y6KV46LGxXWpHJrF2TjPtYbUyFYUp8N56+bmdKOzCGhYXxFVP1edDemqsx6uOhvcVWf9XMX4HW2VH85dQeFml0WwYd0Wta6DVVA2X717ZW/Dwe31xbv3kAp7Bxky6TJ/iGp9dPDquZM9x4Z4lJj+0ei/5ldnGzwYj/AWbHmGnLBbUrx/eERu2h9B5YUo3Yvy+/roIAANQfMFFV3iRWWQhY+WuY+gDXxU9zl+7MQtjHaaa7k/5Jsose6xGtvF+4uPz+Dj7eXt28D40y/XGxhzneIeAa9JnA/y3o1q0Qgmk8bt0zuQ3HqxcVd2b5iJMDlVG8k9Giu02h/PP6JkiJI3I/ltdw/vDwsMY60lMrUdw68ZugxDhoqZgrJDjYTyv0zpXhLSPoK3js40TZi0vpyjtXd6KzxGmuozfhdOPxVG3AuJtPPNadAFqtpx/WfL2pkzS9us5Tx+tfK+WSxzVLM/8LNw/ozn3mYDbz4LR/W/Ky2MhcuZvWvMk4u03t5YnBWMCzdv3GDdyte1b3kD+weLZqF1czCF0WBUMGsxPUxEkXIICkBQXUOTSyljpMXhyKKcPKdnrM1RuU5YumHt4aH/PdAu2jU0tl3iMwp2r7XbjQbpsYSWsGymZZrgZ46YHsK1TM1p3+1zUlgaBOacEWN6KSwsrT5pA2OUeuZ9vgBqxneYF2jo1gwccZRW7K0rXZbG0CUrNQ0reUUoSHFqEC1cBsXNhL6oSrQaZbo0+4JbHq/xUpdUGbPhsgCv1i9kdGHxOZe4fygvHILwzT2ZOTYyuDjmRHfQOb03V1FhG65OiSDvlqrgNqiCS10qVwvEr95ewfdPu3Cpm2GalIprQwduG+7F3CNxzOAf6trgjTHa2DXqs1c/dHBznedMpf62H106eyjiy6AH3gc9DxhfPe0RCkKrBO9RucOHApUvb0hVYyScvX7ZwVugSmkX3YHDtspHN0FdDN0W3q4Y0JOJFAqHCd2fg7K1yO1k74oNbniCPuwPBfxBOsPofMHlu8vQw1owX3dgxhE3Wfxm/yn24v1FrEOWSoCHGJHzlWHUZSjMpoGUJn8jboQTnMlkxoxqK1S2p76MYiGKXZSmi3v5aFoXSxGQetqBuDjem9iCGWx9u9BxWm2nlwzvkBZmyAiv/0HB6i1pH+sabVg2zdDW3K7asACJkRMmOStmxemEBQZ8rVk7LI5wdKdNyznDw9r5C6pUG0DrRM4cPmisv1qQYhIWS/wckTMFoQJeu2XtgWlpHLOaT6K2dpkdJkp06GntOtGFIZtA6TZJh2pY1qi0OqjcBzbHVLD9pvpl7iyVQU4v8+jSZgIUyuHUUA8IGqsl2T6k/heJ1NOk+T7y3XG9hpWVBamni+u8t8g7pbJsgonNSpfqmdo/aVAACwUNSfH/AwA9IyWm"
 }
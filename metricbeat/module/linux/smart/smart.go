@@ -0,0 +1,405 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package smart
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. After the
+// MetricSet has been created then Fetch will begin to be called periodically.
+func init() {
+	mb.Registry.MustAddMetricSet("linux", "smart", New)
+}
+
+type config struct {
+	SmartctlPath   string        `config:"smart.smartctl_path"`
+	Devices        []string      `config:"smart.devices"`
+	ExcludeDevices []string      `config:"smart.exclude_devices"`
+	Timeout        time.Duration `config:"smart.timeout"`
+}
+
+func defaultConfig() config {
+	return config{
+		SmartctlPath: "smartctl",
+		Timeout:      30 * time.Second,
+	}
+}
+
+// MetricSet for fetching SMART health data of the host's disks.
+type MetricSet struct {
+	mb.BaseMetricSet
+	smartctl string
+	timeout  time.Duration
+	include  []string
+	exclude  []string
+
+	mu     sync.Mutex
+	denied map[string]bool
+}
+
+// New creates a new instance of the MetricSet. New is responsible for unpacking
+// any MetricSet specific configuration options if there are any.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	base.Logger().Warn(cfgwarn.Beta("The linux smart metricset is beta."))
+
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	for _, pattern := range append(config.Devices, config.ExcludeDevices...) {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid device pattern %q: %w", pattern, err)
+		}
+	}
+
+	path, err := exec.LookPath(config.SmartctlPath)
+	if err != nil {
+		return nil, fmt.Errorf("could not find smartctl binary %q: %w", config.SmartctlPath, err)
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		smartctl:      path,
+		timeout:       config.Timeout,
+		include:       config.Devices,
+		exclude:       config.ExcludeDevices,
+		denied:        map[string]bool{},
+	}, nil
+}
+
+// Fetch scans for disks and reports one event with the SMART health data of
+// each disk matching the configured device filters.
+func (m *MetricSet) Fetch(report mb.ReporterV2) error {
+	out, err := m.run("--scan", "--json")
+	if err != nil {
+		return fmt.Errorf("error scanning for devices: %w", err)
+	}
+	devices, err := parseScan(out)
+	if err != nil {
+		return fmt.Errorf("error parsing device scan: %w", err)
+	}
+
+	for _, dev := range devices {
+		if !m.selected(dev.Name) {
+			continue
+		}
+
+		args := []string{"--json", "--all", dev.Name}
+		if dev.Type != "" {
+			args = append(args, "--device", dev.Type)
+		}
+		out, err := m.run(args...)
+		if err != nil {
+			report.Error(fmt.Errorf("error reading SMART data from %s: %w", dev.Name, err))
+			continue
+		}
+		info, err := parseDevice(out)
+		if err != nil {
+			report.Error(fmt.Errorf("error parsing SMART data from %s: %w", dev.Name, err))
+			continue
+		}
+		if info.Device.Name == "" {
+			info.Device = dev
+		}
+
+		if info.Smartctl.ExitStatus&exitOpenFailed != 0 {
+			if !info.permissionDenied() {
+				report.Error(fmt.Errorf("could not open device %s: %s", dev.Name, info.messages()))
+				continue
+			}
+			// Degrade to a device-only event so the disk stays visible
+			// even when Metricbeat is not privileged enough to query it.
+			m.warnDenied(dev.Name)
+			if !report.Event(mb.Event{MetricSetFields: info.deniedFields()}) {
+				return nil
+			}
+			continue
+		}
+
+		if !report.Event(mb.Event{MetricSetFields: info.fields()}) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// run executes smartctl with the given arguments. smartctl encodes the disk
+// status in its exit code bitmask, so a non-zero exit status is not treated
+// as an error as long as it produced output.
+func (m *MetricSet) run(args ...string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	out, err := exec.CommandContext(ctx, m.smartctl, args...).Output()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && len(out) > 0 {
+		return out, nil
+	}
+	return out, err
+}
+
+// selected returns whether the device matches the include patterns, or any
+// device when none are configured, and none of the exclude patterns.
+func (m *MetricSet) selected(name string) bool {
+	for _, pattern := range m.exclude {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return false
+		}
+	}
+	if len(m.include) == 0 {
+		return true
+	}
+	for _, pattern := range m.include {
+		if ok, _ := filepath.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// warnDenied logs a permission warning once per device.
+func (m *MetricSet) warnDenied(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.denied[name] {
+		return
+	}
+	m.denied[name] = true
+	m.Logger().Warnf("Insufficient privileges to read SMART data from %s, only device information "+
+		"will be reported. Run Metricbeat as root or with the CAP_SYS_RAWIO and CAP_SYS_ADMIN capabilities.", name)
+}
+
+// smartctl exit status bits, see smartctl(8).
+const (
+	exitCommandLine           = 1 << 0
+	exitOpenFailed            = 1 << 1
+	exitDiskFailing           = 1 << 3
+	exitPrefailBelowThreshold = 1 << 4
+)
+
+type device struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Protocol string `json:"protocol"`
+}
+
+type scanResult struct {
+	Devices []device `json:"devices"`
+}
+
+type deviceInfo struct {
+	Smartctl struct {
+		ExitStatus int `json:"exit_status"`
+		Messages   []struct {
+			String   string `json:"string"`
+			Severity string `json:"severity"`
+		} `json:"messages"`
+	} `json:"smartctl"`
+	Device          device `json:"device"`
+	ModelName       string `json:"model_name"`
+	SerialNumber    string `json:"serial_number"`
+	FirmwareVersion string `json:"firmware_version"`
+	UserCapacity    *struct {
+		Bytes uint64 `json:"bytes"`
+	} `json:"user_capacity"`
+	SmartStatus *struct {
+		Passed bool `json:"passed"`
+	} `json:"smart_status"`
+	Temperature *struct {
+		Current int64 `json:"current"`
+	} `json:"temperature"`
+	PowerOnTime *struct {
+		Hours uint64 `json:"hours"`
+	} `json:"power_on_time"`
+	PowerCycleCount    *uint64 `json:"power_cycle_count"`
+	ATASmartAttributes *struct {
+		Table []ataAttribute `json:"table"`
+	} `json:"ata_smart_attributes"`
+	NVMeHealth *nvmeHealth `json:"nvme_smart_health_information_log"`
+}
+
+type ataAttribute struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Value      int64  `json:"value"`
+	Thresh     int64  `json:"thresh"`
+	WhenFailed string `json:"when_failed"`
+	Raw        struct {
+		Value uint64 `json:"value"`
+	} `json:"raw"`
+}
+
+type nvmeHealth struct {
+	CriticalWarning         uint64 `json:"critical_warning"`
+	AvailableSpare          uint64 `json:"available_spare"`
+	AvailableSpareThreshold uint64 `json:"available_spare_threshold"`
+	PercentageUsed          uint64 `json:"percentage_used"`
+	DataUnitsRead           uint64 `json:"data_units_read"`
+	DataUnitsWritten        uint64 `json:"data_units_written"`
+	MediaErrors             uint64 `json:"media_errors"`
+	NumErrLogEntries        uint64 `json:"num_err_log_entries"`
+	UnsafeShutdowns         uint64 `json:"unsafe_shutdowns"`
+}
+
+// nvmeDataUnit is the size of an NVMe data unit, which is reported in
+// thousands of 512 byte blocks.
+const nvmeDataUnit = 512 * 1000
+
+// ataAttributes maps the ATA attribute IDs most predictive of disk failure
+// to the field they are reported as.
+var ataAttributes = map[int]string{
+	5:   "reallocated_sectors",
+	187: "reported_uncorrectable",
+	188: "command_timeouts",
+	196: "reallocation_events",
+	197: "pending_sectors",
+	198: "offline_uncorrectable",
+	199: "crc_errors",
+}
+
+func parseScan(data []byte) ([]device, error) {
+	var result scanResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result.Devices, nil
+}
+
+func parseDevice(data []byte) (*deviceInfo, error) {
+	var info deviceInfo
+	if err := json.Unmarshal(data, &info); err != nil {
+		return nil, err
+	}
+	if info.Smartctl.ExitStatus&exitCommandLine != 0 {
+		return nil, fmt.Errorf("smartctl command line error: %s", info.messages())
+	}
+	return &info, nil
+}
+
+func (d *deviceInfo) messages() string {
+	msgs := make([]string, 0, len(d.Smartctl.Messages))
+	for _, msg := range d.Smartctl.Messages {
+		msgs = append(msgs, msg.String)
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (d *deviceInfo) permissionDenied() bool {
+	msgs := d.messages()
+	return strings.Contains(msgs, "Permission denied") || strings.Contains(msgs, "Operation not permitted")
+}
+
+func (d *deviceInfo) deviceFields() mapstr.M {
+	event := mapstr.M{
+		"device": mapstr.M{
+			"name": d.Device.Name,
+		},
+	}
+	if d.Device.Type != "" {
+		event.Put("device.type", d.Device.Type)
+	}
+	if d.Device.Protocol != "" {
+		event.Put("device.protocol", d.Device.Protocol)
+	}
+	return event
+}
+
+func (d *deviceInfo) deniedFields() mapstr.M {
+	event := d.deviceFields()
+	event["readable"] = false
+	return event
+}
+
+func (d *deviceInfo) fields() mapstr.M {
+	event := d.deviceFields()
+	event["readable"] = true
+	event["exit_status"] = d.Smartctl.ExitStatus
+
+	if d.ModelName != "" {
+		event.Put("device.model", d.ModelName)
+	}
+	if d.SerialNumber != "" {
+		event.Put("device.serial_number", d.SerialNumber)
+	}
+	if d.FirmwareVersion != "" {
+		event.Put("device.firmware_version", d.FirmwareVersion)
+	}
+	if d.UserCapacity != nil {
+		event.Put("capacity.bytes", d.UserCapacity.Bytes)
+	}
+	if d.SmartStatus != nil {
+		event.Put("health.passed", d.SmartStatus.Passed)
+	}
+	event.Put("health.failing", d.Smartctl.ExitStatus&exitDiskFailing != 0)
+	event.Put("health.prefail_threshold_exceeded", d.Smartctl.ExitStatus&exitPrefailBelowThreshold != 0)
+	if d.Temperature != nil {
+		event.Put("temperature.celsius", d.Temperature.Current)
+	}
+	if d.PowerOnTime != nil {
+		event.Put("power_on.hours", d.PowerOnTime.Hours)
+	}
+	if d.PowerCycleCount != nil {
+		event.Put("power_cycles", *d.PowerCycleCount)
+	}
+
+	if d.ATASmartAttributes != nil {
+		failing := 0
+		for _, attr := range d.ATASmartAttributes.Table {
+			if attr.WhenFailed == "now" {
+				failing++
+			}
+			if field, ok := ataAttributes[attr.ID]; ok {
+				event.Put("ata."+field, attr.Raw.Value)
+			}
+		}
+		event.Put("ata.failing_attributes", failing)
+	}
+
+	if h := d.NVMeHealth; h != nil {
+		event.Put("nvme.critical_warning", h.CriticalWarning)
+		event.Put("nvme.available_spare.pct", float64(h.AvailableSpare)/100)
+		event.Put("nvme.available_spare.threshold.pct", float64(h.AvailableSpareThreshold)/100)
+		event.Put("nvme.percentage_used.pct", float64(h.PercentageUsed)/100)
+		event.Put("nvme.data.read.bytes", h.DataUnitsRead*nvmeDataUnit)
+		event.Put("nvme.data.written.bytes", h.DataUnitsWritten*nvmeDataUnit)
+		event.Put("nvme.media_errors", h.MediaErrors)
+		event.Put("nvme.error_log_entries", h.NumErrLogEntries)
+		event.Put("nvme.unsafe_shutdowns", h.UnsafeShutdowns)
+	}
+
+	return event
+}
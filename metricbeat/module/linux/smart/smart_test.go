@@ -0,0 +1,131 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package smart
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	_ "github.com/elastic/beats/v7/metricbeat/module/linux"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestFetch(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(t, nil, nil))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+
+	assert.Empty(t, errs)
+	require.Len(t, events, 3)
+	t.Logf("%s/%s event: %+v", f.Module().Name(), f.Name(),
+		events[0].BeatEvent("linux", "smart").Fields.StringToPrint())
+
+	sda := events[0].MetricSetFields
+	assert.Equal(t, mapstr.M{
+		"device": mapstr.M{
+			"name":             "/dev/sda",
+			"type":             "sat",
+			"protocol":         "ATA",
+			"model":            "WDC WD40EFRX-68N32N0",
+			"serial_number":    "WD-WCC7K0000000",
+			"firmware_version": "82.00A82",
+		},
+		"readable":    true,
+		"exit_status": 0,
+		"capacity":    mapstr.M{"bytes": uint64(4000787030016)},
+		"health": mapstr.M{
+			"passed":                     true,
+			"failing":                    false,
+			"prefail_threshold_exceeded": false,
+		},
+		"temperature":  mapstr.M{"celsius": int64(33)},
+		"power_on":     mapstr.M{"hours": uint64(28092)},
+		"power_cycles": uint64(41),
+		"ata": mapstr.M{
+			"reallocated_sectors":   uint64(8),
+			"reallocation_events":   uint64(2),
+			"pending_sectors":       uint64(1),
+			"offline_uncorrectable": uint64(0),
+			"crc_errors":            uint64(0),
+			"failing_attributes":    0,
+		},
+	}, sda)
+
+	// Permission denied: only device information is reported.
+	assert.Equal(t, mapstr.M{
+		"device": mapstr.M{
+			"name":     "/dev/sdb",
+			"type":     "sat",
+			"protocol": "ATA",
+		},
+		"readable": false,
+	}, events[1].MetricSetFields)
+
+	nvme := events[2].MetricSetFields
+	assert.Equal(t, 64, nvme["exit_status"])
+	assert.Equal(t, mapstr.M{
+		"critical_warning": uint64(0),
+		"available_spare": mapstr.M{
+			"pct":       1.0,
+			"threshold": mapstr.M{"pct": 0.1},
+		},
+		"percentage_used": mapstr.M{"pct": 0.03},
+		"data": mapstr.M{
+			"read":    mapstr.M{"bytes": uint64(20000 * nvmeDataUnit)},
+			"written": mapstr.M{"bytes": uint64(30000 * nvmeDataUnit)},
+		},
+		"media_errors":      uint64(0),
+		"error_log_entries": uint64(4),
+		"unsafe_shutdowns":  uint64(12),
+	}, nvme["nvme"])
+	assert.NotContains(t, nvme, "ata")
+}
+
+func TestFetchDeviceFilter(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(t, []string{"/dev/sd*"}, []string{"/dev/sdb"}))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+
+	assert.Empty(t, errs)
+	require.Len(t, events, 1)
+	name, _ := events[0].MetricSetFields.GetValue("device.name")
+	assert.Equal(t, "/dev/sda", name)
+}
+
+func TestSelected(t *testing.T) {
+	ms := &MetricSet{include: []string{"/dev/nvme*"}, exclude: []string{"/dev/nvme1"}}
+	assert.True(t, ms.selected("/dev/nvme0"))
+	assert.False(t, ms.selected("/dev/nvme1"))
+	assert.False(t, ms.selected("/dev/sda"))
+}
+
+func getConfig(t *testing.T, devices, exclude []string) map[string]interface{} {
+	smartctl, err := filepath.Abs(filepath.Join("_meta", "testdata", "smartctl"))
+	require.NoError(t, err)
+	return map[string]interface{}{
+		"module":                "linux",
+		"metricsets":            []string{"smart"},
+		"smart.smartctl_path":   smartctl,
+		"smart.devices":         devices,
+		"smart.exclude_devices": exclude,
+	}
+}
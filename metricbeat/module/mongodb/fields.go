@@ -32,5 +32,5 @@ func init() {
 // AssetMongodb returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/mongodb.
 func AssetMongodb() string {
-	return "eJzsXV+TI7dxf79PgVIeJFXt8cpOKg9XjqpOlh0rpbMU3bn8kErNgjNNEloMMAYw5NGfPoUGMIMZYv7w7+0xy4fEuiUbv240Go1Gd+M1eYL9W1JKsZbF8hUhhhkOb8lX7+2//PD9V68IKUDnilWGSfGWfPeKEELeg1Es1ySXnENuoCArJUvif0Q0qC0ovXhFiN5IZbJcihVbvyUryjW8IkQBB6rhLVlT+x0whom1fkv+5yut+Vf/+4qQFQNe6Lc42msiaAkxSvsx+8oSULKu/L8kgCJYj6p0oBf+D/EI8SiWJ22o0c1fUmONjBeP6QXEpCCWJtPGiq2HxH66EgmfPsYYZyOILsgn2O+kKnp/G4FqPz9QQ5dUA5JuYSXHbVm63Ph/bMU0A4H9v5ccu1wyQXFwuSJFEAUVRTx9M3AZaShfGFbCotZJgFyK9XHoPlqaZEeZXSHE0iYrqQiX+ZMmTJCS5UpqyKUoOvrUR5XLWpiLYhJ1uQRlRWbBIEQCWxBGT4jJfj2JpL++yMAKiIkpoMWAyEdZnMEmsmoFHqRvBW/HmyP9A4ypCbgEwr8209BAmzEXMbydYgZuKUMc8FghOpTXl2IL7giV/kcNioG+9Nq3glO1EFZwfoh5az7gueSqb0UUoMAnyGsDxYRw1mBKqYY07CzhUP0UlMoOQfJaabtI5W6moAK26wjKcqwJDbCofrKeUgBrd5oJ0TGhQZlrSM5RtsITsCOFzOvS6vk8qXlY1xFawOJHmbcA66qgg0bsLEEhZSunI2XkEV1ZRm6UeTJSUMrtVWRUAIdTZOQRXVlGiG6mjHJZltTivYKUnLlEMQUfMww3T1wNuCsJ7ADVTAPfPy2Rszw6pLbQ7J99H3+Uyxmc2s/HDTiHmNQil2WlQGsoiB0N5wBKqfZWGJRzoiCXyk0OjY4Cwz6Kg34tH8Vib51uufwNcqOJVN1VZzZMHwGWbtc/L3/7cD1p0y0ougYnYitY4aE7sBCfsb5hgiz3BvS3U7C1kZbqFXE7LaGlnUyL249oFUPm1EBBjOwLG/f2xu6EX8znCsf8URTw6eqMNdPBOWF2RNDHAr0iRl2X6D/Z/9nONB7JuzI6AnNJP10B7YeN9TUt0JJ+YmVdRku0XZdmQw0p6Z4sgVib49wba1VoVUFxxHoVfrKubl+CUsj+Kl2Qd5xH/63Jhm6BUEM4UG2IFOB+HH6ascIZ/Za3NoZ1dqTNkyCVkltWWHdbELkFtWWwQwUnFVWG5TWnykUQm41uQT7a9RumqUOWaVJKbUguRQ5KQEF2zGzwp2QreW0de6TeEIt+fnwwj27XmVz+ltlluUB9nru9r6QqqXlLuj8a8B+aOUtSZ8LAGtQ4EcvvdWHODGwmf7tiHK6LDlX7BkPMoZz8tajLDD4Za3dOpOBdixN/7Q32dUUkNNLPyuWiTKvLNFDUZFSYLSg9FM8+xYUt6W9SzbPQwzSYOIlG+L1TgWylADLOdPq4cApzoi7PZG1QMwYIRdiSGtKIzF2rTO4mafM8ssd8aK9ucCdXsLKG1G2MtVJ4EvdXBnY3cp5VE7p7dBdXj4QJbajIu15TEw9iwrHHpHgghj4BoYRL+WS31o0xlX775k0hc73w92CLXJZvSipqyt8oWIECkcMbf3h74+7gLPJav/kXfyOH/7U4lNPY1hROg7MVaMLV+BUqqUzjWFi5pQ6fh46Q9U8cUHdUc3dozWGVKkCCFrVOn2ft/ADNN2RLeQ12j6fp3R8ZdDPtwJrGcW49pINjMqGa7IBz+/9Nx5laUcahCBEAKcbC8n3GFn/w/+u7hSejG59T9EKNzVfDiNIfCJ3KHMp0ZDB/TpgxVsP/+GhThoXpTANfDdqElK6N0Y1pO3EkvzJhcnoXaceTaFy89VrBmprhM9MXzuCyZrzIrBW7Vw6tA52lol13xKEQ7oiQVVLePbNjgbovnLlied+TV1iHTOR3O38rJlIA7oK3NZgsL4uMMwGZrO5XSS2jnGqTgVIjp7l74FKmhrgb9uwB7Z75q6iiJRi4Wx3dSG3u2jd12RJ3y53OSqrvWD9xlxgO0N8bn8mQ0v0wybTJQvjpbr045HLqOvIL57FiSfr3wZuSK8bv1q1RUHENJrPujVqyu12GMZ8uwH/vnG6AKrMEerfeTmDUZXdmldQsUW9zN+y6y6k7V143l/fK3W5DjS73tWL3wuGrFA2XyP9qLnuTlWfCUCa0yypSsKaqYGIdygXcTTIVBam76UVkxmWaYSUUmayvlYh6cBmKmP39OCaEIQIia0M0E7m7m3Ur3XoeOWhtOVSd9OI+F7KC463eDA6GJG/HC6wcopqnq0JmlvW06Mm0vs5igBzmA0fQXa6aFbhEKuSH7/+7BrVf/Iz/uRDyo0NINBhiJKkUZscTDzzFescnFqcuxhOZe3RjPk5O0NkG7QyQnSUQCoCZJiVlqG2hUinnbEYlwkBewhlpF5iz0qY7EprjIrQGppQFW7HcFcFW1BhQ4kC+UybH1VkM+9mXNThtcq0fd9iKuNDYZ0AWBh4rDTW1Sq+m60ILA5PlvqkWnPBdbg/SjztdXzh8yXLyerErxorIJb8gaeI81JCZXinIAbcNVzMqK1C+jFx0UsPaS/VjlxQSznaUmUV5rZTvfqGDceWBpGScM1+P5GwaZrljag/ZUE10ZZmrQK2kKq0Y1mB+otr8CWXVSCOFm7gdysnNp1iTb9gCFmT3LVkroAaUHVSQ302UBDvpXLPmpq+fA2w6lqgVS85WDIrLsOe35Osn/LusLpzoLvCIxZ5jRzWx3pOuuQmLYufxErNRoDeSY51MLLKJ1dyMdqmF/BfJC+1yPUBp3IE1bEFRjjRxOfsySrsVWmO4j3nubOcbKgoOmtTaKjxONeXRykeKx65ynVORUVFkUhUjNxyX1eJQxO3THK2pI1ra3cp/yf8pp0LIZpk7L14qE/HsZEGFy1uf0udcihVnh+nlN+EThNeDeHU6ODMq/PeZy3S83CYTcmHxGIItdKx8nQo2yaJE77WB8hStElCECP1NTaSr/2EGSh1wkKJWcR+DZq29rjgVBLaU13SiAspz1DgIN+WpdUtO5yipWgoq7n3vS+uVT+8lCnhbsgjxiOEsPpwaHH+ZVhVQhbnblPPgDIS0dv2ARdbEbKQGt+CoAvG1ISU4i4JdSpCcPXkebSgHlt+4qGaIi3TT0rdUMVnruGOT3TX6kgtoTg0UhC1p9JA6xNHUIPFAeLbPcnQ7hs7tZM6xuE/Sul6XoZdb/eFDojiSmIV1IVI630BRD8ceyayJIjMmKx5VgMnLsSFnckE6+aA7qfp9l86kCZ9yXmu2TYW1zyBrgWbJ250ziV6W4ooyXqvk3fcRVCMHox6kdTlbwERWKblWoKdXyIVV+vIz8Mx1ujEiHKAaNvRHUlNAi/1lSK0UjLE5U3NrodlaUA5F5nqsjCrxJLl2a5naGydJ6U2NnSWzQu5S9wgtqaWUHGj6Oz0VzpjdtVc0HxKdo5juRdhSo1XFU9N4SUfGeix2nOCxuGuCcLBtzhuxUyMrLtenejTUGCgrozMjsyXksoTMBZCoGtLYmTO5pCbfnGEeZwb0e7JDYXQkGG6u7KnMc2Zd/5n+bfjMNdZDx5su3xOmbSbrZODY4yXvpAAFobmSWqP7HzLcBvnsXkemQ5jX52UytJmIauLq9DeCB+tlcI3EUawTTddRd0/9uXL6GkH2kzZ8pF7Wq9UJSbUzMIaImRtBH2JLmh+9FznRslY5+F+SJaykgnhGLCEQJrRDpE5H0xOSOMUuPOG2YwjZ0T2ejRXNn6KV77541vnuBloQrhk6d8FBykzM4qdFXdKR7hbXgB/65oSORBauB+r6s0TF2rnEUIMJLaMDe279PmAIhmkiJF66rNi6VnTJD5InuhzflNswWTG3uRTYriP89/hktZ48M4zyzC6Z6/kRYRi/ModStOath7IauiEm810BlwuUBV/jfGIjZ7gZmU/DB5uLeXJ+CGyVsNzPj+DNm5ZbaX5/M/apN7SY3AnGl69v4Xpr39APG9naB7LbsHyDjSgU/KMGbVzgkBYF5m9S7i/L+r5EOq+s/VCNTay69mGmCpAvzN88lOuX5FzaeWfKRdp9NU90uXPI2/P0IhvvYWR5zliZCgpQeiLwfH1/2F2n+SUCmng8wztrpYBLenzBwPGH88dKwesVmHzzaPfWNVgbAgoa79ni0NHVEzo4vjMfE0aSX9+9t7rGSuvNdqfIbJSs15sqnc83Z2soZH5rs9qyalmHwnHp+7L6CzeffOME5+R2D5bvgPVhdqNAQ/rKtM/fxUxkrs81ke6AbVnsdozubXKncNy6xkNFaeSKqhtfeh+lvDNn80vSZSeLcEj30ljum0CZP9QnmqtfQA3Is/QNHvqRp4feUujK7EQ5vEoJwbdkXDTNABcaqMo3r1KSOKUn4LLOn8Bk8GlDa329TNFksloUu8s3kD9Z52sDeLeBJaqYlydrgx1VMFOJ6JoZuuR7wqlag+/zHTo7j6agBL/+RgzGcUmcMf9+BN1SxumSJ7CPpPH6vJWrYx9Dl2Jp6lkl08/jPzsvponvNxm7K+/h+tij70qIbf+M75A9dMqamRf/2dOWPA7nz8f9m33iasPoiGdMrQd9ow73YY0DWdL8yc62KJoLGPd0ROwaH8FWp86yH9JKK9fRPUNb2oOtqaOrIh9ZiyU/1pMTDzaXWhTulDQBsr0c9FBDtINhLYFisA2qVSwXazC/tr/7UazkNwf92SfzF9k/YdF02z9W29KdYmfLhAy9AlBRZx2KOBLn5zm0oh+xwJaneqwnxPXYSbPSe85ggp9BxlZMafdOkTa0TPnVZ9uGQDvYZRzSZ9UDVZyBNt9GJt1fDSTW+CAXnN6aCTui54FTcz4HOyYKubuSaS7YyncZJkswOwARTQQVheNmBP+UmwomExd92/Gj7wfc7u+NxZ3EgjXpiZptByfxh7kFeHFpEJ6IaW69JDwcyrhMFt3bELcqGgY+gPlPMK6zc2hCPFlUgVp9MX+/6eLDzHW8/R/jXthNANLuSZVk/kyFnYbxPPkQlfC660BtrO9pJRzVZrjSZHv4BIHp/8ZqsCTUNWxnZt/flf1xddig+wv2L1ICIaMjvGPjKI0ulT77hbtf/SLZjxTA/uQ3WStBeY/w8YaD04s5Zj8AxydjnKmlQ2WGlWIlVXsXGzbWJFR7p9ZDyVfT7xcc/VROcFCsRiQqGWZwixwf7jDOdNl5iPnUXO5Am0EOI17YcAeB58HLimqT5iWpYBughZKy/wjD6VqW3tU9wq9D5o7zLNwTTL27IE7XzWtNd6Bxp3E+PIsHvD4jjTxxlgdXX1Jj/S56KYX9JZxUowczCF3KeqjOyA3SHNmYb61ypIp6US028uAxFTKZ9Ttrfv5idy1aFAozTFfxMpxE5UzLdXD93Ddbw+fboBAopeEo6VlwfmLaHWDDYCQ92CGotBt8USnpGBlLjHWI6pxomhpD9MfmqD8Dj4JcbkExsb7F3LWjNWt2fBIjeNeKPjbiCoj8+fWxHfsxaTniUognIXfiFhL0IL92cmuwegRzgd5MlhiMnYsO+0LV1e9vIUef38j+OV8XG3g318Qw8pQAqVoyA+oW8vNDTcksILq6yAKe4TPrjRboY4GaPk+lipssxi6kYVsrOV/S/OmGZqy1tX7sKQ1vMN5+N5iJsBYboNxsbuIL9XYDtLZ+ePIfZEW5ngP06rJshjrQwci2zrmOGxk0pM75IKq/ywh1INFXj3+ldeydSMoZ7U9yRc3GhZJZDov0ryfE96PvrRDGTr8M74PERwIL99mJmPuMuHoIYQd5B2rkF6k1W3JwhRvuYT689cfHylNvw7V9xtLpOoP6N1d4jnA/AScNgsuc8ixxmjo18P+TJegj/dq/KNjeHzaNAj3WifAi1RqU0QsF65rTdCOa4wX11+bS3dP14+A9eqVkUectYrew0sIL8HZUicPW6efD83TPhVfqy0Mr9dmwan1Qi3c+Lkv0XGB2z7NHoEstzEjfAuVjICaxtl32LhZp+qHbHTa+uGtbATKRS2x/FwEgNPF4l0+LYtxfNGG6c3ijNDUL0zEpj+YKW3avtWnEmr8v6SH36Te+tSkW7iXpeppM5LwuoHsZqjfAOdGgcaMjf5RCswJc4x1XBChTr4MS8thknD3ixUdRuBd1sbDuk2luuApq6oM+WpG2BypXkGdPoLXAHJI2Uy6pRbGc01RzKkLmzjBfmPkxUoZxLm/vXMoxnr44T7PiR+/ecB+hJ12xhB7TrrN0zqWezCKET0bR1AtQp8ehOTXYE893fMxxro5dwhugVVZrur7ok9izmSCdRCesSg1pP3Y+LbpE0lOz+EcLz34WfB+puBTkb4J9evMTE/VojuEashWt+c1aBNoRiRsxtEPEKi5SMP0UF2uRX+g6vRD9r/EZbqvj/f6RgWLKcprUm2QktGfG6jGkwbT42nRMBqG5YVvwFQZTbXK5XFKecZn3i1UvkU5rybZvn8dszl0QfXuFR4FksfFkzv6sbH3UhDYvP1eyzctvGtY3c4WpTb5fPV6PNQZtA160P8n8aaggAQvZmSZYkcX3xKrDlnJMAZJh8Tl18IegEfn4/TLDllEj8hkucZktoGRVII5bkCXk1OdJU5z/NPPjZSudOR/4zqwajdkVGhMlj565HWUmNGzHRD2r35io9+A2sLr0Pd/bqVgooAW6slY/On/B1A2lD5Jg+2LwFG4miKQIQufGwAPfB6lYxvqSwRrR4env9X/9snhzGTdDzDV+I1rhzDu/t1qQ3iOCInjd0a099ex0E4Z06OLbbOQaDl8jiD/Pbu321i02uHJ7oOOj4ydGrclRJFIdSOT5rscjWZvN0edbhbM5Gp+kKE73dLGT/rv2WQzvrznPxhoCoPnG2f8/WPrfPTifLDg9fyhlAd8l5G65r0IsNPq9i4g6n+Gh8TAeotqVB1KCoTiKXcYD/aY69D0K13zigeweyK/427/7Mg0FulKg3TGbKige2k6H1gMy7V98kTn+S/OdkRoAnIiF421Bc3R03VXCwqEieiN37jSbqu5B0eyoJv63RbhqaTvpWzKHAugM3D4DMH9UHeC6CwoEgv/i3lvT3X7hya6ywQlqSJIdKCAb4IXrTRUajOP18kw2an3IQ16XNae4eux3ol5mrefa9VZiTiZGLYAWuMsdO22D0goUj66Wc+ti8OR3zitiXd0carcys3NQl1iq5OJkYr9ektjfzyMWLaszBRZROlNaEaUzRRVRuoScan0RIdX6IhKq9UXEU+tzZdOzLmeKqEftTEn1qJ0psB61E+TWUBoO+74YwhdD+GIIXwzh/w9D2J6KXkzhpYi9mMJ5lF5M4TCZF1N4c1NYgqGZdQxfLOGliL1YwnmUXizhMJkXS3hzS5jqf0RerOCLFXyxgi9W8G6t4KsUufT7A585qZGJz5rM2DY5C08nGEVXK5Y/NMmND9gehm1DKoTLDk4mrEXbTm2eP194x+s75szi6so9Tfu5Vs3TCN0JmJnlLyu94NSAyA+L2U9W+p+bljoN6eYqtckAxMfOdxuZek4GM137r+H6JILQTsgolh+7jhTQIvA7/FjcGfODc5PLcskEFJ77ff9aeUx1LL6rtQM90JxOpkuUzeQ7IbWxQp/C6eulJ94kf84S9gBvJeKRjLGTZexb1D1nKTcQbyXnMOAp8h20iwMPSp+eFNXtBNtYwkg9rOneV7NzvFv/QIO63WP0drDusyZ+52kTvZ3xTtIMBr2TAz6sS/jcyI1YC0/PDzLUBZ+kO8kQNum/Rk1UiiM32M0my/XEvhFvbrCb8eZfG7oRc4dvG12XO29Bb8RdY6+Z1nVbxtaW8lyAw0G7HrqwHRTvXdyyd5t9f1FWvhXSLIOfpJuymc/L4EdcTtr+S6yym9r+iLk528AFp/Cm20DE5pwd4YJs3nZHiPictTlckNHbbg4Ro7P2iSThIZ9t5j7hSkAvtT9g4wPXUjMu0PcvWWG1cqjo8JhP61+wTKXUnz9Jf2JmA4r8+78Rqci//v6BFFCBe/xHCl8QYahagyFU5RtmIDe1AixCaIoOkpSjR7w847ksK8YnnuFs4yWaFSDMolxeSTfbEOGv79670lZYU1fP/c377799iArfUhXdScKTfG2ZMjXlV2Gr5SrJjlyF0Vv1bNka3gkneSppVUFxi5lyI3n4SSZTM5Uu9Pre16OEtkK1Bu0pv/bjrBgH/eDCks3b2pw9Ace24csB+2T/MlS/3G0gIFdkL2sVBQzS1znh75OTkO2Y2WS+Q/vzmBHXmKKxBSnr6z44dSupQoN5JtbpejYMvWVLmj9pVzSc9vaXUnKgR3YR+6hqILuNa+2tAKvE+k/x09Ckq9l+fGGsBa/AqP0gdP++XAZizQSk+ok57Ce94fGOaOONrivMc2VsvjApetyjbc2DaIhHM75v7piCIjNsPdB56YTN84OhhmnDch1toX+343y0wwzic5/Jlj9ShNJyo6jQNNnyaJyBGUwMMhIPGu0lDHe/tTrjIXJcAAtZD71dOXG/Orsu9a9xCXVQGhf4jpib4qiPe6x50K3Qs/wJjG5bhMzBHZpt4E9vht3tFh3YU29J0+Jz6QbeO52kGoj6s2qGw36cYiDqz6kXMeiRQx7Nk4fG69g9HA1Lo7FdEGxZHm2ezTdONX4l/cTKuhxMLyBzxD2VZjCTf/t57/B4tvHdulH81s15NuA/RG2s4nZE3f1qcL6iuBBTZv8s2WoeiECIjjtfNj+DL6vCGtvV3Gxl45BuZeOr2EchxY3ixlDD+1DT67uPFm3DQADhenj9qJN4A9ZTMmhPNZ5uo6cb1ypo3ZxnW6/4VLuJlum5LFB3y49vpMZLdY4b9lxYaLXKWZmwCpo1m2xFQro7WbZiHLJnNTVhP7PIZmxnK17rzenAjxY2jjfZxaebFHQzcPOaJjUrMqfJ4+h1sNnR5kPbi/yG0PYiT0JLBiLaN64zVIah7tnnR/PDE9qdEKGRfppdv0mJvS9df1bvXBwKTnb7e66kipIfMVoTOuO8f//ul+3vzox8DK/Jc4N/oYNteHPV52AN975pIpubg9b6gRkEi0/f++XaCHWQQTz8HHa/vwSHqWuruBU++abU3zrmo7uN8Pasxm67ScK6CvEHJtb+JeOgv9/2lOn7pleSa4Z5EMV0H7rUktfG93x+sKfZwz7Q5NGrwyOeyR7pFjAEWerHoYaqvk8yWYKxMx2aQ7tE3zndoXGE682PH8B1dnJyDZIMMiQVKL9XwBbEyMus+JT19aA2kfKog2qrTQ+tJuEVZm93S5I1Uj5ZNnNZVhzMmONKtclWTDC7wAYZHMhbmNXO2iSf6Q7Iij4/E5a9DfxfyaC3A7xWwPEeOgruY8jCZZni+xLkv4bwkM6Lis66VxVQ5bLhD/LbEyb+gGLX5CMY7NzdYiYg7PZxcJs/HQgvy+tcH/faFsYB74FHg6Nr4cE8y/BdnG+PHtvsqS3lw9ruf3abm8gQRujedsVcH3KcJIsL5kSO3UWYkVjqjkcJ/Qx4x2N14MhNf5IokkHQfa04VR7W7ij3KMKVhBCNQNBsdIJmaZ0fT75hRmdMZG4qEy3HL4G8eVXKpc3joM4myRwDfQXZbexxj0Z9fDHHYwM8kTzkaDoyTHR/xUSBGT6ENm6zkAWQWljHhJIN0O2eDIepuPQteXPrEFpruqoVJqkUjK6F1Gwk0A1U8X12M3uHXm7g0ldrWU82aOwSVtK3stf5BoqaD8S6TtT38TdazwhN/XjwXjAeh9q9sZfT1FyETxi7LVVM1ppUG6pdYDba3XxQdtAAJCkOC2leVKwY8P3IBQ+6h34g5hT79KqgP/4o5RvlO91KVZS2n7+FEwIyaq1OcNw7z7kAkbh+HEn3Xffg2ESIVkGVcbnOlvVqBeqzyMk59xYJVd6791ehEyY2fD6UlHNQ4YW1xjr5o03Qs9jtmw4o2S23ybH5bFKhuakp5/vmTNmTCfkzG7xJJUTvtQEsXobCub4FbFkObkWtqHVbcyoIXa0gNycIKPZJPpuMItFE7gZdRXN/kKsTf/6MQdAriUpBSausUmxLDWRbBrvPKCkEU7nHiKr9ayleu4XmUwVdntcgWQteLy6+2vxO/hnFYh2QgGIO1K4v9zyAj7l6g4QHXMBX/xcAAP//bZsKPA=="
+	return "eJzsfW2vGzeS7vf+FUTuh0mAkzZm7sX9YMwN4CSDO1nEk6ztRYBdLPpQ3SWJOd1kD8mWrPn1i+JLN9Viv0hHLdvKQQzM2JKKz1MsFt+qit+SJzi8JpXgG1GsEkI00yW8Jl+9xX/58fuvEkIKULlktWaCvybfJYQQ8ha0ZLkiuShLyDUUZC1FRdyPiAK5A6nShBC1FVJnueBrtnlN1rRUkBAioQSq4DXZUPwOaM34Rr0m//WVUuVX/50QsmZQFuq1ae1bwmkFIUr8Tx9qFCBFU7t/iQDFPx5VZUGn7oOwhbAV5KQ01ar9JNbWSHthm05BTHCCMpnSqLYeEkL6GiEkjjHE2SrC/2dBPsFhL2TR+2wEKv75kWq6ogqMojtY0XY7Stdr/4dOTTMQ4Feu2Xa1Ypzix0SsSeFVQXlB8rNwaaFpmWpWQdqoKMBS8M156D6gTLKnDEcIQdlkLSQpRf6kCOOkYrkUCnLBC5WOoMpFw/VVMfGmWoFElSEYA5HADrgOcEQB4dejSPrja2gEhMIk0GJA5aMUZ9DEPx9Q4V77qHhsb472TzDGOuAaCP/RdkMLbUZfhPD2kmm4pQ5Ng+cq0fzoBlrswJ1h0v9sQDJQA0ocBDcBzBifbDhHxbkmxhXWxxNT16VoOhV5KPAR8kZDMaGcDehKSFhCOVQ9eaPCJkjeSIWDVOxnKspjW0ZRyFgR6mFR9QRFBxZnmgnVMa5A6iU0ZyWj8jjsSSHypkI7n6c1B2sZpXksrpV5A7CpC6phCUUZyainM3XkEC2sI9vKPB1JqMRuER0VUMIlOnKIFtaRQTdTR7moKop9uoCWrLs0avJrTN/cPHW14BZS2AmqmQ6+v1t63orOSEsV+1d/jT/KcgZT/PNhC3ZBTBqei6qWoBQUBFszfQCVkAdUBi1LIiEX0nYODbYC6QT0WO9cC3u36Bar3yHXigh5POr0lqkzwNLd5pfV7++X0zbdgaQbsCpGxXIH3YKFACv5mnGyOmhQ30zBVlqg1AVxWyuhFXYm6tu1SGhZipzikYcWfWWTddAb7S/mszJt/sQL+Lg4sbY7ypIwbBHUGeo3QBfEqJoKwaF5OC1iY2ZLfqyjMzBX9OMCaN9vca2JQCv6kVVNFQzRblzqLdWkogeyAoI+B83DehVa1xCeM0yx4K6zFqBy7F+8UYj+KE3Jm7IM/q7Ilu6AUE3wBEsTwXF1WcBH/9OMFfY4L036nIrVs0/anAhSS7FjBSjjYHYgdwz2yIOSmkrN8qakuEngG9FOdCn5gOPXd9ORWKZIJZQmueA5SA4F2TO9NT8lO1E2uLA30lthzzrMo7tNJla/ZzgsU+Mskpmduxayovo1Of5RtJGgz6LSGdewAdl+FhWCfJeFOfNgM/rbNSthWXTGtG/QxBzJ0V/zpsrgo8b1wIUS3NLiwl87h72sirgy8rNqlVaraflRGcaSjcHgZcXQefYlS9iK/i5k0vtwBrIjGYxfJMP/3ppAtpYAWcmUvho53lQXwAolDFrGgKApC/Fy3bVKMsUy7p5H5pj33dWNmcklrHHysxNjIyVO5427MsDZyC5026O7RzPtFI94oqEpz49XTe15EOOWHhP8gWj6BISSUognnFq3Wtfq9atXhchV6u7B0lxUryrKG1q+krAGCTyHV27z9srewSHyRr36X+5GzvwtPdVTrM+9Vv1uMIn1U8yARhSJf95BLaRuFxaot9jm83QhhOsTCxS3Do5E6r9PqATTIYhaxfezuH4Emm/JjpYNEIZnc9HZH/+4nrZgdbtw7lZIJ9tkQhXZQ1ni/+qjxdSashIK90VcM3XsTtrtE0v/6v7fd6kTo9o1Z9cCnlco0n7VtyjchtCaTHpOY26fMKOtlv94a1OOhalMQbk++XzM1sbkhrKtOqJfmXA5nQyjkPNF+J/TzUbChmq4V4KrhpVFhl7sXhniAjqLnXbdEUPO7RYhq4W4e7JjB3VfOLlidd+dV2AsDc/vtv/WjBf3ym0DOsurIisZh0zU92ukSLSkSmcgpZB3zVJs7pkebtDumV9NJa1Agzz51p2Q3Aql73ptaqMl7padyiqq7tg+zSwxfEB/bzyjR0r3Q5Ipnfnjp+KuWcbvKu6GY8345m65SYG3H/dKT0JdKtAZLm/kihV/BJ644z8JWrs7plugUq+A6nsnaqM7s1ooFsm3uRu69nLqzo3X9uW9sttvqVbVoZHsXhgmMRk2kD+J/fiCm9AfBNeUcWVuKgleDskC74tdugCOB3un3ByHF41prWXLKigy0ehkFtvZmP1F7MllqMHs7sdNQBjeSBZENJooxnN7N2tHOsZq5aAUBubJo/DiPgtRw/lebwaDIc1je55KeqGtcpEh9bjqJ9U/k4Dvhq4DQug2Vg0VLowU8uP3/96APKS/mL+mXGBAuuka0EQLjEnEDALigKejBGvGORQ3Jfdo23yc7KCLh/sVQHrlmyHgE4AxjpAyY20+Uykv2YxMhIG4hIudzTsbs9KFOxKam0GIDqYSBVuz3MSekJpqDZKrc12OzbMokjP1faHD8TSUbzcdBGaPxj4BMt/wMDQJupH8E0DzDZPVoc0WHERp1y63B+na7dSXxNCNXrJcPF4wUAlVhOMZiLm/wflKN8pHptcScjATts0ZFTVIl0bOj0LDukv1c4eUEZxhHmpaqUXV3yU64BxgAqxYWTKXj2SndeRkQ3vIliqiaiRXg8SwNVTDBvTPVOm/GV212ojhJnaGMvR8iDX5mqWQkv03ZCOBapDYKCd/TudoZ8mcm759DtC0lCiqJWdrBsV16Lkpeane72hhQ27yOgYeUOwt7CgG0ElQTan9oNg7vERvJaitKDFj40hlHeEkxrptLYmRvWAg/12UBSbpNFyDVBhkTBRgUlJpZJrh7NIocSpEZ3gIOR9N51vKixIUaRQavOlqWnbfthLPHeUqpzyjvMiELEAu1NF9K/ZJ3C7MEV0dUQJnK/cl91FOORftMDd+Db8WcLa6oNxmfaSDNI1VmVIwJTsNL78JT+DODsLRaeF0uJMYeBRzyGyko5DXjoU1G0BTQgf1a9rqgkWJOigN1SVWxQED8swJ/aJpiX2lmzYJ01Apj4MUjQzrGLRj7du6pJwAhuTSiQwox6hdINyUU7csuZxREqOFB9Vu7Z3EaDzDrlx4LyYkdSmLELbo9+KnWne/TcMvY7YalSZ2GxNk3WLAh7WrB5NkTfRWKNzvU41B2fxPmlSAlmFcrROHRZ/OdpQDw29cVTO73KtMcLKjkolGhRWbcNboa86jOVXdMJOQjZ+Sol+aYjTVyJHacG+f5RIGFvGzRk1MJC69riMvR/sp1XWEIawriVL5Fopm+OxxXkfN6aywVQ46r8aanMkilFms9kI+JSPfPF8mfMzLRrEdJINfvUQsAs2itzvPFHpdiXip0Mjo3fcZUr20fzbQwPK+gPGslmKDdQ+SKcxXNunr98BnbtNepCoB6mFHf6Y0LPN1uI4oTA68XJKX0nDFNpyWUGRmWlDJs8R1UwvIZ4pS28ZUlswKseejolZClED5qDRnwhmmScs1zSH67aF05b40WtflITl36J23kMEVC627FYtYd9s21e03wkWNqEuxuXRFgwfHVa1VpkW2glxUkNkDJCoPz+vJFdX59hnucYbeIrozyjjSoL+5wl2ZY4abmZnr2zm6DFkPbW9mKu8s6kPbHqd5qwUoCMUqRQrLoLQJliqdZIKnQvEjzOW5TB5tRk41ke3B3QiejJfBMdLxFbVKLuQ5k2OsrwywELLrtDQZwrlq1usLgmpnYPQnZrYFdYot6n7UgedEiUbm4H5JVrDGanlBjyBJ4NqXQ6TWRuMdEtnFpk5wVzGE7OnB7I0lzZ+CkW+/mCbnjeA5o/eaVuCvGUI7aLXM+Cw+HeqKfhwu3bAEfF83x1ckQvU7oLY+S5CsnQtz1KB9yWhfQ8qO3wdzBMMUwQNLPGdkm0bSVQnjjG/K1ndWyDYXHGs1tBdL453lYTPONKNlhkNmiQFs50LfDMFm3AXYxeOhqoduiGeo2YvBbRcUmV9rPF/YyB5uREpvVbhcDyjfhCmVsDrMP8Gb1y23svz+ZOxCb2gxOROko/hdCddxCkO9cPna0DUb+NoHst+yfItnnkTCPxtQ2l430aIw8Zu0dJdl/bWEi2gZbJoqU8Tq2D/MNIEpM5g7Y80yhzPUObSGOdXrJOzPaHGJ/c6kPWl32TzB5c4pt89zFenRjQ3PGSNTQgFSTRw8L8sEB4q5ZfJDBBRxeNJkCHctoRS0SM51Jme4dOdEHmsJ365B59tHnFs3gD4EJLT3zYijC9myt2buQo8wrgV59+Yt2hqrcDV73EV6K0Wz2daNvnRqwGpDo912fbfaUUXqgGy18HVZ3YWbC76xirN6uwfPd0J9mG5w0BC/Mu3zu5qLzNVzXaQNG0KKxxWje5PcJYw9WzdCbmy64aX3WcY7sze/JFu2uvCbdKeN1aE9KHOb+khx9SuYwSKGr/JnGv5D/+TpoTcUjnV2oR6SmBJcSca0LQaYKqAy3yYxTcQGxpDp+QZWTf4EOoOPW9qo+Fw/qubnBKsFZ3f5FnJ8YgHHGHI1KaomLg8j0rCiCo5ASlTDNF2VB1JSuQFX59tXdj5SZ5+oX9ffiGB4Lml6zL0fQXeUlXiycYpdDYN3cSuLYx9DF6PUIU5isLUuZxvqBNaex25Xun6USXBnj64qIf6b1q5C9tAua2Zc/CcPW3I47N4zKDXgA1dboukglZriCnpR/KdjHMiK5k/Y27zwfeCejgiXxmfQ8nTQg54caV2pZmgne7A0tXPgeJjpTtbSZNysPHCzsUliyr9gUBhhUyC7y0EH1Z92MIXRo5LBzptWsUo3oN91v/uJr8XX35w7bPCENHWeA4rrVIqdrZOhiVzV1HqHIjyJc/3sS9Gn45wa9UnoxKm0Cg5jAwf5DBJbM6nsO0VK06o+l96c3vCyvV82TbqoeqCyZKD0N93hhb8a6Pi0YgdZlPTWJEraciipfj6DPeOF2C+BfAukYGtXZZisQO8BeNAReDJh2IzgHzAkDx6rNVz3bccPrh6wV3fgcSexYKZqFsnZtoYc+WBuAl6YGoQjBhPxhM0/1SJMkzURtG7Cc6taJPAe9P8HbSs7+yLEE2wwDbQCNXvGmPLNbRUfppdZ7f8U1sJuDyDxTYdaMLenwrtBu598CFJ4Ubvm5QbMzuKhObrUZEVWaLmYjaDRggWhtmA704f+rOy2q2nSg9cqwl2wf5EacNjbd2ws2dGh0qdf2PvVL5J+YADI93fRSE7LnuDzHUdJN7OH2YQefoTSPBljXS11GSsdH7eLqSWrqDzYs2GNqU71ATuVkqHgq6nhfcFTOX6BUjTR7K2Zvf7j6QxjXRf2Q8hTlWIPSg8yDLgw/plzWWNhuyiXJEZoC7SQQlRXs7JTnIFV/clH7tiVhTGyYEBgV5OSbvw4uQeLu4z5cC+ecGX8C+c6PPqSKGHjSuevPiao/Op3qsGDGYSuRNPO1/1p3DTSbtmYK61ypok6VaVYSXSw/4aifmf1z99x1qJFgcH5XtWu1UlU1rUsg+uXvtsaRNMahNGSWgbOz3ioK9ZdYyTe2Cmo+DL4qlpSITIGc1A95zRNjiH6od3qz8CDR7U7wAvHW/Rd11o7Zsc7MYC31Oljqy6PyO1fH7u2H6Oeo0PZ8Ccu9vwWGnQg/2SNv8XqEMwFejNdmjPmuehMXaim/sst9OjiG9m/5ttiC+/mluhbnlIglStMn7mF/lxTUzrziBZXmccziKS40QB9xIYeZ5pUcZPBeAxpEIwUZYlXLrfQUt/CfdtTFt5iXFxplyJs+BZoqbeHTzAbGG/rmif/j6xpqeYAXVyXbVMecNIHo7b2GCeZ2ii0F3Ir0DOv5Lzs7q4LnaoWtSjFxpz9UfsdfJG3xEAGaXZ9Nchvzb/7bBJ/zWgOZU2QHZ4h4sW2IlI0+jhsP7axOKKbxLR9wcbovcF49Iwgxds5vPPuLqwwJsJ8M3J4PG8nxIpljPkfwUm9UUw6CMHt7jIFenks4VYSR74/ZZzAuOCwf+t8UqgsE1+Ebb7G/nZHBsPo4s7r+WP9vX//MgD2Z8KCv5t3F/cYJS/WZAUuJGdcl4W0BRST8xN4Z2D+bQt6C/IYokVm4wyK7nB12/AnZWL87RcqtpH+/lQYKYbkyCWwppuFzOI/BXdOzWAgK8B+VESLNIkhsWSibn/QDCZg/KMN33CaEntXZbCFNYrl96ZaiVsjamvVEC0EWTFzEbeCtm/jiLv012XgtvKDp8cFn9Jjh8od1iyObi2kiwXpFMpUeHo1hhdDIjLBs4Kpp2gW3iDUsZiICRofTCwENo1x59i091gdrWlVd3Ud1bWm8B86kd2t19HKwt9XuQXUkac9eyLnWRNZGI7ofAaFYzMJdITzU6SSdQenjRtcFBErSjiC1YUruiU+vnw5jHI4neW5GD/0QvdCkNGkFQ9pRUusnySvZYUutsCZlpfuTc0tjaOLS//doeiEeXZZiQKWmSG/91ywiQeybsqSYBXP9TodRMN4ZmIPl197tJrGoDr3eDh1/4prDQNjGKj5WA3CvMbg6WExMIP67jbp2sevtN6/X+bdA+5FNQ1Z7AhCnyflWnSBaz7pP7ZdPIrfjBmiB7cDqU6v2ixCWjLaV3RN9RYvG+SO5ZDGfz2h659MdnsOvu00CsxFBJ0JzP0qjQRYTaAK09a9vr008qtQiqHvNFn6dmHs5ishow+BexpNHc/NGDTWucpr6lidjzgIDH4ss8jV2aVRXj+jQBfWdeoevfp8xcQOVBQdRltLjcUrNk1J5ZUU1Y1mJ9e1YxYdtRRFk3eI0Z5BxpXn4e2pjOzNng/PyX0uvEpdH1qlng2rUSCvjguFPhcYHnCK3ekB8fPRtZLPgRjFGiyNoigvWPT8ePwUSBil6aYVXDzyXFT4cbg2o5G1gVtUstJFFYZ7i2gvTK+KHJqTz0d6ZgbtNky27aWQmhYx5C7Xwr1jYaq0ROW6pSzjedn449ZWp1soS6JAmYmO/CC4YoVbB5m5hIh1VOhju15/xL0QLfD5CumqqHzUbThjQXVTpYPabKUsoM+eQhuOIf9H+4yIFYV6jkvNKfdH18O8TJj/SM79c7m9saXUcThjDbAoFde6tx5n7vPt5Fgt/kEhu8zMS6HCtWQSUwJ81JLGnvu92Dv8WlKNBw6+vH9uxsS5Q3gLtM4ahbmYsVOL2XFgQz8+K6vFHH/4HA/sT0QXyXBpB79f9EXl/sLLQ2DigpP/4Ozjq58Zb4LMq75CarqBbE2bUp+ti7O4doMRWyS2RV/73pTscKdAPrgWl7Z0Ex+I7tcSsDyZFsGPjiXGPKcWSbTCi32LB79tZTCFdbNDl0FortkOXDp5p9IkptdNKVa0zEqRP11rBAS5kyjW3s/2p7a5A6JDav0VLpjTRkW+N2EFs+zAWUKXhI01DNsk7Hb32vYVpjf4Xas5+W8d2hacan8W+VOaRNsyfg5vEMzNYHkgWBNmR0uc9dAjImFvDm4TNKIfN19mpj5wtMHhHj1TQd0gCSzatFuQFeTUJcVSUg6SH+7vkz4f+M5kf8+mNOQBTslhsVn/OhdaM/KzWVkPxjBUU7kHvrquSLE2jlnK8qL3iYnTl+ok47GvBifhZoqIqqC9+nAcyoPXCuWnmkHII93fcXM6+JK4GciD5Dwx64Uz94jbrQakWxGZwATWVgJqewT/0ssOUf7JlnYiV9Au2b+Msdsbt8jWzYGWx9E60bHFDjUqEfJEI+kEtU83Hs+kNpvRpxuFsxmNd5IngqNSJXOH2QTmN23VAL9esysbdARA863xAeSvKP+7h/be1f4j3ht8F9E7sq/9WWjwe3siatcMD+0y6SEoVPCAUUUUPzFLjYHiwkfyHQojWj6Q/QN5Z377m7uHlaBqCQoJ4r0gFA9dWXsME9HdJ66imPmX9jtd8yc4kJpKrW5SmpuFrr1hTi0qorZij643XsoBf0/2+HBY7irluUu37tk0FJOON9y9+Ta/VeXh2mg0/CcLwj6urY4fh4o+IeIXQa1IssdibVsoDQ3aviaFXmgujUadcsibqimpGT1INShc3a1c23nrhMlEqwXQAv/h7G4b1JaXeDIuhqYUP6ztuEiGHNPQFDo2U3nRrrMdyf5J60wHGBe2v6awd9cU9tvzhAXDSl5N0v5qkt5dTdI19NSoqyipUVfRUKOuop5GPVc3Pe8iryptf1Vp764q7QK9tZLcsuDFEb44whdH+OII/7COsNsVvbjCF1f44gpfXOEf1hXioRCWHwvDFV884YsnfPGEL57wj+UJY8VuX7zgixd88YIvXvB+vWASExd/bO7ii9DrBDUy/kmDGbuK1v6dPC3pes3yhza4EevD5sB2PhSCqfbINR2kJRr9+fMyd7wuUXQWq4UfsOjHWvnmeh3QxgS3gpIYWFGrFMti8/y0ctnFRv+Lj54grej2KtXrD1MbKdlvReztUBPp6p6x8Ik8PtXH147F/LNzxxGGn3i+hyX6x/RNLqoVwwR411D/WjmdwBfLJr8eutByjiJdfMgIFD4xuzsrdCGcrjjWMAETavI5a9gBvJWK+6E319Cxy/j9nLXcQryVnn2Dl+g3iTFAv+iiL67mFt8cP/vResLAPNB1H2o4168xjnllS+g5MuHYxkKjbmeeLk3ZOu+oTO/Qj2LA00Fu5m3JG1HDthiMEGrTSg34qNxJQuZFNrgRI9vYzTrLPoB0I262sZtxc0/L3ojc6UO2y7JzHvRG7Fp/zZRqujS21iVeg2ESo4l+3RUbO03eu7pn9y1h+ewvzMt3SnLtjltiVG7MZ35eDj9gOen7L7HBPreb+v6A3Jxp4IpdeNNpIKA5Z0a4Is3bzggBz1mTwxWJ3nZyCIjOmieigrtxO0U2iTG2KaBJjOwF84MpfGDfTwgT9N2zxSZb2Wd0OMz9w5t5M8QqFlL//E76GzNVF//v/8FCTv/7Lw+kgBrsS6+C+8J0+M4rVvjLt0xDrhsJJgmhTTqISg5ebHbEc1HVDAPzBW9L4AzylYAVDbhOq9UCtI+PCN+9eWuOBSvYUHPuSL5++/03D0HiWyyjOyp4kteOSd3QchFaHasoHbH2rXfm2dEangknOVW0rqG4RU/Zlhz8KMlYT50ix/++d/kovqxQg3ULreRvXTtrVuI5sTmWtGU1mCIle4LSvBG1ituA+WQof7l1iqaAgFiTg2hki5SI+HWO/3yyEzKsdpm557g+jx6xBUxaXxDzvvY/03V4mOzg42saSYyqOXrLsJiwsknDRTK/stwEww+yAbLf4tt2W0xPosdzsbktaMtVt9OPS4xF8Pie62EQuntMPAO+YRxi9cTG6vRNYH9DlHZO1ybm2TQ2l5gU1GT2s5RDQxyaVmAU+p5JKDLNNtctlciUZrlyTxAh0N+wnQ/YzCC+eVNmLrgjmmlJuaLRkkfjBGaQGCQSNhrMJczMfhsZdanDrI66AgdAKprYjnFyiM+kdFx8qtOlO/gOyE0x6uNu63J8QvQMn9wPqpfOwe2LbZif3gy7afUY9jhYvPv5VLaBbV9mGvjLT2sZiOBcw8DffFK7CEGnyRDMnOZbuJnfM62Z1GhTLgh2rF+T2XzjUudX0Y+saqrB8IJZ6p4KMzijS95aPJaUWdalo/hxmfPZgH8flLHyqwNcbB7PV4P91bEqmNSHz5JWW4rZQDRLbZ82P4MXmjCeK9P+Wmw2rbNHtmnSFqtgXIvzkJqJ4sZQ/WPA0+O7j9b4BihujNe1OonXY70kgvZS54m6BELxnVqC76f6/Wy3Kr7UbxrP9LkMUHvLjwSPCumlowyMbj4bH9NZlRHWvonejtloKZKQT0U/ZnjEkH1WXePnM0Q2Yzpbl43aXg78bGWb9oIt+Tg67JQbghuvx9MHp3LKb4cNW5sP7cDzG0I78DwKLYlhw4MfdLu8yIwxDFXPjrnm807z3clnW3ncHBFq4Zy0WUloYWpfprZuofmnSJ1WcVzfE8+J/NmRK7TmK+O8ffvm192fn3nyMTwmR/tuTr+5CrZYuxOrP7kYrOHaN+3J5paquEQLtjAFcY1iTeFko9RBgmbLlVZLMIxdW4Wl8MnXlfrGkg/uNpylgDLVdqOCVe3PH/CIzhRIJN5+v+kZkz+b1q4YZvR9DELoSomy0a7mM9bFitSBJo/OHB7NnuyR7gBNK6vU41BBVVcnmaxA46M5vjg0mu286tCmheX6xzVgKztZvXpNeh1iuKBVL4EdcD2MFq8OF4TanpQHFVQ7a3roLAlxOMStK4yK1UI8IU28T8NL8XFq2ZpxprbRRfbgOw0zCb4xPaA0rWq/ycImW2RFn08HNYnh7Q7+kxjQ5zv0roFvJWCQ7VGUtHuu0qyleA4p+bchPOTo+Xzr3esaqLR18JxTGHXxJxKPXb4Zq+hLAswEOB5JFedOB7moqmWuj3tlC7uDv25TqEWo+fBaeDDO0n/X9DcaU8W0KbMnd3TkbSn3s9vcRBZU/w9zV5TjOAhD//cUXGDmEiuttN+r/Y48wUyRKESQVMrtR3ZMhraQVlVHafvbGPuV2M9gDFzvdpVWX1tcGVctZRoPWkyjYTcGPurOqUR6Ads5rc4WLX9/VSiLYaUvZ8WjePThSK0bK3cHPQuEYgTFLm1dXCJj6nN+U196MTvrO3oUay3Hn3qFMGkqgy6BJ/S80Kep1MOhAqG2a6NJ6s54rT99f4sY68+fsl5zhY+ClTb7oFFNni6oAHVAOM2qvUzlgrTk7YkQkjc1U+QiFW3h04dkUxtQhOjmTiz8ASAv/B0Fv9VKOa1FvZ8FY/WBJkgr+9QfUE+usdb14Hzn6FsR2A6Xd9r5t4huRTr0HRvzZLqIdLec3QmiDVNSw4EvvwwmvyP0kPXbDqAqsQ1SOy6WEOoG97s5W+5E8tp70p/Gp0WkvCrPH0mlpFE+/SpVT5R+f/7nDIENpZiTifvZdS4oV+suIpffcsKQ3jehGSIOnQuf3cdkDMZdcFrIPWkCUdi9bIXecLH5++8IzmHMN6yt3klSmzzPStq3CcoacvPI+6ECPVWSuXnNKS/CjvpjmzupSqU5jciHl1FTgqmVpnVx2jYZMRog2korRmAM9uMDAJWcZDeMCmgKugFmxHaZUfkhCH8MqohHGLoh2hOM2NERgx2RYmUGwqoPw/wW/Bthlwu2pM6rKZaUT+9Pf9skku8ICxGQrMU9qp5zuddQfIvqNQU3KOCvrwEACiiudA=="
 }
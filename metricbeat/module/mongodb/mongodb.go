@@ -37,6 +37,18 @@ import (
 	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
 )
 
+const (
+	// srvScheme is the scheme of seed list connection strings, such as the
+	// ones of MongoDB Atlas clusters.
+	srvScheme = "mongodb+srv"
+
+	// awsAuthMechanism authenticates with AWS IAM credentials. When no
+	// username and password are configured, the driver takes them from the
+	// environment, the ECS task role or the EC2 instance profile.
+	awsAuthMechanism = "MONGODB-AWS"
+	awsAuthSource    = "$external"
+)
+
 func init() {
 	// Register the ModuleFactory function for the "mongodb" module.
 	if err := mb.Registry.AddModule("mongodb", NewModule); err != nil {
@@ -115,6 +127,12 @@ func ParseURL(module mb.Module, host string) (mb.HostData, error) {
 		return mb.HostData{}, fmt.Errorf("error parsing URL: %w", err)
 	}
 
+	// Seed list connection strings resolve the hosts from the DNS SRV record
+	// of a single hostname, so they can't contain ports or several hosts.
+	if u.Scheme == srvScheme && (strings.Contains(u.Host, ",") || u.Port() != "") {
+		return mb.HostData{}, fmt.Errorf("%s URL must contain a single hostname without port: %s", srvScheme, u.Redacted())
+	}
+
 	parse.SetURLUser(u, c.Username, c.Password)
 
 	return parse.NewHostDataFromURL(u), nil
@@ -123,25 +141,12 @@ func ParseURL(module mb.Module, host string) (mb.HostData, error) {
 func NewClient(config ModuleConfig, uri string, timeout time.Duration, mode readpref.Mode, logger *logp.Logger) (*mongo.Client, error) {
 	clientOptions := options.Client()
 
-	// options.Credentials must be nil for the driver to work properly if no auth is provided. Zero values breaks
-	// the connnection
-	if config.Username != "" && config.Password != "" {
-		clientOptions.Auth = &options.Credential{
-			AuthMechanism: config.Credentials.AuthMechanism,
-			AuthSource:    config.Credentials.AuthSource,
-			Username:      config.Username,
-			Password:      config.Password,
-			PasswordSet:   config.Credentials.PasswordSet,
-		}
-
-		// clientOptions.Auth.AuthMechanismProperties is the only field here that might be nil, be empty or filled.
-		if config.Credentials.AuthMechanismProperties != nil {
-			clientOptions.Auth.AuthMechanismProperties = config.Credentials.AuthMechanismProperties
-		}
-	}
-
 	clientOptions.ApplyURI(uri)
 
+	// The username and password are part of the URI, see ParseURL, so the
+	// remaining credential options are merged into what the URI provides.
+	clientOptions.Auth = credential(config, clientOptions.Auth)
+
 	if mode == 0 {
 		mode = readpref.NearestMode
 	}
@@ -169,3 +174,45 @@ func NewClient(config ModuleConfig, uri string, timeout time.Duration, mode read
 
 	return client, nil
 }
+
+// credential merges the credential options of the module into the credential
+// parsed from the URI, if any. Options in the URI take precedence. It returns
+// nil if no authentication is configured.
+func credential(config ModuleConfig, fromURI *options.Credential) *options.Credential {
+	cred := options.Credential{
+		Username:    config.Username,
+		Password:    config.Password,
+		PasswordSet: config.Credentials.PasswordSet,
+	}
+	if fromURI != nil {
+		cred = *fromURI
+	}
+	if cred.AuthMechanism == "" {
+		cred.AuthMechanism = config.Credentials.AuthMechanism
+	}
+	if cred.AuthSource == "" {
+		cred.AuthSource = config.Credentials.AuthSource
+	}
+	// AuthMechanismProperties is the only field here that might be nil, be empty or filled.
+	// For MONGODB-AWS it holds the AWS_SESSION_TOKEN of temporary credentials.
+	if cred.AuthMechanismProperties == nil {
+		cred.AuthMechanismProperties = config.Credentials.AuthMechanismProperties
+	}
+
+	isAWS := strings.EqualFold(cred.AuthMechanism, awsAuthMechanism)
+
+	// options.Credentials must be nil for the driver to work properly if no auth is provided. Zero values breaks
+	// the connnection
+	if fromURI == nil && !isAWS && (cred.Username == "" || cred.Password == "") {
+		return nil
+	}
+
+	// MONGODB-AWS only supports the $external source, while a URI with a
+	// username defaults to the admin database.
+	if isAWS {
+		cred.AuthMechanism = awsAuthMechanism
+		cred.AuthSource = awsAuthSource
+	}
+
+	return &cred
+}
@@ -22,6 +22,8 @@ package mongodb
 import (
 	"testing"
 
+	"go.mongodb.org/mongo-driver/mongo/options"
+
 	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
 
 	"github.com/stretchr/testify/assert"
@@ -107,6 +109,16 @@ func TestParseMongoURL(t *testing.T) {
 			ExpectedUsername: "anotheruser",
 			ExpectedPassword: "anotherpass",
 		},
+		{
+			Name:     "atlas seed list",
+			URL:      "mongodb+srv://cluster0.abcde.mongodb.net/?authSource=admin",
+			Username: "user",
+			Password: "secret",
+
+			ExpectedAddr:     "cluster0.abcde.mongodb.net",
+			ExpectedUsername: "user",
+			ExpectedPassword: "secret",
+		},
 	}
 
 	for _, test := range tests {
@@ -125,3 +137,101 @@ func TestParseMongoURL(t *testing.T) {
 		assert.Equal(t, test.ExpectedPassword, hostData.Password, test.Name)
 	}
 }
+
+func TestParseMongoURLInvalidSeedList(t *testing.T) {
+	mod := mbtest.NewTestModule(t, map[string]interface{}{})
+
+	for _, url := range []string{
+		"mongodb+srv://cluster0.abcde.mongodb.net:27017",
+		"mongodb+srv://host1.example.com,host2.example.com",
+	} {
+		_, err := ParseURL(mod, url)
+		assert.Error(t, err, url)
+	}
+}
+
+func TestCredential(t *testing.T) {
+	withCredentials := func(mechanism, source string) ModuleConfig {
+		var config ModuleConfig
+		config.Credentials.AuthMechanism = mechanism
+		config.Credentials.AuthSource = source
+		return config
+	}
+
+	tests := []struct {
+		Name     string
+		Config   ModuleConfig
+		FromURI  *options.Credential
+		Expected *options.Credential
+	}{
+		{
+			Name:     "no auth",
+			Config:   ModuleConfig{},
+			Expected: nil,
+		},
+		{
+			Name:     "username without password",
+			Config:   ModuleConfig{Username: "user"},
+			Expected: nil,
+		},
+		{
+			Name:   "mechanism from config merged into URI credential",
+			Config: withCredentials("SCRAM-SHA-256", "users"),
+			FromURI: &options.Credential{
+				Username:    "user",
+				Password:    "secret",
+				PasswordSet: true,
+			},
+			Expected: &options.Credential{
+				AuthMechanism: "SCRAM-SHA-256",
+				AuthSource:    "users",
+				Username:      "user",
+				Password:      "secret",
+				PasswordSet:   true,
+			},
+		},
+		{
+			Name:   "URI takes precedence",
+			Config: withCredentials("SCRAM-SHA-256", "users"),
+			FromURI: &options.Credential{
+				AuthMechanism: "SCRAM-SHA-1",
+				AuthSource:    "admin",
+				Username:      "user",
+			},
+			Expected: &options.Credential{
+				AuthMechanism: "SCRAM-SHA-1",
+				AuthSource:    "admin",
+				Username:      "user",
+			},
+		},
+		{
+			Name:   "AWS IAM without keys",
+			Config: withCredentials("mongodb-aws", ""),
+			Expected: &options.Credential{
+				AuthMechanism: "MONGODB-AWS",
+				AuthSource:    "$external",
+			},
+		},
+		{
+			Name:   "AWS IAM with keys",
+			Config: withCredentials("MONGODB-AWS", ""),
+			FromURI: &options.Credential{
+				AuthSource:  "admin",
+				Username:    "AKIAEXAMPLE",
+				Password:    "secretkey",
+				PasswordSet: true,
+			},
+			Expected: &options.Credential{
+				AuthMechanism: "MONGODB-AWS",
+				AuthSource:    "$external",
+				Username:      "AKIAEXAMPLE",
+				Password:      "secretkey",
+				PasswordSet:   true,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		assert.Equal(t, test.Expected, credential(test.Config, test.FromURI), test.Name)
+	}
+}
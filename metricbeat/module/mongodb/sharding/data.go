@@ -0,0 +1,165 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package sharding
+
+import (
+	"strings"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// shard is an entry of the listShards response.
+type shard struct {
+	ID       string   `bson:"_id"`
+	Host     string   `bson:"host"` // <replicaSet>/<host1>,<host2>,... for replica set shards.
+	State    int64    `bson:"state"`
+	Draining bool     `bson:"draining"`
+	Tags     []string `bson:"tags"`
+}
+
+type balancerStatus struct {
+	Mode            string `bson:"mode"`
+	InBalancerRound bool   `bson:"inBalancerRound"`
+	Rounds          int64  `bson:"numBalancerRounds"`
+}
+
+// shardCount is the result of grouping a config collection by shard.
+type shardCount struct {
+	Shard string `bson:"_id"`
+	Count int64  `bson:"count"`
+	Jumbo int64  `bson:"jumbo"`
+}
+
+// database is an entry of the listDatabases response of a mongos, which
+// includes the size of the database on each shard.
+type database struct {
+	Name   string           `bson:"name"`
+	Shards map[string]int64 `bson:"shards"`
+}
+
+type hostPoolStats struct {
+	InUse     int64 `bson:"inUse"`
+	Available int64 `bson:"available"`
+	Created   int64 `bson:"created"`
+}
+
+// clusterInfo holds the data gathered from the mongos router. Fields that
+// could not be retrieved are nil.
+type clusterInfo struct {
+	shards      []shard
+	balancer    *balancerStatus
+	chunks      []shardCount
+	primaries   []shardCount
+	databases   []database
+	connections map[string]hostPoolStats
+}
+
+// parseShardHost splits the host of a shard into its replica set name and
+// members. Standalone shards have no replica set name.
+func parseShardHost(host string) (string, []string) {
+	var replicaSet string
+	if i := strings.Index(host, "/"); i >= 0 {
+		replicaSet, host = host[:i], host[i+1:]
+	}
+	if host == "" {
+		return replicaSet, nil
+	}
+	return replicaSet, strings.Split(host, ",")
+}
+
+func eventsMapping(info clusterInfo) []mapstr.M {
+	chunks := map[string]shardCount{}
+	for _, c := range info.chunks {
+		chunks[c.Shard] = c
+	}
+	primaries := map[string]int64{}
+	for _, c := range info.primaries {
+		primaries[c.Shard] = c.Count
+	}
+
+	events := make([]mapstr.M, 0, len(info.shards))
+	for _, s := range info.shards {
+		replicaSet, hosts := parseShardHost(s.Host)
+
+		shardFields := mapstr.M{
+			"id":       s.ID,
+			"hosts":    hosts,
+			"state":    s.State,
+			"draining": s.Draining,
+		}
+		if replicaSet != "" {
+			shardFields["replica_set"] = replicaSet
+		}
+		if len(s.Tags) > 0 {
+			shardFields["tags"] = s.Tags
+		}
+
+		event := mapstr.M{"shard": shardFields}
+
+		if info.chunks != nil {
+			event["chunks"] = mapstr.M{
+				"count": chunks[s.ID].Count,
+				"jumbo": mapstr.M{"count": chunks[s.ID].Jumbo},
+			}
+		}
+
+		if info.databases != nil || info.primaries != nil {
+			databases := mapstr.M{}
+			if info.databases != nil {
+				var count, size int64
+				for _, db := range info.databases {
+					if bytes, ok := db.Shards[s.ID]; ok {
+						count++
+						size += bytes
+					}
+				}
+				databases["count"] = count
+				event["size_on_disk"] = mapstr.M{"bytes": size}
+			}
+			if info.primaries != nil {
+				databases["primary"] = mapstr.M{"count": primaries[s.ID]}
+			}
+			event["databases"] = databases
+		}
+
+		if info.connections != nil {
+			var connections hostPoolStats
+			for _, host := range hosts {
+				stats := info.connections[host]
+				connections.InUse += stats.InUse
+				connections.Available += stats.Available
+				connections.Created += stats.Created
+			}
+			event["connections"] = mapstr.M{
+				"in_use":    connections.InUse,
+				"available": connections.Available,
+				"created":   connections.Created,
+			}
+		}
+
+		if info.balancer != nil {
+			event["balancer"] = mapstr.M{
+				"mode":     info.balancer.Mode,
+				"in_round": info.balancer.InBalancerRound,
+				"rounds":   info.balancer.Rounds,
+			}
+		}
+		events = append(events, event)
+	}
+	return events
+}
@@ -0,0 +1,121 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package sharding
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestParseShardHost(t *testing.T) {
+	replicaSet, hosts := parseShardHost("shard01/mongo-1:27018,mongo-2:27018")
+	assert.Equal(t, "shard01", replicaSet)
+	assert.Equal(t, []string{"mongo-1:27018", "mongo-2:27018"}, hosts)
+
+	replicaSet, hosts = parseShardHost("mongo-3:27018")
+	assert.Empty(t, replicaSet)
+	assert.Equal(t, []string{"mongo-3:27018"}, hosts)
+}
+
+func TestEventsMapping(t *testing.T) {
+	info := clusterInfo{
+		shards: []shard{
+			{ID: "shard01", Host: "shard01/mongo-1:27018,mongo-2:27018", State: 1, Tags: []string{"eu"}},
+			{ID: "shard02", Host: "shard02/mongo-3:27018", State: 1, Draining: true},
+		},
+		balancer: &balancerStatus{Mode: "full", Rounds: 12},
+		chunks: []shardCount{
+			{Shard: "shard01", Count: 40, Jumbo: 1},
+			{Shard: "shard02", Count: 22},
+		},
+		primaries: []shardCount{
+			{Shard: "shard01", Count: 3},
+		},
+		databases: []database{
+			{Name: "orders", Shards: map[string]int64{"shard01": 1000, "shard02": 500}},
+			{Name: "users", Shards: map[string]int64{"shard01": 200}},
+		},
+		connections: map[string]hostPoolStats{
+			"mongo-1:27018": {InUse: 2, Available: 5, Created: 10},
+			"mongo-2:27018": {InUse: 1, Available: 3, Created: 4},
+			"mongo-4:27019": {InUse: 9, Available: 9, Created: 9},
+		},
+	}
+
+	events := eventsMapping(info)
+	if !assert.Len(t, events, 2) {
+		return
+	}
+
+	expected := mapstr.M{
+		"shard": mapstr.M{
+			"id":          "shard01",
+			"replica_set": "shard01",
+			"hosts":       []string{"mongo-1:27018", "mongo-2:27018"},
+			"state":       int64(1),
+			"draining":    false,
+			"tags":        []string{"eu"},
+		},
+		"chunks": mapstr.M{
+			"count": int64(40),
+			"jumbo": mapstr.M{"count": int64(1)},
+		},
+		"databases": mapstr.M{
+			"count":   int64(2),
+			"primary": mapstr.M{"count": int64(3)},
+		},
+		"size_on_disk": mapstr.M{"bytes": int64(1200)},
+		"connections": mapstr.M{
+			"in_use":    int64(3),
+			"available": int64(8),
+			"created":   int64(14),
+		},
+		"balancer": mapstr.M{
+			"mode":     "full",
+			"in_round": false,
+			"rounds":   int64(12),
+		},
+	}
+	assert.Equal(t, expected, events[0])
+
+	draining, _ := events[1].GetValue("shard.draining")
+	assert.Equal(t, true, draining)
+	primaries, _ := events[1].GetValue("databases.primary.count")
+	assert.Equal(t, int64(0), primaries)
+}
+
+func TestEventsMappingPartialData(t *testing.T) {
+	info := clusterInfo{
+		shards: []shard{{ID: "shard01", Host: "shard01/mongo-1:27018"}},
+	}
+
+	events := eventsMapping(info)
+	if !assert.Len(t, events, 1) {
+		return
+	}
+
+	for _, key := range []string{"chunks", "databases", "size_on_disk", "connections", "balancer"} {
+		_, err := events[0].GetValue(key)
+		assert.Error(t, err, key)
+	}
+}
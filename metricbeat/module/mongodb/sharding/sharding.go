@@ -0,0 +1,177 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !requirefips
+
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/module/mongodb"
+)
+
+// mongosMsg is the value of the msg field of the hello response when the
+// server is a mongos router.
+const mongosMsg = "isdbgrid"
+
+func init() {
+	mb.Registry.MustAddMetricSet("mongodb", "sharding", New,
+		mb.WithHostParser(mongodb.ParseURL))
+}
+
+// MetricSet type defines all fields of the MetricSet
+// As a minimum it must inherit the mb.BaseMetricSet fields, but can be extended with
+// additional entries. These variables can be used to persist data or configuration between
+// multiple fetch calls.
+type MetricSet struct {
+	*mongodb.Metricset
+}
+
+// New creates a new instance of the MetricSet
+// Part of new is also setting up the configuration by processing additional
+// configuration entries if needed.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	base.Logger().Warn(cfgwarn.Beta("The mongodb sharding metricset is beta."))
+
+	ms, err := mongodb.NewMetricset(base)
+	if err != nil {
+		return nil, err
+	}
+	return &MetricSet{ms}, nil
+}
+
+// Fetch discovers the shards of the cluster through the mongos router and
+// reports one event per shard.
+func (m *MetricSet) Fetch(reporter mb.ReporterV2) error {
+	client, err := mongodb.NewClient(m.Config, m.HostData().URI, m.Module().Config().Timeout, 0, m.Logger())
+	if err != nil {
+		return fmt.Errorf("could not create mongodb client: %w", err)
+	}
+
+	defer func() {
+		if disconnectErr := client.Disconnect(context.Background()); disconnectErr != nil {
+			m.Logger().Warn("client disconnection did not happen gracefully")
+		}
+	}()
+
+	ctx := context.Background()
+	admin := client.Database("admin")
+
+	var hello struct {
+		Msg string `bson:"msg"`
+	}
+	if err = runCommand(ctx, admin, "hello", &hello); err != nil {
+		return err
+	}
+	if hello.Msg != mongosMsg {
+		return errors.New("the sharding metricset requires a connection to a mongos router")
+	}
+
+	var shards struct {
+		Shards []shard `bson:"shards"`
+	}
+	if err = runCommand(ctx, admin, "listShards", &shards); err != nil {
+		return err
+	}
+
+	info := clusterInfo{shards: shards.Shards}
+
+	// The remaining data only enriches the shard events, so failures are
+	// reported without dropping the topology and leave the related fields
+	// out of the events.
+	var balancer balancerStatus
+	if err = runCommand(ctx, admin, "balancerStatus", &balancer); err != nil {
+		reporter.Error(err)
+	} else {
+		info.balancer = &balancer
+	}
+
+	if info.chunks, err = countByShard(ctx, client.Database("config").Collection("chunks"), bson.D{
+		{Key: "_id", Value: "$shard"},
+		{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+		{Key: "jumbo", Value: bson.D{{Key: "$sum", Value: bson.D{
+			{Key: "$cond", Value: bson.A{bson.D{{Key: "$eq", Value: bson.A{"$jumbo", true}}}, 1, 0}},
+		}}}},
+	}); err != nil {
+		reporter.Error(fmt.Errorf("could not count chunks per shard: %w", err))
+	}
+
+	if info.primaries, err = countByShard(ctx, client.Database("config").Collection("databases"), bson.D{
+		{Key: "_id", Value: "$primary"},
+		{Key: "count", Value: bson.D{{Key: "$sum", Value: 1}}},
+	}); err != nil {
+		reporter.Error(fmt.Errorf("could not count primary databases per shard: %w", err))
+	}
+
+	databases := struct {
+		Databases []database `bson:"databases"`
+	}{Databases: []database{}}
+	if err = runCommand(ctx, admin, "listDatabases", &databases); err != nil {
+		reporter.Error(err)
+	} else {
+		info.databases = databases.Databases
+	}
+
+	pool := struct {
+		Hosts map[string]hostPoolStats `bson:"hosts"`
+	}{Hosts: map[string]hostPoolStats{}}
+	if err = runCommand(ctx, admin, "connPoolStats", &pool); err != nil {
+		reporter.Error(err)
+	} else {
+		info.connections = pool.Hosts
+	}
+
+	for _, event := range eventsMapping(info) {
+		if !reporter.Event(mb.Event{MetricSetFields: event}) {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+func runCommand(ctx context.Context, db *mongo.Database, command string, result interface{}) error {
+	res := db.RunCommand(ctx, bson.D{{Key: command, Value: 1}})
+	if err := res.Err(); err != nil {
+		return fmt.Errorf("failed to retrieve '%s': %w", command, err)
+	}
+	if err := res.Decode(result); err != nil {
+		return fmt.Errorf("could not decode '%s' response: %w", command, err)
+	}
+	return nil
+}
+
+func countByShard(ctx context.Context, collection *mongo.Collection, group bson.D) ([]shardCount, error) {
+	cursor, err := collection.Aggregate(ctx, mongo.Pipeline{{{Key: "$group", Value: group}}})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := []shardCount{}
+	if err = cursor.All(ctx, &counts); err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
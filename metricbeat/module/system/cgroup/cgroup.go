@@ -0,0 +1,167 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package cgroup
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet("system", "cgroup", New,
+		mb.WithHostParser(parse.EmptyHostParser),
+	)
+}
+
+type config struct {
+	// Paths are glob patterns matched against the cgroup paths relative to
+	// the cgroup v2 mount point, for example "system.slice/*.service".
+	Paths []string `config:"cgroup.paths" validate:"required"`
+}
+
+// MetricSet for fetching the resource usage of cgroup v2 subtrees, whether
+// or not they belong to a container.
+type MetricSet struct {
+	mb.BaseMetricSet
+	paths   []string
+	root    string
+	numCPU  int
+	lastCPU map[string]cpuSample
+}
+
+type cpuSample struct {
+	usage uint64
+	time  time.Time
+}
+
+// New returns a new cgroup MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	base.Logger().Warn(cfgwarn.Beta("The system cgroup metricset is beta."))
+
+	var config config
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+	for _, pattern := range config.Paths {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid cgroup.paths pattern %q: %w", pattern, err)
+		}
+	}
+
+	sys, ok := base.Module().(resolve.Resolver)
+	if !ok {
+		return nil, fmt.Errorf("unexpected module type: %T", base.Module())
+	}
+	root := findCgroupV2Root(sys.ResolveHostFS("/sys/fs/cgroup"))
+	if root == "" {
+		return nil, fmt.Errorf("no cgroup v2 hierarchy found under %s", sys.ResolveHostFS("/sys/fs/cgroup"))
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		paths:         config.Paths,
+		root:          root,
+		numCPU:        runtime.NumCPU(),
+		lastCPU:       map[string]cpuSample{},
+	}, nil
+}
+
+// findCgroupV2Root returns the mount point of the cgroup v2 hierarchy, which
+// is either mounted at the root of the cgroup filesystem or, on hosts running
+// systemd in hybrid mode, at its unified subdirectory. It returns an empty
+// string if the host only has cgroup v1.
+func findCgroupV2Root(root string) string {
+	for _, path := range []string{root, filepath.Join(root, "unified")} {
+		if _, err := os.Stat(filepath.Join(path, "cgroup.controllers")); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// Fetch reports one event for each cgroup matching the configured paths.
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	cgroups, err := m.matchCgroups()
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	seen := make(map[string]struct{}, len(cgroups))
+	for _, path := range cgroups {
+		seen[path] = struct{}{}
+		fields, err := m.readCgroup(path, filepath.Join(m.root, path), now)
+		if errors.Is(err, os.ErrNotExist) {
+			// The cgroup was removed after it was listed.
+			continue
+		}
+		if err != nil {
+			m.Logger().Debugf("error reading cgroup %s: %s", path, err)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+		fields["path"] = path
+		if !r.Event(mb.Event{MetricSetFields: fields}) {
+			return nil
+		}
+	}
+
+	for path := range m.lastCPU {
+		if _, ok := seen[path]; !ok {
+			delete(m.lastCPU, path)
+		}
+	}
+	return nil
+}
+
+// matchCgroups returns the sorted paths of the cgroups matching the
+// configured patterns, relative to the cgroup v2 mount point.
+func (m *MetricSet) matchCgroups() ([]string, error) {
+	var cgroups []string
+	for _, pattern := range m.paths {
+		matches, err := filepath.Glob(filepath.Join(m.root, pattern))
+		if err != nil {
+			return nil, fmt.Errorf("error matching cgroup.paths pattern %q: %w", pattern, err)
+		}
+		for _, match := range matches {
+			if info, err := os.Stat(match); err != nil || !info.IsDir() {
+				continue
+			}
+			rel, err := filepath.Rel(m.root, match)
+			if err != nil {
+				continue
+			}
+			cgroups = append(cgroups, "/"+filepath.ToSlash(rel))
+		}
+	}
+	slices.Sort(cgroups)
+	return slices.Compact(cgroups), nil
+}
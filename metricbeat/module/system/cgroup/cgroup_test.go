@@ -0,0 +1,131 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package cgroup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	_ "github.com/elastic/beats/v7/metricbeat/module/system"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/paths"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/cgroup/cgv2"
+)
+
+func TestData(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig("system.slice/*.service"))
+	err := mbtest.WriteEventsReporterV2Error(f, t, ".")
+	if err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig("system.slice/*", "user.slice", "system.slice/nginx.service", "missing.slice"))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+
+	assert.Empty(t, errs)
+	require.Len(t, events, 2)
+
+	nginx := events[0].MetricSetFields
+	assert.Equal(t, "/system.slice/nginx.service", nginx["path"])
+	assert.Equal(t, mapstr.M{
+		"usage":     mapstr.M{"ns": uint64(1500000000)},
+		"user":      mapstr.M{"ns": uint64(1000000000)},
+		"system":    mapstr.M{"ns": uint64(500000000)},
+		"throttled": mapstr.M{"periods": uint64(4), "us": uint64(20000)},
+		"pressure": mapstr.M{
+			"some": mapstr.M{
+				"10":    mapstr.M{"pct": 2.50},
+				"60":    mapstr.M{"pct": 1.20},
+				"300":   mapstr.M{"pct": 0.40},
+				"total": uint64(830211),
+			},
+			"full": mapstr.M{
+				"10":    mapstr.M{"pct": 1.10},
+				"60":    mapstr.M{"pct": 0.60},
+				"300":   mapstr.M{"pct": 0.20},
+				"total": uint64(402177),
+			},
+		},
+	}, nginx["cpu"])
+	usage, _ := nginx.GetValue("memory.usage.bytes")
+	assert.Equal(t, uint64(52428800), usage)
+	memoryPressure, _ := nginx.GetValue("memory.pressure.full.total")
+	assert.Equal(t, uint64(6112), memoryPressure)
+	read, _ := nginx.GetValue("io.read.bytes")
+	assert.Equal(t, uint64(1052672), read)
+	ioPressure, _ := nginx.GetValue("io.pressure.some.10.pct")
+	assert.Equal(t, 4.20, ioPressure)
+	tasks, _ := nginx.GetValue("tasks.count")
+	assert.Equal(t, uint64(5), tasks)
+
+	slice := events[1].MetricSetFields
+	assert.Equal(t, "/user.slice", slice["path"])
+	assert.Equal(t, mapstr.M{"count": uint64(42)}, slice["tasks"])
+	assert.NotContains(t, slice, "memory")
+	assert.NotContains(t, slice, "io")
+	assert.NotContains(t, slice["cpu"], "pressure")
+}
+
+func TestNewWithoutCgroupV2(t *testing.T) {
+	config := getConfig("*")
+	config["hostfs"] = t.TempDir()
+	c, err := conf.NewConfigFrom(config)
+	require.NoError(t, err)
+	_, _, err = mb.NewModule(c, mb.Registry, paths.New(), logptest.NewTestingLogger(t, ""))
+	assert.ErrorContains(t, err, "no cgroup v2 hierarchy")
+}
+
+func TestCPUPct(t *testing.T) {
+	ms := &MetricSet{numCPU: 4, lastCPU: map[string]cpuSample{}}
+	start := time.Now()
+
+	fields := ms.cpuFields("/a.slice", statsWithUsage(1e9), start)
+	assert.NotContains(t, fields["usage"], "pct")
+
+	fields = ms.cpuFields("/a.slice", statsWithUsage(3e9), start.Add(10*time.Second))
+	pct, _ := fields.GetValue("usage.pct")
+	assert.InDelta(t, 0.2, pct, 1e-9)
+	norm, _ := fields.GetValue("usage.norm.pct")
+	assert.InDelta(t, 0.05, norm, 1e-9)
+}
+
+func statsWithUsage(ns uint64) cgv2.CPUStats {
+	stats := cgv2.CPUStats{}
+	stats.Usage.NS = ns
+	return stats
+}
+
+func getConfig(paths ...string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":       "system",
+		"metricsets":   []string{"cgroup"},
+		"hostfs":       "./_meta/testdata",
+		"cgroup.paths": paths,
+	}
+}
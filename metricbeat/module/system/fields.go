@@ -32,5 +32,5 @@ func init() {
 // AssetSystem returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/system.
 func AssetSystem() string {
-	return "eJzsfXtvI7eS7//5FMRcHMSza8v2JCeb+I8LTGY29xo3iY2x5+wCi4WG6i5JPO4mOyRbsvLpL1hkv9kvvazJ2jjISWyJ/FWxWCzWixfkCTY3RG2UhvgbQjTTEdyQNw/4izffEBKCCiRLNBP8hvzvbwghxP6RKE11qkgMWrJAnZOIPQH5cP+ZUB6SGGIhNyRVdAHnRC+pJlQCCUQUQaAhJHMpYqKXQEQCkmrGFw7F5BtC1FJIPQ0En7PFDdEyhW8IkRABVXBDFvQbQuYMolDdIKALwmkMNySRIgCl8HeE6E1iPixFmrjfeGgxP/f2axklE/eH8gzlWQzdkP82m+cJNmshw9LvW2YzP49LyMDa4SbkFyEJPNM4Qf7LlHPGF28mjdmDJJ0kgW7MrwIaQTidR4KW/zgXMqb6hiQgA+B6BDz7BboAIua4rJrFQFQCXJPZBpcuJ4HxAPA3EVWawAq4ntRGZIqsaJQCYYpwAypif0KYjcTTeAYymykQEhSKEdNEUr4AVRkNZeeKaEGu/QxSmko9NYAbfAqri9fDBaR5vQReoXdNcdmkhrA5v5X8F1gjt+XKQEUQpAmDkDBOYmr+YT9z9un9b28nlb2TqwAyZut8sV/7QgLBNWVckUgENHKjDd1RZr0bzCrP3sMLh+LCjFOCYkTJITA8JtQI6iICnM9wjJI4jTTD75W0T/ZTVTj5atWIKBPCwsqvM1IiwRe1P3RQY34M9A8Gld0YBarKJ/8Xuc8lQHkBaaFpVJNF0iePpFMmB6B/NLMSGmi2Ao/aqCy3F3aqQB4fdZ/WYxyBEZXQAFqWpEKBZsGT2o9EGHA0FinXOwJzYn6KzH0CySEaQ8UeGdzL4RHoOAvg9DgsOInE+iKRTEimN9khAWoINUfj9LYoWRidIM8R1QDgxxPkAYDEmjJ9grzkxAAjZ4KTkKmnt8PoOKaOGIdP/nF6TFYgVywwtzFjfi8pDyPzH0sqw7W5wDGuQco00b37Uf5xPNbvDbUSc/01rYvBux2FL702WyDX8BK27AC1xPhKRCnXVG6sCnCG7opJndIIv7FessjekZebxLBECdmYDC+WJX4JvQSZHYFCThpfeL+iLKKzCIjg0cYcnp85ex7EyGPqxa+MQbEIIZraq5eXQ01nzwAm4XXUjJxd6sidgURLCMmvjKfPg8DRim9jf9BoDNsCW/7pReTbkgPw4KU8SKU0MhREInjaEpcZaNpyM9+aW8lyo1hAIwvz9qPB5hwLMVssNYFnCFIN1sOQoGRLoKE6J0vgAZDYfFQvKW8MLzhkHo2JGXDCwi8koJyoJZV2hyga1z7jiPyyJY8ycvbOJ+RIzizLp258JT/eN3UYY3xCQZI23FIGkdK0fNRs4+ZB/+QeVafP+5lIUO4+hDpRKD2xHxb8onCgNsYrzipF1iyKyJKugFAS02cWp7Fzwoo5+XJ9dfU38i92ui84dmOwkqO2PC6NjChviKZPRh4L1y7XgtAgwIPAnvar5qAeLAbK1j6ur8FZRO5409eozhvDbkSKWx0XrczyPIKykEA1SFQdlm/l0ME5YXPyXWNY51CXQKgmP1z9zUA7N3JlhSvXJEk6ybj5xUrPDMj1j62L89dyKv213DZfr0Pkr+J/+Iruka83ZQ+Fr/fN/VynXigINYCRGHtXxJKNJ+ptGAEKzu3dfxgt1GaU/F5YRoPsE2NJnSQLxgaOTpaQsQf9aRKy02l/miQNP/JPFP8W5/5pUrL3w/+rInNbC+A0ifxazYBT4+YQK+A8c4QoX8YaXq49tNcshseGv/1ryRU55SyLryMv4QTD+ycdFn/p4OT2J+JLI9/2kHuNBpZ5YuSUiW/qrBgTfjBDlOIP5j/J7V2ejzowET77GR+jGBkgzFPPVUivxy83koexwx5hA8no/kOrGYRvlZshD7Fi4nlMN4QLTWaYGb1ioT3GaRQVTG+M6Xz0PQRJoOEEAx573DxoKZUsDAwckkCYFTIio9LASPg8jaJND761ZBoODhBn2RIhcnC20cMjapkp6PvSFuBxGIRRhU3uXGjShrhYfSpSswMVBFpIN5IL+zInaZxQpdLYcAY/RRT7E+3Qv1+/G7SCL88gg0MD3w+PssEGsqkxaj/bUKxqFSCdTNuCMTGLzJ0gEDxURYq7USu4Ywct7ItBtHu211h8AYChMIfg7eWdqp7gbQhFsk/LpQ7Q4DBWSyLFQoJSTWsBuJYi2exiLhSGiaucaY453gTIkyymM6b3atzlaM3AhklNuKXckhe/7Bd4Hc5zw01qy1OErZgSIsqV8vdXP/3QWOU5i6BSJEW2sguLYRrZKcWf9pGkkhN9pFMDTUC8t5f4rYVR6ilPJFuxCBYQWu8D43aaiRd6CCsWwJ5z3KpmarXe8stlCKtL89frL15EZt4DQDFj1KHAs/7+y4TccqJEDCSgCrB67T8YD8VakbsHe1nC3JksR+NLynOmfyFUEYqHtJVuczBzu7pMcFuAqc0ZINYQkjN4nhB41iA5jRCWeutfFrzSTRPBWmzJrXmBAxudj2M31sa/JLhb9qXUajqfcRGCynKv7J48N3fSYJmznBpzd8a4ZaqYW0DnZC6iEKQ6J2oTR4w/qXO8oVuZbhF4gcj81GzNVTdoPZOspGWQ735Ecwkvqji6FYRBN92rAFSsVDN8JgOs4JrnXl7zbh/VQC/xrZtbqYLj3rDMhCPhvbyRUAPd5dDPxVCZc3onc8CJlB2pZBGUd6lzGdHFQsKC5j4jY8TjDq5lgRZf3TnNdVuvwe/FVir2jSJzkfKwY/vssKWPqMHJ7wKVqTuQu+gxBuWIreUXnyZ3QaEQFKwloQhU3d3pW1rSreI7+d2HvoGzuetIftaiiq1ttDpAsyNfDCCqgx6APp1/PIRWt54h0CRKFfL0bfN6Ggka7qKjPtx/xjEIXYGkC9hRq7y5fjNW05s/Mb6Yzmmghbwh11dX47T9ryX4aEznvUpixlMN/j385u+nhPTvDmuLwnlzfVJorz1w/bgxIeClZMIjCyRkeYRgWKC/Sc5LLYVfYPZB0YtJV4tU7UgTfshP0WFLeBra2Xbk2cmGtEM03Emu188eXElHu9vgueYaKHWv4FHvNJ/NETsI1hGvzfYSWMTZ0aJya55fuMqNt0IB1vPDeBClYf7hQHAbc5ltMnMyoMHSduBqTD1L53OQipwpyC/EjjU00CmNJjUzxH+1MBMMbpa0s0h9wOkyggVv3ORP/mI6SPrsAmx3qWgieY+jFZ38IMRrkFnevqtGp0nv3bbkCGazI6jEz9JGudVEgtPYCvV1yIykY7HuDPQaXLGc23c8xP8qPFZuhbx1lOan/kkSQgI8zO+odw/W8YmFwSFoyiJ1ThLU1SRYQvCUewtKG+1Li0iQl7/oOXb79dKtJkyRgEZBGqFLY0bNspR4UY0sV93dv0FcRMzQGXKZSBFcxhAzPhfNukrzI2R5PvxWGRteoQrFlys6Nq8OnvvPMwDN+6H5uePk7uE/CUM6KVFpXFfSmQgx7jqlZRJ0l/sWzt334Y/mvnaLKHKpcF8fKhUt2o0M0XCkV8uRgTfZZqyusUn7UorWtK7Y2lVeImHOnm/Im/9Csv67bgJWfUpG8HCUwrQy1hRTmgXKhhAhzCKIBkel7WnV+TTWO/PCvoWCmKGi9FJaHY2zcXhfSiEWQf7x7D29nZoOY3xGxZ+jNmoPot9KbT3NHsPBMf9MgspxoTafVLI+3ZmCLV3tl4x65eav4dgtWkz3AsL0IeuMUqLZpS+EVFOitJC28SuSOUTM+IsSdCfZgnGj+vn+aAphlvrwtgveQLQf0UCD0AlR6Viw5ojaqEtby3CJGC5riMs/n+CPlElQFu0co/YzyGI7aCfYcD5rsTbaBLXKiYyr0zllfmOiZ5EHssb8FAEg9BwLadPNZjR4IlRriBPtelrMLRvDFJNFCpRMcPxbKttyiusUJkJE04jFTE+XzKflDkOgZjGokhLCTCKEgfnpS6bPCVXENjNPZeGyshIR0+cpInfKNauASaikMejW3r9e6lujToPJ79vII1jkLAm3gWsMaoaEO6mT8E8I9JRGkQiOK7+oc/wyO4OApspWKaQ8BBltsA7BgKTaXDdEGoV4WViA7j/vPQS/zJ7toNnt0/IupdFCSKaX8cj9WicxEZ4SjyORaLHU1rR0DCXG5jHbWQtBZmyRGx7FYmuBGTcxjaKNHX4cH55iiNFgOZm1LvMCuJYbEoOmeBIXkj3LeQAhOZNUtvr+WuiWEESUxSdDtZNwShywTKoJnWuQdfUuAV2LA0m2BsyU5RaOOdGnSaN1xCFJr06Okq2yPnuZ5A6ysTyEHZeS/UB3pQhTY5u8CAFZLQQaR2g92nNSC3uLwbtKn9jlZV+g10I+fVOHPSae5MYoBZTcb8oFbJUHFbK/Y9HhvJYzcbzaNdDLkSFGDN3VwQ8pZBOpPmpKXb0aR1WeWPFCZPxFEUoIgK1aVWOZkQkNnmCvFQq8tMdw7IEMOxwSmSMZyBjGJyClkIdhix3aldlaRIwvBqzVsTAp4GE/IsYnoRRJMjyWOAoRnpWYmO7WDg2FNUggbtoBHDskQJHqhegGWHt7iUZrumkecVdEcPKRyjXj6HD4+eFjbv/ZGIQx9iQkQuriCtteslw7j6YqjWM6IM8hPyxmoOmw8yrzBdqSHr4wB+ciEjMa5aodAyxMbwaePyyZ/It3ucTMmKzjFuz23ibSgmx5oifY52yPH3qmS8N9Tvf5Y/9004hp2POcvzIN3ROzIN7rKn74zUNpLuU62c3i0knJ2vrdSe0ji4HcS6FFICJy9vvj/dv6i1qkY8usQJr7eVXEzWduyE+Td5Orzs2QUbYUqsqpdkOsk4NGbUmIhQby++M9dm0ASWgYVksqS5pzPlfgm7qhMnvnBWWu5UZt2UEzd5RNkzIUfqvyzJdIBE+N6kAL91vl/nzGOOHKk2pbfYCQbCULboySPCTF+4R4+z4vv2x2Xn5usUs6dre+acRo/eRIqF7mdE88X43ZQlJLqHvHsTlj/UVFsnvx08jXFctokpYe6P3kN785hPpkhwmpufpuO+9i+3mbXx0yI0/jqWuD753Y3MAWjb4gPatd8sTbobOSreZmLIXu4jBifM8yN0+jiAQijikPL8zwNkRq7vGaSl0Gde6S8NBc8SQMUblIY8xEUpBQSZ3N5a1HYAsuJEzpTKzghry7+v5H/0msQG6xtW330u32dbDeVsDM+cf4YhoyiY0gNlvMDnw1/Pi3v5zuKAHAV0wKblaOrKhkdBaBapcC29AfHaqezhmVNxJ+kQA/P3w8t/Frq/TvHsh/+lVY9e0Esr+UmA/3ny9UAgGbs6Ac9EyKvktjo+it3e9In89tYNaCpxVV5fHarrZ4dbC2hyFepg6ENn8TwYC1eUT2eV2UHqcv2njd33OZvHDSyKiHhNMkxNP7VpcusIrFLKLSmWbeaf9mZskZWZ4gZCqJ6Ka4wWqRZCo7awfW7PzkZ25LJ8uvisOep5qLkff5ZHNOdPF0c+XNjzqLO1pPkiPrBX9LyjpgKxOHxGsTjzuXt4Ofvhexq+jCphE+Bt3wl7JJV/kLGZ4W9ht+d+x51Hfe9Z1XLdkVB8/LzCUga5Poee97SVU5fdimTtfS2j+IOGaafFhSuQBypj2lJPnI1Jor2e2ScroAaWZBnck0JlJjIMhdqTIkb/MXgVw0wMbLmeqXVKnUi+WSGiZ/AsVCs7UeQJMH9idMatpi9DvrfSuSPXLmjF6iQKOUnbc0Tqhz6/TOoO2eovfRh2+evZAk4Nyhj5hUda2JJ9F1Z8heZ17xs6A35MfJ9U+T63rzzjH0VjLYi5M+o/pMpYnz/2ftU202rTshHUbybvLD5Lvv/7Wli1ClryoZru0/YgfVy7vM349ZdXMh11SGWT2HLdT4r/vbj/99yYQ389cgH53oS3kAkdlHmMU43Uo91UoLmgHTsnospjy3dSu4HlxolxvqcgY69ALQ8EA456Cxmi5vXKu0wAJwY1h7mu5W+h0eCFOWQ5H5a4chQiYFS+pLO9sND/YARQaZfzt79xZN4+zqojZGLUcdKsTy6iDQsCRZixwEHs1mvkq01cuqZqPF8XCC7Bg1I15kNTRDObJXBDhkD4S8ZMTvydqidOEXFkH+GSHdHT/z3NtrlwtSZLk2TJWcQXNPlqRVbUbEnJ9o30avSKD5UOn2kjiv8kDh+IPvMpgGNVFi3m727HbTMkO7ZCtXw9UDPT8rvUNWvH2lsQPKjS4Plua63DhtqSaUb/Be1ceKJW04K/fFCjP0oVhRGtuwAm2KGRBJs3c2pBAt7ekC38bbuZrIbCDEo4rDzc6E6gKbR+F9iaonW9YZA7YZb4zovpW37pJQ5E40LsnmPmUHUkuWGAOZNgbkgl8YdriRkYEKKhMI/8O6Y42dRmSH9ERGBjCYOGm6/YgXR4EHNY0iR40iVCkRMNTDa6aX1hYybPb7pm7RqyeBMMW/1baRlxn19mNuuJZHx9GQ7qyC2DsqnXUkiZVZlFC9PByTzOiZIePkqB6Pdr9W6cz6yb5VtlrI9s8cxTKc7RhMc+NO3S1h59Mku22IeQ4532JZdn49BNgOrhEwIfuoEkNzI0mLhSLKmM5pBAodea5LK8Kl6ikPXbpN7h3zfZBdEPDwEFxLEUVO7a5FHjDMp5LqnHz45QG126dH/6Dm70pTHlowWSP/aEPmlMliKKcEEykMp5ngNIrq3jvHHezP41xOmc8y66OQLVhe9L8GtljqCfn0WILhHVcCjZwDtAZKgValx6W97l2vE4QUj/lUFwCZ7NqjkDCVWN1DFmwFnCQgmWiUjNqfW26jNzwEmdP6j3fn5aGZ0flykSm82Jz1LljhlHq7/zxIUhoEGpsE0zBkZiHODaKLgiflk2EhOLri/tHWNN+NPaCM0Hs6kAHKj9R3zO3HjN66tHcCaNG9W0Foz9G/30YHt47m082dRGLPpk4q25RSg0YLc/Wuc8yulSeVWC1dwKTRqLgJr6NKogWhIqt37i7EOOGUC/cowEBQfidoFVWbF3QQrKHMaY8K7gdMKRJXxTUAIMgDLp456DCGnr2rN24RvY/4N8Fty7YKuoGsOuJS+uH14swe5T3YojqzZZslddgOycASusGsOuKi+uB16v1gribOsEgPXYZ2j/NkFewYlmGBzFYXzcClWBMJizSi0lwtW4ey1H9bfnXA2D8SlEhlAIqoJdaLmss95NWiA89Cw5M/UqHp4VnyWIuDtjLGGpw0aitYJYU5T8u2pEx5Zkcak8wuNTmjioQwZ9Z30s7lsnAMLbI1X8I41qF5955n6Zsu9IvRYxebB4X1tM6AQjxlw7x10MpDIpnnpcLWSSlpKpssdFZ8OyeT1DHF+rDiVGEh8ztihJ4tloO7Lhj22rvLgdmLbyLiRPnl1snVWWbhvZ3YXkjOj/jhl4fW0YprAvbc0CBjxm36S2NxWh+SKRFZtogn5B80YqFdE2Vzgey15dql/VxdtXfJsyyZ0zTSRfLR9dXV0JpyfcL6M9eT7fLeoj8xZ2Cs4pR6IlOO/uNTYIatuOALUBq9FoynIlVOB7YOzHjN71pVqku6gjaujblrOak5Yum3ZRjWHK9opPAQqCgwo6SqKr99/xlVi6yAiCZqRDsCQ7peSqF1BOHRmeBa5/hXdWYbbjps5AyJZMrf25Ggi7x41EgLPGtLHSw2jkHPS5ri8wn4+ue885woHT+oCMsrZLUskwRtk6HHcZ3jhzdp8vQuJCFMbdo8ljCVt+jbkl1TLEiPxedbKEzLKNeJOtN49W4UY1pvNMdgTOmWsze++D2FReEYKJV6M53IeJ/PvRuNnGXKEDUucPPnt82O4OWfoa4gJTrstiGIG6gfMssjO0Kcla1JBFRp+xyaXQPzQaUpur0Fz32yGbUdM/aRVybx+qrnokgGXxbJoFy78o9/QcUKJLm+In138DIZP5woGT+MI+O7qxOl47urcYS0dXZtktHjs9mRCqv9HsyuytTPME/KPO24+O5160cRBr5ZGA3c+USxOI005SBS1RKZcox7VQSnQsarIugjY4Ai8GztX9IoatnajcFKgXAadOSTDwqGu9B29qqnpyo9+3kNOeY/LxxydDVtx3Gx5r6lqrFdeYrEKP9yUkSPzT2KzHb1MahqYgS9OaUVwcMXems1E0aFGJqzb4xbt+6oxulQdV6rJLS1gynXLvOVKTJPeZCllhDK8/fFMvPAFr5Rc3c1soGJJqkVqnE+n+546DiJzzlSFuFcfDGyF4tw6KKW8O17UfM6x0yZNMufz30LWa0+HM/mQwjofmhpF8kxVPZFYvcuTme26ubteLnqDcxutxqPbatRLqDds2wNC+q+LD27ypcZ25ueSLpbWmQ/ldYW+4lEoWg2PGW5rApOgAZL/GjtJO/wYTPVf5R3lg2TcVaqLR/OEr5tF6VXQ/VAhup4gzSGeGJTqrp60R+/oX3liZ/ZpjV39Cwr3h0aGygIjunz6RC9hDyltlYbulfKbSXLKVJd5INYM676lJmhNuvkNGcdQXLs7f7WFdU3k73Rs1UKX6Vq6KFuuOd6lB8jaMIbL19UuwTYgp6z2pq+JetGy6fiZ1wXdRxarI8sLBl5Yo2NxtRSRH5FWoa5ZIvly+A0M48AenyVkzeVePbA7MWLjWv2lCLdlOhSXYUkWrIkgTD3NVvNH8EK2px3Q2NokVh3ePXG5232y2Z5diMhe51+gMiV54/p816n90tS2+xCxHudXWC94IjZp09sQBhlLAQzqJHZwUg6nt3YDoV7RqNjT/dtbrU+NcNvCdkDnlmvlooViE+H2nfAzJ0wM5Bax9un4ZQz61SNxkbjHOyE0GBWhSkdt8RdmXXydmaWK+cErs607lqUg1iXhmtfhbml1l+NwaXWX5vJpdandMuoq1zc1q0jnjX2/lv/MzrttL8anB5CXg3OV4PzKzA4fTCeTtW/6KILB3MzPp2+n7HOgl53Y+uo4zlz8vahmNf402XztQ68lS34dJquxvq67dPXaMae6iA5SVVR0RHm3vD44Z7M0vkcpBr7wm6N0FNVDWWdUKfYoyNax9xFeyKbvgY94ZhV51NDYfRxaevLY86t01Qa9YVsr7Da5qlXqlXjAaoqwZ1h+wrJYfbcvCPi9q6jn0cNwgAh3SOQAYjwNTaYUi78L1RWsR1nA73ngm9ikarC04IBO0wyR7y2CuVCQoCvzV6gCjr79dPndqmJmNKVl17iZK7ImVrGEL/1dXcezrw5i459Gv3CIriY0eCpWP2COb9++pyTuwVVyOsj03NvTk2ceN9rtGQgqQyWLKDR1LJqelrnRTn3Jfc4ZrCdSZm/P1ZSnvZAaK/B3Au71Po0uVX4nAbzrXXIKj+34xvjX5smzRBX1EVl57W78Oo7citOvYDabOeUX6F6ebSFdMQ0SSA8LYof2J+l9wEuLETi/s8gVe2qeL86J6ELmGKbiaOXuxsdQfPmr9Uru5ZssQCJzt+kK9CD0EfKwz+FnH4FdCPQHsLJm9/Mp97Y/1RkaUSIF611nYeEBjrFMoIlVUTXHx4ofrBdu87eYsNmJSErN58dKFFq2uqLOkALCTMh/hP7SAi3q4pCCpo9WLsFHSI9dEcbPyEiLd1cdyWl6ymZQaQc41h0+YNmh0jKlX3SkyzTBSBf3p4TLtojW/s1XKVSUzPzyXDt99rjBWJOaM5IL7/GVUKsaXIytD7k4f0tVy/lsGKBprMTOvF/K/moA8q50LYLXBBRFkM4mtLTaZTR8yKPfZPnp8l3lYe2yz9/8U4bvfwhA3hEXiv1T4mM10r9PjK2q9T/n9qE41VHvOqIVx3Rh2v7bh6z6Knx3B4ZVyX5cySCJ3J791oceajiyC1qI23Th1Ox8W0yQuM5Q3M7m4OUNmhsbr3oWDHnyQyFKjTXldbJSUfOzyg2MXGcpiYFA24v74hIwHYUxBanhtvusIyiHQjHHpRQvJXm2gviy7siYkHjacvSBtxRETgA/3hnlEH+BqeEJKKBmR91zat2OI528JGw34yDBOSFlVOz3jbFaHjKwdYoGgPtNXthR6J6VcmhkZyO92Oo8+J415TXNqGv94nX+8QQXFv7HI7nicw7xrxu4dctvCsdf41NWdgA9gldlcYxrfRA0kxHYCi0EfaH5ge8G7TDdnVD5I8J1t7VBEVkyrl9zsamQglVsVIlmEPZTFrume7bml0cbuVoj9ldv5cVsBEuUw28pdczLGF7QlLEMTOGjcHCwkZfmd2BoD93DAoVASSHYEk28Dg0WiQJ+F9l3wmMHXcUlj9FPGP7XyE77CgkIdD9s8QM2oaC3OpvFVmB3JCUR+wJIhfsZdo+G0yTBKgksxRb56Fxjo9o0IgoplPnImGaxHTj0nj8pK3pE3gqGHcnLxv4wlx4WtlN7ni0IXORcjRERBSCdO/u/z+bbOSCGaod/kGgHxZ2QuXTAXaZHXZf0M+JkNlruyA1Zfhqr30Xt4WulD9xsa4na+2BsJyW0rshS3u3N9frNAr5ty4HQksGK2PXSsJUhsgPVy8l0IaNUjvwH/0farXKqx1HRco1JtiUWuq6aa3l7Xpt1gbpe07ef452MtuPrdR9Jdrkx6hD2O72RFdzQ4K3nD/jhxuUzDbk9vJu0rDRJK04NEfbXbXvj7eizD+9cuB3Y/aJNy1aPVunVduZTHXjRaMd5sVEaKY3Tk8MQOBrkrADgMcl4FrYcasA/BzY8GBKsX31/lB8cD2HzODEDn5OmMXy6f3tR0KlpBv7SFyY8pByTfzWAVNPWQXRnjRfSfW5tHU7Scf8h7TwcYbSImFPWqZQsXVhwjTC/bMEhw37WWJbGOx/ftcaoXd+3F/N2EGHU7vSVHgrqSYxTfBCRNeIwh6RyosS9e1+JaeUWYqDl4VmKaIQKxHI9dW77y9mGw0ZhC54Zn8ewFZy+NwN20G02fQSfWZm3h60uX4CWdNd/rMpP3FmoOmwM6vsI3APH7vZ1BEOLaz8LR1TTULLXXBoOEVp22U2MwqpHExdc+48XXYWjpgyne1OpUpnF+OInCrGG45ZO2fYBNOYEItFNI2TbMIIgzrWfF5SvoAJua1AwfoAe/ZQHmaW4bm9SZj/aUXSpPqkRhk1PEMwDUS4E58ebv/Ph//760dixrF5DiWE3yoSm0uJuxy0XEiYtsVau69Zeb3MuM3GxM1ZV8BDIaeJBAV1ZT9q9uz13hEo8idbt7u6OG2TKSCqlAgY1RCSNdPLVqntv7sESeraYXifdRhxgbj/7GK8PW/ol3qOCbnpaToyfP5Ko5BGAWV9coy8TLDgZqdZS50YbCxn8MKUzgTQayGfWnEMSl1xgxQJqm1NFAelqhy71NamU7UUlpVQJTR4gu3L+kbjcvP1IROp3hWad9q2CrXyvDstlGcRynM2bSthgO1y7X/8cO9GUYWBZ4+23YIqIZPQfimlEWv0S0qoXuYbZ9L2/ZgtbJLbDdEybUlILRq/xayRDT4UgfncLpNHIqDRhNVVhZ2+8Wt4pnESwQ25/und5GrybnJNhCTvrq6ub64+/vzjzfuf//3jzY9//+6Hm5vrcWb9rwYHub0nNAwlKOVKHAPKyczYL+T2fvW9mez2fvVD/qEhtCVC+s9tj4jn9L2rv8o8CL6ZqgeThFhoOAGGf0Ige+a4o+4oLHcEDOf5UqgxBlwO7N9+uHh3fX1xff1vF9/9MOHrifvLJBANr28P5vvHT0RCIGToPfRltiYTcquNiS5mmjIOIVkxSiSsQKrm8Xx7TyIhnlpTOmtsAB2F0yRK1VTwMeZ0zo+tyTdWMMznELi8zuTCug9DgbeAM3j89ePbzDJ2vDCLZhuMCA4kFs0cv4jOIJqQX4TMkJ3jAGa0f73Ga/ebuRCTGZWThYgoX0yEXEzeGP6+Kf+i4St/zAvfhSQhaJAxc851OzwJRAzKpW9zAvEMwhBCEohkkztFqW48i45fWGqd3FxeJuksYoFK53P2jDgGy/IUpGw0BNjB8fTvZjj3oVlGZkBTBcWaoAQ6cSOueVkP4iwvI2nkMvedce3fHHXEZcMEIo4p3xaExwmzHYo4jNiojdezbFj/4mgjlaE7ccCzH0M/J+AZghSrqXfhB75+OFok/N8aP3GrS61n6nkaRdMRolC1gdtzkx7w78Tz911Tk8SciAR4bj+zIiHJOQh2sqBpS+JBr3OiKcjvUY45txZ1fRF6fRKd13LGNSzA1z1lQIWCAYY8bEdXMjqZ0tAZ2d0ZSz4FGj9+t5kO/BbmlutibmDbr03Pq4TtDOm7ew9g2G/VZsnlq2Tm8DknM6psHm3hmqGRuT4Y88y2YcHuJNahlmBRAvsTJuSDkBJUgo80a5G9magAk3oujca8VBt1yUFfsmT1/aUOkmkMscvhoCvKIqNIzdSYwTFpr1ls7jQyztvTLlDdq0sGOoCETJa0u81P+0oPRIuI7V53i+SmhdCIfLa07fztpKBNh+ybgEyf9PN9mF45AD4DrUvP1OGBMhYBU0tvSsmeARYxwNK0o7gZRELBdE1b38Y4CNoaQqMjpgWSqTcYVsWtWXwasHMgQ1CrDZ+q9kz5o4HOcAzFLCFYnQJmg2MI5jnjuCZ1V9DRQedAxqCu+39eDPW7IagjqvSUBr4IzFFBZziGYDa65ignSL/KY3zhQ5xf0sK9mq+fP/5FzFdDyAuar2l4iuZr9+qSgebrsY2/NtQd/5LvjqRWljjaS/DFDvGl2sza9Wbgi0xU7KecL2HHUFtqHSST2J/N4AkNZNsn+2rtz4wnqZ5mH4pZFDF/+sCAZNa7h4xWxitDNVPFUgVS9fJ+i0SxX8ViAeEF4/Y9bQVKMcHrDuQuHre407ZO8S16Rjgw3lkVNCoNd5j3PS+HRiKxYEZz1afoaE+xI80ff06Vy+LE0YdwwBOE3RGF+XqeI1SShpYF8OWK7LIGufANTU2phie8SGZCRNDwD/QiMV/D0ovAaiaaRYY6ObJLqph/RbIHbmtJfx0YArFvqSithlXQoWeWIuWfho3Daut88iUQKYQm98N0gl2j6ciQa+8R+r4SFnQx6eJl2Bqg4l/+fwAAAP//KyKQxA=="
+	return "eJztfetz47aS7/f9K1hz61Tss7b8SDKbzIetmsxs9rhuJvYdT85u1daWBiIhCWuK4OHDsvPX3+4GSIIk+JIoWZ6VajcnsWXg141Go9Hox7nzwJ/fOfFznPDVPzlOIhKfv3Pe3NMP3sBPPB67kQgTIYN3zr/CDxxH/dKJE5aksbPiSSTc+MzxxQN3Ptz94bDAg5+uZPTspDFb8DMnWbLEYRF3XOn73E2458wjuYKfc0eGPGKJCBYaxQTmiJcySqauDOZi8c5JopTDDyPucxYDugWD/5oL7nvxOwJ07gRsBb8II+nyOKafAS3PIX45kmmof2KhBT936s8ySib6F+YM5ixIN89/ms0DfFzLyDN+3jAbfr4A3RqsGm7i/Cojhz+xVUj8j9IgAJa8mdRmd8N0ErpJbf7YZT73pnNfMvOXcxmtWAKs4ZHLg2QAPPUHsHyOnNOyJmLFnTiEHzqzZ1q6nAQRuJx+4rM4cfgjfGdSGVHEziPzU+7AvwQIyhd/ghjokYJ0NeNRNpMrIx6TGInEiViw4HFpNJKdSyeRzpWdQcBTkB8EXOOTV168Di4QzeslD0r0rhktWwRyXJ9fSf4LrJHeciZQ6bppKIDNInBWDP+hvnPy+f2n00lp7+QqoIDbY+t8VX/2FZYMcIggdnwJROrR+u4o15zJPj9+cgUw4wkzfv7IoxhxlfiD33nn/Dz5cXLZj6GfeSxT4KfSWSR9IYhhnILagsX2fWCiWin4Y+S3Qu08XjtxOksiXhLSKqkmuSFLlqVfNOuQGuY7+FucHFdZAThDvgCoR44bovg5AlvJFHZrKEV1P5qbheidBLEVki+DRRueLxJYU6gHEIM4XRX7OoMIohewQMYcvuDFXVjKe6cA07B/iN+Ne6gGuQOsoc1g/R+FhDNuzhN3eebAtmdwNuFBBksgAzruOhkLyF4JRSjkMFbr+vBoU1HpQgkygsOD0Hq8v8Roo2F3mB54FHB/IKpkGckETClvAssnpLcZut/zU1GPgvOvl8JdmhjxNMrnA73nMmAj/pFIYiLwHyls0j5Y0210ALFxMCxi6Uq4UTdPM21sxVg9K2og71p1OeEOUzrGIrRRo0l1O1oUuokwlqsqsnZ0NYT32TYkTuYrDZYzHnsJzeAkLH6IyZQmOoCzUtkmkT6+LLM0QTfhX11adFSZCJuSyqdoVVY9yM2pgcO8sCSvLh0tFq3g3x4k+Lf9wH9/eZDoAVYv+Alu/lbwFvVhRaXUiNqeCKymGhr33jz1bRBG2HsAJZDBufD8HlsPztlV6ics4HDC+s/HnXgo4I87cZc7sXLxVVbv7Dnhva2JjHG2PyoB+6Sur24aRcBgH/1LVZPNbj9obEuxWO4Wmro8ZnaP44uVSMr3tYnzu4TDnCfKr5AG9B3Tk2ABvmJPO8L9Nxbl3rqx0IKFHOyazTZTHed9XuH1ZsXCUASLBmtSw5wLf8eCaoEJd3SXufDfOLt2k3isyT7XSOM1C3e6se5hgs23FTn8YtpdW95zUNvE3bcIkFL+5HLuoWsLrhM4c0mI+8CFPTUy2u/ikt+bwLOZTBNc9YVUeh3xwtT9cUq52jHOJfATYan9j54v0PzSpdtR7MyZ8Ds2fIF0+iBqttjgy67yXgLkkipycOhCLm9vP6mfRK3Qdn1r1Iw8Xhxtn6O5ugn4o7l6vDhWPsedeNyJ39xOzOYXchJx5u3ItP0Fv+DgBBVLnF6REekMbJ0H+KNHAWaH3ZjIIAq5rSefgOiYB7SuyiYOKIb+iNYR3MR2yjWcIYFrX+0KMxTk9nyjcUZinCdiF669fLcil89SYd+GULdnoR5rJCbu2rAW8mhUWz/Ho3wT8Mej/GhUVz7HnXjcid/cTsznx/0xcTEAbFvvIe00EZQdiFW3cB6pIaN60GV117eE4emIwnMcxwgrxIARHU2IK4tRSyJY+Jzmo9glB3e7oL8zIomzTzl4OPu0RemJahxeP779a21JEfoHRKWCXAtUpW/+H+cuj+ZsWFGUgx1HktXRK+ljrgowrIUAl5bbCptCxvaOuiuCOQs2i0Pm8oYlKVGQCPdhMwO8hg7BMRWauR0wLeaHyFwdNTeAihEZ3MnhAegCuAQdHofB1vHl+jyMhIR78XPxZNKHmr1xelOUaOAdHs8JVQ/g+xPkHoDkmonkAHkJNj0Ac05ARDwRP5z2o2OfOmIYvugfh8dkOEYehYuZVfiku4R/+PgfYOR6azR0RZDwKErDpHM/AnV7Y/1oqGM5T17TuiDezSh86bXZAHnCX8KW7aGWRPAofaCeRc9KBWhD91FESapTXdZL4atY9yXgBZbEMqpNRkliBr8kfD/KjkBZ82g6zvtHJnw2g5Fl4D/j4flHIJ56MXKfevGVMQjzNvypunpZOdQj6cp+p6ORs0udc4uQmIHQ+U0E6VMvcKzmrB4HGoy7KbDln1ZEti3ZAw9dynV4mePi88KGuHCgacPNfGNuhcvnWGDWIsG8+YjYtGNhJRZLjDXjbppw5WEISbLxGS8+c5YcM6tW+NUE1GFteMwR0x6NCQ44Ed5Xx2WBzrnCHRIDYeXvaCK/bsijjJzR+UQcyZml+NSOz0jfMUYc7hOCv6+lmCIizJ2Ot3PzUK7xiKrTlsmMT2X6PkQ6UcbJRH1ZBudFMnRtvOKsip218H04cR85errYk1ilK51QDVN9vbq8/IvzVzXdVxq7NpiRdG2Oy3wUZVDh7AHlsUjTDvCZ1yUHoj7tH+uDWrAglI19XK/BWQRSX/c1xme1YZ9lSludFs1keV4NYQGsT8i5DF8jvpllAM4cMXe+rw2rk+Phzxn60v+C0LDCgs5RzzVJlq0J3PyqpGfGnaufGhfn23IqfVtum9frEPlW/A+v6B55vClbKDzeN8e5Tr3QI1QPRlIdndhRZNOJeoNxAig4N7f/wcxci4pR8nthGfWyT/ZQ0WHHL1pZ0YRDJWToQX+YhGx12h8mSf2P/APFv8G5f5iUjH74vyoyN7UADpPI12oGHBo3+1gBZ5kjxEhxLXw2dLm20F6xGL7U/O2vJVbkkKMsXkdcwgE+7x/0s/hLP05ufiK+NPJND7nja6DJE5RTIY0/H/78gEMY7w/4n3Ca5PGoPYvaZp/hbxQDHwjzMrKxx66GLzeRR2+HHcLGI8HGf1rNIHwX6xnyJ1YqIrtiz3CCJ+jMBuF4FDq/CoOjc6bXxtQ++g6CKMNv84hpOylkKRkWBj0cgsjgCqHIxKmLEo5JE88d+FQq3a4B0iwbItxhGmc/8LMi2bMEG99s6GlSPXGJeux+2Q6MYT/LSI+kn32FlrTAYbGqFam+5cTiT7JDf7y67rWCL8+gLK9zFB5lg/VkU23UbraRWFWqObcybQPGrISPdwJKqChC3FGt0I7ttbAvBlHt2U5j8QUAehIPwZuL20oiaRNCGY5puVQBIg60WuDkWGACat1a4JhxGprZYoPNhcIw0eWd6mMONwHyIIvpTCSjGnc5WhwYmVSHa8SWvPhlv8CrcdbrX4dS+rlS/uHy57e1VS5qb21lFxbD1KJTjOpeIwSp5ETv6dQgE5Du7Qa/MR4EK8DBtfkRqFuAZifvgwjUNPZMcZVGPnKMW9lMLfdO+HoBU17gb6++WhHhvDuAgmNUofCn5IevE+cmUOniLqw6Za/9hwg8uY6d23t1WaLYmSxG42sa5Ez/ShW66ZBW0o0Hs5ErT80UEirXtYb1OOFPE5gebosByCDCik/ty0JXuilVhh+XFzQw6nxVdb66NvYlod0yllKr6HwRSA89gio8Ru3JM51RnLGcobk7E0FegIAAncFa+R6P4F/i5xVcyR/g3/CG3loaQRIyOzUbc1UPWo0kM7QM8d2OaB7xF1Uc7QoC0U1HFYCSlYrDZzIgCq5Z7uXtubu7NdANvrVzCysz7vUCQaUgh8F7eSOhArrNoZ+LYYzn9FbmgBYpNZJhEZi7VLuM2ALszwXLfUZoxNMOrkSBFn+6dZjr9ongxr7BJOw0aCgKSSK9xZbeowan6rbwB/pAbqMHDcoBW6tnHZzfeUxCULAWrkxuXHV3dtW7saj4Vn53oa/hrO86/KiVIhVb2WhVgLgjXwwgqYMOgE31GvaDUOnWEwIa+tifBXh6Wr+eguo02ThYR6E7HseA05lHGOW0nVZ5c/VmqKbHX4F5OJ0zdAphgZXLtv1RZ9pvBnwypvPSHCsRpAm37+E3Px4S0h811gaF8+bqoNBeWeDacVNAwEvJhEUWwAbIXwj6PfTXyXmppbALzBgUvZh0NUjVljTRl+wU7TaFp6adVS3orWxINUTNnaSrTI/gStrb3YbONV2Eu30F93qn+QOP2F6w9nhtVpfA4p2dLCq95vmFy2yi6UmuPD8icP3UM8uQ523MtDlJ3Qaom2Zt6lk6n4Nx7JzEPL8QZy0cXHy+n1TMEPvVAifoXSxpa5H6QNNlBMN+rN7kD/5i2kv61AJsdqmoI3lPoxVV/wEFXoNwebuuGq0mvXXbFkh3ZzZrggx+GhvlJgHFqDW26nvgCZR0Stad8WTNdbKc3nfAiaT0FKJXyJpHiZ/qNwFxyPHRSx8Pt/fK8UmJwR4HTe7DxTMkXe2A+LoPubfA2GhfG0TCZPoLXfQ0u+16CdgNign2kJv65NKYsbiox4i8KL8sl93dn/iqeDEjZ8gFRv1cwDTo6a7nVeIHeGvMR39lYqMrVKH4ckUn5uXBc/95BqB+P8QPwL+9/0/sOiKoX2a6qirpTIREoCulZRJ0m/sWzvTf83/U97VeRJlLRdahpqdUNGg3GrRLw+Gnu1Zin5ts/a2utkm7QorWrKrYmlUebPC5eAID8L+IrP+umoBlnxIKnqpynJtWaE2JOEF/HD0hAkr9gog4yi1SSs6nod6ZF/YtFMT0FaWX0upknA3D+1IKsXjkH87ew9upaT/GZ1T8OWijdiD6ZJT1xD1Gg1P8GYZoZLhIm09KUZ/6TKHGZOqPUL0G+Ftv6BYtpnsBYfqQVUYxaNbhC9gaDBQV2BHUxJ3I7CNmwYsSdBuJhcAnaBPHtjR5fJba8A4oLG1H+5EMNACjhMg4FpQ5Akr/QuUyXBCGiwpi8/MZjneBDgP65pxe7Wc8e9tRvbXoOV80WBt9ilJ7POPqFJtzWb87tMqwnTX4KR6AyHMMu5TCzWYMrFiWwHEYJrqmheoU5ngpBYsUKPFJBn+XRk0xxVUKMYRnSu3IpkvRXgN6TAKLRmlKFCiSSHVFw/j0JaYPMYyNDeZikUaFy0pJxIo9TQm5Vq5ZBkzIIqAqaaz9a6W+8dWpN/ldG3kAi7QloTdwhUH1J+FW6iL+P9xNptRibr/ySzrHLrNZZ0EkLQ08HvnPlIeg+uDhdUOmvkeXhQVPus97C8Evs2dbaNb71NylzF9gStRyNXC/VkkEuaineOyJRIWlsqbGMRRmrRkTCbpZLHLDo1hs4ApG3KzgJ89q+GF8eAD5IIPlYNba5AUGTj5jKAKjk7iQ7FnOA4wli1jU6PtroDvirs/E6mCo1hLOHA0sk2qHzbHuUkW9R5xciz1JVgbMVOQWDp7o07BWOmKXpJcnJ8mOjTau/W0sC2H7pWQc6DoVYYq2yYsQkDfMQuOIrEd1ToIQ0v/SXaVL7PK0L56sZfRgTD78PUmPYTwo6Z+YCWylhgrZ7ynpcF6Jmdhf7hpPlgOfGOnprgq+TyKbTJO9htRVs3HQTd2Vu7Grftr9EIL65OKxUTWajAxB8vmoGQqBscdo7J4M2x2SKEfSkzGAhkeRjHbDFjW0TrNViGAr91irfWGC9fK6EQGTvEiGYf+3xEGI6KykwHS9dmQorDm+0Khpe3BslwBh/IVsB2g+AeNThL9mz/Uj7hJ9Yx9ZtIbTEx0Ov9x/NDun6zePiIcySoorbHPKcuU8msbpasV6xDnkh8UMTM1+51XmC1QpPYAFG6f7cobxoFq10wOLSMzpW9v4hJO/WpdLztBkHbZgN3cqkJZXdk3u6HfHnO3Lh47pUm/M6f742D3d1Mc8x3Hn/A0be7ZOLNzVqKv44ZOF0lzKk7BTslstriQ0rK3ftdR+waoJd5FMpAvG38nvX+5OM/PLal9VtswjIMXJSlPhd945P0+uJ5etmyGjDIOWSgM0G2KtHES1FfGVhGUDOqhqA2gw5nnllEpDc87nMbdNXVOZnfPyGK/lqLbUoJk7SoVJIYVYN0BHvlAf02p2oIKLHczo1yegJIPYEmpr6W86WBjuFZC18Hg+nqXj6cnd/c2p+fadfXdr0fixQzSaSnQ3U1ujmCqoZ3hpjTCdHO43AZ1R5jGj3mt+nFx9T+dSwNfHzq51Io79JIeBP/aTHObNOHZ2Pe7E4058fTuxMcq8mLjzsP6k4+Ms9gVx9nj4Hrf8ccvX4B8P3+Phe9yJu0R/6DuxKFG12cF7c3s8dC2f41bfBPxxqx8P3crnuBOPO/Gb24lGGfENT93P/y8/ds9Uneh6t0rn7eRqc3/0UUMYUxw1xFFDNIAfX0MUD3VUI94YfPijrR7DeLjVP8nDZM9UY2X1ZKuKSOkEwbZn3O3D5JgvWJX3IUuWOd0Ty5+uxCJiitAkSrl1RuqUYp1y4yqFGc9o6ErpxzdRGgQiWLyxl7yJlzJKpj7GUlkxzaQEllYbLHdhijBORj0QG9iiLBOHPwkVJ64zwtfSmfMkzxpe1xjvOC4LE0r8WItk6fBf7n6lN88YSzK46YoSzumc0YJUl6PakIFE0TrLKxpERmhm9TSyPvbix/7ga2yShmbQ3eJV/8s+0hVuMSHDGOBN511sPm/9T/vMGKSrqe4Hbp0YQ1EXtQYJHZJrpCSpobPalXVlZzzhr7BvyMh7mh7zXblawY44p0d9yhXFgGbcSSaoM12NhOK2LJUTWLTQOyTmmBKlowKshdnEIsAu6GwG59E75/ryh5+sJGMB4g1Up2rjuJnedNebChgGAmERJU9EVBG/aqX1mZ0Hj9bZrXFQ6ofTLSUAphSRDHDlnEcWCTSe42YpUDEglFliaSFQMr9/jTj/5f7jmbLD1aF6e+/8p/2IGBKhMqg2AGjf8zjkrpgL18z+DIsGNENvBY1twArYDQZRz/RtS0+e0inX1h+sClY1c6Oo0h2hzZvDI1hVUCEWWIyFpEfriyZeV4EeXvZ8pS2SDqjN14IozU3qNPTIOrpJjEheuL3Btoh0jJp12r/gLDkjzQk8EYc+ey5CeRMZZio764tUb4FjZ25DS79XxWH+yKulsIuRzfjpoGj8Wys9V5R+Qy6KxIlY0JRPRJF7l7h4V5eXf2lmcUsPvoLB+9EL9t58VcBKJnaJV1Vgal3eFn6i9phampoU6Lz6JWcIOsREOVsmE6lVmbpGNBTX3zpCY+h51HXedb4x2dPMd16gJpeArF+cvgSZ7F6y2KyjpGpIVep7fQBtB3v0wxJsTO6cGLW98v2Qj8yUuZLd3lkAOibCWUhnYv6epzPi9JU1Q3KaaQ6d764Th0XcLakR/PKliuogkz/zWHi4te45/D8s9qSiLSx8l66bhkIlYa7wKqu/c/L5/afTzhXRqZyONnodjNVGKTtrqCBf5dbhnUGDWdS829Dz9kKSQHN7NmLSuG1NLBV/tobc6M1QnwV75/w0ufp5clXtYjiE3lIpr+Kkz6g+idNQR6hnfSTVq4A+ITVG53rydvL9D//c0E5l07CAj9RK8uI2S3yi8iLAtjWLvKywnUoJ+K+7m4//fSGktQQSIh9c8YiB0YQu3ymVc5lupJ4qNdbqmaOmeiymPFMF/Gg9MBdNFcnRydMteoEzb0c4ld/P6OCJCei48dGwtnQfNTPOd8W7LJk8S1zph4iY5MIuHx0PNUMkBuG/nVyfkmmcXV3AuAW17LeoEMWrnUCj2szojdAg6GjG+Uppp1ZW1TvODYfjZscojnieFRPsy5FREdCQHRDyt0u7J2uDGm6/Yppv9h1gvbrjZ05sde3S2VpZ0QHMXy3qflrKxSjVhiKm/URjG70SLhsjSuK8zIOYxu99l6F6EBNs+byjmxYOratO6LfjDuj5WWkdsuTtM8YGHY+6HHZ5sKiftmB7s+CZ7lVdrMC+3TtiBQ69K1YYYyMryKYAfkRMaOMjkrKhT5dr23hbl1XEDUR44uJwUzORuqBYA7ovMTBFqL4tWEsz2wmj/yrvYRTxIruvdknG+5QaKF6KEA1kVhsQwxuQHXrk7CXNnKAhZmOosVN72SlYa/eL92AwfnDFbz7SxVHSQY3P1oqaGPvfSleQHqbXQrKFkM1239QNefWw3XscfJeojkY4KoyfGa7m6DQa0Z2VUraOymYt1TJMFuFbxe6YhKNnhoyWo2pirv5xnM6Un+y7WJVNVI0EB7GMZtsH0/S4U31L2Po0yW4b2PtCQ863WFamrPoE2Ayu9mBSANqiXCaZG2FaLBTcx8F0TvFVCB15ul0lwaWwJf10qTe5dcz3bnZBoMNDYmtXCkdBtbuW+YNhPhW29Prw6z1pt89f7IPi7+OEYV32OVXwUx3NQaHMmYiKobQSBL4ip4FyLPtmHVE1KtEup8xnmYUPZAuWVz9fc7FYJhPAZ8Cwjgtmo68doBVQMRYCAZXMnsQqXdnduw1BQVpOeGUBiMm6T4TjpRGVOXQWsBEDdHgIWaudqz43gXq9weKIOa1/vz4zhxao86NFpvBWeNbrxwqt1Jv95yBSIDkJdUtlnidwIc4Q0XnBE/NkWMiAXHF/b+oersfuEfZmPR3w06X88FMOOPyY0VuV9lYADbp3IwjNxcruNtHBjaPZdHMrkdS8ppXKJqVUo1HBfLxuHbNt5U1cdFeZ1Dq21uG1hLA1IIwdwKjuQlhmggWNAed2UM0RgQWqtqjATlh9mdP8KjgOGOMlroyrB0AM5djZ4uFBR2/oWXDusEUEbLtkWwldT1btcSnt8Dpx6mfS3S2qNls2WVKNbZcMNND1ZtUeF9UGr1Xvu/N4og2LdNf1OO9oHjOY3gwdJjNwKddw7C1Sn0V4tWwcSlH/ndl+He2fLOA+xpBVLJyLl3uel83teRYiT/6RyoTtniVfKu+gjYzJ0w4ah8rNeWbaklEaZHYkmmRqqZ0ThjXw50L5Tpq5bApH32rD+Ef0jrVr3r0PsvBN/fRLr8f6bR5DQwoDKs6i6TPDvHHQwpuhja4aWydG0FQ2maet+GZOhqlmivJhrdKYKjpf41vLEm4ivcvPI3vV3WXH7EVWqYnyy62Wq5PMwjudqKYw2o8IV7rG0YprAjUfSHi0wnDVZGlZnGIRmoXdtIgnzt9B/XlqTWIVC6SuLVc67OfysrldmGLJnKV+UgQfwd/0La6dHLD+zPVks7w36E+KGRiqOIEXoG/If3wIzFCl5zAwLCGvhQhSmcZaBzYOnOd4WXe/s2SgTxq4NuSupaVmjzWwFcOo+DLIeUyHQEmBoZIqq/zm/YeqllgBKjaMB9RlR9KTZSSTBBsB7ZsJuoeIfVVnqvOgxoaBSgwLstqb3OGHutPBHlmraGs8a41S/s+aQU9L+Alltfi+dkD0OX5IEZorpLSsiByyTfoex1WO796kycO7iAQwPnQdxcoWPTXsmmJBOiw+20JRWIZZyVCbxo/XgxjTeKPZB2OMW85ofLF7ClsqaNaJ7u3zucvqZ57kOa+ocXmAvz6tt0Y2P31dQQ2lNvojrqHevPRG5pNtKb/RlzyTxNYU3zKhXZdFmrpX3mn2sS8opZx2JPlWyWhN9n1BMjrSfatktKf9viAdXYm/VULaEoDLZHT4bLakQmm/e9xVecHdXp6Uhjz+MvZRtn5nXn9153fn9ueMOyqCQyHjqAi6yOihCCxb+1fMR7Vv7dpgxkM4Pi1aJhvwGK6fttGNhq6uhgRt/ByfHPPPCz856py2/bhYc99S2djO3trzWBQzKKLD5h5EZlf5kI6siQH05pSWBA/vSKySM4EqBGnO/mLYurW/ahwOVWeVTEKVOwiyqiNfMeYoDdwstMRhcMbjEAuj/AolvjG8u6JsUKBJqoRqmM+n/T10mMTnHDFFOBdfetlbSa/vohr4xl7UPM8xUyb19Ocz20KWsw+Hs3kXAjoOLc0iOYTKrpfY0cXpRGXdnA6Xq86H2c1W40vTapgJtCPLVr9H3ZelZ1v5wrGt4YkFadaSFtmnVNpinJcoEs2apyyXVbiYYaNG+mrlJG/xYYPq7zzKW9OGC370slJ1gXcd8K2qVB0N1R0ZqsMNUljiiQqpamvK3SnVo3f2zhMkcbPPnhtjR0+y5N2+bwMFwSv2dDhEY0CqDqmt5IaOSrnKZDlEqot4EGXGKSbkme0nRSUnDCZovqZg69pTnVRfD/Ymz5bxfAVzDeCebta8j0eToPp8V6kSoBJ6TipremotEZd9hrWTpqHles/CkpEn11RoLF5K365ITZgYTPIyOHHmAUD3r3LyohJPFpideKlwzUgh0nWJNvIqIgdsAuwomvualeb3AUGT867vGxrIUotXb3jcZrdsmrOjhIw6fQ+RM+eHlR91erskNc0u5WrU2SXlCw6YffogejyjDIWAg1JV2r5I8OQYFQUOiEdY857u2tzx+tAMP4yLA0sAU810rZaSFRj6aayqZ9CdMDOQGscb03DKmXWoRmOtcA5VQqgxq8SUllvitsw6eDszi5XTAldlWnsuyk6sS+TaqzC3AOhrMbgA6iszuQDxAd0yqiqXtnXjiCe1vU/3kfq9o5n2o8FpIeRocB4NzldgcNpgPByqf1G/LuzMzfhw+H7GKgs63Y2Now7nzMHbh3BqlPnTZvM1DryRLfhwmK7G6rqN6WvEsaeJGx6kqijpCLw3fPlw58zS+ZxHsaV26BBCD1U1mDqhSrFFRzSOuY32JDa9Bj2hmVXlU01hdHFp48tjzq3DVBrVhWzOsLJeFlpJV/EJf20luPXZvkSyxxNl5Wgibm5b6nlUIPQQ0hGB9EDEXHwrnrJABgezgd4DmOcVJiTmnhZ6sKMgc8KrslDOI46BHv7zOamgk98+/9EsNb6Ik1Knl1U4xzK6S2Deqa26c3/m4VPjnpmHhSvPZ8x9KFa/YA7wISd3A6qI13um5w5PTZp47DVaCh6xyF0Kl/lTxarpYZ0XZuxL7nHMYGuTMu/vZihPdSA052COwq54fZjcKnxOvfnWOGSZn5vxTQSvTZNmiEvqorTzml141R25EadeQG02c8quUK082kA6VgydiIdFMbZTKAzTcwXR0f+DSONmVTyuzglB+U+pzMTe091RR7C8+Gv5yp5EYrHgETl/w7aHHoI+UB7+R0bTV0A3Ae0g3HnzCb/1Rv0nltANsYJ0XlpXe0hgJ6WURoAldpNq44HiQ+Xa8e+oFxsVK/GEWXy2p0TF00Zf1A5KSOCE9E+qIyH1rioSKZgKM+5dzMakQ6a7rmhjJwTmLRTEtqS0tZLpRco+jkUdP4g7JGJBrFp6Ost0wYkvpxg+3vyyNa7hCgyb4swHw7XfK80L4F9Yzkgrv4ZlQsAyHAyt9/nz/oarlwb8UbgJFiw5GKI+GT5qlwVYo4WqwLk+g1PBG0zp4RTK6OjIo3ry/Dz5vtJluPh845U2OvmDny4e4eeYqX8wZBwz9bvI2CxT/39rEY6jjjjqiKOO6MK1eTWPmf9Qa7dX4OiVJfmLL90HfOY5JkfuKDlyg9xIVfThUGx8FYxQa2eIt7M5jyL1aIy3XnKs4HkyI6Hy8LrSOLnTEvMziE1C7qeoScEA7FIpQQFRRUEqcYrc1oclkL854VSDkhe90nR5Qeq8K33h1lpbGhtwS0WgAfz9GpVB3oMz4qHPXJyfdM1RO+xHO9hIGDfiALvJKDnF9VYhRv1DDjZGURto1OiFLYnqVCW7RnI43o++zov9XVOOZUKP94njfaIPro19DvvzROYVY45b+LiFt6Xj29iUhQ2gWujG6WrFSjWQEpH4HClUL+z39S9YN2iL7aqHyJsJVvpqYnuPNAhUOxsVCiXjkpUK1i0cyjipWTPdtjXbONzI0Q6zu3ovK2ATXBHX8BrdMxRhIyEp3jEzhg3Bgq7X0YGQP3cIitjnPNwFS7KBh6FJJEYNjQ9GjTsIy59yNRPjr5AadhASj7PxWYKDNqFwbpLvYvTjPztp4IsHbBmrOnUkqm0wBuawyJmlVDqPjHNqogEbMxZJql0k8O0Ve9ZhPHbS1uyBWzIYtycvG/gcLzyN7HZusb32HO7iZIhIH3u7UoNt5/+qYCP9mBE3w98J9N3CDln0sINdpoYdC/oZ9u3S3XZ5hMG3eJFVfXEb6EqDh0Cuq8FaIxCW02L0DVmquz1er1Pfw/bbFAMBZyp/RLs2whAnjcgOF5NbWc1GqRz4X+xfarTKyxVH0ctEATZGSV09rbK8da3NyiBd7eTt52grs+3YjOorIBbZMaoRNrs9ydVck+AN58/4oQdFL+zNxW0xeU40Kzk0B9tdlb8fbkXhP61yYHdjdok3K0o9K6dV05nMklpHoy3mpUBokTxrPdEDga1IwhYAMJ8M10KNWwZg58Bz4E4Zla8eD8UHXXMIB3fU4GeOUFg+v7/5CJfkCA5PahLngRpk8F27dSDgSq0ziEbSfIbq02HrapKW+Xdp4dMMxiJRTVoRk2Jrw0RhhOOzhIb1ulmiShiMP78ujdA5P+2v+ttBi1O7VFR4I6nGsH+6ELE1oVBHZGxFSfp2XMkxIktpcFNosIoEZSI4V5fXP5zjC0QGoQ0e7s8d2Eoan75ha4gqmj4inxnO24E21088qugu+9mUnzgznphnTgto00egGx/r2cw13dWhRZm/xjFVJ9SsgsO8KUnbNrPhKE7pYGqbc+vpsrNwwJTpbHsqYZDzYUROYxHUHLNqTq8OpjYhJYskbBVmE/r0qKPM5yU2k4U7ZwkK5QeosweDDLRleKZuEvh/oP3TsNxSw0TNn7g7daW3FZ/ub/79w99+++jgOCrOwUCI5a/xUqIvBw0XEpGoZK3t18xcLxy3Xpi4PusjDzzMD4p4zKvKftDsWffeASjylq2bXV20tskUEItj6QpslOusRbJslNruuwu2YFXlMKxtHQZcIO7+0G+85Zr5jTPr/Jf2oiP95y8VCqklUFYnp5eXCSXcbDWrUYlBveX0XhjjTOAJCN1DI45eoSt6kCJAtamIYq9QlX2n2qpwqobEMgNViJmkm6f1Dcal5+tCJtNkW2jWaZsy1Mx5t1ooyyKYc9ZtK/QJmJgGX/uxSIsaJS4MPHW0bfeo4omIN19KmS9q9ZIwHirfOJOmv1+JhQpyA6URpQ0BqUXht5WoRYP3RYDf22ZybJ3uT0RVVajpaz/mT2CAoGvt6ufryeXkenKFTrrry8urd5cff/np3ftf/u3ju59+/P7tu3dXw8z63xCHc3PnMM/DZz6d4ugybFaN3a5u7h5/wMngf9/mX+pDG3Zc7nv3yOm7rnZl7gUfp+rAFMGJk/ADYPhnAjIyxzV1e2G5JqA/z9F5PsCAy4H9y9vz66ur86urfzn//u0kWE/0b8ASqHl9OzDfffmMOX4wmfXQj7I1wecjNNHlDJ328JVHweC36NevH8+whL6UD40hnRU28MT3pliyYyqDIeZ0zo+NyUcrmM/n3NVxneG5ch96km4BJ/zLbx9PM8tY8wIXTRUYAbQOWGs1+n024/7E+RUkVSM7owFwtH++omv3m7mUkxmLJgvpw01pIqPF5A3y9435g5qv/Eue+A5jeDzh0Upo57oaHm41mMGvwrcDh4NZ53mwWq4Mn3OnKEtqbdHpD5ZJEr67uAjTmQ/WeTqfiyfC0VuWpzyKagUBtnA8/RsOp780y8h0WRrzYk1IArW4Obp4WQfiLC4jrMUyd51xzX856IjLhoHFWsFFeEMQFifMZihWni8GbbyOZaP8F02bUxq6FQfc7jfkBPoFUsqm3oYf1P1wsEjY/2r4xI0utY6pMWx3OkAUyjZwc2zSPf3esfx+29Ak7DWKJUAy+1kUAUnaQbCVBc0aAg86nRN1QX5PchwEyqKuLkKnT6L1Wi6ChC+4rXpKjwwFBEY8bEZnGJ0CeNr6srs1lnwKMn7sbrPEtVuYG64L3sA2X5uOroTNDOm6e/dg2KdysWTzKpk5fM6cGYtVHG3hmsG6ni6ZZ6oMC1UnUQ61kJISxJ984nyQEZjFITVpTmTWMzHmFNRzgRrzAv7oAm5rFyJ8/OEClgUruekYDvYIJygqUpyaIjgmzTmL9Z1WcLCXt6dZoNpXlxaihwNIRuGStZf5aV7pnmgJsdrrepH0tJhM54bZ0jbzt5WCJh0yNgGZPunmez+9sgN8CK1Nz1Th4dsEGLLx0hpSMjLA4g3QmHYQN11fxny6Zo29MXaCtoIQdcS0QDK1PoaVceNrzUHAzoH0QR0/B9O4OVJ+b6AzHH0xw1X98RAwI44+mOcioDWpuoL2DjoHMgR11f/zYqiv+6DG59cpc20vMHsFneHogxl1zV5OkG6VBzBsiPNLmjeq+frHx2/EfEVCXtB8hWU5QPO1fXVpIXqYr/s2/ppQt/xLvjvCSlriYC/BVzXE13Ixa12bAQw+LSrqW9qXsOVTW6ocJJOVPZrB8jSQbZ/sTyu/FkGYJtPsSyvhgx1oDR/oEcx6e5/RCoJvDlUPFUM/UNzJ+w0CxX6TiwX3zkWg+mnHPMYg/qoDuY3HDe60jUN8i5oRGox11pjXMg23mPd9YD6N+HIhUHNVp2gpT7ElzR9/wbcGiuKk0ftwwPIIuyUK/PM8RsiQhoYFsMWKbLMGufD1DU0pP09YkcykhC1R3cSdSPDPKPXCVZqJZS9DrRzZJlTMviJZg9tK0F8LBleOLRXGaigF7VlmKUL+mVc7rDaOJ8fXHykT566fTlBrNB345Np5hL4vPQvqN+miM2wFUPEv/x+oqrMc"
 }
@@ -0,0 +1,106 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package pressure
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/cgroup/cgcommon"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
+)
+
+// resources are the files of /proc/pressure. The irq file only exists on
+// kernels 6.1 and newer built with CONFIG_IRQ_TIME_ACCOUNTING.
+var resources = []string{"cpu", "memory", "io", "irq"}
+
+func init() {
+	mb.Registry.MustAddMetricSet("system", "pressure", New,
+		mb.WithHostParser(parse.EmptyHostParser),
+	)
+}
+
+// MetricSet for fetching system wide pressure stall information.
+type MetricSet struct {
+	mb.BaseMetricSet
+	mod resolve.Resolver
+}
+
+// New returns a new pressure MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	base.Logger().Warn(cfgwarn.Beta("The system pressure metricset is beta."))
+
+	sys, ok := base.Module().(resolve.Resolver)
+	if !ok {
+		return nil, fmt.Errorf("unexpected module type: %T", base.Module())
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		mod:           sys,
+	}, nil
+}
+
+// Fetch fetches the pressure stall information of the CPU, memory, IO and
+// IRQ resources.
+func (m *MetricSet) Fetch(r mb.ReporterV2) error {
+	event := mapstr.M{}
+	for _, resource := range resources {
+		pressure, err := cgcommon.GetPressure(m.mod.ResolveHostFS(filepath.Join("/proc/pressure", resource)))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error reading %s pressure: %w", resource, err)
+		}
+
+		event[resource] = pressureFields(pressure)
+	}
+	if len(event) == 0 {
+		return errors.New("pressure stall information is not available, it requires Linux 4.20 or newer with CONFIG_PSI enabled")
+	}
+
+	r.Event(mb.Event{
+		MetricSetFields: event,
+	})
+
+	return nil
+}
+
+// pressureFields converts the "some" and "full" lines of a pressure file to
+// the same layout as the cgroup pressure fields of the process metricset.
+func pressureFields(pressure map[string]cgcommon.Pressure) mapstr.M {
+	fields := mapstr.M{}
+	for stall, p := range pressure {
+		fields[stall] = mapstr.M{
+			"10":    mapstr.M{"pct": p.Ten.Pct},
+			"60":    mapstr.M{"pct": p.Sixty.Pct},
+			"300":   mapstr.M{"pct": p.ThreeHundred.Pct},
+			"total": p.Total.ValueOr(0),
+		}
+	}
+	return fields
+}
@@ -0,0 +1,85 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package pressure
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	_ "github.com/elastic/beats/v7/metricbeat/module/system"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestData(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig("./_meta/testdata"))
+	err := mbtest.WriteEventsReporterV2Error(f, t, ".")
+	if err != nil {
+		t.Fatal("write", err)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig("./_meta/testdata"))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+
+	assert.Empty(t, errs)
+	require.Len(t, events, 1)
+	fields := events[0].MetricSetFields
+
+	assert.Equal(t, mapstr.M{
+		"some": mapstr.M{
+			"10":    mapstr.M{"pct": 3.10},
+			"60":    mapstr.M{"pct": 2.25},
+			"300":   mapstr.M{"pct": 1.40},
+			"total": uint64(387210450),
+		},
+		"full": mapstr.M{
+			"10":    mapstr.M{"pct": 2.05},
+			"60":    mapstr.M{"pct": 1.61},
+			"300":   mapstr.M{"pct": 0.98},
+			"total": uint64(290332871),
+		},
+	}, fields["io"])
+	total, _ := fields.GetValue("cpu.some.total")
+	assert.Equal(t, uint64(104876534), total)
+	assert.Contains(t, fields, "memory")
+	// The test data has no irq file, like kernels older than 6.1.
+	assert.NotContains(t, fields, "irq")
+}
+
+func TestFetchWithoutPSI(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig(t.TempDir()))
+	events, errs := mbtest.ReportingFetchV2Error(f)
+
+	assert.Empty(t, events)
+	require.Len(t, errs, 1)
+	assert.ErrorContains(t, errs[0], "CONFIG_PSI")
+}
+
+func getConfig(hostfs string) map[string]interface{} {
+	return map[string]interface{}{
+		"module":     "system",
+		"metricsets": []string{"pressure"},
+		"hostfs":     hostfs,
+	}
+}
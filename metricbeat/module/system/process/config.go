@@ -34,7 +34,17 @@ type Config struct {
 	IncludePerCPU   bool                     `config:"process.include_per_cpu"`
 	CPUTicks        *bool                    `config:"cpu_ticks"` // Deprecated
 	// Pid, if set, will override the `processes` config, and only monitor a single process.
-	Pid int `config:"process.pid"`
+	Pid        int              `config:"process.pid"`
+	ShortLived ShortLivedConfig `config:"process.short_lived"`
+}
+
+// ShortLivedConfig configures the capture of processes that start and exit
+// between two fetches, using eBPF.
+type ShortLivedConfig struct {
+	Enabled bool `config:"enabled"`
+	// MaxProcesses is the maximum number of short-lived processes reported
+	// per fetch.
+	MaxProcesses int `config:"max_processes" validate:"min=1"`
 }
 
 // log warning for unsupported config
@@ -53,4 +63,7 @@ var defaultConfig = Config{
 		ByMemory: 0,
 	},
 	IncludePerCPU: true,
+	ShortLived: ShortLivedConfig{
+		MaxProcesses: 1000,
+	},
 }
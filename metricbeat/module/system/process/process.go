@@ -23,6 +23,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
 	"runtime"
 
 	"github.com/elastic/beats/v7/metricbeat/mb"
@@ -46,6 +47,8 @@ type MetricSet struct {
 	perCPU           bool
 	setpid           int
 	degradeOnPartial bool
+	shortLived       shortLivedTracker
+	procPatterns     []*regexp.Regexp
 }
 
 // New creates and returns a new MetricSet.
@@ -112,15 +115,44 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 	if err != nil {
 		return nil, err
 	}
+
+	if config.ShortLived.Enabled && m.setpid == 0 {
+		for _, pattern := range config.Procs {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("failed to compile regexp [%s]: %w", pattern, err)
+			}
+			m.procPatterns = append(m.procPatterns, re)
+		}
+		m.shortLived, err = newShortLivedTracker(config.ShortLived.MaxProcesses, base.Logger().Named("system.process"))
+		if err != nil {
+			return nil, fmt.Errorf("error starting short-lived process capture: %w", err)
+		}
+	}
 	return m, nil
 }
 
+// Close stops the capture of short-lived processes.
+func (m *MetricSet) Close() error {
+	if m.shortLived != nil {
+		m.shortLived.Close()
+	}
+	return nil
+}
+
 // Fetch fetches metrics for all processes. It iterates over each PID and
 // collects process metadata, CPU metrics, and memory metrics.
 func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 
 	// monitor either a single PID, or the configured set of processes.
 	if m.setpid == 0 {
+		// Drain before polling, a process started in between is reported
+		// twice rather than missed.
+		var shortLived []shortLivedProcess
+		if m.shortLived != nil {
+			shortLived = m.shortLived.Drain()
+		}
+
 		procs, roots, err := m.stats.Get()
 		if err != nil && !errors.Is(err, process.NonFatalErr{}) {
 			// return only if the error is fatal in nature
@@ -141,6 +173,14 @@ func (m *MetricSet) Fetch(r mb.ReporterV2) error {
 				return err
 			}
 		}
+		for _, p := range shortLived {
+			if !matchProcess(m.procPatterns, p.name()) {
+				continue
+			}
+			if !r.Event(p.event()) {
+				return err
+			}
+		}
 		return err
 	} else {
 		proc, root, err := m.stats.GetOneRootEvent(m.setpid)
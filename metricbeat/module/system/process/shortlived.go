@@ -0,0 +1,101 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build darwin || freebsd || linux || windows || aix
+
+package process
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// shortLivedTracker reports the processes that started and exited between two
+// fetches, which are never seen when polling the process table.
+type shortLivedTracker interface {
+	// Drain returns the processes that exited since the previous call and
+	// were started after it.
+	Drain() []shortLivedProcess
+	Close()
+}
+
+// shortLivedProcess is a process observed by a shortLivedTracker.
+type shortLivedProcess struct {
+	PID              uint32
+	PPID             uint32
+	PGID             uint32
+	UID              uint32
+	Executable       string
+	Args             []string
+	WorkingDirectory string
+	Start            time.Time
+	End              time.Time
+	ExitCode         int32
+}
+
+func (p shortLivedProcess) name() string {
+	return filepath.Base(p.Executable)
+}
+
+// event returns the process event of a short-lived process. It only contains
+// the metadata known when the process started and exited, there are no CPU,
+// memory or IO metrics.
+func (p shortLivedProcess) event() mb.Event {
+	proc := mapstr.M{
+		"pid":        p.PID,
+		"parent":     mapstr.M{"pid": p.PPID},
+		"pgid":       p.PGID,
+		"name":       p.name(),
+		"executable": p.Executable,
+		"end":        p.End,
+		"exit_code":  p.ExitCode,
+	}
+	if len(p.Args) > 0 {
+		proc["args"] = p.Args
+		proc["command_line"] = strings.Join(p.Args, " ")
+	}
+	if p.WorkingDirectory != "" {
+		proc["working_directory"] = p.WorkingDirectory
+	}
+	if !p.Start.IsZero() {
+		proc["start"] = p.Start
+	}
+
+	return mb.Event{
+		MetricSetFields: mapstr.M{"short_lived": true},
+		RootFields: mapstr.M{
+			"process": proc,
+			"user":    mapstr.M{"id": p.UID},
+		},
+	}
+}
+
+// matchProcess reports whether the process name matches one of the
+// `processes` regular expressions.
+func matchProcess(patterns []*regexp.Regexp, name string) bool {
+	for _, re := range patterns {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux && (amd64 || arm64)
+
+package process
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/ebpf"
+	"github.com/elastic/beats/v7/libbeat/ebpf/sys"
+	"github.com/elastic/ebpfevents"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const shortLivedClientName = "metricbeat_system_process"
+
+// ebpfTracker follows process executions and exits with the shared eBPF
+// watcher.
+type ebpfTracker struct {
+	log          *logp.Logger
+	watcher      *ebpf.Watcher
+	maxProcesses int
+	done         chan struct{}
+
+	mu      sync.Mutex
+	started map[uint32]shortLivedProcess
+	exited  []shortLivedProcess
+	dropped int
+}
+
+func newShortLivedTracker(maxProcesses int, log *logp.Logger) (shortLivedTracker, error) {
+	watcher, err := ebpf.GetWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	t := &ebpfTracker{
+		log:          log,
+		watcher:      watcher,
+		maxProcesses: maxProcesses,
+		done:         make(chan struct{}),
+		started:      make(map[uint32]shortLivedProcess),
+	}
+	records := watcher.Subscribe(shortLivedClientName, ebpf.EventMask(ebpfevents.EventTypeProcessExec|ebpfevents.EventTypeProcessExit))
+	go t.consume(records)
+	return t, nil
+}
+
+func (t *ebpfTracker) consume(records <-chan ebpfevents.Record) {
+	for {
+		select {
+		case rec, ok := <-records:
+			if !ok {
+				return
+			}
+			if rec.Error != nil {
+				t.log.Errorf("ebpf watcher error: %v", rec.Error)
+				continue
+			}
+			switch body := rec.Event.Body.(type) {
+			case *ebpfevents.ProcessExec:
+				t.exec(body)
+			case *ebpfevents.ProcessExit:
+				t.exit(body)
+			}
+		case <-t.done:
+			return
+		}
+	}
+}
+
+func (t *ebpfTracker) exec(e *ebpfevents.ProcessExec) {
+	p := shortLivedProcess{
+		PID:              e.Pids.Tgid,
+		PPID:             e.Pids.Ppid,
+		PGID:             e.Pids.Pgid,
+		UID:              e.Creds.Euid,
+		Executable:       e.Filename,
+		Args:             e.Argv,
+		WorkingDirectory: e.Cwd,
+	}
+	if start, err := sys.TimeFromNsSinceBoot(e.Pids.StartTimeNs); err == nil {
+		p.Start = start
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	// A process executing a new program replaces its previous entry.
+	if _, ok := t.started[p.PID]; !ok && len(t.started) >= t.maxProcesses {
+		t.dropped++
+		return
+	}
+	t.started[p.PID] = p
+}
+
+func (t *ebpfTracker) exit(e *ebpfevents.ProcessExit) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	p, ok := t.started[e.Pids.Tgid]
+	if !ok {
+		// The process started before the previous fetch, polling saw it.
+		return
+	}
+	delete(t.started, e.Pids.Tgid)
+	if len(t.exited) >= t.maxProcesses {
+		t.dropped++
+		return
+	}
+	p.End = time.Now()
+	p.ExitCode = e.ExitCode
+	t.exited = append(t.exited, p)
+}
+
+// Drain returns the processes that were executed and exited since the
+// previous call. The processes still running are forgotten, the next fetch
+// polls them.
+func (t *ebpfTracker) Drain() []shortLivedProcess {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	exited := t.exited
+	t.exited = nil
+	t.started = make(map[uint32]shortLivedProcess, len(t.started))
+	if t.dropped > 0 {
+		t.log.Warnf("Dropped %d short-lived processes, more than process.short_lived.max_processes (%d) were executed between two fetches", t.dropped, t.maxProcesses)
+		t.dropped = 0
+	}
+	return exited
+}
+
+func (t *ebpfTracker) Close() {
+	close(t.done)
+	t.watcher.Unsubscribe(shortLivedClientName)
+}
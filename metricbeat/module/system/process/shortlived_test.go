@@ -0,0 +1,112 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build darwin || freebsd || linux || windows || aix
+
+package process
+
+import (
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type fakeTracker struct {
+	processes []shortLivedProcess
+	closed    bool
+}
+
+func (f *fakeTracker) Drain() []shortLivedProcess {
+	processes := f.processes
+	f.processes = nil
+	return processes
+}
+
+func (f *fakeTracker) Close() {
+	f.closed = true
+}
+
+func TestShortLivedEvent(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	p := shortLivedProcess{
+		PID:              4321,
+		PPID:             1000,
+		PGID:             1000,
+		UID:              1001,
+		Executable:       "/usr/bin/curl",
+		Args:             []string{"curl", "-s", "http://localhost"},
+		WorkingDirectory: "/home/user",
+		Start:            start,
+		End:              start.Add(50 * time.Millisecond),
+		ExitCode:         7,
+	}
+
+	event := p.event()
+	assert.Equal(t, mapstr.M{"short_lived": true}, event.MetricSetFields)
+	assert.Equal(t, mapstr.M{
+		"process": mapstr.M{
+			"pid":               uint32(4321),
+			"parent":            mapstr.M{"pid": uint32(1000)},
+			"pgid":              uint32(1000),
+			"name":              "curl",
+			"executable":        "/usr/bin/curl",
+			"args":              []string{"curl", "-s", "http://localhost"},
+			"command_line":      "curl -s http://localhost",
+			"working_directory": "/home/user",
+			"start":             start,
+			"end":               start.Add(50 * time.Millisecond),
+			"exit_code":         int32(7),
+		},
+		"user": mapstr.M{"id": uint32(1001)},
+	}, event.RootFields)
+}
+
+func TestFetchShortLived(t *testing.T) {
+	f := mbtest.NewReportingMetricSetV2Error(t, getConfig())
+	m, ok := f.(*MetricSet)
+	require.True(t, ok)
+	tracker := &fakeTracker{processes: []shortLivedProcess{
+		{PID: 10, Executable: "/bin/true"},
+		{PID: 11, Executable: "/usr/bin/sleep"},
+	}}
+	m.shortLived = tracker
+	m.procPatterns = []*regexp.Regexp{regexp.MustCompile("^true$")}
+
+	events, errs := mbtest.ReportingFetchV2Error(f)
+	for _, err := range errs {
+		t.Logf("fetch error: %v", err)
+	}
+
+	var shortLived []mapstr.M
+	for _, e := range events {
+		if e.MetricSetFields["short_lived"] == true {
+			shortLived = append(shortLived, e.RootFields)
+		}
+	}
+	require.Len(t, shortLived, 1)
+	name, _ := shortLived[0].GetValue("process.name")
+	assert.Equal(t, "true", name)
+
+	require.NoError(t, m.Close())
+	assert.True(t, tracker.closed)
+}
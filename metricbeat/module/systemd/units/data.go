@@ -0,0 +1,178 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package units
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/cgroup/cgcommon"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/cgroup/cgv2"
+)
+
+// formEvent returns the event for a unit with its state and, for services,
+// the main process and restart count.
+func formEvent(unit dbus.UnitStatus, props map[string]interface{}) mb.Event {
+	msData := mapstr.M{
+		"name":       unit.Name,
+		"type":       unit.Name[strings.LastIndex(unit.Name, ".")+1:],
+		"load_state": unit.LoadState,
+		"state":      unit.ActiveState,
+		"sub_state":  unit.SubState,
+	}
+	if restarts, ok := props["NRestarts"].(uint32); ok {
+		msData["restarts"] = restarts
+	}
+
+	event := mb.Event{
+		RootFields: mapstr.M{
+			"systemd": mapstr.M{
+				"unit": unit.Name,
+			},
+		},
+		MetricSetFields: msData,
+	}
+	if pid, ok := props["MainPID"].(uint32); ok && pid > 0 {
+		event.RootFields["process"] = mapstr.M{"pid": pid}
+	}
+	return event
+}
+
+// readCgroup reads the CPU, memory, IO and tasks accounting of the cgroup v2
+// at path. Controllers that are not enabled for the cgroup are left out.
+func (m *MetricSet) readCgroup(unit, path string, now time.Time) (mapstr.M, error) {
+	if _, err := os.Stat(path); err != nil {
+		return nil, err
+	}
+	fields := mapstr.M{}
+
+	cpu := cgv2.CPUSubsystem{}
+	if err := cpu.Get(path); err != nil {
+		return fields, fmt.Errorf("error reading cpu stats: %w", err)
+	}
+	fields["cpu"] = m.cpuFields(unit, cpu.Stats, now)
+
+	if exists(filepath.Join(path, "memory.current")) {
+		mem := cgv2.MemorySubsystem{}
+		if err := mem.Get(path); err != nil {
+			return fields, fmt.Errorf("error reading memory stats: %w", err)
+		}
+		memory := memoryFields(mem)
+		if exists(filepath.Join(path, "memory.swap.current")) {
+			memory.Put("swap.usage.bytes", mem.MemSwap.Usage.Bytes)
+		}
+		fields["memory"] = memory
+	}
+
+	if exists(filepath.Join(path, "io.stat")) {
+		io := cgv2.IOSubsystem{}
+		if err := io.Get(path, false); err != nil {
+			return fields, fmt.Errorf("error reading io stats: %w", err)
+		}
+		fields["io"] = ioFields(io)
+	}
+
+	if exists(filepath.Join(path, "pids.current")) {
+		tasks, err := cgcommon.ParseUintFromFile(filepath.Join(path, "pids.current"))
+		if err != nil {
+			return fields, fmt.Errorf("error reading pids.current: %w", err)
+		}
+		fields["tasks"] = mapstr.M{"count": tasks}
+	}
+
+	return fields, nil
+}
+
+// cpuFields reports the CPU time of the unit and, from the second fetch on,
+// the share of CPU time used since the previous fetch.
+func (m *MetricSet) cpuFields(unit string, stats cgv2.CPUStats, now time.Time) mapstr.M {
+	fields := mapstr.M{
+		"usage":  mapstr.M{"ns": stats.Usage.NS},
+		"user":   mapstr.M{"ns": stats.User.NS},
+		"system": mapstr.M{"ns": stats.System.NS},
+	}
+	if stats.Throttled.Periods.Exists() {
+		fields.Put("throttled.periods", stats.Throttled.Periods.ValueOr(0))
+		fields.Put("throttled.us", stats.Throttled.Us.ValueOr(0))
+	}
+
+	last, ok := m.lastCPU[unit]
+	m.lastCPU[unit] = cpuSample{usage: stats.Usage.NS, time: now}
+	// A counter going backwards means the unit was restarted with a new cgroup.
+	if ok && stats.Usage.NS >= last.usage && now.After(last.time) {
+		pct := float64(stats.Usage.NS-last.usage) / float64(now.Sub(last.time).Nanoseconds())
+		fields.Put("usage.pct", pct)
+		fields.Put("usage.norm.pct", pct/float64(m.numCPU))
+	}
+	return fields
+}
+
+func memoryFields(mem cgv2.MemorySubsystem) mapstr.M {
+	fields := mapstr.M{
+		"usage": mapstr.M{"bytes": mem.Mem.Usage.Bytes},
+		"anon":  mapstr.M{"bytes": mem.Stats.Anon.Bytes},
+		"file":  mapstr.M{"bytes": mem.Stats.File.Bytes},
+		"events": mapstr.M{
+			"high":     mem.Mem.Events.High,
+			"max":      mem.Mem.Events.Max,
+			"oom":      mem.Mem.Events.OOM.ValueOr(0),
+			"oom_kill": mem.Mem.Events.OOMKill.ValueOr(0),
+		},
+	}
+	// memory.high and memory.max are not set when they are "max".
+	if mem.Mem.High.Bytes.Exists() {
+		fields.Put("high.bytes", mem.Mem.High.Bytes.ValueOr(0))
+	}
+	if mem.Mem.Max.Bytes.Exists() {
+		fields.Put("max.bytes", mem.Mem.Max.Bytes.ValueOr(0))
+	}
+	return fields
+}
+
+// ioFields sums the IO stats of all devices used by the unit.
+func ioFields(io cgv2.IOSubsystem) mapstr.M {
+	var total cgv2.IOStat
+	for _, stat := range io.Stats {
+		total.Read.Bytes += stat.Read.Bytes
+		total.Read.IOs += stat.Read.IOs
+		total.Write.Bytes += stat.Write.Bytes
+		total.Write.IOs += stat.Write.IOs
+		total.Discarded.Bytes += stat.Discarded.Bytes
+		total.Discarded.IOs += stat.Discarded.IOs
+	}
+	return mapstr.M{
+		"read":      mapstr.M{"bytes": total.Read.Bytes, "ios": total.Read.IOs},
+		"write":     mapstr.M{"bytes": total.Write.Bytes, "ios": total.Write.IOs},
+		"discarded": mapstr.M{"bytes": total.Discarded.Bytes, "ios": total.Discarded.IOs},
+	}
+}
+
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return !errors.Is(err, os.ErrNotExist)
+}
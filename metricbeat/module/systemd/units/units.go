@@ -0,0 +1,201 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package units
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/resolve"
+)
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. After the
+// MetricSet has been created then Fetch will begin to be called periodically.
+func init() {
+	mb.Registry.MustAddMetricSet("systemd", "units", New)
+}
+
+type config struct {
+	StateFilter   []string `config:"units.state_filter"`
+	PatternFilter []string `config:"units.pattern_filter"`
+}
+
+func defaultConfig() config {
+	return config{
+		// Only these unit types have processes and thus a cgroup to account.
+		PatternFilter: []string{"*.service", "*.slice", "*.scope"},
+	}
+}
+
+// unitConn is the subset of the systemd D-Bus API used by the metricset.
+type unitConn interface {
+	ListUnitsByPatternsContext(ctx context.Context, states, patterns []string) ([]dbus.UnitStatus, error)
+	GetUnitTypePropertiesContext(ctx context.Context, unit, unitType string) (map[string]interface{}, error)
+}
+
+// MetricSet holds any configuration or state information. It must implement
+// the mb.MetricSet interface. And this is best achieved by embedding
+// mb.BaseMetricSet because it implements all of the required mb.MetricSet
+// interface methods except for Fetch.
+type MetricSet struct {
+	mb.BaseMetricSet
+	conn       unitConn
+	cfg        config
+	cgroupRoot string
+	numCPU     int
+	lastCPU    map[string]cpuSample
+}
+
+type cpuSample struct {
+	usage uint64
+	time  time.Time
+}
+
+// New creates a new instance of the MetricSet. New is responsible for unpacking
+// any MetricSet specific configuration options if there are any.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	base.Logger().Warn(cfgwarn.Beta("The systemd units metricset is beta."))
+
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, err
+	}
+
+	conn, err := dbus.NewWithContext(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("error connecting to dbus: %w", err)
+	}
+
+	sys := base.Module().(resolve.Resolver)
+	cgroupRoot := findCgroupV2Root(sys.ResolveHostFS("/sys/fs/cgroup"))
+	if cgroupRoot == "" {
+		base.Logger().Warnf("No cgroup v2 hierarchy found under %s, unit resource usage will not be reported",
+			sys.ResolveHostFS("/sys/fs/cgroup"))
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		conn:          conn,
+		cfg:           config,
+		cgroupRoot:    cgroupRoot,
+		numCPU:        runtime.NumCPU(),
+		lastCPU:       map[string]cpuSample{},
+	}, nil
+}
+
+// findCgroupV2Root returns the mount point of the cgroup v2 hierarchy, which
+// is either mounted at the root of the cgroup filesystem or, on hosts running
+// systemd in hybrid mode, at its unified subdirectory. It returns an empty
+// string if the host only has cgroup v1.
+func findCgroupV2Root(root string) string {
+	for _, path := range []string{root, filepath.Join(root, "unified")} {
+		if _, err := os.Stat(filepath.Join(path, "cgroup.controllers")); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(report mb.ReporterV2) error {
+	ctx := context.Background()
+	units, err := m.conn.ListUnitsByPatternsContext(ctx, m.cfg.StateFilter, m.cfg.PatternFilter)
+	if err != nil {
+		return fmt.Errorf("error listing units: %w", err)
+	}
+
+	now := time.Now()
+	seen := make(map[string]struct{}, len(units))
+	for _, unit := range units {
+		// Units referenced by dependencies but without a unit file.
+		if unit.LoadState == "not-found" {
+			continue
+		}
+		seen[unit.Name] = struct{}{}
+
+		var props map[string]interface{}
+		if iface := unitInterface(unit.Name); iface != "" {
+			props, err = m.conn.GetUnitTypePropertiesContext(ctx, unit.Name, iface)
+			if err != nil {
+				m.Logger().Debugf("error getting properties for unit %s: %s", unit.Name, err)
+			}
+		}
+
+		event := formEvent(unit, props)
+		if cgroup, _ := props["ControlGroup"].(string); cgroup != "" && m.cgroupRoot != "" {
+			resources, err := m.readCgroup(unit.Name, filepath.Join(m.cgroupRoot, cgroup), now)
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				m.Logger().Debugf("error reading cgroup of unit %s: %s", unit.Name, err)
+			}
+			if len(resources) > 0 {
+				event.MetricSetFields["cgroup"] = cgroup
+				for k, v := range resources {
+					event.MetricSetFields[k] = v
+				}
+			}
+		}
+
+		if !report.Event(event) {
+			return nil
+		}
+	}
+
+	for name := range m.lastCPU {
+		if _, ok := seen[name]; !ok {
+			delete(m.lastCPU, name)
+		}
+	}
+	return nil
+}
+
+// unitInterface returns the D-Bus interface holding the type specific
+// properties of the unit, or an empty string for unit types without a cgroup.
+func unitInterface(name string) string {
+	switch name[strings.LastIndex(name, ".")+1:] {
+	case "service":
+		return "Service"
+	case "slice":
+		return "Slice"
+	case "scope":
+		return "Scope"
+	case "socket":
+		return "Socket"
+	case "mount":
+		return "Mount"
+	case "swap":
+		return "Swap"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,161 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build linux
+
+package units
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	mbtest "github.com/elastic/beats/v7/metricbeat/mb/testing"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-system-metrics/metric/system/cgroup/cgv2"
+)
+
+type fakeConn struct {
+	units []dbus.UnitStatus
+	props map[string]map[string]interface{}
+}
+
+func (c fakeConn) ListUnitsByPatternsContext(_ context.Context, _, _ []string) ([]dbus.UnitStatus, error) {
+	return c.units, nil
+}
+
+func (c fakeConn) GetUnitTypePropertiesContext(_ context.Context, unit, _ string) (map[string]interface{}, error) {
+	return c.props[unit], nil
+}
+
+func TestFetch(t *testing.T) {
+	ms := &MetricSet{
+		conn: fakeConn{
+			units: []dbus.UnitStatus{
+				{Name: "nginx.service", LoadState: "loaded", ActiveState: "active", SubState: "running"},
+				{Name: "user.slice", LoadState: "loaded", ActiveState: "active", SubState: "active"},
+				{Name: "multi-user.target", LoadState: "loaded", ActiveState: "active", SubState: "active"},
+				{Name: "missing.service", LoadState: "not-found", ActiveState: "inactive", SubState: "dead"},
+			},
+			props: map[string]map[string]interface{}{
+				"nginx.service": {
+					"ControlGroup": "/system.slice/nginx.service",
+					"MainPID":      uint32(1234),
+					"NRestarts":    uint32(2),
+				},
+				"user.slice": {
+					"ControlGroup": "/user.slice",
+				},
+			},
+		},
+		cgroupRoot: findCgroupV2Root(filepath.Join("_meta", "testdata", "sys", "fs", "cgroup")),
+		numCPU:     2,
+		lastCPU:    map[string]cpuSample{},
+	}
+	require.NotEmpty(t, ms.cgroupRoot)
+
+	reporter := &mbtest.CapturingReporterV2{}
+	require.NoError(t, ms.Fetch(reporter))
+	assert.Empty(t, reporter.GetErrors())
+	events := reporter.GetEvents()
+	require.Len(t, events, 3)
+
+	nginx := events[0]
+	assert.Equal(t, mapstr.M{
+		"systemd": mapstr.M{"unit": "nginx.service"},
+		"process": mapstr.M{"pid": uint32(1234)},
+	}, nginx.RootFields)
+	assert.Equal(t, mapstr.M{
+		"name":       "nginx.service",
+		"type":       "service",
+		"load_state": "loaded",
+		"state":      "active",
+		"sub_state":  "running",
+		"restarts":   uint32(2),
+		"cgroup":     "/system.slice/nginx.service",
+		"cpu": mapstr.M{
+			"usage":     mapstr.M{"ns": uint64(1500000000)},
+			"user":      mapstr.M{"ns": uint64(1000000000)},
+			"system":    mapstr.M{"ns": uint64(500000000)},
+			"throttled": mapstr.M{"periods": uint64(4), "us": uint64(20000)},
+		},
+		"memory": mapstr.M{
+			"usage": mapstr.M{"bytes": uint64(52428800)},
+			"max":   mapstr.M{"bytes": uint64(536870912)},
+			"anon":  mapstr.M{"bytes": uint64(31457280)},
+			"file":  mapstr.M{"bytes": uint64(20971520)},
+			"swap":  mapstr.M{"usage": mapstr.M{"bytes": uint64(4096)}},
+			"events": mapstr.M{
+				"high":     uint64(0),
+				"max":      uint64(3),
+				"oom":      uint64(1),
+				"oom_kill": uint64(1),
+			},
+		},
+		"io": mapstr.M{
+			"read":      mapstr.M{"bytes": uint64(1052672), "ios": uint64(65)},
+			"write":     mapstr.M{"bytes": uint64(2105344), "ios": uint64(130)},
+			"discarded": mapstr.M{"bytes": uint64(512), "ios": uint64(1)},
+		},
+		"tasks": mapstr.M{"count": uint64(5)},
+	}, nginx.MetricSetFields)
+
+	slice := events[1].MetricSetFields
+	assert.Equal(t, mapstr.M{"count": uint64(42)}, slice["tasks"])
+	assert.NotContains(t, slice, "memory")
+	assert.NotContains(t, slice, "io")
+
+	target := events[2].MetricSetFields
+	assert.Equal(t, "target", target["type"])
+	assert.NotContains(t, target, "cgroup")
+	assert.NotContains(t, target, "cpu")
+}
+
+func TestCPUPct(t *testing.T) {
+	ms := &MetricSet{numCPU: 4, lastCPU: map[string]cpuSample{}}
+	start := time.Now()
+
+	fields := ms.cpuFields("a.service", statsWithUsage(1e9), start)
+	assert.NotContains(t, fields["usage"], "pct")
+
+	fields = ms.cpuFields("a.service", statsWithUsage(3e9), start.Add(10*time.Second))
+	pct, _ := fields.GetValue("usage.pct")
+	assert.InDelta(t, 0.2, pct, 1e-9)
+	norm, _ := fields.GetValue("usage.norm.pct")
+	assert.InDelta(t, 0.05, norm, 1e-9)
+
+	// The unit was restarted and its counter reset.
+	fields = ms.cpuFields("a.service", statsWithUsage(1e6), start.Add(20*time.Second))
+	assert.NotContains(t, fields["usage"], "pct")
+}
+
+func statsWithUsage(ns uint64) cgv2.CPUStats {
+	stats := cgv2.CPUStats{}
+	stats.Usage.NS = ns
+	return stats
+}
+
+func TestFindCgroupV2Root(t *testing.T) {
+	root := filepath.Join("_meta", "testdata", "sys", "fs", "cgroup")
+	assert.Equal(t, root, findCgroupV2Root(root))
+	assert.Empty(t, findCgroupV2Root(filepath.Join(root, "user.slice")))
+}
@@ -279,7 +279,8 @@ func setupSniffer(id string, cfg config.Config, pub *publish.TransactionPublishe
 		}
 		decoders[iface.Device] = sniffer.DecodersFor(id, pub, protocols, watch, flows, cfg)
 		closers = append(closers, protocols.Close)
-		if iface.BpfFilter != "" || cfg.Flows.IsEnabled() {
+		// Detecting protocols on any port requires all traffic.
+		if iface.BpfFilter != "" || cfg.Flows.IsEnabled() || cfg.ProtocolDetection.IsEnabled() {
 			continue
 		}
 		interfaces[i].BpfFilter = protocols.BpfFilter(iface.WithVlans, icmp.Enabled())
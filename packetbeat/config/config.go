@@ -36,6 +36,7 @@ type Config struct {
 	Interface          *InterfaceConfig   `config:"interfaces"`
 	Interfaces         []InterfaceConfig  `config:"interfaces"`
 	Flows              *Flows             `config:"flows"`
+	ProtocolDetection  *ProtocolDetection `config:"protocol_detection"`
 	Protocols          map[string]*conf.C `config:"protocols"`
 	ProtocolsList      []*conf.C          `config:"protocols"`
 	Procs              procs.ProcsConfig  `config:"procs"`
@@ -147,6 +148,58 @@ type Flows struct {
 	// DeltaFlowReports when enabled will report flow network stats(bytes, packets) as delta values
 	EnableDeltaFlowReports bool `config:"enable_delta_flow_reports"`
 	AllowMismatchedEth     bool `config:"allow_mismatched_eth"`
+	// IPFIX configures the export of flow records to an IPFIX collector
+	// in addition to the flow events.
+	IPFIX *IPFIX `config:"ipfix"`
+}
+
+// IPFIX configures the export of flows as IPFIX biflow records (RFC 7011,
+// RFC 5103) over UDP.
+type IPFIX struct {
+	Enabled             bool          `config:"enabled"`
+	Host                string        `config:"host"`
+	ObservationDomainID uint32        `config:"observation_domain_id"`
+	TemplateRefresh     time.Duration `config:"template_refresh"`
+}
+
+func (c *IPFIX) IsEnabled() bool {
+	return c != nil && c.Enabled
+}
+
+func (c *IPFIX) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.Host == "" {
+		return errors.New("ipfix.host is required when IPFIX export is enabled")
+	}
+	if c.TemplateRefresh < 0 {
+		return errors.New("ipfix.template_refresh must not be negative")
+	}
+	return nil
+}
+
+// ProtocolDetection configures the payload based detection of protocols on
+// ports that are not configured for any protocol.
+type ProtocolDetection struct {
+	Enabled bool `config:"enabled"`
+	// MinConfidence is the confidence, between 0 and 1, a protocol must be
+	// detected with to be attached to a connection.
+	MinConfidence float64 `config:"min_confidence"`
+}
+
+// IsEnabled returns whether protocol detection is enabled. Detection is
+// disabled unless it is explicitly enabled, as it requires capturing all the
+// traffic.
+func (d *ProtocolDetection) IsEnabled() bool {
+	return d != nil && d.Enabled
+}
+
+func (d *ProtocolDetection) Validate() error {
+	if d.MinConfidence < 0 || d.MinConfidence > 1 {
+		return errors.New("protocol_detection.min_confidence must be between 0 and 1")
+	}
+	return nil
 }
 
 type ProtocolCommon struct {
@@ -0,0 +1,377 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package flows
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/packetbeat/config"
+)
+
+const (
+	ipfixVersion         = 10
+	ipfixHeaderLen       = 16
+	ipfixSetHeaderLen    = 4
+	ipfixTemplateSetID   = 2
+	ipfixMaxMessageSize  = 1400 // Stay below the common path MTU.
+	ipfixReversePEN      = 29305
+	ipfixEnterpriseBit   = 0x8000
+	ipfixTemplateIPv4    = 256
+	ipfixTemplateIPv6    = 257
+	defaultIPFIXRefresh  = 10 * time.Minute
+	ipfixEndIdleTimeout  = 0x01
+	ipfixEndActiveReport = 0x02
+)
+
+// IANA IPFIX information elements.
+const (
+	ieOctetDeltaCount          = 1
+	iePacketDeltaCount         = 2
+	ieProtocolIdentifier       = 4
+	ieSourceTransportPort      = 7
+	ieSourceIPv4Address        = 8
+	ieDestinationTransportPort = 11
+	ieDestinationIPv4Address   = 12
+	ieSourceIPv6Address        = 27
+	ieDestinationIPv6Address   = 28
+	ieOctetTotalCount          = 85
+	iePacketTotalCount         = 86
+	ieFlowEndReason            = 136
+	ieFlowStartMilliseconds    = 152
+	ieFlowEndMilliseconds      = 153
+)
+
+// ipfixField is a field specifier of a template. Reverse fields are the
+// RFC 5103 reverse information elements of the forward ones.
+type ipfixField struct {
+	id      uint16
+	length  uint16
+	reverse bool
+}
+
+type ipfixTemplate struct {
+	id     uint16
+	fields []ipfixField
+}
+
+func (t *ipfixTemplate) recordLen() int {
+	n := 0
+	for _, f := range t.fields {
+		n += int(f.length)
+	}
+	return n
+}
+
+// ipfixRecord is a biflow record. The forward direction is from the source
+// to the destination of the flow event.
+type ipfixRecord struct {
+	start, end       time.Time
+	srcIP, dstIP     net.IP
+	srcPort, dstPort uint16
+	protocol         uint8
+	endReason        uint8
+	octets, packets  uint64
+	revOctets        uint64
+	revPackets       uint64
+}
+
+// ipfixExporter sends the flows reported by the flows worker as IPFIX data
+// records. It is only used from the flows worker and is not safe for
+// concurrent use.
+type ipfixExporter struct {
+	conn            io.WriteCloser
+	domainID        uint32
+	templateRefresh time.Duration
+	templates       [2]*ipfixTemplate
+
+	lastTemplates time.Time
+	seq           uint32
+	pending       [2][]ipfixRecord
+}
+
+// newIPFIXExporter returns an exporter sending IPFIX messages over UDP to the
+// collector configured in cfg.
+func newIPFIXExporter(cfg *config.IPFIX, deltaCounts bool) (*ipfixExporter, error) {
+	conn, err := net.Dial("udp", cfg.Host)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to IPFIX collector %v: %w", cfg.Host, err)
+	}
+	return newIPFIXExporterConn(conn, cfg, deltaCounts), nil
+}
+
+func newIPFIXExporterConn(conn io.WriteCloser, cfg *config.IPFIX, deltaCounts bool) *ipfixExporter {
+	refresh := cfg.TemplateRefresh
+	if refresh <= 0 {
+		refresh = defaultIPFIXRefresh
+	}
+
+	// Delta counters are only accurate if Packetbeat resets its counters on
+	// each report, otherwise the running totals are exported.
+	octets, packets := uint16(ieOctetTotalCount), uint16(iePacketTotalCount)
+	if deltaCounts {
+		octets, packets = ieOctetDeltaCount, iePacketDeltaCount
+	}
+	newTemplate := func(id, srcAddr, dstAddr, addrLen uint16) *ipfixTemplate {
+		return &ipfixTemplate{id: id, fields: []ipfixField{
+			{id: ieFlowStartMilliseconds, length: 8},
+			{id: ieFlowEndMilliseconds, length: 8},
+			{id: srcAddr, length: addrLen},
+			{id: dstAddr, length: addrLen},
+			{id: ieSourceTransportPort, length: 2},
+			{id: ieDestinationTransportPort, length: 2},
+			{id: ieProtocolIdentifier, length: 1},
+			{id: ieFlowEndReason, length: 1},
+			{id: octets, length: 8},
+			{id: packets, length: 8},
+			{id: octets, length: 8, reverse: true},
+			{id: packets, length: 8, reverse: true},
+		}}
+	}
+
+	return &ipfixExporter{
+		conn:            conn,
+		domainID:        cfg.ObservationDomainID,
+		templateRefresh: refresh,
+		templates: [2]*ipfixTemplate{
+			newTemplate(ipfixTemplateIPv4, ieSourceIPv4Address, ieDestinationIPv4Address, net.IPv4len),
+			newTemplate(ipfixTemplateIPv6, ieSourceIPv6Address, ieDestinationIPv6Address, net.IPv6len),
+		},
+	}
+}
+
+// add queues the flow of a flow event for export. Events of flows without
+// IP addresses are ignored.
+func (e *ipfixExporter) add(event beat.Event, isOver bool) {
+	rec, ok := ipfixRecordFromEvent(event)
+	if !ok {
+		return
+	}
+	rec.endReason = ipfixEndActiveReport
+	if isOver {
+		rec.endReason = ipfixEndIdleTimeout
+	}
+	if rec.srcIP.To4() != nil && rec.dstIP.To4() != nil {
+		e.pending[0] = append(e.pending[0], rec)
+	} else {
+		e.pending[1] = append(e.pending[1], rec)
+	}
+}
+
+// flush sends the queued records, preceded by the templates on the first
+// call and after each template refresh interval.
+func (e *ipfixExporter) flush(now time.Time) error {
+	if len(e.pending[0]) == 0 && len(e.pending[1]) == 0 {
+		return nil
+	}
+
+	var errs []error
+	if e.lastTemplates.IsZero() || now.Sub(e.lastTemplates) >= e.templateRefresh {
+		if err := e.send(now, e.templateSet()); err != nil {
+			errs = append(errs, err)
+		} else {
+			e.lastTemplates = now
+		}
+	}
+
+	for i, t := range e.templates {
+		records := e.pending[i]
+		perMessage := (ipfixMaxMessageSize - ipfixHeaderLen - ipfixSetHeaderLen) / t.recordLen()
+		for len(records) > 0 {
+			n := min(len(records), perMessage)
+			if err := e.send(now, t.dataSet(records[:n])); err != nil {
+				errs = append(errs, err)
+			}
+			// Records are counted even if sending failed, so the collector
+			// can detect the loss.
+			e.seq += uint32(n)
+			records = records[n:]
+		}
+		e.pending[i] = e.pending[i][:0]
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes the connection to the collector.
+func (e *ipfixExporter) Close() error {
+	return e.conn.Close()
+}
+
+// send writes an IPFIX message containing the given sets.
+func (e *ipfixExporter) send(now time.Time, sets ...[]byte) error {
+	length := ipfixHeaderLen
+	for _, s := range sets {
+		length += len(s)
+	}
+	msg := make([]byte, ipfixHeaderLen, length)
+	binary.BigEndian.PutUint16(msg[0:], ipfixVersion)
+	binary.BigEndian.PutUint16(msg[2:], uint16(length))
+	binary.BigEndian.PutUint32(msg[4:], uint32(now.Unix()))
+	binary.BigEndian.PutUint32(msg[8:], e.seq)
+	binary.BigEndian.PutUint32(msg[12:], e.domainID)
+	for _, s := range sets {
+		msg = append(msg, s...)
+	}
+	_, err := e.conn.Write(msg)
+	return err
+}
+
+// templateSet returns a template set with all templates.
+func (e *ipfixExporter) templateSet() []byte {
+	set := make([]byte, ipfixSetHeaderLen)
+	for _, t := range e.templates {
+		set = binary.BigEndian.AppendUint16(set, t.id)
+		set = binary.BigEndian.AppendUint16(set, uint16(len(t.fields)))
+		for _, f := range t.fields {
+			if f.reverse {
+				set = binary.BigEndian.AppendUint16(set, f.id|ipfixEnterpriseBit)
+				set = binary.BigEndian.AppendUint16(set, f.length)
+				set = binary.BigEndian.AppendUint32(set, ipfixReversePEN)
+			} else {
+				set = binary.BigEndian.AppendUint16(set, f.id)
+				set = binary.BigEndian.AppendUint16(set, f.length)
+			}
+		}
+	}
+	binary.BigEndian.PutUint16(set[0:], ipfixTemplateSetID)
+	binary.BigEndian.PutUint16(set[2:], uint16(len(set)))
+	return set
+}
+
+// dataSet returns a data set with the records encoded using template t.
+func (t *ipfixTemplate) dataSet(records []ipfixRecord) []byte {
+	set := make([]byte, ipfixSetHeaderLen, ipfixSetHeaderLen+len(records)*t.recordLen())
+	binary.BigEndian.PutUint16(set[0:], t.id)
+	binary.BigEndian.PutUint16(set[2:], uint16(cap(set)))
+	for _, r := range records {
+		for _, f := range t.fields {
+			set = r.appendField(set, f)
+		}
+	}
+	return set
+}
+
+func (r *ipfixRecord) appendField(b []byte, f ipfixField) []byte {
+	switch f.id {
+	case ieFlowStartMilliseconds:
+		return binary.BigEndian.AppendUint64(b, uint64(r.start.UnixMilli()))
+	case ieFlowEndMilliseconds:
+		return binary.BigEndian.AppendUint64(b, uint64(r.end.UnixMilli()))
+	case ieSourceIPv4Address:
+		return append(b, r.srcIP.To4()...)
+	case ieDestinationIPv4Address:
+		return append(b, r.dstIP.To4()...)
+	case ieSourceIPv6Address:
+		return append(b, r.srcIP.To16()...)
+	case ieDestinationIPv6Address:
+		return append(b, r.dstIP.To16()...)
+	case ieSourceTransportPort:
+		return binary.BigEndian.AppendUint16(b, r.srcPort)
+	case ieDestinationTransportPort:
+		return binary.BigEndian.AppendUint16(b, r.dstPort)
+	case ieProtocolIdentifier:
+		return append(b, r.protocol)
+	case ieFlowEndReason:
+		return append(b, r.endReason)
+	case ieOctetDeltaCount, ieOctetTotalCount:
+		if f.reverse {
+			return binary.BigEndian.AppendUint64(b, r.revOctets)
+		}
+		return binary.BigEndian.AppendUint64(b, r.octets)
+	case iePacketDeltaCount, iePacketTotalCount:
+		if f.reverse {
+			return binary.BigEndian.AppendUint64(b, r.revPackets)
+		}
+		return binary.BigEndian.AppendUint64(b, r.packets)
+	}
+	return append(b, make([]byte, f.length)...)
+}
+
+// ipfixTransports maps network.transport values to IANA protocol numbers.
+var ipfixTransports = map[string]uint8{
+	"icmp":      1,
+	"tcp":       6,
+	"udp":       17,
+	"ipv6-icmp": 58,
+}
+
+// ipfixRecordFromEvent returns the biflow record of a flow event. The inner
+// addresses are used for tunneled flows.
+func ipfixRecordFromEvent(event beat.Event) (ipfixRecord, bool) {
+	var rec ipfixRecord
+	srcIP, _ := event.GetValue("source.ip")
+	dstIP, _ := event.GetValue("destination.ip")
+	rec.srcIP = innerIP(srcIP)
+	rec.dstIP = innerIP(dstIP)
+	if rec.srcIP == nil || rec.dstIP == nil {
+		return rec, false
+	}
+
+	if v, err := event.GetValue("event.start"); err == nil {
+		if t, ok := v.(common.Time); ok {
+			rec.start = time.Time(t)
+		}
+	}
+	if v, err := event.GetValue("event.end"); err == nil {
+		if t, ok := v.(common.Time); ok {
+			rec.end = time.Time(t)
+		}
+	}
+	if v, err := event.GetValue("network.transport"); err == nil {
+		if s, ok := v.(string); ok {
+			rec.protocol = ipfixTransports[s]
+		}
+	}
+	rec.srcPort = uint16Value(event, "source.port")
+	rec.dstPort = uint16Value(event, "destination.port")
+	rec.octets = uint64Value(event, "source.bytes")
+	rec.packets = uint64Value(event, "source.packets")
+	rec.revOctets = uint64Value(event, "destination.bytes")
+	rec.revPackets = uint64Value(event, "destination.packets")
+	return rec, true
+}
+
+func innerIP(v interface{}) net.IP {
+	switch v := v.(type) {
+	case string:
+		return net.ParseIP(v)
+	case []string:
+		if len(v) > 0 {
+			return net.ParseIP(v[len(v)-1])
+		}
+	}
+	return nil
+}
+
+func uint16Value(event beat.Event, key string) uint16 {
+	v, _ := event.GetValue(key)
+	n, _ := v.(uint16)
+	return n
+}
+
+func uint64Value(event beat.Event, key string) uint64 {
+	v, _ := event.GetValue(key)
+	n, _ := v.(uint64)
+	return n
+}
@@ -0,0 +1,134 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package flows
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/packetbeat/config"
+	"github.com/elastic/beats/v7/packetbeat/procs"
+)
+
+type messageRecorder struct {
+	messages [][]byte
+}
+
+func (r *messageRecorder) Write(b []byte) (int, error) {
+	r.messages = append(r.messages, append([]byte(nil), b...))
+	return len(b), nil
+}
+
+func (r *messageRecorder) Close() error { return nil }
+
+func TestIPFIXExport(t *testing.T) {
+	start := time.Unix(1542292881, 0)
+	end := start.Add(3 * time.Second)
+
+	id := newFlowID()
+	id.AddIPv4([]byte{203, 0, 113, 3}, []byte{198, 51, 100, 2})
+	id.AddTCP(38901, 80)
+	bif := &biFlow{
+		id:       id.rawFlowID,
+		createTS: start,
+		ts:       end,
+		dir:      flowDirForward,
+	}
+	bif.stats[0] = &flowStats{uintFlags: []uint8{1, 1}, uints: []uint64{10, 1}}
+	bif.stats[1] = &flowStats{uintFlags: []uint8{1, 1}, uints: []uint64{460, 2}}
+	event := createEvent(&procs.ProcessesWatcher{}, end, bif, true, nil, []string{"bytes", "packets"}, nil, false)
+
+	conn := &messageRecorder{}
+	e := newIPFIXExporterConn(conn, &config.IPFIX{Enabled: true, ObservationDomainID: 42}, false)
+	e.add(event, true)
+	require.NoError(t, e.flush(end))
+	require.Len(t, conn.messages, 2)
+
+	// The templates are sent first.
+	msg := conn.messages[0]
+	assert.Equal(t, uint16(ipfixVersion), binary.BigEndian.Uint16(msg[0:]))
+	assert.Equal(t, uint16(len(msg)), binary.BigEndian.Uint16(msg[2:]))
+	assert.Equal(t, uint32(end.Unix()), binary.BigEndian.Uint32(msg[4:]))
+	assert.Equal(t, uint32(0), binary.BigEndian.Uint32(msg[8:]))
+	assert.Equal(t, uint32(42), binary.BigEndian.Uint32(msg[12:]))
+	set := msg[ipfixHeaderLen:]
+	assert.Equal(t, uint16(ipfixTemplateSetID), binary.BigEndian.Uint16(set[0:]))
+	assert.Equal(t, uint16(len(set)), binary.BigEndian.Uint16(set[2:]))
+	assert.Equal(t, uint16(ipfixTemplateIPv4), binary.BigEndian.Uint16(set[4:]))
+	assert.Equal(t, uint16(12), binary.BigEndian.Uint16(set[6:]))
+	assert.Equal(t, uint16(ieFlowStartMilliseconds), binary.BigEndian.Uint16(set[8:]))
+
+	// The data record follows the IPv4 template.
+	msg = conn.messages[1]
+	assert.Equal(t, uint16(len(msg)), binary.BigEndian.Uint16(msg[2:]))
+	set = msg[ipfixHeaderLen:]
+	assert.Equal(t, uint16(ipfixTemplateIPv4), binary.BigEndian.Uint16(set[0:]))
+	assert.Equal(t, uint16(len(set)), binary.BigEndian.Uint16(set[2:]))
+	rec := set[ipfixSetHeaderLen:]
+	require.Len(t, rec, e.templates[0].recordLen())
+	assert.Equal(t, uint64(start.UnixMilli()), binary.BigEndian.Uint64(rec[0:]))
+	assert.Equal(t, uint64(end.UnixMilli()), binary.BigEndian.Uint64(rec[8:]))
+	assert.Equal(t, net.IP{203, 0, 113, 3}, net.IP(rec[16:20]))
+	assert.Equal(t, net.IP{198, 51, 100, 2}, net.IP(rec[20:24]))
+	assert.Equal(t, uint16(38901), binary.BigEndian.Uint16(rec[24:]))
+	assert.Equal(t, uint16(80), binary.BigEndian.Uint16(rec[26:]))
+	assert.Equal(t, uint8(6), rec[28])
+	assert.Equal(t, uint8(ipfixEndIdleTimeout), rec[29])
+	assert.Equal(t, uint64(10), binary.BigEndian.Uint64(rec[30:]))
+	assert.Equal(t, uint64(1), binary.BigEndian.Uint64(rec[38:]))
+	assert.Equal(t, uint64(460), binary.BigEndian.Uint64(rec[46:]))
+	assert.Equal(t, uint64(2), binary.BigEndian.Uint64(rec[54:]))
+
+	// Templates are not resent before the refresh interval and the sequence
+	// number counts the data records sent.
+	e.add(event, false)
+	require.NoError(t, e.flush(end.Add(time.Minute)))
+	require.Len(t, conn.messages, 3)
+	assert.Equal(t, uint32(1), binary.BigEndian.Uint32(conn.messages[2][8:]))
+	assert.Equal(t, uint8(ipfixEndActiveReport), conn.messages[2][ipfixHeaderLen+ipfixSetHeaderLen+29])
+
+	e.add(event, false)
+	require.NoError(t, e.flush(end.Add(defaultIPFIXRefresh)))
+	require.Len(t, conn.messages, 5)
+	assert.Equal(t, uint16(ipfixTemplateSetID), binary.BigEndian.Uint16(conn.messages[3][ipfixHeaderLen:]))
+}
+
+func TestIPFIXSplitMessages(t *testing.T) {
+	conn := &messageRecorder{}
+	e := newIPFIXExporterConn(conn, &config.IPFIX{Enabled: true}, true)
+	e.lastTemplates = time.Now()
+
+	rec := ipfixRecord{srcIP: net.ParseIP("2001:db8::1"), dstIP: net.ParseIP("2001:db8::2"), protocol: 17}
+	for i := 0; i < 20; i++ {
+		e.pending[1] = append(e.pending[1], rec)
+	}
+	require.NoError(t, e.flush(time.Now()))
+	require.Len(t, conn.messages, 2)
+	perMessage := (ipfixMaxMessageSize - ipfixHeaderLen - ipfixSetHeaderLen) / e.templates[1].recordLen()
+	for _, msg := range conn.messages {
+		assert.LessOrEqual(t, len(msg), ipfixMaxMessageSize)
+		assert.Equal(t, uint16(ipfixTemplateIPv6), binary.BigEndian.Uint16(msg[ipfixHeaderLen:]))
+	}
+	assert.Equal(t, uint32(perMessage), binary.BigEndian.Uint32(conn.messages[1][8:]))
+	assert.Equal(t, uint16(iePacketDeltaCount), e.templates[1].fields[9].id)
+}
@@ -126,8 +126,8 @@ func (w *worker) periodically(tick time.Duration, fn func() error) {
 // reporting intervals specified by period. If period is less than or equal to zero
 // reporting will be done at flow lifetime end.
 // Flows are published via the pub Reporter after being enriched with process information
-// by watcher.
-func newFlowsWorker(pub Reporter, watcher *procs.ProcessesWatcher, table *flowMetaTable, counters *counterReg, timeout, period time.Duration, enableDeltaFlowReports bool) (*worker, error) {
+// by watcher. If ipfix is not nil, flows are also exported as IPFIX records.
+func newFlowsWorker(pub Reporter, watcher *procs.ProcessesWatcher, table *flowMetaTable, counters *counterReg, timeout, period time.Duration, enableDeltaFlowReports bool, ipfix *ipfixExporter) (*worker, error) {
 	if timeout < time.Second {
 		return nil, ErrInvalidTimeout
 	}
@@ -166,6 +166,7 @@ func newFlowsWorker(pub Reporter, watcher *procs.ProcessesWatcher, table *flowMe
 		counters:                 counters,
 		timeout:                  timeout,
 		enableDeltaFlowReporting: enableDeltaFlowReports,
+		ipfix:                    ipfix,
 	}
 	processor.spool.init(pub, defaultBatchSize)
 
@@ -228,6 +229,7 @@ type flowsProcessor struct {
 	counters                 *counterReg
 	timeout                  time.Duration
 	enableDeltaFlowReporting bool
+	ipfix                    *ipfixExporter
 }
 
 func (fw *flowsProcessor) execute(w *worker, checkTimeout, handleReports, lastReport bool) {
@@ -280,12 +282,20 @@ func (fw *flowsProcessor) execute(w *worker, checkTimeout, handleReports, lastRe
 	}
 
 	fw.spool.flush()
+	if fw.ipfix != nil {
+		if err := fw.ipfix.flush(ts); err != nil {
+			logp.Warn("failed to export flows to IPFIX collector: %v", err)
+		}
+	}
 }
 
 func (fw *flowsProcessor) report(w *worker, ts time.Time, flow *biFlow, isOver bool, intNames, uintNames, floatNames []string) {
 	event := createEvent(fw.watcher, ts, flow, isOver, intNames, uintNames, floatNames, fw.enableDeltaFlowReporting)
 
 	debugf("add event: %v", event)
+	if fw.ipfix != nil {
+		fw.ipfix.add(event, isOver)
+	}
 	fw.spool.publish(event)
 }
 
@@ -0,0 +1,108 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package protos
+
+import "sort"
+
+// DefaultMinConfidence is the confidence a detector must report for a
+// protocol to be attached to a connection on a port that is not configured
+// for any protocol.
+const DefaultMinConfidence = 0.8
+
+// TCPDetector is an optional interface that TCP plugins can implement to
+// recognize their protocol on ports that are not configured for it.
+type TCPDetector interface {
+	// DetectTCP returns the confidence, between 0 and 1, that payload is
+	// the start of a stream of the protocol. It is called with the first
+	// payload seen in each direction of a connection.
+	DetectTCP(payload []byte) float64
+}
+
+// UDPDetector is an optional interface that UDP plugins can implement to
+// recognize their protocol on ports that are not configured for it.
+type UDPDetector interface {
+	// DetectUDP returns the confidence, between 0 and 1, that payload is a
+	// datagram of the protocol.
+	DetectUDP(payload []byte) float64
+}
+
+// Detector identifies the protocol of connections and datagrams on ports
+// that are not configured for any protocol, using the heuristics of the
+// plugins that implement TCPDetector or UDPDetector.
+type Detector struct {
+	minConfidence float64
+	tcp           []tcpDetector
+	udp           []udpDetector
+}
+
+type tcpDetector struct {
+	proto Protocol
+	TCPDetector
+}
+
+type udpDetector struct {
+	proto Protocol
+	UDPDetector
+}
+
+// NewDetector returns a Detector for the plugins in p. A protocol is only
+// reported if its confidence is at least minConfidence.
+func NewDetector(p Protocols, minConfidence float64) *Detector {
+	if minConfidence <= 0 {
+		minConfidence = DefaultMinConfidence
+	}
+	d := &Detector{minConfidence: minConfidence}
+	for proto, plugin := range p.GetAllTCP() {
+		if detector, ok := plugin.(TCPDetector); ok {
+			d.tcp = append(d.tcp, tcpDetector{proto, detector})
+		}
+	}
+	for proto, plugin := range p.GetAllUDP() {
+		if detector, ok := plugin.(UDPDetector); ok {
+			d.udp = append(d.udp, udpDetector{proto, detector})
+		}
+	}
+	// Sort the detectors so that ties are resolved consistently.
+	sort.Slice(d.tcp, func(i, j int) bool { return d.tcp[i].proto < d.tcp[j].proto })
+	sort.Slice(d.udp, func(i, j int) bool { return d.udp[i].proto < d.udp[j].proto })
+	return d
+}
+
+// DetectTCP returns the protocol of the TCP payload with the highest
+// confidence, or UnknownProtocol if no protocol reaches the minimum.
+func (d *Detector) DetectTCP(payload []byte) Protocol {
+	best, bestConfidence := UnknownProtocol, d.minConfidence
+	for _, detector := range d.tcp {
+		if c := detector.DetectTCP(payload); c >= bestConfidence && (best == UnknownProtocol || c > bestConfidence) {
+			best, bestConfidence = detector.proto, c
+		}
+	}
+	return best
+}
+
+// DetectUDP returns the protocol of the UDP payload with the highest
+// confidence, or UnknownProtocol if no protocol reaches the minimum.
+func (d *Detector) DetectUDP(payload []byte) Protocol {
+	best, bestConfidence := UnknownProtocol, d.minConfidence
+	for _, detector := range d.udp {
+		if c := detector.DetectUDP(payload); c >= bestConfidence && (best == UnknownProtocol || c > bestConfidence) {
+			best, bestConfidence = detector.proto, c
+		}
+	}
+	return best
+}
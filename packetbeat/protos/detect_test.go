@@ -0,0 +1,72 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package protos
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// detectingProtocol detects payloads starting with prefix.
+type detectingProtocol struct {
+	TCPUDPProtocol
+	prefix     []byte
+	confidence float64
+}
+
+func (proto *detectingProtocol) detect(payload []byte) float64 {
+	if bytes.HasPrefix(payload, proto.prefix) {
+		return proto.confidence
+	}
+	return 0
+}
+
+func (proto *detectingProtocol) DetectTCP(payload []byte) float64 { return proto.detect(payload) }
+func (proto *detectingProtocol) DetectUDP(payload []byte) float64 { return proto.detect(payload) }
+
+func TestDetector(t *testing.T) {
+	p := ProtocolsStruct{
+		all: map[Protocol]protocolInstance{},
+		tcp: map[Protocol]TCPPlugin{},
+		udp: map[Protocol]UDPPlugin{},
+	}
+	p.register(1, nil, &TCPProtocol{Ports: []int{80}})
+	p.register(2, nil, &detectingProtocol{prefix: []byte("A"), confidence: 0.9})
+	p.register(3, nil, &detectingProtocol{prefix: []byte("AB"), confidence: 1})
+	p.register(4, nil, &detectingProtocol{prefix: []byte("C"), confidence: 0.5})
+	p.register(5, nil, &detectingProtocol{prefix: []byte("D"), confidence: 0.9})
+	p.register(6, nil, &detectingProtocol{prefix: []byte("D"), confidence: 0.9})
+
+	d := NewDetector(p, 0)
+	assert.Equal(t, Protocol(2), d.DetectTCP([]byte("AA")))
+	// The protocol with the highest confidence wins.
+	assert.Equal(t, Protocol(3), d.DetectTCP([]byte("ABC")))
+	assert.Equal(t, Protocol(3), d.DetectUDP([]byte("ABC")))
+	// Below the minimum confidence.
+	assert.Equal(t, UnknownProtocol, d.DetectTCP([]byte("C")))
+	// Ties are resolved by protocol ID.
+	assert.Equal(t, Protocol(5), d.DetectTCP([]byte("D")))
+	assert.Equal(t, UnknownProtocol, d.DetectUDP([]byte("E")))
+
+	d = NewDetector(p, 0.5)
+	assert.Equal(t, Protocol(4), d.DetectTCP([]byte("C")))
+}
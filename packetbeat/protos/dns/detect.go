@@ -0,0 +1,43 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package dns
+
+import "encoding/binary"
+
+// DetectUDP implements protos.UDPDetector. It recognizes a datagram that
+// can be decoded as a DNS message with a single question.
+func (dns *dnsPlugin) DetectUDP(payload []byte) float64 {
+	return detectDNS(transportUDP, payload)
+}
+
+// DetectTCP implements protos.TCPDetector. It recognizes a stream starting
+// with a length prefixed DNS message with a single question.
+func (dns *dnsPlugin) DetectTCP(payload []byte) float64 {
+	if len(payload) < decodeOffset || int(binary.BigEndian.Uint16(payload)) != len(payload)-decodeOffset {
+		return 0
+	}
+	return detectDNS(transportTCP, payload)
+}
+
+func detectDNS(transp transport, payload []byte) float64 {
+	msg, err := decodeDNSData(transp, payload)
+	if err != nil || len(msg.Question) != 1 {
+		return 0
+	}
+	return 0.9
+}
@@ -0,0 +1,40 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package dns
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetect(t *testing.T) {
+	dns := &dnsPlugin{}
+	assert.Equal(t, 0.9, dns.DetectUDP(elasticA.request))
+	assert.Equal(t, 0.9, dns.DetectUDP(elasticA.response))
+	assert.Zero(t, dns.DetectUDP([]byte("GET / HTTP/1.1\r\n")))
+	assert.Zero(t, dns.DetectUDP(elasticA.request[:10]))
+
+	tcp := binary.BigEndian.AppendUint16(nil, uint16(len(elasticA.request)))
+	tcp = append(tcp, elasticA.request...)
+	assert.Equal(t, 0.9, dns.DetectTCP(tcp))
+	assert.Zero(t, dns.DetectTCP(elasticA.request))
+}
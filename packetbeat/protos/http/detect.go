@@ -0,0 +1,61 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package http
+
+import "bytes"
+
+var detectMethods = [][]byte{
+	[]byte("GET "), []byte("POST "), []byte("PUT "), []byte("DELETE "),
+	[]byte("HEAD "), []byte("OPTIONS "), []byte("PATCH "), []byte("CONNECT "),
+	[]byte("TRACE "),
+}
+
+// DetectTCP implements protos.TCPDetector. It recognizes a stream starting
+// with an HTTP/1.x request line or status line.
+func (http *httpPlugin) DetectTCP(payload []byte) float64 {
+	line, _, complete := bytes.Cut(payload, constCRLF)
+
+	// Status line: HTTP/1.1 200 OK
+	if rest, ok := bytes.CutPrefix(line, []byte("HTTP/1.")); ok {
+		if len(rest) >= 5 && (rest[0] == '0' || rest[0] == '1') && rest[1] == ' ' &&
+			isDigit(rest[2]) && isDigit(rest[3]) && isDigit(rest[4]) {
+			return 1
+		}
+		return 0
+	}
+
+	// Request line: GET /index.html HTTP/1.1
+	for _, method := range detectMethods {
+		if !bytes.HasPrefix(line, method) {
+			continue
+		}
+		if !complete {
+			// The request line may continue in the next segment.
+			return 0.5
+		}
+		if bytes.HasSuffix(line, []byte(" HTTP/1.1")) || bytes.HasSuffix(line, []byte(" HTTP/1.0")) {
+			return 1
+		}
+		return 0
+	}
+	return 0
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,46 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package http
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectTCP(t *testing.T) {
+	tests := []struct {
+		payload string
+		want    float64
+	}{
+		{"GET /index.html HTTP/1.1\r\nHost: example.com\r\n\r\n", 1},
+		{"POST /api HTTP/1.0\r\n", 1},
+		{"HTTP/1.1 404 Not Found\r\n\r\n", 1},
+		{"GET /a-very-long-path", 0.5},
+		{"GET / SPDY/3\r\n", 0},
+		{"HTTP/2.0 200 OK\r\n", 0},
+		{"SSH-2.0-OpenSSH_9.6\r\n", 0},
+		{"\x16\x03\x01\x02\x00\x01", 0},
+	}
+	http := &httpPlugin{}
+	for _, test := range tests {
+		assert.Equal(t, test.want, http.DetectTCP([]byte(test.payload)), test.payload)
+	}
+}
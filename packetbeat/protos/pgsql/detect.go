@@ -0,0 +1,39 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package pgsql
+
+// protocolVersion3 is the protocol version code of a startup message.
+const protocolVersion3 = 196608
+
+// DetectTCP implements protos.TCPDetector. It recognizes a stream starting
+// with a protocol 3.0 startup message.
+func (pgsql *pgsqlPlugin) DetectTCP(payload []byte) float64 {
+	if len(payload) < 8 {
+		return 0
+	}
+	length := readLength(payload)
+	if length < 8 || length > 10000 || readLength(payload[4:]) != protocolVersion3 {
+		return 0
+	}
+	if len(payload) >= length && payload[length-1] != 0 {
+		// Startup parameters are a list of null terminated strings ending
+		// with an empty string.
+		return 0
+	}
+	return 0.9
+}
@@ -0,0 +1,42 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package pgsql
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectTCP(t *testing.T) {
+	startup := func(params string) []byte {
+		b := binary.BigEndian.AppendUint32(nil, uint32(8+len(params)))
+		b = binary.BigEndian.AppendUint32(b, protocolVersion3)
+		return append(b, params...)
+	}
+
+	pgsql := &pgsqlPlugin{}
+	assert.Equal(t, 0.9, pgsql.DetectTCP(startup("user\x00postgres\x00database\x00test\x00\x00")))
+	assert.Zero(t, pgsql.DetectTCP(startup("user\x00postgres")))
+	// SSLRequest
+	assert.Zero(t, pgsql.DetectTCP([]byte{0, 0, 0, 8, 0x04, 0xd2, 0x16, 0x2f}))
+	assert.Zero(t, pgsql.DetectTCP([]byte("GET / HTTP/1.1\r\n")))
+}
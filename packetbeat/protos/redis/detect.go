@@ -0,0 +1,59 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package redis
+
+import "bytes"
+
+// DetectTCP implements protos.TCPDetector. It recognizes a stream starting
+// with a command sent as a RESP array of bulk strings, such as
+// "*1\r\n$4\r\nPING\r\n".
+func (redis *redisPlugin) DetectTCP(payload []byte) float64 {
+	rest, ok := bytes.CutPrefix(payload, []byte("*"))
+	if !ok {
+		return 0
+	}
+	rest, ok = cutNumberLine(rest)
+	if !ok {
+		return 0
+	}
+	if len(rest) == 0 {
+		// The first bulk string is in the next segment.
+		return 0.5
+	}
+	rest, ok = bytes.CutPrefix(rest, []byte("$"))
+	if !ok {
+		return 0
+	}
+	if _, ok = cutNumberLine(rest); !ok {
+		return 0
+	}
+	return 0.9
+}
+
+// cutNumberLine removes a positive decimal number followed by CRLF from the
+// start of b.
+func cutNumberLine(b []byte) ([]byte, bool) {
+	i := 0
+	for i < len(b) && i < 10 && b[i] >= '0' && b[i] <= '9' {
+		i++
+	}
+	if i == 0 {
+		return nil, false
+	}
+	return bytes.CutPrefix(b[i:], []byte("\r\n"))
+}
@@ -0,0 +1,45 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectTCP(t *testing.T) {
+	tests := []struct {
+		payload string
+		want    float64
+	}{
+		{"*1\r\n$4\r\nPING\r\n", 0.9},
+		{"*3\r\n$3\r\nSET\r\n$3\r\nkey\r\n$5\r\nvalue\r\n", 0.9},
+		{"*2\r\n", 0.5},
+		{"*2\r\n:1\r\n", 0},
+		{"*x\r\n", 0},
+		{"PING\r\n", 0},
+		{"+OK\r\n", 0},
+	}
+	redis := &redisPlugin{}
+	for _, test := range tests {
+		assert.Equal(t, test.want, redis.DetectTCP([]byte(test.payload)), test.payload)
+	}
+}
@@ -45,6 +45,11 @@ const (
 	TCPDirectionOriginal = 1
 )
 
+// maxDetectAttempts is the number of payloads inspected to detect the
+// protocol of a connection on an unknown port, one per direction for
+// protocols where the server speaks first.
+const maxDetectAttempts = 2
+
 type Processor interface {
 	Process(flow *flows.FlowID, hdr *layers.TCP, pkt *protos.Packet)
 }
@@ -54,6 +59,7 @@ type TCP struct {
 	streams      *common.Cache
 	portMap      map[uint16]protos.Protocol
 	protocols    protos.Protocols
+	detector     *protos.Detector
 	expiredConns expirationQueue
 
 	metrics *inputMetrics
@@ -86,6 +92,13 @@ func NewTCP(p protos.Protocols, id, device string, idx int) (*TCP, error) {
 	return tcp, nil
 }
 
+// EnableDetection makes tcp follow connections on ports that are not
+// configured for any protocol, and attach the protocol identified by d from
+// their first payloads.
+func (tcp *TCP) EnableDetection(d *protos.Detector) {
+	tcp.detector = d
+}
+
 func (tcp *TCP) removalListener(_ common.Key, value common.Value) {
 	conn, ok := value.(*TCPConnection)
 	if !ok {
@@ -200,7 +213,7 @@ func (tcp *TCP) getStream(pkt *protos.Packet) (stream TCPStream, created bool) {
 	}
 
 	protocol := tcp.decideProtocol(&pkt.Tuple)
-	if protocol == protos.UnknownProtocol {
+	if protocol == protos.UnknownProtocol && tcp.detector == nil {
 		// don't follow
 		return TCPStream{}, false
 	}
@@ -223,6 +236,7 @@ func (tcp *TCP) getStream(pkt *protos.Packet) (stream TCPStream, created bool) {
 		tuple:    &pkt.Tuple,
 		protocol: protocol,
 		tcp:      tcp,
+		detect:   protocol == protos.UnknownProtocol,
 	}
 	conn.tcptuple = common.TCPTupleFromIPPort(conn.tuple, conn.id)
 	tcp.streams.PutWithTimeout(pkt.Tuple.Hashable(), conn, timeout)
@@ -297,6 +311,11 @@ type TCPConnection struct {
 
 	lastSeq [2]uint32
 
+	// detect is set while the protocol of a connection on an unknown port
+	// is being detected.
+	detect         bool
+	detectAttempts int
+
 	// protocols private data
 	data protos.ProtocolData
 }
@@ -313,6 +332,9 @@ type TCPStream struct {
 
 func (stream *TCPStream) addPacket(pkt *protos.Packet, tcphdr *layers.TCP) {
 	conn := stream.conn
+	if conn.detect && len(pkt.Payload) > 0 {
+		conn.detectProtocol(pkt.Payload)
+	}
 	mod := conn.tcp.protocols.GetTCP(conn.protocol)
 	if mod == nil {
 		if isDebug {
@@ -332,9 +354,33 @@ func (stream *TCPStream) addPacket(pkt *protos.Packet, tcphdr *layers.TCP) {
 	}
 }
 
+// detectProtocol attaches the protocol detected from payload to conn, and
+// gives up after maxDetectAttempts payloads.
+func (conn *TCPConnection) detectProtocol(payload []byte) {
+	conn.detectAttempts++
+	protocol := conn.tcp.detector.DetectTCP(payload)
+	if protocol != protos.UnknownProtocol {
+		if isDebug {
+			logp.Debug("tcp", "Detected protocol %s on connection %s", protocol, conn.tuple)
+		}
+		conn.protocol = protocol
+		conn.detect = false
+		conn.tcp.metrics.logDetected()
+		return
+	}
+	if conn.detectAttempts >= maxDetectAttempts {
+		conn.detect = false
+	}
+}
+
 func (stream *TCPStream) gapInStream(nbytes int) (drop bool) {
 	conn := stream.conn
 	mod := conn.tcp.protocols.GetTCP(conn.protocol)
+	if mod == nil {
+		// Gaps before the protocol was detected make detection unreliable.
+		conn.detect = false
+		return false
+	}
 	conn.data, drop = mod.GapInStream(&conn.tcptuple, stream.dir, nbytes, conn.data)
 	return drop
 }
@@ -420,6 +466,7 @@ type inputMetrics struct {
 	bytes          *monitoring.Uint   // number of bytes processed
 	overlapped     *monitoring.Uint   // number of packets shrunk due to overlap
 	dropped        *monitoring.Int    // number of packets dropped because of gaps
+	detected       *monitoring.Uint   // number of connections with a detected protocol
 	arrivalPeriod  metrics.Sample     // histogram of the elapsed time between packet arrivals
 	processingTime metrics.Sample     // histogram of the elapsed time between packet receipt and publication
 }
@@ -452,6 +499,7 @@ func newInputMetrics(id, device string, ports map[uint16]protos.Protocol) *input
 		ns:             monitoring.NewUint(reg, "ns_flags_total"),
 		headers:        monitoring.NewUint(reg, "received_headers_total"),
 		dropped:        monitoring.NewInt(reg, "tcp.dropped_because_of_gaps"), // Name and type retained for compatibility.
+		detected:       monitoring.NewUint(reg, "protocols_detected_total"),
 		arrivalPeriod:  metrics.NewUniformSample(1024),
 		processingTime: metrics.NewUniformSample(1024),
 	}
@@ -550,6 +598,14 @@ func (m *inputMetrics) logDrop() {
 	m.dropped.Add(1)
 }
 
+// logDetected logs metric for a connection with a detected protocol.
+func (m *inputMetrics) logDetected() {
+	if m == nil {
+		return
+	}
+	m.detected.Add(1)
+}
+
 func (m *inputMetrics) close() {
 	if m == nil {
 		return
@@ -335,6 +335,68 @@ func TestTCSeqPayload(t *testing.T) {
 	}
 }
 
+// detectingProtocol is a TestProtocol that detects payloads starting with
+// "HELLO".
+type detectingProtocol struct {
+	TestProtocol
+}
+
+func (proto detectingProtocol) DetectTCP(payload []byte) float64 {
+	if len(payload) >= 5 && string(payload[:5]) == "HELLO" {
+		return 1
+	}
+	return 0
+}
+
+func TestDetectProtocol(t *testing.T) {
+	var state []byte
+	gap := 0
+	p := protocols{tcp: map[protos.Protocol]protos.TCPPlugin{
+		httpProtocol: detectingProtocol{TestProtocol{
+			Ports: []int{ServerPort},
+			gap:   makeCountGaps(nil, &gap),
+			parse: makeCollectPayload(&state, true),
+		}},
+	}}
+	tcp, err := NewTCP(p, "test", "test", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tcp.metrics.close()
+
+	process := func(tuple common.IPPortTuple, seq uint32, payload string) {
+		tcp.Process(nil, &layers.TCP{Seq: seq}, &protos.Packet{Ts: time.Now(), Tuple: tuple, Payload: []byte(payload)})
+	}
+	server := common.NewIPPortTuple(4, net.ParseIP(ClientIP), 40000, net.ParseIP(ServerIP), 8081)
+
+	// Connections on unknown ports are not followed without a detector.
+	process(server, 1, "HELLO")
+	assert.Empty(t, state)
+
+	tcp.EnableDetection(protos.NewDetector(p, 0))
+	process(server, 1, "HELLO")
+	process(server, 6, " WORLD")
+	assert.Equal(t, "HELLO WORLD", string(state))
+	conn := tcp.findStream(server.Hashable())
+	if assert.NotNil(t, conn) {
+		assert.Equal(t, httpProtocol, conn.protocol)
+		assert.False(t, conn.detect)
+	}
+
+	// Detection gives up after one payload in each direction.
+	state = nil
+	other := common.NewIPPortTuple(4, net.ParseIP(ClientIP), 40001, net.ParseIP(ServerIP), 8081)
+	process(other, 1, "SSH-2.0")
+	process(common.NewIPPortTuple(4, net.ParseIP(ServerIP), 8081, net.ParseIP(ClientIP), 40001), 1, "SSH-2.0")
+	process(other, 8, "HELLO")
+	assert.Empty(t, state)
+	conn = tcp.findStream(other.Hashable())
+	if assert.NotNil(t, conn) {
+		assert.Equal(t, protos.UnknownProtocol, conn.protocol)
+		assert.False(t, conn.detect)
+	}
+}
+
 // Benchmark that runs with parallelism to help find concurrency related
 // issues. To run with parallelism, the 'go test' cpu flag must be set
 // greater than 1, otherwise it just runs concurrently but not in parallel.
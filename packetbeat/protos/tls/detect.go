@@ -0,0 +1,41 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+package tls
+
+import "encoding/binary"
+
+// DetectTCP implements protos.TCPDetector. It recognizes a stream starting
+// with a handshake record containing a ClientHello or a ServerHello.
+func (plugin *tlsPlugin) DetectTCP(payload []byte) float64 {
+	if len(payload) < recordHeaderSize+1 {
+		return 0
+	}
+	if recordType(payload[0]) != recordTypeHandshake || payload[1] != 3 || payload[2] > 4 {
+		return 0
+	}
+	length := binary.BigEndian.Uint16(payload[3:])
+	if length == 0 || length > maxTLSRecordLength {
+		return 0
+	}
+	switch handshakeType(payload[recordHeaderSize]) {
+	case clientHello, serverHello:
+		return 1
+	default:
+		return 0.5
+	}
+}
@@ -0,0 +1,44 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build !integration
+
+package tls
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectTCP(t *testing.T) {
+	plugin := &tlsPlugin{}
+	for _, raw := range []string{rawClientHello, rawServerHello} {
+		payload, err := hex.DecodeString(raw)
+		require.NoError(t, err)
+		assert.Equal(t, 1.0, plugin.DetectTCP(payload))
+	}
+
+	// A handshake record with another message type.
+	assert.Equal(t, 0.5, plugin.DetectTCP([]byte{0x16, 0x03, 0x03, 0x00, 0x04, 0x0b, 0x00, 0x00, 0x00}))
+	// Not a handshake record.
+	assert.Zero(t, plugin.DetectTCP([]byte{0x17, 0x03, 0x03, 0x00, 0x04, 0x01, 0x00, 0x00, 0x00}))
+	assert.Zero(t, plugin.DetectTCP([]byte("GET / HTTP/1.1\r\n")))
+	assert.Zero(t, plugin.DetectTCP([]byte{0x16, 0x03}))
+}
@@ -43,6 +43,7 @@ type Processor interface {
 type UDP struct {
 	protocols protos.Protocols
 	portMap   map[uint16]protos.Protocol
+	detector  *protos.Detector
 
 	metrics *inputMetrics
 }
@@ -64,6 +65,12 @@ func NewUDP(p protos.Protocols, id, device string, idx int) (*UDP, error) {
 	return udp, nil
 }
 
+// EnableDetection makes udp parse datagrams on ports that are not configured
+// for any protocol with the protocol identified by d from their payload.
+func (udp *UDP) EnableDetection(d *protos.Detector) {
+	udp.detector = d
+}
+
 // buildPortsMap creates a mapping of port numbers to protocol identifiers. If
 // any two UdpProtocolPlugins operate on the same port number then an error
 // will be returned.
@@ -88,11 +95,15 @@ func buildPortsMap(plugins map[protos.Protocol]protos.UDPPlugin) (map[uint16]pro
 }
 
 // Process handles UDP packets that have been received. It attempts to
-// determine the protocol type and then invokes the associated
+// determine the protocol type from the ports, or from the payload if
+// detection is enabled, and then invokes the associated
 // UdpProtocolPlugin's ParseUDP method. If the protocol cannot be determined
 // or the payload is empty then the method is a noop.
 func (udp *UDP) Process(id *flows.FlowID, pkt *protos.Packet) {
 	protocol := udp.decideProtocol(&pkt.Tuple)
+	if protocol == protos.UnknownProtocol && udp.detector != nil && len(pkt.Payload) > 0 {
+		protocol = udp.detector.DetectUDP(pkt.Payload)
+	}
 	if protocol == protos.UnknownProtocol {
 		logp.Debug("udp", "unknown protocol")
 		return
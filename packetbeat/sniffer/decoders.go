@@ -74,6 +74,12 @@ func DecodersFor(id string, publisher *publish.TransactionPublisher, protocols *
 		if err != nil {
 			return nil, nil, err
 		}
+		if cfg.ProtocolDetection.IsEnabled() {
+			detector := protos.NewDetector(protocols, cfg.ProtocolDetection.MinConfidence)
+			tcp.EnableDetection(detector)
+			udp.EnableDetection(detector)
+		}
+
 		allowMismatchedEth := false
 		if cfg.Flows != nil {
 			allowMismatchedEth = cfg.Flows.AllowMismatchedEth
@@ -64,6 +64,7 @@ type config struct {
 	NoMoreEvents         NoMoreEventsAction `config:"no_more_events"` // Action to take when no more events are available - wait or stop.
 	EventLanguage        uint32             `config:"language"`
 	IgnoreMissingChannel *bool              `config:"ignore_missing_channel"` // Ignore missing channels and continue reading.
+	RenderWorkers        int                `config:"render_workers"`         // Number of goroutines rendering the events read in a batch.
 }
 
 // query contains parameters used to customize the event log data that is
@@ -137,5 +138,9 @@ func (c *config) Validate() error {
 		errs = append(errs, fmt.Errorf("event log is missing a 'name'"))
 	}
 
+	if c.RenderWorkers < 0 || c.RenderWorkers > maxRenderWorkers {
+		errs = append(errs, fmt.Errorf("render_workers must be between 1 and %d, or 0 for the default", maxRenderWorkers))
+	}
+
 	return errors.Join(errs...)
 }
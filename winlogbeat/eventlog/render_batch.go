@@ -0,0 +1,79 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build windows
+
+package eventlog
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/elastic/beats/v7/winlogbeat/sys/winevent"
+	win "github.com/elastic/beats/v7/winlogbeat/sys/wineventlog"
+)
+
+// maxRenderWorkers is the maximum number of render workers of an event log.
+const maxRenderWorkers = 64
+
+// renderResult is the result of rendering an event handle.
+type renderResult struct {
+	event *winevent.Event
+	xml   string
+	err   error
+}
+
+// renderBatch renders the event handles and returns the results in the
+// order of the handles, so that records keep the order in which they were
+// read from the channel. The handles are rendered concurrently by one
+// goroutine per renderer. A renderer is never used by two goroutines at the
+// same time since it reuses its buffers between calls.
+func renderBatch(renderers []win.EventRenderer, handles []win.EvtHandle) []renderResult {
+	results := make([]renderResult, len(handles))
+	render := func(r win.EventRenderer, i int) {
+		evt, xml, err := r.Render(handles[i])
+		results[i] = renderResult{event: evt, xml: xml, err: err}
+	}
+
+	workers := min(len(renderers), len(handles))
+	if workers <= 1 {
+		for i := range handles {
+			render(renderers[0], i)
+		}
+		return results
+	}
+
+	var (
+		wg   sync.WaitGroup
+		next atomic.Int64
+	)
+	for _, r := range renderers[:workers] {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				i := int(next.Add(1) - 1)
+				if i >= len(handles) {
+					return
+				}
+				render(r, i)
+			}
+		}()
+	}
+	wg.Wait()
+	return results
+}
@@ -0,0 +1,94 @@
+// Licensed to Elasticsearch B.V. under one or more contributor
+// license agreements. See the NOTICE file distributed with
+// this work for additional information regarding copyright
+// ownership. Elasticsearch B.V. licenses this file to you under
+// the Apache License, Version 2.0 (the "License"); you may
+// not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing,
+// software distributed under the License is distributed on an
+// "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY
+// KIND, either express or implied.  See the License for the
+// specific language governing permissions and limitations
+// under the License.
+
+//go:build windows
+
+package eventlog
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/winlogbeat/sys/winevent"
+	win "github.com/elastic/beats/v7/winlogbeat/sys/wineventlog"
+)
+
+// fakeRenderer renders a handle as an event with the handle as record ID.
+type fakeRenderer struct {
+	inUse    atomic.Bool
+	rendered atomic.Int64
+	overlap  atomic.Bool
+}
+
+func (r *fakeRenderer) Render(h win.EvtHandle) (*winevent.Event, string, error) {
+	if !r.inUse.CompareAndSwap(false, true) {
+		r.overlap.Store(true)
+	}
+	defer r.inUse.Store(false)
+	r.rendered.Add(1)
+
+	// Make later handles render faster to shuffle the completion order.
+	time.Sleep(time.Duration(100-h) * 10 * time.Microsecond)
+	if h%10 == 0 {
+		return nil, "", errors.New("render failed")
+	}
+	return &winevent.Event{RecordID: uint64(h)}, "", nil
+}
+
+func (r *fakeRenderer) Close() error { return nil }
+
+func TestRenderBatch(t *testing.T) {
+	handles := make([]win.EvtHandle, 0, 100)
+	for i := 1; i <= 100; i++ {
+		handles = append(handles, win.EvtHandle(i))
+	}
+
+	for _, workers := range []int{1, 4, 200} {
+		fakes := make([]*fakeRenderer, workers)
+		renderers := make([]win.EventRenderer, workers)
+		for i := range fakes {
+			fakes[i] = &fakeRenderer{}
+			renderers[i] = fakes[i]
+		}
+
+		results := renderBatch(renderers, handles)
+		require.Len(t, results, len(handles))
+		for i, result := range results {
+			h := handles[i]
+			if h%10 == 0 {
+				assert.Nil(t, result.event)
+				assert.Error(t, result.err)
+				continue
+			}
+			require.NotNil(t, result.event)
+			assert.Equal(t, uint64(h), result.event.RecordID, "results must be in the order of the handles")
+			assert.NoError(t, result.err)
+		}
+
+		var total int64
+		for _, f := range fakes {
+			assert.False(t, f.overlap.Load(), "renderer used concurrently")
+			total += f.rendered.Load()
+		}
+		assert.Equal(t, int64(len(handles)), total)
+	}
+}
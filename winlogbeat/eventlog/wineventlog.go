@@ -109,8 +109,8 @@ type winEventLog struct {
 	lastRead    checkpoint.EventLogState // Record number of the last read event.
 	log         *logp.Logger
 
-	iterator *win.EventIterator
-	renderer win.EventRenderer
+	iterator  *win.EventIterator
+	renderers []win.EventRenderer // One renderer per render worker.
 
 	metrics *inputMetrics
 
@@ -168,24 +168,31 @@ func newWinEventLog(options *conf.C) (EventLog, error) {
 		l.query = "*"
 	}
 
-	switch c.IncludeXML || l.isForwarded() {
-	case true:
-		l.renderer = win.NewXMLRenderer(
-			c.EventLanguage,
-			l.isForwarded(),
-			win.NilHandle, l.log)
-	case false:
-		l.renderer, err = win.NewRenderer(
-			c.EventLanguage,
-			win.NilHandle, l.log)
+	for i := 0; i < max(c.RenderWorkers, 1); i++ {
+		renderer, err := l.newRenderer()
 		if err != nil {
+			_ = l.closeRenderers()
 			return nil, err
 		}
+		l.renderers = append(l.renderers, renderer)
 	}
 
 	return l, nil
 }
 
+func (l *winEventLog) newRenderer() (win.EventRenderer, error) {
+	c := l.config
+	if c.IncludeXML || l.isForwarded() {
+		return win.NewXMLRenderer(
+			c.EventLanguage,
+			l.isForwarded(),
+			win.NilHandle, l.log), nil
+	}
+	return win.NewRenderer(
+		c.EventLanguage,
+		win.NilHandle, l.log)
+}
+
 func (l *winEventLog) isForwarded() bool {
 	c := l.config
 	return (c.Forwarded != nil && *c.Forwarded) || (c.Forwarded == nil && c.Name == "ForwardedEvents")
@@ -362,28 +369,40 @@ func (l *winEventLog) Read() ([]Record, error) {
 		l.metrics.log(records)
 	}()
 
-	for h, ok := l.iterator.Next(); ok; h, ok = l.iterator.Next() {
-		record, err := l.processHandle(h)
-		if err != nil {
-			if returnErr := l.handleProcessError(err); returnErr != nil {
-				return records, returnErr
-			}
-			continue
+	for len(records) < l.maxRead {
+		handles := l.nextHandles(l.maxRead - len(records))
+		if len(handles) == 0 {
+			break
 		}
-		l.resetRenderNoEventRetry()
-		// Any successfully processed event breaks a previous gap retry streak.
-		l.resetGapRetry()
-		if l.filter != nil && !l.filter.match(record) {
-			continue
-		}
-		records = append(records, *record)
 
-		// It has read the maximum requested number of events.
-		if len(records) >= l.maxRead {
-			return records, nil
+		results := renderBatch(l.renderers, handles)
+		for i, h := range handles {
+			record, err := l.processRendered(h, results[i])
+			h.Close()
+			if err != nil {
+				if returnErr := l.handleProcessError(err); returnErr != nil {
+					// The unprocessed events are read again after the
+					// reset that follows the error.
+					closeHandles(handles[i+1:])
+					return records, returnErr
+				}
+				continue
+			}
+			l.resetRenderNoEventRetry()
+			// Any successfully processed event breaks a previous gap retry streak.
+			l.resetGapRetry()
+			if l.filter != nil && !l.filter.match(record) {
+				continue
+			}
+			records = append(records, *record)
 		}
 	}
 
+	// It has read the maximum requested number of events.
+	if len(records) >= l.maxRead {
+		return records, nil
+	}
+
 	// An error occurred while retrieving more events.
 	if err := l.iterator.Err(); err != nil {
 		l.metrics.logError(err)
@@ -478,11 +497,34 @@ func (l *winEventLog) handleProcessError(err error) error {
 	return nil
 }
 
-func (l *winEventLog) processHandle(h win.EvtHandle) (*Record, error) {
-	defer h.Close()
+// nextHandles returns up to n event handles to render in a batch. Without
+// render workers, events are rendered one at a time.
+func (l *winEventLog) nextHandles(n int) []win.EvtHandle {
+	if len(l.renderers) == 1 {
+		n = 1
+	}
+	var handles []win.EvtHandle
+	for len(handles) < n {
+		h, ok := l.iterator.Next()
+		if !ok {
+			break
+		}
+		handles = append(handles, h)
+	}
+	return handles
+}
+
+func closeHandles(handles []win.EvtHandle) {
+	for _, h := range handles {
+		h.Close()
+	}
+}
 
+// processRendered returns the record of a rendered event handle. The handle
+// is owned by the caller.
+func (l *winEventLog) processRendered(h win.EvtHandle, result renderResult) (*Record, error) {
 	// NOTE: Render can return an error and a partial event.
-	evt, xml, err := l.renderer.Render(h)
+	evt, err := result.event, result.err
 	if evt == nil {
 		return nil, l.newRenderNoEventError(h, err)
 	}
@@ -495,7 +537,7 @@ func (l *winEventLog) processHandle(h win.EvtHandle) (*Record, error) {
 	}
 
 	if l.config.IncludeXML {
-		r.XML = xml
+		r.XML = result.xml
 	}
 
 	if l.file {
@@ -557,7 +599,7 @@ func (l *winEventLog) createBookmarkFromEvent(evtHandle win.EvtHandle) (string,
 
 func (l *winEventLog) Reset() error {
 	l.log.Debug("Closing event log reader handles for reset.")
-	// Only close the iterator, keep the renderer alive to avoid
+	// Only close the iterator, keep the renderers alive to avoid
 	// unnecessarily recreating render contexts. The renderer's
 	// systemContext and userContext should remain valid across
 	// session resets since they were created independently.
@@ -577,14 +619,22 @@ func (l *winEventLog) Close() error {
 
 func (l *winEventLog) close() error {
 	if l.iterator == nil {
-		return l.renderer.Close()
+		return l.closeRenderers()
 	}
 	return errors.Join(
 		l.iterator.Close(),
-		l.renderer.Close(),
+		l.closeRenderers(),
 	)
 }
 
+func (l *winEventLog) closeRenderers() error {
+	errs := make([]error, 0, len(l.renderers))
+	for _, r := range l.renderers {
+		errs = append(errs, r.Close())
+	}
+	return errors.Join(errs...)
+}
+
 func (l *winEventLog) incrementRenderNoEventRetry(bookmark string) int {
 	if bookmark == l.renderNoEventKey && l.renderNoEventCount > 0 {
 		l.renderNoEventCount++
@@ -145,6 +145,26 @@ func TestWinEventLogConfig_Validate(t *testing.T) {
 			WantErr: true,
 			Desc:    "missing name",
 		},
+		{
+			In:      config{Name: "Security", RenderWorkers: 8},
+			WantErr: false,
+			Desc:    "render workers",
+		},
+		{
+			In:      config{Name: "Security", RenderWorkers: 0},
+			WantErr: false,
+			Desc:    "default render workers",
+		},
+		{
+			In:      config{Name: "Security", RenderWorkers: -1},
+			WantErr: true,
+			Desc:    "negative render workers",
+		},
+		{
+			In:      config{Name: "Security", RenderWorkers: maxRenderWorkers + 1},
+			WantErr: true,
+			Desc:    "too many render workers",
+		},
 	}
 
 	for _, tc := range tests {
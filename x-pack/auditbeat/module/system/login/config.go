@@ -8,13 +8,17 @@ package login
 
 // config defines the metricset's configuration options.
 type config struct {
-	WtmpFilePattern string `config:"login.wtmp_file_pattern"`
-	BtmpFilePattern string `config:"login.btmp_file_pattern"`
+	WtmpFilePattern   string `config:"login.wtmp_file_pattern"`
+	BtmpFilePattern   string `config:"login.btmp_file_pattern"`
+	WtmpdbFilePattern string `config:"login.wtmpdb_file_pattern"`
+	LogindEnabled     bool   `config:"login.logind.enabled"`
 }
 
 func defaultConfig() config {
 	return config{
 		WtmpFilePattern: "/var/log/wtmp*",
 		BtmpFilePattern: "/var/log/btmp*",
+		// Written by pam_wtmpdb on distributions that replaced utmp files.
+		WtmpdbFilePattern: "/var/lib/wtmpdb/wtmp.db",
 	}
 }
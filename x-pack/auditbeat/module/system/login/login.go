@@ -72,6 +72,8 @@ type LoginRecord struct {
 	IP        *net.IP
 	Timestamp time.Time
 	Origin    string
+	// SessionID is the systemd-logind session ID.
+	SessionID string
 }
 
 func init() {
@@ -81,12 +83,15 @@ func init() {
 	)
 }
 
-// MetricSet collects login records from /var/log/wtmp.
+// MetricSet collects login records from /var/log/wtmp, wtmpdb and
+// systemd-logind.
 type MetricSet struct {
 	mb.BaseMetricSet
-	config     config
-	log        *logp.Logger
-	utmpReader *UtmpFileReader
+	config       config
+	log          *logp.Logger
+	utmpReader   *UtmpFileReader
+	wtmpdbReader *WtmpdbReader
+	logindReader *LogindReader
 }
 
 // New constructs a new MetricSet.
@@ -114,23 +119,47 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 		return nil, err
 	}
 
+	ms.wtmpdbReader, err = NewWtmpdbReader(ms.log, bucket, config.WtmpdbFilePattern)
+	if err != nil {
+		ms.utmpReader.Close()
+		return nil, err
+	}
+
+	if config.LogindEnabled {
+		ms.logindReader, err = NewLogindReader(ms.log, bucket)
+		if err != nil {
+			ms.utmpReader.Close()
+			return nil, err
+		}
+	}
+
 	return ms, nil
 }
 
 // Close cleans up the MetricSet when it finishes.
 func (ms *MetricSet) Close() error {
+	if ms.logindReader != nil {
+		if err := ms.logindReader.Close(); err != nil {
+			ms.log.Warnf("failed to close the systemd-logind connection: %v", err)
+		}
+	}
 	return ms.utmpReader.Close()
 }
 
-// Fetch collects any new login records from /var/log/wtmp. It is invoked periodically.
+// Fetch collects any new login records from /var/log/wtmp, wtmpdb and
+// systemd-logind. It is invoked periodically.
 func (ms *MetricSet) Fetch(report mb.ReporterV2) {
 	count := ms.readAndEmit(report)
+	count += ms.emitRecords(report, ms.wtmpdbReader.ReadNew)
+	if ms.logindReader != nil {
+		count += ms.emitRecords(report, ms.logindReader.ReadNew)
+	}
 
 	ms.log.Debugf("%d new login records.", count)
 
 	// Save new state to disk
 	if count > 0 {
-		err := ms.utmpReader.saveStateToDisk()
+		err := ms.saveStateToDisk()
 		if err != nil {
 			ms.log.Error(err)
 			report.Error(err)
@@ -138,6 +167,34 @@ func (ms *MetricSet) Fetch(report mb.ReporterV2) {
 	}
 }
 
+func (ms *MetricSet) saveStateToDisk() error {
+	err := ms.utmpReader.saveStateToDisk()
+	if err != nil {
+		return err
+	}
+	err = ms.wtmpdbReader.saveStateToDisk()
+	if err != nil {
+		return err
+	}
+	if ms.logindReader != nil {
+		return ms.logindReader.saveStateToDisk()
+	}
+	return nil
+}
+
+// emitRecords emits the login records returned by read and returns the
+// number of events.
+func (ms *MetricSet) emitRecords(report mb.ReporterV2, read func() ([]LoginRecord, error)) int {
+	loginRecords, err := read()
+	if err != nil {
+		ms.log.Error(err)
+	}
+	for i := range loginRecords {
+		report.Event(ms.loginEvent(&loginRecords[i]))
+	}
+	return len(loginRecords)
+}
+
 // readAndEmit reads and emits login events and returns the number of events.
 func (ms *MetricSet) readAndEmit(report mb.ReporterV2) int {
 	loginRecordC, errorC := ms.utmpReader.ReadNew()
@@ -196,7 +253,7 @@ func (ms *MetricSet) loginEvent(loginRecord *LoginRecord) mb.Event {
 		event.RootFields.Put("related.ip", []string{loginRecord.IP.String()})
 	}
 
-	if loginRecord.Hostname != "" && loginRecord.Hostname != loginRecord.IP.String() {
+	if loginRecord.Hostname != "" && (loginRecord.IP == nil || loginRecord.Hostname != loginRecord.IP.String()) {
 		event.RootFields.Put("source.domain", loginRecord.Hostname)
 	}
 
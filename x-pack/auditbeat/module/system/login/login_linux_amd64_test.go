@@ -276,6 +276,8 @@ func getBaseConfig() map[string]interface{} {
 	return map[string]interface{}{
 		"module":   system.ModuleName,
 		"datasets": []string{"login"},
+		// Don't read the wtmpdb database of the host.
+		"login.wtmpdb_file_pattern": "",
 	}
 }
 
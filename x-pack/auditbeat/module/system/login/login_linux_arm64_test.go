@@ -282,6 +282,8 @@ func getBaseConfig() map[string]interface{} {
 		"module":        system.ModuleName,
 		"datasets":      []string{"login"},
 		"logging.level": "debug",
+		// Don't read the wtmpdb database of the host.
+		"login.wtmpdb_file_pattern": "",
 	}
 }
 
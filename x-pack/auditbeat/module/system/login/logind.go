@@ -0,0 +1,327 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build linux
+
+package login
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+
+	"github.com/elastic/beats/v7/auditbeat/datastore"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	bucketKeyLogindSessions = "logind_sessions"
+
+	logindOrigin = "systemd-logind"
+
+	loginObj     = "org.freedesktop.login1"
+	loginPath    = "/org/freedesktop/login1"
+	listSessions = "org.freedesktop.login1.Manager.ListSessions"
+	getAllProps  = "org.freedesktop.DBus.Properties.GetAll"
+	sessionIface = "org.freedesktop.login1.Session"
+
+	// userClass is the class of sessions of logged in users. Greeter,
+	// lock screen and service manager sessions are ignored.
+	userClass = "user"
+)
+
+// logindSession holds the properties of a systemd-logind session.
+type logindSession struct {
+	ID         string
+	UID        uint32
+	User       string
+	Class      string
+	TTY        string
+	RemoteHost string
+	Leader     uint32
+	// Timestamp is the creation time of the session in microseconds since
+	// the epoch.
+	Timestamp uint64
+}
+
+// sessionSource lists the current systemd-logind sessions.
+type sessionSource interface {
+	Sessions() ([]logindSession, error)
+	Close() error
+}
+
+// LogindReader reports the sessions that systemd-logind opened and closed
+// between two calls to ReadNew.
+type LogindReader struct {
+	log    *logp.Logger
+	bucket datastore.Bucket
+
+	newSource func() (sessionSource, error)
+	source    sessionSource
+
+	// sessions are the known open sessions by session ID.
+	sessions map[string]LoginRecord
+}
+
+// NewLogindReader creates and initializes a new systemd-logind reader. The
+// bucket is owned by the caller.
+func NewLogindReader(log *logp.Logger, bucket datastore.Bucket) (*LogindReader, error) {
+	r := &LogindReader{
+		log:       log,
+		bucket:    bucket,
+		newSource: newDbusSessionSource,
+		sessions:  make(map[string]LoginRecord),
+	}
+
+	err := r.restoreStateFromDisk()
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore state from disk: %w", err)
+	}
+
+	return r, nil
+}
+
+// Close closes the connection to the system bus.
+func (r *LogindReader) Close() error {
+	if r.source == nil {
+		return nil
+	}
+	err := r.source.Close()
+	r.source = nil
+	return err
+}
+
+// ReadNew returns a login record for each session opened and a logout
+// record for each session closed since the last call.
+func (r *LogindReader) ReadNew() ([]LoginRecord, error) {
+	if r.source == nil {
+		source, err := r.newSource()
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to systemd-logind: %w", err)
+		}
+		r.source = source
+	}
+
+	sessions, err := r.source.Sessions()
+	if err != nil {
+		// Reconnect on the next call, systemd-logind may have been restarted.
+		_ = r.Close()
+		return nil, fmt.Errorf("failed to list systemd-logind sessions: %w", err)
+	}
+
+	now := time.Now().UTC()
+	current := make(map[string]logindSession, len(sessions))
+	for _, s := range sessions {
+		if s.Class != userClass {
+			continue
+		}
+		current[s.ID] = s
+	}
+
+	var records []LoginRecord
+
+	// Session IDs are reused after a reboot, so a known session is only the
+	// same session if it was created at the same time.
+	ids := make([]string, 0, len(r.sessions))
+	for id := range r.sessions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		saved := r.sessions[id]
+		if s, ok := current[id]; ok && sessionTimestamp(s).Equal(saved.Timestamp) {
+			continue
+		}
+		logout := saved
+		logout.Type = userLogoutRecord
+		logout.Timestamp = now
+		records = append(records, logout)
+		delete(r.sessions, id)
+	}
+
+	ids = ids[:0]
+	for id := range current {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	for _, id := range ids {
+		if _, ok := r.sessions[id]; ok {
+			continue
+		}
+		login := logindLoginRecord(current[id])
+		records = append(records, login)
+		r.sessions[id] = login
+	}
+
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Timestamp.Before(records[j].Timestamp)
+	})
+	return records, nil
+}
+
+func logindLoginRecord(s logindSession) LoginRecord {
+	record := LoginRecord{
+		Type:      userLoginRecord,
+		SessionID: s.ID,
+		PID:       int(s.Leader),
+		TTY:       s.TTY,
+		UID:       int(s.UID),
+		Username:  s.User,
+		Hostname:  s.RemoteHost,
+		Timestamp: sessionTimestamp(s),
+		Origin:    logindOrigin,
+	}
+	if s.Leader == 0 {
+		record.PID = -1
+	}
+	if ip := net.ParseIP(s.RemoteHost); ip != nil {
+		record.IP = &ip
+	}
+	return record
+}
+
+func sessionTimestamp(s logindSession) time.Time {
+	return time.UnixMicro(int64(s.Timestamp)).UTC()
+}
+
+func (r *LogindReader) saveStateToDisk() error {
+	var buf bytes.Buffer
+	encoder := gob.NewEncoder(&buf)
+
+	for _, loginRecord := range r.sessions {
+		err := encoder.Encode(loginRecord)
+		if err != nil {
+			return fmt.Errorf("error encoding systemd-logind session: %w", err)
+		}
+	}
+
+	err := r.bucket.Store(bucketKeyLogindSessions, buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("error writing systemd-logind sessions to disk: %w", err)
+	}
+
+	r.log.Debugf("Wrote %d systemd-logind sessions to disk", len(r.sessions))
+	return nil
+}
+
+func (r *LogindReader) restoreStateFromDisk() error {
+	var decoder *gob.Decoder
+	err := r.bucket.Load(bucketKeyLogindSessions, func(blob []byte) error {
+		if len(blob) > 0 {
+			decoder = gob.NewDecoder(bytes.NewBuffer(blob))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if decoder != nil {
+		for {
+			var loginRecord LoginRecord
+			err = decoder.Decode(&loginRecord)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("error decoding systemd-logind session: %w", err)
+			}
+			r.sessions[loginRecord.SessionID] = loginRecord
+		}
+	}
+	r.log.Debugf("Restored %d systemd-logind sessions from disk", len(r.sessions))
+
+	return nil
+}
+
+// dbusSessionSource lists the sessions over the system bus.
+type dbusSessionSource struct {
+	conn *dbus.Conn
+}
+
+func newDbusSessionSource() (sessionSource, error) {
+	conn, err := dbus.SystemBusPrivate()
+	if err != nil {
+		return nil, fmt.Errorf("error getting connection to system bus: %w", err)
+	}
+
+	err = conn.Auth([]dbus.Auth{dbus.AuthExternal(strconv.Itoa(os.Getuid()))})
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error authenticating: %w", err)
+	}
+
+	err = conn.Hello()
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("error in Hello: %w", err)
+	}
+
+	return &dbusSessionSource{conn: conn}, nil
+}
+
+func (s *dbusSessionSource) Close() error {
+	return s.conn.Close()
+}
+
+func (s *dbusSessionSource) Sessions() ([]logindSession, error) {
+	var list [][]interface{}
+	err := s.conn.Object(loginObj, loginPath).Call(listSessions, 0).Store(&list)
+	if err != nil {
+		return nil, fmt.Errorf("error calling ListSessions: %w", err)
+	}
+
+	sessions := make([]logindSession, 0, len(list))
+	for _, fields := range list {
+		if len(fields) < 5 {
+			return nil, fmt.Errorf("wrong number of fields in session: %v", fields)
+		}
+		path, ok := fields[4].(dbus.ObjectPath)
+		if !ok {
+			return nil, fmt.Errorf("failed to cast session path to ObjectPath")
+		}
+
+		var props map[string]dbus.Variant
+		err = s.conn.Object(loginObj, path).Call(getAllProps, 0, sessionIface).Store(&props)
+		if err != nil {
+			// The session may have been closed since it was listed.
+			continue
+		}
+		sessions = append(sessions, sessionFromProps(props))
+	}
+	return sessions, nil
+}
+
+// sessionFromProps returns the session described by the properties of a
+// org.freedesktop.login1.Session object.
+func sessionFromProps(props map[string]dbus.Variant) logindSession {
+	var s logindSession
+	get := func(name string, dst interface{}) {
+		if v, ok := props[name]; ok {
+			_ = v.Store(dst)
+		}
+	}
+	get("Id", &s.ID)
+	get("Name", &s.User)
+	get("Class", &s.Class)
+	get("TTY", &s.TTY)
+	get("RemoteHost", &s.RemoteHost)
+	get("Leader", &s.Leader)
+	get("Timestamp", &s.Timestamp)
+	var user []interface{}
+	get("User", &user)
+	if len(user) > 0 {
+		s.UID, _ = user[0].(uint32)
+	}
+	return s
+}
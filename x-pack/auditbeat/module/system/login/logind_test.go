@@ -0,0 +1,120 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build linux
+
+package login
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+type fakeSessionSource struct {
+	sessions []logindSession
+	err      error
+	closed   bool
+}
+
+func (s *fakeSessionSource) Sessions() ([]logindSession, error) { return s.sessions, s.err }
+func (s *fakeSessionSource) Close() error {
+	s.closed = true
+	return nil
+}
+
+func newTestLogindReader(t *testing.T, bucket memBucket, source *fakeSessionSource) *LogindReader {
+	r, err := NewLogindReader(logptest.NewTestingLogger(t, ""), bucket)
+	require.NoError(t, err)
+	r.newSource = func() (sessionSource, error) { return source, nil }
+	return r
+}
+
+func TestLogind(t *testing.T) {
+	start := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	ssh := logindSession{ID: "3", UID: 1000, User: "alice", Class: "user", TTY: "pts/0", RemoteHost: "10.0.0.1", Leader: 1234, Timestamp: uint64(start.UnixMicro())}
+	manager := logindSession{ID: "4", UID: 1000, User: "alice", Class: "manager", Leader: 1240, Timestamp: uint64(start.UnixMicro())}
+	source := &fakeSessionSource{sessions: []logindSession{ssh, manager}}
+	bucket := memBucket{}
+	r := newTestLogindReader(t, bucket, source)
+
+	records, err := r.ReadNew()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, userLoginRecord, records[0].Type)
+	assert.Equal(t, "alice", records[0].Username)
+	assert.Equal(t, 1000, records[0].UID)
+	assert.Equal(t, 1234, records[0].PID)
+	assert.Equal(t, "pts/0", records[0].TTY)
+	assert.Equal(t, "10.0.0.1", records[0].IP.String())
+	assert.Equal(t, start, records[0].Timestamp)
+	assert.Equal(t, logindOrigin, records[0].Origin)
+
+	records, err = r.ReadNew()
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	require.NoError(t, r.saveStateToDisk())
+
+	// The session is closed while the reader is restarted.
+	source.sessions = nil
+	r = newTestLogindReader(t, bucket, source)
+	records, err = r.ReadNew()
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, userLogoutRecord, records[0].Type)
+	assert.Equal(t, "alice", records[0].Username)
+	assert.Equal(t, 1234, records[0].PID)
+
+	// A session ID reused after a reboot is a new session.
+	source.sessions = []logindSession{ssh}
+	_, err = r.ReadNew()
+	require.NoError(t, err)
+	reused := ssh
+	reused.User = "bob"
+	reused.Timestamp = uint64(start.Add(time.Hour).UnixMicro())
+	source.sessions = []logindSession{reused}
+	records, err = r.ReadNew()
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, userLogoutRecord, records[0].Type)
+	assert.Equal(t, "alice", records[0].Username)
+	assert.Equal(t, userLoginRecord, records[1].Type)
+	assert.Equal(t, "bob", records[1].Username)
+
+	// The connection is reopened after an error.
+	source.err = errors.New("connection reset")
+	_, err = r.ReadNew()
+	require.Error(t, err)
+	assert.True(t, source.closed)
+	assert.Nil(t, r.source)
+}
+
+func TestSessionFromProps(t *testing.T) {
+	props := map[string]dbus.Variant{
+		"Id":         dbus.MakeVariant("12"),
+		"Name":       dbus.MakeVariant("alice"),
+		"User":       dbus.MakeVariant([]interface{}{uint32(1000), dbus.ObjectPath("/org/freedesktop/login1/user/_1000")}),
+		"Class":      dbus.MakeVariant("user"),
+		"TTY":        dbus.MakeVariant("pts/2"),
+		"RemoteHost": dbus.MakeVariant("192.168.1.10"),
+		"Leader":     dbus.MakeVariant(uint32(4321)),
+		"Timestamp":  dbus.MakeVariant(uint64(1700000000000000)),
+	}
+	assert.Equal(t, logindSession{
+		ID:         "12",
+		UID:        1000,
+		User:       "alice",
+		Class:      "user",
+		TTY:        "pts/2",
+		RemoteHost: "192.168.1.10",
+		Leader:     4321,
+		Timestamp:  1700000000000000,
+	}, sessionFromProps(props))
+}
@@ -0,0 +1,295 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build linux
+
+package login
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	// Register the sqlite3 database/sql driver.
+	_ "github.com/mattn/go-sqlite3"
+
+	"github.com/elastic/beats/v7/auditbeat/datastore"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const bucketKeyWtmpdbRecords = "wtmpdb_records"
+
+// Entry types of wtmpdb, see wtmpdb.h. They differ from the utmp ones.
+const (
+	wtmpdbBootTime    = 1
+	wtmpdbUserProcess = 3
+)
+
+// WtmpdbFile represents a wtmpdb database at a point in time.
+type WtmpdbFile struct {
+	Inode Inode
+	Path  string
+	// LastID is the ID of the last entry that was read.
+	LastID int64
+	// Open are the IDs of the entries read without a logout time.
+	Open []int64
+}
+
+// wtmpdbEntry is a row of the wtmp table of a wtmpdb database.
+type wtmpdbEntry struct {
+	ID         int64
+	Type       int
+	User       string
+	Login      int64
+	Logout     sql.NullInt64
+	TTY        sql.NullString
+	RemoteHost sql.NullString
+}
+
+// WtmpdbReader reads the Y2038 safe wtmpdb databases that replace the wtmp
+// file on recent distributions (usually /var/lib/wtmpdb/wtmp.db).
+type WtmpdbReader struct {
+	log         *logp.Logger
+	bucket      datastore.Bucket
+	filePattern string
+	savedFiles  map[Inode]WtmpdbFile
+}
+
+// NewWtmpdbReader creates and initializes a new wtmpdb reader. The bucket is
+// owned by the caller.
+func NewWtmpdbReader(log *logp.Logger, bucket datastore.Bucket, filePattern string) (*WtmpdbReader, error) {
+	r := &WtmpdbReader{
+		log:         log,
+		bucket:      bucket,
+		filePattern: filePattern,
+		savedFiles:  make(map[Inode]WtmpdbFile),
+	}
+
+	err := r.restoreStateFromDisk()
+	if err != nil {
+		return nil, fmt.Errorf("failed to restore state from disk: %w", err)
+	}
+
+	return r, nil
+}
+
+// ReadNew returns the logins, logouts, boots and shutdowns recorded since the
+// last call in any database matching the configured pattern.
+func (r *WtmpdbReader) ReadNew() ([]LoginRecord, error) {
+	if r.filePattern == "" {
+		return nil, nil
+	}
+	paths, err := filepath.Glob(r.filePattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to expand file pattern %v: %w", r.filePattern, err)
+	}
+	sort.Strings(paths)
+
+	var (
+		records []LoginRecord
+		errs    []error
+	)
+	existingInodes := make(map[Inode]struct{})
+	for _, path := range paths {
+		fileInfo, err := os.Stat(path)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			errs = append(errs, fmt.Errorf("unexpected error when looking up file %v: %w", path, err))
+			continue
+		}
+		inode := Inode(fileInfo.Sys().(*syscall.Stat_t).Ino) //nolint:errcheck // Always a Stat_t on Linux.
+		existingInodes[inode] = struct{}{}
+
+		saved, ok := r.savedFiles[inode]
+		if !ok {
+			r.log.Debugf("Found new wtmpdb file: %v", path)
+			saved = WtmpdbFile{Inode: inode}
+		}
+		saved.Path = path
+
+		fileRecords, saved, err := r.readNewInFile(saved)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error reading wtmpdb file %v: %w", path, err))
+			continue
+		}
+		r.savedFiles[inode] = saved
+		records = append(records, fileRecords...)
+	}
+
+	for inode := range r.savedFiles {
+		if _, exists := existingInodes[inode]; !exists {
+			r.log.Debugf("Deleting wtmpdb file record for old inode %d.", inode)
+			delete(r.savedFiles, inode)
+		}
+	}
+
+	return records, errors.Join(errs...)
+}
+
+// readNewInFile reads the entries added since LastID and the logout times of
+// the entries that were open, and returns the updated file record.
+func (r *WtmpdbReader) readNewInFile(file WtmpdbFile) ([]LoginRecord, WtmpdbFile, error) {
+	db, err := sql.Open("sqlite3", "file:"+file.Path+"?mode=ro")
+	if err != nil {
+		return nil, file, err
+	}
+	defer db.Close()
+
+	args := []interface{}{file.LastID}
+	query := "SELECT ID, Type, User, Login, Logout, TTY, RemoteHost FROM wtmp WHERE ID > ?"
+	if len(file.Open) > 0 {
+		query += " OR ID IN (?" + strings.Repeat(",?", len(file.Open)-1) + ")"
+		for _, id := range file.Open {
+			args = append(args, id)
+		}
+	}
+	rows, err := db.Query(query+" ORDER BY ID", args...)
+	if err != nil {
+		return nil, file, err
+	}
+	defer rows.Close()
+
+	wasOpen := make(map[int64]bool, len(file.Open))
+	for _, id := range file.Open {
+		wasOpen[id] = true
+	}
+
+	var (
+		records []LoginRecord
+		open    []int64
+	)
+	for rows.Next() {
+		var e wtmpdbEntry
+		err = rows.Scan(&e.ID, &e.Type, &e.User, &e.Login, &e.Logout, &e.TTY, &e.RemoteHost)
+		if err != nil {
+			return nil, file, err
+		}
+		r.log.Debugf("wtmpdb: (id=%d, type=%d, user=%v, login=%d, logout=%v, tty=%v, remote_host=%v)",
+			e.ID, e.Type, e.User, e.Login, e.Logout, e.TTY, e.RemoteHost)
+
+		if e.ID > file.LastID {
+			file.LastID = e.ID
+		}
+		if !e.Logout.Valid {
+			open = append(open, e.ID)
+		}
+		records = append(records, wtmpdbLoginRecords(e, wasOpen[e.ID], file.Path)...)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, file, err
+	}
+
+	// Open entries that were not returned have been rotated out of the
+	// database, their logout can't be reported anymore.
+	file.Open = open
+	return records, file, nil
+}
+
+// wtmpdbLoginRecords returns the login records of a wtmpdb entry. The start
+// record is skipped for entries that were already read while open.
+func wtmpdbLoginRecords(e wtmpdbEntry, wasOpen bool, origin string) []LoginRecord {
+	var startType, endType loginRecordType
+	switch e.Type {
+	case wtmpdbBootTime:
+		startType, endType = bootRecord, shutdownRecord
+	case wtmpdbUserProcess:
+		startType, endType = userLoginRecord, userLogoutRecord
+	default:
+		return nil
+	}
+
+	record := LoginRecord{
+		UID:    -1,
+		PID:    -1,
+		Origin: origin,
+	}
+	if startType == userLoginRecord {
+		record.Username = e.User
+		record.UID = lookupUsername(e.User)
+		record.TTY = e.TTY.String
+		if host := e.RemoteHost.String; host != "" {
+			record.Hostname = host
+			if ip := net.ParseIP(host); ip != nil {
+				record.IP = &ip
+			}
+		}
+	}
+
+	var records []LoginRecord
+	if !wasOpen {
+		start := record
+		start.Type = startType
+		start.Timestamp = time.UnixMicro(e.Login).UTC()
+		records = append(records, start)
+	}
+	if e.Logout.Valid {
+		end := record
+		end.Type = endType
+		end.Timestamp = time.UnixMicro(e.Logout.Int64).UTC()
+		records = append(records, end)
+	}
+	return records
+}
+
+func (r *WtmpdbReader) saveStateToDisk() error {
+	var buf bytes.Buffer
+	encoder := gob.NewEncoder(&buf)
+
+	for _, file := range r.savedFiles {
+		err := encoder.Encode(file)
+		if err != nil {
+			return fmt.Errorf("error encoding wtmpdb file record: %w", err)
+		}
+	}
+
+	err := r.bucket.Store(bucketKeyWtmpdbRecords, buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("error writing wtmpdb file records to disk: %w", err)
+	}
+
+	r.log.Debugf("Wrote %d wtmpdb file records to disk", len(r.savedFiles))
+	return nil
+}
+
+func (r *WtmpdbReader) restoreStateFromDisk() error {
+	var decoder *gob.Decoder
+	err := r.bucket.Load(bucketKeyWtmpdbRecords, func(blob []byte) error {
+		if len(blob) > 0 {
+			decoder = gob.NewDecoder(bytes.NewBuffer(blob))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if decoder != nil {
+		for {
+			var file WtmpdbFile
+			err = decoder.Decode(&file)
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			if err != nil {
+				return fmt.Errorf("error decoding wtmpdb file record: %w", err)
+			}
+			r.savedFiles[file.Inode] = file
+		}
+	}
+	r.log.Debugf("Restored %d wtmpdb file records from disk", len(r.savedFiles))
+
+	return nil
+}
@@ -0,0 +1,119 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build linux
+
+package login
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+)
+
+// memBucket is an in-memory datastore.Bucket.
+type memBucket map[string][]byte
+
+func (b memBucket) Load(key string, f func(blob []byte) error) error { return f(b[key]) }
+func (b memBucket) Store(key string, blob []byte) error {
+	b[key] = append([]byte(nil), blob...)
+	return nil
+}
+func (b memBucket) Delete(key string) error { delete(b, key); return nil }
+func (b memBucket) DeleteBucket() error     { return nil }
+func (b memBucket) Close() error            { return nil }
+
+func createWtmpdb(t *testing.T) (*sql.DB, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "wtmp.db")
+	db, err := sql.Open("sqlite3", path)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	_, err = db.Exec(`CREATE TABLE wtmp(ID INTEGER PRIMARY KEY, Type INTEGER, User TEXT NOT NULL,
+		Login INTEGER, Logout INTEGER, TTY TEXT, RemoteHost TEXT, Service TEXT)`)
+	require.NoError(t, err)
+	return db, path
+}
+
+func TestWtmpdb(t *testing.T) {
+	db, path := createWtmpdb(t)
+	boot := time.Date(2038, 1, 19, 3, 14, 8, 0, time.UTC)
+	login := boot.Add(time.Minute)
+	logout := login.Add(time.Hour)
+
+	_, err := db.Exec(`INSERT INTO wtmp(Type, User, Login, Logout, TTY, RemoteHost, Service) VALUES
+		(1, 'reboot', ?, NULL, '~', NULL, NULL),
+		(3, 'alice', ?, NULL, 'pts/0', '10.0.0.1', 'sshd'),
+		(3, 'bob', ?, NULL, 'tty1', NULL, 'login')`,
+		boot.UnixMicro(), login.UnixMicro(), login.UnixMicro())
+	require.NoError(t, err)
+
+	bucket := memBucket{}
+	r, err := NewWtmpdbReader(logptest.NewTestingLogger(t, ""), bucket, path)
+	require.NoError(t, err)
+
+	records, err := r.ReadNew()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, bootRecord, records[0].Type)
+	assert.Equal(t, boot, records[0].Timestamp)
+	assert.Equal(t, userLoginRecord, records[1].Type)
+	assert.Equal(t, "alice", records[1].Username)
+	assert.Equal(t, "pts/0", records[1].TTY)
+	assert.Equal(t, "10.0.0.1", records[1].IP.String())
+	assert.Equal(t, path, records[1].Origin)
+	assert.Equal(t, login, records[1].Timestamp)
+	assert.Equal(t, -1, records[1].PID)
+
+	records, err = r.ReadNew()
+	require.NoError(t, err)
+	assert.Empty(t, records)
+
+	// Logouts of open entries and new entries are read.
+	_, err = db.Exec(`UPDATE wtmp SET Logout = ? WHERE User = 'alice'`, logout.UnixMicro())
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO wtmp(Type, User, Login, Logout, TTY, RemoteHost, Service) VALUES
+		(3, 'carol', ?, ?, 'pts/1', 'example.com', 'sshd')`, login.UnixMicro(), logout.UnixMicro())
+	require.NoError(t, err)
+	require.NoError(t, r.saveStateToDisk())
+
+	// The state is restored from the bucket.
+	r, err = NewWtmpdbReader(logptest.NewTestingLogger(t, ""), bucket, path)
+	require.NoError(t, err)
+	records, err = r.ReadNew()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, userLogoutRecord, records[0].Type)
+	assert.Equal(t, "alice", records[0].Username)
+	assert.Equal(t, logout, records[0].Timestamp)
+	assert.Equal(t, userLoginRecord, records[1].Type)
+	assert.Equal(t, "carol", records[1].Username)
+	assert.Equal(t, "example.com", records[1].Hostname)
+	assert.Nil(t, records[1].IP)
+	assert.Equal(t, userLogoutRecord, records[2].Type)
+
+	// Entries rotated out of the database are forgotten.
+	_, err = db.Exec(`DELETE FROM wtmp WHERE User = 'bob'`)
+	require.NoError(t, err)
+	records, err = r.ReadNew()
+	require.NoError(t, err)
+	assert.Empty(t, records)
+	for _, f := range r.savedFiles {
+		assert.Equal(t, []int64{1}, f.Open)
+	}
+}
+
+func TestWtmpdbMissing(t *testing.T) {
+	r, err := NewWtmpdbReader(logptest.NewTestingLogger(t, ""), memBucket{}, filepath.Join(t.TempDir(), "wtmp.db"))
+	require.NoError(t, err)
+	records, err := r.ReadNew()
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
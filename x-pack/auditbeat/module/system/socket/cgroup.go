@@ -0,0 +1,48 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build (linux && 386) || (linux && amd64)
+
+package socket
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"regexp"
+	"strconv"
+)
+
+// cgroupRegex captures 64-character lowercase hexadecimal container IDs found in cgroup paths.
+var cgroupRegex = regexp.MustCompile(`[-/]([0-9a-f]{64})(\.scope)?$`)
+
+// procFS is the mount point of procfs. It is a variable for testing.
+var procFS = "/proc"
+
+// readContainerID returns the ID of the container the process belongs to,
+// or an empty string if it doesn't belong to a container or has exited.
+func readContainerID(pid uint32) string {
+	data, err := os.ReadFile(procFS + "/" + strconv.FormatUint(uint64(pid), 10) + "/cgroup")
+	if err != nil {
+		return ""
+	}
+	return containerIDFromCgroup(data)
+}
+
+// containerIDFromCgroup returns the container ID found in the paths of the
+// contents of a /proc/<pid>/cgroup file. Each line is of the form
+// hierarchy-ID:controller-list:cgroup-path.
+func containerIDFromCgroup(data []byte) string {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := bytes.SplitN(scanner.Bytes(), []byte(":"), 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if m := cgroupRegex.FindSubmatch(fields[2]); m != nil {
+			return string(m[1])
+		}
+	}
+	return ""
+}
@@ -0,0 +1,57 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build (linux && 386) || (linux && amd64)
+
+package socket
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testContainerID = "2a8a2f6b3e2a1cbfd0c3e7e6f4b1a5d8c9e0f1a2b3c4d5e6f7a8b9c0d1e2f3a4"
+
+func TestContainerIDFromCgroup(t *testing.T) {
+	for name, test := range map[string]struct {
+		cgroup string
+		want   string
+	}{
+		"docker v1": {
+			cgroup: "12:pids:/docker/" + testContainerID + "\n11:memory:/docker/" + testContainerID + "\n",
+			want:   testContainerID,
+		},
+		"cri-containerd v2": {
+			cgroup: "0::/kubepods.slice/kubepods-besteffort.slice/kubepods-besteffort-pod1.slice/cri-containerd-" + testContainerID + ".scope\n",
+			want:   testContainerID,
+		},
+		"systemd docker scope": {
+			cgroup: "0::/system.slice/docker-" + testContainerID + ".scope\n",
+			want:   testContainerID,
+		},
+		"host process": {
+			cgroup: "0::/user.slice/user-1000.slice/session-3.scope\n",
+		},
+		"empty": {},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, containerIDFromCgroup([]byte(test.cgroup)))
+		})
+	}
+}
+
+func TestReadContainerID(t *testing.T) {
+	defer func(prev string) { procFS = prev }(procFS)
+	procFS = t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(procFS, "1234"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(procFS, "1234", "cgroup"),
+		[]byte("0::/system.slice/docker-"+testContainerID+".scope\n"), 0o644))
+
+	assert.Equal(t, testContainerID, readContainerID(1234))
+	assert.Empty(t, readContainerID(4321))
+}
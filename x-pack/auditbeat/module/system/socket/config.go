@@ -5,6 +5,7 @@
 package socket
 
 import (
+	"errors"
 	"reflect"
 	"time"
 )
@@ -45,6 +46,11 @@ type Config struct {
 	// be generated for those packets.
 	FlowTerminationTimeout time.Duration `config:"socket.flow_termination_timeout"`
 
+	// UDPFlowWindow is the length of the aggregation windows of UDP flows.
+	// When set, an active UDP flow is reported once per window instead of
+	// only when it becomes inactive. Zero (default) disables it.
+	UDPFlowWindow time.Duration `config:"socket.udp_flow_window"`
+
 	// ClockMaxDrift defines the maximum difference between the kernel internal
 	// clock (boot time) and our reference time used to timestamp events. Once
 	// this max drift is exceeded, the reference time is adjusted.
@@ -69,6 +75,9 @@ type Config struct {
 
 // Validate validates the socket metricset config.
 func (c *Config) Validate() error {
+	if c.UDPFlowWindow < 0 {
+		return errors.New("socket.udp_flow_window can't be negative")
+	}
 	return nil
 }
 
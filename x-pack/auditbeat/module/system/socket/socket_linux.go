@@ -158,7 +158,8 @@ func (m *MetricSet) Run(r mb.PushReporterV2) {
 		m.config.FlowInactiveTimeout,
 		m.config.SocketInactiveTimeout,
 		m.config.FlowTerminationTimeout,
-		m.config.ClockMaxDrift)
+		m.config.ClockMaxDrift,
+		m.config.UDPFlowWindow)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -168,6 +168,9 @@ type flow struct {
 	local, remote     endpoint
 	complete          bool
 	done              bool
+	// partial is set for the reports of the aggregation windows of a flow
+	// that is still active.
+	partial bool
 	// these are automatically calculated by state from kernelTimes above
 	createdTime, lastSeenTime time.Time
 }
@@ -219,6 +222,9 @@ type process struct {
 	// populated after createdTime is adjusted.
 	entityID string
 
+	// populated from the cgroup of the process.
+	containerID string
+
 	// populated by DNS enrichment.
 	resolvedDomains map[string]string
 }
@@ -363,6 +369,7 @@ type state struct {
 	// configuration
 	inactiveTimeout, closeTimeout, socketTimeout time.Duration
 	clockMaxDrift                                time.Duration
+	udpFlowWindow                                time.Duration
 
 	// lru used for flow expiration.
 	flowLRU helper.LinkedList
@@ -403,8 +410,9 @@ var kernelProcess = process{
 	name: "[kernel_task]",
 }
 
-func NewState(r mb.PushReporterV2, log helper.Logger, inactiveTimeout, socketTimeout, closeTimeout, clockMaxDrift time.Duration) *state {
+func NewState(r mb.PushReporterV2, log helper.Logger, inactiveTimeout, socketTimeout, closeTimeout, clockMaxDrift, udpFlowWindow time.Duration) *state {
 	s := makeState(r, log, inactiveTimeout, socketTimeout, closeTimeout, clockMaxDrift)
+	s.udpFlowWindow = udpFlowWindow
 	go s.expireLoop()
 	go s.logStateLoop()
 	return s
@@ -533,6 +541,11 @@ func (s *state) CreateProcess(p *process) error {
 	if p.pid == 0 {
 		return errors.New("can't create process with PID 0")
 	}
+	if p.containerID == "" {
+		// Read before locking, the process may be short-lived and its
+		// flows reported after it exited.
+		p.containerID = readContainerID(p.pid)
+	}
 	s.Lock()
 	defer s.Unlock()
 	s.processes[p.pid] = p
@@ -564,6 +577,7 @@ func (s *state) ForkProcess(parentPID, childPID uint32, ts kernelTime) error {
 			egid:        parent.egid,
 			hasCreds:    parent.hasCreds,
 			createdTime: s.kernTimestampToTime(ts),
+			containerID: parent.containerID,
 		}
 		child.resolvedDomains = make(map[string]string, len(parent.resolvedDomains))
 		for k, v := range parent.resolvedDomains {
@@ -793,6 +807,11 @@ func (s *state) UpdateFlow(ref flow) error {
 // existing flow. The optional condition must be met before an existing flow is
 // updated. Otherwise the update is ignored.
 func (s *state) UpdateFlowWithCondition(ref flow, cond func(*flow) bool) error {
+	var toReport helper.LinkedList
+	// Send flows to the output as a deferred function to avoid
+	// holding on s mutex when there's backpressure from the output.
+	defer s.reportFlows(&toReport)
+
 	s.Lock()
 	defer s.Unlock()
 	ref.createdTime = s.kernTimestampToTime(ref.created)
@@ -813,6 +832,9 @@ func (s *state) UpdateFlowWithCondition(ref flow, cond func(*flow) bool) error {
 	if cond != nil && !cond(prev) {
 		return nil
 	}
+	if s.udpFlowWindow > 0 && prev.proto == protoUDP && ref.lastSeenTime.Sub(prev.createdTime) >= s.udpFlowWindow {
+		toReport.Add(prev.closeWindow(ref.lastSeenTime))
+	}
 	s.mutualEnrich(sock, &ref)
 	prev.updateWith(ref, s)
 	s.enrichDNS(prev)
@@ -834,6 +856,21 @@ func (s *state) enrichDNS(f *flow) {
 	}
 }
 
+// closeWindow returns a copy of the flow for its current aggregation window,
+// and starts a new window at start. UDP flows can be active indefinitely, so
+// they are reported periodically instead of only when they expire.
+func (f *flow) closeWindow(start time.Time) *flow {
+	window := new(flow)
+	*window = *f
+	window.prev, window.next = nil, nil
+	window.partial = true
+
+	f.createdTime = start
+	f.local.packets, f.local.bytes = 0, 0
+	f.remote.packets, f.remote.bytes = 0, 0
+	return window
+}
+
 func (f *flow) updateWith(ref flow, s *state) {
 	f.lastSeenTime = ref.lastSeenTime
 	if ref.inetType != f.inetType {
@@ -869,7 +906,7 @@ func (f *flow) updateWith(ref flow, s *state) {
 
 func (s *state) reportFlow(f *flow) (reported bool) {
 	if f != nil && f.isValid() && int(f.pid) != s.currentPID {
-		if ev, err := f.toEvent(true); err == nil {
+		if ev, err := f.toEvent(!f.partial); err == nil {
 			reported = s.reporter.Event(ev)
 		} else {
 			s.log.Errorf("Failed to convert flow=%v err=%v", f, err)
@@ -1022,6 +1059,9 @@ func (f *flow) toEvent(final bool) (ev mb.Event, err error) {
 			if f.process.entityID != "" {
 				process["entity_id"] = f.process.entityID
 			}
+			if f.process.containerID != "" {
+				rootPut("container.id", f.process.containerID)
+			}
 
 			if f.process.hasCreds {
 				uid := strconv.Itoa(int(f.process.uid))
@@ -863,6 +863,70 @@ func TestSocketReuse(t *testing.T) {
 	assert.Len(t, flows, 1)
 }
 
+func TestUDPFlowWindow(t *testing.T) {
+	const (
+		localIP            = "192.168.33.10"
+		remoteIP           = "172.19.12.13"
+		localPort          = 38842
+		remotePort         = 443
+		sock       uintptr = 0xff1234
+	)
+	st := makeTestingState(t, time.Hour, time.Hour, 0, time.Hour)
+	st.udpFlowWindow = 10
+	lPort, rPort := be16(localPort), be16(remotePort)
+	lAddr, rAddr := ipv4(localIP), ipv4(remoteIP)
+	send := func(ts uint64) event {
+		return &udpSendMsgCall{
+			Meta:     meta(1234, 1235, ts),
+			Sock:     sock,
+			Size:     123,
+			LAddr:    lAddr,
+			AltRAddr: rAddr,
+			LPort:    lPort,
+			AltRPort: rPort,
+		}
+	}
+	st.feedEvents([]event{
+		&inetCreate{Meta: meta(1234, 1235, 5), Proto: 0},
+		&sockInitData{Meta: meta(1234, 1235, 5), Sock: sock},
+		send(6),
+		send(10),
+		send(15),
+	})
+	assert.Empty(t, st.getFlows())
+
+	// The first packet after the window closes the window.
+	st.feedEvents([]event{
+		send(20),
+		send(25),
+	})
+	flows := st.getFlows()
+	require.Len(t, flows, 1)
+	for field, expected := range map[string]interface{}{
+		"source.packets":    uint64(3),
+		"source.bytes":      uint64(453),
+		"network.transport": "udp",
+		"flow.final":        false,
+	} {
+		assertValue(t, flows[0], expected, field)
+	}
+
+	st.feedEvents([]event{
+		&inetReleaseCall{Meta: meta(1234, 1235, 30), Sock: sock},
+	})
+	st.ExpireFlows()
+	flows = st.getFlows()
+	require.Len(t, flows, 1)
+	for field, expected := range map[string]interface{}{
+		"source.packets": uint64(2),
+		"source.bytes":   uint64(302),
+		"flow.final":     true,
+	} {
+		assertValue(t, flows[0], expected, field)
+	}
+	assertValue(t, flows[0], int64(5), "event.duration")
+}
+
 func TestProcessDNSRace(t *testing.T) {
 	p := new(process)
 	var wg sync.WaitGroup
@@ -771,6 +771,57 @@ func (db *DB) ScrapeProcfs() []uint32 {
 	return pids
 }
 
+// ScrapeProcess adds the process with the given PID to the DB from procfs,
+// along with its parent, process group leader and session leader, and their
+// own relatives, if they are not known yet. It is used to build the process
+// tree on demand for events that don't come with exec events.
+func (db *DB) ScrapeProcess(pid uint32) error {
+	db.mutex.Lock()
+	defer db.mutex.Unlock()
+
+	var procs []procfs.ProcessInfo
+	seen := map[uint32]bool{}
+	queue := []uint32{pid}
+	for len(queue) > 0 {
+		next := queue[0]
+		queue = queue[1:]
+		if next == 0 || seen[next] {
+			continue
+		}
+		seen[next] = true
+		if _, ok := db.processes[next]; ok {
+			continue
+		}
+
+		procInfo, err := db.procfs.GetProcess(next)
+		if err != nil {
+			if next == pid {
+				db.stats.procfsLookupFail.Add(1)
+				return fmt.Errorf("failed to get process %d from procfs: %w", pid, err)
+			}
+			db.logger.Debugf("failed to get relative %d of process %d from procfs: %v", next, pid, err)
+			continue
+		}
+		procs = append(procs, procInfo)
+		queue = append(queue, procInfo.PIDs.Ppid, procInfo.PIDs.Pgid, procInfo.PIDs.Sid)
+	}
+
+	// insert relatives first, so that entry leaders can be evaluated
+	for i := len(procs) - 1; i >= 0; i-- {
+		procInfo := procs[i]
+		db.insertProcess(Process{
+			PIDs:       pidInfoFromProto(procInfo.PIDs),
+			Creds:      credInfoFromProto(procInfo.Creds),
+			CTTY:       ttyDevFromProto(procInfo.CTTY),
+			Argv:       procInfo.Argv,
+			Cwd:        procInfo.Cwd,
+			Filename:   procInfo.Filename,
+			insertTime: time.Now(),
+		})
+	}
+	return nil
+}
+
 func stringStartsWithEntryInList(str string, list []string) bool {
 	for _, entry := range list {
 		if strings.HasPrefix(str, entry) {
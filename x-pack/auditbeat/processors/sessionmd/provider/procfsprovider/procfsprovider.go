@@ -48,11 +48,19 @@ func (p prvdr) GetProcess(pid uint32) (*types.Process, error) {
 }
 
 // Sync updates the process information database using on the syscall event data and by scraping procfs.
+// Events without syscall data, such as those of log sources that only report a PID, add the process and its
+// ancestors to the database from procfs when they are not known yet.
 // As process information will not be available in procfs after a process has exited, the provider is susceptible to missing information in short-lived events.
 func (p prvdr) Sync(ev *beat.Event, pid uint32) error {
 	syscall, err := ev.GetValue(syscallField)
 	if err != nil {
-		return fmt.Errorf("event not supported, no syscall data")
+		if p.db.HasProcess(pid) {
+			return nil
+		}
+		if err := p.db.ScrapeProcess(pid); err != nil {
+			return fmt.Errorf("scrape process: %w", err)
+		}
+		return nil
 	}
 
 	switch syscall {
@@ -558,3 +558,57 @@ func TestSetSidSessionLeaderNotScraped(t *testing.T) {
 
 	require.Equal(t, expected.PIDs.Sid, actual.SessionLeader.PID)
 }
+
+func TestEventWithoutSyscall(t *testing.T) {
+	var pid uint32 = 100
+	event := beat.Event{
+		Timestamp: timestamp,
+		Fields: mapstr.M{
+			"message": "session opened for user root",
+			"process": mapstr.M{
+				"pid": "100",
+			},
+		},
+	}
+	procinfo := []procfs.ProcessInfo{
+		{PIDs: types.PIDInfo{Tid: 1, Tgid: 1, Pgid: 1, Sid: 1}, Filename: "/sbin/init"},
+		{PIDs: types.PIDInfo{Tid: 60, Tgid: 60, Ppid: 1, Pgid: 60, Sid: 60}, Filename: "/usr/sbin/sshd"},
+		{PIDs: types.PIDInfo{Tid: 80, Tgid: 80, Ppid: 60, Pgid: 80, Sid: 60}, Filename: "/bin/bash"},
+		{PIDs: types.PIDInfo{Tid: 100, Tgid: 100, Ppid: 80, Pgid: 100, Sid: 60}, Filename: "/usr/bin/sudo"},
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute*15)
+	defer cancel()
+	reader := procfs.NewMockReader()
+	db, err := processdb.NewDB(ctx, monitoring.NewRegistry(), reader, logger, time.Second*30, false)
+	require.NoError(t, err)
+	for _, entry := range procinfo {
+		reader.AddEntry(entry.PIDs.Tgid, entry)
+	}
+
+	provider, err := NewProvider(context.TODO(), logger, db, reader, "process.pid")
+	require.NoError(t, err, "error creating provider")
+
+	err = provider.Sync(&event, pid)
+	require.NoError(t, err)
+
+	actual, err := db.GetProcess(pid)
+	require.NoError(t, err, "pid not found in db")
+	require.Equal(t, pid, actual.PID)
+	require.Equal(t, uint32(80), actual.Parent.PID)
+	require.Equal(t, "/bin/bash", actual.Parent.Executable)
+	require.Equal(t, uint32(60), actual.SessionLeader.PID)
+	require.Equal(t, "/usr/sbin/sshd", actual.SessionLeader.Executable)
+
+	// known processes are not scraped again
+	reader.AddEntry(pid, procfs.ProcessInfo{PIDs: types.PIDInfo{Tid: 100, Tgid: 100, Ppid: 1}})
+	err = provider.Sync(&event, pid)
+	require.NoError(t, err)
+	actual, err = db.GetProcess(pid)
+	require.NoError(t, err)
+	require.Equal(t, uint32(80), actual.Parent.PID)
+
+	// unknown processes are reported
+	err = provider.Sync(&event, 200)
+	require.Error(t, err)
+}
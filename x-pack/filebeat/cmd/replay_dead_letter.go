@@ -0,0 +1,111 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	cfg "github.com/elastic/beats/v7/filebeat/config"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/cmd/instance"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/awscloudwatch"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// genReplayDeadLetterCmd returns the command queueing the log events of the
+// dead letter stores of the aws-cloudwatch inputs to be published again.
+func genReplayDeadLetterCmd(settings instance.Settings) *cobra.Command {
+	var timeout time.Duration
+	cmd := &cobra.Command{
+		Use:   "replay-dead-letter [id]",
+		Short: "Replay the dead-lettered events of the aws-cloudwatch inputs",
+		Long: "Queue the log events written to the dead letter store of the aws-cloudwatch inputs, or of the input\n" +
+			"with the given id, to be published again. The running inputs publish the queued log events and\n" +
+			"remove them once they are acknowledged by the outputs.",
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			b, err := instance.NewInitializedBeat(settings)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error initializing beat: %s\n", err)
+				os.Exit(1)
+			}
+			beatConfig, err := b.BeatConfig()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading configuration: %s\n", err)
+				os.Exit(1)
+			}
+			config := cfg.DefaultConfig
+			if err := beatConfig.Unpack(&config); err != nil {
+				fmt.Fprintf(os.Stderr, "Error reading configuration: %s\n", err)
+				os.Exit(1)
+			}
+
+			var id string
+			if len(args) != 0 {
+				id = args[0]
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			if err := replayDeadLetters(ctx, os.Stdout, b.Info, config.Inputs, id); err != nil {
+				fmt.Fprintf(os.Stderr, "Error replaying dead letters: %s\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	cmd.Flags().DurationVar(&timeout, "timeout", 5*time.Minute, "Timeout for receiving the log events of the dead letter queues")
+	return cmd
+}
+
+// replayDeadLetters queues the log events of the dead letter stores of the
+// aws-cloudwatch inputs with dead_letter enabled, or of the input with the
+// given id if it is not empty, writing the results to w.
+func replayDeadLetters(ctx context.Context, w io.Writer, info beat.Info, inputs []*conf.C, id string) error {
+	log := info.Logger
+	if log == nil {
+		log = logp.NewNopLogger()
+	}
+
+	found := false
+	for _, c := range inputs {
+		settings := struct {
+			ID         string `config:"id"`
+			Type       string `config:"type"`
+			DeadLetter struct {
+				Enabled bool `config:"enabled"`
+			} `config:"dead_letter"`
+		}{}
+		if err := c.Unpack(&settings); err != nil {
+			return fmt.Errorf("failed to read input configuration: %w", err)
+		}
+		if settings.Type != "aws-cloudwatch" || (id != "" && settings.ID != id) {
+			continue
+		}
+		if id == "" && !settings.DeadLetter.Enabled {
+			continue
+		}
+		found = true
+
+		n, err := awscloudwatch.RequestReplay(ctx, c, info.Paths, log.Named("dead_letter"))
+		if err != nil {
+			return fmt.Errorf("aws-cloudwatch input %q: %w", settings.ID, err)
+		}
+		fmt.Fprintf(w, "aws-cloudwatch input %q: %d log events queued for replay\n", settings.ID, n)
+	}
+	if !found {
+		if id != "" {
+			return fmt.Errorf("no aws-cloudwatch input with id %q is configured", id)
+		}
+		return errors.New("no aws-cloudwatch input with dead_letter enabled is configured")
+	}
+	return nil
+}
@@ -10,7 +10,8 @@ import (
 	// Import packages to perform 'func InitializeModule()' when in-use.
 	m0 "github.com/elastic/beats/v7/x-pack/filebeat/processors/add_nomad_metadata"
 	m1 "github.com/elastic/beats/v7/x-pack/filebeat/processors/aws_vpcflow"
-	m2 "github.com/elastic/beats/v7/x-pack/filebeat/processors/decode_cef"
+	m2 "github.com/elastic/beats/v7/x-pack/filebeat/processors/correlate_suricata_files"
+	m3 "github.com/elastic/beats/v7/x-pack/filebeat/processors/decode_cef"
 
 	// Import packages that perform 'func init()'.
 	_ "github.com/elastic/beats/v7/x-pack/filebeat/input/awscloudwatch"
@@ -65,4 +66,5 @@ func InitializeModule() {
 	m0.InitializeModule()
 	m1.InitializeModule()
 	m2.InitializeModule()
+	m3.InitializeModule()
 }
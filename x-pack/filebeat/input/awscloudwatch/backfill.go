@@ -0,0 +1,54 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"time"
+)
+
+// backfillConfig configures the split of the large time ranges of a log
+// group, such as its backfill from start_position: beginning, into time
+// slices that are read in parallel by the workers.
+type backfillConfig struct {
+	// SliceDuration is the duration of the slices, 0 to read the time
+	// ranges with a single worker.
+	SliceDuration time.Duration `config:"slice_duration" validate:"min=0"`
+	// MaxSlices bounds the number of slices of a time range. The first
+	// slice covers the history older than MaxSlices-1 slices.
+	MaxSlices int `config:"max_slices" validate:"min=1"`
+}
+
+// slices returns the works reading the log group from startTime to endTime.
+// A range longer than backfill.slice_duration is split into slices ending
+// every slice_duration before endTime, which are handed to the workers in
+// order. The slices before the last one are registered in the state handler
+// so that the checkpoint of the log group only advances when all the earlier
+// slices are complete. The last one, ending at endTime, must be registered
+// by the caller with the scan of the other log groups.
+func (p *cloudwatchPoller) slices(lg logGroup, startTime, endTime time.Time) []workResponse {
+	d := p.config.Backfill.SliceDuration
+	if d <= 0 || endTime.Sub(startTime) <= d {
+		return []workResponse{{logGroupId: lg.id, startTime: startTime, endTime: endTime, svc: lg.svc, region: lg.region, accountID: lg.accountID}}
+	}
+
+	n := min(int((endTime.Sub(startTime)+d-1)/d), p.config.Backfill.MaxSlices)
+	works := make([]workResponse, n)
+	sliceEnd := endTime
+	for i := n - 1; i >= 0; i-- {
+		sliceStart := sliceEnd.Add(-d)
+		if i == 0 {
+			sliceStart = startTime
+		}
+		works[i] = workResponse{logGroupId: lg.id, startTime: sliceStart, endTime: sliceEnd, svc: lg.svc, region: lg.region, accountID: lg.accountID}
+		sliceEnd = sliceStart
+	}
+	for _, work := range works[:n-1] {
+		p.stateHandler.WorkRegisterLogGroups(work.endTime.UnixMilli(), []string{lg.id})
+	}
+	p.metrics.backfillSlicesTotal.Add(uint64(n))
+	p.log.Debugw("Split the time range of the log group into slices", "log_group", lg.id,
+		"start_time", startTime, "end_time", endTime, "slices", n)
+	return works
+}
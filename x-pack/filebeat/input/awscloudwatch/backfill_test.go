@@ -0,0 +1,111 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestSlices(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.LogGroupNamePrefix = "prefix"
+	cfg.Backfill.SliceDuration = time.Hour
+	cfg.Backfill.MaxSlices = 3
+	handler, err := newStateHandler(nil, cfg, createTestInputStore())
+	require.NoError(t, err)
+	p := &cloudwatchPoller{
+		config:       cfg,
+		log:          logp.NewLogger("test"),
+		metrics:      newInputMetrics(monitoring.NewRegistry()),
+		stateHandler: handler,
+	}
+
+	end := time.Unix(1792152000, 0)
+	lg := logGroup{id: "a"}
+
+	// A range shorter than a slice is not split.
+	assert.Equal(t, []workResponse{
+		{logGroupId: "a", startTime: end.Add(-time.Hour), endTime: end},
+	}, p.slices(lg, end.Add(-time.Hour), end))
+
+	assert.Equal(t, []workResponse{
+		{logGroupId: "a", startTime: end.Add(-150 * time.Minute), endTime: end.Add(-2 * time.Hour)},
+		{logGroupId: "a", startTime: end.Add(-2 * time.Hour), endTime: end.Add(-time.Hour)},
+		{logGroupId: "a", startTime: end.Add(-time.Hour), endTime: end},
+	}, p.slices(lg, end.Add(-150*time.Minute), end))
+
+	// The first slice covers the history beyond max_slices.
+	assert.Equal(t, []workResponse{
+		{logGroupId: "a", startTime: time.Unix(0, 0), endTime: end.Add(-2 * time.Hour)},
+		{logGroupId: "a", startTime: end.Add(-2 * time.Hour), endTime: end.Add(-time.Hour)},
+		{logGroupId: "a", startTime: end.Add(-time.Hour), endTime: end},
+	}, p.slices(lg, time.Unix(0, 0), end))
+	assert.Equal(t, uint64(6), p.metrics.backfillSlicesTotal.Get())
+}
+
+func TestReceiveBackfillSlices(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t1 := time.Unix(1792152000, 0)
+	cfg := defaultConfig()
+	cfg.LogGroupNamePrefix = "prefix"
+	cfg.StartPosition = beginning
+	cfg.ScanFrequency = time.Hour
+	cfg.Backfill.SliceDuration = 24 * time.Hour
+	cfg.Backfill.MaxSlices = 3
+
+	handler, err := newStateHandler(nil, cfg, createTestInputStore())
+	require.NoError(t, err)
+	p := &cloudwatchPoller{
+		workRequestChan:  make(chan struct{}),
+		workResponseChan: make(chan workResponse),
+		log:              logp.NewLogger("test"),
+		metrics:          newInputMetrics(monitoring.NewRegistry()),
+		stateHandler:     handler,
+		config:           cfg,
+	}
+	clock := &clock{time: t1}
+	go p.receive(ctx, []string{"a"}, clock.now)
+
+	// The slices are handed to the workers as they ask for work.
+	var works []workResponse
+	for i := 0; i < 3; i++ {
+		p.workRequestChan <- struct{}{}
+		works = append(works, <-p.workResponseChan)
+	}
+	assert.Equal(t, []workResponse{
+		{logGroupId: "a", startTime: time.Unix(0, 0), endTime: t1.Add(-48 * time.Hour)},
+		{logGroupId: "a", startTime: t1.Add(-48 * time.Hour), endTime: t1.Add(-24 * time.Hour)},
+		{logGroupId: "a", startTime: t1.Add(-24 * time.Hour), endTime: t1},
+	}, works)
+
+	checkpoint := func() int64 {
+		// pause for backgroundRunner to run
+		<-time.After(100 * time.Millisecond)
+		state, _, err := handler.GetLogGroupState("a")
+		require.NoError(t, err)
+		return state.LastSyncEpoch
+	}
+
+	// The checkpoint only advances when all the earlier slices are done.
+	handler.WorkCompleteLogGroup("a", works[2].endTime.UnixMilli())
+	handler.WorkCompleteLogGroup("a", works[1].endTime.UnixMilli())
+	assert.Equal(t, int64(0), checkpoint())
+	handler.WorkCompleteLogGroup("a", works[0].endTime.UnixMilli())
+	assert.Equal(t, t1.UnixMilli(), checkpoint())
+
+	state, err := handler.GetState()
+	require.NoError(t, err)
+	assert.Equal(t, t1.UnixMilli(), state.LastSyncEpoch)
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/apihealth"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/monitoring"
 )
@@ -26,6 +27,48 @@ type cloudwatchPoller struct {
 	stateHandler *stateHandler
 	status       status.StatusReporter
 
+	// organization enumerates the log groups of the member accounts of an
+	// AWS Organization, nil if organization.enabled is not set.
+	organization *orgEnumerator
+
+	// discovery selects the log groups by name patterns and tags, nil if
+	// discovery.enabled is not set.
+	discovery *discoveryEnumerator
+
+	// crossAccount enumerates the log groups of the accounts and regions
+	// of cross_account, nil if it is not set.
+	crossAccount *crossAccountEnumerator
+
+	// exporter reads the large time ranges from export tasks to S3, nil if
+	// export.enabled is not set.
+	exporter *logExporter
+
+	// classes holds the classes of the log groups, nil in organization
+	// mode.
+	classes *logGroupClasses
+
+	// liveTail streams the log events of the log groups with Live Tail
+	// sessions, nil if mode is not live_tail.
+	liveTail *liveTailer
+
+	// health tracks the API health of the log groups, nil if
+	// api_health.enabled is not set. Its events are published
+	// with healthClient.
+	health       *apihealth.Tracker
+	healthClient beat.Client
+
+	// deadLetter stores the log events that are not acknowledged in time,
+	// nil if dead_letter.enabled is not set.
+	deadLetter deadLetterWriter
+
+	// coordinator partitions the log groups between the instances running
+	// the input, nil if coordination.enabled is not set.
+	coordinator *coordinator
+
+	// limiter limits the rate of the FilterLogEvents requests of all the
+	// workers.
+	limiter *adaptiveLimiter
+
 	workersListingMap    *sync.Map
 	workersProcessingMap *sync.Map
 
@@ -43,8 +86,22 @@ type cloudwatchPoller struct {
 type workResponse struct {
 	logGroupId         string
 	startTime, endTime time.Time
+	// svc is the client of the account owning the log group, nil to use
+	// the client of the worker.
+	svc *cloudwatchlogs.Client
+	// region and accountID identify the region and the account of the
+	// log group when they are not the ones of the input.
+	region    string
+	accountID string
+	// nextToken is the FilterLogEvents token of the page the reading
+	// resumes from, empty to read the time range from its start.
+	nextToken string
 }
 
+// resumeTokenTTL is the time after which the FilterLogEvents pagination
+// tokens expire.
+const resumeTokenTTL = 24 * time.Hour
+
 func newCloudwatchPoller(log *logp.Logger, metrics *inputMetrics, awsRegion string, config config, stateHandler *stateHandler, reporter status.StatusReporter) *cloudwatchPoller {
 	if metrics == nil {
 		metrics = newInputMetrics(monitoring.NewRegistry())
@@ -70,77 +127,306 @@ func newCloudwatchPoller(log *logp.Logger, metrics *inputMetrics, awsRegion stri
 }
 
 func (p *cloudwatchPoller) startWorkers(ctx context.Context, svc *cloudwatchlogs.Client, pipeline beat.Pipeline) error {
+	p.limiter = newAdaptiveLimiter(p.config.rateLimit(), p.metrics, p.log.Named("rate_limiter"))
+	drainCtx, cancel := drainContext(ctx, p.config.ShutdownTimeout)
+	// The workers share the recently published log events, since the
+	// overlapping windows of a log group can be read by distinct workers.
+	var dedup *eventDeduplicator
+	if p.config.Deduplication.Enabled {
+		var err error
+		dedup, err = newEventDeduplicator(p.config.Deduplication.CacheSize)
+		if err != nil {
+			cancel()
+			return err
+		}
+	}
+	// The processors of the log_group_overrides are shared by the workers,
+	// and closed once they all stopped.
+	overrideProcs, err := newOverrideProcessors(p.config.LogGroupOverrides, p.log)
+	if err != nil {
+		cancel()
+		return err
+	}
 	for i := 0; i < p.config.NumberOfWorkers; i++ {
-		worker, err := newCWWorker(p.config, p.region, p.metrics, p.status, svc, pipeline, p.log)
+		worker, err := newCWWorker(p.config, p.region, p.metrics, p.status, svc, pipeline, p.deadLetter, overrideProcs, p.log)
 		if err != nil {
+			cancel()
+			// The processors are closed once the started workers stop.
+			go func() {
+				p.workerWg.Wait()
+				overrideProcs.close()
+			}()
 			return fmt.Errorf("failed to create worker %d: %w", i, err)
 		}
+		worker.health = p.health
+		worker.processor.dedup = dedup
+		worker.exporter = p.exporter
+		worker.classes = p.classes
+		worker.limiter = p.limiter
 		p.workerWg.Add(1)
 		go func(wrk *cwWorker) {
 			defer p.workerWg.Done()
-			wrk.Start(ctx, p.workRequestChan, p.workResponseChan, p.stateHandler)
+			wrk.Start(ctx, drainCtx, p.workRequestChan, p.workResponseChan, p.stateHandler)
 		}(worker)
 	}
+	go func() {
+		p.workerWg.Wait()
+		overrideProcs.close()
+		cancel()
+	}()
 
 	return nil
 }
 
+// drainContext returns the context of the work of the workers, done timeout
+// after ctx is done, so they can drain the work in progress when the input
+// stops. It is ctx if timeout is zero.
+func drainContext(ctx context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return ctx, func() {}
+	}
+	drainCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		timer := time.AfterFunc(timeout, cancel)
+		context.AfterFunc(drainCtx, func() { timer.Stop() })
+	})
+	return drainCtx, func() {
+		stop()
+		cancel()
+	}
+}
+
 // receive implements the main run loop that distributes tasks to the worker
 // goroutines. It accepts a "clock" callback (which on a live input should
 // equal time.Now) to allow deterministic unit tests.
 func (p *cloudwatchPoller) receive(ctx context.Context, logGroupIDs []string, clock func() time.Time) {
 	defer p.workerWg.Wait()
 
-	// startTime and endTime are the bounds of the current scanning interval.
-	endTime := clock().Add(-p.config.Latency)
-
-	var startTime time.Time
-	// If we're starting at the end of the logs, advance the start time to the most recent scan window
-	if p.config.StartPosition == end {
-		startTime = endTime.Add(-p.config.ScanFrequency)
-	}
-
-	if p.config.StartPosition == beginning {
-		startTime = time.Unix(0, 0)
-	}
+	// The scanning interval of a log group ends at now minus its latency,
+	// and starts where its previous one ended, at prev minus its latency.
+	// The log groups whose scan_frequency is overridden with a longer one
+	// are only scanned once their interval is long enough.
+	tick := p.config.minScanFrequency()
+	now := clock()
+	var prev time.Time
 
-	if p.config.StartPosition == lastSync {
-		state, err := p.stateHandler.GetState()
-		if err != nil {
-			p.log.Errorf("error retrieving state from stateHandler: %v, falling back to %s", err, beginning)
-			startTime = time.Unix(0, 0)
-		} else {
-			startTime = time.UnixMilli(state.LastSyncEpoch)
+	// seen holds the log groups whose checkpoint was already looked up.
+	seen := map[string]bool{}
+	// next holds the start of the next scanning interval of the log groups
+	// that are not scanned every tick.
+	next := map[string]time.Time{}
+	for ctx.Err() == nil {
+		logGroups := p.logGroups(ctx, logGroupIDs)
+		var acquired map[string]time.Time
+		if p.coordinator != nil {
+			logGroups, acquired = p.coordinator.claim(ctx, logGroups)
+		}
+		// The log groups are registered by the end of their scanning
+		// interval.
+		var (
+			due      []logGroup
+			endTimes []int64
+			ids      = map[int64][]string{}
+		)
+		for _, lg := range logGroups {
+			endTime := now.Add(-p.config.latency(lg.id))
+			_, claimed := acquired[lg.id]
+			if start, ok := next[lg.id]; ok && !claimed && endTime.Sub(start) < p.config.scanFrequency(lg.id) {
+				continue
+			}
+			due = append(due, lg)
+			ms := endTime.UnixMilli()
+			if _, ok := ids[ms]; !ok {
+				endTimes = append(endTimes, ms)
+			}
+			ids[ms] = append(ids[ms], lg.id)
+		}
+		for _, ms := range endTimes {
+			p.stateHandler.WorkRegisterLogGroups(ms, ids[ms])
 		}
-	}
 
-	for ctx.Err() == nil {
-		p.stateHandler.WorkRegister(endTime.UnixMilli(), len(logGroupIDs))
-
-		for _, lg := range logGroupIDs {
-			select {
-			case <-ctx.Done():
-				return
-			case <-p.workRequestChan:
-				p.workResponseChan <- workResponse{
-					logGroupId: lg,
-					startTime:  startTime,
-					endTime:    endTime,
+		for _, lg := range due {
+			endTime := now.Add(-p.config.latency(lg.id))
+			lgStartTime := prev.Add(-p.config.latency(lg.id))
+			if start, ok := next[lg.id]; ok {
+				lgStartTime = start
+			}
+			var works []workResponse
+			if checkpoint, ok := acquired[lg.id]; ok && !checkpoint.IsZero() {
+				// The log group is collected from where the instance
+				// that held its lease before stopped.
+				seen[lg.id] = true
+				lgStartTime = checkpoint
+				if lgStartTime.After(endTime) {
+					lgStartTime = endTime
+				}
+			} else if !seen[lg.id] {
+				seen[lg.id] = true
+				if prev.IsZero() {
+					lgStartTime = p.startTime(endTime, p.config.scanFrequency(lg.id))
+				}
+				lgStartTime = p.checkpoint(lg.id, lgStartTime, endTime)
+				if work, ok := p.resumeWork(lg, lgStartTime, endTime); ok {
+					works = append(works, work)
+					lgStartTime = work.endTime
+				}
+			}
+			if p.config.scanFrequency(lg.id) > tick {
+				next[lg.id] = endTime
+			}
+
+			// The slices of a log group are read by several workers in
+			// parallel.
+			works = append(works, p.slices(lg, lgStartTime, endTime)...)
+			lgMetrics := p.metrics.logGroup(lg.id)
+			for _, work := range works {
+				lgMetrics.windowScheduled(work.startTime)
+			}
+			for _, work := range works {
+				select {
+				case <-ctx.Done():
+					return
+				case <-p.workRequestChan:
+					p.workResponseChan <- work
 				}
 			}
 		}
 
-		// Delay for ScanFrequency after finishing a time span
-		p.log.Debugf("sleeping for %v before checking new logs", p.config.ScanFrequency)
+		p.publishHealth()
+
+		// Delay for the shortest scan frequency after finishing a time span
+		p.log.Debugf("sleeping for %v before checking new logs", tick)
 		select {
-		case <-time.After(p.config.ScanFrequency):
+		case <-time.After(tick):
 		case <-ctx.Done():
 		}
 		p.log.Debug("done sleeping")
+		p.metrics.updateWorkerUtilization(p.config.NumberOfWorkers)
 
 		// Advance to the next time span
-		startTime, endTime = endTime, clock().Add(-p.config.Latency)
+		prev, now = now, clock()
+	}
+}
+
+// startTime returns the start of the first scan interval, ending at endTime,
+// according to the start position. scanFrequency is the time between the
+// scans.
+func (p *cloudwatchPoller) startTime(endTime time.Time, scanFrequency time.Duration) time.Time {
+	switch p.config.StartPosition {
+	case end:
+		// If we're starting at the end of the logs, advance the start time to the most recent scan window
+		return endTime.Add(-scanFrequency)
+	case lastSync:
+		state, err := p.stateHandler.GetState()
+		if err != nil {
+			p.log.Errorf("error retrieving state from stateHandler: %v, falling back to %s", err, beginning)
+			return time.Unix(0, 0)
+		}
+		return time.UnixMilli(state.LastSyncEpoch)
+	default:
+		return time.Unix(0, 0)
+	}
+}
+
+// checkpoint returns the time to start the first scan of a log group from.
+// Log groups that were checkpointed by a previous run resume from their
+// checkpoint, whatever the start position, so that restarts don't cause gaps
+// or duplicates. Other log groups start from startTime.
+func (p *cloudwatchPoller) checkpoint(logGroupID string, startTime, endTime time.Time) time.Time {
+	state, found, err := p.stateHandler.GetLogGroupState(logGroupID)
+	if err != nil {
+		p.log.Errorw("Failed to read the checkpoint of the log group, using the start position",
+			"log_group", logGroupID, "start_position", p.config.StartPosition, "error", err)
+		return startTime
+	}
+	if !found {
+		return startTime
+	}
+	checkpoint := time.UnixMilli(state.LastSyncEpoch)
+	if checkpoint.After(endTime) {
+		checkpoint = endTime
+	}
+	p.log.Debugw("Resuming log group from its checkpoint", "log_group", logGroupID, "checkpoint", checkpoint)
+	return checkpoint
+}
+
+// resumeWork returns the work reading the rest of the scan of the log group
+// interrupted by the last shutdown of the input, if the scan starts at
+// startTime, ends before endTime and its pagination token has not expired.
+func (p *cloudwatchPoller) resumeWork(lg logGroup, startTime, endTime time.Time) (workResponse, bool) {
+	rp, found, err := p.stateHandler.TakeResumePoint(lg.id)
+	if err != nil {
+		p.log.Errorw("Failed to read the resume point of the log group, reading it from its checkpoint",
+			"log_group", lg.id, "error", err)
+		return workResponse{}, false
+	}
+	if !found || rp.StartTime != startTime.UnixMilli() || rp.EndTime >= endTime.UnixMilli() ||
+		time.Since(time.UnixMilli(rp.SavedAt)) >= resumeTokenTTL {
+		return workResponse{}, false
+	}
+	p.stateHandler.WorkRegisterLogGroups(rp.EndTime, []string{lg.id})
+	p.log.Debugw("Resuming log group from where it stopped at shutdown", "log_group", lg.id,
+		"start_time", startTime, "end_time", time.UnixMilli(rp.EndTime))
+	return workResponse{
+		logGroupId: lg.id,
+		startTime:  startTime,
+		endTime:    time.UnixMilli(rp.EndTime),
+		svc:        lg.svc,
+		region:     lg.region,
+		accountID:  lg.accountID,
+		nextToken:  rp.NextToken,
+	}, true
+}
+
+// publishHealth publishes the API health status events if they are due.
+func (p *cloudwatchPoller) publishHealth() {
+	if p.healthClient == nil {
+		return
+	}
+	//nolint:errcheck // beat.Client.Publish does not return an error.
+	p.health.PublishDue(func(e beat.Event) error {
+		p.healthClient.Publish(e)
+		return nil
+	})
+}
+
+// logGroups returns the log groups to poll during the next scan interval.
+// Log groups added to the organization or discovered by a refresh are
+// polled from the start of the interval they were found in.
+func (p *cloudwatchPoller) logGroups(ctx context.Context, logGroupIDs []string) []logGroup {
+	if p.organization != nil {
+		logGroups := p.organization.logGroups(ctx)
+		p.metrics.logGroupsTotal.Set(uint64(len(logGroups)))
+		return logGroups
+	}
+	if p.discovery != nil {
+		logGroups := p.withoutSkipped(p.discovery.logGroups(ctx))
+		p.metrics.logGroupsTotal.Set(uint64(len(logGroups)))
+		return logGroups
+	}
+	if p.crossAccount != nil {
+		logGroups := p.crossAccount.logGroups(ctx)
+		p.metrics.logGroupsTotal.Set(uint64(len(logGroups)))
+		return logGroups
+	}
+
+	logGroups := make([]logGroup, 0, len(logGroupIDs))
+	for _, id := range logGroupIDs {
+		logGroups = append(logGroups, logGroup{id: id})
+	}
+	return p.withoutSkipped(logGroups)
+}
+
+// withoutSkipped drops the log groups whose class doesn't support
+// FilterLogEvents.
+func (p *cloudwatchPoller) withoutSkipped(logGroups []logGroup) []logGroup {
+	kept := make([]logGroup, 0, len(logGroups))
+	for _, lg := range logGroups {
+		if !p.classes.isSkipped(lg.id) {
+			kept = append(kept, lg)
+		}
 	}
+	return kept
 }
 
 // unixMsFromTime converts time to unix milliseconds.
@@ -10,8 +10,10 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
 )
 
 type clock struct {
@@ -188,6 +190,7 @@ func TestReceive(t *testing.T) {
 			// decided on its output
 			workResponseChan: make(chan workResponse),
 			log:              logp.NewLogger("test"),
+			metrics:          newInputMetrics(monitoring.NewRegistry()),
 			stateHandler:     handler,
 		}
 
@@ -213,3 +216,135 @@ func TestReceive(t *testing.T) {
 		cancel()
 	}
 }
+
+func TestReceiveFromCheckpoint(t *testing.T) {
+	t0 := time.Unix(0, 0)
+	t1 := time.Unix(1792152000, 0)
+	checkpoint := t1.Add(-time.Hour)
+
+	for _, startPosition := range []string{beginning, end, lastSync} {
+		t.Run(startPosition, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			cfg := defaultConfig()
+			cfg.LogGroupNamePrefix = "prefix"
+			cfg.StartPosition = startPosition
+			cfg.ScanFrequency = time.Microsecond
+
+			handler, err := newStateHandler(nil, cfg, createTestInputStore())
+			assert.NoError(t, err)
+			handler.WorkRegisterLogGroups(checkpoint.UnixMilli(), []string{"a"})
+			handler.WorkCompleteLogGroup("a", checkpoint.UnixMilli())
+			assert.Eventually(t, func() bool {
+				_, found, err := handler.GetLogGroupState("a")
+				return err == nil && found
+			}, time.Second, 10*time.Millisecond)
+
+			p := &cloudwatchPoller{
+				workRequestChan:  make(chan struct{}),
+				workResponseChan: make(chan workResponse),
+				log:              logp.NewLogger("test"),
+				metrics:          newInputMetrics(monitoring.NewRegistry()),
+				stateHandler:     handler,
+				config:           cfg,
+			}
+			clock := &clock{time: t1}
+			go p.receive(ctx, []string{"a", "b"}, clock.now)
+
+			var defaultStart time.Time
+			switch startPosition {
+			case beginning:
+				defaultStart = t0
+			case end:
+				defaultStart = t1.Add(-cfg.ScanFrequency)
+			case lastSync:
+				// The input state was stored with the checkpoint of a.
+				defaultStart = checkpoint
+			}
+			for _, expected := range []workResponse{
+				// The checkpointed log group resumes from its checkpoint.
+				{logGroupId: "a", startTime: checkpoint, endTime: t1},
+				// The other log groups use the start position.
+				{logGroupId: "b", startTime: defaultStart, endTime: t1},
+			} {
+				p.workRequestChan <- struct{}{}
+				assert.Equal(t, expected, <-p.workResponseChan)
+			}
+		})
+	}
+}
+
+func TestReceiveFromResumePoint(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	t1 := time.Now().Truncate(time.Millisecond)
+	checkpoint := t1.Add(-time.Hour)
+	stopped := t1.Add(-30 * time.Minute)
+
+	cfg := defaultConfig()
+	cfg.LogGroupNamePrefix = "prefix"
+	cfg.ScanFrequency = time.Microsecond
+
+	handler, err := newStateHandler(nil, cfg, createTestInputStore())
+	require.NoError(t, err)
+	handler.WorkRegisterLogGroups(checkpoint.UnixMilli(), []string{"a", "b"})
+	handler.WorkCompleteLogGroup("a", checkpoint.UnixMilli())
+	handler.WorkCompleteLogGroup("b", checkpoint.UnixMilli())
+	assert.Eventually(t, func() bool {
+		_, found, err := handler.GetLogGroupState("b")
+		return err == nil && found
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, handler.SetResumePoint("a", resumePoint{
+		StartTime: checkpoint.UnixMilli(),
+		EndTime:   stopped.UnixMilli(),
+		NextToken: "token",
+		SavedAt:   t1.UnixMilli(),
+	}))
+	// The pagination tokens expire.
+	require.NoError(t, handler.SetResumePoint("b", resumePoint{
+		StartTime: checkpoint.UnixMilli(),
+		EndTime:   stopped.UnixMilli(),
+		NextToken: "token",
+		SavedAt:   t1.Add(-resumeTokenTTL).UnixMilli(),
+	}))
+
+	p := &cloudwatchPoller{
+		workRequestChan:  make(chan struct{}),
+		workResponseChan: make(chan workResponse),
+		log:              logp.NewLogger("test"),
+		metrics:          newInputMetrics(monitoring.NewRegistry()),
+		stateHandler:     handler,
+		config:           cfg,
+	}
+	clock := &clock{time: t1}
+	go p.receive(ctx, []string{"a", "b"}, clock.now)
+
+	for _, expected := range []workResponse{
+		// The rest of the interrupted scan is read from its next page.
+		{logGroupId: "a", startTime: checkpoint, endTime: stopped, nextToken: "token"},
+		{logGroupId: "a", startTime: stopped, endTime: t1},
+		{logGroupId: "b", startTime: checkpoint, endTime: t1},
+	} {
+		p.workRequestChan <- struct{}{}
+		assert.Equal(t, expected, <-p.workResponseChan)
+	}
+}
+
+func TestDrainContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	drainCtx, release := drainContext(ctx, 0)
+	release()
+	assert.Equal(t, ctx, drainCtx, "the work is not drained without a timeout")
+
+	drainCtx, release = drainContext(ctx, 50*time.Millisecond)
+	defer release()
+	cancel()
+	assert.NoError(t, drainCtx.Err(), "the work is drained after the input stopped")
+	assert.Eventually(t, func() bool { return drainCtx.Err() != nil }, time.Second, 10*time.Millisecond)
+
+	drainCtx, release = drainContext(context.Background(), time.Hour)
+	release()
+	assert.Error(t, drainCtx.Err(), "the context is released with the workers")
+}
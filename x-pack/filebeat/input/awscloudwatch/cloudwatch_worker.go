@@ -8,29 +8,52 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/aws/smithy-go"
 	"github.com/aws/smithy-go/transport/http"
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/common/acker"
 	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/apihealth"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
 
+// maxCredentialsRetries is the number of times a page of log events is
+// requested again after a credentials error.
+const maxCredentialsRetries = 3
+
+// errStopped is returned when the reading of a log group stops because the
+// input is shutting down.
+var errStopped = errors.New("input stopped")
+
 type cwWorker struct {
-	client    beat.Client
-	config    config
-	log       *logp.Logger
-	metrics   *inputMetrics
-	processor *logProcessor
-	region    string
-	status    status.StatusReporter
-	svc       *cloudwatchlogs.Client
-	tracker   *ackTracker
+	classes    *logGroupClasses
+	client     beat.Client
+	config     config
+	deadLetter deadLetterWriter
+	exporter   *logExporter
+	health     *apihealth.Tracker
+	limiter    *adaptiveLimiter
+	log        *logp.Logger
+	metrics    *inputMetrics
+	processor  *logProcessor
+	region     string
+	status     status.StatusReporter
+	svc        *cloudwatchlogs.Client
+	tracker    *ackTracker
+
+	// deadLetterClient publishes the events with dead_letter.enabled, nil
+	// otherwise. completions are the works completed in the background once
+	// their events are acknowledged.
+	deadLetterClient *deadLetterClient
+	completions      sync.WaitGroup
 }
 
 func newCWWorker(cfg config,
@@ -39,37 +62,69 @@ func newCWWorker(cfg config,
 	status status.StatusReporter,
 	svc *cloudwatchlogs.Client,
 	pipeline beat.Pipeline,
+	deadLetter deadLetterWriter,
+	overrideProcs overrideProcessors,
 	log *logp.Logger) (*cwWorker, error) {
 
 	cw := &cwWorker{
-		config:  cfg,
-		region:  region,
-		metrics: metrics,
-		status:  status,
-		svc:     svc,
-		log:     log,
+		config:     cfg,
+		deadLetter: deadLetter,
+		region:     region,
+		metrics:    metrics,
+		status:     status,
+		svc:        svc,
+		log:        log,
 	}
 
 	tracker := newACKTracker()
-	client, err := pipeline.ConnectWith(beat.ClientConfig{
+	clientConfig := beat.ClientConfig{
 		EventListener: acker.TrackingCounter(func(_ int, by int) {
 			tracker.increaseAck(by)
 		}),
-	})
+	}
+	// With dead_letter.enabled, the events are dropped instead of waiting
+	// when the queue is full, so the log events that can't be published
+	// during output outages are written to the dead letter store.
+	if deadLetter != nil {
+		cw.deadLetterClient = newDeadLetterClient(cfg.DeadLetter.ACKTimeout)
+		clientConfig = beat.ClientConfig{
+			PublishMode:    beat.DropIfFull,
+			EventListener:  cw.deadLetterClient,
+			ClientListener: cw.deadLetterClient,
+		}
+	}
+	client, err := pipeline.ConnectWith(clientConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create pipeline client: %w", err)
 	}
 
 	cw.client = client
-	cw.processor = newLogProcessor(log, metrics, client)
+	var publisher beat.Client = client
+	if cw.deadLetterClient != nil {
+		cw.deadLetterClient.Client = client
+		publisher = cw.deadLetterClient
+	}
+	cw.processor = newLogProcessor(log, metrics, publisher, cfg.EventMapping)
+	cw.processor.deadLetter = cw.deadLetterClient != nil
+	cw.processor.overrides = cfg.LogGroupOverrides
+	cw.processor.overrideProcs = overrideProcs
+	if cfg.Mode == modeLiveTail {
+		cw.processor.derivedIDs = &derivedIDs{}
+	}
 	cw.tracker = tracker
 	return cw, nil
 }
 
 // Start the CloudWatch worker that requests and wait for work. Contains blocking operations, hence must be called concurrently.
-func (w *cwWorker) Start(ctx context.Context, workReq chan struct{}, workRsp chan workResponse, handler *stateHandler) {
+// When ctx is done, the worker stops requesting work, finishes the page of log events it is reading, waits for their
+// acknowledgement and stores where the reading of the log group stopped, as long as drainCtx is not done.
+func (w *cwWorker) Start(ctx, drainCtx context.Context, workReq chan struct{}, workRsp chan workResponse, handler *stateHandler) {
 	defer w.client.Close()
 	defer w.tracker.close()
+	defer w.completions.Wait()
+	if w.deadLetterClient != nil {
+		w.deadLetterClient.stop = drainCtx.Done()
+	}
 
 	for {
 		var work workResponse
@@ -80,26 +135,189 @@ func (w *cwWorker) Start(ctx context.Context, workReq chan struct{}, workRsp cha
 			work = <-workRsp
 		}
 
+		svc := w.svc
+		if work.svc != nil {
+			svc = work.svc
+		}
+
+		var pending *pendingWork
+		if w.deadLetterClient != nil {
+			pending = newPendingWork()
+			w.deadLetterClient.work = pending
+		}
+
 		w.log.Infof("aws-cloudwatch input worker for log group: '%v' has started", work.logGroupId)
-		workedCount := w.run(ctx, work.logGroupId, work.startTime, work.endTime)
+		w.metrics.beginWork()
+		workedCount, resume := w.run(drainCtx, ctx.Done(), svc, work)
+		w.metrics.endWork()
 		w.log.Infof("aws-cloudwatch input worker for log group '%v' has completed.", work.logGroupId)
 
+		if pending != nil {
+			pending.end()
+			w.completeDeadLetter(drainCtx, handler, work, pending, resume)
+			continue
+		}
+		if !w.waitACK(drainCtx, work.logGroupId, workedCount) {
+			w.log.Debugf("context completed before acknowledging delivery for log group '%v'", work.logGroupId)
+			continue
+		}
+		if resume != nil {
+			// The scan is not complete, the checkpoint of the log group
+			// does not move.
+			w.saveResumePoint(handler, work.logGroupId, *resume)
+			continue
+		}
+		w.completeWork(handler, work)
+	}
+}
+
+// completeWork moves the checkpoint of the log group past the work.
+func (w *cwWorker) completeWork(handler *stateHandler, work workResponse) {
+	handler.WorkCompleteLogGroup(work.logGroupId, work.endTime.UnixMilli())
+	w.metrics.logGroup(work.logGroupId).windowProcessed(work.startTime)
+	w.health.CursorUpdated(work.logGroupId, work.endTime)
+}
+
+// waitACK waits for the acknowledgement of the count events published for a
+// log group, and returns false if ctx is done before.
+func (w *cwWorker) waitACK(ctx context.Context, logGroupId string, count int) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-w.tracker.waitFor(count):
+		w.log.Debugf("all events (%d) acknowledged for log group '%v'", count, logGroupId)
+		return true
+	}
+}
+
+// completeDeadLetter completes a work with dead_letter.enabled once its
+// events in the queue are acknowledged and the log events whose events
+// could not enter it are written to the dead letter store. The worker waits
+// for dead_letter.ack_timeout, then the work completes in the background so
+// the worker reads the next one: the events in the queue are delivered once
+// the outputs recover. When the input stops, the resume point of the work is
+// stored instead of completing it.
+func (w *cwWorker) completeDeadLetter(ctx context.Context, handler *stateHandler, work workResponse, pending *pendingWork, resume *resumePoint) {
+	complete := func() {
+		if !w.settle(ctx, work.logGroupId, pending) {
+			w.log.Debugf("context completed before acknowledging delivery for log group '%v'", work.logGroupId)
+			return
+		}
+		if resume != nil {
+			w.saveResumePoint(handler, work.logGroupId, *resume)
+			return
+		}
+		w.completeWork(handler, work)
+	}
+	if resume != nil {
+		complete()
+		return
+	}
+	timer := time.NewTimer(w.config.DeadLetter.ACKTimeout)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+	case <-pending.done:
+		complete()
+	case <-timer.C:
+		w.completions.Add(1)
+		go func() {
+			defer w.completions.Done()
+			complete()
+		}()
+	}
+}
+
+// settle waits for the acknowledgement of the events of the work in the
+// queue, and writes the log events whose events could not enter it to the
+// dead letter store. The write is tried again every dead_letter.ack_timeout
+// until it succeeds. It returns false if ctx is done before.
+func (w *cwWorker) settle(ctx context.Context, logGroupId string, pending *pendingWork) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-pending.done:
+	}
+	records := pending.lostRecords()
+	for len(records) != 0 {
+		err := w.deadLetter.write(ctx, records)
+		if err == nil {
+			w.metrics.deadLetterEventsTotal.Add(uint64(len(records)))
+			w.log.Warnw("Log events that could not be published because the queue stayed full were written to the dead letter store",
+				"log_group", logGroupId, "log_events", len(records), "ack_timeout", w.config.DeadLetter.ACKTimeout)
+			break
+		}
+		w.metrics.deadLetterErrorsTotal.Inc()
+		w.log.Errorw("Failed to write the log events that could not be published to the dead letter store",
+			"log_group", logGroupId, "log_events", len(records), "error", err)
 		select {
 		case <-ctx.Done():
-			w.log.Debugf("context completed before acknowledging delivery for log group '%v'", work.logGroupId)
-		case <-w.tracker.waitFor(workedCount):
-			handler.WorkComplete(work.endTime.UnixMilli())
-			w.log.Debugf("all events (%d) acknowledged for log group '%v'", workedCount, work.logGroupId)
+			return false
+		case <-time.After(w.config.DeadLetter.ACKTimeout):
 		}
 	}
+	return true
 }
 
-func (w *cwWorker) run(ctx context.Context, logGroupId string, startTime, endTime time.Time) int {
-	count, err := w.getLogEventsFromCloudWatch(ctx, logGroupId, startTime, endTime)
+// saveResumePoint stores the resume point of the scan of a log group stopped
+// by the shutdown of the input, if a page was read. Otherwise the scan is
+// read again from its start.
+func (w *cwWorker) saveResumePoint(handler *stateHandler, logGroupId string, rp resumePoint) {
+	if rp.NextToken == "" {
+		return
+	}
+	rp.SavedAt = time.Now().UnixMilli()
+	if err := handler.SetResumePoint(logGroupId, rp); err != nil {
+		w.log.Errorw("Failed to store where the reading of the log group stopped", "log_group", logGroupId, "error", err)
+		return
+	}
+	w.log.Infow("Stored where the reading of the log group stopped at shutdown", "log_group", logGroupId)
+}
+
+// run reads the log events of work. It returns the number of events
+// published, and the resume point of the scan if stop was closed before its
+// last page.
+func (w *cwWorker) run(ctx context.Context, stop <-chan struct{}, svc *cloudwatchlogs.Client, work workResponse) (int, *resumePoint) {
+	logGroupId, startTime, endTime := work.logGroupId, work.startTime, work.endTime
+	var count int
+	var nextToken string
+	var err error
+	if w.exporter.covers(startTime, endTime) && !w.classes.infrequentAccess(ctx, logGroupId) {
+		// Large time ranges, as the backfill of a log group, are exported
+		// to S3, FilterLogEvents is used for the following scans.
+		count, err = w.exporter.export(ctx, w.processor, logGroupId, w.region, startTime, endTime)
+	} else {
+		count, nextToken, err = w.getLogEventsFromCloudWatch(ctx, stop, svc, work)
+		if err != nil && count == 0 && work.nextToken != "" && !errors.Is(err, errStopped) && ctx.Err() == nil {
+			// The token of the page the scan stopped at is no longer
+			// valid, the scan is read again from its start.
+			w.log.Warnw("Failed to resume the reading of the log group, reading the time range again",
+				"log_group", logGroupId, "error", err)
+			work.nextToken = ""
+			count, nextToken, err = w.getLogEventsFromCloudWatch(ctx, stop, svc, work)
+		}
+	}
+	if errors.Is(err, errStopped) {
+		return count, &resumePoint{StartTime: startTime.UnixMilli(), EndTime: endTime.UnixMilli(), NextToken: nextToken}
+	}
 	if err == nil {
 		// return fast for non-errors
+		w.health.Succeeded(logGroupId)
 		w.status.UpdateStatus(status.Running, "Input is running")
-		return count
+		return count, nil
+	}
+	if ctx.Err() == nil {
+		w.observeError(logGroupId, err)
+	}
+
+	if isUnsupportedOperationError(err) && w.classes.infrequentAccess(ctx, logGroupId) {
+		// Retrying would fail the same way, the log group is no longer polled.
+		w.classes.skip(logGroupId)
+		msg := fmt.Sprintf("Log group %s has the Infrequent Access log class, which does not support FilterLogEvents, "+
+			"it is no longer collected: %s", logGroupId, err.Error())
+		w.log.Error(msg)
+		w.status.UpdateStatus(status.Degraded, msg)
+		return count, nil
 	}
 
 	// handle errors
@@ -114,40 +332,173 @@ func (w *cwWorker) run(ctx context.Context, logGroupId string, startTime, endTim
 	if errors.As(err, &rspError) && rspError.Response != nil {
 		// update status with context details if Response is available
 		w.status.UpdateStatus(status.Degraded, fmt.Sprintf("Log group listing failed, status: %d, error: %s", rspError.Response.StatusCode, rspError.Error()))
-		return count
+		return count, nil
 	}
 
 	w.status.UpdateStatus(status.Degraded, fmt.Sprintf("Log group listing failed, error: %s", err.Error()))
-	return count
+	return count, nil
 }
 
-// getLogEventsFromCloudWatch uses FilterLogEvents API to collect logs from CloudWatch
-func (w *cwWorker) getLogEventsFromCloudWatch(ctx context.Context, logGroupId string, startTime, endTime time.Time) (int, error) {
-	var logCount int
+// getLogEventsFromCloudWatch uses FilterLogEvents API to collect logs from CloudWatch. When stop is closed, it
+// returns errStopped with the token of the next page once the page being read is published.
+func (w *cwWorker) getLogEventsFromCloudWatch(ctx context.Context, stop <-chan struct{}, svc *cloudwatchlogs.Client, work workResponse) (int, string, error) {
+	logGroupId := work.logGroupId
+	region := w.region
+	if work.region != "" {
+		region = work.region
+	}
+	var logCount, pages int
+	lg := w.metrics.logGroup(logGroupId)
+	defer func() { w.metrics.polled(lg, pages) }()
 	// construct FilterLogEventsInput
-	filterLogEventsInput := w.constructFilterLogEventsInput(startTime, endTime, logGroupId)
-	paginator := cloudwatchlogs.NewFilterLogEventsPaginator(w.svc, filterLogEventsInput)
+	filterLogEventsInput := w.constructFilterLogEventsInput(work.startTime, work.endTime, logGroupId)
+	if work.nextToken != "" {
+		filterLogEventsInput.NextToken = awssdk.String(work.nextToken)
+	}
+	nextToken := work.nextToken
+	paginator := cloudwatchlogs.NewFilterLogEventsPaginator(svc, filterLogEventsInput)
 	for paginator.HasMorePages() && ctx.Err() == nil {
-		filterLogEventsOutput, err := paginator.NextPage(ctx)
+		select {
+		case <-stop:
+			return logCount, nextToken, errStopped
+		default:
+		}
+		filterLogEventsOutput, err := w.nextPage(ctx, svc, paginator, lg)
 		if err != nil {
-			return 0, fmt.Errorf("error FilterLogEvents with Paginator: %w", err)
+			// The events of the previous pages were published, they must be
+			// counted to wait for their acknowledgement.
+			return logCount, "", fmt.Errorf("error FilterLogEvents with Paginator: %w", err)
 		}
+		nextToken = awssdk.ToString(filterLogEventsOutput.NextToken)
 
-		w.metrics.apiCallsTotal.Inc()
+		pages++
 		logEvents := filterLogEventsOutput.Events
-		w.metrics.logEventsReceivedTotal.Add(uint64(len(logEvents)))
-
-		// This sleep is to avoid hitting the FilterLogEvents API limit(5 transactions per second (TPS)/account/Region).
-		w.log.Debugf("sleeping for %v before making FilterLogEvents API call again", w.config.APISleep)
-		time.Sleep(w.config.APISleep)
-		w.log.Debug("done sleeping")
+		timestamps := make([]int64, len(logEvents))
+		for i, logEvent := range logEvents {
+			timestamps[i] = awssdk.ToInt64(logEvent.Timestamp)
+		}
+		w.metrics.receivedLogEvents(lg, timestamps)
 
 		w.log.Debugf("Processing #%v events", len(logEvents))
-		w.processor.processLogEvents(logEvents, logGroupId, w.region)
-		logCount += len(logEvents)
+		logCount += w.processor.processLogEvents(logEvents, logGroupId, region, work.accountID)
+	}
+
+	return logCount, "", nil
+}
+
+// nextPage requests the next page of log events, waiting for the rate limiter
+// shared by the workers, which avoids hitting the FilterLogEvents quota (5
+// transactions per second (TPS)/account/Region). If the request is throttled
+// anyway, the rate limit is reduced and the same page is requested again after
+// a backoff. If the request fails because the credentials expired or could not
+// be retrieved, as when the session of an assumed role ends during a long
+// backfill, the cached credentials are invalidated and the same page is
+// requested again, so the pagination goes on where it stopped. The throttled
+// requests are counted in the metrics of the log group lg.
+func (w *cwWorker) nextPage(ctx context.Context, svc *cloudwatchlogs.Client, paginator *cloudwatchlogs.FilterLogEventsPaginator, lg *logGroupMetrics) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	var credentialsAttempt, throttleAttempt int
+	for {
+		if err := w.limiter.wait(ctx); err != nil {
+			return nil, err
+		}
+		output, err := paginator.NextPage(ctx)
+		w.metrics.apiCallsTotal.Inc()
+		if err == nil {
+			w.limiter.succeeded()
+			return output, nil
+		}
+
+		var wait time.Duration
+		switch {
+		case isThrottlingError(err):
+			w.limiter.throttled()
+			lg.throttled()
+			throttleAttempt++
+			if throttleAttempt > maxThrottleRetries {
+				return nil, err
+			}
+			wait = backoff(throttleAttempt)
+			w.log.Debugw("FilterLogEvents request throttled, retrying", "attempt", throttleAttempt, "backoff", wait)
+		case isCredentialsError(err):
+			w.metrics.credentialsErrorsTotal.Inc()
+			credentialsAttempt++
+			if credentialsAttempt > maxCredentialsRetries {
+				w.metrics.credentialsRefreshFailures.Inc()
+				return nil, err
+			}
+			w.log.Warnw("Failed to read log events because of the credentials, refreshing them",
+				"attempt", credentialsAttempt, "error", err)
+			invalidateCredentials(svc)
+			wait = time.Duration(credentialsAttempt) * w.config.APISleep
+		default:
+			return nil, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// isCredentialsError reports whether err is caused by expired credentials or
+// by a failure to retrieve them, such as a failed AssumeRole call.
+func isCredentialsError(err error) bool {
+	var signingErr *v4.SigningError
+	if errors.As(err, &signingErr) {
+		return true
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "ExpiredTokenException", "ExpiredToken", "UnrecognizedClientException":
+			return true
+		}
 	}
 
-	return logCount, nil
+	// Credentials are retrieved before the request is sent, a failed STS
+	// call is wrapped in the error of the CloudWatch Logs operation.
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if opErr, ok := e.(*smithy.OperationError); ok && opErr.Service() == "STS" { //nolint:errorlint // Each error of the chain is checked.
+			return true
+		}
+	}
+	return false
+}
+
+// observeError records a failed poll of a log group in the API health tracker.
+func (w *cwWorker) observeError(logGroupId string, err error) {
+	if isCredentialsError(err) {
+		w.health.AuthFailed(logGroupId, err)
+		return
+	}
+	if isThrottlingError(err) {
+		w.health.Throttled(logGroupId)
+	}
+	w.health.Failed(logGroupId, err)
+}
+
+// isThrottlingError reports whether err is caused by the API throttling
+// requests after the retries of the SDK were exhausted.
+func isThrottlingError(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "ThrottlingException", "Throttling", "TooManyRequestsException":
+			return true
+		}
+	}
+	return false
+}
+
+// invalidateCredentials forces the credentials of the client to be retrieved
+// again on the next request.
+func invalidateCredentials(svc *cloudwatchlogs.Client) {
+	if cache, ok := svc.Options().Credentials.(*awssdk.CredentialsCache); ok {
+		cache.Invalidate()
+	}
 }
 
 func (w *cwWorker) constructFilterLogEventsInput(startTime, endTime time.Time, logGroupId string) *cloudwatchlogs.FilterLogEventsInput {
@@ -164,8 +515,12 @@ func (w *cwWorker) constructFilterLogEventsInput(startTime, endTime time.Time, l
 		}
 	}
 
-	if w.config.LogStreamPrefix != "" {
-		filterLogEventsInput.LogStreamNamePrefix = awssdk.String(w.config.LogStreamPrefix)
+	if prefix := w.config.logStreamPrefix(logGroupId); prefix != "" {
+		filterLogEventsInput.LogStreamNamePrefix = awssdk.String(prefix)
+	}
+
+	if pattern := w.config.filterPattern(logGroupId); pattern != "" {
+		filterLogEventsInput.FilterPattern = awssdk.String(pattern)
 	}
 	return filterLogEventsInput
 }
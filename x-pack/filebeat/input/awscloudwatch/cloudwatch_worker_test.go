@@ -5,15 +5,23 @@
 package awscloudwatch
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/smithy-go"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/apihealth"
 	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
 )
 
 func TestAckTracker(t *testing.T) {
@@ -134,3 +142,118 @@ func TestFilterLogEventsInput(t *testing.T) {
 	}
 
 }
+
+func TestIsCredentialsError(t *testing.T) {
+	testCases := map[string]struct {
+		err  error
+		want bool
+	}{
+		"expired token": {
+			err: &smithy.OperationError{
+				ServiceID:     "CloudWatch Logs",
+				OperationName: "FilterLogEvents",
+				Err:           &smithy.GenericAPIError{Code: "ExpiredTokenException", Message: "The security token included in the request is expired"},
+			},
+			want: true,
+		},
+		"failed assume role": {
+			err: &smithy.OperationError{
+				ServiceID:     "CloudWatch Logs",
+				OperationName: "FilterLogEvents",
+				Err: fmt.Errorf("get identity: %w", &smithy.OperationError{
+					ServiceID:     "STS",
+					OperationName: "AssumeRole",
+					Err:           errors.New("connection reset"),
+				}),
+			},
+			want: true,
+		},
+		"signing error": {
+			err:  &v4.SigningError{Err: errors.New("failed to retrieve credentials")},
+			want: true,
+		},
+		"throttling": {
+			err: &smithy.OperationError{
+				ServiceID:     "CloudWatch Logs",
+				OperationName: "FilterLogEvents",
+				Err:           &smithy.GenericAPIError{Code: "ThrottlingException"},
+			},
+		},
+		"other error": {
+			err: errors.New("connection reset"),
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, isCredentialsError(tc.err))
+		})
+	}
+}
+
+func TestInvalidateCredentials(t *testing.T) {
+	retrieved := 0
+	svc := cloudwatchlogs.New(cloudwatchlogs.Options{
+		Region: "us-east-1",
+		Credentials: awssdk.NewCredentialsCache(awssdk.CredentialsProviderFunc(func(context.Context) (awssdk.Credentials, error) {
+			retrieved++
+			return awssdk.Credentials{
+				AccessKeyID:     "id",
+				SecretAccessKey: "secret",
+				CanExpire:       true,
+				Expires:         time.Now().Add(time.Hour),
+			}, nil
+		})),
+	})
+
+	creds := svc.Options().Credentials
+	_, err := creds.Retrieve(context.Background())
+	require.NoError(t, err)
+	_, err = creds.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, retrieved, "credentials are cached")
+
+	invalidateCredentials(svc)
+	_, err = creds.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, retrieved, "credentials are retrieved again once invalidated")
+}
+
+func TestObserveError(t *testing.T) {
+	w := &cwWorker{
+		health: apihealth.NewTracker(apihealth.Config{Enabled: true}, inputName, "test"),
+	}
+
+	w.observeError("expired", &smithy.OperationError{
+		ServiceID:     "CloudWatch Logs",
+		OperationName: "FilterLogEvents",
+		Err:           &smithy.GenericAPIError{Code: "ExpiredTokenException"},
+	})
+	w.observeError("throttled", &smithy.OperationError{
+		ServiceID:     "CloudWatch Logs",
+		OperationName: "FilterLogEvents",
+		Err:           &smithy.GenericAPIError{Code: "ThrottlingException"},
+	})
+	w.observeError("other", errors.New("connection reset"))
+
+	events := w.health.Events()
+	require.Len(t, events, 3)
+	want := []struct {
+		resource  string
+		status    string
+		auth      string
+		throttled uint64
+	}{
+		{resource: "expired", status: "auth_failed", auth: "failed"},
+		{resource: "other", status: "degraded", auth: "unknown"},
+		{resource: "throttled", status: "degraded", auth: "unknown", throttled: 1},
+	}
+	for i, e := range events {
+		health, err := e.GetValue("api_health")
+		require.NoError(t, err)
+		got := health.(mapstr.M)
+		assert.Equal(t, want[i].resource, got["resource"])
+		assert.Equal(t, want[i].status, got["status"], want[i].resource)
+		assert.Equal(t, mapstr.M{"status": want[i].auth}, got["auth"], want[i].resource)
+		assert.Equal(t, want[i].throttled, got["throttled_total"], want[i].resource)
+	}
+}
@@ -7,9 +7,12 @@ package awscloudwatch
 import (
 	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/elastic/beats/v7/filebeat/harvester"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/apihealth"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/leases"
 	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
 )
 
@@ -21,20 +24,72 @@ const (
 
 type config struct {
 	harvester.ForwarderConfig          `config:",inline"`
-	LogGroupARN                        string              `config:"log_group_arn"`
-	LogGroupName                       string              `config:"log_group_name"`
-	LogGroupNamePrefix                 string              `config:"log_group_name_prefix"`
-	IncludeLinkedAccountsForPrefixMode bool                `config:"include_linked_accounts_for_prefix_mode"`
-	RegionName                         string              `config:"region_name"`
-	LogStreams                         []*string           `config:"log_streams"`
-	LogStreamPrefix                    string              `config:"log_stream_prefix"`
-	StartPosition                      string              `config:"start_position" default:"beginning"`
-	ScanFrequency                      time.Duration       `config:"scan_frequency" validate:"min=0,nonzero"`
-	APITimeout                         time.Duration       `config:"api_timeout" validate:"min=0,nonzero"`
-	APISleep                           time.Duration       `config:"api_sleep" validate:"min=0,nonzero"`
-	Latency                            time.Duration       `config:"latency"`
-	NumberOfWorkers                    int                 `config:"number_of_workers"`
-	AWSConfig                          awscommon.ConfigAWS `config:",inline"`
+	ID                                 string                  `config:"id"`
+	LogGroupARN                        string                  `config:"log_group_arn"`
+	LogGroupName                       string                  `config:"log_group_name"`
+	LogGroupNamePrefix                 string                  `config:"log_group_name_prefix"`
+	IncludeLinkedAccountsForPrefixMode bool                    `config:"include_linked_accounts_for_prefix_mode"`
+	RegionName                         string                  `config:"region_name"`
+	LogStreams                         []*string               `config:"log_streams"`
+	LogStreamPrefix                    string                  `config:"log_stream_prefix"`
+	FilterPattern                      string                  `config:"filter_pattern"`
+	FilterPatterns                     []filterPatternOverride `config:"filter_patterns"`
+	LogGroupOverrides                  logGroupOverrides       `config:"log_group_overrides"`
+	StartPosition                      string                  `config:"start_position" default:"beginning"`
+	Mode                               string                  `config:"mode"`
+	ScanFrequency                      time.Duration           `config:"scan_frequency" validate:"min=0,nonzero"`
+	APITimeout                         time.Duration           `config:"api_timeout" validate:"min=0,nonzero"`
+	APISleep                           time.Duration           `config:"api_sleep" validate:"min=0,nonzero"`
+	APIRateLimit                       float64                 `config:"api_rate_limit" validate:"min=0"`
+	Latency                            time.Duration           `config:"latency"`
+	NumberOfWorkers                    int                     `config:"number_of_workers"`
+	ShutdownTimeout                    time.Duration           `config:"shutdown_timeout" validate:"min=0"`
+	Organization                       organizationConfig      `config:"organization"`
+	Discovery                          discoveryConfig         `config:"discovery"`
+	CrossAccount                       crossAccountConfig      `config:"cross_account"`
+	Export                             exportConfig            `config:"export"`
+	Backfill                           backfillConfig          `config:"backfill"`
+	Insights                           insightsConfig          `config:"insights"`
+	EventMapping                       eventMappingConfig      `config:",inline"`
+	AWSConfig                          awscommon.ConfigAWS     `config:",inline"`
+	APIHealth                          apihealth.Config        `config:"api_health"`
+	DeadLetter                         deadLetterConfig        `config:"dead_letter"`
+	Deduplication                      deduplicationConfig     `config:"deduplication"`
+	Coordination                       leases.Config           `config:"coordination"`
+}
+
+// eventMappingConfig configures the fields of the events created from the
+// CloudWatch log events.
+type eventMappingConfig struct {
+	// PreserveOriginalEvent keeps a copy of the raw message in event.original.
+	PreserveOriginalEvent bool `config:"preserve_original_event"`
+	// IncludeCloudWatchMetadata adds the ID and the ingestion time of the log
+	// event to event.id and the ingestion_time field of CloudWatchTargetField.
+	IncludeCloudWatchMetadata bool `config:"include_cloudwatch_metadata"`
+	// MessageTargetField is the field holding the message of the log event.
+	MessageTargetField string `config:"message_target_field" validate:"required"`
+	// CloudWatchTargetField is the field holding the log group, log stream
+	// and ingestion time of the log event.
+	CloudWatchTargetField string `config:"cloudwatch_target_field" validate:"required"`
+	// EventIDAsDocumentID uses the ID of the log event as the ID of the
+	// document, so the log events published twice are indexed once.
+	EventIDAsDocumentID bool `config:"event_id_as_document_id"`
+	// Decoding configures the decoding of structured messages.
+	Decoding decodingConfig `config:"decoding"`
+}
+
+// organizationConfig configures the enumeration of the log groups of all the
+// member accounts of an AWS Organization. The accounts are listed with the
+// credentials of the input, which must belong to the management account or a
+// delegated administrator, and RoleName is assumed in each account to list
+// and read its log groups.
+type organizationConfig struct {
+	Enabled           bool          `config:"enabled"`
+	RoleName          string        `config:"role_name"`
+	ExternalID        string        `config:"external_id"`
+	AccountIDs        []string      `config:"account_ids"`
+	ExcludeAccountIDs []string      `config:"exclude_account_ids"`
+	RefreshInterval   time.Duration `config:"refresh_interval" validate:"min=0,nonzero"`
 }
 
 func defaultConfig() config {
@@ -43,11 +98,62 @@ func defaultConfig() config {
 			Type: "aws-cloudwatch",
 		},
 		StartPosition:   beginning,
+		Mode:            modePoll,
 		ScanFrequency:   60 * time.Second,
 		APITimeout:      120 * time.Second,
 		APISleep:        200 * time.Millisecond, // FilterLogEvents has a limit of 5 transactions per second (TPS)/account/Region: 1s / 5 = 200 ms
 		NumberOfWorkers: 1,
+		Organization: organizationConfig{
+			RefreshInterval: time.Hour,
+		},
+		Discovery: discoveryConfig{
+			RefreshInterval: 5 * time.Minute,
+		},
+		CrossAccount: crossAccountConfig{
+			RefreshInterval: 5 * time.Minute,
+		},
+		Export: exportConfig{
+			Prefix:       "exportedlogs",
+			MinRange:     24 * time.Hour,
+			PollInterval: 30 * time.Second,
+		},
+		Backfill: backfillConfig{
+			MaxSlices: 100,
+		},
+		Insights:     defaultInsightsConfig(),
+		EventMapping: defaultEventMappingConfig(),
+		DeadLetter: deadLetterConfig{
+			Type:       deadLetterFile,
+			Path:       "aws-cloudwatch-dead-letter",
+			ACKTimeout: 5 * time.Minute,
+		},
+		Coordination: leases.DefaultConfig(),
+		Deduplication: deduplicationConfig{
+			CacheSize: 10000,
+		},
+	}
+}
+
+func defaultEventMappingConfig() eventMappingConfig {
+	return eventMappingConfig{
+		IncludeCloudWatchMetadata: true,
+		EventIDAsDocumentID:       true,
+		MessageTargetField:        "message",
+		CloudWatchTargetField:     "aws.cloudwatch",
+		Decoding: decodingConfig{
+			AddErrorKey: true,
+		},
+	}
+}
+
+// rateLimit returns the maximum rate of the FilterLogEvents requests of the
+// input, in requests per second. It is derived from api_sleep if
+// api_rate_limit is not set.
+func (c *config) rateLimit() float64 {
+	if c.APIRateLimit > 0 {
+		return c.APIRateLimit
 	}
+	return float64(time.Second) / float64(c.APISleep)
 }
 
 func (c *config) Validate() error {
@@ -55,6 +161,143 @@ func (c *config) Validate() error {
 		return fmt.Errorf("start_position config parameter can only be one of %s, %s or %s", beginning, end, lastSync)
 	}
 
+	if c.Mode != modePoll && c.Mode != modeLiveTail && c.Mode != modeInsights {
+		return fmt.Errorf("mode config parameter can only be one of %s, %s or %s", modePoll, modeLiveTail, modeInsights)
+	}
+
+	if c.Mode == modeLiveTail {
+		if c.Organization.Enabled || c.Discovery.Enabled {
+			return fmt.Errorf("organization.enabled and discovery.enabled cannot be used with mode %s", modeLiveTail)
+		}
+		if (len(c.LogStreams) != 0 || c.LogStreamPrefix != "") && c.LogGroupARN == "" && c.LogGroupName == "" {
+			return fmt.Errorf("log_streams and log_stream_prefix can only be used with mode %s "+
+				"when a single log group is given with log_group_arn or log_group_name", modeLiveTail)
+		}
+	}
+
+	if c.Mode == modeInsights {
+		if c.Insights.Query == "" {
+			return fmt.Errorf("insights.query is required with mode %s", modeInsights)
+		}
+		if c.Organization.Enabled || c.Export.Enabled || c.Backfill.SliceDuration > 0 {
+			return fmt.Errorf("organization.enabled, export.enabled and backfill.slice_duration cannot be used with mode %s", modeInsights)
+		}
+		if c.EventMapping.Decoding.Format != "" {
+			return fmt.Errorf("decoding.format cannot be used with mode %s, the query results are already structured", modeInsights)
+		}
+		if len(c.LogStreams) != 0 || c.LogStreamPrefix != "" {
+			return fmt.Errorf("log_streams and log_stream_prefix cannot be used with mode %s, "+
+				"filter on @logStream in insights.query to select the log streams", modeInsights)
+		}
+		if c.FilterPattern != "" {
+			return fmt.Errorf("filter_pattern cannot be used with mode %s, "+
+				"filter the log events in insights.query", modeInsights)
+		}
+	}
+
+	if c.EventMapping.MessageTargetField == c.EventMapping.CloudWatchTargetField ||
+		strings.HasPrefix(c.EventMapping.CloudWatchTargetField, c.EventMapping.MessageTargetField+".") {
+		return errors.New("cloudwatch_target_field cannot be message_target_field or one of its sub-fields")
+	}
+
+	if c.Backfill.SliceDuration > 0 && c.Backfill.SliceDuration <= c.ScanFrequency {
+		return errors.New("backfill.slice_duration must be greater than scan_frequency")
+	}
+
+	if c.Export.Enabled && c.Backfill.SliceDuration > 0 {
+		return errors.New("backfill.slice_duration cannot be used with export.enabled")
+	}
+
+	if c.Export.Enabled && len(c.LogStreams) != 0 {
+		return errors.New("log_streams cannot be used with export.enabled, use log_stream_prefix to select the log streams")
+	}
+
+	if c.Coordination.Enabled && c.Mode != modePoll {
+		return fmt.Errorf("coordination can only be used with mode %s", modePoll)
+	}
+
+	// The entries of the deprecated filter_patterns are validated as
+	// log_group_overrides entries setting filter_pattern.
+	if overrides := c.logGroupOverrides(); len(overrides) != 0 {
+		if c.Mode != modePoll {
+			return fmt.Errorf("log_group_overrides and filter_patterns can only be used with mode %s", modePoll)
+		}
+		for _, o := range overrides {
+			if c.Export.Enabled && (o.LogStreamPrefix != nil || o.FilterPattern != nil) {
+				return errors.New("log_stream_prefix and filter_pattern cannot be set in log_group_overrides or filter_patterns with export.enabled, " +
+					"export tasks read all the log groups with the same settings")
+			}
+			if o.ScanFrequency != nil && c.Backfill.SliceDuration > 0 && c.Backfill.SliceDuration <= *o.ScanFrequency {
+				return errors.New("backfill.slice_duration must be greater than the scan_frequency of log_group_overrides")
+			}
+		}
+	}
+
+	if c.Deduplication.Enabled && c.Mode != modePoll {
+		return fmt.Errorf("deduplication can only be used with mode %s", modePoll)
+	}
+
+	if c.DeadLetter.Enabled {
+		if c.Mode != modePoll {
+			return fmt.Errorf("dead_letter can only be used with mode %s", modePoll)
+		}
+		if c.ID == "" {
+			return errors.New("id is required with dead_letter.enabled, it names the dead letter store of the input")
+		}
+	}
+
+	if c.Export.Enabled && c.FilterPattern != "" {
+		return errors.New("filter_pattern cannot be used with export.enabled, export tasks do not filter the log events")
+	}
+
+	if c.CrossAccount.enabled() {
+		if c.Mode != modePoll {
+			return fmt.Errorf("cross_account cannot be used with mode %s", c.Mode)
+		}
+		if c.Organization.Enabled || c.Discovery.Enabled || c.Export.Enabled {
+			return errors.New("cross_account cannot be used with organization.enabled, discovery.enabled or export.enabled")
+		}
+		if c.LogGroupARN != "" || c.LogGroupName != "" {
+			return errors.New("log_group_arn and log_group_name cannot be used with cross_account, " +
+				"use log_group_name_prefix to select the log groups of the accounts and regions")
+		}
+		if len(c.CrossAccount.Regions) == 0 && c.RegionName == "" {
+			return errors.New("region_name is required when cross_account.regions is not set")
+		}
+		return nil
+	}
+
+	if c.Organization.Enabled {
+		if c.Export.Enabled {
+			return errors.New("export.enabled cannot be used with organization.enabled")
+		}
+		if c.Organization.RoleName == "" {
+			return errors.New("organization.role_name is required when organization.enabled is set")
+		}
+		if c.LogGroupARN != "" || c.LogGroupName != "" {
+			return errors.New("log_group_arn and log_group_name cannot be used with organization.enabled, " +
+				"use log_group_name_prefix to select the log groups of the member accounts")
+		}
+		if c.RegionName == "" {
+			return errors.New("region_name is required when organization.enabled is set")
+		}
+		if c.Discovery.Enabled {
+			return errors.New("discovery.enabled cannot be used with organization.enabled")
+		}
+		return nil
+	}
+
+	if c.Discovery.Enabled {
+		if c.LogGroupARN != "" || c.LogGroupName != "" {
+			return errors.New("log_group_arn and log_group_name cannot be used with discovery.enabled, " +
+				"use log_group_name_prefix or discovery.log_group_name_patterns to select the log groups")
+		}
+		if c.RegionName == "" {
+			return errors.New("region_name is required when discovery.enabled is set")
+		}
+		return nil
+	}
+
 	if c.LogGroupARN == "" && c.LogGroupName == "" && c.LogGroupNamePrefix == "" {
 		return errors.New("log_group_arn, log_group_name and log_group_name_prefix config parameter " +
 			"cannot all be empty")
@@ -0,0 +1,63 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/leases"
+)
+
+// coordinator partitions the log groups between the instances of the input
+// with a lease per log group. The checkpoints of the log groups are stored
+// with their leases, in Unix milliseconds.
+type coordinator struct {
+	*leases.Coordinator
+}
+
+// leaseKey returns the key of the lease of a log group. Listed log group ARNs
+// end with ":*", which is removed to match configured ARNs.
+func leaseKey(logGroupID string) string {
+	return strings.TrimSuffix(logGroupID, ":*")
+}
+
+// claim returns the log groups of logGroups the instance holds the lease of,
+// and maps the newly acquired ones to their stored checkpoint, zero if none.
+func (c *coordinator) claim(ctx context.Context, logGroups []logGroup) (owned []logGroup, acquired map[string]time.Time) {
+	keys := make([]string, 0, len(logGroups))
+	for _, lg := range logGroups {
+		keys = append(keys, leaseKey(lg.id))
+	}
+	ownedKeys, acquiredKeys := c.Claim(ctx, keys)
+	held := make(map[string]bool, len(ownedKeys))
+	for _, key := range ownedKeys {
+		held[key] = true
+	}
+
+	acquired = map[string]time.Time{}
+	for _, lg := range logGroups {
+		key := leaseKey(lg.id)
+		if !held[key] {
+			continue
+		}
+		owned = append(owned, lg)
+		if checkpoint, ok := acquiredKeys[key]; ok {
+			var start time.Time
+			if checkpoint != 0 {
+				start = time.UnixMilli(checkpoint)
+			}
+			acquired[lg.id] = start
+		}
+	}
+	return owned, acquired
+}
+
+// setCheckpoint records the checkpoint of a log group, stored with the next
+// renewal of its lease.
+func (c *coordinator) setCheckpoint(logGroupID string, timestamp int64) {
+	c.SetCheckpoint(leaseKey(logGroupID), timestamp)
+}
@@ -0,0 +1,84 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/leases"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// singleOwnerStore is a lease store of a single instance, holding the
+// checkpoints of the leases.
+type singleOwnerStore struct {
+	checkpoints map[string]int64
+}
+
+func (s *singleOwnerStore) Heartbeat(context.Context, string, time.Time) error { return nil }
+
+func (s *singleOwnerStore) Owners(context.Context, time.Time) ([]string, error) { return nil, nil }
+
+func (s *singleOwnerStore) Acquire(_ context.Context, key, _ string, _, _ time.Time) (int64, bool, error) {
+	return s.checkpoints[key], true, nil
+}
+
+func (s *singleOwnerStore) Renew(_ context.Context, key, _ string, _ time.Time, checkpoint int64) (bool, error) {
+	if checkpoint != 0 {
+		s.checkpoints[key] = checkpoint
+	}
+	return true, nil
+}
+
+func (s *singleOwnerStore) Release(context.Context, string, string, int64) error { return nil }
+
+func (s *singleOwnerStore) Leave(context.Context, string) error { return nil }
+
+func TestCoordinatorClaim(t *testing.T) {
+	store := &singleOwnerStore{checkpoints: map[string]int64{
+		"arn:aws:logs:us-east-1:123456789012:log-group:app": 5000,
+	}}
+	metrics := newInputMetrics(monitoring.NewRegistry())
+	c := &coordinator{leases.NewCoordinator(leases.Config{OwnerID: "instance-a", LeaseDuration: time.Minute}, store, leases.Metrics{
+		Owned:  metrics.coordinationLogGroupsOwned,
+		Errors: metrics.coordinationErrorsTotal,
+	}, logp.NewLogger("test"))}
+
+	logGroups := []logGroup{
+		{id: "arn:aws:logs:us-east-1:123456789012:log-group:app:*"},
+		{id: "arn:aws:logs:us-east-1:123456789012:log-group:db:*"},
+	}
+	owned, acquired := c.claim(context.Background(), logGroups)
+	assert.Equal(t, logGroups, owned)
+	assert.Equal(t, map[string]time.Time{
+		logGroups[0].id: time.UnixMilli(5000),
+		logGroups[1].id: {},
+	}, acquired, "the leases are keyed by the log group ARNs without the trailing :*")
+	assert.Equal(t, uint64(2), metrics.coordinationLogGroupsOwned.Get())
+
+	owned, acquired = c.claim(context.Background(), logGroups[1:])
+	assert.Equal(t, logGroups[1:], owned)
+	assert.Empty(t, acquired, "the held leases are not acquired again")
+}
+
+func TestCoordinationConfig(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.LogGroupName = "app"
+	cfg.RegionName = "us-east-1"
+	cfg.Coordination.Enabled = true
+	assert.ErrorContains(t, cfg.Coordination.Validate(), "coordination.table_name is required")
+
+	cfg.Coordination.TableName = "leases"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Mode = modeInsights
+	cfg.Insights.Query = "fields @message"
+	assert.ErrorContains(t, cfg.Validate(), "coordination can only be used with mode poll")
+}
@@ -0,0 +1,168 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// crossAccountConfig configures the collection of the log groups of several
+// accounts and regions. A client is created for each pair of role and region,
+// the log groups are listed with each of them.
+type crossAccountConfig struct {
+	// RoleARNs are the roles assumed to read the log groups of their
+	// account. The credentials of the input are used if empty.
+	RoleARNs   []string `config:"role_arns"`
+	ExternalID string   `config:"external_id"`
+	// Regions are the regions the log groups are read from. region_name is
+	// used if empty.
+	Regions         []string      `config:"regions"`
+	RefreshInterval time.Duration `config:"refresh_interval" validate:"min=0,nonzero"`
+}
+
+// enabled reports whether the log groups are read from several accounts or
+// regions.
+func (c *crossAccountConfig) enabled() bool {
+	return len(c.RoleARNs) != 0 || len(c.Regions) != 0
+}
+
+func (c *crossAccountConfig) Validate() error {
+	for _, r := range c.RoleARNs {
+		parsed, err := arn.Parse(r)
+		if err != nil || parsed.Service != "iam" {
+			return fmt.Errorf("invalid role ARN %q in cross_account.role_arns", r)
+		}
+	}
+	for _, r := range c.Regions {
+		if r == "" {
+			return errors.New("cross_account.regions cannot contain empty regions")
+		}
+	}
+	return nil
+}
+
+// crossAccountTarget is a pair of role and region the log groups are listed
+// and read with. The log groups listed with its client are cached.
+type crossAccountTarget struct {
+	roleARN   string
+	region    string
+	svc       *cloudwatchlogs.Client
+	logGroups []string
+}
+
+// crossAccountEnumerator enumerates the log groups of the pairs of roles and
+// regions of cross_account. The log groups are listed again once
+// cross_account.refresh_interval elapsed; in between, the cached log groups
+// are returned.
+type crossAccountEnumerator struct {
+	config config
+	log    *logp.Logger
+
+	listLogGroups func(ctx context.Context, svc *cloudwatchlogs.Client) ([]string, error)
+	clock         func() time.Time
+
+	targets   []*crossAccountTarget
+	refreshed time.Time
+	groups    []logGroup
+}
+
+func newCrossAccountEnumerator(cfg config, awsConfig awssdk.Config, log *logp.Logger) *crossAccountEnumerator {
+	regions := cfg.CrossAccount.Regions
+	if len(regions) == 0 {
+		regions = []string{awsConfig.Region}
+	}
+	roles := cfg.CrossAccount.RoleARNs
+	if len(roles) == 0 {
+		// The log groups are read with the credentials of the input.
+		roles = []string{""}
+	}
+	stsSvc := sts.NewFromConfig(awsConfig)
+
+	e := &crossAccountEnumerator{
+		config: cfg,
+		log:    log,
+		listLogGroups: func(ctx context.Context, svc *cloudwatchlogs.Client) ([]string, error) {
+			return getLogGroupNames(ctx, svc, cfg.LogGroupNamePrefix, cfg.IncludeLinkedAccountsForPrefixMode)
+		},
+		clock: time.Now,
+	}
+	for _, role := range roles {
+		// The credentials of a role are shared by the clients of its
+		// regions.
+		var credentials awssdk.CredentialsProvider
+		if role != "" {
+			provider := stscreds.NewAssumeRoleProvider(stsSvc, role, func(o *stscreds.AssumeRoleOptions) {
+				if cfg.CrossAccount.ExternalID != "" {
+					o.ExternalID = awssdk.String(cfg.CrossAccount.ExternalID)
+				}
+			})
+			credentials = awssdk.NewCredentialsCache(provider)
+		}
+		for _, region := range regions {
+			targetConfig := awsConfig.Copy()
+			targetConfig.Region = region
+			if credentials != nil {
+				targetConfig.Credentials = credentials
+			}
+			e.targets = append(e.targets, &crossAccountTarget{
+				roleARN: role,
+				region:  region,
+				svc:     newLogsClient(cfg, targetConfig),
+			})
+		}
+	}
+	return e
+}
+
+// logGroups returns the log groups of the targets, refreshing them if
+// refresh_interval elapsed since the last listing.
+func (e *crossAccountEnumerator) logGroups(ctx context.Context) []logGroup {
+	if !e.refreshed.IsZero() && e.clock().Sub(e.refreshed) < e.config.CrossAccount.RefreshInterval {
+		return e.groups
+	}
+	e.refresh(ctx)
+	return e.groups
+}
+
+// refresh lists the log groups of the targets. If the log groups of a target
+// can't be listed, its previously listed log groups are kept. The log groups
+// are identified by their ARN, so the log groups with the same name in
+// several accounts or regions have their own checkpoint.
+func (e *crossAccountEnumerator) refresh(ctx context.Context) {
+	e.refreshed = e.clock()
+	previous := len(e.groups)
+	var groups []logGroup
+	for _, t := range e.targets {
+		ids, err := e.listLogGroups(ctx, t.svc)
+		if err != nil {
+			e.log.Warnw("Failed to list the log groups of a role and region, using the cached log groups",
+				"role_arn", t.roleARN, "region", t.region, "error", err)
+		} else {
+			t.logGroups = ids
+		}
+		for _, id := range t.logGroups {
+			lg := logGroup{id: id, svc: t.svc, region: t.region}
+			if parsed, err := arn.Parse(id); err == nil {
+				lg.accountID = parsed.AccountID
+			}
+			groups = append(groups, lg)
+		}
+	}
+
+	e.groups = groups
+	e.log.Infow("Listed the log groups of the accounts and regions",
+		"targets", len(e.targets), "log_groups", len(groups), "previous_log_groups", previous)
+}
@@ -0,0 +1,115 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	pubtest "github.com/elastic/beats/v7/libbeat/publisher/testing"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func TestCrossAccountEnumerator(t *testing.T) {
+	ctx := context.Background()
+	clk := &clock{time: time.Unix(0, 0)}
+	cfg := defaultConfig()
+	cfg.CrossAccount = crossAccountConfig{
+		RoleARNs:        []string{"arn:aws:iam::111:role/reader", "arn:aws:iam::222:role/reader"},
+		Regions:         []string{"us-east-1", "eu-west-1"},
+		RefreshInterval: time.Hour,
+	}
+	e := newCrossAccountEnumerator(cfg, awssdk.Config{Region: "us-east-1"}, logp.NewLogger("test"))
+	require.Len(t, e.targets, 4, "a client is created for each role and region")
+
+	// The log groups of each target are listed with its client.
+	listed := map[*cloudwatchlogs.Client][]string{}
+	failing := map[*cloudwatchlogs.Client]bool{}
+	for _, target := range e.targets {
+		account := target.roleARN[len("arn:aws:iam::") : len("arn:aws:iam::")+3]
+		listed[target.svc] = []string{"arn:aws:logs:" + target.region + ":" + account + ":log-group:app"}
+	}
+	e.listLogGroups = func(_ context.Context, svc *cloudwatchlogs.Client) ([]string, error) {
+		if failing[svc] {
+			return nil, errors.New("access denied")
+		}
+		return listed[svc], nil
+	}
+	e.clock = clk.now
+
+	groups := e.logGroups(ctx)
+	require.Len(t, groups, 4)
+	assert.Equal(t, logGroup{
+		id:        "arn:aws:logs:eu-west-1:222:log-group:app",
+		svc:       e.targets[3].svc,
+		region:    "eu-west-1",
+		accountID: "222",
+	}, groups[3])
+
+	// The log groups are cached until the refresh interval elapsed, and
+	// the targets whose log groups can't be listed keep them.
+	listed[e.targets[0].svc] = append(listed[e.targets[0].svc], "arn:aws:logs:us-east-1:111:log-group:db")
+	failing[e.targets[1].svc] = true
+	clk.time = clk.time.Add(time.Minute)
+	assert.Len(t, e.logGroups(ctx), 4)
+	clk.time = clk.time.Add(time.Hour)
+	assert.Len(t, e.logGroups(ctx), 5)
+}
+
+func TestCrossAccountEvents(t *testing.T) {
+	var events []beat.Event
+	client := pubtest.NewChanClientWithCallback(1, func(event beat.Event) {
+		events = append(events, event)
+	})
+	p := newLogProcessor(logp.NewLogger("test"), nil, client, defaultEventMappingConfig())
+	logEvent := types.FilteredLogEvent{
+		EventId:       awssdk.String("id-1"),
+		LogStreamName: awssdk.String("stream"),
+		Message:       awssdk.String("hello"),
+		Timestamp:     awssdk.Int64(1600000000000),
+	}
+	p.processLogEvents([]types.FilteredLogEvent{logEvent}, "arn:aws:logs:eu-west-1:222:log-group:app", "eu-west-1", "222")
+	p.processLogEvents([]types.FilteredLogEvent{logEvent}, "app", "us-east-1", "")
+	require.Len(t, events, 2)
+
+	region, _ := events[0].Fields.GetValue("cloud.region")
+	assert.Equal(t, "eu-west-1", region)
+	account, _ := events[0].Fields.GetValue("cloud.account.id")
+	assert.Equal(t, "222", account)
+	_, err := events[1].Fields.GetValue("cloud.account.id")
+	assert.Error(t, err, "the account is only set for the log groups of other accounts")
+}
+
+func TestCrossAccountConfig(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.CrossAccount.RoleARNs = []string{"arn:aws:iam::111:role/reader"}
+	assert.ErrorContains(t, cfg.Validate(), "region_name is required when cross_account.regions is not set")
+
+	cfg.CrossAccount.Regions = []string{"us-east-1", "eu-west-1"}
+	assert.NoError(t, cfg.Validate(), "the log group prefix is optional")
+
+	cfg.LogGroupName = "app"
+	assert.ErrorContains(t, cfg.Validate(), "log_group_arn and log_group_name cannot be used with cross_account")
+
+	cfg.LogGroupName = ""
+	cfg.Mode = modeLiveTail
+	assert.ErrorContains(t, cfg.Validate(), "cross_account cannot be used with mode live_tail")
+
+	cfg.Mode = modePoll
+	cfg.Export.Enabled = true
+	assert.ErrorContains(t, cfg.Validate(), "cross_account cannot be used with organization.enabled, discovery.enabled or export.enabled")
+
+	invalid := crossAccountConfig{RoleARNs: []string{"arn:aws:s3:::bucket"}}
+	assert.ErrorContains(t, invalid.Validate(), "invalid role ARN")
+}
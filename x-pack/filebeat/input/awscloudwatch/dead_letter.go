@@ -0,0 +1,221 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+const (
+	deadLetterFile = "file"
+	deadLetterSQS  = "sqs"
+
+	// sqsMaxBatchSize is the maximum number of messages of a
+	// SendMessageBatch request.
+	sqsMaxBatchSize = 10
+)
+
+// deadLetterConfig configures the dead letter store of the log events whose
+// events could not enter the queue of the pipeline because it stayed full
+// for ACKTimeout, so they are not lost during long output outages. They are
+// written to a spool file or to an SQS queue, and published again with the
+// replay-dead-letter command. The events already in the queue are delivered
+// once the outputs recover, they are never written to the dead letter store.
+type deadLetterConfig struct {
+	Enabled bool   `config:"enabled"`
+	Type    string `config:"type"`
+	// Path is the directory of the spool files, relative to the data path
+	// of the Beat.
+	Path string `config:"path"`
+	// QueueURL is the URL of the SQS queue the log events are sent to.
+	QueueURL   string        `config:"queue_url"`
+	ACKTimeout time.Duration `config:"ack_timeout" validate:"min=0,nonzero"`
+}
+
+func (c *deadLetterConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	switch c.Type {
+	case deadLetterFile:
+		if c.Path == "" {
+			return errors.New("dead_letter.path is required with dead_letter.type file")
+		}
+	case deadLetterSQS:
+		if c.QueueURL == "" {
+			return errors.New("dead_letter.queue_url is required with dead_letter.type sqs")
+		}
+	default:
+		return fmt.Errorf("dead_letter.type can only be one of %s or %s", deadLetterFile, deadLetterSQS)
+	}
+	return nil
+}
+
+// deadLetterRecord is a log event written to the dead letter store, with the
+// log group it was read from. Events are the indices of its events that were
+// not published, all of them if it is empty.
+type deadLetterRecord struct {
+	LogGroup      string `json:"log_group"`
+	Region        string `json:"region"`
+	AccountID     string `json:"account_id,omitempty"`
+	LogStream     string `json:"log_stream"`
+	EventID       string `json:"event_id"`
+	Timestamp     int64  `json:"timestamp"`
+	IngestionTime *int64 `json:"ingestion_time,omitempty"`
+	Message       string `json:"message"`
+	Events        []int  `json:"events,omitempty"`
+}
+
+func newDeadLetterRecord(logEvent types.FilteredLogEvent, logGroupId, regionName, accountID string) *deadLetterRecord {
+	return &deadLetterRecord{
+		LogGroup:      logGroupId,
+		Region:        regionName,
+		AccountID:     accountID,
+		LogStream:     awssdk.ToString(logEvent.LogStreamName),
+		EventID:       awssdk.ToString(logEvent.EventId),
+		Timestamp:     awssdk.ToInt64(logEvent.Timestamp),
+		IngestionTime: logEvent.IngestionTime,
+		Message:       awssdk.ToString(logEvent.Message),
+	}
+}
+
+// logEvent returns the log event the record was created from.
+func (r *deadLetterRecord) logEvent() types.FilteredLogEvent {
+	return types.FilteredLogEvent{
+		EventId:       awssdk.String(r.EventID),
+		IngestionTime: r.IngestionTime,
+		LogStreamName: awssdk.String(r.LogStream),
+		Message:       awssdk.String(r.Message),
+		Timestamp:     awssdk.Int64(r.Timestamp),
+	}
+}
+
+// deadLetterWriter writes log events to the dead letter store.
+type deadLetterWriter interface {
+	write(ctx context.Context, records []*deadLetterRecord) error
+}
+
+func newDeadLetterWriter(cfg deadLetterConfig, dir, inputID string, svc sqsAPI) deadLetterWriter {
+	if cfg.Type == deadLetterSQS {
+		return &sqsDeadLetter{svc: svc, queueURL: cfg.QueueURL}
+	}
+	return &fileDeadLetter{path: spoolPath(dir, inputID)}
+}
+
+// deadLetterDir returns the directory of the spool files of the input. The
+// global paths are used if beatPaths is nil.
+func deadLetterDir(cfg deadLetterConfig, beatPaths *paths.Path) string {
+	if beatPaths == nil {
+		return paths.Resolve(paths.Data, cfg.Path)
+	}
+	return beatPaths.Resolve(paths.Data, cfg.Path)
+}
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// spoolPath returns the path of the spool file of the input.
+func spoolPath(dir, inputID string) string {
+	return filepath.Join(dir, unsafeFileChars.ReplaceAllString(inputID, "_")+".ndjson")
+}
+
+// fileDeadLetter appends the log events to a spool file, one JSON object per
+// line.
+type fileDeadLetter struct {
+	mu   sync.Mutex
+	path string
+}
+
+func (d *fileDeadLetter) write(_ context.Context, records []*deadLetterRecord) error {
+	if len(records) == 0 {
+		return nil
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return appendRecords(d.path, records)
+}
+
+// appendRecords appends the records to the file at path, which is synced
+// before returning.
+func appendRecords(path string, records []*deadLetterRecord) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create dead letter directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open dead letter file: %w", err)
+	}
+	enc := json.NewEncoder(f)
+	for _, r := range records {
+		if err := enc.Encode(r); err != nil {
+			f.Close()
+			return fmt.Errorf("failed to write dead letter file: %w", err)
+		}
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return fmt.Errorf("failed to sync dead letter file: %w", err)
+	}
+	return f.Close()
+}
+
+// sqsAPI is the part of the SQS client used by the dead letter store.
+type sqsAPI interface {
+	SendMessageBatch(ctx context.Context, params *sqs.SendMessageBatchInput, optFns ...func(*sqs.Options)) (*sqs.SendMessageBatchOutput, error)
+	ReceiveMessage(ctx context.Context, params *sqs.ReceiveMessageInput, optFns ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error)
+	DeleteMessageBatch(ctx context.Context, params *sqs.DeleteMessageBatchInput, optFns ...func(*sqs.Options)) (*sqs.DeleteMessageBatchOutput, error)
+}
+
+// sqsDeadLetter sends the log events to an SQS queue, one message per log
+// event.
+type sqsDeadLetter struct {
+	svc      sqsAPI
+	queueURL string
+}
+
+func (d *sqsDeadLetter) write(ctx context.Context, records []*deadLetterRecord) error {
+	for start := 0; start < len(records); start += sqsMaxBatchSize {
+		batch := records[start:min(start+sqsMaxBatchSize, len(records))]
+		entries := make([]sqstypes.SendMessageBatchRequestEntry, 0, len(batch))
+		for i, r := range batch {
+			body, err := json.Marshal(r)
+			if err != nil {
+				return err
+			}
+			entries = append(entries, sqstypes.SendMessageBatchRequestEntry{
+				Id:          awssdk.String(strconv.Itoa(i)),
+				MessageBody: awssdk.String(string(body)),
+			})
+		}
+		out, err := d.svc.SendMessageBatch(ctx, &sqs.SendMessageBatchInput{
+			QueueUrl: awssdk.String(d.queueURL),
+			Entries:  entries,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to send log events to the dead letter queue: %w", err)
+		}
+		if len(out.Failed) != 0 {
+			f := out.Failed[0]
+			return fmt.Errorf("failed to send %d log events to the dead letter queue: %s: %s",
+				len(out.Failed), awssdk.ToString(f.Code), awssdk.ToString(f.Message))
+		}
+	}
+	return nil
+}
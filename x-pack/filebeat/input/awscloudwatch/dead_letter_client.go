@@ -0,0 +1,253 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+)
+
+// deadLetterRetryInterval is the time between the attempts to publish an
+// event that could not enter the queue because it is full.
+var deadLetterRetryInterval = time.Second
+
+// pendingEvent is the private field of the events published with
+// dead_letter.enabled.
+type pendingEvent struct {
+	// rec is the record of the log event the event was created from, and
+	// index the index of the event among its events.
+	rec   *deadLetterRecord
+	index int
+	// work is the work the event was published for.
+	work *pendingWork
+}
+
+// pendingWork tracks the events published for a work until they are
+// acknowledged, or until their log events are known to be lost because the
+// queue stayed full.
+type pendingWork struct {
+	mu sync.Mutex
+	// queued is the number of events in the queue, not acknowledged yet.
+	queued int
+	// ended is set once all the events of the work were published.
+	ended bool
+	// lost are the records of the log events with events that did not
+	// enter the queue.
+	lost   []*deadLetterRecord
+	done   chan struct{}
+	closed bool
+}
+
+func newPendingWork() *pendingWork {
+	return &pendingWork{done: make(chan struct{})}
+}
+
+// add tracks an event that entered the queue.
+func (w *pendingWork) add() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.queued++
+}
+
+// resolve stops tracking n events of the queue, acknowledged or dropped.
+func (w *pendingWork) resolve(n int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.queued -= n
+	w.complete()
+}
+
+// lose records that the event at index of the log event of rec did not
+// enter the queue.
+func (w *pendingWork) lose(rec *deadLetterRecord, index int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(rec.Events) == 0 {
+		w.lost = append(w.lost, rec)
+	}
+	rec.Events = append(rec.Events, index)
+}
+
+// end records that all the events of the work were published. Done is closed
+// once they are all acknowledged.
+func (w *pendingWork) end() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.ended = true
+	w.complete()
+}
+
+func (w *pendingWork) complete() {
+	if w.ended && w.queued == 0 && !w.closed {
+		w.closed = true
+		close(w.done)
+	}
+}
+
+// lostRecords returns the records of the log events with events that did not
+// enter the queue.
+func (w *pendingWork) lostRecords() []*deadLetterRecord {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.lost
+}
+
+// deadLetterClient publishes the events of a worker with dead_letter.enabled.
+// Its pipeline client drops the events instead of blocking when the queue is
+// full. A dropped event is published again every deadLetterRetryInterval
+// until the queue has been full for timeout, then it is recorded as lost in
+// its work, to write its log event to the dead letter store. The events that
+// entered the queue are delivered once the outputs recover, so no event is
+// both delivered and dead-lettered.
+//
+// It is the event listener and the client listener of its pipeline client,
+// to track the events of the queue until they are acknowledged.
+type deadLetterClient struct {
+	beat.Client
+
+	timeout time.Duration
+	// stop aborts the attempts to publish a dropped event.
+	stop <-chan struct{}
+	// work is the work of the events published, set by the worker.
+	work *pendingWork
+	// fullSince is when the queue was found full, zero if the last event
+	// entered it.
+	fullSince time.Time
+
+	mu sync.Mutex
+	// queue are the events that entered the queue and are not acknowledged
+	// yet, in the order they were published.
+	queue []*pendingEvent
+	// added is set when an event is added to the queue, until it is
+	// published or dropped.
+	added bool
+	// dropped is set when an event is dropped.
+	dropped bool
+}
+
+var (
+	_ beat.EventListener  = (*deadLetterClient)(nil)
+	_ beat.ClientListener = (*deadLetterClient)(nil)
+)
+
+func newDeadLetterClient(timeout time.Duration) *deadLetterClient {
+	return &deadLetterClient{timeout: timeout}
+}
+
+// Publish publishes the event, again until it enters the queue or the queue
+// has been full for the timeout.
+func (c *deadLetterClient) Publish(event beat.Event) {
+	pe, _ := event.Private.(*pendingEvent)
+	if pe != nil {
+		pe.work = c.work
+	}
+	for !c.tryPublish(event) {
+		if c.fullSince.IsZero() {
+			c.fullSince = time.Now()
+		}
+		if time.Since(c.fullSince) >= c.timeout || !c.waitRetry() {
+			if pe != nil && pe.work != nil {
+				pe.work.lose(pe.rec, pe.index)
+			}
+			return
+		}
+	}
+	c.fullSince = time.Time{}
+}
+
+// tryPublish publishes the event and returns whether it entered the queue.
+func (c *deadLetterClient) tryPublish(event beat.Event) bool {
+	c.mu.Lock()
+	c.dropped = false
+	c.mu.Unlock()
+
+	c.Client.Publish(event)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return !c.dropped
+}
+
+// waitRetry waits for deadLetterRetryInterval, and returns false if stop is
+// closed before.
+func (c *deadLetterClient) waitRetry() bool {
+	select {
+	case <-c.stop:
+		return false
+	case <-time.After(deadLetterRetryInterval):
+		return true
+	}
+}
+
+func (c *deadLetterClient) PublishAll(events []beat.Event) {
+	for _, e := range events {
+		c.Publish(e)
+	}
+}
+
+func (c *deadLetterClient) AddEvent(event beat.Event, published bool) {
+	if !published {
+		// Filtered out by the processors, there is nothing to wait for.
+		return
+	}
+	pe, _ := event.Private.(*pendingEvent)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.queue = append(c.queue, pe)
+	c.added = true
+	if pe != nil && pe.work != nil {
+		pe.work.add()
+	}
+}
+
+func (c *deadLetterClient) ACKEvents(n int) {
+	c.mu.Lock()
+	acked := c.queue[:n]
+	c.queue = c.queue[n:]
+	c.mu.Unlock()
+
+	counts := map[*pendingWork]int{}
+	for _, pe := range acked {
+		if pe != nil && pe.work != nil {
+			counts[pe.work]++
+		}
+	}
+	for w, n := range counts {
+		w.resolve(n)
+	}
+}
+
+func (c *deadLetterClient) ClientClosed() {}
+
+func (c *deadLetterClient) Closing()  {}
+func (c *deadLetterClient) Closed()   {}
+func (c *deadLetterClient) NewEvent() {}
+func (c *deadLetterClient) Filtered() {}
+
+func (c *deadLetterClient) Published() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.added = false
+}
+
+// DroppedOnPublish removes the dropped event from the queue. The queue never
+// acknowledges it, it is the last event added.
+func (c *deadLetterClient) DroppedOnPublish(beat.Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dropped = true
+	if !c.added {
+		// Dropped by a closed client, it was not added.
+		return
+	}
+	c.added = false
+	pe := c.queue[len(c.queue)-1]
+	c.queue = c.queue[:len(c.queue)-1]
+	if pe != nil && pe.work != nil {
+		pe.work.resolve(1)
+	}
+}
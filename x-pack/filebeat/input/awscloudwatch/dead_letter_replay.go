@@ -0,0 +1,256 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/acker"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+// replaySuffix is the suffix of the replay files, holding the log events of
+// the dead letter store to publish again.
+const replaySuffix = ".replay.ndjson"
+
+// replayPath returns the path of a new replay file of the input.
+func replayPath(dir, inputID string, now time.Time) string {
+	spool := spoolPath(dir, inputID)
+	return strings.TrimSuffix(spool, ".ndjson") + "-" + strconv.FormatInt(now.UnixNano(), 10) + replaySuffix
+}
+
+// replayFiles returns the replay files of the input, oldest first.
+func replayFiles(dir, inputID string) ([]string, error) {
+	spool := spoolPath(dir, inputID)
+	files, err := filepath.Glob(strings.TrimSuffix(spool, ".ndjson") + "-*" + replaySuffix)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// readRecords reads the log events of a spool or replay file. The lines that
+// can't be decoded are skipped and counted.
+func readRecords(path string) (records []*deadLetterRecord, skipped int, err error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	// CloudWatch log events are up to 256 KiB.
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var r deadLetterRecord
+		if err := json.Unmarshal(line, &r); err != nil || r.EventID == "" {
+			skipped++
+			continue
+		}
+		records = append(records, &r)
+	}
+	return records, skipped, scanner.Err()
+}
+
+// deadLetterReplayer publishes the log events of the replay files of the
+// input created by the replay-dead-letter command. A replay file is removed
+// once all its events are acknowledged.
+type deadLetterReplayer struct {
+	dir       string
+	inputID   string
+	interval  time.Duration
+	client    beat.Client
+	processor *logProcessor
+	tracker   *ackTracker
+	metrics   *inputMetrics
+	log       *logp.Logger
+}
+
+func newDeadLetterReplayer(cfg config, dir, inputID string, metrics *inputMetrics, pipeline beat.Pipeline, log *logp.Logger) (*deadLetterReplayer, error) {
+	// The replayed log events are processed like the ones read from their
+	// log group.
+	overrideProcs, err := newOverrideProcessors(cfg.LogGroupOverrides, log)
+	if err != nil {
+		return nil, err
+	}
+	// The replayed events wait for the queue, they are not written to the
+	// dead letter store again.
+	tracker := newACKTracker()
+	client, err := pipeline.ConnectWith(beat.ClientConfig{
+		EventListener: acker.TrackingCounter(func(_ int, by int) {
+			tracker.increaseAck(by)
+		}),
+	})
+	if err != nil {
+		tracker.close()
+		overrideProcs.close()
+		return nil, fmt.Errorf("failed to create pipeline client: %w", err)
+	}
+	processor := newLogProcessor(log, metrics, client, cfg.EventMapping)
+	processor.overrides = cfg.LogGroupOverrides
+	processor.overrideProcs = overrideProcs
+	return &deadLetterReplayer{
+		dir:       dir,
+		inputID:   inputID,
+		interval:  cfg.ScanFrequency,
+		client:    client,
+		processor: processor,
+		tracker:   tracker,
+		metrics:   processor.metrics,
+		log:       log,
+	}, nil
+}
+
+// run replays the replay files at each interval until ctx is done.
+func (r *deadLetterReplayer) run(ctx context.Context) {
+	defer r.processor.overrideProcs.close()
+	defer r.client.Close()
+	defer r.tracker.close()
+	for {
+		r.replay(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// replay publishes the log events of the replay files.
+func (r *deadLetterReplayer) replay(ctx context.Context) {
+	files, err := replayFiles(r.dir, r.inputID)
+	if err != nil {
+		r.log.Errorw("Failed to list the dead letter replay files", "error", err)
+		return
+	}
+	for _, path := range files {
+		if ctx.Err() != nil {
+			return
+		}
+		records, skipped, err := readRecords(path)
+		if err != nil {
+			r.log.Errorw("Failed to read a dead letter replay file", "path", path, "error", err)
+			continue
+		}
+		if skipped != 0 {
+			r.log.Warnw("Skipped invalid lines of a dead letter replay file", "path", path, "lines", skipped)
+		}
+		var published int
+		for _, rec := range records {
+			published += r.processor.processRecord(rec)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.tracker.waitFor(published):
+		}
+		if err := os.Remove(path); err != nil {
+			r.log.Errorw("Failed to remove a replayed dead letter file", "path", path, "error", err)
+		}
+		r.metrics.deadLetterReplayedTotal.Add(uint64(len(records)))
+		r.log.Infow("Replayed the log events of the dead letter store", "path", path, "log_events", len(records))
+	}
+}
+
+// RequestReplay moves the log events of the dead letter store of the
+// aws-cloudwatch input configured by cfg to a new replay file, which the
+// input publishes while it runs. The log events are received from the queue
+// for an SQS dead letter store. It returns the number of log events to
+// replay.
+func RequestReplay(ctx context.Context, cfg *conf.C, beatPaths *paths.Path, log *logp.Logger) (int, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return 0, err
+	}
+	if !config.DeadLetter.Enabled {
+		return 0, errors.New("dead_letter is not enabled")
+	}
+	dir := deadLetterDir(config.DeadLetter, beatPaths)
+	replay := replayPath(dir, config.ID, time.Now())
+
+	if config.DeadLetter.Type == deadLetterSQS {
+		awsConfig, err := awscommon.InitializeAWSConfig(config.AWSConfig, log)
+		if err != nil {
+			return 0, fmt.Errorf("failed to initialize AWS credentials: %w", err)
+		}
+		return receiveDeadLetters(ctx, sqs.NewFromConfig(awsConfig), config.DeadLetter.QueueURL, replay)
+	}
+
+	spool := spoolPath(dir, config.ID)
+	if err := os.Rename(spool, replay); err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to move the dead letter file: %w", err)
+	}
+	records, _, err := readRecords(replay)
+	return len(records), err
+}
+
+// receiveDeadLetters receives the log events of the dead letter queue and
+// writes them to the replay file. The messages are deleted once written.
+func receiveDeadLetters(ctx context.Context, svc sqsAPI, queueURL, replay string) (int, error) {
+	var count int
+	for ctx.Err() == nil {
+		out, err := svc.ReceiveMessage(ctx, &sqs.ReceiveMessageInput{
+			QueueUrl:            awssdk.String(queueURL),
+			MaxNumberOfMessages: sqsMaxBatchSize,
+			WaitTimeSeconds:     1,
+		})
+		if err != nil {
+			return count, fmt.Errorf("failed to receive log events from the dead letter queue: %w", err)
+		}
+		if len(out.Messages) == 0 {
+			return count, nil
+		}
+
+		records := make([]*deadLetterRecord, 0, len(out.Messages))
+		entries := make([]sqstypes.DeleteMessageBatchRequestEntry, 0, len(out.Messages))
+		for i, m := range out.Messages {
+			var r deadLetterRecord
+			if err := json.Unmarshal([]byte(awssdk.ToString(m.Body)), &r); err == nil && r.EventID != "" {
+				records = append(records, &r)
+			}
+			entries = append(entries, sqstypes.DeleteMessageBatchRequestEntry{
+				Id:            awssdk.String(strconv.Itoa(i)),
+				ReceiptHandle: m.ReceiptHandle,
+			})
+		}
+		if err := appendRecords(replay, records); err != nil {
+			return count, err
+		}
+		count += len(records)
+		if _, err := svc.DeleteMessageBatch(ctx, &sqs.DeleteMessageBatchInput{
+			QueueUrl: awssdk.String(queueURL),
+			Entries:  entries,
+		}); err != nil {
+			return count, fmt.Errorf("failed to delete log events from the dead letter queue: %w", err)
+		}
+	}
+	return count, ctx.Err()
+}
@@ -0,0 +1,355 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
+	sqstypes "github.com/aws/aws-sdk-go-v2/service/sqs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	pubtest "github.com/elastic/beats/v7/libbeat/publisher/testing"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+type fakeDeadLetter struct {
+	records []*deadLetterRecord
+	err     error
+}
+
+func (d *fakeDeadLetter) write(_ context.Context, records []*deadLetterRecord) error {
+	if d.err != nil {
+		return d.err
+	}
+	d.records = append(d.records, records...)
+	return nil
+}
+
+func testLogEvent(id string) types.FilteredLogEvent {
+	return types.FilteredLogEvent{
+		EventId:       awssdk.String(id),
+		LogStreamName: awssdk.String("stream"),
+		Message:       awssdk.String("message " + id),
+		Timestamp:     awssdk.Int64(1600000000000),
+	}
+}
+
+// fakeQueueClient is a pipeline client with a queue of size events, which
+// drops the events when it is full like a DropIfFull client.
+type fakeQueueClient struct {
+	listener  *deadLetterClient
+	size      int
+	queued    []beat.Event
+	delivered []beat.Event
+}
+
+func (c *fakeQueueClient) Publish(e beat.Event) {
+	c.listener.AddEvent(e, true)
+	if len(c.queued) >= c.size {
+		c.listener.DroppedOnPublish(e)
+		return
+	}
+	c.queued = append(c.queued, e)
+	c.listener.Published()
+}
+
+func (c *fakeQueueClient) PublishAll(events []beat.Event) {
+	for _, e := range events {
+		c.Publish(e)
+	}
+}
+
+func (c *fakeQueueClient) Close() error { return nil }
+
+// deliver delivers and acknowledges the events of the queue.
+func (c *fakeQueueClient) deliver() {
+	n := len(c.queued)
+	c.delivered = append(c.delivered, c.queued...)
+	c.queued = nil
+	c.listener.ACKEvents(n)
+}
+
+func TestDeadLetterClient(t *testing.T) {
+	retryInterval := deadLetterRetryInterval
+	deadLetterRetryInterval = time.Millisecond
+	defer func() { deadLetterRetryInterval = retryInterval }()
+
+	dlc := newDeadLetterClient(20 * time.Millisecond)
+	queue := &fakeQueueClient{listener: dlc, size: 1}
+	dlc.Client = queue
+	mapping := defaultEventMappingConfig()
+	mapping.Decoding.Format = decodingNDJSON
+	p := newLogProcessor(logp.NewLogger("test"), nil, dlc, mapping)
+	p.deadLetter = true
+
+	// The queue is full after the first event of id-1, its second event is
+	// published again until the timeout, the event of id-2 is not since the
+	// queue is still full.
+	work := newPendingWork()
+	dlc.work = work
+	ndjson := testLogEvent("id-1")
+	ndjson.Message = awssdk.String("{\"n\":1}\n{\"n\":2}")
+	start := time.Now()
+	assert.Equal(t, 3, p.processLogEvents([]types.FilteredLogEvent{ndjson, testLogEvent("id-2")}, "app", "us-east-1", ""))
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+	work.end()
+
+	select {
+	case <-work.done:
+		t.Fatal("the work must wait for the events in the queue")
+	default:
+	}
+	queue.deliver()
+	select {
+	case <-work.done:
+	case <-time.After(time.Second):
+		t.Fatal("the work must be done once the events in the queue are acknowledged")
+	}
+
+	lost := work.lostRecords()
+	require.Len(t, lost, 2)
+	assert.Equal(t, "id-1", lost[0].EventID)
+	assert.Equal(t, []int{1}, lost[0].Events, "only the dropped event of the log event is dead-lettered")
+	assert.Equal(t, "id-2", lost[1].EventID)
+	assert.Equal(t, []int{0}, lost[1].Events)
+
+	// Once the outputs recover, the events enter the queue again.
+	next := newPendingWork()
+	dlc.work = next
+	assert.Equal(t, 1, p.processLogEvents([]types.FilteredLogEvent{testLogEvent("id-3")}, "app", "us-east-1", ""))
+	next.end()
+	queue.deliver()
+	<-next.done
+	assert.Empty(t, next.lostRecords())
+
+	// The replay of the dead letter store publishes the other events, each
+	// event is delivered once.
+	var replayed []beat.Event
+	client := pubtest.NewChanClientWithCallback(1, func(event beat.Event) {
+		replayed = append(replayed, event)
+	})
+	replayer := newLogProcessor(logp.NewLogger("test"), nil, client, mapping)
+	for _, rec := range lost {
+		replayer.processRecord(rec)
+	}
+	var ids []interface{}
+	for _, e := range append(queue.delivered, replayed...) {
+		ids = append(ids, e.Meta["_id"])
+	}
+	assert.ElementsMatch(t, []interface{}{"id-1-0", "id-1-1", "id-2", "id-3"}, ids)
+}
+
+func TestCompleteDeadLetter(t *testing.T) {
+	ctx := context.Background()
+	dl := &fakeDeadLetter{}
+	cfg := defaultConfig()
+	cfg.DeadLetter.ACKTimeout = 10 * time.Millisecond
+	handler, err := newStateHandler(nil, cfg, createTestInputStore())
+	require.NoError(t, err)
+	w := &cwWorker{
+		config:     cfg,
+		deadLetter: dl,
+		log:        logp.NewLogger("test"),
+		metrics:    newInputMetrics(monitoring.NewRegistry()),
+	}
+	t1 := time.Unix(1792152000, 0)
+	work := workResponse{logGroupId: "app", startTime: t1.Add(-time.Minute), endTime: t1}
+	handler.WorkRegisterLogGroups(t1.UnixMilli(), []string{"app"})
+	checkpoint := func() int64 {
+		// pause for backgroundRunner to run
+		<-time.After(100 * time.Millisecond)
+		state, _, err := handler.GetLogGroupState("app")
+		require.NoError(t, err)
+		return state.LastSyncEpoch
+	}
+
+	// The worker moves on after dead_letter.ack_timeout, the work completes
+	// once its events in the queue are acknowledged.
+	pending := newPendingWork()
+	pending.add()
+	pending.lose(newDeadLetterRecord(testLogEvent("id-1"), "app", "us-east-1", ""), 0)
+	pending.end()
+	w.completeDeadLetter(ctx, handler, work, pending, nil)
+	assert.Zero(t, checkpoint())
+	assert.Empty(t, dl.records, "the log events are dead-lettered with the work")
+
+	pending.resolve(1)
+	w.completions.Wait()
+	assert.Equal(t, t1.UnixMilli(), checkpoint())
+	require.Len(t, dl.records, 1)
+	assert.Equal(t, "id-1", dl.records[0].EventID)
+	assert.Equal(t, uint64(1), w.metrics.deadLetterEventsTotal.Get())
+
+	// The work does not complete if the log events can't be written.
+	dl.err = errors.New("disk full")
+	pending = newPendingWork()
+	pending.lose(newDeadLetterRecord(testLogEvent("id-2"), "app", "us-east-1", ""), 0)
+	pending.end()
+	cancelCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	assert.False(t, w.settle(cancelCtx, "app", pending))
+	assert.Positive(t, w.metrics.deadLetterErrorsTotal.Get())
+}
+
+func TestDeadLetterReplayFile(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
+		"id":             "cw/1",
+		"log_group_name": "app",
+		"region_name":    "us-east-1",
+		"dead_letter": map[string]interface{}{
+			"enabled": true,
+			"path":    dir,
+		},
+	})
+
+	n, err := RequestReplay(ctx, cfg, nil, logp.NewLogger("test"))
+	require.NoError(t, err)
+	assert.Zero(t, n, "nothing is replayed without a spool file")
+
+	dl := newDeadLetterWriter(deadLetterConfig{Type: deadLetterFile}, dir, "cw/1", nil)
+	ingestion := int64(1600000001000)
+	rec := newDeadLetterRecord(testLogEvent("id-1"), "app", "us-east-1", "111")
+	rec.IngestionTime = &ingestion
+	require.NoError(t, dl.write(ctx, []*deadLetterRecord{rec}))
+	require.NoError(t, dl.write(ctx, []*deadLetterRecord{newDeadLetterRecord(testLogEvent("id-2"), "app", "us-east-1", "")}))
+	assert.FileExists(t, filepath.Join(dir, "cw_1.ndjson"))
+
+	n, err = RequestReplay(ctx, cfg, nil, logp.NewLogger("test"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.NoFileExists(t, filepath.Join(dir, "cw_1.ndjson"), "the spool file is moved to a replay file")
+
+	files, err := replayFiles(dir, "cw/1")
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	f, err := os.OpenFile(files[0], os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString("not json\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	records, skipped, err := readRecords(files[0])
+	require.NoError(t, err)
+	assert.Equal(t, 1, skipped)
+	require.Len(t, records, 2)
+	assert.Equal(t, rec, records[0])
+	assert.Equal(t, testLogEvent("id-2"), records[1].logEvent())
+}
+
+func TestDeadLetterReplayer(t *testing.T) {
+	dir := t.TempDir()
+	path := replayPath(dir, "cw-1", time.Unix(0, 1))
+	require.NoError(t, appendRecords(path, []*deadLetterRecord{
+		newDeadLetterRecord(testLogEvent("id-1"), "arn:aws:logs:eu-west-1:222:log-group:app", "eu-west-1", "222"),
+	}))
+
+	var events []beat.Event
+	tracker := newACKTracker()
+	defer tracker.close()
+	client := pubtest.NewChanClientWithCallback(1, func(event beat.Event) {
+		events = append(events, event)
+		// The events are acknowledged as soon as they are published.
+		go tracker.increaseAck(1)
+	})
+	processor := newLogProcessor(logp.NewLogger("test"), nil, client, defaultEventMappingConfig())
+	r := &deadLetterReplayer{
+		dir:       dir,
+		inputID:   "cw-1",
+		client:    client,
+		processor: processor,
+		tracker:   tracker,
+		metrics:   processor.metrics,
+		log:       logp.NewLogger("test"),
+	}
+
+	r.replay(context.Background())
+	require.Len(t, events, 1)
+	assert.Equal(t, "id-1", events[0].Meta["_id"])
+	account, _ := events[0].Fields.GetValue("cloud.account.id")
+	assert.Equal(t, "222", account)
+	assert.NoFileExists(t, path, "the replay file is removed once its events are acknowledged")
+	assert.Equal(t, uint64(1), r.metrics.deadLetterReplayedTotal.Get())
+}
+
+type fakeSQS struct {
+	sent     []sqstypes.SendMessageBatchRequestEntry
+	messages []sqstypes.Message
+	deleted  int
+}
+
+func (f *fakeSQS) SendMessageBatch(_ context.Context, params *sqs.SendMessageBatchInput, _ ...func(*sqs.Options)) (*sqs.SendMessageBatchOutput, error) {
+	f.sent = append(f.sent, params.Entries...)
+	for _, e := range params.Entries {
+		f.messages = append(f.messages, sqstypes.Message{Body: e.MessageBody, ReceiptHandle: e.Id})
+	}
+	return &sqs.SendMessageBatchOutput{}, nil
+}
+
+func (f *fakeSQS) ReceiveMessage(_ context.Context, params *sqs.ReceiveMessageInput, _ ...func(*sqs.Options)) (*sqs.ReceiveMessageOutput, error) {
+	n := min(int(params.MaxNumberOfMessages), len(f.messages))
+	out := &sqs.ReceiveMessageOutput{Messages: f.messages[:n]}
+	f.messages = f.messages[n:]
+	return out, nil
+}
+
+func (f *fakeSQS) DeleteMessageBatch(_ context.Context, params *sqs.DeleteMessageBatchInput, _ ...func(*sqs.Options)) (*sqs.DeleteMessageBatchOutput, error) {
+	f.deleted += len(params.Entries)
+	return &sqs.DeleteMessageBatchOutput{}, nil
+}
+
+func TestDeadLetterSQS(t *testing.T) {
+	ctx := context.Background()
+	svc := &fakeSQS{}
+	dl := newDeadLetterWriter(deadLetterConfig{Type: deadLetterSQS, QueueURL: "https://sqs.us-east-1.amazonaws.com/111/dlq"}, "", "cw-1", svc)
+
+	records := make([]*deadLetterRecord, 0, 25)
+	for i := 0; i < 25; i++ {
+		records = append(records, newDeadLetterRecord(testLogEvent(time.Duration(i).String()), "app", "us-east-1", ""))
+	}
+	require.NoError(t, dl.write(ctx, records))
+	assert.Len(t, svc.sent, 25, "the log events are sent in batches of 10 messages")
+
+	replay := replayPath(t.TempDir(), "cw-1", time.Now())
+	n, err := receiveDeadLetters(ctx, svc, "https://sqs.us-east-1.amazonaws.com/111/dlq", replay)
+	require.NoError(t, err)
+	assert.Equal(t, 25, n)
+	assert.Equal(t, 25, svc.deleted)
+	received, _, err := readRecords(replay)
+	require.NoError(t, err)
+	assert.Equal(t, records, received)
+}
+
+func TestDeadLetterConfig(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.LogGroupName = "app"
+	cfg.RegionName = "us-east-1"
+	cfg.DeadLetter.Enabled = true
+	assert.ErrorContains(t, cfg.Validate(), "id is required with dead_letter.enabled")
+
+	cfg.ID = "cw-1"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Mode = modeLiveTail
+	assert.ErrorContains(t, cfg.Validate(), "dead_letter can only be used with mode poll")
+
+	dl := deadLetterConfig{Enabled: true, Type: deadLetterSQS}
+	assert.ErrorContains(t, dl.Validate(), "dead_letter.queue_url is required")
+	dl.Type = "kafka"
+	assert.ErrorContains(t, dl.Validate(), "dead_letter.type can only be one of")
+}
@@ -0,0 +1,261 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/jsontransform"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	decodingJSON   = "json"
+	decodingNDJSON = "ndjson"
+	decodingEMF    = "emf"
+	decodingAuto   = "auto"
+)
+
+// decodingConfig configures the decoding of the structured messages of the
+// log events.
+type decodingConfig struct {
+	// Format is the format of the messages: json, ndjson, emf or auto. The
+	// messages are not decoded when it is empty.
+	Format string `config:"format"`
+	// Target is the field the decoded fields are written to. They are
+	// written to the root of the event when it is empty.
+	Target string `config:"target"`
+	// OverwriteKeys lets the decoded fields replace the fields of the event
+	// when they are written to its root.
+	OverwriteKeys bool `config:"overwrite_keys"`
+	// ExpandKeys expands the dotted keys of the decoded fields.
+	ExpandKeys bool `config:"expand_keys"`
+	// AddErrorKey adds error.message to the events whose message could not
+	// be decoded. The events are published with their message.
+	AddErrorKey bool `config:"add_error_key"`
+}
+
+func (c *decodingConfig) Validate() error {
+	switch c.Format {
+	case "", decodingJSON, decodingNDJSON, decodingEMF, decodingAuto:
+		return nil
+	}
+	return fmt.Errorf("decoding.format can only be one of %s, %s, %s or %s", decodingJSON, decodingNDJSON, decodingEMF, decodingAuto)
+}
+
+var errNotEMF = errors.New("message is not in the embedded metric format")
+
+// decodedMessage is a JSON object decoded from a message. raw is the JSON
+// text of the object, the line it was read from for ndjson messages.
+type decodedMessage struct {
+	raw    string
+	fields mapstr.M
+}
+
+// decode decodes the message of event according to the decoding
+// configuration. An ndjson message yields one event per line. The event is
+// returned unchanged if the message is not decoded, with error.message set if
+// decoding failed.
+func (p *logProcessor) decode(event beat.Event, message string) []beat.Event {
+	cfg := p.mapping.Decoding
+	if cfg.Format == "" {
+		return []beat.Event{event}
+	}
+	decoded, err := decodeMessage(cfg.Format, message)
+	if err != nil {
+		p.metrics.decodingErrorsTotal.Inc()
+		p.log.Debugw("failed to decode message", "error", err, "event_id", event.Meta["_id"])
+		event.SetErrorWithOption(fmt.Sprintf("failed to decode message: %v", err), cfg.AddErrorKey, "", p.mapping.MessageTargetField)
+		return []beat.Event{event}
+	}
+	if len(decoded) == 0 {
+		return []beat.Event{event}
+	}
+
+	events := make([]beat.Event, 0, len(decoded))
+	for i, d := range decoded {
+		e := event
+		if len(decoded) > 1 {
+			e = beat.Event{
+				Timestamp: event.Timestamp,
+				Meta:      event.Meta.Clone(),
+				Fields:    event.Fields.Clone(),
+			}
+			if id, ok := event.Meta["_id"]; ok {
+				e.SetID(fmt.Sprintf("%v-%d", id, i))
+			}
+			_, _ = e.Fields.Put(p.mapping.MessageTargetField, d.raw)
+		}
+		p.writeFields(&e, d.fields)
+		events = append(events, e)
+	}
+	return events
+}
+
+// writeFields writes the decoded fields to the event. The metadata of
+// embedded metric format objects is added to the CloudWatch fields and their
+// timestamp is the time of the event.
+func (p *logProcessor) writeFields(event *beat.Event, fields mapstr.M) {
+	cfg := p.mapping.Decoding
+	if cfg.Format == decodingEMF || (cfg.Format == decodingAuto && isEMF(fields)) {
+		emf, ts, err := decodeEMF(fields)
+		if err != nil {
+			p.metrics.decodingErrorsTotal.Inc()
+			event.SetErrorWithOption(fmt.Sprintf("failed to decode message: %v", err), cfg.AddErrorKey, "", p.mapping.MessageTargetField)
+			return
+		}
+		_, _ = event.Fields.Put(p.mapping.CloudWatchTargetField+".emf", emf)
+		if !ts.IsZero() {
+			event.Timestamp = ts
+		}
+	}
+
+	if cfg.Target == "" {
+		jsontransform.WriteJSONKeys(event, fields, cfg.ExpandKeys, cfg.OverwriteKeys, cfg.AddErrorKey)
+		return
+	}
+	if cfg.ExpandKeys {
+		jsontransform.ExpandFields(p.log, event, fields, cfg.AddErrorKey)
+	}
+	_, _ = event.Fields.Put(cfg.Target, fields)
+}
+
+// decodeMessage decodes the JSON objects of a message. In auto mode, the
+// messages that do not start like a JSON object are not decoded, and the
+// messages holding more than one object are decoded as ndjson.
+func decodeMessage(format, message string) ([]decodedMessage, error) {
+	message = strings.TrimSpace(message)
+	switch format {
+	case decodingNDJSON:
+		return decodeLines(message)
+	case decodingAuto:
+		if !strings.HasPrefix(message, "{") {
+			return nil, nil
+		}
+		if fields, err := decodeObject(message); err == nil {
+			return []decodedMessage{{raw: message, fields: fields}}, nil
+		}
+		return decodeLines(message)
+	}
+	fields, err := decodeObject(message)
+	if err != nil {
+		return nil, err
+	}
+	return []decodedMessage{{raw: message, fields: fields}}, nil
+}
+
+// decodeLines decodes a message holding one JSON object per line. Empty
+// lines are skipped.
+func decodeLines(message string) ([]decodedMessage, error) {
+	var decoded []decodedMessage
+	for i, line := range strings.Split(message, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		fields, err := decodeObject(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		decoded = append(decoded, decodedMessage{raw: line, fields: fields})
+	}
+	return decoded, nil
+}
+
+// decodeObject decodes a text holding exactly one JSON object.
+func decodeObject(s string) (mapstr.M, error) {
+	dec := json.NewDecoder(strings.NewReader(s))
+	dec.UseNumber()
+	var fields mapstr.M
+	if err := dec.Decode(&fields); err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return nil, errors.New("message is not a JSON object")
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after the JSON object")
+	}
+	jsontransform.TransformNumbers(fields)
+	return fields, nil
+}
+
+// isEMF returns whether fields is an embedded metric format object.
+func isEMF(fields mapstr.M) bool {
+	meta, ok := fields["_aws"].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, ok = meta["CloudWatchMetrics"]
+	return ok
+}
+
+// decodeEMF removes the _aws metadata from an embedded metric format object.
+// It returns the metrics and dimensions the metadata describes, with their
+// values read from the object, and the time of the metrics.
+func decodeEMF(fields mapstr.M) (mapstr.M, time.Time, error) {
+	if !isEMF(fields) {
+		return nil, time.Time{}, errNotEMF
+	}
+	meta := fields["_aws"].(map[string]interface{})
+	directives, ok := meta["CloudWatchMetrics"].([]interface{})
+	if !ok {
+		return nil, time.Time{}, errors.New("_aws.CloudWatchMetrics is not an array")
+	}
+
+	var ts time.Time
+	if ms, ok := meta["Timestamp"].(int64); ok {
+		ts = time.UnixMilli(ms).UTC()
+	}
+	metrics := []mapstr.M{}
+	dimensions := mapstr.M{}
+	for _, d := range directives {
+		directive, ok := d.(map[string]interface{})
+		if !ok {
+			return nil, time.Time{}, errors.New("_aws.CloudWatchMetrics must hold objects")
+		}
+		namespace, _ := directive["Namespace"].(string)
+		sets, _ := directive["Dimensions"].([]interface{})
+		for _, set := range sets {
+			names, _ := set.([]interface{})
+			for _, n := range names {
+				if name, ok := n.(string); ok {
+					if v, ok := fields[name]; ok {
+						dimensions[name] = v
+					}
+				}
+			}
+		}
+		defs, _ := directive["Metrics"].([]interface{})
+		for _, m := range defs {
+			def, _ := m.(map[string]interface{})
+			name, _ := def["Name"].(string)
+			if name == "" {
+				continue
+			}
+			metric := mapstr.M{"namespace": namespace, "name": name}
+			if unit, ok := def["Unit"].(string); ok {
+				metric["unit"] = unit
+			}
+			if v, ok := fields[name]; ok {
+				metric["value"] = v
+			}
+			metrics = append(metrics, metric)
+		}
+	}
+	delete(fields, "_aws")
+
+	emf := mapstr.M{"metrics": metrics}
+	if len(dimensions) != 0 {
+		emf["dimensions"] = dimensions
+	}
+	return emf, ts, nil
+}
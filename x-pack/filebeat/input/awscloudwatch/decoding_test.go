@@ -0,0 +1,161 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	pubtest "github.com/elastic/beats/v7/libbeat/publisher/testing"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// processDecoded publishes a log event with message through a processor
+// decoding with cfg and returns the published events.
+func processDecoded(t *testing.T, cfg decodingConfig, message string) ([]beat.Event, *inputMetrics) {
+	t.Helper()
+	require.NoError(t, cfg.Validate())
+	var events []beat.Event
+	client := pubtest.NewChanClientWithCallback(1, func(event beat.Event) {
+		events = append(events, event)
+	})
+	mapping := defaultEventMappingConfig()
+	mapping.Decoding = cfg
+	p := newLogProcessor(logp.NewLogger("test"), nil, client, mapping)
+	p.processLogEvents([]types.FilteredLogEvent{{
+		EventId:       awssdk.String("id-1"),
+		LogStreamName: awssdk.String("stream"),
+		Message:       awssdk.String(message),
+		Timestamp:     awssdk.Int64(1600000000000),
+	}}, "/aws/lambda/app", "us-east-1", "")
+	return events, p.metrics
+}
+
+func getValue(t *testing.T, e beat.Event, key string) interface{} {
+	t.Helper()
+	v, err := e.Fields.GetValue(key)
+	require.NoError(t, err, key)
+	return v
+}
+
+func TestDecodeJSON(t *testing.T) {
+	message := `{"level":"info","msg":"started","cloud":{"region":"eu-west-1"},"http.status":200}`
+
+	t.Run("root", func(t *testing.T) {
+		events, _ := processDecoded(t, decodingConfig{Format: decodingJSON, AddErrorKey: true}, message)
+		require.Len(t, events, 1)
+		e := events[0]
+		assert.Equal(t, "info", getValue(t, e, "level"))
+		assert.Equal(t, int64(200), e.Fields["http.status"])
+		assert.Equal(t, "us-east-1", getValue(t, e, "cloud.region"), "the fields of the event are not overwritten")
+		assert.Equal(t, message, getValue(t, e, "message"))
+		assert.Equal(t, "id-1", e.Meta["_id"])
+	})
+
+	t.Run("target and overwrite", func(t *testing.T) {
+		events, _ := processDecoded(t, decodingConfig{Format: decodingJSON, Target: "app", ExpandKeys: true}, message)
+		require.Len(t, events, 1)
+		assert.Equal(t, int64(200), getValue(t, events[0], "app.http.status"))
+		assert.Equal(t, "us-east-1", getValue(t, events[0], "cloud.region"))
+
+		events, _ = processDecoded(t, decodingConfig{Format: decodingJSON, OverwriteKeys: true}, message)
+		assert.Equal(t, "eu-west-1", getValue(t, events[0], "cloud.region"))
+	})
+
+	t.Run("error", func(t *testing.T) {
+		events, metrics := processDecoded(t, decodingConfig{Format: decodingJSON, AddErrorKey: true}, "START RequestId: 1234")
+		require.Len(t, events, 1)
+		assert.Contains(t, getValue(t, events[0], "error.message"), "failed to decode message")
+		assert.Equal(t, "START RequestId: 1234", getValue(t, events[0], "message"))
+		assert.Equal(t, uint64(1), metrics.decodingErrorsTotal.Get())
+
+		events, _ = processDecoded(t, decodingConfig{Format: decodingJSON}, `{"a":1} {"b":2}`)
+		_, err := events[0].Fields.GetValue("error")
+		assert.Error(t, err, "the error is only logged without add_error_key")
+	})
+}
+
+func TestDecodeNDJSON(t *testing.T) {
+	events, metrics := processDecoded(t, decodingConfig{Format: decodingAuto, AddErrorKey: true}, "{\"n\":1}\n\n{\"n\":2}\r\n")
+	require.Len(t, events, 2)
+	for i, e := range events {
+		assert.Equal(t, int64(i+1), getValue(t, e, "n"))
+		assert.Equal(t, "/aws/lambda/app", getValue(t, e, "aws.cloudwatch.log_group"))
+	}
+	assert.Equal(t, `{"n":2}`, getValue(t, events[1], "message"))
+	assert.Equal(t, "id-1-0", events[0].Meta["_id"])
+	assert.Equal(t, "id-1-1", events[1].Meta["_id"])
+	assert.Equal(t, uint64(2), metrics.cloudwatchEventsCreatedTotal.Get())
+
+	events, _ = processDecoded(t, decodingConfig{Format: decodingNDJSON, AddErrorKey: true}, "{\"n\":1}\nplain text")
+	require.Len(t, events, 1)
+	assert.Contains(t, getValue(t, events[0], "error.message"), "line 2")
+}
+
+func TestDecodeAuto(t *testing.T) {
+	events, metrics := processDecoded(t, decodingConfig{Format: decodingAuto, AddErrorKey: true}, "REPORT RequestId: 1234 Duration: 2.5 ms")
+	require.Len(t, events, 1)
+	_, err := events[0].Fields.GetValue("error")
+	assert.Error(t, err, "plain text messages are not decoded in auto mode")
+	assert.Zero(t, metrics.decodingErrorsTotal.Get())
+}
+
+func TestDecodeEMF(t *testing.T) {
+	message := `{
+		"_aws": {
+			"Timestamp": 1700000000123,
+			"CloudWatchMetrics": [{
+				"Namespace": "app",
+				"Dimensions": [["service", "operation"]],
+				"Metrics": [{"Name": "latency", "Unit": "Milliseconds"}, {"Name": "errors"}]
+			}]
+		},
+		"service": "checkout",
+		"operation": "pay",
+		"latency": 12.5,
+		"errors": 0,
+		"requestId": "abc"
+	}`
+
+	for _, format := range []string{decodingEMF, decodingAuto} {
+		t.Run(format, func(t *testing.T) {
+			events, _ := processDecoded(t, decodingConfig{Format: format, AddErrorKey: true}, message)
+			require.Len(t, events, 1)
+			e := events[0]
+			assert.Equal(t, time.UnixMilli(1700000000123).UTC(), e.Timestamp)
+			assert.Equal(t, []mapstr.M{
+				{"namespace": "app", "name": "latency", "unit": "Milliseconds", "value": 12.5},
+				{"namespace": "app", "name": "errors", "value": int64(0)},
+			}, getValue(t, e, "aws.cloudwatch.emf.metrics"))
+			assert.Equal(t, mapstr.M{"service": "checkout", "operation": "pay"}, getValue(t, e, "aws.cloudwatch.emf.dimensions"))
+			assert.Equal(t, "abc", getValue(t, e, "requestId"))
+			_, err := e.Fields.GetValue("_aws")
+			assert.Error(t, err)
+		})
+	}
+
+	events, _ := processDecoded(t, decodingConfig{Format: decodingEMF, AddErrorKey: true}, `{"level":"info"}`)
+	assert.Contains(t, getValue(t, events[0], "error.message"), errNotEMF.Error())
+}
+
+func TestDecodingConfig(t *testing.T) {
+	cfg := decodingConfig{Format: "xml"}
+	assert.ErrorContains(t, cfg.Validate(), "decoding.format can only be one of")
+
+	c := defaultConfig()
+	c.LogGroupNamePrefix = "/aws/lambda/"
+	c.RegionName = "us-east-1"
+	c.Mode = modeInsights
+	c.Insights.Query = "fields @message"
+	c.EventMapping.Decoding.Format = decodingJSON
+	assert.ErrorContains(t, c.Validate(), "decoding.format cannot be used with mode insights")
+}
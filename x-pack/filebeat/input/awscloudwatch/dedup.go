@@ -0,0 +1,52 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"fmt"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// deduplicationConfig configures the suppression of the log events read
+// twice, like the ones whose timestamp is the end of a scan window and the
+// start of the next one.
+type deduplicationConfig struct {
+	Enabled bool `config:"enabled"`
+	// CacheSize is the number of the most recently published log events
+	// that are remembered.
+	CacheSize int `config:"cache_size" validate:"min=1"`
+}
+
+// eventDeduplicator remembers the IDs of the most recently published log
+// events. It is safe for concurrent use.
+type eventDeduplicator struct {
+	cache *lru.Cache[dedupKey, struct{}]
+}
+
+// dedupKey identifies a log event. The event IDs are only unique within a
+// log group, and the log groups of distinct accounts and regions can have
+// the same name.
+type dedupKey struct {
+	region, accountID, logGroupID, eventID string
+}
+
+func newEventDeduplicator(size int) (*eventDeduplicator, error) {
+	cache, err := lru.New[dedupKey, struct{}](size)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create deduplication cache: %w", err)
+	}
+	return &eventDeduplicator{cache: cache}, nil
+}
+
+// seen returns whether the log event was already seen, and remembers it
+// otherwise. The nil *eventDeduplicator has seen no log event.
+func (d *eventDeduplicator) seen(key dedupKey) bool {
+	if d == nil {
+		return false
+	}
+	found, _ := d.cache.ContainsOrAdd(key, struct{}{})
+	return found
+}
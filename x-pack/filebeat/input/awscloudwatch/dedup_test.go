@@ -0,0 +1,72 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	pubtest "github.com/elastic/beats/v7/libbeat/publisher/testing"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func TestEventDeduplicator(t *testing.T) {
+	d, err := newEventDeduplicator(2)
+	require.NoError(t, err)
+
+	key := dedupKey{region: "us-east-1", logGroupID: "/aws/lambda/app", eventID: "id-1"}
+	assert.False(t, d.seen(key))
+	assert.True(t, d.seen(key))
+
+	other := key
+	other.accountID = "123456789012"
+	assert.False(t, d.seen(other), "the log groups of distinct accounts are distinct")
+	other = key
+	other.region = "eu-west-1"
+	assert.False(t, d.seen(other), "the log groups of distinct regions are distinct")
+
+	// Only the most recent log events are remembered.
+	assert.False(t, d.seen(key))
+
+	var none *eventDeduplicator
+	assert.False(t, none.seen(key))
+	assert.False(t, none.seen(key))
+
+	_, err = newEventDeduplicator(0)
+	assert.Error(t, err)
+}
+
+func TestProcessLogEventsDeduplication(t *testing.T) {
+	var events []beat.Event
+	client := pubtest.NewChanClientWithCallback(1, func(event beat.Event) {
+		events = append(events, event)
+	})
+	mapping := defaultEventMappingConfig()
+	mapping.EventIDAsDocumentID = false
+	p := newLogProcessor(logp.NewLogger("test"), nil, client, mapping)
+	var err error
+	p.dedup, err = newEventDeduplicator(10)
+	require.NoError(t, err)
+
+	// The log event at the boundary of two scan windows is read twice.
+	p.processLogEvents([]types.FilteredLogEvent{testLogEvent("id-1"), testLogEvent("id-2")}, "/aws/lambda/app", "us-east-1", "")
+	assert.Equal(t, 1, p.processLogEvents([]types.FilteredLogEvent{testLogEvent("id-2"), testLogEvent("id-3")}, "/aws/lambda/app", "us-east-1", ""),
+		"the skipped log events are not counted as published")
+	p.processLogEvents([]types.FilteredLogEvent{testLogEvent("id-3")}, "/aws/lambda/other", "us-east-1", "")
+
+	require.Len(t, events, 4)
+	var messages []interface{}
+	for _, e := range events {
+		messages = append(messages, e.Fields["message"])
+		assert.NotContains(t, e.Meta, "_id", "the event ID is not the document ID")
+	}
+	assert.Equal(t, []interface{}{"message id-1", "message id-2", "message id-3", "message id-3"}, messages)
+	assert.Equal(t, uint64(1), p.metrics.logEventsDeduplicatedTotal.Get())
+	assert.Equal(t, uint64(4), p.metrics.cloudwatchEventsCreatedTotal.Get())
+}
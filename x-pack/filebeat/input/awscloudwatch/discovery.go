@@ -0,0 +1,197 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// discoveryConfig configures the discovery of the log groups to poll by
+// name patterns and tags.
+type discoveryConfig struct {
+	Enabled bool `config:"enabled"`
+	// Patterns are glob patterns matched against the log group names. A log
+	// group is selected if it matches any of them. All log groups match if
+	// empty.
+	Patterns []string `config:"log_group_name_patterns"`
+	// Tags are the tags the log groups must have. The values are glob
+	// patterns.
+	Tags            map[string]string `config:"tags"`
+	RefreshInterval time.Duration     `config:"refresh_interval" validate:"min=0,nonzero"`
+}
+
+func (c *discoveryConfig) Validate() error {
+	for _, p := range c.Patterns {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid log group name pattern %q: %w", p, err)
+		}
+	}
+	for k, p := range c.Tags {
+		if _, err := path.Match(p, ""); err != nil {
+			return fmt.Errorf("invalid pattern %q for tag %s: %w", p, k, err)
+		}
+	}
+	return nil
+}
+
+// describedLogGroup is a log group listed with DescribeLogGroups.
+type describedLogGroup struct {
+	name string
+	arn  string
+}
+
+// discoveryEnumerator selects the log groups of the account by name patterns
+// and tags. The log groups are listed again once discovery.refresh_interval
+// elapsed, so log groups created later are polled and deleted log groups are
+// dropped; in between, the selected log groups are returned.
+type discoveryEnumerator struct {
+	config discoveryConfig
+	log    *logp.Logger
+
+	listLogGroups func(ctx context.Context) ([]describedLogGroup, error)
+	listTags      func(ctx context.Context, arn string) (map[string]string, error)
+	clock         func() time.Time
+
+	refreshed time.Time
+	// selected caches whether the listed log groups are selected, by ARN,
+	// so that the selection of a log group whose tags can't be listed is
+	// kept.
+	selected map[string]bool
+	groups   []logGroup
+}
+
+func newDiscoveryEnumerator(cfg config, svc *cloudwatchlogs.Client, log *logp.Logger) *discoveryEnumerator {
+	return &discoveryEnumerator{
+		config: cfg.Discovery,
+		log:    log,
+		listLogGroups: func(ctx context.Context) ([]describedLogGroup, error) {
+			return describeLogGroups(ctx, svc, cfg.LogGroupNamePrefix, cfg.IncludeLinkedAccountsForPrefixMode)
+		},
+		listTags: func(ctx context.Context, arn string) (map[string]string, error) {
+			out, err := svc.ListTagsForResource(ctx, &cloudwatchlogs.ListTagsForResourceInput{
+				ResourceArn: awssdk.String(arn),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return out.Tags, nil
+		},
+		clock:    time.Now,
+		selected: map[string]bool{},
+	}
+}
+
+// logGroups returns the selected log groups, refreshing them if
+// refresh_interval elapsed since the last listing.
+func (e *discoveryEnumerator) logGroups(ctx context.Context) []logGroup {
+	if !e.refreshed.IsZero() && e.clock().Sub(e.refreshed) < e.config.RefreshInterval {
+		return e.groups
+	}
+	e.refresh(ctx)
+	return e.groups
+}
+
+// refresh lists the log groups and selects the ones matching the name
+// patterns and the tags. The tags are only listed for the log groups whose
+// name matches. If the log groups can't be listed, the previously selected
+// log groups are kept.
+func (e *discoveryEnumerator) refresh(ctx context.Context) {
+	listed, err := e.listLogGroups(ctx)
+	if err != nil {
+		// Keep the selected log groups and try again on the next scan.
+		e.log.Errorw("Failed to list the log groups, using the previously discovered log groups", "error", err)
+		return
+	}
+	e.refreshed = e.clock()
+
+	previous := len(e.groups)
+	selected := make(map[string]bool, len(listed))
+	var groups []logGroup
+	for _, lg := range listed {
+		ok := e.matchName(lg.name)
+		if ok && len(e.config.Tags) != 0 {
+			tags, err := e.listTags(ctx, lg.arn)
+			if err != nil {
+				ok = e.selected[lg.arn]
+				e.log.Warnw("Failed to list the tags of a log group, keeping its previous selection",
+					"log_group", lg.arn, "selected", ok, "error", err)
+			} else {
+				ok = matchTags(e.config.Tags, tags)
+			}
+		}
+		selected[lg.arn] = ok
+		if ok {
+			groups = append(groups, logGroup{id: lg.arn})
+		}
+	}
+
+	e.selected = selected
+	e.groups = groups
+	e.log.Infow("Discovered the log groups",
+		"listed_log_groups", len(listed), "log_groups", len(groups), "previous_log_groups", previous)
+}
+
+func (e *discoveryEnumerator) matchName(name string) bool {
+	if len(e.config.Patterns) == 0 {
+		return true
+	}
+	for _, p := range e.config.Patterns {
+		if ok, _ := path.Match(p, name); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// matchTags reports whether tags has all the wanted tags, with values
+// matching their patterns.
+func matchTags(want, tags map[string]string) bool {
+	for k, p := range want {
+		v, found := tags[k]
+		if !found {
+			return false
+		}
+		if ok, _ := path.Match(p, v); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// describeLogGroups uses the DescribeLogGroups API to list the names and
+// ARNs of the log groups matching the logGroupNamePrefix. The ARNs don't
+// have the trailing :*, as expected by ListTagsForResource.
+func describeLogGroups(ctx context.Context, svc *cloudwatchlogs.Client, logGroupNamePrefix string, withLinkedAccount bool) ([]describedLogGroup, error) {
+	input := &cloudwatchlogs.DescribeLogGroupsInput{
+		IncludeLinkedAccounts: awssdk.Bool(withLinkedAccount),
+	}
+	if logGroupNamePrefix != "" {
+		input.LogGroupNamePrefix = awssdk.String(logGroupNamePrefix)
+	}
+
+	var logGroups []describedLogGroup
+	paginator := cloudwatchlogs.NewDescribeLogGroupsPaginator(svc, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error DescribeLogGroups with Paginator: %w", err)
+		}
+		for _, lg := range page.LogGroups {
+			if lg.LogGroupName == nil || lg.LogGroupArn == nil {
+				continue
+			}
+			logGroups = append(logGroups, describedLogGroup{name: *lg.LogGroupName, arn: *lg.LogGroupArn})
+		}
+	}
+	return logGroups, nil
+}
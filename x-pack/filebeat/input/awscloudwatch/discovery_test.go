@@ -0,0 +1,136 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const testARNPrefix = "arn:aws:logs:us-east-1:111:log-group:"
+
+// testAccount is a fake account whose log groups are identified by name.
+type testAccount struct {
+	logGroups []string
+	listErr   error
+	tags      map[string]map[string]string
+	tagsErr   map[string]bool
+	tagCalls  int
+}
+
+func newTestDiscoveryEnumerator(account *testAccount, cfg discoveryConfig, clock *clock) *discoveryEnumerator {
+	return &discoveryEnumerator{
+		config: cfg,
+		log:    logp.NewLogger("test"),
+		listLogGroups: func(context.Context) ([]describedLogGroup, error) {
+			var groups []describedLogGroup
+			for _, name := range account.logGroups {
+				groups = append(groups, describedLogGroup{name: name, arn: testARNPrefix + name})
+			}
+			return groups, account.listErr
+		},
+		listTags: func(_ context.Context, arn string) (map[string]string, error) {
+			account.tagCalls++
+			name := arn[len(testARNPrefix):]
+			if account.tagsErr[name] {
+				return nil, errors.New("throttled")
+			}
+			return account.tags[name], nil
+		},
+		clock:    clock.now,
+		selected: map[string]bool{},
+	}
+}
+
+func groupNames(groups []logGroup) []string {
+	var names []string
+	for _, g := range groups {
+		names = append(names, g.id[len(testARNPrefix):])
+	}
+	return names
+}
+
+func TestDiscoveryEnumerator(t *testing.T) {
+	ctx := context.Background()
+	clk := &clock{time: time.Unix(0, 0)}
+	account := &testAccount{
+		logGroups: []string{"/aws/lambda/a-prod", "/aws/lambda/b-dev", "/aws/lambda/c-prod", "/ecs/d-prod"},
+		tags: map[string]map[string]string{
+			"/aws/lambda/a-prod": {"team": "payments", "env": "prod"},
+			"/aws/lambda/c-prod": {"team": "search", "env": "prod"},
+			"/ecs/d-prod":        {"team": "payments", "env": "prod"},
+		},
+	}
+	e := newTestDiscoveryEnumerator(account, discoveryConfig{
+		Patterns:        []string{"/aws/lambda/*-prod"},
+		Tags:            map[string]string{"team": "pay*"},
+		RefreshInterval: 5 * time.Minute,
+	}, clk)
+
+	assert.Equal(t, []string{"/aws/lambda/a-prod"}, groupNames(e.logGroups(ctx)))
+	assert.Equal(t, 2, account.tagCalls, "the tags are only listed for the log groups matching the patterns")
+
+	// The log groups are cached until the refresh interval elapsed.
+	account.logGroups = append(account.logGroups, "/aws/lambda/e-prod")
+	account.tags["/aws/lambda/e-prod"] = map[string]string{"team": "payroll"}
+	clk.time = clk.time.Add(time.Minute)
+	assert.Equal(t, []string{"/aws/lambda/a-prod"}, groupNames(e.logGroups(ctx)))
+
+	// New log groups are added and deleted ones are dropped.
+	account.logGroups = []string{"/aws/lambda/c-prod", "/aws/lambda/e-prod"}
+	clk.time = clk.time.Add(5 * time.Minute)
+	assert.Equal(t, []string{"/aws/lambda/e-prod"}, groupNames(e.logGroups(ctx)))
+
+	// Log groups whose tags can't be listed keep their selection.
+	account.tags["/aws/lambda/c-prod"]["team"] = "payments"
+	account.tagsErr = map[string]bool{"/aws/lambda/c-prod": true, "/aws/lambda/e-prod": true}
+	clk.time = clk.time.Add(5 * time.Minute)
+	assert.Equal(t, []string{"/aws/lambda/e-prod"}, groupNames(e.logGroups(ctx)))
+
+	// The discovered log groups are used if the log groups can't be listed.
+	account.listErr = errors.New("throttled")
+	clk.time = clk.time.Add(5 * time.Minute)
+	assert.Equal(t, []string{"/aws/lambda/e-prod"}, groupNames(e.logGroups(ctx)))
+}
+
+func TestDiscoveryWithoutFilters(t *testing.T) {
+	account := &testAccount{logGroups: []string{"a", "b"}}
+	e := newTestDiscoveryEnumerator(account, discoveryConfig{}, &clock{})
+	assert.Equal(t, []string{"a", "b"}, groupNames(e.logGroups(context.Background())))
+	assert.Zero(t, account.tagCalls)
+}
+
+func TestMatchTags(t *testing.T) {
+	tags := map[string]string{"env": "prod", "team": "payments"}
+	assert.True(t, matchTags(map[string]string{"env": "prod"}, tags))
+	assert.True(t, matchTags(map[string]string{"env": "prod", "team": "*"}, tags))
+	assert.False(t, matchTags(map[string]string{"env": "dev"}, tags))
+	assert.False(t, matchTags(map[string]string{"owner": "*"}, tags))
+}
+
+func TestDiscoveryConfig(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Discovery.Enabled = true
+	assert.ErrorContains(t, cfg.Validate(), "region_name is required")
+
+	cfg.RegionName = "us-east-1"
+	assert.NoError(t, cfg.Validate(), "the log group prefix is optional")
+
+	cfg.LogGroupName = "a"
+	assert.Error(t, cfg.Validate())
+
+	cfg.LogGroupName = ""
+	cfg.Organization = organizationConfig{Enabled: true, RoleName: "role"}
+	assert.ErrorContains(t, cfg.Validate(), "cannot be used with organization.enabled")
+
+	d := discoveryConfig{Patterns: []string{"[a-"}}
+	assert.Error(t, d.Validate())
+}
@@ -0,0 +1,387 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+
+	"github.com/elastic/beats/v7/libbeat/reader"
+	"github.com/elastic/beats/v7/libbeat/reader/readfile"
+	"github.com/elastic/beats/v7/libbeat/reader/readfile/encoding"
+	x_reader "github.com/elastic/beats/v7/x-pack/libbeat/reader"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	// exportBufferSize and exportMaxBytes are the buffer size and the
+	// maximum size of a line when reading the exported objects.
+	exportBufferSize = 16 * 1024
+	exportMaxBytes   = 10 * 1024 * 1024
+)
+
+// exportConfig configures the ingestion of large time ranges from CloudWatch
+// Logs export tasks to S3 instead of FilterLogEvents.
+type exportConfig struct {
+	Enabled bool `config:"enabled"`
+	// BucketName or BucketARN is the S3 bucket the log events are exported
+	// to. It must be in the region of the log groups.
+	BucketName string `config:"bucket_name"`
+	BucketARN  string `config:"bucket_arn"`
+	// Prefix is the prefix of the keys of the exported objects.
+	Prefix string `config:"prefix"`
+	// MinRange is the smallest time range of a scan that is exported. The
+	// shorter scans, as the ones following the live edge, use
+	// FilterLogEvents.
+	MinRange time.Duration `config:"min_range" validate:"min=0,nonzero"`
+	// PollInterval is the interval between two checks of the status of an
+	// export task, and between two attempts to create one while another
+	// export task of the account is running.
+	PollInterval time.Duration `config:"poll_interval" validate:"min=0,nonzero"`
+}
+
+func (c *exportConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.BucketName == "" && c.BucketARN == "" {
+		return errors.New("export.bucket_name or export.bucket_arn is required when export.enabled is set")
+	}
+	if c.BucketName != "" && c.BucketARN != "" {
+		return errors.New("export.bucket_name and export.bucket_arn cannot be given at the same time")
+	}
+	if c.BucketARN != "" {
+		if _, err := arn.Parse(c.BucketARN); err != nil {
+			return fmt.Errorf("failed to parse export.bucket_arn: %w", err)
+		}
+	}
+	return nil
+}
+
+// bucket returns the name of the bucket the log events are exported to.
+func (c *exportConfig) bucket() string {
+	if c.BucketARN != "" {
+		parsed, _ := arn.Parse(c.BucketARN)
+		return parsed.Resource
+	}
+	return c.BucketName
+}
+
+// logExporter reads the log events of a time range of a log group from a
+// CloudWatch Logs export task to S3. An account can only run one export task
+// at a time, the tasks of the workers are created one after the other.
+type logExporter struct {
+	config          exportConfig
+	log             *logp.Logger
+	metrics         *inputMetrics
+	logStreamPrefix string
+
+	createTask   func(ctx context.Context, input *cloudwatchlogs.CreateExportTaskInput) (string, error)
+	describeTask func(ctx context.Context, taskID string) (types.ExportTaskStatusCode, string, error)
+	listObjects  func(ctx context.Context, bucket, prefix string) ([]string, error)
+	getObject    func(ctx context.Context, bucket, key string) (io.ReadCloser, error)
+}
+
+func newLogExporter(cfg config, svc *cloudwatchlogs.Client, s3Client *s3.Client, metrics *inputMetrics, log *logp.Logger) *logExporter {
+	return &logExporter{
+		config:          cfg.Export,
+		log:             log,
+		metrics:         metrics,
+		logStreamPrefix: cfg.LogStreamPrefix,
+		createTask: func(ctx context.Context, input *cloudwatchlogs.CreateExportTaskInput) (string, error) {
+			out, err := svc.CreateExportTask(ctx, input)
+			if err != nil {
+				return "", err
+			}
+			return awssdk.ToString(out.TaskId), nil
+		},
+		describeTask: func(ctx context.Context, taskID string) (types.ExportTaskStatusCode, string, error) {
+			out, err := svc.DescribeExportTasks(ctx, &cloudwatchlogs.DescribeExportTasksInput{
+				TaskId: awssdk.String(taskID),
+			})
+			if err != nil {
+				return "", "", err
+			}
+			if len(out.ExportTasks) == 0 || out.ExportTasks[0].Status == nil {
+				return "", "", fmt.Errorf("export task %s not found", taskID)
+			}
+			status := out.ExportTasks[0].Status
+			return status.Code, awssdk.ToString(status.Message), nil
+		},
+		listObjects: func(ctx context.Context, bucket, prefix string) ([]string, error) {
+			var keys []string
+			paginator := s3.NewListObjectsV2Paginator(s3Client, &s3.ListObjectsV2Input{
+				Bucket: awssdk.String(bucket),
+				Prefix: awssdk.String(prefix),
+			})
+			for paginator.HasMorePages() {
+				page, err := paginator.NextPage(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("error ListObjectsV2 with Paginator: %w", err)
+				}
+				for _, obj := range page.Contents {
+					keys = append(keys, awssdk.ToString(obj.Key))
+				}
+			}
+			return keys, nil
+		},
+		getObject: func(ctx context.Context, bucket, key string) (io.ReadCloser, error) {
+			out, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+				Bucket: awssdk.String(bucket),
+				Key:    awssdk.String(key),
+			})
+			if err != nil {
+				return nil, err
+			}
+			return out.Body, nil
+		},
+	}
+}
+
+// covers reports whether the time range is large enough to be exported.
+func (e *logExporter) covers(startTime, endTime time.Time) bool {
+	return e != nil && endTime.Sub(startTime) >= e.config.MinRange
+}
+
+// export exports the log events of the log group between startTime and
+// endTime to S3 and publishes them with processor. It returns the number of
+// published events.
+func (e *logExporter) export(ctx context.Context, processor *logProcessor, logGroupId, region string, startTime, endTime time.Time) (int, error) {
+	input := &cloudwatchlogs.CreateExportTaskInput{
+		LogGroupName:      awssdk.String(logGroupName(logGroupId)),
+		From:              awssdk.Int64(unixMsFromTime(startTime)),
+		To:                awssdk.Int64(unixMsFromTime(endTime)),
+		Destination:       awssdk.String(e.config.bucket()),
+		DestinationPrefix: awssdk.String(e.config.Prefix),
+	}
+	if e.logStreamPrefix != "" {
+		input.LogStreamNamePrefix = awssdk.String(e.logStreamPrefix)
+	}
+
+	taskID, err := e.startTask(ctx, input)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create export task for log group %s: %w", logGroupId, err)
+	}
+	log := e.log.With("log_group", logGroupId, "task_id", taskID)
+	log.Infow("Exporting log events to S3", "start_time", startTime, "end_time", endTime)
+
+	if err := e.waitTask(ctx, taskID); err != nil {
+		return 0, err
+	}
+
+	taskPrefix := path.Join(e.config.Prefix, taskID) + "/"
+	keys, err := e.listObjects(ctx, e.config.bucket(), taskPrefix)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list the objects of export task %s: %w", taskID, err)
+	}
+
+	var count int
+	for _, key := range keys {
+		// The objects are stored under <prefix>/<task ID>/<log stream>/.
+		stream := path.Dir(strings.TrimPrefix(key, taskPrefix))
+		if stream == "." {
+			continue
+		}
+		n, err := e.readObject(ctx, processor, logGroupId, region, stream, key)
+		count += n
+		if err != nil {
+			// The events of the previous objects were published, they must
+			// be counted to wait for their acknowledgement.
+			return count, fmt.Errorf("failed to read exported object %s: %w", key, err)
+		}
+	}
+	log.Infow("Read the exported log events", "objects", len(keys), "events", count)
+	return count, nil
+}
+
+// startTask creates the export task, waiting for the running export task of
+// the account to complete if there is one.
+func (e *logExporter) startTask(ctx context.Context, input *cloudwatchlogs.CreateExportTaskInput) (string, error) {
+	for {
+		taskID, err := e.createTask(ctx, input)
+		e.metrics.apiCallsTotal.Inc()
+		var apiErr smithy.APIError
+		if err == nil || !errors.As(err, &apiErr) || apiErr.ErrorCode() != "LimitExceededException" {
+			return taskID, err
+		}
+		e.log.Debugw("Another export task is running, waiting to create the export task",
+			"log_group", awssdk.ToString(input.LogGroupName))
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(e.config.PollInterval):
+		}
+	}
+}
+
+// waitTask waits for the export task to complete.
+func (e *logExporter) waitTask(ctx context.Context, taskID string) error {
+	for {
+		code, message, err := e.describeTask(ctx, taskID)
+		e.metrics.apiCallsTotal.Inc()
+		if err != nil {
+			return fmt.Errorf("failed to describe export task %s: %w", taskID, err)
+		}
+		switch code {
+		case types.ExportTaskStatusCodeCompleted:
+			return nil
+		case types.ExportTaskStatusCodeFailed, types.ExportTaskStatusCodeCancelled:
+			return fmt.Errorf("export task %s ended with status %s: %s", taskID, code, message)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(e.config.PollInterval):
+		}
+	}
+}
+
+// readObject publishes the log events of an exported object. Each line of
+// the object is the timestamp of a log event followed by its message, the
+// lines without a timestamp are part of the message of the previous line.
+func (e *logExporter) readObject(ctx context.Context, processor *logProcessor, logGroupId, region, stream, key string) (int, error) {
+	body, err := e.getObject(ctx, e.config.bucket(), key)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	r, err := newExportReader(body, e.log)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		count   int
+		pending *types.FilteredLogEvent
+		ids     = derivedIDs{}
+	)
+	flush := func() {
+		if pending == nil {
+			return
+		}
+		pending.EventId = awssdk.String(ids.next(logGroupId, stream, *pending.Timestamp, *pending.Message))
+		count += processor.processLogEvents([]types.FilteredLogEvent{*pending}, logGroupId, region, "")
+		pending = nil
+	}
+
+	for ctx.Err() == nil {
+		msg, err := r.Next()
+		if len(msg.Content) > 0 {
+			line := string(msg.Content)
+			if ts, message, ok := parseExportLine(line); ok {
+				flush()
+				pending = &types.FilteredLogEvent{
+					LogStreamName: awssdk.String(stream),
+					Timestamp:     awssdk.Int64(ts.UnixMilli()),
+					Message:       awssdk.String(message),
+				}
+				e.metrics.logEventsReceivedTotal.Inc()
+			} else if pending != nil {
+				pending.Message = awssdk.String(*pending.Message + "\n" + line)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			flush()
+			return count, err
+		}
+	}
+	flush()
+	return count, ctx.Err()
+}
+
+// newExportReader returns a line reader of the exported object, which is
+// gzip compressed.
+func newExportReader(body io.Reader, log *logp.Logger) (reader.Reader, error) {
+	stream, err := x_reader.AddGzipDecoderIfNeeded(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add gzip decoder: %w", err)
+	}
+	enc, err := encoding.Plain(stream)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize encoding: %w", err)
+	}
+
+	var r reader.Reader
+	r, err = readfile.NewEncodeReader(io.NopCloser(stream), readfile.Config{
+		Codec:        enc,
+		BufferSize:   exportBufferSize,
+		Terminator:   readfile.AutoLineTerminator,
+		CollectOnEOF: true,
+		MaxBytes:     exportMaxBytes * 4,
+	}, log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create encode reader: %w", err)
+	}
+	r = readfile.NewStripNewline(r, readfile.AutoLineTerminator)
+	return readfile.NewLimitReader(r, exportMaxBytes), nil
+}
+
+// parseExportLine splits a line of an exported object into the timestamp and
+// the message of the log event.
+func parseExportLine(line string) (time.Time, string, bool) {
+	ts, message, found := strings.Cut(line, " ")
+	if !found {
+		return time.Time{}, "", false
+	}
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return time.Time{}, "", false
+	}
+	return t, message, true
+}
+
+// derivedIDs creates the IDs of the log events read from an export or a Live
+// Tail session, which have none. The ID is derived from the log group, log
+// stream, timestamp and message of the log event, so that a range exported
+// again after a restart does not create duplicates. With mode live_tail, the
+// log events of the backfills get derived IDs too, instead of their
+// CloudWatch IDs. Identical log events of the same timestamp are told apart by
+// their order.
+type derivedIDs struct {
+	timestamp int64
+	seen      map[string]int
+}
+
+func (ids *derivedIDs) next(logGroupId, stream string, timestamp int64, message string) string {
+	if ids.seen == nil || ids.timestamp != timestamp {
+		ids.timestamp = timestamp
+		ids.seen = map[string]int{}
+	}
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%s\x00%d\x00%s", logGroupId, stream, timestamp, message)
+	sum := h.Sum(nil)
+	key := string(sum)
+	n := ids.seen[key]
+	ids.seen[key] = n + 1
+	return fmt.Sprintf("%s-%d", hex.EncodeToString(sum[:12]), n)
+}
+
+// logGroupName returns the name of the log group identified by its name or
+// its ARN, as expected by CreateExportTask.
+func logGroupName(logGroupId string) string {
+	parsed, err := arn.Parse(logGroupId)
+	if err != nil {
+		return logGroupId
+	}
+	return strings.TrimSuffix(strings.TrimPrefix(parsed.Resource, "log-group:"), ":*")
+}
@@ -0,0 +1,167 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	pubtest "github.com/elastic/beats/v7/libbeat/publisher/testing"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func gzipped(t *testing.T, content string) []byte {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestLogExporter(t *testing.T) {
+	objects := map[string][]byte{
+		"exportedlogs/task-1/web/1/000000.gz": gzipped(t, "2024-01-01T00:00:00.000Z first\n2024-01-01T00:00:01.500Z second\n  at line 2\n"),
+		"exportedlogs/task-1/web/1/000001.gz": gzipped(t, "2024-01-01T00:00:02.000Z third\n2024-01-01T00:00:02.000Z third\n"),
+		"exportedlogs/task-1/db/000000.gz":    gzipped(t, "2024-01-01T00:00:03.000Z fourth"),
+	}
+
+	var created []*cloudwatchlogs.CreateExportTaskInput
+	var statuses []types.ExportTaskStatusCode
+	e := &logExporter{
+		config: exportConfig{
+			BucketARN:    "arn:aws:s3:::exports",
+			Prefix:       "exportedlogs",
+			MinRange:     time.Hour,
+			PollInterval: time.Millisecond,
+		},
+		log:     logp.NewLogger("test"),
+		metrics: newInputMetrics(monitoring.NewRegistry()),
+		createTask: func(_ context.Context, input *cloudwatchlogs.CreateExportTaskInput) (string, error) {
+			created = append(created, input)
+			if len(created) == 1 {
+				return "", &smithy.GenericAPIError{Code: "LimitExceededException"}
+			}
+			return "task-1", nil
+		},
+		describeTask: func(_ context.Context, taskID string) (types.ExportTaskStatusCode, string, error) {
+			assert.Equal(t, "task-1", taskID)
+			code := types.ExportTaskStatusCodeRunning
+			if len(statuses) == 2 {
+				code = types.ExportTaskStatusCodeCompleted
+			}
+			statuses = append(statuses, code)
+			return code, "", nil
+		},
+		listObjects: func(_ context.Context, bucket, prefix string) ([]string, error) {
+			assert.Equal(t, "exports", bucket)
+			assert.Equal(t, "exportedlogs/task-1/", prefix)
+			return []string{
+				"exportedlogs/task-1/web/1/000000.gz",
+				"exportedlogs/task-1/web/1/000001.gz",
+				"exportedlogs/task-1/db/000000.gz",
+			}, nil
+		},
+		getObject: func(_ context.Context, bucket, key string) (io.ReadCloser, error) {
+			return io.NopCloser(bytes.NewReader(objects[key])), nil
+		},
+	}
+
+	var events []beat.Event
+	client := pubtest.NewChanClientWithCallback(1, func(event beat.Event) {
+		events = append(events, event)
+	})
+	processor := newLogProcessor(logp.NewLogger("test"), nil, client, defaultEventMappingConfig())
+
+	start, end := time.Unix(1704067200, 0), time.Unix(1704153600, 0)
+	logGroup := "arn:aws:logs:us-east-1:111:log-group:/app/web"
+	require.True(t, e.covers(start, end))
+	assert.False(t, e.covers(end.Add(-time.Minute), end))
+
+	count, err := e.export(context.Background(), processor, logGroup, "us-east-1", start, end)
+	require.NoError(t, err)
+	assert.Equal(t, 5, count)
+	require.Len(t, events, 5)
+
+	require.Len(t, created, 2, "the task is created again once the running export task completed")
+	assert.Equal(t, "/app/web", *created[1].LogGroupName)
+	assert.Equal(t, "exports", *created[1].Destination)
+	assert.Equal(t, start.UnixMilli(), *created[1].From)
+	assert.Equal(t, end.UnixMilli(), *created[1].To)
+	assert.Len(t, statuses, 3)
+
+	messages := make([]string, 0, len(events))
+	for _, event := range events {
+		msg, _ := event.Fields.GetValue("message")
+		messages = append(messages, msg.(string))
+	}
+	assert.Equal(t, []string{"first", "second\n  at line 2", "third", "third", "fourth"}, messages)
+
+	stream, _ := events[1].Fields.GetValue("aws.cloudwatch.log_stream")
+	assert.Equal(t, "web/1", stream)
+	assert.Equal(t, time.Unix(1704067201, 500*int64(time.Millisecond)).UTC(), events[1].Timestamp)
+	_, err = events[1].Fields.GetValue("aws.cloudwatch.ingestion_time")
+	assert.Error(t, err, "exported log events have no ingestion time")
+	assert.NotEqual(t, events[2].Meta["_id"], events[3].Meta["_id"], "identical log events have distinct IDs")
+
+	// The IDs don't depend on the export task, a range exported again
+	// doesn't create duplicates.
+	first := events[0].Meta["_id"]
+	events = nil
+	_, err = e.export(context.Background(), processor, logGroup, "us-east-1", start, end)
+	require.NoError(t, err)
+	assert.Equal(t, first, events[0].Meta["_id"])
+}
+
+func TestLogExporterFailedTask(t *testing.T) {
+	e := &logExporter{
+		config:  exportConfig{BucketName: "exports", PollInterval: time.Millisecond},
+		log:     logp.NewLogger("test"),
+		metrics: newInputMetrics(monitoring.NewRegistry()),
+		createTask: func(context.Context, *cloudwatchlogs.CreateExportTaskInput) (string, error) {
+			return "task-1", nil
+		},
+		describeTask: func(context.Context, string) (types.ExportTaskStatusCode, string, error) {
+			return types.ExportTaskStatusCodeFailed, "access denied", nil
+		},
+		listObjects: func(context.Context, string, string) ([]string, error) {
+			t.Fatal("the objects of a failed export task must not be listed")
+			return nil, nil
+		},
+	}
+
+	_, err := e.export(context.Background(), nil, "/app/web", "us-east-1", time.Unix(0, 0), time.Unix(86400, 0))
+	assert.ErrorContains(t, err, "export task task-1 ended with status FAILED: access denied")
+}
+
+func TestExportConfig(t *testing.T) {
+	cfg := exportConfig{Enabled: true}
+	assert.Error(t, cfg.Validate())
+
+	cfg.BucketARN = "arn:aws:s3:::exports"
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, "exports", cfg.bucket())
+
+	cfg.BucketName = "exports"
+	assert.Error(t, cfg.Validate())
+}
+
+func TestLogGroupName(t *testing.T) {
+	assert.Equal(t, "/app/web", logGroupName("/app/web"))
+	assert.Equal(t, "/app/web", logGroupName("arn:aws:logs:us-east-1:111:log-group:/app/web"))
+	assert.Equal(t, "/app/web", logGroupName("arn:aws:logs:us-east-1:111:log-group:/app/web:*"))
+}
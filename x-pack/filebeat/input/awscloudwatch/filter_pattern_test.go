@@ -0,0 +1,72 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func TestFilterPattern(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.FilterPattern = "?ERROR ?WARN"
+	cfg.FilterPatterns = []filterPatternOverride{
+		{LogGroupNamePattern: "/aws/lambda/*", FilterPattern: `{ $.level = "error" }`},
+		{LogGroupNamePattern: "/aws/rds/*"},
+	}
+	for _, o := range cfg.FilterPatterns {
+		assert.NoError(t, o.Validate())
+	}
+
+	// The entries of log_group_overrides match first.
+	lambdaFilter := "REPORT"
+	cfg.LogGroupOverrides = logGroupOverrides{{LogGroupNamePattern: "/aws/lambda/report"}, {LogGroupNamePattern: "/aws/lambda/billing", FilterPattern: &lambdaFilter}}
+
+	tests := map[string]string{
+		"/aws/ecs/web":        "?ERROR ?WARN",
+		"/aws/lambda/report":  "?ERROR ?WARN",
+		"/aws/lambda/billing": "REPORT",
+		"arn:aws:logs:us-east-1:111:log-group:/aws/lambda/api:*": `{ $.level = "error" }`,
+		"/aws/lambda/api":                 `{ $.level = "error" }`,
+		"/aws/rds/instance/db/postgresql": "",
+	}
+	for id, want := range tests {
+		assert.Equal(t, want, cfg.filterPattern(id), id)
+	}
+
+	w := cwWorker{config: cfg, log: logp.NewLogger("test")}
+	now := time.Now()
+	assert.Equal(t, awssdk.String("?ERROR ?WARN"), w.constructFilterLogEventsInput(now, now, "/aws/ecs/web").FilterPattern)
+	assert.Nil(t, w.constructFilterLogEventsInput(now, now, "/aws/rds/instance/db/postgresql").FilterPattern, "an empty override disables the filter")
+
+	invalid := filterPatternOverride{LogGroupNamePattern: "/aws/[lambda"}
+	assert.ErrorContains(t, invalid.Validate(), "invalid log group name pattern")
+}
+
+func TestFilterPatternConfig(t *testing.T) {
+	c := defaultConfig()
+	c.LogGroupName = "app"
+	c.RegionName = "us-east-1"
+	c.FilterPattern = "ERROR"
+	assert.NoError(t, c.Validate())
+
+	c.Export.Enabled = true
+	assert.ErrorContains(t, c.Validate(), "cannot be used with export.enabled")
+
+	c.Export.Enabled = false
+	c.Mode = modeLiveTail
+	assert.NoError(t, c.Validate())
+	c.FilterPatterns = []filterPatternOverride{{LogGroupNamePattern: "app", FilterPattern: "WARN"}}
+	assert.ErrorContains(t, c.Validate(), "log_group_overrides and filter_patterns can only be used with mode poll")
+
+	c.Mode = modeInsights
+	c.Insights.Query = "fields @message"
+	assert.ErrorContains(t, c.Validate(), "cannot be used with mode insights")
+}
@@ -15,6 +15,8 @@ import (
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/sqs"
 
 	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
 	"github.com/elastic/beats/v7/libbeat/beat"
@@ -22,6 +24,8 @@ import (
 	"github.com/elastic/beats/v7/libbeat/feature"
 	"github.com/elastic/beats/v7/libbeat/management/status"
 	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/apihealth"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/leases"
 	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
 	"github.com/elastic/beats/v7/x-pack/libbeat/statusreporterhelper"
 	conf "github.com/elastic/elastic-agent-libs/config"
@@ -72,6 +76,9 @@ type cloudwatchInput struct {
 
 func newInput(config config, store statestore.States, logger *logp.Logger) (*cloudwatchInput, error) {
 	logger.Warn(cfgwarn.Beta("aws-cloudwatch input type is used"))
+	if len(config.FilterPatterns) != 0 {
+		logger.Warn(cfgwarn.Deprecate("", "filter_patterns is deprecated, use filter_pattern in log_group_overrides instead."))
+	}
 
 	// perform AWS configuration validation
 	awsConfig, err := awscommon.InitializeAWSConfig(config.AWSConfig, logger)
@@ -88,10 +95,90 @@ func newInput(config config, store statestore.States, logger *logp.Logger) (*clo
 
 func (in *cloudwatchInput) Name() string { return inputName }
 
+// Test checks that the configured credentials are valid and that the
+// log groups of the input can be described with them.
 func (in *cloudwatchInput) Test(ctx v2.TestContext) error {
+	stdCtx := v2.GoContextFromCanceler(ctx.Cancelation)
+	if _, err := awscommon.CheckCredentials(stdCtx, in.awsConfig); err != nil {
+		return err
+	}
+	if in.config.Organization.Enabled || in.config.CrossAccount.enabled() {
+		// The log groups of the member accounts are only known
+		// once the roles of the accounts are assumed.
+		return nil
+	}
+
+	logGroupIDs, region, err := fromConfig(in.config, in.awsConfig)
+	if err != nil {
+		return err
+	}
+	awsConfig := in.awsConfig.Copy()
+	awsConfig.Region = region
+	return checkLogGroups(stdCtx, newLogsClient(in.config, awsConfig), in.config.LogGroupNamePrefix, logGroupIDs)
+}
+
+// checkLogGroups checks that the log groups identified by logGroupIDs, or
+// at least one log group matching prefix when no ID is given, exist.
+func checkLogGroups(ctx context.Context, svc *cloudwatchlogs.Client, prefix string, logGroupIDs []string) error {
+	if len(logGroupIDs) == 0 {
+		out, err := svc.DescribeLogGroups(ctx, &cloudwatchlogs.DescribeLogGroupsInput{
+			LogGroupNamePrefix: awssdk.String(prefix),
+			Limit:              awssdk.Int32(1),
+		})
+		if err != nil {
+			return awscommon.WithHint(fmt.Errorf("failed to describe log groups: %w", err))
+		}
+		if len(out.LogGroups) == 0 {
+			return fmt.Errorf("no log group found with log_group_name_prefix %q in region %s", prefix, svc.Options().Region)
+		}
+		return nil
+	}
+
+	for _, id := range logGroupIDs {
+		name := id
+		if parsed, err := arn.Parse(id); err == nil {
+			name = strings.TrimPrefix(parsed.Resource, "log-group:")
+		}
+		paginator := cloudwatchlogs.NewDescribeLogGroupsPaginator(svc, &cloudwatchlogs.DescribeLogGroupsInput{
+			LogGroupNamePrefix: awssdk.String(name),
+		})
+		found := false
+		for !found && paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				return awscommon.WithHint(fmt.Errorf("failed to describe log group %q: %w", name, err))
+			}
+			for _, lg := range page.LogGroups {
+				if awssdk.ToString(lg.LogGroupName) == name {
+					found = true
+					break
+				}
+			}
+		}
+		if !found {
+			return fmt.Errorf("log group %q not found in region %s (hint: check log_group_arn, log_group_name and region_name)", name, svc.Options().Region)
+		}
+	}
 	return nil
 }
 
+// newLogsClient returns a CloudWatch Logs client for awsConfig.
+func newLogsClient(cfg config, awsConfig awssdk.Config) *cloudwatchlogs.Client {
+	return cloudwatchlogs.NewFromConfig(awsConfig, func(o *cloudwatchlogs.Options) {
+		if cfg.AWSConfig.FIPSEnabled {
+			o.EndpointOptions.UseFIPSEndpoint = awssdk.FIPSEndpointStateEnabled
+		}
+	})
+}
+
+func newS3Client(cfg config, awsConfig awssdk.Config) *s3.Client {
+	return s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		if cfg.AWSConfig.FIPSEnabled {
+			o.EndpointOptions.UseFIPSEndpoint = awssdk.FIPSEndpointStateEnabled
+		}
+	})
+}
+
 func (in *cloudwatchInput) Run(inputContext v2.Context, pipeline beat.Pipeline) error {
 	ctx := v2.GoContextFromCanceler(inputContext.Cancelation)
 	log := inputContext.Logger
@@ -115,23 +202,49 @@ func (in *cloudwatchInput) Run(inputContext v2.Context, pipeline beat.Pipeline)
 		return fmt.Errorf("error processing configurations: %w", err)
 	}
 
+	if region == "" && len(in.config.CrossAccount.Regions) != 0 {
+		region = in.config.CrossAccount.Regions[0]
+	}
 	in.awsConfig.Region = region
-	svc := cloudwatchlogs.NewFromConfig(in.awsConfig, func(o *cloudwatchlogs.Options) {
-		if in.config.AWSConfig.FIPSEnabled {
-			o.EndpointOptions.UseFIPSEndpoint = awssdk.FIPSEndpointStateEnabled
-		}
-	})
-
-	if len(logGroupIDs) == 0 {
+	svc := newLogsClient(in.config, in.awsConfig)
+
+	var organization *orgEnumerator
+	var discovery *discoveryEnumerator
+	var crossAccount *crossAccountEnumerator
+	if in.config.Organization.Enabled {
+		// The log groups are listed in the member accounts by the poller.
+		organization = newOrgEnumerator(in.config, in.awsConfig, log.Named("organization"))
+	} else if in.config.CrossAccount.enabled() {
+		// The log groups are listed with the client of each role and
+		// region by the poller.
+		crossAccount = newCrossAccountEnumerator(in.config, in.awsConfig, log.Named("cross_account"))
+	} else if in.config.Discovery.Enabled {
+		// The log groups are discovered and refreshed by the poller.
+		discovery = newDiscoveryEnumerator(in.config, svc, log.Named("discovery"))
+	} else if len(logGroupIDs) == 0 {
 		// We haven't extracted group identifiers directly from the input configurations,
 		// now fallback to provided LogGroupNamePrefix and use derived service client to derive logGroupIDs
-		logGroupIDs, err = getLogGroupNames(svc, in.config.LogGroupNamePrefix, in.config.IncludeLinkedAccountsForPrefixMode)
+		logGroupIDs, err = getLogGroupNames(ctx, svc, in.config.LogGroupNamePrefix, in.config.IncludeLinkedAccountsForPrefixMode)
 		if err != nil {
 			in.status.UpdateStatus(status.Failed, fmt.Sprintf("Configuration loading error: %s", err.Error()))
 			return fmt.Errorf("failed to get log group names from LogGroupNamePrefix: %w", err)
 		}
 	}
 
+	if in.config.Mode == modeLiveTail {
+		// Live Tail sessions identify the log groups by ARN.
+		logGroupIDs, err = liveTailLogGroupARNs(ctx, svc, logGroupIDs)
+		if err != nil {
+			in.status.UpdateStatus(status.Failed, fmt.Sprintf("Configuration loading error: %s", err.Error()))
+			return fmt.Errorf("failed to get log group ARNs: %w", err)
+		}
+		if len(logGroupIDs) > maxLiveTailLogGroups {
+			err = fmt.Errorf("mode %s supports up to %d log groups, got %d", modeLiveTail, maxLiveTailLogGroups, len(logGroupIDs))
+			in.status.UpdateStatus(status.Failed, fmt.Sprintf("Configuration loading error: %s", err.Error()))
+			return err
+		}
+	}
+
 	in.metrics = newInputMetrics(inputContext.MetricsRegistry)
 	cwPoller := newCloudwatchPoller(
 		log.Named("cloudwatch_poller"),
@@ -140,10 +253,100 @@ func (in *cloudwatchInput) Run(inputContext v2.Context, pipeline beat.Pipeline)
 		in.config,
 		handler,
 		in.status)
+	cwPoller.organization = organization
+	cwPoller.discovery = discovery
+	cwPoller.crossAccount = crossAccount
+	if organization == nil && crossAccount == nil {
+		// The log groups of the other accounts and regions can't be
+		// described with the client of the input.
+		cwPoller.classes = newLogGroupClasses(svc, log.Named("log_group_class"))
+		cwPoller.classes.lookup(ctx, logGroupIDs)
+	}
+	if in.config.Export.Enabled {
+		// The exported objects are read from a bucket of the region of
+		// the log groups.
+		cwPoller.exporter = newLogExporter(in.config, svc, newS3Client(in.config, in.awsConfig), in.metrics, log.Named("export"))
+	}
+
+	if in.config.Mode == modeLiveTail {
+		tail, err := newLiveTailer(in.config, region, svc, in.metrics, pipeline, log.Named("live_tail"))
+		if err != nil {
+			in.status.UpdateStatus(status.Failed, fmt.Sprintf("Error creating Live Tail client: %s", err.Error()))
+			return err
+		}
+		defer tail.close()
+		cwPoller.liveTail = tail
+	}
+
+	if health := apihealth.NewTracker(in.config.APIHealth, inputName, inputContext.IDWithoutName); health != nil {
+		// API health status events are published by the main loop on a
+		// dedicated client since they do not take part in the
+		// acknowledgement tracking of the workers.
+		client, err := pipeline.Connect()
+		if err != nil {
+			in.status.UpdateStatus(status.Failed, fmt.Sprintf("Error creating API health client: %s", err.Error()))
+			return fmt.Errorf("failed to create API health pipeline client: %w", err)
+		}
+		defer client.Close()
+		cwPoller.health = health
+		cwPoller.healthClient = client
+	}
 
 	in.status.UpdateStatus(status.Running, "Input is running")
 
 	cwPoller.metrics.logGroupsTotal.Add(uint64(len(logGroupIDs)))
+	if in.config.Mode == modeInsights {
+		// The log groups are queried by the queriers instead of the
+		// FilterLogEvents workers.
+		queriers := make([]*insightsQuerier, 0, in.config.Insights.MaxConcurrentQueries)
+		for i := 0; i < in.config.Insights.MaxConcurrentQueries; i++ {
+			q, err := newInsightsQuerier(in.config, region, svc, in.metrics, pipeline, log.Named("insights"))
+			if err != nil {
+				in.status.UpdateStatus(status.Failed, fmt.Sprintf("Error creating Logs Insights querier: %s", err.Error()))
+				return err
+			}
+			defer q.close()
+			queriers = append(queriers, q)
+		}
+		cwPoller.runInsights(ctx, queriers, logGroupIDs, time.Now)
+		in.status.UpdateStatus(status.Stopped, "Input execution ended")
+		return nil
+	}
+
+	if in.config.DeadLetter.Enabled {
+		dir := deadLetterDir(in.config.DeadLetter, inputContext.Agent.Paths)
+		var sqsSvc sqsAPI
+		if in.config.DeadLetter.Type == deadLetterSQS {
+			sqsSvc = sqs.NewFromConfig(in.awsConfig)
+		}
+		cwPoller.deadLetter = newDeadLetterWriter(in.config.DeadLetter, dir, in.config.ID, sqsSvc)
+		replayer, err := newDeadLetterReplayer(in.config, dir, in.config.ID, in.metrics, pipeline, log.Named("dead_letter"))
+		if err != nil {
+			in.status.UpdateStatus(status.Failed, fmt.Sprintf("Error creating dead letter replay client: %s", err.Error()))
+			return err
+		}
+		// The replay files created by the replay-dead-letter command are
+		// published while the input runs.
+		go replayer.run(ctx)
+	}
+
+	if in.config.Coordination.Enabled {
+		store, err := leases.NewStore(in.config.Coordination, in.config.AWSConfig, region, handler.id, log)
+		if err != nil {
+			in.status.UpdateStatus(status.Failed, fmt.Sprintf("Error creating coordination client: %s", err.Error()))
+			return fmt.Errorf("failed to create coordination client: %w", err)
+		}
+		coord := &coordinator{leases.NewCoordinator(in.config.Coordination, store, leases.Metrics{
+			Owned:  in.metrics.coordinationLogGroupsOwned,
+			Errors: in.metrics.coordinationErrorsTotal,
+		}, log.Named("coordination"))}
+		// The checkpoints are stored with the leases, so the log groups
+		// moved to other instances resume from them.
+		handler.checkpointed = coord.setCheckpoint
+		cwPoller.coordinator = coord
+		go coord.Run(ctx)
+	}
+
 	err = cwPoller.startWorkers(ctx, svc, pipeline)
 	if err != nil {
 		in.status.UpdateStatus(status.Failed, fmt.Sprintf("Error starting input processors: %s", err.Error()))
@@ -152,8 +355,12 @@ func (in *cloudwatchInput) Run(inputContext v2.Context, pipeline beat.Pipeline)
 
 	log.Debugf("Config latency = %s", cwPoller.config.Latency)
 	log.Debugf("Config scan_frequency = %s", cwPoller.config.ScanFrequency)
-	log.Debugf("Config api_sleep = %s", cwPoller.config.APISleep)
-	cwPoller.receive(ctx, logGroupIDs, time.Now)
+	log.Debugf("Config api_rate_limit = %v", cwPoller.config.rateLimit())
+	if cwPoller.liveTail != nil {
+		cwPoller.runLiveTail(ctx, logGroupIDs, time.Now)
+	} else {
+		cwPoller.receive(ctx, logGroupIDs, time.Now)
+	}
 	in.status.UpdateStatus(status.Stopped, "Input execution ended")
 
 	return nil
@@ -197,7 +404,7 @@ func fromConfig(cfg config, awsCfg awssdk.Config) (logGroupIDs []string, region
 }
 
 // getLogGroupNames uses DescribeLogGroups API to retrieve LogGroupArn entries that matches the provided logGroupNamePrefix
-func getLogGroupNames(svc *cloudwatchlogs.Client, logGroupNamePrefix string, withLinkedAccount bool) ([]string, error) {
+func getLogGroupNames(ctx context.Context, svc *cloudwatchlogs.Client, logGroupNamePrefix string, withLinkedAccount bool) ([]string, error) {
 	// construct DescribeLogGroupsInput
 	describeLogGroupsInput := &cloudwatchlogs.DescribeLogGroupsInput{
 		LogGroupNamePrefix:    awssdk.String(logGroupNamePrefix),
@@ -208,7 +415,7 @@ func getLogGroupNames(svc *cloudwatchlogs.Client, logGroupNamePrefix string, wit
 	var logGroupIDs []string
 	paginator := cloudwatchlogs.NewDescribeLogGroupsPaginator(svc, describeLogGroupsInput)
 	for paginator.HasMorePages() {
-		page, err := paginator.NextPage(context.TODO())
+		page, err := paginator.NextPage(ctx)
 		if err != nil {
 			return nil, fmt.Errorf("error DescribeLogGroups with Paginator: %w", err)
 		}
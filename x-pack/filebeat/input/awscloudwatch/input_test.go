@@ -5,10 +5,17 @@
 package awscloudwatch
 
 import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 	"github.com/stretchr/testify/assert"
 
@@ -46,12 +53,51 @@ func TestCreateEvent(t *testing.T) {
 			"region":   "us-east-1",
 		},
 	}
-	event := createEvent(*logEvent, "logGroup1", "us-east-1")
+	event := createEvent(*logEvent, "logGroup1", "us-east-1", defaultEventMappingConfig())
 	err := event.Fields.Delete("event.ingested")
 	assert.NoError(t, err)
 	assert.Equal(t, expectedEventFields, event.Fields)
 }
 
+func TestCreateEventWithMapping(t *testing.T) {
+	logEvent := &types.FilteredLogEvent{
+		EventId:       awssdk.String("id-1"),
+		IngestionTime: awssdk.Int64(1590000000000),
+		LogStreamName: awssdk.String("logStreamName1"),
+		Message:       awssdk.String("test-message-1"),
+		Timestamp:     awssdk.Int64(1600000000000),
+	}
+
+	event := createEvent(*logEvent, "logGroup1", "us-east-1", eventMappingConfig{
+		PreserveOriginalEvent: true,
+		EventIDAsDocumentID:   true,
+		MessageTargetField:    "cloudwatch.message",
+		CloudWatchTargetField: "cloudwatch",
+	})
+	err := event.Fields.Delete("event.ingested")
+	assert.NoError(t, err)
+	assert.Equal(t, mapstr.M{
+		"event": mapstr.M{
+			"original": "test-message-1",
+		},
+		"log": mapstr.M{
+			"file": mapstr.M{
+				"path": "logGroup1/logStreamName1",
+			},
+		},
+		"cloudwatch": mapstr.M{
+			"message":    "test-message-1",
+			"log_group":  "logGroup1",
+			"log_stream": "logStreamName1",
+		},
+		"cloud": mapstr.M{
+			"provider": "aws",
+			"region":   "us-east-1",
+		},
+	}, event.Fields)
+	assert.Equal(t, "id-1", event.Meta["_id"], "the event ID is used as document ID")
+}
+
 func Test_FromConfig(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -146,3 +192,41 @@ func Test_FromConfig(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckLogGroups(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			LogGroupNamePrefix string `json:"logGroupNamePrefix"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&req)
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		if req.LogGroupNamePrefix == "denied" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"AccessDeniedException","message":"not authorized"}`))
+			return
+		}
+		var groups []map[string]string
+		for _, name := range []string{"/aws/lambda/a", "/aws/lambda/ab"} {
+			if strings.HasPrefix(name, req.LogGroupNamePrefix) {
+				groups = append(groups, map[string]string{"logGroupName": name})
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"logGroups": groups})
+	}))
+	defer srv.Close()
+
+	svc := cloudwatchlogs.New(cloudwatchlogs.Options{
+		Region:       "us-east-1",
+		Credentials:  credentials.NewStaticCredentialsProvider("id", "secret", ""),
+		BaseEndpoint: awssdk.String(srv.URL),
+		Retryer:      awssdk.NopRetryer{},
+	})
+	ctx := context.Background()
+
+	assert.NoError(t, checkLogGroups(ctx, svc, "", []string{"/aws/lambda/a"}))
+	assert.NoError(t, checkLogGroups(ctx, svc, "", []string{"arn:aws:logs:us-east-1:123456789012:log-group:/aws/lambda/ab"}))
+	assert.NoError(t, checkLogGroups(ctx, svc, "/aws/lambda/", nil))
+	assert.ErrorContains(t, checkLogGroups(ctx, svc, "", []string{"/aws/lambda"}), `log group "/aws/lambda" not found`)
+	assert.ErrorContains(t, checkLogGroups(ctx, svc, "/aws/ecs/", nil), `no log group found with log_group_name_prefix "/aws/ecs/"`)
+	assert.ErrorContains(t, checkLogGroups(ctx, svc, "", []string{"denied"}), "hint: grant the logs:DescribeLogGroups permission")
+}
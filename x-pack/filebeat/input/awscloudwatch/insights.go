@@ -0,0 +1,516 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/smithy-go"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/acker"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	modeInsights = "insights"
+
+	// maxInsightsLogGroups is the maximum number of log groups of a Logs
+	// Insights query.
+	maxInsightsLogGroups = 50
+	// maxInsightsLimit is the maximum number of result rows of a Logs
+	// Insights query.
+	maxInsightsLimit = 10000
+	// insightsTimeLayout is the layout of the @timestamp column of the
+	// Logs Insights results.
+	insightsTimeLayout = "2006-01-02 15:04:05.000"
+)
+
+// insightsConfig configures the insights mode, which collects the results of
+// a Logs Insights query run on the log groups every scan_frequency instead of
+// their log events.
+type insightsConfig struct {
+	// Query is the Logs Insights query, in the Logs Insights query
+	// language.
+	Query string `config:"query"`
+	// Limit is the maximum number of result rows of a query. A time range
+	// whose query returns Limit rows is split in two halves that are
+	// queried again, so that no row is lost.
+	Limit int `config:"limit" validate:"min=1,max=10000"`
+	// Timeout is the time to wait for a query to complete before it is
+	// stopped. The time range is queried again on the next scan.
+	Timeout time.Duration `config:"timeout" validate:"min=0,nonzero"`
+	// PollInterval is the interval between two requests of the results of
+	// a running query, and between two attempts to start a query while the
+	// concurrent queries of the account are at their limit.
+	PollInterval time.Duration `config:"poll_interval" validate:"min=0,nonzero"`
+	// MaxConcurrentQueries is the maximum number of queries of the input
+	// running at the same time. Each query covers up to 50 log groups.
+	MaxConcurrentQueries int `config:"max_concurrent_queries" validate:"min=1"`
+	// TargetField is the field holding the columns of the result rows.
+	TargetField string `config:"target_field" validate:"required"`
+}
+
+func defaultInsightsConfig() insightsConfig {
+	return insightsConfig{
+		Limit:                maxInsightsLimit,
+		Timeout:              15 * time.Minute,
+		PollInterval:         2 * time.Second,
+		MaxConcurrentQueries: 2,
+		TargetField:          "aws.cloudwatch.insights",
+	}
+}
+
+// insightsWork is a query of the log groups between start and end.
+type insightsWork struct {
+	logGroupIDs []string
+	start, end  time.Time
+}
+
+// insightsResult is the outcome of the query of a work.
+type insightsResult struct {
+	work insightsWork
+	err  error
+}
+
+// insightsQuerier runs Logs Insights queries and publishes their result rows.
+// The queriers of the input run their queries concurrently, each one
+// publishes with a dedicated client so that the acknowledgement of its
+// results is tracked apart.
+type insightsQuerier struct {
+	config  insightsConfig
+	mapping eventMappingConfig
+	log     *logp.Logger
+	metrics *inputMetrics
+	region  string
+	client  beat.Client
+	tracker *ackTracker
+
+	startQuery      func(ctx context.Context, input *cloudwatchlogs.StartQueryInput) (string, error)
+	getQueryResults func(ctx context.Context, queryID string) (*cloudwatchlogs.GetQueryResultsOutput, error)
+	stopQuery       func(ctx context.Context, queryID string) error
+}
+
+func newInsightsQuerier(cfg config, region string, svc *cloudwatchlogs.Client, metrics *inputMetrics, pipeline beat.Pipeline, log *logp.Logger) (*insightsQuerier, error) {
+	tracker := newACKTracker()
+	client, err := pipeline.ConnectWith(beat.ClientConfig{
+		EventListener: acker.TrackingCounter(func(_ int, by int) {
+			tracker.increaseAck(by)
+		}),
+	})
+	if err != nil {
+		tracker.close()
+		return nil, fmt.Errorf("failed to create pipeline client: %w", err)
+	}
+
+	return &insightsQuerier{
+		config:  cfg.Insights,
+		mapping: cfg.EventMapping,
+		log:     log,
+		metrics: metrics,
+		region:  region,
+		client:  client,
+		tracker: tracker,
+		startQuery: func(ctx context.Context, input *cloudwatchlogs.StartQueryInput) (string, error) {
+			out, err := svc.StartQuery(ctx, input)
+			if err != nil {
+				return "", err
+			}
+			return awssdk.ToString(out.QueryId), nil
+		},
+		getQueryResults: func(ctx context.Context, queryID string) (*cloudwatchlogs.GetQueryResultsOutput, error) {
+			return svc.GetQueryResults(ctx, &cloudwatchlogs.GetQueryResultsInput{
+				QueryId: awssdk.String(queryID),
+			})
+		},
+		stopQuery: func(ctx context.Context, queryID string) error {
+			_, err := svc.StopQuery(ctx, &cloudwatchlogs.StopQueryInput{
+				QueryId: awssdk.String(queryID),
+			})
+			return err
+		},
+	}, nil
+}
+
+func (q *insightsQuerier) close() {
+	q.client.Close()
+	q.tracker.close()
+}
+
+// runInsights implements the run loop of the insights mode. Every
+// scan_frequency, the time range since the previous scan is queried on the
+// log groups, in batches of up to 50 log groups sharing the same start. The
+// checkpoint of the log groups of a batch advances once the results of its
+// query are acknowledged, a failed query is retried on the next scan from the
+// same start.
+func (p *cloudwatchPoller) runInsights(ctx context.Context, queriers []*insightsQuerier, logGroupIDs []string, clock func() time.Time) {
+	works := make(chan insightsWork)
+	results := make(chan insightsResult)
+	var wg sync.WaitGroup
+	for _, q := range queriers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for work := range works {
+				results <- insightsResult{work: work, err: q.run(ctx, work, p.stateHandler)}
+			}
+		}()
+	}
+	defer wg.Wait()
+	defer close(works)
+
+	// The Logs Insights time ranges are in seconds.
+	endTime := clock().Add(-p.config.Latency).Truncate(time.Second)
+	startTime := p.startTime(endTime, p.config.ScanFrequency).Truncate(time.Second)
+	// from holds the start of the next query of each log group.
+	from := map[string]time.Time{}
+	for ctx.Err() == nil {
+		logGroups := p.logGroups(ctx, logGroupIDs)
+		ids := make([]string, 0, len(logGroups))
+		for _, lg := range logGroups {
+			if _, found := from[lg.id]; !found {
+				from[lg.id] = p.checkpoint(lg.id, startTime, endTime).Truncate(time.Second)
+			}
+			ids = append(ids, lg.id)
+		}
+
+		batches := insightsBatches(ids, from, endTime)
+		var pending int
+		for len(batches) != 0 || pending != 0 {
+			var next chan<- insightsWork
+			var work insightsWork
+			if len(batches) != 0 {
+				next, work = works, batches[0]
+			}
+			select {
+			case <-ctx.Done():
+				// The queriers return once their query is stopped.
+				for ; pending != 0; pending-- {
+					<-results
+				}
+				return
+			case next <- work:
+				batches = batches[1:]
+				pending++
+			case result := <-results:
+				pending--
+				p.completeInsights(ctx, result, from)
+			}
+		}
+
+		p.publishHealth()
+
+		p.log.Debugf("sleeping for %v before querying new logs", p.config.ScanFrequency)
+		select {
+		case <-time.After(p.config.ScanFrequency):
+		case <-ctx.Done():
+		}
+
+		endTime = clock().Add(-p.config.Latency).Truncate(time.Second)
+	}
+}
+
+// completeInsights records the outcome of the query of a batch.
+func (p *cloudwatchPoller) completeInsights(ctx context.Context, result insightsResult, from map[string]time.Time) {
+	if result.err == nil {
+		for _, id := range result.work.logGroupIDs {
+			from[id] = result.work.end
+			p.health.Succeeded(id)
+			p.health.CursorUpdated(id, result.work.end)
+		}
+		p.status.UpdateStatus(status.Running, "Input is running")
+		return
+	}
+	if ctx.Err() != nil {
+		return
+	}
+	for _, id := range result.work.logGroupIDs {
+		p.health.Failed(id, result.err)
+	}
+	msg := fmt.Sprintf("Logs Insights query failed, error: %s", result.err.Error())
+	p.log.Errorw("Logs Insights query failed, the time range is queried again on the next scan",
+		"log_groups", result.work.logGroupIDs, "start_time", result.work.start, "end_time", result.work.end, "error", result.err)
+	p.status.UpdateStatus(status.Degraded, msg)
+}
+
+// insightsBatches returns the works querying the log groups up to endTime.
+// The log groups sharing the same start are queried together, by 50 at most.
+func insightsBatches(logGroupIDs []string, from map[string]time.Time, endTime time.Time) []insightsWork {
+	byStart := map[time.Time][]string{}
+	for _, id := range logGroupIDs {
+		if start := from[id]; start.Before(endTime) {
+			byStart[start] = append(byStart[start], id)
+		}
+	}
+	starts := make([]time.Time, 0, len(byStart))
+	for start := range byStart {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i].Before(starts[j]) })
+
+	var works []insightsWork
+	for _, start := range starts {
+		ids := byStart[start]
+		for len(ids) != 0 {
+			n := min(len(ids), maxInsightsLogGroups)
+			works = append(works, insightsWork{logGroupIDs: ids[:n], start: start, end: endTime})
+			ids = ids[n:]
+		}
+	}
+	return works
+}
+
+// run queries the log groups of the work and checkpoints them once the
+// results are acknowledged.
+func (q *insightsQuerier) run(ctx context.Context, work insightsWork, handler *stateHandler) error {
+	count, err := q.query(ctx, work.logGroupIDs, work.start, work.end)
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-q.tracker.waitFor(count):
+	}
+	if err != nil {
+		// The results that were published are published again by the
+		// next query of the time range.
+		return err
+	}
+	handler.WorkRegisterLogGroups(work.end.UnixMilli(), work.logGroupIDs)
+	for _, id := range work.logGroupIDs {
+		handler.WorkCompleteLogGroup(id, work.end.UnixMilli())
+	}
+	q.log.Debugw("Logs Insights results acknowledged", "log_groups", len(work.logGroupIDs), "end_time", work.end, "results", count)
+	return nil
+}
+
+// query runs the query on the log groups between start, included, and end,
+// excluded, and publishes its result rows. A time range whose results are
+// truncated to insights.limit rows is split in two halves which are queried
+// one after the other. It returns the number of published events.
+func (q *insightsQuerier) query(ctx context.Context, logGroupIDs []string, start, end time.Time) (int, error) {
+	rows, err := q.results(ctx, logGroupIDs, start, end)
+	if err != nil {
+		return 0, err
+	}
+	if len(rows) >= q.config.Limit {
+		if end.Sub(start) >= 2*time.Second {
+			q.metrics.insightsQuerySplitsTotal.Inc()
+			mid := start.Add(end.Sub(start) / 2).Truncate(time.Second)
+			q.log.Debugw("Logs Insights results truncated, splitting the time range",
+				"start_time", start, "end_time", end, "results", len(rows))
+			n, err := q.query(ctx, logGroupIDs, start, mid)
+			if err != nil {
+				return n, err
+			}
+			m, err := q.query(ctx, logGroupIDs, mid, end)
+			return n + m, err
+		}
+		q.log.Warnw("Logs Insights results truncated, some results of the second are missing. "+
+			"Lower the number of result rows of the query, for example with a filter or a coarser aggregation.",
+			"start_time", start, "results", len(rows))
+	}
+
+	for _, row := range rows {
+		q.client.Publish(createInsightsEvent(row, q.region, start, end, q.config.TargetField, q.mapping))
+		q.metrics.cloudwatchEventsCreatedTotal.Inc()
+	}
+	return len(rows), nil
+}
+
+// results runs the query between start and end and returns its result rows.
+func (q *insightsQuerier) results(ctx context.Context, logGroupIDs []string, start, end time.Time) ([][]types.ResultField, error) {
+	identifiers := make([]string, 0, len(logGroupIDs))
+	for _, id := range logGroupIDs {
+		identifiers = append(identifiers, strings.TrimSuffix(id, ":*"))
+	}
+	queryID, err := q.start(ctx, &cloudwatchlogs.StartQueryInput{
+		LogGroupIdentifiers: identifiers,
+		QueryString:         awssdk.String(q.config.Query),
+		StartTime:           awssdk.Int64(start.Unix()),
+		// The end of the time range is inclusive.
+		EndTime: awssdk.Int64(end.Unix() - 1),
+		Limit:   awssdk.Int32(int32(q.config.Limit)), //nolint:gosec // The limit is validated to be at most 10000.
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start Logs Insights query: %w", err)
+	}
+	q.metrics.insightsQueriesTotal.Inc()
+
+	deadline := time.Now().Add(q.config.Timeout)
+	for {
+		select {
+		case <-ctx.Done():
+			q.stop(queryID)
+			return nil, ctx.Err()
+		case <-time.After(q.config.PollInterval):
+		}
+
+		out, err := q.getQueryResults(ctx, queryID)
+		q.metrics.apiCallsTotal.Inc()
+		switch {
+		case err == nil:
+		case isThrottlingError(err):
+			continue
+		default:
+			q.stop(queryID)
+			return nil, fmt.Errorf("failed to get the results of query %s: %w", queryID, err)
+		}
+
+		switch out.Status {
+		case types.QueryStatusComplete:
+			q.metrics.logEventsReceivedTotal.Add(uint64(len(out.Results)))
+			return out.Results, nil
+		case types.QueryStatusFailed, types.QueryStatusCancelled, types.QueryStatusTimeout, types.QueryStatusUnknown:
+			return nil, fmt.Errorf("query %s ended with status %s", queryID, out.Status)
+		}
+		if time.Now().After(deadline) {
+			q.stop(queryID)
+			return nil, fmt.Errorf("query %s did not complete within %v", queryID, q.config.Timeout)
+		}
+	}
+}
+
+// start starts the query, waiting for a running query of the account to
+// complete when the concurrent queries of the account are at their limit.
+func (q *insightsQuerier) start(ctx context.Context, input *cloudwatchlogs.StartQueryInput) (string, error) {
+	var throttleAttempt int
+	for {
+		queryID, err := q.startQuery(ctx, input)
+		q.metrics.apiCallsTotal.Inc()
+		if err == nil {
+			return queryID, nil
+		}
+
+		var apiErr smithy.APIError
+		switch {
+		case errors.As(err, &apiErr) && apiErr.ErrorCode() == "LimitExceededException":
+			q.metrics.insightsQueriesLimitedTotal.Inc()
+			q.log.Debug("Too many concurrent Logs Insights queries in the account, waiting to start the query")
+		case isThrottlingError(err):
+			throttleAttempt++
+			if throttleAttempt > maxThrottleRetries {
+				return "", err
+			}
+		default:
+			return "", err
+		}
+		select {
+		case <-ctx.Done():
+			return "", ctx.Err()
+		case <-time.After(q.config.PollInterval):
+		}
+	}
+}
+
+// stop stops a running query, so that it doesn't count against the
+// concurrent queries of the account.
+func (q *insightsQuerier) stop(queryID string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := q.stopQuery(ctx, queryID); err != nil {
+		q.log.Debugw("Failed to stop Logs Insights query", "query_id", queryID, "error", err)
+	}
+}
+
+// createInsightsEvent returns the event of a result row of a query of the
+// time range between start and end. The @message, @log and @logStream columns
+// are mapped as for the log events, @timestamp is the time of the event, and
+// the other columns are put in targetField. The rows of aggregations, which
+// have no @timestamp, are timestamped with the end of the time range.
+func createInsightsEvent(row []types.ResultField, region string, start, end time.Time, targetField string, mapping eventMappingConfig) beat.Event {
+	var (
+		message    *string
+		ptr        string
+		timestamp  = end.UTC()
+		cloudwatch = mapstr.M{}
+		columns    = mapstr.M{}
+		cloud      = mapstr.M{
+			"provider": "aws",
+			"region":   region,
+		}
+	)
+	for _, f := range row {
+		name, value := awssdk.ToString(f.Field), awssdk.ToString(f.Value)
+		switch name {
+		case "@ptr":
+			ptr = value
+		case "@message":
+			message = &value
+		case "@logStream":
+			cloudwatch["log_stream"] = value
+		case "@log":
+			// The log group is prefixed by the ID of its account.
+			if account, logGroup, found := strings.Cut(value, ":"); found {
+				cloud["account"] = mapstr.M{"id": account}
+				value = logGroup
+			}
+			cloudwatch["log_group"] = value
+		case "@timestamp":
+			if t, err := time.Parse(insightsTimeLayout, value); err == nil {
+				timestamp = t
+				continue
+			}
+			columns[name] = value
+		default:
+			columns[name] = value
+		}
+	}
+
+	eventFields := mapstr.M{
+		"ingested": time.Now(),
+		"start":    start.UTC(),
+		"end":      end.UTC(),
+	}
+	if mapping.PreserveOriginalEvent && message != nil {
+		eventFields["original"] = *message
+	}
+	event := beat.Event{
+		Timestamp: timestamp,
+		Fields: mapstr.M{
+			"event": eventFields,
+			"cloud": cloud,
+		},
+	}
+	if len(cloudwatch) != 0 {
+		_, _ = event.Fields.Put(mapping.CloudWatchTargetField, cloudwatch)
+	}
+	if len(columns) != 0 {
+		_, _ = event.Fields.Put(targetField, columns)
+	}
+	if message != nil {
+		_, _ = event.Fields.Put(mapping.MessageTargetField, *message)
+	}
+
+	// The rows of a log event are identified by its @ptr, the other rows by
+	// their time range and columns, so that a time range queried again
+	// doesn't create duplicates.
+	h := sha256.New()
+	if ptr != "" {
+		h.Write([]byte(ptr))
+	} else {
+		fmt.Fprintf(h, "%d\x00%d", start.Unix(), end.Unix())
+		for _, f := range row {
+			fmt.Fprintf(h, "\x00%s\x00%s", awssdk.ToString(f.Field), awssdk.ToString(f.Value))
+		}
+	}
+	id := hex.EncodeToString(h.Sum(nil)[:16])
+	if mapping.IncludeCloudWatchMetadata {
+		eventFields["id"] = id
+	}
+	event.SetID(id)
+	return event
+}
@@ -0,0 +1,239 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	pubtest "github.com/elastic/beats/v7/libbeat/publisher/testing"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func resultRow(fields ...string) []types.ResultField {
+	row := make([]types.ResultField, 0, len(fields)/2)
+	for i := 0; i < len(fields); i += 2 {
+		row = append(row, types.ResultField{Field: awssdk.String(fields[i]), Value: awssdk.String(fields[i+1])})
+	}
+	return row
+}
+
+func newTestInsightsQuerier(t *testing.T, events *[]beat.Event) *insightsQuerier {
+	cfg := defaultInsightsConfig()
+	cfg.Query = "fields @timestamp, @message"
+	cfg.PollInterval = time.Millisecond
+	return &insightsQuerier{
+		config:  cfg,
+		mapping: defaultEventMappingConfig(),
+		log:     logp.NewLogger("test"),
+		metrics: newInputMetrics(monitoring.NewRegistry()),
+		region:  "us-east-1",
+		client: pubtest.NewChanClientWithCallback(1, func(event beat.Event) {
+			*events = append(*events, event)
+		}),
+		stopQuery: func(context.Context, string) error {
+			t.Fatal("the query must not be stopped")
+			return nil
+		},
+	}
+}
+
+func TestInsightsQuery(t *testing.T) {
+	var events []beat.Event
+	q := newTestInsightsQuerier(t, &events)
+	q.config.Limit = 3
+
+	var started []*cloudwatchlogs.StartQueryInput
+	q.startQuery = func(_ context.Context, input *cloudwatchlogs.StartQueryInput) (string, error) {
+		started = append(started, input)
+		if len(started) == 1 {
+			return "", &smithy.GenericAPIError{Code: "LimitExceededException"}
+		}
+		return fmt.Sprint(len(started)), nil
+	}
+	polled := map[string]bool{}
+	q.getQueryResults = func(_ context.Context, queryID string) (*cloudwatchlogs.GetQueryResultsOutput, error) {
+		if !polled[queryID] {
+			polled[queryID] = true
+			return &cloudwatchlogs.GetQueryResultsOutput{Status: types.QueryStatusRunning}, nil
+		}
+		input := started[len(started)-1]
+		out := &cloudwatchlogs.GetQueryResultsOutput{Status: types.QueryStatusComplete}
+		// The results of the whole time range are truncated.
+		for ts := *input.StartTime; ts <= *input.EndTime; ts++ {
+			out.Results = append(out.Results, resultRow(
+				"@timestamp", time.Unix(ts, 0).UTC().Format(insightsTimeLayout),
+				"@message", fmt.Sprint("message ", ts),
+				"@ptr", fmt.Sprint("ptr-", ts),
+			))
+		}
+		return out, nil
+	}
+
+	start := time.Unix(1792152000, 0)
+	count, err := q.query(context.Background(), []string{"arn:aws:logs:us-east-1:111:log-group:a:*", "b"}, start, start.Add(4*time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+
+	require.Len(t, started, 4, "the query is started again once the concurrent queries are below the limit")
+	assert.Equal(t, []string{"arn:aws:logs:us-east-1:111:log-group:a", "b"}, started[1].LogGroupIdentifiers)
+	assert.Equal(t, "fields @timestamp, @message", *started[1].QueryString)
+	assert.Equal(t, int32(3), *started[1].Limit)
+	times := make([][2]int64, 0, len(started)-1)
+	for _, input := range started[1:] {
+		times = append(times, [2]int64{*input.StartTime - start.Unix(), *input.EndTime - start.Unix()})
+	}
+	assert.Equal(t, [][2]int64{{0, 3}, {0, 1}, {2, 3}}, times, "the truncated time range is split in two halves")
+
+	require.Len(t, events, 4)
+	assert.Equal(t, start.Add(3*time.Second).UTC(), events[3].Timestamp)
+	msg, err := events[3].Fields.GetValue("message")
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprint("message ", start.Unix()+3), msg)
+	assert.Equal(t, uint64(3), q.metrics.insightsQueriesTotal.Get())
+	assert.Equal(t, uint64(1), q.metrics.insightsQuerySplitsTotal.Get())
+	assert.Equal(t, uint64(1), q.metrics.insightsQueriesLimitedTotal.Get())
+}
+
+func TestInsightsQueryTimeout(t *testing.T) {
+	var events []beat.Event
+	q := newTestInsightsQuerier(t, &events)
+	q.config.Timeout = 10 * time.Millisecond
+	q.startQuery = func(context.Context, *cloudwatchlogs.StartQueryInput) (string, error) {
+		return "query-1", nil
+	}
+	q.getQueryResults = func(context.Context, string) (*cloudwatchlogs.GetQueryResultsOutput, error) {
+		return &cloudwatchlogs.GetQueryResultsOutput{Status: types.QueryStatusRunning}, nil
+	}
+	var stopped []string
+	q.stopQuery = func(_ context.Context, queryID string) error {
+		stopped = append(stopped, queryID)
+		return nil
+	}
+
+	start := time.Unix(1792152000, 0)
+	_, err := q.query(context.Background(), []string{"a"}, start, start.Add(time.Minute))
+	assert.ErrorContains(t, err, "query query-1 did not complete within 10ms")
+	assert.Equal(t, []string{"query-1"}, stopped, "the query must not count against the concurrent queries")
+	assert.Empty(t, events)
+}
+
+func TestInsightsQueryFailed(t *testing.T) {
+	var events []beat.Event
+	q := newTestInsightsQuerier(t, &events)
+	q.startQuery = func(context.Context, *cloudwatchlogs.StartQueryInput) (string, error) {
+		return "query-1", nil
+	}
+	q.getQueryResults = func(context.Context, string) (*cloudwatchlogs.GetQueryResultsOutput, error) {
+		return &cloudwatchlogs.GetQueryResultsOutput{Status: types.QueryStatusFailed}, nil
+	}
+
+	start := time.Unix(1792152000, 0)
+	_, err := q.query(context.Background(), []string{"a"}, start, start.Add(time.Minute))
+	assert.ErrorContains(t, err, "query query-1 ended with status Failed")
+}
+
+func TestCreateInsightsEvent(t *testing.T) {
+	start := time.Unix(1792152000, 0)
+	end := start.Add(time.Minute)
+
+	t.Run("log event", func(t *testing.T) {
+		e := createInsightsEvent(resultRow(
+			"@timestamp", "2026-10-16 10:00:01.500",
+			"@message", "GET /index.html 200",
+			"@log", "111:/app/web",
+			"@logStream", "web/1",
+			"@ptr", "CmAKJQohMTEx",
+			"status", "200",
+		), "us-east-1", start, end, "aws.cloudwatch.insights", defaultEventMappingConfig())
+
+		assert.Equal(t, time.Date(2026, 10, 16, 10, 0, 1, 500e6, time.UTC), e.Timestamp)
+		assert.Equal(t, "GET /index.html 200", e.Fields["message"])
+		logGroup, err := e.Fields.GetValue("aws.cloudwatch.log_group")
+		require.NoError(t, err)
+		assert.Equal(t, "/app/web", logGroup)
+		status, err := e.Fields.GetValue("aws.cloudwatch.insights.status")
+		require.NoError(t, err)
+		assert.Equal(t, "200", status)
+		account, err := e.Fields.GetValue("cloud.account.id")
+		require.NoError(t, err)
+		assert.Equal(t, "111", account)
+
+		// The ID of a log event doesn't depend on the time range.
+		other := createInsightsEvent(resultRow("@ptr", "CmAKJQohMTEx"), "us-east-1", end, end.Add(time.Minute), "aws.cloudwatch.insights", defaultEventMappingConfig())
+		assert.Equal(t, e.Meta["_id"], other.Meta["_id"])
+	})
+
+	t.Run("aggregation", func(t *testing.T) {
+		row := resultRow("status", "500", "count()", "42")
+		e := createInsightsEvent(row, "us-east-1", start, end, "insights", defaultEventMappingConfig())
+
+		assert.Equal(t, end.UTC(), e.Timestamp)
+		columns, err := e.Fields.GetValue("insights")
+		require.NoError(t, err)
+		assert.Equal(t, mapstr.M{"status": "500", "count()": "42"}, columns)
+		eventStart, err := e.Fields.GetValue("event.start")
+		require.NoError(t, err)
+		assert.Equal(t, start.UTC(), eventStart)
+		_, err = e.Fields.GetValue("message")
+		assert.Error(t, err)
+
+		other := createInsightsEvent(row, "us-east-1", end, end.Add(time.Minute), "insights", defaultEventMappingConfig())
+		assert.NotEqual(t, e.Meta["_id"], other.Meta["_id"], "the aggregations of distinct time ranges have distinct IDs")
+	})
+}
+
+func TestInsightsBatches(t *testing.T) {
+	t1 := time.Unix(1792152000, 0)
+	t2 := t1.Add(time.Minute)
+	end := t2.Add(time.Minute)
+
+	var ids []string
+	from := map[string]time.Time{}
+	for i := 0; i < 60; i++ {
+		id := fmt.Sprint("lg-", i)
+		ids = append(ids, id)
+		from[id] = t2
+	}
+	from["lg-0"] = t1
+	from["lg-1"] = end
+
+	works := insightsBatches(ids, from, end)
+	require.Len(t, works, 3)
+	assert.Equal(t, insightsWork{logGroupIDs: []string{"lg-0"}, start: t1, end: end}, works[0])
+	assert.Len(t, works[1].logGroupIDs, 50)
+	assert.Len(t, works[2].logGroupIDs, 8, "the log groups queried up to the end are skipped")
+	assert.Equal(t, t2, works[2].start)
+}
+
+func TestInsightsConfig(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.LogGroupNamePrefix = "/aws/lambda/"
+	cfg.RegionName = "us-east-1"
+	cfg.Mode = modeInsights
+	assert.ErrorContains(t, cfg.Validate(), "insights.query is required")
+
+	cfg.Insights.Query = "stats count() by bin(5m)"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.LogStreamPrefix = "app"
+	assert.ErrorContains(t, cfg.Validate(), "filter on @logStream")
+
+	cfg.LogStreamPrefix = ""
+	cfg.Backfill.SliceDuration = time.Hour
+	assert.Error(t, cfg.Validate())
+}
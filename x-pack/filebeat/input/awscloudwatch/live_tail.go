@@ -0,0 +1,341 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/acker"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	modePoll     = "poll"
+	modeLiveTail = "live_tail"
+
+	// maxLiveTailLogGroups is the maximum number of log groups of a Live
+	// Tail session.
+	maxLiveTailLogGroups = 10
+)
+
+// liveTailSession is the event stream of a Live Tail session.
+type liveTailSession interface {
+	Events() <-chan types.StartLiveTailResponseStream
+	Close() error
+	Err() error
+}
+
+// liveTailer streams the log events of the log groups with Live Tail
+// sessions. A session ends after three hours at most, a new session is then
+// started. The events are published with a dedicated client, so that their
+// acknowledgement is tracked apart from the backfills of the workers.
+type liveTailer struct {
+	log       *logp.Logger
+	metrics   *inputMetrics
+	region    string
+	client    beat.Client
+	tracker   *ackTracker
+	processor *logProcessor
+
+	// retryInterval is the time to wait before starting a session again
+	// after a failure.
+	retryInterval time.Duration
+
+	startSession func(ctx context.Context, logGroupIDs []string) (liveTailSession, error)
+}
+
+func newLiveTailer(cfg config, region string, svc *cloudwatchlogs.Client, metrics *inputMetrics, pipeline beat.Pipeline, log *logp.Logger) (*liveTailer, error) {
+	tracker := newACKTracker()
+	client, err := pipeline.ConnectWith(beat.ClientConfig{
+		EventListener: acker.TrackingCounter(func(_ int, by int) {
+			tracker.increaseAck(by)
+		}),
+	})
+	if err != nil {
+		tracker.close()
+		return nil, fmt.Errorf("failed to create pipeline client: %w", err)
+	}
+
+	processor := newLogProcessor(log, metrics, client, cfg.EventMapping)
+	processor.derivedIDs = &derivedIDs{}
+	return &liveTailer{
+		log:           log,
+		metrics:       metrics,
+		region:        region,
+		client:        client,
+		tracker:       tracker,
+		processor:     processor,
+		retryInterval: cfg.ScanFrequency,
+		startSession: func(ctx context.Context, logGroupIDs []string) (liveTailSession, error) {
+			input := &cloudwatchlogs.StartLiveTailInput{
+				LogGroupIdentifiers: logGroupIDs,
+			}
+			for _, stream := range cfg.LogStreams {
+				input.LogStreamNames = append(input.LogStreamNames, *stream)
+			}
+			if cfg.LogStreamPrefix != "" {
+				input.LogStreamNamePrefixes = []string{cfg.LogStreamPrefix}
+			}
+			if cfg.FilterPattern != "" {
+				input.LogEventFilterPattern = awssdk.String(cfg.FilterPattern)
+			}
+			out, err := svc.StartLiveTail(ctx, input)
+			if err != nil {
+				return nil, err
+			}
+			return out.GetStream(), nil
+		},
+	}, nil
+}
+
+func (t *liveTailer) close() {
+	t.client.Close()
+	t.tracker.close()
+}
+
+// runLiveTail streams the log events of the log groups with Live Tail
+// sessions. The Infrequent Access log groups, which don't support Live Tail,
+// are polled with FilterLogEvents.
+func (p *cloudwatchPoller) runLiveTail(ctx context.Context, logGroupIDs []string, clock func() time.Time) {
+	tailed, polled := p.classes.split(ctx, logGroupIDs)
+	if len(polled) != 0 {
+		p.log.Infow("Polling the Infrequent Access log groups with FilterLogEvents, they don't support Live Tail",
+			"log_groups", polled)
+	}
+	switch {
+	case len(tailed) == 0:
+		p.receive(ctx, polled, clock)
+	case len(polled) == 0:
+		p.receiveLiveTail(ctx, tailed, clock)
+	default:
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			p.receive(ctx, polled, clock)
+		}()
+		p.receiveLiveTail(ctx, tailed, clock)
+		wg.Wait()
+	}
+}
+
+// receiveLiveTail implements the run loop of the live_tail mode. Each log
+// group is backfilled with FilterLogEvents by the workers, from its start
+// position or checkpoint up to the start of the first Live Tail session, and
+// the log events ingested afterwards are streamed. When a session ends, the
+// time until the next session is established is backfilled as well.
+func (p *cloudwatchPoller) receiveLiveTail(ctx context.Context, logGroupIDs []string, clock func() time.Time) {
+	var dispatchWg sync.WaitGroup
+	defer p.workerWg.Wait()
+	defer dispatchWg.Wait()
+
+	// from holds the start of the next backfill of each log group.
+	now := clock()
+	startTime := p.startTime(now, p.config.ScanFrequency)
+	from := make(map[string]time.Time, len(logGroupIDs))
+	for _, id := range logGroupIDs {
+		from[id] = p.checkpoint(id, startTime, now)
+	}
+
+	backfill := func(sessionStart time.Time) {
+		// The backfills are registered before the session events, so that
+		// the checkpoints advance in order.
+		p.stateHandler.WorkRegisterLogGroups(sessionStart.UnixMilli(), logGroupIDs)
+		works := make([]workResponse, 0, len(logGroupIDs))
+		for _, id := range logGroupIDs {
+			works = append(works, p.slices(logGroup{id: id}, from[id], sessionStart)...)
+			from[id] = sessionStart
+		}
+		// The session events are read while the workers are busy.
+		dispatchWg.Add(1)
+		go func() {
+			defer dispatchWg.Done()
+			p.dispatch(ctx, works)
+		}()
+	}
+
+	for ctx.Err() == nil {
+		sessionStart, sampledAt, count, err := p.liveTail.tail(ctx, logGroupIDs, backfill, clock)
+		if err != nil {
+			p.log.Errorw("Live Tail session failed", "error", err)
+		}
+		if !sessionStart.IsZero() {
+			sessionEnd := clock()
+			if !sampledAt.IsZero() {
+				// The log events sampled out by CloudWatch are read by the
+				// next backfill.
+				sessionEnd = sampledAt
+			}
+			p.completeSession(ctx, logGroupIDs, from, sessionStart, sessionEnd, count)
+			p.publishHealth()
+		}
+		if err != nil || sessionStart.IsZero() {
+			select {
+			case <-ctx.Done():
+			case <-time.After(p.liveTail.retryInterval):
+			}
+		}
+	}
+}
+
+// completeSession checkpoints the log groups at sessionEnd once the events of
+// the Live Tail session are acknowledged. sessionEnd is the end of the
+// session, or the time its results started to be sampled. The next backfill
+// starts from there.
+func (p *cloudwatchPoller) completeSession(ctx context.Context, logGroupIDs []string, from map[string]time.Time, sessionStart, sessionEnd time.Time, count int) {
+	if ctx.Err() != nil {
+		return
+	}
+	// A session ending in the millisecond it started is not checkpointed,
+	// the timestamp is the one of its backfill.
+	checkpointed := sessionEnd.UnixMilli() > sessionStart.UnixMilli()
+	if checkpointed {
+		p.stateHandler.WorkRegisterLogGroups(sessionEnd.UnixMilli(), logGroupIDs)
+	}
+	select {
+	case <-ctx.Done():
+		return
+	case <-p.liveTail.tracker.waitFor(count):
+	}
+	if !checkpointed {
+		return
+	}
+	for _, id := range logGroupIDs {
+		p.stateHandler.WorkCompleteLogGroup(id, sessionEnd.UnixMilli())
+		from[id] = sessionEnd
+	}
+}
+
+// dispatch hands the works over to the workers.
+func (p *cloudwatchPoller) dispatch(ctx context.Context, works []workResponse) {
+	for _, work := range works {
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.workRequestChan:
+			p.workResponseChan <- work
+		}
+	}
+}
+
+// tail runs a Live Tail session of the log groups and publishes its log
+// events until the session ends. onStart is called with the time the session
+// was established at. It returns this time, zero if the session was not
+// established, the time up to which all the log events of the session were
+// delivered if its results were sampled, zero otherwise, and the number of
+// published events.
+func (t *liveTailer) tail(ctx context.Context, logGroupIDs []string, onStart func(time.Time), clock func() time.Time) (time.Time, time.Time, int, error) {
+	// The identifiers of the events are the ARNs of the log groups.
+	arns := make([]string, 0, len(logGroupIDs))
+	ids := make(map[string]string, len(logGroupIDs))
+	for _, id := range logGroupIDs {
+		a := strings.TrimSuffix(id, ":*")
+		arns = append(arns, a)
+		ids[a] = id
+	}
+
+	session, err := t.startSession(ctx, arns)
+	if err != nil {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("failed to start Live Tail session: %w", err)
+	}
+	defer session.Close()
+	t.metrics.apiCallsTotal.Inc()
+
+	var (
+		sessionStart time.Time
+		count        int
+		// delivered is the time of the last session update whose results
+		// were not sampled, sampledAt the one before the first sampled
+		// update.
+		delivered time.Time
+		sampledAt time.Time
+	)
+	for {
+		var event types.StartLiveTailResponseStream
+		var ok bool
+		select {
+		case <-ctx.Done():
+			return sessionStart, sampledAt, count, nil
+		case event, ok = <-session.Events():
+		}
+		if !ok {
+			return sessionStart, sampledAt, count, session.Err()
+		}
+
+		switch e := event.(type) {
+		case *types.StartLiveTailResponseStreamMemberSessionStart:
+			sessionStart = clock()
+			delivered = sessionStart
+			t.metrics.liveTailSessionsTotal.Inc()
+			t.log.Infow("Live Tail session started",
+				"session_id", awssdk.ToString(e.Value.SessionId), "log_groups", len(arns))
+			onStart(sessionStart)
+		case *types.StartLiveTailResponseStreamMemberSessionUpdate:
+			if e.Value.SessionMetadata != nil && e.Value.SessionMetadata.Sampled {
+				t.metrics.liveTailSampledUpdatesTotal.Inc()
+				if sampledAt.IsZero() {
+					sampledAt = delivered
+					t.log.Warn("Live Tail session results are sampled, log events are missing. They are read " +
+						"by the next backfill. Use the poll mode for log groups ingesting more than 500 log events per second.")
+				}
+			} else if sampledAt.IsZero() {
+				delivered = clock()
+			}
+			t.metrics.logEventsReceivedTotal.Add(uint64(len(e.Value.SessionResults)))
+			for _, le := range e.Value.SessionResults {
+				id := awssdk.ToString(le.LogGroupIdentifier)
+				if configured, found := ids[id]; found {
+					id = configured
+				}
+				// The processor derives the ID of the log event.
+				logEvent := types.FilteredLogEvent{
+					IngestionTime: le.IngestionTime,
+					LogStreamName: le.LogStreamName,
+					Message:       le.Message,
+					Timestamp:     le.Timestamp,
+				}
+				count += t.processor.processLogEvents([]types.FilteredLogEvent{logEvent}, id, t.region, "")
+			}
+		}
+	}
+}
+
+// liveTailLogGroupARNs returns the ARNs of the log groups, as expected by
+// StartLiveTail. The log groups given by name are looked up in the account.
+func liveTailLogGroupARNs(ctx context.Context, svc *cloudwatchlogs.Client, logGroupIDs []string) ([]string, error) {
+	arns := make([]string, 0, len(logGroupIDs))
+	for _, id := range logGroupIDs {
+		if arn.IsARN(id) {
+			arns = append(arns, id)
+			continue
+		}
+		groups, err := describeLogGroups(ctx, svc, id, false)
+		if err != nil {
+			return nil, err
+		}
+		found := false
+		for _, lg := range groups {
+			if lg.name == id {
+				arns = append(arns, lg.arn)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("log group %q not found", id)
+		}
+	}
+	return arns, nil
+}
@@ -0,0 +1,191 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	pubtest "github.com/elastic/beats/v7/libbeat/publisher/testing"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+type fakeLiveTailSession struct {
+	events chan types.StartLiveTailResponseStream
+}
+
+func (s *fakeLiveTailSession) Events() <-chan types.StartLiveTailResponseStream { return s.events }
+func (s *fakeLiveTailSession) Close() error                                     { return nil }
+func (s *fakeLiveTailSession) Err() error                                       { return nil }
+
+func sessionStart() types.StartLiveTailResponseStream {
+	return &types.StartLiveTailResponseStreamMemberSessionStart{
+		Value: types.LiveTailSessionStart{SessionId: awssdk.String("session")},
+	}
+}
+
+func sessionUpdate(sampled bool, messages ...string) types.StartLiveTailResponseStream {
+	update := types.LiveTailSessionUpdate{
+		SessionMetadata: &types.LiveTailSessionMetadata{Sampled: sampled},
+	}
+	for i, msg := range messages {
+		update.SessionResults = append(update.SessionResults, types.LiveTailSessionLogEvent{
+			LogGroupIdentifier: awssdk.String("arn:aws:logs:us-east-1:111:log-group:a"),
+			LogStreamName:      awssdk.String("stream"),
+			Message:            awssdk.String(msg),
+			Timestamp:          awssdk.Int64(int64(1792152000000 + i)),
+			IngestionTime:      awssdk.Int64(int64(1792152001000 + i)),
+		})
+	}
+	return &types.StartLiveTailResponseStreamMemberSessionUpdate{Value: update}
+}
+
+func TestReceiveLiveTail(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const logGroup = "arn:aws:logs:us-east-1:111:log-group:a:*"
+	t0 := time.Unix(0, 0)
+	t1 := time.Unix(1792152000, 0)
+	t2 := t1.Add(3 * time.Hour)
+	t3 := t2.Add(time.Second)
+
+	cfg := defaultConfig()
+	cfg.LogGroupARN = logGroup
+	cfg.Mode = modeLiveTail
+	handler, err := newStateHandler(nil, cfg, createTestInputStore())
+	require.NoError(t, err)
+
+	metrics := newInputMetrics(monitoring.NewRegistry())
+	tracker := newACKTracker()
+	defer tracker.close()
+	var events []beat.Event
+	client := pubtest.NewChanClientWithCallback(1, func(event beat.Event) {
+		events = append(events, event)
+		tracker.increaseAck(1)
+	})
+
+	processor := newLogProcessor(logp.NewLogger("test"), metrics, client, cfg.EventMapping)
+	processor.derivedIDs = &derivedIDs{}
+	sessions := make(chan *fakeLiveTailSession)
+	var sessionLogGroups []string
+	p := &cloudwatchPoller{
+		workRequestChan:  make(chan struct{}),
+		workResponseChan: make(chan workResponse),
+		log:              logp.NewLogger("test"),
+		metrics:          metrics,
+		stateHandler:     handler,
+		config:           cfg,
+		liveTail: &liveTailer{
+			log:           logp.NewLogger("test"),
+			metrics:       metrics,
+			region:        "us-east-1",
+			client:        client,
+			tracker:       tracker,
+			processor:     processor,
+			retryInterval: time.Millisecond,
+			startSession: func(ctx context.Context, logGroupIDs []string) (liveTailSession, error) {
+				sessionLogGroups = logGroupIDs
+				select {
+				case s := <-sessions:
+					return s, nil
+				case <-ctx.Done():
+					return nil, ctx.Err()
+				}
+			},
+		},
+	}
+	clock := &clock{time: t1}
+	go p.receiveLiveTail(ctx, []string{logGroup}, clock.now)
+
+	// The first session backfills the log group from its start position.
+	first := &fakeLiveTailSession{events: make(chan types.StartLiveTailResponseStream)}
+	sessions <- first
+	first.events <- sessionStart()
+	p.workRequestChan <- struct{}{}
+	assert.Equal(t, workResponse{logGroupId: logGroup, startTime: t0, endTime: t1}, <-p.workResponseChan)
+	assert.Equal(t, []string{"arn:aws:logs:us-east-1:111:log-group:a"}, sessionLogGroups)
+
+	// The results are sampled after an hour.
+	sampledAt := t1.Add(time.Hour)
+	clock.time = sampledAt
+	first.events <- sessionUpdate(false, "first", "second")
+	first.events <- sessionUpdate(true, "third")
+	// The session ends after three hours, the backfill is complete.
+	handler.WorkCompleteLogGroup(logGroup, t1.UnixMilli())
+	clock.time = t2
+	close(first.events)
+
+	// The time from the first sampled results until the next session is
+	// established is backfilled.
+	second := &fakeLiveTailSession{events: make(chan types.StartLiveTailResponseStream)}
+	sessions <- second
+	clock.time = t3
+	second.events <- sessionStart()
+	p.workRequestChan <- struct{}{}
+	assert.Equal(t, workResponse{logGroupId: logGroup, startTime: sampledAt, endTime: t3}, <-p.workResponseChan)
+
+	// The log group is checkpointed at the first sampled results of the
+	// first session.
+	assert.Eventually(t, func() bool {
+		state, found, err := handler.GetLogGroupState(logGroup)
+		return err == nil && found && state.LastSyncEpoch == sampledAt.UnixMilli()
+	}, time.Second, 10*time.Millisecond)
+	cancel()
+
+	require.Len(t, events, 3)
+	for i, msg := range []string{"first", "second", "third"} {
+		got, _ := events[i].Fields.GetValue("message")
+		assert.Equal(t, msg, got)
+		lg, _ := events[i].Fields.GetValue("aws.cloudwatch.log_group")
+		assert.Equal(t, logGroup, lg, "the events have the configured log group identifier")
+		assert.NotEmpty(t, events[i].Meta["_id"])
+	}
+	assert.Equal(t, uint64(2), metrics.liveTailSessionsTotal.Get())
+	assert.Equal(t, uint64(1), metrics.liveTailSampledUpdatesTotal.Get())
+	assert.Equal(t, uint64(3), metrics.logEventsReceivedTotal.Get())
+
+	// The log events read by a backfill have the same IDs as the ones
+	// streamed by a session.
+	var backfilled []beat.Event
+	backfill := newLogProcessor(logp.NewLogger("test"), metrics, pubtest.NewChanClientWithCallback(1, func(event beat.Event) {
+		backfilled = append(backfilled, event)
+	}), cfg.EventMapping)
+	backfill.derivedIDs = &derivedIDs{}
+	backfill.processLogEvents([]types.FilteredLogEvent{{
+		EventId:       awssdk.String("cloudwatch-id"),
+		LogStreamName: awssdk.String("stream"),
+		Message:       awssdk.String("first"),
+		Timestamp:     awssdk.Int64(1792152000000),
+	}}, logGroup, "us-east-1", "")
+	require.Len(t, backfilled, 1)
+	assert.Equal(t, events[0].Meta["_id"], backfilled[0].Meta["_id"])
+}
+
+func TestLiveTailConfig(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.LogGroupNamePrefix = "/aws/lambda/"
+	cfg.RegionName = "us-east-1"
+	cfg.Mode = "stream"
+	assert.ErrorContains(t, cfg.Validate(), "mode config parameter can only be one of poll, live_tail or insights")
+
+	cfg.Mode = modeLiveTail
+	assert.NoError(t, cfg.Validate())
+
+	cfg.LogStreamPrefix = "app"
+	assert.ErrorContains(t, cfg.Validate(), "single log group")
+
+	cfg.LogStreamPrefix = ""
+	cfg.Discovery.Enabled = true
+	assert.Error(t, cfg.Validate())
+}
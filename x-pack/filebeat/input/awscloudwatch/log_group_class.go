@@ -0,0 +1,182 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/smithy-go"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// maxDescribeLogGroupIdentifiers is the maximum number of log groups
+// identifiers of a DescribeLogGroups request.
+const maxDescribeLogGroupIdentifiers = 50
+
+// logGroupClasses looks up and caches the classes of the log groups. The log
+// groups of the Infrequent Access class don't support Live Tail nor exports to
+// S3, they are polled with FilterLogEvents instead. A log group whose
+// FilterLogEvents requests are rejected because of its class is skipped.
+// A nil *logGroupClasses reports all log groups as Standard.
+type logGroupClasses struct {
+	log *logp.Logger
+
+	// describe returns the classes of the log groups, by identifier.
+	describe func(ctx context.Context, logGroupIDs []string) (map[string]types.LogGroupClass, error)
+
+	mu      sync.Mutex
+	classes map[string]types.LogGroupClass
+	skipped map[string]bool
+}
+
+func newLogGroupClasses(svc *cloudwatchlogs.Client, log *logp.Logger) *logGroupClasses {
+	return &logGroupClasses{
+		log: log,
+		describe: func(ctx context.Context, logGroupIDs []string) (map[string]types.LogGroupClass, error) {
+			return describeLogGroupClasses(ctx, svc, logGroupIDs)
+		},
+		classes: map[string]types.LogGroupClass{},
+		skipped: map[string]bool{},
+	}
+}
+
+// lookup describes the log groups whose class is not known yet. The log
+// groups that can't be described are considered Standard.
+func (c *logGroupClasses) lookup(ctx context.Context, logGroupIDs []string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var unknown []string
+	for _, id := range logGroupIDs {
+		if _, found := c.classes[id]; !found {
+			unknown = append(unknown, id)
+		}
+	}
+	for len(unknown) != 0 {
+		batch := unknown[:min(len(unknown), maxDescribeLogGroupIdentifiers)]
+		unknown = unknown[len(batch):]
+
+		classes, err := c.describe(ctx, batch)
+		if err != nil {
+			c.log.Warnw("Failed to describe the class of the log groups, considering them Standard", "error", err)
+		}
+		for _, id := range batch {
+			class, found := classes[id]
+			if !found {
+				class = types.LogGroupClassStandard
+			}
+			c.classes[id] = class
+			if class == types.LogGroupClassInfrequentAccess {
+				c.log.Infow("Log group has the Infrequent Access log class, it is polled with FilterLogEvents", "log_group", id)
+			}
+		}
+	}
+}
+
+// infrequentAccess reports whether the log group has the Infrequent Access
+// class, describing it if needed.
+func (c *logGroupClasses) infrequentAccess(ctx context.Context, logGroupID string) bool {
+	if c == nil {
+		return false
+	}
+	c.lookup(ctx, []string{logGroupID})
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.classes[logGroupID] == types.LogGroupClassInfrequentAccess
+}
+
+// split returns the Standard and the Infrequent Access log groups.
+func (c *logGroupClasses) split(ctx context.Context, logGroupIDs []string) (standard, infrequentAccess []string) {
+	if c == nil {
+		return logGroupIDs, nil
+	}
+	c.lookup(ctx, logGroupIDs)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, id := range logGroupIDs {
+		if c.classes[id] == types.LogGroupClassInfrequentAccess {
+			infrequentAccess = append(infrequentAccess, id)
+		} else {
+			standard = append(standard, id)
+		}
+	}
+	return standard, infrequentAccess
+}
+
+// skip stops the polling of the log group.
+func (c *logGroupClasses) skip(logGroupID string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.skipped[logGroupID] = true
+}
+
+// isSkipped reports whether the polling of the log group was stopped.
+func (c *logGroupClasses) isSkipped(logGroupID string) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.skipped[logGroupID]
+}
+
+// isUnsupportedOperationError reports whether err is caused by an operation
+// the log group class does not support.
+func isUnsupportedOperationError(err error) bool {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "InvalidOperationException", "InvalidParameterException", "UnsupportedOperationException":
+			return true
+		}
+	}
+	return false
+}
+
+// describeLogGroupClasses uses the DescribeLogGroups API to get the classes of
+// the log groups identified by name or ARN.
+func describeLogGroupClasses(ctx context.Context, svc *cloudwatchlogs.Client, logGroupIDs []string) (map[string]types.LogGroupClass, error) {
+	// DescribeLogGroups expects the ARNs without the trailing :*.
+	ids := make(map[string]string, len(logGroupIDs))
+	identifiers := make([]string, 0, len(logGroupIDs))
+	for _, id := range logGroupIDs {
+		identifier := strings.TrimSuffix(id, ":*")
+		ids[identifier] = id
+		identifiers = append(identifiers, identifier)
+	}
+
+	classes := make(map[string]types.LogGroupClass, len(logGroupIDs))
+	paginator := cloudwatchlogs.NewDescribeLogGroupsPaginator(svc, &cloudwatchlogs.DescribeLogGroupsInput{
+		LogGroupIdentifiers: identifiers,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return classes, fmt.Errorf("error DescribeLogGroups with Paginator: %w", err)
+		}
+		for _, lg := range page.LogGroups {
+			for _, identifier := range []string{awssdk.ToString(lg.LogGroupName), strings.TrimSuffix(awssdk.ToString(lg.LogGroupArn), ":*")} {
+				if id, found := ids[identifier]; found {
+					classes[id] = lg.LogGroupClass
+				}
+			}
+		}
+	}
+	return classes, nil
+}
@@ -0,0 +1,91 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func TestLogGroupClasses(t *testing.T) {
+	ctx := context.Background()
+	var requests [][]string
+	c := &logGroupClasses{
+		log: logp.NewLogger("test"),
+		describe: func(_ context.Context, ids []string) (map[string]types.LogGroupClass, error) {
+			requests = append(requests, ids)
+			classes := map[string]types.LogGroupClass{}
+			for _, id := range ids {
+				switch id {
+				case "ia-1", "ia-2":
+					classes[id] = types.LogGroupClassInfrequentAccess
+				case "unknown":
+				default:
+					classes[id] = types.LogGroupClassStandard
+				}
+			}
+			return classes, nil
+		},
+		classes: map[string]types.LogGroupClass{},
+		skipped: map[string]bool{},
+	}
+
+	ids := []string{"ia-1", "unknown"}
+	for i := 0; i < maxDescribeLogGroupIdentifiers; i++ {
+		ids = append(ids, fmt.Sprintf("std-%d", i))
+	}
+	standard, infrequentAccess := c.split(ctx, ids)
+	assert.Equal(t, []string{"ia-1"}, infrequentAccess)
+	assert.Len(t, standard, maxDescribeLogGroupIdentifiers+1, "the log groups that can't be described are Standard")
+	assert.Len(t, requests, 2, "the log groups are described in batches")
+
+	// The classes are cached, only the new log groups are described.
+	assert.True(t, c.infrequentAccess(ctx, "ia-1"))
+	assert.True(t, c.infrequentAccess(ctx, "ia-2"))
+	assert.False(t, c.infrequentAccess(ctx, "std-0"))
+	assert.Len(t, requests, 3)
+	assert.Equal(t, []string{"ia-2"}, requests[2])
+
+	assert.False(t, c.isSkipped("ia-1"))
+	c.skip("ia-1")
+	assert.True(t, c.isSkipped("ia-1"))
+
+	p := &cloudwatchPoller{classes: c}
+	assert.Equal(t, []logGroup{{id: "ia-2"}}, p.withoutSkipped([]logGroup{{id: "ia-1"}, {id: "ia-2"}}))
+}
+
+func TestLogGroupClassesDescribeError(t *testing.T) {
+	c := &logGroupClasses{
+		log: logp.NewLogger("test"),
+		describe: func(context.Context, []string) (map[string]types.LogGroupClass, error) {
+			return nil, errors.New("access denied")
+		},
+		classes: map[string]types.LogGroupClass{},
+		skipped: map[string]bool{},
+	}
+	assert.False(t, c.infrequentAccess(context.Background(), "a"))
+
+	// A nil *logGroupClasses considers all the log groups Standard.
+	var none *logGroupClasses
+	standard, infrequentAccess := none.split(context.Background(), []string{"a"})
+	assert.Equal(t, []string{"a"}, standard)
+	assert.Empty(t, infrequentAccess)
+	assert.False(t, none.isSkipped("a"))
+}
+
+func TestIsUnsupportedOperationError(t *testing.T) {
+	assert.True(t, isUnsupportedOperationError(fmt.Errorf("error FilterLogEvents with Paginator: %w",
+		&smithy.GenericAPIError{Code: "InvalidOperationException"})))
+	assert.False(t, isUnsupportedOperationError(&smithy.GenericAPIError{Code: "ThrottlingException"}))
+	assert.False(t, isUnsupportedOperationError(errors.New("connection reset")))
+}
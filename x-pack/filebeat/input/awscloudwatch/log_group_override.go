@@ -0,0 +1,227 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// logGroupOverride sets the settings of the log groups matching LogGroupARN
+// or LogGroupNamePattern, instead of the ones of the input. The settings that
+// are not set are the ones of the input.
+type logGroupOverride struct {
+	// LogGroupARN is the ARN of the log group. It matches the log group of
+	// the same name when the log groups are selected by name.
+	LogGroupARN string `config:"log_group_arn"`
+	// LogGroupNamePattern is a glob pattern matched against the log group
+	// names.
+	LogGroupNamePattern string `config:"log_group_name_pattern"`
+
+	ScanFrequency   *time.Duration          `config:"scan_frequency"`
+	Latency         *time.Duration          `config:"latency"`
+	LogStreamPrefix *string                 `config:"log_stream_prefix"`
+	FilterPattern   *string                 `config:"filter_pattern"`
+	Processors      processors.PluginConfig `config:"processors"`
+}
+
+func (o *logGroupOverride) Validate() error {
+	if (o.LogGroupARN == "") == (o.LogGroupNamePattern == "") {
+		return errors.New("one of log_group_arn and log_group_name_pattern is required")
+	}
+	if o.LogGroupARN != "" {
+		if _, err := arn.Parse(o.LogGroupARN); err != nil {
+			return fmt.Errorf("invalid log group ARN %q: %w", o.LogGroupARN, err)
+		}
+	}
+	if _, err := path.Match(o.LogGroupNamePattern, ""); err != nil {
+		return fmt.Errorf("invalid log group name pattern %q: %w", o.LogGroupNamePattern, err)
+	}
+	if o.ScanFrequency != nil && *o.ScanFrequency <= 0 {
+		return errors.New("scan_frequency must be greater than zero")
+	}
+	if o.Latency != nil && *o.Latency < 0 {
+		return errors.New("latency cannot be negative")
+	}
+	return nil
+}
+
+// matches returns whether the override applies to the log group identified
+// by its name or its ARN.
+func (o *logGroupOverride) matches(logGroupID string) bool {
+	if o.LogGroupARN == "" {
+		ok, _ := path.Match(o.LogGroupNamePattern, logGroupName(logGroupID))
+		return ok
+	}
+	if _, err := arn.Parse(logGroupID); err != nil {
+		// The log groups selected by name are identified by their name.
+		return logGroupID == logGroupName(o.LogGroupARN)
+	}
+	return strings.TrimSuffix(logGroupID, ":*") == strings.TrimSuffix(o.LogGroupARN, ":*")
+}
+
+// logGroupOverrides are the log_group_overrides of the input. The first one
+// matching a log group applies.
+type logGroupOverrides []logGroupOverride
+
+// match returns the index of the override of the log group, -1 and nil if
+// none matches.
+func (s logGroupOverrides) match(logGroupID string) (int, *logGroupOverride) {
+	for i := range s {
+		if s[i].matches(logGroupID) {
+			return i, &s[i]
+		}
+	}
+	return -1, nil
+}
+
+// filterPatternOverride is an entry of the deprecated filter_patterns, the
+// same as a log_group_overrides entry setting only filter_pattern, except
+// that the log events of the matching log groups are not filtered if it is
+// not set.
+type filterPatternOverride struct {
+	LogGroupNamePattern string `config:"log_group_name_pattern" validate:"required"`
+	FilterPattern       string `config:"filter_pattern"`
+}
+
+func (o *filterPatternOverride) Validate() error {
+	override := o.override()
+	return override.Validate()
+}
+
+// override returns the log_group_overrides entry of o.
+func (o *filterPatternOverride) override() logGroupOverride {
+	return logGroupOverride{LogGroupNamePattern: o.LogGroupNamePattern, FilterPattern: &o.FilterPattern}
+}
+
+// logGroupOverrides returns the log_group_overrides of the input followed by
+// the entries of filter_patterns.
+func (c *config) logGroupOverrides() logGroupOverrides {
+	if len(c.FilterPatterns) == 0 {
+		return c.LogGroupOverrides
+	}
+	overrides := make(logGroupOverrides, 0, len(c.LogGroupOverrides)+len(c.FilterPatterns))
+	overrides = append(overrides, c.LogGroupOverrides...)
+	for i := range c.FilterPatterns {
+		overrides = append(overrides, c.FilterPatterns[i].override())
+	}
+	return overrides
+}
+
+// logGroupOverride returns the override of the log group, nil if none
+// matches. The entries of filter_patterns match after the ones of
+// log_group_overrides.
+func (c *config) logGroupOverride(logGroupID string) *logGroupOverride {
+	if _, o := c.LogGroupOverrides.match(logGroupID); o != nil {
+		return o
+	}
+	for i := range c.FilterPatterns {
+		if o := c.FilterPatterns[i].override(); o.matches(logGroupID) {
+			return &o
+		}
+	}
+	return nil
+}
+
+// filterPattern returns the filter pattern of the log group.
+func (c *config) filterPattern(logGroupID string) string {
+	if o := c.logGroupOverride(logGroupID); o != nil && o.FilterPattern != nil {
+		return *o.FilterPattern
+	}
+	return c.FilterPattern
+}
+
+// scanFrequency returns the time between the scans of the log group.
+func (c *config) scanFrequency(logGroupID string) time.Duration {
+	if o := c.logGroupOverride(logGroupID); o != nil && o.ScanFrequency != nil {
+		return *o.ScanFrequency
+	}
+	return c.ScanFrequency
+}
+
+// minScanFrequency returns the shortest time between the scans of the log
+// groups, the interval of the main loop of the input.
+func (c *config) minScanFrequency() time.Duration {
+	d := c.ScanFrequency
+	for _, o := range c.LogGroupOverrides {
+		if o.ScanFrequency != nil && *o.ScanFrequency < d {
+			d = *o.ScanFrequency
+		}
+	}
+	return d
+}
+
+// latency returns the time the scans of the log group stay behind the
+// current time.
+func (c *config) latency(logGroupID string) time.Duration {
+	if o := c.logGroupOverride(logGroupID); o != nil && o.Latency != nil {
+		return *o.Latency
+	}
+	return c.Latency
+}
+
+// logStreamPrefix returns the prefix of the log streams read from the log
+// group.
+func (c *config) logStreamPrefix(logGroupID string) string {
+	if o := c.logGroupOverride(logGroupID); o != nil && o.LogStreamPrefix != nil {
+		return *o.LogStreamPrefix
+	}
+	return c.LogStreamPrefix
+}
+
+// overrideProcessors are the processors of the log_group_overrides entries,
+// by the index of the entry, nil for the entries without processors. They
+// are created once per input and shared by its workers.
+type overrideProcessors []*processors.Processors
+
+func newOverrideProcessors(overrides logGroupOverrides, log *logp.Logger) (overrideProcessors, error) {
+	procs := make(overrideProcessors, len(overrides))
+	for i, o := range overrides {
+		if len(o.Processors) == 0 {
+			continue
+		}
+		p, err := processors.New(o.Processors, log)
+		if err != nil {
+			procs.close()
+			return nil, fmt.Errorf("failed to create the processors of log_group_overrides entry %d: %w", i, err)
+		}
+		procs[i] = p
+	}
+	return procs, nil
+}
+
+// run runs the processors of the entry at index i on the event, and returns
+// false if they drop it. The event is returned as is if the entry has no
+// processors.
+func (s overrideProcessors) run(i int, event beat.Event, log *logp.Logger) (beat.Event, bool) {
+	if i < 0 || i >= len(s) || s[i] == nil {
+		return event, true
+	}
+	out, err := s[i].Run(&event)
+	if err != nil {
+		log.Errorw("Failed to run the processors of log_group_overrides", "entry", i, "error", err)
+	}
+	if out == nil {
+		return beat.Event{}, false
+	}
+	return *out, true
+}
+
+func (s overrideProcessors) close() {
+	for _, p := range s {
+		if p != nil {
+			_ = p.Close()
+		}
+	}
+}
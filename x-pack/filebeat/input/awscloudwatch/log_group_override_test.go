@@ -0,0 +1,201 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	_ "github.com/elastic/beats/v7/libbeat/processors/actions"
+	pubtest "github.com/elastic/beats/v7/libbeat/publisher/testing"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestLogGroupOverride(t *testing.T) {
+	minute, noPrefix, filter := time.Minute, "", "ERROR"
+	cfg := defaultConfig()
+	cfg.Latency = 10 * time.Second
+	cfg.LogStreamPrefix = "app-"
+	cfg.FilterPattern = "?ERROR ?WARN"
+	cfg.LogGroupOverrides = logGroupOverrides{
+		{LogGroupARN: "arn:aws:logs:us-east-1:111:log-group:/aws/lambda/api:*", Latency: &minute, FilterPattern: &filter},
+		{LogGroupNamePattern: "/aws/lambda/*", ScanFrequency: &minute, LogStreamPrefix: &noPrefix},
+	}
+	for _, o := range cfg.LogGroupOverrides {
+		assert.NoError(t, o.Validate())
+	}
+
+	tests := map[string]struct {
+		index                 int
+		scanFrequency         time.Duration
+		latency               time.Duration
+		prefix, filterPattern string
+	}{
+		"arn:aws:logs:us-east-1:111:log-group:/aws/lambda/api": {index: 0, scanFrequency: cfg.ScanFrequency, latency: time.Minute, prefix: "app-", filterPattern: "ERROR"},
+		"/aws/lambda/api": {index: 0, scanFrequency: cfg.ScanFrequency, latency: time.Minute, prefix: "app-", filterPattern: "ERROR"},
+		"arn:aws:logs:us-east-1:222:log-group:/aws/lambda/api:*": {index: 1, scanFrequency: time.Minute, latency: 10 * time.Second, filterPattern: "?ERROR ?WARN"},
+		"/aws/lambda/worker": {index: 1, scanFrequency: time.Minute, latency: 10 * time.Second, filterPattern: "?ERROR ?WARN"},
+		"/aws/ecs/web":       {index: -1, scanFrequency: cfg.ScanFrequency, latency: 10 * time.Second, prefix: "app-", filterPattern: "?ERROR ?WARN"},
+	}
+	for id, want := range tests {
+		i, _ := cfg.LogGroupOverrides.match(id)
+		assert.Equal(t, want.index, i, id)
+		assert.Equal(t, want.scanFrequency, cfg.scanFrequency(id), id)
+		assert.Equal(t, want.latency, cfg.latency(id), id)
+		assert.Equal(t, want.prefix, cfg.logStreamPrefix(id), id)
+		assert.Equal(t, want.filterPattern, cfg.filterPattern(id), id)
+	}
+	assert.Equal(t, time.Minute, cfg.minScanFrequency())
+
+	w := cwWorker{config: cfg, log: logp.NewLogger("test")}
+	now := time.Now()
+	assert.Equal(t, awssdk.String("app-"), w.constructFilterLogEventsInput(now, now, "/aws/ecs/web").LogStreamNamePrefix)
+	assert.Nil(t, w.constructFilterLogEventsInput(now, now, "/aws/lambda/worker").LogStreamNamePrefix, "an empty override reads all the log streams")
+}
+
+func TestLogGroupOverrideConfig(t *testing.T) {
+	zero, negative, prefix := time.Duration(0), -time.Second, "app-"
+	for name, tc := range map[string]struct {
+		override logGroupOverride
+		wantErr  string
+	}{
+		"none":             {wantErr: "one of log_group_arn and log_group_name_pattern is required"},
+		"both":             {override: logGroupOverride{LogGroupARN: "arn:aws:logs:us-east-1:111:log-group:app", LogGroupNamePattern: "app"}, wantErr: "one of log_group_arn and log_group_name_pattern is required"},
+		"invalid ARN":      {override: logGroupOverride{LogGroupARN: "app"}, wantErr: "invalid log group ARN"},
+		"invalid pattern":  {override: logGroupOverride{LogGroupNamePattern: "/aws/[lambda"}, wantErr: "invalid log group name pattern"},
+		"zero frequency":   {override: logGroupOverride{LogGroupNamePattern: "app", ScanFrequency: &zero}, wantErr: "scan_frequency must be greater than zero"},
+		"negative latency": {override: logGroupOverride{LogGroupNamePattern: "app", Latency: &negative}, wantErr: "latency cannot be negative"},
+		"zero latency":     {override: logGroupOverride{LogGroupNamePattern: "app", Latency: &zero}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.override.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.wantErr)
+		})
+	}
+
+	c := defaultConfig()
+	c.LogGroupNamePrefix = "/aws/"
+	c.RegionName = "us-east-1"
+	c.LogGroupOverrides = logGroupOverrides{{LogGroupNamePattern: "/aws/lambda/*", LogStreamPrefix: &prefix}}
+	assert.NoError(t, c.Validate())
+
+	c.Export.Enabled = true
+	assert.ErrorContains(t, c.Validate(), "cannot be set in log_group_overrides or filter_patterns with export.enabled")
+
+	c.Export.Enabled = false
+	c.Mode = modeLiveTail
+	assert.ErrorContains(t, c.Validate(), "log_group_overrides and filter_patterns can only be used with mode poll")
+
+	// The entries of filter_patterns are validated as the ones of
+	// log_group_overrides.
+	c.Mode = modePoll
+	c.LogGroupOverrides = nil
+	c.FilterPatterns = []filterPatternOverride{{LogGroupNamePattern: "/aws/lambda/*", FilterPattern: "ERROR"}}
+	assert.NoError(t, c.Validate())
+	c.Export.Enabled = true
+	assert.ErrorContains(t, c.Validate(), "cannot be set in log_group_overrides or filter_patterns with export.enabled")
+}
+
+func TestReceiveLogGroupOverrides(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The mocked clock advances by the scan frequency of the input, the
+	// slow log group is scanned every three scans.
+	const d = time.Millisecond
+	slow, latency := 3*d, d
+	cfg := defaultConfig()
+	cfg.LogGroupNamePrefix = "/aws/"
+	cfg.StartPosition = end
+	cfg.ScanFrequency = d
+	cfg.LogGroupOverrides = logGroupOverrides{{LogGroupNamePattern: "slow", ScanFrequency: &slow, Latency: &latency}}
+
+	handler, err := newStateHandler(nil, cfg, createTestInputStore())
+	require.NoError(t, err)
+	p := &cloudwatchPoller{
+		workRequestChan:  make(chan struct{}),
+		workResponseChan: make(chan workResponse),
+		log:              logp.NewLogger("test"),
+		metrics:          newInputMetrics(monitoring.NewRegistry()),
+		stateHandler:     handler,
+		config:           cfg,
+	}
+	t1 := time.Unix(1792152000, 0)
+	clock := &clock{time: t1}
+	go p.receive(ctx, []string{"fast", "slow"}, clock.now)
+
+	steps := [][]workResponse{
+		{
+			{logGroupId: "fast", startTime: t1.Add(-d), endTime: t1},
+			{logGroupId: "slow", startTime: t1.Add(-4 * d), endTime: t1.Add(-d)},
+		},
+		{{logGroupId: "fast", startTime: t1, endTime: t1.Add(d)}},
+		{{logGroupId: "fast", startTime: t1.Add(d), endTime: t1.Add(2 * d)}},
+		{
+			{logGroupId: "fast", startTime: t1.Add(2 * d), endTime: t1.Add(3 * d)},
+			{logGroupId: "slow", startTime: t1.Add(-d), endTime: t1.Add(2 * d)},
+		},
+	}
+	for i, step := range steps {
+		for j, expected := range step {
+			p.workRequestChan <- struct{}{}
+			if j+1 == len(step) {
+				clock.time = clock.time.Add(d)
+			}
+			assert.Equal(t, expected, <-p.workResponseChan, "step %d work %d", i, j)
+		}
+	}
+}
+
+func TestLogGroupOverrideProcessors(t *testing.T) {
+	var published []beat.Event
+	client := pubtest.NewChanClientWithCallback(1, func(event beat.Event) {
+		published = append(published, event)
+	})
+	processorsConfig := func(cfg mapstr.M) processors.PluginConfig {
+		return processors.PluginConfig{conf.MustNewConfigFrom(cfg)}
+	}
+	overrides := logGroupOverrides{
+		{LogGroupNamePattern: "/aws/ecs/*", Processors: processorsConfig(mapstr.M{"drop_event": mapstr.M{}})},
+		{LogGroupNamePattern: "/aws/lambda/*", Processors: processorsConfig(mapstr.M{
+			"add_fields": mapstr.M{"target": "", "fields": mapstr.M{"service": "lambda"}},
+		})},
+		{LogGroupNamePattern: "/aws/rds/*"},
+	}
+	procs, err := newOverrideProcessors(overrides, logp.NewLogger("test"))
+	require.NoError(t, err)
+	defer procs.close()
+	require.Len(t, procs, 3)
+	assert.Nil(t, procs[2], "the entries without processors have none")
+
+	p := newLogProcessor(logp.NewLogger("test"), nil, client, defaultEventMappingConfig())
+	p.overrides = overrides
+	p.overrideProcs = procs
+
+	p.processLogEvents([]types.FilteredLogEvent{testLogEvent("id-1")}, "/aws/lambda/app", "us-east-1", "")
+	p.processLogEvents([]types.FilteredLogEvent{testLogEvent("id-2")}, "/aws/ecs/web", "us-east-1", "")
+	p.processLogEvents([]types.FilteredLogEvent{testLogEvent("id-3")}, "/aws/rds/db", "us-east-1", "")
+
+	require.Len(t, published, 2, "the events dropped by the processors are not published")
+	assert.Equal(t, "message id-1", published[0].Fields["message"])
+	assert.Equal(t, "lambda", published[0].Fields["service"])
+	assert.Equal(t, "message id-3", published[1].Fields["message"])
+	assert.NotContains(t, published[1].Fields, "service")
+}
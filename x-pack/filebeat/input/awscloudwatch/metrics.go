@@ -5,21 +5,290 @@
 package awscloudwatch
 
 import (
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rcrowley/go-metrics"
+
+	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/monitoring"
+	"github.com/elastic/elastic-agent-libs/monitoring/adapter"
 )
 
+// maxLogGroupMetrics is the maximum number of log groups with their own
+// metrics, which bounds the size of the registry of the inputs collecting
+// the log groups of large organizations. The log groups beyond it are only
+// counted in the metrics of the input.
+const maxLogGroupMetrics = 500
+
 type inputMetrics struct {
-	logEventsReceivedTotal       *monitoring.Uint // Number of CloudWatch log events received.
-	logGroupsTotal               *monitoring.Uint // Logs collected from number of CloudWatch log groups.
-	cloudwatchEventsCreatedTotal *monitoring.Uint // Number of events created from processing logs from CloudWatch.
-	apiCallsTotal                *monitoring.Uint // Number of API calls made total.
+	logEventsReceivedTotal       *monitoring.Uint  // Number of CloudWatch log events received.
+	logGroupsTotal               *monitoring.Uint  // Logs collected from number of CloudWatch log groups.
+	cloudwatchEventsCreatedTotal *monitoring.Uint  // Number of events created from processing logs from CloudWatch.
+	apiCallsTotal                *monitoring.Uint  // Number of API calls made total.
+	credentialsErrorsTotal       *monitoring.Uint  // Number of API calls failed because of expired or unavailable credentials.
+	credentialsRefreshFailures   *monitoring.Uint  // Number of paginations stopped because the credentials could not be refreshed.
+	apiThrottledTotal            *monitoring.Uint  // Number of FilterLogEvents requests throttled.
+	apiRateLimit                 *monitoring.Float // Current rate limit of the FilterLogEvents requests, in requests per second.
+	liveTailSessionsTotal        *monitoring.Uint  // Number of Live Tail sessions established.
+	liveTailSampledUpdatesTotal  *monitoring.Uint  // Number of Live Tail session updates whose log events were sampled.
+	backfillSlicesTotal          *monitoring.Uint  // Number of time slices the large time ranges of the log groups were split into.
+	insightsQueriesTotal         *monitoring.Uint  // Number of Logs Insights queries started.
+	insightsQuerySplitsTotal     *monitoring.Uint  // Number of Logs Insights query time ranges split because their results were truncated.
+	insightsQueriesLimitedTotal  *monitoring.Uint  // Number of Logs Insights queries delayed by the limit of concurrent queries of the account.
+	decodingErrorsTotal          *monitoring.Uint  // Number of messages that could not be decoded.
+	deadLetterEventsTotal        *monitoring.Uint  // Number of log events written to the dead letter store.
+	deadLetterErrorsTotal        *monitoring.Uint  // Number of failed writes to the dead letter store.
+	deadLetterReplayedTotal      *monitoring.Uint  // Number of log events of the dead letter store published again.
+	coordinationLogGroupsOwned   *monitoring.Uint  // Number of log groups whose lease is held by the instance.
+	coordinationErrorsTotal      *monitoring.Uint  // Number of failed requests to the coordination lease store.
+	logEventsDeduplicatedTotal   *monitoring.Uint  // Number of log events not published because they were already published.
+	workersBusy                  *monitoring.Uint  // Number of workers reading the log events of a log group.
+	workerUtilization            *monitoring.Float // Share of the time the workers were busy during the last scan interval, from 0 to 1.
+	ingestLag                    metrics.Sample    // Histogram of the lag between the timestamps of the log events and their reception, in milliseconds.
+	pagesPerPoll                 metrics.Sample    // Histogram of the number of FilterLogEvents pages read per poll of a log group.
+
+	// clock returns the current time, it can be replaced by the tests.
+	clock func() time.Time
+
+	// The busy time of the workers is accumulated between the updates of
+	// workerUtilization.
+	workersMu     sync.Mutex
+	busy          int
+	busyTime      time.Duration
+	busyChanged   time.Time
+	lastUtilCheck time.Time
+
+	logGroupsMu  sync.Mutex
+	logGroupsReg *monitoring.Registry
+	logGroups    map[string]*logGroupMetrics
 }
 
 func newInputMetrics(reg *monitoring.Registry) *inputMetrics {
-	return &inputMetrics{
+	now := time.Now()
+	m := &inputMetrics{
 		logEventsReceivedTotal:       monitoring.NewUint(reg, "log_events_received_total"),
 		logGroupsTotal:               monitoring.NewUint(reg, "log_groups_total"),
 		cloudwatchEventsCreatedTotal: monitoring.NewUint(reg, "cloudwatch_events_created_total"),
 		apiCallsTotal:                monitoring.NewUint(reg, "api_calls_total"),
+		credentialsErrorsTotal:       monitoring.NewUint(reg, "credentials_errors_total"),
+		credentialsRefreshFailures:   monitoring.NewUint(reg, "credentials_refresh_failures_total"),
+		apiThrottledTotal:            monitoring.NewUint(reg, "api_throttled_total"),
+		apiRateLimit:                 monitoring.NewFloat(reg, "api_rate_limit"),
+		liveTailSessionsTotal:        monitoring.NewUint(reg, "live_tail_sessions_total"),
+		liveTailSampledUpdatesTotal:  monitoring.NewUint(reg, "live_tail_sampled_updates_total"),
+		backfillSlicesTotal:          monitoring.NewUint(reg, "backfill_slices_total"),
+		insightsQueriesTotal:         monitoring.NewUint(reg, "insights_queries_total"),
+		insightsQuerySplitsTotal:     monitoring.NewUint(reg, "insights_query_splits_total"),
+		insightsQueriesLimitedTotal:  monitoring.NewUint(reg, "insights_queries_limited_total"),
+		decodingErrorsTotal:          monitoring.NewUint(reg, "decoding_errors_total"),
+		deadLetterEventsTotal:        monitoring.NewUint(reg, "dead_letter_events_total"),
+		deadLetterErrorsTotal:        monitoring.NewUint(reg, "dead_letter_errors_total"),
+		deadLetterReplayedTotal:      monitoring.NewUint(reg, "dead_letter_replayed_total"),
+		coordinationLogGroupsOwned:   monitoring.NewUint(reg, "coordination_log_groups_owned"),
+		coordinationErrorsTotal:      monitoring.NewUint(reg, "coordination_errors_total"),
+		logEventsDeduplicatedTotal:   monitoring.NewUint(reg, "log_events_deduplicated_total"),
+		workersBusy:                  monitoring.NewUint(reg, "workers_busy"),
+		workerUtilization:            monitoring.NewFloat(reg, "worker_utilization"),
+		ingestLag:                    metrics.NewUniformSample(1024),
+		pagesPerPoll:                 metrics.NewUniformSample(1024),
+		clock:                        time.Now,
+		busyChanged:                  now,
+		lastUtilCheck:                now,
+		logGroupsReg:                 reg.NewRegistry("log_groups"),
+		logGroups:                    map[string]*logGroupMetrics{},
+	}
+	// The adapter only logs the metrics it filters out, it accepts them all.
+	log := logp.NewNopLogger()
+	adapter.NewGoMetrics(reg, "ingest_lag", log, adapter.Accept).
+		Register("histogram", metrics.NewHistogram(m.ingestLag)) //nolint:errcheck // A unique namespace is used so name collisions are impossible.
+	adapter.NewGoMetrics(reg, "pages_per_poll", log, adapter.Accept).
+		Register("histogram", metrics.NewHistogram(m.pagesPerPoll)) //nolint:errcheck // A unique namespace is used so name collisions are impossible.
+	reg.Add("oldest_unprocessed_window_age_ms", monitoring.FuncVar(func(_ monitoring.Mode, vs monitoring.Visitor) {
+		vs.OnInt(m.oldestUnprocessedWindowAge().Milliseconds())
+	}), monitoring.Reported)
+	return m
+}
+
+// beginWork records that a worker started reading the log events of a log
+// group.
+func (m *inputMetrics) beginWork() {
+	m.workersMu.Lock()
+	defer m.workersMu.Unlock()
+	m.accrueBusyTime()
+	m.busy++
+	m.workersBusy.Set(uint64(m.busy))
+}
+
+// endWork records that a worker finished reading the log events of a log
+// group.
+func (m *inputMetrics) endWork() {
+	m.workersMu.Lock()
+	defer m.workersMu.Unlock()
+	m.accrueBusyTime()
+	m.busy--
+	m.workersBusy.Set(uint64(m.busy))
+}
+
+// accrueBusyTime adds the busy time of the workers since the last change of
+// their number to busyTime. workersMu must be held.
+func (m *inputMetrics) accrueBusyTime() {
+	now := m.clock()
+	m.busyTime += time.Duration(m.busy) * now.Sub(m.busyChanged)
+	m.busyChanged = now
+}
+
+// updateWorkerUtilization sets workerUtilization to the share of the time
+// the workers were busy since its last update.
+func (m *inputMetrics) updateWorkerUtilization(workers int) {
+	m.workersMu.Lock()
+	defer m.workersMu.Unlock()
+	m.accrueBusyTime()
+	elapsed := m.busyChanged.Sub(m.lastUtilCheck)
+	if workers <= 0 || elapsed <= 0 {
+		return
+	}
+	utilization := math.Round(m.busyTime.Seconds()/(float64(workers)*elapsed.Seconds())*1000) / 1000
+	m.workerUtilization.Set(min(utilization, 1))
+	m.busyTime = 0
+	m.lastUtilCheck = m.busyChanged
+}
+
+// logGroup returns the metrics of the log group id, nil if the maximum
+// number of log groups with metrics is reached. The metrics are published
+// under log_groups, with the dots of the id, which separate the namespaces
+// of the registry, replaced with underscores.
+func (m *inputMetrics) logGroup(id string) *logGroupMetrics {
+	m.logGroupsMu.Lock()
+	defer m.logGroupsMu.Unlock()
+	if lg, ok := m.logGroups[id]; ok {
+		return lg
+	}
+	if len(m.logGroups) >= maxLogGroupMetrics {
+		return nil
+	}
+	lg := newLogGroupMetrics(m.logGroupsReg.NewRegistry(strings.ReplaceAll(id, ".", "_")), m.clock)
+	m.logGroups[id] = lg
+	return lg
+}
+
+// oldestUnprocessedWindowAge returns the age of the oldest unprocessed time
+// window of the log groups, zero if they are all processed.
+func (m *inputMetrics) oldestUnprocessedWindowAge() time.Duration {
+	m.logGroupsMu.Lock()
+	defer m.logGroupsMu.Unlock()
+	var age time.Duration
+	for _, lg := range m.logGroups {
+		age = max(age, lg.oldestUnprocessedWindowAge())
+	}
+	return age
+}
+
+// receivedLogEvents records the reception of the log events of a page for
+// the log group lg, whose timestamps are in Unix milliseconds.
+func (m *inputMetrics) receivedLogEvents(lg *logGroupMetrics, timestamps []int64) {
+	m.logEventsReceivedTotal.Add(uint64(len(timestamps)))
+	if len(timestamps) == 0 {
+		return
+	}
+	now := m.clock().UnixMilli()
+	newest := timestamps[0]
+	for _, ts := range timestamps {
+		m.ingestLag.Update(now - ts)
+		newest = max(newest, ts)
+	}
+	if lg != nil {
+		lg.logEventsReceivedTotal.Add(uint64(len(timestamps)))
+		lg.ingestLag.Set(now - newest)
+	}
+}
+
+// polled records the number of pages read by a poll of the log group lg.
+func (m *inputMetrics) polled(lg *logGroupMetrics, pages int) {
+	m.pagesPerPoll.Update(int64(pages))
+	if lg != nil {
+		lg.lastPollPages.Set(uint64(pages))
+	}
+}
+
+// logGroupMetrics are the metrics of a log group.
+type logGroupMetrics struct {
+	logEventsReceivedTotal *monitoring.Uint // Number of log events of the log group received.
+	apiThrottledTotal      *monitoring.Uint // Number of FilterLogEvents requests of the log group throttled.
+	lastPollPages          *monitoring.Uint // Number of FilterLogEvents pages read by the last poll of the log group.
+	ingestLag              *monitoring.Int  // Lag between the timestamp of the newest log event of the last page and its reception, in milliseconds.
+
+	clock func() time.Time
+
+	// windows holds the number of unprocessed time windows of the log
+	// group by their start time.
+	mu      sync.Mutex
+	windows map[time.Time]int
+}
+
+func newLogGroupMetrics(reg *monitoring.Registry, clock func() time.Time) *logGroupMetrics {
+	lg := &logGroupMetrics{
+		logEventsReceivedTotal: monitoring.NewUint(reg, "log_events_received_total"),
+		apiThrottledTotal:      monitoring.NewUint(reg, "api_throttled_total"),
+		lastPollPages:          monitoring.NewUint(reg, "last_poll_pages"),
+		ingestLag:              monitoring.NewInt(reg, "ingest_lag_ms"),
+		clock:                  clock,
+		windows:                map[time.Time]int{},
+	}
+	reg.Add("oldest_unprocessed_window_age_ms", monitoring.FuncVar(func(_ monitoring.Mode, vs monitoring.Visitor) {
+		vs.OnInt(lg.oldestUnprocessedWindowAge().Milliseconds())
+	}), monitoring.Reported)
+	return lg
+}
+
+// throttled counts a throttled FilterLogEvents request of the log group.
+func (lg *logGroupMetrics) throttled() {
+	if lg != nil {
+		lg.apiThrottledTotal.Inc()
+	}
+}
+
+// windowScheduled records a time window of the log group starting at start
+// that is not processed yet.
+func (lg *logGroupMetrics) windowScheduled(start time.Time) {
+	if lg == nil {
+		return
+	}
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+	lg.windows[start]++
+}
+
+// windowProcessed records that the time window of the log group starting at
+// start is processed.
+func (lg *logGroupMetrics) windowProcessed(start time.Time) {
+	if lg == nil {
+		return
+	}
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+	if lg.windows[start] <= 1 {
+		delete(lg.windows, start)
+		return
+	}
+	lg.windows[start]--
+}
+
+// oldestUnprocessedWindowAge returns the time elapsed since the start of the
+// oldest unprocessed time window of the log group, zero if there is none.
+func (lg *logGroupMetrics) oldestUnprocessedWindowAge() time.Duration {
+	lg.mu.Lock()
+	defer lg.mu.Unlock()
+	var oldest time.Time
+	for start := range lg.windows {
+		if oldest.IsZero() || start.Before(oldest) {
+			oldest = start
+		}
+	}
+	if oldest.IsZero() {
+		return 0
 	}
+	return max(lg.clock().Sub(oldest), 0)
 }
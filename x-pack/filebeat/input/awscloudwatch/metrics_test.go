@@ -0,0 +1,94 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestWorkerUtilization(t *testing.T) {
+	m := newInputMetrics(monitoring.NewRegistry())
+	now := time.Now()
+	m.clock = func() time.Time { return now }
+	m.busyChanged, m.lastUtilCheck = now, now
+
+	// One of the two workers is busy for the whole interval, the other one
+	// for half of it.
+	m.beginWork()
+	m.beginWork()
+	assert.Equal(t, uint64(2), m.workersBusy.Get())
+	now = now.Add(30 * time.Second)
+	m.endWork()
+	now = now.Add(30 * time.Second)
+	m.updateWorkerUtilization(2)
+	assert.Equal(t, 0.75, m.workerUtilization.Get())
+
+	// The busy time is counted from the last update.
+	now = now.Add(time.Minute)
+	m.updateWorkerUtilization(2)
+	assert.Equal(t, 0.5, m.workerUtilization.Get())
+	m.endWork()
+	assert.Equal(t, uint64(0), m.workersBusy.Get())
+}
+
+func TestLogGroupMetrics(t *testing.T) {
+	reg := monitoring.NewRegistry()
+	m := newInputMetrics(reg)
+	now := time.UnixMilli(1_700_000_000_000)
+	m.clock = func() time.Time { return now }
+
+	lg := m.logGroup("/aws/lambda/app.v2")
+	require.NotNil(t, lg)
+	assert.Same(t, lg, m.logGroup("/aws/lambda/app.v2"))
+
+	m.receivedLogEvents(lg, []int64{now.UnixMilli() - 5000, now.UnixMilli() - 2000})
+	m.polled(lg, 3)
+	lg.throttled()
+
+	lg.windowScheduled(now.Add(-10 * time.Minute))
+	lg.windowScheduled(now.Add(-5 * time.Minute))
+	lg.windowScheduled(now.Add(-5 * time.Minute))
+
+	snapshot := monitoring.CollectFlatSnapshot(reg, monitoring.Full, false)
+	prefix := "log_groups./aws/lambda/app_v2."
+	assert.Equal(t, int64(2), snapshot.Ints[prefix+"log_events_received_total"])
+	assert.Equal(t, int64(1), snapshot.Ints[prefix+"api_throttled_total"])
+	assert.Equal(t, int64(3), snapshot.Ints[prefix+"last_poll_pages"])
+	assert.Equal(t, int64(2000), snapshot.Ints[prefix+"ingest_lag_ms"])
+	assert.Equal(t, int64(10*time.Minute/time.Millisecond), snapshot.Ints[prefix+"oldest_unprocessed_window_age_ms"])
+	assert.Equal(t, int64(10*time.Minute/time.Millisecond), snapshot.Ints["oldest_unprocessed_window_age_ms"])
+	assert.Equal(t, int64(2), snapshot.Ints["log_events_received_total"])
+	assert.Equal(t, int64(2), snapshot.Ints["ingest_lag.histogram.count"])
+	assert.Equal(t, int64(1), snapshot.Ints["pages_per_poll.histogram.count"])
+
+	lg.windowProcessed(now.Add(-10 * time.Minute))
+	lg.windowProcessed(now.Add(-5 * time.Minute))
+	assert.Equal(t, 5*time.Minute, lg.oldestUnprocessedWindowAge())
+	lg.windowProcessed(now.Add(-5 * time.Minute))
+	assert.Zero(t, lg.oldestUnprocessedWindowAge())
+}
+
+func TestLogGroupMetricsLimit(t *testing.T) {
+	m := newInputMetrics(monitoring.NewRegistry())
+	for i := 0; i < maxLogGroupMetrics; i++ {
+		require.NotNil(t, m.logGroup(fmt.Sprint("group-", i)))
+	}
+	lg := m.logGroup("one-too-many")
+	assert.Nil(t, lg)
+
+	// The log groups beyond the limit are counted in the input metrics.
+	m.receivedLogEvents(lg, []int64{time.Now().UnixMilli()})
+	m.polled(lg, 1)
+	lg.throttled()
+	lg.windowScheduled(time.Now())
+	assert.Equal(t, uint64(1), m.logEventsReceivedTotal.Get())
+}
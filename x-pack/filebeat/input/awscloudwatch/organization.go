@@ -0,0 +1,195 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/organizations"
+	orgtypes "github.com/aws/aws-sdk-go-v2/service/organizations/types"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// logGroup is a log group to poll. svc is the client of the account owning
+// the log group, nil to use the client of the input. region and accountID
+// are set when the log group is read from another account or region than
+// the ones of the input.
+type logGroup struct {
+	id        string
+	svc       *cloudwatchlogs.Client
+	region    string
+	accountID string
+}
+
+// memberAccount caches the client and the log groups of a member account of
+// the organization.
+type memberAccount struct {
+	svc       *cloudwatchlogs.Client
+	logGroups []string
+}
+
+// orgEnumerator enumerates the log groups of the member accounts of an AWS
+// Organization. The accounts and their log groups are listed again once
+// organization.refresh_interval elapsed; in between, the cached log groups
+// are returned.
+type orgEnumerator struct {
+	config config
+	log    *logp.Logger
+
+	listAccounts  func(ctx context.Context) ([]string, error)
+	newClient     func(accountID string) *cloudwatchlogs.Client
+	listLogGroups func(ctx context.Context, svc *cloudwatchlogs.Client) ([]string, error)
+	clock         func() time.Time
+
+	accounts  map[string]*memberAccount
+	refreshed time.Time
+	groups    []logGroup
+}
+
+func newOrgEnumerator(cfg config, awsConfig awssdk.Config, log *logp.Logger) *orgEnumerator {
+	orgSvc := organizations.NewFromConfig(awsConfig, func(o *organizations.Options) {
+		if cfg.AWSConfig.FIPSEnabled {
+			o.EndpointOptions.UseFIPSEndpoint = awssdk.FIPSEndpointStateEnabled
+		}
+	})
+	stsSvc := sts.NewFromConfig(awsConfig)
+	partition := partitionForRegion(awsConfig.Region)
+
+	return &orgEnumerator{
+		config: cfg,
+		log:    log,
+		listAccounts: func(ctx context.Context) ([]string, error) {
+			return listActiveAccounts(ctx, orgSvc)
+		},
+		newClient: func(accountID string) *cloudwatchlogs.Client {
+			roleARN := fmt.Sprintf("arn:%s:iam::%s:role/%s", partition, accountID, cfg.Organization.RoleName)
+			provider := stscreds.NewAssumeRoleProvider(stsSvc, roleARN, func(o *stscreds.AssumeRoleOptions) {
+				if cfg.Organization.ExternalID != "" {
+					o.ExternalID = awssdk.String(cfg.Organization.ExternalID)
+				}
+			})
+			accountConfig := awsConfig.Copy()
+			accountConfig.Credentials = awssdk.NewCredentialsCache(provider)
+			return cloudwatchlogs.NewFromConfig(accountConfig, func(o *cloudwatchlogs.Options) {
+				if cfg.AWSConfig.FIPSEnabled {
+					o.EndpointOptions.UseFIPSEndpoint = awssdk.FIPSEndpointStateEnabled
+				}
+			})
+		},
+		listLogGroups: func(ctx context.Context, svc *cloudwatchlogs.Client) ([]string, error) {
+			return getLogGroupNames(ctx, svc, cfg.LogGroupNamePrefix, false)
+		},
+		clock:    time.Now,
+		accounts: map[string]*memberAccount{},
+	}
+}
+
+// logGroups returns the log groups of the member accounts, refreshing them if
+// refresh_interval elapsed since the last listing.
+func (e *orgEnumerator) logGroups(ctx context.Context) []logGroup {
+	if !e.refreshed.IsZero() && e.clock().Sub(e.refreshed) < e.config.Organization.RefreshInterval {
+		return e.groups
+	}
+	e.refresh(ctx)
+	return e.groups
+}
+
+// refresh lists the accounts of the organization and their log groups. The
+// clients of the accounts are kept between refreshes so their assumed role
+// credentials are reused until they expire. If an account can't be listed,
+// its previously listed log groups are kept.
+func (e *orgEnumerator) refresh(ctx context.Context) {
+	accountIDs, err := e.listAccounts(ctx)
+	if err != nil {
+		// Keep the cached log groups and try again on the next scan.
+		e.log.Errorw("Failed to list the accounts of the organization, using the cached log groups", "error", err)
+		return
+	}
+	e.refreshed = e.clock()
+
+	previous := len(e.groups)
+	current := make(map[string]bool, len(accountIDs))
+	var groups []logGroup
+	for _, id := range accountIDs {
+		if !e.selected(id) {
+			continue
+		}
+		current[id] = true
+
+		account, ok := e.accounts[id]
+		if !ok {
+			account = &memberAccount{svc: e.newClient(id)}
+			e.accounts[id] = account
+		}
+		names, err := e.listLogGroups(ctx, account.svc)
+		if err != nil {
+			e.log.Warnw("Failed to list the log groups of a member account, using the cached log groups",
+				"aws.account.id", id, "error", err)
+		} else {
+			account.logGroups = names
+		}
+		for _, name := range account.logGroups {
+			groups = append(groups, logGroup{id: name, svc: account.svc})
+		}
+	}
+	for id := range e.accounts {
+		if !current[id] {
+			delete(e.accounts, id)
+		}
+	}
+
+	e.groups = groups
+	e.log.Infow("Listed the log groups of the organization",
+		"accounts", len(current), "log_groups", len(groups), "previous_log_groups", previous)
+}
+
+// selected reports whether the log groups of the account are polled.
+func (e *orgEnumerator) selected(accountID string) bool {
+	if len(e.config.Organization.AccountIDs) != 0 && !slices.Contains(e.config.Organization.AccountIDs, accountID) {
+		return false
+	}
+	return !slices.Contains(e.config.Organization.ExcludeAccountIDs, accountID)
+}
+
+// listActiveAccounts uses the ListAccounts API to retrieve the IDs of the
+// active accounts of the organization.
+func listActiveAccounts(ctx context.Context, svc *organizations.Client) ([]string, error) {
+	var accountIDs []string
+	paginator := organizations.NewListAccountsPaginator(svc, &organizations.ListAccountsInput{})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error ListAccounts with Paginator: %w", err)
+		}
+		for _, account := range page.Accounts {
+			if account.Status == orgtypes.AccountStatusActive && account.Id != nil {
+				accountIDs = append(accountIDs, *account.Id)
+			}
+		}
+	}
+	return accountIDs, nil
+}
+
+// partitionForRegion returns the AWS partition of the region, used to build
+// the ARN of the role assumed in the member accounts.
+func partitionForRegion(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	default:
+		return "aws"
+	}
+}
@@ -0,0 +1,148 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// testOrganization is a fake organization whose member accounts are
+// identified by the client created for them.
+type testOrganization struct {
+	accounts    []string
+	accountsErr error
+	logGroups   map[string][]string
+	failing     map[string]bool
+
+	clients map[*cloudwatchlogs.Client]string
+	created int
+}
+
+func newTestOrgEnumerator(org *testOrganization, cfg organizationConfig, clock *clock) *orgEnumerator {
+	org.clients = map[*cloudwatchlogs.Client]string{}
+	c := config{Organization: cfg}
+	return &orgEnumerator{
+		config: c,
+		log:    logp.NewLogger("test"),
+		listAccounts: func(context.Context) ([]string, error) {
+			return org.accounts, org.accountsErr
+		},
+		newClient: func(accountID string) *cloudwatchlogs.Client {
+			org.created++
+			svc := &cloudwatchlogs.Client{}
+			org.clients[svc] = accountID
+			return svc
+		},
+		listLogGroups: func(_ context.Context, svc *cloudwatchlogs.Client) ([]string, error) {
+			id := org.clients[svc]
+			if org.failing[id] {
+				return nil, errors.New("access denied")
+			}
+			return org.logGroups[id], nil
+		},
+		clock:    clock.now,
+		accounts: map[string]*memberAccount{},
+	}
+}
+
+func groupIDs(org *testOrganization, groups []logGroup) map[string]string {
+	ids := map[string]string{}
+	for _, g := range groups {
+		ids[g.id] = org.clients[g.svc]
+	}
+	return ids
+}
+
+func TestOrgEnumerator(t *testing.T) {
+	ctx := context.Background()
+	clk := &clock{time: time.Unix(0, 0)}
+	org := &testOrganization{
+		accounts: []string{"111", "222", "333"},
+		logGroups: map[string][]string{
+			"111": {"arn:aws:logs:us-east-1:111:log-group:a"},
+			"222": {"arn:aws:logs:us-east-1:222:log-group:b", "arn:aws:logs:us-east-1:222:log-group:c"},
+			"333": {"arn:aws:logs:us-east-1:333:log-group:d"},
+		},
+	}
+	e := newTestOrgEnumerator(org, organizationConfig{
+		ExcludeAccountIDs: []string{"333"},
+		RefreshInterval:   time.Hour,
+	}, clk)
+
+	want := map[string]string{
+		"arn:aws:logs:us-east-1:111:log-group:a": "111",
+		"arn:aws:logs:us-east-1:222:log-group:b": "222",
+		"arn:aws:logs:us-east-1:222:log-group:c": "222",
+	}
+	assert.Equal(t, want, groupIDs(org, e.logGroups(ctx)))
+	assert.Equal(t, 2, org.created)
+
+	// The log groups are cached until the refresh interval elapsed.
+	org.logGroups["111"] = append(org.logGroups["111"], "arn:aws:logs:us-east-1:111:log-group:e")
+	clk.time = clk.time.Add(time.Minute)
+	assert.Equal(t, want, groupIDs(org, e.logGroups(ctx)))
+
+	// Accounts whose log groups can't be listed keep their cached log groups,
+	// and the clients are reused.
+	org.failing = map[string]bool{"222": true}
+	clk.time = clk.time.Add(time.Hour)
+	want["arn:aws:logs:us-east-1:111:log-group:e"] = "111"
+	assert.Equal(t, want, groupIDs(org, e.logGroups(ctx)))
+	assert.Equal(t, 2, org.created)
+
+	// Accounts that left the organization are removed.
+	org.accounts = []string{"111"}
+	clk.time = clk.time.Add(time.Hour)
+	assert.Equal(t, map[string]string{
+		"arn:aws:logs:us-east-1:111:log-group:a": "111",
+		"arn:aws:logs:us-east-1:111:log-group:e": "111",
+	}, groupIDs(org, e.logGroups(ctx)))
+	require.Len(t, e.accounts, 1)
+
+	// The cached log groups are used if the accounts can't be listed.
+	org.accountsErr = errors.New("throttled")
+	clk.time = clk.time.Add(time.Hour)
+	assert.Len(t, e.logGroups(ctx), 2)
+}
+
+func TestOrgEnumeratorAccountIDs(t *testing.T) {
+	e := newTestOrgEnumerator(&testOrganization{}, organizationConfig{
+		AccountIDs:        []string{"111", "222"},
+		ExcludeAccountIDs: []string{"222"},
+	}, &clock{})
+	assert.True(t, e.selected("111"))
+	assert.False(t, e.selected("222"))
+	assert.False(t, e.selected("333"))
+}
+
+func TestOrganizationConfig(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Organization.Enabled = true
+	assert.ErrorContains(t, cfg.Validate(), "organization.role_name is required")
+
+	cfg.Organization.RoleName = "LogListRole"
+	assert.ErrorContains(t, cfg.Validate(), "region_name is required")
+
+	cfg.RegionName = "us-east-1"
+	assert.NoError(t, cfg.Validate(), "the log group prefix is optional")
+
+	cfg.LogGroupName = "a"
+	assert.Error(t, cfg.Validate())
+}
+
+func TestPartitionForRegion(t *testing.T) {
+	assert.Equal(t, "aws", partitionForRegion("eu-west-1"))
+	assert.Equal(t, "aws-cn", partitionForRegion("cn-north-1"))
+	assert.Equal(t, "aws-us-gov", partitionForRegion("us-gov-west-1"))
+}
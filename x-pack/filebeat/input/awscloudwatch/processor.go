@@ -5,8 +5,10 @@
 package awscloudwatch
 
 import (
+	"slices"
 	"time"
 
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
@@ -19,9 +21,31 @@ type logProcessor struct {
 	log       *logp.Logger
 	metrics   *inputMetrics
 	publisher beat.Client
+	mapping   eventMappingConfig
+
+	// deadLetter sets the private field of the events to their
+	// pendingEvent, to write their log event to the dead letter store if
+	// they can't be published.
+	deadLetter bool
+
+	// dedup suppresses the log events that were already published, nil if
+	// deduplication.enabled is not set.
+	dedup *eventDeduplicator
+
+	// derivedIDs replaces the IDs of the log events by IDs derived from
+	// their content, nil to keep their CloudWatch IDs. It is set with
+	// mode live_tail, whose log events have no ID, so that a log event
+	// read both by a backfill and a Live Tail session has the same ID.
+	derivedIDs *derivedIDs
+
+	// overrides are the log_group_overrides of the input, and
+	// overrideProcs the processors of their entries, run on the events of
+	// the matching log groups before they are published.
+	overrides     logGroupOverrides
+	overrideProcs overrideProcessors
 }
 
-func newLogProcessor(log *logp.Logger, metrics *inputMetrics, publisher beat.Client) *logProcessor {
+func newLogProcessor(log *logp.Logger, metrics *inputMetrics, publisher beat.Client, mapping eventMappingConfig) *logProcessor {
 	if metrics == nil {
 		metrics = newInputMetrics(monitoring.NewRegistry())
 	}
@@ -29,45 +53,116 @@ func newLogProcessor(log *logp.Logger, metrics *inputMetrics, publisher beat.Cli
 		log:       log,
 		metrics:   metrics,
 		publisher: publisher,
+		mapping:   mapping,
 	}
 }
 
-func (p *logProcessor) processLogEvents(logEvents []types.FilteredLogEvent, logGroupId string, regionName string) {
+// processLogEvents publishes the log events of a log group. accountID is the
+// ID of the account owning the log group, it is only set when the log group
+// is read from another account than the one of the input. It returns the
+// number of events published, whose acknowledgement is waited for: the log
+// events already published are skipped, and a decoded log event can make
+// several events.
+func (p *logProcessor) processLogEvents(logEvents []types.FilteredLogEvent, logGroupId string, regionName string, accountID string) int {
+	override, _ := p.overrides.match(logGroupId)
+	var published int
 	for _, logEvent := range logEvents {
-		event := createEvent(logEvent, logGroupId, regionName)
+		if p.derivedIDs != nil {
+			logEvent.EventId = awssdk.String(p.derivedIDs.next(logGroupId,
+				awssdk.ToString(logEvent.LogStreamName), awssdk.ToInt64(logEvent.Timestamp), awssdk.ToString(logEvent.Message)))
+		}
+		if p.dedup.seen(dedupKey{region: regionName, accountID: accountID, logGroupID: logGroupId, eventID: *logEvent.EventId}) {
+			p.metrics.logEventsDeduplicatedTotal.Inc()
+			continue
+		}
+		published += p.publishLogEvent(logEvent, logGroupId, regionName, accountID, override, nil)
+	}
+	return published
+}
+
+// processRecord publishes the events of the log event of a dead letter
+// record that were not published, and returns their number.
+func (p *logProcessor) processRecord(rec *deadLetterRecord) int {
+	override, _ := p.overrides.match(rec.LogGroup)
+	return p.publishLogEvent(rec.logEvent(), rec.LogGroup, rec.Region, rec.AccountID, override, rec.Events)
+}
+
+// publishLogEvent publishes the events created from a log event, only the
+// ones at indices if it is not empty, and returns their number. override is
+// the index of the log_group_overrides entry of the log group.
+func (p *logProcessor) publishLogEvent(logEvent types.FilteredLogEvent, logGroupId, regionName, accountID string, override int, indices []int) int {
+	event := createEvent(logEvent, logGroupId, regionName, p.mapping)
+	if accountID != "" {
+		_, _ = event.Fields.Put("cloud.account.id", accountID)
+	}
+	var rec *deadLetterRecord
+	if p.deadLetter {
+		rec = newDeadLetterRecord(logEvent, logGroupId, regionName, accountID)
+	}
+	var published int
+	for i, e := range p.decode(event, *logEvent.Message) {
+		if len(indices) != 0 && !slices.Contains(indices, i) {
+			continue
+		}
+		// The index is the one of the decoded event, the processors of the
+		// entry run again on the events replayed from the dead letter store.
+		if rec != nil {
+			e.Private = &pendingEvent{rec: rec, index: i}
+		}
+		processed, ok := p.overrideProcs.run(override, e, p.log)
+		if !ok {
+			// Dropped by the processors of the log_group_overrides entry.
+			continue
+		}
 		p.metrics.cloudwatchEventsCreatedTotal.Inc()
-		p.publisher.Publish(event)
+		p.publisher.Publish(processed)
+		published++
 	}
+	return published
 }
 
-func createEvent(logEvent types.FilteredLogEvent, logGroupId string, regionName string) beat.Event {
+func createEvent(logEvent types.FilteredLogEvent, logGroupId string, regionName string, mapping eventMappingConfig) beat.Event {
+	eventFields := mapstr.M{
+		"ingested": time.Now(),
+	}
+	cloudwatchFields := mapstr.M{
+		"log_group":  logGroupId,
+		"log_stream": *logEvent.LogStreamName,
+	}
+	if mapping.IncludeCloudWatchMetadata {
+		eventFields["id"] = *logEvent.EventId
+		// The log events read from an export to S3 have no ingestion time.
+		if logEvent.IngestionTime != nil {
+			cloudwatchFields["ingestion_time"] = time.UnixMilli(*logEvent.IngestionTime)
+		}
+	}
+	if mapping.PreserveOriginalEvent {
+		eventFields["original"] = *logEvent.Message
+	}
+
 	event := beat.Event{
 		Timestamp: time.UnixMilli(*logEvent.Timestamp).UTC(),
 		Fields: mapstr.M{
-			"message": *logEvent.Message,
 			"log": mapstr.M{
 				"file": mapstr.M{
 					"path": logGroupId + "/" + *logEvent.LogStreamName,
 				},
 			},
-			"event": mapstr.M{
-				"id":       *logEvent.EventId,
-				"ingested": time.Now(),
-			},
-			"aws": mapstr.M{
-				"cloudwatch": mapstr.M{
-					"log_group":      logGroupId,
-					"log_stream":     *logEvent.LogStreamName,
-					"ingestion_time": time.UnixMilli(*logEvent.IngestionTime),
-				},
-			},
+			"event": eventFields,
 			"cloud": mapstr.M{
 				"provider": "aws",
 				"region":   regionName,
 			},
 		},
 	}
-	event.SetID(*logEvent.EventId)
+	// The message is put last so it can be a field of the CloudWatch fields.
+	_, _ = event.Fields.Put(mapping.CloudWatchTargetField, cloudwatchFields)
+	_, _ = event.Fields.Put(mapping.MessageTargetField, *logEvent.Message)
+	// The event ID is used as the document ID so the log events read twice,
+	// like the ones at the boundary of two scan windows, are not indexed twice.
+	if mapping.EventIDAsDocumentID {
+		event.SetID(*logEvent.EventId)
+	}
 
 	return event
 }
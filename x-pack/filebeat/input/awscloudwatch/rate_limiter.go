@@ -0,0 +1,96 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	// maxThrottleRetries is the number of times a page of log events is
+	// requested again after a ThrottlingException.
+	maxThrottleRetries = 5
+	// throttleBackoff and maxThrottleBackoff bound the time to wait before
+	// requesting a throttled page again.
+	throttleBackoff    = time.Second
+	maxThrottleBackoff = 30 * time.Second
+	// minRateFraction is the fraction of api_rate_limit the rate limit is
+	// never reduced below.
+	minRateFraction = 0.1
+	// recoverySteps is the number of successful requests it takes to
+	// restore the rate limit after it was halved.
+	recoverySteps = 20
+)
+
+// adaptiveLimiter limits the rate of the FilterLogEvents requests of all the
+// workers of the input, which share the quota of the account. The rate limit
+// is halved when a request is throttled and restored gradually as requests
+// succeed.
+type adaptiveLimiter struct {
+	limiter *rate.Limiter
+	log     *logp.Logger
+	metrics *inputMetrics
+
+	mu      sync.Mutex
+	max     rate.Limit
+	current rate.Limit
+}
+
+func newAdaptiveLimiter(requestsPerSecond float64, metrics *inputMetrics, log *logp.Logger) *adaptiveLimiter {
+	burst := max(1, int(requestsPerSecond))
+	l := &adaptiveLimiter{
+		limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), burst),
+		log:     log,
+		metrics: metrics,
+		max:     rate.Limit(requestsPerSecond),
+		current: rate.Limit(requestsPerSecond),
+	}
+	metrics.apiRateLimit.Set(requestsPerSecond)
+	return l
+}
+
+// wait blocks until a request can be sent.
+func (l *adaptiveLimiter) wait(ctx context.Context) error {
+	return l.limiter.Wait(ctx)
+}
+
+// throttled halves the rate limit after a throttled request.
+func (l *adaptiveLimiter) throttled() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.metrics.apiThrottledTotal.Inc()
+	l.set(max(l.current/2, l.max*minRateFraction))
+	l.log.Debugw("FilterLogEvents request throttled, reducing the rate limit", "rate_limit", float64(l.current))
+}
+
+// succeeded raises the rate limit after a successful request, up to
+// api_rate_limit.
+func (l *adaptiveLimiter) succeeded() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.current < l.max {
+		l.set(min(l.current+l.max/recoverySteps, l.max))
+	}
+}
+
+func (l *adaptiveLimiter) set(limit rate.Limit) {
+	l.current = limit
+	l.limiter.SetLimit(limit)
+	l.metrics.apiRateLimit.Set(float64(limit))
+}
+
+// backoff returns the time to wait before the attempt to send a throttled
+// request again, growing exponentially with some jitter.
+func backoff(attempt int) time.Duration {
+	d := min(throttleBackoff<<(attempt-1), maxThrottleBackoff)
+	return d/2 + rand.N(d/2+1) //nolint:gosec // The jitter doesn't need a secure random number.
+}
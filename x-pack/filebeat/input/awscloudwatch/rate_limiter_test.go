@@ -0,0 +1,106 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awscloudwatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestAdaptiveLimiter(t *testing.T) {
+	metrics := newInputMetrics(monitoring.NewRegistry())
+	l := newAdaptiveLimiter(10, metrics, logp.NewLogger("test"))
+	assert.Equal(t, 10.0, metrics.apiRateLimit.Get())
+	assert.Equal(t, 10, l.limiter.Burst())
+
+	l.throttled()
+	assert.Equal(t, 5.0, metrics.apiRateLimit.Get(), "the rate limit is halved when throttled")
+	for i := 0; i < 10; i++ {
+		l.throttled()
+	}
+	assert.Equal(t, 1.0, metrics.apiRateLimit.Get(), "the rate limit is not reduced below a tenth of api_rate_limit")
+	assert.Equal(t, uint64(11), metrics.apiThrottledTotal.Get())
+
+	l.succeeded()
+	assert.Equal(t, 1.5, metrics.apiRateLimit.Get())
+	for i := 0; i < recoverySteps; i++ {
+		l.succeeded()
+	}
+	assert.Equal(t, 10.0, metrics.apiRateLimit.Get(), "the rate limit is restored up to api_rate_limit")
+	assert.Equal(t, 10.0, float64(l.limiter.Limit()))
+}
+
+func TestBackoff(t *testing.T) {
+	for attempt := 1; attempt <= maxThrottleRetries+2; attempt++ {
+		d := backoff(attempt)
+		want := min(throttleBackoff<<(attempt-1), maxThrottleBackoff)
+		assert.GreaterOrEqual(t, d, want/2, "attempt %d", attempt)
+		assert.LessOrEqual(t, d, want, "attempt %d", attempt)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	cfg := defaultConfig()
+	assert.Equal(t, 5.0, cfg.rateLimit(), "the rate limit is derived from api_sleep by default")
+
+	cfg.APIRateLimit = 2.5
+	assert.Equal(t, 2.5, cfg.rateLimit())
+}
+
+// throttlingClient is a FilterLogEvents client throttling the first
+// requests.
+type throttlingClient struct {
+	throttled int
+	calls     int
+}
+
+func (c *throttlingClient) FilterLogEvents(context.Context, *cloudwatchlogs.FilterLogEventsInput, ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.FilterLogEventsOutput, error) {
+	c.calls++
+	if c.calls <= c.throttled {
+		return nil, &smithy.GenericAPIError{Code: "ThrottlingException"}
+	}
+	return &cloudwatchlogs.FilterLogEventsOutput{}, nil
+}
+
+func TestNextPageThrottled(t *testing.T) {
+	metrics := newInputMetrics(monitoring.NewRegistry())
+	w := &cwWorker{
+		log:     logp.NewLogger("test"),
+		metrics: metrics,
+		limiter: newAdaptiveLimiter(100, metrics, logp.NewLogger("test")),
+	}
+
+	client := &throttlingClient{throttled: 1}
+	paginator := cloudwatchlogs.NewFilterLogEventsPaginator(client, &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupIdentifier: awssdk.String("a"),
+	})
+	output, err := w.nextPage(context.Background(), nil, paginator, metrics.logGroup("a"))
+	require.NoError(t, err)
+	assert.NotNil(t, output)
+	assert.Equal(t, 2, client.calls, "the throttled page is requested again")
+	assert.Equal(t, uint64(1), metrics.apiThrottledTotal.Get())
+	assert.Equal(t, uint64(1), metrics.logGroup("a").apiThrottledTotal.Get())
+	assert.Equal(t, uint64(2), metrics.apiCallsTotal.Get())
+
+	// The backoff is interrupted when the input stops.
+	client = &throttlingClient{throttled: maxThrottleRetries + 1}
+	paginator = cloudwatchlogs.NewFilterLogEventsPaginator(client, &cloudwatchlogs.FilterLogEventsInput{
+		LogGroupIdentifier: awssdk.String("a"),
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = w.nextPage(ctx, nil, paginator, nil)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -6,6 +6,8 @@ package awscloudwatch
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 
 	"github.com/zyedidia/generic/heap"
@@ -15,19 +17,51 @@ import (
 )
 
 const (
-	statePrefix      = "filebeat::aws-cloudwatch::state::"
-	inputGroupArn    = "groupArn"
-	inputGroupName   = "groupName"
-	inputGroupPrefix = "groupPrefix"
+	statePrefix         = "filebeat::aws-cloudwatch::state::"
+	inputGroupArn       = "groupArn"
+	inputGroupName      = "groupName"
+	inputGroupPrefix    = "groupPrefix"
+	inputGroupOrg       = "organization"
+	inputGroupDiscovery = "discovery"
+	logGroupState       = "logGroup"
 )
 
 type storableState struct {
 	LastSyncEpoch int64 `json:"last_sync_epoch" struct:"last_sync_epoch"`
 }
 
+// resumePoint is the page of a scan of a log group where its reading stopped
+// when the input was shut down.
+type resumePoint struct {
+	// StartTime and EndTime are the bounds of the scan, in Unix
+	// milliseconds.
+	StartTime int64 `json:"start_time" struct:"start_time"`
+	EndTime   int64 `json:"end_time" struct:"end_time"`
+	// NextToken is the FilterLogEvents token of the next page of the scan.
+	NextToken string `json:"next_token" struct:"next_token"`
+	// SavedAt is the time the resume point was stored, in Unix
+	// milliseconds. The tokens expire after resumeTokenTTL.
+	SavedAt int64 `json:"saved_at" struct:"saved_at"`
+}
+
 type tracker struct {
 	timeStamp int64
 	count     int
+	// logGroups are the log groups scanned up to timeStamp, if they are
+	// checkpointed.
+	logGroups []string
+}
+
+// completion is a log group scan whose events were all acknowledged.
+type completion struct {
+	timeStamp int64
+	logGroup  string
+}
+
+// logGroupScan is a scan of a log group up to timeStamp.
+type logGroupScan struct {
+	timeStamp int64
+	done      bool
 }
 
 // stateHandler wraps state handling.
@@ -37,9 +71,15 @@ type stateHandler struct {
 	store *statestore.Store
 	log   *logp.Logger
 
+	// checkpointed is called with the checkpoints of the log groups once
+	// they are stored, nil if unset.
+	checkpointed func(logGroupID string, timestamp int64)
+
 	registerReceiver chan tracker
-	completeReceiver chan int64
+	completeReceiver chan completion
 	shutdown         chan struct{}
+	// done is closed when backgroundRunner returns.
+	done chan struct{}
 
 	lock sync.Mutex
 }
@@ -59,8 +99,9 @@ func newStateHandler(log *logp.Logger, cfg config, store statestore.States) (*st
 		store:            st,
 		log:              log,
 		registerReceiver: make(chan tracker),
-		completeReceiver: make(chan int64),
+		completeReceiver: make(chan completion),
 		shutdown:         make(chan struct{}),
+		done:             make(chan struct{}),
 	}
 
 	go sh.backgroundRunner()
@@ -92,6 +133,49 @@ func (s *stateHandler) GetState() (storableState, error) {
 	return ss, nil
 }
 
+// GetLogGroupState returns the checkpoint of the given log group, the end of
+// its last scan whose events were all acknowledged. found is false if the
+// log group has no checkpoint.
+func (s *stateHandler) GetLogGroupState(logGroupID string) (ss storableState, found bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := s.logGroupKey(logGroupID)
+	found, err = s.store.Has(key)
+	if err != nil || !found {
+		return storableState{}, false, err
+	}
+	if err := s.store.Get(key, &ss); err != nil {
+		return storableState{}, false, err
+	}
+	return ss, true, nil
+}
+
+// SetResumePoint stores the resume point of the scan of a log group that was
+// interrupted by the shutdown of the input.
+func (s *stateHandler) SetResumePoint(logGroupID string, rp resumePoint) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.store.Set(s.resumePointKey(logGroupID), rp)
+}
+
+// TakeResumePoint returns the resume point of the given log group and removes
+// it from the store. found is false if the log group has none.
+func (s *stateHandler) TakeResumePoint(logGroupID string) (rp resumePoint, found bool, err error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	key := s.resumePointKey(logGroupID)
+	found, err = s.store.Has(key)
+	if err != nil || !found {
+		return resumePoint{}, false, err
+	}
+	if err := s.store.Get(key, &rp); err != nil {
+		return resumePoint{}, false, err
+	}
+	return rp, true, s.store.Remove(key)
+}
+
 // WorkRegister accepts work identified through timestamp and amount of work.
 func (s *stateHandler) WorkRegister(timestamp int64, workCount int) {
 	s.registerReceiver <- tracker{
@@ -100,10 +184,31 @@ func (s *stateHandler) WorkRegister(timestamp int64, workCount int) {
 	}
 }
 
+// WorkRegisterLogGroups accepts the scan of the given log groups up to
+// timestamp. Each log group is checkpointed once its scan and all its
+// previous scans are complete.
+func (s *stateHandler) WorkRegisterLogGroups(timestamp int64, logGroupIDs []string) {
+	s.registerReceiver <- tracker{
+		timeStamp: timestamp,
+		count:     len(logGroupIDs),
+		logGroups: logGroupIDs,
+	}
+}
+
 // WorkComplete accepts an individual work tracked at the given timestamp.
 func (s *stateHandler) WorkComplete(timestamp int64) {
+	s.complete(completion{timeStamp: timestamp})
+}
+
+// WorkCompleteLogGroup accepts the completed scan of a log group registered
+// with WorkRegisterLogGroups.
+func (s *stateHandler) WorkCompleteLogGroup(logGroupID string, timestamp int64) {
+	s.complete(completion{timeStamp: timestamp, logGroup: logGroupID})
+}
+
+func (s *stateHandler) complete(c completion) {
 	select {
-	case s.completeReceiver <- timestamp:
+	case s.completeReceiver <- c:
 	case <-s.shutdown: // Make sure to not block during a shutdown
 	}
 }
@@ -111,21 +216,42 @@ func (s *stateHandler) WorkComplete(timestamp int64) {
 // backgroundRunner tracks registered work and completed work.
 // It stores the oldest tracked work once all work for corresponding timestamp is complete.
 func (s *stateHandler) backgroundRunner() {
+	defer close(s.done)
 	trackingMap := map[int64]*tracker{}
 	bHeap := heap.New[*tracker](func(a, b *tracker) bool {
 		return a.timeStamp < b.timeStamp
 	})
+	// The scans of each log group, in the order of their timestamps.
+	logGroupScans := map[string][]*logGroupScan{}
 
 	for {
 		select {
 		case <-s.shutdown:
 			return
 		case r := <-s.registerReceiver:
-			trackingMap[r.timeStamp] = &r
-			bHeap.Push(&r)
+			if got, found := trackingMap[r.timeStamp]; found {
+				// Works registered in the same millisecond, as by the poll
+				// and Live Tail loops, are tracked together.
+				got.count += r.count
+				got.logGroups = append(got.logGroups, r.logGroups...)
+			} else {
+				trackingMap[r.timeStamp] = &r
+				bHeap.Push(&r)
+			}
+			for _, id := range r.logGroups {
+				// The earlier slices of a backfill are registered after the
+				// scan they belong to, the scans are kept in timestamp order.
+				scans := append(logGroupScans[id], &logGroupScan{timeStamp: r.timeStamp})
+				sort.SliceStable(scans, func(i, j int) bool { return scans[i].timeStamp < scans[j].timeStamp })
+				logGroupScans[id] = scans
+			}
 		case cmp := <-s.completeReceiver:
+			if cmp.logGroup != "" {
+				s.completeLogGroup(logGroupScans, cmp)
+			}
+
 			// reduce tracked work
-			got := trackingMap[cmp]
+			got := trackingMap[cmp.timeStamp]
 			got.count -= 1
 
 			// check if oldest entry completed and select most recent oldest entry to store
@@ -151,6 +277,42 @@ func (s *stateHandler) backgroundRunner() {
 	}
 }
 
+// completeLogGroup marks a scan of a log group complete, and stores the
+// checkpoint of the log group if all its previous scans are complete too.
+func (s *stateHandler) completeLogGroup(logGroupScans map[string][]*logGroupScan, cmp completion) {
+	scans := logGroupScans[cmp.logGroup]
+	for _, scan := range scans {
+		if scan.timeStamp == cmp.timeStamp {
+			scan.done = true
+			break
+		}
+	}
+
+	var toStore *logGroupScan
+	for len(scans) > 0 && scans[0].done {
+		toStore, scans = scans[0], scans[1:]
+	}
+	if len(scans) == 0 {
+		delete(logGroupScans, cmp.logGroup)
+	} else {
+		logGroupScans[cmp.logGroup] = scans
+	}
+	if toStore == nil {
+		return
+	}
+
+	s.lock.Lock()
+	err := s.store.Set(s.logGroupKey(cmp.logGroup), storableState{LastSyncEpoch: toStore.timeStamp})
+	s.lock.Unlock()
+	if err != nil {
+		s.log.Errorf("error storing state of log group %s: %v", cmp.logGroup, err)
+		return
+	}
+	if s.checkpointed != nil {
+		s.checkpointed(cmp.logGroup, toStore.timeStamp)
+	}
+}
+
 func (s *stateHandler) storeState(ss storableState) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -163,12 +325,28 @@ func (s *stateHandler) storeState(ss storableState) error {
 	return nil
 }
 
+// logGroupKey returns the registry key of the checkpoint of a log group. It
+// is scoped to the input state ID so that inputs reading the same log group
+// with different settings keep their own checkpoints. Listed log group ARNs
+// end with ":*", which is removed to match configured ARNs.
+func (s *stateHandler) logGroupKey(logGroupID string) string {
+	return fmt.Sprintf("%s::%s::%s", s.id, logGroupState, strings.TrimSuffix(logGroupID, ":*"))
+}
+
+// resumePointKey returns the registry key of the resume point of a log group.
+func (s *stateHandler) resumePointKey(logGroupID string) string {
+	return s.logGroupKey(logGroupID) + "::resume"
+}
+
+// Close stops tracking the work and closes the store. The completions accepted
+// before are stored first.
 func (s *stateHandler) Close() {
+	close(s.shutdown)
+	<-s.done
+
 	s.lock.Lock()
 	defer s.lock.Unlock()
-
 	s.store.Close()
-	close(s.shutdown)
 }
 
 // generateID is a helper to derive state registry identifier matching provided configurations.
@@ -183,6 +361,22 @@ func generateID(forCfg config) (string, error) {
 		return fmt.Sprintf("%s%s::%s::%s", statePrefix, inputGroupName, forCfg.LogGroupName, forCfg.RegionName), nil
 	}
 
+	// discovered log groups are selected with an optional prefix, patterns and tags
+	if forCfg.Discovery.Enabled {
+		tags := make([]string, 0, len(forCfg.Discovery.Tags))
+		for k, v := range forCfg.Discovery.Tags {
+			tags = append(tags, k+"="+v)
+		}
+		sort.Strings(tags)
+		return fmt.Sprintf("%s%s::%s::%s::%s::%s", statePrefix, inputGroupDiscovery, forCfg.LogGroupNamePrefix,
+			strings.Join(forCfg.Discovery.Patterns, ","), strings.Join(tags, ","), forCfg.RegionName), nil
+	}
+
+	// the log groups of an organization are selected with an optional prefix
+	if forCfg.Organization.Enabled {
+		return fmt.Sprintf("%s%s::%s::%s", statePrefix, inputGroupOrg, forCfg.LogGroupNamePrefix, forCfg.RegionName), nil
+	}
+
 	// finally fallback to log group prefix
 	if forCfg.LogGroupNamePrefix != "" {
 		return fmt.Sprintf("%s%s::%s::%s", statePrefix, inputGroupPrefix, forCfg.LogGroupNamePrefix, forCfg.RegionName), nil
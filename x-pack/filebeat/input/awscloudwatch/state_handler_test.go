@@ -5,6 +5,7 @@
 package awscloudwatch
 
 import (
+	"strings"
 	"testing"
 	"time"
 
@@ -99,6 +100,109 @@ func TestStateHandler(t *testing.T) {
 		assert.Equal(t, int64(200), state.LastSyncEpoch)
 
 	})
+
+	t.Run("Works registered with the same timestamp are tracked together", func(t *testing.T) {
+		cfg := config{LogGroupARN: "logGroupARN"}
+		st, err := newStateHandler(nil, cfg, createTestInputStore())
+		assert.NoError(t, err)
+
+		st.WorkRegisterLogGroups(100, []string{"a"})
+		st.WorkRegisterLogGroups(100, []string{"b"})
+		st.WorkCompleteLogGroup("a", 100)
+
+		<-time.After(100 * time.Millisecond)
+		state, err := st.GetState()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), state.LastSyncEpoch)
+
+		st.WorkCompleteLogGroup("b", 100)
+
+		<-time.After(100 * time.Millisecond)
+		state, err = st.GetState()
+		assert.NoError(t, err)
+		assert.Equal(t, int64(100), state.LastSyncEpoch)
+	})
+}
+
+func TestLogGroupState(t *testing.T) {
+	cfg := config{LogGroupNamePrefix: "prefix", RegionName: "region-A"}
+	st, err := newStateHandler(nil, cfg, createTestInputStore())
+	require.NoError(t, err)
+
+	getState := func(id string) (int64, bool) {
+		t.Helper()
+		// pause for backgroundRunner to run
+		<-time.After(100 * time.Millisecond)
+		state, found, err := st.GetLogGroupState(id)
+		require.NoError(t, err)
+		return state.LastSyncEpoch, found
+	}
+
+	const (
+		a = "arn:aws:logs:region-A:123:log-group:a:*"
+		b = "arn:aws:logs:region-A:123:log-group:b:*"
+	)
+	st.WorkRegisterLogGroups(100, []string{a, b})
+	st.WorkRegisterLogGroups(200, []string{a, b})
+
+	_, found := getState(a)
+	assert.False(t, found)
+
+	// A log group is checkpointed independently of the others.
+	st.WorkCompleteLogGroup(a, 100)
+	epoch, found := getState(a)
+	assert.True(t, found)
+	assert.Equal(t, int64(100), epoch)
+	_, found = getState(b)
+	assert.False(t, found)
+
+	// A scan is not checkpointed before the previous scans complete.
+	st.WorkCompleteLogGroup(b, 200)
+	_, found = getState(b)
+	assert.False(t, found)
+	st.WorkCompleteLogGroup(b, 100)
+	epoch, _ = getState(b)
+	assert.Equal(t, int64(200), epoch)
+
+	st.WorkCompleteLogGroup(a, 200)
+	epoch, _ = getState(a)
+	assert.Equal(t, int64(200), epoch)
+
+	// The input state is tracked as before.
+	state, err := st.GetState()
+	require.NoError(t, err)
+	assert.Equal(t, int64(200), state.LastSyncEpoch)
+
+	// Configured ARNs match listed ARNs.
+	epoch, found = getState(strings.TrimSuffix(a, ":*"))
+	assert.True(t, found)
+	assert.Equal(t, int64(200), epoch)
+	assert.Equal(t,
+		"filebeat::aws-cloudwatch::state::groupPrefix::prefix::region-A::logGroup::arn:aws:logs:region-A:123:log-group:a",
+		st.logGroupKey(a))
+}
+
+func TestResumePoint(t *testing.T) {
+	cfg := config{LogGroupNamePrefix: "prefix", RegionName: "region-A"}
+	st, err := newStateHandler(nil, cfg, createTestInputStore())
+	require.NoError(t, err)
+	defer st.Close()
+
+	_, found, err := st.TakeResumePoint("a")
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	want := resumePoint{StartTime: 100, EndTime: 200, NextToken: "token", SavedAt: 150}
+	require.NoError(t, st.SetResumePoint("a", want))
+	got, found, err := st.TakeResumePoint("a")
+	require.NoError(t, err)
+	assert.True(t, found)
+	assert.Equal(t, want, got)
+
+	// A resume point is only used once.
+	_, found, err = st.TakeResumePoint("a")
+	require.NoError(t, err)
+	assert.False(t, found)
 }
 
 func TestStoreAndGetState(t *testing.T) {
@@ -186,6 +290,27 @@ func TestGenerateID(t *testing.T) {
 			},
 			want: "filebeat::aws-cloudwatch::state::groupPrefix::groupPrefix::region-A",
 		},
+		{
+			name: "ID using organization",
+			cfg: config{
+				LogGroupNamePrefix: "groupPrefix",
+				RegionName:         "region-A",
+				Organization:       organizationConfig{Enabled: true},
+			},
+			want: "filebeat::aws-cloudwatch::state::organization::groupPrefix::region-A",
+		},
+		{
+			name: "ID using discovery",
+			cfg: config{
+				RegionName: "region-A",
+				Discovery: discoveryConfig{
+					Enabled:  true,
+					Patterns: []string{"/aws/lambda/*-prod"},
+					Tags:     map[string]string{"team": "payments", "env": "prod"},
+				},
+			},
+			want: "filebeat::aws-cloudwatch::state::discovery::::/aws/lambda/*-prod::env=prod,team=payments::region-A",
+		},
 		{
 			name:    "Invalid configuration results in an error",
 			isError: true,
@@ -0,0 +1,73 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awsfirehose
+
+import (
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/acker"
+)
+
+// requestACKTracker invokes requestACK when all events of a Firehose request
+// have been published and acknowledged by an output.
+type requestACKTracker struct {
+	requestACK func()
+
+	mutex       sync.Mutex // mutex synchronizes access to pendingACKs.
+	pendingACKs int64      // Number of events of the request that are pending ACKs.
+}
+
+// newRequestACKTracker returns a new requestACKTracker. Ready() must be
+// invoked after all events of the request are published.
+func newRequestACKTracker(requestACK func()) *requestACKTracker {
+	return &requestACKTracker{
+		requestACK:  requestACK,
+		pendingACKs: 1, // Ready() must be called to consume this "1".
+	}
+}
+
+// Ready signals that all the events of the request were published.
+func (t *requestACKTracker) Ready() {
+	t.ACK()
+}
+
+// Add increments the number of pending ACKs.
+func (t *requestACKTracker) Add() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.pendingACKs++
+}
+
+// ACK decrements the number of pending ACKs and acknowledges the request
+// when none is left.
+func (t *requestACKTracker) ACK() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.pendingACKs <= 0 {
+		panic("misuse detected: negative ACK counter")
+	}
+
+	t.pendingACKs--
+	if t.pendingACKs == 0 {
+		t.requestACK()
+	}
+}
+
+// newEventACKHandler returns a beat ACKer that calls the ACK method of the
+// requestACKTracker stored in the private field of the acknowledged events.
+func newEventACKHandler() beat.EventListener {
+	return acker.ConnectionOnly(
+		acker.EventPrivateReporter(func(_ int, privates []interface{}) {
+			for _, private := range privates {
+				if ack, ok := private.(*requestACKTracker); ok {
+					ack.ACK()
+				}
+			}
+		}),
+	)
+}
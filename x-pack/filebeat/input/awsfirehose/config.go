@@ -0,0 +1,47 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awsfirehose
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+type config struct {
+	ListenAddress string                  `config:"listen_address"`
+	ListenPort    string                  `config:"listen_port"`
+	Path          string                  `config:"path"`
+	TLS           *tlscommon.ServerConfig `config:"ssl"`
+	AccessKey     string                  `config:"access_key" validate:"required"`
+	MaxBodySize   int64                   `config:"max_body_bytes" validate:"positive"`
+	ReadTimeout   time.Duration           `config:"read_timeout" validate:"positive"`
+	ACKTimeout    time.Duration           `config:"ack_timeout" validate:"positive"`
+	// NewlineDelimited declares that the records hold newline-delimited
+	// entries, published as separate events.
+	NewlineDelimited bool `config:"newline_delimited"`
+}
+
+func defaultConfig() config {
+	return config{
+		ListenAddress: "127.0.0.1",
+		ListenPort:    "8443",
+		Path:          "/",
+		// Firehose buffers up to 64 MiB of records, which are base64 encoded
+		// in the request body.
+		MaxBodySize: 128 << 20,
+		ReadTimeout: 60 * time.Second,
+		ACKTimeout:  60 * time.Second,
+	}
+}
+
+func (c *config) Validate() error {
+	if !strings.HasPrefix(c.Path, "/") {
+		return fmt.Errorf("path must start with '/': %q", c.Path)
+	}
+	return nil
+}
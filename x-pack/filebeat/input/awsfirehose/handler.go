@@ -0,0 +1,134 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awsfirehose
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/x-pack/libbeat/common/aws/firehose"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// handler receives the requests of a Firehose stream with an HTTP endpoint
+// destination. A request is acknowledged once all its events are
+// acknowledged by the outputs, otherwise Firehose delivers it again.
+type handler struct {
+	accessKey   []byte
+	log         *logp.Logger
+	metrics     *inputMetrics
+	publish     func(beat.Event)
+	maxBodySize int64
+	ackTimeout  time.Duration
+	// newlineDelimited splits the records on newlines.
+	newlineDelimited bool
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.metrics.requestsTotal.Inc()
+	requestID := r.Header.Get(firehose.HeaderRequestID)
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.sendResponse(w, http.StatusMethodNotAllowed, requestID, "method not allowed")
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(r.Header.Get(firehose.HeaderAccessKey)), h.accessKey) != 1 {
+		h.metrics.requestsUnauthorizedTotal.Inc()
+		h.log.Debugw("rejected request with invalid access key", "request_id", requestID, "remote_addr", r.RemoteAddr)
+		h.sendResponse(w, http.StatusUnauthorized, requestID, "invalid or missing access key")
+		return
+	}
+
+	req, attributes, err := h.readRequest(w, r)
+	if err != nil {
+		h.metrics.requestErrorsTotal.Inc()
+		h.log.Errorw("failed to read Firehose request", "request_id", requestID, "error", err)
+		code := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			code = http.StatusRequestEntityTooLarge
+		}
+		h.sendResponse(w, code, requestID, err.Error())
+		return
+	}
+	if req.RequestID != "" {
+		requestID = req.RequestID
+	}
+
+	done := make(chan struct{})
+	acker := newRequestACKTracker(func() { close(done) })
+	dec := newRecordDecoder(requestID, r.Header.Get(firehose.HeaderSourceARN), attributes, time.Now().UTC(), h.maxBodySize, h.newlineDelimited)
+	h.publishRecords(requestID, req.Records, dec, acker)
+	acker.Ready()
+
+	timeout := time.NewTimer(h.ackTimeout)
+	defer timeout.Stop()
+	select {
+	case <-done:
+		h.sendResponse(w, http.StatusOK, requestID, "")
+	case <-timeout.C:
+		// Firehose delivers the request again, the events that were
+		// acknowledged in the meantime are duplicated.
+		h.metrics.requestACKTimeoutsTotal.Inc()
+		h.log.Warnw("timed out waiting for the events of the Firehose request to be acknowledged", "request_id", requestID, "ack_timeout", h.ackTimeout)
+		h.sendResponse(w, http.StatusServiceUnavailable, requestID, "timed out waiting for the events to be acknowledged")
+	case <-r.Context().Done():
+		// The input is stopping or Firehose closed the connection.
+	}
+}
+
+// readRequest decodes the body and the common attributes of a request.
+func (h *handler) readRequest(w http.ResponseWriter, r *http.Request) (*firehose.Request, mapstr.M, error) {
+	req, err := firehose.ReadRequest(w, r, h.maxBodySize)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var attributes mapstr.M
+	if v := r.Header.Get(firehose.HeaderCommonAttributes); v != "" {
+		var common struct {
+			CommonAttributes mapstr.M `json:"commonAttributes"`
+		}
+		if err := json.Unmarshal([]byte(v), &common); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode %s header: %w", firehose.HeaderCommonAttributes, err)
+		}
+		attributes = common.CommonAttributes
+	}
+	return req, attributes, nil
+}
+
+// publishRecords publishes the events of the records. The records that can
+// not be decoded are still published with the error so they are not lost.
+func (h *handler) publishRecords(requestID string, records []firehose.Record, dec *recordDecoder, acker *requestACKTracker) {
+	h.metrics.recordsReceivedTotal.Add(uint64(len(records)))
+	for i, record := range records {
+		events, err := dec.events(record.Data)
+		if err != nil {
+			h.metrics.recordErrorsTotal.Inc()
+			h.log.Warnw("failed to decode Firehose record", "request_id", requestID, "record", i, "error", err)
+			evt := dec.event(dec.arrival, string(record.Data))
+			evt.Fields["error"] = mapstr.M{"message": err.Error()}
+			events = []beat.Event{evt}
+		}
+		for _, evt := range events {
+			acker.Add()
+			evt.Private = acker
+			h.publish(evt)
+		}
+		h.metrics.eventsPublishedTotal.Add(uint64(len(events)))
+	}
+}
+
+func (h *handler) sendResponse(w http.ResponseWriter, code int, requestID, msg string) {
+	if err := firehose.WriteResponse(w, code, requestID, msg); err != nil {
+		h.log.Errorw("failed to write response", "request_id", requestID, "error", err)
+	}
+}
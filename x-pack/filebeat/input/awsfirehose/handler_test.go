@@ -0,0 +1,180 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awsfirehose
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/x-pack/libbeat/common/aws/firehose"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+const testAccessKey = "secret"
+
+// newTestMux returns the handler of an input whose events are acknowledged as
+// soon as they are published, unless ack is false.
+func newTestMux(t *testing.T, c config, ack bool) (http.Handler, *[]beat.Event) {
+	t.Helper()
+	c.AccessKey = testAccessKey
+	require.NoError(t, c.Validate())
+
+	var events []beat.Event
+	in := &firehoseInput{config: c}
+	mux := in.newMux(logp.NewLogger(inputName), newInputMetrics(monitoring.NewRegistry()), func(e beat.Event) {
+		events = append(events, e)
+		if ack {
+			e.Private.(*requestACKTracker).ACK()
+		}
+	})
+	return mux, &events
+}
+
+func newTestRequest(body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	req.Header.Set(firehose.HeaderRequestID, "ed4acda5-034f-9f42-bba1-f29aea6d7d8f")
+	req.Header.Set(firehose.HeaderAccessKey, testAccessKey)
+	req.Header.Set(firehose.HeaderSourceARN, "arn:aws:firehose:us-east-1:123456789012:deliverystream/logs")
+	return req
+}
+
+func decodeResponse(t *testing.T, rec *httptest.ResponseRecorder) firehose.Response {
+	t.Helper()
+	var resp firehose.Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return resp
+}
+
+func TestHandler(t *testing.T) {
+	const body = `{"requestId":"ed4acda5-034f-9f42-bba1-f29aea6d7d8f","timestamp":1578090901599,"records":[` +
+		`{"data":"aGVsbG8Kd29ybGQK"},{"data":"Zm9v"}]}`
+
+	t.Run("acknowledged", func(t *testing.T) {
+		mux, events := newTestMux(t, defaultConfig(), true)
+		req := newTestRequest(body)
+		req.Header.Set(firehose.HeaderCommonAttributes, `{"commonAttributes":{"env":"prod"}}`)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		resp := decodeResponse(t, rec)
+		assert.Equal(t, "ed4acda5-034f-9f42-bba1-f29aea6d7d8f", resp.RequestID)
+		assert.Empty(t, resp.ErrorMessage)
+		assert.NotZero(t, resp.Timestamp)
+
+		require.Len(t, *events, 2)
+		var messages []string
+		for _, e := range *events {
+			messages = append(messages, e.Fields["message"].(string))
+		}
+		assert.Equal(t, []string{"hello\nworld\n", "foo"}, messages, "the records are not split by default")
+		env, err := (*events)[0].Fields.GetValue("aws.firehose.parameters.env")
+		require.NoError(t, err)
+		assert.Equal(t, "prod", env)
+		assert.Equal(t, mapstr.M{
+			"provider": "aws",
+			"region":   "us-east-1",
+			"account":  mapstr.M{"id": "123456789012"},
+		}, (*events)[0].Fields["cloud"])
+	})
+
+	t.Run("ack timeout", func(t *testing.T) {
+		c := defaultConfig()
+		c.ACKTimeout = 10 * time.Millisecond
+		mux, events := newTestMux(t, c, false)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, newTestRequest(body))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code, "Firehose must deliver the request again")
+		assert.Equal(t, "timed out waiting for the events to be acknowledged", decodeResponse(t, rec).ErrorMessage)
+		assert.Len(t, *events, 2)
+	})
+
+	t.Run("newline delimited", func(t *testing.T) {
+		c := defaultConfig()
+		c.NewlineDelimited = true
+		mux, events := newTestMux(t, c, true)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, newTestRequest(body))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var messages []string
+		for _, e := range *events {
+			messages = append(messages, e.Fields["message"].(string))
+		}
+		assert.Equal(t, []string{"hello", "world", "foo"}, messages)
+	})
+
+	t.Run("no records", func(t *testing.T) {
+		mux, _ := newTestMux(t, defaultConfig(), true)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, newTestRequest(`{"requestId":"a","timestamp":1578090901599,"records":[]}`))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, "a", decodeResponse(t, rec).RequestID)
+	})
+
+	t.Run("invalid record", func(t *testing.T) {
+		mux, events := newTestMux(t, defaultConfig(), true)
+		// A truncated gzip stream.
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, newTestRequest(`{"requestId":"a","timestamp":1578090901599,"records":[{"data":"H4sIAAAAAAAA"}]}`))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.Len(t, *events, 1)
+		_, err := (*events)[0].Fields.GetValue("error.message")
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid access key", func(t *testing.T) {
+		mux, events := newTestMux(t, defaultConfig(), true)
+		req := newTestRequest(body)
+		req.Header.Set(firehose.HeaderAccessKey, "other")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Equal(t, "ed4acda5-034f-9f42-bba1-f29aea6d7d8f", decodeResponse(t, rec).RequestID)
+		assert.Empty(t, *events)
+	})
+
+	t.Run("invalid body", func(t *testing.T) {
+		mux, _ := newTestMux(t, defaultConfig(), true)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, newTestRequest(`{"records":[{"data":"not base64"}]}`))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		mux, _ := newTestMux(t, defaultConfig(), true)
+		req := newTestRequest("")
+		req.Method = http.MethodGet
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		c := defaultConfig()
+		c.MaxBodySize = 16
+		mux, _ := newTestMux(t, c, true)
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, newTestRequest(body))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+}
@@ -0,0 +1,33 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awsfirehose
+
+import (
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+type inputMetrics struct {
+	bindAddress               *monitoring.String // Bind address of input.
+	requestsTotal             *monitoring.Uint   // Number of requests received.
+	requestsUnauthorizedTotal *monitoring.Uint   // Number of requests rejected because of an invalid access key.
+	requestErrorsTotal        *monitoring.Uint   // Number of requests that could not be decoded.
+	requestACKTimeoutsTotal   *monitoring.Uint   // Number of requests whose events were not acknowledged in time.
+	recordsReceivedTotal      *monitoring.Uint   // Number of records received.
+	recordErrorsTotal         *monitoring.Uint   // Number of records that could not be decoded.
+	eventsPublishedTotal      *monitoring.Uint   // Number of events published.
+}
+
+func newInputMetrics(reg *monitoring.Registry) *inputMetrics {
+	return &inputMetrics{
+		bindAddress:               monitoring.NewString(reg, "bind_address"),
+		requestsTotal:             monitoring.NewUint(reg, "requests_total"),
+		requestsUnauthorizedTotal: monitoring.NewUint(reg, "requests_unauthorized_total"),
+		requestErrorsTotal:        monitoring.NewUint(reg, "request_errors_total"),
+		requestACKTimeoutsTotal:   monitoring.NewUint(reg, "request_ack_timeouts_total"),
+		recordsReceivedTotal:      monitoring.NewUint(reg, "records_received_total"),
+		recordErrorsTotal:         monitoring.NewUint(reg, "record_errors_total"),
+		eventsPublishedTotal:      monitoring.NewUint(reg, "events_published_total"),
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awsfirehose
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/x-pack/libbeat/common/aws/firehose"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// cloudwatchLogsData is the gzip compressed payload of the records written by
+// a CloudWatch Logs subscription filter.
+type cloudwatchLogsData struct {
+	MessageType         string   `json:"messageType"`
+	Owner               string   `json:"owner"`
+	LogGroup            string   `json:"logGroup"`
+	LogStream           string   `json:"logStream"`
+	SubscriptionFilters []string `json:"subscriptionFilters"`
+	LogEvents           []struct {
+		ID        string `json:"id"`
+		Timestamp int64  `json:"timestamp"`
+		Message   string `json:"message"`
+	} `json:"logEvents"`
+}
+
+// CloudWatch Logs subscription message types.
+const (
+	messageTypeData    = "DATA_MESSAGE"
+	messageTypeControl = "CONTROL_MESSAGE"
+)
+
+// recordDecoder converts the records of a Firehose request into events.
+type recordDecoder struct {
+	firehose mapstr.M
+	cloud    mapstr.M
+	arrival  time.Time
+	maxSize  int64
+	// newlineDelimited splits the records on newlines.
+	newlineDelimited bool
+}
+
+// newRecordDecoder returns a decoder for the records of a request. The
+// request metadata is added to all its events. If newlineDelimited is set,
+// each line of the records is published as an event.
+func newRecordDecoder(requestID, sourceARN string, attributes mapstr.M, arrival time.Time, maxSize int64, newlineDelimited bool) *recordDecoder {
+	fields := mapstr.M{"request_id": requestID}
+	cloud := mapstr.M{"provider": "aws"}
+	if sourceARN != "" {
+		fields["arn"] = sourceARN
+		if a, err := arn.Parse(sourceARN); err == nil {
+			cloud["region"] = a.Region
+			cloud["account"] = mapstr.M{"id": a.AccountID}
+		}
+	}
+	if len(attributes) != 0 {
+		fields["parameters"] = attributes
+	}
+	return &recordDecoder{firehose: fields, cloud: cloud, arrival: arrival, maxSize: maxSize, newlineDelimited: newlineDelimited}
+}
+
+// events returns the events of a record. The log events delivered by a
+// CloudWatch Logs subscription are published individually, the control
+// messages are dropped. Other records are published as is, as one event, or
+// one event per line if they are declared newline-delimited, like the ones
+// of CloudWatch Metric Streams.
+func (d *recordDecoder) events(data []byte) ([]beat.Event, error) {
+	if isGzip(data) {
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gzip record: %w", err)
+		}
+		defer gz.Close()
+		data, err = io.ReadAll(io.LimitReader(gz, d.maxSize))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gzip record: %w", err)
+		}
+		var logs cloudwatchLogsData
+		if json.Unmarshal(data, &logs) == nil && logs.MessageType != "" {
+			return d.logEvents(logs)
+		}
+	}
+
+	if !d.newlineDelimited {
+		return []beat.Event{d.event(d.arrival, string(data))}, nil
+	}
+	var events []beat.Event
+	for _, line := range firehose.Lines(data) {
+		events = append(events, d.event(d.arrival, string(line)))
+	}
+	return events, nil
+}
+
+func (d *recordDecoder) logEvents(logs cloudwatchLogsData) ([]beat.Event, error) {
+	switch logs.MessageType {
+	case messageTypeControl:
+		// Sent by CloudWatch Logs to check that the destination is reachable.
+		return nil, nil
+	case messageTypeData:
+	default:
+		return nil, fmt.Errorf("unknown CloudWatch Logs message type %q", logs.MessageType)
+	}
+
+	events := make([]beat.Event, 0, len(logs.LogEvents))
+	for _, le := range logs.LogEvents {
+		e := d.event(time.UnixMilli(le.Timestamp).UTC(), le.Message)
+		_, _ = e.Fields.Put("aws.cloudwatch", mapstr.M{
+			"log_group":            logs.LogGroup,
+			"log_stream":           logs.LogStream,
+			"owner":                logs.Owner,
+			"subscription_filters": logs.SubscriptionFilters,
+		})
+		e.Fields["log"] = mapstr.M{"file": mapstr.M{"path": logs.LogGroup + "/" + logs.LogStream}}
+		// The event ID is used as the document ID to avoid duplicates when
+		// Firehose retries a request.
+		if le.ID != "" {
+			e.SetID(le.ID)
+		}
+		events = append(events, e)
+	}
+	return events, nil
+}
+
+func (d *recordDecoder) event(timestamp time.Time, message string) beat.Event {
+	return beat.Event{
+		Timestamp: timestamp,
+		Fields: mapstr.M{
+			"message": message,
+			"aws":     mapstr.M{"firehose": d.firehose.Clone()},
+			"cloud":   d.cloud.Clone(),
+		},
+	}
+}
+
+func isGzip(data []byte) bool {
+	return len(data) > 2 && data[0] == 0x1f && data[1] == 0x8b
+}
@@ -0,0 +1,102 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awsfirehose
+
+import (
+	"bytes"
+	"compress/gzip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func gzipData(t *testing.T, data string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	_, err := gz.Write([]byte(data))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+	return buf.Bytes()
+}
+
+func TestRecordDecoder(t *testing.T) {
+	arrival := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	dec := newRecordDecoder("req-1", "arn:aws:firehose:eu-west-1:123456789012:deliverystream/logs", nil, arrival, 1<<20, false)
+
+	t.Run("cloudwatch logs", func(t *testing.T) {
+		events, err := dec.events(gzipData(t, `{
+  "messageType": "DATA_MESSAGE",
+  "owner": "123456789012",
+  "logGroup": "/aws/lambda/app",
+  "logStream": "2026/10/16/[$LATEST]abc",
+  "subscriptionFilters": ["to-firehose"],
+  "logEvents": [
+    {"id": "37880", "timestamp": 1792144800000, "message": "START RequestId: 1"},
+    {"id": "37881", "timestamp": 1792144800500, "message": "END RequestId: 1"}
+  ]
+}`))
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+
+		e := events[1]
+		assert.Equal(t, time.UnixMilli(1792144800500).UTC(), e.Timestamp)
+		assert.Equal(t, "END RequestId: 1", e.Fields["message"])
+		assert.Equal(t, "37881", e.Meta["_id"])
+		cloudwatch, err := e.Fields.GetValue("aws.cloudwatch")
+		require.NoError(t, err)
+		assert.Equal(t, mapstr.M{
+			"log_group":            "/aws/lambda/app",
+			"log_stream":           "2026/10/16/[$LATEST]abc",
+			"owner":                "123456789012",
+			"subscription_filters": []string{"to-firehose"},
+		}, cloudwatch)
+		requestID, err := e.Fields.GetValue("aws.firehose.request_id")
+		require.NoError(t, err)
+		assert.Equal(t, "req-1", requestID)
+		region, err := e.Fields.GetValue("cloud.region")
+		require.NoError(t, err)
+		assert.Equal(t, "eu-west-1", region)
+	})
+
+	t.Run("cloudwatch logs control message", func(t *testing.T) {
+		events, err := dec.events(gzipData(t, `{"messageType":"CONTROL_MESSAGE","logEvents":[{"id":"","timestamp":1792144800000,"message":"CWL CONTROL MESSAGE: Checking health of destination Firehose."}]}`))
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+
+	t.Run("unknown message type", func(t *testing.T) {
+		_, err := dec.events(gzipData(t, `{"messageType":"OTHER"}`))
+		assert.ErrorContains(t, err, `unknown CloudWatch Logs message type "OTHER"`)
+	})
+
+	t.Run("not delimited", func(t *testing.T) {
+		for _, data := range []string{"Exception in thread \"main\"\n\tat App.main(App.java:3)\n", "\x00\x01\n\x02"} {
+			events, err := dec.events([]byte(data))
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+			assert.Equal(t, data, events[0].Fields["message"], "the record is published as is")
+		}
+	})
+
+	t.Run("metric stream", func(t *testing.T) {
+		dec := newRecordDecoder("req-1", "", nil, arrival, 1<<20, true)
+		data := `{"metric_stream_name":"all","namespace":"AWS/EC2","metric_name":"CPUUtilization","value":{"sum":1.5}}` + "\n" +
+			`{"metric_stream_name":"all","namespace":"AWS/EC2","metric_name":"NetworkIn","value":{"sum":42}}` + "\n"
+		for name, record := range map[string][]byte{"plain": []byte(data), "gzip": gzipData(t, data)} {
+			t.Run(name, func(t *testing.T) {
+				events, err := dec.events(record)
+				require.NoError(t, err)
+				require.Len(t, events, 2)
+				assert.Equal(t, arrival, events[0].Timestamp)
+				assert.Contains(t, events[1].Fields["message"], `"metric_name":"NetworkIn"`)
+			})
+		}
+	})
+}
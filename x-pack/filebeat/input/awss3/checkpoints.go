@@ -0,0 +1,225 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awss3
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	sqsCheckpointKeyPrefix = "filebeat::aws-s3::sqs::checkpoint::"
+
+	// sqsCheckpointTTL is how long the checkpoints of an object are kept
+	// when its SQS message is never deleted. SQS keeps messages for 14 days
+	// at most.
+	sqsCheckpointTTL = 14 * 24 * time.Hour
+
+	// checkpointEvents is the number of events of an object after which its
+	// offset is checkpointed once they are acknowledged.
+	checkpointEvents = 1000
+)
+
+// objectCheckpoint is the persisted progress of an object: the offset of the
+// last acknowledged event.
+type objectCheckpoint struct {
+	Offset  int64     `json:"offset" struct:"offset"`
+	Updated time.Time `json:"updated" struct:"updated"`
+}
+
+// objectCheckpoints keeps the per-object checkpoints of the SQS messages that
+// are being processed. They are used to skip the events that were already
+// acknowledged when an SQS message is delivered again, for example after its
+// visibility timeout expired or Filebeat restarted while processing it.
+type objectCheckpoints struct {
+	log       *logp.Logger
+	store     *statestore.Store
+	keyPrefix string
+
+	mu          sync.Mutex
+	checkpoints map[string]objectCheckpoint
+}
+
+// newObjectCheckpoints loads the checkpoints stored under keyPrefix, removing
+// the ones that were not updated for ttl.
+func newObjectCheckpoints(log *logp.Logger, store *statestore.Store, keyPrefix string, ttl time.Duration, now time.Time) (*objectCheckpoints, error) {
+	c := &objectCheckpoints{
+		log:         log,
+		store:       store,
+		keyPrefix:   keyPrefix,
+		checkpoints: map[string]objectCheckpoint{},
+	}
+
+	var expired []string
+	err := store.Each(func(key string, dec statestore.ValueDecoder) (bool, error) {
+		if !strings.HasPrefix(key, keyPrefix) {
+			return true, nil
+		}
+		var cp objectCheckpoint
+		if err := dec.Decode(&cp); err != nil {
+			log.Warnf("invalid checkpoint for key %v", key)
+			expired = append(expired, key)
+			return true, nil
+		}
+		if now.Sub(cp.Updated) > ttl {
+			expired = append(expired, key)
+			return true, nil
+		}
+		c.checkpoints[strings.TrimPrefix(key, keyPrefix)] = cp
+		return true, nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("loading checkpoints: %w", err)
+	}
+
+	for _, key := range expired {
+		if err := store.Remove(key); err != nil {
+			return nil, fmt.Errorf("removing expired checkpoint %s: %w", key, err)
+		}
+	}
+	return c, nil
+}
+
+// get returns the offset of the last acknowledged event of the object, or -1
+// if none was acknowledged.
+func (c *objectCheckpoints) get(id string) int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cp, ok := c.checkpoints[id]; ok {
+		return cp.Offset
+	}
+	return -1
+}
+
+// commit records offset as acknowledged. Acknowledgements may complete out of
+// order so a checkpoint never moves backwards.
+func (c *objectCheckpoints) commit(id string, offset int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cp, ok := c.checkpoints[id]; ok && cp.Offset >= offset {
+		return
+	}
+	cp := objectCheckpoint{Offset: offset, Updated: time.Now().UTC()}
+	c.checkpoints[id] = cp
+	if err := c.store.Set(c.keyPrefix+id, cp); err != nil {
+		c.log.Errorw("Failed to persist checkpoint.", "object", id, "offset", offset, "error", err)
+	}
+}
+
+// remove drops the checkpoint of an object once its SQS message was deleted.
+func (c *objectCheckpoints) remove(id string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.checkpoints, id)
+	return c.store.Remove(c.keyPrefix + id)
+}
+
+func (c *objectCheckpoints) Close() {
+	_ = c.store.Close()
+}
+
+// pendingCheckpoint is committed once eventCount more events published from
+// the SQS message are acknowledged.
+type pendingCheckpoint struct {
+	eventCount int
+	commit     func()
+}
+
+// checkpointBatch tracks the events published from the objects of one SQS
+// message and the checkpoints to commit as they are acknowledged.
+type checkpointBatch struct {
+	checkpoints *objectCheckpoints
+	pending     []pendingCheckpoint
+	objects     []string
+}
+
+func (c *objectCheckpoints) newBatch() *checkpointBatch {
+	return &checkpointBatch{checkpoints: c}
+}
+
+// objectCheckpointID returns the ID of the checkpoint of an object. The
+// sequencer of the notification, if any, tells apart the successive versions
+// of an object written to the same key.
+func objectCheckpointID(obj s3EventV2) string {
+	id := obj.S3.Bucket.Name + "/" + obj.S3.Object.Key
+	if obj.S3.Object.Sequencer != "" {
+		id += "#" + obj.S3.Object.Sequencer
+	}
+	return id
+}
+
+// object wraps eventCallback for the processing of one object. Events at or
+// before the checkpointed offset are dropped, and a checkpoint is queued every
+// checkpointEvents published events. The returned function must be called
+// when the object is done to queue the checkpoint of its remaining events.
+func (b *checkpointBatch) object(log *logp.Logger, obj s3EventV2, eventCallback func(beat.Event)) (callback func(beat.Event), done func()) {
+	id := objectCheckpointID(obj)
+	b.objects = append(b.objects, id)
+	checkpoint := b.checkpoints.get(id)
+
+	var published, skipped int
+	lastOffset := int64(-1)
+	queue := func() {
+		// Every published event is counted so the checkpoints stay aligned
+		// with the acknowledgements, even if some events carry no offset.
+		pc := pendingCheckpoint{eventCount: published}
+		if offset := lastOffset; offset >= 0 {
+			pc.commit = func() { b.checkpoints.commit(id, offset) }
+		}
+		b.pending = append(b.pending, pc)
+		published = 0
+	}
+
+	callback = func(e beat.Event) {
+		offset, ok := eventOffset(e)
+		if ok && offset <= checkpoint {
+			skipped++
+			return
+		}
+		eventCallback(e)
+		published++
+		if ok {
+			lastOffset = offset
+		}
+		if published == checkpointEvents {
+			queue()
+		}
+	}
+	done = func() {
+		if published > 0 {
+			queue()
+		}
+		if skipped > 0 {
+			log.Debugw("Skipped events already acknowledged in a previous delivery.",
+				"object", id, "checkpoint", checkpoint, "skipped", skipped)
+		}
+	}
+	return callback, done
+}
+
+// finalizers returns the functions removing the checkpoints of the batch,
+// called once the SQS message is deleted.
+func (b *checkpointBatch) finalizers() []finalizerFunc {
+	out := make([]finalizerFunc, 0, len(b.objects))
+	for _, id := range b.objects {
+		out = append(out, func() error { return b.checkpoints.remove(id) })
+	}
+	return out
+}
+
+func eventOffset(e beat.Event) (int64, bool) {
+	v, err := e.Fields.GetValue("log.offset")
+	if err != nil {
+		return 0, false
+	}
+	offset, ok := v.(int64)
+	return offset, ok
+}
@@ -0,0 +1,116 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awss3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestObjectCheckpoints(t *testing.T) {
+	log := logp.NewLogger(inputName)
+	states := openTestStatestore()
+	store, err := states.StoreFor("")
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	require.NoError(t, store.Set(sqsCheckpointKeyPrefix+"b/current.gz", objectCheckpoint{Offset: 42, Updated: now}))
+	require.NoError(t, store.Set(sqsCheckpointKeyPrefix+"b/expired.gz", objectCheckpoint{Offset: 7, Updated: now.Add(-sqsCheckpointTTL - time.Hour)}))
+	require.NoError(t, store.Set(fdrCheckpointKeyPrefix+"b/fdr.gz", objectCheckpoint{Offset: 3, Updated: now}))
+
+	c, err := newObjectCheckpoints(log, store, sqsCheckpointKeyPrefix, sqsCheckpointTTL, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), c.get("b/current.gz"))
+	assert.Equal(t, int64(-1), c.get("b/expired.gz"))
+	assert.Equal(t, int64(-1), c.get("b/fdr.gz"), "checkpoints of other prefixes must be ignored")
+	has, err := store.Has(sqsCheckpointKeyPrefix + "b/expired.gz")
+	require.NoError(t, err)
+	assert.False(t, has, "expired checkpoint must be removed from the store")
+
+	// Checkpoints never move backwards.
+	c.commit("b/current.gz", 10)
+	assert.Equal(t, int64(42), c.get("b/current.gz"))
+	c.commit("b/current.gz", 100)
+	assert.Equal(t, int64(100), c.get("b/current.gz"))
+
+	// A new instance sees the persisted value.
+	reloaded, err := newObjectCheckpoints(log, store, sqsCheckpointKeyPrefix, sqsCheckpointTTL, now)
+	require.NoError(t, err)
+	assert.Equal(t, int64(100), reloaded.get("b/current.gz"))
+
+	require.NoError(t, c.remove("b/current.gz"))
+	assert.Equal(t, int64(-1), c.get("b/current.gz"))
+	has, err = store.Has(sqsCheckpointKeyPrefix + "b/current.gz")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestCheckpointBatchObject(t *testing.T) {
+	log := logp.NewLogger(inputName)
+	store, err := openTestStatestore().StoreFor("")
+	require.NoError(t, err)
+	c, err := newObjectCheckpoints(log, store, fdrCheckpointKeyPrefix, fdrCheckpointTTL, time.Now())
+	require.NoError(t, err)
+
+	events, err := parseFDRNotification(fdrTestNotification, "us-west-1")
+	require.NoError(t, err)
+	obj := events[0]
+	c.commit(objectCheckpointID(obj), 1500)
+
+	batch := c.newBatch()
+	var published []int64
+	callback, done := batch.object(log, obj, func(e beat.Event) {
+		offset, _ := eventOffset(e)
+		published = append(published, offset)
+	})
+	// Offsets are byte positions; use 100 bytes per line.
+	const lines = 1200
+	for i := 0; i < lines; i++ {
+		callback(beat.Event{Fields: mapstr.M{"log": mapstr.M{"offset": int64(i * 100)}}})
+	}
+	done()
+
+	// Lines 0 to 15 were acknowledged in a previous delivery.
+	require.Len(t, published, lines-16)
+	assert.Equal(t, int64(1600), published[0])
+
+	require.Len(t, batch.pending, 2)
+	assert.Equal(t, checkpointEvents, batch.pending[0].eventCount)
+	assert.Equal(t, lines-16-checkpointEvents, batch.pending[1].eventCount)
+
+	batch.pending[0].commit()
+	assert.Equal(t, int64((16+checkpointEvents-1)*100), c.get(objectCheckpointID(obj)))
+	batch.pending[1].commit()
+	assert.Equal(t, int64((lines-1)*100), c.get(objectCheckpointID(obj)))
+
+	for _, f := range batch.finalizers() {
+		require.NoError(t, f())
+	}
+	assert.Equal(t, int64(-1), c.get(objectCheckpointID(obj)))
+}
+
+func TestObjectCheckpointID(t *testing.T) {
+	var obj s3EventV2
+	obj.S3.Bucket.Name = "bucket"
+	obj.S3.Object.Key = "logs/a.gz"
+	assert.Equal(t, "bucket/logs/a.gz", objectCheckpointID(obj))
+
+	// Each version of the object written to the key has its own checkpoint.
+	obj.S3.Object.Sequencer = "0055AED6DCD90281E5"
+	assert.Equal(t, "bucket/logs/a.gz#0055AED6DCD90281E5", objectCheckpointID(obj))
+
+	var msg eventBridgeEvent
+	msg.Detail.Bucket.Name = "bucket"
+	msg.Detail.Object.Key = "logs/a.gz"
+	msg.Detail.Object.Sequencer = "0055AED6DCD90281E5"
+	assert.Equal(t, "bucket/logs/a.gz#0055AED6DCD90281E5", objectCheckpointID(convertEventBridgeEvent("arn:aws:s3:::bucket", &msg)))
+}
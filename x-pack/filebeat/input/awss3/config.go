@@ -22,6 +22,8 @@ import (
 	"github.com/elastic/beats/v7/libbeat/reader/parser"
 	"github.com/elastic/beats/v7/libbeat/reader/readfile"
 	"github.com/elastic/beats/v7/libbeat/reader/readfile/encoding"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/leases"
 	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
 	"github.com/elastic/beats/v7/x-pack/libbeat/reader/decoder"
 )
@@ -34,6 +36,8 @@ type config struct {
 	BucketARN                   string               `config:"bucket_arn"`
 	BucketListInterval          time.Duration        `config:"bucket_list_interval"`
 	BucketListPrefix            string               `config:"bucket_list_prefix"`
+	Coordination                leases.Config        `config:"coordination"`
+	Download                    download.Config      `config:"download"`
 	FileSelectors               []fileSelectorConfig `config:"file_selectors"`
 	IgnoreOlder                 time.Duration        `config:"ignore_older"`
 	LexicographicalOrdering     bool                 `config:"lexicographical_ordering"`
@@ -49,6 +53,7 @@ type config struct {
 	SQSScript                   *scriptConfig        `config:"sqs.notification_parsing_script"`
 	SQSWaitTime                 time.Duration        `config:"sqs.wait_time"`           // The max duration for which the SQS ReceiveMessage call waits for a message to arrive in the queue before returning.
 	SQSGraceTime                time.Duration        `config:"sqs.shutdown_grace_time"` // The time that the processing loop will wait for messages before shutting down.
+	SQSCheckpointObjects        bool                 `config:"sqs.checkpoint_objects"`  // Checkpoint the progress within the objects so redelivered messages resume after the acknowledged events.
 	StartTimestamp              string               `config:"start_timestamp"`
 	VisibilityTimeout           time.Duration        `config:"visibility_timeout"`
 }
@@ -61,6 +66,8 @@ func defaultConfig() config {
 		BucketListPrefix:            "",
 		LexicographicalOrdering:     false,
 		LexicographicalLookbackKeys: 100,
+		Coordination:                leases.DefaultConfig(),
+		Download:                    download.DefaultConfig(),
 		SQSWaitTime:                 20 * time.Second,
 		SQSGraceTime:                20 * time.Second,
 		SQSMaxReceiveCount:          5,
@@ -102,6 +109,19 @@ func (c *config) Validate() error {
 		return errors.New("lexicographical_ordering can only be used when polling AWS S3, S3 Access Point, or non-AWS S3 bucket")
 	}
 
+	if c.Coordination.Enabled {
+		if c.BucketARN == "" && c.AccessPointARN == "" && c.NonAWSBucketName == "" {
+			return errors.New("coordination can only be used when polling AWS S3, S3 Access Point, or non-AWS S3 bucket")
+		}
+		if c.LexicographicalOrdering {
+			return errors.New("coordination cannot be used with lexicographical_ordering")
+		}
+		if c.Coordination.Type == leases.TypeS3 && c.Coordination.BucketName == c.getBucketName() &&
+			strings.HasPrefix(c.Coordination.ObjectPrefix, c.BucketListPrefix) {
+			return errors.New("coordination.object_prefix must not be listed with bucket_list_prefix when the leases are stored in the polled bucket")
+		}
+	}
+
 	if c.LexicographicalOrdering && c.LexicographicalLookbackKeys <= 0 {
 		return fmt.Errorf("lexicographical_lookback_keys <%d> must be greater than 0", c.LexicographicalLookbackKeys)
 	}
@@ -0,0 +1,75 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awss3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	fdrInputName           = "crowdstrike-fdr"
+	fdrCheckpointKeyPrefix = "filebeat::crowdstrike-fdr::checkpoint::"
+
+	// fdrCheckpointTTL is how long the checkpoints of a batch are kept when
+	// its SQS message is never deleted. FDR only keeps data for 7 days.
+	fdrCheckpointTTL = 7 * 24 * time.Hour
+)
+
+// fdrNotification is the SQS message that CrowdStrike Falcon Data Replicator
+// sends when a new batch of files is available in its bucket. Each file of the
+// batch is a gzip compressed, newline delimited JSON shard.
+type fdrNotification struct {
+	CID        string    `json:"cid"`
+	Timestamp  int64     `json:"timestamp"`
+	FileCount  int       `json:"fileCount"`
+	TotalSize  int64     `json:"totalSize"`
+	Bucket     string    `json:"bucket"`
+	PathPrefix string    `json:"pathPrefix"`
+	Files      []fdrFile `json:"files"`
+}
+
+type fdrFile struct {
+	Path     string `json:"path"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"`
+}
+
+// parseFDRNotification converts an FDR notification into one S3 event per
+// file of the batch. The batch timestamp is used as the object modification
+// time so the event IDs stay the same when the message is delivered again.
+func parseFDRNotification(body, region string) ([]s3EventV2, error) {
+	var n fdrNotification
+	dec := json.NewDecoder(strings.NewReader(body))
+	if err := dec.Decode(&n); err != nil {
+		return nil, fmt.Errorf("failed to decode SQS message body as an FDR notification: %w", err)
+	}
+	if n.Bucket == "" {
+		return nil, errors.New("the message is an invalid FDR notification: missing bucket field")
+	}
+	if n.FileCount != len(n.Files) {
+		return nil, fmt.Errorf("the message is an invalid FDR notification: fileCount is %d but %d files are listed", n.FileCount, len(n.Files))
+	}
+
+	out := make([]s3EventV2, 0, len(n.Files))
+	for _, f := range n.Files {
+		if f.Path == "" {
+			return nil, errors.New("the message is an invalid FDR notification: file with empty path")
+		}
+		var event s3EventV2
+		event.SetAWSRegion(region)
+		event.SetEventSource("aws:s3")
+		event.SetEventName("ObjectCreated:Put")
+		event.SetS3BucketName(n.Bucket)
+		event.SetS3BucketARN("arn:aws:s3:::" + n.Bucket)
+		event.SetS3ObjectKey(f.Path)
+		event.S3.Object.LastModified = time.UnixMilli(n.Timestamp).UTC()
+		out = append(out, event)
+	}
+	return out, nil
+}
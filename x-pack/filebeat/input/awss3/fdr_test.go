@@ -0,0 +1,55 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awss3
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const fdrTestNotification = `{
+  "cid": "1234567890abcdef",
+  "timestamp": 1700000000000,
+  "fileCount": 2,
+  "totalSize": 2048,
+  "bucket": "cs-prod-cannon-0123",
+  "pathPrefix": "data/f0714ca5",
+  "files": [
+    {"path": "data/f0714ca5/part-00000.gz", "size": 1024, "checksum": "a"},
+    {"path": "data/f0714ca5/part-00001.gz", "size": 1024, "checksum": "b"}
+  ]
+}`
+
+func TestParseFDRNotification(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		events, err := parseFDRNotification(fdrTestNotification, "us-west-1")
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+
+		assert.Equal(t, "aws:s3", events[0].EventSource)
+		assert.Equal(t, "us-west-1", events[0].AWSRegion)
+		assert.Equal(t, "cs-prod-cannon-0123", events[0].S3.Bucket.Name)
+		assert.Equal(t, "arn:aws:s3:::cs-prod-cannon-0123", events[0].S3.Bucket.ARN)
+		assert.Equal(t, "data/f0714ca5/part-00000.gz", events[0].S3.Object.Key)
+		assert.Equal(t, "data/f0714ca5/part-00001.gz", events[1].S3.Object.Key)
+		assert.Equal(t, time.UnixMilli(1700000000000).UTC(), events[1].S3.Object.LastModified)
+	})
+
+	testCases := map[string]string{
+		"invalid json":   `{"bucket": `,
+		"missing bucket": `{"fileCount": 0, "files": []}`,
+		"count mismatch": `{"bucket": "b", "fileCount": 2, "files": [{"path": "a.gz"}]}`,
+		"empty path":     `{"bucket": "b", "fileCount": 1, "files": [{"path": ""}]}`,
+	}
+	for name, body := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := parseFDRNotification(body, "us-west-1")
+			assert.Error(t, err)
+		})
+	}
+}
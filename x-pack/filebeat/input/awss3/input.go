@@ -29,10 +29,24 @@ func Plugin(logger *logp.Logger, store statestore.States, p *paths.Path) v2.Plug
 	}
 }
 
+// FDRPlugin returns the crowdstrike-fdr input. It reads the notifications
+// CrowdStrike Falcon Data Replicator sends to its SQS queue and the S3 objects
+// they list, checkpointing the progress within each object.
+func FDRPlugin(logger *logp.Logger, store statestore.States, p *paths.Path) v2.Plugin {
+	return v2.Plugin{
+		Name:       fdrInputName,
+		Stability:  feature.Beta,
+		Deprecated: false,
+		Info:       "Collect CrowdStrike Falcon Data Replicator logs from S3 via SQS",
+		Manager:    &s3InputManager{store: store, logger: logger, path: p, fdr: true},
+	}
+}
+
 type s3InputManager struct {
 	store  statestore.States
 	logger *logp.Logger
 	path   *paths.Path
+	fdr    bool
 }
 
 func (im *s3InputManager) Init(grp unison.Group) error {
@@ -56,8 +70,19 @@ func (im *s3InputManager) Create(cfg *conf.C) (v2.Input, error) {
 		awsConfig.Region = config.RegionName
 	}
 
+	if im.fdr {
+		if config.QueueURL == "" {
+			return nil, fmt.Errorf("%s input requires queue_url", fdrInputName)
+		}
+		return newFDRReaderInput(config, awsConfig, im.store, im.path), nil
+	}
+
 	if config.QueueURL != "" {
-		return newSQSReaderInput(config, awsConfig, im.path), nil
+		in := newSQSReaderInput(config, awsConfig, im.path)
+		if config.SQSCheckpointObjects {
+			in.store = im.store
+		}
+		return in, nil
 	}
 
 	if config.BucketARN != "" || config.AccessPointARN != "" || config.NonAWSBucketName != "" {
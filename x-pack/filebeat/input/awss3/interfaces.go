@@ -254,6 +254,24 @@ func (a *awsS3API) GetObject(ctx context.Context, region, bucket, key string) (*
 	return getObjectOutput, nil
 }
 
+// GetObjectRange gets an S3 object from offset, if its ETag still matches
+// etag.
+func (a *awsS3API) GetObjectRange(ctx context.Context, region, bucket, key, etag string, offset int64) (*s3.GetObjectOutput, error) {
+	in := &s3.GetObjectInput{
+		Bucket: awssdk.String(bucket),
+		Key:    awssdk.String(key),
+		Range:  awssdk.String(fmt.Sprintf("bytes=%d-", offset)),
+	}
+	if etag != "" {
+		in.IfMatch = awssdk.String(etag)
+	}
+	getObjectOutput, err := a.clientFor(region).GetObject(ctx, in)
+	if err != nil {
+		return nil, fmt.Errorf("s3 GetObject from offset %d failed: %w", offset, err)
+	}
+	return getObjectOutput, nil
+}
+
 func (a *awsS3API) CopyObject(ctx context.Context, region, from_bucket, to_bucket, from_key, to_key string) (*s3.CopyObjectOutput, error) {
 	copyObjectOutput, err := a.clientFor(region).CopyObject(ctx, &s3.CopyObjectInput{
 		Bucket:     awssdk.String(to_bucket),
@@ -75,6 +75,9 @@ type inputMetrics struct {
 	s3PollingRunTime        metrics.Sample   // Histogram of the elapsed time for each S3 polling run in nanoseconds.
 	s3PollingRunTimeTotal   *monitoring.Uint // Cumulative time spent in S3 polling runs in nanoseconds.
 	s3ObjectsListedPerRun   metrics.Sample   // Histogram of the number of S3 objects listed in each polling run.
+
+	coordinationPartitionsOwned *monitoring.Uint // Number of partitions of the object keys whose lease is held by the instance.
+	coordinationErrorsTotal     *monitoring.Uint // Number of failed requests to the coordination lease store.
 }
 
 // Close cancels the context and removes the metrics from the registry.
@@ -180,6 +183,8 @@ func newInputMetrics(reg *monitoring.Registry, maxWorkers int, logger *logp.Logg
 		s3PollingRunTime:                    metrics.NewUniformSample(1024),
 		s3PollingRunTimeTotal:               monitoring.NewUint(reg, "s3_polling_run_time_total"),
 		s3ObjectsListedPerRun:               metrics.NewUniformSample(1024),
+		coordinationPartitionsOwned:         monitoring.NewUint(reg, "coordination_partitions_owned"),
+		coordinationErrorsTotal:             monitoring.NewUint(reg, "coordination_errors_total"),
 	}
 
 	// Initializing the sqs_messages_waiting_gauge value to -1 so that we can distinguish between no messages waiting (0) and never collected / error collecting (-1).
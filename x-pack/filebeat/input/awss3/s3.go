@@ -6,13 +6,17 @@ package awss3
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
 )
 
 func (in *s3PollerInput) createS3API(ctx context.Context) (*awsS3API, error) {
@@ -42,6 +46,28 @@ func (in *s3PollerInput) createS3API(ctx context.Context) (*awsS3API, error) {
 	return newAWSs3API(s3Client, in.log), nil
 }
 
+// checkBucket checks that bucket exists and can be accessed by client.
+func checkBucket(ctx context.Context, client *s3.Client, bucket string) error {
+	_, err := client.HeadBucket(ctx, &s3.HeadBucketInput{
+		Bucket: awssdk.String(bucket),
+	})
+	if err != nil {
+		var respErr *smithyhttp.ResponseError
+		if errors.As(err, &respErr) {
+			// HeadBucket responses have no body, so the error
+			// code must be derived from the status code.
+			switch respErr.HTTPStatusCode() {
+			case http.StatusNotFound:
+				return fmt.Errorf("bucket %q does not exist (hint: check bucket_arn, access_point_arn or non_aws_bucket_name)", bucket)
+			case http.StatusForbidden:
+				return fmt.Errorf("access to bucket %q is denied (hint: grant the s3:ListBucket permission on the bucket to the configured credentials)", bucket)
+			}
+		}
+		return awscommon.WithHint(fmt.Errorf("failed to access bucket %q: %w", bucket, err))
+	}
+	return nil
+}
+
 func createPipelineClient(pipeline beat.Pipeline, acks *awsACKHandler) (beat.Client, error) {
 	return pipeline.ConnectWith(beat.ClientConfig{
 		EventListener: acks.pipelineEventListener(),
@@ -0,0 +1,153 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awss3
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/leases"
+)
+
+// partitionKey returns the lease key of the partition of an object key.
+func partitionKey(objectKey string, partitions int) string {
+	h := fnv.New32a()
+	h.Write([]byte(objectKey))
+	return "partition-" + strconv.FormatUint(uint64(h.Sum32()%uint32(partitions)), 10) //nolint:gosec // partitions is validated to be positive
+}
+
+// partitionCoordinator partitions the object keys of the polled bucket
+// between the instances running the input, with a lease per partition. The
+// checkpoint of a partition is the LastModified time, in Unix milliseconds,
+// up to which all its listed objects were processed.
+type partitionCoordinator struct {
+	*leases.Coordinator
+	partitions int
+
+	// checkpoints maps the held partitions acquired from another instance
+	// to the checkpoint of their previous owner. The objects last modified
+	// before are not processed again.
+	checkpoints map[string]int64
+}
+
+func newPartitionCoordinator(c *leases.Coordinator, partitions int) *partitionCoordinator {
+	return &partitionCoordinator{
+		Coordinator: c,
+		partitions:  partitions,
+		checkpoints: map[string]int64{},
+	}
+}
+
+// claim acquires the leases of the partitions assigned to the instance, and
+// returns the partitions of the polling run.
+func (c *partitionCoordinator) claim(ctx context.Context) *pollPartitions {
+	keys := make([]string, 0, c.partitions)
+	for i := 0; i < c.partitions; i++ {
+		keys = append(keys, "partition-"+strconv.Itoa(i))
+	}
+	owned, acquired := c.Claim(ctx, keys)
+
+	run := &pollPartitions{
+		partitions:  c.partitions,
+		owned:       make(map[string]bool, len(owned)),
+		checkpoints: map[string]int64{},
+		newest:      map[string]int64{},
+		pending:     map[string]int64{},
+	}
+	for _, key := range owned {
+		run.owned[key] = true
+	}
+	for key, checkpoint := range acquired {
+		if checkpoint != 0 {
+			c.checkpoints[key] = checkpoint
+		} else {
+			delete(c.checkpoints, key)
+		}
+	}
+	for key, checkpoint := range c.checkpoints {
+		if !run.owned[key] {
+			delete(c.checkpoints, key)
+			continue
+		}
+		run.checkpoints[key] = checkpoint
+	}
+	return run
+}
+
+// complete stores the checkpoints of the partitions after a polling run that
+// listed all the objects.
+func (c *partitionCoordinator) complete(run *pollPartitions) {
+	for key := range run.owned {
+		if checkpoint := run.checkpoint(key); checkpoint > 0 {
+			c.SetCheckpoint(key, checkpoint)
+		}
+	}
+}
+
+// pollPartitions tracks the partitions held by the instance during a polling
+// run. A nil *pollPartitions holds all the object keys.
+type pollPartitions struct {
+	partitions int
+	owned      map[string]bool
+	// checkpoints are the checkpoints of the previous owners of the
+	// partitions.
+	checkpoints map[string]int64
+	// newest is the LastModified time of the newest processed object of
+	// each partition.
+	newest map[string]int64
+	// pending is the LastModified time of the oldest object of each
+	// partition sent for processing.
+	pending map[string]int64
+}
+
+// owns returns the partition of s, and whether it is held by the instance.
+func (p *pollPartitions) owns(s state) (string, bool) {
+	if p == nil {
+		return "", true
+	}
+	key := partitionKey(s.Key, p.partitions)
+	return key, p.owned[key]
+}
+
+// checkpointed returns whether s was processed by the previous owner of its
+// partition.
+func (p *pollPartitions) checkpointed(partition string, s state) bool {
+	if p == nil {
+		return false
+	}
+	checkpoint, ok := p.checkpoints[partition]
+	return ok && s.LastModified.UnixMilli() <= checkpoint
+}
+
+// processed records that s was already processed.
+func (p *pollPartitions) processed(partition string, s state) {
+	if p == nil {
+		return
+	}
+	if t := s.LastModified.UnixMilli(); t > p.newest[partition] {
+		p.newest[partition] = t
+	}
+}
+
+// sent records that s was sent for processing.
+func (p *pollPartitions) sent(partition string, s state) {
+	if p == nil {
+		return
+	}
+	if t, ok := p.pending[partition]; !ok || s.LastModified.UnixMilli() < t {
+		p.pending[partition] = s.LastModified.UnixMilli()
+	}
+}
+
+// checkpoint returns the checkpoint of a partition: the LastModified time
+// before its oldest object sent for processing, or of its newest processed
+// object if none was sent.
+func (p *pollPartitions) checkpoint(partition string) int64 {
+	if t, ok := p.pending[partition]; ok {
+		return t - 1
+	}
+	return p.newest[partition]
+}
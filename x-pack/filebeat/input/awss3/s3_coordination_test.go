@@ -0,0 +1,92 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awss3
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/leases"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// checkpointStore is a lease store of a single instance, holding the
+// checkpoints of the leases.
+type checkpointStore struct {
+	checkpoints map[string]int64
+}
+
+func (s *checkpointStore) Heartbeat(context.Context, string, time.Time) error { return nil }
+
+func (s *checkpointStore) Owners(context.Context, time.Time) ([]string, error) { return nil, nil }
+
+func (s *checkpointStore) Acquire(_ context.Context, key, _ string, _, _ time.Time) (int64, bool, error) {
+	return s.checkpoints[key], true, nil
+}
+
+func (s *checkpointStore) Renew(context.Context, string, string, time.Time, int64) (bool, error) {
+	return true, nil
+}
+
+func (s *checkpointStore) Release(context.Context, string, string, int64) error { return nil }
+
+func (s *checkpointStore) Leave(context.Context, string) error { return nil }
+
+func TestPartitionKey(t *testing.T) {
+	counts := map[string]int{}
+	for i := 0; i < 1000; i++ {
+		key := partitionKey(fmt.Sprintf("logs/2024/01/01/object-%d.json.gz", i), 4)
+		assert.Equal(t, key, partitionKey(fmt.Sprintf("logs/2024/01/01/object-%d.json.gz", i), 4))
+		counts[key]++
+	}
+	require.Len(t, counts, 4)
+	for key, n := range counts {
+		assert.Greater(t, n, 150, "the object keys are spread across the partitions, %s", key)
+	}
+}
+
+func TestPartitionCoordinator(t *testing.T) {
+	obj := func(key string, lastModified int64) state {
+		return newState("bucket", key, "etag", time.UnixMilli(lastModified))
+	}
+	partition := partitionKey("a.log", 2)
+	store := &checkpointStore{checkpoints: map[string]int64{partition: 5000}}
+	reg := monitoring.NewRegistry()
+	c := newPartitionCoordinator(leases.NewCoordinator(leases.Config{OwnerID: "instance-a", LeaseDuration: time.Minute}, store, leases.Metrics{
+		Owned:  monitoring.NewUint(reg, "owned"),
+		Errors: monitoring.NewUint(reg, "errors"),
+	}, logp.NewLogger("test")), 2)
+
+	run := c.claim(context.Background())
+	assert.Len(t, run.owned, 2, "the only instance holds all the partitions")
+
+	p, owned := run.owns(obj("a.log", 4000))
+	require.True(t, owned)
+	assert.Equal(t, partition, p)
+	assert.True(t, run.checkpointed(p, obj("a.log", 4000)), "the objects processed by the previous owner are skipped")
+	assert.False(t, run.checkpointed(p, obj("a.log", 6000)))
+
+	// The checkpoint of the previous owner is kept until the lease is
+	// lost.
+	run = c.claim(context.Background())
+	assert.True(t, run.checkpointed(p, obj("a.log", 4000)))
+
+	run.processed(p, obj("a.log", 7000))
+	assert.Equal(t, int64(7000), run.checkpoint(p))
+	run.sent(p, obj("b.log", 9000))
+	run.sent(p, obj("c.log", 8000))
+	assert.Equal(t, int64(7999), run.checkpoint(p), "the checkpoint is before the oldest object being processed")
+
+	var all *pollPartitions
+	_, owned = all.owns(obj("a.log", 0))
+	assert.True(t, owned, "all the object keys are processed without coordination")
+	assert.False(t, all.checkpointed("", obj("a.log", 0)))
+}
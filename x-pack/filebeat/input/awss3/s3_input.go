@@ -21,6 +21,9 @@ import (
 	"github.com/elastic/beats/v7/libbeat/common/backoff"
 	"github.com/elastic/beats/v7/libbeat/management/status"
 	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/leases"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
 	"github.com/elastic/beats/v7/x-pack/libbeat/statusreporterhelper"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/go-concert/timed"
@@ -44,6 +47,10 @@ type s3PollerInput struct {
 	filterProvider  *filterProvider
 	strategy        pollingStrategy
 
+	// coordinator partitions the object keys between the instances running
+	// the input, nil if coordination.enabled is not set.
+	coordinator *partitionCoordinator
+
 	// health status reporting
 	status status.StatusReporter
 }
@@ -63,8 +70,24 @@ func newS3PollerInput(
 
 func (in *s3PollerInput) Name() string { return inputName }
 
+// Test checks that the configured credentials are valid and that the
+// bucket of the input can be accessed with them.
 func (in *s3PollerInput) Test(ctx v2.TestContext) error {
-	return nil
+	stdCtx := v2.GoContextFromCanceler(ctx.Cancelation)
+	if in.config.NonAWSBucketName == "" {
+		// STS is not available from S3 compatible services.
+		if _, err := awscommon.CheckCredentials(stdCtx, in.awsConfig); err != nil {
+			return err
+		}
+	}
+	if in.log == nil {
+		in.log = ctx.Logger.Named("s3")
+	}
+	api, err := in.createS3API(stdCtx)
+	if err != nil {
+		return awscommon.WithHint(fmt.Errorf("failed to get the region of bucket %q: %w", in.config.getBucketName(), err))
+	}
+	return checkBucket(stdCtx, api.client, in.config.getBucketName())
 }
 
 func (in *s3PollerInput) Run(
@@ -101,13 +124,31 @@ func (in *s3PollerInput) Run(
 	in.metrics = newInputMetrics(inputContext.MetricsRegistry, in.config.NumberOfWorkers, in.log)
 	defer in.metrics.Close()
 
-	in.s3ObjectHandler = newS3ObjectProcessorFactory(
+	if in.config.Coordination.Enabled {
+		// The leases are shared by the inputs polling the same objects.
+		prefix := in.config.getBucketARN() + "::" + in.config.BucketListPrefix
+		store, err := leases.NewStore(in.config.Coordination, in.config.AWSConfig, in.awsConfig.Region, prefix, in.log)
+		if err != nil {
+			err = fmt.Errorf("failed to create coordination client: %w", err)
+			in.status.UpdateStatus(status.Failed, fmt.Sprintf("Setup failure: %s", err.Error()))
+			return err
+		}
+		in.coordinator = newPartitionCoordinator(leases.NewCoordinator(in.config.Coordination, store, leases.Metrics{
+			Owned:  in.metrics.coordinationPartitionsOwned,
+			Errors: in.metrics.coordinationErrorsTotal,
+		}, in.log.Named("coordination")), in.config.Coordination.Partitions)
+		go in.coordinator.Run(ctx)
+	}
+
+	s3ObjectHandler := newS3ObjectProcessorFactory(
 		in.metrics,
 		in.s3,
 		in.config.getFileSelectors(),
 		in.config.BackupConfig,
 		in.log,
 	)
+	s3ObjectHandler.downloads = download.NewManager(in.config.Download, in.metrics.registry, in.log.Named("download"))
+	in.s3ObjectHandler = s3ObjectHandler
 
 	in.strategy = newPollingStrategy(in.config.LexicographicalOrdering, in.log)
 
@@ -146,8 +187,15 @@ func (in *s3PollerInput) runPoll(ctx context.Context) {
 		}()
 	}
 
+	// Only the partitions of the object keys held by the instance are
+	// processed when coordination is enabled.
+	var partitions *pollPartitions
+	if in.coordinator != nil {
+		partitions = in.coordinator.claim(ctx)
+	}
+
 	// Start reading data and wait for its processing to be done
-	ids, numObjectsListed, ok := in.readerLoop(ctx, workChan)
+	ids, numObjectsListed, ok := in.readerLoop(ctx, workChan, partitions)
 	workerWg.Wait()
 
 	in.metrics.s3ObjectsListedPerRun.Update(int64(numObjectsListed))
@@ -156,6 +204,9 @@ func (in *s3PollerInput) runPoll(ctx context.Context) {
 		in.log.Warn("skipping state registry cleanup as object reading ended with a non-ok return")
 		return
 	}
+	if in.coordinator != nil {
+		in.coordinator.complete(partitions)
+	}
 
 	// Perform state cleanup operation
 	err := in.registry.CleanUp(ids)
@@ -254,7 +305,7 @@ func (in *s3PollerInput) workerLoop(ctx context.Context, workChan <-chan state)
 // readerLoop performs the S3 object listing and emit state to work listeners if object needs to be processed.
 // Returns all tracked state IDs correlates to all tracked S3 objects iff listing is successful.
 // These IDs are intended to be used for state clean-up.
-func (in *s3PollerInput) readerLoop(ctx context.Context, workChan chan<- state) (knownStateIDSlice []string, numObjectsListed int, ok bool) {
+func (in *s3PollerInput) readerLoop(ctx context.Context, workChan chan<- state, partitions *pollPartitions) (knownStateIDSlice []string, numObjectsListed int, ok bool) {
 	defer close(workChan)
 	bucketName := getBucketNameFromARN(in.config.getBucketARN())
 
@@ -302,6 +353,11 @@ func (in *s3PollerInput) readerLoop(ctx context.Context, workChan chan<- state)
 				continue
 			}
 
+			partition, owned := partitions.owns(state)
+			if !owned {
+				continue
+			}
+
 			id := in.strategy.GetStateID(state)
 
 			// Add to known states for cleanup tracking
@@ -309,9 +365,16 @@ func (in *s3PollerInput) readerLoop(ctx context.Context, workChan chan<- state)
 
 			if in.registry.IsProcessed(id) {
 				in.log.Debugw("skipping state processing as already processed.", "state", state)
+				partitions.processed(partition, state)
+				continue
+			}
+			if partitions.checkpointed(partition, state) {
+				in.log.Debugw("skipping state processing as processed by the previous owner of its partition.", "state", state)
+				partitions.processed(partition, state)
 				continue
 			}
 
+			partitions.sent(partition, state)
 			workChan <- state
 
 			in.metrics.s3ObjectsProcessedTotal.Inc()
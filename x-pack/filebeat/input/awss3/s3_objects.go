@@ -17,12 +17,14 @@ import (
 	"strings"
 	"time"
 
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/reader"
 	"github.com/elastic/beats/v7/libbeat/reader/readfile"
 	"github.com/elastic/beats/v7/libbeat/reader/readfile/encoding"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
 	x_reader "github.com/elastic/beats/v7/x-pack/libbeat/reader"
 	"github.com/elastic/beats/v7/x-pack/libbeat/reader/decoder"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -35,6 +37,13 @@ type s3ObjectProcessorFactory struct {
 	s3            s3API
 	fileSelectors []fileSelectorConfig
 	backupConfig  backupConfig
+	downloads     *download.Manager // Resumes, limits and prefetches the object downloads, if set.
+}
+
+// s3RangeGetter is implemented by the S3 APIs able to get an object from an
+// offset, to resume its interrupted downloads.
+type s3RangeGetter interface {
+	GetObjectRange(ctx context.Context, region, bucket, key, etag string, offset int64) (*s3.GetObjectOutput, error)
 }
 
 type s3ObjectProcessor struct {
@@ -248,8 +257,13 @@ func (p *s3ObjectProcessor) download() (obj *s3DownloadedObject, err error) {
 		ctType = *getObjectOutput.ContentType
 	}
 
+	body, err := p.downloads.Wrap(p.ctx, getObjectOutput.Body, p.resumeDownload(awssdk.ToString(getObjectOutput.ETag)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to download s3 object: %w", err)
+	}
+
 	s := &s3DownloadedObject{
-		body:        getObjectOutput.Body,
+		body:        body,
 		contentType: ctType,
 		metadata:    meta,
 	}
@@ -257,6 +271,22 @@ func (p *s3ObjectProcessor) download() (obj *s3DownloadedObject, err error) {
 	return s, nil
 }
 
+// resumeDownload returns the download.OpenFunc resuming the download of the
+// version etag of the S3 object.
+func (p *s3ObjectProcessor) resumeDownload(etag string) download.OpenFunc {
+	return func(ctx context.Context, offset int64) (io.ReadCloser, error) {
+		rg, ok := p.s3.(s3RangeGetter)
+		if !ok {
+			return nil, errors.New("resuming s3 object downloads is not supported")
+		}
+		out, err := rg.GetObjectRange(ctx, p.s3Obj.AWSRegion, p.s3Obj.S3.Bucket.Name, p.s3Obj.S3.Object.Key, etag, offset)
+		if err != nil {
+			return nil, err
+		}
+		return out.Body, nil
+	}
+}
+
 func (p *s3ObjectProcessor) readJSON(r io.Reader) error {
 	dec := json.NewDecoder(r)
 	dec.UseNumber()
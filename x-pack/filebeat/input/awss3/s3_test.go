@@ -6,6 +6,8 @@ package awss3
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
@@ -14,6 +16,7 @@ import (
 	"github.com/elastic/elastic-agent-libs/monitoring"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 	"github.com/stretchr/testify/require"
@@ -1041,3 +1044,30 @@ func TestCreateS3API(t *testing.T) {
 		require.Equal(t, "eu-west-1", input.awsConfig.Region)
 	})
 }
+
+func TestCheckBucket(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/bucket":
+			w.WriteHeader(http.StatusOK)
+		case "/denied":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	client := s3.New(s3.Options{
+		Region:       "us-east-1",
+		Credentials:  credentials.NewStaticCredentialsProvider("id", "secret", ""),
+		BaseEndpoint: aws.String(srv.URL),
+		UsePathStyle: true,
+		Retryer:      aws.NopRetryer{},
+	})
+	ctx := context.Background()
+
+	require.NoError(t, checkBucket(ctx, client, "bucket"))
+	require.ErrorContains(t, checkBucket(ctx, client, "missing"), `bucket "missing" does not exist`)
+	require.ErrorContains(t, checkBucket(ctx, client, "denied"), "grant the s3:ListBucket permission")
+}
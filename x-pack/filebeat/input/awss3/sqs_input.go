@@ -21,6 +21,9 @@ import (
 	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
 	"github.com/elastic/beats/v7/x-pack/libbeat/statusreporterhelper"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/paths"
@@ -54,6 +57,15 @@ type sqsReaderInput struct {
 	status status.StatusReporter
 
 	path *paths.Path
+
+	// fdr is set when the input reads CrowdStrike Falcon Data Replicator
+	// notifications.
+	fdr bool
+
+	// store is set when the progress of the objects is checkpointed, which
+	// is always the case for CrowdStrike Falcon Data Replicator shards.
+	store       statestore.States
+	checkpoints *objectCheckpoints
 }
 
 // Simple wrapper to handle creation of internal channels
@@ -67,12 +79,64 @@ func newSQSReaderInput(config config, awsConfig awssdk.Config, p *paths.Path) *s
 	}
 }
 
-func (in *sqsReaderInput) Name() string { return inputName }
+// newFDRReaderInput creates an SQS reader for CrowdStrike Falcon Data Replicator
+// notifications.
+func newFDRReaderInput(config config, awsConfig awssdk.Config, store statestore.States, p *paths.Path) *sqsReaderInput {
+	in := newSQSReaderInput(config, awsConfig, p)
+	in.fdr = true
+	in.store = store
+	return in
+}
+
+func (in *sqsReaderInput) Name() string {
+	if in.fdr {
+		return fdrInputName
+	}
+	return inputName
+}
 
+// Test checks that the configured credentials are valid and that the
+// queue of the input can be accessed with them.
 func (in *sqsReaderInput) Test(ctx v2.TestContext) error {
+	stdCtx := v2.GoContextFromCanceler(ctx.Cancelation)
+	if _, err := awscommon.CheckCredentials(stdCtx, in.awsConfig); err != nil {
+		return err
+	}
+
+	awsConfig := in.awsConfig.Copy()
+	region, err := in.region()
+	if err != nil {
+		return err
+	}
+	awsConfig.Region = region
+	_, err = sqs.NewFromConfig(awsConfig, in.config.sqsConfigModifier).GetQueueAttributes(stdCtx, &sqs.GetQueueAttributesInput{
+		QueueUrl:       awssdk.String(in.config.QueueURL),
+		AttributeNames: []types.QueueAttributeName{types.QueueAttributeNameApproximateNumberOfMessages},
+	})
+	if err != nil {
+		return awscommon.WithHint(fmt.Errorf("failed to access queue %q: %w", in.config.QueueURL, err))
+	}
 	return nil
 }
 
+// region returns the region of the queue of the input.
+func (in *sqsReaderInput) region() (string, error) {
+	if in.config.RegionName != "" {
+		// Configured region always takes precedence
+		return in.config.RegionName, nil
+	}
+	if region := getRegionFromQueueURL(in.config.QueueURL); region != "" {
+		// Only use detected region if there is no explicit region configured.
+		return region, nil
+	}
+	if in.config.AWSConfig.DefaultRegion != "" {
+		// If we can't find anything else, fall back on the default.
+		return in.config.AWSConfig.DefaultRegion, nil
+	}
+	// If we can't find a usable region, return an error
+	return "", fmt.Errorf("region not specified and failed to get AWS region from queue_url: %w", errBadQueueURL)
+}
+
 func (in *sqsReaderInput) Run(
 	inputContext v2.Context,
 	pipeline beat.Pipeline,
@@ -108,19 +172,11 @@ func (in *sqsReaderInput) setup(
 
 	in.status.UpdateStatus(status.Configuring, "Configuring input")
 	in.detectedRegion = getRegionFromQueueURL(in.config.QueueURL)
-	if in.config.RegionName != "" {
-		// Configured region always takes precedence
-		in.awsConfig.Region = in.config.RegionName
-	} else if in.detectedRegion != "" {
-		// Only use detected region if there is no explicit region configured.
-		in.awsConfig.Region = in.detectedRegion
-	} else if in.config.AWSConfig.DefaultRegion != "" {
-		// If we can't find anything else, fall back on the default.
-		in.awsConfig.Region = in.config.AWSConfig.DefaultRegion
-	} else {
-		// If we can't find a usable region, return an error
-		return fmt.Errorf("region not specified and failed to get AWS region from queue_url: %w", errBadQueueURL)
+	region, err := in.region()
+	if err != nil {
+		return err
 	}
+	in.awsConfig.Region = region
 
 	in.sqs = &awsSQSAPI{
 		client: sqs.NewFromConfig(in.awsConfig, in.config.sqsConfigModifier),
@@ -135,7 +191,6 @@ func (in *sqsReaderInput) setup(
 
 	in.metrics = newInputMetrics(inputContext.MetricsRegistry, in.config.NumberOfWorkers, logp.NewNopLogger())
 
-	var err error
 	in.msgHandler, err = in.createEventProcessor()
 	if err != nil {
 		return fmt.Errorf("failed to initialize sqs reader: %w", err)
@@ -149,6 +204,9 @@ func (in *sqsReaderInput) cleanup() {
 	if in.metrics != nil {
 		in.metrics.Close()
 	}
+	if in.checkpoints != nil {
+		in.checkpoints.Close()
+	}
 }
 
 // Create the main goroutines for the input (workers, message count monitor)
@@ -309,6 +367,12 @@ func (w *sqsWorker) processMessage(ctx context.Context, msg types.Message) {
 		result.Done()
 	} else {
 		w.pending.Add(1)
+		// Object checkpoints are committed as the events before them are
+		// acknowledged, ahead of the message itself.
+		for _, c := range result.checkpoints {
+			w.ackHandler.Add(c.eventCount, c.commit)
+			publishCount -= c.eventCount
+		}
 		// Add this result's Done callback to the pending ACKs list
 		w.ackHandler.Add(publishCount, func() {
 			result.Done()
@@ -360,14 +424,37 @@ func (in *sqsReaderInput) logConfigSummary() {
 func (in *sqsReaderInput) createEventProcessor() (sqsProcessor, error) {
 	fileSelectors := in.config.getFileSelectors()
 	s3EventHandlerFactory := newS3ObjectProcessorFactory(in.metrics, in.s3, fileSelectors, in.config.BackupConfig, in.log)
+	s3EventHandlerFactory.downloads = download.NewManager(in.config.Download, in.metrics.registry, in.log.Named("download"))
 
 	script, err := newScriptFromConfig(in.log.Named("sqs_script"), in.config.SQSScript, in.path)
 	if err != nil {
 		return nil, err
 	}
-	return newSQSS3EventProcessor(in.log.Named("sqs_s3_event"), in.metrics,
+	p := newSQSS3EventProcessor(in.log.Named("sqs_s3_event"), in.metrics,
 		in.sqs, script, in.config.VisibilityTimeout,
-		in.config.SQSMaxReceiveCount, s3EventHandlerFactory, in.status), nil
+		in.config.SQSMaxReceiveCount, s3EventHandlerFactory, in.status)
+
+	if in.fdr {
+		p.fdr = true
+		p.fdrRegion = in.awsConfig.Region
+	}
+	if in.store != nil {
+		store, err := in.store.StoreFor("")
+		if err != nil {
+			return nil, fmt.Errorf("can't access persistent store: %w", err)
+		}
+		keyPrefix, ttl := sqsCheckpointKeyPrefix, sqsCheckpointTTL
+		if in.fdr {
+			keyPrefix, ttl = fdrCheckpointKeyPrefix, fdrCheckpointTTL
+		}
+		in.checkpoints, err = newObjectCheckpoints(in.log.Named("checkpoints"), store, keyPrefix, ttl, time.Now())
+		if err != nil {
+			_ = store.Close()
+			return nil, err
+		}
+		p.checkpoints = in.checkpoints
+	}
+	return p, nil
 }
 
 // Read all pending requests and return their count. If block is true,
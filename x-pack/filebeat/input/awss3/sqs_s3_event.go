@@ -82,6 +82,7 @@ type s3EventV2 struct {
 		Object struct {
 			Key          string    `json:"key"`
 			LastModified time.Time `json:"lastModified"`
+			Sequencer    string    `json:"sequencer"`
 		} `json:"object"`
 	} `json:"s3"`
 }
@@ -129,6 +130,15 @@ type sqsS3EventProcessor struct {
 	metrics              *inputMetrics
 	script               *script
 	status               status.StatusReporter
+
+	// fdr is set when the queue receives CrowdStrike Falcon Data Replicator
+	// notifications instead of S3 notifications.
+	fdr       bool
+	fdrRegion string
+
+	// checkpoints is set when the progress of the objects is checkpointed
+	// so a redelivered message resumes after the acknowledged events.
+	checkpoints *objectCheckpoints
 }
 
 func newSQSS3EventProcessor(
@@ -165,6 +175,10 @@ type sqsProcessingResult struct {
 	keepaliveCancel context.CancelFunc
 	processingErr   error
 
+	// Object checkpoints to commit as the events of the message are
+	// acknowledged, in publication order.
+	checkpoints []pendingCheckpoint
+
 	// Finalizer callbacks for the returned S3 events, invoked via
 	// finalizeS3Objects after all events are acknowledged.
 	finalizers []finalizerFunc
@@ -200,12 +214,23 @@ func (p *sqsS3EventProcessor) ProcessSQS(ctx context.Context, msg *types.Message
 		}
 	}
 
+	var batch *checkpointBatch
+	if p.checkpoints != nil {
+		batch = p.checkpoints.newBatch()
+	}
+
 	eventCount := 0
-	finalizers, processingErr := p.processS3Events(ctx, log, *msg.Body, func(e beat.Event) {
+	finalizers, processingErr := p.processS3Events(ctx, log, *msg.Body, batch, func(e beat.Event) {
 		eventCount++
 		eventCallback(e)
 	})
 
+	var checkpoints []pendingCheckpoint
+	if batch != nil {
+		checkpoints = batch.pending
+		finalizers = append(finalizers, batch.finalizers()...)
+	}
+
 	return sqsProcessingResult{
 		msg:             msg,
 		processor:       p,
@@ -213,6 +238,7 @@ func (p *sqsS3EventProcessor) ProcessSQS(ctx context.Context, msg *types.Message
 		eventCount:      eventCount,
 		keepaliveCancel: keepaliveCancel,
 		processingErr:   processingErr,
+		checkpoints:     checkpoints,
 		finalizers:      finalizers,
 	}
 }
@@ -311,6 +337,10 @@ func (p *sqsS3EventProcessor) keepalive(ctx context.Context, log *logp.Logger, m
 }
 
 func (p *sqsS3EventProcessor) getS3Notifications(body string) ([]s3EventV2, error) {
+	if p.fdr {
+		return parseFDRNotification(body, p.fdrRegion)
+	}
+
 	// Check if a parsing script is defined. If so, it takes precedence over
 	// format autodetection.
 	if p.script != nil {
@@ -384,6 +414,7 @@ func convertEventBridgeEvent(resource string, message *eventBridgeEvent) s3Event
 	}
 	event.SetS3BucketName(message.Detail.Bucket.Name)
 	event.SetS3ObjectKey(message.Detail.Object.Key)
+	event.S3.Object.Sequencer = message.Detail.Object.Sequencer
 	return event
 }
 
@@ -421,6 +452,7 @@ func (p *sqsS3EventProcessor) processS3Events(
 	ctx context.Context,
 	log *logp.Logger,
 	body string,
+	batch *checkpointBatch,
 	eventCallback func(beat.Event),
 ) ([]finalizerFunc, error) {
 	s3Events, err := p.getS3Notifications(body)
@@ -448,8 +480,15 @@ func (p *sqsS3EventProcessor) processS3Events(
 			continue
 		}
 
+		callback, objectDone := eventCallback, func() {}
+		if batch != nil {
+			callback, objectDone = batch.object(log, event, eventCallback)
+		}
+
 		// Process S3 object (download, parse, create events).
-		if err := s3Processor.ProcessS3Object(log, eventCallback); err != nil {
+		err := s3Processor.ProcessS3Object(log, callback)
+		objectDone()
+		if err != nil {
 			err = fmt.Errorf(
 				"failed processing S3 event for object key %q in bucket %q (object record %d of %d in SQS notification): %w",
 				event.S3.Object.Key, event.S3.Bucket.Name, i+1, len(s3Events), err)
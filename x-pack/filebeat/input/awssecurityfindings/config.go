@@ -0,0 +1,93 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awssecurityfindings
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+)
+
+type config struct {
+	// Regions are the regions of the service to collect the findings of.
+	// Each region is queried and checkpointed independently.
+	Regions []string `config:"regions" validate:"required"`
+
+	Interval        time.Duration `config:"interval" validate:"positive"`
+	InitialInterval time.Duration `config:"initial_interval" validate:"positive"`
+	// Overlap is how far before the end of the previous query the findings
+	// are queried again, for the findings that are indexed by the service
+	// after their update time.
+	Overlap  time.Duration `config:"overlap" validate:"min=0"`
+	PageSize int           `config:"page_size" validate:"positive"`
+
+	// Filters are the filters of the findings in the syntax of the API
+	// of the service. The input adds its filter on the update time.
+	Filters map[string]interface{} `config:"filters"`
+
+	// DetectorIDs are the GuardDuty detectors to collect the findings of,
+	// all the detectors of the region if empty.
+	DetectorIDs []string `config:"detector_ids"`
+
+	AWSConfig awscommon.ConfigAWS `config:",inline"`
+}
+
+func defaultConfig() config {
+	return config{
+		Interval:        5 * time.Minute,
+		InitialInterval: 24 * time.Hour,
+		Overlap:         5 * time.Minute,
+	}
+}
+
+func (c *config) validate(svc *service) error {
+	if c.PageSize == 0 {
+		c.PageSize = svc.maxPageSize
+	}
+	if c.PageSize > svc.maxPageSize {
+		return fmt.Errorf("page_size must not be greater than %d for %s", svc.maxPageSize, svc.inputName)
+	}
+	if _, ok := c.Filters[svc.updatedAtFilter]; ok {
+		return fmt.Errorf("filters must not contain %s, it is set by the input", svc.updatedAtFilter)
+	}
+	if err := svc.checkFilters(c.Filters); err != nil {
+		return err
+	}
+	if len(c.DetectorIDs) != 0 && svc.inputName != guardDuty.inputName {
+		return errors.New("detector_ids can only be used with the aws-guardduty input")
+	}
+	return nil
+}
+
+// endpointURL returns the base URL of the API of svc in region. The
+// endpoint option is either the domain of the endpoints, or the URL of
+// the API, for example of a VPC endpoint.
+func (c *config) endpointURL(svc *service, region string) string {
+	if strings.Contains(c.AWSConfig.Endpoint, "://") {
+		return strings.TrimSuffix(c.AWSConfig.Endpoint, "/")
+	}
+	domain := "amazonaws.com"
+	if c.AWSConfig.Endpoint != "" {
+		domain = c.AWSConfig.Endpoint
+	}
+	prefix := svc.endpointPrefix
+	if c.AWSConfig.FIPSEnabled {
+		prefix += "-fips"
+	}
+	return "https://" + prefix + "." + region + "." + domain
+}
+
+// baseEndpoint returns the endpoint of the client of svc in region, nil to
+// use the endpoint resolved by the SDK if the endpoint option is not set.
+func (c *config) baseEndpoint(svc *service, region string) *string {
+	if c.AWSConfig.Endpoint == "" {
+		return nil
+	}
+	u := c.endpointURL(svc, region)
+	return &u
+}
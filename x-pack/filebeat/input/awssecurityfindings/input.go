@@ -0,0 +1,272 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awssecurityfindings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/go-concert/ctxtool"
+	"github.com/elastic/go-concert/timed"
+)
+
+// SecurityHubPlugin returns the input collecting the findings of the
+// Security Hub GetFindings API.
+func SecurityHubPlugin(log *logp.Logger, store statestore.States) v2.Plugin {
+	return plugin(securityHub, log, store,
+		"Collect AWS Security Hub findings",
+		"Collect the findings of the AWS Security Hub API")
+}
+
+// GuardDutyPlugin returns the input collecting the findings of the GuardDuty
+// detectors.
+func GuardDutyPlugin(log *logp.Logger, store statestore.States) v2.Plugin {
+	return plugin(guardDuty, log, store,
+		"Collect AWS GuardDuty findings",
+		"Collect the findings of the AWS GuardDuty detectors")
+}
+
+// InspectorPlugin returns the input collecting the findings of the Inspector
+// API.
+func InspectorPlugin(log *logp.Logger, store statestore.States) v2.Plugin {
+	return plugin(inspector, log, store,
+		"Collect AWS Inspector findings",
+		"Collect the findings of the AWS Inspector API")
+}
+
+func plugin(svc *service, log *logp.Logger, store statestore.States, info, doc string) v2.Plugin {
+	return v2.Plugin{
+		Name:      svc.inputName,
+		Stability: feature.Beta,
+		Info:      info,
+		Doc:       doc,
+		Manager: &cursor.InputManager{
+			Logger:     log,
+			StateStore: store,
+			Type:       svc.inputName,
+			Configure: func(cfg *conf.C, _ *logp.Logger) ([]cursor.Source, cursor.Input, error) {
+				return configure(svc, cfg)
+			},
+		},
+	}
+}
+
+func configure(svc *service, cfg *conf.C) ([]cursor.Source, cursor.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, nil, fmt.Errorf("reading config: %w", err)
+	}
+	if err := config.validate(svc); err != nil {
+		return nil, nil, err
+	}
+
+	sources := make([]cursor.Source, 0, len(config.Regions))
+	for _, region := range config.Regions {
+		sources = append(sources, &source{url: config.endpointURL(svc, region), region: region})
+	}
+	return sources, &findingsInput{svc: svc, config: config}, nil
+}
+
+// source is a region of the service.
+type source struct {
+	url    string
+	region string
+}
+
+func (s *source) Name() string {
+	return s.url
+}
+
+// checkpoint is the persisted cursor of a region: the findings updated up to
+// Time were published. The findings updated in the overlap before Time are
+// queried again, Findings holds the update time of the published ones so
+// that each update of a finding is published once.
+type checkpoint struct {
+	Time     time.Time            `json:"time" struct:"time"`
+	Findings map[string]time.Time `json:"findings" struct:"findings"`
+}
+
+// add records the update of f, and reports whether it was not published yet.
+func (cp *checkpoint) add(f finding) bool {
+	if t, ok := cp.Findings[f.id]; ok && !f.updatedAt.After(t) {
+		return false
+	}
+	cp.Findings[f.id] = f.updatedAt
+	return true
+}
+
+// advance moves the checkpoint to t, and forgets the findings updated before
+// the overlap, which are not queried again.
+func (cp *checkpoint) advance(t time.Time, overlap time.Duration) {
+	cp.Time = t
+	for id, updatedAt := range cp.Findings {
+		if updatedAt.Before(t.Add(-overlap)) {
+			delete(cp.Findings, id)
+		}
+	}
+}
+
+type findingsInput struct {
+	svc    *service
+	config config
+}
+
+func (inp *findingsInput) Name() string { return inp.svc.inputName }
+
+func (inp *findingsInput) Test(src cursor.Source, ctx v2.TestContext) error {
+	awsConfig, err := awscommon.InitializeAWSConfig(inp.awsConfig(src.(*source).region), ctx.Logger)
+	if err != nil {
+		return err
+	}
+	_, err = awscommon.CheckCredentials(ctxtool.FromCanceller(ctx.Cancelation), awsConfig)
+	return err
+}
+
+func (inp *findingsInput) Run(env v2.Context, src cursor.Source, crsr cursor.Cursor, pub cursor.Publisher) error {
+	env.UpdateStatus(status.Starting, "")
+
+	region := src.(*source).region
+	log := env.Logger.With("region", region)
+	ctx := ctxtool.FromCanceller(env.Cancelation)
+
+	env.UpdateStatus(status.Configuring, "")
+	var cp checkpoint
+	if !crsr.IsNew() {
+		if err := crsr.Unpack(&cp); err != nil {
+			env.UpdateStatus(status.Failed, "failed to read checkpoint: "+err.Error())
+			return fmt.Errorf("failed to read checkpoint: %w", err)
+		}
+	}
+	if cp.Time.IsZero() {
+		cp.Time = time.Now().Add(-inp.config.InitialInterval)
+	}
+	if cp.Findings == nil {
+		cp.Findings = make(map[string]time.Time)
+	}
+
+	awsConfig, err := awscommon.InitializeAWSConfig(inp.awsConfig(region), log)
+	if err != nil {
+		env.UpdateStatus(status.Failed, "failed to configure client: "+err.Error())
+		return err
+	}
+	l := inp.svc.newLister(awsConfig, &inp.config, region)
+
+	log.Infow("Start collecting findings", "checkpoint", cp.Time)
+	env.UpdateStatus(status.Running, "")
+	for {
+		to := time.Now()
+		n, err := inp.collect(ctx, l, region, &cp, to, pub)
+		switch {
+		case errors.Is(err, context.Canceled):
+			return nil
+		case err != nil:
+			// The findings are queried again from the checkpoint on the
+			// next interval, the published ones are not published again.
+			log.Errorw("failed to collect findings", "from", cp.Time, "to", to, "published", n, "error", err)
+			env.UpdateStatus(status.Degraded, awscommon.WithHint(err).Error())
+		default:
+			log.Debugw("collected findings", "from", cp.Time, "to", to, "published", n)
+			env.UpdateStatus(status.Running, "")
+		}
+
+		if err := timed.Wait(env.Cancelation, inp.config.Interval); err != nil {
+			return nil
+		}
+	}
+}
+
+// awsConfig returns the AWS configuration of the input in region.
+func (inp *findingsInput) awsConfig(region string) awscommon.ConfigAWS {
+	cfg := inp.config.AWSConfig
+	cfg.DefaultRegion = region
+	return cfg
+}
+
+// collect queries the findings updated since the checkpoint minus the
+// overlap, and publishes the updates that were not published yet. The last
+// published finding carries the checkpoint moved to the end of the query. It
+// returns the number of published findings.
+func (inp *findingsInput) collect(ctx context.Context, l lister, region string, cp *checkpoint, to time.Time, pub cursor.Publisher) (int, error) {
+	q := query{
+		from:        cp.Time.Add(-inp.config.Overlap),
+		to:          to,
+		filters:     inp.config.Filters,
+		pageSize:    inp.config.PageSize,
+		detectorIDs: inp.config.DetectorIDs,
+	}
+
+	// The last event is held back until the query is complete, so that
+	// it carries the checkpoint.
+	var (
+		n    int
+		last *beat.Event
+	)
+	err := l.list(ctx, q, func(findings []finding) error {
+		for _, f := range findings {
+			if !cp.add(f) {
+				continue
+			}
+			if last != nil {
+				if err := pub.Publish(*last, nil); err != nil {
+					return err
+				}
+				n++
+			}
+			e := newEvent(inp.svc, region, f)
+			last = &e
+		}
+		return nil
+	})
+	if err == nil {
+		cp.advance(to, inp.config.Overlap)
+	}
+	if last != nil {
+		var update any
+		if err == nil {
+			update = checkpoint{Time: cp.Time, Findings: maps.Clone(cp.Findings)}
+		}
+		if err := pub.Publish(*last, update); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, err
+}
+
+// newEvent returns the event of a finding. The finding is kept as the message
+// and its update time is the event time.
+func newEvent(svc *service, region string, f finding) beat.Event {
+	return beat.Event{
+		Timestamp: f.updatedAt,
+		Fields: mapstr.M{
+			"message": string(f.raw),
+			"aws": mapstr.M{
+				svc.fieldsKey: mapstr.M{
+					"finding": mapstr.M{
+						"id":         f.id,
+						"updated_at": f.updatedAt,
+					},
+				},
+			},
+			"cloud": mapstr.M{
+				"provider": "aws",
+				"region":   region,
+			},
+		},
+	}
+}
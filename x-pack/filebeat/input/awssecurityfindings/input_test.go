@@ -0,0 +1,258 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awssecurityfindings
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/guardduty"
+	gdtypes "github.com/aws/aws-sdk-go-v2/service/guardduty/types"
+	"github.com/aws/aws-sdk-go-v2/service/inspector2"
+	i2types "github.com/aws/aws-sdk-go-v2/service/inspector2/types"
+	"github.com/aws/aws-sdk-go-v2/service/securityhub"
+	shtypes "github.com/aws/aws-sdk-go-v2/service/securityhub/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+)
+
+type publishedEvent struct {
+	event  beat.Event
+	update any
+}
+
+type testPublisher struct {
+	events []publishedEvent
+}
+
+func (p *testPublisher) Publish(event beat.Event, update any) error {
+	p.events = append(p.events, publishedEvent{event: event, update: update})
+	return nil
+}
+
+func (p *testPublisher) findingIDs(t *testing.T, svc *service) []string {
+	t.Helper()
+	var ids []string
+	for _, e := range p.events {
+		id, err := e.event.Fields.GetValue("aws." + svc.fieldsKey + ".finding.id")
+		require.NoError(t, err)
+		ids = append(ids, id.(string))
+	}
+	return ids
+}
+
+type fakeSecurityHub struct {
+	getFindings func(*securityhub.GetFindingsInput) (*securityhub.GetFindingsOutput, error)
+}
+
+func (f *fakeSecurityHub) GetFindings(_ context.Context, in *securityhub.GetFindingsInput, _ ...func(*securityhub.Options)) (*securityhub.GetFindingsOutput, error) {
+	return f.getFindings(in)
+}
+
+type fakeGuardDuty struct {
+	detectors [][]string
+	findings  map[string][]gdtypes.Finding
+	requests  []*guardduty.ListFindingsInput
+}
+
+func (f *fakeGuardDuty) ListDetectors(_ context.Context, in *guardduty.ListDetectorsInput, _ ...func(*guardduty.Options)) (*guardduty.ListDetectorsOutput, error) {
+	i := 0
+	if in.NextToken != nil {
+		i, _ = strconv.Atoi(*in.NextToken)
+	}
+	out := &guardduty.ListDetectorsOutput{DetectorIds: f.detectors[i]}
+	if i+1 < len(f.detectors) {
+		out.NextToken = awssdk.String(strconv.Itoa(i + 1))
+	}
+	return out, nil
+}
+
+func (f *fakeGuardDuty) ListFindings(_ context.Context, in *guardduty.ListFindingsInput, _ ...func(*guardduty.Options)) (*guardduty.ListFindingsOutput, error) {
+	f.requests = append(f.requests, in)
+	out := &guardduty.ListFindingsOutput{}
+	for _, finding := range f.findings[*in.DetectorId] {
+		out.FindingIds = append(out.FindingIds, *finding.Id)
+	}
+	return out, nil
+}
+
+func (f *fakeGuardDuty) GetFindings(_ context.Context, in *guardduty.GetFindingsInput, _ ...func(*guardduty.Options)) (*guardduty.GetFindingsOutput, error) {
+	out := &guardduty.GetFindingsOutput{}
+	for _, finding := range f.findings[*in.DetectorId] {
+		if slices.Contains(in.FindingIds, *finding.Id) {
+			out.Findings = append(out.Findings, finding)
+		}
+	}
+	return out, nil
+}
+
+type fakeInspector struct {
+	pages    [][]i2types.Finding
+	requests []*inspector2.ListFindingsInput
+}
+
+func (f *fakeInspector) ListFindings(_ context.Context, in *inspector2.ListFindingsInput, _ ...func(*inspector2.Options)) (*inspector2.ListFindingsOutput, error) {
+	f.requests = append(f.requests, in)
+	i := 0
+	if in.NextToken != nil {
+		i, _ = strconv.Atoi(*in.NextToken)
+	}
+	out := &inspector2.ListFindingsOutput{Findings: f.pages[i]}
+	if i+1 < len(f.pages) {
+		out.NextToken = awssdk.String(strconv.Itoa(i + 1))
+	}
+	return out, nil
+}
+
+func TestCollect(t *testing.T) {
+	t0 := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+
+	// The findings returned by the service, updated between the polls.
+	updates := map[string]string{"a": "2026-10-16T09:58:00Z", "b": "2026-10-16T09:59:00Z"}
+	api := &fakeSecurityHub{getFindings: func(in *securityhub.GetFindingsInput) (*securityhub.GetFindingsOutput, error) {
+		assert.Equal(t, []shtypes.StringFilter{{Value: awssdk.String("ACTIVE"), Comparison: shtypes.StringFilterComparisonEquals}}, in.Filters.RecordState)
+		assert.Equal(t, []shtypes.SortCriterion{{Field: awssdk.String("UpdatedAt"), SortOrder: shtypes.SortOrderAscending}}, in.SortCriteria)
+		start := *in.Filters.UpdatedAt[0].Start
+		out := &securityhub.GetFindingsOutput{}
+		for _, id := range []string{"a", "b"} {
+			if updates[id] < start {
+				continue
+			}
+			out.Findings = append(out.Findings, shtypes.AwsSecurityFinding{Id: awssdk.String(id), UpdatedAt: awssdk.String(updates[id])})
+		}
+		return out, nil
+	}}
+	l := securityHubLister{api: api}
+
+	inp := &findingsInput{svc: securityHub, config: defaultConfig()}
+	inp.config.Regions = []string{"us-east-1"}
+	inp.config.Filters = map[string]interface{}{
+		"RecordState": []interface{}{map[string]interface{}{"Value": "ACTIVE", "Comparison": "EQUALS"}},
+	}
+	require.NoError(t, inp.config.validate(securityHub))
+	cp := &checkpoint{Time: t0.Add(-time.Hour), Findings: map[string]time.Time{}}
+
+	var pub testPublisher
+	n, err := inp.collect(context.Background(), l, "us-east-1", cp, t0, &pub)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, []string{"a", "b"}, pub.findingIDs(t, securityHub))
+	assert.Nil(t, pub.events[0].update)
+	assert.Equal(t, checkpoint{Time: t0, Findings: map[string]time.Time{
+		"a": t0.Add(-2 * time.Minute),
+		"b": t0.Add(-time.Minute),
+	}}, pub.events[1].update)
+	assert.Equal(t, t0.Add(-time.Minute), pub.events[1].event.Timestamp)
+	assert.JSONEq(t, `{"Id":"b","UpdatedAt":"2026-10-16T09:59:00Z"}`, pub.events[1].event.Fields["message"].(string))
+	region, err := pub.events[1].event.Fields.GetValue("cloud.region")
+	require.NoError(t, err)
+	assert.Equal(t, "us-east-1", region)
+
+	// The findings that were not updated are not published again.
+	pub = testPublisher{}
+	updates["a"] = "2026-10-16T10:01:00Z"
+	n, err = inp.collect(context.Background(), l, "us-east-1", cp, t0.Add(5*time.Minute), &pub)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, []string{"a"}, pub.findingIDs(t, securityHub))
+
+	assert.Equal(t, map[string]time.Time{"a": t0.Add(time.Minute)}, cp.Findings,
+		"the findings updated before the overlap are forgotten")
+}
+
+func TestListGuardDutyFindings(t *testing.T) {
+	from := time.UnixMilli(1792144800000)
+	to := from.Add(time.Hour)
+
+	api := &fakeGuardDuty{
+		detectors: [][]string{{"d1"}, {"d2"}},
+		findings: map[string][]gdtypes.Finding{
+			"d1": {{Id: awssdk.String("d1-f1"), UpdatedAt: awssdk.String("2026-10-16T10:00:00Z")}},
+			"d2": {{Id: awssdk.String("d2-f1"), UpdatedAt: awssdk.String("2026-10-16T10:30:00.123Z")}},
+		},
+	}
+	var findings []finding
+	q := query{from: from, to: to, pageSize: 50, filters: map[string]interface{}{"severity": map[string]interface{}{"greaterThanOrEqual": 4}}}
+	err := guardDutyLister{api: api}.list(context.Background(), q, func(page []finding) error {
+		findings = append(findings, page...)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	assert.Equal(t, "d1-f1", findings[0].id)
+	assert.Equal(t, "d2-f1", findings[1].id)
+	assert.Equal(t, time.Date(2026, 10, 16, 10, 30, 0, 123e6, time.UTC), findings[1].updatedAt)
+
+	require.Len(t, api.requests, 2)
+	criterion := api.requests[0].FindingCriteria.Criterion
+	assert.Equal(t, gdtypes.Condition{
+		GreaterThanOrEqual: awssdk.Int64(from.UnixMilli()),
+		LessThanOrEqual:    awssdk.Int64(to.UnixMilli()),
+	}, criterion["updatedAt"])
+	assert.Equal(t, awssdk.Int64(4), criterion["severity"].GreaterThanOrEqual)
+	assert.Equal(t, &gdtypes.SortCriteria{AttributeName: awssdk.String("updatedAt"), OrderBy: gdtypes.OrderByAsc}, api.requests[0].SortCriteria)
+}
+
+func TestListInspectorFindings(t *testing.T) {
+	from := time.UnixMilli(1792144800000)
+	to := from.Add(time.Hour)
+
+	api := &fakeInspector{pages: [][]i2types.Finding{
+		{{FindingArn: awssdk.String("arn:1"), UpdatedAt: awssdk.Time(time.UnixMilli(1792146600500))}},
+		{{FindingArn: awssdk.String("arn:2"), UpdatedAt: awssdk.Time(time.UnixMilli(1792146000000))}},
+	}}
+	var findings []finding
+	err := inspectorLister{api: api}.list(context.Background(), query{from: from, to: to, pageSize: 100}, func(page []finding) error {
+		findings = append(findings, page...)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Len(t, findings, 2)
+	assert.Equal(t, "arn:1", findings[0].id)
+	assert.Equal(t, time.UnixMilli(1792146600500).UTC(), findings[0].updatedAt)
+	assert.Equal(t, "arn:2", findings[1].id)
+	require.Len(t, api.requests, 2)
+	assert.Equal(t, []i2types.DateFilter{{StartInclusive: awssdk.Time(from), EndInclusive: awssdk.Time(to)}},
+		api.requests[0].FilterCriteria.UpdatedAt)
+}
+
+func TestConfigValidate(t *testing.T) {
+	c := defaultConfig()
+	c.Regions = []string{"us-east-1"}
+	require.NoError(t, c.validate(guardDuty))
+	assert.Equal(t, 50, c.PageSize)
+	assert.Equal(t, "https://guardduty.us-east-1.amazonaws.com", c.endpointURL(guardDuty, "us-east-1"))
+	c.AWSConfig.FIPSEnabled = true
+	assert.Equal(t, "https://inspector2-fips.us-east-1.amazonaws.com", c.endpointURL(inspector, "us-east-1"))
+
+	c = defaultConfig()
+	c.PageSize = 100
+	assert.ErrorContains(t, c.validate(guardDuty), "page_size must not be greater than 50")
+
+	c = defaultConfig()
+	c.Filters = map[string]interface{}{"UpdatedAt": nil}
+	assert.ErrorContains(t, c.validate(securityHub), "filters must not contain UpdatedAt")
+
+	c = defaultConfig()
+	c.Filters = map[string]interface{}{"severity": map[string]interface{}{"greaterThanOrEqual": 4}}
+	assert.ErrorContains(t, c.validate(securityHub), "invalid filters")
+	assert.NoError(t, c.validate(guardDuty))
+
+	c = defaultConfig()
+	c.AWSConfig.Endpoint = "amazonaws.com.cn"
+	assert.Equal(t, "https://securityhub.cn-north-1.amazonaws.com.cn", *c.baseEndpoint(securityHub, "cn-north-1"))
+	c.AWSConfig.Endpoint = ""
+	assert.Nil(t, c.baseEndpoint(securityHub, "us-east-1"), "the SDK resolves the endpoint if it is not set")
+
+	c = defaultConfig()
+	c.DetectorIDs = []string{"d1"}
+	assert.ErrorContains(t, c.validate(securityHub), "detector_ids can only be used with the aws-guardduty input")
+}
@@ -0,0 +1,345 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package awssecurityfindings
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/aws-sdk-go-v2/service/guardduty"
+	gdtypes "github.com/aws/aws-sdk-go-v2/service/guardduty/types"
+	"github.com/aws/aws-sdk-go-v2/service/inspector2"
+	i2types "github.com/aws/aws-sdk-go-v2/service/inspector2/types"
+	"github.com/aws/aws-sdk-go-v2/service/securityhub"
+	shtypes "github.com/aws/aws-sdk-go-v2/service/securityhub/types"
+)
+
+// finding is a finding returned by a service, with its identifier and its
+// update time.
+type finding struct {
+	id        string
+	updatedAt time.Time
+	raw       json.RawMessage
+}
+
+// query is a query of the findings updated in [from, to].
+type query struct {
+	from, to    time.Time
+	filters     map[string]interface{}
+	pageSize    int
+	detectorIDs []string
+}
+
+// lister lists the findings of a service.
+type lister interface {
+	// list calls fn with the pages of the findings matching q.
+	list(ctx context.Context, q query, fn func([]finding) error) error
+}
+
+// service is the findings API of an AWS security service.
+type service struct {
+	inputName string
+	// fieldsKey is the key of the fields of the service under aws.
+	fieldsKey string
+	// endpointPrefix is the prefix of the endpoints of the service.
+	endpointPrefix  string
+	maxPageSize     int
+	updatedAtFilter string
+	// checkFilters returns an error if the filters are not filters of the
+	// API of the service.
+	checkFilters func(filters map[string]interface{}) error
+	// newLister returns the lister of the findings of the service in region.
+	newLister func(awsConfig awssdk.Config, c *config, region string) lister
+}
+
+var (
+	securityHub = &service{
+		inputName:       "aws-securityhub",
+		fieldsKey:       "securityhub",
+		endpointPrefix:  "securityhub",
+		maxPageSize:     100,
+		updatedAtFilter: "UpdatedAt",
+		checkFilters: func(filters map[string]interface{}) error {
+			_, err := decodeFilters[shtypes.AwsSecurityFindingFilters](filters)
+			return err
+		},
+		newLister: func(awsConfig awssdk.Config, c *config, region string) lister {
+			return securityHubLister{api: securityhub.NewFromConfig(awsConfig, func(o *securityhub.Options) {
+				o.Retryer = newRetryer()
+				o.BaseEndpoint = c.baseEndpoint(securityHub, region)
+				if c.AWSConfig.FIPSEnabled {
+					o.EndpointOptions.UseFIPSEndpoint = awssdk.FIPSEndpointStateEnabled
+				}
+			})}
+		},
+	}
+	guardDuty = &service{
+		inputName:       "aws-guardduty",
+		fieldsKey:       "guardduty",
+		endpointPrefix:  "guardduty",
+		maxPageSize:     50,
+		updatedAtFilter: "updatedAt",
+		checkFilters: func(filters map[string]interface{}) error {
+			_, err := decodeFilters[map[string]gdtypes.Condition](filters)
+			return err
+		},
+		newLister: func(awsConfig awssdk.Config, c *config, region string) lister {
+			return guardDutyLister{api: guardduty.NewFromConfig(awsConfig, func(o *guardduty.Options) {
+				o.Retryer = newRetryer()
+				o.BaseEndpoint = c.baseEndpoint(guardDuty, region)
+				if c.AWSConfig.FIPSEnabled {
+					o.EndpointOptions.UseFIPSEndpoint = awssdk.FIPSEndpointStateEnabled
+				}
+			})}
+		},
+	}
+	inspector = &service{
+		inputName:       "aws-inspector",
+		fieldsKey:       "inspector",
+		endpointPrefix:  "inspector2",
+		maxPageSize:     100,
+		updatedAtFilter: "updatedAt",
+		checkFilters: func(filters map[string]interface{}) error {
+			_, err := decodeFilters[i2types.FilterCriteria](filters)
+			return err
+		},
+		newLister: func(awsConfig awssdk.Config, c *config, region string) lister {
+			return inspectorLister{api: inspector2.NewFromConfig(awsConfig, func(o *inspector2.Options) {
+				o.Retryer = newRetryer()
+				o.BaseEndpoint = c.baseEndpoint(inspector, region)
+				if c.AWSConfig.FIPSEnabled {
+					o.EndpointOptions.UseFIPSEndpoint = awssdk.FIPSEndpointStateEnabled
+				}
+			})}
+		},
+	}
+)
+
+// newRetryer returns the retryer of the clients. The APIs of the findings are
+// throttled at a few requests per second, the standard retryer backs off on
+// the throttling errors.
+func newRetryer() awssdk.Retryer {
+	return retry.NewStandard(func(so *retry.StandardOptions) {
+		so.MaxAttempts = 5
+	})
+}
+
+// decodeFilters returns the filters in the syntax of the API as the SDK type
+// T. The names of the filters are matched case-insensitively, unknown names
+// are an error.
+func decodeFilters[T any](filters map[string]interface{}) (*T, error) {
+	f := new(T)
+	if len(filters) == 0 {
+		return f, nil
+	}
+	b, err := json.Marshal(filters)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filters: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(f); err != nil {
+		return nil, fmt.Errorf("invalid filters: %w", err)
+	}
+	return f, nil
+}
+
+// securityHubLister lists the findings with the Security Hub GetFindings
+// API, in ascending order of update time.
+type securityHubLister struct {
+	api securityhub.GetFindingsAPIClient
+}
+
+func (l securityHubLister) list(ctx context.Context, q query, fn func([]finding) error) error {
+	filters, err := decodeFilters[shtypes.AwsSecurityFindingFilters](q.filters)
+	if err != nil {
+		return err
+	}
+	filters.UpdatedAt = []shtypes.DateFilter{{
+		Start: awssdk.String(q.from.UTC().Format(time.RFC3339Nano)),
+		End:   awssdk.String(q.to.UTC().Format(time.RFC3339Nano)),
+	}}
+	p := securityhub.NewGetFindingsPaginator(l.api, &securityhub.GetFindingsInput{
+		Filters:      filters,
+		SortCriteria: []shtypes.SortCriterion{{Field: awssdk.String("UpdatedAt"), SortOrder: shtypes.SortOrderAscending}},
+		MaxResults:   awssdk.Int32(int32(q.pageSize)),
+	})
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get Security Hub findings: %w", err)
+		}
+		findings, err := decodeFindings(page.Findings, func(f shtypes.AwsSecurityFinding) (string, time.Time, error) {
+			updatedAt, err := parseTime(f.UpdatedAt)
+			return awssdk.ToString(f.Id), updatedAt, err
+		})
+		if err != nil {
+			return err
+		}
+		if err := fn(findings); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// guardDutyAPI is the part of the GuardDuty client used by the input.
+type guardDutyAPI interface {
+	guardduty.ListDetectorsAPIClient
+	guardduty.ListFindingsAPIClient
+	GetFindings(ctx context.Context, params *guardduty.GetFindingsInput, optFns ...func(*guardduty.Options)) (*guardduty.GetFindingsOutput, error)
+}
+
+// guardDutyLister lists the findings of the detectors with the GuardDuty
+// ListFindings and GetFindings APIs, in ascending order of update time for
+// each detector.
+type guardDutyLister struct {
+	api guardDutyAPI
+}
+
+func (l guardDutyLister) list(ctx context.Context, q query, fn func([]finding) error) error {
+	detectorIDs := q.detectorIDs
+	if len(detectorIDs) == 0 {
+		var err error
+		detectorIDs, err = l.detectors(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	criterion, err := decodeFilters[map[string]gdtypes.Condition](q.filters)
+	if err != nil {
+		return err
+	}
+	if *criterion == nil {
+		*criterion = make(map[string]gdtypes.Condition, 1)
+	}
+	(*criterion)["updatedAt"] = gdtypes.Condition{
+		GreaterThanOrEqual: awssdk.Int64(q.from.UnixMilli()),
+		LessThanOrEqual:    awssdk.Int64(q.to.UnixMilli()),
+	}
+	for _, detectorID := range detectorIDs {
+		p := guardduty.NewListFindingsPaginator(l.api, &guardduty.ListFindingsInput{
+			DetectorId:      awssdk.String(detectorID),
+			FindingCriteria: &gdtypes.FindingCriteria{Criterion: *criterion},
+			SortCriteria:    &gdtypes.SortCriteria{AttributeName: awssdk.String("updatedAt"), OrderBy: gdtypes.OrderByAsc},
+			MaxResults:      awssdk.Int32(int32(q.pageSize)),
+		})
+		for p.HasMorePages() {
+			ids, err := p.NextPage(ctx)
+			if err != nil {
+				return fmt.Errorf("failed to list GuardDuty findings of detector %s: %w", detectorID, err)
+			}
+			if len(ids.FindingIds) == 0 {
+				continue
+			}
+			resp, err := l.api.GetFindings(ctx, &guardduty.GetFindingsInput{
+				DetectorId: awssdk.String(detectorID),
+				FindingIds: ids.FindingIds,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get GuardDuty findings of detector %s: %w", detectorID, err)
+			}
+			findings, err := decodeFindings(resp.Findings, func(f gdtypes.Finding) (string, time.Time, error) {
+				updatedAt, err := parseTime(f.UpdatedAt)
+				return awssdk.ToString(f.Id), updatedAt, err
+			})
+			if err != nil {
+				return err
+			}
+			if err := fn(findings); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// detectors returns the identifiers of the GuardDuty detectors of the
+// region.
+func (l guardDutyLister) detectors(ctx context.Context) ([]string, error) {
+	var detectorIDs []string
+	p := guardduty.NewListDetectorsPaginator(l.api, &guardduty.ListDetectorsInput{})
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list GuardDuty detectors: %w", err)
+		}
+		detectorIDs = append(detectorIDs, page.DetectorIds...)
+	}
+	return detectorIDs, nil
+}
+
+// inspectorLister lists the findings with the Inspector ListFindings API.
+// The API can not sort the findings by update time.
+type inspectorLister struct {
+	api inspector2.ListFindingsAPIClient
+}
+
+func (l inspectorLister) list(ctx context.Context, q query, fn func([]finding) error) error {
+	filters, err := decodeFilters[i2types.FilterCriteria](q.filters)
+	if err != nil {
+		return err
+	}
+	filters.UpdatedAt = []i2types.DateFilter{{
+		StartInclusive: awssdk.Time(q.from),
+		EndInclusive:   awssdk.Time(q.to),
+	}}
+	p := inspector2.NewListFindingsPaginator(l.api, &inspector2.ListFindingsInput{
+		FilterCriteria: filters,
+		MaxResults:     awssdk.Int32(int32(q.pageSize)),
+	})
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to list Inspector findings: %w", err)
+		}
+		findings, err := decodeFindings(page.Findings, func(f i2types.Finding) (string, time.Time, error) {
+			return awssdk.ToString(f.FindingArn), awssdk.ToTime(f.UpdatedAt), nil
+		})
+		if err != nil {
+			return err
+		}
+		if err := fn(findings); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseTime parses the update time of a Security Hub or GuardDuty finding,
+// a timestamp in the ISO 8601 format.
+func parseTime(s *string) (time.Time, error) {
+	if s == nil {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, *s)
+}
+
+// decodeFindings returns the findings of a page, encoded as JSON. The
+// identifier and the update time of each finding are read by decode.
+func decodeFindings[T any](page []T, decode func(T) (string, time.Time, error)) ([]finding, error) {
+	findings := make([]finding, 0, len(page))
+	for _, f := range page {
+		raw, err := json.Marshal(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode finding: %w", err)
+		}
+		id, updatedAt, err := decode(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode finding: %w", err)
+		}
+		if id == "" || updatedAt.IsZero() {
+			return nil, fmt.Errorf("finding without identifier or update time: %s", raw)
+		}
+		findings = append(findings, finding{id: id, updatedAt: updatedAt.UTC(), raw: raw})
+	}
+	return findings, nil
+}
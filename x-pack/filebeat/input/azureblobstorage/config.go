@@ -12,6 +12,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/common/match"
 	"github.com/elastic/beats/v7/libbeat/reader/parser"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
 	"github.com/elastic/beats/v7/x-pack/libbeat/reader/decoder"
 	conf "github.com/elastic/elastic-agent-libs/config"
 )
@@ -65,6 +66,8 @@ type config struct {
 	ExpandEventListFromField string `config:"expand_event_list_from_field"`
 	// PathPrefix is the prefix for blob paths, useful for filtering blobs in a specific directory structure.
 	PathPrefix string `config:"path_prefix"`
+	// Download defines how interrupted blob downloads are resumed, and how the downloads are limited and prefetched.
+	Download download.Config `config:"download"`
 }
 
 // container contains the config for each specific blob storage container in the root account.
@@ -159,6 +162,7 @@ type OAuth2Config struct {
 func defaultConfig() config {
 	return config{
 		AccountName: "some_account",
+		Download:    download.DefaultConfig(),
 	}
 }
 
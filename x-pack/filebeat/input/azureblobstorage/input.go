@@ -19,6 +19,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/feature"
 	"github.com/elastic/beats/v7/libbeat/management/status"
 	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
@@ -226,5 +227,6 @@ func (input *azurebsInput) run(inputCtx v2.Context, src cursor.Source, st *state
 	}
 
 	scheduler := newScheduler(publisher, containerClient, credential, currentSource, &input.config, st, input.serviceURL, inputCtx, metrics, log)
+	scheduler.downloads = download.NewManager(input.config.Download, inputCtx.MetricsRegistry, log.Named("download"))
 	return scheduler.schedule(ctx)
 }
@@ -17,13 +17,13 @@ import (
 	"time"
 	"unicode"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
 	azcontainer "github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
 
 	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
 	"github.com/elastic/beats/v7/x-pack/libbeat/reader"
 	"github.com/elastic/beats/v7/x-pack/libbeat/reader/decoder"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -58,6 +58,8 @@ type job struct {
 	status status.StatusReporter
 	// metrics is used to track the input's metrics
 	metrics *inputMetrics
+	// downloads resumes, limits and prefetches the blob download
+	downloads *download.Manager
 }
 
 // newJob, returns an instance of a job, which is a unit of work that can be assigned to a go routine
@@ -80,6 +82,7 @@ func newJob(client *blob.Client, blob *azcontainer.BlobItem, blobURL string,
 		log:       log,
 		status:    stat,
 		metrics:   metrics,
+		downloads: download.NewManager(download.DefaultConfig(), nil, log),
 	}
 }
 
@@ -160,10 +163,23 @@ func (j *job) processAndPublishData(ctx context.Context, id string) error {
 		j.status.UpdateStatus(status.Degraded, "failed to create a download stream: "+err.Error())
 		return fmt.Errorf("failed to download data from blob with error: %w", err)
 	}
-	const maxRetries = 3
-	reader := get.NewRetryReader(ctx, &azblob.RetryReaderOptions{
-		MaxRetries: maxRetries,
+	// interrupted downloads are resumed from the same version of the blob.
+	reader, err := j.downloads.Wrap(ctx, get.Body, func(ctx context.Context, offset int64) (io.ReadCloser, error) {
+		resp, err := j.client.DownloadStream(ctx, &blob.DownloadStreamOptions{
+			Range: blob.HTTPRange{Offset: offset},
+			AccessConditions: &blob.AccessConditions{
+				ModifiedAccessConditions: &blob.ModifiedAccessConditions{IfMatch: get.ETag},
+			},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.Body, nil
 	})
+	if err != nil {
+		j.status.UpdateStatus(status.Degraded, "failed to download blob: "+err.Error())
+		return fmt.Errorf("failed to download data from blob with error: %w", err)
+	}
 	defer func() {
 		err = reader.Close()
 		if err != nil {
@@ -17,6 +17,7 @@ import (
 
 	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
 	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/monitoring"
 	"github.com/elastic/go-concert/timed"
@@ -58,6 +59,7 @@ type scheduler struct {
 	serviceURL string
 	status     status.StatusReporter
 	metrics    *inputMetrics
+	downloads  *download.Manager
 }
 
 // newScheduler, returns a new scheduler instance
@@ -148,6 +150,9 @@ func (s *scheduler) scheduleOnce(ctx context.Context) error {
 			}
 
 			job := newJob(blobClient, v, blobURL, s.state, s.src, s.publisher, s.status, s.metrics, s.log)
+			if s.downloads != nil {
+				job.downloads = s.downloads
+			}
 			jobs = append(jobs, job)
 		}
 
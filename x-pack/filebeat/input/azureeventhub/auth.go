@@ -23,6 +23,10 @@ const (
 	AuthTypeClientSecret string = "client_secret"
 	// AuthTypeManagedIdentity uses Azure Managed Identity authentication.
 	AuthTypeManagedIdentity string = "managed_identity"
+	// AuthTypeWorkloadIdentity uses Azure Workload Identity federation.
+	AuthTypeWorkloadIdentity string = "workload_identity"
+	// AuthTypeClientCertificate uses client certificate credentials.
+	AuthTypeClientCertificate string = "client_certificate"
 )
 
 // createCredential creates a TokenCredential if needed based on the authentication type.
@@ -44,6 +48,18 @@ func createCredential(cfg *azureInputConfig, log *logp.Logger) (azcore.TokenCred
 			return nil, fmt.Errorf("failed to create managed identity credential: %w", err)
 		}
 		return credential, nil
+	case AuthTypeWorkloadIdentity:
+		credential, err := newWorkloadIdentityCredential(cfg, log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create workload identity credential: %w", err)
+		}
+		return credential, nil
+	case AuthTypeClientCertificate:
+		credential, err := newClientCertificateCredential(cfg, log)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client certificate credential: %w", err)
+		}
+		return credential, nil
 	default:
 		return nil, fmt.Errorf("invalid auth_type: %s", cfg.AuthType)
 	}
@@ -111,7 +127,7 @@ func CreateEventHubConsumerClient(cfg *azureInputConfig, log *logp.Logger) (*aze
 		return consumerClient, nil
 	}
 
-	// All credential-based authentication types (client_secret, managed_identity, etc.)
+	// All credential-based authentication types (client_secret, managed_identity, workload_identity, client_certificate)
 	credential, err := createCredential(cfg, log)
 	if err != nil {
 		return nil, err
@@ -162,7 +178,7 @@ func CreateStorageAccountContainerClient(cfg *azureInputConfig, log *logp.Logger
 		return containerClient, nil
 	}
 
-	// All credential-based authentication types (client_secret, managed_identity, etc.)
+	// All credential-based authentication types (client_secret, managed_identity, workload_identity, client_certificate)
 	credential, err := createCredential(cfg, log)
 	if err != nil {
 		return nil, err
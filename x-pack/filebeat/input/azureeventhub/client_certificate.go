@@ -0,0 +1,75 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !aix
+
+package azureeventhub
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// newClientCertificateCredential creates a new client certificate credential.
+//
+// The certificate file holds the certificate and its private key, either
+// PEM-encoded or in a PKCS#12 archive, optionally protected by
+// ClientCertificatePassword.
+func newClientCertificateCredential(config *azureInputConfig, log *logp.Logger) (azcore.TokenCredential, error) {
+	log = log.Named("client_certificate")
+
+	if config.TenantID == "" {
+		return nil, fmt.Errorf("tenant_id is required for client_certificate authentication")
+	}
+	if config.ClientID == "" {
+		return nil, fmt.Errorf("client_id is required for client_certificate authentication")
+	}
+	if config.ClientCertificatePath == "" {
+		return nil, fmt.Errorf("client_certificate_path is required for client_certificate authentication")
+	}
+
+	data, err := os.ReadFile(config.ClientCertificatePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read client certificate: %w", err)
+	}
+	var password []byte
+	if config.ClientCertificatePassword != "" {
+		password = []byte(config.ClientCertificatePassword)
+	}
+	certs, key, err := azidentity.ParseCertificates(data, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse client certificate: %w", err)
+	}
+
+	// Create credential options
+	credentialOptions := &azidentity.ClientCertificateCredentialOptions{
+		ClientOptions: azcore.ClientOptions{
+			Cloud: getAzureCloud(config.AuthorityHost),
+		},
+	}
+
+	// Create the credential
+	credential, err := azidentity.NewClientCertificateCredential(
+		config.TenantID,
+		config.ClientID,
+		certs,
+		key,
+		credentialOptions,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client certificate credential: %w", err)
+	}
+
+	log.Infow("successfully created client certificate credential",
+		"tenant_id", config.TenantID,
+		"client_id", config.ClientID,
+	)
+
+	return credential, nil
+}
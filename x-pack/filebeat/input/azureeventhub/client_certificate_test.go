@@ -0,0 +1,155 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !aix
+
+package azureeventhub
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// writeTestCertificate writes a self-signed certificate and its private key
+// to a PEM file, and returns its path.
+func writeTestCertificate(t *testing.T) string {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "filebeat"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	data = append(data, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})...)
+	path := filepath.Join(t.TempDir(), "client.pem")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+	return path
+}
+
+func TestClientCertificateConfigValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   func(c *azureInputConfig)
+		errorMsg string
+	}{
+		{
+			name:   "valid client_certificate config",
+			config: func(c *azureInputConfig) {},
+		},
+		{
+			name: "client_certificate config missing namespace",
+			config: func(c *azureInputConfig) {
+				c.EventHubNamespace = ""
+			},
+			errorMsg: "eventhub_namespace is required when using client_certificate authentication",
+		},
+		{
+			name: "client_certificate config missing tenant_id",
+			config: func(c *azureInputConfig) {
+				c.TenantID = ""
+			},
+			errorMsg: "tenant_id is required when using client_certificate authentication",
+		},
+		{
+			name: "client_certificate config missing client_id",
+			config: func(c *azureInputConfig) {
+				c.ClientID = ""
+			},
+			errorMsg: "client_id is required when using client_certificate authentication",
+		},
+		{
+			name: "client_certificate config missing certificate path",
+			config: func(c *azureInputConfig) {
+				c.ClientCertificatePath = ""
+			},
+			errorMsg: "client_certificate_path is required when using client_certificate authentication",
+		},
+		{
+			name: "client_certificate config with processor v1",
+			config: func(c *azureInputConfig) {
+				c.ProcessorVersion = "v1"
+				c.SAKey = "storage-key"
+			},
+			errorMsg: "client_certificate authentication requires processor v2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := defaultConfig()
+			c.EventHubName = "test-hub"
+			c.EventHubNamespace = "test-namespace.servicebus.windows.net"
+			c.SAName = "test-storage"
+			c.ProcessorVersion = "v2"
+			c.AuthType = "client_certificate"
+			c.TenantID = "tenant-id"
+			c.ClientID = "client-id"
+			c.ClientCertificatePath = "/etc/filebeat/client.pem"
+			tt.config(&c)
+
+			err := c.Validate()
+			if tt.errorMsg != "" {
+				assert.EqualError(t, err, tt.errorMsg)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewClientCertificateCredential(t *testing.T) {
+	path := writeTestCertificate(t)
+
+	t.Run("PEM certificate", func(t *testing.T) {
+		credential, err := newClientCertificateCredential(&azureInputConfig{
+			TenantID:              "tenant-id",
+			ClientID:              "client-id",
+			ClientCertificatePath: path,
+		}, logp.NewLogger("test"))
+		require.NoError(t, err)
+		assert.NotNil(t, credential)
+	})
+
+	t.Run("missing certificate file", func(t *testing.T) {
+		_, err := newClientCertificateCredential(&azureInputConfig{
+			TenantID:              "tenant-id",
+			ClientID:              "client-id",
+			ClientCertificatePath: filepath.Join(t.TempDir(), "missing.pem"),
+		}, logp.NewLogger("test"))
+		assert.ErrorContains(t, err, "failed to read client certificate")
+	})
+
+	t.Run("invalid certificate file", func(t *testing.T) {
+		invalid := filepath.Join(t.TempDir(), "invalid.pem")
+		require.NoError(t, os.WriteFile(invalid, []byte("not a certificate"), 0o600))
+		_, err := newClientCertificateCredential(&azureInputConfig{
+			TenantID:              "tenant-id",
+			ClientID:              "client-id",
+			ClientCertificatePath: invalid,
+		}, logp.NewLogger("test"))
+		assert.ErrorContains(t, err, "failed to parse client certificate")
+	})
+}
@@ -46,17 +46,19 @@ type azureInputConfig struct {
 
 	// AuthType specifies the authentication method to use for both Event Hub and Storage Account.
 	// If not specified, defaults to connection_string for backwards compatibility.
-	// Valid values: connection_string, client_secret, managed_identity
+	// Valid values: connection_string, client_secret, managed_identity,
+	// workload_identity, client_certificate
 	AuthType string `config:"auth_type"`
 
 	// EventHubNamespace is the fully qualified namespace for the Event Hub.
-	// Required when using credential-based authentication (client_secret, managed_identity).
+	// Required when using credential-based authentication (client_secret, managed_identity,
+	// workload_identity, client_certificate).
 	EventHubNamespace string `config:"eventhub_namespace"`
 	// TenantID is the Azure Active Directory tenant ID.
-	// Required when using client_secret authentication.
+	// Required when using client_secret or client_certificate authentication.
 	TenantID string `config:"tenant_id"`
 	// ClientID is the Azure Active Directory application (client) ID.
-	// Required when using client_secret authentication.
+	// Required when using client_secret or client_certificate authentication.
 	ClientID string `config:"client_id"`
 	// ClientSecret is the Azure Active Directory application client secret.
 	// Required when using client_secret authentication.
@@ -70,6 +72,19 @@ type azureInputConfig struct {
 	// Only used when auth_type is managed_identity.
 	ManagedIdentityClientID string `config:"managed_identity_client_id"`
 
+	// WorkloadIdentityTokenFile is the path of the federated token file.
+	// Optional, defaults to the AZURE_FEDERATED_TOKEN_FILE environment variable.
+	// Only used when auth_type is workload_identity.
+	WorkloadIdentityTokenFile string `config:"workload_identity_token_file"`
+
+	// ClientCertificatePath is the path of the PEM or PKCS#12 file holding the
+	// client certificate and its private key.
+	// Required when using client_certificate authentication.
+	ClientCertificatePath string `config:"client_certificate_path"`
+	// ClientCertificatePassword is the password of the client certificate file.
+	// Optional, only used when auth_type is client_certificate.
+	ClientCertificatePassword string `config:"client_certificate_password"`
+
 	// LegacySanitizeOptions is a list of sanitization options to apply to messages.
 	//
 	// The supported options are:
@@ -245,8 +260,12 @@ func (conf *azureInputConfig) validateAuth() error {
 		return conf.validateClientSecretAuth()
 	case AuthTypeManagedIdentity:
 		return conf.validateManagedIdentityAuth()
+	case AuthTypeWorkloadIdentity:
+		return conf.validateWorkloadIdentityAuth()
+	case AuthTypeClientCertificate:
+		return conf.validateClientCertificateAuth()
 	default:
-		return fmt.Errorf("unknown auth_type: %s (valid values: connection_string, client_secret, managed_identity)", conf.AuthType)
+		return fmt.Errorf("unknown auth_type: %s (valid values: connection_string, client_secret, managed_identity, workload_identity, client_certificate)", conf.AuthType)
 	}
 }
 
@@ -341,6 +360,46 @@ func (conf *azureInputConfig) validateManagedIdentityAuth() error {
 	return nil
 }
 
+// validateWorkloadIdentityAuth validates workload identity authentication configuration.
+func (conf *azureInputConfig) validateWorkloadIdentityAuth() error {
+	// Validate Event Hub namespace is provided (required for credential-based auth)
+	if conf.EventHubNamespace == "" {
+		return errors.New("eventhub_namespace is required when using workload_identity authentication")
+	}
+	if conf.ProcessorVersion != processorV2 {
+		return errors.New("workload_identity authentication requires processor v2")
+	}
+
+	// TenantID, ClientID and WorkloadIdentityTokenFile are optional, they
+	// default to the environment variables set by the workload identity webhook.
+
+	// For workload_identity, storage account uses the same credential as Event Hub
+	return nil
+}
+
+// validateClientCertificateAuth validates client certificate authentication configuration.
+func (conf *azureInputConfig) validateClientCertificateAuth() error {
+	// Validate Event Hub client certificate configuration
+	if conf.EventHubNamespace == "" {
+		return errors.New("eventhub_namespace is required when using client_certificate authentication")
+	}
+	if conf.TenantID == "" {
+		return errors.New("tenant_id is required when using client_certificate authentication")
+	}
+	if conf.ClientID == "" {
+		return errors.New("client_id is required when using client_certificate authentication")
+	}
+	if conf.ClientCertificatePath == "" {
+		return errors.New("client_certificate_path is required when using client_certificate authentication")
+	}
+	if conf.ProcessorVersion != processorV2 {
+		return errors.New("client_certificate authentication requires processor v2")
+	}
+
+	// For client_certificate, storage account uses the same credential as Event Hub
+	return nil
+}
+
 // validateRequiredFields validates that all required fields are present.
 func (conf *azureInputConfig) validateRequiredFields() error {
 	if conf.EventHubName == "" {
@@ -476,10 +535,10 @@ func (conf *azureInputConfig) GetFullyQualifiedEventHubNamespace() (string, erro
 			return "", fmt.Errorf("failed to parse connection string: %w", err)
 		}
 		return connectionStringProperties.FullyQualifiedNamespace, nil
-	case AuthTypeClientSecret, AuthTypeManagedIdentity:
-		// When using client_secret auth, use EventHubNamespace directly
+	case AuthTypeClientSecret, AuthTypeManagedIdentity, AuthTypeWorkloadIdentity, AuthTypeClientCertificate:
+		// When using credential-based auth, use EventHubNamespace directly
 		if conf.EventHubNamespace == "" {
-			return "", fmt.Errorf("eventhub_namespace is required when using client_secret authentication")
+			return "", fmt.Errorf("eventhub_namespace is required when using %s authentication", conf.AuthType)
 		}
 		return conf.EventHubNamespace, nil
 	default:
@@ -0,0 +1,50 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !aix
+
+package azureeventhub
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// newWorkloadIdentityCredential creates a new workload identity credential,
+// exchanging a federated token, such as the service account token projected
+// in an AKS pod, for an Azure AD token.
+//
+// TenantID, ClientID and WorkloadIdentityTokenFile default to the
+// AZURE_TENANT_ID, AZURE_CLIENT_ID and AZURE_FEDERATED_TOKEN_FILE environment
+// variables set by the Azure Workload Identity webhook.
+func newWorkloadIdentityCredential(config *azureInputConfig, log *logp.Logger) (azcore.TokenCredential, error) {
+	log = log.Named("workload_identity")
+
+	// Create credential options
+	credentialOptions := &azidentity.WorkloadIdentityCredentialOptions{
+		ClientOptions: azcore.ClientOptions{
+			Cloud: getAzureCloud(config.AuthorityHost),
+		},
+		TenantID:      config.TenantID,
+		ClientID:      config.ClientID,
+		TokenFilePath: config.WorkloadIdentityTokenFile,
+	}
+
+	// Create the credential
+	credential, err := azidentity.NewWorkloadIdentityCredential(credentialOptions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create workload identity credential: %w", err)
+	}
+
+	log.Infow("successfully created workload identity credential",
+		"tenant_id", config.TenantID,
+		"client_id", config.ClientID,
+	)
+
+	return credential, nil
+}
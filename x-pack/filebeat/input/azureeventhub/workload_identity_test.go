@@ -0,0 +1,105 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !aix
+
+package azureeventhub
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func TestWorkloadIdentityConfigValidation(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   func(c *azureInputConfig)
+		errorMsg string
+	}{
+		{
+			name:   "valid workload_identity config with defaults from the environment",
+			config: func(c *azureInputConfig) {},
+		},
+		{
+			name: "valid workload_identity config with explicit settings",
+			config: func(c *azureInputConfig) {
+				c.TenantID = "tenant-id"
+				c.ClientID = "client-id"
+				c.WorkloadIdentityTokenFile = "/var/run/secrets/azure/tokens/azure-identity-token"
+			},
+		},
+		{
+			name: "workload_identity config missing namespace",
+			config: func(c *azureInputConfig) {
+				c.EventHubNamespace = ""
+			},
+			errorMsg: "eventhub_namespace is required when using workload_identity authentication",
+		},
+		{
+			name: "workload_identity config with processor v1",
+			config: func(c *azureInputConfig) {
+				c.ProcessorVersion = "v1"
+				c.SAKey = "storage-key"
+			},
+			errorMsg: "workload_identity authentication requires processor v2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := defaultConfig()
+			c.EventHubName = "test-hub"
+			c.EventHubNamespace = "test-namespace.servicebus.windows.net"
+			c.SAName = "test-storage"
+			c.ProcessorVersion = "v2"
+			c.AuthType = "workload_identity"
+			tt.config(&c)
+
+			err := c.Validate()
+			if tt.errorMsg != "" {
+				assert.EqualError(t, err, tt.errorMsg)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestNewWorkloadIdentityCredential(t *testing.T) {
+	t.Setenv("AZURE_TENANT_ID", "")
+	t.Setenv("AZURE_CLIENT_ID", "")
+	t.Setenv("AZURE_FEDERATED_TOKEN_FILE", "")
+
+	t.Run("explicit settings", func(t *testing.T) {
+		credential, err := newWorkloadIdentityCredential(&azureInputConfig{
+			TenantID:                  "tenant-id",
+			ClientID:                  "client-id",
+			WorkloadIdentityTokenFile: "/var/run/secrets/azure/tokens/azure-identity-token",
+		}, logp.NewLogger("test"))
+		require.NoError(t, err)
+		assert.NotNil(t, credential)
+	})
+
+	t.Run("settings from the environment", func(t *testing.T) {
+		t.Setenv("AZURE_TENANT_ID", "tenant-id")
+		t.Setenv("AZURE_CLIENT_ID", "client-id")
+		t.Setenv("AZURE_FEDERATED_TOKEN_FILE", "/var/run/secrets/azure/tokens/azure-identity-token")
+
+		credential, err := newWorkloadIdentityCredential(&azureInputConfig{}, logp.NewLogger("test"))
+		require.NoError(t, err)
+		assert.NotNil(t, credential)
+	})
+
+	t.Run("missing token file", func(t *testing.T) {
+		_, err := newWorkloadIdentityCredential(&azureInputConfig{
+			TenantID: "tenant-id",
+			ClientID: "client-id",
+		}, logp.NewLogger("test"))
+		assert.Error(t, err)
+	})
+}
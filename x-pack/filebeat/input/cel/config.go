@@ -16,6 +16,7 @@ import (
 	"gopkg.in/natefinch/lumberjack.v2"
 	"gopkg.in/yaml.v3"
 
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/apihealth"
 	"github.com/elastic/beats/v7/x-pack/filebeat/otel"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
@@ -86,6 +87,10 @@ type config struct {
 	// OTel configuration for which headers and request parameters should be
 	// redacted or unredacted in span attributes.
 	OTelTraceConfig *otel.TraceConfig `config:"otel.trace"`
+
+	// APIHealth configures the publication of API health
+	// status events.
+	APIHealth apihealth.Config `config:"api_health"`
 }
 
 func (c config) GetPackageData(key string) string {
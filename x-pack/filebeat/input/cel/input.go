@@ -52,6 +52,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/management/status"
 	"github.com/elastic/beats/v7/libbeat/statestore"
 	"github.com/elastic/beats/v7/libbeat/version"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/apihealth"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/httplog"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/httpmon"
 	"github.com/elastic/beats/v7/x-pack/filebeat/otel"
@@ -210,7 +211,10 @@ func (i input) run(env v2.Context, src *source, cursor map[string]any, pub input
 		cfg.Resource.Tracer.Filename = resolved
 	}
 
-	client, trace, otelMetrics, contextInjector, err := newClient(ctx, cfg, log, reg, env, otelTracerProvider)
+	apiHealth := apihealth.NewTracker(cfg.APIHealth, inputName, env.IDWithoutName)
+	resourceName := cfg.Resource.URL.String()
+
+	client, trace, otelMetrics, contextInjector, err := newClient(ctx, cfg, log, reg, env, otelTracerProvider, apiHealth)
 	if err != nil {
 		return err
 	}
@@ -328,11 +332,14 @@ func (i input) run(env v2.Context, src *source, cursor map[string]any, pub input
 
 		pub: pub,
 
-		log:      log,
-		health:   health,
-		metrics:  metricsRecorder,
-		tracer:   otelTracer,
-		injector: contextInjector,
+		log:       log,
+		health:    health,
+		apiHealth: apiHealth,
+		metrics:   metricsRecorder,
+		tracer:    otelTracer,
+		injector:  contextInjector,
+
+		resource: resourceName,
 
 		state:      state,
 		cursor:     cursor,
@@ -366,11 +373,16 @@ type runSession struct {
 
 	pub inputcursor.Publisher
 
-	log      *logp.Logger
-	health   status.StatusReporter
-	metrics  *metricsRecorder
-	tracer   trace.Tracer
-	injector *otel.ContextInjector
+	log       *logp.Logger
+	health    status.StatusReporter
+	apiHealth *apihealth.Tracker
+	metrics   *metricsRecorder
+	tracer    trace.Tracer
+	injector  *otel.ContextInjector
+
+	// resource is the resource address reported in
+	// API health status events.
+	resource string
 
 	state      map[string]any
 	cursor     map[string]any
@@ -397,6 +409,7 @@ func (s *runSession) runCycle(ctx context.Context) error {
 
 	s.metrics.StartPeriodic(runCtx)
 	defer s.metrics.EndPeriodic(runCtx)
+	defer s.publishAPIHealth(runLog)
 	runLog.Debug("process periodic request")
 	var (
 		budget    = *s.cfg.MaxExecutions
@@ -588,6 +601,7 @@ func (s *runSession) execute(ctx context.Context, executionNumber, budget int) (
 		}
 		execLog.Errorw("failed evaluation", "error", err)
 		s.health.UpdateStatus(status.Degraded, "failed evaluation: "+err.Error())
+		s.apiHealth.Failed(s.resource, err)
 	}
 	if s.trace != nil {
 		execLog.Debugw("final transaction", "transaction.id", s.trace.TxID())
@@ -623,6 +637,7 @@ func (s *runSession) execute(ctx context.Context, executionNumber, budget int) (
 		if len(e) == 0 {
 			s.metrics.AddProgramRunDuration(execCtx, time.Since(start))
 			s.metrics.AddProgramSuccessExecution(execCtx)
+			s.apiHealth.Succeeded(s.resource)
 			okSpans(execSpan)
 			return result, nil
 		}
@@ -631,6 +646,7 @@ func (s *runSession) execute(ctx context.Context, executionNumber, budget int) (
 		if e == nil {
 			s.metrics.AddProgramRunDuration(execCtx, time.Since(start))
 			s.metrics.AddProgramSuccessExecution(execCtx)
+			s.apiHealth.Succeeded(s.resource)
 			okSpans(execSpan)
 			return result, nil
 		}
@@ -650,7 +666,12 @@ func (s *runSession) execute(ctx context.Context, executionNumber, budget int) (
 			execLog.Errorw("single event object returned by evaluation", "error", e)
 		}
 		if err, ok := e["error"]; ok {
-			s.health.UpdateStatus(status.Degraded, fmt.Sprintf("single event error object returned by evaluation: %s", mapstr.M{"error": err}))
+			msg := fmt.Sprintf("single event error object returned by evaluation: %s", mapstr.M{"error": err})
+			s.health.UpdateStatus(status.Degraded, msg)
+			if !isDegraded {
+				// Evaluation failures have already been recorded.
+				s.apiHealth.Failed(s.resource, errors.New(msg))
+			}
 		} else {
 			s.health.UpdateStatus(status.Degraded, "single event object returned by evaluation")
 		}
@@ -708,6 +729,7 @@ func (s *runSession) execute(ctx context.Context, executionNumber, budget int) (
 
 	if !pubResult.degraded {
 		s.health.UpdateStatus(status.Running, "")
+		s.apiHealth.Succeeded(s.resource)
 	}
 
 	// Replace the last known good cursor.
@@ -840,6 +862,9 @@ loop:
 	// Advance the cursor to the final state if there was no error during
 	// publications. This is needed to transition to the next set of events.
 	if !hadPublicationError && !result.degraded {
+		if cursors != nil {
+			s.apiHealth.CursorUpdated(s.resource, s.now())
+		}
 		s.goodCursor = s.cursor
 		s.metrics.AddProgramSuccessExecution(pubCtx)
 		okSpans(pubSpan)
@@ -848,6 +873,16 @@ loop:
 	return result, nil
 }
 
+// publishAPIHealth publishes the API health status events if they are due.
+func (s *runSession) publishAPIHealth(log *logp.Logger) {
+	err := s.apiHealth.PublishDue(func(e beat.Event) error {
+		return s.pub.Publish(e, nil)
+	})
+	if err != nil {
+		log.Errorw("failed to publish API health status", "error", err)
+	}
+}
+
 func logWithTracingIds(log *logp.Logger, span trace.Span) *logp.Logger {
 	ctx := span.SpanContext()
 	return log.With(
@@ -1130,7 +1165,7 @@ func getLimit(which string, rateLimit map[string]any, log *logp.Logger) (limit r
 // https://github.com/natefinch/lumberjack/blob/4cb27fcfbb0f35cb48c542c5ea80b7c1d18933d0/lumberjack.go#L39
 const lumberjackTimestamp = "[0-9][0-9][0-9][0-9]-[0-9][0-9]-[0-9][0-9]T[0-9][0-9]-[0-9][0-9]-[0-9][0-9].[0-9][0-9][0-9]"
 
-func newClient(ctx context.Context, cfg config, log *logp.Logger, reg *monitoring.Registry, env v2.Context, tp *sdktrace.TracerProvider) (*http.Client, *httplog.LoggingRoundTripper, *otelCELMetrics, *otel.ContextInjector, error) {
+func newClient(ctx context.Context, cfg config, log *logp.Logger, reg *monitoring.Registry, env v2.Context, tp *sdktrace.TracerProvider, apiHealth *apihealth.Tracker) (*http.Client, *httplog.LoggingRoundTripper, *otelCELMetrics, *otel.ContextInjector, error) {
 	c, err := cfg.Resource.Transport.Client(clientOptions(cfg.Resource.URL.URL, cfg.Resource.KeepAlive.settings(), log)...)
 	if err != nil {
 		return nil, nil, nil, nil, err
@@ -1229,6 +1264,9 @@ func newClient(ctx context.Context, cfg config, log *logp.Logger, reg *monitorin
 	if reg != nil {
 		c.Transport = httpmon.NewMetricsRoundTripper(c.Transport, reg, log)
 	}
+	// Inside the retry client so that each throttled
+	// attempt is counted.
+	c.Transport = apiHealth.Transport(c.Transport, cfg.Resource.URL.String())
 
 	otelhttpOptions := []otelhttp.Option{otelhttp.WithTracerProvider(tp)}
 	otelMetrics, otelTransport, err := createOTELMetrics(ctx, cfg, log, env, c.Transport, otelhttpOptions)
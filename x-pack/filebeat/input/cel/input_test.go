@@ -21,6 +21,7 @@ import (
 	"runtime"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -2516,6 +2517,85 @@ func TestInput(t *testing.T) {
 	}
 }
 
+func TestAPIHealth(t *testing.T) {
+	var requests atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"message":"hello"}`))
+	}))
+	defer srv.Close()
+
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
+		"interval": 1,
+		"program": `
+			get(state.url).as(resp, resp.StatusCode == 200 ?
+				bytes(resp.Body).decode_json().as(body, {
+					"events": [body],
+					"cursor": {"seen": true},
+				})
+			:
+				{"events": []}
+			)`,
+		"resource": map[string]interface{}{"url": srv.URL},
+		"api_health": map[string]interface{}{
+			"enabled":  true,
+			"interval": 1,
+		},
+	})
+	config := defaultConfig()
+	config.Redact = &redact{}
+	err := cfg.Unpack(&config)
+	if err != nil {
+		t.Fatalf("unexpected error unpacking config: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	v2Ctx := v2.Context{
+		Logger:          logp.NewLogger("cel_test"),
+		ID:              "test_id:api_health",
+		IDWithoutName:   "test_id:api_health",
+		Cancelation:     ctx,
+		MetricsRegistry: monitoring.NewRegistry(),
+	}
+	var (
+		client publisher
+		health mapstr.M
+	)
+	client.done = func() {
+		e := client.published[len(client.published)-1]
+		if h, err := e.Fields.GetValue("api_health"); err == nil {
+			if h, _ := h.(mapstr.M); h["cursor"] != nil {
+				health = h
+				cancel()
+			}
+		}
+	}
+	err = input{}.run(v2Ctx, &source{config}, nil, &client, &v2Ctx)
+	if err != nil {
+		t.Fatalf("unexpected error running input: %v", err)
+	}
+
+	if health == nil {
+		t.Fatal("no API health event with a cursor published")
+	}
+	want := map[string]any{
+		"resource":        srv.URL,
+		"status":          "healthy",
+		"throttled_total": uint64(1),
+		"input":           mapstr.M{"type": "cel", "id": "test_id:api_health"},
+		"auth":            mapstr.M{"status": "ok"},
+	}
+	for k, v := range want {
+		if !reflect.DeepEqual(health[k], v) {
+			t.Errorf("unexpected value for api_health.%s: got:%v want:%v", k, health[k], v)
+		}
+	}
+}
+
 var _ inputcursor.Publisher = (*publisher)(nil)
 
 type publisher struct {
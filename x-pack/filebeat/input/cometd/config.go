@@ -4,11 +4,54 @@
 
 package cometd
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/replay"
+)
 
 type config struct {
-	ChannelName string      `config:"channel_name" validate:"required"`
-	Auth        *authConfig `config:"auth"`
+	ChannelName string       `config:"channel_name" validate:"required"`
+	Auth        *authConfig  `config:"auth"`
+	Retry       retryConfig  `config:"retry"`
+	Replay      replayConfig `config:"replay"`
+}
+
+// retryConfig is the backoff configuration for restarting
+// the pub/sub client after a failure.
+type retryConfig struct {
+	WaitMin time.Duration `config:"wait_min"`
+	WaitMax time.Duration `config:"wait_max"`
+}
+
+func (c retryConfig) Validate() error {
+	switch {
+	case c.WaitMin <= 0:
+		return errors.New("wait_min must be greater than zero")
+	case c.WaitMin > c.WaitMax:
+		return errors.New("wait_min must be less than or equal to wait_max")
+	}
+	return nil
+}
+
+// backoff returns the time to wait before the given restart attempt,
+// doubling from wait_min up to wait_max.
+func (c retryConfig) backoff(attempt int) time.Duration {
+	wait := c.WaitMin
+	for i := 1; i < attempt && wait < c.WaitMax; i++ {
+		wait *= 2
+	}
+	return min(wait, c.WaitMax)
+}
+
+type replayConfig struct {
+	replay.Config `config:",inline"`
+	// Resume subscribes from the last received replay ID when
+	// the pub/sub client is restarted, so that events retained
+	// by the server during the disconnection are received.
+	Resume bool `config:"resume"`
 }
 
 func (c *config) Validate() error {
@@ -21,5 +64,9 @@ func (c *config) Validate() error {
 func defaultConfig() config {
 	var c config
 	c.ChannelName = "cometd-channel"
+	c.Retry = retryConfig{
+		WaitMin: 30 * time.Second,
+		WaitMax: 5 * time.Minute,
+	}
 	return c
 }
@@ -6,6 +6,7 @@ package cometd
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -71,3 +72,16 @@ func TestConfigAuthValidateFailure_MissingUsernamePassword(t *testing.T) {
 
 	assert.Error(t, o.Validate())
 }
+
+func TestRetryBackoff(t *testing.T) {
+	c := defaultConfig().Retry
+	assert.NoError(t, c.Validate())
+	assert.Equal(t, 30*time.Second, c.backoff(1))
+	assert.Equal(t, time.Minute, c.backoff(2))
+	assert.Equal(t, 4*time.Minute, c.backoff(4))
+	assert.Equal(t, 5*time.Minute, c.backoff(5))
+	assert.Equal(t, 5*time.Minute, c.backoff(100))
+
+	c.WaitMax = time.Second
+	assert.ErrorContains(t, c.Validate(), "wait_min must be less than or equal to wait_max")
+}
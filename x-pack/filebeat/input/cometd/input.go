@@ -9,15 +9,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"golang.org/x/time/rate"
-
 	"github.com/elastic/beats/v7/filebeat/channel"
 	"github.com/elastic/beats/v7/filebeat/input"
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/replay"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 
@@ -25,12 +26,7 @@ import (
 	conf "github.com/elastic/elastic-agent-libs/config"
 )
 
-const (
-	inputName = "cometd"
-
-	// retryInterval is the minimum duration between pub/sub client retries.
-	retryInterval = 30 * time.Second
-)
+const inputName = "cometd"
 
 // Run starts the input worker then returns. Only the first invocation
 // will ever start the worker.
@@ -45,13 +41,17 @@ func (in *cometdInput) Run() {
 			defer in.log.Info("Input worker has stopped.")
 			in.b = bay.Bayeux{}
 
-			rt := rate.NewLimiter(rate.Every(retryInterval), 1)
-
+			var attempt int
 			for in.workerCtx.Err() == nil {
-				// Rate limit.
-				if err := rt.Wait(in.workerCtx); err != nil {
-					continue
+				// Back off between restarts.
+				if attempt != 0 {
+					select {
+					case <-in.workerCtx.Done():
+						continue
+					case <-time.After(in.Retry.backoff(attempt)):
+					}
 				}
+				attempt++
 
 				// Creating a new channel for cometd input.
 				in.msgCh = make(chan bay.MaybeMsg, 1)
@@ -62,7 +62,14 @@ func (in *cometdInput) Run() {
 					continue
 				}
 
-				if err := in.run(); err != nil {
+				in.published = false
+				err = in.run()
+				if in.published {
+					// The client was healthy, so start the
+					// backoff from wait_min again.
+					attempt = 1
+				}
+				if err != nil {
 					if in.workerCtx.Err() == nil {
 						in.log.Errorw("Restarting failed CometD input worker.", "error", err)
 						continue
@@ -83,7 +90,12 @@ func (in *cometdInput) run() error {
 	defer cancel()
 	// Ticker with 5 seconds to avoid log too many warnings
 	ticker := time.NewTicker(5 * time.Second)
-	in.msgCh = in.b.Channel(ctx, in.msgCh, "-1", *in.creds, in.ChannelName)
+	replayID := "-1"
+	if last, ok := in.replayIDs.Last(); ok && in.Replay.Resume {
+		replayID = strconv.FormatInt(last, 10)
+		in.log.Infow("resuming subscription from last received replay ID", "replay_id", replayID)
+	}
+	in.msgCh = in.b.Channel(ctx, in.msgCh, replayID, *in.creds, in.ChannelName)
 	for e := range in.msgCh {
 		if e.Failed() {
 			// if err bayeux library returns recoverable error, do not close input.
@@ -125,6 +137,17 @@ func (in *cometdInput) run() error {
 				in.log.Errorw("error while parsing JSON", "error", err)
 				continue
 			}
+			if id := int64(e.Msg.Data.Event.ReplayID); id != 0 {
+				gap, missed := in.replayIDs.Observe(id)
+				if missed && in.Replay.GapDetection {
+					if ok := in.handleGap(ctx, gap, e.Msg.Channel); !ok {
+						in.log.Debug("OnEvent returned false. Stopping input worker.")
+						cancel()
+						return fmt.Errorf("error ingesting data to elasticsearch")
+					}
+				}
+			}
+			in.published = true
 			if ok := in.outlet.OnEvent(makeEvent(event.EventId, e.Msg.Channel, string(msg))); !ok {
 				in.log.Debug("OnEvent returned false. Stopping input worker.")
 				cancel()
@@ -135,6 +158,40 @@ func (in *cometdInput) run() error {
 	return nil
 }
 
+// handleGap publishes a warning event for the replay IDs missed in gap and,
+// if configured, the events returned by the backfill endpoint for the gap.
+// It returns false if the outlet is closed.
+func (in *cometdInput) handleGap(ctx context.Context, gap replay.Gap, channel string) bool {
+	in.log.Warnw("detected gap in received replay IDs", "from", gap.From, "to", gap.To, "count", gap.Count())
+	gapEvent := gap.Event(inputName, time.Now().UTC())
+	_, _ = gapEvent.Fields.Put("cometd.channel_name", channel)
+	if !in.outlet.OnEvent(gapEvent) {
+		return false
+	}
+	if in.backfill == nil {
+		return true
+	}
+	header := http.Header{"Authorization": []string{"Bearer " + in.creds.AccessToken}}
+	msgs, err := in.backfill.Fetch(ctx, gap, header)
+	if err != nil {
+		in.log.Errorw("failed to backfill missed events", "from", gap.From, "to", gap.To, "error", err)
+		return true
+	}
+	for _, msg := range msgs {
+		var event event
+		err = json.Unmarshal(msg, &event)
+		if err != nil {
+			in.log.Errorw("error while parsing backfilled JSON", "error", err)
+			continue
+		}
+		if !in.outlet.OnEvent(makeEvent(event.EventId, channel, string(msg))) {
+			return false
+		}
+	}
+	in.log.Infow("backfilled missed events", "from", gap.From, "to", gap.To, "events", len(msgs))
+	return true
+}
+
 func init() {
 	err := input.Register(inputName, NewInput)
 	if err != nil {
@@ -186,6 +243,9 @@ func NewInput(
 		workerCancel: workerCancel,
 		authParams:   authParams,
 	}
+	if conf.Replay.Backfill != nil {
+		in.backfill = replay.NewBackfiller(conf.Replay.Backfill, nil)
+	}
 
 	// Build outlet for events.
 	in.outlet, err = connector.Connect(cfg)
@@ -223,6 +283,10 @@ type cometdInput struct {
 	b          bay.Bayeux
 	creds      *bay.Credentials
 	authParams bay.AuthenticationParameters
+
+	replayIDs replay.Detector    // Tracks received replay IDs across client restarts.
+	backfill  *replay.Backfiller // Requests missed events, nil if not configured.
+	published bool               // Whether the current client run has published an event.
 }
 
 type event struct {
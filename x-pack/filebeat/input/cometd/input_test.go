@@ -25,6 +25,7 @@ import (
 	finput "github.com/elastic/beats/v7/filebeat/input"
 	"github.com/elastic/beats/v7/filebeat/input/inputtest"
 	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/replay"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/logp/logptest"
@@ -611,3 +612,51 @@ func getBayData(body []byte) *bay.Subscription {
 
 	return &data
 }
+
+func TestHandleGap(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"EventIdentifier":"missed-1"},{"EventIdentifier":"missed-2"}]`))
+	}))
+	defer srv.Close()
+
+	var events []beat.Event
+	c := defaultConfig()
+	c.Replay.GapDetection = true
+	c.Replay.Backfill = &replay.BackfillConfig{URL: srv.URL}
+	require.NoError(t, c.Replay.Backfill.Validate())
+	in := &cometdInput{
+		config: c,
+		log:    logptest.NewTestingLogger(t, ""),
+		outlet: &mockedOutleter{
+			onEventHandler: func(event beat.Event) bool {
+				events = append(events, event)
+				return true
+			},
+		},
+		creds:    &bay.Credentials{AccessToken: "token"},
+		backfill: replay.NewBackfiller(c.Replay.Backfill, nil),
+	}
+
+	_, missed := in.replayIDs.Observe(10)
+	require.False(t, missed)
+	gap, missed := in.replayIDs.Observe(13)
+	require.True(t, missed)
+	require.True(t, in.handleGap(context.Background(), gap, "channel_name"))
+
+	require.Len(t, events, 3)
+	got, err := events[0].Fields.GetValue("cometd.replay_gap")
+	require.NoError(t, err)
+	assert.Equal(t, mapstr.M{"from": int64(11), "to": int64(12), "count": int64(2)}, got)
+	got, err = events[0].Fields.GetValue("cometd.channel_name")
+	require.NoError(t, err)
+	assert.Equal(t, "channel_name", got)
+	for i, id := range []string{"missed-1", "missed-2"} {
+		got, err = events[i+1].Fields.GetValue("event.id")
+		require.NoError(t, err)
+		assert.Equal(t, id, got)
+	}
+}
@@ -8,13 +8,20 @@ import (
 	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/awsfirehose"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/awss3"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/awssecurityfindings"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/entityanalytics"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/firelens"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/http_endpoint"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/httpjson"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/lumberjack"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/o365audit"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/salesforce"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/sql"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/strataloggingservice"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/taxii"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/zscalernss"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
 
@@ -25,7 +32,17 @@ func xpackInputs(info beat.Info, log *logp.Logger, store statestore.States) []v2
 		httpjson.Plugin(log, store),
 		o365audit.Plugin(log, store),
 		awss3.Plugin(log, store, info.Paths),
+		awss3.FDRPlugin(log, store, info.Paths),
+		awsfirehose.Plugin(log),
+		awssecurityfindings.SecurityHubPlugin(log, store),
+		awssecurityfindings.GuardDutyPlugin(log, store),
+		awssecurityfindings.InspectorPlugin(log, store),
 		lumberjack.Plugin(log),
+		firelens.Plugin(log),
 		salesforce.Plugin(log, store),
+		sql.Plugin(log, store),
+		strataloggingservice.Plugin(log, store),
+		taxii.Plugin(log, store),
+		zscalernss.Plugin(log),
 	}
 }
@@ -11,13 +11,16 @@ import (
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/statestore"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/awscloudwatch"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/awsfirehose"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/awss3"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/awssecurityfindings"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/azureblobstorage"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/azureeventhub"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/benchmark"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/cel"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/cloudfoundry"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/entityanalytics"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/firelens"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/gcs"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/http_endpoint"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/httpjson"
@@ -25,8 +28,12 @@ import (
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/netflow"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/o365audit"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/salesforce"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/sql"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/strataloggingservice"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/streaming"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/taxii"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/unifiedlogs"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/zscalernss"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
 
@@ -42,9 +49,19 @@ func xpackInputs(info beat.Info, log *logp.Logger, store statestore.States) []v2
 		httpjson.Plugin(log, store),
 		o365audit.Plugin(log, store),
 		awss3.Plugin(log, store, info.Paths),
+		awss3.FDRPlugin(log, store, info.Paths),
+		awsfirehose.Plugin(log),
+		awssecurityfindings.SecurityHubPlugin(log, store),
+		awssecurityfindings.GuardDutyPlugin(log, store),
+		awssecurityfindings.InspectorPlugin(log, store),
 		awscloudwatch.Plugin(log, store),
 		lumberjack.Plugin(log),
+		firelens.Plugin(log),
 		salesforce.Plugin(log, store),
+		sql.Plugin(log, store),
+		strataloggingservice.Plugin(log, store),
+		taxii.Plugin(log, store),
+		zscalernss.Plugin(log),
 		streaming.Plugin(log, store),
 		streaming.PluginWebsocketAlias(log, store),
 		netflow.Plugin(log),
@@ -0,0 +1,73 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package firelens
+
+import (
+	"sync"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/acker"
+)
+
+// messageACKTracker invokes messageACK when all events of a forward protocol
+// message have been published and acknowledged by an output.
+type messageACKTracker struct {
+	messageACK func()
+
+	mutex       sync.Mutex // mutex synchronizes access to pendingACKs.
+	pendingACKs int64      // Number of events of the message that are pending ACKs.
+}
+
+// newMessageACKTracker returns a new messageACKTracker. Ready() must be
+// invoked after all events of the message are published.
+func newMessageACKTracker(messageACK func()) *messageACKTracker {
+	return &messageACKTracker{
+		messageACK:  messageACK,
+		pendingACKs: 1, // Ready() must be called to consume this "1".
+	}
+}
+
+// Ready signals that all the events of the message were published.
+func (t *messageACKTracker) Ready() {
+	t.ACK()
+}
+
+// Add increments the number of pending ACKs.
+func (t *messageACKTracker) Add() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	t.pendingACKs++
+}
+
+// ACK decrements the number of pending ACKs and acknowledges the message
+// when none is left.
+func (t *messageACKTracker) ACK() {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.pendingACKs <= 0 {
+		panic("misuse detected: negative ACK counter")
+	}
+
+	t.pendingACKs--
+	if t.pendingACKs == 0 {
+		t.messageACK()
+	}
+}
+
+// newEventACKHandler returns a beat ACKer that calls the ACK method of the
+// messageACKTracker stored in the private field of the acknowledged events.
+func newEventACKHandler() beat.EventListener {
+	return acker.ConnectionOnly(
+		acker.EventPrivateReporter(func(_ int, privates []interface{}) {
+			for _, private := range privates {
+				if ack, ok := private.(*messageACKTracker); ok {
+					ack.ACK()
+				}
+			}
+		}),
+	)
+}
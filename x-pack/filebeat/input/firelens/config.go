@@ -0,0 +1,63 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package firelens
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+// metadataEnv is the environment variable ECS sets in every container of a
+// task with the URL of the task metadata endpoint version 4.
+const metadataEnv = "ECS_CONTAINER_METADATA_URI_V4"
+
+type config struct {
+	ListenAddress  string                  `config:"listen_address"`
+	ListenPort     string                  `config:"listen_port"`
+	TLS            *tlscommon.ServerConfig `config:"ssl"`
+	MaxConnections int                     `config:"max_connections" validate:"min=0"` // Maximum number of concurrent connections. Default is 0 which means no limit.
+	Timeout        time.Duration           `config:"timeout" validate:"min=0"`         // Idle timeout of a connection. Default is 0 which means no timeout.
+	Metadata       metadataConfig          `config:"ecs_metadata"`
+}
+
+// metadataConfig is the configuration of the enrichment of the events with
+// the ECS task metadata endpoint of the task Filebeat runs in.
+type metadataConfig struct {
+	Enabled         bool          `config:"enabled"`
+	URL             string        `config:"url"`
+	RefreshInterval time.Duration `config:"refresh_interval" validate:"positive"`
+	Timeout         time.Duration `config:"timeout" validate:"positive"`
+}
+
+func defaultConfig() config {
+	return config{
+		ListenAddress: "localhost",
+		ListenPort:    "24224",
+		Metadata: metadataConfig{
+			Enabled:         true,
+			URL:             os.Getenv(metadataEnv),
+			RefreshInterval: 5 * time.Minute,
+			Timeout:         10 * time.Second,
+		},
+	}
+}
+
+func (c *config) Validate() error {
+	if !c.Metadata.Enabled || c.Metadata.URL == "" {
+		return nil
+	}
+	u, err := url.Parse(c.Metadata.URL)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("ecs_metadata.url must be an http or https URL")
+	}
+	return nil
+}
@@ -0,0 +1,200 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package firelens
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/ugorji/go/codec"
+)
+
+// eventTimeExt is the msgpack extension type of the EventTime of the forward
+// protocol: seconds and nanoseconds as two big endian 32 bit integers.
+const eventTimeExt = 0
+
+// forwardEntry is one event of a forward protocol message.
+type forwardEntry struct {
+	time   time.Time
+	record map[string]interface{}
+}
+
+// forwardMessage is a decoded forward protocol message, in any of the
+// Message, Forward, PackedForward or CompressedPackedForward modes.
+type forwardMessage struct {
+	tag     string
+	entries []forwardEntry
+	chunk   string // ID the client expects to be acknowledged, if any.
+}
+
+func newMsgpackHandle() *codec.MsgpackHandle {
+	h := &codec.MsgpackHandle{}
+	h.RawToString = true
+	h.WriteExt = true
+	h.MapType = reflect.TypeOf(map[string]interface{}(nil))
+	return h
+}
+
+// decodeForwardMessage converts the array read from the connection into a
+// forward protocol message.
+//
+// See https://github.com/fluent/fluentd/wiki/Forward-Protocol-Specification-v1.
+func decodeForwardMessage(h *codec.MsgpackHandle, msg []interface{}) (*forwardMessage, error) {
+	if len(msg) < 2 {
+		return nil, fmt.Errorf("message has %d elements, expected at least 2", len(msg))
+	}
+	tag, ok := msg[0].(string)
+	if !ok {
+		return nil, fmt.Errorf("message tag is a %T, expected a string", msg[0])
+	}
+
+	var (
+		entries []forwardEntry
+		options interface{}
+		err     error
+	)
+	switch v := msg[1].(type) {
+	case []interface{}:
+		// Forward mode: [tag, [[time, record], ...], options].
+		entries, err = decodeEntries(v)
+		options = optionsAt(msg, 2)
+	case string:
+		// PackedForward mode: [tag, msgpack stream of entries, options].
+		options = optionsAt(msg, 2)
+		entries, err = decodePackedEntries(h, []byte(v), options)
+	case []byte:
+		options = optionsAt(msg, 2)
+		entries, err = decodePackedEntries(h, v, options)
+	default:
+		// Message mode: [tag, time, record, options].
+		if len(msg) < 3 {
+			return nil, errors.New("message mode requires a time and a record")
+		}
+		var e forwardEntry
+		e, err = decodeEntry(msg[1], msg[2])
+		entries = []forwardEntry{e}
+		options = optionsAt(msg, 3)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m := &forwardMessage{tag: tag, entries: entries}
+	if opts, ok := options.(map[string]interface{}); ok {
+		m.chunk, _ = opts["chunk"].(string)
+	}
+	return m, nil
+}
+
+func optionsAt(msg []interface{}, i int) interface{} {
+	if i < len(msg) {
+		return msg[i]
+	}
+	return nil
+}
+
+func decodeEntries(raw []interface{}) ([]forwardEntry, error) {
+	entries := make([]forwardEntry, 0, len(raw))
+	for i, r := range raw {
+		pair, ok := r.([]interface{})
+		if !ok || len(pair) != 2 {
+			return nil, fmt.Errorf("entry %d is not a [time, record] pair", i)
+		}
+		e, err := decodeEntry(pair[0], pair[1])
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// decodePackedEntries decodes the concatenated [time, record] pairs of the
+// PackedForward and CompressedPackedForward modes.
+func decodePackedEntries(h *codec.MsgpackHandle, data []byte, options interface{}) ([]forwardEntry, error) {
+	var r io.Reader = bytes.NewReader(data)
+	if opts, ok := options.(map[string]interface{}); ok {
+		switch compressed := opts["compressed"]; compressed {
+		case nil, "text":
+		case "gzip":
+			gz, err := gzip.NewReader(r)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read gzip compressed entries: %w", err)
+			}
+			defer gz.Close()
+			r = gz
+		default:
+			return nil, fmt.Errorf("unsupported compression %v", compressed)
+		}
+	}
+
+	var entries []forwardEntry
+	dec := codec.NewDecoder(r, h)
+	for i := 0; ; i++ {
+		var pair []interface{}
+		err := dec.Decode(&pair)
+		if errors.Is(err, io.EOF) {
+			return entries, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode packed entry %d: %w", i, err)
+		}
+		if len(pair) != 2 {
+			return nil, fmt.Errorf("packed entry %d is not a [time, record] pair", i)
+		}
+		e, err := decodeEntry(pair[0], pair[1])
+		if err != nil {
+			return nil, fmt.Errorf("packed entry %d: %w", i, err)
+		}
+		entries = append(entries, e)
+	}
+}
+
+func decodeEntry(t, record interface{}) (forwardEntry, error) {
+	ts, err := decodeTime(t)
+	if err != nil {
+		return forwardEntry{}, err
+	}
+	r, ok := record.(map[string]interface{})
+	if !ok {
+		return forwardEntry{}, fmt.Errorf("record is a %T, expected a map", record)
+	}
+	return forwardEntry{time: ts, record: r}, nil
+}
+
+// decodeTime converts the time of an entry, either a Unix time in seconds or
+// an EventTime extension.
+func decodeTime(t interface{}) (time.Time, error) {
+	switch t := t.(type) {
+	case int64:
+		return time.Unix(t, 0).UTC(), nil
+	case uint64:
+		return time.Unix(int64(t), 0).UTC(), nil
+	case float64:
+		sec := int64(t)
+		return time.Unix(sec, int64((t-float64(sec))*1e9)).UTC(), nil
+	case codec.RawExt:
+		return decodeEventTime(&t)
+	case *codec.RawExt:
+		return decodeEventTime(t)
+	default:
+		return time.Time{}, fmt.Errorf("time is a %T, expected an integer or EventTime", t)
+	}
+}
+
+func decodeEventTime(ext *codec.RawExt) (time.Time, error) {
+	if ext.Tag != eventTimeExt || len(ext.Data) != 8 {
+		return time.Time{}, fmt.Errorf("invalid EventTime extension: type %d with %d bytes", ext.Tag, len(ext.Data))
+	}
+	sec := binary.BigEndian.Uint32(ext.Data[:4])
+	nsec := binary.BigEndian.Uint32(ext.Data[4:])
+	return time.Unix(int64(sec), int64(nsec)).UTC(), nil
+}
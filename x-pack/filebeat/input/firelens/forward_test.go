@@ -0,0 +1,176 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package firelens
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/binary"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ugorji/go/codec"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+var testTime = time.Date(2024, 5, 1, 12, 30, 15, 250000000, time.UTC)
+
+func eventTime(t time.Time) codec.RawExt {
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint32(data[:4], uint32(t.Unix()))
+	binary.BigEndian.PutUint32(data[4:], uint32(t.Nanosecond()))
+	return codec.RawExt{Tag: eventTimeExt, Data: data}
+}
+
+// roundTrip encodes msg and decodes it the way the server reads it from a
+// connection.
+func roundTrip(t *testing.T, h *codec.MsgpackHandle, msg []interface{}) []interface{} {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, codec.NewEncoder(&buf, h).Encode(msg))
+	var raw []interface{}
+	require.NoError(t, codec.NewDecoder(&buf, h).Decode(&raw))
+	return raw
+}
+
+func packEntries(t *testing.T, h *codec.MsgpackHandle, compress bool, entries ...[]interface{}) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	var w io.Writer = &buf
+	var gz *gzip.Writer
+	if compress {
+		gz = gzip.NewWriter(&buf)
+		w = gz
+	}
+	enc := codec.NewEncoder(w, h)
+	for _, e := range entries {
+		require.NoError(t, enc.Encode(e))
+	}
+	if gz != nil {
+		require.NoError(t, gz.Close())
+	}
+	return buf.Bytes()
+}
+
+func TestDecodeForwardMessage(t *testing.T) {
+	h := newMsgpackHandle()
+	record := map[string]interface{}{"log": "hello"}
+
+	testCases := map[string]struct {
+		msg       []interface{}
+		wantTimes []time.Time
+		wantChunk string
+	}{
+		"message mode with integer time": {
+			msg:       []interface{}{"app", testTime.Unix(), record},
+			wantTimes: []time.Time{testTime.Truncate(time.Second)},
+		},
+		"message mode with EventTime and chunk": {
+			msg:       []interface{}{"app", eventTime(testTime), record, map[string]interface{}{"chunk": "c1"}},
+			wantTimes: []time.Time{testTime},
+			wantChunk: "c1",
+		},
+		"forward mode": {
+			msg: []interface{}{"app", []interface{}{
+				[]interface{}{eventTime(testTime), record},
+				[]interface{}{eventTime(testTime.Add(time.Second)), record},
+			}},
+			wantTimes: []time.Time{testTime, testTime.Add(time.Second)},
+		},
+		"packed forward mode": {
+			msg: []interface{}{"app", packEntries(t, h, false,
+				[]interface{}{eventTime(testTime), record},
+				[]interface{}{eventTime(testTime.Add(time.Second)), record},
+			), map[string]interface{}{"chunk": "c2", "size": 2}},
+			wantTimes: []time.Time{testTime, testTime.Add(time.Second)},
+			wantChunk: "c2",
+		},
+		"compressed packed forward mode": {
+			msg: []interface{}{"app", packEntries(t, h, true,
+				[]interface{}{eventTime(testTime), record},
+			), map[string]interface{}{"compressed": "gzip"}},
+			wantTimes: []time.Time{testTime},
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			msg, err := decodeForwardMessage(h, roundTrip(t, h, tc.msg))
+			require.NoError(t, err)
+			assert.Equal(t, "app", msg.tag)
+			assert.Equal(t, tc.wantChunk, msg.chunk)
+			require.Len(t, msg.entries, len(tc.wantTimes))
+			for i, e := range msg.entries {
+				assert.Equal(t, tc.wantTimes[i], e.time)
+				assert.Equal(t, "hello", e.record["log"])
+			}
+		})
+	}
+
+	errorCases := map[string][]interface{}{
+		"too short":            {"app"},
+		"tag is not a string":  {1, testTime.Unix(), record},
+		"missing record":       {"app", testTime.Unix()},
+		"record is not a map":  {"app", testTime.Unix(), "hello"},
+		"invalid entry":        {"app", []interface{}{"hello"}},
+		"unknown compression":  {"app", packEntries(t, h, false), map[string]interface{}{"compressed": "zstd"}},
+		"invalid packed entry": {"app", []byte{0xc1}},
+	}
+	for name, msg := range errorCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := decodeForwardMessage(h, roundTrip(t, h, msg))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMakeEvent(t *testing.T) {
+	s := newServer(defaultConfig(), nil, logp.NewNopLogger(), nil, nil, newInputMetrics(monitoring.NewRegistry()))
+
+	event := s.makeEvent("app-firelens-0123", forwardEntry{
+		time: testTime,
+		record: map[string]interface{}{
+			"log":                 "GET / 200\n",
+			"source":              "stdout",
+			"container_id":        "0123abcd",
+			"container_name":      "/ecs-app-1-app-e4f2",
+			"ecs_cluster":         "prod",
+			"ecs_task_arn":        "arn:aws:ecs:us-west-2:111122223333:task/prod/158d1c8083dd49d6b527399fd6414f5c",
+			"ecs_task_definition": "app:7",
+			"request_id":          "r-1",
+		},
+	})
+
+	want := beat.Event{
+		Timestamp: testTime,
+		Fields: mapstr.M{
+			"message": "GET / 200",
+			"stream":  "stdout",
+			"container": mapstr.M{
+				"id":   "0123abcd",
+				"name": "/ecs-app-1-app-e4f2",
+			},
+			"awsfargate": mapstr.M{
+				"log": mapstr.M{
+					"tag":    "app-firelens-0123",
+					"record": mapstr.M{"request_id": "r-1"},
+				},
+				"task": mapstr.M{
+					"arn":      "arn:aws:ecs:us-west-2:111122223333:task/prod/158d1c8083dd49d6b527399fd6414f5c",
+					"cluster":  "prod",
+					"family":   "app",
+					"revision": "7",
+				},
+			},
+		},
+	}
+	assert.Equal(t, want, event)
+}
@@ -0,0 +1,142 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package firelens
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+
+	"golang.org/x/net/netutil"
+
+	inputv2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+	"github.com/elastic/go-concert/ctxtool"
+)
+
+const (
+	inputName = "firelens"
+)
+
+func Plugin(log *logp.Logger) inputv2.Plugin {
+	return inputv2.Plugin{
+		Name:      inputName,
+		Stability: feature.Beta,
+		Info:      "Receives Amazon ECS FireLens logs sent with the Fluentd forward protocol.",
+		Manager:   inputv2.ConfigureWith(configure, log),
+	}
+}
+
+func configure(cfg *conf.C, logger *logp.Logger) (inputv2.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	return newFireLensInput(config, logger)
+}
+
+// fireLensInput implements the Filebeat input V2 interface. The input is
+// stateless.
+type fireLensInput struct {
+	config    config
+	addr      string
+	tlsConfig *tls.Config
+}
+
+var _ inputv2.Input = (*fireLensInput)(nil)
+
+func newFireLensInput(config config, logger *logp.Logger) (*fireLensInput, error) {
+	addr := net.JoinHostPort(config.ListenAddress, config.ListenPort)
+
+	var tlsConfig *tls.Config
+	tlsConfigBuilder, err := tlscommon.LoadTLSServerConfig(config.TLS, logger)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfigBuilder != nil {
+		tlsConfig = tlsConfigBuilder.BuildServerConfig(addr)
+	}
+
+	return &fireLensInput{config: config, addr: addr, tlsConfig: tlsConfig}, nil
+}
+
+func (i *fireLensInput) Name() string { return inputName }
+
+func (i *fireLensInput) Test(_ inputv2.TestContext) error {
+	l, err := net.Listen("tcp", i.addr)
+	if err != nil {
+		return err
+	}
+	return l.Close()
+}
+
+func (i *fireLensInput) Run(inputCtx inputv2.Context, pipeline beat.Pipeline) error {
+	inputCtx.UpdateStatus(status.Starting, "")
+	inputCtx.Logger.Info("Starting " + inputName + " input")
+	defer inputCtx.Logger.Info(inputName + " input stopped")
+
+	inputCtx.UpdateStatus(status.Configuring, "")
+	// Create client for publishing events and receive notification of their ACKs.
+	client, err := pipeline.ConnectWith(beat.ClientConfig{
+		EventListener: newEventACKHandler(),
+	})
+	if err != nil {
+		err := fmt.Errorf("failed to create pipeline client: %w", err)
+		inputCtx.UpdateStatus(status.Failed, err.Error())
+		return err
+	}
+	defer client.Close()
+
+	ctx := ctxtool.FromCanceller(inputCtx.Cancelation)
+	var metadata *metadataProvider
+	switch {
+	case !i.config.Metadata.Enabled:
+	case i.config.Metadata.URL == "":
+		inputCtx.Logger.Warnf("ECS task metadata enrichment is disabled: %s is not set and no ecs_metadata.url is configured.", metadataEnv)
+	default:
+		metadata = newMetadataProvider(i.config.Metadata, inputCtx.Logger)
+		go metadata.run(ctx, i.config.Metadata.RefreshInterval)
+	}
+
+	metrics := newInputMetrics(inputCtx.MetricsRegistry)
+
+	l, err := net.Listen("tcp", i.addr)
+	if err != nil {
+		err := fmt.Errorf("failed to listen on %s: %w", i.addr, err)
+		inputCtx.UpdateStatus(status.Failed, err.Error())
+		return err
+	}
+	if i.tlsConfig != nil {
+		l = tls.NewListener(l, i.tlsConfig)
+	}
+	if i.config.MaxConnections > 0 {
+		l = netutil.LimitListener(l, i.config.MaxConnections)
+	}
+	metrics.bindAddress.Set(l.Addr().String())
+	inputCtx.Logger.Infof(inputName+" is listening at %v.", l.Addr())
+
+	s := newServer(i.config, l, inputCtx.Logger, client.Publish, metadata, metrics)
+
+	// Shutdown the server when cancellation is signaled.
+	go func() {
+		<-ctx.Done()
+		inputCtx.UpdateStatus(status.Stopping, "")
+		s.Close()
+	}()
+
+	inputCtx.UpdateStatus(status.Running, "")
+	if err := s.Run(); err != nil {
+		inputCtx.UpdateStatus(status.Failed, err.Error())
+		return err
+	}
+	inputCtx.UpdateStatus(status.Stopped, "")
+	return nil
+}
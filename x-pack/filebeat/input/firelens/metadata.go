@@ -0,0 +1,164 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package firelens
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// taskMetadata is the response of the ${ECS_CONTAINER_METADATA_URI_V4}/task
+// endpoint.
+type taskMetadata struct {
+	Cluster          string              `json:"Cluster"`
+	TaskARN          string              `json:"TaskARN"`
+	Family           string              `json:"Family"`
+	Revision         string              `json:"Revision"`
+	AvailabilityZone string              `json:"AvailabilityZone"`
+	LaunchType       string              `json:"LaunchType"`
+	Containers       []containerMetadata `json:"Containers"`
+}
+
+type containerMetadata struct {
+	DockerID string `json:"DockerId"`
+	Name     string `json:"Name"`
+	Image    string `json:"Image"`
+}
+
+// fields returns the task fields of an event, in the layout of the event
+// fields.
+func (m *taskMetadata) fields() mapstr.M {
+	task := mapstr.M{}
+	putNonEmpty(task, "arn", m.TaskARN)
+	putNonEmpty(task, "cluster", m.Cluster)
+	putNonEmpty(task, "family", m.Family)
+	putNonEmpty(task, "revision", m.Revision)
+	putNonEmpty(task, "launch_type", m.LaunchType)
+
+	cloud := mapstr.M{"provider": "aws"}
+	putNonEmpty(cloud, "availability_zone", m.AvailabilityZone)
+	// The task ARN has the form arn:aws:ecs:<region>:<account>:task/<cluster>/<id>.
+	if parts := strings.SplitN(m.TaskARN, ":", 6); len(parts) == 6 {
+		putNonEmpty(cloud, "region", parts[3])
+		if parts[4] != "" {
+			cloud["account"] = mapstr.M{"id": parts[4]}
+		}
+	}
+
+	return mapstr.M{
+		"awsfargate": mapstr.M{"task": task},
+		"cloud":      cloud,
+	}
+}
+
+// container returns the metadata of the container with the given Docker ID.
+func (m *taskMetadata) container(id string) (containerMetadata, bool) {
+	for _, c := range m.Containers {
+		if c.DockerID == id {
+			return c, true
+		}
+	}
+	return containerMetadata{}, false
+}
+
+// metadataProvider keeps the last metadata of the task read from the task
+// metadata endpoint.
+type metadataProvider struct {
+	log    *logp.Logger
+	url    string
+	client *http.Client
+
+	mu   sync.RWMutex
+	task *taskMetadata
+}
+
+func newMetadataProvider(cfg metadataConfig, log *logp.Logger) *metadataProvider {
+	return &metadataProvider{
+		log:    log,
+		url:    strings.TrimSuffix(cfg.URL, "/") + "/task",
+		client: &http.Client{Timeout: cfg.Timeout},
+	}
+}
+
+// run refreshes the task metadata every interval until ctx is cancelled.
+// A failed refresh keeps the previous metadata.
+func (p *metadataProvider) run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := p.refresh(ctx); err != nil && ctx.Err() == nil {
+			p.log.Warnw("Failed to read ECS task metadata.", "url", p.url, "error", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *metadataProvider) refresh(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	var task taskMetadata
+	if err := json.NewDecoder(resp.Body).Decode(&task); err != nil {
+		return fmt.Errorf("failed to decode task metadata: %w", err)
+	}
+
+	p.mu.Lock()
+	p.task = &task
+	p.mu.Unlock()
+	return nil
+}
+
+// enrich adds the task metadata to the fields of an event. The values set
+// from the FireLens record are kept.
+func (p *metadataProvider) enrich(fields mapstr.M) {
+	p.mu.RLock()
+	task := p.task
+	p.mu.RUnlock()
+	if task == nil {
+		return
+	}
+
+	fields.DeepUpdateNoOverwrite(task.fields())
+
+	id, _ := fields.GetValue("container.id")
+	if id, ok := id.(string); ok {
+		if c, ok := task.container(id); ok {
+			if _, err := fields.GetValue("container.name"); err != nil {
+				_, _ = fields.Put("container.name", c.Name)
+			}
+			if c.Image != "" {
+				_, _ = fields.Put("container.image.name", c.Image)
+			}
+		}
+	}
+}
+
+func putNonEmpty(m mapstr.M, key, value string) {
+	if value != "" {
+		m[key] = value
+	}
+}
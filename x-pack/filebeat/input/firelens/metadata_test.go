@@ -0,0 +1,108 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package firelens
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const testTaskMetadata = `{
+  "Cluster": "arn:aws:ecs:us-west-2:111122223333:cluster/prod",
+  "TaskARN": "arn:aws:ecs:us-west-2:111122223333:task/prod/158d1c8083dd49d6b527399fd6414f5c",
+  "Family": "app",
+  "Revision": "7",
+  "DesiredStatus": "RUNNING",
+  "KnownStatus": "RUNNING",
+  "AvailabilityZone": "us-west-2b",
+  "LaunchType": "FARGATE",
+  "Containers": [
+    {"DockerId": "0123abcd", "Name": "app", "Image": "public.ecr.aws/nginx/nginx:1.25"},
+    {"DockerId": "4567efab", "Name": "log_router", "Image": "amazon/aws-for-fluent-bit:stable"}
+  ]
+}`
+
+func TestMetadataProvider(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v4/0123abcd/task" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(testTaskMetadata)) //nolint:errcheck // Test server.
+	}))
+	defer srv.Close()
+
+	p := newMetadataProvider(metadataConfig{URL: srv.URL + "/v4/0123abcd", Timeout: time.Second}, logp.NewNopLogger())
+
+	// Events are not enriched until the metadata was read.
+	fields := mapstr.M{"message": "hello"}
+	p.enrich(fields)
+	assert.Equal(t, mapstr.M{"message": "hello"}, fields)
+
+	require.NoError(t, p.refresh(context.Background()))
+
+	t.Run("record values are kept", func(t *testing.T) {
+		fields := mapstr.M{
+			"container": mapstr.M{"id": "0123abcd", "name": "/ecs-app-7-app-e4f2"},
+			"awsfargate": mapstr.M{
+				"task": mapstr.M{"cluster": "prod"},
+			},
+		}
+		p.enrich(fields)
+		assert.Equal(t, mapstr.M{
+			"container": mapstr.M{
+				"id":    "0123abcd",
+				"name":  "/ecs-app-7-app-e4f2",
+				"image": mapstr.M{"name": "public.ecr.aws/nginx/nginx:1.25"},
+			},
+			"awsfargate": mapstr.M{
+				"task": mapstr.M{
+					"arn":         "arn:aws:ecs:us-west-2:111122223333:task/prod/158d1c8083dd49d6b527399fd6414f5c",
+					"cluster":     "prod",
+					"family":      "app",
+					"revision":    "7",
+					"launch_type": "FARGATE",
+				},
+			},
+			"cloud": mapstr.M{
+				"provider":          "aws",
+				"region":            "us-west-2",
+				"availability_zone": "us-west-2b",
+				"account":           mapstr.M{"id": "111122223333"},
+			},
+		}, fields)
+	})
+
+	t.Run("unknown container", func(t *testing.T) {
+		fields := mapstr.M{"container": mapstr.M{"id": "ffff"}}
+		p.enrich(fields)
+		assert.Equal(t, mapstr.M{"id": "ffff"}, fields["container"])
+		assert.Equal(t, "arn:aws:ecs:us-west-2:111122223333:cluster/prod", mustGet(t, fields, "awsfargate.task.cluster"))
+	})
+
+	t.Run("failed refresh keeps the metadata", func(t *testing.T) {
+		bad := newMetadataProvider(metadataConfig{URL: srv.URL + "/missing", Timeout: time.Second}, logp.NewNopLogger())
+		assert.Error(t, bad.refresh(context.Background()))
+		bad.task = p.task
+		assert.Error(t, bad.refresh(context.Background()))
+		assert.Same(t, p.task, bad.task)
+	})
+}
+
+func mustGet(t *testing.T, m mapstr.M, key string) interface{} {
+	t.Helper()
+	v, err := m.GetValue(key)
+	require.NoError(t, err)
+	return v
+}
@@ -0,0 +1,29 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package firelens
+
+import (
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+type inputMetrics struct {
+	bindAddress           *monitoring.String // Bind address of input.
+	connectionsActive     *monitoring.Uint   // Number of open connections.
+	connectionsTotal      *monitoring.Uint   // Number of connections accepted.
+	messagesReceivedTotal *monitoring.Uint   // Number of forward protocol messages received.
+	messageErrorsTotal    *monitoring.Uint   // Number of messages that could not be read or decoded.
+	eventsReceivedTotal   *monitoring.Uint   // Number of events received.
+}
+
+func newInputMetrics(reg *monitoring.Registry) *inputMetrics {
+	return &inputMetrics{
+		bindAddress:           monitoring.NewString(reg, "bind_address"),
+		connectionsActive:     monitoring.NewUint(reg, "connections_active"),
+		connectionsTotal:      monitoring.NewUint(reg, "connections_total"),
+		messagesReceivedTotal: monitoring.NewUint(reg, "messages_received_total"),
+		messageErrorsTotal:    monitoring.NewUint(reg, "message_errors_total"),
+		eventsReceivedTotal:   monitoring.NewUint(reg, "events_received_total"),
+	}
+}
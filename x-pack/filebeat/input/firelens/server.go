@@ -0,0 +1,248 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package firelens
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ugorji/go/codec"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// server accepts forward protocol connections from the FireLens log router
+// of the task and publishes the received records.
+type server struct {
+	config   config
+	log      *logp.Logger
+	publish  func(beat.Event)
+	metadata *metadataProvider // nil when the enrichment is disabled.
+	metrics  *inputMetrics
+	handle   *codec.MsgpackHandle
+
+	listener net.Listener
+	wg       sync.WaitGroup
+
+	mu     sync.Mutex
+	conns  map[net.Conn]struct{}
+	closed bool
+}
+
+func newServer(c config, l net.Listener, log *logp.Logger, pub func(beat.Event), metadata *metadataProvider, metrics *inputMetrics) *server {
+	return &server{
+		config:   c,
+		log:      log,
+		publish:  pub,
+		metadata: metadata,
+		metrics:  metrics,
+		handle:   newMsgpackHandle(),
+		listener: l,
+		conns:    map[net.Conn]struct{}{},
+	}
+}
+
+// Run accepts connections until the server is closed.
+func (s *server) Run() error {
+	defer s.wg.Wait()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if s.isClosed() {
+				return nil
+			}
+			return err
+		}
+		if !s.track(conn) {
+			conn.Close()
+			return nil
+		}
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer s.untrack(conn)
+			s.handleConn(conn)
+		}()
+	}
+}
+
+// Close stops accepting connections and closes the open ones.
+func (s *server) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+	return s.listener.Close()
+}
+
+func (s *server) isClosed() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closed
+}
+
+func (s *server) track(conn net.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return false
+	}
+	s.conns[conn] = struct{}{}
+	s.metrics.connectionsActive.Inc()
+	s.metrics.connectionsTotal.Inc()
+	return true
+}
+
+func (s *server) untrack(conn net.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	conn.Close()
+	delete(s.conns, conn)
+	s.metrics.connectionsActive.Dec()
+}
+
+func (s *server) handleConn(conn net.Conn) {
+	log := s.log.With("remote_address", conn.RemoteAddr().String())
+	log.Debug("Accepted forward protocol connection.")
+
+	w := &ackWriter{enc: codec.NewEncoder(conn, s.handle)}
+	dec := codec.NewDecoder(bufio.NewReader(conn), s.handle)
+	for {
+		if s.config.Timeout > 0 {
+			_ = conn.SetReadDeadline(time.Now().Add(s.config.Timeout))
+		}
+		var raw []interface{}
+		err := dec.Decode(&raw)
+		if err != nil {
+			if !errors.Is(err, io.EOF) && !errors.Is(err, net.ErrClosed) && !s.isClosed() {
+				log.Warnw("Failed to read forward protocol message, closing the connection.", "error", err)
+				s.metrics.messageErrorsTotal.Inc()
+			}
+			return
+		}
+		s.metrics.messagesReceivedTotal.Inc()
+
+		msg, err := decodeForwardMessage(s.handle, raw)
+		if err != nil {
+			// The client resends the chunks that are not acknowledged,
+			// so an invalid message is dropped together with the
+			// connection.
+			log.Warnw("Invalid forward protocol message, closing the connection.", "error", err)
+			s.metrics.messageErrorsTotal.Inc()
+			return
+		}
+		s.processMessage(msg, w)
+	}
+}
+
+// processMessage publishes the entries of a message. When the client requires
+// an acknowledgement, it is sent once all the events were acknowledged by the
+// output.
+func (s *server) processMessage(msg *forwardMessage, w *ackWriter) {
+	s.metrics.eventsReceivedTotal.Add(uint64(len(msg.entries)))
+
+	var acker *messageACKTracker
+	if msg.chunk != "" {
+		chunk := msg.chunk
+		acker = newMessageACKTracker(func() {
+			if err := w.ack(chunk); err != nil {
+				s.log.Debugw("Failed to acknowledge chunk.", "chunk", chunk, "error", err)
+			}
+		})
+	}
+	for _, e := range msg.entries {
+		event := s.makeEvent(msg.tag, e)
+		if acker != nil {
+			acker.Add()
+			event.Private = acker
+		}
+		s.publish(event)
+	}
+	if acker != nil {
+		acker.Ready()
+	}
+}
+
+func (s *server) makeEvent(tag string, e forwardEntry) beat.Event {
+	record := e.record
+	fields := mapstr.M{}
+
+	// FireLens stores the log line in "log", and the stream and container
+	// it was read from in the other keys of the record.
+	if v, ok := record["log"].(string); ok {
+		fields["message"] = strings.TrimSuffix(v, "\n")
+		delete(record, "log")
+	}
+	if v, ok := record["source"].(string); ok {
+		fields["stream"] = v
+		delete(record, "source")
+	}
+	container := mapstr.M{}
+	moveString(record, "container_id", container, "id")
+	moveString(record, "container_name", container, "name")
+	if len(container) != 0 {
+		fields["container"] = container
+	}
+	task := mapstr.M{}
+	moveString(record, "ecs_cluster", task, "cluster")
+	moveString(record, "ecs_task_arn", task, "arn")
+	if v, ok := record["ecs_task_definition"].(string); ok {
+		// The task definition is reported as family:revision.
+		family, revision, _ := strings.Cut(v, ":")
+		task["family"] = family
+		if revision != "" {
+			task["revision"] = revision
+		}
+		delete(record, "ecs_task_definition")
+	}
+
+	logFields := mapstr.M{"tag": tag}
+	if len(record) != 0 {
+		logFields["record"] = mapstr.M(record)
+	}
+	awsfargate := mapstr.M{"log": logFields}
+	if len(task) != 0 {
+		awsfargate["task"] = task
+	}
+	fields["awsfargate"] = awsfargate
+
+	if s.metadata != nil {
+		s.metadata.enrich(fields)
+	}
+
+	return beat.Event{
+		Timestamp: e.time,
+		Fields:    fields,
+	}
+}
+
+func moveString(from map[string]interface{}, key string, to mapstr.M, name string) {
+	if v, ok := from[key].(string); ok {
+		to[name] = v
+		delete(from, key)
+	}
+}
+
+// ackWriter writes the acknowledgements of a connection. Acknowledgements are
+// sent from the pipeline ACK handler so the writes are serialized.
+type ackWriter struct {
+	mu  sync.Mutex
+	enc *codec.Encoder
+}
+
+func (w *ackWriter) ack(chunk string) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.enc.Encode(map[string]interface{}{"ack": chunk})
+}
@@ -17,6 +17,7 @@ import (
 
 	"github.com/elastic/beats/v7/libbeat/common/match"
 	"github.com/elastic/beats/v7/libbeat/reader/parser"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
 	"github.com/elastic/beats/v7/x-pack/libbeat/reader/decoder"
 	conf "github.com/elastic/elastic-agent-libs/config"
 )
@@ -77,6 +78,8 @@ type config struct {
 	AlternativeHost string `config:"alternative_host"`
 	// Retry - Defines the retry configuration for the input.
 	Retry retryConfig `config:"retry"`
+	// Download - Defines how interrupted object downloads are resumed, and how the downloads are limited and prefetched.
+	Download download.Config `config:"download"`
 }
 
 // bucket contains the config for each specific object storage bucket in the root account
@@ -205,5 +208,6 @@ func defaultConfig() config {
 			MaxBackOffDuration:     30 * time.Second,
 			BackOffMultiplier:      2,
 		},
+		Download: download.DefaultConfig(),
 	}
 }
@@ -20,6 +20,7 @@ import (
 	"github.com/elastic/beats/v7/libbeat/feature"
 	"github.com/elastic/beats/v7/libbeat/management/status"
 	"github.com/elastic/beats/v7/libbeat/statestore"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
 )
@@ -207,6 +208,7 @@ func (input *gcsInput) Run(inputCtx v2.Context, src cursor.Source,
 		storage.WithPolicy(storage.RetryAlways),
 	)
 	scheduler := newScheduler(publisher, bucket, currentSource, &input.config, st, &inputCtx, metrics, log)
+	scheduler.downloads = download.NewManager(input.config.Download, inputCtx.MetricsRegistry, log.Named("download"))
 
 	return scheduler.schedule(ctx)
 }
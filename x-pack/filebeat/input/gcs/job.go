@@ -22,6 +22,7 @@ import (
 	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
 	"github.com/elastic/beats/v7/libbeat/beat"
 	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
 	"github.com/elastic/beats/v7/x-pack/libbeat/reader"
 	"github.com/elastic/beats/v7/x-pack/libbeat/reader/decoder"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -56,6 +57,8 @@ type job struct {
 	log *logp.Logger
 	// flag used to denote if this object has previously failed without being processed at all.
 	isFailed bool
+	// downloads resumes, limits and prefetches the object download, if set.
+	downloads *download.Manager
 }
 
 // newJob, returns an instance of a job, which is a unit of work that can be assigned to a go routine
@@ -162,8 +165,16 @@ func (j *job) processAndPublishData(ctx context.Context, id string) error {
 		j.status.UpdateStatus(status.Degraded, "could not open object to read: "+err.Error())
 		return fmt.Errorf("failed to open reader for object: %s, with error: %w", j.object.Name, err)
 	}
+	// interrupted downloads are resumed from the same generation of the object.
+	body, err := j.downloads.Wrap(ctx, reader, func(ctx context.Context, offset int64) (io.ReadCloser, error) {
+		return obj.If(storage.Conditions{GenerationMatch: j.object.Generation}).NewRangeReader(ctx, offset, -1)
+	})
+	if err != nil {
+		j.status.UpdateStatus(status.Degraded, "could not download object: "+err.Error())
+		return fmt.Errorf("failed to download object: %s, with error: %w", j.object.Name, err)
+	}
 	defer func() {
-		err = reader.Close()
+		err = body.Close()
 		if err != nil {
 			j.metrics.errorsTotal.Inc()
 			j.log.Errorw("failed to close reader for object", "objectName", j.object.Name, "error", err)
@@ -174,7 +185,7 @@ func (j *job) processAndPublishData(ctx context.Context, id string) error {
 	j.metrics.sourceLagTime.Update(time.Since(j.object.Updated).Nanoseconds())
 
 	// calculate number of decode errors
-	if err := j.decode(ctx, reader, id); err != nil {
+	if err := j.decode(ctx, body, id); err != nil {
 		j.metrics.decodeErrorsTotal.Inc()
 		return fmt.Errorf("failed to decode object: %s, with error: %w", j.object.Name, err)
 	}
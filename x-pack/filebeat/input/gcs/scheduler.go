@@ -18,6 +18,7 @@ import (
 
 	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
 	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/monitoring"
 	"github.com/elastic/go-concert/timed"
@@ -40,6 +41,7 @@ type scheduler struct {
 	log       *logp.Logger
 	limiter   *limiter
 	metrics   *inputMetrics
+	downloads *download.Manager
 }
 
 // newScheduler, returns a new scheduler instance
@@ -196,6 +198,7 @@ func (s *scheduler) createJobs(objects []*storage.ObjectAttrs, log *logp.Logger)
 
 		objectURI := "gs://" + s.src.BucketName + "/" + obj.Name
 		job := newJob(s.bucket, obj, objectURI, s.state, s.src, s.publisher, s.status, s.metrics, log, false)
+		job.downloads = s.downloads
 		jobs = append(jobs, job)
 	}
 
@@ -257,6 +260,7 @@ func (s *scheduler) addFailedJobs(ctx context.Context, jobs []*job) []*job {
 
 			objectURI := "gs://" + s.src.BucketName + "/" + obj.Name
 			job := newJob(s.bucket, obj, objectURI, s.state, s.src, s.publisher, s.status, s.metrics, s.log, true)
+			job.downloads = s.downloads
 			jobs = append(jobs, job)
 			s.log.Debugf("scheduler: adding failed job number %d with name %s to job current list", fj, job.Name())
 			fj++
@@ -0,0 +1,355 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package http_endpoint
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// clientCertConfig holds additional checks applied to client certificates
+// presented during the TLS handshake.
+type clientCertConfig struct {
+	// PinnedSHA256 is a list of base64 encoded SHA-256 digests of the
+	// Subject Public Key Info of accepted client certificates.
+	PinnedSHA256 []string `config:"pinned_sha256"`
+
+	CRL  crlConfig  `config:"crl"`
+	OCSP ocspConfig `config:"ocsp"`
+}
+
+type crlConfig struct {
+	// Files is a list of PEM or DER encoded certificate revocation lists.
+	Files []string `config:"files"`
+	// RefreshInterval is how often the CRL files are reloaded.
+	RefreshInterval time.Duration `config:"refresh_interval"`
+}
+
+type ocspConfig struct {
+	Enabled bool `config:"enabled"`
+	// FailOpen accepts client certificates when the OCSP status
+	// can not be determined.
+	FailOpen bool          `config:"fail_open"`
+	Timeout  time.Duration `config:"timeout"`
+	// MaxCacheAge is the longest time an OCSP response is cached for.
+	MaxCacheAge time.Duration `config:"max_cache_age"`
+}
+
+func (c *clientCertConfig) Validate() error {
+	for _, p := range c.PinnedSHA256 {
+		b, err := base64.StdEncoding.DecodeString(p)
+		if err != nil || len(b) != sha256.Size {
+			return fmt.Errorf("client_certificate.pinned_sha256 value %q is not a base64 encoded SHA-256 digest", p)
+		}
+	}
+	if c.CRL.RefreshInterval < 0 {
+		return fmt.Errorf("client_certificate.crl.refresh_interval is negative: %v", c.CRL.RefreshInterval)
+	}
+	if c.CRL.RefreshInterval == 0 {
+		c.CRL.RefreshInterval = time.Hour
+	}
+	if c.OCSP.Timeout < 0 {
+		return fmt.Errorf("client_certificate.ocsp.timeout is negative: %v", c.OCSP.Timeout)
+	}
+	if c.OCSP.Timeout == 0 {
+		c.OCSP.Timeout = 5 * time.Second
+	}
+	if c.OCSP.MaxCacheAge < 0 {
+		return fmt.Errorf("client_certificate.ocsp.max_cache_age is negative: %v", c.OCSP.MaxCacheAge)
+	}
+	if c.OCSP.MaxCacheAge == 0 {
+		c.OCSP.MaxCacheAge = time.Hour
+	}
+	return nil
+}
+
+// clientCertVerifier checks client certificates against the configured
+// public key pins, CRLs and OCSP responders.
+type clientCertVerifier struct {
+	pins map[string]bool
+	crl  *crlStore
+	ocsp *ocspChecker
+	log  *logp.Logger
+}
+
+func newClientCertVerifier(cfg *clientCertConfig, log *logp.Logger) (*clientCertVerifier, error) {
+	v := &clientCertVerifier{log: log}
+	if len(cfg.PinnedSHA256) != 0 {
+		v.pins = make(map[string]bool, len(cfg.PinnedSHA256))
+		for _, p := range cfg.PinnedSHA256 {
+			// Validated in config.
+			b, _ := base64.StdEncoding.DecodeString(p)
+			v.pins[string(b)] = true
+		}
+	}
+	if len(cfg.CRL.Files) != 0 {
+		v.crl = &crlStore{
+			files:   cfg.CRL.Files,
+			refresh: cfg.CRL.RefreshInterval,
+			now:     time.Now,
+			log:     log,
+		}
+		err := v.crl.load()
+		if err != nil {
+			return nil, err
+		}
+	}
+	if cfg.OCSP.Enabled {
+		v.ocsp = &ocspChecker{
+			client:   &http.Client{Timeout: cfg.OCSP.Timeout},
+			failOpen: cfg.OCSP.FailOpen,
+			maxAge:   cfg.OCSP.MaxCacheAge,
+			cache:    make(map[string]ocspCacheEntry),
+			now:      time.Now,
+			log:      log,
+		}
+	}
+	return v, nil
+}
+
+// wrap adds the verifier's checks to the connection verification of cfg,
+// after any verification already performed by cfg.
+func (v *clientCertVerifier) wrap(cfg *tls.Config) {
+	verify := cfg.VerifyConnection
+	cfg.VerifyConnection = func(cs tls.ConnectionState) error {
+		if verify != nil {
+			err := verify(cs)
+			if err != nil {
+				return err
+			}
+		}
+		err := v.verifyConnection(cs)
+		if err != nil {
+			v.log.Warnw("rejected client certificate", "error", err)
+		}
+		return err
+	}
+}
+
+func (v *clientCertVerifier) verifyConnection(cs tls.ConnectionState) error {
+	if len(cs.PeerCertificates) == 0 {
+		return errors.New("no client certificate presented")
+	}
+	leaf := cs.PeerCertificates[0]
+	if v.pins != nil {
+		sum := sha256.Sum256(leaf.RawSubjectPublicKeyInfo)
+		if !v.pins[string(sum[:])] {
+			return fmt.Errorf("public key of client certificate %q is not pinned", leaf.Subject)
+		}
+	}
+	if v.crl != nil {
+		err := v.crl.check(leaf)
+		if err != nil {
+			return err
+		}
+	}
+	if v.ocsp != nil {
+		err := v.ocsp.check(leaf, issuerOf(cs))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// issuerOf returns the certificate that issued the client's leaf
+// certificate, or nil if it is not available.
+func issuerOf(cs tls.ConnectionState) *x509.Certificate {
+	for _, chain := range cs.VerifiedChains {
+		if len(chain) > 1 {
+			return chain[1]
+		}
+	}
+	leaf := cs.PeerCertificates[0]
+	for _, c := range cs.PeerCertificates[1:] {
+		if bytes.Equal(c.RawSubject, leaf.RawIssuer) {
+			return c
+		}
+	}
+	return nil
+}
+
+// crlStore holds the revoked serial numbers of the configured CRLs,
+// keyed on the raw issuer name of each list.
+type crlStore struct {
+	files   []string
+	refresh time.Duration
+	now     func() time.Time
+	log     *logp.Logger
+
+	mu      sync.Mutex
+	loaded  time.Time
+	revoked map[string]map[string]bool
+}
+
+func (s *crlStore) load() error {
+	revoked := make(map[string]map[string]bool)
+	for _, path := range s.files {
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read CRL file: %w", err)
+		}
+		ders := [][]byte{b}
+		if bytes.Contains(b, []byte("-----BEGIN")) {
+			ders = ders[:0]
+			for {
+				var block *pem.Block
+				block, b = pem.Decode(b)
+				if block == nil {
+					break
+				}
+				if block.Type == "X509 CRL" {
+					ders = append(ders, block.Bytes)
+				}
+			}
+		}
+		for _, der := range ders {
+			rl, err := x509.ParseRevocationList(der)
+			if err != nil {
+				return fmt.Errorf("failed to parse CRL in %s: %w", path, err)
+			}
+			if !rl.NextUpdate.IsZero() && s.now().After(rl.NextUpdate) {
+				s.log.Warnw("CRL is past its next update time", "path", path, "next_update", rl.NextUpdate)
+			}
+			serials := revoked[string(rl.RawIssuer)]
+			if serials == nil {
+				serials = make(map[string]bool)
+				revoked[string(rl.RawIssuer)] = serials
+			}
+			for _, e := range rl.RevokedCertificateEntries {
+				serials[e.SerialNumber.String()] = true
+			}
+		}
+	}
+	s.revoked = revoked
+	s.loaded = s.now()
+	return nil
+}
+
+func (s *crlStore) check(leaf *x509.Certificate) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.now().Sub(s.loaded) >= s.refresh {
+		err := s.load()
+		if err != nil {
+			// Keep using the previously loaded lists and retry
+			// after the next refresh interval.
+			s.loaded = s.now()
+			s.log.Errorw("failed to reload CRL files", "error", err)
+		}
+	}
+	if s.revoked[string(leaf.RawIssuer)][leaf.SerialNumber.String()] {
+		return fmt.Errorf("client certificate %q is revoked", leaf.Subject)
+	}
+	return nil
+}
+
+// ocspChecker queries the OCSP responders listed in client certificates,
+// caching responses until their next update time.
+type ocspChecker struct {
+	client   *http.Client
+	failOpen bool
+	maxAge   time.Duration
+	now      func() time.Time
+	log      *logp.Logger
+
+	mu    sync.Mutex
+	cache map[string]ocspCacheEntry
+}
+
+type ocspCacheEntry struct {
+	status  int
+	expires time.Time
+}
+
+func (c *ocspChecker) check(leaf, issuer *x509.Certificate) error {
+	status, err := c.status(leaf, issuer)
+	if err == nil {
+		switch status {
+		case ocsp.Good:
+			return nil
+		case ocsp.Revoked:
+			return fmt.Errorf("client certificate %q is revoked", leaf.Subject)
+		default:
+			err = errors.New("OCSP responder returned unknown status")
+		}
+	}
+	if c.failOpen {
+		c.log.Warnw("accepting client certificate with unknown OCSP status", "subject", leaf.Subject.String(), "error", err)
+		return nil
+	}
+	return fmt.Errorf("failed to check OCSP status of client certificate %q: %w", leaf.Subject, err)
+}
+
+func (c *ocspChecker) status(leaf, issuer *x509.Certificate) (int, error) {
+	if issuer == nil {
+		return 0, errors.New("issuer certificate not available")
+	}
+	if len(leaf.OCSPServer) == 0 {
+		return 0, errors.New("no OCSP responder in certificate")
+	}
+
+	key := string(issuer.RawSubjectPublicKeyInfo) + leaf.SerialNumber.String()
+	now := c.now()
+	c.mu.Lock()
+	cached, ok := c.cache[key]
+	c.mu.Unlock()
+	if ok && now.Before(cached.expires) {
+		return cached.status, nil
+	}
+
+	body, err := ocsp.CreateRequest(leaf, issuer, &ocsp.RequestOptions{Hash: crypto.SHA1})
+	if err != nil {
+		return 0, err
+	}
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, leaf.OCSPServer[0], bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/ocsp-request")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("OCSP responder returned %s", resp.Status)
+	}
+	body, err = io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return 0, err
+	}
+	r, err := ocsp.ParseResponseForCert(body, leaf, issuer)
+	if err != nil {
+		return 0, err
+	}
+
+	expires := now.Add(c.maxAge)
+	if !r.NextUpdate.IsZero() && r.NextUpdate.Before(expires) {
+		expires = r.NextUpdate
+	}
+	c.mu.Lock()
+	c.cache[key] = ocspCacheEntry{status: r.Status, expires: expires}
+	c.mu.Unlock()
+	return r.Status, nil
+}
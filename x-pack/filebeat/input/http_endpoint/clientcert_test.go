@@ -0,0 +1,188 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package http_endpoint
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ocsp"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+type testCA struct {
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func newTestCA(t *testing.T) testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign | x509.KeyUsageDigitalSignature,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return testCA{cert: cert, key: key}
+}
+
+func (ca testCA) issue(t *testing.T, serial int64, ocspURL string) *x509.Certificate {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "client"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	if ocspURL != "" {
+		tmpl.OCSPServer = []string{ocspURL}
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, key.Public(), ca.key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return cert
+}
+
+func connState(certs ...*x509.Certificate) tls.ConnectionState {
+	return tls.ConnectionState{PeerCertificates: certs}
+}
+
+func TestClientCertPinning(t *testing.T) {
+	ca := newTestCA(t)
+	pinned := ca.issue(t, 2, "")
+	other := ca.issue(t, 3, "")
+
+	sum := sha256.Sum256(pinned.RawSubjectPublicKeyInfo)
+	cfg := &clientCertConfig{PinnedSHA256: []string{base64.StdEncoding.EncodeToString(sum[:])}}
+	require.NoError(t, cfg.Validate())
+	v, err := newClientCertVerifier(cfg, logp.NewLogger("test"))
+	require.NoError(t, err)
+
+	assert.NoError(t, v.verifyConnection(connState(pinned, ca.cert)))
+	assert.ErrorContains(t, v.verifyConnection(connState(other, ca.cert)), "is not pinned")
+	assert.ErrorContains(t, v.verifyConnection(connState()), "no client certificate presented")
+}
+
+func TestClientCertCRL(t *testing.T) {
+	ca := newTestCA(t)
+	good := ca.issue(t, 2, "")
+	revoked := ca.issue(t, 3, "")
+
+	crl, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(1),
+		ThisUpdate: time.Now().Add(-time.Minute),
+		NextUpdate: time.Now().Add(time.Hour),
+		RevokedCertificateEntries: []x509.RevocationListEntry{
+			{SerialNumber: revoked.SerialNumber, RevocationTime: time.Now()},
+		},
+	}, ca.cert, ca.key)
+	require.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "ca.crl")
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "X509 CRL", Bytes: crl}), 0o600))
+
+	cfg := &clientCertConfig{CRL: crlConfig{Files: []string{path}}}
+	require.NoError(t, cfg.Validate())
+	v, err := newClientCertVerifier(cfg, logp.NewLogger("test"))
+	require.NoError(t, err)
+
+	assert.NoError(t, v.verifyConnection(connState(good, ca.cert)))
+	assert.ErrorContains(t, v.verifyConnection(connState(revoked, ca.cert)), "is revoked")
+
+	_, err = newClientCertVerifier(&clientCertConfig{CRL: crlConfig{Files: []string{filepath.Join(t.TempDir(), "missing.crl")}}}, logp.NewLogger("test"))
+	assert.ErrorContains(t, err, "failed to read CRL file")
+}
+
+func TestClientCertOCSP(t *testing.T) {
+	ca := newTestCA(t)
+
+	var (
+		requests  atomic.Int64
+		revokedSN = big.NewInt(3)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req, err := ocsp.ParseRequest(body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		tmpl := ocsp.Response{
+			Status:       ocsp.Good,
+			SerialNumber: req.SerialNumber,
+			ThisUpdate:   time.Now().Add(-time.Minute),
+			NextUpdate:   time.Now().Add(time.Hour),
+		}
+		if req.SerialNumber.Cmp(revokedSN) == 0 {
+			tmpl.Status = ocsp.Revoked
+			tmpl.RevokedAt = time.Now().Add(-time.Minute)
+		}
+		resp, err := ocsp.CreateResponse(ca.cert, ca.cert, tmpl, ca.key)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(resp)
+	}))
+	defer srv.Close()
+
+	good := ca.issue(t, 2, srv.URL)
+	revoked := ca.issue(t, revokedSN.Int64(), srv.URL)
+	noResponder := ca.issue(t, 4, "")
+
+	cfg := &clientCertConfig{OCSP: ocspConfig{Enabled: true}}
+	require.NoError(t, cfg.Validate())
+	v, err := newClientCertVerifier(cfg, logp.NewLogger("test"))
+	require.NoError(t, err)
+
+	assert.NoError(t, v.verifyConnection(connState(good, ca.cert)))
+	assert.NoError(t, v.verifyConnection(connState(good, ca.cert)))
+	assert.Equal(t, int64(1), requests.Load(), "expected cached OCSP response to be used")
+
+	assert.ErrorContains(t, v.verifyConnection(connState(revoked, ca.cert)), "is revoked")
+	assert.ErrorContains(t, v.verifyConnection(connState(noResponder, ca.cert)), "no OCSP responder")
+	assert.ErrorContains(t, v.verifyConnection(connState(good)), "issuer certificate not available")
+
+	v.ocsp.failOpen = true
+	assert.NoError(t, v.verifyConnection(connState(noResponder, ca.cert)))
+	assert.ErrorContains(t, v.verifyConnection(connState(revoked, ca.cert)), "is revoked")
+}
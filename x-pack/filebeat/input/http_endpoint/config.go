@@ -27,6 +27,7 @@ var crcProviders = map[string]func(string) *crcValidator{
 type config struct {
 	Method                string                  `config:"method"`
 	TLS                   *tlscommon.ServerConfig `config:"ssl"`
+	ClientCertificate     *clientCertConfig       `config:"client_certificate"`
 	BasicAuth             bool                    `config:"basic_auth"`
 	Username              string                  `config:"username"`
 	Password              string                  `config:"password"`
@@ -122,6 +123,10 @@ func (c *config) Validate() error {
 		return errors.New("crc.provider is required when crc.secret is defined")
 	}
 
+	if c.ClientCertificate != nil && !c.TLS.IsEnabled() {
+		return errors.New("client_certificate requires ssl to be enabled")
+	}
+
 	if c.MaxBodySize != nil && *c.MaxBodySize < 0 {
 		return fmt.Errorf("max_body_bytes is negative: %d", *c.MaxBodySize)
 	}
@@ -58,6 +58,16 @@ func Test_validateConfig(t *testing.T) {
 				Tracer:       &tracerConfig{Enabled: ptrTo(true), Logger: lumberjack.Logger{Filename: "http_endpoint/log"}},
 			},
 		},
+		{
+			name: "client certificate checks without ssl",
+			config: config{
+				URL:               "/",
+				ResponseBody:      `{"message": "success"}`,
+				Method:            http.MethodPost,
+				ClientCertificate: &clientCertConfig{OCSP: ocspConfig{Enabled: true}},
+			},
+			wantError: errors.New("client_certificate requires ssl to be enabled accessing config"),
+		},
 		{
 			name: "invalid_log_destination_accepted_at_config_time",
 			config: config{
@@ -90,6 +90,13 @@ func newHTTPEndpoint(config config, logger *logp.Logger) (*httpEndpoint, error)
 	}
 	if tlsConfigBuilder != nil {
 		tlsConfig = tlsConfigBuilder.BuildServerConfig(addr)
+		if config.ClientCertificate != nil {
+			verifier, err := newClientCertVerifier(config.ClientCertificate, logger)
+			if err != nil {
+				return nil, err
+			}
+			verifier.wrap(tlsConfig)
+		}
 	}
 
 	return &httpEndpoint{
@@ -207,6 +214,9 @@ func (p *pool) serve(ctx v2.Context, e *httpEndpoint, pub func(beat.Event), metr
 	s, ok := p.servers[e.addr]
 	if ok {
 		err = checkTLSConsistency(e.addr, s.tls, e.config.TLS)
+		if err == nil && !reflect.DeepEqual(s.clientCert, e.config.ClientCertificate) {
+			err = invalidTLSStateErr{addr: e.addr, reason: "client_certificate options do not agree"}
+		}
 		if err != nil {
 			p.mu.Unlock()
 			handlerCancel()
@@ -234,6 +244,7 @@ func (p *pool) serve(ctx v2.Context, e *httpEndpoint, pub func(beat.Event), metr
 			idOf:          map[string]string{pattern: ctx.ID},
 			handlerCancel: map[string]context.CancelFunc{pattern: handlerCancel},
 			tls:           e.config.TLS,
+			clientCert:    e.config.ClientCertificate,
 			mux:           m,
 			srv:           srv,
 			done:          make(chan struct{}),
@@ -369,7 +380,8 @@ type server struct {
 	// that handler's context, aborting in-flight ACK waits.
 	handlerCancel map[string]context.CancelFunc
 
-	tls *tlscommon.ServerConfig
+	tls        *tlscommon.ServerConfig
+	clientCert *clientCertConfig
 
 	mux *mux
 	srv *http.Server
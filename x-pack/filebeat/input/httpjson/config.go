@@ -10,6 +10,8 @@ import (
 	"strings"
 	"time"
 
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/apihealth"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
 	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
 )
 
@@ -20,6 +22,9 @@ type config struct {
 	Response *responseConfig `config:"response"`
 	Cursor   cursorConfig    `config:"cursor"`
 	Chain    []chainConfig   `config:"chain"`
+
+	// APIHealth configures publication of API health status events.
+	APIHealth apihealth.Config `config:"api_health"`
 }
 
 type cursorConfig map[string]cursorEntry
@@ -76,6 +81,7 @@ func defaultConfig() config {
 			},
 			RedirectForwardHeaders: false,
 			RedirectMaxRedirects:   10,
+			Download:               download.DefaultConfig(),
 			Transport:              transport,
 		},
 		Response: &responseConfig{},
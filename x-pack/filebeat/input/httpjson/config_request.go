@@ -14,6 +14,7 @@ import (
 
 	"gopkg.in/natefinch/lumberjack.v2"
 
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
 )
@@ -132,6 +133,7 @@ type requestConfig struct {
 	Method                 string           `config:"method" validate:"required"`
 	Body                   *mapstr.M        `config:"body"`
 	EncodeAs               string           `config:"encode_as"`
+	SOAP                   *soapConfig      `config:"soap"`
 	Retry                  retryConfig      `config:"retry"`
 	RedirectForwardHeaders bool             `config:"redirect.forward_headers"`
 	RedirectHeadersBanList []string         `config:"redirect.headers_ban_list"`
@@ -139,6 +141,7 @@ type requestConfig struct {
 	RateLimit              *rateLimitConfig `config:"rate_limit"`
 	KeepAlive              keepAlive        `config:"keep_alive"`
 	Transforms             transformsConfig `config:"transforms"`
+	Download               download.Config  `config:"download"`
 
 	Transport httpcommon.HTTPTransportSettings `config:",inline"`
 
@@ -176,6 +179,17 @@ func (c *requestConfig) Validate() error {
 		}
 	}
 
+	if c.SOAP != nil {
+		switch {
+		case c.Method != http.MethodPost:
+			return errors.New("soap can only be used with method: \"POST\"")
+		case c.Body != nil:
+			return errors.New("soap can't be used together with body")
+		case c.EncodeAs != "":
+			return errors.New("soap can't be used together with encode_as")
+		}
+	}
+
 	if c.Tracer.enabled() {
 		if c.Tracer.Filename == "" {
 			return errors.New("request tracer must have a filename if used")
@@ -6,6 +6,7 @@ package httpjson
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 )
 
@@ -18,6 +19,7 @@ const (
 type responseConfig struct {
 	DecodeAs                string           `config:"decode_as"`
 	XSD                     string           `config:"xsd"`
+	XML                     xmlDecodeConfig  `config:"xml"`
 	RequestBodyOnPagination bool             `config:"request_body_on_pagination"`
 	Transforms              transformsConfig `config:"transforms"`
 	Pagination              transformsConfig `config:"pagination"`
@@ -25,6 +27,12 @@ type responseConfig struct {
 	SaveFirstResponse       bool             `config:"save_first_response"`
 }
 
+type xmlDecodeConfig struct {
+	// ForceArray is a list of dotted element paths that are always
+	// decoded as arrays, even when the document holds a single element.
+	ForceArray []string `config:"force_array"`
+}
+
 type splitConfig struct {
 	Target           string           `config:"target" validation:"required"`
 	Type             string           `config:"type"`
@@ -48,9 +56,27 @@ func (c *responseConfig) Validate() error {
 			return fmt.Errorf("decoder not found for contentType: %v", c.DecodeAs)
 		}
 	}
+	for _, p := range c.XML.ForceArray {
+		if p == "" || slices.Contains(strings.Split(p, "."), "") {
+			return fmt.Errorf("invalid xml.force_array path: %q", p)
+		}
+	}
 	return nil
 }
 
+// forceArrayPaths returns the xml.force_array paths split into
+// their element names.
+func (c xmlDecodeConfig) forceArrayPaths() [][]string {
+	if len(c.ForceArray) == 0 {
+		return nil
+	}
+	paths := make([][]string, len(c.ForceArray))
+	for i, p := range c.ForceArray {
+		paths[i] = strings.Split(p, ".")
+	}
+	return paths
+}
+
 func (c *splitConfig) Validate() error {
 	if _, err := newBasicTransformsFromConfig(registeredTransforms, c.Transforms, responseNamespace, noopReporter{}, nil); err != nil {
 		return err
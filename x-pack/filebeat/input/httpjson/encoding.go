@@ -53,7 +53,11 @@ var (
 		"text/csv":                decodeAsCSV,
 		"application/zip":         decodeAsZip,
 		"application/xml":         decodeAsXML,
+		"text/xml":                decodeAsXML,
 		"text/xml; charset=utf-8": decodeAsXML,
+
+		"application/soap+xml":                decodeAsXML,
+		"application/soap+xml; charset=utf-8": decodeAsXML,
 	}
 	// defaultDecoder is the decoder used when no registers
 	// decoder is available.
@@ -196,11 +200,38 @@ func decodeAsXML(p []byte, dst *response) error {
 	if err != nil {
 		return textContextError{error: err, body: p}
 	}
+	for _, path := range dst.xmlArrays {
+		forceXMLArray(body, path)
+	}
 	dst.body = body
 	dst.header["XML-CDATA"] = []string{cdata}
 	return nil
 }
 
+// forceXMLArray wraps the element at path in v in an array if it was
+// decoded as a single value. Arrays found along the path are traversed
+// element-wise.
+func forceXMLArray(v interface{}, path []string) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		elem, ok := v[path[0]]
+		if !ok {
+			return
+		}
+		if len(path) > 1 {
+			forceXMLArray(elem, path[1:])
+			return
+		}
+		if _, ok := elem.([]interface{}); !ok {
+			v[path[0]] = []interface{}{elem}
+		}
+	case []interface{}:
+		for _, elem := range v {
+			forceXMLArray(elem, path)
+		}
+	}
+}
+
 // textContextError is an error that can provide the text context for
 // a decoding error from the csv, json and xml packages.
 type textContextError struct {
@@ -261,6 +261,34 @@ func TestDecodeXML(t *testing.T) {
 	}
 }
 
+func TestDecodeXMLForceArray(t *testing.T) {
+	body := `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope>
+        <Body>
+                <Result>
+                        <Event><id>1</id></Event>
+                </Result>
+                <Result>
+                        <Event><id>2</id></Event>
+                        <Event><id>3</id></Event>
+                </Result>
+        </Body>
+</Envelope>
+`
+	resp := &response{
+		header:    make(http.Header),
+		xmlArrays: [][]string{{"Envelope", "Body", "Result", "Event"}, {"Envelope", "Missing"}},
+	}
+	err := decodeAsXML([]byte(body), resp)
+	assert.NoError(t, err)
+
+	j, err := json.Marshal(resp.body)
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	assert.JSONEq(t, `{"Envelope":{"Body":{"Result":[{"Event":[{"id":"1"}]},{"Event":[{"id":"2"},{"id":"3"}]}]}}}`, string(j))
+}
+
 func TestEncodeAsForm(t *testing.T) {
 	tests := []struct {
 		params map[string]string
@@ -35,6 +35,8 @@ import (
 	"github.com/elastic/beats/v7/libbeat/management/status"
 	"github.com/elastic/beats/v7/libbeat/statestore"
 	"github.com/elastic/beats/v7/libbeat/version"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/apihealth"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/httplog"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/httpmon"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/private"
@@ -208,11 +210,14 @@ func run(ctx v2.Context, cfg config, pub inputcursor.Publisher, crsr *inputcurso
 	}
 
 	metrics := newInputMetrics(reg, ctx.Logger)
-	client, err := newHTTPClient(stdCtx, cfg.Auth, cfg.Request, ctx, log, reg, nil)
+	apiHealth := apihealth.NewTracker(cfg.APIHealth, inputName, ctx.IDWithoutName)
+	resource := cfg.Request.URL.String()
+	client, err := newHTTPClient(stdCtx, cfg.Auth, cfg.Request, ctx, log, reg, nil, apiHealth)
 	if err != nil {
 		ctx.UpdateStatus(status.Failed, "failed to create HTTP client: "+err.Error())
 		return err
 	}
+	client.endpoint, client.metrics = "request", metrics
 
 	requestFactory, err := newRequestFactory(stdCtx, cfg, ctx, log, metrics, reg)
 	if err != nil {
@@ -247,6 +252,7 @@ func run(ctx v2.Context, cfg config, pub inputcursor.Publisher, crsr *inputcurso
 		log.Info("Process another repeated request.")
 
 		startTime := time.Now()
+		lastCursor := trCtx.cursorMap().String()
 
 		var err error
 		if err = requester.doRequest(stdCtx, trCtx, pub); err != nil {
@@ -260,6 +266,17 @@ func run(ctx v2.Context, cfg config, pub inputcursor.Publisher, crsr *inputcurso
 			} else {
 				log.Errorw("Error while processing http request", "error", err)
 			}
+			apiHealth.Failed(resource, err)
+		} else {
+			apiHealth.Succeeded(resource)
+		}
+		if c := trCtx.cursorMap(); len(c) != 0 && c.String() != lastCursor {
+			apiHealth.CursorUpdated(resource, time.Now())
+		}
+		if err := apiHealth.PublishDue(func(e beat.Event) error {
+			return pub.Publish(e, nil)
+		}); err != nil {
+			log.Errorw("failed to publish API health status", "error", err)
 		}
 
 		metrics.updateIntervalMetrics(err, startTime)
@@ -300,7 +317,7 @@ func sanitizeFileName(name string) string {
 // sharing common OAuth2 client if it is configured. If authCfg.OAuth2.isEnabled() is true
 // and there is no prepared OAuth2 client, one will be constructed and cached in the
 // authCfg.OAuth2.prepared field, otherwise the existing cached client will be used.
-func newHTTPClient(ctx context.Context, authCfg *authConfig, requestCfg *requestConfig, stat status.StatusReporter, log *logp.Logger, reg *monitoring.Registry, p *Policy) (*httpClient, error) {
+func newHTTPClient(ctx context.Context, authCfg *authConfig, requestCfg *requestConfig, stat status.StatusReporter, log *logp.Logger, reg *monitoring.Registry, p *Policy, health *apihealth.Tracker) (*httpClient, error) {
 	var (
 		client *http.Client
 		err    error
@@ -350,6 +367,15 @@ func newHTTPClient(ctx context.Context, authCfg *authConfig, requestCfg *request
 		}
 	}
 
+	if health != nil {
+		// The OAuth2 client may be shared, so wrap a copy. The health
+		// transport is placed inside the retry client so that retried
+		// throttling and authentication failures are recorded.
+		c := *client
+		c.Transport = health.Transport(c.Transport, requestCfg.URL.String())
+		client = &c
+	}
+
 	if requestCfg.Retry.getMaxAttempts() > 1 {
 		retryPolicy := retryablehttp.DefaultRetryPolicy
 		if p != nil {
@@ -382,6 +408,7 @@ func newNetHTTPClient(ctx context.Context, cfg *requestConfig, log *logp.Logger,
 	if err != nil {
 		return nil, err
 	}
+	netHTTPClient.Transport = download.NewManager(cfg.Download, reg, log.Named("download")).RoundTripper(netHTTPClient.Transport)
 
 	if cfg.Tracer.enabled() {
 		w := zapcore.AddSync(cfg.Tracer)
@@ -1773,6 +1773,65 @@ func TestInput(t *testing.T) {
 	}
 }
 
+func TestAPIHealth(t *testing.T) {
+	logp.TestingSetup()
+
+	baseConfig := map[string]interface{}{
+		"interval":                     1,
+		"request.method":               http.MethodGet,
+		"request.rate_limit.limit":     `[[.last_response.header.Get "X-Rate-Limit-Limit"]]`,
+		"request.rate_limit.remaining": `[[.last_response.header.Get "X-Rate-Limit-Remaining"]]`,
+		"request.rate_limit.reset":     `[[.last_response.header.Get "X-Rate-Limit-Reset"]]`,
+		"api_health.enabled":           true,
+		"api_health.interval":          "1ms",
+	}
+	newTestServer(httptest.NewServer)(t, rateLimitHandler(), baseConfig)
+
+	cfg := defaultConfig()
+	err := conf.MustNewConfigFrom(baseConfig).Unpack(&cfg)
+	assert.NoError(t, err)
+	input := newStatelessInput(cfg)
+
+	chanClient := beattest.NewChanClient(10)
+	t.Cleanup(func() { _ = chanClient.Close() })
+	ctx, cancel, err := newV2Context("httpjson-health")
+	t.Cleanup(cancel)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var g errgroup.Group
+	g.Go(func() error {
+		return input.Run(ctx, chanClient)
+	})
+
+	timeout := time.NewTimer(5 * time.Second)
+	t.Cleanup(func() { _ = timeout.Stop() })
+	var health mapstr.M
+wait:
+	for {
+		select {
+		case <-timeout.C:
+			t.Fatal("timed out waiting for API health event")
+		case got := <-chanClient.Channel:
+			v, err := got.Fields.GetValue("api_health")
+			if err != nil {
+				continue
+			}
+			health = v.(mapstr.M)
+			break wait
+		}
+	}
+	cancel()
+	assert.NoError(t, g.Wait())
+
+	assert.Equal(t, baseConfig["request.url"], health["resource"])
+	assert.Equal(t, "healthy", health["status"])
+	assert.Equal(t, uint64(1), health["throttled_total"])
+	assert.Equal(t, mapstr.M{"type": "httpjson", "id": "httpjson-health"}, health["input"])
+	assert.Equal(t, mapstr.M{"status": "ok"}, health["auth"])
+}
+
 func BenchmarkInput(b *testing.B) {
 	for _, test := range testCases {
 		b.Run(test.name, func(b *testing.B) {
@@ -9,19 +9,23 @@ import (
 
 	"github.com/rcrowley/go-metrics"
 
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/httpmon"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/schemadrift"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/monitoring"
 	"github.com/elastic/elastic-agent-libs/monitoring/adapter"
 )
 
 type inputMetrics struct {
-	intervalExecutionTime     metrics.Sample   // histogram of the total time elapsed during an interval
-	intervalPageExecutionTime metrics.Sample   // histogram of per page execution time during an interval
-	intervalPages             metrics.Sample   // histogram of pages per interval
-	intervals                 *monitoring.Uint // total number of intervals executed
-	intervalErrs              *monitoring.Uint // total number of interval errors
-	eventsPublished           *monitoring.Uint // number of events published
-	pagesPublished            *monitoring.Uint // number of pages of event published
+	intervalExecutionTime     metrics.Sample          // histogram of the total time elapsed during an interval
+	intervalPageExecutionTime metrics.Sample          // histogram of per page execution time during an interval
+	intervalPages             metrics.Sample          // histogram of pages per interval
+	intervals                 *monitoring.Uint        // total number of intervals executed
+	intervalErrs              *monitoring.Uint        // total number of interval errors
+	eventsPublished           *monitoring.Uint        // number of events published
+	pagesPublished            *monitoring.Uint        // number of pages of event published
+	endpointErrors            *httpmon.EndpointErrors // number of failed requests by endpoint and error class
+	schema                    *schemadrift.Tracker    // fields of the published events
 }
 
 func newInputMetrics(reg *monitoring.Registry, logger *logp.Logger) *inputMetrics {
@@ -37,6 +41,8 @@ func newInputMetrics(reg *monitoring.Registry, logger *logp.Logger) *inputMetric
 		intervalExecutionTime:     metrics.NewUniformSample(1024),
 		intervalPageExecutionTime: metrics.NewUniformSample(1024),
 		intervalPages:             metrics.NewUniformSample(1024),
+		endpointErrors:            httpmon.NewEndpointErrors(reg),
+		schema:                    schemadrift.NewTracker(reg),
 	}
 
 	_ = adapter.GetGoMetrics(reg, "httpjson_interval_execution_time", logger, adapter.Accept).
@@ -56,6 +62,23 @@ func (m *inputMetrics) addEventsPublished(n uint64) {
 	m.eventsPublished.Add(n)
 }
 
+// addEndpointError counts a failed request to endpoint.
+func (m *inputMetrics) addEndpointError(endpoint, class string) {
+	if m == nil {
+		return
+	}
+	m.endpointErrors.Add(endpoint, class)
+}
+
+// observeSchema records the fields of an event, and returns the fields not
+// seen before.
+func (m *inputMetrics) observeSchema(event map[string]interface{}) []string {
+	if m == nil {
+		return nil
+	}
+	return m.schema.Observe(event)
+}
+
 func (m *inputMetrics) updateIntervalMetrics(err error, t time.Time) {
 	if m == nil {
 		return
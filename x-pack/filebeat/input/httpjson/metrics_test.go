@@ -94,6 +94,7 @@ func TestMetrics(t *testing.T) {
 					"http_response_body_bytes", "http_response_total",
 					"http_round_trip_time", "httpjson_interval_execution_time",
 					"httpjson_interval_pages_execution_time", "httpjson_interval_total",
+					"schema_fields",
 				} {
 					if !checkHasValue(snapshot[m]) {
 						return fmt.Errorf("expected non zero value for metric %s", m)
@@ -12,6 +12,7 @@ import (
 	"net/url"
 
 	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/httpmon"
 	"github.com/elastic/mito/lib/xml"
 
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -24,6 +25,7 @@ type pagination struct {
 	client         *httpClient
 	requestFactory *requestFactory
 	decoder        decoderFunc
+	xmlArrays      [][]string
 	status         status.StatusReporter
 	log            *logp.Logger
 }
@@ -35,6 +37,7 @@ func newPagination(config config, client *httpClient, stat status.StatusReporter
 	}
 
 	pagination.decoder = registeredDecoders[config.Response.DecodeAs]
+	pagination.xmlArrays = config.Response.XML.forceArrayPaths()
 
 	if len(config.Response.Pagination) == 0 {
 		return pagination
@@ -57,6 +60,7 @@ func newPagination(config config, client *httpClient, stat status.StatusReporter
 		body,
 		append(rts, pts...),
 		config.Auth,
+		config.Request.SOAP,
 		stat,
 		log,
 	)
@@ -64,7 +68,7 @@ func newPagination(config config, client *httpClient, stat status.StatusReporter
 	return pagination
 }
 
-func newPaginationRequestFactory(method, encodeAs string, url url.URL, body *mapstr.M, ts []basicTransform, authConfig *authConfig, stat status.StatusReporter, log *logp.Logger) *requestFactory {
+func newPaginationRequestFactory(method, encodeAs string, url url.URL, body *mapstr.M, ts []basicTransform, authConfig *authConfig, soap *soapConfig, stat status.StatusReporter, log *logp.Logger) *requestFactory {
 	// config validation already checked for errors here
 	rf := &requestFactory{
 		url:        url,
@@ -73,6 +77,7 @@ func newPaginationRequestFactory(method, encodeAs string, url url.URL, body *map
 		transforms: ts,
 		log:        log,
 		encoder:    registeredEncoders[encodeAs],
+		soap:       newSOAPEnvelope(soap, stat, log),
 	}
 	if authConfig != nil && authConfig.Basic.isEnabled() {
 		rf.user = authConfig.Basic.User
@@ -182,13 +187,15 @@ func (iter *pageIterator) getPage() (*response, error) {
 	iter.n++
 
 	if len(bodyBytes) > 0 {
+		r.xmlDetails = iter.xmlDetails
+		r.xmlArrays = iter.pagination.xmlArrays
 		if iter.pagination.decoder != nil {
 			err = iter.pagination.decoder(bodyBytes, &r)
 		} else {
-			r.xmlDetails = iter.xmlDetails
 			err = decode(iter.resp.Header.Get("Content-Type"), bodyBytes, &r)
 		}
 		if err != nil {
+			iter.pagination.client.addError(httpmon.ErrorClassDecode)
 			iter.status.UpdateStatus(status.Degraded, "failed to decode page: "+err.Error())
 			return nil, err
 		}
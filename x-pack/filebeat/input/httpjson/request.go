@@ -21,6 +21,7 @@ import (
 
 	inputcursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
 	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/httpmon"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 	"github.com/elastic/elastic-agent-libs/monitoring"
@@ -32,6 +33,10 @@ const requestNamespace = "request"
 type httpClient struct {
 	client  *http.Client
 	limiter *rateLimiter
+
+	// endpoint is the name the failed requests are counted for in metrics.
+	endpoint string
+	metrics  *inputMetrics
 }
 
 // httpError represents an HTTP error with status code, message, and body.
@@ -274,10 +279,12 @@ func (c *httpClient) do(ctx context.Context, req *http.Request) (*http.Response,
 		return resp, err
 	})
 	if err != nil {
+		c.addError(httpmon.RequestErrorClass(err))
 		return nil, err
 	}
 
 	if resp.StatusCode >= http.StatusBadRequest {
+		c.addError(httpmon.StatusErrorClass(resp.StatusCode))
 		body, _ := io.ReadAll(resp.Body)
 		return nil, &httpError{
 			StatusCode: resp.StatusCode,
@@ -288,6 +295,14 @@ func (c *httpClient) do(ctx context.Context, req *http.Request) (*http.Response,
 	return resp, nil
 }
 
+// addError counts a failed request of class to the endpoint of the client.
+func (c *httpClient) addError(class string) {
+	if c == nil {
+		return
+	}
+	c.metrics.addEndpointError(c.endpoint, class)
+}
+
 type requestFactory struct {
 	chainClient            *httpClient
 	url                    url.URL
@@ -297,6 +312,7 @@ type requestFactory struct {
 	user                   string
 	password               string
 	encoder                encoderFunc
+	soap                   *soapEnvelope
 	replace                string
 	replaceWith            string
 	isChain                bool
@@ -318,6 +334,7 @@ func newRequestFactory(ctx context.Context, config config, stat status.StatusRep
 		transforms:        ts,
 		log:               log,
 		encoder:           registeredEncoders[config.Request.EncodeAs],
+		soap:              newSOAPEnvelope(config.Request.SOAP, stat, log),
 		saveFirstResponse: config.Response.SaveFirstResponse,
 	}
 	if config.Auth != nil && config.Auth.Basic.isEnabled() {
@@ -335,16 +352,18 @@ func newRequestFactory(ctx context.Context, config config, stat status.StatusRep
 		}
 	}
 	rfs = append(rfs, rf)
-	for _, ch := range config.Chain {
+	for i, ch := range config.Chain {
 		var rf *requestFactory
+		endpoint := fmt.Sprintf("chain_%d", i+1)
 		// chain calls requestFactory object
 		if ch.Step != nil {
 			ts, _ := newBasicTransformsFromConfig(registeredTransforms, ch.Step.Request.Transforms, requestNamespace, stat, log)
 			ch.Step.Auth = tryAssignAuth(config.Auth, ch.Step.Auth)
-			client, err := newHTTPClient(ctx, ch.Step.Auth, ch.Step.Request, stat, log, reg, nil)
+			client, err := newHTTPClient(ctx, ch.Step.Auth, ch.Step.Request, stat, log, reg, nil, nil)
 			if err != nil {
 				return nil, fmt.Errorf("failed in creating chain http client with error: %w", err)
 			}
+			client.endpoint, client.metrics = endpoint, metrics
 
 			responseProcessor := newChainResponseProcessor(ch, client, xmlDetails, metrics, stat, log)
 			rf = &requestFactory{
@@ -354,6 +373,7 @@ func newRequestFactory(ctx context.Context, config config, stat status.StatusRep
 				transforms:             ts,
 				log:                    log,
 				encoder:                registeredEncoders[config.Request.EncodeAs],
+				soap:                   newSOAPEnvelope(ch.Step.Request.SOAP, stat, log),
 				replace:                ch.Step.Replace,
 				replaceWith:            ch.Step.ReplaceWith,
 				isChain:                true,
@@ -368,10 +388,11 @@ func newRequestFactory(ctx context.Context, config config, stat status.StatusRep
 			ts, _ := newBasicTransformsFromConfig(registeredTransforms, ch.While.Request.Transforms, requestNamespace, stat, log)
 			policy := newHTTPPolicy(evaluateResponse, ch.While.Until, stat, log)
 			ch.While.Auth = tryAssignAuth(config.Auth, ch.While.Auth)
-			client, err := newHTTPClient(ctx, ch.While.Auth, ch.While.Request, stat, log, reg, policy)
+			client, err := newHTTPClient(ctx, ch.While.Auth, ch.While.Request, stat, log, reg, policy, nil)
 			if err != nil {
 				return nil, fmt.Errorf("failed in creating chain http client with error: %w", err)
 			}
+			client.endpoint, client.metrics = endpoint, metrics
 
 			responseProcessor := newChainResponseProcessor(ch, client, xmlDetails, metrics, stat, log)
 			rf = &requestFactory{
@@ -381,6 +402,7 @@ func newRequestFactory(ctx context.Context, config config, stat status.StatusRep
 				transforms:             ts,
 				log:                    log,
 				encoder:                registeredEncoders[config.Request.EncodeAs],
+				soap:                   newSOAPEnvelope(ch.While.Request.SOAP, stat, log),
 				replace:                ch.While.Replace,
 				replaceWith:            ch.While.ReplaceWith,
 				until:                  ch.While.Until,
@@ -443,7 +465,9 @@ func (rf *requestFactory) newHTTPRequest(ctx context.Context, trCtx *transformCo
 
 	var body []byte
 	if rf.method == http.MethodPost {
-		if rf.encoder != nil {
+		if rf.soap != nil {
+			body, err = rf.soap.encode(trCtx, trReq)
+		} else if rf.encoder != nil {
 			body, err = rf.encoder(trReq)
 		} else {
 			body, err = encode(trReq.header().Get("Content-Type"), trReq)
@@ -808,10 +832,11 @@ func newPublisher(trCtx *transformContext, pub inputcursor.Publisher, publish bo
 		pub = nil
 	}
 	return &publisher{
-		trCtx:  trCtx,
-		pub:    pub,
-		status: stat,
-		log:    log,
+		trCtx:   trCtx,
+		pub:     pub,
+		status:  stat,
+		log:     log,
+		metrics: metrics,
 	}
 }
 
@@ -829,6 +854,9 @@ func (p *publisher) handleEvent(_ context.Context, msg mapstr.M) {
 			return
 		}
 	}
+	if fields := p.metrics.observeSchema(msg); len(fields) != 0 {
+		p.log.Infow("new fields found in the API response", "fields", fields)
+	}
 	if len(*p.trCtx.firstEvent) == 0 {
 		p.trCtx.updateFirstEvent(msg)
 	}
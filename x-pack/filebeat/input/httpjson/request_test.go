@@ -58,10 +58,10 @@ func TestCtxAfterDoRequest(t *testing.T) {
 
 	log := logp.NewLogger("")
 	ctx := context.Background()
-	client, err := newHTTPClient(ctx, config.Auth, config.Request, noopReporter{}, log, nil, nil)
+	client, err := newHTTPClient(ctx, config.Auth, config.Request, noopReporter{}, log, nil, nil, nil)
 	assert.NoError(t, err)
 
-	requestFactory, err := newRequestFactory(ctx, config, noopReporter{}, log, nil, nil)
+	requestFactory, err := newRequestFactory(ctx, config, noopReporter{}, log, nil, nil, nil)
 	assert.NoError(t, err)
 	pagination := newPagination(config, client, noopReporter{}, log)
 	responseProcessor := newResponseProcessor(config, pagination, nil, nil, noopReporter{}, log)
@@ -193,7 +193,7 @@ func Test_newRequestFactory_UsesBasicAuthInChainedRequests(t *testing.T) {
 
 			tt.args.cfg.Chain[0].Step = tt.args.step
 			tt.args.cfg.Chain[0].While = tt.args.while
-			requestFactories, err := newRequestFactory(ctx, tt.args.cfg, noopReporter{}, log, nil, nil)
+			requestFactories, err := newRequestFactory(ctx, tt.args.cfg, noopReporter{}, log, nil, nil, nil)
 			assert.NoError(t, err)
 			assert.NotNil(t, requestFactories)
 			for _, rf := range requestFactories {
@@ -235,7 +235,7 @@ func Test_newChainHTTPClient(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got, err := newHTTPClient(tt.args.ctx, tt.args.authCfg, tt.args.requestCfg, noopReporter{}, tt.args.log, nil, tt.args.p)
+			got, err := newHTTPClient(tt.args.ctx, tt.args.authCfg, tt.args.requestCfg, noopReporter{}, tt.args.log, nil, tt.args.p, nil)
 			assert.NoError(t, err)
 			assert.NotNil(t, got)
 		})
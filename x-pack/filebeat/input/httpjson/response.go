@@ -24,6 +24,7 @@ type response struct {
 	url        url.URL
 	header     http.Header
 	xmlDetails map[string]xml.Detail
+	xmlArrays  [][]string
 	body       interface{}
 }
 
@@ -0,0 +1,190 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package httpjson
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	soapVersion11 = "1.1"
+	soapVersion12 = "1.2"
+
+	soapEnvelopeNS11 = "http://schemas.xmlsoap.org/soap/envelope/"
+	soapEnvelopeNS12 = "http://www.w3.org/2003/05/soap-envelope"
+
+	wsseNS           = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-wssecurity-secext-1.0.xsd"
+	wsuNS            = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-wssecurity-utility-1.0.xsd"
+	wssePasswordText = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-username-token-profile-1.0#PasswordText"
+	wssePasswordDig  = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-username-token-profile-1.0#PasswordDigest"
+	wsseBase64Binary = "http://docs.oasis-open.org/wss/2004/01/oasis-200401-wss-soap-message-security-1.0#Base64Binary"
+
+	passwordTypeText   = "text"
+	passwordTypeDigest = "digest"
+)
+
+type soapConfig struct {
+	Version    string            `config:"version"`
+	Action     string            `config:"action"`
+	Body       *valueTpl         `config:"body" validate:"required"`
+	WSSecurity *wsSecurityConfig `config:"ws_security"`
+}
+
+type wsSecurityConfig struct {
+	Username     string `config:"username" validate:"required"`
+	Password     string `config:"password" validate:"required"`
+	PasswordType string `config:"password_type"`
+}
+
+func (c *soapConfig) Validate() error {
+	switch c.Version {
+	case "":
+		c.Version = soapVersion11
+	case soapVersion11, soapVersion12:
+	default:
+		return fmt.Errorf("unsupported SOAP version %q", c.Version)
+	}
+	return nil
+}
+
+func (c *wsSecurityConfig) Validate() error {
+	c.PasswordType = strings.ToLower(c.PasswordType)
+	switch c.PasswordType {
+	case "":
+		c.PasswordType = passwordTypeText
+	case passwordTypeText, passwordTypeDigest:
+	default:
+		return fmt.Errorf("unsupported ws_security password_type %q", c.PasswordType)
+	}
+	return nil
+}
+
+// soapEnvelope wraps the evaluated body template of a request into a
+// SOAP envelope, adding the WS-Security header when configured.
+type soapEnvelope struct {
+	config *soapConfig
+	status status.StatusReporter
+	log    *logp.Logger
+
+	now   func() time.Time
+	nonce func() ([]byte, error)
+}
+
+func newSOAPEnvelope(config *soapConfig, stat status.StatusReporter, log *logp.Logger) *soapEnvelope {
+	if config == nil {
+		return nil
+	}
+	return &soapEnvelope{
+		config: config,
+		status: stat,
+		log:    log,
+		now:    time.Now,
+		nonce:  randomNonce,
+	}
+}
+
+func randomNonce() ([]byte, error) {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	return b, err
+}
+
+// encode renders the SOAP envelope for trReq and sets the content type
+// and action headers required by the configured SOAP version.
+func (s *soapEnvelope) encode(trCtx *transformContext, trReq transformable) ([]byte, error) {
+	body, err := s.config.Body.Execute(trCtx, trReq, "soap.body", nil, s.status, s.log)
+	if err != nil {
+		return nil, fmt.Errorf("failed to evaluate SOAP body: %w", err)
+	}
+	if body == "" {
+		return nil, errors.New("SOAP body template evaluated to an empty value")
+	}
+
+	ns := soapEnvelopeNS11
+	if s.config.Version == soapVersion12 {
+		ns = soapEnvelopeNS12
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	fmt.Fprintf(&buf, `<soap:Envelope xmlns:soap="%s">`, ns)
+	if s.config.WSSecurity != nil {
+		buf.WriteString("<soap:Header>")
+		if err := s.writeSecurityHeader(&buf); err != nil {
+			return nil, err
+		}
+		buf.WriteString("</soap:Header>")
+	}
+	buf.WriteString("<soap:Body>")
+	buf.WriteString(body)
+	buf.WriteString("</soap:Body></soap:Envelope>")
+
+	header := trReq.header()
+	switch s.config.Version {
+	case soapVersion12:
+		contentType := "application/soap+xml; charset=utf-8"
+		if s.config.Action != "" {
+			contentType += fmt.Sprintf("; action=%q", s.config.Action)
+		}
+		header.Set("Content-Type", contentType)
+	default:
+		header.Set("Content-Type", "text/xml; charset=utf-8")
+		header.Set("SOAPAction", fmt.Sprintf("%q", s.config.Action))
+	}
+	header.Set("Accept", "application/soap+xml, text/xml")
+	trReq.setHeader(header)
+
+	return buf.Bytes(), nil
+}
+
+// writeSecurityHeader writes a WS-Security UsernameToken header to buf.
+func (s *soapEnvelope) writeSecurityHeader(buf *bytes.Buffer) error {
+	cfg := s.config.WSSecurity
+	nonce, err := s.nonce()
+	if err != nil {
+		return fmt.Errorf("failed to generate WS-Security nonce: %w", err)
+	}
+	created := s.now().UTC().Format(time.RFC3339)
+
+	passwordType, password := wssePasswordText, cfg.Password
+	if cfg.PasswordType == passwordTypeDigest {
+		passwordType, password = wssePasswordDig, passwordDigest(nonce, created, cfg.Password)
+	}
+
+	fmt.Fprintf(buf, `<wsse:Security xmlns:wsse="%s" xmlns:wsu="%s" soap:mustUnderstand="1">`, wsseNS, wsuNS)
+	buf.WriteString("<wsse:UsernameToken><wsse:Username>")
+	if err := xml.EscapeText(buf, []byte(cfg.Username)); err != nil {
+		return err
+	}
+	fmt.Fprintf(buf, `</wsse:Username><wsse:Password Type="%s">`, passwordType)
+	if err := xml.EscapeText(buf, []byte(password)); err != nil {
+		return err
+	}
+	fmt.Fprintf(buf, `</wsse:Password><wsse:Nonce EncodingType="%s">%s</wsse:Nonce>`, wsseBase64Binary, base64.StdEncoding.EncodeToString(nonce))
+	fmt.Fprintf(buf, "<wsu:Created>%s</wsu:Created>", created)
+	buf.WriteString("</wsse:UsernameToken></wsse:Security>")
+	return nil
+}
+
+// passwordDigest returns the UsernameToken password digest,
+// Base64(SHA-1(nonce + created + password)).
+func passwordDigest(nonce []byte, created, password string) string {
+	h := sha1.New()
+	h.Write(nonce)
+	h.Write([]byte(created))
+	h.Write([]byte(password))
+	return base64.StdEncoding.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,174 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package httpjson
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestSOAPEnvelope(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     map[string]interface{}
+		wantHeader map[string]string
+		wantBody   string
+	}{
+		{
+			name: "soap 1.1",
+			config: map[string]interface{}{
+				"action": "urn:GetEvents",
+				"body":   `<GetEvents><Since>[[.cursor.since]]</Since></GetEvents>`,
+			},
+			wantHeader: map[string]string{
+				"Content-Type": "text/xml; charset=utf-8",
+				"SOAPAction":   `"urn:GetEvents"`,
+			},
+			wantBody: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/">` +
+				`<soap:Body><GetEvents><Since>2024-01-01</Since></GetEvents></soap:Body></soap:Envelope>`,
+		},
+		{
+			name: "soap 1.2 with password text",
+			config: map[string]interface{}{
+				"version": "1.2",
+				"action":  "urn:GetEvents",
+				"body":    `<GetEvents/>`,
+				"ws_security": map[string]interface{}{
+					"username": "user",
+					"password": "p&ss",
+				},
+			},
+			wantHeader: map[string]string{
+				"Content-Type": `application/soap+xml; charset=utf-8; action="urn:GetEvents"`,
+				"SOAPAction":   "",
+			},
+			wantBody: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<soap:Envelope xmlns:soap="http://www.w3.org/2003/05/soap-envelope"><soap:Header>` +
+				`<wsse:Security xmlns:wsse="` + wsseNS + `" xmlns:wsu="` + wsuNS + `" soap:mustUnderstand="1">` +
+				`<wsse:UsernameToken><wsse:Username>user</wsse:Username>` +
+				`<wsse:Password Type="` + wssePasswordText + `">p&amp;ss</wsse:Password>` +
+				`<wsse:Nonce EncodingType="` + wsseBase64Binary + `">AAECAwQFBgcICQoLDA0ODw==</wsse:Nonce>` +
+				`<wsu:Created>2024-01-02T03:04:05Z</wsu:Created></wsse:UsernameToken></wsse:Security>` +
+				`</soap:Header><soap:Body><GetEvents/></soap:Body></soap:Envelope>`,
+		},
+		{
+			name: "password digest",
+			config: map[string]interface{}{
+				"body": `<GetEvents/>`,
+				"ws_security": map[string]interface{}{
+					"username":      "user",
+					"password":      "p&ss",
+					"password_type": "Digest",
+				},
+			},
+			wantHeader: map[string]string{
+				"Content-Type": "text/xml; charset=utf-8",
+				"SOAPAction":   `""`,
+			},
+			wantBody: `<?xml version="1.0" encoding="UTF-8"?>` + "\n" +
+				`<soap:Envelope xmlns:soap="http://schemas.xmlsoap.org/soap/envelope/"><soap:Header>` +
+				`<wsse:Security xmlns:wsse="` + wsseNS + `" xmlns:wsu="` + wsuNS + `" soap:mustUnderstand="1">` +
+				`<wsse:UsernameToken><wsse:Username>user</wsse:Username>` +
+				`<wsse:Password Type="` + wssePasswordDig + `">vrEX6hWJYUhByDABl6tyIIjdMaY=</wsse:Password>` +
+				`<wsse:Nonce EncodingType="` + wsseBase64Binary + `">AAECAwQFBgcICQoLDA0ODw==</wsse:Nonce>` +
+				`<wsu:Created>2024-01-02T03:04:05Z</wsu:Created></wsse:UsernameToken></wsse:Security>` +
+				`</soap:Header><soap:Body><GetEvents/></soap:Body></soap:Envelope>`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cfg soapConfig
+			require.NoError(t, conf.MustNewConfigFrom(test.config).Unpack(&cfg))
+
+			env := newSOAPEnvelope(&cfg, noopReporter{}, logp.NewLogger("test"))
+			env.now = func() time.Time { return time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC) }
+			env.nonce = func() ([]byte, error) {
+				b := make([]byte, 16)
+				for i := range b {
+					b[i] = byte(i)
+				}
+				return b, nil
+			}
+
+			trCtx := emptyTransformContext()
+			trCtx.cursor.state = mapstr.M{"since": "2024-01-01"}
+			trReq := transformable{}
+			body, err := env.encode(trCtx, trReq)
+			require.NoError(t, err)
+			assert.Equal(t, test.wantBody, string(body))
+			for k, v := range test.wantHeader {
+				assert.Equal(t, v, trReq.header().Get(k), k)
+			}
+		})
+	}
+}
+
+func TestSOAPConfigValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]interface{}
+		wantErr string
+	}{
+		{
+			name: "unsupported version",
+			config: map[string]interface{}{
+				"url":    "http://localhost",
+				"method": "POST",
+				"soap":   map[string]interface{}{"version": "2.0", "body": "<a/>"},
+			},
+			wantErr: `unsupported SOAP version "2.0"`,
+		},
+		{
+			name: "GET method",
+			config: map[string]interface{}{
+				"url":    "http://localhost",
+				"method": "GET",
+				"soap":   map[string]interface{}{"body": "<a/>"},
+			},
+			wantErr: `soap can only be used with method: "POST"`,
+		},
+		{
+			name: "with body",
+			config: map[string]interface{}{
+				"url":    "http://localhost",
+				"method": "POST",
+				"body":   map[string]interface{}{"a": "b"},
+				"soap":   map[string]interface{}{"body": "<a/>"},
+			},
+			wantErr: "soap can't be used together with body",
+		},
+		{
+			name: "invalid password type",
+			config: map[string]interface{}{
+				"url":    "http://localhost",
+				"method": "POST",
+				"soap": map[string]interface{}{
+					"body": "<a/>",
+					"ws_security": map[string]interface{}{
+						"username":      "user",
+						"password":      "pass",
+						"password_type": "plain",
+					},
+				},
+			},
+			wantErr: `unsupported ws_security password_type "plain"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var cfg requestConfig
+			err := conf.MustNewConfigFrom(test.config).Unpack(&cfg)
+			assert.ErrorContains(t, err, test.wantErr)
+		})
+	}
+}
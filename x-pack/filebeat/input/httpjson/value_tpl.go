@@ -12,6 +12,7 @@ import (
 	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
+	"encoding/xml"
 	"errors"
 	"fmt"
 	"hash"
@@ -83,6 +84,7 @@ func (t *valueTpl) Unpack(in string) error {
 			"urlEncode":           urlEncode,
 			"userAgent":           userAgentString,
 			"uuid":                uuidString,
+			"xmlEscape":           xmlEscape,
 			"terminate":           func(s string) (any, error) { return nil, &errTerminate{s} },
 		}).
 		Delims(leftDelim, rightDelim).
@@ -564,6 +566,13 @@ func urlEncode(value string) string {
 	return url.QueryEscape(value)
 }
 
+// xmlEscape returns value escaped for use as XML character data.
+func xmlEscape(value string) string {
+	var buf strings.Builder
+	_ = xml.EscapeText(&buf, []byte(value)) // strings.Builder never returns an error.
+	return buf.String()
+}
+
 // replaceAll returns a copy of the string s with all non-overlapping instances
 // of old replaced by new.
 //
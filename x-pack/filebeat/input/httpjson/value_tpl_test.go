@@ -778,6 +778,13 @@ func TestValueTpl(t *testing.T) {
 			paramTr:     transformable{},
 			expectedVal: "2022-02-17T04%3A37%3A10.406%2B0000",
 		},
+		{
+			name:        "func xmlEscape",
+			value:       `[[xmlEscape "<a href=\"x\">Tom & Jerry</a>"]]`,
+			paramCtx:    emptyTransformContext(),
+			paramTr:     transformable{},
+			expectedVal: "&lt;a href=&#34;x&#34;&gt;Tom &amp; Jerry&lt;/a&gt;",
+		},
 		{
 			name:        "func replaceAll",
 			value:       `[[ "some value" | replaceAll "some" "my" ]]`,
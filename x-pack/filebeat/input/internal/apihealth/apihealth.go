@@ -0,0 +1,330 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Package apihealth provides vendor API health status events for API based
+// inputs.
+package apihealth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors/add_data_stream"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Dataset is the dataset of the health status events. Under Elastic Agent,
+// they are published to the data stream of this dataset instead of the one
+// of the input.
+const Dataset = "filebeat.api_health"
+
+const (
+	statusUnknown    = "unknown"
+	statusHealthy    = "healthy"
+	statusDegraded   = "degraded"
+	statusAuthFailed = "auth_failed"
+
+	authStatusOK     = "ok"
+	authStatusFailed = "failed"
+)
+
+const (
+	defaultInterval = 5 * time.Minute
+	// maxErrorLength is the longest error message retained
+	// for a resource.
+	maxErrorLength = 1024
+)
+
+// Config is the health status event configuration shared by API inputs.
+type Config struct {
+	// Enabled enables publication of health status events.
+	Enabled bool `config:"enabled"`
+	// Interval is the minimum time between the publication
+	// of health status events.
+	Interval time.Duration `config:"interval"`
+}
+
+func (c *Config) Validate() error {
+	if c.Interval < 0 {
+		return errors.New("api_health.interval must not be negative")
+	}
+	if c.Interval == 0 {
+		c.Interval = defaultInterval
+	}
+	return nil
+}
+
+// Tracker collects the health of the resources polled by an input and
+// produces health status events for them. A nil *Tracker is valid and
+// ignores all calls, so inputs need not check whether health status
+// events are enabled. A Tracker is safe for concurrent use.
+type Tracker struct {
+	inputType string
+	inputID   string
+	interval  time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	published time.Time
+	resources map[string]*resourceState
+}
+
+type resourceState struct {
+	lastSuccess time.Time
+	lastFailure time.Time
+	lastError   string
+	authStatus  string
+	throttled   uint64
+	cursor      time.Time
+}
+
+// NewTracker returns a Tracker for the input with the given type and ID,
+// or nil if health status events are not enabled in cfg.
+func NewTracker(cfg Config, inputType, inputID string) *Tracker {
+	if !cfg.Enabled {
+		return nil
+	}
+	interval := cfg.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	return &Tracker{
+		inputType: inputType,
+		inputID:   inputID,
+		interval:  interval,
+		now:       time.Now,
+		resources: make(map[string]*resourceState),
+	}
+}
+
+// resource returns the state for name. It must be called with t.mu held.
+func (t *Tracker) resource(name string) *resourceState {
+	r, ok := t.resources[name]
+	if !ok {
+		r = &resourceState{authStatus: statusUnknown}
+		t.resources[name] = r
+	}
+	return r
+}
+
+// Succeeded records a successful poll of resource. A successful poll
+// implies successful authentication.
+func (t *Tracker) Succeeded(resource string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r := t.resource(resource)
+	r.lastSuccess = t.now()
+	r.authStatus = authStatusOK
+}
+
+// Failed records a failed poll of resource.
+func (t *Tracker) Failed(resource string, err error) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r := t.resource(resource)
+	r.lastFailure = t.now()
+	r.lastError = errorMessage(err)
+}
+
+// AuthFailed records a failure to authenticate for resource.
+func (t *Tracker) AuthFailed(resource string, err error) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	r := t.resource(resource)
+	r.lastFailure = t.now()
+	r.lastError = errorMessage(err)
+	r.authStatus = authStatusFailed
+}
+
+// Throttled records that a request for resource was throttled by the API.
+func (t *Tracker) Throttled(resource string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.resource(resource).throttled++
+}
+
+// CursorUpdated records that the cursor of resource advanced to position.
+// If the cursor has no time position, the time of the update should be used.
+func (t *Tracker) CursorUpdated(resource string, position time.Time) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.resource(resource).cursor = position
+}
+
+// ObserveStatus records the authentication and throttling state implied by
+// an HTTP response status code for resource.
+func (t *Tracker) ObserveStatus(resource string, code int) {
+	if t == nil {
+		return
+	}
+	switch {
+	case code == http.StatusTooManyRequests:
+		t.Throttled(resource)
+	case code == http.StatusUnauthorized, code == http.StatusForbidden:
+		t.AuthFailed(resource, fmt.Errorf("request failed with %d %s", code, http.StatusText(code)))
+	case code >= 200 && code < 300:
+		t.mu.Lock()
+		t.resource(resource).authStatus = authStatusOK
+		t.mu.Unlock()
+	}
+}
+
+// Transport returns an http.RoundTripper that records the status of the
+// responses of next for resource. If t is nil, next is returned.
+func (t *Tracker) Transport(next http.RoundTripper, resource string) http.RoundTripper {
+	if t == nil {
+		return next
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripper{next: next, tracker: t, resource: resource}
+}
+
+type roundTripper struct {
+	next     http.RoundTripper
+	tracker  *Tracker
+	resource string
+}
+
+func (rt roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.next.RoundTrip(req)
+	if err == nil {
+		rt.tracker.ObserveStatus(rt.resource, resp.StatusCode)
+	}
+	return resp, err
+}
+
+// PublishDue publishes the health status events with publish if the
+// configured interval has passed since they were last published.
+func (t *Tracker) PublishDue(publish func(beat.Event) error) error {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	now := t.now()
+	if !t.published.IsZero() && now.Sub(t.published) < t.interval {
+		t.mu.Unlock()
+		return nil
+	}
+	t.published = now
+	t.mu.Unlock()
+
+	var errs []error
+	for _, e := range t.Events() {
+		err := publish(e)
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Events returns a health status event for each resource that has been
+// recorded, ordered by resource name.
+func (t *Tracker) Events() []beat.Event {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	names := make([]string, 0, len(t.resources))
+	for name := range t.resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	now := t.now()
+	events := make([]beat.Event, 0, len(names))
+	for _, name := range names {
+		events = append(events, t.event(name, t.resources[name], now))
+	}
+	return events
+}
+
+func (t *Tracker) event(name string, r *resourceState, now time.Time) beat.Event {
+	status := statusUnknown
+	switch {
+	case r.authStatus == authStatusFailed:
+		status = statusAuthFailed
+	case r.lastFailure.After(r.lastSuccess):
+		status = statusDegraded
+	case !r.lastSuccess.IsZero():
+		status = statusHealthy
+	}
+
+	health := mapstr.M{
+		"input": mapstr.M{
+			"type": t.inputType,
+			"id":   t.inputID,
+		},
+		"resource":        name,
+		"status":          status,
+		"throttled_total": r.throttled,
+		"auth": mapstr.M{
+			"status": r.authStatus,
+		},
+	}
+	if !r.lastSuccess.IsZero() {
+		health["last_success"] = r.lastSuccess.UTC()
+	}
+	if !r.lastFailure.IsZero() {
+		health["last_failure"] = r.lastFailure.UTC()
+		health["last_error"] = r.lastError
+	}
+	if !r.cursor.IsZero() {
+		health["cursor"] = mapstr.M{
+			"position":    r.cursor.UTC(),
+			"age_seconds": int64(now.Sub(r.cursor) / time.Second),
+		}
+	}
+
+	event := beat.Event{
+		Timestamp: now,
+		Fields: mapstr.M{
+			"message": fmt.Sprintf("%s API health for %s: %s", t.inputType, name, status),
+			"event": mapstr.M{
+				"kind":    "state",
+				"dataset": Dataset,
+			},
+			"api_health": health,
+		},
+	}
+	// The add_data_stream processor of Elastic Agent routes the event to
+	// the data stream of the dataset and sets its data_stream fields.
+	add_data_stream.SetEventDataset(&event, Dataset)
+	return event
+}
+
+func errorMessage(err error) string {
+	if err == nil {
+		return ""
+	}
+	msg := err.Error()
+	if len(msg) > maxErrorLength {
+		msg = msg[:maxErrorLength]
+	}
+	return msg
+}
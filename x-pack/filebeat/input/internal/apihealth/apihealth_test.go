@@ -0,0 +1,141 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package apihealth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestNilTracker(t *testing.T) {
+	tr := NewTracker(Config{}, "cel", "id")
+	require.Nil(t, tr)
+
+	tr.Succeeded("r")
+	tr.Failed("r", errors.New("fail"))
+	tr.AuthFailed("r", errors.New("fail"))
+	tr.Throttled("r")
+	tr.CursorUpdated("r", time.Now())
+	tr.ObserveStatus("r", http.StatusOK)
+	assert.Nil(t, tr.Events())
+	assert.NoError(t, tr.PublishDue(func(beat.Event) error {
+		t.Fatal("unexpected publication")
+		return nil
+	}))
+	assert.Equal(t, http.DefaultTransport, tr.Transport(http.DefaultTransport, "r"))
+}
+
+func TestTrackerEvents(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tr := NewTracker(Config{Enabled: true, Interval: time.Minute}, "httpjson", "httpjson-1")
+	tr.now = func() time.Time { return now }
+
+	tr.ObserveStatus("b", http.StatusTooManyRequests)
+	tr.ObserveStatus("b", http.StatusTooManyRequests)
+	tr.Succeeded("b")
+	tr.CursorUpdated("b", now.Add(-90*time.Second))
+
+	tr.ObserveStatus("a", http.StatusUnauthorized)
+
+	tr.Succeeded("c")
+	now = now.Add(time.Second)
+	tr.Failed("c", errors.New("server error"))
+
+	events := tr.Events()
+	require.Len(t, events, 3)
+	for _, e := range events {
+		assert.Equal(t, mapstr.M{"dataset": Dataset}, e.Meta, "the events are routed to the data stream of their dataset")
+	}
+
+	assert.Equal(t, mapstr.M{
+		"input":           mapstr.M{"type": "httpjson", "id": "httpjson-1"},
+		"resource":        "a",
+		"status":          "auth_failed",
+		"throttled_total": uint64(0),
+		"auth":            mapstr.M{"status": "failed"},
+		"last_failure":    now.Add(-time.Second),
+		"last_error":      "request failed with 401 Unauthorized",
+	}, events[0].Fields["api_health"])
+
+	assert.Equal(t, mapstr.M{
+		"input":           mapstr.M{"type": "httpjson", "id": "httpjson-1"},
+		"resource":        "b",
+		"status":          "healthy",
+		"throttled_total": uint64(2),
+		"auth":            mapstr.M{"status": "ok"},
+		"last_success":    now.Add(-time.Second),
+		"cursor": mapstr.M{
+			"position":    now.Add(-91 * time.Second),
+			"age_seconds": int64(91),
+		},
+	}, events[1].Fields["api_health"])
+
+	got, err := events[2].Fields.GetValue("api_health.status")
+	require.NoError(t, err)
+	assert.Equal(t, "degraded", got)
+	got, err = events[2].Fields.GetValue("event.dataset")
+	require.NoError(t, err)
+	assert.Equal(t, Dataset, got)
+	assert.Equal(t, "httpjson API health for c: degraded", events[2].Fields["message"])
+}
+
+func TestPublishDue(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tr := NewTracker(Config{Enabled: true, Interval: time.Minute}, "cel", "cel-1")
+	tr.now = func() time.Time { return now }
+	tr.Succeeded("a")
+
+	var published int
+	publish := func(beat.Event) error {
+		published++
+		return nil
+	}
+	require.NoError(t, tr.PublishDue(publish))
+	assert.Equal(t, 1, published)
+
+	now = now.Add(30 * time.Second)
+	require.NoError(t, tr.PublishDue(publish))
+	assert.Equal(t, 1, published, "expected no publication before the interval")
+
+	now = now.Add(30 * time.Second)
+	require.NoError(t, tr.PublishDue(publish))
+	assert.Equal(t, 2, published)
+}
+
+func TestTransport(t *testing.T) {
+	var code atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(int(code.Load()))
+	}))
+	defer srv.Close()
+
+	tr := NewTracker(Config{Enabled: true}, "cel", "cel-1")
+	client := &http.Client{Transport: tr.Transport(nil, "r")}
+	for _, c := range []int{http.StatusTooManyRequests, http.StatusOK} {
+		code.Store(int64(c))
+		resp, err := client.Get(srv.URL)
+		require.NoError(t, err)
+		resp.Body.Close()
+	}
+
+	events := tr.Events()
+	require.Len(t, events, 1)
+	got, err := events[0].Fields.GetValue("api_health.throttled_total")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), got)
+	got, err = events[0].Fields.GetValue("api_health.auth.status")
+	require.NoError(t, err)
+	assert.Equal(t, "ok", got)
+}
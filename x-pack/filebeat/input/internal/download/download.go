@@ -0,0 +1,228 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Package download provides the downloads of the payloads of the API inputs,
+// like the objects of a bucket or the content blobs of an API. Interrupted
+// downloads are resumed from where they stopped with range requests, the
+// download rate of an input can be limited, and the payloads can be
+// downloaded completely before they are processed, with the large ones
+// buffered on disk.
+package download
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"golang.org/x/time/rate"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// Config is the configuration of the downloads of an input.
+type Config struct {
+	// MaxResumes is the maximum number of times an interrupted download is
+	// resumed from where it stopped. Zero disables resuming.
+	MaxResumes int `config:"max_resumes" validate:"min=0"`
+
+	// BandwidthLimit is the maximum rate of the downloads of the input, in
+	// bytes per second. Zero means unlimited.
+	BandwidthLimit cfgtype.ByteSize `config:"bandwidth_limit"`
+
+	// Prefetch downloads the payloads completely before they are processed,
+	// so the connections aren't held open while the events are published.
+	Prefetch bool `config:"prefetch"`
+
+	// SpillThreshold is the size above which prefetched payloads are
+	// buffered in a file instead of memory.
+	SpillThreshold cfgtype.ByteSize `config:"spill_threshold"`
+
+	// SpillDirectory is the directory of the files of the spilled payloads,
+	// the temporary directory of the OS if empty.
+	SpillDirectory string `config:"spill_directory"`
+}
+
+// DefaultConfig returns the default configuration of the downloads.
+func DefaultConfig() Config {
+	return Config{
+		MaxResumes:     3,
+		SpillThreshold: 64 * 1024 * 1024,
+	}
+}
+
+// OpenFunc opens a payload from offset. When offset is positive, the
+// returned reader must start at offset of the same version of the payload as
+// the one opened from zero, or OpenFunc must fail.
+type OpenFunc func(ctx context.Context, offset int64) (io.ReadCloser, error)
+
+// Manager downloads the payloads of an input. The nil *Manager opens the
+// payloads without resuming, limiting or prefetching them.
+type Manager struct {
+	cfg     Config
+	limiter *rate.Limiter
+	log     *logp.Logger
+
+	resumes      *monitoring.Uint // resumed downloads
+	resumeErrors *monitoring.Uint // downloads that couldn't be resumed
+	spilled      *monitoring.Uint // payloads buffered in a file
+}
+
+// NewManager returns a Manager of the downloads of an input, publishing its
+// metrics to reg if it is not nil:
+//
+//	download_resumes_total
+//	download_resume_errors_total
+//	download_spilled_total
+func NewManager(cfg Config, reg *monitoring.Registry, log *logp.Logger) *Manager {
+	m := &Manager{cfg: cfg, log: log}
+	if cfg.BandwidthLimit > 0 {
+		m.limiter = rate.NewLimiter(rate.Limit(cfg.BandwidthLimit), int(cfg.BandwidthLimit))
+	}
+	if reg != nil {
+		m.resumes = monitoring.NewUint(reg, "download_resumes_total")
+		m.resumeErrors = monitoring.NewUint(reg, "download_resume_errors_total")
+		m.spilled = monitoring.NewUint(reg, "download_spilled_total")
+	}
+	return m
+}
+
+// Open opens the payload with open, and returns a reader resuming it with open
+// when it is interrupted. The reader must be closed.
+func (m *Manager) Open(ctx context.Context, open OpenFunc) (io.ReadCloser, error) {
+	body, err := open(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	return m.Wrap(ctx, body, open)
+}
+
+// Wrap returns a reader of the payload read from body, the payload opened
+// from zero, resuming it with open when it is interrupted. body is closed
+// with the reader, or before Wrap returns an error.
+func (m *Manager) Wrap(ctx context.Context, body io.ReadCloser, open OpenFunc) (io.ReadCloser, error) {
+	if m == nil {
+		return body, nil
+	}
+	r := &reader{ctx: ctx, m: m, body: body, open: open}
+	if !m.cfg.Prefetch {
+		return r, nil
+	}
+	defer r.Close()
+	return m.spool(r)
+}
+
+// spool reads r completely, in memory up to the spill threshold and in a
+// file for the rest.
+func (m *Manager) spool(r io.Reader) (io.ReadCloser, error) {
+	threshold := int64(m.cfg.SpillThreshold)
+	var buf bytes.Buffer
+	_, err := io.CopyN(&buf, r, threshold+1)
+	if errors.Is(err, io.EOF) {
+		return io.NopCloser(&buf), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.CreateTemp(m.cfg.SpillDirectory, "filebeat-download-*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create spill file: %w", err)
+	}
+	spill := &spillFile{File: f}
+	if _, err = buf.WriteTo(f); err == nil {
+		_, err = io.Copy(f, r)
+	}
+	if err == nil {
+		_, err = f.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		spill.Close()
+		return nil, err
+	}
+	inc(m.spilled)
+	return spill, nil
+}
+
+// spillFile is a spilled payload, removed when it is closed.
+type spillFile struct {
+	*os.File
+}
+
+func (f *spillFile) Close() error {
+	err := f.File.Close()
+	return errors.Join(err, os.Remove(f.Name()))
+}
+
+// reader reads a payload, resuming it from where it stopped when it is
+// interrupted, and waiting for the bandwidth limit.
+type reader struct {
+	ctx     context.Context
+	m       *Manager
+	body    io.ReadCloser
+	open    OpenFunc
+	offset  int64
+	resumes int
+}
+
+func (r *reader) Read(p []byte) (int, error) {
+	if r.body == nil {
+		return 0, errors.New("read of closed download")
+	}
+	if l := r.m.limiter; l != nil && len(p) > l.Burst() {
+		p = p[:l.Burst()]
+	}
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+	if err != nil && !errors.Is(err, io.EOF) && r.ctx.Err() == nil && r.resumes < r.m.cfg.MaxResumes {
+		err = r.resume(err)
+		if err == nil && n == 0 {
+			return r.Read(p)
+		}
+	}
+	if l := r.m.limiter; l != nil && n > 0 {
+		if waitErr := l.WaitN(r.ctx, n); waitErr != nil && err == nil {
+			err = waitErr
+		}
+	}
+	return n, err
+}
+
+// resume opens the payload again from the current offset after cause
+// interrupted the download.
+func (r *reader) resume(cause error) error {
+	r.resumes++
+	r.body.Close()
+	body, err := r.open(r.ctx, r.offset)
+	if err != nil {
+		r.body = nil
+		inc(r.m.resumeErrors)
+		return fmt.Errorf("download interrupted at offset %d: %w: failed to resume: %w", r.offset, cause, err)
+	}
+	r.body = body
+	inc(r.m.resumes)
+	if r.m.log != nil {
+		r.m.log.Debugw("Resumed interrupted download", "offset", r.offset, "error", cause)
+	}
+	return nil
+}
+
+func (r *reader) Close() error {
+	if r.body == nil {
+		return nil
+	}
+	err := r.body.Close()
+	r.body = nil
+	return err
+}
+
+func inc(c *monitoring.Uint) {
+	if c != nil {
+		c.Inc()
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package download
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgtype"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+var errInterrupted = errors.New("connection reset by peer")
+
+// failingReader returns errInterrupted after n bytes of r.
+type failingReader struct {
+	r io.Reader
+	n int
+}
+
+func (f *failingReader) Read(p []byte) (int, error) {
+	if f.n <= 0 {
+		return 0, errInterrupted
+	}
+	if len(p) > f.n {
+		p = p[:f.n]
+	}
+	n, err := f.r.Read(p)
+	f.n -= n
+	return n, err
+}
+
+// interruptedOpener returns an OpenFunc of payload interrupted after every
+// chunk bytes, and the offsets it was opened from.
+func interruptedOpener(payload string, chunk int) (OpenFunc, *[]int64) {
+	var offsets []int64
+	return func(_ context.Context, offset int64) (io.ReadCloser, error) {
+		offsets = append(offsets, offset)
+		return io.NopCloser(&failingReader{r: strings.NewReader(payload[offset:]), n: chunk}), nil
+	}, &offsets
+}
+
+func TestManagerResume(t *testing.T) {
+	payload := strings.Repeat("0123456789", 10)
+
+	reg := monitoring.NewRegistry()
+	cfg := DefaultConfig()
+	cfg.MaxResumes = 10
+	m := NewManager(cfg, reg, logp.NewNopLogger())
+	open, offsets := interruptedOpener(payload, 30)
+	r, err := m.Open(context.Background(), open)
+	require.NoError(t, err)
+	got, err := io.ReadAll(r)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	assert.Equal(t, payload, string(got))
+	assert.Equal(t, []int64{0, 30, 60, 90}, *offsets)
+	assert.Equal(t, uint64(3), reg.Get("download_resumes_total").(*monitoring.Uint).Get())
+
+	cfg.MaxResumes = 1
+	m = NewManager(cfg, nil, logp.NewNopLogger())
+	open, _ = interruptedOpener(payload, 30)
+	r, err = m.Open(context.Background(), open)
+	require.NoError(t, err)
+	got, err = io.ReadAll(r)
+	assert.ErrorIs(t, err, errInterrupted)
+	assert.Equal(t, payload[:60], string(got))
+
+	var none *Manager
+	open, _ = interruptedOpener(payload, 30)
+	r, err = none.Open(context.Background(), open)
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	assert.ErrorIs(t, err, errInterrupted, "the nil manager doesn't resume the downloads")
+}
+
+func TestManagerResumeError(t *testing.T) {
+	reg := monitoring.NewRegistry()
+	m := NewManager(DefaultConfig(), reg, logp.NewNopLogger())
+	errModified := errors.New("modified")
+	r, err := m.Open(context.Background(), func(_ context.Context, offset int64) (io.ReadCloser, error) {
+		if offset > 0 {
+			return nil, errModified
+		}
+		return io.NopCloser(&failingReader{r: strings.NewReader("payload"), n: 3}), nil
+	})
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	assert.ErrorIs(t, err, errInterrupted)
+	assert.ErrorIs(t, err, errModified)
+	assert.ErrorContains(t, err, "offset 3")
+	assert.Equal(t, uint64(1), reg.Get("download_resume_errors_total").(*monitoring.Uint).Get())
+	assert.NoError(t, r.Close())
+}
+
+func TestManagerPrefetch(t *testing.T) {
+	payload := strings.Repeat("0123456789", 10)
+	for name, tc := range map[string]struct {
+		threshold int
+		spilled   uint64
+	}{
+		"memory": {threshold: len(payload)},
+		"file":   {threshold: len(payload) - 1, spilled: 1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			dir := t.TempDir()
+			reg := monitoring.NewRegistry()
+			cfg := DefaultConfig()
+			cfg.Prefetch = true
+			cfg.SpillThreshold = cfgtype.ByteSize(tc.threshold)
+			cfg.SpillDirectory = dir
+			m := NewManager(cfg, reg, logp.NewNopLogger())
+
+			var closed bool
+			r, err := m.Open(context.Background(), func(context.Context, int64) (io.ReadCloser, error) {
+				return closer{Reader: strings.NewReader(payload), closed: &closed}, nil
+			})
+			require.NoError(t, err)
+			assert.True(t, closed, "the payload is downloaded before it is returned")
+			assert.Equal(t, tc.spilled, reg.Get("download_spilled_total").(*monitoring.Uint).Get())
+
+			files, err := os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Len(t, files, int(tc.spilled))
+
+			var got bytes.Buffer
+			_, err = io.Copy(&got, r)
+			require.NoError(t, err)
+			assert.Equal(t, payload, got.String())
+			require.NoError(t, r.Close())
+
+			files, err = os.ReadDir(dir)
+			require.NoError(t, err)
+			assert.Empty(t, files, "the spill file is removed when the payload is closed")
+		})
+	}
+}
+
+type closer struct {
+	io.Reader
+	closed *bool
+}
+
+func (c closer) Close() error {
+	*c.closed = true
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package download
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// RoundTripper returns an http.RoundTripper downloading the bodies of the
+// successful GET responses sent by next with m. The downloads are resumed
+// only if the server supports range requests and the response has an ETag or
+// a Last-Modified header, so a modified payload is not resumed.
+func (m *Manager) RoundTripper(next http.RoundTripper) http.RoundTripper {
+	if m == nil {
+		return next
+	}
+	return &roundTripper{next: next, m: m}
+}
+
+type roundTripper struct {
+	next http.RoundTripper
+	m    *Manager
+}
+
+func (rt *roundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := rt.next.RoundTrip(req)
+	if err != nil || req.Method != http.MethodGet || resp.StatusCode != http.StatusOK || req.Header.Get("Range") != "" {
+		return resp, err
+	}
+	open := func(context.Context, int64) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("%s does not support range requests", req.URL.Redacted())
+	}
+	if validator := rangeValidator(resp); validator != "" && resp.Header.Get("Accept-Ranges") == "bytes" {
+		open = rangeOpener(rt.next, req, validator)
+	}
+	body, err := rt.m.Wrap(req.Context(), resp.Body, open)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = body
+	return resp, nil
+}
+
+// rangeValidator returns the validator of the payload of resp for the
+// If-Range header, the empty string if it has none. Weak ETags can't be used.
+func rangeValidator(resp *http.Response) string {
+	if etag := resp.Header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return resp.Header.Get("Last-Modified")
+}
+
+// rangeOpener returns an OpenFunc requesting the payload of req from offset,
+// if it still matches validator.
+func rangeOpener(next http.RoundTripper, req *http.Request, validator string) OpenFunc {
+	return func(ctx context.Context, offset int64) (io.ReadCloser, error) {
+		r := req.Clone(ctx)
+		r.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+		r.Header.Set("If-Range", validator)
+		resp, err := next.RoundTrip(r)
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusPartialContent {
+			resp.Body.Close()
+			if resp.StatusCode == http.StatusOK {
+				return nil, fmt.Errorf("%s was modified during the download", req.URL.Redacted())
+			}
+			return nil, fmt.Errorf("unexpected status of range request: %s", resp.Status)
+		}
+		if start := "bytes " + strconv.FormatInt(offset, 10) + "-"; !strings.HasPrefix(resp.Header.Get("Content-Range"), start) {
+			resp.Body.Close()
+			return nil, fmt.Errorf("unexpected content range: %q", resp.Header.Get("Content-Range"))
+		}
+		return resp.Body, nil
+	}
+}
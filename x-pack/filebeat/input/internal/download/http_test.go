@@ -0,0 +1,79 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package download
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// interruptingTransport interrupts the bodies of the responses to the
+// requests without a Range header after n bytes.
+type interruptingTransport struct {
+	n int
+}
+
+func (t interruptingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil || req.Header.Get("Range") != "" {
+		return resp, err
+	}
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{&failingReader{r: resp.Body, n: t.n}, resp.Body}
+	return resp, nil
+}
+
+func TestRoundTripper(t *testing.T) {
+	payload := strings.Repeat("0123456789", 100)
+	var (
+		mu     sync.Mutex
+		etag   = `"v1"`
+		ranges []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ranges = append(ranges, r.Header.Get("Range"))
+		w.Header().Set("ETag", etag)
+		mu.Unlock()
+		http.ServeContent(w, r, "payload", time.Time{}, strings.NewReader(payload))
+	}))
+	defer srv.Close()
+
+	m := NewManager(DefaultConfig(), nil, logp.NewNopLogger())
+	client := &http.Client{Transport: m.RoundTripper(interruptingTransport{n: 300})}
+
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	got, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	require.NoError(t, err)
+	assert.Equal(t, payload, string(got))
+	mu.Lock()
+	assert.Equal(t, []string{"", "bytes=300-"}, ranges)
+	mu.Unlock()
+
+	// A payload modified since the download started is not resumed.
+	resp, err = client.Get(srv.URL)
+	require.NoError(t, err)
+	mu.Lock()
+	etag = `"v2"`
+	mu.Unlock()
+	_, err = io.ReadAll(resp.Body)
+	resp.Body.Close()
+	assert.ErrorContains(t, err, "was modified during the download")
+	assert.ErrorIs(t, err, errInterrupted)
+}
@@ -0,0 +1,96 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package httpmon
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// Error classes of the failed requests.
+const (
+	ErrorClassTimeout   = "timeout"      // the request timed out
+	ErrorClassNetwork   = "network"      // the request could not be sent or the response received
+	ErrorClassAuth      = "auth"         // 401 and 403 responses
+	ErrorClassThrottled = "throttled"    // 429 responses
+	ErrorClassClient    = "client_error" // other 4xx responses
+	ErrorClassServer    = "server_error" // 5xx responses
+	ErrorClassDecode    = "decode"       // the response body could not be decoded
+)
+
+// StatusErrorClass returns the error class of a response status code, or an
+// empty string if it is not an error.
+func StatusErrorClass(code int) string {
+	switch {
+	case code == http.StatusUnauthorized, code == http.StatusForbidden:
+		return ErrorClassAuth
+	case code == http.StatusTooManyRequests:
+		return ErrorClassThrottled
+	case code >= 500:
+		return ErrorClassServer
+	case code >= 400:
+		return ErrorClassClient
+	}
+	return ""
+}
+
+// RequestErrorClass returns the error class of an error returned when sending
+// a request.
+func RequestErrorClass(err error) string {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return ErrorClassTimeout
+	}
+	return ErrorClassNetwork
+}
+
+// EndpointErrors counts the failed requests to each endpoint of an API by
+// error class, in the endpoint_errors namespace of a registry:
+//
+//	endpoint_errors.<endpoint>.<class>
+//
+// The endpoints are short names chosen by the inputs, not URLs, so the
+// number of metrics is bounded. The nil *EndpointErrors does not count
+// anything.
+type EndpointErrors struct {
+	mu       sync.Mutex
+	reg      *monitoring.Registry
+	counters map[string]*monitoring.Uint
+}
+
+// NewEndpointErrors returns an EndpointErrors publishing its metrics to
+// reg. It returns nil if reg is nil.
+func NewEndpointErrors(reg *monitoring.Registry) *EndpointErrors {
+	if reg == nil {
+		return nil
+	}
+	return &EndpointErrors{
+		reg:      reg.NewRegistry("endpoint_errors"),
+		counters: map[string]*monitoring.Uint{},
+	}
+}
+
+// Add counts an error of class for endpoint. Empty classes are ignored.
+func (e *EndpointErrors) Add(endpoint, class string) {
+	if e == nil || class == "" {
+		return
+	}
+	// Dots separate the namespaces of the registries.
+	key := strings.ReplaceAll(endpoint, ".", "_") + "." + class
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	c, ok := e.counters[key]
+	if !ok {
+		c = monitoring.NewUint(e.reg, key)
+		e.counters[key] = c
+	}
+	c.Inc()
+}
@@ -0,0 +1,53 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package httpmon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestErrorClasses(t *testing.T) {
+	for code, want := range map[int]string{
+		200: "",
+		304: "",
+		400: ErrorClassClient,
+		401: ErrorClassAuth,
+		403: ErrorClassAuth,
+		404: ErrorClassClient,
+		429: ErrorClassThrottled,
+		500: ErrorClassServer,
+		503: ErrorClassServer,
+	} {
+		assert.Equal(t, want, StatusErrorClass(code), "status %d", code)
+	}
+	assert.Equal(t, ErrorClassTimeout, RequestErrorClass(fmt.Errorf("sending: %w", context.DeadlineExceeded)))
+	assert.Equal(t, ErrorClassNetwork, RequestErrorClass(errors.New("connection refused")))
+}
+
+func TestEndpointErrors(t *testing.T) {
+	reg := monitoring.NewRegistry()
+	e := NewEndpointErrors(reg)
+	e.Add("content_list", ErrorClassServer)
+	e.Add("content_list", ErrorClassServer)
+	e.Add("chain.1", ErrorClassDecode)
+	e.Add("content_list", "")
+
+	snapshot := monitoring.CollectFlatSnapshot(reg, monitoring.Full, false)
+	assert.Equal(t, map[string]int64{
+		"endpoint_errors.content_list.server_error": 2,
+		"endpoint_errors.chain_1.decode":            1,
+	}, snapshot.Ints)
+
+	var none *EndpointErrors
+	none.Add("content_list", ErrorClassServer)
+	assert.Nil(t, NewEndpointErrors(nil))
+}
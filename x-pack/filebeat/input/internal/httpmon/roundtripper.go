@@ -6,6 +6,8 @@
 package httpmon
 
 import (
+	"errors"
+	"io"
 	"net/http"
 	"time"
 
@@ -152,14 +154,50 @@ func (rt *MetricsRoundTripper) RoundTrip(req *http.Request) (*http.Response, err
 
 	rt.monitorByStatusCode(resp.StatusCode)
 
-	if resp.ContentLength >= 0 {
+	switch {
+	case resp.ContentLength >= 0:
 		rt.metrics.respsAccSize.Add(uint64(resp.ContentLength))
 		rt.metrics.respsSize.Update(resp.ContentLength)
+	case resp.Body != nil && resp.Body != http.NoBody:
+		// The size of chunked responses is only known once they are read.
+		resp.Body = &countingBody{ReadCloser: resp.Body, metrics: rt.metrics}
 	}
 
 	return resp, err
 }
 
+// countingBody records the size of a response body of unknown length once it
+// is read to the end or closed.
+type countingBody struct {
+	io.ReadCloser
+	metrics *httpMetrics
+	n       int64
+	done    bool
+}
+
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n += int64(n)
+	if errors.Is(err, io.EOF) {
+		b.record()
+	}
+	return n, err
+}
+
+func (b *countingBody) Close() error {
+	b.record()
+	return b.ReadCloser.Close()
+}
+
+func (b *countingBody) record() {
+	if b.done {
+		return
+	}
+	b.done = true
+	b.metrics.respsAccSize.Add(uint64(b.n))
+	b.metrics.respsSize.Update(b.n)
+}
+
 func (rt *MetricsRoundTripper) monitorByMethod(method string) {
 	switch method {
 	case http.MethodDelete:
@@ -0,0 +1,42 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package httpmon
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestMetricsRoundTripperChunkedResponse(t *testing.T) {
+	body := strings.Repeat("x", 1000)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		// Flushing before writing the body makes the response chunked.
+		w.(http.Flusher).Flush()
+		_, _ = io.WriteString(w, body)
+	}))
+	defer server.Close()
+
+	reg := monitoring.NewRegistry()
+	client := &http.Client{Transport: NewMetricsRoundTripper(http.DefaultTransport, reg, logp.NewLogger("test"))}
+	resp, err := client.Get(server.URL)
+	require.NoError(t, err)
+	assert.Equal(t, int64(-1), resp.ContentLength)
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Len(t, b, len(body))
+
+	snapshot := monitoring.CollectFlatSnapshot(reg, monitoring.Full, false)
+	assert.Equal(t, int64(len(body)), snapshot.Ints["http_response_body_bytes_total"], "the body is counted once")
+}
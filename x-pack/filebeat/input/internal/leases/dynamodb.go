@@ -0,0 +1,308 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package leases
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	// dynamoDBTargetPrefix is the prefix of the X-Amz-Target header of the
+	// DynamoDB API operations.
+	dynamoDBTargetPrefix = "DynamoDB_20120810."
+
+	// The attributes of the items of the coordination table. The table
+	// has the partition key leaseKeyAttr of type string.
+	leaseKeyAttr        = "lease_key"
+	leaseOwnerAttr      = "owner"
+	leaseExpiresAttr    = "expires"
+	leaseCheckpointAttr = "checkpoint"
+)
+
+// attributeValue is a DynamoDB attribute value of type string or number.
+type attributeValue struct {
+	S *string `json:"S,omitempty"`
+	N *string `json:"N,omitempty"`
+}
+
+func stringValue(s string) attributeValue { return attributeValue{S: &s} }
+
+func numberValue(n int64) attributeValue {
+	s := strconv.FormatInt(n, 10)
+	return attributeValue{N: &s}
+}
+
+type updateItemInput struct {
+	TableName                 string                    `json:"TableName"`
+	Key                       map[string]attributeValue `json:"Key"`
+	UpdateExpression          string                    `json:"UpdateExpression"`
+	ConditionExpression       string                    `json:"ConditionExpression,omitempty"`
+	ExpressionAttributeNames  map[string]string         `json:"ExpressionAttributeNames"`
+	ExpressionAttributeValues map[string]attributeValue `json:"ExpressionAttributeValues"`
+	ReturnValues              string                    `json:"ReturnValues,omitempty"`
+}
+
+type updateItemOutput struct {
+	Attributes map[string]attributeValue `json:"Attributes"`
+}
+
+type deleteItemInput struct {
+	TableName string                    `json:"TableName"`
+	Key       map[string]attributeValue `json:"Key"`
+}
+
+type scanInput struct {
+	TableName                 string                    `json:"TableName"`
+	FilterExpression          string                    `json:"FilterExpression"`
+	ProjectionExpression      string                    `json:"ProjectionExpression"`
+	ExpressionAttributeNames  map[string]string         `json:"ExpressionAttributeNames"`
+	ExpressionAttributeValues map[string]attributeValue `json:"ExpressionAttributeValues"`
+	ExclusiveStartKey         map[string]attributeValue `json:"ExclusiveStartKey,omitempty"`
+	ConsistentRead            bool                      `json:"ConsistentRead"`
+}
+
+type scanOutput struct {
+	Items            []map[string]attributeValue `json:"Items"`
+	LastEvaluatedKey map[string]attributeValue   `json:"LastEvaluatedKey"`
+}
+
+// dynamoDBError is an error response of the DynamoDB API.
+type dynamoDBError struct {
+	StatusCode int
+	Type       string
+	Message    string
+}
+
+func (e *dynamoDBError) Error() string {
+	return fmt.Sprintf("%s (status %d): %s", e.Type, e.StatusCode, e.Message)
+}
+
+func isConditionalCheckFailed(err error) bool {
+	var e *dynamoDBError
+	return errors.As(err, &e) && e.Type == "ConditionalCheckFailedException"
+}
+
+// dynamoDBStore stores the leases in a DynamoDB table, one item per lease and
+// per instance. The keys of the items are prefixed with the prefix of the
+// input, so several inputs can share a table.
+type dynamoDBStore struct {
+	http   *http.Client
+	url    string
+	table  string
+	prefix string
+}
+
+// newDynamoDBStore returns a lease store using the table of cfg, in region.
+func newDynamoDBStore(cfg Config, awsCfg awscommon.ConfigAWS, region, prefix string, log *logp.Logger) (*dynamoDBStore, error) {
+	awsCfg.DefaultRegion = region
+	awsConfig, err := awscommon.InitializeAWSConfig(awsCfg, log)
+	if err != nil {
+		return nil, err
+	}
+	// The HTTP client of the AWS configuration holds the proxy and the TLS
+	// settings of the input.
+	var next http.RoundTripper = http.DefaultTransport
+	if hc, ok := awsConfig.HTTPClient.(*http.Client); ok && hc.Transport != nil {
+		next = hc.Transport
+	}
+	signer, err := awscommon.InitializeSignerTransport(awscommon.SignerInputConfig{
+		ServiceName: "dynamodb",
+		ConfigAWS:   awsCfg,
+	}, log, next)
+	if err != nil {
+		return nil, err
+	}
+
+	url := cfg.Endpoint
+	if url == "" {
+		url = "https://dynamodb." + region + ".amazonaws.com"
+		if strings.HasPrefix(region, "cn-") {
+			url += ".cn"
+		}
+	}
+	return &dynamoDBStore{
+		http:   &http.Client{Transport: signer, Timeout: time.Minute},
+		url:    url,
+		table:  cfg.TableName,
+		prefix: prefix,
+	}, nil
+}
+
+// call sends a request of the DynamoDB API operation op, and decodes the
+// response into out.
+func (s *dynamoDBStore) call(ctx context.Context, op string, in, out any) error {
+	body, err := json.Marshal(in)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s request: %w", op, err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.0")
+	req.Header.Set("X-Amz-Target", dynamoDBTargetPrefix+op)
+
+	resp, err := s.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read %s response: %w", op, err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		var msg struct {
+			Type    string `json:"__type"`
+			Message string `json:"message"`
+		}
+		_ = json.Unmarshal(b, &msg)
+		e := &dynamoDBError{StatusCode: resp.StatusCode, Type: msg.Type, Message: msg.Message}
+		// The type holds the namespace of the error.
+		if i := strings.LastIndexByte(e.Type, '#'); i >= 0 {
+			e.Type = e.Type[i+1:]
+		}
+		if e.Message == "" {
+			e.Message = string(bytes.TrimSpace(b))
+		}
+		return e
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(b, out); err != nil {
+		return fmt.Errorf("failed to decode %s response: %w", op, err)
+	}
+	return nil
+}
+
+func (s *dynamoDBStore) instanceItemKey(owner string) map[string]attributeValue {
+	return map[string]attributeValue{leaseKeyAttr: stringValue(s.prefix + "::instance::" + owner)}
+}
+
+func (s *dynamoDBStore) leaseItemKey(key string) map[string]attributeValue {
+	return map[string]attributeValue{leaseKeyAttr: stringValue(s.prefix + "::lease::" + key)}
+}
+
+// update sends an UpdateItem request setting the owner and the expiration of
+// an item, and its checkpoint if it is not 0.
+func (s *dynamoDBStore) update(ctx context.Context, key map[string]attributeValue, owner string, expires time.Time, checkpoint int64, condition string, extra map[string]attributeValue, out *updateItemOutput) error {
+	in := updateItemInput{
+		TableName:                s.table,
+		Key:                      key,
+		UpdateExpression:         "SET #owner = :owner, #expires = :expires",
+		ConditionExpression:      condition,
+		ExpressionAttributeNames: map[string]string{"#owner": leaseOwnerAttr, "#expires": leaseExpiresAttr},
+		ExpressionAttributeValues: map[string]attributeValue{
+			":owner":   stringValue(owner),
+			":expires": numberValue(expires.UnixMilli()),
+		},
+	}
+	if checkpoint != 0 {
+		in.UpdateExpression += ", #checkpoint = :checkpoint"
+		in.ExpressionAttributeNames["#checkpoint"] = leaseCheckpointAttr
+		in.ExpressionAttributeValues[":checkpoint"] = numberValue(checkpoint)
+	}
+	if strings.Contains(condition, "#key") {
+		in.ExpressionAttributeNames["#key"] = leaseKeyAttr
+	}
+	for k, v := range extra {
+		in.ExpressionAttributeValues[k] = v
+	}
+	if out == nil {
+		return s.call(ctx, "UpdateItem", in, nil)
+	}
+	in.ReturnValues = "ALL_NEW"
+	return s.call(ctx, "UpdateItem", in, out)
+}
+
+func (s *dynamoDBStore) Heartbeat(ctx context.Context, owner string, expires time.Time) error {
+	return s.update(ctx, s.instanceItemKey(owner), owner, expires, 0, "", nil, nil)
+}
+
+func (s *dynamoDBStore) Leave(ctx context.Context, owner string) error {
+	return s.call(ctx, "DeleteItem", deleteItemInput{TableName: s.table, Key: s.instanceItemKey(owner)}, nil)
+}
+
+func (s *dynamoDBStore) Owners(ctx context.Context, now time.Time) ([]string, error) {
+	in := scanInput{
+		TableName:                s.table,
+		FilterExpression:         "begins_with(#key, :prefix) AND #expires > :now",
+		ProjectionExpression:     "#owner",
+		ExpressionAttributeNames: map[string]string{"#key": leaseKeyAttr, "#owner": leaseOwnerAttr, "#expires": leaseExpiresAttr},
+		ExpressionAttributeValues: map[string]attributeValue{
+			":prefix": stringValue(s.prefix + "::instance::"),
+			":now":    numberValue(now.UnixMilli()),
+		},
+		ConsistentRead: true,
+	}
+	var owners []string
+	for {
+		var out scanOutput
+		if err := s.call(ctx, "Scan", in, &out); err != nil {
+			return nil, err
+		}
+		for _, item := range out.Items {
+			if v := item[leaseOwnerAttr].S; v != nil {
+				owners = append(owners, *v)
+			}
+		}
+		if len(out.LastEvaluatedKey) == 0 {
+			return owners, nil
+		}
+		in.ExclusiveStartKey = out.LastEvaluatedKey
+	}
+}
+
+func (s *dynamoDBStore) Acquire(ctx context.Context, key, owner string, now, expires time.Time) (int64, bool, error) {
+	var out updateItemOutput
+	err := s.update(ctx, s.leaseItemKey(key), owner, expires, 0,
+		"attribute_not_exists(#key) OR #owner = :owner OR #expires < :now",
+		map[string]attributeValue{":now": numberValue(now.UnixMilli())}, &out)
+	if isConditionalCheckFailed(err) {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	var checkpoint int64
+	if v := out.Attributes[leaseCheckpointAttr].N; v != nil {
+		checkpoint, err = strconv.ParseInt(*v, 10, 64)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid checkpoint of the lease %s: %w", key, err)
+		}
+	}
+	return checkpoint, true, nil
+}
+
+func (s *dynamoDBStore) Renew(ctx context.Context, key, owner string, expires time.Time, checkpoint int64) (bool, error) {
+	err := s.update(ctx, s.leaseItemKey(key), owner, expires, checkpoint, "#owner = :owner", nil, nil)
+	if isConditionalCheckFailed(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (s *dynamoDBStore) Release(ctx context.Context, key, owner string, checkpoint int64) error {
+	// The lease expires at once, so the assigned instance acquires it with
+	// its next claim.
+	err := s.update(ctx, s.leaseItemKey(key), owner, time.UnixMilli(0), checkpoint, "#owner = :owner", nil, nil)
+	if isConditionalCheckFailed(err) {
+		return nil
+	}
+	return err
+}
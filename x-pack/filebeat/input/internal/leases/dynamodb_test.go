@@ -0,0 +1,71 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package leases
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDynamoDBStore(t *testing.T) {
+	var requests []map[string]any
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/x-amz-json-1.0", r.Header.Get("Content-Type"))
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		body["target"] = r.Header.Get("X-Amz-Target")
+		requests = append(requests, body)
+
+		switch {
+		case body["target"] == "DynamoDB_20120810.Scan" && body["ExclusiveStartKey"] == nil:
+			_, _ = w.Write([]byte(`{"Items":[{"owner":{"S":"a"}}],"LastEvaluatedKey":{"lease_key":{"S":"k"}}}`))
+		case body["target"] == "DynamoDB_20120810.Scan":
+			_, _ = w.Write([]byte(`{"Items":[{"owner":{"S":"b"}}]}`))
+		case body["ConditionExpression"] == "#owner = :owner":
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"__type":"com.amazonaws.dynamodb.v20120810#ConditionalCheckFailedException","message":"The conditional request failed"}`))
+		default:
+			_, _ = w.Write([]byte(`{"Attributes":{"checkpoint":{"N":"1700000000000"}}}`))
+		}
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	now := time.UnixMilli(1000)
+	s := &dynamoDBStore{http: srv.Client(), url: srv.URL, table: "leases", prefix: "input"}
+
+	owners, err := s.Owners(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, owners)
+	assert.Equal(t, map[string]any{"S": "input::instance::"}, requests[0]["ExpressionAttributeValues"].(map[string]any)[":prefix"])
+
+	checkpoint, ok, err := s.Acquire(ctx, "group", "a", now, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1700000000000), checkpoint)
+	acquire := requests[2]
+	assert.Equal(t, "DynamoDB_20120810.UpdateItem", acquire["target"])
+	assert.Equal(t, map[string]any{"lease_key": map[string]any{"S": "input::lease::group"}}, acquire["Key"])
+	assert.Equal(t, "ALL_NEW", acquire["ReturnValues"])
+	assert.Equal(t, map[string]any{"#key": "lease_key", "#owner": "owner", "#expires": "expires"}, acquire["ExpressionAttributeNames"])
+
+	ok, err = s.Renew(ctx, "group", "a", now.Add(time.Minute), 2000)
+	require.NoError(t, err)
+	assert.False(t, ok, "the lease is held by another owner")
+	assert.Equal(t, "SET #owner = :owner, #expires = :expires, #checkpoint = :checkpoint", requests[3]["UpdateExpression"])
+	assert.NoError(t, s.Release(ctx, "group", "a", 0))
+
+	require.NoError(t, s.Leave(ctx, "a"))
+	leave := requests[len(requests)-1]
+	assert.Equal(t, "DynamoDB_20120810.DeleteItem", leave["target"])
+	assert.Equal(t, map[string]any{"lease_key": map[string]any{"S": "input::instance::a"}}, leave["Key"])
+}
@@ -0,0 +1,403 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Package leases partitions the work of an input between the Filebeat
+// instances running it, with leases stored in a DynamoDB table or an S3
+// bucket.
+package leases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"sync"
+	"time"
+
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+const (
+	// TypeDynamoDB stores the leases in a DynamoDB table.
+	TypeDynamoDB = "dynamodb"
+	// TypeS3 stores the leases in an S3 bucket.
+	TypeS3 = "s3"
+)
+
+// Config configures the coordination of the instances running an input, so
+// each lease is held by a single instance at a time.
+type Config struct {
+	Enabled bool `config:"enabled"`
+	// Type is the lease store, dynamodb or s3.
+	Type string `config:"type"`
+	// TableName is the DynamoDB table holding the leases and the instances
+	// of the input.
+	TableName string `config:"table_name"`
+	// BucketName is the S3 bucket holding the leases and the instances of
+	// the input.
+	BucketName string `config:"bucket_name"`
+	// ObjectPrefix is the prefix of the keys of the objects of the S3
+	// bucket.
+	ObjectPrefix string `config:"object_prefix"`
+	// Endpoint overrides the endpoint of the DynamoDB or S3 API.
+	Endpoint string `config:"endpoint"`
+	// LeaseDuration is the time a lease is held without being renewed.
+	// The leases are renewed three times per duration.
+	LeaseDuration time.Duration `config:"lease_duration" validate:"min=0,nonzero"`
+	// OwnerID identifies the instance in the leases. It defaults to the
+	// host name with a random suffix.
+	OwnerID string `config:"owner_id"`
+	// Partitions is the number of partitions the inputs with no natural
+	// keys hash their work into, each partition being a lease. The other
+	// inputs ignore it.
+	Partitions int `config:"partitions"`
+}
+
+// DefaultConfig returns the default coordination configuration.
+func DefaultConfig() Config {
+	return Config{
+		Type:          TypeDynamoDB,
+		ObjectPrefix:  "filebeat-leases/",
+		LeaseDuration: time.Minute,
+		Partitions:    16,
+	}
+}
+
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	switch c.Type {
+	case TypeDynamoDB:
+		if c.TableName == "" {
+			return errors.New("coordination.table_name is required with coordination.type dynamodb")
+		}
+	case TypeS3:
+		if c.BucketName == "" {
+			return errors.New("coordination.bucket_name is required with coordination.type s3")
+		}
+	default:
+		return fmt.Errorf("invalid coordination.type %q, must be %s or %s", c.Type, TypeDynamoDB, TypeS3)
+	}
+	if c.Partitions <= 0 {
+		return fmt.Errorf("coordination.partitions <%d> must be greater than 0", c.Partitions)
+	}
+	return nil
+}
+
+// Store stores the leases, with their checkpoint, and the heartbeats of the
+// instances of an input.
+type Store interface {
+	// Heartbeat records owner as alive until expires.
+	Heartbeat(ctx context.Context, owner string, expires time.Time) error
+	// Owners returns the owners alive at now.
+	Owners(ctx context.Context, now time.Time) ([]string, error)
+	// Acquire takes the lease of key for owner until expires if it is
+	// free, expired at now or already held by owner. It returns the
+	// checkpoint stored with the lease, 0 if none, and false if the lease is
+	// held by another owner.
+	Acquire(ctx context.Context, key, owner string, now, expires time.Time) (checkpoint int64, ok bool, err error)
+	// Renew extends the lease of key held by owner until expires, and
+	// stores checkpoint if it is not 0. It returns false if the lease is
+	// held by another owner.
+	Renew(ctx context.Context, key, owner string, expires time.Time, checkpoint int64) (bool, error)
+	// Release gives up the lease of key held by owner, and stores
+	// checkpoint if it is not 0.
+	Release(ctx context.Context, key, owner string, checkpoint int64) error
+	// Leave removes the heartbeat of owner, once it stopped.
+	Leave(ctx context.Context, owner string) error
+}
+
+// NewStore returns the lease store of cfg, in region. The items of the input
+// are prefixed with prefix, so several inputs can share a store. The requests
+// are signed with the credentials of the input.
+func NewStore(cfg Config, awsCfg awscommon.ConfigAWS, region, prefix string, log *logp.Logger) (Store, error) {
+	if cfg.Type == TypeS3 {
+		return newS3Store(cfg, awsCfg, region, prefix, log)
+	}
+	return newDynamoDBStore(cfg, awsCfg, region, prefix, log)
+}
+
+// Metrics are the metrics updated by a Coordinator.
+type Metrics struct {
+	// Owned is the number of leases held by the instance.
+	Owned *monitoring.Uint
+	// Errors is the number of failed requests to the store.
+	Errors *monitoring.Uint
+}
+
+// Coordinator partitions keys between the instances of an input with leases.
+// The keys are assigned to the live instances by rendezvous hashing, and an
+// instance works on a key once it holds its lease. The checkpoints are stored
+// with the leases, so a key moved to another instance is worked on from where
+// the previous one stopped.
+type Coordinator struct {
+	store         Store
+	owner         string
+	leaseDuration time.Duration
+	metrics       Metrics
+	log           *logp.Logger
+	clock         func() time.Time
+
+	mu sync.Mutex
+	// held are the leases held by the instance, by key.
+	held map[string]heldLease
+}
+
+// heldLease is a lease held by the instance.
+type heldLease struct {
+	// checkpoint is the checkpoint to store with the next renewal, 0 if
+	// unchanged.
+	checkpoint int64
+	// expires is when the lease expires if it is not renewed, from the last
+	// successful acquisition or renewal.
+	expires time.Time
+}
+
+// NewCoordinator returns a Coordinator of the leases of store.
+func NewCoordinator(cfg Config, store Store, metrics Metrics, log *logp.Logger) *Coordinator {
+	owner := cfg.OwnerID
+	if owner == "" {
+		owner = defaultOwnerID()
+	}
+	return &Coordinator{
+		store:         store,
+		owner:         owner,
+		leaseDuration: cfg.LeaseDuration,
+		metrics:       metrics,
+		log:           log.With("owner_id", owner),
+		clock:         time.Now,
+		held:          map[string]heldLease{},
+	}
+}
+
+// defaultOwnerID returns the host name with a random suffix, so the instances
+// running on the same host are told apart.
+func defaultOwnerID() string {
+	host, err := os.Hostname()
+	if err != nil {
+		host = "filebeat"
+	}
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	return host + "-" + hex.EncodeToString(b)
+}
+
+// Claim returns the keys the instance holds the lease of. The leases of the
+// keys assigned to the instance are acquired, and acquired maps the newly
+// acquired ones to their stored checkpoint, 0 if none. The leases of the keys
+// assigned to other instances, or no longer listed, are released.
+func (c *Coordinator) Claim(ctx context.Context, keys []string) (owned []string, acquired map[string]int64) {
+	now := c.clock()
+	acquired = map[string]int64{}
+	owners, err := c.liveOwners(ctx, now)
+	if err != nil {
+		// Without the list of instances, the keys are not reassigned
+		// until the lease store is available again.
+		c.metrics.Errors.Inc()
+		c.log.Errorw("Failed to list the instances of the input, keeping the held leases", "error", err)
+	}
+
+	listed := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		listed[key] = true
+		held := c.holds(key)
+		if owners == nil {
+			if held {
+				owned = append(owned, key)
+			}
+			continue
+		}
+		assigned := assignedOwner(owners, key) == c.owner
+		switch {
+		case held && assigned:
+			owned = append(owned, key)
+		case held:
+			c.release(ctx, key)
+		case assigned:
+			checkpoint, ok, err := c.store.Acquire(ctx, key, c.owner, now, now.Add(c.leaseDuration))
+			if err != nil {
+				c.metrics.Errors.Inc()
+				c.log.Errorw("Failed to acquire the lease", "lease", key, "error", err)
+				continue
+			}
+			if !ok {
+				// The previous owner holds the lease until it releases
+				// it or it expires.
+				c.log.Debugw("The lease is held by another instance", "lease", key)
+				continue
+			}
+			c.mu.Lock()
+			c.held[key] = heldLease{expires: now.Add(c.leaseDuration)}
+			c.mu.Unlock()
+			acquired[key] = checkpoint
+			owned = append(owned, key)
+			c.log.Infow("Acquired the lease", "lease", key, "checkpoint", checkpoint)
+		}
+	}
+
+	for _, key := range c.heldKeys() {
+		if !listed[key] {
+			c.release(ctx, key)
+		}
+	}
+	c.metrics.Owned.Set(uint64(len(owned)))
+	return owned, acquired
+}
+
+// liveOwners records the heartbeat of the instance and returns the live
+// instances, the instance included.
+func (c *Coordinator) liveOwners(ctx context.Context, now time.Time) ([]string, error) {
+	if err := c.store.Heartbeat(ctx, c.owner, now.Add(c.leaseDuration)); err != nil {
+		return nil, err
+	}
+	owners, err := c.store.Owners(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range owners {
+		if o == c.owner {
+			return owners, nil
+		}
+	}
+	return append(owners, c.owner), nil
+}
+
+// assignedOwner returns the owner a lease is assigned to, the owner with the
+// highest hash of the owner and the key.
+func assignedOwner(owners []string, key string) string {
+	var best string
+	var bestHash uint64
+	for _, o := range owners {
+		h := fnv.New64a()
+		h.Write([]byte(o))
+		h.Write([]byte{0})
+		h.Write([]byte(key))
+		if sum := h.Sum64(); best == "" || sum > bestHash || (sum == bestHash && o < best) {
+			best, bestHash = o, sum
+		}
+	}
+	return best
+}
+
+func (c *Coordinator) holds(key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	_, ok := c.held[key]
+	return ok
+}
+
+func (c *Coordinator) heldKeys() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	keys := make([]string, 0, len(c.held))
+	for key := range c.held {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// SetCheckpoint records the checkpoint of a held lease, stored with its next
+// renewal. The checkpoints only move forward.
+func (c *Coordinator) SetCheckpoint(key string, checkpoint int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if l, ok := c.held[key]; ok && checkpoint > l.checkpoint {
+		l.checkpoint = checkpoint
+		c.held[key] = l
+	}
+}
+
+// release gives up the lease of key with its last checkpoint.
+func (c *Coordinator) release(ctx context.Context, key string) {
+	c.mu.Lock()
+	l, ok := c.held[key]
+	delete(c.held, key)
+	c.mu.Unlock()
+	if !ok {
+		return
+	}
+	if err := c.store.Release(ctx, key, c.owner, l.checkpoint); err != nil {
+		c.metrics.Errors.Inc()
+		c.log.Errorw("Failed to release the lease", "lease", key, "error", err)
+		return
+	}
+	c.log.Infow("Released the lease", "lease", key)
+}
+
+// renew extends the held leases and stores their checkpoints. The leases
+// taken over by other instances are dropped, and so are the leases that could
+// not be renewed before they expired, since another instance may acquire them
+// from then on.
+func (c *Coordinator) renew(ctx context.Context) {
+	now := c.clock()
+	expires := now.Add(c.leaseDuration)
+	if err := c.store.Heartbeat(ctx, c.owner, expires); err != nil {
+		c.metrics.Errors.Inc()
+		c.log.Errorw("Failed to record the heartbeat of the instance", "error", err)
+	}
+	for _, key := range c.heldKeys() {
+		c.mu.Lock()
+		l, ok := c.held[key]
+		c.mu.Unlock()
+		if !ok {
+			continue
+		}
+		ok, err := c.store.Renew(ctx, key, c.owner, expires, l.checkpoint)
+		if err != nil {
+			c.metrics.Errors.Inc()
+			c.log.Errorw("Failed to renew the lease", "lease", key, "error", err)
+			c.mu.Lock()
+			if cur, held := c.held[key]; held && !now.Before(cur.expires) {
+				delete(c.held, key)
+				c.metrics.Owned.Set(uint64(len(c.held)))
+				c.log.Warnw("Dropped the lease, it expired before it could be renewed", "lease", key)
+			}
+			c.mu.Unlock()
+			continue
+		}
+		c.mu.Lock()
+		if cur, held := c.held[key]; !ok && held {
+			delete(c.held, key)
+			c.metrics.Owned.Set(uint64(len(c.held)))
+			c.log.Warnw("The lease was taken over by another instance", "lease", key)
+		} else if held {
+			cur.expires = expires
+			if cur.checkpoint == l.checkpoint {
+				cur.checkpoint = 0
+			}
+			c.held[key] = cur
+		}
+		c.mu.Unlock()
+	}
+}
+
+// Run renews the held leases until ctx is done, and releases them and removes
+// the heartbeat of the instance then.
+func (c *Coordinator) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.leaseDuration / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			releaseCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			for _, key := range c.heldKeys() {
+				c.release(releaseCtx, key)
+			}
+			if err := c.store.Leave(releaseCtx, c.owner); err != nil {
+				c.metrics.Errors.Inc()
+				c.log.Errorw("Failed to remove the heartbeat of the instance", "error", err)
+			}
+			return
+		case <-ticker.C:
+			c.renew(ctx)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package leases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+type memoryLease struct {
+	owner      string
+	expires    time.Time
+	checkpoint int64
+}
+
+// memoryStore is a Store shared by the coordinators of a test.
+type memoryStore struct {
+	mu         sync.Mutex
+	heartbeats map[string]time.Time
+	leases     map[string]*memoryLease
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{heartbeats: map[string]time.Time{}, leases: map[string]*memoryLease{}}
+}
+
+func (s *memoryStore) Heartbeat(_ context.Context, owner string, expires time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.heartbeats[owner] = expires
+	return nil
+}
+
+func (s *memoryStore) Owners(_ context.Context, now time.Time) ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var owners []string
+	for owner, expires := range s.heartbeats {
+		if expires.After(now) {
+			owners = append(owners, owner)
+		}
+	}
+	return owners, nil
+}
+
+func (s *memoryStore) Acquire(_ context.Context, key, owner string, now, expires time.Time) (int64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.leases[key]
+	if !ok {
+		l = &memoryLease{}
+		s.leases[key] = l
+	}
+	if l.owner != "" && l.owner != owner && !l.expires.Before(now) {
+		return 0, false, nil
+	}
+	l.owner, l.expires = owner, expires
+	return l.checkpoint, true, nil
+}
+
+func (s *memoryStore) Renew(_ context.Context, key, owner string, expires time.Time, checkpoint int64) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	l, ok := s.leases[key]
+	if !ok || l.owner != owner {
+		return false, nil
+	}
+	l.expires = expires
+	if checkpoint != 0 {
+		l.checkpoint = checkpoint
+	}
+	return true, nil
+}
+
+func (s *memoryStore) Leave(_ context.Context, owner string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.heartbeats, owner)
+	return nil
+}
+
+func (s *memoryStore) Release(_ context.Context, key, owner string, checkpoint int64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if l, ok := s.leases[key]; ok && l.owner == owner {
+		l.expires = time.UnixMilli(0)
+		if checkpoint != 0 {
+			l.checkpoint = checkpoint
+		}
+	}
+	return nil
+}
+
+func testCoordinator(store Store, owner string, now *time.Time) *Coordinator {
+	reg := monitoring.NewRegistry()
+	c := NewCoordinator(Config{OwnerID: owner, LeaseDuration: time.Minute}, store, Metrics{
+		Owned:  monitoring.NewUint(reg, "owned"),
+		Errors: monitoring.NewUint(reg, "errors"),
+	}, logp.NewLogger("test"))
+	c.clock = func() time.Time { return *now }
+	return c
+}
+
+func sorted(keys []string) []string {
+	keys = append([]string(nil), keys...)
+	sort.Strings(keys)
+	return keys
+}
+
+func TestCoordinatorPartitioning(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1000, 0)
+	store := newMemoryStore()
+	a := testCoordinator(store, "instance-a", &now)
+	b := testCoordinator(store, "instance-b", &now)
+
+	var keys []string
+	for i := 0; i < 20; i++ {
+		keys = append(keys, fmt.Sprintf("key-%02d", i))
+	}
+
+	// The first instance holds all the leases until the second one joins.
+	ownedA, acquired := a.Claim(ctx, keys)
+	assert.Len(t, ownedA, 20)
+	assert.Len(t, acquired, 20)
+	for _, key := range keys {
+		a.SetCheckpoint(key, 5000)
+	}
+	a.renew(ctx)
+
+	ownedB, _ := b.Claim(ctx, keys)
+	assert.Empty(t, ownedB, "the leases are held by the first instance")
+	ownedA, acquired = a.Claim(ctx, keys)
+	assert.Empty(t, acquired)
+	assert.NotEmpty(t, ownedA)
+	assert.Less(t, len(ownedA), 20, "the leases assigned to the second instance are released")
+
+	ownedB, acquired = b.Claim(ctx, keys)
+	assert.Len(t, ownedB, 20-len(ownedA))
+	for _, key := range ownedB {
+		assert.Equal(t, int64(5000), acquired[key], "the checkpoint of the previous owner is returned")
+	}
+	assert.Equal(t, keys, sorted(append(ownedA, ownedB...)), "each lease is held by a single instance")
+	assert.Equal(t, uint64(len(ownedB)), b.metrics.Owned.Get())
+
+	// The leases of an instance that stopped are taken over once its
+	// heartbeat and its leases expired.
+	now = now.Add(30 * time.Second)
+	b.Claim(ctx, keys)
+	now = now.Add(45 * time.Second)
+	ownedB, _ = b.Claim(ctx, keys)
+	assert.Len(t, ownedB, 20)
+
+	// The leases taken over are dropped by the renewal.
+	a.renew(ctx)
+	assert.Empty(t, a.heldKeys())
+}
+
+func TestCoordinatorStoreUnavailable(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1000, 0)
+	store := &failingStore{memoryStore: newMemoryStore()}
+	c := testCoordinator(store, "instance-a", &now)
+
+	owned, _ := c.Claim(ctx, []string{"key-1"})
+	require.Len(t, owned, 1)
+
+	store.failing = true
+	owned, acquired := c.Claim(ctx, []string{"key-1", "key-2"})
+	assert.Equal(t, []string{"key-1"}, owned, "the held leases are kept while the store is unavailable")
+	assert.Empty(t, acquired)
+	assert.Positive(t, c.metrics.Errors.Get())
+}
+
+func TestCoordinatorRenewFailing(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1000, 0)
+	store := &failingStore{memoryStore: newMemoryStore()}
+	c := testCoordinator(store, "instance-a", &now)
+
+	owned, _ := c.Claim(ctx, []string{"key-1"})
+	require.Len(t, owned, 1)
+
+	// The leases are kept while they are valid, and dropped once they
+	// expired without being renewed.
+	store.failing = true
+	now = now.Add(40 * time.Second)
+	c.renew(ctx)
+	assert.Equal(t, []string{"key-1"}, c.heldKeys())
+	now = now.Add(20 * time.Second)
+	c.renew(ctx)
+	assert.Empty(t, c.heldKeys())
+	assert.Zero(t, c.metrics.Owned.Get())
+
+	// A successful renewal extends the validity of the leases.
+	store.failing = false
+	c.Claim(ctx, []string{"key-1"})
+	now = now.Add(40 * time.Second)
+	c.renew(ctx)
+	store.failing = true
+	now = now.Add(40 * time.Second)
+	c.renew(ctx)
+	assert.Equal(t, []string{"key-1"}, c.heldKeys())
+}
+
+func TestCoordinatorRunLeave(t *testing.T) {
+	now := time.Unix(1000, 0)
+	store := newMemoryStore()
+	c := testCoordinator(store, "instance-a", &now)
+	owned, _ := c.Claim(context.Background(), []string{"key-1"})
+	require.Len(t, owned, 1)
+	require.Contains(t, store.heartbeats, "instance-a")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c.Run(ctx)
+	assert.Empty(t, c.heldKeys())
+	assert.Equal(t, time.UnixMilli(0), store.leases["key-1"].expires, "the leases are released")
+	assert.NotContains(t, store.heartbeats, "instance-a", "the heartbeat of the instance is removed")
+}
+
+type failingStore struct {
+	*memoryStore
+	failing bool
+}
+
+func (s *failingStore) Owners(ctx context.Context, now time.Time) ([]string, error) {
+	if s.failing {
+		return nil, fmt.Errorf("unavailable")
+	}
+	return s.memoryStore.Owners(ctx, now)
+}
+
+func (s *failingStore) Renew(ctx context.Context, key, owner string, expires time.Time, checkpoint int64) (bool, error) {
+	if s.failing {
+		return false, fmt.Errorf("unavailable")
+	}
+	return s.memoryStore.Renew(ctx, key, owner, expires, checkpoint)
+}
+
+func TestAssignedOwner(t *testing.T) {
+	owners := []string{"a", "b", "c"}
+	counts := map[string]int{}
+	for i := 0; i < 300; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		owner := assignedOwner(owners, key)
+		counts[owner]++
+		assert.Equal(t, owner, assignedOwner([]string{"c", "a", "b"}, key), "the assignment doesn't depend on the order of the owners")
+	}
+	for _, o := range owners {
+		assert.Greater(t, counts[o], 50, "the keys are spread across the owners")
+	}
+}
+
+func TestConfig(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Enabled = true
+	assert.ErrorContains(t, cfg.Validate(), "coordination.table_name is required")
+	cfg.TableName = "leases"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Type = TypeS3
+	assert.ErrorContains(t, cfg.Validate(), "coordination.bucket_name is required")
+	cfg.BucketName = "bucket"
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Partitions = 0
+	assert.ErrorContains(t, cfg.Validate(), "coordination.partitions <0> must be greater than 0")
+	cfg.Partitions = 16
+
+	cfg.Type = "etcd"
+	assert.ErrorContains(t, cfg.Validate(), "invalid coordination.type")
+}
@@ -0,0 +1,250 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package leases
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/smithy-go"
+
+	awscommon "github.com/elastic/beats/v7/x-pack/libbeat/common/aws"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// s3Client is the part of the S3 API used by the S3 lease store.
+type s3Client interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	PutObject(ctx context.Context, params *s3.PutObjectInput, optFns ...func(*s3.Options)) (*s3.PutObjectOutput, error)
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+	DeleteObject(ctx context.Context, params *s3.DeleteObjectInput, optFns ...func(*s3.Options)) (*s3.DeleteObjectOutput, error)
+}
+
+// s3Lease is the content of the objects of the S3 lease store.
+type s3Lease struct {
+	Owner      string `json:"owner"`
+	Expires    int64  `json:"expires"`
+	Checkpoint int64  `json:"checkpoint,omitempty"`
+}
+
+// s3Store stores the leases in an S3 bucket, one object per lease and per
+// instance. The objects are updated with conditional writes, so a lease is
+// only taken by one instance when several try at once. The objects of the
+// expired instances are deleted when the instances are listed.
+type s3Store struct {
+	client s3Client
+	bucket string
+	prefix string
+	log    *logp.Logger
+}
+
+// newS3Store returns a lease store using the bucket of cfg, in region.
+func newS3Store(cfg Config, awsCfg awscommon.ConfigAWS, region, prefix string, log *logp.Logger) (*s3Store, error) {
+	awsCfg.DefaultRegion = region
+	awsConfig, err := awscommon.InitializeAWSConfig(awsCfg, log)
+	if err != nil {
+		return nil, err
+	}
+	client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		if cfg.Endpoint != "" {
+			o.BaseEndpoint = awssdk.String(cfg.Endpoint)
+			o.UsePathStyle = true
+		}
+	})
+	return &s3Store{
+		client: client,
+		bucket: cfg.BucketName,
+		prefix: cfg.ObjectPrefix + prefix,
+		log:    log,
+	}, nil
+}
+
+func (s *s3Store) instanceObjectKey(owner string) string {
+	return s.prefix + "/instances/" + owner
+}
+
+func (s *s3Store) leaseObjectKey(key string) string {
+	return s.prefix + "/leases/" + key
+}
+
+// get returns the lease stored in the object of key and its ETag, or nil if
+// the object doesn't exist.
+func (s *s3Store) get(ctx context.Context, key string) (*s3Lease, *string, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: awssdk.String(s.bucket),
+		Key:    awssdk.String(key),
+	})
+	if hasErrorCode(err, "NoSuchKey", "NotFound") {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	defer out.Body.Close()
+	b, err := io.ReadAll(out.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+	var l s3Lease
+	if err := json.Unmarshal(b, &l); err != nil {
+		return nil, nil, fmt.Errorf("invalid lease %s: %w", key, err)
+	}
+	return &l, out.ETag, nil
+}
+
+// put writes l to the object of key. If etag is nil, the object must not
+// exist, otherwise it must not have changed since it was read. It returns
+// false if the condition is not met.
+func (s *s3Store) put(ctx context.Context, key string, l s3Lease, etag *string) (bool, error) {
+	b, err := json.Marshal(l)
+	if err != nil {
+		return false, err
+	}
+	in := &s3.PutObjectInput{
+		Bucket:      awssdk.String(s.bucket),
+		Key:         awssdk.String(key),
+		Body:        bytes.NewReader(b),
+		ContentType: awssdk.String("application/json"),
+	}
+	if etag == nil {
+		in.IfNoneMatch = awssdk.String("*")
+	} else {
+		in.IfMatch = etag
+	}
+	_, err = s.client.PutObject(ctx, in)
+	if hasErrorCode(err, "PreconditionFailed", "ConditionalRequestConflict") {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func hasErrorCode(err error, codes ...string) bool {
+	var apiErr smithy.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	for _, code := range codes {
+		if apiErr.ErrorCode() == code {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *s3Store) Heartbeat(ctx context.Context, owner string, expires time.Time) error {
+	b, err := json.Marshal(s3Lease{Owner: owner, Expires: expires.UnixMilli()})
+	if err != nil {
+		return err
+	}
+	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:      awssdk.String(s.bucket),
+		Key:         awssdk.String(s.instanceObjectKey(owner)),
+		Body:        bytes.NewReader(b),
+		ContentType: awssdk.String("application/json"),
+	})
+	return err
+}
+
+func (s *s3Store) Owners(ctx context.Context, now time.Time) ([]string, error) {
+	paginator := s3.NewListObjectsV2Paginator(s.client, &s3.ListObjectsV2Input{
+		Bucket: awssdk.String(s.bucket),
+		Prefix: awssdk.String(s.instanceObjectKey("")),
+	})
+	var owners []string
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, obj := range page.Contents {
+			l, etag, err := s.get(ctx, awssdk.ToString(obj.Key))
+			if err != nil {
+				return nil, err
+			}
+			switch {
+			case l == nil:
+			case l.Expires > now.UnixMilli():
+				owners = append(owners, l.Owner)
+			default:
+				s.deleteExpired(ctx, awssdk.ToString(obj.Key), etag)
+			}
+		}
+	}
+	return owners, nil
+}
+
+// deleteExpired deletes the object of an expired instance if it was not
+// updated since it was read, so an instance coming back is not removed. It is
+// best effort, the expired instances are not listed anyway.
+func (s *s3Store) deleteExpired(ctx context.Context, key string, etag *string) {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket:  awssdk.String(s.bucket),
+		Key:     awssdk.String(key),
+		IfMatch: etag,
+	})
+	if err != nil && !hasErrorCode(err, "PreconditionFailed", "ConditionalRequestConflict", "NoSuchKey", "NotFound") {
+		s.log.Debugw("Failed to delete the object of an expired instance", "key", key, "error", err)
+	}
+}
+
+func (s *s3Store) Leave(ctx context.Context, owner string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: awssdk.String(s.bucket),
+		Key:    awssdk.String(s.instanceObjectKey(owner)),
+	})
+	return err
+}
+
+func (s *s3Store) Acquire(ctx context.Context, key, owner string, now, expires time.Time) (int64, bool, error) {
+	objKey := s.leaseObjectKey(key)
+	l, etag, err := s.get(ctx, objKey)
+	if err != nil {
+		return 0, false, err
+	}
+	if l == nil {
+		l = &s3Lease{}
+	} else if l.Owner != owner && l.Expires >= now.UnixMilli() {
+		return 0, false, nil
+	}
+	l.Owner, l.Expires = owner, expires.UnixMilli()
+	ok, err := s.put(ctx, objKey, *l, etag)
+	if !ok || err != nil {
+		return 0, false, err
+	}
+	return l.Checkpoint, true, nil
+}
+
+// update writes the expiration and the checkpoint of the lease of key if it
+// is held by owner.
+func (s *s3Store) update(ctx context.Context, key, owner string, expires time.Time, checkpoint int64) (bool, error) {
+	objKey := s.leaseObjectKey(key)
+	l, etag, err := s.get(ctx, objKey)
+	if err != nil || l == nil || l.Owner != owner {
+		return false, err
+	}
+	l.Expires = expires.UnixMilli()
+	if checkpoint != 0 {
+		l.Checkpoint = checkpoint
+	}
+	return s.put(ctx, objKey, *l, etag)
+}
+
+func (s *s3Store) Renew(ctx context.Context, key, owner string, expires time.Time, checkpoint int64) (bool, error) {
+	return s.update(ctx, key, owner, expires, checkpoint)
+}
+
+func (s *s3Store) Release(ctx context.Context, key, owner string, checkpoint int64) error {
+	// The lease expires at once, so the assigned instance acquires it with
+	// its next claim.
+	_, err := s.update(ctx, key, owner, time.UnixMilli(0), checkpoint)
+	return err
+}
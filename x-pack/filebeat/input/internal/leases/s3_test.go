@@ -0,0 +1,150 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package leases
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+type memoryObject struct {
+	body []byte
+	etag string
+}
+
+// memoryS3 is an S3 client keeping the objects in memory, supporting the
+// conditional writes.
+type memoryS3 struct {
+	mu      sync.Mutex
+	objects map[string]memoryObject
+	version int
+}
+
+func (m *memoryS3) GetObject(_ context.Context, in *s3.GetObjectInput, _ ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	obj, ok := m.objects[awssdk.ToString(in.Key)]
+	if !ok {
+		return nil, &smithy.GenericAPIError{Code: "NoSuchKey"}
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewReader(obj.body)), ETag: awssdk.String(obj.etag)}, nil
+}
+
+func (m *memoryS3) PutObject(_ context.Context, in *s3.PutObjectInput, _ ...func(*s3.Options)) (*s3.PutObjectOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := awssdk.ToString(in.Key)
+	obj, exists := m.objects[key]
+	if (in.IfNoneMatch != nil && exists) || (in.IfMatch != nil && (!exists || obj.etag != *in.IfMatch)) {
+		return nil, &smithy.GenericAPIError{Code: "PreconditionFailed"}
+	}
+	body, err := io.ReadAll(in.Body)
+	if err != nil {
+		return nil, err
+	}
+	m.version++
+	m.objects[key] = memoryObject{body: body, etag: strconv.Itoa(m.version)}
+	return &s3.PutObjectOutput{}, nil
+}
+
+func (m *memoryS3) ListObjectsV2(_ context.Context, in *s3.ListObjectsV2Input, _ ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := &s3.ListObjectsV2Output{}
+	for key := range m.objects {
+		if strings.HasPrefix(key, awssdk.ToString(in.Prefix)) {
+			out.Contents = append(out.Contents, types.Object{Key: awssdk.String(key)})
+		}
+	}
+	sort.Slice(out.Contents, func(i, j int) bool { return *out.Contents[i].Key < *out.Contents[j].Key })
+	return out, nil
+}
+
+func (m *memoryS3) DeleteObject(_ context.Context, in *s3.DeleteObjectInput, _ ...func(*s3.Options)) (*s3.DeleteObjectOutput, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	key := awssdk.ToString(in.Key)
+	obj, exists := m.objects[key]
+	if in.IfMatch != nil && (!exists || obj.etag != *in.IfMatch) {
+		return nil, &smithy.GenericAPIError{Code: "PreconditionFailed"}
+	}
+	delete(m.objects, key)
+	return &s3.DeleteObjectOutput{}, nil
+}
+
+func TestS3Store(t *testing.T) {
+	ctx := context.Background()
+	now := time.UnixMilli(10_000)
+	client := &memoryS3{objects: map[string]memoryObject{}}
+	s := &s3Store{client: client, bucket: "bucket", prefix: "filebeat-leases/input", log: logp.NewLogger("test")}
+
+	require.NoError(t, s.Heartbeat(ctx, "a", now.Add(time.Minute)))
+	require.NoError(t, s.Heartbeat(ctx, "b", now.Add(-time.Second)))
+	owners, err := s.Owners(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, owners, "the expired instances are not listed")
+	assert.Contains(t, client.objects, "filebeat-leases/input/instances/a")
+	assert.NotContains(t, client.objects, "filebeat-leases/input/instances/b", "the expired instances are deleted")
+
+	// An expired instance updated since it was read is not deleted.
+	require.NoError(t, s.Heartbeat(ctx, "b", now.Add(-time.Second)))
+	_, etag, err := s.get(ctx, s.instanceObjectKey("b"))
+	require.NoError(t, err)
+	require.NoError(t, s.Heartbeat(ctx, "b", now.Add(time.Minute)))
+	s.deleteExpired(ctx, s.instanceObjectKey("b"), etag)
+	assert.Contains(t, client.objects, "filebeat-leases/input/instances/b")
+
+	require.NoError(t, s.Leave(ctx, "b"))
+	assert.NotContains(t, client.objects, "filebeat-leases/input/instances/b", "the instance leaving is deleted")
+
+	checkpoint, ok, err := s.Acquire(ctx, "key", "a", now, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Zero(t, checkpoint)
+	assert.Contains(t, client.objects, "filebeat-leases/input/leases/key")
+
+	_, ok, err = s.Acquire(ctx, "key", "b", now, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.False(t, ok, "the lease is held by another owner")
+
+	ok, err = s.Renew(ctx, "key", "a", now.Add(time.Minute), 5000)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = s.Renew(ctx, "key", "b", now.Add(time.Minute), 6000)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	require.NoError(t, s.Release(ctx, "key", "a", 0))
+	checkpoint, ok, err = s.Acquire(ctx, "key", "b", now, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, ok, "the released lease is acquired")
+	assert.Equal(t, int64(5000), checkpoint)
+
+	// A lease changed since it was read is not written.
+	l, etag, err = s.get(ctx, s.leaseObjectKey("key"))
+	require.NoError(t, err)
+	ok, err = s.put(ctx, s.leaseObjectKey("key"), *l, etag)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = s.put(ctx, s.leaseObjectKey("key"), *l, etag)
+	require.NoError(t, err)
+	assert.False(t, ok)
+}
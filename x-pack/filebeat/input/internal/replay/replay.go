@@ -0,0 +1,199 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Package replay provides replay ID gap detection and backfill for streaming
+// inputs.
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// Config is the replay configuration shared by streaming inputs.
+type Config struct {
+	// GapDetection enables reporting of gaps between the
+	// replay IDs of consecutively received events.
+	GapDetection bool `config:"gap_detection"`
+	// Backfill is the configuration for requesting missed
+	// events from a REST endpoint when a gap is detected.
+	Backfill *BackfillConfig `config:"backfill"`
+}
+
+func (c *Config) Validate() error {
+	if c.Backfill != nil && !c.GapDetection {
+		return errors.New("replay.backfill requires replay.gap_detection to be enabled")
+	}
+	return nil
+}
+
+// BackfillConfig is the configuration for requesting missed events.
+type BackfillConfig struct {
+	// URL is the endpoint that is queried for missed events. The
+	// first and last missed replay IDs are added to the request as
+	// the from_id and to_id query parameters.
+	URL string `config:"url" validate:"required"`
+	// Timeout is the timeout for each backfill request.
+	Timeout time.Duration `config:"timeout"`
+	// MaxRange is the largest gap that will be requested. Larger
+	// gaps are still reported, but are not backfilled. A zero
+	// value places no limit on the gap size.
+	MaxRange int64 `config:"max_range"`
+}
+
+func (c *BackfillConfig) Validate() error {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return fmt.Errorf("invalid replay.backfill.url: %w", err)
+	}
+	switch u.Scheme {
+	case "http", "https":
+	default:
+		return fmt.Errorf("unsupported replay.backfill.url scheme: %q", u.Scheme)
+	}
+	if c.Timeout < 0 {
+		return fmt.Errorf("replay.backfill.timeout is negative: %v", c.Timeout)
+	}
+	if c.Timeout == 0 {
+		c.Timeout = 30 * time.Second
+	}
+	if c.MaxRange < 0 {
+		return fmt.Errorf("replay.backfill.max_range is negative: %d", c.MaxRange)
+	}
+	return nil
+}
+
+// Gap is an inclusive range of replay IDs that were not received.
+type Gap struct {
+	From, To int64
+}
+
+// Count returns the number of replay IDs missing in the gap.
+func (g Gap) Count() int64 {
+	return g.To - g.From + 1
+}
+
+// Event returns a warning event describing the gap. The details of
+// the gap are placed under the replay_gap field in namespace.
+func (g Gap) Event(namespace string, now time.Time) beat.Event {
+	return beat.Event{
+		Timestamp: now,
+		Fields: mapstr.M{
+			"message": fmt.Sprintf("missed %d events between replay IDs %d and %d", g.Count(), g.From, g.To),
+			"event": mapstr.M{
+				"kind":    "event",
+				"action":  "replay-gap",
+				"created": now,
+			},
+			"log": mapstr.M{
+				"level": "warning",
+			},
+			namespace: mapstr.M{
+				"replay_gap": mapstr.M{
+					"from":  g.From,
+					"to":    g.To,
+					"count": g.Count(),
+				},
+			},
+		},
+	}
+}
+
+// Detector tracks the replay IDs of a stream and reports gaps between them.
+// The zero value is ready to use.
+type Detector struct {
+	last int64
+	seen bool
+}
+
+// Observe records id as received and returns the gap between it and the
+// previously received replay ID, if there is one. IDs that are not greater
+// than the last received ID, such as replayed duplicates, are ignored.
+func (d *Detector) Observe(id int64) (gap Gap, ok bool) {
+	if !d.seen {
+		d.last, d.seen = id, true
+		return Gap{}, false
+	}
+	if id <= d.last {
+		return Gap{}, false
+	}
+	if id > d.last+1 {
+		gap, ok = Gap{From: d.last + 1, To: id - 1}, true
+	}
+	d.last = id
+	return gap, ok
+}
+
+// Last returns the last received replay ID and whether any ID has been
+// received.
+func (d *Detector) Last() (int64, bool) {
+	return d.last, d.seen
+}
+
+// Backfiller requests missed events from a REST endpoint.
+type Backfiller struct {
+	cfg    *BackfillConfig
+	client *http.Client
+}
+
+// NewBackfiller returns a Backfiller for cfg. If client is nil a client
+// with the configured timeout is used.
+func NewBackfiller(cfg *BackfillConfig, client *http.Client) *Backfiller {
+	if client == nil {
+		client = &http.Client{Timeout: cfg.Timeout}
+	}
+	return &Backfiller{cfg: cfg, client: client}
+}
+
+// Fetch requests the events missed in gap, adding header to the request.
+// The endpoint must respond with a JSON array of events. Fetch returns
+// a nil slice without error if the gap is larger than the configured
+// maximum range.
+func (b *Backfiller) Fetch(ctx context.Context, gap Gap, header http.Header) ([]json.RawMessage, error) {
+	if b.cfg.MaxRange != 0 && gap.Count() > b.cfg.MaxRange {
+		return nil, nil
+	}
+	u, err := url.Parse(b.cfg.URL)
+	if err != nil {
+		return nil, err
+	}
+	q := u.Query()
+	q.Set("from_id", strconv.FormatInt(gap.From, 10))
+	q.Set("to_id", strconv.FormatInt(gap.To, 10))
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := b.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request backfill: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return nil, fmt.Errorf("backfill request failed with status %s: %s", resp.Status, body)
+	}
+	var events []json.RawMessage
+	err = json.NewDecoder(resp.Body).Decode(&events)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode backfill response: %w", err)
+	}
+	return events, nil
+}
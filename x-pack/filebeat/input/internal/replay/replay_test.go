@@ -0,0 +1,147 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package replay
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+)
+
+func TestDetector(t *testing.T) {
+	var d Detector
+	_, ok := d.Last()
+	assert.False(t, ok)
+
+	for _, tc := range []struct {
+		id      int64
+		wantGap Gap
+		wantOK  bool
+	}{
+		{id: 10},
+		{id: 11},
+		{id: 15, wantGap: Gap{From: 12, To: 14}, wantOK: true},
+		{id: 13}, // Replayed duplicate.
+		{id: 16},
+		{id: 1000, wantGap: Gap{From: 17, To: 999}, wantOK: true},
+	} {
+		gap, ok := d.Observe(tc.id)
+		assert.Equal(t, tc.wantOK, ok, "id %d", tc.id)
+		assert.Equal(t, tc.wantGap, gap, "id %d", tc.id)
+	}
+	last, ok := d.Last()
+	assert.True(t, ok)
+	assert.Equal(t, int64(1000), last)
+}
+
+func TestGapEvent(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	e := Gap{From: 12, To: 14}.Event("cometd", now)
+	assert.Equal(t, now, e.Timestamp)
+
+	msg, err := e.Fields.GetValue("message")
+	require.NoError(t, err)
+	assert.Equal(t, "missed 3 events between replay IDs 12 and 14", msg)
+	count, err := e.Fields.GetValue("cometd.replay_gap.count")
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	level, err := e.Fields.GetValue("log.level")
+	require.NoError(t, err)
+	assert.Equal(t, "warning", level)
+}
+
+func TestConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		cfg  map[string]interface{}
+		err  string
+	}{
+		{
+			name: "gap detection",
+			cfg:  map[string]interface{}{"gap_detection": true},
+		},
+		{
+			name: "backfill",
+			cfg: map[string]interface{}{
+				"gap_detection": true,
+				"backfill":      map[string]interface{}{"url": "https://example.com/events"},
+			},
+		},
+		{
+			name: "backfill without gap detection",
+			cfg: map[string]interface{}{
+				"backfill": map[string]interface{}{"url": "https://example.com/events"},
+			},
+			err: "replay.backfill requires replay.gap_detection to be enabled",
+		},
+		{
+			name: "backfill unsupported scheme",
+			cfg: map[string]interface{}{
+				"gap_detection": true,
+				"backfill":      map[string]interface{}{"url": "ftp://example.com/events"},
+			},
+			err: `unsupported replay.backfill.url scheme: "ftp"`,
+		},
+		{
+			name: "backfill negative max_range",
+			cfg: map[string]interface{}{
+				"gap_detection": true,
+				"backfill":      map[string]interface{}{"url": "https://example.com/events", "max_range": -1},
+			},
+			err: "replay.backfill.max_range is negative",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var c Config
+			err := conf.MustNewConfigFrom(tc.cfg).Unpack(&c)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestBackfiller(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		q := r.URL.Query()
+		_ = json.NewEncoder(w).Encode([]map[string]string{
+			{"id": q.Get("from_id")},
+			{"id": q.Get("to_id")},
+		})
+	}))
+	defer srv.Close()
+
+	cfg := &BackfillConfig{URL: srv.URL + "/events", MaxRange: 10}
+	require.NoError(t, cfg.Validate())
+	b := NewBackfiller(cfg, nil)
+
+	header := http.Header{"Authorization": []string{"Bearer token"}}
+	events, err := b.Fetch(context.Background(), Gap{From: 12, To: 14}, header)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.JSONEq(t, `{"id":"12"}`, string(events[0]))
+	assert.JSONEq(t, `{"id":"14"}`, string(events[1]))
+
+	events, err = b.Fetch(context.Background(), Gap{From: 12, To: 100}, header)
+	assert.NoError(t, err)
+	assert.Nil(t, events, "expected gap larger than max_range to be skipped")
+
+	_, err = b.Fetch(context.Background(), Gap{From: 12, To: 14}, nil)
+	assert.ErrorContains(t, err, "backfill request failed with status 401")
+}
@@ -0,0 +1,102 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Package schemadrift detects the fields of API documents not seen before,
+// so changes of the upstream APIs can be noticed before they break the
+// parsing of the events.
+package schemadrift
+
+import (
+	"sync"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+const (
+	// warmup is the number of documents observed before fields not seen
+	// before are reported as new. The fields of the first documents are the
+	// baseline schema of the API.
+	warmup = 100
+	// maxFields is the maximum number of fields tracked, bounding the memory
+	// used by APIs with unbounded field names.
+	maxFields = 10000
+	// maxDepth is the maximum depth of the tracked fields.
+	maxDepth = 10
+)
+
+// Tracker records the fields of the observed documents. The nil *Tracker
+// does not record anything.
+type Tracker struct {
+	mu    sync.Mutex
+	known map[string]struct{}
+	docs  int
+
+	fields    *monitoring.Uint // number of known fields
+	newFields *monitoring.Uint // fields first seen after the warm-up
+}
+
+// NewTracker returns a Tracker publishing its metrics to reg:
+//
+//	schema_fields
+//	schema_new_fields_total
+//
+// It returns nil if reg is nil.
+func NewTracker(reg *monitoring.Registry) *Tracker {
+	if reg == nil {
+		return nil
+	}
+	return &Tracker{
+		known:     map[string]struct{}{},
+		fields:    monitoring.NewUint(reg, "schema_fields"),
+		newFields: monitoring.NewUint(reg, "schema_new_fields_total"),
+	}
+}
+
+// Observe records the fields of doc, and returns the dotted paths of the
+// fields not seen before once the warm-up is over. The fields of the objects
+// in arrays are recorded under the path of the array.
+func (t *Tracker) Observe(doc map[string]interface{}) []string {
+	if t == nil {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var found []string
+	t.walk("", doc, 0, &found)
+	t.docs++
+	t.fields.Set(uint64(len(t.known)))
+	if t.docs <= warmup {
+		return nil
+	}
+	t.newFields.Add(uint64(len(found)))
+	return found
+}
+
+func (t *Tracker) walk(prefix string, v interface{}, depth int, found *[]string) {
+	if depth >= maxDepth {
+		return
+	}
+	switch v := v.(type) {
+	case mapstr.M:
+		t.walk(prefix, map[string]interface{}(v), depth, found)
+	case map[string]interface{}:
+		for k, child := range v {
+			path := k
+			if prefix != "" {
+				path = prefix + "." + k
+			}
+			if _, ok := t.known[path]; !ok && len(t.known) < maxFields {
+				t.known[path] = struct{}{}
+				*found = append(*found, path)
+			}
+			t.walk(path, child, depth+1, found)
+		}
+	case []interface{}:
+		for _, elem := range v {
+			t.walk(prefix, elem, depth+1, found)
+		}
+	}
+}
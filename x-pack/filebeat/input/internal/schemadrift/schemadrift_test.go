@@ -0,0 +1,41 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package schemadrift
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestTracker(t *testing.T) {
+	reg := monitoring.NewRegistry()
+	tr := NewTracker(reg)
+
+	doc := map[string]interface{}{
+		"Id":   "1",
+		"User": map[string]interface{}{"Name": "alice"},
+		"Targets": []interface{}{
+			map[string]interface{}{"Type": "user"},
+		},
+	}
+	for i := 0; i < warmup; i++ {
+		assert.Empty(t, tr.Observe(doc), "the fields are not reported during the warm-up")
+	}
+	assert.Equal(t, uint64(5), tr.fields.Get())
+
+	doc["User"].(map[string]interface{})["Role"] = "admin"
+	doc["Targets"] = append(doc["Targets"].([]interface{}), map[string]interface{}{"Type": "group", "Size": 3})
+	assert.ElementsMatch(t, []string{"User.Role", "Targets.Size"}, tr.Observe(doc))
+	assert.Empty(t, tr.Observe(doc), "the new fields are reported once")
+	assert.Equal(t, uint64(7), tr.fields.Get())
+	assert.Equal(t, uint64(2), tr.newFields.Get())
+
+	var none *Tracker
+	assert.Nil(t, NewTracker(nil))
+	assert.Empty(t, none.Observe(doc))
+}
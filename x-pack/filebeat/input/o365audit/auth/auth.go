@@ -6,7 +6,10 @@ package auth
 
 import (
 	"context"
+	"strings"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 )
@@ -18,6 +21,17 @@ type TokenProvider interface {
 	Token(ctx context.Context) (string, error)
 }
 
+// clientOptions returns the options of the credentials requesting tokens from
+// the authority at endpoint. The requests are sent with transport, or with the
+// default HTTP client of the Azure SDK if transport is nil.
+func clientOptions(endpoint string, transport policy.Transporter) azcore.ClientOptions {
+	opts := azcore.ClientOptions{Cloud: cloud.Configuration{ActiveDirectoryAuthorityHost: endpoint}}
+	if transport != nil {
+		opts.Transport = transport
+	}
+	return opts
+}
+
 // credentialTokenProvider extends azidentity.ClientSecretCredential with the
 // the TokenProvider interface.
 type credentialTokenProvider azidentity.ClientSecretCredential
@@ -33,3 +47,25 @@ func (provider *credentialTokenProvider) Token(ctx context.Context) (string, err
 	}
 	return tk.Token, nil
 }
+
+// scopedTokenProvider extends an Azure credential with the TokenProvider
+// interface. The tokens are requested for the default scope of a resource.
+type scopedTokenProvider struct {
+	cred  azcore.TokenCredential
+	scope string
+}
+
+func newScopedTokenProvider(cred azcore.TokenCredential, resource string) *scopedTokenProvider {
+	return &scopedTokenProvider{
+		cred:  cred,
+		scope: strings.TrimSuffix(resource, "/") + "/.default",
+	}
+}
+
+func (provider *scopedTokenProvider) Token(ctx context.Context) (string, error) {
+	tk, err := provider.cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{provider.scope}})
+	if err != nil {
+		return "", err
+	}
+	return tk.Token, nil
+}
@@ -5,32 +5,103 @@
 package auth
 
 import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
 	"crypto/rsa"
+	"crypto/sha256"
 	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
 	"fmt"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/golang-jwt/jwt/v5"
 
 	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
 )
 
+// CertificateOptions are the settings of certificate-based authentication
+// not covered by tlscommon.CertificateConfig.
+type CertificateOptions struct {
+	// PKCS12 is the path of a PKCS#12 (.pfx) bundle holding the
+	// certificates and the private key. When set, it is used instead of the
+	// certificate and key of the tlscommon.CertificateConfig.
+	PKCS12 string
+
+	// PKCS12Passphrase is the passphrase of the PKCS#12 bundle.
+	PKCS12Passphrase string
+
+	// SendCertificateChain sends the certificate chain in the x5c header of
+	// the token requests, as required by subject name and issuer
+	// authentication.
+	SendCertificateChain bool
+}
+
 // NewProviderFromCertificate returns a TokenProvider that uses certificate-based
-// authentication.
-func NewProviderFromCertificate(resource, applicationID, tenantID string, conf tlscommon.CertificateConfig) (sptp TokenProvider, err error) {
-	cert, privKey, err := loadConfigCerts(conf)
-	if err != nil {
-		return nil, fmt.Errorf("failed loading certificates: %w", err)
-	}
+// authentication. If the certificate or the key are read from files, the
+// provider is rebuilt when they are modified, and onReload, if not nil, is
+// called after each attempt to rebuild it. The token requests are sent with
+// transport, if not nil.
+//
+// RSA keys are used through azidentity. As it doesn't support ECDSA keys, the
+// client assertions of ECDSA keys are signed by the provider.
+func NewProviderFromCertificate(endpoint string, transport policy.Transporter, resource, applicationID, tenantID string, conf tlscommon.CertificateConfig, opts CertificateOptions, onReload func(error)) (sptp TokenProvider, err error) {
+	load := func() (TokenProvider, error) {
+		var (
+			certs   []*x509.Certificate
+			privKey crypto.PrivateKey
+			err     error
+		)
+		if opts.PKCS12 != "" {
+			certs, privKey, err = loadPKCS12(opts.PKCS12, opts.PKCS12Passphrase)
+		} else {
+			certs, privKey, err = loadConfigCerts(conf)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed loading certificates: %w", err)
+		}
 
-	cred, err := azidentity.NewClientCertificateCredential(tenantID, applicationID, []*x509.Certificate{cert}, privKey, nil)
-	if err != nil {
-		return nil, err
+		if ecKey, ok := privKey.(*ecdsa.PrivateKey); ok {
+			getAssertion := certificateAssertion(endpoint, applicationID, tenantID, certs, ecKey, opts.SendCertificateChain)
+			return NewProviderFromClientAssertion(endpoint, transport, resource, applicationID, tenantID, getAssertion)
+		}
+
+		cred, err := azidentity.NewClientCertificateCredential(tenantID, applicationID, certs, privKey, &azidentity.ClientCertificateCredentialOptions{
+			ClientOptions:        clientOptions(endpoint, transport),
+			SendCertificateChain: opts.SendCertificateChain,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return (*credentialTokenProvider)(cred), nil
 	}
 
-	return (*credentialTokenProvider)(cred), nil
+	var files []string
+	if opts.PKCS12 != "" {
+		files = append(files, opts.PKCS12)
+	} else {
+		for _, f := range []string{conf.Certificate, conf.Key} {
+			if f != "" && !tlscommon.IsPEMString(f) {
+				files = append(files, f)
+			}
+		}
+	}
+	if len(files) == 0 {
+		return load()
+	}
+	return newReloadingTokenProvider(files, load, onReload)
 }
 
-func loadConfigCerts(cfg tlscommon.CertificateConfig) (cert *x509.Certificate, key *rsa.PrivateKey, err error) {
+// loadConfigCerts loads the certificate chain and the private key of a
+// tlscommon.CertificateConfig.
+func loadConfigCerts(cfg tlscommon.CertificateConfig) (certs []*x509.Certificate, key crypto.PrivateKey, err error) {
 	tlsCert, err := tlscommon.LoadCertificate(&cfg)
 	if err != nil {
 		return nil, nil, fmt.Errorf("error loading X509 certificate from '%s': %w", cfg.Certificate, err)
@@ -38,16 +109,95 @@ func loadConfigCerts(cfg tlscommon.CertificateConfig) (cert *x509.Certificate, k
 	if tlsCert == nil || len(tlsCert.Certificate) == 0 {
 		return nil, nil, fmt.Errorf("no certificates loaded from '%s'", cfg.Certificate)
 	}
-	cert, err = x509.ParseCertificate(tlsCert.Certificate[0])
-	if err != nil {
-		return nil, nil, fmt.Errorf("error parsing X509 certificate from '%s': %w", cfg.Certificate, err)
+	for _, der := range tlsCert.Certificate {
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error parsing X509 certificate from '%s': %w", cfg.Certificate, err)
+		}
+		certs = append(certs, cert)
 	}
 	if tlsCert.PrivateKey == nil {
 		return nil, nil, fmt.Errorf("failed loading private key from '%s'", cfg.Key)
 	}
-	key, ok := tlsCert.PrivateKey.(*rsa.PrivateKey)
-	if !ok {
-		return nil, nil, fmt.Errorf("private key at '%s' is not an RSA private key", cfg.Key)
+	if err := checkPrivateKey(tlsCert.PrivateKey); err != nil {
+		return nil, nil, fmt.Errorf("private key at '%s': %w", cfg.Key, err)
+	}
+	return certs, tlsCert.PrivateKey, nil
+}
+
+// loadPKCS12 loads the certificate chain and the private key of a PKCS#12
+// bundle, such as the ones exported from Azure Key Vault.
+func loadPKCS12(path, passphrase string) (certs []*x509.Certificate, key crypto.PrivateKey, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading PKCS#12 bundle from '%s': %w", path, err)
+	}
+	var password []byte
+	if passphrase != "" {
+		password = []byte(passphrase)
+	}
+	certs, key, err = azidentity.ParseCertificates(data, password)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error parsing PKCS#12 bundle from '%s': %w", path, err)
+	}
+	if err := checkPrivateKey(key); err != nil {
+		return nil, nil, fmt.Errorf("private key in '%s': %w", path, err)
+	}
+	return certs, key, nil
+}
+
+// checkPrivateKey returns an error if key is not of a type supported to sign
+// the client assertions.
+func checkPrivateKey(key crypto.PrivateKey) error {
+	switch key.(type) {
+	case *rsa.PrivateKey, *ecdsa.PrivateKey:
+		return nil
+	}
+	return fmt.Errorf("unsupported private key type %T, an RSA or ECDSA key is required", key)
+}
+
+// certificateAssertion returns a function returning client assertions signed
+// with an ECDSA key, identified by the SHA-256 thumbprint of its certificate,
+// the first of certs. If sendChain is set, the certificate chain is sent in
+// the x5c header.
+func certificateAssertion(endpoint, applicationID, tenantID string, certs []*x509.Certificate, key *ecdsa.PrivateKey, sendChain bool) func(context.Context) (string, error) {
+	thumbprint := sha256.Sum256(certs[0].Raw)
+	var chain []string
+	if sendChain {
+		for _, cert := range certs {
+			chain = append(chain, base64.StdEncoding.EncodeToString(cert.Raw))
+		}
+	}
+	audience := strings.TrimSuffix(endpoint, "/") + "/" + tenantID + "/oauth2/v2.0/token"
+	var method jwt.SigningMethod
+	switch key.Curve.Params().BitSize {
+	case 384:
+		method = jwt.SigningMethodES384
+	case 521:
+		method = jwt.SigningMethodES512
+	default:
+		method = jwt.SigningMethodES256
+	}
+
+	return func(context.Context) (string, error) {
+		jti := make([]byte, 16)
+		if _, err := rand.Read(jti); err != nil {
+			return "", err
+		}
+		now := time.Now()
+		token := jwt.NewWithClaims(method, jwt.RegisteredClaims{
+			Issuer:    applicationID,
+			Subject:   applicationID,
+			Audience:  jwt.ClaimStrings{audience},
+			ID:        hex.EncodeToString(jti),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(10 * time.Minute)),
+		})
+		token.Header["x5t#S256"] = base64.RawURLEncoding.EncodeToString(thumbprint[:])
+		if chain != nil {
+			token.Header["x5c"] = chain
+		}
+		return token.SignedString(key)
 	}
-	return cert, key, nil
 }
@@ -0,0 +1,142 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+// writeCertificateChain writes a certificate issued by a CA for key, followed
+// by the certificate of the CA, and the key to PEM files, and returns the
+// certificates and the paths of the files.
+func writeCertificateChain(t *testing.T, key crypto.Signer) (certs []*x509.Certificate, certFile, keyFile string) {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	require.NoError(t, err)
+	ca, err := x509.ParseCertificate(caDER)
+	require.NoError(t, err)
+
+	leafDER, err := x509.CreateCertificate(rand.Reader, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "filebeat"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}, ca, key.Public(), caKey)
+	require.NoError(t, err)
+	leaf, err := x509.ParseCertificate(leafDER)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: leafDER})
+	certPEM = append(certPEM, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER})...)
+	require.NoError(t, os.WriteFile(certFile, certPEM, 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+	return []*x509.Certificate{leaf, ca}, certFile, keyFile
+}
+
+func TestLoadConfigCerts(t *testing.T) {
+	t.Run("ECDSA key and certificate chain", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+		want, certFile, keyFile := writeCertificateChain(t, key)
+
+		certs, privKey, err := loadConfigCerts(tlscommon.CertificateConfig{Certificate: certFile, Key: keyFile})
+		require.NoError(t, err)
+		require.Len(t, certs, 2, "the whole chain is loaded")
+		assert.Equal(t, want[0].Raw, certs[0].Raw)
+		assert.Equal(t, want[1].Raw, certs[1].Raw)
+		assert.True(t, key.Equal(privKey))
+	})
+
+	t.Run("unsupported key", func(t *testing.T) {
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+		require.NoError(t, err)
+		_, certFile, keyFile := writeCertificateChain(t, key)
+
+		_, _, err = loadConfigCerts(tlscommon.CertificateConfig{Certificate: certFile, Key: keyFile})
+		assert.ErrorContains(t, err, "unsupported private key type ed25519.PrivateKey")
+	})
+}
+
+func TestLoadPKCS12(t *testing.T) {
+	_, _, err := loadPKCS12(filepath.Join(t.TempDir(), "missing.pfx"), "")
+	assert.ErrorContains(t, err, "error reading PKCS#12 bundle")
+
+	invalid := filepath.Join(t.TempDir(), "invalid.pfx")
+	require.NoError(t, os.WriteFile(invalid, []byte("not a bundle"), 0o600))
+	_, _, err = loadPKCS12(invalid, "passphrase")
+	assert.ErrorContains(t, err, "error parsing PKCS#12 bundle")
+}
+
+func TestCertificateAssertion(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	certs, _, _ := writeCertificateChain(t, key)
+
+	for _, sendChain := range []bool{false, true} {
+		getAssertion := certificateAssertion("https://login.microsoftonline.com/", "app", "tenant", certs, key, sendChain)
+		assertion, err := getAssertion(context.Background())
+		require.NoError(t, err)
+
+		var claims jwt.RegisteredClaims
+		token, err := jwt.ParseWithClaims(assertion, &claims, func(*jwt.Token) (interface{}, error) {
+			return key.Public(), nil
+		}, jwt.WithValidMethods([]string{"ES256"}))
+		require.NoError(t, err)
+		assert.Equal(t, "app", claims.Issuer)
+		assert.Equal(t, "app", claims.Subject)
+		assert.Equal(t, jwt.ClaimStrings{"https://login.microsoftonline.com/tenant/oauth2/v2.0/token"}, claims.Audience)
+		assert.NotEmpty(t, claims.ID)
+
+		thumbprint := sha256.Sum256(certs[0].Raw)
+		assert.Equal(t, base64.RawURLEncoding.EncodeToString(thumbprint[:]), token.Header["x5t#S256"])
+		if sendChain {
+			assert.Equal(t, []interface{}{
+				base64.StdEncoding.EncodeToString(certs[0].Raw),
+				base64.StdEncoding.EncodeToString(certs[1].Raw),
+			}, token.Header["x5c"])
+		} else {
+			assert.NotContains(t, token.Header, "x5c")
+		}
+	}
+}
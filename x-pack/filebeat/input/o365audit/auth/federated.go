@@ -0,0 +1,99 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+)
+
+// DefaultFederatedAudience is the audience of the federated tokens exchanged
+// with Microsoft Entra ID.
+const DefaultFederatedAudience = "api://AzureADTokenExchange"
+
+// NewProviderFromWorkloadIdentity returns a TokenProvider that exchanges the
+// federated token of a Kubernetes service account, read from tokenFile, for an
+// access token of the application. If tokenFile is empty, the file set by the
+// AKS workload identity webhook in AZURE_FEDERATED_TOKEN_FILE is used. The
+// token requests are sent with transport, if not nil.
+func NewProviderFromWorkloadIdentity(endpoint string, transport policy.Transporter, resource, applicationID, tenantID, tokenFile string) (p TokenProvider, err error) {
+	cred, err := azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{
+		ClientOptions: clientOptions(endpoint, transport),
+		ClientID:      applicationID,
+		TenantID:      tenantID,
+		TokenFilePath: tokenFile,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newScopedTokenProvider(cred, resource), nil
+}
+
+// NewProviderFromClientAssertion returns a TokenProvider that exchanges the
+// client assertions returned by getAssertion, such as the OIDC tokens of a CI
+// job, for access tokens of the application. The token requests are sent with
+// transport, if not nil.
+func NewProviderFromClientAssertion(endpoint string, transport policy.Transporter, resource, applicationID, tenantID string, getAssertion func(context.Context) (string, error)) (p TokenProvider, err error) {
+	cred, err := azidentity.NewClientAssertionCredential(tenantID, applicationID, getAssertion, &azidentity.ClientAssertionCredentialOptions{
+		ClientOptions: clientOptions(endpoint, transport),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newScopedTokenProvider(cred, resource), nil
+}
+
+// TokenURLAssertion returns a function requesting a federated token for
+// audience from tokenURL, authenticated with bearerToken if set. The response
+// is a JSON object holding the token in its value field, like the responses of
+// the OIDC token endpoint of GitHub Actions.
+func TokenURLAssertion(client *http.Client, tokenURL, bearerToken, audience string) func(context.Context) (string, error) {
+	return func(ctx context.Context) (string, error) {
+		u, err := url.Parse(tokenURL)
+		if err != nil {
+			return "", fmt.Errorf("invalid token URL: %w", err)
+		}
+		q := u.Query()
+		q.Set("audience", audience)
+		u.RawQuery = q.Encode()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+		if err != nil {
+			return "", err
+		}
+		if bearerToken != "" {
+			req.Header.Set("Authorization", "Bearer "+bearerToken)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", fmt.Errorf("failed to request federated token: %w", err)
+		}
+		defer resp.Body.Close()
+		body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		if err != nil {
+			return "", fmt.Errorf("failed to read federated token: %w", err)
+		}
+		if resp.StatusCode != http.StatusOK {
+			return "", fmt.Errorf("failed to request federated token: %s: %s", resp.Status, body)
+		}
+		var token struct {
+			Value string `json:"value"`
+		}
+		if err := json.Unmarshal(body, &token); err != nil {
+			return "", fmt.Errorf("failed to decode federated token: %w", err)
+		}
+		if token.Value == "" {
+			return "", fmt.Errorf("no federated token in the response of %s", u.Redacted())
+		}
+		return token.Value, nil
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenURLAssertion(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer request-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		assert.Equal(t, "v1", r.URL.Query().Get("api-version"))
+		if r.URL.Query().Get("audience") != DefaultFederatedAudience {
+			_, _ = w.Write([]byte(`{"count":0}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"count":1,"value":"federated-token"}`))
+	}))
+	defer srv.Close()
+
+	ctx := context.Background()
+	token, err := TokenURLAssertion(srv.Client(), srv.URL+"?api-version=v1", "request-token", DefaultFederatedAudience)(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "federated-token", token)
+
+	_, err = TokenURLAssertion(srv.Client(), srv.URL+"?api-version=v1", "", DefaultFederatedAudience)(ctx)
+	assert.ErrorContains(t, err, "401 Unauthorized")
+
+	_, err = TokenURLAssertion(srv.Client(), srv.URL+"?api-version=v1", "request-token", "other")(ctx)
+	assert.ErrorContains(t, err, "no federated token")
+}
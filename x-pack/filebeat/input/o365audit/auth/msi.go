@@ -0,0 +1,52 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package auth
+
+import (
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+)
+
+// ManagedIdentityID identifies a user-assigned managed identity by one of its
+// client ID, Azure resource ID or object ID. The zero value identifies the
+// system-assigned managed identity.
+type ManagedIdentityID struct {
+	ClientID   string
+	ResourceID string
+	ObjectID   string
+}
+
+// kind returns the identifier passed to the Azure SDK, nil for the
+// system-assigned managed identity.
+func (id ManagedIdentityID) kind() azidentity.ManagedIDKind {
+	switch {
+	case id.ClientID != "":
+		return azidentity.ClientID(id.ClientID)
+	case id.ResourceID != "":
+		return azidentity.ResourceID(id.ResourceID)
+	case id.ObjectID != "":
+		return azidentity.ObjectID(id.ObjectID)
+	}
+	return nil
+}
+
+// NewProviderFromManagedIdentity returns a TokenProvider that authenticates
+// using an Azure managed identity, the system-assigned one if id is the zero
+// value. The tokens are requested for resource, so the national clouds are
+// supported by setting the resource of their Management API.
+func NewProviderFromManagedIdentity(endpoint, resource string, id ManagedIdentityID) (p TokenProvider, err error) {
+	opts := &azidentity.ManagedIdentityCredentialOptions{
+		ClientOptions: azcore.ClientOptions{Cloud: cloud.Configuration{ActiveDirectoryAuthorityHost: endpoint}},
+		ID:            id.kind(),
+	}
+
+	cred, err := azidentity.NewManagedIdentityCredential(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return newScopedTokenProvider(cred, resource), nil
+}
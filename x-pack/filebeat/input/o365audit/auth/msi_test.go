@@ -0,0 +1,20 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package auth
+
+import (
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManagedIdentityID(t *testing.T) {
+	assert.Nil(t, ManagedIdentityID{}.kind(), "the system-assigned identity is used by default")
+	assert.Equal(t, azidentity.ClientID("client"), ManagedIdentityID{ClientID: "client"}.kind())
+	assert.Equal(t, azidentity.ResourceID("/subscriptions/s/resourceGroups/g/providers/Microsoft.ManagedIdentity/userAssignedIdentities/i"),
+		ManagedIdentityID{ResourceID: "/subscriptions/s/resourceGroups/g/providers/Microsoft.ManagedIdentity/userAssignedIdentities/i"}.kind())
+	assert.Equal(t, azidentity.ObjectID("object"), ManagedIdentityID{ObjectID: "object"}.kind())
+}
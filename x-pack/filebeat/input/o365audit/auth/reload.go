@@ -0,0 +1,115 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package auth
+
+import (
+	"context"
+	"os"
+	"sync"
+	"time"
+)
+
+// reloadCheckInterval is the minimum time between two checks of the files of
+// a reloadingTokenProvider.
+const reloadCheckInterval = 10 * time.Second
+
+// reloadingTokenProvider is a TokenProvider rebuilt when the files it is
+// loaded from are modified, so the certificates can be rotated without
+// restarting Filebeat.
+type reloadingTokenProvider struct {
+	files    []string
+	load     func() (TokenProvider, error)
+	onReload func(error)
+	clock    func() time.Time
+
+	mu        sync.Mutex
+	current   TokenProvider
+	modTimes  []time.Time
+	lastCheck time.Time
+}
+
+// newReloadingTokenProvider returns a TokenProvider built by load, and built
+// again when the modification time of one of files changes. onReload, if not
+// nil, is called after each attempt to rebuild it.
+func newReloadingTokenProvider(files []string, load func() (TokenProvider, error), onReload func(error)) (*reloadingTokenProvider, error) {
+	if onReload == nil {
+		onReload = func(error) {}
+	}
+	p := &reloadingTokenProvider{
+		files:    files,
+		load:     load,
+		onReload: onReload,
+		clock:    time.Now,
+	}
+	p.modTimes = p.stat()
+	current, err := load()
+	if err != nil {
+		return nil, err
+	}
+	p.current = current
+	p.lastCheck = p.clock()
+	return p, nil
+}
+
+// stat returns the modification times of the files, zero for the files that
+// can't be read.
+func (p *reloadingTokenProvider) stat() []time.Time {
+	modTimes := make([]time.Time, len(p.files))
+	for i, f := range p.files {
+		if info, err := os.Stat(f); err == nil {
+			modTimes[i] = info.ModTime()
+		}
+	}
+	return modTimes
+}
+
+// reload rebuilds the provider if the files were modified since it was built,
+// checking them at most once per reloadCheckInterval unless force is set. It
+// returns the current provider and whether it was rebuilt.
+func (p *reloadingTokenProvider) reload(force bool) (TokenProvider, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.clock()
+	if !force && now.Sub(p.lastCheck) < reloadCheckInterval {
+		return p.current, false
+	}
+	p.lastCheck = now
+
+	modTimes := p.stat()
+	changed := false
+	for i := range modTimes {
+		if !modTimes[i].Equal(p.modTimes[i]) {
+			changed = true
+			break
+		}
+	}
+	if !changed {
+		return p.current, false
+	}
+	provider, err := p.load()
+	if err != nil {
+		// The files may be partially written, they are loaded again with
+		// the next check.
+		p.onReload(err)
+		return p.current, false
+	}
+	p.current, p.modTimes = provider, modTimes
+	p.onReload(nil)
+	return provider, true
+}
+
+// Token returns a token of the current provider. If it fails, the files are
+// checked at once, and the token is requested again if they were modified.
+func (p *reloadingTokenProvider) Token(ctx context.Context) (string, error) {
+	provider, _ := p.reload(false)
+	tk, err := provider.Token(ctx)
+	if err == nil {
+		return tk, nil
+	}
+	if provider, ok := p.reload(true); ok {
+		return provider.Token(ctx)
+	}
+	return "", err
+}
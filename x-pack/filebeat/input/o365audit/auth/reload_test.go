@@ -0,0 +1,97 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticTokenProvider struct {
+	token string
+	err   error
+}
+
+func (p staticTokenProvider) Token(context.Context) (string, error) { return p.token, p.err }
+
+func TestReloadingTokenProvider(t *testing.T) {
+	ctx := context.Background()
+	certFile := filepath.Join(t.TempDir(), "cert.pem")
+	require.NoError(t, os.WriteFile(certFile, []byte("cert-1"), 0o600))
+	modTime := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+
+	load := func() (TokenProvider, error) {
+		b, err := os.ReadFile(certFile)
+		if err != nil {
+			return nil, err
+		}
+		switch string(b) {
+		case "invalid":
+			return nil, errors.New("invalid certificate")
+		case "revoked":
+			return staticTokenProvider{err: errors.New("certificate revoked")}, nil
+		}
+		return staticTokenProvider{token: "token-" + string(b)}, nil
+	}
+	var reloads, failures int
+	p, err := newReloadingTokenProvider([]string{certFile}, load, func(err error) {
+		if err != nil {
+			failures++
+			return
+		}
+		reloads++
+	})
+	require.NoError(t, err)
+	now := time.Now()
+	p.clock = func() time.Time { return now }
+
+	rotate := func(content string) {
+		require.NoError(t, os.WriteFile(certFile, []byte(content), 0o600))
+		modTime = modTime.Add(time.Minute)
+		require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	}
+
+	tk, err := p.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-cert-1", tk)
+
+	// The files are checked once per interval.
+	rotate("cert-2")
+	tk, err = p.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-cert-1", tk)
+	now = now.Add(reloadCheckInterval)
+	tk, err = p.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-cert-2", tk)
+	assert.Equal(t, 1, reloads)
+
+	// An invalid certificate keeps the previous one.
+	rotate("invalid")
+	now = now.Add(reloadCheckInterval)
+	tk, err = p.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-cert-2", tk)
+	assert.Equal(t, 1, failures)
+
+	// The files are checked at once when a token can't be acquired.
+	rotate("revoked")
+	now = now.Add(reloadCheckInterval)
+	_, err = p.Token(ctx)
+	assert.ErrorContains(t, err, "certificate revoked")
+	rotate("cert-3")
+	tk, err = p.Token(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "token-cert-3", tk)
+	assert.Equal(t, 3, reloads)
+}
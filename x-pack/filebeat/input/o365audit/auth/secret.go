@@ -5,15 +5,14 @@
 package auth
 
 import (
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore/cloud"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 )
 
 // NewProviderFromClientSecret returns a token provider that uses a secret
-// for authentication.
-func NewProviderFromClientSecret(endpoint, resource, applicationID, tenantID, secret string) (p TokenProvider, err error) {
-	clientOpts := azcore.ClientOptions{Cloud: cloud.Configuration{ActiveDirectoryAuthorityHost: endpoint}}
+// for authentication. The token requests are sent with transport, if not nil.
+func NewProviderFromClientSecret(endpoint string, transport policy.Transporter, resource, applicationID, tenantID, secret string) (p TokenProvider, err error) {
+	clientOpts := clientOptions(endpoint, transport)
 
 	cred, err := azidentity.NewClientSecretCredential(
 		tenantID, applicationID, secret, &azidentity.ClientSecretCredentialOptions{ClientOptions: clientOpts},
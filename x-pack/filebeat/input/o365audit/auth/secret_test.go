@@ -0,0 +1,61 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package auth
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingTransport is a policy.Transporter recording the hosts of the
+// requests, and rejecting them.
+type recordingTransport struct {
+	mu    sync.Mutex
+	hosts []string
+}
+
+func (t *recordingTransport) Do(req *http.Request) (*http.Response, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.hosts = append(t.hosts, req.URL.Host)
+	return &http.Response{
+		StatusCode: http.StatusForbidden,
+		Status:     "403 Forbidden",
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(`{"error":"proxy_denied"}`)),
+		Request:    req,
+	}, nil
+}
+
+func TestProvidersUseTransport(t *testing.T) {
+	const endpoint = "https://login.example.com/"
+	for name, newProvider := range map[string]func(*recordingTransport) (TokenProvider, error){
+		"client_secret": func(tr *recordingTransport) (TokenProvider, error) {
+			return NewProviderFromClientSecret(endpoint, tr, "https://manage.office.com", "app", "tenant", "secret")
+		},
+		"client_assertion": func(tr *recordingTransport) (TokenProvider, error) {
+			return NewProviderFromClientAssertion(endpoint, tr, "https://manage.office.com", "app", "tenant", func(context.Context) (string, error) {
+				return "assertion", nil
+			})
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			tr := &recordingTransport{}
+			p, err := newProvider(tr)
+			require.NoError(t, err)
+			_, err = p.Token(context.Background())
+			assert.Error(t, err)
+			require.NotEmpty(t, tr.hosts, "the token requests are sent with the transport")
+			assert.Equal(t, "login.example.com", tr.hosts[0])
+		})
+	}
+}
@@ -7,10 +7,17 @@ package o365audit
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/apihealth"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/o365audit/auth"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
 	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
 )
 
@@ -19,15 +26,33 @@ type Config struct {
 	// CertificateConfig contains the authentication credentials (certificate).
 	CertificateConfig tlscommon.CertificateConfig `config:",inline"`
 
+	// PKCS12 configures authentication using a PKCS#12 certificate bundle.
+	PKCS12 PKCS12Config `config:"pkcs12"`
+
+	// SendCertificateChain sends the certificate chain with the token
+	// requests, for subject name and issuer authentication.
+	SendCertificateChain bool `config:"send_certificate_chain"`
+
 	// ApplicationID (aka. client ID) of the Azure application.
-	ApplicationID string `config:"application_id" validate:"required"`
+	ApplicationID string `config:"application_id"`
 
 	// ClientSecret (aka. API key) to use for authentication.
 	ClientSecret string `config:"client_secret"`
 
+	// ManagedIdentity configures authentication using an Azure managed identity.
+	ManagedIdentity ManagedIdentityConfig `config:"managed_identity"`
+
+	// Federated configures authentication using federated tokens.
+	Federated FederatedConfig `config:"federated"`
+
 	// TenantID (aka. Directory ID) is a list of tenants for which to fetch
 	// the audit logs. This can be a string or a list of strings.
-	TenantID stringList `config:"tenant_id,replace" validate:"required"`
+	TenantID stringList `config:"tenant_id,replace"`
+
+	// Tenants is a list of tenants, each with its own credentials and
+	// content types. Settings not configured for a tenant are inherited
+	// from the top-level configuration.
+	Tenants []TenantConfig `config:"tenants"`
 
 	// Content-Type is a list of content-types to fetch.
 	// This can be a string or a list of strings.
@@ -35,6 +60,129 @@ type Config struct {
 
 	// API contains settings to adapt to changes on the API.
 	API APIConfig `config:"api"`
+
+	// APIHealth configures publication of API health status events.
+	APIHealth apihealth.Config `config:"api_health"`
+
+	// Transport configures the HTTP client of the token and API requests,
+	// for example to send them through a proxy.
+	Transport httpcommon.HTTPTransportSettings `config:",inline"`
+
+	// Download configures how the interrupted downloads of the content
+	// blobs are resumed, and how the downloads are limited and prefetched.
+	Download download.Config `config:"download"`
+}
+
+// ManagedIdentityConfig contains the settings to authenticate using an Azure
+// managed identity.
+type ManagedIdentityConfig struct {
+	// Enabled controls whether the managed identity is used for authentication.
+	Enabled bool `config:"enabled"`
+
+	// ClientID of a user-assigned managed identity. If ClientID, ResourceID
+	// and ObjectID are empty, the system-assigned managed identity is used.
+	ClientID string `config:"client_id"`
+
+	// ResourceID is the Azure resource ID of a user-assigned managed
+	// identity.
+	ResourceID string `config:"resource_id"`
+
+	// ObjectID (aka. principal ID) of a user-assigned managed identity.
+	ObjectID string `config:"object_id"`
+}
+
+func (c *ManagedIdentityConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if btoi(c.ClientID != "")+btoi(c.ResourceID != "")+btoi(c.ObjectID != "") > 1 {
+		return errors.New("only one of managed_identity.client_id, managed_identity.resource_id or managed_identity.object_id can be set.")
+	}
+	return nil
+}
+
+// PKCS12Config contains the settings to authenticate using a PKCS#12 (.pfx)
+// bundle holding the certificates and the private key, like the ones
+// exported from Azure Key Vault.
+type PKCS12Config struct {
+	// Path of the PKCS#12 bundle.
+	Path string `config:"path"`
+
+	// Passphrase of the PKCS#12 bundle.
+	Passphrase string `config:"passphrase"`
+}
+
+// FederatedConfig contains the settings to authenticate using a federated
+// token, like the service account tokens of AKS workload identity or the OIDC
+// tokens of GitHub Actions, instead of a long-lived secret.
+type FederatedConfig struct {
+	// Enabled controls whether a federated token is used for authentication.
+	Enabled bool `config:"enabled"`
+
+	// TokenFile is the path of the file holding the federated token. It is
+	// read again on each authentication, as the token is rotated. If neither
+	// TokenFile nor TokenURL are set, the file set by the AKS workload
+	// identity webhook in AZURE_FEDERATED_TOKEN_FILE is used.
+	TokenFile string `config:"token_file"`
+
+	// TokenURL is the URL to request federated tokens from.
+	TokenURL string `config:"token_url"`
+
+	// TokenURLBearerToken authenticates the requests to TokenURL.
+	TokenURLBearerToken string `config:"token_url_bearer_token"`
+
+	// Audience of the federated tokens requested from TokenURL.
+	Audience string `config:"audience"`
+}
+
+func (c *FederatedConfig) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+	if c.TokenFile != "" && c.TokenURL != "" {
+		return errors.New("only one of federated.token_file or federated.token_url can be set.")
+	}
+	if c.TokenURL == "" && c.TokenURLBearerToken != "" {
+		return errors.New("federated.token_url_bearer_token can only be used with federated.token_url.")
+	}
+	if c.TokenURL != "" {
+		if _, err := url.Parse(c.TokenURL); err != nil {
+			return fmt.Errorf("federated.token_url '%s' is not a valid URL: %w", c.TokenURL, err)
+		}
+	}
+	return nil
+}
+
+// TenantConfig contains the settings for a single tenant.
+type TenantConfig struct {
+	// TenantID (aka. Directory ID) of the tenant.
+	TenantID string `config:"tenant_id" validate:"required"`
+
+	// CertificateConfig contains the authentication credentials (certificate).
+	CertificateConfig tlscommon.CertificateConfig `config:",inline"`
+
+	// PKCS12 configures authentication using a PKCS#12 certificate bundle.
+	PKCS12 PKCS12Config `config:"pkcs12"`
+
+	// SendCertificateChain sends the certificate chain with the token
+	// requests, for subject name and issuer authentication.
+	SendCertificateChain bool `config:"send_certificate_chain"`
+
+	// ApplicationID (aka. client ID) of the Azure application.
+	ApplicationID string `config:"application_id"`
+
+	// ClientSecret (aka. API key) to use for authentication.
+	ClientSecret string `config:"client_secret"`
+
+	// ManagedIdentity configures authentication using an Azure managed identity.
+	ManagedIdentity ManagedIdentityConfig `config:"managed_identity"`
+
+	// Federated configures authentication using federated tokens.
+	Federated FederatedConfig `config:"federated"`
+
+	// Content-Type is a list of content-types to fetch.
+	// This can be a string or a list of strings.
+	ContentType stringList `config:"content_type,replace"`
 }
 
 // APIConfig contains advanced settings that are only supposed to be changed
@@ -130,23 +278,36 @@ func defaultConfig() Config {
 
 			SetIDFromAuditRecord: true,
 		},
+
+		Transport: httpcommon.DefaultHTTPTransportSettings(),
+
+		Download: download.DefaultConfig(),
 	}
 }
 
+// httpClient returns the HTTP client of the token and API requests. The auth
+// namespace holds the authentication settings of the input, so it doesn't set
+// authorization headers on the requests like in the other HTTP inputs.
+func (c *Config) httpClient(log *logp.Logger) (*http.Client, error) {
+	transport := c.Transport
+	transport.Auth = nil
+	return transport.Client(httpcommon.WithLogger(log))
+}
+
 // Validate checks that the configuration is correct.
 func (c *Config) Validate() (err error) {
-	hasSecret := c.ClientSecret != ""
-	hasCert := c.CertificateConfig.Certificate != ""
-
-	if !hasSecret && !hasCert {
-		return errors.New("no authentication configured. Configure a client_secret or a certificate and key.")
+	tenants := c.tenantConfigs()
+	if len(tenants) == 0 {
+		return errors.New("no tenants configured. Configure a tenant_id or a list of tenants.")
 	}
-	if hasSecret && hasCert {
-		return errors.New("both client_secret and certificate are configured. Only one authentication method can be used.")
-	}
-	if hasCert {
-		if err = c.CertificateConfig.Validate(); err != nil {
-			return fmt.Errorf("invalid certificate config: %w", err)
+	seen := make(map[string]bool, len(tenants))
+	for _, tenant := range tenants {
+		if seen[tenant.TenantID] {
+			return fmt.Errorf("tenant '%s' is configured more than once", tenant.TenantID)
+		}
+		seen[tenant.TenantID] = true
+		if err = tenant.validate(); err != nil {
+			return fmt.Errorf("invalid configuration for tenant '%s': %w", tenant.TenantID, err)
 		}
 	}
 	c.API.Resource, err = forceURLScheme(c.API.Resource, "https")
@@ -160,6 +321,79 @@ func (c *Config) Validate() (err error) {
 	return nil
 }
 
+// tenantConfigs returns the configuration for every tenant, combining the
+// tenant_id list with the tenants list. Credentials, application ID and
+// content types not set for a tenant are inherited from the top-level config.
+func (c *Config) tenantConfigs() []TenantConfig {
+	tenants := make([]TenantConfig, 0, len(c.TenantID)+len(c.Tenants))
+	for _, tenantID := range c.TenantID {
+		tenants = append(tenants, c.inherit(TenantConfig{TenantID: tenantID}))
+	}
+	for _, tenant := range c.Tenants {
+		tenants = append(tenants, c.inherit(tenant))
+	}
+	return tenants
+}
+
+func (c *Config) inherit(tenant TenantConfig) TenantConfig {
+	if !tenant.hasCredentials() {
+		tenant.CertificateConfig = c.CertificateConfig
+		tenant.PKCS12 = c.PKCS12
+		tenant.SendCertificateChain = c.SendCertificateChain
+		tenant.ClientSecret = c.ClientSecret
+		tenant.ManagedIdentity = c.ManagedIdentity
+		tenant.Federated = c.Federated
+	}
+	if tenant.ApplicationID == "" {
+		tenant.ApplicationID = c.ApplicationID
+	}
+	if len(tenant.ContentType) == 0 {
+		tenant.ContentType = c.ContentType
+	}
+	return tenant
+}
+
+func (t *TenantConfig) hasCredentials() bool {
+	return t.ClientSecret != "" || t.CertificateConfig.Certificate != "" || t.PKCS12.Path != "" || t.ManagedIdentity.Enabled || t.Federated.Enabled
+}
+
+func (t *TenantConfig) validate() error {
+	hasSecret := t.ClientSecret != ""
+	hasCert := t.CertificateConfig.Certificate != ""
+	hasPKCS12 := t.PKCS12.Path != ""
+	hasMSI := t.ManagedIdentity.Enabled
+	hasFederated := t.Federated.Enabled
+
+	switch n := btoi(hasSecret) + btoi(hasCert) + btoi(hasPKCS12) + btoi(hasMSI) + btoi(hasFederated); {
+	case n == 0:
+		return errors.New("no authentication configured. Configure a client_secret, a certificate and key, a pkcs12 bundle, a managed_identity or federated.")
+	case n > 1:
+		return errors.New("more than one authentication method is configured. Only one of client_secret, certificate, pkcs12, managed_identity or federated can be used.")
+	}
+	if !hasMSI && t.ApplicationID == "" {
+		return errors.New("application_id is required when using client_secret, certificate, pkcs12 or federated authentication.")
+	}
+	if t.SendCertificateChain && !hasCert && !hasPKCS12 {
+		return errors.New("send_certificate_chain can only be used with certificate or pkcs12 authentication.")
+	}
+	if hasCert {
+		if err := t.CertificateConfig.Validate(); err != nil {
+			return fmt.Errorf("invalid certificate config: %w", err)
+		}
+	}
+	if len(t.ContentType) == 0 {
+		return errors.New("no content types configured.")
+	}
+	return nil
+}
+
+func btoi(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
+
 type stringList []string
 
 // Unpack populates the stringList with either a single string value or an array.
@@ -185,22 +419,80 @@ func (s *stringList) Unpack(value interface{}) error {
 	return nil
 }
 
-// NewTokenProvider returns an auth.TokenProvider for the given tenantID.
-func (c *Config) NewTokenProvider(tenantID string) (auth.TokenProvider, error) {
-	if c.ClientSecret != "" {
+// NewTokenProvider returns an auth.TokenProvider for the tenant. The token
+// requests are sent with client, or with the default HTTP client of the Azure
+// SDK if it is nil. Managed identity tokens are always requested with the
+// default client, as the local identity endpoint must not be proxied.
+// onReload, if not nil, is called after each attempt to reload a rotated
+// certificate.
+func (t *TenantConfig) NewTokenProvider(api APIConfig, client *http.Client, onReload func(error)) (auth.TokenProvider, error) {
+	var transport policy.Transporter
+	if client != nil {
+		transport = client
+	} else {
+		client = &http.Client{Timeout: time.Minute}
+	}
+	switch {
+	case t.ManagedIdentity.Enabled:
+		return auth.NewProviderFromManagedIdentity(
+			api.AuthenticationEndpoint,
+			api.Resource,
+			auth.ManagedIdentityID{
+				ClientID:   t.ManagedIdentity.ClientID,
+				ResourceID: t.ManagedIdentity.ResourceID,
+				ObjectID:   t.ManagedIdentity.ObjectID,
+			},
+		)
+	case t.Federated.Enabled && t.Federated.TokenURL != "":
+		audience := t.Federated.Audience
+		if audience == "" {
+			audience = auth.DefaultFederatedAudience
+		}
+		return auth.NewProviderFromClientAssertion(
+			api.AuthenticationEndpoint,
+			transport,
+			api.Resource,
+			t.ApplicationID,
+			t.TenantID,
+			auth.TokenURLAssertion(
+				client,
+				t.Federated.TokenURL,
+				t.Federated.TokenURLBearerToken,
+				audience,
+			),
+		)
+	case t.Federated.Enabled:
+		return auth.NewProviderFromWorkloadIdentity(
+			api.AuthenticationEndpoint,
+			transport,
+			api.Resource,
+			t.ApplicationID,
+			t.TenantID,
+			t.Federated.TokenFile,
+		)
+	case t.ClientSecret != "":
 		return auth.NewProviderFromClientSecret(
-			c.API.AuthenticationEndpoint,
-			c.API.Resource,
-			c.ApplicationID,
-			tenantID,
-			c.ClientSecret,
+			api.AuthenticationEndpoint,
+			transport,
+			api.Resource,
+			t.ApplicationID,
+			t.TenantID,
+			t.ClientSecret,
 		)
 	}
 	return auth.NewProviderFromCertificate(
-		c.API.Resource,
-		c.ApplicationID,
-		tenantID,
-		c.CertificateConfig,
+		api.AuthenticationEndpoint,
+		transport,
+		api.Resource,
+		t.ApplicationID,
+		t.TenantID,
+		t.CertificateConfig,
+		auth.CertificateOptions{
+			PKCS12:               t.PKCS12.Path,
+			PKCS12Passphrase:     t.PKCS12.Passphrase,
+			SendCertificateChain: t.SendCertificateChain,
+		},
+		onReload,
 	)
 }
 
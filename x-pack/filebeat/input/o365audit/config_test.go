@@ -0,0 +1,289 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package o365audit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func TestTenantsConfig(t *testing.T) {
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
+		"application_id": "app",
+		"client_secret":  "secret",
+		"tenant_id":      "tenant-a",
+		"content_type":   []string{"Audit.Exchange", "Audit.SharePoint"},
+		"tenants": []map[string]interface{}{
+			{
+				"tenant_id":     "tenant-b",
+				"client_secret": "secret-b",
+				"content_type":  "DLP.All",
+			},
+			{
+				"tenant_id": "tenant-c",
+				"managed_identity": map[string]interface{}{
+					"enabled":   true,
+					"client_id": "identity",
+				},
+			},
+			{
+				"tenant_id": "tenant-d",
+				"federated": map[string]interface{}{
+					"enabled":   true,
+					"token_url": "https://token.example.com",
+				},
+			},
+		},
+	})
+	config := defaultConfig()
+	require.NoError(t, cfg.Unpack(&config))
+
+	tenants := config.tenantConfigs()
+	require.Len(t, tenants, 4)
+
+	assert.Equal(t, "tenant-a", tenants[0].TenantID)
+	assert.Equal(t, "app", tenants[0].ApplicationID)
+	assert.Equal(t, "secret", tenants[0].ClientSecret)
+	assert.Equal(t, stringList{"Audit.Exchange", "Audit.SharePoint"}, tenants[0].ContentType)
+
+	assert.Equal(t, "tenant-b", tenants[1].TenantID)
+	assert.Equal(t, "app", tenants[1].ApplicationID)
+	assert.Equal(t, "secret-b", tenants[1].ClientSecret)
+	assert.Equal(t, stringList{"DLP.All"}, tenants[1].ContentType)
+
+	assert.Equal(t, "tenant-c", tenants[2].TenantID)
+	assert.Empty(t, tenants[2].ClientSecret)
+	assert.True(t, tenants[2].ManagedIdentity.Enabled)
+	assert.Equal(t, "identity", tenants[2].ManagedIdentity.ClientID)
+	assert.Equal(t, stringList{"Audit.Exchange", "Audit.SharePoint"}, tenants[2].ContentType)
+
+	assert.Equal(t, "tenant-d", tenants[3].TenantID)
+	assert.Equal(t, "app", tenants[3].ApplicationID)
+	assert.Empty(t, tenants[3].ClientSecret, "the top-level credentials are not inherited")
+	assert.True(t, tenants[3].Federated.Enabled)
+	assert.Equal(t, "https://token.example.com", tenants[3].Federated.TokenURL)
+}
+
+func TestTenantsConfigValidation(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		cfg  map[string]interface{}
+		err  string
+	}{
+		{
+			name: "no tenants",
+			cfg: map[string]interface{}{
+				"application_id": "app",
+				"client_secret":  "secret",
+			},
+			err: "no tenants configured",
+		},
+		{
+			name: "duplicate tenant",
+			cfg: map[string]interface{}{
+				"application_id": "app",
+				"client_secret":  "secret",
+				"tenant_id":      "tenant-a",
+				"tenants": []map[string]interface{}{
+					{"tenant_id": "tenant-a"},
+				},
+			},
+			err: "tenant 'tenant-a' is configured more than once",
+		},
+		{
+			name: "no credentials",
+			cfg: map[string]interface{}{
+				"application_id": "app",
+				"tenants": []map[string]interface{}{
+					{"tenant_id": "tenant-a", "client_secret": "secret"},
+					{"tenant_id": "tenant-b"},
+				},
+			},
+			err: "invalid configuration for tenant 'tenant-b': no authentication configured",
+		},
+		{
+			name: "multiple credentials",
+			cfg: map[string]interface{}{
+				"application_id": "app",
+				"tenants": []map[string]interface{}{
+					{
+						"tenant_id":        "tenant-a",
+						"client_secret":    "secret",
+						"managed_identity": map[string]interface{}{"enabled": true},
+					},
+				},
+			},
+			err: "more than one authentication method is configured",
+		},
+		{
+			name: "missing application_id",
+			cfg: map[string]interface{}{
+				"tenants": []map[string]interface{}{
+					{"tenant_id": "tenant-a", "client_secret": "secret"},
+				},
+			},
+			err: "application_id is required",
+		},
+		{
+			name: "federated token",
+			cfg: map[string]interface{}{
+				"application_id": "app",
+				"tenant_id":      "tenant-a",
+				"federated": map[string]interface{}{
+					"enabled":    true,
+					"token_file": "/var/run/secrets/azure/tokens/azure-identity-token",
+				},
+			},
+		},
+		{
+			name: "federated token with secret",
+			cfg: map[string]interface{}{
+				"application_id": "app",
+				"tenant_id":      "tenant-a",
+				"client_secret":  "secret",
+				"federated":      map[string]interface{}{"enabled": true},
+			},
+			err: "more than one authentication method is configured",
+		},
+		{
+			name: "federated token without application_id",
+			cfg: map[string]interface{}{
+				"tenant_id": "tenant-a",
+				"federated": map[string]interface{}{"enabled": true},
+			},
+			err: "application_id is required",
+		},
+		{
+			name: "federated token file and URL",
+			cfg: map[string]interface{}{
+				"application_id": "app",
+				"tenant_id":      "tenant-a",
+				"federated": map[string]interface{}{
+					"enabled":    true,
+					"token_file": "/token",
+					"token_url":  "https://token.actions.githubusercontent.com",
+				},
+			},
+			err: "only one of federated.token_file or federated.token_url can be set",
+		},
+		{
+			name: "federated bearer token without URL",
+			cfg: map[string]interface{}{
+				"application_id": "app",
+				"tenant_id":      "tenant-a",
+				"federated": map[string]interface{}{
+					"enabled":                true,
+					"token_url_bearer_token": "token",
+				},
+			},
+			err: "federated.token_url_bearer_token can only be used with federated.token_url",
+		},
+		{
+			name: "pkcs12 bundle",
+			cfg: map[string]interface{}{
+				"application_id":         "app",
+				"tenant_id":              "tenant-a",
+				"pkcs12":                 map[string]interface{}{"path": "/etc/filebeat/o365.pfx", "passphrase": "secret"},
+				"send_certificate_chain": true,
+			},
+		},
+		{
+			name: "pkcs12 bundle and certificate",
+			cfg: map[string]interface{}{
+				"application_id": "app",
+				"tenant_id":      "tenant-a",
+				"certificate":    "/etc/filebeat/o365.crt",
+				"key":            "/etc/filebeat/o365.key",
+				"pkcs12":         map[string]interface{}{"path": "/etc/filebeat/o365.pfx"},
+			},
+			err: "more than one authentication method is configured",
+		},
+		{
+			name: "certificate chain without certificate",
+			cfg: map[string]interface{}{
+				"application_id":         "app",
+				"tenant_id":              "tenant-a",
+				"client_secret":          "secret",
+				"send_certificate_chain": true,
+			},
+			err: "send_certificate_chain can only be used with certificate or pkcs12 authentication",
+		},
+		{
+			name: "managed identity with client_id and resource_id",
+			cfg: map[string]interface{}{
+				"tenant_id": "tenant-a",
+				"managed_identity": map[string]interface{}{
+					"enabled":     true,
+					"client_id":   "identity",
+					"resource_id": "/subscriptions/s/resourceGroups/g/providers/Microsoft.ManagedIdentity/userAssignedIdentities/i",
+				},
+			},
+			err: "only one of managed_identity.client_id, managed_identity.resource_id or managed_identity.object_id can be set",
+		},
+		{
+			name: "managed identity without application_id",
+			cfg: map[string]interface{}{
+				"tenants": []map[string]interface{}{
+					{
+						"tenant_id":        "tenant-a",
+						"managed_identity": map[string]interface{}{"enabled": true},
+					},
+				},
+			},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			config := defaultConfig()
+			err := conf.MustNewConfigFrom(tc.cfg).Unpack(&config)
+			if tc.err == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestTransportConfig(t *testing.T) {
+	cfg := conf.MustNewConfigFrom(map[string]interface{}{
+		"application_id": "app",
+		"tenant_id":      "tenant-a",
+		"federated": map[string]interface{}{
+			"enabled":   true,
+			"token_url": "https://token.example.com",
+		},
+		"proxy_url": "http://proxy.example.com:3128",
+		"timeout":   "30s",
+	})
+	config := defaultConfig()
+	require.NoError(t, cfg.Unpack(&config))
+	require.NotNil(t, config.Transport.Proxy.URL)
+	assert.Equal(t, "proxy.example.com:3128", config.Transport.Proxy.URL.Host)
+
+	client, err := config.httpClient(logp.NewNopLogger())
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Second, client.Timeout)
+
+	var authorization []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = append(authorization, r.Header.Get("Authorization"))
+	}))
+	defer srv.Close()
+	config.Transport.Proxy.Disable = true
+	client, err = config.httpClient(logp.NewNopLogger())
+	require.NoError(t, err)
+	resp, err := client.Get(srv.URL)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, []string{""}, authorization, "the auth namespace doesn't set authorization headers")
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/Azure/go-autorest/autorest"
 
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/httpmon"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/o365audit/poll"
 	"github.com/elastic/elastic-agent-libs/mapstr"
 )
@@ -53,14 +54,19 @@ func (c contentBlob) OnResponse(response *http.Response) (actions []poll.Action)
 	}
 	var raws []json.RawMessage
 	if err := readJSONBody(response, &raws); err != nil {
+		c.env.metrics.endpointErrors.Add(endpointContentBlob, httpmon.ErrorClassDecode)
 		return append(actions, poll.Terminate(fmt.Errorf("reading body failed: %w", err)))
 	}
 	entries := make([]mapstr.M, len(raws))
 	for idx, raw := range raws {
 		var entry mapstr.M
 		if err := json.Unmarshal(raw, &entry); err != nil {
+			c.env.metrics.endpointErrors.Add(endpointContentBlob, httpmon.ErrorClassDecode)
 			return append(actions, poll.Terminate(fmt.Errorf("decoding json failed: %w", err)))
 		}
+		if fields := c.env.metrics.schema.Observe(entry); len(fields) != 0 {
+			c.env.logger.Infow("New fields found in audit records", "fields", fields)
+		}
 		entries[idx] = entry
 		id, _ := getString(entry, "Id")
 		ts, _ := getString(entry, "CreationTime")
@@ -98,6 +104,10 @@ func (c contentBlob) handleError(response *http.Response) (actions []poll.Action
 	var msg apiError
 	readJSONBody(response, &msg)
 	c.env.logger.Warnf("Got error %s: %+v", response.Status, msg)
+	c.env.metrics.endpointErrors.Add(endpointContentBlob, httpmon.StatusErrorClass(response.StatusCode))
+	if response.StatusCode != http.StatusNotFound {
+		c.env.observeError(response, msg)
+	}
 
 	if _, found := fatalErrors[msg.Error.Code]; found {
 		return []poll.Action{
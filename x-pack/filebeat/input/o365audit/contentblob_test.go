@@ -16,6 +16,7 @@ import (
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/o365audit/poll"
 	"github.com/elastic/elastic-agent-libs/logp"
 	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
 )
 
 type contentStore struct {
@@ -85,6 +86,7 @@ func TestContentBlob(t *testing.T) {
 		status:   noopReporter{},
 		logger:   logp.L(),
 		callback: store.onEvent,
+		metrics:  newInputMetrics(monitoring.NewRegistry(), "", ""),
 	}
 	baseCursor := checkpoint{Timestamp: time.Now()}
 	query := ContentBlob("http://test.localhost/", baseCursor, ctx)
@@ -132,6 +134,7 @@ func TestContentBlobPaged(t *testing.T) {
 		status:   noopReporter{},
 		logger:   logp.L(),
 		callback: store.onEvent,
+		metrics:  newInputMetrics(monitoring.NewRegistry(), "", ""),
 	}
 	baseCursor := checkpoint{Timestamp: time.Now()}
 	query := ContentBlob("http://test.localhost/", baseCursor, ctx)
@@ -11,6 +11,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
 	"time"
 
 	"github.com/Azure/go-autorest/autorest"
@@ -22,6 +24,9 @@ import (
 	"github.com/elastic/beats/v7/libbeat/management/status"
 	"github.com/elastic/beats/v7/libbeat/statestore"
 	"github.com/elastic/beats/v7/libbeat/version"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/apihealth"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/download"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/httpmon"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/o365audit/poll"
 	conf "github.com/elastic/elastic-agent-libs/config"
 	"github.com/elastic/elastic-agent-libs/logp"
@@ -42,7 +47,7 @@ type o365input struct {
 
 // Stream represents an event stream.
 type stream struct {
-	tenantID    string
+	tenant      *TenantConfig
 	contentType string
 }
 
@@ -76,10 +81,10 @@ func configure(cfg *conf.C, _ *logp.Logger) ([]cursor.Source, cursor.Input, erro
 	}
 
 	var sources []cursor.Source
-	for _, tenantID := range config.TenantID {
-		for _, contentType := range config.ContentType {
+	for _, tenant := range config.tenantConfigs() {
+		for _, contentType := range tenant.ContentType {
 			sources = append(sources, &stream{
-				tenantID:    tenantID,
+				tenant:      &tenant,
 				contentType: contentType,
 			})
 		}
@@ -89,25 +94,55 @@ func configure(cfg *conf.C, _ *logp.Logger) ([]cursor.Source, cursor.Input, erro
 }
 
 func (s *stream) Name() string {
-	return s.tenantID + "::" + s.contentType
+	return s.tenant.TenantID + "::" + s.contentType
 }
 
 func (inp *o365input) Name() string { return pluginName }
 
 func (inp *o365input) Test(src cursor.Source, ctx v2.TestContext) error {
-	tenantID := src.(*stream).tenantID
-	auth, err := inp.config.NewTokenProvider(tenantID)
+	tenant := src.(*stream).tenant
+	client, err := inp.config.httpClient(ctx.Logger)
+	if err != nil {
+		return err
+	}
+	auth, err := tenant.NewTokenProvider(inp.config.API, client, nil)
 	if err != nil {
 		return err
 	}
 
 	if _, err := auth.Token(ctxtool.FromCanceller(ctx.Cancelation)); err != nil {
-		return fmt.Errorf("unable to acquire authentication token for tenant:%s: %w", tenantID, err)
+		return withAuthHint(fmt.Errorf("unable to acquire authentication token for tenant:%s: %w", tenant.TenantID, err))
 	}
 
 	return nil
 }
 
+// authErrorHints are hints on how to fix the configuration for the
+// Microsoft Entra ID error codes returned by the token endpoint.
+var authErrorHints = []struct {
+	code, hint string
+}{
+	{"AADSTS7000215", "the client secret is not valid, check client_secret"},
+	{"AADSTS7000222", "the client secret expired, create a new secret for the application and update client_secret"},
+	{"AADSTS700016", "the application was not found in the tenant, check application_id and tenant_id"},
+	{"AADSTS700027", "the client assertion is not valid, check that the certificate is registered for the application"},
+	{"AADSTS90002", "the tenant was not found, check tenant_id"},
+	{"AADSTS900023", "the tenant ID is not valid, check tenant_id"},
+	{"AADSTS50049", "the authority is not valid, check api.authentication_endpoint"},
+}
+
+// withAuthHint returns err annotated with a hint on how to fix the
+// configuration when err is caused by a known token endpoint error.
+func withAuthHint(err error) error {
+	msg := err.Error()
+	for _, h := range authErrorHints {
+		if strings.Contains(msg, h.code) {
+			return fmt.Errorf("%w (hint: %s)", err, h.hint)
+		}
+	}
+	return err
+}
+
 func (inp *o365input) Run(ctx v2.Context, src cursor.Source, cursor cursor.Cursor, pub cursor.Publisher) error {
 	ctx.UpdateStatus(status.Starting, "")
 
@@ -118,8 +153,24 @@ func (inp *o365input) Run(ctx v2.Context, src cursor.Source, cursor cursor.Curso
 		return errors.New("source is not an O365 stream")
 	}
 
+	// Each tenant and content type is run as an independent source, so
+	// failures are retried here without affecting the other streams.
+	metrics := newInputMetrics(ctx.MetricsRegistry, stream.tenant.TenantID, stream.contentType)
+	health := apihealth.NewTracker(inp.config.APIHealth, pluginName, ctx.IDWithoutName)
+	client, err := inp.config.httpClient(ctx.Logger)
+	if err != nil {
+		ctx.UpdateStatus(status.Failed, "failed to create HTTP client: "+err.Error())
+		return fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+	// The token requests are not included in the request metrics of the
+	// Management API.
+	downloads := download.NewManager(inp.config.Download, ctx.MetricsRegistry, ctx.Logger.Named("download"))
+	apiClient := &http.Client{
+		Transport: httpmon.NewMetricsRoundTripper(downloads.RoundTripper(client.Transport), ctx.MetricsRegistry, ctx.Logger),
+		Timeout:   client.Timeout,
+	}
 	for ctx.Cancelation.Err() == nil {
-		err := inp.run(ctx, stream, cursor, pub, ctx)
+		err := inp.run(ctx, stream, cursor, pub, ctx, metrics, health, client, apiClient)
 		switch {
 		case err == nil, errors.Is(err, context.Canceled):
 			return nil
@@ -137,6 +188,7 @@ func (inp *o365input) Run(ctx v2.Context, src cursor.Source, cursor cursor.Curso
 			}
 			ctx.UpdateStatus(status.Degraded, err.Error())
 			ctx.Logger.Errorf("Input failed: %v", err)
+			metrics.streamRestartsTotal.Inc()
 			ctx.Logger.Infof("Restarting in %v", inp.config.API.ErrorRetryInterval)
 			timed.Wait(ctx.Cancelation, inp.config.API.ErrorRetryInterval)
 		}
@@ -145,30 +197,49 @@ func (inp *o365input) Run(ctx v2.Context, src cursor.Source, cursor cursor.Curso
 	return nil
 }
 
-func (inp *o365input) run(v2ctx v2.Context, stream *stream, cursor cursor.Cursor, pub cursor.Publisher, stat status.StatusReporter) error {
-	tenantID, contentType := stream.tenantID, stream.contentType
+func (inp *o365input) run(v2ctx v2.Context, stream *stream, cursor cursor.Cursor, pub cursor.Publisher, stat status.StatusReporter, metrics *inputMetrics, health *apihealth.Tracker, client, apiClient *http.Client) error {
+	tenantID, contentType := stream.tenant.TenantID, stream.contentType
 	log := v2ctx.Logger.With("tenantID", tenantID, "contentType", contentType)
 	ctx := ctxtool.FromCanceller(v2ctx.Cancelation)
 
-	tokenProvider, err := inp.config.NewTokenProvider(stream.tenantID)
+	tokenProvider, err := stream.tenant.NewTokenProvider(inp.config.API, client, func(err error) {
+		if err != nil {
+			metrics.certificateReloadErrorsTotal.Inc()
+			log.Warnw("Failed to reload the rotated certificate, the previous one is used", "error", err)
+			return
+		}
+		metrics.certificateReloadsTotal.Inc()
+		log.Info("Reloaded the rotated certificate")
+	})
 	if err != nil {
+		metrics.authFailuresTotal.Inc()
 		return err
 	}
 
 	if _, err := tokenProvider.Token(ctx); err != nil {
-		return fmt.Errorf("unable to acquire authentication token for tenant:%s: %w", stream.tenantID, err)
+		metrics.authFailuresTotal.Inc()
+		err = withAuthHint(fmt.Errorf("unable to acquire authentication token for tenant:%s: %w", tenantID, err))
+		health.AuthFailed(stream.Name(), err)
+		if err := health.PublishDue(func(e beat.Event) error { return pub.Publish(e, nil) }); err != nil {
+			log.Errorw("failed to publish API health status", "error", err)
+		}
+		return err
 	}
 
 	config := &inp.config
 
 	// MaxRequestsPerMinute limitation is per tenant.
-	delay := time.Duration(len(config.ContentType)) * time.Minute / time.Duration(config.API.MaxRequestsPerMinute)
+	delay := time.Duration(len(stream.tenant.ContentType)) * time.Minute / time.Duration(config.API.MaxRequestsPerMinute)
 
 	poller, err := poll.New(
 		poll.WithTokenProvider(tokenProvider),
 		poll.WithMinRequestInterval(delay),
 		poll.WithLogger(log),
 		poll.WithContext(ctx),
+		poll.WithSender(apiClient),
+		poll.WithSendErrorHandler(func(t poll.Transaction, err error) {
+			metrics.endpointErrors.Add(endpointOf(t), httpmon.RequestErrorClass(err))
+		}),
 		poll.WithRequestDecorator(
 			autorest.WithUserAgent(useragent.UserAgent("Filebeat-"+pluginName, version.GetDefaultVersion(), version.Commit(), version.BuildTime().String())),
 			autorest.WithQueryParameters(mapstr.M{
@@ -188,6 +259,8 @@ func (inp *o365input) run(v2ctx v2.Context, stream *stream, cursor cursor.Cursor
 		contentType: contentType,
 		config:      inp.config.API,
 		callback:    pub.Publish,
+		metrics:     metrics,
+		health:      health,
 		clock:       time.Now,
 	})
 	if start.Line > 0 {
@@ -238,27 +311,66 @@ type apiEnvironment struct {
 	callback    func(event beat.Event, cursor interface{}) error
 	status      status.StatusReporter
 	logger      *logp.Logger
+	metrics     *inputMetrics
+	health      *apihealth.Tracker
 	clock       func() time.Time
 }
 
+// resource returns the name of the API health resource of the stream.
+func (env apiEnvironment) resource() string {
+	return env.tenantID + "::" + env.contentType
+}
+
 // Report returns an action that produces a beat.Event from the given object.
 func (env apiEnvironment) Report(raw json.RawMessage, doc mapstr.M, private interface{}) poll.Action {
 	return func(poll.Enqueuer) error {
 		err := env.callback(env.toBeatEvent(raw, doc), private)
 		switch err {
 		case nil:
+			env.metrics.eventsPublished.Inc()
 			env.status.UpdateStatus(status.Running, "")
+			if cp, ok := private.(checkpoint); ok {
+				env.health.CursorUpdated(env.resource(), cp.Timestamp)
+			}
 		default:
 			env.status.UpdateStatus(status.Degraded, "failed to publish event: "+err.Error())
 		}
+		env.publishHealth()
 		return err
 	}
 }
 
+// observeError records a failed API response in the health tracker.
+func (env apiEnvironment) observeError(response *http.Response, msg apiError) {
+	err := fmt.Errorf("request failed with %s", response.Status)
+	if msg.Error.Message != "" {
+		err = fmt.Errorf("request failed with %s: %s: %s", response.Status, msg.Error.Code, msg.Error.Message)
+	}
+	switch {
+	case response.StatusCode == http.StatusUnauthorized:
+		env.health.AuthFailed(env.resource(), err)
+	case response.StatusCode == http.StatusTooManyRequests, msg.Error.Code == "AF429":
+		env.health.Throttled(env.resource())
+	default:
+		env.health.Failed(env.resource(), err)
+	}
+}
+
+// publishHealth publishes the API health status events if they are due.
+func (env apiEnvironment) publishHealth() {
+	err := env.health.PublishDue(func(e beat.Event) error {
+		return env.callback(e, nil)
+	})
+	if err != nil {
+		env.logger.Errorw("failed to publish API health status", "error", err)
+	}
+}
+
 // ReportAPIError returns an action that produces a beat.Event from an API error.
 func (env apiEnvironment) ReportAPIError(err apiError) poll.Action {
 	return func(poll.Enqueuer) error {
 		msg := err.Error.Message
+		env.metrics.apiErrorsTotal.Inc()
 		err := env.callback(err.toBeatEvent(), nil)
 		if err != nil {
 			env.status.UpdateStatus(status.Degraded, fmt.Sprintf("failed to publish API error event %q: %v", msg, err.Error()))
@@ -6,6 +6,7 @@ package o365audit
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -39,3 +40,11 @@ func TestPreserveOriginalEvent(t *testing.T) {
 	require.NoError(t, err)
 	assert.JSONEq(t, `{"field1":"val1"}`, v.(string))
 }
+
+func TestWithAuthHint(t *testing.T) {
+	err := withAuthHint(errors.New("AADSTS7000215: Invalid client secret provided."))
+	assert.EqualError(t, err, "AADSTS7000215: Invalid client secret provided. (hint: the client secret is not valid, check client_secret)")
+
+	err = errors.New("connection refused")
+	assert.Equal(t, err, withAuthHint(err))
+}
@@ -16,6 +16,7 @@ import (
 
 	"github.com/Azure/go-autorest/autorest"
 
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/httpmon"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/o365audit/poll"
 )
 
@@ -101,6 +102,8 @@ func (l listBlob) OnResponse(response *http.Response) (actions []poll.Action) {
 	if response.StatusCode != 200 {
 		return l.handleError(response)
 	}
+	l.env.health.Succeeded(l.env.resource())
+	l.env.publishHealth()
 
 	if delta := getServerTimeDelta(response); l.env.config.AdjustClockWarn && !inRange(delta, l.env.config.AdjustClockMinDifference) {
 		l.env.logger.Warnf("Server clock is offset by %v: Check system clock to avoid event loss.", delta)
@@ -108,6 +111,7 @@ func (l listBlob) OnResponse(response *http.Response) (actions []poll.Action) {
 
 	var list []content
 	if err := readJSONBody(response, &list); err != nil {
+		l.env.metrics.endpointErrors.Add(endpointContentList, httpmon.ErrorClassDecode)
 		return []poll.Action{
 			poll.Terminate(err),
 		}
@@ -180,6 +184,9 @@ func (l listBlob) handleError(response *http.Response) (actions []poll.Action) {
 	var msg apiError
 	readJSONBody(response, &msg)
 	l.env.logger.Warnf("Got error %s: %+v", response.Status, msg)
+	l.env.metrics.endpointErrors.Add(endpointContentList, httpmon.StatusErrorClass(response.StatusCode))
+	l.env.observeError(response, msg)
+	l.env.publishHealth()
 	l.delay = l.env.config.ErrorRetryInterval
 
 	switch response.StatusCode {
@@ -18,9 +18,14 @@ import (
 
 	"github.com/Azure/go-autorest/autorest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/apihealth"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/o365audit/poll"
 	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
 )
 
 const contentType = "Audit.AzureActiveDirectory"
@@ -186,9 +191,10 @@ func testConfig() apiEnvironment {
 	logp.TestingSetup()
 	config := defaultConfig()
 	return apiEnvironment{
-		status: noopReporter{},
-		config: config.API,
-		logger: logp.NewLogger(pluginName + " test"),
+		status:  noopReporter{},
+		config:  config.API,
+		logger:  logp.NewLogger(pluginName + " test"),
+		metrics: newInputMetrics(monitoring.NewRegistry(), "", ""),
 		clock: func() time.Time {
 			return now
 		},
@@ -296,6 +302,7 @@ func TestSubscriptionStart(t *testing.T) {
 		contentType: contentType,
 		tenantID:    "1234",
 		logger:      log,
+		metrics:     newInputMetrics(monitoring.NewRegistry(), "1234", contentType),
 		clock: func() time.Time {
 			return now
 		},
@@ -420,3 +427,42 @@ func TestAdvance(t *testing.T) {
 	assert.Equal(t, now2.Add(time.Hour*24), lb.startTime)
 	assert.Equal(t, now3, lb.endTime)
 }
+
+func TestListBlobAPIHealth(t *testing.T) {
+	ctx := testConfig()
+	ctx.tenantID = "1234"
+	ctx.contentType = contentType
+	ctx.health = apihealth.NewTracker(apihealth.Config{Enabled: true, Interval: time.Hour}, pluginName, "o365-test")
+	var published []beat.Event
+	ctx.callback = func(event beat.Event, _ interface{}) error {
+		published = append(published, event)
+		return nil
+	}
+	lb := makeListBlob(checkpoint{}, ctx)
+	var f fakePoll
+
+	_, next := f.finishQuery(t, lb, &http.Response{
+		StatusCode: http.StatusUnauthorized,
+		Status:     "401 Unauthorized",
+		Body:       io.NopCloser(strings.NewReader(`{}`)),
+	})
+	require.Len(t, published, 1, "expected health event on first error")
+	status, _ := published[0].GetValue("api_health.status")
+	assert.Equal(t, "auth_failed", status)
+
+	_, next = f.finishQuery(t, next, &http.Response{
+		StatusCode: http.StatusTooManyRequests,
+		Status:     "429 Too Many Requests",
+		Body:       io.NopCloser(strings.NewReader(`{"error":{"code":"AF429","message":"Too many requests."}}`)),
+	})
+	f.SearchQuery(t, next.(listBlob), nil)
+
+	events := ctx.health.Events()
+	require.Len(t, events, 1)
+	health, err := events[0].GetValue("api_health")
+	require.NoError(t, err)
+	assert.Equal(t, "1234::"+contentType, health.(mapstr.M)["resource"])
+	assert.Equal(t, "healthy", health.(mapstr.M)["status"])
+	assert.Equal(t, uint64(1), health.(mapstr.M)["throttled_total"])
+	assert.Equal(t, mapstr.M{"status": "ok"}, health.(mapstr.M)["auth"])
+}
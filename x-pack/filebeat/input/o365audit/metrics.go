@@ -0,0 +1,66 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package o365audit
+
+import (
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/httpmon"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/schemadrift"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/o365audit/poll"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+// Names of the API endpoints the failed requests are counted for.
+const (
+	endpointSubscriptions = "subscriptions"
+	endpointContentList   = "content_list"
+	endpointContentBlob   = "content_blob"
+)
+
+// inputMetrics are collected for each tenant and content type stream.
+type inputMetrics struct {
+	tenantID                     *monitoring.String      // Tenant ID of the stream.
+	contentType                  *monitoring.String      // Content type of the stream.
+	eventsPublished              *monitoring.Uint        // Number of audit events published.
+	apiErrorsTotal               *monitoring.Uint        // Number of errors returned by the API.
+	authFailuresTotal            *monitoring.Uint        // Number of failures to acquire an authentication token.
+	streamRestartsTotal          *monitoring.Uint        // Number of times the stream was restarted after a failure.
+	certificateReloadsTotal      *monitoring.Uint        // Number of times a rotated certificate was reloaded.
+	certificateReloadErrorsTotal *monitoring.Uint        // Number of failures to reload a rotated certificate.
+	endpointErrors               *httpmon.EndpointErrors // Number of failed requests by endpoint and error class.
+	schema                       *schemadrift.Tracker    // Fields of the audit records.
+}
+
+func newInputMetrics(reg *monitoring.Registry, tenantID, contentType string) *inputMetrics {
+	out := &inputMetrics{
+		tenantID:                     monitoring.NewString(reg, "tenant_id"),
+		contentType:                  monitoring.NewString(reg, "content_type"),
+		eventsPublished:              monitoring.NewUint(reg, "events_published_total"),
+		apiErrorsTotal:               monitoring.NewUint(reg, "api_errors_total"),
+		authFailuresTotal:            monitoring.NewUint(reg, "auth_failures_total"),
+		streamRestartsTotal:          monitoring.NewUint(reg, "stream_restarts_total"),
+		certificateReloadsTotal:      monitoring.NewUint(reg, "certificate_reloads_total"),
+		certificateReloadErrorsTotal: monitoring.NewUint(reg, "certificate_reload_errors_total"),
+		endpointErrors:               httpmon.NewEndpointErrors(reg),
+		schema:                       schemadrift.NewTracker(reg),
+	}
+	out.tenantID.Set(tenantID)
+	out.contentType.Set(contentType)
+	return out
+}
+
+// endpointOf returns the API endpoint requested by a transaction.
+func endpointOf(t poll.Transaction) string {
+	switch t := t.(type) {
+	case paginator:
+		return endpointOf(t.inner)
+	case subscribe:
+		return endpointSubscriptions
+	case listBlob:
+		return endpointContentList
+	case contentBlob, withDelay:
+		return endpointContentBlob
+	}
+	return "other"
+}
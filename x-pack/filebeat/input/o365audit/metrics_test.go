@@ -0,0 +1,52 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package o365audit
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestEndpointOf(t *testing.T) {
+	env := testConfig()
+	blob := ContentBlob("http://test.localhost/", checkpoint{}, env)
+	assert.Equal(t, endpointSubscriptions, endpointOf(Subscribe(env)))
+	assert.Equal(t, endpointContentList, endpointOf(makeListBlob(checkpoint{}, env)))
+	assert.Equal(t, endpointContentBlob, endpointOf(blob))
+	assert.Equal(t, endpointContentBlob, endpointOf(withDelay{contentBlob: blob, delay: time.Second}))
+	assert.Equal(t, endpointContentList, endpointOf(newPager("http://test.localhost/page2", makeListBlob(checkpoint{}, env))))
+}
+
+func TestEndpointErrorMetrics(t *testing.T) {
+	reg := monitoring.NewRegistry()
+	env := testConfig()
+	env.metrics = newInputMetrics(reg, "", "")
+	env.callback = (&contentStore{}).onEvent
+
+	respond := func(code int, body string) *http.Response {
+		return &http.Response{
+			StatusCode: code,
+			Status:     http.StatusText(code),
+			Header:     http.Header{},
+			Body:       io.NopCloser(strings.NewReader(body)),
+		}
+	}
+	blob := ContentBlob("http://test.localhost/", checkpoint{}, env)
+	blob.OnResponse(respond(http.StatusServiceUnavailable, `{}`))
+	blob.OnResponse(respond(http.StatusOK, `not json`))
+	blob.OnResponse(respond(http.StatusOK, `[{"Id":"1","CreationTime":"2020-02-02T10:00:00"}]`))
+
+	snapshot := monitoring.CollectFlatSnapshot(reg, monitoring.Full, false)
+	assert.Equal(t, int64(1), snapshot.Ints["endpoint_errors.content_blob.server_error"])
+	assert.Equal(t, int64(1), snapshot.Ints["endpoint_errors.content_blob.decode"])
+	assert.Equal(t, int64(2), snapshot.Ints["schema_fields"])
+}
@@ -43,6 +43,8 @@ type Poller struct {
 	list       transactionList // List of pending transactions.
 	interval   time.Duration   // Minimum interval between transactions.
 	ctx        context.Context
+	sender     autorest.Sender          // Sender of the requests, autorest's default if nil.
+	onSendErr  func(Transaction, error) // Called when a request can't be sent.
 }
 
 // New creates a new Poller.
@@ -105,10 +107,17 @@ func (r *Poller) fetchWithDelay(item Transaction, minDelay time.Duration) error
 	if err != nil {
 		return fmt.Errorf("failed preparing request: %w", err)
 	}
-	response, err := autorest.Send(request,
-		autorest.DoCloseIfError())
+	var response *http.Response
+	if r.sender != nil {
+		response, err = autorest.SendWithSender(r.sender, request, autorest.DoCloseIfError())
+	} else {
+		response, err = autorest.Send(request, autorest.DoCloseIfError())
+	}
 	if err != nil {
 		r.log.Warnf("-- error sending request: %v", err)
+		if r.onSendErr != nil {
+			r.onSendErr(item, err)
+		}
 		return r.fetchWithDelay(item, max(time.Minute, r.interval))
 	}
 
@@ -177,6 +186,23 @@ func WithMinRequestInterval(d time.Duration) PollerOption {
 	}
 }
 
+// WithSender sets the sender of the requests, like an *http.Client.
+func WithSender(s autorest.Sender) PollerOption {
+	return func(r *Poller) error {
+		r.sender = s
+		return nil
+	}
+}
+
+// WithSendErrorHandler sets a function called with the transactions whose
+// request could not be sent, before they are retried.
+func WithSendErrorHandler(fn func(Transaction, error)) PollerOption {
+	return func(r *Poller) error {
+		r.onSendErr = fn
+		return nil
+	}
+}
+
 type listItem struct {
 	item Transaction
 	next *listItem
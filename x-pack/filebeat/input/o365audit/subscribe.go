@@ -11,6 +11,7 @@ import (
 
 	"github.com/Azure/go-autorest/autorest"
 
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/httpmon"
 	"github.com/elastic/beats/v7/x-pack/filebeat/input/o365audit/poll"
 )
 
@@ -46,6 +47,7 @@ func (s subscribe) OnResponse(response *http.Response) []poll.Action {
 	}
 	var js subscribeResponse
 	if err := readJSONBody(response, &js); err != nil {
+		s.metrics.endpointErrors.Add(endpointSubscriptions, httpmon.ErrorClassDecode)
 		return []poll.Action{
 			poll.Terminate(err),
 		}
@@ -59,6 +61,7 @@ func (s subscribe) OnResponse(response *http.Response) []poll.Action {
 }
 
 func (s subscribe) handleError(response *http.Response) []poll.Action {
+	s.metrics.endpointErrors.Add(endpointSubscriptions, httpmon.StatusErrorClass(response.StatusCode))
 	var msg apiError
 	if err := readJSONBody(response, &msg); err != nil {
 		return []poll.Action{poll.Terminate(err)}
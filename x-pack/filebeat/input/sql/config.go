@@ -0,0 +1,120 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sql
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	sqlhelper "github.com/elastic/beats/v7/metricbeat/helper/sql"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/sql/query/cursor"
+)
+
+type config struct {
+	// Driver is the database driver: postgres, mysql, mssql or oracle.
+	Driver string `config:"driver" validate:"required"`
+	// DSN is the connection string of the database, in the format of the
+	// driver.
+	DSN string `config:"dsn" validate:"required"`
+	// StateID identifies the checkpoint instead of the DSN, so that the
+	// DSN can change, for example when the password is rotated.
+	StateID string `config:"state_id"`
+
+	// Table is the table to read. The input builds the query.
+	Table string `config:"table"`
+	// Query is the query to run instead of reading Table. It must select
+	// the rows whose tracking column is greater than or equal to
+	// :sql_last_value, ordered by the tracking column.
+	Query string `config:"query"`
+
+	TrackingColumn     string `config:"tracking_column" validate:"required"`
+	TrackingColumnType string `config:"tracking_column_type"`
+	// InitialValue is the tracking value to start from when there is no
+	// checkpoint.
+	InitialValue string `config:"initial_value"`
+	// TimestampColumn is the column holding the time of the rows. It
+	// defaults to the tracking column for timestamp tracking columns.
+	TimestampColumn string `config:"timestamp_column"`
+
+	BatchSize int           `config:"batch_size" validate:"positive"`
+	Interval  time.Duration `config:"interval" validate:"positive"`
+}
+
+func defaultConfig() config {
+	return config{
+		TrackingColumnType: trackingNumeric,
+		BatchSize:          1000,
+		Interval:           time.Minute,
+	}
+}
+
+// identifierPattern and columnPattern restrict table and column names to
+// unquoted identifiers, table names can be schema qualified. They are
+// inserted in the queries built by the input and can not be passed as
+// parameters.
+var (
+	identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$#]*(\.[A-Za-z_][A-Za-z0-9_$#]*)*$`)
+	columnPattern     = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$#]*$`)
+)
+
+func (c *config) Validate() error {
+	if _, ok := lookupDialect(c.Driver); !ok {
+		return fmt.Errorf("unsupported driver %q, must be one of postgres, mysql, mssql or oracle", c.Driver)
+	}
+	if !slices.Contains(sql.Drivers(), sqlhelper.SwitchDriverName(c.Driver)) {
+		return fmt.Errorf("driver %q is not available in this build", c.Driver)
+	}
+	switch {
+	case c.Table == "" && c.Query == "":
+		return errors.New("one of table or query is required")
+	case c.Table != "" && c.Query != "":
+		return errors.New("table and query can not be used together")
+	case c.Table != "":
+		if !identifierPattern.MatchString(c.Table) {
+			return fmt.Errorf("invalid table name %q", c.Table)
+		}
+		if !columnPattern.MatchString(c.TrackingColumn) {
+			return fmt.Errorf("invalid tracking_column name %q", c.TrackingColumn)
+		}
+	default:
+		if len(cursor.FindPlaceholders(c.Query, lastValuePlaceholder)) == 0 {
+			return errors.New("query must filter the rows on :sql_last_value")
+		}
+	}
+	if c.TrackingColumnType != trackingNumeric && c.TrackingColumnType != trackingTimestamp {
+		return fmt.Errorf("invalid tracking_column_type %q, must be numeric or timestamp", c.TrackingColumnType)
+	}
+	if c.InitialValue != "" {
+		if _, err := parseTrackingValue(c.TrackingColumnType, c.InitialValue); err != nil {
+			return fmt.Errorf("invalid initial_value: %w", err)
+		}
+	}
+	return nil
+}
+
+// initialValue returns the tracking value to start from without checkpoint.
+// It defaults to 0 for numeric tracking columns and to the epoch for
+// timestamps.
+func (c *config) initialValue() string {
+	if c.InitialValue != "" {
+		return c.InitialValue
+	}
+	if c.TrackingColumnType == trackingTimestamp {
+		return time.Unix(0, 0).UTC().Format(time.RFC3339Nano)
+	}
+	return "0"
+}
+
+// timestampColumn returns the column holding the time of the rows, if any.
+func (c *config) timestampColumn() string {
+	if c.TimestampColumn == "" && c.TrackingColumnType == trackingTimestamp {
+		return c.TrackingColumn
+	}
+	return c.TimestampColumn
+}
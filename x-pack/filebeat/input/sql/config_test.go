@@ -0,0 +1,166 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidate(t *testing.T) {
+	valid := func() config {
+		c := defaultConfig()
+		c.Driver = "sqltest"
+		c.DSN = "test"
+		c.Table = "app.audit_log"
+		c.TrackingColumn = "id"
+		return c
+	}
+
+	tests := map[string]struct {
+		modify  func(*config)
+		wantErr string
+	}{
+		"valid": {modify: func(*config) {}},
+		"unknown driver": {
+			modify:  func(c *config) { c.Driver = "sqlite" },
+			wantErr: `unsupported driver "sqlite"`,
+		},
+		"no table or query": {
+			modify:  func(c *config) { c.Table = "" },
+			wantErr: "one of table or query is required",
+		},
+		"table and query": {
+			modify:  func(c *config) { c.Query = "SELECT * FROM t WHERE id >= :sql_last_value" },
+			wantErr: "table and query can not be used together",
+		},
+		"invalid table": {
+			modify:  func(c *config) { c.Table = "audit; DROP TABLE users" },
+			wantErr: "invalid table name",
+		},
+		"qualified tracking column": {
+			modify:  func(c *config) { c.TrackingColumn = "audit_log.id" },
+			wantErr: "invalid tracking_column name",
+		},
+		"query without placeholder": {
+			modify: func(c *config) {
+				c.Table = ""
+				c.Query = "SELECT * FROM audit_log ORDER BY id"
+			},
+			wantErr: "query must filter the rows on :sql_last_value",
+		},
+		"placeholder in a string": {
+			modify: func(c *config) {
+				c.Table = ""
+				c.Query = "SELECT * FROM audit_log WHERE note = ':sql_last_value' ORDER BY id"
+			},
+			wantErr: "query must filter the rows on :sql_last_value",
+		},
+		"invalid tracking column type": {
+			modify:  func(c *config) { c.TrackingColumnType = "uuid" },
+			wantErr: `invalid tracking_column_type "uuid"`,
+		},
+		"invalid initial value": {
+			modify: func(c *config) {
+				c.TrackingColumnType = trackingTimestamp
+				c.InitialValue = "yesterday"
+			},
+			wantErr: "invalid initial_value",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			c := valid()
+			test.modify(&c)
+			err := c.Validate()
+			if test.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestDialects(t *testing.T) {
+	query := "SELECT * FROM t WHERE a >= :sql_last_value OR b >= :sql_last_value"
+	tests := map[string]struct {
+		batch string
+		query string
+	}{
+		"postgresql": {
+			batch: "SELECT * FROM audit_log WHERE id >= $1 ORDER BY id LIMIT 10",
+			query: "SELECT * FROM t WHERE a >= $1 OR b >= $2",
+		},
+		"mysql": {
+			batch: "SELECT * FROM audit_log WHERE id >= ? ORDER BY id LIMIT 10",
+			query: "SELECT * FROM t WHERE a >= ? OR b >= ?",
+		},
+		"mssql": {
+			batch: "SELECT TOP (10) * FROM audit_log WHERE id >= @p1 ORDER BY id",
+			query: "SELECT * FROM t WHERE a >= @p1 OR b >= @p2",
+		},
+		"oracle": {
+			batch: "SELECT * FROM audit_log WHERE id >= :1 ORDER BY id FETCH FIRST 10 ROWS ONLY",
+			query: "SELECT * FROM t WHERE a >= :1 OR b >= :2",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			d, ok := lookupDialect(name)
+			require.True(t, ok)
+			assert.Equal(t, test.batch, d.batchQuery("audit_log", "id", ">=", 10))
+			q, n := d.translateQuery(query)
+			assert.Equal(t, test.query, q)
+			assert.Equal(t, 2, n)
+		})
+	}
+}
+
+func TestTranslateQuery(t *testing.T) {
+	d, _ := lookupDialect("postgres")
+	q, n := d.translateQuery(`SELECT id, ':sql_last_value' AS "x:sql_last_value" -- :sql_last_value
+FROM t /* :sql_last_value */ WHERE id >= :sql_last_value AND note <> 'it''s :sql_last_value' AND k = :sql_last_value_2`)
+	assert.Equal(t, `SELECT id, ':sql_last_value' AS "x:sql_last_value" -- :sql_last_value
+FROM t /* :sql_last_value */ WHERE id >= $1 AND note <> 'it''s :sql_last_value' AND k = :sql_last_value_2`, q,
+		"the placeholders in strings, identifiers and comments are kept")
+	assert.Equal(t, 1, n)
+
+	d, _ = lookupDialect("oracle")
+	assert.Equal(t, "SELECT * FROM audit_log WHERE id > :1 ORDER BY id FETCH FIRST 10 ROWS ONLY", d.batchQuery("audit_log", "id", ">", 10))
+}
+
+func TestTrackingValue(t *testing.T) {
+	ts := time.Date(2026, 10, 16, 10, 0, 1, 500e6, time.UTC)
+	tests := []struct {
+		typ   string
+		value any
+		want  any
+	}{
+		{trackingNumeric, int64(42), int64(42)},
+		{trackingNumeric, float64(42), int64(42)},
+		{trackingNumeric, []byte("42"), int64(42)},
+		{trackingNumeric, "42", int64(42)},
+		{trackingTimestamp, ts.In(time.FixedZone("CEST", 7200)), ts},
+		{trackingTimestamp, []byte("2026-10-16 10:00:01.5"), ts},
+		{trackingTimestamp, "2026-10-16T12:00:01.5+02:00", ts},
+	}
+	for _, test := range tests {
+		got, err := trackingValue(test.typ, test.value)
+		require.NoError(t, err)
+		assert.Equal(t, test.want, got)
+		parsed, err := parseTrackingValue(test.typ, formatTrackingValue(got))
+		require.NoError(t, err)
+		assert.Equal(t, got, parsed)
+	}
+
+	_, err := trackingValue(trackingNumeric, 4.2)
+	assert.Error(t, err)
+	_, err = trackingValue(trackingTimestamp, int64(42))
+	assert.Error(t, err)
+}
@@ -0,0 +1,94 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sql
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/sql/query/cursor"
+)
+
+// dialect is the SQL flavour of a database driver.
+type dialect struct {
+	// placeholder returns the nth query parameter, starting at 1.
+	placeholder func(n int) string
+	// batchQuery returns the query selecting the first limit rows of table
+	// whose column compares with op, >= or >, to the first query parameter,
+	// ordered by column.
+	batchQuery func(table, column, op string, limit int) string
+}
+
+// dialects are the supported drivers by the name used in the configuration.
+var dialects = map[string]dialect{
+	"postgres": {
+		placeholder: numberedPlaceholder("$"),
+		batchQuery:  limitQuery("$1"),
+	},
+	"mysql": {
+		placeholder: func(int) string { return "?" },
+		batchQuery:  limitQuery("?"),
+	},
+	"mssql": {
+		placeholder: numberedPlaceholder("@p"),
+		batchQuery: func(table, column, op string, limit int) string {
+			return fmt.Sprintf("SELECT TOP (%d) * FROM %s WHERE %s %s @p1 ORDER BY %[3]s", limit, table, column, op)
+		},
+	},
+	"oracle": {
+		placeholder: numberedPlaceholder(":"),
+		batchQuery: func(table, column, op string, limit int) string {
+			return fmt.Sprintf("SELECT * FROM %s WHERE %s %s :1 ORDER BY %[2]s FETCH FIRST %d ROWS ONLY", table, column, op, limit)
+		},
+	},
+}
+
+// driverAliases are alternative names of the supported drivers.
+var driverAliases = map[string]string{
+	"postgresql": "postgres",
+	"sqlserver":  "mssql",
+}
+
+func lookupDialect(driver string) (dialect, bool) {
+	if name, ok := driverAliases[driver]; ok {
+		driver = name
+	}
+	d, ok := dialects[driver]
+	return d, ok
+}
+
+func numberedPlaceholder(prefix string) func(int) string {
+	return func(n int) string {
+		return prefix + strconv.Itoa(n)
+	}
+}
+
+func limitQuery(placeholder string) func(table, column, op string, limit int) string {
+	return func(table, column, op string, limit int) string {
+		return fmt.Sprintf("SELECT * FROM %s WHERE %s %s %s ORDER BY %[2]s LIMIT %d", table, column, op, placeholder, limit)
+	}
+}
+
+// lastValuePlaceholder is replaced in user queries by the tracking value of
+// the checkpoint.
+const lastValuePlaceholder = ":sql_last_value"
+
+// translateQuery replaces the occurrences of :sql_last_value in query by the
+// parameters of the dialect. The occurrences in quoted strings, quoted
+// identifiers and comments are left as is. It returns the query and the
+// number of parameters, each of them is bound to the tracking value.
+func (d dialect) translateQuery(query string) (string, int) {
+	positions := cursor.FindPlaceholders(query, lastValuePlaceholder)
+	var b strings.Builder
+	last := 0
+	for i, pos := range positions {
+		b.WriteString(query[last:pos])
+		b.WriteString(d.placeholder(i + 1))
+		last = pos + len(lastValuePlaceholder)
+	}
+	b.WriteString(query[last:])
+	return b.String(), len(positions)
+}
@@ -0,0 +1,13 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package sql
+
+import (
+	// Register drivers.
+	_ "github.com/godror/godror"
+	_ "github.com/microsoft/go-mssqldb"
+)
@@ -0,0 +1,310 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sql
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	sqlhelper "github.com/elastic/beats/v7/metricbeat/helper/sql"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/go-concert/ctxtool"
+	"github.com/elastic/go-concert/timed"
+)
+
+const inputName = "sql"
+
+func Plugin(log *logp.Logger, store statestore.States) v2.Plugin {
+	return v2.Plugin{
+		Name:      inputName,
+		Stability: feature.Beta,
+		Info:      "Collect rows from SQL database tables",
+		Doc:       "Incrementally read the rows of SQL database tables, like audit tables, using a tracking column",
+		Manager: &cursor.InputManager{
+			Logger:     log,
+			StateStore: store,
+			Type:       inputName,
+			Configure:  configure,
+		},
+	}
+}
+
+func configure(cfg *conf.C, _ *logp.Logger) ([]cursor.Source, cursor.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, nil, fmt.Errorf("reading config: %w", err)
+	}
+	return []cursor.Source{newSource(config)}, &sqlInput{config: config}, nil
+}
+
+// source is the table or query of a database. Its name is a hash so that
+// the credentials of the DSN are not stored in the registry.
+type source struct {
+	name string
+}
+
+func newSource(c config) *source {
+	identity := c.DSN
+	if c.StateID != "" {
+		identity = "state-id:" + c.StateID
+	}
+	h := sha256.New()
+	for _, s := range []string{c.Driver, identity, c.Table, c.Query, c.TrackingColumn} {
+		h.Write([]byte(s))
+		h.Write([]byte{0})
+	}
+	return &source{name: "sql::" + hex.EncodeToString(h.Sum(nil))}
+}
+
+func (s *source) Name() string { return s.name }
+
+// maxCheckpointRows is the maximum number of rows of a checkpoint.
+const maxCheckpointRows = 1000
+
+// checkpoint is the persisted cursor of a source: the rows up to Value were
+// published. Rows are the hashes of the published rows whose tracking value
+// is Value, the rows with the same tracking value inserted later are
+// published with the next batch. If more than maxCheckpointRows rows have
+// the tracking value Value, Rows is cleared and Exclusive is set, the rows
+// with this value are not read again.
+type checkpoint struct {
+	Value     string   `json:"value" struct:"value"`
+	Rows      []string `json:"rows" struct:"rows"`
+	Exclusive bool     `json:"exclusive" struct:"exclusive"`
+}
+
+type sqlInput struct {
+	config config
+}
+
+func (inp *sqlInput) Name() string { return inputName }
+
+func (inp *sqlInput) Test(_ cursor.Source, ctx v2.TestContext) error {
+	db, err := inp.open(ctx.Logger)
+	if err != nil {
+		return err
+	}
+	return db.Close()
+}
+
+func (inp *sqlInput) Run(env v2.Context, _ cursor.Source, crsr cursor.Cursor, pub cursor.Publisher) error {
+	env.UpdateStatus(status.Starting, "")
+
+	log := env.Logger
+	ctx := ctxtool.FromCanceller(env.Cancelation)
+
+	env.UpdateStatus(status.Configuring, "")
+	cp := checkpoint{Value: inp.config.initialValue()}
+	if !crsr.IsNew() {
+		if err := crsr.Unpack(&cp); err != nil {
+			env.UpdateStatus(status.Failed, "failed to read checkpoint: "+err.Error())
+			return fmt.Errorf("failed to read checkpoint: %w", err)
+		}
+	}
+	c, err := newCollector(inp.config, cp, log)
+	if err != nil {
+		env.UpdateStatus(status.Failed, err.Error())
+		return err
+	}
+
+	db, err := inp.open(log)
+	if err != nil {
+		env.UpdateStatus(status.Failed, "failed to connect to the database: "+err.Error())
+		return fmt.Errorf("failed to connect to the database: %w", err)
+	}
+	defer db.Close()
+	c.db = db
+
+	log.Infow("Start reading rows", "tracking_value", cp.Value)
+	env.UpdateStatus(status.Running, "")
+	for {
+		n, err := c.collect(ctx, pub)
+		switch {
+		case errors.Is(err, context.Canceled):
+			return nil
+		case err != nil:
+			// The rows are read again from the checkpoint on the
+			// next interval.
+			log.Errorw("failed to read rows", "tracking_value", c.cp.Value, "published", n, "error", err)
+			env.UpdateStatus(status.Degraded, err.Error())
+		default:
+			log.Debugw("read rows", "tracking_value", c.cp.Value, "published", n)
+			env.UpdateStatus(status.Running, "")
+			if n > 0 {
+				// More rows may follow the batch.
+				continue
+			}
+		}
+
+		if err := timed.Wait(env.Cancelation, inp.config.Interval); err != nil {
+			return nil
+		}
+	}
+}
+
+// open connects to the database. The rows are read sequentially, so one
+// connection is enough. The DSN is redacted from the errors.
+func (inp *sqlInput) open(log *logp.Logger) (*sqlhelper.DbClient, error) {
+	db, err := sqlhelper.NewDBClient(inp.config.Driver, inp.config.DSN, log)
+	if err != nil {
+		return nil, sqlhelper.SanitizeError(err, inp.config.DSN)
+	}
+	db.SetMaxOpenConns(1)
+	return db, nil
+}
+
+// collector reads the rows following a checkpoint.
+type collector struct {
+	config  config
+	dialect dialect
+	db      *sqlhelper.DbClient
+	log     *logp.Logger
+
+	cp   checkpoint
+	last any // The parsed cp.Value.
+}
+
+func newCollector(c config, cp checkpoint, log *logp.Logger) (*collector, error) {
+	last, err := parseTrackingValue(c.TrackingColumnType, cp.Value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tracking value in checkpoint: %w", err)
+	}
+	d, _ := lookupDialect(c.Driver)
+	return &collector{config: c, dialect: d, log: log, cp: cp, last: last}, nil
+}
+
+// query returns the query reading the next rows and its parameters.
+func (c *collector) query() (string, []any) {
+	if c.config.Table != "" {
+		// The rows of the checkpoint are read again, the limit leaves
+		// room for a full batch of new rows.
+		limit := c.config.BatchSize + len(c.cp.Rows)
+		op := ">="
+		if c.cp.Exclusive {
+			op = ">"
+		}
+		return c.dialect.batchQuery(c.config.Table, c.config.TrackingColumn, op, limit), []any{c.last}
+	}
+	query, n := c.dialect.translateQuery(c.config.Query)
+	args := make([]any, n)
+	for i := range args {
+		args[i] = c.last
+	}
+	return query, args
+}
+
+// collect publishes the rows following the checkpoint. Each event moves the
+// checkpoint to its row. It returns the number of published rows.
+func (c *collector) collect(ctx context.Context, pub cursor.Publisher) (int, error) {
+	query, args := c.query()
+	// The column names of the rows are in lower case.
+	rows, err := c.db.FetchTableModeWithParams(ctx, query, args...)
+	if err != nil {
+		return 0, err
+	}
+	trackingColumn := strings.ToLower(c.config.TrackingColumn)
+	timestampColumn := strings.ToLower(c.config.timestampColumn())
+
+	// exclusive is whether the rows with the tracking value of the
+	// checkpoint were all published before this query.
+	exclusive := c.cp.Exclusive
+	var n, skipped int
+	for _, row := range rows {
+		tv, err := row.GetValue(trackingColumn)
+		if err != nil {
+			return n, fmt.Errorf("tracking column %q is not in the result columns", c.config.TrackingColumn)
+		}
+		v, err := trackingValue(c.config.TrackingColumnType, tv)
+		if err != nil {
+			return n, fmt.Errorf("invalid value of tracking column %q: %w", c.config.TrackingColumn, err)
+		}
+		var ts time.Time
+		if timestampColumn != "" {
+			tv, err := row.GetValue(timestampColumn)
+			if err != nil {
+				return n, fmt.Errorf("timestamp column %q is not in the result columns", c.config.timestampColumn())
+			}
+			if t, err := trackingValue(trackingTimestamp, tv); err == nil {
+				ts = t.(time.Time)
+			}
+		}
+		msg, err := json.Marshal(row)
+		if err != nil {
+			return n, fmt.Errorf("failed to encode row: %w", err)
+		}
+		hash := sha256.Sum256(msg)
+		rowHash := hex.EncodeToString(hash[:])
+
+		switch compareTrackingValues(v, c.last) {
+		case -1:
+			// The query does not filter or order the rows on the
+			// tracking column.
+			skipped++
+			continue
+		case 0:
+			if exclusive || slices.Contains(c.cp.Rows, rowHash) {
+				continue
+			}
+			if len(c.cp.Rows) >= maxCheckpointRows {
+				c.log.Warnw("too many rows with the same tracking value, the rows with this value that are not read yet are skipped", "tracking_value", c.cp.Value, "max_rows", maxCheckpointRows)
+				c.cp.Rows, c.cp.Exclusive = nil, true
+			}
+			if !c.cp.Exclusive {
+				c.cp.Rows = append(c.cp.Rows, rowHash)
+			}
+		default:
+			c.last = v
+			c.cp = checkpoint{Value: formatTrackingValue(v), Rows: []string{rowHash}}
+			exclusive = false
+		}
+
+		update := checkpoint{Value: c.cp.Value, Rows: slices.Clone(c.cp.Rows), Exclusive: c.cp.Exclusive}
+		if err := pub.Publish(c.newEvent(ts, msg), update); err != nil {
+			return n, err
+		}
+		n++
+	}
+	if skipped > 0 {
+		c.log.Warnw("skipped rows before the tracking value, the query must order the rows on the tracking column", "skipped", skipped, "tracking_value", c.cp.Value)
+	}
+	return n, nil
+}
+
+// newEvent returns the event of a row. The row is kept as a JSON object in
+// the message.
+func (c *collector) newEvent(ts time.Time, msg []byte) beat.Event {
+	if ts.IsZero() {
+		ts = time.Now()
+	}
+	fields := mapstr.M{
+		"driver":         c.config.Driver,
+		"tracking_value": c.cp.Value,
+	}
+	if c.config.Table != "" {
+		fields["table"] = c.config.Table
+	}
+	return beat.Event{
+		Timestamp: ts.UTC(),
+		Fields: mapstr.M{
+			"message": string(msg),
+			"sql":     fields,
+		},
+	}
+}
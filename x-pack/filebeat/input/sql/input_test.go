@@ -0,0 +1,313 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"io"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	sqlhelper "github.com/elastic/beats/v7/metricbeat/helper/sql"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func init() {
+	sql.Register("sqltest", testDriver{})
+	dialects["sqltest"] = dialect{
+		placeholder: numberedPlaceholder("$"),
+		batchQuery:  limitQuery("$1"),
+	}
+}
+
+// testQuery is a query received by the test driver.
+type testQuery struct {
+	query string
+	args  []driver.Value
+}
+
+// testHandler answers the queries of the test driver.
+var testHandler func(q testQuery) (columns []string, rows [][]driver.Value)
+
+type testDriver struct{}
+
+func (testDriver) Open(string) (driver.Conn, error) { return testConn{}, nil }
+
+type testConn struct{}
+
+func (testConn) Prepare(query string) (driver.Stmt, error) { return testStmt{query: query}, nil }
+func (testConn) Close() error                              { return nil }
+func (testConn) Begin() (driver.Tx, error)                 { return nil, errors.New("not supported") }
+
+type testStmt struct {
+	query string
+}
+
+func (testStmt) Close() error  { return nil }
+func (testStmt) NumInput() int { return -1 }
+func (testStmt) Exec([]driver.Value) (driver.Result, error) {
+	return nil, errors.New("not supported")
+}
+
+func (s testStmt) Query(args []driver.Value) (driver.Rows, error) {
+	columns, rows := testHandler(testQuery{query: s.query, args: args})
+	return &testRows{columns: columns, rows: rows}, nil
+}
+
+type testRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *testRows) Columns() []string { return r.columns }
+func (r *testRows) Close() error      { return nil }
+
+func (r *testRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
+
+type publishedEvent struct {
+	event  beat.Event
+	update any
+}
+
+type testPublisher struct {
+	events []publishedEvent
+}
+
+func (p *testPublisher) Publish(event beat.Event, update any) error {
+	p.events = append(p.events, publishedEvent{event: event, update: update})
+	return nil
+}
+
+var batchPattern = regexp.MustCompile(`(>=?) \$1 .*LIMIT (\d+)$`)
+
+// tableHandler emulates the batch queries of the table, whose rows are
+// ordered by the tracking column at index col.
+func tableHandler(queries *[]testQuery, columns []string, table [][]driver.Value, col int, compare func(a, b driver.Value) int) func(testQuery) ([]string, [][]driver.Value) {
+	return func(q testQuery) ([]string, [][]driver.Value) {
+		*queries = append(*queries, q)
+		m := batchPattern.FindStringSubmatch(q.query)
+		limit, _ := strconv.Atoi(m[2])
+		minCmp := 0
+		if m[1] == ">" {
+			minCmp = 1
+		}
+		var rows [][]driver.Value
+		for _, row := range table {
+			if compare(row[col], q.args[0]) >= minCmp && len(rows) < limit {
+				rows = append(rows, row)
+			}
+		}
+		return columns, rows
+	}
+}
+
+func newTestCollector(t *testing.T, c config) *collector {
+	t.Helper()
+	c.Driver = "sqltest"
+	c.DSN = "test"
+	require.NoError(t, c.Validate())
+	col, err := newCollector(c, checkpoint{Value: c.initialValue()}, logp.NewLogger("test"))
+	require.NoError(t, err)
+	col.db, err = sqlhelper.NewDBClient(c.Driver, c.DSN, logp.NewLogger("test"))
+	require.NoError(t, err)
+	t.Cleanup(func() { col.db.Close() })
+	return col
+}
+
+// collectAll collects until no new row is published.
+func collectAll(t *testing.T, c *collector, pub *testPublisher) {
+	t.Helper()
+	for i := 0; i < 10; i++ {
+		n, err := c.collect(context.Background(), pub)
+		require.NoError(t, err)
+		if n == 0 {
+			return
+		}
+	}
+	t.Fatal("rows are published again")
+}
+
+func TestCollectNumeric(t *testing.T) {
+	created := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	columns := []string{"id", "created_at", "action"}
+	var table [][]driver.Value
+	for i := int64(1); i <= 5; i++ {
+		table = append(table, []driver.Value{i, created.Add(time.Duration(i) * time.Second), []byte("login")})
+	}
+	var queries []testQuery
+	testHandler = tableHandler(&queries, columns, table, 0, func(a, b driver.Value) int {
+		return compareTrackingValues(a, b)
+	})
+
+	cfg := defaultConfig()
+	cfg.Table = "audit.log"
+	cfg.TrackingColumn = "id"
+	cfg.TimestampColumn = "created_at"
+	cfg.BatchSize = 2
+	c := newTestCollector(t, cfg)
+
+	var pub testPublisher
+	collectAll(t, c, &pub)
+
+	require.Len(t, pub.events, 5)
+	for i, e := range pub.events {
+		var row map[string]any
+		require.NoError(t, json.Unmarshal([]byte(e.event.Fields["message"].(string)), &row))
+		assert.Equal(t, float64(i+1), row["id"])
+		assert.Equal(t, "login", row["action"])
+		assert.Equal(t, created.Add(time.Duration(i+1)*time.Second), e.event.Timestamp)
+		assert.Equal(t, strconv.Itoa(i+1), e.update.(checkpoint).Value)
+	}
+	tableName, _ := pub.events[0].event.Fields.GetValue("sql.table")
+	assert.Equal(t, "audit.log", tableName)
+
+	require.Len(t, queries, 4)
+	assert.Equal(t, "SELECT * FROM audit.log WHERE id >= $1 ORDER BY id LIMIT 2", queries[0].query)
+	assert.Equal(t, []driver.Value{int64(0)}, queries[0].args)
+	assert.Equal(t, "SELECT * FROM audit.log WHERE id >= $1 ORDER BY id LIMIT 3", queries[1].query, "the batch leaves room for the rows of the checkpoint")
+	assert.Equal(t, []driver.Value{int64(2)}, queries[1].args)
+	assert.Len(t, c.cp.Rows, 1)
+}
+
+func TestCollectTimestamp(t *testing.T) {
+	t1 := time.Date(2026, 10, 16, 10, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Second)
+	columns := []string{"ID", "CREATED_AT"}
+	table := [][]driver.Value{
+		{int64(1), t1},
+		{int64(2), t1},
+		{int64(3), t2},
+		{int64(4), t2},
+		{int64(5), t2},
+	}
+	var queries []testQuery
+	testHandler = tableHandler(&queries, columns, table, 1, func(a, b driver.Value) int {
+		return a.(time.Time).Compare(b.(time.Time))
+	})
+
+	cfg := defaultConfig()
+	cfg.Table = "audit_log"
+	cfg.TrackingColumn = "created_at"
+	cfg.TrackingColumnType = trackingTimestamp
+	cfg.BatchSize = 3
+	c := newTestCollector(t, cfg)
+
+	var pub testPublisher
+	collectAll(t, c, &pub)
+
+	require.Len(t, pub.events, 5, "the rows sharing a tracking value are published once")
+	for i, e := range pub.events {
+		assert.Equal(t, table[i][1], e.event.Timestamp)
+	}
+	require.Len(t, queries, 3)
+	assert.Equal(t, []driver.Value{t2}, queries[1].args)
+	assert.Equal(t, "SELECT * FROM audit_log WHERE created_at >= $1 ORDER BY created_at LIMIT 6", queries[2].query)
+	assert.Equal(t, checkpoint{Value: t2.Format(time.RFC3339Nano), Rows: c.cp.Rows}, pub.events[4].update)
+	assert.Len(t, c.cp.Rows, 3)
+}
+
+func TestCollectQuery(t *testing.T) {
+	var queries []testQuery
+	testHandler = func(q testQuery) ([]string, [][]driver.Value) {
+		queries = append(queries, q)
+		return []string{"seq", "event"}, [][]driver.Value{
+			{"7", "a"},
+			{"6", "out of order"},
+			{"8", "b"},
+		}
+	}
+
+	cfg := defaultConfig()
+	cfg.Query = "SELECT seq, event FROM events WHERE seq >= :sql_last_value AND seq < :sql_last_value + 100 ORDER BY seq"
+	cfg.TrackingColumn = "seq"
+	cfg.InitialValue = "7"
+	c := newTestCollector(t, cfg)
+
+	var pub testPublisher
+	n, err := c.collect(context.Background(), &pub)
+	require.NoError(t, err)
+	assert.Equal(t, 2, n, "the rows before the tracking value are skipped")
+	assert.Equal(t, `{"event":"b","seq":"8"}`, pub.events[1].event.Fields["message"])
+	_, err = pub.events[0].event.Fields.GetValue("sql.table")
+	assert.Error(t, err)
+
+	require.Len(t, queries, 1)
+	assert.Equal(t, "SELECT seq, event FROM events WHERE seq >= $1 AND seq < $2 + 100 ORDER BY seq", queries[0].query)
+	assert.Equal(t, []driver.Value{int64(7), int64(7)}, queries[0].args)
+
+	testHandler = func(testQuery) ([]string, [][]driver.Value) {
+		return []string{"event"}, [][]driver.Value{{"c"}}
+	}
+	_, err = c.collect(context.Background(), &pub)
+	assert.ErrorContains(t, err, `tracking column "seq" is not in the result columns`)
+}
+
+func TestCollectMaxCheckpointRows(t *testing.T) {
+	columns := []string{"id", "n"}
+	var table [][]driver.Value
+	for i := 0; i <= maxCheckpointRows; i++ {
+		table = append(table, []driver.Value{int64(1), int64(i)})
+	}
+	table = append(table, []driver.Value{int64(2), int64(0)})
+	var queries []testQuery
+	testHandler = tableHandler(&queries, columns, table, 0, func(a, b driver.Value) int {
+		return compareTrackingValues(a, b)
+	})
+
+	cfg := defaultConfig()
+	cfg.Table = "audit_log"
+	cfg.TrackingColumn = "id"
+	cfg.BatchSize = maxCheckpointRows + 1
+	c := newTestCollector(t, cfg)
+
+	var pub testPublisher
+	n, err := c.collect(context.Background(), &pub)
+	require.NoError(t, err)
+	assert.Equal(t, maxCheckpointRows+1, n)
+	assert.Equal(t, checkpoint{Value: "1", Exclusive: true}, pub.events[n-1].update,
+		"the rows are not kept beyond the maximum")
+
+	n, err = c.collect(context.Background(), &pub)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	assert.Equal(t, "SELECT * FROM audit_log WHERE id > $1 ORDER BY id LIMIT 1001", queries[1].query)
+	assert.Equal(t, checkpoint{Value: "2", Rows: c.cp.Rows}, pub.events[len(pub.events)-1].update)
+}
+
+func TestSourceName(t *testing.T) {
+	cfg := defaultConfig()
+	cfg.Driver = "postgres"
+	cfg.DSN = "postgres://user:secret@db:5432/app"
+	cfg.Table = "audit_log"
+	cfg.TrackingColumn = "id"
+
+	name := newSource(cfg).Name()
+	assert.NotContains(t, name, "secret")
+
+	cfg.DSN = "postgres://user:rotated@db:5432/app"
+	assert.NotEqual(t, name, newSource(cfg).Name())
+
+	cfg.StateID = "app-audit"
+	name = newSource(cfg).Name()
+	cfg.DSN = "postgres://user:secret@db:5432/app"
+	assert.Equal(t, name, newSource(cfg).Name(), "the state ID identifies the checkpoint instead of the DSN")
+}
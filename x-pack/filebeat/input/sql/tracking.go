@@ -0,0 +1,109 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package sql
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	trackingNumeric   = "numeric"
+	trackingTimestamp = "timestamp"
+)
+
+// timestampLayouts are the layouts of the timestamps returned as text by the
+// drivers, like MySQL without parseTime. Timestamps without a time zone are
+// in UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	time.DateOnly,
+}
+
+// parseTrackingValue parses a tracking value of a checkpoint or of the
+// initial_value setting. Numeric values are int64, timestamps are time.Time.
+func parseTrackingValue(typ, s string) (any, error) {
+	switch typ {
+	case trackingNumeric:
+		return strconv.ParseInt(s, 10, 64)
+	case trackingTimestamp:
+		return parseTimestamp(s)
+	}
+	return nil, fmt.Errorf("unsupported tracking column type %q", typ)
+}
+
+// formatTrackingValue is the inverse of parseTrackingValue.
+func formatTrackingValue(v any) string {
+	switch v := v.(type) {
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(v)
+}
+
+// trackingValue converts a value of the tracking column returned by the
+// driver to a tracking value of type typ.
+func trackingValue(typ string, v any) (any, error) {
+	switch typ {
+	case trackingNumeric:
+		switch v := v.(type) {
+		case int64:
+			return v, nil
+		case float64:
+			if v == math.Trunc(v) && v >= math.MinInt64 && v < math.MaxInt64 {
+				return int64(v), nil
+			}
+		case []byte:
+			return strconv.ParseInt(string(v), 10, 64)
+		case string:
+			return strconv.ParseInt(v, 10, 64)
+		}
+	case trackingTimestamp:
+		switch v := v.(type) {
+		case time.Time:
+			return v.UTC(), nil
+		case []byte:
+			return parseTimestamp(string(v))
+		case string:
+			return parseTimestamp(v)
+		}
+	}
+	return nil, fmt.Errorf("unsupported %s value %v of type %T", typ, v, v)
+}
+
+// compareTrackingValues returns -1, 0 or 1 if a is less than, equal to or
+// greater than b. Both values must have the same type.
+func compareTrackingValues(a, b any) int {
+	switch a := a.(type) {
+	case int64:
+		b := b.(int64)
+		switch {
+		case a < b:
+			return -1
+		case a > b:
+			return 1
+		}
+		return 0
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	}
+	panic(fmt.Sprintf("unsupported tracking value type %T", a))
+}
+
+func parseTimestamp(s string) (time.Time, error) {
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+}
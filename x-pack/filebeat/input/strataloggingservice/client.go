@@ -0,0 +1,149 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package strataloggingservice
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// Query job states reported by the Query API.
+const (
+	jobPending   = "PENDING"
+	jobRunning   = "RUNNING"
+	jobDone      = "DONE"
+	jobFailed    = "FAILED"
+	jobCancelled = "CANCELLED"
+)
+
+// client is a Query API client. Logs are read by creating a query job and
+// paging through its results once it is done.
+type client struct {
+	http    *http.Client
+	baseURL string
+	log     *logp.Logger
+
+	pageSize     int
+	pollInterval time.Duration
+	jobTimeout   time.Duration
+}
+
+type jobRequest struct {
+	Params jobParams `json:"params"`
+}
+
+type jobParams struct {
+	Query string `json:"query"`
+}
+
+type jobResponse struct {
+	JobID string `json:"jobId"`
+}
+
+// resultsPage is a page of the results of a query job. When the job is not
+// done yet the page is empty.
+type resultsPage struct {
+	JobID string `json:"jobId"`
+	State string `json:"state"`
+	Page  struct {
+		PageCursor string `json:"pageCursor"`
+		Result     struct {
+			Data []json.RawMessage `json:"data"`
+		} `json:"result"`
+	} `json:"page"`
+}
+
+// createJob submits a query and returns the ID of its job.
+func (c *client) createJob(ctx context.Context, query string) (string, error) {
+	body, err := json.Marshal(jobRequest{Params: jobParams{Query: query}})
+	if err != nil {
+		return "", err
+	}
+	var resp jobResponse
+	if err := c.do(ctx, http.MethodPost, c.baseURL+"/query/v2/jobs", bytes.NewReader(body), &resp); err != nil {
+		return "", fmt.Errorf("failed to create query job: %w", err)
+	}
+	if resp.JobID == "" {
+		return "", fmt.Errorf("failed to create query job: response has no job ID")
+	}
+	return resp.JobID, nil
+}
+
+// results returns the page of results of the job at pageCursor, waiting for
+// the job to complete. An empty pageCursor returns the first page.
+func (c *client) results(ctx context.Context, jobID, pageCursor string) (*resultsPage, error) {
+	q := url.Values{"pageSize": []string{strconv.Itoa(c.pageSize)}}
+	if pageCursor != "" {
+		q.Set("pageCursor", pageCursor)
+	}
+	u := c.baseURL + "/query/v2/jobResults/" + url.PathEscape(jobID) + "?" + q.Encode()
+
+	ctx, cancel := context.WithTimeout(ctx, c.jobTimeout)
+	defer cancel()
+	for {
+		var page resultsPage
+		if err := c.do(ctx, http.MethodGet, u, nil, &page); err != nil {
+			return nil, fmt.Errorf("failed to get results of job %s: %w", jobID, err)
+		}
+		switch page.State {
+		case jobDone:
+			return &page, nil
+		case jobPending, jobRunning:
+			c.log.Debugw("waiting for query job", "job_id", jobID, "state", page.State)
+		default:
+			return nil, fmt.Errorf("query job %s is %s", jobID, page.State)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("query job %s did not complete: %w", jobID, ctx.Err())
+		case <-time.After(c.pollInterval):
+		}
+	}
+}
+
+func (c *client) do(ctx context.Context, method, u string, body io.Reader, dst any) error {
+	req, err := http.NewRequestWithContext(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+	if err := json.Unmarshal(b, dst); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// query returns the query selecting the logs of table generated in
+// (from, to], oldest first.
+func query(table string, from, to time.Time) string {
+	return fmt.Sprintf("SELECT * FROM `%s` WHERE time_generated > TIMESTAMP_SECONDS(%d) AND time_generated <= TIMESTAMP_SECONDS(%d) ORDER BY time_generated ASC",
+		table, from.Unix(), to.Unix())
+}
@@ -0,0 +1,109 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package strataloggingservice
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+)
+
+const defaultTokenURL = "https://auth.apps.paloaltonetworks.com/oauth2/access_token"
+
+type config struct {
+	// URL is the base URL of the Query API of the region of the
+	// tenant, for example https://api.us.cdl.paloaltonetworks.com.
+	URL  string     `config:"url" validate:"required"`
+	Auth authConfig `config:"auth"`
+
+	// Tables are the log tables to collect, for example firewall.traffic.
+	// Each table is queried and checkpointed independently.
+	Tables []string `config:"tables" validate:"required"`
+
+	Interval        time.Duration `config:"interval" validate:"positive"`
+	InitialInterval time.Duration `config:"initial_interval" validate:"positive"`
+	Delay           time.Duration `config:"delay" validate:"min=0"`
+	PageSize        int           `config:"page_size" validate:"positive"`
+	Job             jobConfig     `config:"job"`
+
+	Transport httpcommon.HTTPTransportSettings `config:",inline"`
+}
+
+type authConfig struct {
+	ClientID     string   `config:"client_id" validate:"required"`
+	ClientSecret string   `config:"client_secret" validate:"required"`
+	TokenURL     string   `config:"token_url"`
+	TSGID        string   `config:"tsg_id"`
+	Scopes       []string `config:"scopes"`
+}
+
+// jobConfig controls how the input waits for query jobs to complete.
+type jobConfig struct {
+	PollInterval time.Duration `config:"poll_interval" validate:"positive"`
+	Timeout      time.Duration `config:"timeout" validate:"positive"`
+}
+
+func defaultConfig() config {
+	return config{
+		Auth: authConfig{
+			TokenURL: defaultTokenURL,
+		},
+		Interval:        time.Minute,
+		InitialInterval: 24 * time.Hour,
+		Delay:           time.Minute,
+		PageSize:        1000,
+		Job: jobConfig{
+			PollInterval: 5 * time.Second,
+			Timeout:      5 * time.Minute,
+		},
+		Transport: httpcommon.DefaultHTTPTransportSettings(),
+	}
+}
+
+// tableNamePattern restricts table names to the characters of dataset
+// qualified names. Table names are quoted in the query but can not be
+// passed as parameters.
+var tableNamePattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+
+func (c *config) Validate() error {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("url must use the https scheme: %q", c.URL)
+	}
+	if _, err := url.Parse(c.Auth.TokenURL); err != nil {
+		return fmt.Errorf("invalid auth.token_url: %w", err)
+	}
+	seen := make(map[string]bool, len(c.Tables))
+	for _, t := range c.Tables {
+		if !tableNamePattern.MatchString(t) {
+			return fmt.Errorf("invalid table name %q", t)
+		}
+		if seen[t] {
+			return fmt.Errorf("duplicate table %q", t)
+		}
+		seen[t] = true
+	}
+	if c.Job.PollInterval > c.Job.Timeout {
+		return errors.New("job.poll_interval must not be greater than job.timeout")
+	}
+	return nil
+}
+
+// scopes returns the OAuth2 scopes to request. The TSG ID selects the tenant
+// service group the token is issued for.
+func (c *authConfig) scopes() []string {
+	scopes := c.Scopes
+	if c.TSGID != "" {
+		scopes = append([]string{"tsg_id:" + c.TSGID}, scopes...)
+	}
+	return scopes
+}
@@ -0,0 +1,233 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package strataloggingservice
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+	"github.com/elastic/go-concert/ctxtool"
+	"github.com/elastic/go-concert/timed"
+)
+
+const (
+	inputName    = "strata-logging-service"
+	fieldsPrefix = "strata_logging_service"
+)
+
+func Plugin(log *logp.Logger, store statestore.States) v2.Plugin {
+	return v2.Plugin{
+		Name:      inputName,
+		Stability: feature.Beta,
+		Info:      "Collect Palo Alto Networks Strata Logging Service logs",
+		Doc:       "Collect logs from the Strata Logging Service (Cortex Data Lake) Query API",
+		Manager: &cursor.InputManager{
+			Logger:     log,
+			StateStore: store,
+			Type:       inputName,
+			Configure:  configure,
+		},
+	}
+}
+
+func configure(cfg *conf.C, _ *logp.Logger) ([]cursor.Source, cursor.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	sources := make([]cursor.Source, 0, len(config.Tables))
+	for _, table := range config.Tables {
+		sources = append(sources, &source{url: config.URL, table: table})
+	}
+	return sources, &slsInput{config: config}, nil
+}
+
+// source is a log table of a tenant.
+type source struct {
+	url   string
+	table string
+}
+
+func (s *source) Name() string {
+	return s.url + "::" + s.table
+}
+
+// checkpoint is the persisted cursor of a table: logs generated up to Time
+// were published.
+type checkpoint struct {
+	Time time.Time `json:"time" struct:"time"`
+}
+
+type slsInput struct {
+	config config
+}
+
+func (inp *slsInput) Name() string { return inputName }
+
+func (inp *slsInput) Test(_ cursor.Source, ctx v2.TestContext) error {
+	httpClient, err := inp.config.Transport.Client(httpcommon.WithLogger(ctx.Logger))
+	if err != nil {
+		return err
+	}
+	oauthCtx := context.WithValue(ctxtool.FromCanceller(ctx.Cancelation), oauth2.HTTPClient, httpClient)
+	if _, err := inp.credentials().Token(oauthCtx); err != nil {
+		return fmt.Errorf("unable to acquire authentication token: %w", err)
+	}
+	return nil
+}
+
+func (inp *slsInput) Run(env v2.Context, src cursor.Source, crsr cursor.Cursor, pub cursor.Publisher) error {
+	env.UpdateStatus(status.Starting, "")
+
+	table := src.(*source).table
+	log := env.Logger.With("table", table)
+	ctx := ctxtool.FromCanceller(env.Cancelation)
+
+	env.UpdateStatus(status.Configuring, "")
+	var cp checkpoint
+	if !crsr.IsNew() {
+		if err := crsr.Unpack(&cp); err != nil {
+			env.UpdateStatus(status.Failed, "failed to read checkpoint: "+err.Error())
+			return fmt.Errorf("failed to read checkpoint: %w", err)
+		}
+	}
+	if cp.Time.IsZero() {
+		cp.Time = time.Now().Add(-inp.config.InitialInterval).Truncate(time.Second)
+	}
+
+	c, err := inp.newClient(ctx, log)
+	if err != nil {
+		env.UpdateStatus(status.Failed, "failed to configure client: "+err.Error())
+		return err
+	}
+
+	log.Infow("Start fetching logs", "checkpoint", cp.Time)
+	env.UpdateStatus(status.Running, "")
+	for {
+		to := time.Now().Add(-inp.config.Delay).Truncate(time.Second)
+		if to.After(cp.Time) {
+			n, err := collect(ctx, c, table, cp.Time, to, pub)
+			switch {
+			case errors.Is(err, context.Canceled):
+				return nil
+			case err != nil:
+				// The window is queried again from its start on the
+				// next interval.
+				log.Errorw("failed to collect logs", "from", cp.Time, "to", to, "published", n, "error", err)
+				env.UpdateStatus(status.Degraded, err.Error())
+			default:
+				log.Debugw("collected logs", "from", cp.Time, "to", to, "published", n)
+				cp.Time = to
+				env.UpdateStatus(status.Running, "")
+			}
+		}
+
+		if err := timed.Wait(env.Cancelation, inp.config.Interval); err != nil {
+			return nil
+		}
+	}
+}
+
+func (inp *slsInput) newClient(ctx context.Context, log *logp.Logger) (*client, error) {
+	httpClient, err := inp.config.Transport.Client(httpcommon.WithAPMHTTPInstrumentation(), httpcommon.WithLogger(log))
+	if err != nil {
+		return nil, err
+	}
+	return &client{
+		http:         inp.credentials().Client(context.WithValue(ctx, oauth2.HTTPClient, httpClient)),
+		baseURL:      strings.TrimSuffix(inp.config.URL, "/"),
+		log:          log,
+		pageSize:     inp.config.PageSize,
+		pollInterval: inp.config.Job.PollInterval,
+		jobTimeout:   inp.config.Job.Timeout,
+	}, nil
+}
+
+func (inp *slsInput) credentials() *clientcredentials.Config {
+	return &clientcredentials.Config{
+		ClientID:     inp.config.Auth.ClientID,
+		ClientSecret: inp.config.Auth.ClientSecret,
+		TokenURL:     inp.config.Auth.TokenURL,
+		Scopes:       inp.config.Auth.scopes(),
+	}
+}
+
+// collect queries the logs of table generated in (from, to] and publishes
+// them. The checkpoint moves to the end of the window with the last log, so a
+// window is collected again from its start if the input stops before it is
+// complete. It returns the number of published logs.
+func collect(ctx context.Context, c *client, table string, from, to time.Time, pub cursor.Publisher) (int, error) {
+	jobID, err := c.createJob(ctx, query(table, from, to))
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		n          int
+		pageCursor string
+	)
+	for {
+		page, err := c.results(ctx, jobID, pageCursor)
+		if err != nil {
+			return n, err
+		}
+		pageCursor = page.Page.PageCursor
+		rows := page.Page.Result.Data
+		for i, row := range rows {
+			var update any
+			if pageCursor == "" && i == len(rows)-1 {
+				update = checkpoint{Time: to}
+			}
+			if err := pub.Publish(newEvent(table, row), update); err != nil {
+				return n, err
+			}
+			n++
+		}
+		if pageCursor == "" {
+			return n, nil
+		}
+	}
+}
+
+// newEvent returns the event for a log row. The row is kept as the message
+// and its time_generated, in seconds since the epoch, is the event time.
+func newEvent(table string, row json.RawMessage) beat.Event {
+	ts := time.Now().UTC()
+	var fields struct {
+		TimeGenerated json.Number `json:"time_generated"`
+	}
+	if err := json.Unmarshal(row, &fields); err == nil {
+		if sec, err := fields.TimeGenerated.Int64(); err == nil {
+			ts = time.Unix(sec, 0).UTC()
+		}
+	}
+	return beat.Event{
+		Timestamp: ts,
+		Fields: mapstr.M{
+			"message": string(row),
+			fieldsPrefix: mapstr.M{
+				"table": table,
+			},
+		},
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package strataloggingservice
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+type publishedEvent struct {
+	event  beat.Event
+	update any
+}
+
+type testPublisher struct {
+	events []publishedEvent
+}
+
+func (p *testPublisher) Publish(event beat.Event, update any) error {
+	p.events = append(p.events, publishedEvent{event: event, update: update})
+	return nil
+}
+
+// newTestServer returns a Query API server holding one job. The job is
+// reported as running on the first results request, and its rows are
+// returned in pages of two.
+func newTestServer(t *testing.T, wantQuery string, rows []string) *httptest.Server {
+	t.Helper()
+	polled := false
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /query/v2/jobs", func(w http.ResponseWriter, r *http.Request) {
+		var req jobRequest
+		if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&req)) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		assert.Equal(t, wantQuery, req.Params.Query)
+		fmt.Fprint(w, `{"jobId":"job-1"}`)
+	})
+	mux.HandleFunc("GET /query/v2/jobResults/job-1", func(w http.ResponseWriter, r *http.Request) {
+		if !polled {
+			polled = true
+			fmt.Fprint(w, `{"jobId":"job-1","state":"RUNNING"}`)
+			return
+		}
+		start := 0
+		if c := r.URL.Query().Get("pageCursor"); c != "" {
+			var err error
+			start, err = strconv.Atoi(c)
+			require.NoError(t, err)
+		}
+		end := min(start+2, len(rows))
+		next := ""
+		if end < len(rows) {
+			next = strconv.Itoa(end)
+		}
+		data := "[" + strings.Join(rows[start:end], ",") + "]"
+		fmt.Fprintf(w, `{"jobId":"job-1","state":"DONE","page":{"pageCursor":%q,"result":{"data":%s}}}`, next, data)
+	})
+	return httptest.NewServer(mux)
+}
+
+func TestCollect(t *testing.T) {
+	from := time.Unix(1700000000, 0)
+	to := time.Unix(1700000060, 0)
+	rows := []string{
+		`{"time_generated":1700000001,"action":"allow"}`,
+		`{"time_generated":1700000002,"action":"deny"}`,
+		`{"time_generated":1700000030,"action":"allow"}`,
+	}
+	srv := newTestServer(t, "SELECT * FROM `firewall.traffic` WHERE time_generated > TIMESTAMP_SECONDS(1700000000) AND time_generated <= TIMESTAMP_SECONDS(1700000060) ORDER BY time_generated ASC", rows)
+	defer srv.Close()
+
+	c := &client{
+		http:         srv.Client(),
+		baseURL:      srv.URL,
+		log:          logp.NewLogger(inputName),
+		pageSize:     2,
+		pollInterval: time.Millisecond,
+		jobTimeout:   time.Minute,
+	}
+	var pub testPublisher
+	n, err := collect(context.Background(), c, "firewall.traffic", from, to, &pub)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	require.Len(t, pub.events, 3)
+
+	for i, e := range pub.events {
+		assert.Equal(t, rows[i], e.event.Fields["message"])
+		table, _ := e.event.Fields.GetValue("strata_logging_service.table")
+		assert.Equal(t, "firewall.traffic", table)
+	}
+	assert.Equal(t, time.Unix(1700000002, 0).UTC(), pub.events[1].event.Timestamp)
+
+	// Only the last log of the window moves the checkpoint.
+	assert.Nil(t, pub.events[0].update)
+	assert.Nil(t, pub.events[1].update)
+	assert.Equal(t, checkpoint{Time: to}, pub.events[2].update)
+}
+
+func TestCollectFailedJob(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /query/v2/jobs", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"jobId":"job-2"}`)
+	})
+	mux.HandleFunc("GET /query/v2/jobResults/job-2", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"jobId":"job-2","state":"FAILED"}`)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := &client{
+		http:         srv.Client(),
+		baseURL:      srv.URL,
+		log:          logp.NewLogger(inputName),
+		pageSize:     10,
+		pollInterval: time.Millisecond,
+		jobTimeout:   time.Minute,
+	}
+	var pub testPublisher
+	_, err := collect(context.Background(), c, "firewall.threat", time.Unix(0, 0), time.Unix(60, 0), &pub)
+	assert.EqualError(t, err, "query job job-2 is FAILED")
+	assert.Empty(t, pub.events)
+}
+
+func TestConfigValidate(t *testing.T) {
+	testCases := map[string]struct {
+		url     string
+		tables  []string
+		wantErr string
+	}{
+		"valid":      {tables: []string{"firewall.traffic", "firewall.threat", "log.system"}},
+		"plain http": {url: "http://api.us.cdl.paloaltonetworks.com", wantErr: `url must use the https scheme: "http://api.us.cdl.paloaltonetworks.com"`},
+		"injection":  {tables: []string{"firewall.traffic` WHERE 1=1 --"}, wantErr: "invalid table name \"firewall.traffic` WHERE 1=1 --\""},
+		"empty part": {tables: []string{"firewall..traffic"}, wantErr: `invalid table name "firewall..traffic"`},
+		"duplicate":  {tables: []string{"firewall.traffic", "firewall.traffic"}, wantErr: `duplicate table "firewall.traffic"`},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := defaultConfig()
+			c.URL = "https://api.us.cdl.paloaltonetworks.com"
+			if tc.url != "" {
+				c.URL = tc.url
+			}
+			c.Tables = tc.tables
+			err := c.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
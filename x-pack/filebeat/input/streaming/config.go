@@ -48,6 +48,8 @@ type config struct {
 	Redact *redact `config:"redact"`
 	// Retry is the configuration for retrying failed connections.
 	Retry *retry `config:"retry"`
+	// Replay is the configuration for replay ID gap detection.
+	Replay *replayConfig `config:"replay"`
 	// Transport is the common the transport config.
 	Transport httpcommon.HTTPTransportSettings `config:",inline"`
 	// KeepAlive is the configuration for keep-alive settings.
@@ -183,6 +185,10 @@ func (c config) Validate() error {
 		}
 	}
 
+	if c.Replay != nil && c.Type == "crowdstrike" {
+		return errors.New("replay is only supported by websocket streams")
+	}
+
 	if c.Auth.OAuth2.isEnabled() {
 		if c.Auth.OAuth2.AuthStyle != authStyleInHeader && c.Auth.OAuth2.AuthStyle != authStyleInParams && c.Auth.OAuth2.AuthStyle != "" {
 			return fmt.Errorf("unsupported auth style: %s", c.Auth.OAuth2.AuthStyle)
@@ -174,6 +174,7 @@ type processor struct {
 	log     *logp.Logger
 	redact  *redact
 	metrics *inputMetrics
+	replay  *replayTracker // nil unless replay gap detection is enabled
 }
 
 // process processes the data in state, updates the cursor and publishes it to
@@ -257,6 +258,14 @@ func (p processor) process(ctx context.Context, state, cursor map[string]any, st
 		if !ok {
 			return goodCursor, fmt.Errorf("unexpected type returned for evaluation events: %T", e)
 		}
+		if p.replay != nil {
+			err = p.checkReplay(ctx, event)
+			if err != nil {
+				hadPublicationError = true
+				p.metrics.errorsTotal.Inc()
+				p.log.Errorw("error publishing replay gap event", "error", err)
+			}
+		}
 		var pubCursor any
 		if cursors != nil {
 			if singleCursor {
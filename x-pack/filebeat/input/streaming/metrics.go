@@ -23,6 +23,9 @@ type inputMetrics struct {
 	batchesPublished        *monitoring.Uint   // number of event arrays published
 	eventsPublished         *monitoring.Uint   // number of events published
 	writeControlErrors      *monitoring.Uint   // number of errors encountered while sending write control messages like ping
+	replayGaps              *monitoring.Uint   // number of gaps detected in received replay IDs
+	replayIDsMissed         *monitoring.Uint   // number of replay IDs missed in detected gaps
+	replayEventsBackfilled  *monitoring.Uint   // number of missed events published from backfill requests
 	celProcessingTime       metrics.Sample     // histogram of the elapsed successful cel program processing times in nanoseconds
 	batchProcessingTime     metrics.Sample     // histogram of the elapsed successful batch processing times in nanoseconds (time of receipt to time of ACK for non-empty batches).
 	pingMessageSendTime     metrics.Sample     // histogram of the elapsed successful ping message send times in nanoseconds
@@ -40,6 +43,9 @@ func newInputMetrics(reg *monitoring.Registry, logger *logp.Logger) *inputMetric
 		batchesPublished:        monitoring.NewUint(reg, "batches_published_total"),
 		eventsPublished:         monitoring.NewUint(reg, "events_published_total"),
 		writeControlErrors:      monitoring.NewUint(reg, "write_control_errors"),
+		replayGaps:              monitoring.NewUint(reg, "replay_gaps_total"),
+		replayIDsMissed:         monitoring.NewUint(reg, "replay_ids_missed_total"),
+		replayEventsBackfilled:  monitoring.NewUint(reg, "replay_events_backfilled_total"),
 		celProcessingTime:       metrics.NewUniformSample(1024),
 		batchProcessingTime:     metrics.NewUniformSample(1024),
 		pingMessageSendTime:     metrics.NewUniformSample(1024),
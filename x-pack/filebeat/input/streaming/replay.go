@@ -0,0 +1,131 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package streaming
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/replay"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+type replayConfig struct {
+	replay.Config `config:",inline"`
+	// IDField is the dotted path of the replay ID in the
+	// events returned by the program.
+	IDField string `config:"id_field" validate:"required"`
+}
+
+// replayTracker detects gaps in the replay IDs of the events
+// published by a stream.
+type replayTracker struct {
+	field    string
+	detector replay.Detector
+	backfill *replay.Backfiller
+	// header returns the headers to send with backfill
+	// requests.
+	header func() http.Header
+}
+
+func newReplayTracker(cfg *replayConfig, header func() http.Header) *replayTracker {
+	if cfg == nil || !cfg.GapDetection {
+		return nil
+	}
+	t := &replayTracker{
+		field:  cfg.IDField,
+		header: header,
+	}
+	if cfg.Backfill != nil {
+		t.backfill = replay.NewBackfiller(cfg.Backfill, nil)
+	}
+	return t
+}
+
+// checkReplay observes the replay ID of event and publishes a warning event
+// for any gap since the previous replay ID, followed by the backfilled
+// events if backfill is configured.
+func (p processor) checkReplay(ctx context.Context, event mapstr.M) error {
+	v, err := event.GetValue(p.replay.field)
+	if err != nil {
+		p.log.Debugw("event has no replay ID", "field", p.replay.field)
+		return nil
+	}
+	id, err := replayID(v)
+	if err != nil {
+		p.metrics.errorsTotal.Inc()
+		p.log.Warnw("invalid replay ID", "field", p.replay.field, "error", err)
+		return nil
+	}
+	gap, ok := p.replay.detector.Observe(id)
+	if !ok {
+		return nil
+	}
+
+	p.log.Warnw("detected gap in received replay IDs", "from", gap.From, "to", gap.To, "count", gap.Count())
+	p.metrics.replayGaps.Inc()
+	p.metrics.replayIDsMissed.Add(uint64(gap.Count()))
+	err = p.pub.Publish(gap.Event(p.ns, time.Now().UTC()), nil)
+	if err != nil {
+		return err
+	}
+	if p.replay.backfill == nil {
+		return nil
+	}
+
+	var header http.Header
+	if p.replay.header != nil {
+		header = p.replay.header()
+	}
+	msgs, err := p.replay.backfill.Fetch(ctx, gap, header)
+	if err != nil {
+		p.metrics.errorsTotal.Inc()
+		p.log.Errorw("failed to backfill missed events", "from", gap.From, "to", gap.To, "error", err)
+		return nil
+	}
+	for _, msg := range msgs {
+		var fields map[string]any
+		err = json.Unmarshal(msg, &fields)
+		if err != nil {
+			p.metrics.errorsTotal.Inc()
+			p.log.Errorw("failed to decode backfilled event", "error", err)
+			continue
+		}
+		err = p.pub.Publish(beat.Event{
+			Timestamp: time.Now(),
+			Fields:    fields,
+		}, nil)
+		if err != nil {
+			return err
+		}
+		p.metrics.replayEventsBackfilled.Add(1)
+	}
+	return nil
+}
+
+// replayID returns v as an integer replay ID.
+func replayID(v any) (int64, error) {
+	switch v := v.(type) {
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case float64:
+		if v != math.Trunc(v) {
+			return 0, fmt.Errorf("replay ID is not an integer: %v", v)
+		}
+		return int64(v), nil
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("unexpected replay ID type: %T", v)
+	}
+}
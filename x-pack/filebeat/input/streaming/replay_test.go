@@ -0,0 +1,92 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package streaming
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/x-pack/filebeat/input/internal/replay"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func TestCheckReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`[{"seq":2},{"seq":3}]`))
+	}))
+	defer srv.Close()
+
+	cfg := &replayConfig{
+		Config: replay.Config{
+			GapDetection: true,
+			Backfill:     &replay.BackfillConfig{URL: srv.URL},
+		},
+		IDField: "meta.seq",
+	}
+	require.NoError(t, cfg.Backfill.Validate())
+
+	log := logp.NewLogger("test")
+	pub := &publisher{done: func() {}}
+	p := processor{
+		ns:      "websocket",
+		pub:     pub,
+		log:     log,
+		metrics: newInputMetrics(monitoring.NewRegistry(), log),
+		replay: newReplayTracker(cfg, func() http.Header {
+			return http.Header{"Authorization": []string{"Bearer token"}}
+		}),
+	}
+
+	ctx := context.Background()
+	require.NoError(t, p.checkReplay(ctx, mapstr.M{"meta": map[string]any{"seq": float64(1)}}))
+	require.NoError(t, p.checkReplay(ctx, mapstr.M{"meta": map[string]any{"seq": "4"}}))
+	require.NoError(t, p.checkReplay(ctx, mapstr.M{"message": "no replay ID"}))
+
+	require.Len(t, pub.published, 3)
+	got, err := pub.published[0].Fields.GetValue("websocket.replay_gap")
+	require.NoError(t, err)
+	assert.Equal(t, mapstr.M{"from": int64(2), "to": int64(3), "count": int64(2)}, got)
+	assert.Equal(t, mapstr.M{"seq": float64(2)}, pub.published[1].Fields)
+	assert.Equal(t, mapstr.M{"seq": float64(3)}, pub.published[2].Fields)
+
+	assert.Equal(t, uint64(1), p.metrics.replayGaps.Get())
+	assert.Equal(t, uint64(2), p.metrics.replayIDsMissed.Get())
+	assert.Equal(t, uint64(2), p.metrics.replayEventsBackfilled.Get())
+}
+
+func TestReplayID(t *testing.T) {
+	for _, tc := range []struct {
+		in      any
+		want    int64
+		wantErr bool
+	}{
+		{in: int64(5), want: 5},
+		{in: 5, want: 5},
+		{in: float64(5), want: 5},
+		{in: "5", want: 5},
+		{in: 5.5, wantErr: true},
+		{in: "five", wantErr: true},
+		{in: true, wantErr: true},
+	} {
+		got, err := replayID(tc.in)
+		if tc.wantErr {
+			assert.Error(t, err, "%v", tc.in)
+			continue
+		}
+		assert.NoError(t, err, "%v", tc.in)
+		assert.Equal(t, tc.want, got)
+	}
+}
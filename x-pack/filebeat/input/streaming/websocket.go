@@ -138,6 +138,7 @@ func NewWebsocketFollower(ctx context.Context, env v2.Context, cfg config, curso
 		// the token expiry handler will never trigger unless a valid expiry time is assigned
 		tokenExpiry: nil,
 	}
+	s.replay = newReplayTracker(cfg.Replay, func() http.Header { return formHeader(s.cfg) })
 	s.metrics.url.Set(cfg.URL.String())
 	s.metrics.errorsTotal.Set(0)
 	// initialize the oauth2 token source if oauth2 is enabled and set access token in the config
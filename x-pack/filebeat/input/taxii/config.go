@@ -0,0 +1,133 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package taxii
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+// Input modes.
+const (
+	modePoll = "poll"
+	modePush = "push"
+)
+
+type config struct {
+	Mode string     `config:"mode"`
+	Poll pollConfig `config:"poll"`
+	Push pushConfig `config:"push"`
+}
+
+// pollConfig is the configuration of the TAXII 2.1 client polling the
+// collections of an API root.
+type pollConfig struct {
+	// URL is the URL of the API root, for example
+	// https://taxii.example.com/api1/.
+	URL string `config:"url"`
+
+	// Collections are the IDs of the collections to poll. When empty, all
+	// the collections the client can read are discovered on each poll.
+	Collections []string `config:"collections"`
+
+	// Types are the STIX object types to request.
+	Types []string `config:"types"`
+
+	Auth            authConfig    `config:"auth"`
+	Interval        time.Duration `config:"interval" validate:"positive"`
+	InitialInterval time.Duration `config:"initial_interval" validate:"positive"`
+	Limit           int           `config:"limit" validate:"positive"`
+
+	Transport httpcommon.HTTPTransportSettings `config:",inline"`
+}
+
+// pushConfig is the configuration of the TAXII 2.1 server that receives the
+// objects added to its collections by a TAXII client.
+type pushConfig struct {
+	ListenAddress string                  `config:"listen_address"`
+	ListenPort    string                  `config:"listen_port"`
+	Path          string                  `config:"path"`
+	TLS           *tlscommon.ServerConfig `config:"ssl"`
+	Auth          authConfig              `config:"auth"`
+	MaxBodySize   int64                   `config:"max_body_bytes" validate:"positive"`
+}
+
+// authConfig holds the credentials of a TAXII request: either HTTP basic
+// authentication or a bearer token.
+type authConfig struct {
+	Username string `config:"username"`
+	Password string `config:"password"`
+	Token    string `config:"token"`
+}
+
+func defaultConfig() config {
+	return config{
+		Mode: modePoll,
+		Poll: pollConfig{
+			Types:           []string{"indicator"},
+			Interval:        time.Hour,
+			InitialInterval: 24 * time.Hour,
+			Limit:           1000,
+			Transport:       httpcommon.DefaultHTTPTransportSettings(),
+		},
+		Push: pushConfig{
+			ListenAddress: "localhost",
+			ListenPort:    "8080",
+			Path:          "/taxii2/api/",
+			MaxBodySize:   10 << 20,
+		},
+	}
+}
+
+func (c *config) Validate() error {
+	switch c.Mode {
+	case modePoll:
+		u, err := url.Parse(c.Poll.URL)
+		if err != nil {
+			return fmt.Errorf("invalid poll.url: %w", err)
+		}
+		if u.Scheme != "https" && u.Scheme != "http" {
+			return fmt.Errorf("poll.url must be an http or https URL: %q", c.Poll.URL)
+		}
+		if err := c.Poll.Auth.validate(); err != nil {
+			return fmt.Errorf("poll.auth: %w", err)
+		}
+	case modePush:
+		if !strings.HasPrefix(c.Push.Path, "/") || !strings.HasSuffix(c.Push.Path, "/") {
+			return fmt.Errorf("push.path must start and end with '/': %q", c.Push.Path)
+		}
+		if err := c.Push.Auth.validate(); err != nil {
+			return fmt.Errorf("push.auth: %w", err)
+		}
+	default:
+		return fmt.Errorf("mode must be poll or push: %q", c.Mode)
+	}
+	return nil
+}
+
+func (a *authConfig) validate() error {
+	if (a.Username == "") != (a.Password == "") {
+		return errors.New("both username and password must be set")
+	}
+	if a.Username != "" && a.Token != "" {
+		return errors.New("username and token can not be used together")
+	}
+	return nil
+}
+
+// apiRoot returns the API root URL with a trailing slash, as the TAXII
+// endpoints are relative to it.
+func (c *pollConfig) apiRoot() string {
+	if strings.HasSuffix(c.URL, "/") {
+		return c.URL
+	}
+	return c.URL + "/"
+}
@@ -0,0 +1,31 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package taxii
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// newEvent returns the event of a STIX object. The object is kept as the
+// message so the ingest pipeline can map it.
+func newEvent(obj json.RawMessage, col collection, now time.Time) beat.Event {
+	c := mapstr.M{"id": col.ID}
+	if col.Title != "" {
+		c["title"] = col.Title
+	}
+	return beat.Event{
+		Timestamp: now.UTC(),
+		Fields: mapstr.M{
+			"message": string(obj),
+			"taxii": mapstr.M{
+				"collection": c,
+			},
+		},
+	}
+}
@@ -0,0 +1,231 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package taxii
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	v2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	"github.com/elastic/beats/v7/libbeat/statestore"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport/httpcommon"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+	"github.com/elastic/go-concert/ctxtool"
+	"github.com/elastic/go-concert/timed"
+)
+
+const inputName = "taxii"
+
+func Plugin(log *logp.Logger, store statestore.States) v2.Plugin {
+	return v2.Plugin{
+		Name:      inputName,
+		Stability: feature.Beta,
+		Info:      "Collect STIX objects from TAXII 2.1 servers",
+		Doc:       "Poll TAXII 2.1 collections or receive the objects a TAXII 2.1 client adds to them",
+		Manager: &cursor.InputManager{
+			Logger:     log,
+			StateStore: store,
+			Type:       inputName,
+			Configure:  configure,
+		},
+	}
+}
+
+func configure(cfg *conf.C, _ *logp.Logger) ([]cursor.Source, cursor.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, nil, fmt.Errorf("reading config: %w", err)
+	}
+
+	var src source
+	switch config.Mode {
+	case modePoll:
+		src.name = config.Poll.apiRoot()
+	case modePush:
+		src.name = modePush + "::" + net.JoinHostPort(config.Push.ListenAddress, config.Push.ListenPort) + config.Push.Path
+	}
+	return []cursor.Source{&src}, &taxiiInput{config: config}, nil
+}
+
+// source is the API root polled by the input, or the address it receives
+// objects on in push mode.
+type source struct {
+	name string
+}
+
+func (s *source) Name() string { return s.name }
+
+type taxiiInput struct {
+	config config
+}
+
+func (inp *taxiiInput) Name() string { return inputName }
+
+func (inp *taxiiInput) Test(_ cursor.Source, ctx v2.TestContext) error {
+	if inp.config.Mode == modePush {
+		l, err := net.Listen("tcp", net.JoinHostPort(inp.config.Push.ListenAddress, inp.config.Push.ListenPort))
+		if err != nil {
+			return err
+		}
+		return l.Close()
+	}
+	c, err := inp.newPollClient(ctx.Logger)
+	if err != nil {
+		return err
+	}
+	_, err = c.collections(ctxtool.FromCanceller(ctx.Cancelation), inp.config.Poll.Collections)
+	return err
+}
+
+func (inp *taxiiInput) Run(env v2.Context, _ cursor.Source, crsr cursor.Cursor, pub cursor.Publisher) error {
+	env.UpdateStatus(status.Starting, "")
+	if inp.config.Mode == modePush {
+		return inp.runPush(env, pub)
+	}
+	return inp.runPoll(env, crsr, pub)
+}
+
+func (inp *taxiiInput) newPollClient(log *logp.Logger) (*pollClient, error) {
+	httpClient, err := inp.config.Poll.Transport.Client(httpcommon.WithAPMHTTPInstrumentation(), httpcommon.WithLogger(log))
+	if err != nil {
+		return nil, err
+	}
+	return &pollClient{
+		http:    httpClient,
+		apiRoot: inp.config.Poll.apiRoot(),
+		auth:    inp.config.Poll.Auth,
+		types:   inp.config.Poll.Types,
+		limit:   inp.config.Poll.Limit,
+		log:     log,
+	}, nil
+}
+
+func (inp *taxiiInput) runPoll(env v2.Context, crsr cursor.Cursor, pub cursor.Publisher) error {
+	log := env.Logger.With("api_root", inp.config.Poll.apiRoot())
+	ctx := ctxtool.FromCanceller(env.Cancelation)
+
+	env.UpdateStatus(status.Configuring, "")
+	var cp checkpoint
+	if !crsr.IsNew() {
+		if err := crsr.Unpack(&cp); err != nil {
+			env.UpdateStatus(status.Failed, "failed to read checkpoint: "+err.Error())
+			return fmt.Errorf("failed to read checkpoint: %w", err)
+		}
+	}
+	c, err := inp.newPollClient(log)
+	if err != nil {
+		env.UpdateStatus(status.Failed, "failed to configure client: "+err.Error())
+		return err
+	}
+	initial := time.Now().Add(-inp.config.Poll.InitialInterval).UTC().Format(time.RFC3339Nano)
+
+	env.UpdateStatus(status.Running, "")
+	for {
+		err := inp.pollOnce(ctx, c, &cp, initial, pub, log)
+		switch {
+		case errors.Is(err, context.Canceled):
+			return nil
+		case err != nil:
+			log.Errorw("failed to poll collections", "error", err)
+			env.UpdateStatus(status.Degraded, err.Error())
+		default:
+			env.UpdateStatus(status.Running, "")
+		}
+
+		if err := timed.Wait(env.Cancelation, inp.config.Poll.Interval); err != nil {
+			return nil
+		}
+	}
+}
+
+// pollOnce polls every collection once. A collection that fails is retried
+// on the next poll without blocking the others.
+func (inp *taxiiInput) pollOnce(ctx context.Context, c *pollClient, cp *checkpoint, initial string, pub cursor.Publisher, log *logp.Logger) error {
+	cols, err := c.collections(ctx, inp.config.Poll.Collections)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, col := range cols {
+		n, err := c.poll(ctx, col, cp, initial, pub)
+		if err != nil {
+			if errors.Is(err, context.Canceled) {
+				return err
+			}
+			errs = append(errs, err)
+			continue
+		}
+		log.Debugw("polled collection", "collection", col.ID, "published", n, "added_after", cp.AddedAfter[col.ID])
+	}
+	return errors.Join(errs...)
+}
+
+func (inp *taxiiInput) runPush(env v2.Context, pub cursor.Publisher) error {
+	cfg := inp.config.Push
+	addr := net.JoinHostPort(cfg.ListenAddress, cfg.ListenPort)
+
+	env.UpdateStatus(status.Configuring, "")
+	var tlsConfig *tls.Config
+	tlsConfigBuilder, err := tlscommon.LoadTLSServerConfig(cfg.TLS, env.Logger)
+	if err != nil {
+		env.UpdateStatus(status.Failed, "failed to configure TLS: "+err.Error())
+		return err
+	}
+	if tlsConfigBuilder != nil {
+		tlsConfig = tlsConfigBuilder.BuildServerConfig(addr)
+	}
+
+	srv := &http.Server{
+		Addr: addr,
+		Handler: &pushHandler{
+			path:        cfg.Path,
+			auth:        cfg.Auth,
+			maxBodySize: cfg.MaxBodySize,
+			publish: func(e beat.Event) {
+				if err := pub.Publish(e, nil); err != nil {
+					env.Logger.Errorw("failed to publish event", "error", err)
+				}
+			},
+			log: env.Logger,
+			now: time.Now,
+		},
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	go func() {
+		<-env.Cancelation.Done()
+		env.UpdateStatus(status.Stopping, "")
+		srv.Close()
+	}()
+
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		err := fmt.Errorf("failed to listen on %s: %w", addr, err)
+		env.UpdateStatus(status.Failed, err.Error())
+		return err
+	}
+	env.UpdateStatus(status.Running, "")
+	if tlsConfig != nil {
+		err = srv.ServeTLS(l, "", "")
+	} else {
+		err = srv.Serve(l)
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	env.UpdateStatus(status.Failed, err.Error())
+	return err
+}
@@ -0,0 +1,188 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package taxii
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	cursor "github.com/elastic/beats/v7/filebeat/input/v2/input-cursor"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	mediaType = "application/taxii+json;version=2.1"
+
+	// dateAddedLastHeader holds the date the last object of a page was
+	// added to the collection. It is the added_after value of the next poll.
+	dateAddedLastHeader = "X-TAXII-Date-Added-Last"
+)
+
+// checkpoint is the persisted cursor of an API root. It holds the
+// added_after value of each collection.
+type checkpoint struct {
+	AddedAfter map[string]string `json:"added_after" struct:"added_after"`
+}
+
+type collection struct {
+	ID      string `json:"id"`
+	Title   string `json:"title"`
+	CanRead bool   `json:"can_read"`
+}
+
+type collectionsResource struct {
+	Collections []collection `json:"collections"`
+}
+
+type envelope struct {
+	More    bool              `json:"more"`
+	Next    string            `json:"next"`
+	Objects []json.RawMessage `json:"objects"`
+}
+
+// pollClient is a TAXII 2.1 client reading the collections of an API root.
+type pollClient struct {
+	http    *http.Client
+	apiRoot string
+	auth    authConfig
+	types   []string
+	limit   int
+	log     *logp.Logger
+}
+
+// collections returns the collections to poll: the configured ones, or the
+// readable collections of the API root.
+func (c *pollClient) collections(ctx context.Context, ids []string) ([]collection, error) {
+	if len(ids) != 0 {
+		out := make([]collection, 0, len(ids))
+		for _, id := range ids {
+			out = append(out, collection{ID: id, CanRead: true})
+		}
+		return out, nil
+	}
+
+	var res collectionsResource
+	if _, err := c.get(ctx, c.apiRoot+"collections/", &res); err != nil {
+		return nil, fmt.Errorf("failed to discover collections: %w", err)
+	}
+	out := res.Collections[:0]
+	for _, col := range res.Collections {
+		if col.CanRead {
+			out = append(out, col)
+		}
+	}
+	return out, nil
+}
+
+// poll publishes the objects added to col after its checkpoint, or after
+// initial if it has none. The checkpoint of the collection is updated with the
+// last object of each page. The map of cp is replaced rather than modified as
+// the published cursor updates are applied once their events are acknowledged.
+func (c *pollClient) poll(ctx context.Context, col collection, cp *checkpoint, initial string, pub cursor.Publisher) (int, error) {
+	u := c.apiRoot + "collections/" + url.PathEscape(col.ID) + "/objects/"
+	addedAfter, ok := cp.AddedAfter[col.ID]
+	if !ok {
+		addedAfter = initial
+	}
+	var (
+		n    int
+		next string
+	)
+	for {
+		q := url.Values{"limit": []string{strconv.Itoa(c.limit)}}
+		if addedAfter != "" {
+			q.Set("added_after", addedAfter)
+		}
+		if len(c.types) != 0 {
+			q.Set("match[type]", strings.Join(c.types, ","))
+		}
+		if next != "" {
+			q.Set("next", next)
+		}
+		var env envelope
+		h, err := c.get(ctx, u+"?"+q.Encode(), &env)
+		if err != nil {
+			return n, fmt.Errorf("failed to get objects of collection %s: %w", col.ID, err)
+		}
+
+		last := h.Get(dateAddedLastHeader)
+		for i, obj := range env.Objects {
+			var update any
+			if i == len(env.Objects)-1 && last != "" {
+				cp.AddedAfter = maps.Clone(cp.AddedAfter)
+				if cp.AddedAfter == nil {
+					cp.AddedAfter = map[string]string{}
+				}
+				cp.AddedAfter[col.ID] = last
+				update = *cp
+			}
+			if err := pub.Publish(newEvent(obj, col, time.Now()), update); err != nil {
+				return n, err
+			}
+			n++
+		}
+
+		if !env.More || len(env.Objects) == 0 {
+			return n, nil
+		}
+		if env.Next != "" {
+			next = env.Next
+			continue
+		}
+		// Servers that do not support next are paged with added_after. The
+		// next parameter of a previous page does not apply to the new
+		// added_after value.
+		if last == "" || last == addedAfter {
+			return n, fmt.Errorf("collection %s has more objects but the server returned no next parameter or %s header", col.ID, dateAddedLastHeader)
+		}
+		addedAfter = last
+		next = ""
+	}
+}
+
+func (c *pollClient) get(ctx context.Context, u string, dst any) (http.Header, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", mediaType)
+	switch {
+	case c.auth.Username != "":
+		req.SetBasicAuth(c.auth.Username, c.auth.Password)
+	case c.auth.Token != "":
+		req.Header.Set("Authorization", "Bearer "+c.auth.Token)
+	}
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case resp.StatusCode == http.StatusNoContent:
+		// Some servers answer with no content when nothing matches.
+		return resp.Header, nil
+	case resp.StatusCode/100 != 2:
+		return nil, fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+	if len(b) == 0 {
+		return resp.Header, nil
+	}
+	if err := json.Unmarshal(b, dst); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return resp.Header, nil
+}
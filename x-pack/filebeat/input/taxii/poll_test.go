@@ -0,0 +1,197 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package taxii
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+type publishedEvent struct {
+	event  beat.Event
+	update any
+}
+
+type testPublisher struct {
+	events []publishedEvent
+}
+
+func (p *testPublisher) Publish(event beat.Event, update any) error {
+	p.events = append(p.events, publishedEvent{event: event, update: update})
+	return nil
+}
+
+func indicator(n int) string {
+	return fmt.Sprintf(`{"type":"indicator","spec_version":"2.1","id":"indicator--%d","pattern":"[ipv4-addr:value = '10.0.0.%d']"}`, n, n)
+}
+
+func TestPoll(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api1/collections/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, mediaType, r.Header.Get("Accept"))
+		fmt.Fprint(w, `{"collections":[{"id":"readable","title":"Readable","can_read":true},{"id":"private","can_read":false}]}`)
+	})
+	// The collection is paged with next.
+	mux.HandleFunc("GET /api1/collections/readable/objects/", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		assert.Equal(t, "2", q.Get("limit"))
+		assert.Equal(t, "indicator", q.Get("match[type]"))
+		assert.Equal(t, "2026-10-01T00:00:00Z", q.Get("added_after"))
+		switch q.Get("next") {
+		case "":
+			w.Header().Set(dateAddedLastHeader, "2026-10-02T00:00:00Z")
+			fmt.Fprintf(w, `{"more":true,"next":"page2","objects":[%s,%s]}`, indicator(1), indicator(2))
+		case "page2":
+			w.Header().Set(dateAddedLastHeader, "2026-10-03T00:00:00Z")
+			fmt.Fprintf(w, `{"more":false,"objects":[%s]}`, indicator(3))
+		default:
+			t.Errorf("unexpected next %q", q.Get("next"))
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := &pollClient{
+		http:    srv.Client(),
+		apiRoot: srv.URL + "/api1/",
+		auth:    authConfig{Token: "secret"},
+		types:   []string{"indicator"},
+		limit:   2,
+		log:     logp.NewLogger(inputName),
+	}
+	ctx := context.Background()
+	cols, err := c.collections(ctx, nil)
+	require.NoError(t, err)
+	require.Equal(t, []collection{{ID: "readable", Title: "Readable", CanRead: true}}, cols)
+
+	var (
+		cp  checkpoint
+		pub testPublisher
+	)
+	n, err := c.poll(ctx, cols[0], &cp, "2026-10-01T00:00:00Z", &pub)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	require.Len(t, pub.events, 3)
+
+	assert.Equal(t, indicator(1), pub.events[0].event.Fields["message"])
+	title, _ := pub.events[0].event.Fields.GetValue("taxii.collection.title")
+	assert.Equal(t, "Readable", title)
+
+	assert.Nil(t, pub.events[0].update)
+	assert.Equal(t, checkpoint{AddedAfter: map[string]string{"readable": "2026-10-02T00:00:00Z"}}, pub.events[1].update)
+	assert.Equal(t, checkpoint{AddedAfter: map[string]string{"readable": "2026-10-03T00:00:00Z"}}, pub.events[2].update)
+	assert.Equal(t, "2026-10-03T00:00:00Z", cp.AddedAfter["readable"])
+}
+
+func TestPollAddedAfterPaging(t *testing.T) {
+	// The server stops returning next after the first page, so the
+	// collection is paged with the date of the last object of each page,
+	// without the next parameter of the first page.
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /collections/c1/objects/", func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "user", user)
+		assert.Equal(t, "pass", pass)
+		q := r.URL.Query()
+		switch q.Get("added_after") + " " + q.Get("next") {
+		case "2026-10-05T00:00:00Z ":
+			w.Header().Set(dateAddedLastHeader, "2026-10-06T00:00:00Z")
+			fmt.Fprintf(w, `{"more":true,"next":"p2","objects":[%s]}`, indicator(1))
+		case "2026-10-05T00:00:00Z p2":
+			w.Header().Set(dateAddedLastHeader, "2026-10-06T12:00:00Z")
+			fmt.Fprintf(w, `{"more":true,"objects":[%s]}`, indicator(2))
+		case "2026-10-06T12:00:00Z ":
+			w.Header().Set(dateAddedLastHeader, "2026-10-07T00:00:00Z")
+			fmt.Fprintf(w, `{"more":false,"objects":[%s]}`, indicator(3))
+		default:
+			t.Errorf("unexpected added_after %q and next %q", q.Get("added_after"), q.Get("next"))
+		}
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	c := &pollClient{
+		http:    srv.Client(),
+		apiRoot: srv.URL + "/",
+		auth:    authConfig{Username: "user", Password: "pass"},
+		limit:   1,
+		log:     logp.NewLogger(inputName),
+	}
+	cp := checkpoint{AddedAfter: map[string]string{"c1": "2026-10-05T00:00:00Z"}}
+	published := cp.AddedAfter
+	var pub testPublisher
+	n, err := c.poll(context.Background(), collection{ID: "c1"}, &cp, "2026-01-01T00:00:00Z", &pub)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, "2026-10-07T00:00:00Z", cp.AddedAfter["c1"])
+	assert.Equal(t, "2026-10-05T00:00:00Z", published["c1"], "the previous checkpoint must not be modified")
+}
+
+func TestConfigValidate(t *testing.T) {
+	testCases := map[string]struct {
+		mutate  func(*config)
+		wantErr string
+	}{
+		"poll": {
+			mutate: func(c *config) { c.Poll.URL = "https://taxii.example.com/api1/" },
+		},
+		"poll without url": {
+			mutate:  func(*config) {},
+			wantErr: `poll.url must be an http or https URL: ""`,
+		},
+		"basic and token": {
+			mutate: func(c *config) {
+				c.Poll.URL = "https://taxii.example.com/api1/"
+				c.Poll.Auth = authConfig{Username: "u", Password: "p", Token: "t"}
+			},
+			wantErr: "poll.auth: username and token can not be used together",
+		},
+		"push": {
+			mutate: func(c *config) { c.Mode = modePush },
+		},
+		"push path": {
+			mutate: func(c *config) {
+				c.Mode = modePush
+				c.Push.Path = "/taxii2"
+			},
+			wantErr: `push.path must start and end with '/': "/taxii2"`,
+		},
+		"unknown mode": {
+			mutate:  func(c *config) { c.Mode = "stream" },
+			wantErr: `mode must be poll or push: "stream"`,
+		},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := defaultConfig()
+			tc.mutate(&c)
+			err := c.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestAPIRoot(t *testing.T) {
+	for _, u := range []string{"https://taxii.example.com/api1", "https://taxii.example.com/api1/"} {
+		c := pollConfig{URL: u}
+		assert.True(t, strings.HasSuffix(c.apiRoot(), "/api1/"), u)
+	}
+}
@@ -0,0 +1,146 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package taxii
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// statusResource is the TAXII 2.1 status of an add objects request.
+type statusResource struct {
+	ID               string `json:"id"`
+	Status           string `json:"status"`
+	RequestTimestamp string `json:"request_timestamp"`
+	TotalCount       int    `json:"total_count"`
+	SuccessCount     int    `json:"success_count"`
+	FailureCount     int    `json:"failure_count"`
+	PendingCount     int    `json:"pending_count"`
+}
+
+type taxiiError struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	HTTPStatus  string `json:"http_status"`
+}
+
+// pushHandler implements the add objects endpoint of a TAXII 2.1 API root,
+// {path}collections/{id}/objects/. Every added object is published.
+type pushHandler struct {
+	path        string
+	auth        authConfig
+	maxBodySize int64
+	publish     func(beat.Event)
+	log         *logp.Logger
+	now         func() time.Time
+}
+
+func (h *pushHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Basic")
+		h.sendError(w, http.StatusUnauthorized, "invalid or missing credentials")
+		return
+	}
+
+	id, ok := h.collectionID(r.URL.Path)
+	if !ok {
+		h.sendError(w, http.StatusNotFound, "unknown endpoint")
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		h.sendError(w, http.StatusMethodNotAllowed, "only adding objects is supported")
+		return
+	}
+	if ct := r.Header.Get("Content-Type"); ct != "" && !strings.HasPrefix(ct, "application/taxii+json") {
+		h.sendError(w, http.StatusUnsupportedMediaType, "content type must be "+mediaType)
+		return
+	}
+
+	requested := h.now().UTC()
+	var env envelope
+	err := json.NewDecoder(http.MaxBytesReader(w, r.Body, h.maxBodySize)).Decode(&env)
+	if err != nil {
+		code := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			code = http.StatusRequestEntityTooLarge
+		}
+		h.sendError(w, code, "invalid envelope: "+err.Error())
+		return
+	}
+
+	col := collection{ID: id}
+	for _, obj := range env.Objects {
+		h.publish(newEvent(obj, col, requested))
+	}
+	h.log.Debugw("objects added", "collection", id, "count", len(env.Objects))
+
+	statusID, err := uuid.NewV4()
+	if err != nil {
+		h.sendError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	h.send(w, http.StatusAccepted, statusResource{
+		ID:               statusID.String(),
+		Status:           "complete",
+		RequestTimestamp: requested.Format(time.RFC3339Nano),
+		TotalCount:       len(env.Objects),
+		SuccessCount:     len(env.Objects),
+	})
+}
+
+// collectionID returns the collection of an add objects request path.
+func (h *pushHandler) collectionID(path string) (string, bool) {
+	rest, ok := strings.CutPrefix(path, h.path+"collections/")
+	if !ok {
+		return "", false
+	}
+	id, ok := strings.CutSuffix(rest, "/objects/")
+	if !ok || id == "" || strings.Contains(id, "/") {
+		return "", false
+	}
+	return id, true
+}
+
+func (h *pushHandler) authorized(r *http.Request) bool {
+	switch {
+	case h.auth.Username != "":
+		user, pass, ok := r.BasicAuth()
+		return ok &&
+			subtle.ConstantTimeCompare([]byte(user), []byte(h.auth.Username)) == 1 &&
+			subtle.ConstantTimeCompare([]byte(pass), []byte(h.auth.Password)) == 1
+	case h.auth.Token != "":
+		return subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+h.auth.Token)) == 1
+	default:
+		return true
+	}
+}
+
+func (h *pushHandler) sendError(w http.ResponseWriter, code int, msg string) {
+	h.send(w, code, taxiiError{
+		Title:       http.StatusText(code),
+		Description: msg,
+		HTTPStatus:  strconv.Itoa(code),
+	})
+}
+
+func (h *pushHandler) send(w http.ResponseWriter, code int, body any) {
+	w.Header().Set("Content-Type", mediaType)
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		h.log.Errorw("failed to write response", "error", err)
+	}
+}
@@ -0,0 +1,89 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package taxii
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+func TestPushHandler(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	newHandler := func(events *[]beat.Event) *pushHandler {
+		return &pushHandler{
+			path:        "/taxii2/api/",
+			auth:        authConfig{Token: "secret"},
+			maxBodySize: 1 << 20,
+			publish:     func(e beat.Event) { *events = append(*events, e) },
+			log:         logp.NewLogger(inputName),
+			now:         func() time.Time { return now },
+		}
+	}
+
+	t.Run("add objects", func(t *testing.T) {
+		var events []beat.Event
+		body := `{"objects":[` + indicator(1) + `,` + indicator(2) + `]}`
+		req := httptest.NewRequest(http.MethodPost, "/taxii2/api/collections/feed-1/objects/", strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer secret")
+		req.Header.Set("Content-Type", mediaType)
+		rec := httptest.NewRecorder()
+		newHandler(&events).ServeHTTP(rec, req)
+
+		require.Equal(t, http.StatusAccepted, rec.Code)
+		var status statusResource
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		assert.Equal(t, "complete", status.Status)
+		assert.Equal(t, 2, status.TotalCount)
+		assert.Equal(t, 2, status.SuccessCount)
+		assert.Equal(t, "2026-10-16T12:00:00Z", status.RequestTimestamp)
+		assert.NotEmpty(t, status.ID)
+
+		require.Len(t, events, 2)
+		assert.Equal(t, indicator(2), events[1].Fields["message"])
+		id, _ := events[1].Fields.GetValue("taxii.collection.id")
+		assert.Equal(t, "feed-1", id)
+	})
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+		auth   string
+		ctype  string
+		body   string
+		want   int
+	}{
+		{name: "invalid token", method: http.MethodPost, path: "/taxii2/api/collections/feed-1/objects/", auth: "Bearer wrong", body: `{"objects":[]}`, want: http.StatusUnauthorized},
+		{name: "unknown endpoint", method: http.MethodPost, path: "/taxii2/api/collections/", auth: "Bearer secret", body: `{"objects":[]}`, want: http.StatusNotFound},
+		{name: "get objects", method: http.MethodGet, path: "/taxii2/api/collections/feed-1/objects/", auth: "Bearer secret", want: http.StatusMethodNotAllowed},
+		{name: "media type", method: http.MethodPost, path: "/taxii2/api/collections/feed-1/objects/", auth: "Bearer secret", ctype: "text/plain", body: `{"objects":[]}`, want: http.StatusUnsupportedMediaType},
+		{name: "invalid envelope", method: http.MethodPost, path: "/taxii2/api/collections/feed-1/objects/", auth: "Bearer secret", body: `{"objects":`, want: http.StatusBadRequest},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var events []beat.Event
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			req.Header.Set("Authorization", tc.auth)
+			if tc.ctype != "" {
+				req.Header.Set("Content-Type", tc.ctype)
+			}
+			rec := httptest.NewRecorder()
+			newHandler(&events).ServeHTTP(rec, req)
+
+			assert.Equal(t, tc.want, rec.Code)
+			assert.Empty(t, events)
+		})
+	}
+}
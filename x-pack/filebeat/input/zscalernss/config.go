@@ -0,0 +1,134 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package zscalernss
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+// Feed formats.
+const (
+	formatJSON = "json"
+	formatCSV  = "csv"
+	formatTSV  = "tsv"
+)
+
+type config struct {
+	ListenAddress string                  `config:"listen_address"`
+	ListenPort    string                  `config:"listen_port"`
+	TLS           *tlscommon.ServerConfig `config:"ssl"`
+	MaxBodySize   int64                   `config:"max_body_bytes" validate:"positive"`
+	ReadTimeout   time.Duration           `config:"read_timeout" validate:"positive"`
+	Feeds         []feedConfig            `config:"feeds" validate:"required"`
+}
+
+// feedConfig is the configuration of one Cloud NSS feed. Each feed posts to
+// its own path and is authenticated with its own token.
+type feedConfig struct {
+	Name       string   `config:"name" validate:"required"`
+	Path       string   `config:"path" validate:"required"`
+	AuthHeader string   `config:"auth.header"`
+	AuthToken  string   `config:"auth.token" validate:"required"`
+	Format     string   `config:"format"`
+	Fields     []string `config:"fields"`
+	Template   string   `config:"template"`
+}
+
+func defaultConfig() config {
+	return config{
+		ListenAddress: "127.0.0.1",
+		ListenPort:    "9443",
+		MaxBodySize:   10 << 20,
+		ReadTimeout:   30 * time.Second,
+	}
+}
+
+func (c *config) Validate() error {
+	names := make(map[string]bool, len(c.Feeds))
+	paths := make(map[string]bool, len(c.Feeds))
+	for i := range c.Feeds {
+		f := &c.Feeds[i]
+		if names[f.Name] {
+			return fmt.Errorf("duplicate feed name %q", f.Name)
+		}
+		names[f.Name] = true
+		if paths[f.Path] {
+			return fmt.Errorf("feed %q: path %q is used by another feed", f.Name, f.Path)
+		}
+		paths[f.Path] = true
+		if err := f.validate(); err != nil {
+			return fmt.Errorf("feed %q: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// validate checks the feed configuration. It is called by config.Validate so
+// the error names the feed.
+func (f *feedConfig) validate() error {
+	if !strings.HasPrefix(f.Path, "/") {
+		return fmt.Errorf("path must start with '/': %q", f.Path)
+	}
+	switch f.Format {
+	case "", formatJSON, formatCSV, formatTSV:
+	default:
+		return fmt.Errorf("format must be json, csv or tsv: %q", f.Format)
+	}
+	if f.Template != "" && len(f.Fields) != 0 {
+		return errors.New("fields and template can not be used together")
+	}
+	fields := f.fields()
+	if f.Template != "" && len(fields) == 0 {
+		return fmt.Errorf("no fields found in template %q", f.Template)
+	}
+	if f.Format == "" || f.Format == formatJSON {
+		if len(fields) != 0 {
+			return errors.New("fields and template are only used with the csv and tsv formats")
+		}
+		return nil
+	}
+	if len(fields) == 0 {
+		return fmt.Errorf("fields or template is required for the %s format", f.Format)
+	}
+	seen := make(map[string]bool, len(fields))
+	for _, name := range fields {
+		if name == "" {
+			return errors.New("empty field name")
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate field %q", name)
+		}
+		seen[name] = true
+	}
+	return nil
+}
+
+// fields returns the ordered field names of the feed records.
+func (f *feedConfig) fields() []string {
+	if f.Template != "" {
+		return templateFields(f.Template)
+	}
+	return f.Fields
+}
+
+// templateFieldPattern matches the field specifiers of an NSS feed output
+// format, for example %s{login}, %d{reqsize} or %02d{hh}.
+var templateFieldPattern = regexp.MustCompile(`%[-+0-9.]*[a-z]+\{([^}]+)\}`)
+
+// templateFields returns the names of the fields of an NSS feed output format
+// in the order they appear in it.
+func templateFields(template string) []string {
+	var fields []string
+	for _, m := range templateFieldPattern.FindAllStringSubmatch(template, -1) {
+		fields = append(fields, m[1])
+	}
+	return fields
+}
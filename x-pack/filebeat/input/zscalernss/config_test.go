@@ -0,0 +1,121 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package zscalernss
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValidate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		feeds   []feedConfig
+		wantErr string
+	}{
+		{
+			name: "json",
+			feeds: []feedConfig{
+				{Name: "web", Path: "/web", AuthToken: "secret"},
+			},
+		},
+		{
+			name: "csv with fields",
+			feeds: []feedConfig{
+				{Name: "fw", Path: "/fw", AuthToken: "secret", Format: "csv", Fields: []string{"time", "action"}},
+			},
+		},
+		{
+			name: "tsv with template",
+			feeds: []feedConfig{
+				{Name: "dns", Path: "/dns", AuthToken: "secret", Format: "tsv", Template: `%s{time}\t%s{login}\t%s{req}`},
+			},
+		},
+		{
+			name: "duplicate name",
+			feeds: []feedConfig{
+				{Name: "web", Path: "/a", AuthToken: "secret"},
+				{Name: "web", Path: "/b", AuthToken: "secret"},
+			},
+			wantErr: `duplicate feed name "web"`,
+		},
+		{
+			name: "duplicate path",
+			feeds: []feedConfig{
+				{Name: "a", Path: "/web", AuthToken: "secret"},
+				{Name: "b", Path: "/web", AuthToken: "secret"},
+			},
+			wantErr: `feed "b": path "/web" is used by another feed`,
+		},
+		{
+			name: "relative path",
+			feeds: []feedConfig{
+				{Name: "web", Path: "web", AuthToken: "secret"},
+			},
+			wantErr: `feed "web": path must start with '/': "web"`,
+		},
+		{
+			name: "unknown format",
+			feeds: []feedConfig{
+				{Name: "web", Path: "/web", AuthToken: "secret", Format: "xml"},
+			},
+			wantErr: `feed "web": format must be json, csv or tsv: "xml"`,
+		},
+		{
+			name: "csv without fields",
+			feeds: []feedConfig{
+				{Name: "fw", Path: "/fw", AuthToken: "secret", Format: "csv"},
+			},
+			wantErr: `feed "fw": fields or template is required for the csv format`,
+		},
+		{
+			name: "json with fields",
+			feeds: []feedConfig{
+				{Name: "web", Path: "/web", AuthToken: "secret", Fields: []string{"time"}},
+			},
+			wantErr: `feed "web": fields and template are only used with the csv and tsv formats`,
+		},
+		{
+			name: "fields and template",
+			feeds: []feedConfig{
+				{Name: "fw", Path: "/fw", AuthToken: "secret", Format: "csv", Fields: []string{"time"}, Template: "%s{time}"},
+			},
+			wantErr: `feed "fw": fields and template can not be used together`,
+		},
+		{
+			name: "template without fields",
+			feeds: []feedConfig{
+				{Name: "fw", Path: "/fw", AuthToken: "secret", Format: "csv", Template: "time,action"},
+			},
+			wantErr: `feed "fw": no fields found in template "time,action"`,
+		},
+		{
+			name: "duplicate field",
+			feeds: []feedConfig{
+				{Name: "fw", Path: "/fw", AuthToken: "secret", Format: "csv", Fields: []string{"time", "time"}},
+			},
+			wantErr: `feed "fw": duplicate field "time"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := defaultConfig()
+			c.Feeds = tc.feeds
+			err := c.Validate()
+			if tc.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestTemplateFields(t *testing.T) {
+	template := `"%s{time}","%s{login}","%s{proto}","%d{reqsize}","%02d{hh}","%-20s{action}"`
+	assert.Equal(t, []string{"time", "login", "proto", "reqsize", "hh", "action"}, templateFields(template))
+}
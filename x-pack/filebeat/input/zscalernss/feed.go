@@ -0,0 +1,98 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package zscalernss
+
+import (
+	"crypto/subtle"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// feed converts the records pushed by a Cloud NSS feed into events.
+type feed struct {
+	name       string
+	path       string
+	authHeader string
+	authToken  []byte
+	format     string
+	fields     []string
+}
+
+func newFeed(cfg feedConfig) *feed {
+	f := &feed{
+		name:       cfg.Name,
+		path:       cfg.Path,
+		authHeader: cfg.AuthHeader,
+		authToken:  []byte(cfg.AuthToken),
+		format:     cfg.Format,
+		fields:     cfg.fields(),
+	}
+	if f.authHeader == "" {
+		f.authHeader = "Authorization"
+	}
+	if f.format == "" {
+		f.format = formatJSON
+	}
+	return f
+}
+
+// authorized returns whether the request carries the token of the feed.
+func (f *feed) authorized(r *http.Request) bool {
+	return subtle.ConstantTimeCompare([]byte(r.Header.Get(f.authHeader)), f.authToken) == 1
+}
+
+// parse decodes one record according to the format of the feed.
+func (f *feed) parse(record string) (mapstr.M, error) {
+	if f.format == formatJSON {
+		var m mapstr.M
+		if err := json.Unmarshal([]byte(record), &m); err != nil {
+			return nil, fmt.Errorf("failed to decode JSON record: %w", err)
+		}
+		return m, nil
+	}
+
+	r := csv.NewReader(strings.NewReader(record))
+	if f.format == formatTSV {
+		r.Comma = '\t'
+	}
+	r.LazyQuotes = true
+	r.FieldsPerRecord = -1
+	values, err := r.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s record: %w", f.format, err)
+	}
+	if len(values) != len(f.fields) {
+		return nil, fmt.Errorf("record has %d values but the feed has %d fields", len(values), len(f.fields))
+	}
+	m := make(mapstr.M, len(values))
+	for i, v := range values {
+		m[f.fields[i]] = v
+	}
+	return m, nil
+}
+
+// event returns the event for a record. Records that can not be parsed are
+// still published with the error so they are not lost.
+func (f *feed) event(record string, now time.Time) beat.Event {
+	nss := mapstr.M{"feed": f.name}
+	fields := mapstr.M{
+		"message":     record,
+		"zscaler_nss": nss,
+	}
+	parsed, err := f.parse(record)
+	if err != nil {
+		fields["error"] = mapstr.M{"message": err.Error()}
+	} else {
+		nss["event"] = parsed
+	}
+	return beat.Event{Timestamp: now, Fields: fields}
+}
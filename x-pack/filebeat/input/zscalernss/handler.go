@@ -0,0 +1,113 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package zscalernss
+
+import (
+	"bufio"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+// handler receives the HTTPS pushes of one Cloud NSS feed. Each request body
+// holds newline delimited records formatted with the feed output format.
+type handler struct {
+	feed        *feed
+	log         *logp.Logger
+	metrics     *inputMetrics
+	publish     func(beat.Event)
+	maxBodySize int64
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.metrics.requestsTotal.Inc()
+	if !h.feed.authorized(r) {
+		h.metrics.requestsUnauthorizedTotal.Inc()
+		h.log.Debugw("rejected request with invalid token", "feed", h.feed.name, "remote_addr", r.RemoteAddr)
+		h.sendResponse(w, http.StatusUnauthorized, "invalid or missing token")
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPost:
+	case http.MethodGet, http.MethodHead:
+		// Cloud NSS checks the connectivity of a feed before it is saved.
+		h.sendResponse(w, http.StatusOK, "")
+		return
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		h.sendResponse(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	// The events are only published once the whole body is read, so that
+	// a request that fails is resent by Cloud NSS without duplicating the
+	// events of its first records.
+	events, err := h.readRecords(w, r)
+	h.metrics.recordsReceivedTotal.Add(uint64(len(events)))
+	if err != nil {
+		h.metrics.requestErrorsTotal.Inc()
+		h.log.Errorw("failed to read Cloud NSS request", "feed", h.feed.name, "records", len(events), "error", err)
+		code := http.StatusBadRequest
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) || errors.Is(err, bufio.ErrTooLong) {
+			code = http.StatusRequestEntityTooLarge
+		}
+		h.sendResponse(w, code, err.Error())
+		return
+	}
+	for _, evt := range events {
+		if _, ok := evt.Fields["error"]; ok {
+			h.metrics.recordErrorsTotal.Inc()
+		}
+		h.publish(evt)
+	}
+	h.sendResponse(w, http.StatusOK, "")
+}
+
+// readRecords returns one event per record of the request body. The events
+// of the records read before an error are returned with the error.
+func (h *handler) readRecords(w http.ResponseWriter, r *http.Request) ([]beat.Event, error) {
+	var body io.Reader = http.MaxBytesReader(w, r.Body, h.maxBodySize)
+	if strings.EqualFold(r.Header.Get("Content-Encoding"), "gzip") {
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gzip body: %w", err)
+		}
+		defer gz.Close()
+		body = http.MaxBytesReader(w, gz, h.maxBodySize)
+	}
+
+	sc := bufio.NewScanner(body)
+	sc.Buffer(nil, int(h.maxBodySize))
+	now := time.Now().UTC()
+	var events []beat.Event
+	for sc.Scan() {
+		record := strings.TrimSpace(sc.Text())
+		if record == "" {
+			continue
+		}
+		events = append(events, h.feed.event(record, now))
+	}
+	return events, sc.Err()
+}
+
+func (h *handler) sendResponse(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if msg == "" {
+		msg = http.StatusText(code)
+	}
+	if _, err := fmt.Fprintf(w, `{"message":%q}`, msg); err != nil {
+		h.log.Errorw("failed to write response", "feed", h.feed.name, "error", err)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package zscalernss
+
+import (
+	"bytes"
+	"compress/gzip"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+func newTestMux(t *testing.T, maxBodySize int64, feeds ...feedConfig) (http.Handler, *[]beat.Event) {
+	t.Helper()
+	c := defaultConfig()
+	c.Feeds = feeds
+	if maxBodySize != 0 {
+		c.MaxBodySize = maxBodySize
+	}
+	require.NoError(t, c.Validate())
+
+	var events []beat.Event
+	in := &nssInput{config: c}
+	mux := in.newMux(logp.NewLogger(inputName), newInputMetrics(monitoring.NewRegistry()), func(e beat.Event) {
+		events = append(events, e)
+	})
+	return mux, &events
+}
+
+func TestHandler(t *testing.T) {
+	feeds := []feedConfig{
+		{Name: "web", Path: "/web", AuthToken: "web-token"},
+		{Name: "fw", Path: "/fw", AuthHeader: "X-NSS-Token", AuthToken: "fw-token", Format: "csv", Template: `"%s{time}","%s{action}","%d{dport}"`},
+		{Name: "dns", Path: "/dns", AuthToken: "dns-token", Format: "tsv", Fields: []string{"time", "login", "req"}},
+	}
+
+	t.Run("json", func(t *testing.T) {
+		mux, events := newTestMux(t, 0, feeds...)
+		body := `{"sourcetype":"zscalernss-web","event":{"action":"Allowed","url":"example.com"}}` + "\n\n" +
+			`{"sourcetype":"zscalernss-web","event":{"action":"Blocked","url":"example.org"}}` + "\n"
+		req := httptest.NewRequest(http.MethodPost, "/web", strings.NewReader(body))
+		req.Header.Set("Authorization", "web-token")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.Len(t, *events, 2)
+		action, err := (*events)[1].Fields.GetValue("zscaler_nss.event.event.action")
+		require.NoError(t, err)
+		assert.Equal(t, "Blocked", action)
+		feed, _ := (*events)[1].Fields.GetValue("zscaler_nss.feed")
+		assert.Equal(t, "web", feed)
+	})
+
+	t.Run("csv template", func(t *testing.T) {
+		mux, events := newTestMux(t, 0, feeds...)
+		body := `"Mon Oct 16 10:00:00 2026","Block","443"` + "\n" + `"Mon Oct 16 10:00:01 2026","Allow"` + "\n"
+		req := httptest.NewRequest(http.MethodPost, "/fw", strings.NewReader(body))
+		req.Header.Set("X-NSS-Token", "fw-token")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.Len(t, *events, 2)
+		assert.Equal(t, mapstr.M{
+			"time":   "Mon Oct 16 10:00:00 2026",
+			"action": "Block",
+			"dport":  "443",
+		}, (*events)[0].Fields["zscaler_nss"].(mapstr.M)["event"])
+		msg, _ := (*events)[1].Fields.GetValue("error.message")
+		assert.Equal(t, "record has 2 values but the feed has 3 fields", msg)
+		assert.Equal(t, `"Mon Oct 16 10:00:01 2026","Allow"`, (*events)[1].Fields["message"])
+	})
+
+	t.Run("gzip tsv", func(t *testing.T) {
+		mux, events := newTestMux(t, 0, feeds...)
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		_, err := gz.Write([]byte("2026-10-16 10:00:00\tjdoe@example.com\texample.com\n"))
+		require.NoError(t, err)
+		require.NoError(t, gz.Close())
+		req := httptest.NewRequest(http.MethodPost, "/dns", &buf)
+		req.Header.Set("Authorization", "dns-token")
+		req.Header.Set("Content-Encoding", "gzip")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		require.Len(t, *events, 1)
+		login, _ := (*events)[0].Fields.GetValue("zscaler_nss.event.login")
+		assert.Equal(t, "jdoe@example.com", login)
+	})
+
+	t.Run("token of another feed", func(t *testing.T) {
+		mux, events := newTestMux(t, 0, feeds...)
+		req := httptest.NewRequest(http.MethodPost, "/web", strings.NewReader("{}\n"))
+		req.Header.Set("Authorization", "dns-token")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Empty(t, *events)
+	})
+
+	t.Run("connectivity test", func(t *testing.T) {
+		mux, events := newTestMux(t, 0, feeds...)
+		req := httptest.NewRequest(http.MethodGet, "/web", nil)
+		req.Header.Set("Authorization", "web-token")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Empty(t, *events)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		mux, _ := newTestMux(t, 0, feeds...)
+		req := httptest.NewRequest(http.MethodDelete, "/web", nil)
+		req.Header.Set("Authorization", "web-token")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		mux, _ := newTestMux(t, 16, feeds...)
+		req := httptest.NewRequest(http.MethodPost, "/web", strings.NewReader(`{"event":{"action":"Allowed"}}`+"\n"))
+		req.Header.Set("Authorization", "web-token")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+	})
+
+	t.Run("nothing published on error", func(t *testing.T) {
+		mux, events := newTestMux(t, 64, feeds...)
+		body := `{"event":{"action":"Allowed"}}` + "\n" + `{"event":{"action":"Blocked","url":"example.org/a/long/path"}}` + "\n"
+		req := httptest.NewRequest(http.MethodPost, "/web", strings.NewReader(body))
+		req.Header.Set("Authorization", "web-token")
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, rec.Code)
+		assert.Empty(t, *events)
+	})
+}
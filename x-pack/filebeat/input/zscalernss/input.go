@@ -0,0 +1,143 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package zscalernss
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+
+	inputv2 "github.com/elastic/beats/v7/filebeat/input/v2"
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/feature"
+	"github.com/elastic/beats/v7/libbeat/management/status"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/transport/tlscommon"
+)
+
+const (
+	inputName = "zscaler-nss"
+)
+
+func Plugin(log *logp.Logger) inputv2.Plugin {
+	return inputv2.Plugin{
+		Name:      inputName,
+		Stability: feature.Beta,
+		Info:      "Receives Zscaler Cloud NSS feeds pushed over HTTPS.",
+		Manager:   inputv2.ConfigureWith(configure, log),
+	}
+}
+
+func configure(cfg *conf.C, logger *logp.Logger) (inputv2.Input, error) {
+	config := defaultConfig()
+	if err := cfg.Unpack(&config); err != nil {
+		return nil, err
+	}
+
+	return newNSSInput(config, logger)
+}
+
+// nssInput implements the Filebeat input V2 interface. The input is stateless.
+type nssInput struct {
+	config    config
+	addr      string
+	tlsConfig *tls.Config
+}
+
+var _ inputv2.Input = (*nssInput)(nil)
+
+func newNSSInput(config config, logger *logp.Logger) (*nssInput, error) {
+	addr := net.JoinHostPort(config.ListenAddress, config.ListenPort)
+
+	var tlsConfig *tls.Config
+	tlsConfigBuilder, err := tlscommon.LoadTLSServerConfig(config.TLS, logger)
+	if err != nil {
+		return nil, err
+	}
+	if tlsConfigBuilder != nil {
+		tlsConfig = tlsConfigBuilder.BuildServerConfig(addr)
+	}
+
+	return &nssInput{config: config, addr: addr, tlsConfig: tlsConfig}, nil
+}
+
+func (i *nssInput) Name() string { return inputName }
+
+func (i *nssInput) Test(_ inputv2.TestContext) error {
+	l, err := net.Listen("tcp", i.addr)
+	if err != nil {
+		return err
+	}
+	return l.Close()
+}
+
+func (i *nssInput) Run(inputCtx inputv2.Context, pipeline beat.Pipeline) error {
+	inputCtx.UpdateStatus(status.Starting, "")
+	inputCtx.Logger.Info("Starting " + inputName + " input")
+	defer inputCtx.Logger.Info(inputName + " input stopped")
+
+	inputCtx.UpdateStatus(status.Configuring, "")
+	client, err := pipeline.ConnectWith(beat.ClientConfig{})
+	if err != nil {
+		err := fmt.Errorf("failed to create pipeline client: %w", err)
+		inputCtx.UpdateStatus(status.Failed, err.Error())
+		return err
+	}
+	defer client.Close()
+
+	metrics := newInputMetrics(inputCtx.MetricsRegistry)
+	metrics.bindAddress.Set(i.addr)
+
+	srv := &http.Server{
+		Addr:        i.addr,
+		Handler:     i.newMux(inputCtx.Logger, metrics, client.Publish),
+		TLSConfig:   i.tlsConfig,
+		ReadTimeout: i.config.ReadTimeout,
+	}
+
+	// Shutdown the server when cancellation is signaled.
+	go func() {
+		<-inputCtx.Cancelation.Done()
+		inputCtx.UpdateStatus(status.Stopping, "")
+		srv.Close()
+	}()
+
+	l, err := net.Listen("tcp", i.addr)
+	if err != nil {
+		err := fmt.Errorf("failed to listen on %s: %w", i.addr, err)
+		inputCtx.UpdateStatus(status.Failed, err.Error())
+		return err
+	}
+	inputCtx.UpdateStatus(status.Running, "")
+	if i.tlsConfig != nil {
+		err = srv.ServeTLS(l, "", "")
+	} else {
+		err = srv.Serve(l)
+	}
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	inputCtx.UpdateStatus(status.Failed, err.Error())
+	return err
+}
+
+// newMux returns a handler routing the requests of each feed by path.
+func (i *nssInput) newMux(log *logp.Logger, metrics *inputMetrics, publish func(beat.Event)) *http.ServeMux {
+	mux := http.NewServeMux()
+	for _, cfg := range i.config.Feeds {
+		f := newFeed(cfg)
+		mux.Handle(f.path, &handler{
+			feed:        f,
+			log:         log,
+			metrics:     metrics,
+			publish:     publish,
+			maxBodySize: i.config.MaxBodySize,
+		})
+	}
+	return mux
+}
@@ -0,0 +1,29 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package zscalernss
+
+import (
+	"github.com/elastic/elastic-agent-libs/monitoring"
+)
+
+type inputMetrics struct {
+	bindAddress               *monitoring.String // Bind address of input.
+	requestsTotal             *monitoring.Uint   // Number of requests received.
+	requestsUnauthorizedTotal *monitoring.Uint   // Number of requests rejected because of an invalid token.
+	requestErrorsTotal        *monitoring.Uint   // Number of requests with a body that could not be read.
+	recordsReceivedTotal      *monitoring.Uint   // Number of records received.
+	recordErrorsTotal         *monitoring.Uint   // Number of records that could not be parsed with the feed format.
+}
+
+func newInputMetrics(reg *monitoring.Registry) *inputMetrics {
+	return &inputMetrics{
+		bindAddress:               monitoring.NewString(reg, "bind_address"),
+		requestsTotal:             monitoring.NewUint(reg, "requests_total"),
+		requestsUnauthorizedTotal: monitoring.NewUint(reg, "requests_unauthorized_total"),
+		requestErrorsTotal:        monitoring.NewUint(reg, "request_errors_total"),
+		recordsReceivedTotal:      monitoring.NewUint(reg, "records_received_total"),
+		recordErrorsTotal:         monitoring.NewUint(reg, "record_errors_total"),
+	}
+}
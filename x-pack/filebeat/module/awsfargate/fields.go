@@ -19,5 +19,5 @@ func init() {
 // AssetAwsfargate returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/awsfargate.
 func AssetAwsfargate() string {
-	return "eJx0kEFqwzAQRfc+xb9AfAAtCqE0u6666Hpqj4XoWGNGE0p6+mITGTXEf/mR5j3+Cd98C6CfMpFFcu4ATy4ccP78wGUvjYWpcMAXO3XAyGWwtHjSHPDSAcC7jldhTGoYVIQHTzli0OyUMhtEY8FkOuM8069mvL3uhL4DpsQylrDdOiHTzA9ia/y2cEA0vS735onJmst27Yi3yfT3xy24hYvGvXtGXvO4S82BVWumVsWq1P+t+uZXVfwLAAD//0tEf3I="
+	return "eJyslMFunDAQhu88xf8AGx6AQyVUhR6a5rCN1GM1wQNrrbGRPTSlT1/ZuyCWkjSRkDiN7f//PPifO5x5LEAvoSHfknAGiBbDBcof31HNRc+GKXCBZxbKAMWh9roX7WyBTxkAfHNqMIzGedTOGK5F2xa1s0LasodxbUDjXYeyoz/O4v7z7JBnQKPZqFAkrTtY6ngFFhdk7LlA693QXysbJPGrktprfgkmv25eGi/NhcJ5Lm5Zv2G/QHAN5MRLiCh8uKDFlUp7fmAb4Ll2XgWQVenItBcdCykSAlvVO21lQt/Cv+mftzf16RZnHl+cV6u1N+4Sv/L4OF0mUuWbjrUZgrDfz/WROobzS/erx0wCP9gAbbeJGuq0GfcDqpLeshNQ3Gir44FtBM+/dNBux39xvCp+CMPQYOvTz2i7H8lDEk0KS5hDGgT8m7reMKry+KV8us+zf5Bc+9+IrWfPO8Cm9Md3c8ndFPzbefTeHAm1+3XsidqpU6voHxBY8Dyml21cC++G+NAXfc03+S6TY+V0QWwMibDlD0J+5TG8QgknJ/aQE9kZ1GjLBwTxTN1h0eQ0ymJEG81GhTz7OwCdmcPW"
 }
@@ -317,9 +317,9 @@ function azureADLogonSchema(debug) {
     return builder.Build();
 }
 
-function sharePointFileOperationSchema(debug) {
-    var builder = new PipelineBuilder("o365.audit.SharePointFileOperation", debug);
-    builder.Add("saveFields", new processor.Convert({
+// Processor that sets the url and file fields of SharePoint events.
+function sharePointFileFields() {
+    return new processor.Convert({
         fields: [
             {from: 'o365audit.ObjectId', to: 'url.original'},
             {from: 'o365audit.SourceRelativeUrl', to: 'file.directory'},
@@ -328,7 +328,12 @@ function sharePointFileOperationSchema(debug) {
         ],
         ignore_missing: true,
         fail_on_error: false
-    }));
+    });
+}
+
+function sharePointFileOperationSchema(debug) {
+    var builder = new PipelineBuilder("o365.audit.SharePointFileOperation", debug);
+    builder.Add("saveFields", sharePointFileFields());
 
     var actionToCategoryType = {
         ComplianceSettingChanged: ['configuration', 'change'],
@@ -360,8 +365,35 @@ function sharePointFileOperationSchema(debug) {
     return builder.Build();
 }
 
+// Parses the "<Name>Value</Name>" elements of a SharePoint EventData string.
+function parseEventData(str) {
+    var re = /<([^<>\/]+)>([^<>]*)<\/\1>/g;
+    var result = {};
+    var match;
+    while ((match = re.exec(str)) !== null) {
+        result[match[1]] = match[2];
+    }
+    return result;
+}
+
+// Sets the name and domain of a user.target from a "DOMAIN\name" account
+// name, or its id from an email address.
+function setTargetUser(evt, name) {
+    if (name.indexOf('@') !== -1) {
+        evt.Put("user.target.id", name);
+        return;
+    }
+    var pos = name.indexOf('\\');
+    if (pos !== -1) {
+        evt.Put("user.target.domain", name.substr(0, pos));
+        evt.Put("user.target.name", name.substr(pos+1));
+        return;
+    }
+    evt.Put("user.target.name", name);
+}
+
 function exchangeMailboxSchema(debug) {
-    var builder = new PipelineBuilder("o365.audit.SharePointFileOperation", debug);
+    var builder = new PipelineBuilder("o365.audit.ExchangeItem", debug);
     builder.Add("saveFields", new processor.Convert({
         fields: [
             {from: 'o365audit.MailboxOwnerUPN', to: 'user.email'},
@@ -371,10 +403,121 @@ function exchangeMailboxSchema(debug) {
             {from: 'o365audit.OriginatingServer', to: 'server.address'},
             {from: 'o365audit.ClientIPAddress', to: 'client.address'},
             {from: 'o365audit.ClientProcessName', to: 'process.name'},
+            {from: 'o365audit.DestFolder.Path', to: 'o365audit.mailbox.destination_folder.path'},
         ],
         ignore_missing: true,
         fail_on_error: false
     }));
+
+    // The folder of a single item is its parent folder, while operations on
+    // multiple items report their common folder.
+    builder.Add("setFolder", function (evt) {
+        var path = evt.Get("o365audit.Item.ParentFolder.Path");
+        if (path == null) path = evt.Get("o365audit.Folder.Path");
+        if (path != null) evt.Put("o365audit.mailbox.folder.path", path);
+    });
+
+    var logonTypes = {
+        0: 'owner',
+        1: 'admin',
+        2: 'delegate',
+        3: 'transport',
+        4: 'system_service',
+        5: 'best_access',
+        6: 'delegated_admin',
+    };
+    // Mailboxes accessed by an admin or a delegate are accessed with the
+    // privileges of their owner.
+    builder.Add("setLogonType", function (evt) {
+        var logonType = logonTypes[evt.Get("o365audit.LogonType")];
+        if (logonType == null) return;
+        evt.Put("o365audit.mailbox.logon_type", logonType);
+        if (logonType !== 'admin' && logonType !== 'delegate' && logonType !== 'delegated_admin') return;
+        var owner = evt.Get("o365audit.MailboxOwnerUPN");
+        if (owner != null) evt.Put("user.effective.email", owner);
+        var ownerSid = evt.Get("o365audit.MailboxOwnerSid");
+        if (ownerSid != null) evt.Put("user.effective.id", ownerSid);
+    });
+
+    var permissionOperations = {
+        AddFolderPermissions: {category: 'iam', type: ['change']},
+        ModifyFolderPermissions: {category: 'iam', type: ['change']},
+        RemoveFolderPermissions: {category: 'iam', type: ['change']},
+    };
+    builder.Add("setIAMFields", typeMapEnrich(permissionOperations));
+    builder.Add("setPermissionFields", function (evt) {
+        if (!permissionOperations.hasOwnProperty(evt.Get("event.action"))) return;
+        var member = evt.Get("o365audit.Item.ParentFolder.MemberUpn");
+        if (member != null) setTargetUser(evt, member);
+        var rights = evt.Get("o365audit.Item.ParentFolder.MemberRights");
+        if (rights != null && typeof rights === "string") {
+            evt.Put("o365audit.mailbox.folder.member_rights", rights.split(/,\s*/));
+        }
+    });
+    return builder.Build();
+}
+
+function sharePointSharingOperationSchema(debug) {
+    var builder = new PipelineBuilder("o365.audit.SharePointSharingOperation", debug);
+    builder.Add("saveFields", sharePointFileFields());
+
+    var groupChange = {category: 'iam', type: ['group', 'change']};
+    var sharingChange = {category: 'iam', type: ['change']};
+    var sharingAccess = {category: 'file', type: ['access']};
+    builder.Add("setIAMFields", typeMapEnrich({
+        AddedToGroup: groupChange,
+        RemovedFromGroup: groupChange,
+        SharingSet: sharingChange,
+        SharingRevoked: sharingChange,
+        SharingInheritanceBroken: sharingChange,
+        SharingInheritanceReset: sharingChange,
+        SharingInvitationCreated: sharingChange,
+        SharingInvitationRevoked: sharingChange,
+        AnonymousLinkCreated: sharingChange,
+        AnonymousLinkUpdated: sharingChange,
+        AnonymousLinkRemoved: sharingChange,
+        CompanyLinkCreated: sharingChange,
+        CompanyLinkRemoved: sharingChange,
+        SecureLinkCreated: sharingChange,
+        SecureLinkUpdated: sharingChange,
+        SecureLinkDeleted: sharingChange,
+        AddedToSecureLink: sharingChange,
+        RemovedFromSecureLink: sharingChange,
+        SharingInvitationAccepted: sharingAccess,
+        AnonymousLinkUsed: sharingAccess,
+        CompanyLinkUsed: sharingAccess,
+        SecureLinkUsed: sharingAccess,
+    }));
+
+    // The target of a sharing operation is a user or a group.
+    builder.Add("setTarget", function (evt) {
+        var name = evt.Get("o365audit.TargetUserOrGroupName");
+        if (name == null || name === "") return;
+        switch (evt.Get("o365audit.TargetUserOrGroupType")) {
+            case 'Member':
+            case 'Guest':
+                setTargetUser(evt, name);
+                break;
+            case 'SecurityGroup':
+            case 'SharePointGroup':
+                evt.Put("group.name", name);
+                break;
+        }
+    });
+
+    builder.Add("setSharingFields", function (evt) {
+        var action = evt.Get("event.action");
+        var link = /^(Anonymous|Company|Secure)Link/.exec(action || "");
+        if (link != null) evt.Put("o365audit.sharing.link_type", link[1].toLowerCase());
+
+        var data = evt.Get("o365audit.EventData");
+        if (data == null || typeof data !== "string") return;
+        var fields = parseEventData(data);
+        if (fields.Group != null) evt.Put("user.target.group.name", fields.Group);
+        var permission = fields["Permissions granted"];
+        if (permission == null) permission = fields.Type;
+        if (permission != null) evt.Put("o365audit.sharing.permission", permission);
+    });
     return builder.Build();
 }
 
@@ -949,15 +1092,19 @@ function AuditProcessor(tenant_names, debug) {
 
     // Populate event specific fields.
     var dlp = dataLossPreventionSchema(debug);
+    var exchangeMailbox = exchangeMailboxSchema(debug);
     builder.Add("productSpecific", makeConditional({
         condition: function(event) {
             return event.Get("event.code");
         },
         'ExchangeAdmin': exchangeAdminSchema(debug).Run,
-        'ExchangeItem': exchangeMailboxSchema(debug).Run,
+        'ExchangeItem': exchangeMailbox.Run,
+        'ExchangeItemGroup': exchangeMailbox.Run,
+        'ExchangeItemAggregated': exchangeMailbox.Run,
         'AzureActiveDirectory': azureADSchema(debug).Run,
         'AzureActiveDirectoryStsLogon': azureADLogonSchema(debug).Run,
         'SharePointFileOperation': sharePointFileOperationSchema(debug).Run,
+        'SharePointSharingOperation': sharePointSharingOperationSchema(debug).Run,
         'SecurityComplianceAlerts': securityComplianceAlertsSchema(debug).Run,
         'ComplianceDLPSharePoint': dlp.Run,
         'ComplianceDLPExchange': dlp.Run,
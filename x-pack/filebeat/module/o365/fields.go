@@ -19,5 +19,5 @@ func init() {
 // AssetO365 returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/o365.
 func AssetO365() string {
-	return "eJzUmUFv4zYQhe/5FTwX2FyK9uBDAddOFkbj2IicLnqkpbEyNUVqhyMn3l9fkNqtk6zSuH6+rI8W5tPTcOaRI30wW9qPTPj5118ujFFWRyOz2Gy4JNP/V1EshVvl4EfmtwtjjJmHqnNkNkHMg/WVY18bF+poNhKaZ9GXF8ZsmFwVRzmu/30w3jbU3/PSdhXrs4vG6L6lkakldO2L/wd0HH7X+S6v72/m1tuaGvJqxsuZyTfLSi9fxH+v8aByPJ5+TFpm1atb9jq3tH8Mcrg2zCiVd6x7iBBkMNyK2Nfgoec5sGbTVxeOUnKIX+1bOoXw4lkmwSs9KZjVILN2XFVCMYKc+0gCi/nLNg3hKEeiV15Z9zAGBtyw3w7n9mXhvU0YKJf/I6LFOq9tHZc2mcaUY+vs/tY2mJxvPEjWl04ouwJNWSjVzv5qRx5M1tVT+WB9TXNSO7VqL38aZIX131Tqf6MmVqkOAljWxDF5Bdevh8z8JhQq7GuYtMQJsOFMQpO2pGFCcsVXF3yQJo7MxrpI75M7/7VAsVqaBBFycKVPhHoxjLTdpIsamnvPnztC1KSmwKKxpE7DbdApRxVed0q55Qdp6xAcWf9Ou+c9ArQMkSC3XbOm4ePFcZD0HFhuM6IInZSY/1E+ICbDAMzv6qklYfKYFiVfUbWU0JIoU4QEKYm3blyWkPNcS2jGbXt6yeWjMLaFIv07a9L2y1o8WEEk+JKr5OWIFN8vyU2oUavNqGIflZoIShLbcyBMnIbyhtenV8lMqQGqvQ8HAZPQveGtLjw7R7wZDxa5UoMVxQ1H/d1GWlHTOqt0JhpMmQT3xiR6NGJWBg8SgPJO8StWB+YhrTB+IMnukfdgkAEubEKkubVg4GHmlt06PH3szgBZPHqSuY1KMi7L1MvnkJapZwPdL28BEKUz18mj9ddwwCTnoeINvzygADjMLxf5Dkgn9YSzDPk9CuuoRUuSB58zIKC0fIMclvnkJV5IbT1/wTU944ArJVyzt8q+Lkh2yAyztGIbUqynlsFxuZ+SWnYnt3YPQRJ8R2WQCivgO/rcEbbT3lHsnBZqtQNmloJ8ZOUdpaFuSkplrr8486XrKgL05SliGdjrGV6YFRQj2BcF7UigzwQFK7DkKfpeHAAA5/c+/pod5ek5QvZ5gGEe03Pu8ruwHZgftBW6tg2iKy63BHXmykpNw9PRj/c9qX+YM3xQ6kHpGLyQM7zo+A6HGfKKLDiUJgJ2OH8+jEKZ5oai2mb4TdRRiH72ShbOvka0pAUa12+9iD0agUr4gwDfTwCsuv4kwez2E62RFHwKsnXBgoTY2hIqzH8hWKf1H4JvSR+DbN/R808AAAD//1qh4Z8="
+	return "eJzUms1u4zYQx+95Ch63RTaXoj3kUMC1swuj+TDipIueAlocy6xJjnY4cqJ9+oJUHOVDbrwetUCxe7IyP/45nBlyKH1Ua2hOFf70y89HSrFlB6fqarm0Baj2NwOxIFuxxXCqfj1SSqkLNLUDtURSKx2Ms6FUDsuoloT+mfXJkVJLC87E02zX/v+ogvbQjnmia2P52UOluKngVJWEdfXi9x4d3b9PeZTX46sLHXQJHgKr0Wyq8mBZ6ckL+7caO5Wj0eRz0jI1Lx5vda6huUfqnvUzCrYby42IgNRrron0a3DffDrWdPLqwV5KOvubpoJDCFv7UcFIYwwMDyz0KtK0GhlDEKOQcxuBxGL+1N6DHOWA+Cyw5UaMEQPObVjHPQJvN6EnXL5HRCXLvKpyttCpeE1srJxuLrWHQXgiWd9qglwVYGIJUkI0ZxsIQmedPRQrHUq4ANYTzfrkx14WLv6Coiu6vaixZiiRmsPFjJ2FwML1ayHTsMQ5kw2lmDSTE8QFZ4w+bUn9hFQVXz0ISD6eqqV2sQuOneQ6PAaoLJbGSAROHOljgsy4sZK0G9eR0d8G+7UGiZqUFDJrmVMneIk8sZHJLmqGnPK9tAWiAx2envXS2j1CpuiMCOmy9gsgASTNQ+bb7Io51lRIJvNQQD4gpoIhKH5nDxWQhSDTwhAMmBlhBcQWokgQAwXtRkUhqjyfCP2oqnoBe4VcPgrLtlBJ/k592s4tz1eaJBJCYQ0E0elzGtolOcdSWmozat5EBh+Fkki3HBEmTrA4t4tewl5RMmXwgmhvzYWAMdY7aqvDUL5vLwxyBi8LinMb+Tcd4QZ85TTDQDQxZYwOSYaYFhiEBCOzv0n3HDJEihH5gSRXj7wHCxnC8EgyUt86t+ZwyoW2boEPn+sBIFf3AehCRwYaFUXK5SGkZepgoNvZpQAE6cx1cGv9aC4okhdo7NK+PKAIcLJ6eZVHkGRSSxikyW9Rsoy6qoBy4zMAYmoGgHTL3AvbZ4mvqNTBfpNresYRrhTZ0gbNNpRzoI2kh5lp0h5YllMzdLZoJsDauoNTu4VIHHwNBZKRBfA1fK1BttNeQ6wdz1lzHQ+nzCFEm27KpmGJE2AocvzFaShcbcAIyKmLmKENPMCF2RxiFObFHDZAotcEc8uCJU/Wt+QEAGH/3vb/n6yD3D1HUfnsYLIa03Ku813YRugfaSrUVYXEN7ZYgygzbzSVwL32/7/3Se1kBnih1ILSMfiKBrjoeIOTFeQb0MKmNBFkh/PnzajI09ZDZO2rwxFt75UugmwoJVrSAo3KXRexeyOkEn4HQd1PAFl0/QEkK7dfYCFxwRektUMtJMRKFzAdAiLLtPZF8CXwPdJaose37WYv4O0nEu98JKHUJZLXzn4Do0x7SFW4fHptuB3t8TuJ1C/kM3o8+a79YInOAJ1Umlev/mLX1PdUr9RM8yop5lUnth0u//SkWN3rqCqgJZIHozB0M/hn0T631XdkyxXHodVfZ6oqSQcGoxiz6HbINCv9qCIp9zYfJ1X7Qvdd+QYi50YIw91/5P9nQ2515zl43IBCUgVWTbci787AYYnhjt8WMbHoVBiTe/MIqo6t63V+lfIikj5guh46Vtp4G46VAQelZjhWTDrEdPI6VjFfr99FoI0t4FgtIPLdIwvpycbcZcgPO6a9nXRst6+jvvkOlt5dm7MdT+lgtg6gts87ONudDet/ddnSCm11p8HUBx0wNB7reKwK9JUOTYq3CEVNsMvjneAuuYZWPOvSdpvji+aFfqyANFsMJ0d/DwBxpX7Q"
 }
@@ -19,5 +19,5 @@ func init() {
 // AssetSuricata returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/suricata.
 func AssetSuricata() string {
-	return "eJzsXE2P27wRvudX6JZTgiZpgmIPvaWHAmkPAXolxtRIZsyvkCN73V9fSF57ZYv0ih/Y4s2bvQSww0cPh/PNkd81Ozw+NH5wggPBm6YhQRIfmu/Pn7TouROWhNEPzd/fNE3TfDPtILHpjGu2oFspdN/QFpuv//na/PP7v//VSNP7xjrTDhzbZnO84L1/0zSdQNn6hwnpXaNB4RWD8Y+OFh+a3pnBPn0SYDH+/WPCajpn1MTg/JyJijR90wmJ75/++/zB84fjHi+fhZ595/kzDvhojaPTdhfCmC24ZXHDRBMbGVx9fSa1w+PBuPbyXRADrGXWGTLMONHn4xC3wcW3kont6QqJdRKupLCazjOM9dsIwMYYiaBfArjwYMTLqADflVHxR10IQEC3SpK4iZk4Ck/GeSrbTSeyxPG8XKLQnamkr34LHwpF+8hEG4GQRvevcLqejMMYh5XH0oONacZaNd3Cx89fynai2s85AOflgisbd6nx07i4HQ6WcX2r4WuXt/pWhNludLmHVDt1NTDGfwsx2udQnwlRamFEsmC146YtlEEG+4u7k+aw3H2CSaACcbv9bGdJQENW/Lgy0IBA14pjS1QrU3HYCoc8Fs3WJipj9sVNTMFWoozbYtxoiqSEaTLWTHTAS9JKiS7sA9PFrJDgjgvoJBChDkSvqyz82xNKAxsz0JRzTyTfvyRZDoS9ccdCJ4b7Ag/Sl+UHotdAgyvNEc4wK33p9eqzmq81/uvVQAR8xwhcj2vt7RqBg0UeZ/7C4sOdTd9fqkAeYLXobx67z13nPdt/ZBvwZQCEyhoHa31TCORTKYtPZSysE8YJWmu+14u3xpOQeeyF7pBTNKl+YesOgbBlEFP2dpnyn9cOts1eyyV4nxA+rle7QSLzZnCL0LFuvc+0MehOomanJlKeg2jRSnNUuMjd1y3vjFPoWGK0uMZQghwyMkbmehuLbiQCmiMTykKmLJ5dvcc9ZpsPwW00SEoi/KKFk5tCcCni5xqCiwHexDO2R+eFWbYjVobW2V5NR2OcKEW84KHbo/v1dzwvLGqVzhzsnWwpT3g7dBols8B3GGigrUnnFmCtM4GOSxaU6PLBnuMOiWitvwagRYpXU3liD9UhaaQC9WIZJYWKw3JZ2rEpVEMgq0rZWCfGYE2msqIbi5qN2GXK1GLnotEjU/DwyEZQthXFFijs/q9RiDC9OMFZwBQKzRBkt47f7Hgd9GMyUwPLIXiPaiMD2exatJnklh3e35J72VylOVQ1B+KWOcz1IjO331ZwZuUeERW6ninTIkNNGLrNyAY0oVQqDW15P5qKILi6ZzoJIHHPtQ7E21BD49UDWEikRfmtx6E1rAMRttYkEXnNTmo9ZWmlp6Y9OmItEEy6KMFONEtJoh8T79JYeJJbKZejDl2T58tJj2WwrCKmwO37qyn7fHN7kKJlfIt85wdVfPhTkKuopU+aWecMpfBU4/BCwwaJgiKHoFiLlrbMIfBtsXe4ZAVHVkU1Znh98QGcHUwruo4F713S8LRhwdwlrRrgpq3czoB9z+yOmBf/LZX/5kiZ5U7NBMFBnpBnntyWcthL0Oyn0D8Lcd7qQcq3hSCSRpQoSH6xMWg/2NP8XviudS3Tmeh3xMgY5hUEKWfFikL59cVZ3t0yuUK9uFKtdzXq/S9MaFaBjbD3DDVfKc/5gbB3OqrJ/sD8/8vDNnCbVC6uugY3+r3SPIMHGo2JeSY81gppUwVdmtQrG7juT5TKHQf+R6ub0XlbrCZIW3Q6MASR7plrOTRE/NtfPn6A5cB1Uo4XKETL++ysl2YTGB3I6VCx8KDt63fupTkw1ddNh505eLYZ/PKmN21/IznPntqxVbC0qYM27dDvhLXFBRyXxk/jBkNo7iyxPsJDHaCTtBwqsy/G2hztWFJW2iJ6qrnFJ3VgQpeXzifEqadSXtaPUAoeJZY6eVeZFCobGOFI8TlgLZNwrFyD320MFFwGBdOO5HugYD6WihK8P84gM3WlWTibTgVrta+IFO5cpCJ5VVNOdTgpn19D3RtjykERKtjZSz4wjs7yWqd/AqulAJtslIvhP/72Jn9yB1DH2n49M4lrc9w+7o/JaUMb7OLzQ1djxhEM4f2Aro29z7l22FGLMoBYwyyNxek6lTn0gyp9mbITukdnnVg9jhxl5cTq0fn4SUNH0dxvzUH7YfOj+FWpH/CpTo/g6nZPLN5ZuUdtCbGFuy5n/d5+ra3NK4fT6HBkQHcVSqBSyPVb0JcMqVZ42doh+FJnMw225jmZ52Qp9lroy1Fkdqi3P46QcKiBkJ97qI5bYrRsWCcJVYGQrHNmOVuRBLNFmUVkKVx8tKF3iJLsZvlLGi/L+H8BAAD//0e/PqQ="
+	return "eJzsnE2P4zbSx+/6FLrlkgyezCSDB3PYW3JYYLKHALtHgiZLFqcpkmGV3O399AvKLctuk7ZEcvZlkGCAAJbqp38Vi8UXUf1D+wTHTy2OXglOvGlbUqThU/v78osEFF45UtZ8av/StG3bfrZy1NB21rc9N1Irs2+ph/aXv//S/vX3v/3WarvH1nkrRwGy3R3PvHdN23YKtMRPE+mH1vABrhSEn+no4FO793Z0r79EVIR/v06stvN2mBTMz5mkaLtvO6Xh3evtlw++fDgc4Pxb7Nl3nn+hAV6c9XRy9yYYFwZvVbxRYogFBVeXZ1FPcHy2Xp6vRRncOea8JcusV/t8DgkXNX4bmZRPVyTWaX4VhdVyFozDPgHYWauBm/PVBOCsg5FIkFZK4eIpAVgpBY/LLXkA4gQJxEonLsJR2DIeKQFY6U2nlls2ABZzDcp0tokRtucr9vzHhJy1oX1harlp/u/kkbZm6ZUJ+wqti2Q9yJyoLow9d1hGwJ6///ljgrHSk0H+nAOYzYX1HjQP41cTg8Ty4061D/9+1fa55Ua2XIOnVnLiLZcSZEv2nIytB2G9xHk8mHUAmwc7Fm6dxkkBiNa/a66e8iBLhR2cBoKVDbTStbb9Rw/Ug5+G0y44evKjtd3y0zPH4B6oQxjvoLMepouvt4bLbtxphT3IxauEI+EhCSdibZPqvQvQPREysgj+AP7mrjX98IYltAJDhazdkaCasBlWRRkS92mGvCxGSQYY2by5tJHA99C8ubTdD4Kbq6vKxSXGA0dryjlTeQCZBL3to80dTBNH5PUQYce1ObOhcrTtb+OwAx9KxeQ6XhaNZB1YZKHaG06jh9joGVX3GJXkrG5FwQn21h/LSVxERqEMDsIBvKLjuhhtasHPFunEh+9bbZ8B6fvz8+bmXN24s2AlBpde1qRbdLZ3gjsmDGWaS4PN2s4T7zozKeLDytabCd7XYIT/FzLkstzORJTOcol0gbUXVhbGIEP9bBqS/tb7FU+fATBwpStlZVgujJgTi5kwddBIQNeGoydylZzxIJUHQTnuLJRpB0RYXUYJbjFhDSW2ZVbhZpgyTHVcQJNNiE0EcsM8APE7JaDTnAhMZPJyNYh8fqW0fGdHWgaH5GD/cFBdEY6F4uGQAKRTdrHeK1lgnZ5hbHLhjFlZS+NpvrbzX1tzIi6eGHG/B8oiCO5ApJU/MH6GXNOB62fuIctWHHLtENnhPdtxLAMQDM56rgsgH0pVfChT4byy0RnoGuPeIimdp16ZDgSBzDIWHjiBZJyaFevUa9vRyWxboTnihuHj2tqPGhja0Ys8e8zsY7w7hTq8TZCjoCyIBKftcQCTZ95ZP4BnG0eLa8agyAMja3VutXHggxBuBDA1OJ4Zi6XUJxdwazDE9yvtmpg5Yl9pCpHYekrjUsAFOY1n7AAe6yyUbUdhnCglnnnxLcVvzuMzjThhs9bZuKszS3B3Z7aUF7wn8AY0c1w8wY3QxATqEUx66yqhVJcPm1GjI5Vc668BSKD0aiov7PENyS2iIuvFMkkDDIK7LE1XkBEhCzIjwisdNr17a+KQPPesA3N6XVSkTkLn+b6qsoG/sABlvSrugcodfkoi4vLSAhds6EB2jKpbp29BBU/DZKYGK7xlQBh2GmQ2bWYpd/jYpBB/Ru4mcl/t3WM4VOEht4osnFG6QkKViggD+D0brAQGhiB2oiAbaMtfgt6eUdpKCBufh481ID8VQtDFNjT+7QNYLKQlHcIhjNKyjqt4b93iHqJhp7RmYWJVSFMGwRMLm6BTLmruJpmFWAQMSw0sxJziVgjBo4kdVcuPkwnLYF0lTEdTSChI9gWizIFrJZnoQTzhOBTiEKZBrmKWvmZmIeW1DbVCqtF4sQN/2whIHvjAJDjqmQcu+uLqcJ4VHFmV1Ljg7XmlZmRSdR2LvnfZxjOWRecuaygzQ0J441d3O4Mf9sw9EUP1z9L4TyecChnlEwTPnwsJzpVqOGhu2B/K/FHI+c6MWn9XCNEUKElIPG3SibOAR4OjO52hj79rXat0QYZMJGsZDlzrbN6bsaIpo+w9fM1lcoX14jpEOBlZiAgqWHgtXmEK7u511PyknOcHyt3ZUd0m1Tln//PLQykgScgPV90OF+peU4ZAQa4QEXa4Kg1p0wq6dFI/OI2FCNS6kPDfs24Gj644TaYj7waoEBM2MGsVNAD4//97/yPvs0Dpo4/3O3mqi8+814XNXtsd11nKblj3D21/9bXgjAjTaTbs606HvX1GthvxmCXtWhyy1+3YKixj69AmD/FJOVe8gBPa4nTcYDTFLAPPdUCnyHsY7KGYtTs6jtVcBKSaLr6mA1OmfOl8Ik57KsXqpgQb+IuG0iLvK4uCwdExCzRjuHNM82PlNfjdjYE4Mg1dsBSddqxx90ocuQqUnqpgwlYYSDbKGjBpsCKJRA0SDjXjVEfTgKYC5fYYUw5FDdxVwEgB3olarX+C1UqAXTZlZtBLkwL8WU0uqsk3XADq9LZvr5ukszndP+J9YyYZS6cPqpu4lqtjxgmGQhzBS9NkHP1bKGhUGSC1YbZNxel1KvOA4wBy5dfuCVinzB6882r1ceQECMErrssYxhLvCHxBQ+O4+1L8qdQX/gGbLeX9cXFH8uqiyzyWdovoOfYbAE2c8oV/aOKA/1HXbv/Kz80u/AZKZKWQjk48Nvc+3n9cg+9/uL/Ck4cf7W9ipD7YX1Fk7n0Uu3YUuWhU0Wx2486Qn9uoXriwn//m6io1CyR86MrCX+gqw/Sgs4TcBhdeXOwbog2s2F+zehzjfw0AhGj9yg=="
 }
@@ -19,5 +19,5 @@ func init() {
 // AssetThreatintel returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/threatintel.
 func AssetThreatintel() string {
-	return "eJzsXF9v47ayf99PMchLdwGv0e7tXlzk4QLpbns3QNrt3SQ9fTNocmzNCUWqJGXH/fQHJCVZtmnZTuh0z0HzFMvSzI/k/PnNkPJbeMDVJbjCIHOkHMpXAI6cxEu4CxfhurlqUCKzeAlz9gpAoOWGKkdaXcL/vgKA9v4gRdIcFUf4iSRO/dWftagljl8BzAilsJfhkbegWImXcHERPgK4VeU1GF1XzZX+7f1HIuQxKUGcOW3GM5I4Lpgtxk7aoru/lfmAq6U2onc9MYT2765A8PK+sUBlpY0DL3MENAO2YCTZ1I/lFEy2YP/1P99nQxXFgRd9LKoZohj7K3tBDD4pmC2mmhkxIXFwGK0ANq0t8mJcMrlkZldzf6EPDP6nYAcw0wauvNQPn+DnKHXbUNu/bctZw/KTOPEINjTsW5FBWBBMPDwL8xqtRQHTFdx/uSlYbcfdvQkUluaKudpkQdHOxYyVJGk1qLg20oObCL1UUjORQ/+N5sx/A6/vv9y8gWWBBmGla+BMQasIGHBdrUDPwBVkwzoMIl2Qqa3TjsmxQVtLlwPq1W8g0CEPcE1AfSyICg1HlUIxk5q5J2IgBY3gY3FIUg9ZVo3UAzgNrkD4rRMPBn3MG+9159rIr8WVKYv1+sh6/THaJXr/OMp/DM7Q+DSXcymayAGonDnoxhPrmKttringtTGoHESp7XTcf7kZw6/aWppKhAWTNVpgBi9BK0kKR6BnM/8PMCWgVg9KL4c9KuaVXKijNODaGLSVVoLUPBo1WWgyTxjEEKSpZPxBknV2bGszlTnA3d5/+eFmLbmZ1j1z6e9AEaZQaTeJH49FXLEyRvRMwBt58DGFfwhUjBxocq3t3ZKcQwMFU0Jia5GtFnAFc54EtVfEzqLDa22AKa1Wpa7tm0Hwkpn+nK+hT7WWyNTx0K8jA0TrE6ErAlLcgOVBTxFVD3kMw4W2zhtwZfSCBBp47UyNoA3MmLT4ZtCv2DxLLLgK1hhmm80tMGs1J+ZBLskVAeYfNRpC0R/TbrpQumSSxpJKnStfRJFfR55Y8/yEwi2m/SyVXtZRSivmvUXl0tuIO3bICyZJTGZGlwkAgjk8Xvs/ClSbCmHJPGE01sFM10p4jyALXCvr3QRFVJ/CVWpBM0p6dhZUklmXUrKOK1OU2ZJ0lObLcdYLGx2ilO0nvnsWhJC3dkxiFDwUH1lZSfRFqf82FEZkQeiSkRrF+LzUtRQwxXBHEJZath6MPEGt9/VR9hz6IFnmzAtqbpkGdnJINdfKpQuNk5X/+OgMA4ePzrtMd+sCWyV7TSk5J5mq5rsNL/JCRqFU7JvQFOGitRssGclRKLY/MVu8vf109e79f1+kIOrpP5G7ScnMA6m5Z+nZfO/27vp36Gh/oylRJzWJLzJT6wyy8iwJ8HZb9HYCTJmWZNbSjGK9nmNmdtkOU73FJQuVoQVzkcUYXQKDqp5K4jDDhvN2DazmG7kab2nZYs2XrdBR88Tm/Y0NdXcNzohWMxJ7CjlbaHNCYe+tpERma9MFSMZ5bRhfwesw9m+9n3337bdvPK+iuYoNo/56fuMnDAXx4KVMMblyxC045IXSUs9XXkQ3wcOMXKBjJN8lRuZDwvED+xjkxDjiDTEZJXrzfl02vHa6gtqige/fDeJ8IYKW0hyQTixaS1pN8gDpgYgN5EZ8LFm4X+xQrfSjoFYbZjAMOlcgvt4Iwged7oJVbhKD8sUofgqhuf1AVftfbeTFaEvcxVS3t/B3PTH83fpqeA4u6F3VXCuZ7G7dlue/6/T7D+tHSvG+/bcRWTHzgKK5pSrIFu2z22Ljl/0bGxGWM9Vc90V3bwi2eWBblK1tRZx06Le2Nztt/M0h8vkPBpWbOMP4A5qganDtSyb3rP6SpODstOWfaVPG1q3BOTOhe8K6ui70k1cjYPDhtx/h+uMIYj0dw7xzjD/4KzHTjZKl4nFeWIr3OczZk4MQn47XbNDq2nCc1IZyQPji6ZSP3PdfrnehNAH5wNYALtCQW+WA88GQI84kuVVyeZr2WcjEITjZuqokbUeng5FB6uUIShRUlyMoaF6MYIFm9db/OzzYMPs5hnobJA0tP/RT1JXPrNYdxjbxk5MvMazxOZybYBRRz/A0ue1a9amz5AU1IMgenCUWaMgQMmdq61BMOBkucUIiC99eZ9BGPkT50Uip7Bp+x/p5XflqP9My3gdhcP3xUIs+i7IvNyfGtOCU2bY4PzLHYiU/VDZDKipQNepq/9rIrpArxfv9+0tN8pmyPxkzmQqndofphyD0a9wz3kSYqmxfZsf4q+gn2xB9UqD650sS4LatI7Wmveqo2Zm2W4ijLKnVfOuLwXEC/FKXUzR+pJ3kWPE2s727tv34dB4kjdyjcXgHTYLYndr05K5F/R8qNMR3wO23oyNGtjZdixh2z+dRDXhKDs6w2Wy7HbCGdH13bjTXd3uB9BpEcUMqYcA7Sz2cJQUqRzNC23Zco+eExrStpyW5uK3YKJRJR+da4MRHlwQehXZ7V2yYb2qBIVL5QoJ65UWbwbZdu7M7slWuYH99++tftUd05Zyhab1FDnrtSTPnmUjIZzNniv6M0/tBl2WtPMtfMydcbBwq2QBxDgxHaI7lxkTiAmUawmkO0KxykBdD3PvQYBs1Z5C+A7Jx91VbB7wphtKJZaazGIAQ5L9hsuu600aVvdFt3ztRoatpizwb0t3OVVAXgkMnP6W8rvP14+7vt8xip2/ey8fpGue0bTqvNLBzPfNGoHY0h/E3PbhkeG5deMJ1ndyFOc1GP+kllEyt1oLDoQ8gxWUtUPiswcCSmsvDU+SoROtYWWWZp07akydLkI2DyrSb8LEnL3Lknsf4wJ50FqMrbdtm4ERqnjofd7Lb3KJzpOY2bhHMa4MCtIrpJexUeW3gtUFo7ZI9uHz+5kyHTKJLtYe2AqiNhSPbaPMhSOmhKDPJZ1VXDpa+QvcS08aUimxdiVMwQ2o+CXk/U4ra3BQIklFEWBa65os9mLoEWTaVPiiYYJJpg3/KOvYkhs3yqEhEo8LdpU2hw0eHStjJS4RuIAclzQvXaN1DMcb5kOgtmpHKogOT48HkPJGzASccz3kCoHOt1Aa4J+CSmrNU3+qpESoRlJjs7QUbLLXDnR7oJmXOaEv7aTMpWBbEi3Tc6o7qedx7ceY0sz1Ig8HlwJrTAvdgDYaZA2tuq9yDNxzoag3UrhRvcB1jpx29y2isa7fupJ/q02tYOU/v9PvBx0GDn3qHe5o+MFWL70c+638XNkNtzYvhIXDmcK5Nlq2xcOa9kfeMoVz8gm6pzQNccUcLcqvksaTeKug9eyRPNeU1Zlu0R+tY7bSvODmTctUacmi3Mp9Ab4chnis1Dc/uMKZwS0ZKGL08LmsehAdKodOLxk1A3ZnxTg+KkY+eBi2q5qxN2FREAyr0f+0BSzxTLbkR79cD8LE1nBkonhK8uC7LTGciP0RRFkomsEWyxknOopwNozlXoRLk9eyx0dNc37JJWyGnGfEermEDRYm5XnHYdpfeGw2lXrRZ87QlfpkKq0OKaqPMypBgm7On50j/nxv+nHYtWnvWUQgHpvdZbKCb5F3j6Pb6tirJw74WNpNzId04vN7pGIHACuO7Yw36MCRJDwgXYefak5Rm//qtNTyZ5MOR6kc3Pi8TtMi1Esysnm6yu0DPxQ1PBfsMlrg7qHOzxWcN7jjemFip3PwxNYrnMcld0OdilMeuwHEoX4Zj5sV8FtaZgpiLfyYs+iV4aGpIT2GkiShzZmaaQr4/b+7i+7q4amI0Rxp6dvY6aOUn89ika/6FfPYsNOGFGW7S9Ie57l7ML8J595nUqex3dxRfOwvuurXu8dU2xqcd4/l89/u/wZvef79eC3+/Xvuf/nrt5vGtPzI5eHTu/2+d/Iamhm0Ua/vdPJ7mF5Pk78icxhlv1u/vNz9i5RlkymziKZgcOptcFyQOaMTHigzaHBp/9KKOUsaZ5LXMNdKe3lbwAIbubEjGSY7vBw+qzfc7So3O5ieUfNanmEj32vhOHplky/SfDc1JMdmLBduyu26bWKCxzBDmOfK/Fhdf2GHG0wj1sA6EkQKloHSn5BJIZpI5h+qUE8l3BVpsf1AH2Pp4ZsOxgE117YCpFtK/AgAA///8vSAJ"
+	return "eNrtXFuP2zYWfs+vIOalCeAYTbYtFnlYYJq0GwNp081Mun0TaIm2uEOLKkmNx/31ew5JXSxTsjymp9lF89COdTnn4+G5k+JLcsd2b4jJFaOGF4aJZ4QYbgR7Q27tRbLwVxUTjGq4vqbwK2M6Vbw0XBZvyD/gAqmft1QEX7MiZeRHLtgSr/4ks0qwOTy44kxk+o195SUp6AZIXl3Zn8B6VyIHJavSX+k+3n3FQZ7zIuMpNVLNV8BqnlOdz43QefN8TRPGuZUq61wPDKH+d5szgvS+0oRvSqkMQZozwleE3lMu6BLHcgomndO//f2baKgcOYKkp6JaMZbN8cogiNE3M2C1lFRlCc+ODqMmQJeVZmk+31CxpeqQc3eijwz+R6sHZCUVuUaqb9+TnxzVvqLW//qa08JCISaIYI/D0IyMwiJWxe27ZF0xrVlGljvy+dOHnFZ63jwbQKH5uqCmUlFQ1LJY0Q0XfDfKuFICwSWZ3BZC0iwG/w8SNB7ukOcw9BdkmzMAs5MVSWlBakaEklSWOyJXoFpc23kYRXrPVaWNNFTMFdOVMDGgXv8K9w1LLVxlUU8FUTKVsiKEYgXjM4/EwAviCU/FIXhxF2XWgA4xEmaDkV8b8uDq0efNB80ZFOhLMWUeRXvRsy7eOb1kaB+T7EexFWg5hLmYU+E9BwFtUEfNONEGPIiOJYK0UjAeQxzVWhyAaE5+kVpziC/kngpwcwQm6w2RBSgim8GDK/yD0CIjVXFXgLmPIndxJRZqRw08C4DXpYTgW6ydUoOL8ZHHDmIM0lLQ9E5wbfRcV2opYoC7+fzp+w8tZS/WAVniExA7UISFNIn7ORVxSTfOo0cC7umRdyH8Y6Cc52Aq1tzebrkBcpDoFBkIzGtkzQV+wMRDElRfyQ4mnTwHL0MLWew2stIvRsELqroyb6EvpYTct5gOfeEyQJhbCISAWFnYXVgIeslY0UHu3HAutUEFLpW85xm8+dyoCkauILQLzV6M2hVdR/EF11YbrbSBIqFay5RTBAnTkVuYv1dMcbjQGdNhuCgk3OYQrTYyVrxwJL+MONHm+QGGvUz7LJZIaxLTkqK1FLH4enJThwzujGfJSslNAEAG+jOd+79zMI09hmRLMWFUoJcrWYGXhEtg6qksNJoJqKJlH8K1kRkHzcguhUpQABVg0vqVJRPRgrSjhuU47biNBlFI9wP3zoJg49aBSsyshbIHuinBVXN31xZGME8Z2C0vZs4/b2UloExi9glLLDRtHRhxnFrn9iR9tn2QKDJDQv6Rpc1OjrEGtTbhQuNk5j88GEWJYQ8GTaZ59J7VTAZVKSiTSFXz7Z4VIZGZLRW7KgQKclXrDYP/iZkttt9Tnb+8eX/9+tvvrkIQ5fI/UFglG6ruQNaYpUezvZvbxW+kSfs9p/lg4HOZqTbw381FAuBNn3Q/AIZUC9yVBlfl6vUYkjnMdmjRmVww+FLxe3jAZjEQHgglZbUUPCXYWrI5b9PA8nfEbt7j0sua39REZ/6N/ee9DjVPjUpEFiuIIeFCTueQnp2mJRtGoYZoHCRNoa6i6Y48t2P/Gu3s1ddfv8C8iq8L1zDqzudXKDAG4rNWSgsqdoanGmw4zQsp5HqHJBoBj2fkGTMg2deBkaFLmD6wd5aO8yOoiEEv0ZH7YuPzWhgc1PiKfPN6FOcTJWghzhZpohlMhyySOEA6IFwD2ZN3JUuKk22rla4XhLtdNRgHHcsRL/ac8FGju6KlSZxTvpq5X9Y11z94Wf9VKbjYI3e1lPUj6esOGfjRXLXvkSv+uvTXwN81j/bp4b2GP/5oX9lk39Z/epIlRASW+UdKSETy+t0+WXez+6AnoSFG+etYdHeGoP0LfVK60iVPubT91vphEDc+bD0f/sCuSwJhOr1jyrIanXsY0sDsb7nIUnra9IMxb1zrVrE1vIv5CW3qOttP3s3gyttffwCtnhFXTzs3bwxAxisu0s2CpeI0K4TpiqHOmBxY/zSds2JaViplIHgeA8InTKfQc3/+tDiE4h3ykaUBdg/ltdnFgPMWCAFrAeSC0+PbZzYSW+ekq7IUvO+djnoGIbczCH0ZrzYzkvN1PiMwiN1L/HN8sFb6UfpWltLY9O+FqGuMrNocx5agcOIFhhafYWtllcLxGReT6deqj5USEvIgIDc7JiVq05DRthMuHICAUq5SwUBOOm4E9fSJo++UlG+aht9UO69KrPYjTeNnSwxAHmvRR2H26cOJPs0aZbQlznfUUFfJj5XNQa/Ay1lT+4M0mkIO3P382HLxkv5BqYpUONUrTN9bol/imvE+wvmftmL8RfSTtfU+82A3q91fEgDX147QnHaqI78yrXuIHS0hi3Xvxug4Cfm52iwhMYKRNpRdxeulfTi3Xf90GSSe7mQcaKBBEIeiDQu3JfVPVsC0pgfghvVowsha1dW4gMILsnZsCKbkEC3oatVvB7SQFreXRrO4HQTSaRC5BalnE6Z6PEpmUDpg41nXHVdnObYxravlBtfPspah2IXbjRlL0LsE8BRM91fFxvNNoGU9FRYSvFNe1BGsb9qN3nFdxnL2i5tf/qw1omtjFF9WveSg055U6zRSEvJRrWnB/3DifSs3m6rALL/NnKCM6G4q2QNxCQwTOLtyIxHwgAhDOM0A/Cxbes7FfWsbbDO/B+kV9h/t6qvU2HhxxVA4sKxkFAXIMo53qGi67nyvyt7rtg8KynY1dR5nQbpZubLsrHNo6IeYV1W8ftznzz21OOibd+JxuMY5bZkOmdrsHJhu9wfuONvx+x5c0D3XJpyksgquwpymo+/lFqJqsWsJ200foBapqDLQBYgaFHxmsRbHRWT4Bjwy+NAocmqoPVpYGaR2dlCRVhPedei5HLljMejYg8aiZCl13QxMhEzvYpjNDTO4GUO7JYJ1hcvMgMqGF7tShdwIciO2tQuu5tj04cORNpk4k6o3bVlQexMHaBw3dEGFHPMySTytugaNwQodKYaVKeTZmhInpwpX7WzcTy6xKGApg0wsLE2a5os+GrpA03GFKrE7zcTQ8tlj5rFD0S6WO0aZUyp2OLUhdBBqWJHp5ClcN+EG0rV1bjzXgRRjHg+J7KUZoSg6IhwEE3NHzh4cuz3nEYAuNVN74B6BC5wGFRE9VMApQW7UrgUrtpEQrfs90P2UOaIuDafNEIe3OU/zsN9qtuoh7kGcMdVsAKlVuBhYY2rgAFarmDGwxtbKAbx2Q1etoHpXpB7XFD1t0rv5JQJXQ/1Um25hxdy90+0HT4NGfuxs7vF9YF7efzPDqP/KLobqKs3Hh4AbTdZS7aLteff0zhjK1c/MAOM7co1LJaBEV0dmQQ6skTxWlVvMOq+31tHKSKw4QZPFrlZk226lGEBvxiFeKjSNS3cck30kYkrorNxNaxyER0qh04vGfUDNnvGGD8tm6D0V04DNrSLYRUUG+b7t/+ojmnihWnLP37cDQN9q9wzkj3FeKfjoSHsi3zpS+KVAxmokLU5uNBOrcTSXKlQsvY4+ej7+ek8ndclS3MrXwTWuoEwwE7X6DGgnhsn7OmqeNsVPU2E1SFmxV2ZFCLB+7+klwv9Hnz+HTYu3ljUJ4Yh4z8oGGiEfKkez1terJI/bml1Mvsjm9YbHDF4smft2zKO3QxL8DgK8XbnGJMWvX7/UKr0a3Lf9YOaXzQQ1Az4ZVbvHq+wh0EvlhqeCPSNLPBzUpbPFswY3LW8MzFTs/DE0ivMyyUPQl8oop87ANJRPk2PGxXyRrDMEMVb+GdDop8hDQ0N6TEYa8DIXzkxDyIfj5iG+LytXDYxmoqJHz15HtfzkPDZomn9iPnuRNOGJM9yg6o/nuoOYnyTnHVKpU7Pfw1F86Vlw0601D5G28Xy8/e1/4Evvvz6v/evz2v/7z2vbLcEPnEcyb/s17ev5q86njCA0IcAx1hXG7fVviwU+M8n0/ct4oNMlvIAD0zIJHAUAvmp4GasDL57u23zXtGejnAtyzJsdbgCe9sX03hwHso3xPdo8ezZ9v+6R3boT3HpnLwpkrMk9FDF9F3k2BCuVOiF2uYPnMxGa348VRHVQMB2FNHCuRWDT15FDNaJD2DtaIwzkYL397Nk5etzK8SA6+I31JABTI+n4wS9ny6E9oa4+COYkMIdfy5yNqMYhaLGu6Jrhl+W26zCSKWnDH8I4mxcsUh0Xqu8U8nZf2RGpDZyeE9WkjhyY0wdTFWbgU5BYaApp+1NMTcSl2L28G3A6oTJ6CqzmfK5+lLSsbE/LeGc4NHeBE37OViB/zs/EeBA+wuLxnw9ZFW6I1ii8ELBE71tc92iL0ZiVLHd4JsvFYrr9IMbsRg5aCMNjDyVXdNCTR9F4y4PpOVnYjXAdK3N7rzUzM2K/rd9y7eq3TgT0mYLdWFuKyn3W0NmZzGWatKNIsqp/uOnBcJ/GkjwrrI9qo7Kj5W77F1Rs9hOjWiqFNP5b3YzQFfj7LVWZnqGv70wSqlqas/Ru8NCZmi2oAX5duLOCmw8ccvx7pJrGtSv+VbctPvClonvLT8PVix9zEjwZ87Qu+Ieg0jwbtskIPH33zlIc4ehNIAbHHzrKMM4spSKtRKyRdvjWhEcwNLvdIwrZnXg0yjbeybCepz8UFsty7/4HdfwwwYrWu/yo+Jrjp1WtqfdpN/sHMqypKKZgcT7rasi52IJf8OBBzG1rx1W2Y1sZdPDoaMxqC3Za1Q/O0B8RCgNtPjjzXWNCl7Iy6BE9pP8Cd+SdOg=="
 }
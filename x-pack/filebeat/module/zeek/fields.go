@@ -19,5 +19,5 @@ func init() {
 // AssetZeek returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/zeek.
 func AssetZeek() string {
-	return "eJzsfW1v3Di25vf5FcRggaQB25n0nZ7d28DehWMnHaNtx2M703f3S4ElnaritUSqScp2Ne6H/Rv79/aXLHj4IqkklVQqlvOyY2B6kriKfPh2eHhennNMHmD9M/kD4OFPhGimM/iZ/C+AB/L6nRQ//ImQFFQiWaGZ4D+Tf/sTIYRcibTMgCyEJCvK04zxJcnEUpFCirRMICXzNTby5p0UfyJkwSBL1c/43WPCaQ6hR/Oj1wX8TJZSlIX7l44+zc8HbIcspMhD87ZjutAgCRcypxn7g5ovum/V+673r0ApJviMpeFXHskDrJ+ErP97Dx7zc0pKzn4vgbAUuGYLBpKIBdEr8F20uk5ooUsJs0wo1eq8Pg0DXbvpgOdCSG0n3XSLq1fvw0xR7YubM1KHptUshUzTxi89NMY1LEFu/K4B8D83fknI/QqIZjmQFDK6JnPQTwCc6BVTJAeqSgk5cE0oTxF9RpU+Ca10giygBaJv4UYAvODYLzwaFHpFzX9AAqESSF5mmhUZELPRGFea8gRwPpdmz2th15nmQFZC6SM7rJQpzfiyZGoFigBNVgiZPDG9IkwrwnjKHlla0gxHNDDcJS1UvPW4LvO53aI5UwpScnr2qztSZiyFhEcmyubamI7kI80GgNLkISLQe6HN/AS4iFOZvcP43lALkAlwbY6H7oScinKewW6Ib2yjdAlNvE+4nwzklGpK5mD2zunZr5CSJ6r4K40fO2nLCcE5JLouQiJLibPQww4yIhMJzWZCsmXnzM2FyIDybVP3bx2HMGUJ1aDMZJnzVxeghCliumOcmgFg/9l6YIUtSgmqeEGUpjvB09Eg7RGczdcauk9OJvjmLA9gvLKnGpvEG7oGcQCN0lRvbvnRgrWN5EykgKIuoRqlpQFiuti4H8egmuWgFF1GRHc/DQ1L8u4NtXkiSc8pqjdmvtn65XahOWJk5ufi7OqGuDnD9nqGRRriJn0JNKabgUleMaWFXMdb7A8ZXarNveh62W39H7OW0Bh9x7Vx/ePy9LqmOA7tPc5Bzl4age89TWAmi+RQV9H52fvZ7c3ZDveQ1N1X9yRt41aURgGVrLDaalCIJPxegtJe17MSXsEJuVgQYOES8B8TMnykfrk7zfCJZRmZA+FlNqShmP+ms4K1JMQequ4t5EIDMY2OUTyBp4VgvHuWJwF471rEDkgmxAOkpCyq2S5Llg6gEgVIuqEU7Qnrk2+yoV+aC7ytlKWrpDjYGfh4drPDAUhFTtnkaWjLgnNsjyzZI3CPS4F8BGmmROD3yNufBtYnLbcszxS1+tw16AU1zpJXuiQUEpQRX06qN0/vgsmWgk+qi1GEJ+eRGaKCRPBUDd1OQmnzp3gTf21OQ5j2JGP2JUM+up7C3P84AM1+dbb4PY24LT78/fy6C92Z/RP+2gH8b2+HtHKgCmZmjeJdYRc3hKapBKVs8+ENOH5J3fdHK3YDiE5tc1B/rzJlNy48JyvKlzXZa3+GdEWqFFty2Fy72txtghwBdGP6fB/Nwz9GdcStsAe09qnfgObOvu0IL19KtKRcUft8NU9Enq0J9RtUAU9Z6/Hk2r3+8On2yosBZVrmzurDFOEi7KOFkLmVPX7ZUqIErmZnw0yRFWTFosyMbHng4ok8rYTBglal0OMJ+QU0iizKwxDdNulsGHcHSQUo1DQY2hOsqSoMWJQyAUWoRviaCO5kZffbyf6UClEQLvjxXAqaJuarDtKIlc9p0rvs3dKGjNuWVrq8UmRFZfpEJewAyiljhz0soRN/WtzeHIZnj9UhsTVvyi2HOCyj6jbmTH3Vdu3iCJvikpl9vWhsa9tfU9K2hWwvWmtYii66Xju1hLAFKUTGkvWbQgotEpGpN0aHfJOr5bHp/GQuhREcmaAppD90tna/CtvfnvuaOQzty34uguKTq+WJnRhcrv1l+Mh1656Nq2qpGNrFCE0SkReUM0itXZzios7O359dXly/t1I2yLak9eR10CDL6mrq02pNmCYS/gMSMzeVdN3/WL7QBFyf/mrujgwawt1cJZ0Nb4649hYdsquJhTZyNdq5P+QGGj5OfjhjjtOd+6x5PTReYZVya/72CDwVcpZk1IhUxPeFt9FXPg3BULU5vD02FZNJyb7crirnx6LQasx0nqapnUK8nSS6O+kSuFbkacWSFdEgcxTaRD0xnawgJUKSAmROeffJIX786oRcaAI8EalR8bht+Bi9DB2eZ/ctvA56xIYBZEWOW+5jLY6dGC1o8gCaPFGj5STAHiE9IfdBdHUuByFqJcosrV7khBIpSm1QSTdi+zRb0AScY2+UMpcLDTMc8DexDcgyE3OcqHZkAPV7pLE9tPCfwfPX2aydBjQ+EODpxkqPkUvl3MzE/AvKph1mEd0zATG5ODdfoOSRZiU6laAAbqbMz0OxWiuzPQkH/STkQ/dhEnzBlt6chCoGNds0KZUWOchX7o3e/FxCOZk7+0y3FoK6Sk4SISUkOlubJ2ROtUaNxPl+1xhQ4GFma+/dhbTDxsiLf/nT5uLEsjFe3/zLDjbGRck3PdD9kPoaI+232WH2oNk11mBmd4VHH/Rjd6Vt15CacIts0xH14mCLQUdyz407OWLH+zBeKSuyOVrTrPtM8H7hXe3gg0U2nV/f7RLQJClXs57pmWYlv75rWp7Guu/6/FXDNvE2iJa7yjn6fy9BrjGWKjiqtoPCL8SbHLN3rFvCbm0UsEzZN1o5N4+UYJy4vrO9DyFExbMT4u7REQbf388uT+/u3FWiCkjYYu29B07JXVSTOQrdLK4/4LT6wKOTEX6BdwfYEWQwdfZOyd/v/+fN++6pM43uDOzlJm5XeLIjHGKfXRe8wqbdoMfgKQi/CubhEdBebuY6kA8APD3tBDYi5qr7Njot9UpIpimCO+XqCSSZm/dN3d0ezPl2YzJQlY3cBWZ1OQRwrN7Nad9Wrrt1mIK6SHO3MioQ1mWcjHgS359NnZLu7XQvS+4uYzMRHUP2WoR5xWn7aRjyrd+ex124W0hKiX7ac1BMmpucoV2BhoCJStnxsXUbjo1Ww0/UvJmkbfrRrh1L/H5latTxvo28RauRnj5SltF5BvWxNrdo12A7jjPx21KVhVGE6oM2Y2SDkoLiUem+PKdHzQHe4RKsx6n+FVU7HaiHmO6HTsb9ZTfAKXrRPcZtJCtzKXk3cLguqVIiYWg0v71Vrql5pV662dpqMa/eBdbgGu9UdweXBi3JWWOcmXeMgzaowELTbLZtK0xyu2Ng/0aMdtgTEhIhU7XLE8bCNJ9kPcGwLwPTH9Ih8zl9mvUrz5N2wG+1dV+UWVZb/BVVZA7ANyLFe5F1PVtjI7Oz1YMsPASLdn5NrIfgzfkOD0HKabb+Y3riSPfO8q1aOb4EDpJ6N8wjExkdoRosKMtKCTMJVE2PauvZ+fCsS5oR23bQZxC2Ysr3PZQtgfbgmYJl3r6Q94i76zAZLiTN4UnIB/UmLdI3tudj1/OxS7wZMiCekmRV8odgG6Rr80m7RrlQmmTsAbK1kQNlZpYrZJRYe2XX0oXVYhkczLbxwTRuNvXJyF29KGOaNk5xdG2z9ZDkfp6t+vJoWv7zEZsWbdxWMZKUqwVI6Y6UgTd0MR8MjHeFjEVSZRhGV8CwYac8rugjWL13DCa89+Kd4NPK/pU0gjTdBetNm2ZbpVTTE/L+ZHlCmCY5JgMS6g324fC1+hDWs8+Slfmefc6EbXFEhCTUptvYbgqqV+7jVmWi9kNK5ECEucZaPTBelD6Aaii6FQq9mmQW6Jg8ZwbQouY0w+em6cTOnVtWI6EkWJmE7iKtPF5ywcnd1f3NUZdfzpneag0Cubq4ek+o1jRZYcqc4PX3Irb38d621/X9Vh/+JffE9MoJ0nvrROGjnsb+Do14Sk79IyVcdDYWJhUcSFpKb7PCqfWfGczVymHWa0ybBPOK5U3D1IgTbD5ygOjj+kFlC0L9I3ZUlt0+uYAdCbltCRJOgaoJjGqDHfmPQJbW3tZsEZIu20KlCqAKMVNWiPjmhSRcDAXRMPWVD959s9WPTUJFieMUED8joooiqLJDG6bAdFAj2DMHoMev4B2yYVxGvjvpkRrd9oRcCydBN3IGRqYaAvCoyZhVsrXNxyykeGSpUSFEW/4Q4EvGK7vrCGFg38wxEW9mXVvc1k8uwVqilB1AIvJCMgXVq3AE4pwpxfhyD8zd9rdNxKy2TZSWQHM7iCeQgMgz0OYJ4JLga3dCIUXSzgUg9dtkKEPpEeQiE09fdpBcaJJCxh5B2vVyn6j2nLlzN9MQTEdMkQRDeeaAY8loUZjZsZ0KWd8U+EH+SpvPmkemgnyeDdqZNcshFWW3lr6/haJxqrAvIkpdj4xPdjpmBZWYVrPHY6tD0teigirDpAsFTQTXlHGQdjB4RbkgshWmOljhB89aUhvpqgxKo/O0OtpZ0Ul/iqiJcXJ1/hNJ2RKUbmgZZojA9RAataJvYyqGdx9P3+4H58ef/hYZ0I8//W0PSGETxEN1GZ5TPkCl2mkjzkv48CwptVhsbskIRvKGjRwnyyo0FUxzPJLSTOiCrMAceCMQq99Xd8DowSj2R0yP8KY0r7BpQVKmHgbTc7UURbeVd6qOVU95SoErptc1bRB3Y/dbJTxP9MGyYz/c75IcW6qYJEGfFciNQIxSSkx/vL8ZqV0WVKmOnvdAdeNa3AbKPAQcGdTQrZyIPKc8Ir4z22Azo3hrmlSwCcjuN9U0I5VclmjpCNPkcLEF3qRMWYQjXt6doKaEQXbIErJNnowYpvm5Y3+03vKNEYf3IbW/96a0Mal+PQYQEicc8o6zxQJS7CYYRbZcNmS7GTwSqrqLYk4V1GKaF7p4g96AMfHMH2qG9YvzQedyn/duUgpFBF6X7sm5RQ8ghgUFI0pFGBCiHtxj1+3BMTutlY1I4qzmbd3Buz/mYO6gms6SFeUcNs3XI3PIO6gyngvr6jeyHHnDXAe7Jo6ba4c99u+Abj1s5IT6Z9fN6d0/SI6ET4posVxm1iKDWoMUWRt8L15vh2J82eXJIXunZja9Op6ShXGmWUUOZGd8yIRNGofWbp2XBE2TBIoYmI26dRgZYYCb1s3r279gPXCBEXcZUxq4GUQItxs1kCDfnmKqLE6DehLywUBKmYQEaaJcpBZTdcI3xk/IuzXJ6UNYBFjQMtPOtfPq5NUReQLMIfF3r22p5BkohV4pjeFKuUDLFE8kaDxGKVOJcNYbHyUGz5ZbksxL08QDF0+8hpEpIh6M7CoHA7+TPO1TsiZIK6/p+Xhvp4hma/JEmfZrSzui0u3P4B2WH+h+Pxt9KbVnKxKEIfXUvGS36aekYcH+/TDH+EyUXJv3tjAqY/JAVuKJ5JSvPVplHdEYCgTPkJTD0aaFpeiY+RY6N+OkFJa/l1CCu38cuspX7qKVuMZDxIUma9A16kYt0NSNw4SUrGBEfEzXFbuPF2jTjdMQOYQmGAgtpO8Zr96hA+8Ygbc+QyehPQeb2VrB9X2Ei8q/QKsg3XFJ6hlVekZLvZr1cXrsIeu3q/ZLydKFLprKfdtcfol8KaVeAdcuBuKNgqTE+PEcjN7DlLfLU2Ukc0fG30ofzm7y8X4nw4nNmOqPc5gUhXnr4xtsPEHBCsgYh9RFFjAe9O3K5bhobJQ3lWpeJV+NoA4t1ayP3GSSafQO2wxvCAm6lGMpkyoix4WY9eaXTJpg3IbIgfL2+bluxsPgv/BMmw436iQOo91rfjVdTg4m6QrTccEkToETEm2gj1Qi+zTVWrJ5aYR1TWOzKXgZdeZcit4ZlpQZbV/ArR1eUDY0xH2Nie01CcZEKw5ZcmykmhF9BUizQJDWUoLGCG8v9Pe+cjqSMA935RRSPLOYzpTTLPMmuRXQFKRTRnK6rt4EbhQoq0NAFVUezZDBiPIlzLpTq6OkJLCmMMb3DFWqzIH8+Je/2c1NM+8jqFtSxjEW2onBnLaIJ/ce2UsSF9KCc2s7ws5VI/zFM8tdC/8ZfMG14wCMbsh4kpWpUw6PyH+UStfW17Y+GGtiBNlXMHAnUV9s4EKyJbrSY8b7cSJkioK3GvaiYXKt1TioIp3GQA1m70PjDYbvyVC9w/YlZtZupN2BGrnwZZd/bBAbIv2iq78T0i+4+GNxVucp8vPCW+0qlzoGOZsnmWZVoFTjdvUa5lykg7ngYSd8UdwbSaRN4JWyrhtOiKhPyQvT+FB+Ss911yLPme7RCop46xNRWCZtIISGLGNLjBwJ/Y2wwIXPRnGV9gP0HlK0letVDWSVTDCG9XCrt2IvlC6UucKFkI1Si9vo559Pz89vj4gj3w0hzF5/d2MYVbiC9xN4xhgCzrEBXnvguWwHuhGJ3TBh2PRST4y2FMSSQQk051pl+TlUFsD0mOHBIqHUYUb7W7NOUXPAI6DxbY7mvTc8r6UpmJ5qZZVyql22z054IwQNHGTblGynPeO7My34NIAq1Hyj8gQttcipdhxkC5ZlNjh2TJBF72QJJPY53FwZleOoimnpPVZuzBNPVowgkmhj7N4D2wboe6lP0SLOTui80fHQxbSTvLcV+ESDQdIebacHNS5lnE1UQd0V6AzsYzLkLVV6POh3jnvdOrhVWRQZg9RB9OYnHMpQjFnUfF5kya8yZTaizBFUY0pXzJcwdHl/ZhfuECdvPjI7QJ7caS0ojC3aW2FlMxUb9laE68/Ef1m0BeNecrExYoM+3mA/4AMFfecYXeb5Jyu6En8ZUGSY8XEDRiCMGHBTyRoedtBr5cFKP13c7lL2ibNkk91zD0vZNUseeFV+pXLBjww+iR9mvA+aryiCF42I8YD8A8NpNiMkJgQWp2l3iN7E9Pe1aZE511VN3o8OHUy6RceUZ3FHgPL2Brc1Wm+4k5uq3ni/dkTGhZeYn5tedzyTyZs0SY4V8HTIH+9/zs/OqgSWEDywRQ0kw9HZZChC+4uNth73bUbuw7oJDWUP6z7ULbY68nIR3/3xF7tOQWfs+FilJWpW3/YxdYSLtwfUFyruAT+AnIMUByOh+dW1v0spRnu+Iqt9t770olnQY2SBrIJsyJ2j/Xt9evcDEZLcMySt/0VSWwwu/P7+l7sfRnkh4yF3pdH4cI1FR14Y8Snihj2m7zLpyHTew1/sF8zyKg2lr0nZMuB+2SSH7sf7e4NzW9VaEjdzYRuKcdR0jzSLWIGjp4aEyzNsV4weOSB3XBEr9jHGYMc1a/MDRcaCnYwAk9J1f5WrvXZcxRph+kDBrC1CpvyEDfpZE1a0qY72IZ6yCIAncl1YGo7+stJh7wj5RGVK27moe0iZD1Wjfl6G9LvqnuLwFBfNrW9yVyz249GOKS1bjkkSR/R8pMpyP9nxlY7+w7Ek/xG06zfDgZl1wBy6KlrFx7sZQfjr+dnQ0YFWMsweF9P2qlj7PMu6ntjN1nd4l3XPJamUGTMrluZt20VIhg3qkeE11WWz8EkFeOx7r+zzYEQGe1fVQEh2mdZm1Ni3vJvubK7lt7Ob1FTAX2JTbQMbdFSRzsuDvR5t67txmG4pMTQ5/JFvq6xTcxYNWgzhGXMsY+MLzXqPRojvQV/NSGZeTFKabStfPSl/6mZLKS+7wG+w5+Mc8pwO5shc2T1hE8daBTnDvlyr3w8WuXS1vvv75S67sp1xuOeCJ/UUSbPGTKlyUEuMSoSBjNU+29B2v1ui+54WgzaiOm2XnyDsBNJhZV48RdzyTUYeuljY7HvTyREyYvIxUYLmAMdbL3dXOqnVhlEF3uimDSnq0bnG1nc6O8FfFbXy1kWrzqX3+NaCZ8Zx8bAkL14I28XZ1ViGILRWRyX05o3iky2aOcX4MnMhIz51u+ZFR4ex3VxjkDuavANN6/4keWPG8E+C7D0JsnEWXyYGxO7M7siPMQftn9y9vXPzzTHhLvAz3x4frjsu3z8pLg70n8y4g5fQAQt6XJyHPM7popOLlpdlH6/CRmXGUcpG1FR0JFYqc8rxIkRDfuAHc+JnFChVzg8KSpXz43Huv25DxYRqn5XClYiSa6dJWFKFbQ7Rit/FZbbGmxhfq8BVQzc9uOIslCkf4jhqvRAc9taJTsPzpuVuaM1cqaXax8IO2gOn9adEriDjGnVaYMWKQ13g8k66P+SUZbO5SNczVwuzG+3QlLYl+zsbXceXtp6TpZ6FZ+35QoymkEEOuDfRjmCbszGz8FxQnhLB3TDat1e4xh15sBnIoIS2w00ho+uZFg8wfW06Xgt2tOaQ2avXtB9WQ4Fjfkto6dSMeckyfcy4RYU8W87WSTOm24XLtSCIHGPA/OWO3yXAgiXGdhsqu5nD9gipVyTx462Ww6ex/RpXhC9NmtpK1b4yw9ADPTz8Yu78SteuaaBPK7Pza1diVcYtnN9SGjUPXfKQlkWGjzm+9Dtr8G4oCglKzRY9ES/T6Jo7Xg4GbAZ86ar1MF+CG/ePUwPcQF12yRwCvMGtn0pRFIeoeRloruzT6eLGW4ltuoztFjlVUuBmK/mnFEV7ZAfscBnqrB69Etcwdn95tYtZzFYRjrefz2tViXePhF4JpeNezh9dixPARA9FaxHF23I+lnyyGUiIfiDb/6Lsi/ypx/vHnbWNmP+dZq3LGzw9VKEnzHt/13LauiObbUfxgp5f33UchYral5Kzj6eXl++vf3k/0oHLQc+ZeAHo16DfXXyKDV9LeAF//r0E2B14JZ4PRnt3bVnvxgrnR8Dy3Z2HaXLhYYPBNexNFK/fHpEfj8i/HJG//nDS/byNSgznYSA5s9VzmxSEpEGYJ6kuN8M99+zdNUpeF5LlVK7d3jgiChLB0/q/gE5OemalEFl34s7UUhc5fWZ5mYcy4WQO+gmpQe1dgLSa9sGN9iSLVvWgk5Cw3v0zFWJoNTgN1kpDTpJMJA+DmKQQ2r5T4oFCa6gUJU+PtWSFe0w4G66EBUhMyNwFIFOb18q++EyT7tBNRQaLqG6sD0KGY/CXI/JXkqwo+qSkf+lhVKV5bqQwL22R462tvD0iF+eEKsWWvLKib45zviYXp9en7aZO5+IR6o3hI6hUBsrFzeNfbSZ6TQ8PTs1GB+2Gr4WGiq7aCJ5QVDllKapgzlmRZWv3NlwTSjIql0CAi3K5cq+aRib4xc3j3zwYUEetfhVOwJr4h/GCSaXJQpTeJuuLn57/ZJ6qq54B/d///X9Uo69WR6/ZCZwQynGawvRggr41mBAORnxQybI1fqhHoJk9Zh5o3Zu/Hcs+GJ+dg13FlqAwCm5mGTfN7JNESAmJ7oYl5DI6LLcbXMRjAOmZmFIoqKw/t6+xfE2T6LM5dcmhMDq9pYWRSom1nwPnlcG4qaTXMT7n+uUwusixzoncBpKX+Qye9eQwmm1+PnjWwFEIW7WLvLbWRuo8MBVFfEGlgvSHtnYoErWjevifo9XDT2d3m6zIhHziGeNAzqrwSc/3e+Ol2GvzxR9OzEfXJJGAdqIQJ+cMkN3BcFuDIFkGe1w5bVMiBrKy1Ms5HC5S63bvBCMToz0kabYUkulVf97QXnmjGOAf+ggJCd5sZyPb5DXNwX6Sp+6ffoW1+ZcxLF74+YM8hl8mmfpj7Y6zg3mFvDOa8WUJagXptgzBTcwP0DYlvwzkopxnLDEdDNtCGM1m9pUV7xjdYbM1/zIakHx04HBQePWyMmIkIi4rlqYDkvAoHuLVM+u46shOmXk91WPctVdVjqmN0IUvmXGMofuSQFXrgUbiiKRbbBvv3alQg4Wx6JiuPRZm1RFBFGFhMClxc3Gcb9gFKK3EEzIJ2So1WtQcjS0VtBc/b3sNY+HPqMb08sYwODw1qmDSuSh1fXA+wKq+uJ5NZ0vV/MrvvJtGM97gVewWoxs55f0+KPivVLB52cftoKsv4kO7rvUwhZWnMHzClqTBP46pUk6TFeOx41zME1f7tqtHciDoSkReMFc4bZjox3w06gvDn+gOYE7NpUMOiZaxfM8pM68vJiElojCqHUoVfNkOB+PYz8WFE5qtBUs7gFoQWfIq4nNoz6sZPEfMQr5Q9SjK2m7HoB2kaafc8TTip/7HIL6//XXOYtYSqCMkf/vr8ZzpGs4BPKUCNaMq69bsppWQEFCDhIGcUpNTZ9C5K2gC5JKujfS/pTwVOfsDb4QxSFPotmlGBHpONSXvcf6MpL2R8Ah8LL5EpDDD971sR4lEgwrmGk3AVp4JvZFkBcmDGgNTQfebNAK2UqH5s0xsjZAqG29FMTBmAN6KqhnLzULMdFw+gSZM1JgoJ7YvMuaoGGhWP3gZaLav0dCM3vQywEJhnoauNhYn2uFnKdX0sDitwX8ULBddt1+xkJ5a9Ejo771MLorviDBXAGCLPitpyg6XTXyLre+i1+4boNEdX+5bxRy87czkNR0yHoar0zPva9gBwkLSHFJMD+6E0ipGOyZ/1F2Q1tvv/E7NcKzgzq0BteFqmJWVrQklK8Z1ZUy/PT2/+HznDds2S7anVe9lqWteK8GdwbuVXNw5LzZ6eNYa/rRZubWxyJWzrHvgicgya6QJw74vOYfs2BJsHL/naSHMtATBNfgIwByfmXmuxttqZ7ZVfATvsNfQtrxPlbiuid1WrTtZ0SwDvoRqihcVda5aOZuDdqe3EfU1+Ca1DGbxhnIX4suMPr7AOOONOLShTH/dHf4wOdnZB+KGuIfKbRqcXjythwX/+RQHcXyKxZv/HFZGSNQDpBSyxkLuwmwhL/T6CBMagXKXG1Nr1PeFdStqDAjmjI+g8s7Ecjk9InVbQjpTGNNMMwk0XVuzle0N58WxMrMlF7KroqdMDxbZdHu+U2RTIsQDi3gfnmF7rsA1Ol8aMYvewFEdS70uHME0DRfpS58/a7uzzXbxKpML/UoRSnL2HA6EmWcOS6HtOXGMHFVQkNkGv5ydBYGEVpJWnZ7hQnC2WOysJ793L+eFq0MbYjCSlVCbQXoD+B5gPRdUprMMH8Px0P3qGia2YfI6o3xZ0iX8EGyszQ01cBv2GzSn2M3nJcsOVHPc7Cs3NG8o3XKMukfdHnlXplUkwNc1Lp2d0RVSpGXSQQsQCdyNbd+xNe28bVJQD1p0a4JT9s0TS7cQ8e1Vg/3cQrVdTF6PFbDlqt+lEgWh7WMyxERkQnbWWiNx9ozl48kw3K7Qq4qzceMMujTiFVuuZjVQdhO8JItgB5dCpKm4aPEFMO/Ac1yfnnCDU6w6b6RXxQLarBfiU3nN2DuDjbcsebmFJXGvTdnMDzfQXJEY8u8nP/3lX1GTq/jSOVbfk02jUbKibAynZQEyp3wb32O3UjpyJBebDBEhh60OVsg2dlfG2YLDRD7ICyGpHAqrqFY62l7O4BH6WXz32szvK3Za7KXNhjGGwxn0ShzosqoBtN2MRlglkfX4JSc9d+6Rkq3ybJsHjjm1qKIESIMqrOpWWidBel1sIZ+TafHGZzvOlMqazHM/tBr7kNFlS74tPE0OVqBH9py7u8uOJ9xifrAn3Id3sZJTvjJS2pz+R2ddThI3WOzKdBPU54aiMTKmLWf8RYCabnYGujXDj3zzC7X14dkC+gUXatQLuYN6e/rR7M6EJXEUiN+aybBTEmHJC92UTUK9DaTu7tyiW4bpXFEJs0VGI/J8NUgr7UMBLYWcwHOSlZg5ZqlSTOfpSPI/9xDdizClo75Z7emuEmntvDZ2xUfp0f5qqdsetXuYn3+rP2Cno+p8ye4B62Pj1ToKV9hmbMmpLuXBgvvufAe7qA1xuZocD+gmD2MYOsEIlKHzyJZRM+s2qZ4rNLhwrkjp0CPHAN/Hd9VJVygkVJ+qkklbSI8RJD64x0xgOY+L9L2nxyQFXRs92paxE9ISZ46sOWPWtesNHyWVSLGlOiKlKm2VSilyosocc4mxzxFMF4cDZ1pXRxYV7cNVTdPBvEJ3Fzt5hWrFOjotbXvMzW2oP2+NFKyAjHFInbHNkTc1OSpdvK4vJRKcKMM1RSpV+fcSeA936xRNLI52082c8A+Qc2v0dyZGs3reFfr64vofF/fvj8jt+18u7u7f327mxfdC7sx+iQT5THCNa+rk19kd/G7uYJqCrJzzo3hLSsniya/PtxeNmXSzOKRt9eVYTKv3tjE351TDxLlx8KPt4q3lu6JuCL99P0iRh8FfCzMTNvx9+d+d09hcza9UEOi0KIDXuGBtfUqjdCktGVI/icUC3a3m1WKd8CNOg96MijnQwO+FH+4YEzE9VJ2m+5qrxde5MNIT2WUFx56PSJ0Qu9qaFn6fna+OH3n+LMVY7zB6qqNOETL278eX2OHkI7WFmP9rP1PuHvz/7lCFkX9Fp0s16jF8/6drlPXLBv61tkXE2xTjAI8H90EwbtMsi/rGbE0bzbLji/OJ0qi7LFREeK4c1FTdTIGc0WXccrgbCD8rkMenpo+pc9ifTf11FY+9q8iSW4UIB43g4SFyqKKyDp2XZzsBDKZBKjlrCaSIm+U328HYw2+/Hbn4RJ/gvMcmh8Rm2LX5fNYsKxXVAOCa3y00FEsuTZ2o7YWu6gUqxkrG2UEQqXLeD2qHGHdfOyseujMHK1Tl6sE3XH0qOsGE1XXwdg9Fwnx+97hqYVJ3z9Qws1tHLoAoeUqQcw6TlMNh86/OwIBm1cYBaNsc6pOm6x+V3/Du6l0twnjMRMXPWarVyoe0mb20jaSANAyELfrQPQBdLEKgWKPihOllI4ZMQq1AY+DFw9m0lFEjZ9U0NNuzvn63PPFhbgj1dcrUg3UMHZFCIp2k/6sBkqIBtMVt2Ql5wXrSIru0mjEzXknjQHSXujofR0RpIYEwjex1m1R+e5EF7+X5/cAyQJcOW4QaXlsyQeqgaFch0UiwTq2lGrdqJpZEQiJkWlEUjnnedZewjQDucxX9XFvoLUQEZMM/0gtrnxAT/dz5695Eux0GTGy1hDo3pXuOa6SJMGMaGV8iXxSlhATY4wDEmsb4diYWZkHTWcpoBkkPWd8kWVYr8zqnCmpxdiqfm/+9Pc4p40PlXc8dMOKQ7qb05fMfxw1x71K23WP8MeoY67q+OX4Hy4w2WoaRlDslR3fKx3283BNlYnXfdWv6k9yOF+dB9tnKJuOoWPZRvLoJiHa4vLbYLfcAcUP1ihRlltWNkE6v8hUJN8pEGm1M2DfsEFQJj0yUKnJlpwsvHnH27EYNs+c0RM+z5SkUXqmAhoxIKlTsj27Au9fGerfWgO3VC3gO6aOsj7ZhULvr5oNVmuaF6kZgfwaJOjHYr5U/S3YiWOu3l7s1cvn7ZvxjMkFWlLdTeiNDsp2MhmT5rw4MyaaPjoSUixSLKh0WE/ay3oYpaLZR6xZ+/twnzI1cPT97/+b25qz70s1tScYDXruzK1f1cYeLN658rwd1olg3zQ/zo+7zEN5eulFCs5xx9IexzT1DjWoWm9kNOQMdr1sN/6jA4n3MzFuNJHdX73488iHEdp797cd4kpVGScWCAHdX794eNZLv8Cuttv23U0jLxHEY+JYIVeQJsqzrRB2uQMvd1W4VWg4YsXZq4/WaJQcMUBux5qPTGo5fp6JghRtXR70W0PaEFQHyMtOsyGqcAViAtqZ3DYY4Zwd0rH6ETIxzq2DNw46wh33iTrHqYCjpQBZo4XVBXFenF5fkw+2nq3HwZFLoqC7oreBuz27uyf2ncdB6A812Z+jsijIbByLuym3i+GCeGKNwHDJK4H7kVk4m03KN8AuejdyvLxMzMXZKchU3KH8TzJVaXpyPg8L47PCTc8GPfUzJOFQvFbMxDs3zzNf7Z3zZx2IWBda/H3+qejq+uBkpbZhUemYNnT3cTFOZyRroLFnVretn5Epi2acXAGc72hFdRnHiiqybk3Wywwqr/wTtZdWsI2OVHTHeZNv7mJnCJxg5mC5eGFOHjxfjLFsxTNNCmHQ2udppx8OhVgDYF1uqdNEVVUQ9McyEMgtty3zdX94NWvpEAkqFw9KvAO4J2rFZ/NkfFquw/NkvtS9VrLR7wzhgw9ozVTNHjmS09cc9uI4H4Lu4a99LVQ3bWi/npcYo2zUMRNoGEVqyNKKjp86p0PKC6OINeiqGuBROHRkspOQREi0w+8mSKdvC0hfnjlKBak39ZtPVGR+6+tXsCeYdxcVj7jEvbqxlGV95EmvOUeI6twUhF7Re9z7cHjw/3HP4+mqn57Dnb+x+XEysOElzfAf72uFtasiCJg+gyRwywW2Fequ/GPAuzznwt7r6GoLDGBfIniE53eOppfMjxEFOonpkXMn7BMb0Chm+WV/3saHKIEQ3w5tptvbCtonkgVkRo2OoIgWV2o/xlSKvH9/iIjz+mPxAaJozzrDUI3uEN55u0JIDPwn5cELuAMjthzPy9u1P/5UIaf/8r395OzBHS4iXpuTCuPp5D/YKw21SQD1SybASyJwh1zuWd/0F9K1LPvwF9DU8+7+Sm/PPTq75OKTtCf31Yc3L7GH2NYztXZk9xBiQj6/7wgtlQbzxf9h5SNVz4Dvawncwec+GS4WpIqPrmZVO8WTfqTew1z4X3r5G6tl9ZdRocDTYQwp+MVOsL913j+o3jXpWPdBIklGWK8L0K6fmlQVBNB0qg0geDhcE8uns17sXrHLeFV7jmGXrN61BNeJ9Fk+//ewCXEMtyMBgbSaHBSLwQornoUDqgirVgWCfMCvXYgx0BwrzdgxwXlhQrSEvtDkM9rE4Fl7s7OWtoYl7RUxa0v0a86c9S76AADnDJ+fcVhLxvP7UcY1IMhe9Htc6fnNm970PRuI3XQ2szlyUPakVX9fauE2JcL+dFWmgHrEaCS10KWG2VeBMevWegwaZM14jCvUiyLuBXec+tr6i3ui4wRqVgePeX3cfv82ahSmTsFcsZcei+SY7KEFDML+roE4V3h0JzfAUBtpOxjvSs5GXTJsL0n7aB9P54/Tp8/27T5+vz0/IxTX+ofqVgkCW32pWFIVQDMPgdDmmggXS8LQrCe+5ftaI/UqZFohelfkcQ01GRMNMV0AGkOy0k/bUyNpQzJnKG6SLr98a+fjjUIxNX9XuyTGKZ6xYgazaVTWeFqbaLxL7M5isi63ue/H0P7IqxuNajXGGFP1jQgVFXkg7sMNBrHUyBWPPQYwI0HmWUDY9wPqVqmCOwPcA6xk82xDNw2E08sL3MmUW2/W9IoJTbInm1ddXp2c/jEUXDnJMPlL3FNjbqjGGm32D5dN2rcgTEDHHLZWemG9IDCrOnuhaEdqlzBGSAbWW5yP7LidK5H5bKpIjz6OvpMlFu2NC69Fw9R+sE2PLMVLrN+VCO6cqcqpmnnVcAaESfNkYVUfS2bJH5yqbEf0kjhcUHS0GH3kNJ8uTSpM7vT4nRTl/gPUYZrBItLLdO/a0OX22yk6XIpkdTpG8jGUGmXT/391dvrm/vAtXbshfHeXt67zSIqCx7RJVMg27QirlY8Rg4fdZZn6Z2HY3sZCnFXBnaXh/dv7xjfnP+2FWpzKPWfrLcsUL4vnl/dvJu7VsCX/slEio7CLmIsmpBsloFm4UVHM6zhNQmTGQ4/n+OTzrvUtDdYSQw7OuykLpelk9oaBm9aFFkfmDndE1SERUfdU8LfgIyzIoTecZU6vpS9YRut25ZEa3VFlYt+CLr0Go8VxnQzatR5qxtN/VOonku8uKR+KoD7e2xpkrdeKrgFSDqF78O1XF2MqR8+KAj1z9UarIpwK4Ef+1LxisYyKtaNZXo2fSmbo0Oge2WQk49MGkpfTHafS53/JCnbLjDpeu34iKcjYwTFBxsRdChvuokhXkQodDq+ripwcnqQU2VTYQZ7Lqf2TXZwALT2MxnMNs4zOss9Pcxa10YTdKLfx2BcI08qkUGehK7x9bcKga1KwrZCjS0Dojf+qH1WZmBcb+ypEwPBcjBsmUKicUwRhDgOXCbmuU3PYlgsGvtjpUvbLS9gFUP2PIERKR566Yd+fnhldu5CCJ4xkS3NnKowx2YGwl17LLwBB9XNiRJVh7gYGhzbUdFXSAkV26nl5iVEIuKWd/dOk4BxjZp1pvLz06ms1KzrptBgcbJM2I6fQlxmoUyxcQJ3faFZrDAnQ8addgiDnA7VkY5AAXwXcu+r8jYf+9iPfvTaB/3yL8OxTa+w1phId+EjHv1/Bmcw/Of77Zdilc+LW+2TYHUP18Cxf3noP9eq/xQw7sm3mz7TSqL3/Fv9TovoYL/5Bj/Squ/9gD/ArfbN+26P+OhP33It6/N4H+fYvw71Bo7zykOvqDJSVh4zuR40HMXKAbwNLMVJMlcJCWat4R1J6QK6F0tsa3TpKVSg8zSeSQi5ak3CNj6jSkWNuWPat9tnbRfeb/rt4NZiklD9BDlDzlpR0oFXpv6jFlp7o9xFWtVIe66s3FxGnPUWL3DmbgP9IxJchTKYrihVC7vrZjJmxBJNAUCSLZIxKbLBYsGZVb20lKc5jBYGCCsKn9GeMPUUcVEo7WegfK2+EE2xeYH4QcujrIrFQxIPHmRiddWvR2HXuM2ouMJtsvuv4AYDJu8s3P/dlNfV6aQtEKyl1U25cHjOfJ7pZpkqxMv+0V/Hz+ja3gJuC9V5Al+be9hBdnV9/YGrYQT1rEEKP6CPxFdaq9cieqO8vijqhV/V5C+WKwUVdf0UewUbq270lDCJchy0HGLA/ZvZejTobFTFSygrTMwgRMW79egREj8dwV4arSdirMdhCD9JBZRKXwRRYHIe9/QbzssljQts9s7aivRhBxpVz1URZ95ct0fn0XWHi+tdVqYKdPlGEZK2qpggdTYahSkM+z9ay3xknsR0WU4fsKKkaZTqmmRs2oBjNijToK5h0IIlYJmIZR0v76vVExOuo40yEWE50Et+QPXDz1B1xERew660BKXvtSCIJna3JzcUrm5WIBkkjM3uTiaSihXfsaObOstQJ7GPAu6bJBjPhEs4wkmUgekHTPLYKuCvQMWgmCWXatMnGw0iW29V0MswuasA7Xyj6ZiRaDbzjEnIxjCFXwCPIQeHzDO+Jxn4oH534FpMgo40TDs26jCDu75BwOmEy7EyuLjlpqu1FfFYc5lO0euaZdHUDg88U3ikjwPZy21+MJmDxYXe/fTOO7LEiHj3oPfwqm6NecTjjWvgnpXqE0Zc4LyPhiMq9/hyslNExMwzJ36ftJIvKC8rVPurOQR5XR5kL3VUnai4A4ZKZaLMjgkylBXMV9xrEasu19yPUT1V2GIie4zHxhAe8zQ7QV3+wKsmJRmtn23jOSQpGJdY4veTPDyEXLkjKjwcNGuEgBv06x8KmWyE5MtMAGfRDi2hEvdn96iDw6BDLGnBosOgZZirWphaMef2SpT1mnfmupmrPR3yFFKQuh8NykkJYujZov7XcUUlnUcHtGYEd2Pg+02qa9UGKIcaUpT5qn8WQDqucZTGHBOLSgJoInUOiSZtmaUOKhWYlXVVS0Tbdk3fNPf/nXQ4k60/Yuki5m0ZIPNh42FHuqudWH/DnVJ0c/vQbAvKOKJU2hNhel3ozP3ZU7qZv3guyq23fHK/gS+PZNOyY2DCkTesHsFf58Z+kYxmM5eJza9MCzFw7VCiLOlbuqx5eozbEMQP9CMXPxhvAFosvigf+CIWWHGcQXjSOLN6QvFjy23xAOn9txgS1/F5LSTpLF/S2Lywnj+Mpk5oQRfKWCc8+RfLXSc8K4vkoROnIc4857BCUYCYle50JpogpIDMwfXK8IYQRGTHk8hKg/2+R6QjNbMJpPvAI6KpQ1EfeURR8JmtQr75M5LIR0z+vmNkAmSBzVyO1cct0qe3U44HShQU7GXaNp3XljjIp26uEhbvYQ5XjXC6k/wHoUSy3Zbvo+AMpfYY3NHRG2QJgFlQps+Zc1EQVwpTLyGphegSRS0SOSKmqkFiTbuEHrI8mAL1ussc2x7BCwtX0sti8jQOdM90WL1MEptuQUufK37Y0IAvN6IyMOO92JuxieC8HbKfCR8L13reNOuL07PR42UpEBUs9IyM5M0wjr/dkIVGFlW/b0ySYznw5IM+S61+zR+S0C61wg1p9uQEtbIduR5u+SKe3DQIBrycCWdDq9HuOyl+ywqD7fXuyOCjpKOUbGhV3sjqxVp7aC1fmrHRBd3OwORxi5PbNbrxfYXgTN97IMdLb1Gz8RXFPGIbUYjvD+l5CIJWd/mH+V9q5J7UmyEIdyPqhiySwRXGlJ2S7xtKNs4rWGo57s2ldntNQr0RFoEGk1zk7JIqNLrK4hpJnzEZukoHo1672lI5jvr+gzy8scO3JX9BCHqljOkj4G1Une0puqTG0hMpas64VqVfYmE8vjlVDa9KqOBc/WzaK1rQYvXWGUDe4TppD9XdpoabbApdCCfDj50/8LAAD//0zkSzo="
+	return "eJzsfe9uI7ey53c9BXGxwEwA23OSkwS7ATYLxfZkjIwdx/ZJdveLQHWXJB53kx2SbVvBfNjX2NfbJ1kU//QfNfuPWi1Pcs+5HpybGUvkr4rFYlWxqnhKHmH7HfkD4HFGiGY6ge/I/wZ4JG9/kOKLGSExqEiyTDPBvyPfzwgh5FrEeQJkJSTZUB4njK9JItaKZFLEeQQxWW7NIO9+kGJGyIpBEqvvzHdPCacpFDPiP+ltBt+RtRR55v4lMCf+eW/GISsp0mJ4OzFdaZCEC5nShP1BEaz7VnXu6vwKlGKCL1hc/MojeYTts5DVf2/Bg3/mJOfs9xwIi4FrtmIgiVgRvQE/RWPqiGY6l7BIhFKVsZps6JnasQNeMiG1ZTpOi5ypzYEsqnxxlyNVaFotYkg0rf3SQ2Ncwxrkzu9qAD/t/JKQhw0QzVIgMSR0S5agnwE40RumSApU5RJS4JpQHhumJVTps2KUIMgMGiDaFm4AwCsEAwSeEIXeUPwfkECoBJLmiWZZAgQFjXGlKY/A8HONMq8FfpYomgLZCKVPLFkxU5rxdc7UBhQBGm0MZPLM9IYwrQjjMXticU4TQ1EPuWuaqSC5o9bjJk+XVkRTphTEZH7+k9tSSEsm4YmJvL42OJF8okkPUBo9Tgj0QWjkTwHX4FQoO4wfDDUDGQHXuAV1EHIs8mUC+yG+tYPSNdTxPm9AgoEcU03JElB25uc/QUyeqeJvtPnY2WwXZCQ4h6iiyybXEufFDHvoiERENFkIydZBzi2FSIDyLtbVAeLPFY9ZRDUo8rwB3H9VBUqYIjgd4xTVnJk/2fassPnUQoLKXhElTid4PBik3YKL5VZDeOckgq/3w3hthiRmSHNCVyD2oFGaahirWJtIzkUMRtVFFNWh5RVOsXM+DkG1SEEpup4Q3cM4NCxKwwK1uyPbdlF1MPxm45fdSnMAZfjn6vz6ljiemfFayKqiiUT8Gmhwmh4mb5jSQm5nISCjFvt9QtdqVxbdLPut/1PSUBpdDOrB9evH+U3FcOyZm3EOcvHaCPzscQQLmUWz3WknOoouzi8Xd7fne5xDUuuxfGie3XciRwNUssxaq4VBJOH3HJT2tp7V8ArOyNWKACsOAf8xIYuPVA93Zxk+syQhSyA8T/osFPzfeJGxDMZugwCRkAoNBAcdYngCjzPBuJ4OwKUb0cxOEiEeISZ5VnI7z1ncg0pkIKsO3gSwfvZD1uxLtDLOZrvTx5soO9oe+HB+u8cGiEVK2Wg21GHhz4UZj6zZE3CPS4F8AkkYJ8J8j3z5Tc/6xHnH8owxqy/cgF5RGy45bU0kZBIU8EKr13fvismGgU/Kg1EULucJkqggEjxWPQSip4eyECRwFONvcDcUbI8SZp0u8sHNVPD+qx5o9quL1e/xhGLx/peLmxC6czOZ/bUD+F+/7AGYAFWwwDUK4ht1hF3dEhrHEjDQgcMXPuDwJXXfD2La3dsDEM3tcFD1V5my2xteog3l64rubd/oNYhKsTWH3bWr8G4X5ACgO+zzc9Q3fwvvquCsUBwA7VMfNLf37UTm8KVES8oVte4ruog82RLqBVQBjxlfh8e9ef/z3bVXAwpH5i7qwxThopCjFQYUzfh+2WKiUGmwXXfN/jBFNpBkqzxB3fLIxTN53giC+gnHL2Y8Iz+CNiqL8oJExtsHNtJBYgHoY/6eMxNPsKGqgmCRSwxOUW3gayK405Vu1uDAuTIoCBf8dCkFjSOqtIc0YOVTWrUIh2ibgWJptcsbRTZUxs8YjBsOyhljx90sxSR+tzjZLL7XCs9uq2Niq5+UHZvYQ0rVerDy69NU+E11HKH4yJRGM6Aq1oZrO5q2qWRb0drAUivcsarrrTNLCFuRTCQs2r7LpNAiEol6hzbku1StT3HysyXGljGoTGOIvwiOhsEKv7YmRF0Jh5n4sudFYfikan1mGWOW63AdPnDdwty4LpeKoVm9JTSKRJpRzjAIinFxaqR1cXF5/vHq5tJq2UK3RQ2X10GDJKmaqc+bLWGaSPgnRLgzS+16+LZ8JQbczH/CsyOBmnLHoyQ48C7FFV+0heSCXLHSqFdnIWLH7PtjClD/dvLkDNlO9+6z6D3UvDDHbecAPgGPhVxECUUDxKzvZxajPzkbPAtqd6oHChWTUc4+n1Tly1ORaTWEnfMYA//LrVFkRJrrTroGrvFSg0UbokGmRmkT9cx0tIGYCEkykCnl4Z1DPP3qjFxpAhyDp8qoNRz41FxyVMJ3/uB33zJ+cHBYC8iqHLfcp1qcOjWa0egRNMaviIQI2BPEZ+ShUF3B5SBEbUSexKVHTiiRIsd7eSEdxdY1W9EI3MXegP0kTdBqYTj5lxADsk7E0jCqmRlAvYzUxEML/xljSQaHtWww18wEeLyz0gP4qPIlboglyL8CF9HiKRGTqwv8AiVPNEGG8hgy4Mgyz4dss1UonoSDfhbycRYYk0SCr9jah5OMiUE1uoy50iIF+cb56PXPRZRjzNZEQILD2tBwSiIhJUQ62aILmVKNol/c/W5NQoGHmWz97S5U4p1+qWKe/X3Wpz07zOXOGOPN7d/3iDGucr57A32YQnfGSeP3k8ggSg1O46XCoy98BXekORADdo2ELNl+brBZsh134o66CnmoXHO8wVszDZLTpLg+E7xdeXs8MVezPmkZLcD3e8ivlpSrRQt7wsvXgQv/XNzc1yNPhXbvWSOpx6aafN9/XeUu+n/PQW5NLpVfwR5Q5gtBWKOYg7JjryXM+FbBMnRajTpHt8yLO/LRzN6H0BieQYj7Z0cgvl/OP87v791RojKI2Grrbw+ckbsqmTkI3WLa+4B5+YEnpyN8Jsf+AANJBmO5Nye/PPyv28sw63DQvYG9HuP2hScD6RCHSJ3fjwTHdRxk3Giz4ldO8ash0F6PcwHkPQDn8yCwATlX4dNonuuNkExTA27O1TNIsmTa5Dc1uOcEk4GLlZU0BC8EkI/OaTc2JifUTbctWFBVae5UNgYEntbKpuj1sOThfCxLwuL0IHPuDmNkRIBkxw7jxWn76aqtGUR5dzEWZXjh7iDKJWZVkQtQTOJJzkxcgXo70K+aty6qFLQElJ4putTSDv1kb6lY5OWVqUHb+25+LErnT5QldJlAlda6iIaIDWxn4sVS5RkaQlWikUbWqymo2SpqOjWBi6rAxOAl2Bun6lfcJZbTsG76HowPDx/VZHYR4ototMFDyV8DF8clVUpEDLcBubtTbqhlaV46bnVGzD1qH3ANIh8gRUOTSwsryUVjXJh3yAWth6oxkXrRJQqjrt2R1WZo5w3UZEJCJGSs9nFhzFgL9LcYfE6YfpP2wFX0edFuPI+SgN8q677Kk6Sy+BuqyBJzk+qZ4q3IQm7r1MjMHG3IPJg4i4/mCN5e7OEIUk6T7R8gp9WFflSrx9fAMZHMXcM8MZHQAabBirIES3UkUCX4tPg0vGgsM7FjF/aMga2Y8nP3ILTx4IWCddo8kAcj/DQbEDJcSZoCBvDUuziL39mZT93Mp67wpi+AOCfRJuePXu9ndIuftGuUYgA1YY+QYBBW5QkeB27/+3hlaOmK1WIJqGOJ9HscHIX6bKBUr/IpQxtzsmIJNAvaSjRBEPplsWmro2HZfhBQaHEwZxhhnGUFUrothfB6wMijgfFXIUORuBTFBYvVdEv0UGaqO+NxQ58wwMzUIEzGZBsLp7mD52X8y3kjbs+5A9b9DdfNFD+dkcuz9Rne0aemGJBQH7AvNl9jDoEmsL2wYtq5M4VYnOAVGrXlNoYBJKN64z6On5VA7YeUSIEIPMYaMzCe5T6BqoeBMWR6E+RfX1ggwDwXBtCicmmGwmUmsdrLLStaKHhlhENhegBWETq85IqT++uH25PQvRxTuwMCub66viRUaxptUJ0Twav+ohnvw4MdL/T9xhzek8PyRqdIH85vK9VrPSz1Z+iEu2TunZTioMPBFIkxJy7OpY9ZGYHxn+mBmbIUFq3BtFEwr1laD0wN2MH4kWlDPzfVOwhkCFsR6p3YHjSH1wI2t8VVU4MUu0BVFEYpYK66whyUFd+arYqiy8YklQSqImfKEFMML7CevK8Umak/OfHum415bBGq0TjOAPEcEUX9ejkHEbIaRgN53BqApozisecHLelC/e60R4y27Rm5EU6D+s86UtyR2QMbnatJizHLYmszKp5xTwwzALRo6h8CfM14GXcdoAyMYzsp4t2qazO2uyeXeIeTZQLrS7UgmMAnmYLSKxyAGCteGV8fgLm5Z1A4dhG7gwgREaUlUKwHoZo843U8Ik9AowvgiuArZ0ImRdSsBSDV06SHRjRYVol4/rxEcqGx5wJ7AmM9C/+JUub+AKnOAhMxRSKTyrMEQ0tCswy1hSEHVUFFKMwHscptidqBKgXpMumNM2MBSCxyPVZv9kQoarvKzEVErquZ8dFe2yyjEpsFHOJsNRf0qnCvqoFJlwoaCa4p4yANPJPH5Wxacx6gGYPKD160pCYgSBXJqMTAbGOiBkt6qE3jb8ZS2VyXOSfXF9+QmK3RRHTa2KBBEjH7qQeN2tAvJ4RD7j/MvzwMzlfffDsxoK+++fYASIUQTIfqY+FO+QSVUtIG7Jfiw4so12K1mm6b+yB5rQEDInJdNUqYuD2iHBm6IhvgmHEodIWM8gwYTIxif8Aobf79IG1eYtOCxEw99gHjWopsO6mNVS15ioErprdeJL00VkX0bLYLaqWzowXHHvYpjs0VyOmU9T8UyPqteJRLPBQMqmHWZUaVCsx8AKpbN2IXKIytumZQfadyJNKU8gnxndsBy+LR8la3BwqV6+lgzOU6N5GOgk0OF1uZIjmmLMIeTCjwQVC78t0mmtXBArqkU58MIBP/3LM/oHaGODfQLW3FP6T29z6U1kL7kABI3+oMhc7ZagUxSatBkY7DpicMfoSs5iVVUMlpXunsHeIblM/8vhJYv7poIakr6XS8qE3R1yXMnDtzA4jjl4VnPpOGl1kPWlRlcMByNqsRJ1rNu+oF7+GYPV6MMC2w4pBDMguBDi1bD9zLl8xe9eMBgxMQN8HZnsuPxw57apeAsB02kKHe7bqd3/9KUhQE9LjEep1gYE1IjFNpKZIm+Fa8Pg6FUYLATU7Hbc5AzPVbHd+ShXGmGS3aSFiOFwGwAbi96LwmaBpFkE2BGc2tdsyH6AgEjqOj9+09WA9cSLxgSJjSwJEIfz4PI8QTET3Hs+GaogfyubOgMMiKkGKGJR3YJsplajFVWlYYZjojP2xJSh+LRYAVzRPtrnbenL05Ic+Yk8SLs9cuYc4TrObFWym8NlmTVJjIFI8kaLONYqYi4aI3PksMXmxvSbLMcQgsC+UVjBgaeETdlfcmfkdp3GZkjdBW3tLz+d7OEE225Jky7de2zITbV4lF6e469q3zANAV4C3M6jZJJ4LQZ54+0277tApSwe+tIA/axucix3Q6lC30Uh/JRjyTlPKtR4uNGp7AJinBC0R5f7YplnChlvcjzIaj7kH8Sw45uPPHoSvvyl22Et585NpEBbagnWS62DyWahkyISZYwNVHSPCIHRzm+NQa5iiucWoqh2DlyRNgJNbNbI7eHpDOCVx0uqGj0F6ArWwt4fo5ioPKe6BOxlnRPqMHNLZmWmA+9sJ9fnyUq4m727RfSxavdFY37s9mO2OQjxhQRoQYzbU5EO8UJgNj6CQFtHuY8nF5qkiuqqN4Ojf6eHGTDw97BU6MR7hoz3MYsCG/nwUMb8tsvDawHegShp2GzDy4yb297Q/94u7TLfw7f3RUi69KRgZJwf6euVo03YnBQtOk5N6MWfgQEnQuh7ZM8rgwyrZorS8ZxWAjhnh/Sb58eamG8UzyX+GmjYc7KRP70R7EX03XaizYT+3JJM6AE1KhcD5RabpPU60lW+YaahYbGkMmYccdKOZ2hkV5QmVbAk0p4RllfSR2avFRa1IEE606ZNEpajVUfRlIXKBCew/tMOKV/sFHzveveeRkUrywKS9T5kniQ3IboDFWGJjTADPgCp/AUWF0tcNpjguHpgezxGZH/ogMIj/otsUxuWSh8WeoUnkK5Ku/fWuFmyb+jqAaSRnWsdAyxtS0Tbhz0fd8Mq4RLoDhrZ3ITK5q6S8uLE1uhFsl68HtoiGmjJ/xKMljZxyekH/mSlfW147eQ7hVZH8Cwp1GfTXCMb6zwICtmnCLcSJkbBRvSXY1l/nqovLGQZnpNARqEfY+Nt4i8D0aqr+wfQ3OmnlGAEW98HmXf2gSG37u867+Xkg/4+IPxVnup4ndCx+1K6/UTZIzumSalYlStdPVW5hLEW8HS8Jnxe2swzBwjxVxJMdyJa9w8L76lCAL0TUJ8m0XWZt7WqfRGeKNT3SL+4AV8aenYWPC1iZzpJivJLsf2yRXpe0A/Q2piZXrTQVkWUygBuDtvK04COXVageXgYxGrRGj776bX1zcnfjmu0UKs7ffHQ0DSMCgwVFJMDx+pjUHz1U7VO8pTnZDGLYK1jdGW6OBhYFFYdIN8ItYnuevGnGSAcSahlLHofa3+jtFdYIHQOMiPhKy3VZJOFPlWaWU6mizP94JkgaOIjY520tm/HQ4gi8DKFPNXXmA31U01wIDQ7YL64oleFErcj2AXatWZokllqUfj1docpyUOS2t28rRPHJnrT6rPOzQmLN9CfSzVFm0mkYSZiF2mU03ZZzk0r7AVy2Go3YWbwfVDmVcVWsquyPQBdiHVMibyrkJDeV713vdXnBjkUSCjY0NsCL8ZEjpQRaQwQNgmS75ZaXMTpa5AVVj6Yb5Jwxd3V/OyphfRypWgZ4lUHpM09ExL70jwlZNUdjYSsVavNXA9Xviv6yaivEgvVijGFdhOmLfmzAexzaSOLry/SfLdiX+MKCmw4zPG0CNN4DgupHVT7anlMlo1me7j/Uq7vZ59omz6HEst5uRshsWPfLy+RUv7OVp3CMA06cZH4LG3XZPB2h8Bq+Jnk4H5FccrsKQ0YnFcZxMh2rOt9hgnrmrq4q+LzD24ImjaDK3OJCg3D1g16DVgYO9qfqZN5CJ/ue29TqeyehdHEWn+KZL3328/7+L8/MiVOVjPp1ewYDs7N4M7c9GbTXvGyn3ad2EKu+Al0pXQUesrsqGtqN8yMIPYEM2GQuCueNDjZYDTK5Ps/1oCqSLn82Gpop7wI8glyCFmvVt8ZHH8U9u/D3OZLe/Jjb77lykFocgp2ReS7Ih967t39v5/ReYCvXAsFUQ+VFS+xhc8fuHH++/KLkYxB/sNHgAcvc02oA3Fl3zwumm9mQPmTuPApXOB9wX+wWzfZV6ZgcpGwHc9qOq7ZiassihSRD+XCLOrldrqzCaWTJ9y7kXChf67+HsE01YPBlnW96QcHWGzRejBxLktqvBatz4AdzNuWbJsbGYSQaAielWtWI5SOLKrhE4h3vV0iBkyoFc9d6zRixrtjo6QK84HgGP5NZ8qOtZaQ9iJeQzlTFt1qIeoGXel4N6vvTZdx6PBA7P06K580Pui8V+PAhkzDbFZK3GL7tXfAB1+OcDVbb3kwFsElgxSue6JP9RZIO960/MrALm8PwqeHczCH+6OO9ZmQjkdOsSNC26h+wats/FHsrHgbysGDPIFdvmresg7LGsjwCvbi6joLrnqxDwQKTuaYLjg70v30CI9mFrAbT7cbG/gDShfQpyINl/BmlSYwF/DqHqAutxpSJe5mrWJzsjvUc7+n49TDueGAozqMcJ731Zp3JZ1BsxhBcsVZwcXzGsv9Eo8nvwhpAO7MxripQWXc9Xhy3RHoC3jb68ZRzDLjAe9tHjaQppSntrZK7NV1zhmAN7NtulJd2q35NjieX19v6Xj/tIZbPi8MAF9zHrQvaYUnmvldhWozkaBvXVhnZ6f4U8LFp9YMTg06ytfrwCwYYloCZIQTBSPE8o8vWOPHS1stX3OMkJblHKh2QJ4gYOYhq1Xu6sdFqrCcPPzIWux5Am3To3ZvS99k5xXzXpy1uVjmlOsfsb3/KCzBdnl1CDCFmUZq+E7er8+nYgKgwPn02JqtKeOdhmDh9/T1zKiC/d9riNYjDP4ePyD0Hu2uQdia2HN8kbQsO/G2Qf2CAbKTl7nRwQq/bCmR/F99tB/rt3b7B3r1nAv1wnXPORv147XLdb/vP3xDWE/rsxbl9j3ECQ4yCdWS+CKso4x2tOLjRMB7BayFBq9B4Ik1aiI4JNnlIsWKXmoqIIEnjtMwiUypdHBYVvZztgPUj4qH3VBDEv7a0Im784Q8L2VOi6D/VAMnCFrUFABz1V4B5Dxxnc2yyUKZ/hOGi98KsLM1sQHSZT7gftwb20VPlYcX4dgNP2uVDTsRBlyw3qjMCyKQ51ect7mf6QUpYssBhtoaADbR9Lm5r9B5tcx9f2OSfTdQFH8e1C0FBIAFsnnVi7lVjKbcosvGQYTcDXqo1A7KIi5THuegcjIb0a2pIb4/P9Cy0eYfzaNAmeW2opnkj4H2b8whHDLhQmbzeiuTMzljlL9CnjFhV+xYc6acL0bgtJgkMZ5Gg8xv5wN98lwIpAjJ22eNgNN9sTpgXL8uONkYtPm/ErrSL8y6SxfajaP+LQw+XSax3L3ZDkMxXIin7eANZflEdi+YpbsX9ziWYeyl8McZ4leAuG7HOS1UMM1gNIUGqxEnLCbs0BxwHBJsDX7rEe5l/gNvLjzABHqCsuWdo3HRBer+jHUmQZxGMdpvb+EoTVPKerWx8kNg6qm9a8ah4DR1HynhQ14cgAbA+Z6yQ9Wlzs4eP1PlEx+4hwkHuj5Pmi8ijx/onQWPc57eH8wY04Iit78ky0Rp94+5qPYU+9WZcRMTf/Kk96gOYuQT+IdBTXdlL+9+Ja6DI4LOJtslkdLkBX94Bdg1YHjhtn5BC+DeSf/8EXSZt8dPehplD3/MP848fLmx8vW9i6C5uDXrJamu2RoN+A/uHq56nhawlwfOwPEmB/4AWLj9f17sY2vTubdcuqR/IE5p3yWYhRA+6TmgxCOxsxuIF9iOLtlyfkqxPy9xPy9RdnQSRpM5N1Ahg4qrNz6x0Iq1MrLanO02lnd4NiJ3WWUrl1snFCFESCx9V/AR2dtXAlE0kyoe0EJKUvLM3T4pVwsgT9jE6QOwvYU9Fpw0TOLFrVgk5CxFrlZyzEYlQfHlFbpSElUSKix15MUgi9MNb4dKAwvkqkyHl8qiXLnDPhQrgSViBNPeY+AJnKJsYXM5W5TTcWGawmvRx8L2SxDf52Qr7GbujmSkp6Tw/3pHGZYljm9o3jzlG+PCFXFwSbv645xG10Lrfkan4zbw41X4qncmd+eYJmEic5RqHJ1e3T11gFXLPDxSo0QXPgG+H7W2unePytFYlZbEwwd1eB9abWN9zi9RSVayDARb7eOK+mVgh+dfv0rQcD6qQxrzILvSXeMV4xqTRZidzHZB0B1xff4IvlmxaC/t//+b+qNldjorfsDM4I5fipr/2nbAMCG3oiHNAnoZIlW/OhFoUmYbVAjzgs/FTvK/ro65lVbCgKNHCxj659E1WSSEjsaR6GJeR6clhOGlzCYwHSpQ3j47RUVt1tFBvXlS6IUUJ0LIzObmlgpFKap5+LlleIcddIr2J8SfXrYXSJY0FGdoHkebqAF62mO/DLaz540fh+lHAtSRR5a6ON1N3AlB3iMyoVxJVt4uGJSO1pHn4abB7+fH6/2xSZkJ85NiUm52X2pG/3e+u12Fv84hdn+NEtiSSYfIQiTc4FIMO5cF3mJ97THHDkNEOJJo+VxV7PIWpUp9XXzqrzo07cGSPM7jZCqoPRZC0k05tdQ7KPpAFkFfn9xRxFPYIP29nENomvGttPYid9808/wRb/peRAKwV2iFb4IaZ0MWaAlz2ENwP5U/DIrb0l5o1pO4OVkTmoDcRdBYK7mB9h+5kgZ/kyYRHGo1qgeogKJKPJwnpZs+Ege8Ddm2Er98u4mYrkwP6c8AKeUSMT4jLjHQBIwpN4hNlQ6e7b8oGjrvO4G0Bi9dgrH46pUOiyl5COip5thYiX8IK3ggwvw0Ccd2Zsc+6Oheph5lmAXQcszIapVpoPWBi8JmosjrsbdvlJG/FsGgnZR2q0qLy+0TBBW/Hz5q3hVPgx8UHpHTI4PFf70RO6xJeDK8SJVXNxfTOdjkfzPT21lLjwsnaYWF0WTQb7xLuCZWmDFVLT8HsoDPw3qoh5Wec2wI0qkkkd7arVg62PhNS4IO5FGvOfA5JcUhptGIfpcCF3NLq42o9dOslFfy58kZa5d9N6ALqPTuph+B0dAObMXKp7YAk1LcvQQ2SYpCUywGtjzHo0IbAeHCpfWg94WjjFsMUzVwVADFbkRRvTXgFjagEvMOF1lypXjPr3l1DaTdKOaU9PuWvTaPD9j2KINnzffr1k+kgIybdfn2LrshJnD55cgVpQlcjpAF0Id19uIOEdOD5SN3cBnfuMRkA+0i1q/zvKY5GyP8wLJUOQxpAdG+gFtpq6NPzDAPGtBOyeOBAfJm8tjH8vm1kik0EFPEYxsoZXD8VsJNpA9KiGwFSwORY2jBMqLfPIPhFSFuNtqHmTrAfehqoFS3EhFnradgJ1mMZiopzYuciQrYLQ4OX1oMHLXtAieC1gxbs8NVttKE4Th19gQ7fj4iRmokHsc9l1h70V8n1rsWxh2vosvhO8JzH9/wOnmQclacyOV0x8Z0bfx649NEGj6S4gf/yopgTP3UkEmFIFktJoOgzX83N/17AHhJWkKcSmOjgIhWX7oUBO+BsPm+Pj7p3q6VguQF0DatPVmCIC46aUbBjXZTD9bn5x9Y97H9jGPK/WUf0tS9Xy2gjuAt4OXQ9fMKFRw4Jlk3DlzoxWuSwLEx6JJLElpQXZDzk+iXxq+2ucXvI4E8iWQnH1kOFKfBborgYpGSVq53ZU4wTXKCk+GQRjYsuHPBL3abbfY93RhiaY6ggli1dl51y1cTEH7XZv7YnGXp/UNjCbjpT7Ir8M7XEs7Ye4hqg3IVbrZOwtTRPOw6ZMxC3yHspr0+LSi8fVtOD/mBsiTvH/Zfo/ipXBsgBu27lVmpC7NFtIM709MfWMQLmrjakM6ucSsrzHQp8T9/iATt6JWK/HZ6R21aMzfNBWEZpgCcbW5sfb2cyNgmvKzNZc1B4b8MBknB3tULzYK7MpEuKRTXgenpvx3PvWuSrBuftdF+Aot6XeZq6/NC0O0hJ8EPPk+88GJu2wxfVoWSN5Rq70G+y6kbKXYkPcXdwSDmuhzf70DTm82CsjBj+enxcKyURJSin2n+sh1b8Vu2gp7z2Eav8MbZGDEW2E2k3S68H3CNuloDJeJMYZng7dT25gYgcmbxPK1zldwxfeEN0RqGKEIM6OgObuvmvbMdXhsLJil6A+ggcQjX9QrhxpPlDasY2KIVqhuicUWy4WJwDsq65GocukiPMo0BVgInC3dnzXrGkwRA8vBvWoRTaZ3DyzuKMPX/i0HkjphYVqpxi9Hhtg640+LkI7x2iIkUiEDD61NpHMoP1jJnHPTzsjpLEHXRnxhq03iwooe9y2EHKUJoKBVgoTseJqtXMU4ont6uFsq0/fb4NT8+g8aq+yCWj9uRBfyou0B5ONWwk0FaatFB4klPX6cITm3ogh//Psm7/9N1MrWLZL5wTDqLJ+wRdtKOMDqMhAppRDByVho3ToWu02iChq2KpgRa39G6aaMu5ecbbg0AXRgBFFKvvSKsqVnoXIGSPLCTzVHiicUJgvC7R2FiJ2pXvAIqagNyI+OkA7zWCEHl0sOEzn7jxIGj1WbrbRwcGtj5u8hNRrwqpkOkhvXbwh2HtOxtk7X+24UCqpN577ojHY+4Sud/iL47ouOagFbPOc+/uPZ7NdsuRqOesT+LEu3Psf9nHhnJkYZPKYLRi0lbuH7Bq2OnRK/9koch26gQZIh/+5xmmKPAO/iXZSXbuBMv4qQBkfAdSDtB7arA3hX3WhOh3PBtDPuFCDPORA5+325WlbGj+Yqz6atdEa1qkD6fytXgw7phD2tU7Kej+9HaTu7OywLQt2bqiExSqh69lwbg6OEfqdbCOFeBcaJblCKxljonbyeGDvP+eIHtQw5dOsy3VXkcQDz+eu+Cw9Knu5GHJquyzzPgZWHdjxqIKe7AGwPtS81kG4PBQsw6KYSjDr0wEjDYZ7P8E+ZsO0vZpcG9DdNowF6cRkoJTYgpAUWx9Q5tB+eV2sWoHGiLl7o7QHlAF+yN1VE9fcPstYfKosJm0gPTUgcS8OYmC+nBbppe+OSTK6RTsai88o+oiAv/Gw+2Cx9SLkww/ejl2lRIqt1QnJVW4uEcxFoMpTU0ts5uzBhp0ujgcOR1cnFhVtw1WyKTuahrja61ao8lZHMNJ2AG/ufNWmDQplLAMsq4pdsM01b6r5ZD5f1wXi3hWXKBWYJWVBghR+lbe0bt3lcRt3prdumuzBn19BLk1ozHcqvL+6La5d317d/Hr1cHlC7i5/vLp/uLzbrYtvhRysfpkI8jm+MItr6vTX+T38jmcwjUGWl/Odno2HmUs2G46vB9s/7q5qnHRc7IEQyI8+AMMuby6ohpG8cfCD2CZ/vStM7EiBcMjJeynSgnisB//OnNKarv+7uzTGo/mNKhQ6zTJ8kLKoYsfeZNg7RGGBOsOOU0SsVua6FbMCMEbVeqRXSdfidQh/EJ7cAaCw+fJxYJUVGIWpYbQndnNFlxZnPiHVftilaFr4agB+0+cP8246yAj0mhzJWvf3049mwtFbqqMv/599T7lz8F9uUxWU/4l2l0v0+JfZXYOiXzbxryEWXSzvgboL0+QBnvbKgQeEqUaT+pi7eM5pkpxeXYzURuFXoSaE554YGwkPE7QWdA38iAix5d3pHOcYy8P2auo/19uxrnsETtB4h7Bzd1XhNd39vvXYD53XZ3sB9OCeqeSMr48nLL/ZCbyk9MDBJ0EwmDPt2xO7mNzfTx/MkLsivMs3j02ly0X9VamwyI4MALjh9wkCuBeXxjKq+52r6vsUw3Z1vlwcBZHKl+2g9shx909nTYfu3MEqHuVqwVd79SmIrUMlHvL0lDndvQ1Z1Hc7bvZAklqP7OzWhHOHPecIWq62SLnYbN7r1KJmNvZAc9d8QXij2PVreW94f/1DJcN4CKN8UvR0cCpP5UNcjG9FqaNJQU/70AMAXbkA3+6LEzjLTg6ZhMr7jEVfPPyVaxk1kKs49OLA5/U/db9YgVDfxkw92ouhE5JJjJhK/1fkZGwCoI3elkHIWJoahBqyaoZwvNTGRaO72L3zcUKUFvL/k3d1vW3j2vbdv4KYl6ZAk0wLDC7mpUDqdKbBJE0QJ/feN4OWaIeIJOqIUhIX58cfbIqUaIsSKZly4pyZYqZtbHJtbnLza3MtAi8zgL3upOeaydBfbS3rAFpxOEDp0FmVhFfHSxAdFDbpiHqCdSbKLrtqxFYoIwHLwpqi0GV7Z1aw9QDu/uK8wWgYdhER6KjgBqMVlqnndfUPveD8xfjj1od2PQyWagk6N6XcjueCJgJs6jBdR5ntFWVGAkKfLBAVNB4vPs/ZEhwazkOK4QGhxzleU3ldYE60PDseL06h8mNgmLepu55LYEgi3Vy/2E384maiy2XUABu/eLVRM2sOw49PbANq4GofVhkQKXspLRvjo3MH+jrxFRMVnmXLMY2Dp5tgLs6r2AfDy5WKZZeF19fJjpNXx7nlDiBucP6A0iKK9ENIWA7VjbKtEgmrMXiZmrHYBjUjT5QV3LOyk1yuZASKlRN51Xpyhah4thSFwgdeoeliM1TIOf1FJo4nqha039Y5ZHD8qrI5HDoa7Fv4xHWOtQAAYjye4zjlZgTdk7SCVL6sb7yf7aSRcgCn1snSR/L9Ptjf0kQ6puABJ6uxIZWVOEMSLzvHhiQqcYUUs1CIKo2LSdSy7sKk8BRedQvv79uCOcTV8+n309ub6clkGwRMunEpyTixjbHh0+78Sqo+9ph4/cZ3PalThHUovoZiRLDjRvhr50Y4I7rmLfG/MS7fnokV1dw3sxtM2YowXcNva0+wa6dj5s5DktnVty+fVAqxsK5imaRJEBWwSBWCALOrb59heqydIZq4Ubb6NuiXBZLDQJUE15bPJNJSvisr4/EEWmZXd28lY+0MZF0TcYhYrV0FUFFPxSa5cfErlyg0qWXUtYS2ZzhTQXER5TStJUWhmTP5/XLdVRtvtPmBRCNerP4gEXO7VhGah4a0hx3AfIcyFWUO4WgpTnhlEtfV2cUl+uv2+soNXhak+TxnewJ3O725Q3fXbtAM83DrBN3TfZBl5gbCr+e2cUBuihuOMbME7hy7chCMh2E6dcOwp5wJ1yaJud+k/G0wV3x1ce4GhSbz8RvnIjlWOSVuqPaVs+GG5mWu5P5BNJ6m48H6/+Pruqbjixs3fIJEal4edJJwKLp/W9GJetCtrMcNWyn7tAdwZUU90YFKT9n//QGDdTqUq9YgNb+zTGeDg7rNG96hh1U07Q/NczKdvzSmJtz/FcnLjRwmiahG6NSIecT9vUisiSEq52prUXicyJ+peAkFji5lvu4uZxaIacbg4KYaLO0LwB1BSzaL39RgKRcsvylXK6linss9jARmXz1jPpfkSLBaf9qB69gCX+Zdq1pq7Ww4+/2EFkUuHtyuiSXTViFfFjTk/nruUdpxC5Knp7Cv5jYuhTNJBktC9ESCnAk5FPimEpa+OJeUCjjPsepseT3GLTZTPn8mC4O4uM8+psJNebIMge8hE5pzGMnKxTVTtsS67r2CyJN4vO3wz6te22HF32hsLHuKTXPyh2iMY7EPVtrhTWrIFAePJEcLEjHIylMeFuDlO+eKv1Xqa8A1iMMVyI4pOWZ7ZKHQUwVEKyeRQhOwOC6StoAxGE9VrNJ9lLNJ2bgComzh7We20MrqIXnFrAiGwDQJsm/Kxg8cHT19Fk54+hJ8RDiMaUKF1CN9IqeKbrAkB35m2eMJmhGCbv+aos+f//gfePgpfv/n758tbbQi5sl1e0C09WS9LJnGtR3zbOc6Ds3epIB6whmFBCi0ACqZZCXkXf8m+a18fPg3yX+SF/VHdHN+L+OaykNSfqjqajVrUUSP87dg27cievRhkMqve2VHlSBO1W96m6TM4e+pC89IPtTFygaQzo3wel5Gp4nJikGx70wdsGufU7EPgpbsV7CMJpIG24K0SOecJoGvszWznlULNBREmMYcUeCMFcTARQpnwMYlAwse+cTWv4auGa6n/8z6LBq6Jllz97QssCpdTH2mBVQ1IiMQ2J8ZUTh0ryaKe5ngWmlByrEIwsJsRSsi8DRjL7ZE6hRzbkCwA7obWaIPdCOleUsGOBUscA78gMBELzeLrvCkZUZ8Q2JqZ2qi2WAHo+FXSbqvAJNQjiV55H+CpmLLCaJuiboHgPs4gAVLpAVrvXHV8cOYbcXvOB844oeqLN5ZwBXLxATlbflGdkoB93A8soHawRsBToGoZt4ZcAbtes9JTrKYJvW2V9VRXSLLylVufU29cTLZxsk3lIHN/WXo/DX70Wf26qD4Nvc3h0l/mGZhSDOyUy6lwWmqSLUuqk/wKlUFecwEhxcwswU4EqOwou0EapVGwUIPLocJsvy0SqZTw+n6/u7b9f3P8xN08VP8pv4RCMbLOaFRLEtTxqlIg8sLFwULqHveVBLe0X/lIfYHDo2O8ociXohUEwsWI6+iLyS9epL8sBGKORxZoMxmP1C8Qbp49BlmrC+2HJs21e72GcKCZErTB5LV5crzCioThhs7ErdpJxClNn7c7T4HtMqNNeNxjRx2V0Xro7INdCyGs1aDSz1C1CoZgrFlIPonSoCKYFx+4DVMB3yPZD0nL2WK5ngYIV6oWoa0YlPfy2cD0pU4Xj26Opt+dEU3Ch+p3ArwVlt3WjNtnmpssXyWVUPOFGILEbFDEJUhGaSm4ugZrznCubHciMCNJ0vIp3JfjjiLVbfkKBY8j0pJM2HNihHWs+H0f4ROTCnHiOH6gMHFjrxUhSseHEVyAucE4Ywo2RiuIzGWrNBJZTOUP7PjJRYXLYAPHZGT1Um9kjv7eY7SYvFI1m2BXXej5HVt9aJ5nenYY7XXptB8pcrOyaSBYYMm3NwlBy8kL30dg5jHrqUVZrPL07vLWTXlVu9XnW77jFOaBzRluYgXNCd9IRXZE/GH6HsUQUQOynK3saDnBzhDEycN36fnP07hP98tCKGXxcTjrqnkimdI8curvZNcp0gJf1Epykh9LgITSYxzklEcVTOKSBY0jCeCs4iSTF/cV58xmgn69ztLQzUdAhcctSyUlg0iFKK0Ux+cppEa2BFekwwlG18lLzlJHE6WCQeJU8ofhrvsq6PLKEecR5Xfqrt4DYLGcx3ZzrSecETD9qvWIZOq8RTP5lGH9oBftyL6KqkTpQJSG1Hv+K190JkjZ++AP0n9UczRdUqS2exS/wJgtTgVMqLmOCKNw6AugyzGXMKaQ5RZBzhxJxUWmRpO1jZ32KEO6XGGx3q93Wdewm1kRckzMPFAReZesKyaj+pYgS7yatByPfy04ERaYlN9BiKPrNo32XoLwGiYCzGcXdvB3I2nUPRWL248F5ZW5kx1V+BfgJEYpxHJ63W/q+BQbdTclDLkyTRj5o8+WEUWEK0Y++uLBHtbOBhJOS9I1mqaaTg4Giap0lTvBUrucicCf5LqUJqZFgPso3HDdSyOpZi38XN2zzkaqXiGWCLPyr0Ya7GtSPJsvQ+7REUi4u/DMHHm2swKGsGyS1nTPqxi2Qon9JdpjTOCZddabfu2DkdzSL/ar5E4ggzJfB+2wsKSjG8d0BqKSzchQJcETQ0GnwZ2v8IYYyLojfmwQv/uLnkzwf69hPfxQt74tpgC+vsO4e8waO9mksMNvSk6twXL5vZm0r9pfO3Z5KX7f/merdyzdT5tOoQ927YB9r74libuHY212HY4e7Zehh3Mnq2XVa8/xe/LugPcs/Wy9RD3bFYD3+CebRvzYYX+3V3yZoL9ewnv44W88W0xBfT3HcLfYdDubZKOnk9ssXhgLo4ovE82TkoaO4WuJrTcSd4QuGCD28IVSUgmXtAqgtoTdMV4DhqiLEMg1ZzbmSRiErNGpOxKfbPgO6ueWJclK1b7aC2z++B/V98sqMpXwnziOqe2TXFVeYpSofGJDpJUB2s3tVIl6ro2mROXK46Ssu9Au2ZPWMvAa8UdZgwEAveCWtbVjRlSUDKC4e0tiijkOmV4uaSBgy0tpDTjGCMSE0SmGkERTR69WqUsWqxz4q+P7qV9BOSqqlFapc4B8dc2eZC2NoupvK4y9XIFo0n3RNeeAOzY+PDrbnqjpffwzaBYBsqWVt1GbBJ63gNgMZ7K3mLoKR3YFe4iPGwP3p8fmAfvzz17kAYb7DCH58KL6dWB+bCBeJATFWihv+8vJlernEn/Nug1Z5W4Pa6q/lWQYm+wRWbfA34CuiGSyLoHmaDgA5FD5s+PbX3Za2OUmBEH3qwiqhpgmP9aA4YHyFMpwlU/26kxl0ZYvLMti3IAzhGQd58g9uuWEnRZZ7SW1FcORFxhwtsoi964m85/zlRO8MF5awM7fsYUKFcRBi0d6wuFjGDOSbyI1vNWjRPfmwov5isFFVj9hzjHsNivjXHwkUEwbySIQiVgGMYMr/aDUVLHLTO8EmKig+AWyWPCnpP9IJaVGZyPjpQUAkuiNbq5OEOLAi6hUCZebybs2fagPVcaOfOo4YEuWyx2XOLVBjHiMzz5DCIWPArSPemEqnJuPyVQgPmaR2w1sQ3doeeyovQ+B7NLHFDD1Yrz4ewmNvh3VmJQBVc5J24MoZw8kWwMPKrgnnjkp/zBgefQaQTvSHJ4ZNdAoSrOiyQh0RthZfErtb2hryrMrHEYq/etaacDqPh8xR6FBWI/rD2dVRieCc3Csdzxf1B4H4cY7qid26MZ7u4eNi+dhK1tDWLEg8OQyltAmiyZP2hnVcEICs5i+Xw/gNw8nKzVo7sSspOMdsLyNpWknQiIq5epJRZ4vYYjzpBU3AfiHuCyErVbIPq9LgP3ptWVmRIWUHdmAm3NN/tAonRZRLCmkLdnKCRpxNaw3BATHRZctDQoIlzdsKEELv8pRw9YCJ/mmWAnhoe7UKBKQlxL4kXzpy2NUicy+mwaITpGohDg5ExSjz/RUD1Zx6prce2yUc0haZGl8JaaLYXoknxGnazK73BoVT0BU9IFKbLzRUWrDeVVEkM04TlOaqUt3UEVVMUzGJIlTUgDasCSgKR5gaNojTBS0ESZuFZUFJ8/mWy39Msfv/85VqiDsvtEOp+iJWKBT8NK7Em7Vq/hGFFonzTC2W4cBzDfMKfBZlBbsEI+gDUBa28rO++FbT3sgFeXwC9PIFraTEfDBWVCKxizFx3BzETZPbCMnqd2Mtn6+w5/vWqqVhXipNyV1r0R37bFAv2Vcub8mfAK2WX+wL9iStk4RrxqHpk/k14teWw3E8Z/23EhXvqfTLb++hAjZdlIJe6ONn3z4XKAHW8sZg6w4I0Gzh0tebPRc4BdbzKEOtrhNt5tVjhYIAiJjmJg4eQpCSA0fZS1dunl6xgFTU0ruh1C/VRrN1EJcF3Uh+YDpwCDQplVGaIH6A3lfbQgS5bJ7fVmN4CDnPK1aEcT67iLJG/IXo0HHC/hVGYoboW5i0G2rWO4+LBiO+1sDy/DWxdSBxq/qmpHtxmOvkdA+Q8wW69T8gkO2ABmijNOSpWfNWIpSYDu7ojQHOiWM44/oZBjiFok+OhoSUSSVYM11vVwoKctZV2waFrQvC1bRAcHr++w4Mrv6hu2FndAqPeHqlKnXqGgkpeUJc0n8J7wfZeli55wOzs71oavAzgTqacnZFMoWsD6PnVApRDxxnl6e/ywoJDHLAhHgusesl5EJTXrnPKsEV53fFJ4w4SP036XFPiKyzQQkuQZBe2GBM3Ofra0oI6qyOi4qO5vL/qjIgYpR8+4RBX9kdG0FRZNd0N0cdMfDoO4PS+7Xisw8+2TI7q7rKjobLXeD5mzOaYJCUsMn8S6JSMBWyX0F/xtBtc5cJsASOXoaLFIWbOAo+t5wBKQHKR98mkthpRn4lrBXke29tU50G0zQ6KBJ29Mz9ASuIZBXYNl0OYtTarjS3H+MG+dpW0ztAOqK/xC4yIW2styOdACS0GK2GoetDGomlvIcuN2U8vUpiyiwVoXquXRacRWxyAqALXyY0gM2hStbRR4KYVRtrhPgCG4SIVQA5zeLBEnOcoZ+utk8p8BAMsDStQ="
 }
@@ -0,0 +1,35 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package correlate_suricata_files
+
+import (
+	"errors"
+	"time"
+)
+
+type config struct {
+	TargetField string        `config:"target_field"` // Target field for the correlated flow and alert data.
+	Timeout     time.Duration `config:"timeout"`      // How long file events wait for the flow record of their flow.
+	MaxFlows    int           `config:"max_flows" validate:"min=1"`
+	ID          string        `config:"id"` // Instance ID for debugging purposes.
+}
+
+func defaultConfig() config {
+	return config{
+		TargetField: "suricata.eve.correlation",
+		Timeout:     2 * time.Minute,
+		MaxFlows:    10000,
+	}
+}
+
+func (c *config) Validate() error {
+	if c.Timeout <= 0 {
+		return errors.New("timeout must be greater than zero")
+	}
+	if c.TargetField == "" {
+		return errors.New("target_field must not be empty")
+	}
+	return nil
+}
@@ -0,0 +1,355 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package correlate_suricata_files
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	procName = "correlate_suricata_files"
+	logName  = "processor." + procName
+)
+
+// Fields of the decoded EVE records, as written by the suricata module.
+const (
+	eventTypeField = "suricata.eve.event_type"
+	flowIDField    = "suricata.eve.flow_id"
+	flowField      = "suricata.eve.flow"
+	alertField     = "suricata.eve.alert"
+)
+
+// errSplitUnsupported is returned when the processor is run by a caller that
+// can only handle a single resulting event.
+var errSplitUnsupported = errors.New(procName + " processor requires a processor chain that supports multiple events")
+
+// InitializeModule initializes this module.
+func InitializeModule() {
+	processors.RegisterPlugin(procName, New)
+}
+
+// flow holds the records seen for a Suricata flow until its flow record
+// arrives. Suricata writes the flow record when the flow ends, after the
+// alert and fileinfo records of the flow.
+type flow struct {
+	id   string
+	seen time.Time // Processing time of the last record, used for expiration.
+
+	files  []*beat.Event
+	alerts alerts
+}
+
+// alerts summarizes the alert records of a flow.
+type alerts struct {
+	count        int
+	signatureIDs []interface{}
+	signatures   []string
+	categories   []string
+	actions      []string
+	severity     int64 // Lowest, most severe, value. Zero when unknown.
+}
+
+type processor struct {
+	config
+	log   *logp.Logger
+	clock clockwork.Clock
+
+	mu sync.Mutex
+	// flows indexes open flows by flow ID. order holds the same flows by
+	// last activity, least recent first.
+	flows map[string]*list.Element
+	order *list.List
+}
+
+// New constructs a new processor built from ucfg config.
+func New(cfg *conf.C, log *logp.Logger) (beat.Processor, error) {
+	c := defaultConfig()
+	if err := cfg.Unpack(&c); err != nil {
+		return nil, fmt.Errorf("fail to unpack the "+procName+" processor configuration: %w", err)
+	}
+
+	return newCorrelate(c, log), nil
+}
+
+func newCorrelate(c config, logger *logp.Logger) *processor {
+	log := logger.Named(logName)
+	if c.ID != "" {
+		log = log.With("instance_id", c.ID)
+	}
+	log.Warn(cfgwarn.Beta("The " + procName + " processor is beta."))
+
+	return &processor{
+		config: c,
+		log:    log,
+		clock:  clockwork.NewRealClock(),
+		flows:  make(map[string]*list.Element),
+		order:  list.New(),
+	}
+}
+
+func (p *processor) String() string {
+	return fmt.Sprintf("%v=[target_field=%v, timeout=%v, max_flows=%d]",
+		procName, p.TargetField, p.Timeout, p.MaxFlows)
+}
+
+// Run returns an error because held events can only be released by a caller
+// that supports multiple events. The publisher pipeline uses RunSplit.
+func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
+	return event, errSplitUnsupported
+}
+
+// RunSplit holds fileinfo records until the flow record of their flow is
+// seen, and records the alerts of the flow. When the flow record arrives the
+// held fileinfo records are enriched and returned before it. Records of other
+// types pass through. It returns processors.ErrEventHeld with the released
+// records when it holds the record, so that the publishing client doesn't
+// acknowledge it before it is released.
+func (p *processor) RunSplit(event *beat.Event) ([]*beat.Event, error) {
+	eventType, _ := event.GetValue(eventTypeField)
+	flowID, err := event.GetValue(flowIDField)
+	if err != nil {
+		flowID = nil
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	out := p.expire(p.clock.Now())
+	if flowID == nil {
+		return append(out, event), nil
+	}
+	id := fmt.Sprint(flowID)
+
+	switch t, _ := eventType.(string); strings.ToLower(t) {
+	case "alert":
+		p.touch(id, &out).alerts.add(event)
+		return append(out, event), nil
+	case "fileinfo":
+		f := p.touch(id, &out)
+		f.files = append(f.files, event)
+		return out, processors.ErrEventHeld
+	case "flow":
+		elem, ok := p.flows[id]
+		if !ok {
+			return append(out, event), nil
+		}
+		out = append(out, p.emit(elem, event)...)
+		return append(out, event), nil
+	default:
+		return append(out, event), nil
+	}
+}
+
+// Flush releases all held fileinfo records without their flow record.
+func (p *processor) Flush() []*beat.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var out []*beat.Event
+	for p.order.Len() > 0 {
+		out = append(out, p.emit(p.order.Front(), nil)...)
+	}
+	return out
+}
+
+// Expires returns true, the held records are released after the timeout.
+func (p *processor) Expires() bool {
+	return true
+}
+
+// Expire releases the flows without activity during the timeout. It is called
+// periodically by the publishing client, so that their records are released
+// when no record arrives.
+func (p *processor) Expire() []*beat.Event {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return p.expire(p.clock.Now())
+}
+
+// Close discards held records. Pending records are expected to have been
+// flushed by the publishing client.
+func (p *processor) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if n := p.order.Len(); n > 0 {
+		p.log.Warnw("Discarding open flows on close.", "flows", n)
+	}
+	p.flows = make(map[string]*list.Element)
+	p.order.Init()
+	return nil
+}
+
+// touch returns the state of the flow, creating it if needed, and marks it as
+// the most recently active. If the maximum number of flows is reached, the
+// least recently active flow is released to out.
+func (p *processor) touch(id string, out *[]*beat.Event) *flow {
+	now := p.clock.Now()
+	if elem, ok := p.flows[id]; ok {
+		f := elem.Value.(*flow)
+		f.seen = now
+		p.order.MoveToBack(elem)
+		return f
+	}
+	if p.order.Len() >= p.MaxFlows {
+		p.log.Debugw("Maximum number of flows reached, releasing the oldest flow early.", "max_flows", p.MaxFlows)
+		*out = append(*out, p.emit(p.order.Front(), nil)...)
+	}
+	f := &flow{id: id, seen: now}
+	p.flows[id] = p.order.PushBack(f)
+	return f
+}
+
+// expire releases the flows without activity during the timeout.
+func (p *processor) expire(now time.Time) []*beat.Event {
+	var out []*beat.Event
+	for front := p.order.Front(); front != nil; front = p.order.Front() {
+		if now.Sub(front.Value.(*flow).seen) < p.Timeout {
+			break
+		}
+		out = append(out, p.emit(front, nil)...)
+	}
+	return out
+}
+
+// emit removes the flow and returns its fileinfo records enriched with the
+// flow record, if any, and the summary of its alerts.
+func (p *processor) emit(elem *list.Element, flowEvent *beat.Event) []*beat.Event {
+	f := p.order.Remove(elem).(*flow)
+	delete(p.flows, f.id)
+	if len(f.files) == 0 {
+		return nil
+	}
+
+	var flowData mapstr.M
+	if flowEvent != nil {
+		if v, err := flowEvent.GetValue(flowField); err == nil {
+			flowData = toMap(v)
+		}
+	}
+
+	for _, event := range f.files {
+		correlation := mapstr.M{"complete": flowEvent != nil}
+		if flowData != nil {
+			correlation["flow"] = flowData.Clone()
+		}
+		if a := f.alerts.fields(); a != nil {
+			correlation["alert"] = a
+		}
+		if _, err := event.PutValue(p.TargetField, correlation); err != nil {
+			p.log.Debugw("Failed to write correlation data.", "flow_id", f.id, "error", err)
+		}
+	}
+	return f.files
+}
+
+func (a *alerts) add(event *beat.Event) {
+	v, err := event.GetValue(alertField)
+	if err != nil {
+		return
+	}
+	alert := toMap(v)
+	if alert == nil {
+		return
+	}
+	a.count++
+	if id, ok := alert["signature_id"]; ok && !containsValue(a.signatureIDs, id) {
+		a.signatureIDs = append(a.signatureIDs, id)
+	}
+	a.signatures = appendUnique(a.signatures, alert["signature"])
+	a.categories = appendUnique(a.categories, alert["category"])
+	a.actions = appendUnique(a.actions, alert["action"])
+	if s, ok := toInt64(alert["severity"]); ok && s > 0 && (a.severity == 0 || s < a.severity) {
+		a.severity = s
+	}
+}
+
+func (a *alerts) fields() mapstr.M {
+	if a.count == 0 {
+		return nil
+	}
+	m := mapstr.M{"count": a.count}
+	if len(a.signatureIDs) > 0 {
+		m["signature_id"] = a.signatureIDs
+	}
+	if len(a.signatures) > 0 {
+		m["signature"] = a.signatures
+	}
+	if len(a.categories) > 0 {
+		m["category"] = a.categories
+	}
+	if len(a.actions) > 0 {
+		m["action"] = a.actions
+	}
+	if a.severity > 0 {
+		m["severity"] = a.severity
+	}
+	return m
+}
+
+func toMap(v interface{}) mapstr.M {
+	switch v := v.(type) {
+	case mapstr.M:
+		return v
+	case map[string]interface{}:
+		return mapstr.M(v)
+	default:
+		return nil
+	}
+}
+
+func appendUnique(values []string, v interface{}) []string {
+	s, ok := v.(string)
+	if !ok || s == "" {
+		return values
+	}
+	for _, x := range values {
+		if x == s {
+			return values
+		}
+	}
+	return append(values, s)
+}
+
+func containsValue(values []interface{}, v interface{}) bool {
+	for _, x := range values {
+		if fmt.Sprint(x) == fmt.Sprint(v) {
+			return true
+		}
+	}
+	return false
+}
+
+func toInt64(v interface{}) (int64, bool) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case int64:
+		return v, true
+	case uint64:
+		return int64(v), true
+	case float64:
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
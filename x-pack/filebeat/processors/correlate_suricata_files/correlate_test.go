@@ -0,0 +1,159 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package correlate_suricata_files
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/beats/v7/libbeat/processors"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func newTestCorrelate(t *testing.T, maxFlows int) (*processor, *clockwork.FakeClock) {
+	t.Helper()
+	c := defaultConfig()
+	c.Timeout = time.Minute
+	c.MaxFlows = maxFlows
+	p := newCorrelate(c, logptest.NewTestingLogger(t, ""))
+	clock := clockwork.NewFakeClock()
+	p.clock = clock
+	return p, clock
+}
+
+func eveEvent(eventType, flowID string, fields mapstr.M) *beat.Event {
+	eve := mapstr.M{"event_type": eventType, "flow_id": flowID}
+	eve.Update(fields)
+	return &beat.Event{
+		Fields:  mapstr.M{"suricata": mapstr.M{"eve": eve}},
+		Private: eventType,
+	}
+}
+
+func runSplit(t *testing.T, p *processor, event *beat.Event) []*beat.Event {
+	t.Helper()
+	out, err := p.RunSplit(event)
+	require.NoError(t, err)
+	return out
+}
+
+// hold runs the processor on a record it holds, and returns the released
+// records.
+func hold(t *testing.T, p *processor, event *beat.Event) []*beat.Event {
+	t.Helper()
+	out, err := p.RunSplit(event)
+	require.ErrorIs(t, err, processors.ErrEventHeld)
+	return out
+}
+
+func TestCorrelateFlow(t *testing.T) {
+	p, _ := newTestCorrelate(t, 100)
+
+	alert := eveEvent("alert", "1", mapstr.M{"alert": mapstr.M{
+		"signature_id": int64(2100498), "signature": "GPL ATTACK_RESPONSE id check returned root",
+		"category": "Potentially Bad Traffic", "severity": int64(2), "action": "allowed",
+	}})
+	assert.Equal(t, []*beat.Event{alert}, runSplit(t, p, alert), "alerts pass through")
+	runSplit(t, p, eveEvent("alert", "1", mapstr.M{"alert": mapstr.M{
+		"signature_id": int64(2000419), "signature": "ET POLICY PE EXE or DLL Windows file download",
+		"category": "Potential Corporate Privacy Violation", "severity": int64(1), "action": "allowed",
+	}}))
+
+	file := eveEvent("fileinfo", "1", mapstr.M{"fileinfo": mapstr.M{"filename": "/a.exe", "stored": true}})
+	assert.Empty(t, hold(t, p, file), "fileinfo records are held")
+
+	other := eveEvent("dns", "2", nil)
+	assert.Equal(t, []*beat.Event{other}, runSplit(t, p, other))
+
+	flowRecord := eveEvent("flow", "1", mapstr.M{"flow": map[string]interface{}{
+		"pkts_toserver": int64(10), "bytes_toclient": int64(4096), "state": "closed", "alerted": true,
+	}})
+	out := runSplit(t, p, flowRecord)
+	require.Len(t, out, 2)
+	assert.Same(t, file, out[0])
+	assert.Same(t, flowRecord, out[1])
+	assert.Equal(t, "fileinfo", out[0].Private, "held records keep their state to be acknowledged when released")
+
+	correlation, err := out[0].GetValue("suricata.eve.correlation")
+	require.NoError(t, err)
+	assert.Equal(t, mapstr.M{
+		"complete": true,
+		"flow": mapstr.M{
+			"pkts_toserver": int64(10), "bytes_toclient": int64(4096), "state": "closed", "alerted": true,
+		},
+		"alert": mapstr.M{
+			"count":        2,
+			"signature_id": []interface{}{int64(2100498), int64(2000419)},
+			"signature":    []string{"GPL ATTACK_RESPONSE id check returned root", "ET POLICY PE EXE or DLL Windows file download"},
+			"category":     []string{"Potentially Bad Traffic", "Potential Corporate Privacy Violation"},
+			"action":       []string{"allowed"},
+			"severity":     int64(1),
+		},
+	}, correlation)
+
+	assert.Empty(t, p.flows, "the flow is released with its flow record")
+	_, err = flowRecord.GetValue("suricata.eve.correlation")
+	assert.Error(t, err, "the flow record is not enriched")
+}
+
+func TestCorrelateTimeout(t *testing.T) {
+	p, clock := newTestCorrelate(t, 100)
+
+	file := eveEvent("fileinfo", "1", nil)
+	assert.Empty(t, hold(t, p, file))
+
+	clock.Advance(30 * time.Second)
+	other := eveEvent("http", "2", nil)
+	assert.Equal(t, []*beat.Event{other}, runSplit(t, p, other))
+
+	clock.Advance(30 * time.Second)
+	out := runSplit(t, p, other)
+	require.Len(t, out, 2)
+	assert.Same(t, file, out[0])
+	complete, err := file.GetValue("suricata.eve.correlation.complete")
+	require.NoError(t, err)
+	assert.Equal(t, false, complete)
+}
+
+func TestCorrelateExpire(t *testing.T) {
+	p, clock := newTestCorrelate(t, 100)
+	assert.True(t, processors.Expires(p))
+
+	file := eveEvent("fileinfo", "1", nil)
+	assert.Empty(t, hold(t, p, file))
+	assert.Empty(t, p.Expire())
+
+	clock.Advance(time.Minute)
+	assert.Equal(t, []*beat.Event{file}, p.Expire(), "the flow expires without a record arriving")
+	assert.Empty(t, p.flows)
+}
+
+func TestCorrelateMaxFlows(t *testing.T) {
+	p, _ := newTestCorrelate(t, 1)
+
+	first := eveEvent("fileinfo", "1", nil)
+	assert.Empty(t, hold(t, p, first))
+	second := eveEvent("fileinfo", "2", nil)
+	assert.Equal(t, []*beat.Event{first}, hold(t, p, second))
+
+	assert.Equal(t, []*beat.Event{second}, p.Flush())
+	assert.Empty(t, p.flows)
+}
+
+func TestCorrelateNoFlowID(t *testing.T) {
+	p, _ := newTestCorrelate(t, 100)
+
+	event := &beat.Event{Fields: mapstr.M{"suricata": mapstr.M{"eve": mapstr.M{"event_type": "fileinfo"}}}}
+	assert.Equal(t, []*beat.Event{event}, runSplit(t, p, event))
+
+	_, err := p.Run(event)
+	assert.ErrorIs(t, err, errSplitUnsupported)
+}
@@ -141,8 +141,12 @@ func (e *Event) Unpack(data string, opts ...Option) error {
 		}
 	}
 
+	vendorMappings := settings.extensionMappings(e.DeviceVendor, e.DeviceProduct)
 	for key, field := range e.Extensions {
-		mapping, found := extensionMappingLowerCase[strings.ToLower(key)]
+		mapping, found := vendorMappings[strings.ToLower(key)]
+		if !found {
+			mapping, found = extensionMappingLowerCase[strings.ToLower(key)]
+		}
 		if !found {
 			continue
 		}
@@ -175,6 +179,37 @@ func (e *Event) Unpack(data string, opts ...Option) error {
 	return errors.Join(errs...)
 }
 
+// LabeledExtensions returns the custom extensions of the event, like
+// deviceCustomString1, by the value of their label extension, like
+// deviceCustomString1Label. The custom extensions without a label are not
+// returned.
+func (e *Event) LabeledExtensions() map[string]*Field {
+	byName := make(map[string]*Field, len(e.Extensions))
+	for key, field := range e.Extensions {
+		if mapping, found := extensionMappingLowerCase[strings.ToLower(key)]; found {
+			key = mapping.Target
+		}
+		byName[key] = field
+	}
+
+	var labeled map[string]*Field
+	for name, field := range byName {
+		labelName, found := customLabels[name]
+		if !found {
+			continue
+		}
+		label, found := byName[labelName]
+		if !found || label.String == "" {
+			continue
+		}
+		if labeled == nil {
+			labeled = map[string]*Field{}
+		}
+		labeled[label.String] = field
+	}
+	return labeled
+}
+
 type escapePosition struct {
 	start, end int
 }
@@ -483,6 +483,40 @@ func TestEventUnpackWithFullExtensionNames(t *testing.T) {
 	}, e.Extensions)
 }
 
+func TestEventUnpackWithExtensionMappings(t *testing.T) {
+	const message = "CEF:0|Acme|Firewall|1.0|100|Blocked|5|rule=42 src=10.0.0.1 zone=dmz"
+
+	var e Event
+	err := e.Unpack(message, WithFullExtensionNames(),
+		WithExtensionMappings("acme", "", map[string]ExtensionMapping{
+			"Rule": {Target: "ruleId", Type: StringType},
+			"zone": {Target: "zoneName", Type: StringType},
+		}),
+		WithExtensionMappings("ACME", "firewall", map[string]ExtensionMapping{
+			"rule": {Target: "ruleNumber", Type: LongType},
+		}),
+		WithExtensionMappings("Other", "", map[string]ExtensionMapping{
+			"src": {Target: "otherSource", Type: StringType},
+		}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*Field{
+		"ruleNumber":    LongField(42),
+		"sourceAddress": IPField("10.0.0.1"),
+		"zoneName":      StringField("dmz"),
+	}, e.Extensions)
+}
+
+func TestEventLabeledExtensions(t *testing.T) {
+	var e Event
+	err := e.Unpack("CEF:0|Acme|Firewall|1.0|100|Blocked|5|cs1=allow-all cs1Label=Rule Name cn2=3 cn2Label=Hit Count cs3=unlabeled deviceCustomString4=x deviceCustomString4Label=")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]*Field{
+		"Rule Name": StringField("allow-all"),
+		"Hit Count": LongField(3),
+	}, e.LabeledExtensions())
+}
+
 func BenchmarkEventUnpack(b *testing.B) {
 	messages := append([]string(nil), testMessages...)
 	b.ResetTimer()
@@ -6,7 +6,8 @@ package cef
 
 import "strings"
 
-type mappedField struct {
+// ExtensionMapping is the full name and the data type of a CEF extension.
+type ExtensionMapping struct {
 	Target string
 	Type   DataType
 }
@@ -19,7 +20,7 @@ type mappedField struct {
 //     dated November 23, 2018.
 //   - "HPE Security ArcSight Common Event Format Version 23"
 //     dated May 16, 2016.
-var extensionMapping = map[string]mappedField{
+var extensionMapping = map[string]ExtensionMapping{
 	"agt": {
 		Target: "agentAddress",
 		Type:   IPType,
@@ -688,12 +689,105 @@ var extensionMapping = map[string]mappedField{
 		Target: "managerReceiptTime",
 		Type:   TimestampType,
 	},
+
+	// These are the categorization fields, the zone keys and the reported
+	// resource fields of the "ArcSight Extension Dictionary". They have no
+	// short names.
+	"categoryBehavior": {
+		Target: "categoryBehavior",
+		Type:   StringType,
+	},
+	"categoryDeviceGroup": {
+		Target: "categoryDeviceGroup",
+		Type:   StringType,
+	},
+	"categoryObject": {
+		Target: "categoryObject",
+		Type:   StringType,
+	},
+	"categoryOutcome": {
+		Target: "categoryOutcome",
+		Type:   StringType,
+	},
+	"categorySignificance": {
+		Target: "categorySignificance",
+		Type:   StringType,
+	},
+	"categoryTechnique": {
+		Target: "categoryTechnique",
+		Type:   StringType,
+	},
+	"agentTranslatedZoneKey": {
+		Target: "agentTranslatedZoneKey",
+		Type:   LongType,
+	},
+	"agentZoneKey": {
+		Target: "agentZoneKey",
+		Type:   LongType,
+	},
+	"customerKey": {
+		Target: "customerKey",
+		Type:   LongType,
+	},
+	"destinationTranslatedZoneKey": {
+		Target: "destinationTranslatedZoneKey",
+		Type:   LongType,
+	},
+	"destinationZoneKey": {
+		Target: "destinationZoneKey",
+		Type:   LongType,
+	},
+	"deviceTranslatedZoneKey": {
+		Target: "deviceTranslatedZoneKey",
+		Type:   LongType,
+	},
+	"deviceZoneKey": {
+		Target: "deviceZoneKey",
+		Type:   LongType,
+	},
+	"sourceTranslatedZoneKey": {
+		Target: "sourceTranslatedZoneKey",
+		Type:   LongType,
+	},
+	"sourceZoneKey": {
+		Target: "sourceZoneKey",
+		Type:   LongType,
+	},
+	"reportedDuration": {
+		Target: "reportedDuration",
+		Type:   LongType,
+	},
+	"reportedResourceGroupName": {
+		Target: "reportedResourceGroupName",
+		Type:   StringType,
+	},
+	"reportedResourceID": {
+		Target: "reportedResourceID",
+		Type:   StringType,
+	},
+	"reportedResourceName": {
+		Target: "reportedResourceName",
+		Type:   StringType,
+	},
+	"reportedResourceType": {
+		Target: "reportedResourceType",
+		Type:   StringType,
+	},
 }
 
-var extensionMappingLowerCase = map[string]mappedField{}
+var extensionMappingLowerCase = map[string]ExtensionMapping{}
+
+// customLabels maps the full names of the custom extensions, like
+// deviceCustomString1, to the full names of their label extensions.
+var customLabels = map[string]string{}
 
 func init() {
 	for k, v := range extensionMapping {
 		extensionMappingLowerCase[strings.ToLower(k)] = v
 	}
+	for _, v := range extensionMapping {
+		if target, ok := strings.CutSuffix(v.Target, "Label"); ok {
+			customLabels[target] = v.Target
+		}
+	}
 }
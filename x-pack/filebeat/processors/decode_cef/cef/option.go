@@ -5,6 +5,7 @@
 package cef
 
 import (
+	"strings"
 	"time"
 )
 
@@ -18,6 +19,30 @@ type Settings struct {
 	fullExtensionNames bool
 	removeEmptyValues  bool
 	timezone           *time.Location
+	vendorMappings     []withExtensionMappings
+}
+
+// extensionMappings returns the mappings of the extensions of the events of
+// the devices of vendor and product, nil if there are none.
+func (s *Settings) extensionMappings(vendor, product string) map[string]ExtensionMapping {
+	var mappings map[string]ExtensionMapping
+	// The mappings of all the products of the vendor are applied first, so
+	// the ones of the product take precedence.
+	for _, forProduct := range []bool{false, true} {
+		for _, m := range s.vendorMappings {
+			if (m.product != "") != forProduct || !strings.EqualFold(m.vendor, vendor) ||
+				(forProduct && !strings.EqualFold(m.product, product)) {
+				continue
+			}
+			if mappings == nil {
+				mappings = map[string]ExtensionMapping{}
+			}
+			for k, v := range m.mappings {
+				mappings[k] = v
+			}
+		}
+	}
+	return mappings
 }
 
 type withRemoveEmptyValues struct{}
@@ -52,6 +77,28 @@ func (w withTimezone) Apply(s *Settings) {
 	s.timezone = w.timezone
 }
 
+type withExtensionMappings struct {
+	vendor, product string
+	mappings        map[string]ExtensionMapping
+}
+
+func (w withExtensionMappings) Apply(s *Settings) {
+	s.vendorMappings = append(s.vendorMappings, w)
+}
+
+// WithExtensionMappings causes the extensions of the events of the devices of
+// vendor and product to be mapped with mappings, by their key, instead of the
+// CEF extension dictionary. The vendor, product and keys are case-insensitive
+// and an empty product matches all the products of vendor. The mappings given
+// for a product take precedence over the ones given for all the products.
+func WithExtensionMappings(vendor, product string, mappings map[string]ExtensionMapping) Option {
+	lower := make(map[string]ExtensionMapping, len(mappings))
+	for k, v := range mappings {
+		lower[strings.ToLower(k)] = v
+	}
+	return withExtensionMappings{vendor: vendor, product: product, mappings: lower}
+}
+
 // WithTimezone causes CEF timestamps that do not contain a timezone to be
 // parsed in the specified timezone.
 func WithTimezone(timezone *time.Location) Option {
@@ -8,6 +8,7 @@ import (
 	"errors"
 	"fmt"
 	"net"
+	"net/netip"
 	"strconv"
 	"strings"
 	"time"
@@ -32,6 +33,29 @@ const (
 	TimestampType
 )
 
+var dataTypeNames = map[string]DataType{
+	"integer":   IntegerType,
+	"long":      LongType,
+	"float":     FloatType,
+	"double":    DoubleType,
+	"string":    StringType,
+	"boolean":   BooleanType,
+	"ip":        IPType,
+	"mac":       MACAddressType,
+	"timestamp": TimestampType,
+}
+
+// Unpack sets the data type from its name: integer, long, float, double,
+// string, boolean, ip, mac or timestamp.
+func (t *DataType) Unpack(s string) error {
+	typ, ok := dataTypeNames[strings.ToLower(s)]
+	if !ok {
+		return fmt.Errorf("invalid data type: %q", s)
+	}
+	*t = typ
+	return nil
+}
+
 // toType converts the given value string value to the specified data type.
 func toType(value string, typ DataType, settings *Settings) (interface{}, error) {
 	switch typ {
@@ -81,12 +105,18 @@ func toBoolean(v string) (bool, error) {
 	return strconv.ParseBool(v)
 }
 
+// toIP validates that v is an IPv4 or IPv6 address, and returns it in its
+// canonical form. IPv6 addresses can be enclosed in brackets, and their zone
+// is removed since it can't be stored in an ip field.
 func toIP(v string) (string, error) {
-	// This is validating that the value is an IP.
-	if net.ParseIP(v) != nil {
-		return v, nil
+	if strings.HasPrefix(v, "[") && strings.HasSuffix(v, "]") {
+		v = v[1 : len(v)-1]
+	}
+	ip, err := netip.ParseAddr(v)
+	if err != nil {
+		return "", errors.New("value is not a valid IP address")
 	}
-	return "", errors.New("value is not a valid IP address")
+	return ip.WithZone("").String(), nil
 }
 
 // toMACAddress accepts a MAC addresses as hex characters separated by colon,
@@ -100,3 +100,23 @@ func TestToMACAddress(t *testing.T) {
 		assert.NoError(t, err, mac)
 	}
 }
+
+func TestToIP(t *testing.T) {
+	for v, want := range map[string]string{
+		"10.0.0.1":                      "10.0.0.1",
+		"2001:DB8:0:0:0:0:0:1":          "2001:db8::1",
+		"[2001:db8::1]":                 "2001:db8::1",
+		"fe80::1%eth0":                  "fe80::1",
+		"ffff:0:0:0:222:5555:ffff:5555": "ffff::222:5555:ffff:5555",
+	} {
+		ip, err := toIP(v)
+		if assert.NoError(t, err, v) {
+			assert.Equal(t, want, ip, v)
+		}
+	}
+
+	for _, v := range []string{"", "[10.0.0.1", "10.0.0.256", "2001:db8::1::2", "host"} {
+		_, err := toIP(v)
+		assert.Error(t, err, v)
+	}
+}
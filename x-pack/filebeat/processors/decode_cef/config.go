@@ -7,14 +7,16 @@ package decode_cef
 import "github.com/elastic/beats/v7/libbeat/common/cfgtype"
 
 type config struct {
-	Field             string            `config:"field"`               // Source field containing the CEF message.
-	TargetField       string            `config:"target_field"`        // Target field for the CEF object.
-	IgnoreMissing     bool              `config:"ignore_missing"`      // Ignore missing source field.
-	IgnoreFailure     bool              `config:"ignore_failure"`      // Ignore failures when the source field does not contain a CEF message. Parse errors do not cause failures, but are added to error.message.
-	IgnoreEmptyValues bool              `config:"ignore_empty_values"` // Ignore CEF extensions with empty values
-	ID                string            `config:"id"`                  // Instance ID for debugging purposes.
-	ECS               bool              `config:"ecs"`                 // Generate ECS fields.
-	Timezone          *cfgtype.Timezone `config:"timezone"`            // Timezone used when parsing timestamps that do not contain a time zone or offset.
+	Field             string            `config:"field"`                // Source field containing the CEF message.
+	TargetField       string            `config:"target_field"`         // Target field for the CEF object.
+	IgnoreMissing     bool              `config:"ignore_missing"`       // Ignore missing source field.
+	IgnoreFailure     bool              `config:"ignore_failure"`       // Ignore failures when the source field does not contain a CEF message. Parse errors do not cause failures, but are added to error.message.
+	IgnoreEmptyValues bool              `config:"ignore_empty_values"`  // Ignore CEF extensions with empty values
+	ID                string            `config:"id"`                   // Instance ID for debugging purposes.
+	ECS               bool              `config:"ecs"`                  // Generate ECS fields.
+	Timezone          *cfgtype.Timezone `config:"timezone"`             // Timezone used when parsing timestamps that do not contain a time zone or offset.
+	MapCustomLabels   bool              `config:"map_custom_labels"`    // Write the custom extensions to fields named after their labels.
+	VendorMappings    string            `config:"vendor_mappings_file"` // File of the vendor-specific mappings of the CEF extensions.
 }
 
 func defaultConfig() config {
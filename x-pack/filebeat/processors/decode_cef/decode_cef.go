@@ -31,6 +31,9 @@ func InitializeModule() {
 type processor struct {
 	config
 	log *logp.Logger
+
+	vendorMappings []vendorMapping
+	unpackOptions  []cef.Option
 }
 
 // New constructs a new processor built from ucfg config.
@@ -49,7 +52,19 @@ func newDecodeCEF(c config, logger *logp.Logger) (*processor, error) {
 		log = log.With("instance_id", c.ID)
 	}
 
-	return &processor{config: c, log: log}, nil
+	p := &processor{config: c, log: log}
+	if c.VendorMappings != "" {
+		var err error
+		p.vendorMappings, err = loadVendorMappings(c.VendorMappings)
+		if err != nil {
+			return nil, fmt.Errorf("fail to load the "+procName+" processor vendor mappings: %w", err)
+		}
+	}
+	p.unpackOptions = []cef.Option{cef.WithFullExtensionNames(), cef.WithTimezone(c.Timezone.Location()), cef.WithRemoveEmptyValues()}
+	for _, m := range p.vendorMappings {
+		p.unpackOptions = append(p.unpackOptions, m.option())
+	}
+	return p, nil
 }
 
 func (p *processor) String() string {
@@ -86,7 +101,7 @@ func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
 
 	// If the version < 0 after parsing then none of the data is valid so return here.
 	var ce cef.Event
-	if err = ce.Unpack(cefData, cef.WithFullExtensionNames(), cef.WithTimezone(p.Timezone.Location()), cef.WithRemoveEmptyValues()); ce.Version < 0 && err != nil {
+	if err = ce.Unpack(cefData, p.unpackOptions...); ce.Version < 0 && err != nil {
 		if p.IgnoreFailure {
 			return event, nil
 		}
@@ -107,14 +122,22 @@ func (p *processor) Run(event *beat.Event) (*beat.Event, error) {
 	}
 
 	cefObject := toCEFObject(&ce)
+	if p.MapCustomLabels {
+		addCustomLabels(cefObject, &ce)
+	}
 	_, _ = event.PutValue(p.TargetField, cefObject)
 
 	// Map CEF extension fields to ECS fields.
 	if p.ECS {
 		writeCEFHeaderToECS(&ce, event)
 
+		vendorFields := ecsFields(p.vendorMappings, ce.DeviceVendor, ce.DeviceProduct)
 		for key, field := range ce.Extensions {
 			mapping, found := ecsExtensionMapping[key]
+			if target, ok := vendorFields[key]; ok {
+				// The vendor mappings are copied as is.
+				mapping, found = mappedField{Target: target}, true
+			}
 			if !found {
 				continue
 			}
@@ -186,6 +209,23 @@ func toCEFObject(cefEvent *cef.Event) mapstr.M {
 	return cefObject
 }
 
+// addCustomLabels adds the custom extensions of cefEvent with a label, like
+// deviceCustomString1, to the custom object of cefObject, named after their
+// label.
+func addCustomLabels(cefObject mapstr.M, cefEvent *cef.Event) {
+	for label, field := range cefEvent.LabeledExtensions() {
+		name := labelFieldName(label)
+		if name == "" {
+			continue
+		}
+		if field.Interface != nil {
+			cefObject.Put("custom."+name, field.Interface)
+		} else {
+			cefObject.Put("custom."+name, field.String)
+		}
+	}
+}
+
 func writeCEFHeaderToECS(cefEvent *cef.Event, event *beat.Event) {
 	if cefEvent.DeviceVendor != "" {
 		//nolint:errcheck // All errors are from mapstr puts.
@@ -163,6 +163,32 @@ func TestProcessorRun(t *testing.T) {
 				"message":                       "CEF:0|Trend Micro|Deep Security Manager|1.2.3|600|User Signed In|3|src=10.52.116.160 suser=admin target=admin msg=User signed in from 2001:db8::5",
 			},
 		},
+		"custom_labels": {
+			config: func() config {
+				c := defaultConfig()
+				c.ECS = false
+				c.MapCustomLabels = true
+				return c
+			},
+			message: "CEF:0|Acme|Firewall|1.0|100|Blocked|5|cs1=allow-all cs1Label=Rule Name cn1=3 cn1Label=Hit Count (24h) c6a1=[2001:DB8::1]",
+			fields: mapstr.M{
+				"cef.version":                             "0",
+				"cef.device.event_class_id":               "100",
+				"cef.device.product":                      "Firewall",
+				"cef.device.vendor":                       "Acme",
+				"cef.device.version":                      "1.0",
+				"cef.name":                                "Blocked",
+				"cef.severity":                            "5",
+				"cef.extensions.deviceCustomString1":      "allow-all",
+				"cef.extensions.deviceCustomString1Label": "Rule Name",
+				"cef.extensions.deviceCustomNumber1":      int64(3),
+				"cef.extensions.deviceCustomNumber1Label": "Hit Count (24h)",
+				"cef.extensions.deviceCustomIPv6Address1": "2001:db8::1",
+				"cef.custom.rule_name":                    "allow-all",
+				"cef.custom.hit_count_24h":                int64(3),
+				"message":                                 "CEF:0|Acme|Firewall|1.0|100|Blocked|5|cs1=allow-all cs1Label=Rule Name cn1=3 cn1Label=Hit Count (24h) c6a1=[2001:DB8::1]",
+			},
+		},
 		"truncated_header": {
 			config: func() config {
 				c := defaultConfig()
@@ -19,5 +19,5 @@ func init() {
 // AssetDecodeCef returns asset data.
 // This is the base64 encoded zlib format compressed contents of processors/decode_cef.
 func AssetDecodeCef() string {
-	return "eJzsPWlz28aS3/0rurQf7FRRiuTr7dNW3paeaMXcsmyujmRra6uSIdAkZzWYQWYGpJBf/2ouEOABUgDoxGL0wQlJoKev6eludDeO4QHzc4hw/AJAU83wHPoYiRjh8sMVpFJEqJSQMKbIYvUCIEYVSZpqKvg5/OMFAMClSBLB4cMMuYYrIROi4dXlh6vvICaanLwAf/e5vfoYOEkwrGn+dJ7iOUykyFL/zZpFzN8/c4hxTDKmQU8Rfo0tpr9EOP61hOpcUo0KCGN2fRhLkdjrLz9cFaASVIpMELQAPaUKfrVAxOj/MdInMNAQCa4J5SrcCVMkMQZGAOGx+aWAh48auaKCFzSbvzLdZdpnKM21xfeBBw+Yz4WMS99v4IT5+8kBATEucFQpRnRMI2Kuh0xhDKPc/urpPXmxgkuMMxrhyQx5LGRbjAyMgJADDHpKtJFOnEUY74aLu1q3Q2bogLTHZh/C8hQ2QQfNNvslYkSpX2jcDqt7Tn/LEGiMXNMxxUJ2dhELcQ0iCmcoqc53WBsfSZIao/ITyvz4I51Md0NskKRCasIjrGB0AndThBlhNAalJeUT8yEzu10i3PMHLua8B5/EvFcBd40xzZIeGAR6du8W+JRBUq5xgrIM8/T4zQ8r4N4ev/8hgPzbMfz7Dwu4fz8+O/1hAXyVeebfdkK7nQqpyxdUWbS6ZGGb1MrCZaO7ZdlLwRhGYb0HzI8tmyAlVCqIiJQUDQsLY7QwidYMnpSgLdvFMrZkglxfxLFEpSoXBJxpuvR1BWkjzcEQiAMQWHMho1s6mVqzzjHSQhZbz5wafu8tM3AFrT5XfZEQytcitirFtdj1P99CbKFY0B2j+FEo/bmqY0/GcCqU3gNqg+WFn4SUBQGDfsdIXZOoTtl2RO764nJPKvdZt9K4Gsg3GCFN9R3doCwx0cs/rNCtaYJANMynNJoC5WPr/Zk9T0Yi06WzZE4USLPibOGUrLKojhMG0f8VvJVmOyWyWP8ueNcKficJV4xojBvbr52gGy58eNQoOWGDfveaUV3p/mawhyXytAtB5mkTGdbg9dOKs/dk1GZVV68j3dq3zPci6TRlPhgZSqFFJFhTxl4sQAHDGTLDPwuxF5zMstv28e5u2LP/3vbg9vbj7HUP7pBx1D0Yfhn2YHA9vDD/XpgLjOOmBAi+QQAjotBGt5ci43otCUzwSS3+EJl7gSglImq2Fsypnrrg0/u2H8UcEsJza5+UtZj2Z2WOYmdFxUihnGH8n2BRgYhwGCGIhGoDko6BaqAKzjZRkmtUg/XqvY2Ez1kychGChQLaWIkxSmndvpHIeNwDiYxoOvOBNYISmYzspxiVptxJ0F0luJrStAcJEm5cebstbMhuSB8zMTff2vB9LZg6Gr9kzeRUR6TItKWyAZEncCVkUNSevcnAB14s51IVZZpd1LqAV1lsA+1RprRIUO7JUgTwXRuKEpnNPf9BiGLVCuuCa2Y1bOGTSByjVIaxlAPhJm7gqOdCPrio0HkzZj/ZH2dvA6AN3C8t2XWkkBJZpDIiYbRII4wzxnL4LSPMkB1XoopXV//d//zddkR/RPGJaKqzeIMXKLIR2+oHMgeCcsKcGXYq7NzCJWm8VOUAbYRmS+7AUYOo4JPWmHoY+0S1bQxWo8lWgwl/mgKrqchYbA4KsoPOrDmjqjhwEWMP5lPkQOwHu0NmhDIyYpssUwlA+5Drlj5CJJjgxwpTlBbVKT6SGCOaEObN6g6SahldWSb/THks5mpdTL/GBm3HaShks7NrkchKhdSBCdYfGqGeI3I4tb7O+3fv3rzbARHnoW4I27dhM5RiRmOvwouwvcwS7wKvVTjvE1WOTTpeqH6Rnw9ABn04O33Xg6Oz03dHRiN9ntX/uDO5bZMnZflbVF+qdURvx+cW5YxG2BYf5cCAJnKCOgTfhde5FY0Ogtplg6YLkKu2bafT+QlIN95N5kYgY43S+NTWGS+A/oexrAu9JDCmEueEsRP4af0GPDV07Ljvvkqgv3G95r6dVbf7m4FljpHjAjAYyAsBV+yiUqhhSsz5ivYjnXATFVu5h+h5O9fuFcrmGcaaIzdTKM2mGfSXzRGH+8+D/3HuvBRCu0upgglylMQctMumzV4x6MPpbgTtyYsIJJk1jadAVTCY9peN9rhqyk7gQ0IoCyebD73FWCOHhKSpjQt91BKI8al/555IjGhKzV43DoQJZmMaE20jqTTT4W6qwhOEXVg2lHRGGU6wVUJX5ymNgofoCDu6iBPKqdKSaCGPenBkljty6YOjHzNU+igws571L82pFbA8gUHQo6pRiTSdUZ0DPmKUGVkIvqRp8+DWFet5gS2xBBY8MSfTEiHb2fp1zFDHxmdvFsecphf2QVjjZJZ7jKbJA/KQC/dPd2sX7S40th5BJSreJaaoQ+7SpgaumCCa8slQUK7PPpERNs/4MebzDaHmYkpmJoKJhJSoUsFjyifAzBrePsAHEk2976WCqXG2xT8tNlvVrTLyvEgzmQpVeGzlFZ9K75sDo/ft86e3TzSeNXo69sU/XZoLl87VJEmL4qEQK5tdlpDie5cAFcCFtrnz3PxOeA5CT41jwd1hGFNrP4jMd6XgMAT1+psX1OvnL6jqCdEwkedlNhaZhLEHCKmB+EdIrkLSBiX8pkk6MK188/xE+PZZkDQYzt57J3i95aj3gsvEGFCFA/wHk/L8t1eJ2vX28ZsU3AHYxRK1663iNym4AwjUStSut/3fpOAOIOJ0BSjrj7dtjyxCKDOVWJSWdC4wuFcIKiWGepa72inEByCQCIlFC0qvdA+oLE0Zxdit6h4dp0IpWve8eIUnhyL89UfkYQv/AM5aR+j6Y/awhX8A5/WtBbve7O9Ao5e/oo8ewW9c7p4dhyL39Rb/YOV+AMbeEbre2B+s3A/Gzq8PyQ5W7gcQ1jlC3/0l9wo7DkXu7/+Se4Udz1nufSpxc3XQ1j4xnq9po51b2QbAFp/QCQaRSJKMh964KVGurig0ADDfUlQqZDMyFFJjDMdwdHpkK6Z8AxcICUdn7qvQ7lRP7Z+8z8Xg6Fr3iMaJkHlTPG8wlaiQa6cdkQe3qB7zdVxC0oktNOOTUNMFfftf5UsjM4XmSipBzHkARH938lPRFBNitq6d8EHHeaiE/+C7HI++vxacaiG/71P18P0NknhzCZ8lP1TuNS6OvXCsthYmsyNDWL5a42hrylzlq3btfFuLzM0tVySirDpN5MkKNPYwNixfLdu9zRUTE7tTCAd8TBmNqF7AWK5+xRnKfBdCGD52kb2dCyDMisz2GD6LbE6JN8/Z9i/IbJfDfd4q8KzD+y7mr3TZtmetcsOOPXPvwDkGA65RjknUvBshAADBS82WKYkeUBu3wzZAo7nKj0TYoSK6405C8vROQtuhte8mwnK1di0mX7zL9pWkxXCsdxXVkORMkLi5E7I6qyxU+qcOdE0bYS1i++hyDH2H3llf5xw9Abc29sSDcBpV02h5wYs5GokdVzLCCiVLuCvnRCHXMg+NULWkdD9tqNwp6JrMfK9R0TlTnjm03NF/fX0NcQx5nufw8eN5kpwbcUlIKGNUYSR4rEBRHiFgKqIpvPovwuFMaTj7+99Ov6sjtYtBRYsRRUHLm+rQvvs3V1tJWrVwVlHea+fR6lJfp/fRcax9E9LemdOeJfUtWe0ZgTzu2KIUxmPJqDiFRh53aEqsJamY3qKjT2Iq5GKX89icLAQUKrV58o3FseFBFppAiY/wzWFmRVXMr3KSsbsZjT9dZ3rsb18yHYnmJ1efqpSR3Jkc4WD1IFOZb6yFlyqLzNn00jD75ZhQlkl8uQmj1lmQO3fCh6m6hK/N9dxNMbdZtoAo5ZFEosw13pnsrUsxcL3JtRtThpcSicbmqm7U3Lr/hpcGoD0bIwt1UzxjLvtI1LQpv8y9TmsNpJpFWiSmuJHIMjfdghCFvWRopibqqcHhWsTF4OSu2cyI0pDYBWqZzVu4eZ9LAYNdWHCWwyvDD5FpoFpBSvR0U6LU3DIkurGsrzLG7ALBXBqAPaP7LLPhuMXJ+qBUK2TjOjxQJlS1mcK3gKDKPKlZ85b+vp7128ymuXHHNVqNPPSDDi0bX6U0xR4oYYKxHqCOTjb4o2OGj81bGC+WG+JWU0+kdInPP6HalIGKcUztyb4EdVNmijBmKQgJmy4zVcZOL1JBxl77jn9mTn7/aMX4LWHkmU3b23VdVsbQOEIwvssoBzc9XUHGmQ220JxLm8ubC7m0yoS5wVtFmutpCa0FY2tw7KYq7at0D3Wey/zjNaSb2rC/uN+G+9/GDm2XUd8vlv6FBi1cPCJHVEujMuGtHRM6M8hZ3YpRE8rU8sDpE7jOmKbHjHK0aSqKKoxLLV64MMohs575/5nA097McQ72Hp8P3jiTWrD4qkvPXLB4V8/cL92Fc25jK79y/Wpde+nllXf31T0yHbvrFd7v4q57NNokZsse+24S6NRBD0uWnfQieNjuqAeU9uGr78aNTlz23ZbqwnOvqPvuHrwkc1u+0ml27waJajfjXFoIQDiQLKa6NOPf56cxrlRdwNGIxJASpQz0I1tmlLnXtdhRVmFwlrMDhClhH3tyQCmFNJdL1JnkEBnTsKiFOX08e/3m7ab6F4m/Zaj0JaPIdWmCeKscr0J5fGGnz6+zaH7JLQgJrvGxqUyhv/iwKJXi2qC0NMrWr1ckqjCGV5UhYx/v7oZwg3bAtdykggFp8UCbD1Xztzdn2jXqqWiVvLPEJhaMy+IZrzZyE0jh/uZT/fr3srGDNeC+Zsy5TYQ7VDzgXmnEXfW1Y/c3nzyCIelsvvEOrr8yPKSzs/CNEzNHxjZQ4kaHd/YYKIw9bzc+zEH5c9cROhxbj8reBrzdeOs66G2rUv6x9CO01oVleN3WvXh0ynUvKysmK3Uw4VxR5/ByKpR2DxbM/514i3kSiWTTEwa3ZqOSlOI1aUenp+en8fn703MyPj8bnb8/O6p3ZdpUrziE91W9Eh76eVHUl6+4i/Yx+Xrr4F2/dKsqkLtK8YenePt06x0Q2sc06ip+tVh0PYN66p6Yujo9m+mxevKUqmGH2H6rGsrWrEU1wzKqjfX7oqzXfij1CC27FkiP8uUJsh2MpV4mYY81B+uW+qaGUTsCOp1D7RWxPIJ6p2nN/r7quzP3Prx6wYAO51YvsYDbkdXrBk/bqHGnudMKeexobTx0ekHqHzxveqCXOzFKDGs4aNq3suwwaHq3UdIOo72bjr0OkA5uTCsDoYnsoAKyqDAoJLd0RkmHkF3uK1U+2nPIHC9tX/S2tPs/kRzl8dtFhJsVsXB4VlTeM+EyBSqLpiYcvrscGuLu+8MNQtGbknvbDuVT++IyZd8O57jfgzP/HZlMJE6M5ezBa/+d7U9wrwRze/hNuNg+EvOW3acBqu91Myq5WEbBK4MhnC6Hq/+2KNby5m4txaF3zbWltUlu9v1LtfO0FDzBMQyleMx7MOjf9uBnHIFxI1Gu53/A5ot993pTTNzdyx4b9Y+FqrlIWrw3j3AQafA8Va4x6dnKdsPrnk+Po442aE7A/J84JTNaeXn6k3D34+CFBAIjD2u1qImHY3xgfPi596SdFxhbU+UT++4d9vUo32E0tWV+jY1lAOAxsPm0V3gyOYHv++J2UyKlqno/Lr19+kkY/OjclKIu2sACRh8QroLXW4/DLZ1w+xCJN++TuJwSSSKNRf+m97johveXb9kC7Qom/e3Vd7g7majMhHo98EWSPatsWmOS6k2iSggnE5Rt6/Z/DofVhYyUNU0fbq8XbwJeZk31RD/xgeNJqTRuHTsqHkJA43Yp6NzkF1cXLr/fpOvV3SuYllb/VwAAAP//vL8m0g=="
+	return "eNrtHWtz28bxe37FjfrB9gzF6GE7jTpJRxX9YGvZqiUnnU5n7CNwJC8CccjdQRTz67t7DxAASRACQCeR5A+yRAL7vr3dxd5in1yzxQkJ2PgbQjTXETshAxaIkJGzV69JIkXAlBKSjDmLQgUXhUwFkieai/iE/AgfEHImZjMRk1c3LNbktZAzqslTuP0ZCammfbjG3n1irt4nMZ0xjxP/6UUCf0+kSBP3yRok+O8fC/hmTNNIEz1l5EtoKP0MkL7kSJ1LrpkiNIoMfjKWYmauB5IyUDO4lk4Y0QK+4op8MUDE6BcW6D4ZahKIWFMeK38nmTIaMi8IQuMQv8ngsVvNYgX0Zjzjvzzfed5vmMRrs8+9DEAZcyHD3OcbJIH/frJAiBhnNKqEBXzMA4rXk1SxkIwW5lvHb/+bFVpCdsMD1gflhUK2pQhheIIsYPgVzAG0E6YBC+vRYq/W7Yi5sEDaU7MLZTkOm5DDcJl9DiKq1GcetqPqU8x/TRnhIYAEu2GZ7gwSA3ENIQq+hTW2qIGb3dJZgk4F+F/sv+WTaT3ChrNESE1j0Fieoj65gl9vaMRDorTk8QT/SHG1SwbcXMdiHvfIOzHvFcCds5Cnsx5BAnpm7Wb05EHyWLMJCCEH82D/+IcVcM/3X/7gQX63T/76wxLu9/uHBz8sga8KD3+2U9rlFESTv6AoolWUQaq0mK0gHUdUg9NiNdGeGShLR6d6JOLXfmHZby+NTg57BnFI6FiDNLWVb8oK4CzNXJKIjli0BLsZ6ju8sAdKIpGYM1mAFlDFyJzrqcGmGFg12I5CLMGUShoAIcquNtRqLDSJmDYfiiKkkE+4VkSyJKKB9Z9pDI5fBUIy1Sc/g+UDqWQ+hR9fZjT5bMX72fChvhCuCvBYTEcRC9eoZSnJFdXk98JtahFRBHuWMwMwpH0ja5JQDswFVErO0LKzPWK5U5ndqZ+DVt6u8tSCT4r1aRiCEIoMepp5Uvq4QDQusuEFoRaAt9hTGVzCMjG7bQxcCJl5RNzMnUss2/UKWYNYDcQMduu1hK0urrXUDd5fktBAMaA7JvGtUPp9cenfmcIpwNgBacOwDVEGBBkOOibqnAZVxlaTuPPTsx2Z3HvdyuIqIH9kAYOrrvgGY4H4km3jW8PNBJiaT3kwhcU/NkE5rnk6EqnObfFzir4OMN4sY8VVEVVJAgn9r4hZeyMyVP8GoDrW1ZWksYLNjoWN/Vct6CiFV+BdZUyj4aB7yyhi+vRxuAMUAKIDRcItDXRYQddPKzH4nUm7KUbgHdnWrnW+E00nSeRyREiStAhE1FSwp0tQEFDdQCCXOIg9H/vno+m3V1cXPfPzskcuL9/eHPXIFYtipnvk4gN8NTy/OMWfp3gBxtNKEBFvUMAIgj5TdDgTaazXshCJeFJJP6gf7iWQTImA49LyUSRXPuV4K+ZkRuOF8U/KeEzztcKt2HpRMVJMggf9OzGkQNgVkxEsgRnGihB9jQnXEBiSw02cLDRTw7gRC+/T2cgmbgYK0eglxkxKE/aBu4/DHrh48Bvg4229AyJkkcrA/AWwNI+tBu1VEJBOedKDTJTGmGGZZWEqKcj6GIJv/NRUVdaCqeLxQ6o7ZxI2NMNlAyb7WK7yhtozNyF8EmfobAUpz7MtJizhFZBt4N2mCEzuyFN48F07ihybzSP/oS8uqBXR+dDMWNgyJpFsbLI1gWkLrCTIG8BDAAfXNlm30QyuJ/PlzXMPqL+Vja4zhYTKrMIUCLQisJ5xGkUL8mtKI2Q7LGQVT1//e/D+2XZC3zDxDn7RabghChQppJXbKI0sCIAZWTdsTdiGhSVtPFH5BA1yWViSqh6hcGVrSh2MXZLaNgersGRbWYjvZsBqKtIoxI2C1rCZNXtUkYZYhODDTF2Cmj/MCrmhPMIKxHbxtE+5LvktrALQxr5iCZOG1Cl415AFfAZ6tW61hqZaZldGyD/zOBRztS6nX+ODttN0IWSzvWtZX8SapheCiYdGYBUM9HVgYp2XL14cv6hBiI1QN6Tt26iBu2946Ex4mbbnReJC4LUG52KiwrYJ4U1m+tljEw8EUBwevOiRPfi5hxbpyt/uy9rsti2e5PVvSAUnsobp7fRcQqTHA9aWHmXBEE3lhGmffGdR51YyOkhqyw5NZyBXfVut3fkORDdeTXijKyxzW75Ykv039KxLuwSvyiWb0yjqk5/WL8AD5KPmuvsqif5GfM1jO2NucLsRDupxCZgg5KWCC34RcmBNphT3V2b+5JMYs2Kjd589b5faJzD05hXGii03BcC4aMCHlNxRTD69H/7HhvNSCG0vhaUFCTXsSrjRll2buQJc1UE9hnYURXiWECdGCkCyc5jmm43+uOjK+uQV7HiR39lc6i3G+NRiBrm/yQtd1uKZcaV/G55I2K4TjmsdAwhMZkOOVUfUfZJqfzd86Z4g1BHZheQ3PGIT1qqgCxfzwEeIlrG903DGY67ADWgh92CnQXR7tnyw9wYu03temNWif4K7lqeyT4bejopOJYAUk+sFfMKCFHUh4pKlzX1Yl+FzCiuJhCxlgjtTiZHtYv06bqhj57Mzj4O76al5ENa4mGUfo2l6DehdLdw9dK9E2l1qbCKCQlZcJ6eoIs4+QX0dCZBxPLkQsHbtg9TGQooiV2/wrTBTeoMZTCAkkJ0IcBbxxD3Vtf6BvKKQw9nYS3lXY32Le4iPS9ViGTlZJKlMhMoitjzGu/J7/MD4fX7/+R3AbnTY6OnYB/d0aS5sOVeDW896unyujKsMdkr/uS2ACtM1gLXzBX6PBWEBpEv7bB24DbnxH1Qu6nLwMBR19KdX1NED8yCHDQt5TmdjkUqsmRuAkHMBxN9DcwWWju4hSw9tH79/Knx+L1gaXty8dEHwYYMoOM8MgsoC4N+Zlfu/vHLcHt0fxR09KMUd3x/FHT8oxT2/P4p7ABmnbUA5bPTIwqcyU8my1pLOFYalXKISitxjgRt7pxi7BonMhGTZyaBe7h6iUmzfYqHFah8dg4wUr3pevCKTh6L8o0flr8rkoSj/+FH5qzK5/8p3R4+a8uj0r/itI/BPrvf8SawHoPejR70XxPFQ9H78qPeCOB6K3p8/6r0gjoei9xePei+I46Ho/eWj3gviuM96H3DJNncHbT0nBgpbPUY7N7r1gA09/iQYngaZpbE/G4ddTaavyB8AiNyRolwjG+pQSOwo2yd7B3umY8od4CLw696h/cgfd6rm9g9+zgVptEf3qGYTIRdN6fzIEjAuAGStI3Dglt1jro9LSD4xjWYgc9fTRQbmf+VaI1PF3EQKMY89IP6b1Z8KpmxGcemawSt8vPCd8K/cKce9b89FzLWQ3w64uv72I6PhXjX7vnOvcXPsqRW18TCpmeQC6uBre8ps56u2x/m2NpnjLa9pwKPikJc7G9DYwdiAvti2e7lQkZiYlYJNb7d4sJTrJYxy9ysOoVnUYSRit11Ub+fgtiOjMnPG8F5Uc3Kyuc++f8nm0aMJbJbNfTaBLuavdHlsz3jlhif28N6hDQyGMZgjuMjmpxE8AGxjXx62TGhwzTSGHeYANMOr3EiEGh3RHZ8kpHc/SWhOaO36EGG+W7uSkg8uZPtK2orYWNdV1QVdRIKGzYOQ1RFyvtM/saArjhFWEraLU47+3KEL1tcFR3egrY0/cSCsRVUctDyNszkaMzOuZMQKnJRoVzaIglvlwh+EqmSl+2lD+ZOC9pCZO2uUnZzJzxwqn+g/Pz8nYUgW8I+8fXsym52guiQwH0VcsQC2EQV5Nw4GZIkAdE//CdHiodLk8PvvDp5VsdrFoKLliCJv5U1taNfnN1ePkrQ6wlkkeacnj1ZRfZ2zj1Zi7Q8h7Vw47UVSfSSrvSBYHHbsUTLnUXIq1qABX4euxHiSguvNTvRJhiWabJVjZWaM4Sosss2TbwyNDTcyfwiUugwfNzOjqmx+ldWMWc0M4+kq12O+g2gkEM13rgFXSUQX1uUIC6tHUpW6g7XkiUoD3JueoLCfjCGaTSV7somi1lWQK7vD+2HHoMJ1tR64amGqbJ5Q0LtkVOE1LpjsrSsxxHpTaDfmETsDEJo1N3U0cxP+oywRoNkbAwM1rMD7lqppU3nhvdZqEVIFkhaFqRg1UpamRYgDt+xaQp45Zj0VNJyLMJtn3bWYIwpRw8wgqBR23CLMe59LGAxiEYPtPUV5YAEZJ80mVE+fVaC/gO+bon8N6alB4N0lAsQT8kGUmnTc0GRiUCCFReMqOpiccdVmCt8SgsrLpALnJf+NNXKbeGNNHK1GHrpBh0aMTxOegHDB6K9xnh3TQX9DPDqO2G3zI4yn5QNxq6UnmrvE1Z8gRtxQgQrZmJudvQR1U2UKB+sjB75g02WlCv30shSE/tqd+I9w53ePVjBu8SPPTNne4LVVGeRxhNNVNG4Gdqi9gr0zMskWw31pc3tzppdWlTA7eCsrc92toLUUbAWN3XSlfZXTQ53XMn9/C+mmN+xR+m2k/+dYoUd/YCrdeyZahHhUjriWaDL+ZSoTSNGAOGNbIdNgxao8cLpPztNI8/0IthxTpsLihRuXmr0HA2fum8j8f5h4mptjNifmHlcP3jiTWkTh6y4jc4BXNzJ3qLsIzk1u5TBXY+s6Ss9jrh+rO2I6DtcLsq8Trjsy3ncUsdfTQKcBukeZD9Kz5GF7oO5J2kWsXk8anYTs9VB1EbkXzL1+BC/p3LSvdFrd+8ioajfjXBoIWAOhYDo6N+Pf1aexSpbruiB7IxqC/SmF0PdMm1Fq36JjRln5wVnWD9BICfPYMyZMSmxFkoBRpzIm+B6uXC/Mwe3h0fHzTf0vEl/PovRZhDPGchPEW9V4gdr9UzN9fp1Hcyi3ECRizW5148LY6ht5cBomklQaZevwZYUqoPRpYcgYTkgHczADruWzLUSLa958qJq7vbnQzpmeilbFO8PszICxVTyMagM7gZR8+viuGv8nGTV/mup6xmzYBGZtSHGAe7kRd8W3wQFNjkBfdMZPXIDrrvQP6cwsfAxi5iyKNnBiR4d39hjIjz1vNz7MQvlj9xFaGluPyt4GvN146yrobbtSfix9SVrbQhlet30vjpx838sKxtlKH4zfV9QJeYLvQLIPFvC3vvOYfbClJ5VG0qglJXt73d7BwclBePLy4ISOTw5HJy8P93bXvWIJ3lX3in/o51RR3b5iL9rF5Outg3cd6lZdIFeF5g/H8fbp1jUI2sU06iJ9lVR0PYN6ap+Y2j49U+kxdnKXrmFL2G67GvLerEU3Q5nUxvZ9mrdrN5R6xIy4lkSPFr3ux1KXWdhhz8E6VH+qYdSWgU7nUDtDzI+grjWt2d1XfKXpzodXLwXQ4dzqkgjsyOp1g6dN1lhr7rTCNg7Zauj0ktXfed70UJdPYuQE1nDQtDvKUmPQdL1R0painbuOnQ6Q9mFMKwehIStp36+UdRhkmivtUdISZNB9pc5Hsw/h9tL2RW+l1f+OLpjcf77McNMsF/bPivJrxl8GDKRAOajp6uwCmfs0uNigFL2puLdtUz4wLy5T5u1wVvo9cug+o5OJZBP0nD1y5D4z5xPsK8HsGj72Fwd2mv5VrgxQfK8bmuQSjSJPzYsPD8rp6l+WzVrO3a1/i5c7u2aPpbUpbg7cu87x9d1Z8gR4wAhuIRoZDi575Gc2IhhGMvlNJTUfRr+woHFNzN5djti4eyxUrEXy7L152MOV+MhTLTSb9UxnO8q658rjWJytpvwfbEpveOn1zncfBw8UUiDKwlptaor9Nj7EGH7uImkbBYbGVbnCvpHEFpKvWDA1bX6NnaUH4Cgw9bSnrD/pk28H4vLZFvzWcN6U3j59Jwre2DAl64tGWPaV3q991FtNAyyU2DxEipufkzjz7/z25zddxMU3vFZ+yxJo1zDpbi9gdDoBbwiBEpi0bZLsGWMD1zJL9LN+7TfB/ostmnjKLW8d7RSqbwfoFOjGd+XsCssOYK82u+8AQedg1+WEO0DQOVjbvs3CQSpp41kA/ma/oD1QH2xAwJmP2frVpHxkrtCMjrKrB9fW62b0ZXWT3AacUT3e2EpRJrFxdpArwnVGTFei6oygrp5GNyZoRmNw3rLtia6ffRoDQasyQeury/PlO+LLm2ZxxfZdSbGfa5peJ4XCUvVkXJbKkZsqJkXE+TdfdY3dvpyvhP3/NGhS0A=="
 }
@@ -0,0 +1,122 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package decode_cef
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+
+	"github.com/elastic/beats/v7/x-pack/filebeat/processors/decode_cef/cef"
+	conf "github.com/elastic/elastic-agent-libs/config"
+	"github.com/elastic/elastic-agent-libs/paths"
+)
+
+// vendorMappingsFile is the content of a vendor_mappings_file.
+type vendorMappingsFile struct {
+	Vendors []vendorMapping `config:"vendors"`
+}
+
+// vendorMapping overrides the mappings of the CEF extensions of the events of
+// the devices of a vendor, or of one of its products.
+type vendorMapping struct {
+	Vendor     string             `config:"vendor" validate:"required"`
+	Product    string             `config:"product"`                        // All the products of the vendor if empty.
+	Extensions []extensionMapping `config:"extensions" validate:"required"` // Mappings of the extensions by key.
+}
+
+type extensionMapping struct {
+	Key  string       `config:"key" validate:"required"` // Key of the extension in the CEF messages.
+	Name string       `config:"name"`                    // Full name of the extension, the key if empty.
+	Type cef.DataType `config:"type"`                    // Data type of the extension, string if unset.
+	ECS  string       `config:"ecs"`                     // ECS field the extension is written to, if any.
+}
+
+// loadVendorMappings reads the vendor mappings from the YAML file at path,
+// relative to the configuration directory.
+func loadVendorMappings(path string) ([]vendorMapping, error) {
+	path = paths.Resolve(paths.Config, path)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vendor mappings: %w", err)
+	}
+	c, err := conf.NewConfigWithYAML(data, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse vendor mappings in %s: %w", path, err)
+	}
+	var f vendorMappingsFile
+	if err := c.Unpack(&f); err != nil {
+		return nil, fmt.Errorf("invalid vendor mappings in %s: %w", path, err)
+	}
+	return f.Vendors, nil
+}
+
+// option returns the option unpacking the extensions with the mappings.
+func (m vendorMapping) option() cef.Option {
+	mappings := make(map[string]cef.ExtensionMapping, len(m.Extensions))
+	for _, ext := range m.Extensions {
+		mapping := cef.ExtensionMapping{Target: ext.Name, Type: ext.Type}
+		if mapping.Target == "" {
+			mapping.Target = ext.Key
+		}
+		if mapping.Type == cef.Unset {
+			mapping.Type = cef.StringType
+		}
+		mappings[ext.Key] = mapping
+	}
+	return cef.WithExtensionMappings(m.Vendor, m.Product, mappings)
+}
+
+// ecsFields returns the ECS fields of the extensions of the events of the
+// devices of vendor and product set by the vendor mappings, by the full names
+// of the extensions. The mappings of a product take precedence over the ones
+// of all the products of its vendor.
+func ecsFields(mappings []vendorMapping, vendor, product string) map[string]string {
+	var fields map[string]string
+	for _, forProduct := range []bool{false, true} {
+		for _, m := range mappings {
+			if (m.Product != "") != forProduct || !strings.EqualFold(m.Vendor, vendor) ||
+				(forProduct && !strings.EqualFold(m.Product, product)) {
+				continue
+			}
+			for _, ext := range m.Extensions {
+				if ext.ECS == "" {
+					continue
+				}
+				if fields == nil {
+					fields = map[string]string{}
+				}
+				name := ext.Name
+				if name == "" {
+					name = ext.Key
+				}
+				fields[name] = ext.ECS
+			}
+		}
+	}
+	return fields
+}
+
+// labelFieldName returns the name of the field of a custom extension with the
+// given label: the label in lower case, with the sequences of characters that
+// are not letters or digits replaced by underscores.
+func labelFieldName(label string) string {
+	var sb strings.Builder
+	sb.Grow(len(label))
+	separate := false
+	for _, r := range strings.ToLower(label) {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			separate = sb.Len() > 0
+			continue
+		}
+		if separate {
+			sb.WriteByte('_')
+			separate = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
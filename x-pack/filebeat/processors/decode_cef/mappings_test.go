@@ -0,0 +1,118 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package decode_cef
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/libbeat/beat"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const vendorMappingsYAML = `
+vendors:
+  - vendor: Acme
+    extensions:
+      - key: rule
+        name: ruleName
+        ecs: rule.name
+      - key: zone
+  - vendor: acme
+    product: firewall
+    extensions:
+      - key: rule
+        name: ruleId
+        type: long
+        ecs: rule.id
+`
+
+func TestVendorMappings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cef-mappings.yml")
+	require.NoError(t, os.WriteFile(path, []byte(vendorMappingsYAML), 0o600))
+
+	c := defaultConfig()
+	c.TargetField = ""
+	c.VendorMappings = path
+	dec, err := newDecodeCEF(c, logp.NewNopLogger())
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		message string
+		fields  mapstr.M
+	}{
+		"vendor": {
+			message: "CEF:0|Acme|Proxy|1.0|100|Blocked|5|rule=deny-all zone=dmz src=10.0.0.1",
+			fields: mapstr.M{
+				"extensions.ruleName":      "deny-all",
+				"extensions.zone":          "dmz",
+				"extensions.sourceAddress": "10.0.0.1",
+				"rule.name":                "deny-all",
+				"source.ip":                "10.0.0.1",
+			},
+		},
+		"product": {
+			message: "CEF:0|Acme|Firewall|1.0|100|Blocked|5|rule=42 zone=dmz",
+			fields: mapstr.M{
+				"extensions.ruleId": int64(42),
+				"extensions.zone":   "dmz",
+				"rule.id":           int64(42),
+			},
+		},
+		"other_vendor": {
+			message: "CEF:0|Other|Firewall|1.0|100|Blocked|5|rule=42",
+			fields: mapstr.M{
+				"extensions.rule": "42",
+			},
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			evt, err := dec.Run(&beat.Event{Fields: mapstr.M{"message": tc.message}})
+			require.NoError(t, err)
+			fields := evt.Fields.Flatten()
+			for k, v := range tc.fields {
+				assert.Equal(t, v, fields[k], k)
+			}
+		})
+	}
+}
+
+func TestVendorMappingsErrors(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"missing_vendor": "vendors:\n  - extensions:\n      - key: rule\n",
+		"missing_key":    "vendors:\n  - vendor: Acme\n    extensions:\n      - name: rule\n",
+		"invalid_type":   "vendors:\n  - vendor: Acme\n    extensions:\n      - key: rule\n        type: number\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(dir, name+".yml")
+			require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+			_, err := loadVendorMappings(path)
+			assert.Error(t, err)
+		})
+	}
+
+	c := defaultConfig()
+	c.VendorMappings = filepath.Join(dir, "missing.yml")
+	_, err := newDecodeCEF(c, logp.NewNopLogger())
+	assert.ErrorContains(t, err, "failed to read vendor mappings")
+}
+
+func TestLabelFieldName(t *testing.T) {
+	for label, want := range map[string]string{
+		"Rule Name":       "rule_name",
+		"Hit Count (24h)": "hit_count_24h",
+		"  --src.zone--":  "src_zone",
+		"Größe":           "größe",
+		"()":              "",
+	} {
+		assert.Equal(t, want, labelFieldName(label), label)
+	}
+}
@@ -19,6 +19,7 @@ import (
 	// Import OSS monitor types.
 	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/http"
 	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/icmp"
+	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/mail"
 	_ "github.com/elastic/beats/v7/heartbeat/monitors/active/tcp"
 
 	// Import X-Pack modules.
@@ -0,0 +1,106 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+	"github.com/aws/smithy-go"
+)
+
+// CheckCredentials verifies that the credentials of awsConfig are accepted
+// by AWS by calling STS GetCallerIdentity, and returns the ARN of the caller.
+// This does not require any IAM permission. Errors are annotated with a hint
+// on how to fix the configuration.
+func CheckCredentials(ctx context.Context, awsConfig awssdk.Config) (string, error) {
+	out, err := sts.NewFromConfig(awsConfig).GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", WithHint(fmt.Errorf("failed to verify AWS credentials: %w", err))
+	}
+	return awssdk.ToString(out.Arn), nil
+}
+
+// WithHint returns err annotated with a hint on how to fix the configuration
+// when the cause of err is known. Otherwise err is returned unchanged.
+func WithHint(err error) error {
+	hint := ErrorHint(err)
+	if hint == "" {
+		return err
+	}
+	return fmt.Errorf("%w (hint: %s)", err, hint)
+}
+
+// ErrorHint returns a hint on how to fix the configuration that caused
+// err, or an empty string if the cause is not known.
+func ErrorHint(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var opErr *smithy.OperationError
+	for e := err; e != nil; e = errors.Unwrap(e) {
+		if op, ok := e.(*smithy.OperationError); ok { //nolint:errorlint // The innermost operation is wanted.
+			opErr = op
+		}
+	}
+	if opErr != nil && opErr.Service() == "STS" && strings.HasPrefix(opErr.Operation(), "AssumeRole") {
+		return "the role could not be assumed, check role_arn and external_id and that the trust policy of the role allows the configured credentials to assume it"
+	}
+
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.ErrorCode() {
+		case "InvalidClientTokenId", "UnrecognizedClientException":
+			return "the access key is not valid, check access_key_id"
+		case "SignatureDoesNotMatch":
+			return "the request signature is not valid, check secret_access_key"
+		case "ExpiredToken", "ExpiredTokenException":
+			return "the temporary credentials expired, renew session_token or use credentials that are refreshed automatically"
+		case "AccessDenied", "AccessDeniedException", "UnauthorizedOperation", "Forbidden":
+			if opErr != nil {
+				return fmt.Sprintf("grant the %s permission to the configured credentials", iamAction(opErr))
+			}
+			return "grant the required IAM permissions to the configured credentials"
+		case "NoSuchBucket":
+			return "the bucket does not exist, check bucket_arn or non_aws_bucket_name"
+		case "AWS.SimpleQueueService.NonExistentQueue", "QueueDoesNotExist":
+			return "the queue does not exist, check queue_url"
+		case "ResourceNotFoundException":
+			return "the resource does not exist, check the configured ARN or name and region"
+		}
+	}
+
+	var signingErr *v4.SigningError
+	if errors.As(err, &signingErr) || strings.Contains(err.Error(), "failed to retrieve credentials") {
+		return "no credentials were found, configure access_key_id and secret_access_key, credential_profile_name or role_arn"
+	}
+
+	var netErr net.Error
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) || errors.As(err, &netErr) {
+		return "AWS could not be reached, check the network connectivity, endpoint and proxy_url"
+	}
+	return ""
+}
+
+// iamAction returns the IAM action name of the operation, such as
+// logs:DescribeLogGroups.
+func iamAction(op *smithy.OperationError) string {
+	service := op.Service()
+	switch service {
+	case "CloudWatch Logs":
+		service = "logs"
+	default:
+		service = strings.ToLower(strings.ReplaceAll(service, " ", ""))
+	}
+	return service + ":" + op.Operation()
+}
@@ -0,0 +1,92 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package aws
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/smithy-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestErrorHint(t *testing.T) {
+	opErr := func(service, op string, err error) error {
+		return &smithy.OperationError{ServiceID: service, OperationName: op, Err: err}
+	}
+	tests := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{
+			name: "invalid access key",
+			err:  opErr("STS", "GetCallerIdentity", &smithy.GenericAPIError{Code: "InvalidClientTokenId"}),
+			want: "the access key is not valid, check access_key_id",
+		},
+		{
+			name: "access denied",
+			err:  opErr("CloudWatch Logs", "DescribeLogGroups", &smithy.GenericAPIError{Code: "AccessDeniedException"}),
+			want: "grant the logs:DescribeLogGroups permission to the configured credentials",
+		},
+		{
+			name: "assume role",
+			err: opErr("S3", "HeadBucket", fmt.Errorf("get identity: %w",
+				opErr("STS", "AssumeRole", &smithy.GenericAPIError{Code: "AccessDenied"}))),
+			want: "the role could not be assumed, check role_arn and external_id and that the trust policy of the role allows the configured credentials to assume it",
+		},
+		{
+			name: "unreachable",
+			err:  opErr("STS", "GetCallerIdentity", &net.DNSError{Err: "no such host", Name: "sts.example.com"}),
+			want: "AWS could not be reached, check the network connectivity, endpoint and proxy_url",
+		},
+		{
+			name: "unknown",
+			err:  errors.New("something else"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.want, ErrorHint(test.err))
+		})
+	}
+}
+
+func TestCheckCredentials(t *testing.T) {
+	valid := true
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/xml")
+		if !valid {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `<ErrorResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><Error><Type>Sender</Type><Code>InvalidClientTokenId</Code><Message>The security token included in the request is invalid.</Message></Error><RequestId>1</RequestId></ErrorResponse>`)
+			return
+		}
+		fmt.Fprint(w, `<GetCallerIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><GetCallerIdentityResult><Arn>arn:aws:iam::123456789012:user/test</Arn><UserId>AIDATEST</UserId><Account>123456789012</Account></GetCallerIdentityResult><ResponseMetadata><RequestId>1</RequestId></ResponseMetadata></GetCallerIdentityResponse>`)
+	}))
+	defer srv.Close()
+
+	cfg := aws.Config{
+		Region:       "us-east-1",
+		Credentials:  credentials.NewStaticCredentialsProvider("id", "secret", ""),
+		BaseEndpoint: aws.String(srv.URL),
+		Retryer:      func() aws.Retryer { return aws.NopRetryer{} },
+	}
+
+	arn, err := CheckCredentials(context.Background(), cfg)
+	require.NoError(t, err)
+	assert.Equal(t, "arn:aws:iam::123456789012:user/test", arn)
+
+	valid = false
+	_, err = CheckCredentials(context.Background(), cfg)
+	assert.ErrorContains(t, err, "hint: the access key is not valid, check access_key_id")
+}
@@ -0,0 +1,109 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+// Package firehose decodes the requests of the Amazon Data Firehose HTTP
+// endpoint delivery and writes the responses Firehose expects.
+package firehose
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Headers set by Firehose on HTTP endpoint deliveries.
+const (
+	HeaderRequestID        = "X-Amz-Firehose-Request-Id"
+	HeaderAccessKey        = "X-Amz-Firehose-Access-Key"
+	HeaderSourceARN        = "X-Amz-Firehose-Source-Arn"
+	HeaderCommonAttributes = "X-Amz-Firehose-Common-Attributes"
+)
+
+// Request is the body of a delivery. The data of the records is base64
+// encoded in the body.
+type Request struct {
+	RequestID string   `json:"requestId"`
+	Timestamp int64    `json:"timestamp"`
+	Records   []Record `json:"records"`
+}
+
+// Record is a record of a delivery, as written to the Firehose stream.
+type Record struct {
+	Data []byte `json:"data"`
+}
+
+// Response is the body of the responses expected by Firehose. A delivery is
+// retried unless the status code is 200 and the request ID matches the
+// request.
+type Response struct {
+	RequestID    string `json:"requestId"`
+	Timestamp    int64  `json:"timestamp"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// ReadRequest reads and decodes the body of a delivery, decompressing it if
+// Firehose was configured with GZIP content encoding. The limit applies to
+// both the compressed and the decompressed size, a *http.MaxBytesError is
+// returned if the body exceeds it.
+func ReadRequest(w http.ResponseWriter, r *http.Request, maxBodyBytes int64) (*Request, error) {
+	var body io.Reader = http.MaxBytesReader(w, r.Body, maxBodyBytes)
+	switch encoding := r.Header.Get("Content-Encoding"); encoding {
+	case "", "identity":
+	case "gzip":
+		gz, err := gzip.NewReader(body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip body: %w", err)
+		}
+		defer gz.Close()
+		body = gz
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", encoding)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, maxBodyBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxBodyBytes {
+		return nil, &http.MaxBytesError{Limit: maxBodyBytes}
+	}
+
+	var req Request
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+	return &req, nil
+}
+
+// WriteResponse writes the response to the delivery with requestID. The
+// error message defaults to the status text for failed deliveries.
+func WriteResponse(w http.ResponseWriter, status int, requestID, errorMessage string) error {
+	if status != http.StatusOK && errorMessage == "" {
+		errorMessage = http.StatusText(status)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	return json.NewEncoder(w).Encode(Response{
+		RequestID:    requestID,
+		Timestamp:    time.Now().UnixMilli(),
+		ErrorMessage: errorMessage,
+	})
+}
+
+// Lines returns the entries of newline-delimited record data, like the
+// records of CloudWatch Metric Streams. Empty lines are skipped.
+func Lines(data []byte) [][]byte {
+	var lines [][]byte
+	for _, line := range bytes.Split(data, []byte{'\n'}) {
+		line = bytes.TrimSuffix(line, []byte{'\r'})
+		if len(line) != 0 {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
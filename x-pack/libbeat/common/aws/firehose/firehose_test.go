@@ -0,0 +1,80 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package firehose
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBody = `{"requestId":"req-1","timestamp":1578090901599,"records":[{"data":"aGVsbG8Kd29ybGQK"},{"data":"Zm9v"}]}`
+
+func TestReadRequest(t *testing.T) {
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	_, err := gz.Write([]byte(testBody))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	for name, tc := range map[string]struct {
+		body     []byte
+		encoding string
+		limit    int64
+		wantErr  string
+	}{
+		"plain":                {body: []byte(testBody), limit: 1024},
+		"gzip":                 {body: gzipped.Bytes(), encoding: "gzip", limit: 1024},
+		"invalid body":         {body: []byte("{"), limit: 1024, wantErr: "invalid request body"},
+		"unsupported encoding": {body: []byte(testBody), encoding: "br", limit: 1024, wantErr: `unsupported content encoding "br"`},
+		"too large":            {body: []byte(testBody), limit: 16, wantErr: "request body too large"},
+		"too large once decompressed": {
+			body: gzipped.Bytes(), encoding: "gzip", limit: int64(gzipped.Len()) + 1, wantErr: "request body too large",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tc.body))
+			if tc.encoding != "" {
+				r.Header.Set("Content-Encoding", tc.encoding)
+			}
+			req, err := ReadRequest(httptest.NewRecorder(), r, tc.limit)
+			if tc.wantErr != "" {
+				assert.ErrorContains(t, err, tc.wantErr)
+				if strings.Contains(tc.wantErr, "too large") {
+					var tooLarge *http.MaxBytesError
+					assert.True(t, errors.As(err, &tooLarge))
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "req-1", req.RequestID)
+			assert.Equal(t, []Record{{Data: []byte("hello\nworld\n")}, {Data: []byte("foo")}}, req.Records)
+		})
+	}
+}
+
+func TestWriteResponse(t *testing.T) {
+	rec := httptest.NewRecorder()
+	require.NoError(t, WriteResponse(rec, http.StatusServiceUnavailable, "req-1", ""))
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	var resp Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "req-1", resp.RequestID)
+	assert.Equal(t, "Service Unavailable", resp.ErrorMessage)
+	assert.NotZero(t, resp.Timestamp)
+}
+
+func TestLines(t *testing.T) {
+	assert.Equal(t, [][]byte{[]byte("a"), []byte(" b "), []byte("c")}, Lines([]byte("a\r\n\n b \nc")))
+	assert.Nil(t, Lines(nil))
+}
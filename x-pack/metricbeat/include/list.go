@@ -24,6 +24,7 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/aws/awshealth"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/aws/billing"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/aws/cloudwatch"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/aws/metric_streams"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/awsfargate"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/awsfargate/task_stats"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/azure"
@@ -48,6 +49,7 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/gcp/billing"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/gcp/carbon"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/gcp/metrics"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/gcp/query"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/gcp/vertexai_logs"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/ibmmq"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/iis"
@@ -65,9 +67,13 @@ import (
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/mssql/performance"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/mssql/transaction_log"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/asm"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/performance"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/rac"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/sysmetric"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/tablespace"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/tablespace_growth"
+	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle/top_sql"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/panw"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/panw/interfaces"
 	_ "github.com/elastic/beats/v7/x-pack/metricbeat/module/panw/routing"
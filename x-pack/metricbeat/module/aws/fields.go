@@ -19,5 +19,5 @@ func init() {
 // AssetAws returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/aws.
 func AssetAws() string {
-	return "eJzsvVtz4zbyN3yfT4Ham8xs2U4ySbbeysVbZcvOxs96PI5lJ3muuBDZkrAmAQ4A2qPUfvin0ABI8KQjKTv/+s/NbiwJ+PUBjUaj0X1KnmD1E6Ev6itCNNMp/ET+dv779G9fEZKAiiXLNRP8J/L/f0UIIf+mL+rfJBNJkQKJRZpCrBU5/31KMsGZFpLxBclASxYrMpciw88mqSiSF6rj5dlXhEhIgSr4iSzoV4TMGaSJ+glHPyWcZuDRmH96lZsvSlHk7i8doOqDhANpulBnfy//7McTs/9ArIM/2z9E9tMnWL0ImXR/HGU0zxlfuO/+7e9/C77Xic3+e6ALMzB5pmkBJKdMOv7QF0UkKFHIGNRZiwL1/dmsiJ9An5n/blHSxroGwy3NgIg5oWT6PXGjtiZMWAZcMcHfCOM+ojKFsFqQv/77mVO5s7+f/f3rHVEnopilMAZoRfSSaiJBF5JDYuVdrQVyfndNPhcgV22SUsafIIloHIuC6xZF4YIgHfofDsWS2p/7NWcDTebf9SUpFCREC8IS4JrNVw4qcVDPOjE0dPdAFFaPJaEpo2p7QIF1WQJN9XIjW0tLNQNNt5O8MXe/4OjeDG4pJjqfQ6whiQwVmoGKhF6CVJ1sm6eC6t2Y9rAEwotsBhJNgJutMj2GWKotK/USCDwD1+RlKRQQpakuFIkp50KTGZBnkGzOIOmWdpuUHHjC+OJotOC6y+iKSPhcMAmExmasbeGagdJn6F44o+FNBDHsLSHz1VrYwJNIs551lVDd/KCOkXSCND8jlCfEDExelsADXQCeQHJGpiJzf1HIYoN5SZ/N78xXcIwexOZHEZV8OFNgQBecfS7Ar38GksyFrHCf4ZcsCef3t2RJFX44FzKjmlDJf6Iv6idrEn7C751KWJgJ7X99M726/+16cvXN1W9Xtw/Rw/+9u4omny5r/3138ziNri/X0a1ikUMUi2RAS/iwZIrkVNIMNEiicogNB5QRnbEeSKgzR5YDTBFK8mKWspicZ/RPwcnvMCNTkM8s9mwytpV7A3rqRo09O8/TVLxAYl0ZRagEcvd4cXM9OSHnk8mnx9uHaHp3Nbn+2fxFSHL76fbqjKzhDO60MdWwEHI1rG5YeswgxE9AjAjOyJ1Qis1SCMlgShVw4gm/FUadYmrGR0JUvATj+CaTJeWLtUpuSRpY1htVHYe3+u60m6FzHjkNji6vppP767uH60+33ehTqnRU5GYNH2JdOsFnQhnrFhugaw3Ni9nVLYZulHZ5Dsva9mJQ5B7nwUmN4a5MSicqZX82Nqxq/5itQiv3s5AEvtAsT+GEXE0+GI29v5z2YNVU6qEFvFaoM1jQnp3MuhcjLJdQ45wPUxNjpxEQOfATEqdCQXKC5BR5LDLGF+sWfICmkwYNX/ZwGhLQlKWQhN/dQhNbHk0nJColbZrbTf4/t3bFwKAzUWi76WumVzW1pKEf3AC6HeIz+qL88Sca8gjTt9bdXN5PQ48sFlxTxq2/UNJn6d2S82f221Eh02FpeLy/8ZpwGDJU/WGxXV8eCO1Y2xAaKhQ1riiryBu3nzbev7YF61WNQ1z17gPGGgfGKoj12qwg2t76Ty13Hb/p/XX6ok7dOnaffOO+wJJ2HGDG0rR+KO2OAqwR07/dGP+umwpQmmV4mI6XVC5AIZUrUUi0i97UMN6IjPp/fXGHdfGDKz/nxE7ZKbhUtA7hNeo+0i8sK7IeAhz2NXGeSSEl8HhvJ/6qNW/sRjSa0zOpM+G3B8SYvGuFnp7XyKyPGd0wzjMhNfsTkolQza2+W7H6RBqOSrNGALA+ZCt42UleCY3Exp70jOmnNJzunbCbmZtmbA3p57pIMarw9ljmgB2NYbX5etl1a6xgavj6qOgCzrtwvTLjKoikMBiPwbyeOfv5+Mhnb1XxSmhHU73GjP1MM6z9taC4o74xpqHUPztsR2FafcZeptmDdoenuv3eZEawZ2uzM0nQksEz4wvcj43IVPe5lCcHzXvlYrm7zYoqECUwZ5z1Hob30ZMnaOrcJmpaFBnXUmm8onYXc7kEhYFsipezhlJaRlGTTpzB5fIq71LMoSAZFwQDl2LeAaTO79mqdllL+q8+yeZLW7LDHWiLoPYxxphYAl/yVEiQFi+ZrarLcNVyzOPSKT7IN6+GabjnWXg7+wISiIolzf0NbZmx8Dve0r4sWbysBujIc1AY31qRhM3nIM1/GDpUTuO6r1hPfPD/1jn15TjDnirLYQNdx3Ad3kYH/Cc0Zx0pAitOM5HMDpKOH+RIsjE/vMQpLy8OPWq1L3b7WdA3VjjetIhjUGpepPfwuQClb6g2Z54z+tw8rZEdN8a2/InTAfoM0mxhqZ3LWBlV4sBLSFDGComSbcQcAW3krPzTVEugWZMVDkjh7FqoZhhxyUEy0QiubMWQjH4ZjSH+uPcWGfKJp4zDNU/gyx3IGLimC7iTYiFBqVHVJC+nMwyJRZangOFftBeUcHghi1TMaEoUxIInVK4IM0AJU2QGhmCaJDavgBJNZyn003knxTNTTHBIfpdMw4TmNGZ69ciZHpfO6no+rzCQFwOCxA4FennKeQlICd6U9tC/FZX3QJPXJlICTQancSK4KrJjE+iNWkVoF3Gxw0bEs7si71yOJ53TKEFWoiAx5URLGj+RpXghWREvzWwY4gt5q5dSFItlXuBVbKGaIeXtWKaKrJdlHSG9HRimiuwvyqUj24e2ZnXahr8e00bXrb8Sn+4hT13GxzF9MEhprjzlM9AvYPZW7m+hCNOQEZrnQNGBYPZevfQ5FPocxmZ3ziQ4mHOlIcxadHsnhB52e2TKMe+w/IWbzNn/Dft3B/+O4bL9j+Hfg6Rc2aS/ieDzlMV6NAU8d8on4T/2itbQcprCMwTeblIAJoRWuGhqFi9CUyWvY8HtRU1XXI1UwwnLDEUzwOnUbqwYyVYJTdO3yoZze93W5zJqlrI/cb0dxVDVTwObnMgC0VWJSp0p4uuJrW9Yb4bazj1tZ3KnK6Uhu5JSyDH34R2PrtawLYCDbEeP7T/KyS8PD3fkx2+/LXPDRQIHHHAngifMrqvJEuKnnzHtyZ3+x2RO5c/NbaYV1Rqy3HIrBzkXMjPr2qOzol+zYO9sqnuwE05QC45BAu5GdtNzYqQSELEGbghqb2Wdo84KbX+Oed1caLICTWbGxAWDHegp0ORhKYXWKVxhMvlIHLrv0n4kDr7EgP4h9FuyziEHOiJ78sdW8505EHjMKcuY7o5mCU5o+YSBvFPG/6aqxhJuWfC+nwdo39+mHtRt/JiK4La9j/SLWRVqrct8mKnwDvP6+IjNfaSczMC+rpqtgvcAbTqIf4mA2kISAQqNBs3zdGXNzmkCGTrNhkvKsKmbSessa8WmBzPKjXHR3jDDKo2wpHYfZRsxUzEPOY053S3m6YrVMc1VmKvU43bSxDsBDvAW6or0FGqNCe+Sx+92dzymQDp9sbctEQt5VJG8aUG8vi35SL8EpwzU375z1ZgBjMPOU0u2WEIrf8n+a43V0P0Ner4L43rPaK/DuaYadjMt/MmaNbon18ocnFnoO+1+SQ4zdcT78auL6WHpCkNfjP8m0iLDhXmxMtbs8EO/D3op9icqDtB4adeHyM15lwkenmJdFBpdxFwbl/cZISlzTKTx0l9r3jItxemMGgPHuNKUx3BCXpZGPjqIKDTSe/yfO4Lgmw7MljW49EbljV0Gf0nmGL35lA/BGWNwNEYJG35gyReFod8WRHyswbI1O3Ygx/GwNoR4INhfCyjgBvhCLwfC2+Cq2dybelcGsV4o06iBwrgULiEBNesAkh7KE2+VXjEQbfWN6vqbT6EccpBuSyHvrj/dTd+TBFL2DBIS/87HytJ8WNvl5vZ87WJ4VxdTt/jOyKNZZy9ML8M8AzvAdHpZrlHB09UmtoQ30qOoqMvTXiN4Rd7xKrtbC/Lhx3/8q+EYva+uE9drwTC8uSik0hc0NXZsAG5UmP6JMdeU3BUyFwoQ0rtF/uH9CakUlHzKNcuQG79cXpJ3Sn/33l5ITUTq/xZ/975OjKU3AbP0m084daeWxhIS43S+M5pmQBB8FlPCqH2u9HcIASeWkFHGg4u2mWFYq+BQt8rhZQwGB43A1oWC9jeHdsUpoyfW+aFp2rLn7gHoMObFALChriNT1VpNQ5J1naTHIGgtRpuHxoWTn2xTbJ3kYpYxXXvRWfro8YfDfPT4wzF99MmHw3z0OC/OkNNneSsx3BKvYppCEu1Z56ZuSWiaihjv4K8mH1DvCg1haIBKcG/8dGoOVaRQ4O9HvbPY/d7OEGKNUISPfjpp2fTgcVOtnsndY2npyoUVYsON2HyrCA6+m/DO7OYxCmKgWGssBG4ZzSvMS4rv3WUBCVHM/IXZqhspLTg67mjTqex9Am2IUYXM00JFRyDKTVWnCC+n8FKqMnmcFBwjR8FZw5oI87PJ3eMER3C7t6vGxxT5E6TYllIV2Xeg3UUJDiYVaekk2KwVLjTJKUtIIl64Ibktb+sNWLOil4UxoHFhK/ok5TWmJaGnWhvoFyGfzhg/y6nZtPd7TNxNadPKuxnwkTt7NqrHcedyIAjjGuScxqBaS49xX4LSODNdh8J+iqIcZKQgHsECtmkL3Hy05cbr2prM9RSJQh9RSLuj30NIAUn/U6TE+Nlspbd/lO+rHXT9aA/x4TBHW2E421EkZ+kK5LY7iZtV8fUFd7RV94qSG2rFJUw9MXFmTgPHkxxKzS8y6tx8Q0UpD6WFhCo++kxZijcLrtjmHnJrETqS3C4qsgJx7U3hWmLw7PYqYgvTmo4it4DUUQXnCQtktyeNm9WwWat4reC2Ek4VqGiGZ469xGwhsnWS2p3GSS91Q6y0XWI7nco5pjjbcanjLrxxxdmi7vDVt480bWruWbyE+Cmy6a0DkXoPuZC6Xma2Fl3IqcJkD6GX9Q99urDB5J5RAFGYCF3/zMWOU6o0yRgv+ooKdxAZ2fGOTOsYhPh5XoGUboltS0y5acRCrrMkxr1bQPPdze4hOiFdjbLNO1b5KcvoAs6Gri1Z1T7E8cvy9Da0tgu+KhKM9QUHrAFxzRMWY5K414Sy0GgQfmaKADe2qMeglkBzyZ6phrOEq2jYSv8YULajk8vbaa0Sb+uEsCVK1sxCcZrY/PMO0K7vnn8gNEkkKEWoUiJmGPPGW729sGKB7rEYaqt/N/m5pVY6aANy0TPO4bgyxoXF5Pqu/OSdYfB7MhMFL/sT7MpSXEJnvRU69zZEOG6Thyc2E/67f5zOmCYFV2zBMSKNk2yFdHi5dyIl71xvBvJfIgvO7f9Ty0JrxhenGGX+L9EgM8ZRp/9rPBYsCOT/LyTvN1Ckl8bBtQcdY6rH2grcPOhu+W2h48IvPaxyDaTHLFpzddNdr+bVkvIuaPwEPJkIzq3XPdADtroo43L4kK1c6KAoS7oioDSdpUwtjbPpXmGigyJoQtyNlCz9TAkLpjRm13jdXJMj/MvDw91EJBA5iqMPf/wxMJX4iu7DH38QCSoXXIF9R+cf32HS6oGgvx8H9Pejgv5hHNA/jAr6x3FA/zgK6KubizG5HKcMW8YY04CgVR11a41uCXlEHiuQzyAHgezemg3z8LOZIOnyIKtYCsKtrGVG+17ioqv0TNM1L5JzlqbiGeRw0Nt5s/4dXmnVy6f3M4hpoWxWsCokFtgEe0FvzP0aHcFq4KtfhGf6oe9e6ky3xcZX1QILVx06+Vh1ZEvtmBrKwiTaIcD2svkdKnhq0HKQ75va8u5hEn5a5hl4r1CKwqfb0hYf+ml85COLpODDCmW4ci+VNDA/zdUmOSGM+4y2E+sWYnavbUDQdFjQAdTV233L/g5TTwquWdoK2EjXyE1B6fm4DWQJNAG5ZocoS7Cf31ycx5o9Q+XpWUEOw6KqqnrN6XO5YMSoZainFKFYxtnNRfmTYNvXK9lb/8h8n8oF6C3J9+nPN5PHodKeu6iug2y8+Xp3M3l8H76cO8/LwgLkxvzyYqNuhzTdwsvx5MnhpSXI0GM/njTvpDCHBhjsIVEfye5i20+3vdDKetjVVw89qNaHOuKZNSD3zR1fu23aGJ7OG7BmExz74WZ6CwuhGS2P62O4pg830xqRWAE89J7docA2u2QJnuZLc0AoUaAUlhb1YdM6wa4IE8WJ0E1ff2iIfmZfIInu3dYXjUHz3ExxWu6utBWxqKIVG8DeQ8IkxHoUmNINPgjAR5lGNyxjOrrCyhmQHBFzLIo04V/r+uOv8ODweH/jr6lKuWASulEt6/6YA0Vq1g72+eLk//vXlsfP7//4YxRag5CKJdpgtWdQpFpItsD4a48x2P7APx78nmP/kPh/HBN/TwxgUPzffjsi/m+/HRH4hzGBfxgR+PdjAv9+ROA/jAn8hyGBX989/6PhYI/hT3W41m0nAV+LG0Dr4Y4YoTPDV+GXMiN5twhixzFtDJa++gHtranND0jQev25d+HKMQS06QKsM1RaJ2WJ1Z5s/QWGPdqbhXqCoV83hl0JZSf+FylcPdO0sMl1Q4Mr0s3qsmDPYMvf2fCcxM7HtmCFI4ZyshTFmiU+QnRpr5jSLlHSkYO6zlwEL0MFVyzBiKcL975iyHkdujIc3Q7ouESVQ4M51TBHDOTc2knfaBDn51S8DBnCXBPAmafiRZF39cuT9+39cdN+1wAePUzuxgdvdvjRCLiZHoGAm+loBDxeHkECj5fDSeCvuG8cIQ7Z5L7RmSXliVrSJ3/EcSWe3eU4r7BUTQN8CMO4ITbS6C9H1zrrlSkay03vUZ+13rrbsFw0bKtC3CEtuLhHO3b0r+mhaXojh4wTwnicFnit/jC5++b6bvNtbB36aALpgB+q/ro2DSiPv8TKDily69tq0xrqJneRtV3RPSgYMjjfTthQoMm7++nD+/pze/sArLw8EVvCvrq5eBXM++ZMGcxWmV6d1Za9ltWW7f97IhryRPTEOCh2WGVUN8axzkK2zN6/7KSdZ6FX7B/6T9D3EAuZqGio9IZdGqH5h+/YIRqCcKBjl+uvdEIyoKqQG5t+9St0QOi1NlZGyPMFfGRpylxq1bikL8rnE/gATiIWfJmZpgE4EtM0dYmYdGF0SxM6HDfMv/MFZkWaX/muwzHUMuD90QOHsgWBgLewO3Ia2LEWVPAkXtsu1UCzrWRzjNZpNnGOPrmn7QEB5bPbYRXO/e9R+rhIR0rHmlJLKpNhKXPddo9CWdBRt0tk7qX0UPbimsciY3wxvlVslWwJH6nkhfarqG4ENhFmK+Hb7cIdHrAOipkBNeKucDzEFV7+V8jRzdzxmn0c/njdHpNDzrjhs+MhOFV+/Qj7a+tQ1suaQvkU/4q4qmDyvmumovUVzHgHIQOYgYokb+qO1zE7MHi2c5g9VgzuGlUa/Yo+4E66qoZU1uM4HZ7uPq0d1vkIiNtGbw/boptn5E4L6Yr2U03MoUe7dnK4XlHPIYHkBFkypn67EtFju2PtqIHbuIxXjdVYOlU5pD6hmo7CgmlpVY7plga2zDPjlfng20S+hmvuEjFcVjK+reM0xUhkIeHVWRN0F3x97mgLxrdIPjZb7oEmYdeisoq9T2Ae0bBWzGkFCLQXUVm5Zjunt5fOaTEzmGbwIKbmnBjdUw2j0xg44IqALbNuow0Ub3qURYWj+PaquExUmMVk9pVUAk1WZhhsqoRvJGq/diFlbJzsej5IIiRh2Pc97OQYXMMir4MKXDRNxUvJdBao4A6cHXtHrpjq11RYtKkJp86lF6p6qyRsTyJ249zCmRxqeTSK1A4e8eimzwYPL2DJeGJcSLU+meQwYocI1bVED4aOfSJ23Qx5ne3iuEI/3uINLCI8g1x5GTupMWWrMeFVd7hkz8g1fiq4Wb6hTUVT+XWfheznBLYfef1NcAsPYbfNsDsCFA63c/jHsyyl2SyhB11T2SGOmLF3gxO+rWy9a/7s3l8Nn7BkVEHZXKR5wauHU7jyvkBcaPv23ideBGcY+7F9j8mT8D9RMBJUkbqTXjn0hmeHrhTS0ESyioH7Y7sEmtyA1iAHQ/mzkISqFY+XUnCBnSY80JOGF2blZLWz1mMfSxSUBhGDYwnQ5DRFqK4AyKxwHuM68pRmHOe+tD3UVj+7o9hbprQEvRWNhfNTh1GwqvuaK0NBdddKUjnwpMwOMovIE7Emm8MdbcZcC41SMhTbSboT1Zpbj+rieCC9sPJ0ffAzirXxynXqq8HbzUxZZem/SDZ/2sDaSZlWelVarMG5XGpAVV2lKg1TKQLYjv29WB+5BHxTmYyE+mfXBvH3KbmHRcdqtAgr8DMwuGuZbp5W961E8K9dcyEPvkrljdek2wTOVSe1w+be7CQhInite+Te9MRbN3jehiKUHnkGic/+zX+kjLo1YlszifkmtpKEPbOkSjdrNpbsIbvqTLYrA0JvZtinR9v4MgNKsiz29foUGQ1OqKxLyIaQ0rRXhEy5hnEdj26oXlANL3R1kPteDdPjwqNtR2f9BZ11c5KRNH4i2JLOsOD2/IG4MYwrTm25f7tbvLlMMoz2XPOfpcgCf2pgpWgEety6DflUhgEC/6hfuwPQU2Tr6+D1SeqMW4X/7W6yAfOnQj+IsflcNtZxvVtb4F20aHtWI+wROe2Kn61FuxOzq4e659YdH/e9buX0YwZgDyXbwL2q4rZjPzEO31zsjBgPlHdC6vPUV1oZZSNpKgMWg8EyQn43J9Q74rmQa5xo37pWFCMrg/PKtKRcYe/FMMrpA3hYmNs3/EhS95c1+7lNWr+UIh8Dvc+JTyRW9+6weBuhjb2HtLpGHryL1ICPYt22xryTcXO4R95LWg0gh9hNQuijcnzwHaW7iNwYdVyDR6TOWjTrdWy01h60TA57ciGToz23MMf5y+lhUWzsOu+7Eu/T7Nq3lnMJfV+tkdzaVtj27r+ntb+FeWqb49ca75etoLqbKxzUx/sg0hrdrm25Pntcl5QvXP2gb83C+q4f+/+27n6l1t0J1XRGFUSBERmFHD9Royhk/cBeQzYrG16dUck7Qe3V/cQ937p3x25ySzN4d35/+x5VAGi8NMZxM6g4paqbV3vBmoS2lAeteHyffMoTkkEm5Kp6io8Y/BcvLza1ZwzQswS4ZnPW6rEyBAnUiFWeqiLPUwZJJfxqVndVW/3Bv2AqOPtcgAFg9b38hhl2JxJtr7LhyJu6K2dVy9MIGum4VDVDaQ86pp4ivMSKEsj1shPbwe10RaExhGY20+tPiryTQJNvbBtCf0vynrxQVtZyx1tQ935MPXVj9930PqeRLYwY0QVwHf1HzMaxGO4V9/TXGzK1lRjPzYTETBi2NNjYfm4uAczWGdnVc9QezlXouWrmKClPROa57kD1Io+UFpIujtgJtwe2w0FU3ttfy1UXiwoFSYSnXFuuNWLJkDrii5gFM5DrS2suzJY4A8CNJTmzxaftO4s7ofRCwvTXm27wIjXnlEhCWa46UqnQUUoXZ9lsQPgpXSww+4D9WRp5N2v5GTrUQuGtvgaZoZH//fzG5sL6Q+NO9GHnWibybknsaXXajz+MBbFXncZ/7Wx72ocPWYD83qEPq9f0xF2HH6LsNh8LM4TJvZNIsOUY6RjtWjLfqtR6EOGuFErk42r6680J+Uglo5cXJzbbqJRSbZoef0O90Nx6xa+0/A0Au+JtqR/BW65GM8UNI3Cl1TA+VWXCu6kMLUUqFipyZSPa0jxk2aFiBqSYA0BgQMzEO60n2zv4SAvK7t47rqjPBUi2vdLshc7NUV3gbQKVAE1SET+NC6ucxedRlC7oJny2+TJuYa+15txGW6utdV5IIWvWCJvI4GTrCDlbb/aHpyO4wGFp6tuZNzS3LGNTKA3SQT0xW4DAbjVUkx9PrU9XNqpaT+aG1TgmnXZt4jJtkFlGEw8nE13BVMQ0fWWH0Gtn3cRryHIhqVwRbf5mkyiNSd2kpalYMB75d1Kj2gR3oMAZq2u5TfZAl6nRZ7HIMtYdXhvM2ts5drHyAcAEUuhpDT3cdoRzlHZ/F3RJOi60y8uboJzwDsCykYExrkBqdUKKPKEalHUFLSd3QmoHOgbYfQTsqtIOCq+0O77FczUfmQm9bDwXweauxqtzryS0KO9yZisb3Cu9eecZuJ0VnXWzvzprXRmuPVgQOVRDsoK5Ch3k3b0d/H3FE0nncxZ3eOdhtjuyKy6UFhnIyiHyPzas87HRy2n5Z/RCjIkPrmjMV4Nz8tZc8ZIZki2i0AuBbHlwo/91+GJcozEWczOXuyxO0HZSNmJUkELPndJgJsfOsY/JsQZ1XHR2jn3QoWc4LjhroYLHfijiTRhTVx9jR49myFiLg4BLqOX0oPHNwlJua8nYxbMYiwYMzCUwx0ZngpOU8kVhZPXu8vLmfemX7ErZDq7JWJSt9V52pGdHB2ZckvyS3pGGnaz2ABQMZdQ9/h0t+lgyqBv9HWWwo90fi4b61rAjDbvtDm9QkXY8bo5meWsn0i2FgFexLrLOMOz8SvGUICwt4rjImQ36zRincoUhFO++ZtScS9o3DDbCJtdeJATkNi+4hr3c6oiyBxMSMyGZsxR2i7UH8JuXBaPDP+iSIPixOrM5e6PGuMrqEcG8/jEzXxhNotyfeKusDH8i3ujahtTMUhE/QfdOOBQ5NTKakfzqGZ9FsvnqIUgOSWaRO+hHY6TC7Jnc4iPFrrpBTNPU2jh3AK1uAdw3NxMqRevh5AF0XV4QM6AiKXsC8vv99cPVPRGS3F+dX17dnwwJHPiCcYjMB8Phv6LxsnalKwvueG/nO7GUNa9ug2tbLA+g424CKNIZuS0lCu60h1wnzQtrWd1Vew2SBeduxTveY2am3TBikeVUsxlLmV6tudVeKytH6iIVM5pGyazcWCCJ0LWJmNhtT91A+nVovP6J05JLZwyaL30770srgNVzgFyyzGy01aPh7lsbW17BWpf697fkjjFbNgA2B3lkvlQKIyERZhezx1UPR4YcsW5GgyEHkR56HJhNMxTl/sH3VqSndGFfkZZw+MIfadfpw5YOpaPaDX42Ip0uZeQw+mq3yPtQF2X0y3AUhmlddZLC2lhN8NYWG5Pevh737kIjor8fqYwPTCrjb4HUGY2f8IVyFC8pX0DkCjKdxRLscpV9p+xDszvLqYmduqwFhVP7Il9z9gwut1OhO4G5EJt2pl6ylBZyWI811kW9d1IfWbVkju0JeGE8ES9ndp5Bzzmdxedcs5uKCju/vVar6G1+vi0VaV/s71Bt8i9CqV4H03jhKqNp6lv9ryN5juUobG9XW13MT9STqmfzIlziEI2fijySoI1/L3jk6pMNue0/dNS3sPOWORrlDSZqnxZEFXkupGVSLhjXp4yfohMpARcHmQPVhQT0FusXpJXSfq38RCWBaxWhxhrFaa6WQr8aL1ypUFyNNE09eR6XtTO048iCifUsAWykvBMDYhovIVoyHaErejYrzOobkPZ6mfl2KSRXuca9ebLTW1TbAbYlxiIFQy7f3UDfIwQFeh1ud2YsclynO2QR737qKo1N7TUWpp67sxduwmv3X5moSIvIeRy5PWOqz2m0Zy70jiHURQBwB9fx/nIanodL+rUgAovScpFAGa7ZuNEVuc9oi2zGYGRfMr6WfTDL375OXYnCxpdsImO4I2wZz3CSdTmlKcz1SMRJyCjDA3/wYAPDmL6AZjMJsaym2s7PK+3291FCWbry8vmqiXWXB8PNwRqvh/GzUhhjviWefn/oU+L4CfSZYn++Vgomnt1LfbVOrY1POGyduK23FIl5JGb/gVgPv7aCJ2h2hg5sdhWlaSlqfMLYo31uTzhU79wwgcb5Vv1vWc/8hmgfdo8orF8eHu6q7deWqRHoAdnY7fR7J7sTImFBZZKCe3S6ynv24RL7YlCPoYH5n1cPDdxGubzuMd5Fwwa8eTEi3rvHwfGuuYIdBPLl1c3Vw9XQqJd9GRSDYP7l6vxyK33epAtCjakMn6ZNbdgL5ZpsjkNxVkimVzdXkwfyCYWO77yNoRtYKywlkYop50d+fNPMp/ObrMNi7062Zsch1EvQhXwr5Hswx6A/ZWOutvrp0szlaisgdKR4vfeUiBeeCpq8jmSsWCoMuNi227Jtly77xljlguN9vyuMT8lMJD1vz4v8tcn1CKzMnNuFt53WeTPYT3a3nGALnv/wpVmfaUB1++HLF9+jHacjthaFLXe6jdzsiqNV4VtgeLT+lgjZV0jHEfbjmIT9+OWLjcvIIxLm883mDIs4rTTscBtzeNZZDvLU6xyGfsqISCyyHHPPSpXEgtJhTbcuFmhRNXUpFyWW6cGcohmUhnc9P9CR96ebo7IEUporm3HTwxqUFS7kih3uYh0LduAnyte+X7d2y/MgP6ximeLHrFg2ve2uWPaK9XzvbHOZKftziGL3Rg18BYsMlKILCPrX9FfLm36cuo4791QPBUS6GjxBQ4/px6nHRRLbA4I1H6GGuG7R0H2af3S03JWkDFuGsM0rswLsG2+3Bm6nRIucxVugvRUa060wwcW1uhgPcq2PWOJns6ulmwJ76WTbLmEFep7gvdOupNnejmPRhQYgwO5eCmvhidwVLUu14cynYuhqrHXIaLPK5kmzVb3z2xxRkFykLN5K9ftoOL3mzzRlybnWks0KDUNXhj+AqrBrYDnO14SWUDGCzywB5NTWePtCzb59Uvtt+Qvyf6afbm05+VhICbG2qYwZ1WsbBGzk4q1wtuUvw0fb+IKLgJ070n8PiWTPwB/EZfp5VGoRKl6/ZcI5Gx3Ng/YyOw/CkTE+FVjEmn9tPMk96Zh+nH4UXC8fxCXVMM2B68fp5SCg4yWVC9vCwbK7XnvStmmjUpeVC10yekxT4AnFp7J66R7/2Pp0wS7ddQXw+UCX7/NRXb5fDyxS6yqQOX5EdLHTFfbhXn2ZgJfnUnxhGZZQr7oTWXiEC35qw85J6WC5u94O1aycWSfkBFK6Gi4Jq2cxhYCqjAI3N6YztctUSaCokyzLIGFUQ9oTGilp4UJHz0yxtpc6zJG7bhvsRkbmKVsse2IbJbKjoGqyT0sGzzStDoFb6oNRpQGRNu+3Srxea3fC50+vxwBYxltnK98VWrjkFwTh/AdiX8RsAK7atZxHAU2TxG9Ta/gJWa5XvizGmFfPDVad312XDbapJgmzK99ymlBPRk/iGvDKHB/9wr91ut6O0/ajYZ/NTH+dOltaG7f2LowN0mSpPtTejZbcMH+5ZktH6VbUYE6/L+k7/IzX2ac0xVtjKltzHKkhx67AhmdXrXHFfqjK5jAXKY2fliIdq7lG2SWmOk2uSGYWqXG7yMxPT6Ro1WteA/tW3OP3jwja7xQIntBNgHGpjIzX3QIOjnYspViDdwuVKC8i1aGphzjC3luJEhng+e7N7h0TmqZjtH6qeqwbL6pektD4NjazDwO/NI4RQC9G325hDJz1fvClmMo3sE2QLmPWf82eDOeMV0Y/YRlwZbuBKyVihs4DXl1WytNW1eecH6SozznfW01/u7t9+17OQ8E5pFM93M1P0H4BiMbhz/C9pPmAxYYt6oR8SxhP8OmvIpeffr/FCMB3wR8f7+yvLv55534Sfno1fTi/uLme/nJ1ib/8ljBVFYCjaeoS3xHMmhCpJf+SarrBfdme/oaHF/Z/MhrhOLIFok1+y66QWm22Qjj/LwAA//8wqQ1d"
+	return "eJztXd1z4zaSf7+/grUvmUnZymQm2bqburoqfyXxrcf2Wnay98RQJCxxhyIVgrTHqf3jrz8AEPyUKJGS5+r8sJuxJeDXjUaj0d3oPnY+i5ePjvcs/81xsjCLxEfnLye/Tf8C/wyE9NNwlYVJ/NH5L/iF4/wOH/zdWSZBHgnHT6JI+Jl04PPwuzjMkjSM585SZGnoS+cxTZb0t7MoyYNnL/MXExglFZHwJMwz9+Bfj6GIAvmRRj92Ym8pNBr8yV5W+ME0yVfqNw2gyoPYA2XeXE6+Nb/W4yWzfwJu69f8C5f/Cgx5TtKg+c/u0lutgEj12b98+xfrc43Y+Ofem+PAzpMX5cJZeWGq+AO0Akdkkqe+kJMaBfLDZJb7n0U2wX/XKKlj7cBwDSM4yaPjOdMPjhq1NmEQLkUs4duvhHGfSJhsWDXI33w7USI3+Xby7Tc9UQdJPovEGKClky28DFY3y9NYBLzexV5wTm4vnT9ykb7USYrC+LMIXM/3kzzOahTZGwJ/qvJvDxUGpV+3S84amvDn8tzJJVCSJTCsiLPw8UVBdRTUSSOGiuzuiILlOHW8KPTk5oAs7bIQXpQt1rLVaKqZyLzNVh7V3S80ulaDGy6T9/gIUgdrjlRkoZBuki1EKhvZ9hglXtaPafcL4cT5ciZSUgFqtkL1ILFexqyEiR3xBECc50UihSMzL8ul43txnGTADOdJpCEQEzSvdp2UlYgD2EZ7o4X23dJ7gV/9kYepAGHAsTaFiwNFT6J544yGN0gcZK+BHL90wgaWulnYsq8CWMpOjE4jSPwaTAxCAAPD4ovYkgWYEFbcmSZL9RtJLEbMC+8Jv4cfoTFaEOOXXC+Nh1MFCDqPQ9Ciev+HwORHUA4G94Q+xCSc3F0DVkl/hA8tgesA5yNohI+sEj7S545TMccJ+V/fTS/ufr08u/ju4teL63v3/n9uL9yzm/PSv2+vHqbu5XkX3dJP4EDxk2BATXi/CCUYEylMkwHdciV85IDEpUPtQYQqdcQcgM97zgpOPDhTT5ben0ns/CZmzlSkT6Gv2YS6NdYK9FiN6mt2nkRR8gziS6YMDAeyevtwenV5duScnJ3dPABPprcXZ5c/4W9gqOub64uJ08EZOml9EJt5kr4MKxtMDw7i6AkcXIKJc5tIGcLBb5MRSpmLI034dYLiBF+D8YkQ6S8EGr7B2cKL551CziQNvNZrRZ2GZ3lX0h2Sce4qCXbPL6Znd5e395c3183oI09mbr7CPbyLdmkEv0wkajcfgXYqmmc81RlDM0rensOytr4ZpHNH89CkqLgLldKISvLXxoZVnB+zF1vL/QSSIL54y1UEInxx9h4l9u582oI189Js6AXuXNSZmHstJxmbFyNsF1vilA1TWsZGJQBaGra7H4HhExwROfnKT5ZgvnRteAtNIw2Z+LKF0RCA3RmCwrE/u4Ek1iyaRkhemnpVdbvO/o9ZryAMb5bkGR/6MMlLSSw92w6uAN0M8QTOZH39cYe8wrTtdTWXttPIIvOTGPgfs71g6GN6N+T8hD/t5mk0LA0Pd1daEnZDRqI/LDa4Ju4GbV/HECkqWmraUSzIa4+fOt6vW4O1isYupnrzBaPDgGEBYauNF6JurX+smev0SW2vwx+O1T5Wf/lOfSAM6n6AWRhF5UtpsxegY5l+V2P8XlYVQoJg0WXaX3jpHJYFqXyByx7pRa1qwrjiGdU/bX6HLv/BhZ7zjKdsXLgoqV3CS9R98r6Ey3zZQoDC3uHnOcvTVMT+1kb8RW1eX42IktMyqVLh1zv4mLRpRZaelshlGzOaYZwskzQL/4QFgD3bCKQqWPjTtKT2qN6y4gAsD1lzXjaSZ6CBmII+aRlTT4mcbp2wmZnrZqwNqec6jcir8PpYpoDtjWGl+VrZdY1aMEK+PkhvLk6acB2YcQVEJ0eM+2Bey5ztfHyIZ69V8Ay0vYleZcZ2piFr/557dKK+MqbRqv+hsO2FaeUZW5nGF+0GS3XzswlH4Ls1nkwpRhXEE8ZZ8TzGJZONM6N/eJd5L5Qvt9+sJAJwG34MgSNtl+Ft5AQQ77pmaFrKjELUKjC3SoUkR7ZHwVmk1DNe1KARpxVchrlHhIQmCDkuweCvAynze/ZSCtYWMGqhT/xZE7StfKQzBlojqH6NQRXriC+rKEnB3Ce86CIwwfBCjjRNvjGKd7LNi2Eq5vnSjs4+C7gxwSDeSkdoTcbCbxSlfV6E8L9mgIY8B0n+rRcnCOEileI/kA658vyyrVhOfNA/XUa9GWfYW6UZ1pJ1ctdRNNriv+OtwoYUgRf4/ySY7bQ6epA9rQ1+8ZymPD/d9apVD+y2s6BtLHu8ae6DpMjHPLoTsAIyuwK1C3eeifdUva0VE212MNbXH39QBrwnkeIRFvFcqGWkwUFBSAAiMRqs2YYOZeU5M7+aZqnwllVWKCC50mu2mJHHZSXSMKk4VzZiCFzJRmOIvu69RobcxFEYi0sw0r7cihS9QbB0t2kyh8NCjiomKzMdMsRPMLxA7l/SF54Ti2dnHiUzL4KtBhsx8ECBhAgUY08zgQR7QcB5BZ6TeYCmnU4g6SnELB8R/JaGmTjzQEuBffUA+3pcOovw/KrA4DwjCMdXKMjKk8pKIEooUtpC/0ZU3gkvODSRILDB4DSeJbHMl/smUCu1gtAm4nyFzUmeVIi8cTseNU4jE3TkYS6Mk6We/9lZJM/OModjCGYjF5/N22wB58F8scopFIupStuwDH7dyrIGl14PhsHIXymX9qwf6pLVqBu+PqaNLltfE5/uxCpSGR/7tMFE5K2kphwM0WeBZ2uso1AOMHAJpvhKeGRAhBxXNzaHJJsDdXbjTMAFvFciYazROSZEFnZ9ZC+mvEPzDTWZ0v9rzu8G/u3DZPs/w7/71IslJ/3Bln2EAbLRBPBECV8q/skhWqTlOBJPwrJ2g1xQQmiBC4jwFTRpeA2/4UBNk1/NKYZLmBkSwyw4nezHipF0VZJ50WtlwwmH29pMxiyMwj9pv+1FUZVvA+uMyJzQFYlKjSni3cSWD6xXQ23jmdab3OmLhMW/SNMkHfMc7nl1ZcU2FzEwoeY95h9Qrb/c3986P757Z3LDk0DscMGFLR6EvK/OFsL//BOlPanb/5jMKey5R8608jJYkxVzC1Bj3gHua42Ol75jw95yqrt1Ep6RFOyDBDqN+NBTy4jpIIg4w1y8pOEoaxx1lmf8dcrrxgTvF4EZfDCINdiOloIX3INllmWRuKBk8pE4dNck/USc+OILsg9FuyZrHHKgK7Imf2wx780By2KOwmWYNXuzMAXQPGFw3ki0vz1ZYknMLHjbzgPS769TDso6fkxBUMfeJ+8L7grZaTLvpiq0wdztH+HcR1i9meDXVZjRGbefZzw6XK5IWuD4Be2DSgPs4uiF1c5xIJZkNCOXJLKpmUldmrVg0z2OcoUm2itmWCERTGrzVbbiM8XnQQWnKae7xrysYDXgkHauUovZ6QXaCFCANxBXogeI6bcev/HpuM8FabTFXveKMORRl+RVL8ThdQlwyLplkPy23avGdGDsdp9ahPOFqOUv8U9trIrsr5HzPoxrvaMdhnNVMWxmmv2Vjj26JddMDs7Mtp36B8nh+3uMj1+cTndLVxg6MP5rEuVL2pinL6jNdr/0a6eXBJHAxRMe8If2R7LC+y5GNq1brPJCk4m4ytDkfSJIEq+JHj4H5LDmdZilyfHMQwUHjM682BdHGCJNBbsCjK+tlN6jf93gBF93YWbW0NYblTe8Db5K5qDc3KyG4AwqnIy8hBU70PBFkuu3BpEea4TLjhPbWsfxsFYWcUewf89FDtZePM8WA+GtcBUP96rcGSfWsxdmJIEJmhQqIYEkaweS7s2Nt0ivGIi28kF1+d2NvQ4rfLRNR4rz5vLmdvoWvh+FIPAi0O98eC3xj6VT7pHv18qHB5pbbb6J84D77DnMFnaeAQ8wnZ6bPZrE0cs6ttgR6VFEVOVpdyy8dN7ERXY3LPr7H//6t4ph9LYIJ3ZLwTC8Oc1TmZ16EeqxAbhRYPqZfK6Rc5unKyy7gZDezFfv3x45hYA6N/C9JXHjl3P4u8y+f8sBqbMk0r/zv39bJobphXmBQ9UnnFmjlPogg2h0vkFJQxAOPYsxMEp/BxAEgSZOwTwPYyvQNkOG1QoONYscBWPIOYgL1uUK2l4d8o6TKCds/HhRVNPn6gHoMOoFAbCra89U1XbTkGRdBtE+COrEyHlocaLWL61TzEZyPlui47rhGaDw3+9mo/vv92mjn73fzUb3V/mEOD1Z1RLDmXjpe5EI3C3r3JQ1Cchg4lMMHisSoNzlmbBdAxigUDHTCC9V6CTQ8VFtLDa/t0NCWAm59OinkZZ1Dx7X1eo5u30wms5sLBsbHcT4qdy6+K7DO+PDYxTEwqNaYzZwZnRcYMZHtXBnTXP4oAzxNyFX3Yi8PCbDnXS6l7Y+gUZiJBxTEb56Hp8oNVWZIgpOUVCqUHkgPzF5jqy7BqsI/Bow5YxGUKe3qsYXSudPkSabUgr/T+9Am4sS7Ewq0dJIMO4V9IWtvDAAvfocI8n19WZrgNVKtshRgcIOIz9FYMKYTEJLtTaRPSfp50kYT8DMgkN7u8fEzZRWtbyagR65g+UbUFwJTi4FAsBnIn3EpxS1rRfGugQlGjNNl8J2ilz4uAsnzAgasE6bZeaTLkera2MyuymCofa4SP3Rb7FIFkn/V1YJ5G6GXppNl0hXO2j60hbLR8PsbYfRbHtZOabLWrf+JK4XxcMv3N523QFXbqgdF4Tyc5hM8Dawv5WjVdObzFNmPlJh1kOCSS8K/+iTF0YUWVDFNrdYtxqhI63baUGWtVxbU9hJDN3dDrJsdlrTXtbNInXUhdOEWWu3JY3rxbBaq7hz4TZanMJRUXXP7HuLcSGyrpXqT+NZK3VD7LQ+vp1G4RxzOet+qf1uvHGXs0bd7rtvm9Xk1NyJjwm1Lqe3DkTqnVglaVYuM1vyLqw8SckeSbYo/1GnCyMm9YwCfkmJ0OW/Kd8xVoJzlmGctxUVbiDS5fH2TOsYhOh5DkBK84ptSow5NHyQ7g5NgubdXFTf3fR30cEs0hTu6j6xzF/DJUb5hq4tWdQ+pPFNeXp2rfXBV3iCqb7ggDUgLuMAc9OtOtGm0Kjlfg6lI2LURS0K1QBdpeETFv0OYunGO1Rha3Eo8+jO+fW0VIm3dkPYEGVYzUJRklj9dQ9ol7dPP6BzDV/jO7CFEj8knzdF9bbCSgW6x2IoV/+u8nNDqVTQBuSiZpzCcYHKBfBd3pq/vEEGv4XTJI9Nf4K+LKUtNGmt0Lm1IqJxqzw84kz47/96PAsxwVOG85g80jTJRkiHX/dGpM4b1ZvB+ZeT5nHM/yUXeYZZFsfkZf6XAywGbU8y/S+0WKggkP5PEbxdQ1G2QAOXLzqoqsc6CtQ8ZG7pY6Eh4BftVrkGvr/PgN9Vc72agyXlnaK7NA7Okjhmq3ugB2zlpfTN8DZbMfpRFGWJXrA2J5xQocSYlX6FSQZKAhcqFZFKjZ2JZWLBQEut4FBHjjA+cTsDfeEqit33//jHwFTSKzoYFt/RrIBUwe/o9OM7SlrdEfSHcUB/GBX0D+OA/mFU0D+OA/rHUUCDWhmTy34UUssYVA0EWpZR1/bohpBH5DG2b4BfDQFZvTUb5uFnNUFS5UEWvhSCW2jLpdf2EpdMpScv6niRvAqjCBNuh4Nez5vV7/CMVjdP72fC9zD/g2DnKRXYFBygR3XfISNUDfzll0Qzfdd3L2Wmc7Hxl2KD2buOjHyqOrKhdEyRMjuJdgiwrWx+QwIeIVoQ5rdVaXlzf2b/1eQZaKsQDASdbuvV+NBO40M88pLk8bCLMly5l2I1KD9N1SY5QteJymg7YrOQsnu5AUHVYCEDMCve7jP7G1Q98CELo5rDJlWN3KQwlo86QIBrgUg7TghTgv3k6vQEhORJFJYeL+QwLCqqqpeMPpUL5qBY2nLqERRmHB8uUt8E67aeYW/5T/h5zHrJNiRfpz9fnT0MlfbcRHUZZOXN1xuY/K39cu5kZQoLOFf4zdO1sm3TdC2e97eeWBywupC2xb6/1bxNE7w0iMEeErWRrALberrNF83Uwy4+uutFtTzUHu+sFrmv7vrarNPGsHRegTY7o7Hvr6bXYp5koWeu62OYpjBNiUiqAG5bz+pSwM0uw4Bu80YdYHQLtgzuEOM2LROsijB5NBGZ6d2XBven8IsI3Dt19Llj0PyIUxyb09WreSwKb8UasHciCFOsuT3OrYEHHwTgQxq5V5hj615Q5Qzg8f4w+0keBfE3Wfnxl31xwMZYKkxl1oWS0FG02PzBC0WEe4f6fMXOv/9tw+vnh3/8YxRaLZcKE41Y+Q5KVIOqnZP/tUUZbH7hHw9+y7V/SPw/jom/xQcwKP5370bE/+7diMDfjwn8/YjAP4wJ/MOIwH8YE/gPQwK/vH36a8XAHsOeajCt60YCvRZHQN1wR/TQ4fCF+8VkJPfzIDZc08Zg6cEvaK9NbH4ggrrl5065K8dYoHUBsEZXaZmUBVV74voLIfVorxbqsYY+rA+7WJRe/M+xVJwX5ZxcNzS4PFovLnPY0lz+jt1zKXU+5oIVihgwKxdJ3rHFR/AubeVT6uMlHdmpq9SF9TIUeBQG5PFU7t4Dupy70Bl3dN2hoxJVdnXmFMPs0ZFzzZO+UifOT1HyPKQLs8OB8whTwcYpB0/e1s/HdeddBbgLh+/44PGEH42Aq+keCLiajkbAw/keVgAmGYyAr/Hc2IMfssp9lJkFGBNy4X3WVxxV4lkFx+MCS9E0QLsw0AxhT6MOjnYa64UqGstMbxGfTmtdHVjKG7ZRIW6bFtrco1072vf00DS9kksGhoD9KKewOqjk7y5v10djy9BHW5AG+Lbod7VpoPX4Kna2TZHa3yxNHdSd3bqsuzCMIIZ0ztcTNmB8583d9P5t+bk9PwAzwZNkQ9joRDoE5m1zphAzC9PBWc3sZVYz2///RjTkjehzGAsZ7lYZVY2xr7sQl9n7G0/aeBc6YP/Qn0V2J/wkDaQ7VHpDn0Zo+uE7dYgWljtQsUv1VzoCuj2Zp2Jd0692gbYIvcxQyyTpyVx8CiNQ65xaNS7pc/N8gh7ApYSFXmZGkQUO7OYoUomY3hxlC3bHcNzAHyAbg5j4Ld112BelDHh99aChuCCQiGvYFTkV7FQLynoST+AI9kZrs4/WaZw4BwYAP223CDDPbocVOPX/e+njkipSGvYUGD1pMCxlqtvuXiizOuo2LZl6KT2UvriM/WQJ+nx8rVgr2WI/UsFymWoXlZXAOsK4Ej4fF+ryQHVQcAaSiNtc8ZB2uPmXzdH13NGSvR/+aNkek0NKudGz4yE4ZT6+h/O1dilrZU0udYp/QVxRMHnbPVPQegA13kDIAGqgIEmruv11zLYUHncO42vF4KZRIdEHtAF7yaocUlj3Y3RoutukdljjwyJuE7nd7Yiu3pEbNaQq2g93K7z0ZKqdHO1XknPMBTwilowp36pE9NjmWN1roA4utKqpGkujKNvUB17mjcKCqdEq+zRLLV2mmXFgPug2kYcwzVUihspKprd1WFgVPZF5Kg7OGqu74OG5kzEY3SJ532zBevJ21yJTxV4nMI+oWAvm1BwEmV4iU7lmM6O3lc5pPkNMM3GfTPGe6N7BoTg6jZYBLh3BZdbZ2+BRpEcyKhpFt1elbSLtLCY8VyIs3/CCw1BTJXojUfq2cilT42TV8yHFF40h9X23OzlaYVjitVWBC2uSPxumh5YI9uDs2CdywVS9p+yiTVU4ZS6h96atSsLmJFI3zg2MyaG2R6VI7eAej2b62Hl4KhZhHKAJKbuTSXYjdghXXW3pBdKxjceumSGHOS72u+j727yWRoR1Sl/0GqtVCyVXY6JQt71lJ84l/RWbt5R1KqnKb9o0ZDsnqP3I4Q/BDSyEfodhswfIHq63+0ezLPKWs8AO6vQPU/EQe8zYu6IJX1e23mX8pN5fDZ+whKIgORfpMY+Lh1O0874IP8/47b1OvLDuMPxnfo8JQmH9kxYGbPg8Ujc9M/SaZ4eqFNLQRIYFA7fHdg621ZUARZIOhhKb4XryJfZht8UJdZrQQI8qVhivE0unNgKlKVFgFCI5xwJAehwRVFUABBvSk8XYRZ7M8PkKzH3OPdReflJXsddMqQG9EY25slOHEbCi+5oqQwGS1bCTsCNJYLKDcBNpIjqyOdTVZsy9UCkl41E7SXWj6oh6FIHjgeSC11MC43xsi0y18cw+1dXg+TCTLCztgWT81RrWnpm00gujsQbnspGAorpKURqmEASC2iGwDzHm2qRP9FhmDNQ/qTaIcPLdiXnDbmSEBfiZQNylTDdNq/pUkODzYmoupMEXqbx+R7qNZVw1Ujts7k2vFcKuCnb3yK3p8Tdu8LwJRbR6DqhqevaP/4hCT+0Rbs2EnuhutsKlChsbmnSzamPJFrKLzmR9GWBbM8M+PdrElhlwJU2xr8NThBIceGl5hdiFFEWtSxhK1TCubrqzCesqrbuTCV8eyjLl4Q8e+SmVKtfmePk2a1nyn/iSMuWRJsQrdXEhaZe0eakgPObPYaGC371nOflPpEmuPF/810RN8rsThZ+FPjF+93GSZ5zkdw2jnB/alqam9l7ZdsfPfHT+Y/Lj5N2GN4Zhi9VeW1WJFX/UXZAVtGmRa3G94d2Vl81hfz97LzstfzFMyy2uWGVaALrMpp7/2aGuhEjH9cm9o8bAhfC44wMbDK8umZAcfpfxT0CVZVIPrBcqvj6lum0+GU+QZSK3KzgL9JTYehi8+p0CbEvSeb/enq3BfJNn98nYfDa9lVT73hp45TDcnNUEe0ROq/p3nWh7Mbt4q33CN7Jxn2wX9z5KAm2hZBO4F4XrfuxX5vazm96Iyadwm6TZSaSL7YxiS1SFgeoBUSUpbdBhISm+i2Gzi46LoOpeDBtjL+/34VCIJbXftB3d2odLtdl1zxdAxr/pMOn43cJ5mqzGQK+fRQQpFXhv0HhroY19htQah+58ipSAj6LdNsbcS7kp3COfJbUeoEOcJjb0UTk++InSXEdwjFK+1jtipS2qJVvWamsNOg12uwvB9/cYy7g7n+4WyJjlqTSNqbfpd667C6qczn7XGCsZlNM/8GFc5Nzm6SqRwplOz50389X7twzzeJajpDqX392Yrs+mG1hzf42dWrnvRFql4TlXbGSPDZxyc1VC6h1urO/bsf9/9/Ya4v10b0evxQy2kmspkVHI0RNV6oKWfTYlZDPT82wCrBjOp6Be8N2pazf5GN6c3F2/JRHAckmoHNeD8iNPNvNqK1hnti6NLb8H1rbMM04HWYplkr4U1RgIg/7g+em6Dp0W+jCATYAx9GqbnSFI8HBZ02OZY/VfjOzrxS9mVdH64hf6EVseh3+A8gAALO/mEzhsLxK5Xd1w5E1V1oEspepYvZRUtiJS2oIulJ9dimO6gVhli0ZsO3dUBouMvKh4mF7eSOcNxp6+406UOlD2FtREaMr5UyBcPSGUn5ux64aKf0Qu18Z0QVfHmfvPZDaOxlAP+ad/v3KmXIzzBCd0cEK7q8XaDoSPqRB4dLq8e/baxruIPhT9POFcDPB1OXNdgWpF7qIXGNsTHhq2wuGQ+7kZryow52JhGJduuVyx1w2DIWVE17GzZsBmi6Qu8EicYTodYphw/XF+anObyGyeCpCnZvBJhPcUNxWmYrkroyRzI28+Wc4GhA8DzikBJfzTKHk1q/kbGdQAmPz6Il2Skv/t5IrTofWlsRd91Lw4TFbNK7Gl1qm//0ENwtFutF8bO9+24SMWEL97tOLVkh6ojIhdhJ1T8ihJHDuk0opYRw6uDkoXZohyHhpbEPapZK/IpxdYjCPnk5eG3vnpESecmVUqTdNib8hnb8VW8YG2PwLgHc/VnpK4ZmpUsxzJA2e0BtpUhQpvptLWFFEyl66qHFJfzV22HQmmRQpeACwFghP32k/cPnpPG4pP7547Co76NNxcaLZCp+YoYrjrQGFKV5T4n8eFZWbRgVFjgq7Dx/236Qg71J5TB22pvNpJnsJv7Y1HfYRosi5CJt1qf3g6rABOGEUiaDwLTCWjHLsYKahHeAQk1LAIDvIfj9mmM73KuslcsxvHpJP3Jm3TCpnGm7g7mWQKYlgjOrBBqKWzrOIxxgW/x7R2/B3n0aJKXSel8JkwdvVTuVF1grpQ0IxFWG6dPshMdvwEbuLLsNm9Npi25zn6aHkLYCCwCcbIxxHNYfR+H3RBNC608/Mrq6J0D2DLkYGByhZpBnshXwX4sohNQeZkL6Q80D7AbrPAqjDxoPCM3tFdvov5nFmSLSo5VtTfF6069VAGNLCO5cBJSs49Y80ry0CdrGSs4/mqtHWhuLZggatQDcmKUBVpcd7c8eBvC56k3uMjWN9169x+8EDs8oG4BN9KGoNIfxlZp32j51Pza7JCUMVbIRr8qHVP3pgremWGZEuSZ/OE2HKvRv96+IKm0RibuZrOb+pT1I2UtRglaKmWmNJgKofn2EblsEIdFx3PsQ06sgzHBccaynrvSUu8DmOkSqT0tGiG9LUoCLSFakYPKd+lXc2vk4w+lsVYNJBjLhCP1OsO/QlePM9xrd6AWfLW2CV9KethmoxFWaf10pOengbMuCTpLd2Thl5aewAKhlLqGn9PjT7WGpSVfs816Kn3x6KhfDT0pKHf6fAKBanndXM0zVu6kW64CBSKVZ71kNzOB/KnWG7pxPfzVchOPwCF3hR0oWjzdenhvaQeYWAPW9oZSLDIrQa4hg1uNXjZrQkdnNB5DPE5aB9fuwW/GiwYHf5OQQLry3LCOXuj+rhMARFrXv2eHS4omI8e6xtvkZWhb8RrTVubmhn610XzSTgUOSUyqp784iUnI1kferCSQ+C/1UXfHSMVZsvkFu0pVgUusGAR6zh1AS2iAOqT6wlNk9rb2R3ogplxQMmPzn67u7y/uMMks7uLk/OLu6MhgYt4HsbCHfZB2QV6gOyQbprHivc83xFTVg3dWmFbqhCR+c0EeESnq44U14ppD7lPqgHrtIhVawkCumK14xXvKTOTDwxMKQN9PAsjTCJrj2p3rpUidR4lMy9yg5k5WETgkmnjhkm/M3UN6Ze28vqZpnXOlTKoPvZujJcWAIvnAKs0XOJBW7wbb47acIUN1i7lz2/IHVRb7AB7BI7uly+FwKQiSPAU4+uqhpPaHGEzo8KQnUi3LQ7KphmKcv3mfyPSYWZ+SGzgwPZQV9ouedjQoFRUq8EnI9KpUkZ2o68URd6GOnfpfRmOQjutq0ySXR6tCp51Mar0enhcmwsVj/52pIbxwKSG8Wsgdeb5n+mFsusvMFnfVTW5ML+dt2vadsveNbvTTO3w1KYcGE2t67w94hsXDpBLMicoF2LdydRKFj+oH/Ik9rO83D6rjaxSMsfmBDzDoZw8T3ieQe85jfUHVb+jggqen8NqBb3Vv29KRdTm+9tVmvSLUHxc2Q4TrXC5BNOUysh53SQ/UkUSbu/LBeb0RC2pepwXoRKHYKB8BXKXoX0P+0iVqBvy2C8eiBVahOc1ORomgknSh4Wn8hWmnrCGScI4Ow7jYzIiU0Gbw3mE3ZfD/6O1WA6QFkL7jdQTGQI7BaHEGhl7K7lIsoPxQlWLpd2INUUUeRoX6xmv4cpCifUhlizJejLAxx7c7iLMXDJFJ7Mcd9+AtJc7DdSrYaniRerNE0/PqDYDzFXmXCmG3L79QN8RBGzX1oFb3RnzFe3THlnE/W9dRtmUXmNR6rm6e9Eh3Hn+YnXuLHGVxbHiOya+sNgyF7qnC3VuAexhOmIU3LoPG/pBvyQZ1iWOk0AYd83agw70pFYQnDHo8kvGQ+kH3P78OhULSZN/iRMZ7RNhQ3+GWlmVUxqJx2wk4lKx9EK68FsPNsiNqWuoVpMQTUHden6e0dsf3AAGe9HrY83c/8FwdbDK62H6m1mMMd8STz/s+pQYX+dO8PXGoUIGeHc38qqqRJF/QmFrxM3Wkps8uskMK+4Ov7esJ2g8QwM23kVw7OqlpieMLdKnzoRd5U4NY0mc+s2rljN9IPLD7hEX65f7+9vi+OUyNQlZQOy7nX5Qa4eZy3MvDSKhHp0CiLa3PAr7fFCLoYL554v7Cm4ULi17YdxEwxq8q3xEvLcPg+PtCMEOAvn84uri/mJo1Iu2DIpBMP9ycXK+kTyvk4VEjikMN9OqNGyFsiObY1ecBZIpiMHZvXNDi07vvFHRDSwVTIkrfS+O9/z4pppPpw9ZhYVjJxuzYxfq4UKZp6+FfA1mH/RjP/bxdlv5dolzqdoKBJ0o7rae4G4fY//yw6wML0uBgTbbZkc2N2rjN8ZylcQU71e9EYDkJGh5e56vDk2uRsBrpswuinay8YbYj/prTsE173/4Uq3PNKC4weDq3QFP53AtCq54u8m68Y7zitrHIqSr9TsMtrcU0lGE/TgmYTA4+2XSPRKm880eQyriBMLRIxqze9bZSqTHWubI9WM8IhhHp9wzI5JUU9yu6dbEAuCM6etjNiWV6aGcopkwirebH2TI69vNXlkiIm8lOeOmhTW0VrSRC3aowDoV7KC/SN3+oGvvmvtgqTLRFhfBeJ8Vy6bXzRXLDljP95b7C03RcTFMlwBdwWIJF0K4y1stjNqr5U0/TVXTJWxJNxCQVNXgsXq6wDwal64THVYfodq4rknR3Tx+UrTcGlKGLUNY5xXuAH7jrfbA9RR+tSqVsm5De51klG5FCS7nuhz2WJBLreSK4tu0W5op4KATd96iJgRxQHGnvqRxe8+x6CIFYGFXL4XxNQ0T2RdtGGXImZt86GqsZciks0z/LNA8peZ/j4TCWSVR6G8k+m00HF/GcFyHwUkGCmmGsfjXQ5XdONKM8w0+71ZQyYMfMgFAMdV4++LhuX1U+q75hvPf05tr7ijgJykcXhmnMi7xyXiHYlvLxetE6Zavho/c+yROLHb2pP9OBCkGTO6T8+iPUaklqBR+WybK2GjoH7WV2rlPFBnjU0FFrLHrzExsSQece5/ARlkAVjgUp1jr8mF6Pghof4G5EtTFg9ldrj1JuaNoxZrKhSoZHUuPYuog2kxg/PPjH65PZ53STSGAP3Y0+f7Yq8n39x2L1KoKZIofWF9vv4WwTALeapUm8J9UQr1oUMXwQB3Ex+x2DoyBpWK9DaJZGLNqkeGr3stwSVgtm8kGVGQUqLkpnalepgqf0VBf6uVSBCEQH7W4RgwtMIaL/WjqVuowV+6ybuCDzHmMwvmixbdhkO0FVZV9sCXEEzY+15fADeUBRWlApNX4lsGrpbYXPn173QdA42+dvejG4IlKfiEQyn5w+EXMGuCyXst5FNBeEOhjqoOfWGznRZfFGDP0XGHVye2l6bGOHYNC3vnMaYCsyGhJXMMCp1od7z3gX7tdb8Zp/tOwz2bgaFO6tDRu6V1YOEiTpfJQWzdaUsN8dc2W9tKtqMKcdltSd/gZr7OPUcUbYzKtOfbUkKMvsOHZVWpcsR0q0xzmNIJ/L5JorOYapktMcZt8Absyo2L0njPT0zuwRdY3tTGwr5M7+vweQeuTgsBj1KkbMG2VkfGqKODgaMcSig68G4iECURWmkL0P1NohK2PEpksBd3vXu3ZcQb2zRitn9RjXlgmtKLKJQnRtuHMPnL8er5PAFox6nYLY+Akx4PBapbJvIGtglQZs/pjfDN8DONC6QfhUsSSaPWkTPyQjAcKXRbCUxfVp1W8k6DC97cW019vr1+/lXMPKyKiaTZc5MdqvwCqhYaf0HtJ/EPoI1vkkfMOZCCgp7/SOb/57Zo8AN9bv3y45W+d/nyrvmL/9WJ6f3J6dTn95eKcvvkO3cOmABxms3LiO4HpcJEy+fhAdY35sjn9FQvP7v+EEqE4sgGidXZLX0i1Nls2nP8FMZ+l5w=="
 }
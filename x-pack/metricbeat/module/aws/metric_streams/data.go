@@ -0,0 +1,111 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metric_streams
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/elastic/beats/v7/libbeat/common"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/libbeat/common/aws/firehose"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/aws"
+)
+
+// streamMetric is a single entry of a metric stream using the JSON output
+// format.
+type streamMetric struct {
+	MetricStreamName string             `json:"metric_stream_name"`
+	AccountID        string             `json:"account_id"`
+	Region           string             `json:"region"`
+	Namespace        string             `json:"namespace"`
+	MetricName       string             `json:"metric_name"`
+	Dimensions       map[string]string  `json:"dimensions"`
+	Timestamp        int64              `json:"timestamp"`
+	Value            map[string]float64 `json:"value"`
+	Unit             string             `json:"unit"`
+}
+
+// eventsFromRecords decodes the newline delimited metric stream entries of
+// all records. Metrics sharing the stream, account, region, namespace,
+// dimensions and timestamp are grouped into one event, like the cloudwatch
+// metricset does.
+func eventsFromRecords(records []firehose.Record) ([]mb.Event, error) {
+	var keys []string
+	events := make(map[string]mb.Event)
+
+	for i, record := range records {
+		for _, line := range firehose.Lines(record.Data) {
+			var metric streamMetric
+			if err := json.Unmarshal(line, &metric); err != nil {
+				return nil, fmt.Errorf("record %d is not in the metric streams JSON output format: %w", i, err)
+			}
+			if metric.Namespace == "" || metric.MetricName == "" {
+				return nil, fmt.Errorf("record %d contains a metric without namespace or metric name", i)
+			}
+
+			key := eventKey(metric)
+			event, ok := events[key]
+			if !ok {
+				event = newEvent(metric)
+				events[key] = event
+				keys = append(keys, key)
+			}
+			prefix := "aws." + stripNamespace(metric.Namespace) + ".metrics." + common.DeDot(metric.MetricName) + "."
+			for stat, value := range metric.Value {
+				_, _ = event.RootFields.Put(prefix+common.DeDot(stat), value)
+			}
+			if count := metric.Value["count"]; count > 0 {
+				if sum, ok := metric.Value["sum"]; ok {
+					_, _ = event.RootFields.Put(prefix+"avg", sum/count)
+				}
+			}
+		}
+	}
+
+	result := make([]mb.Event, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, events[key])
+	}
+	return result, nil
+}
+
+func newEvent(metric streamMetric) mb.Event {
+	timestamp := time.UnixMilli(metric.Timestamp).UTC()
+	event := aws.InitEvent(metric.Region, "", metric.AccountID, timestamp, "")
+	_, _ = event.RootFields.Put("aws.cloudwatch.namespace", metric.Namespace)
+	for name, value := range metric.Dimensions {
+		_, _ = event.RootFields.Put("aws.dimensions."+common.DeDot(name), value)
+	}
+	if metric.MetricStreamName != "" {
+		_, _ = event.MetricSetFields.Put("name", metric.MetricStreamName)
+	}
+	return event
+}
+
+func eventKey(metric streamMetric) string {
+	names := make([]string, 0, len(metric.Dimensions))
+	for name := range metric.Dimensions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	parts := []string{metric.MetricStreamName, metric.AccountID, metric.Region, metric.Namespace, strconv.FormatInt(metric.Timestamp, 10)}
+	for _, name := range names {
+		parts = append(parts, name+"="+metric.Dimensions[name])
+	}
+	return strings.Join(parts, "|")
+}
+
+// stripNamespace converts a CloudWatch namespace into the root field used
+// for its metrics, for example AWS/EC2 -> ec2.
+func stripNamespace(namespace string) string {
+	parts := strings.Split(namespace, "/")
+	return strings.ToLower(parts[len(parts)-1])
+}
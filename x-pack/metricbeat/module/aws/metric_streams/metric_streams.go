@@ -0,0 +1,162 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metric_streams
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+
+	serverhelper "github.com/elastic/beats/v7/metricbeat/helper/server"
+	httpserver "github.com/elastic/beats/v7/metricbeat/helper/server/http"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/metricbeat/mb/parse"
+	"github.com/elastic/beats/v7/x-pack/libbeat/common/aws/firehose"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/aws"
+	"github.com/elastic/elastic-agent-libs/logp"
+)
+
+const (
+	metricsetName = "metric_streams"
+
+	// DefaultMaxBodyBytes is the default maximum size of a decompressed
+	// request body. Firehose buffers at most 64MiB per request.
+	DefaultMaxBodyBytes int64 = 64 * 1024 * 1024
+)
+
+func init() {
+	mb.Registry.MustAddMetricSet(aws.ModuleName, metricsetName, New,
+		mb.WithHostParser(parse.EmptyHostParser),
+	)
+}
+
+// Config holds the configuration specific to the metric_streams metricset.
+// The listener address and TLS settings are read by the HTTP server helper
+// from the host, port and ssl options.
+type Config struct {
+	// AccessKey must match the access key configured on the Firehose
+	// HTTP endpoint destination. Requests are not authenticated when empty.
+	AccessKey    string `config:"access_key"`
+	MaxBodyBytes int64  `config:"max_body_bytes" validate:"min=1"`
+}
+
+func defaultConfig() Config {
+	return Config{
+		MaxBodyBytes: DefaultMaxBodyBytes,
+	}
+}
+
+// MetricSet receives CloudWatch Metric Streams delivered by Amazon Data
+// Firehose to an HTTP endpoint.
+type MetricSet struct {
+	mb.BaseMetricSet
+	logger       *logp.Logger
+	server       serverhelper.Server
+	events       chan mb.Event
+	accessKey    string
+	maxBodyBytes int64
+}
+
+// New creates a new instance of the MetricSet.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, fmt.Errorf("error unpack raw module config using UnpackConfig: %w", err)
+	}
+
+	m := &MetricSet{
+		BaseMetricSet: base,
+		logger:        base.Logger().Named(aws.ModuleName + "." + metricsetName),
+		events:        make(chan mb.Event),
+		accessKey:     config.AccessKey,
+		maxBodyBytes:  config.MaxBodyBytes,
+	}
+	if m.accessKey == "" {
+		m.logger.Warn("access_key is not set, metric stream deliveries will not be authenticated")
+	}
+
+	svc, err := httpserver.NewHttpServerWithHandler(base, m.handleFunc)
+	if err != nil {
+		return nil, err
+	}
+	m.server = svc
+
+	return m, nil
+}
+
+// Run starts the HTTP listener and publishes the received metrics until the
+// reporter is closed.
+func (m *MetricSet) Run(reporter mb.PushReporterV2) {
+	_ = m.server.Start()
+
+	for {
+		select {
+		case <-reporter.Done():
+			m.server.Stop()
+			return
+		case e := <-m.events:
+			reporter.Event(e)
+		}
+	}
+}
+
+func (m *MetricSet) handleFunc(writer http.ResponseWriter, req *http.Request) {
+	requestID := req.Header.Get(firehose.HeaderRequestID)
+
+	if req.Method != http.MethodPost {
+		m.writeResponse(writer, requestID, http.StatusMethodNotAllowed, "metric streams must be delivered with POST")
+		return
+	}
+	if requestID == "" {
+		m.writeResponse(writer, requestID, http.StatusBadRequest, "missing "+firehose.HeaderRequestID+" header")
+		return
+	}
+	if m.accessKey != "" && subtle.ConstantTimeCompare([]byte(req.Header.Get(firehose.HeaderAccessKey)), []byte(m.accessKey)) != 1 {
+		m.logger.Warnf("Rejected metric stream delivery %s from %s: invalid access key", requestID, req.RemoteAddr)
+		m.writeResponse(writer, requestID, http.StatusUnauthorized, "invalid access key")
+		return
+	}
+
+	delivery, err := firehose.ReadRequest(writer, req, m.maxBodyBytes)
+	if err != nil {
+		var maxBytesError *http.MaxBytesError
+		if errors.As(err, &maxBytesError) {
+			m.logger.Warnf("Request body of delivery %s too large: exceeds %d bytes limit", requestID, m.maxBodyBytes)
+			m.writeResponse(writer, requestID, http.StatusRequestEntityTooLarge, fmt.Sprintf("request body too large: exceeds %d bytes limit", m.maxBodyBytes))
+			return
+		}
+		m.logger.Errorf("Read error for delivery %s: %v", requestID, err)
+		m.writeResponse(writer, requestID, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if delivery.RequestID != requestID {
+		m.writeResponse(writer, requestID, http.StatusBadRequest, "request ID of the body does not match the "+firehose.HeaderRequestID+" header")
+		return
+	}
+
+	events, err := eventsFromRecords(delivery.Records)
+	if err != nil {
+		m.logger.Errorf("Decode error for delivery %s: %v", requestID, err)
+		m.writeResponse(writer, requestID, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	for _, e := range events {
+		select {
+		case <-req.Context().Done():
+			return
+		case m.events <- e:
+		}
+	}
+	m.writeResponse(writer, requestID, http.StatusOK, "")
+}
+
+func (m *MetricSet) writeResponse(writer http.ResponseWriter, requestID string, status int, errorMessage string) {
+	if err := firehose.WriteResponse(writer, status, requestID, errorMessage); err != nil {
+		m.logger.Debugf("Failed to write response for delivery %s: %v", requestID, err)
+	}
+}
@@ -0,0 +1,184 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package metric_streams
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/libbeat/common/aws/firehose"
+	"github.com/elastic/elastic-agent-libs/logp/logptest"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	cpuMetric  = `{"metric_stream_name":"main","account_id":"123456789012","region":"us-east-1","namespace":"AWS/EC2","metric_name":"CPUUtilization","dimensions":{"InstanceId":"i-0123"},"timestamp":1700000000000,"value":{"max":4,"min":1,"sum":10,"count":4},"unit":"Percent"}`
+	diskMetric = `{"metric_stream_name":"main","account_id":"123456789012","region":"us-east-1","namespace":"AWS/EC2","metric_name":"DiskWriteOps","dimensions":{"InstanceId":"i-0123"},"timestamp":1700000000000,"value":{"max":3,"min":0,"sum":9,"count":3,"p99":2.5},"unit":"Count"}`
+	sqsMetric  = `{"metric_stream_name":"main","account_id":"123456789012","region":"eu-west-1","namespace":"AWS/SQS","metric_name":"NumberOfMessagesSent","dimensions":{"QueueName":"jobs"},"timestamp":1700000060000,"value":{"max":1,"min":1,"sum":1,"count":1},"unit":"Count"}`
+)
+
+func newTestMetricSet(t *testing.T, accessKey string) *MetricSet {
+	return &MetricSet{
+		logger:       logptest.NewTestingLogger(t, ""),
+		events:       make(chan mb.Event),
+		accessKey:    accessKey,
+		maxBodyBytes: 4096,
+	}
+}
+
+func newDelivery(t *testing.T, requestID string, records ...string) []byte {
+	t.Helper()
+	body := firehose.Request{RequestID: requestID, Timestamp: 1700000000000}
+	for _, r := range records {
+		body.Records = append(body.Records, firehose.Record{Data: []byte(r)})
+	}
+	data, err := json.Marshal(body)
+	require.NoError(t, err)
+	return data
+}
+
+// serve sends the request to the metricset handler and collects the events
+// it publishes.
+func serve(m *MetricSet, req *http.Request) (*httptest.ResponseRecorder, []mb.Event) {
+	rec := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		m.handleFunc(rec, req)
+		close(done)
+	}()
+
+	var events []mb.Event
+	for {
+		select {
+		case e := <-m.events:
+			events = append(events, e)
+		case <-done:
+			return rec, events
+		}
+	}
+}
+
+func TestHandleFunc(t *testing.T) {
+	m := newTestMetricSet(t, "secret")
+
+	var gzipped bytes.Buffer
+	gz := gzip.NewWriter(&gzipped)
+	_, err := gz.Write(newDelivery(t, "req-1", cpuMetric+"\n"+diskMetric+"\n", sqsMetric+"\n"))
+	require.NoError(t, err)
+	require.NoError(t, gz.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/", &gzipped)
+	req.Header.Set(firehose.HeaderRequestID, "req-1")
+	req.Header.Set(firehose.HeaderAccessKey, "secret")
+	req.Header.Set("Content-Encoding", "gzip")
+	rec, events := serve(m, req)
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	var resp firehose.Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.Equal(t, "req-1", resp.RequestID)
+	assert.Empty(t, resp.ErrorMessage)
+
+	require.Len(t, events, 2)
+	ec2 := events[0]
+	assert.Equal(t, time.UnixMilli(1700000000000).UTC(), ec2.Timestamp)
+	assert.Equal(t, mapstr.M{"name": "main"}, ec2.MetricSetFields)
+	for field, expected := range map[string]interface{}{
+		"cloud.provider":                           "aws",
+		"cloud.region":                             "us-east-1",
+		"cloud.account.id":                         "123456789012",
+		"aws.cloudwatch.namespace":                 "AWS/EC2",
+		"aws.dimensions.InstanceId":                "i-0123",
+		"aws.ec2.metrics.CPUUtilization.max":       4.0,
+		"aws.ec2.metrics.CPUUtilization.avg":       2.5,
+		"aws.ec2.metrics.DiskWriteOps.sum":         9.0,
+		"aws.ec2.metrics.DiskWriteOps.avg":         3.0,
+		"aws.ec2.metrics.DiskWriteOps.p99":         2.5,
+		"aws.ec2.metrics.CPUUtilization.count":     4.0,
+		"aws.ec2.metrics.CPUUtilization.min":       1.0,
+		"aws.ec2.metrics.DiskWriteOps.count":       3.0,
+		"aws.ec2.metrics.CPUUtilization.sum":       10.0,
+		"aws.ec2.metrics.DiskWriteOps.max":         3.0,
+		"aws.ec2.metrics.DiskWriteOps.min":         0.0,
+		"aws.sqs.metrics.NumberOfMessagesSent.sum": nil,
+	} {
+		v, _ := ec2.RootFields.GetValue(field)
+		assert.Equal(t, expected, v, field)
+	}
+
+	sqs := events[1]
+	v, _ := sqs.RootFields.GetValue("aws.sqs.metrics.NumberOfMessagesSent.sum")
+	assert.Equal(t, 1.0, v)
+	v, _ = sqs.RootFields.GetValue("cloud.region")
+	assert.Equal(t, "eu-west-1", v)
+}
+
+func TestHandleFuncValidation(t *testing.T) {
+	valid := newDelivery(t, "req-1", cpuMetric)
+
+	tests := []struct {
+		name      string
+		method    string
+		requestID string
+		accessKey string
+		body      []byte
+		status    int
+		message   string
+	}{
+		{name: "wrong method", method: http.MethodGet, requestID: "req-1", accessKey: "secret", status: http.StatusMethodNotAllowed, message: "POST"},
+		{name: "missing request ID", requestID: "", accessKey: "secret", body: valid, status: http.StatusBadRequest, message: firehose.HeaderRequestID},
+		{name: "missing access key", requestID: "req-1", body: valid, status: http.StatusUnauthorized, message: "invalid access key"},
+		{name: "wrong access key", requestID: "req-1", accessKey: "guess", body: valid, status: http.StatusUnauthorized, message: "invalid access key"},
+		{name: "request ID mismatch", requestID: "req-2", accessKey: "secret", body: valid, status: http.StatusBadRequest, message: "does not match"},
+		{name: "invalid body", requestID: "req-1", accessKey: "secret", body: []byte("{"), status: http.StatusBadRequest, message: "invalid request body"},
+		{name: "OpenTelemetry output format", requestID: "req-1", accessKey: "secret", body: newDelivery(t, "req-1", "\x0a\x02\x08\x01"), status: http.StatusBadRequest, message: "JSON output format"},
+		{name: "metric without name", requestID: "req-1", accessKey: "secret", body: newDelivery(t, "req-1", `{"namespace":"AWS/EC2"}`), status: http.StatusBadRequest, message: "without namespace or metric name"},
+		{name: "body too large", requestID: "req-1", accessKey: "secret", body: newDelivery(t, "req-1", strings.Repeat(cpuMetric+"\n", 20)), status: http.StatusRequestEntityTooLarge, message: "too large"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			m := newTestMetricSet(t, "secret")
+			method := tc.method
+			if method == "" {
+				method = http.MethodPost
+			}
+			req := httptest.NewRequest(method, "/", bytes.NewReader(tc.body))
+			if tc.requestID != "" {
+				req.Header.Set(firehose.HeaderRequestID, tc.requestID)
+			}
+			if tc.accessKey != "" {
+				req.Header.Set(firehose.HeaderAccessKey, tc.accessKey)
+			}
+			rec, events := serve(m, req)
+
+			assert.Equal(t, tc.status, rec.Code)
+			assert.Empty(t, events)
+			var resp firehose.Response
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+			assert.Contains(t, resp.ErrorMessage, tc.message)
+		})
+	}
+}
+
+func TestHandleFuncWithoutAccessKey(t *testing.T) {
+	m := newTestMetricSet(t, "")
+	req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(newDelivery(t, "req-1", cpuMetric)))
+	req.Header.Set(firehose.HeaderRequestID, "req-1")
+	rec, events := serve(m, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Len(t, events, 1)
+}
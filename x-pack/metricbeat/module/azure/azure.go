@@ -278,14 +278,15 @@ func fetchBatch(m *MetricSet, report mb.ReporterV2) error {
 					m.BatchClient.ResourceConfigurations.MetricDefinitions.Metrics[resId] = resMetricDefinition
 				}
 				m.BatchClient.GroupAndStoreMetrics(resMetricDefinition, referenceTime, metricStores)
-				var metricValues []Metric
-				// check if the store size is >= BatchApiResourcesLimit and then process the store(collect metric values)
+				// check if the store size is >= BatchApiResourcesLimit and then process the stores(collect metric values)
+				fullStores := make(map[ResDefGroupingCriteria]*MetricStore)
 				for criteria, store := range metricStores {
 					if store.Size() >= BatchApiResourcesLimit {
 						m.BatchClient.Log.Debugf("Store %+v size is %d. Process the Store", criteria, store.Size())
-						metricValues = append(metricValues, processStore(m.BatchClient, criteria, store, referenceTime, report)...)
+						fullStores[criteria] = store
 					}
 				}
+				metricValues := processAllStores(m.BatchClient, fullStores, referenceTime, report)
 				// Map the collected metric values into events and publish them.
 				if len(metricValues) > 0 {
 					if err := m.BatchClient.MapToEvents(metricValues, report); err != nil {
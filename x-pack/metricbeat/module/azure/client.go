@@ -8,7 +8,6 @@ package azure
 
 import (
 	"fmt"
-	"strings"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/resources/armresources"
@@ -173,14 +172,7 @@ func (client *Client) GetMetricValues(referenceTime time.Time, metrics []Metric,
 		}
 
 		// build the 'filter' parameter which will contain any dimensions configured
-		var filter string
-		if len(metric.Dimensions) > 0 {
-			var filterList []string
-			for _, dim := range metric.Dimensions {
-				filterList = append(filterList, dim.Name+" eq '"+dim.Value+"'")
-			}
-			filter = strings.Join(filterList, " AND ")
-		}
+		filter := buildDimensionsFilter(metric.Dimensions)
 
 		// Fetch the metric values from the Azure API.
 		resp, timeGrain, err := client.AzureMonitorService.GetMetricValues(
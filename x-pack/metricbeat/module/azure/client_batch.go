@@ -8,6 +8,7 @@ package azure
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -156,118 +157,177 @@ func (client *BatchClient) InitResources(fn concurrentMapResourceMetrics) error
 	return nil
 }
 
-// GetMetricsInBatch will query the batch API for each group
+// batchRequest is a single call to the batch API for up to
+// BatchApiResourcesLimit resources and metricNameLimit metric names.
+type batchRequest struct {
+	criteria ResDefGroupingCriteria
+	// batch is the index of the resource batch the request belongs to.
+	batch   int
+	metrics []Metric
+	names   []string
+}
+
+// batchResponse holds the values returned for each metric of a batch request.
+type batchResponse struct {
+	values    [][]MetricValue
+	intervals []string
+	err       error
+}
+
+// GetMetricsInBatch will query the batch API for each group. The requests run
+// concurrently, up to the configured batch_api_concurrency.
 func (client *BatchClient) GetMetricsInBatch(groupedMetrics map[ResDefGroupingCriteria][]Metric, referenceTime time.Time, reporter mb.ReporterV2) []Metric {
-	var result []Metric
+	var (
+		requests []batchRequest
+		batches  [][]Metric
+	)
 	for criteria, metricsDefinitions := range groupedMetrics {
-		// Same end time for all metrics in the same batch.
-		startTime, endTime := calculateTimespan(referenceTime, criteria.TimeGrain, client.Config)
-
-		// Limit batch size to 50 resources (if you have more, you can split the batch)
-		filter := ""
-		if len(metricsDefinitions[0].Dimensions) > 0 {
-			var filterList []string
-			for _, dim := range metricsDefinitions[0].Dimensions {
-				filterList = append(filterList, dim.Name+" eq '"+dim.Value+"'")
+		// Limit batch size to 50 resources and 20 metric names due to batch api limitation.
+		names := strings.Split(criteria.Names, ",")
+		for batch := range slices.Chunk(metricsDefinitions, BatchApiResourcesLimit) {
+			for namesChunk := range slices.Chunk(names, metricNameLimit) {
+				requests = append(requests, batchRequest{
+					criteria: criteria,
+					batch:    len(batches),
+					metrics:  batch,
+					names:    namesChunk,
+				})
 			}
-			filter = strings.Join(filterList, " AND ")
+			batches = append(batches, batch)
 		}
-		for i := 0; i < len(metricsDefinitions); i += BatchApiResourcesLimit {
-			end := i + BatchApiResourcesLimit
-			if end > len(metricsDefinitions) {
-				end = len(metricsDefinitions)
-			}
-
-			// Slice the metrics to form the batch request
-			batchMetrics := metricsDefinitions[i:end]
-
-			// Slice the Metric Names by batches of 20 due to batch api limitation
-			names := strings.Split(criteria.Names, ",")
-			for j := 0; j < len(names); j += metricNameLimit {
-				endMetric := j + metricNameLimit
-				if endMetric > len(names) {
-					endMetric = len(names)
-				}
-
-				// Make the batch API call (adjust parameters as needed)
-				response, err := client.AzureMonitorService.QueryResources(
-					getResourceIDs(batchMetrics), // Get the resource IDs from the batch
-					criteria.SubscriptionID,
-					criteria.Namespace,
-					criteria.TimeGrain,
-					startTime.Format("2006-01-02T15:04:05.000Z07:00"),
-					endTime.Format("2006-01-02T15:04:05.000Z07:00"),
-					names[j:endMetric],
-					strings.ToLower(batchMetrics[0].Aggregations),
-					filter,
-					criteria.Location,
-				)
-				if err != nil {
-					err = fmt.Errorf("error while listing metric values by resource ID %s and namespace  %s: %w", metricsDefinitions[0].ResourceSubId, metricsDefinitions[0].Namespace, err)
-					client.Log.Error(err)
-					reporter.Error(err)
-					continue
-				}
-
-				// Process the response as needed
-				for i, v := range response {
-					if response[i].Interval == nil || *response[i].Interval == "" {
-						// this should not happen because we have handled the wildcard
-						// timegrain config scenario. Therefore, we should not
-						// continue with data returned from the latest API call,
-						// because this data could be bad
-
-						client.Log.Error(
-							"error while listing some metric values by resource ID"+
-								" %s and namespace %s: The returned"+
-								"interval (timegrain) for batch %v of responses is empty",
-							metricsDefinitions[0].ResourceSubId,
-							metricsDefinitions[0].Namespace,
-							i,
-						)
-						if resp, err := response[i].MarshalJSON(); err == nil {
-							jsonResp := string(resp)
-							client.Log.Debugf("JSON of errored batch %v: %s", i, jsonResp)
-						}
-						continue // we do not record this data as it may be corrupted
-					} else if *response[i].Interval != criteria.TimeGrain {
-						client.Log.Warnf(
-							"error while listing some metric values by resource ID"+
-								" %s and namespace %s: The "+
-								"interval (timegrain) in the response for batch %v of "+
-								"responses does not match the requested timegrain %s",
-							metricsDefinitions[0].ResourceSubId,
-							metricsDefinitions[0].Namespace,
-							i,
-							*response[i].Interval)
-						if resp, err := response[i].MarshalJSON(); err == nil {
-							jsonResp := string(resp)
-							client.Log.Debugf("JSON of errored batch %v: %s", i, jsonResp)
-						}
-						// we leverage the modified response interval
-					}
-					client.MetricRegistry.Update(metricsDefinitions[i], MetricCollectionInfo{
-						timeGrain: *response[i].Interval,
-						timestamp: referenceTime,
-					})
-					values := mapBatchMetricValues(client, v)
-					metricsDefinitions[i].Values = append(metricsDefinitions[i].Values, values...)
-					if metricsDefinitions[i].TimeGrain == "" {
-						// this should not be hit because we always set a timegrain during
-						// fetching definitions
-						metricsDefinitions[i].TimeGrain = *response[i].Interval
-					}
-				}
-
-				result = append(result, metricsDefinitions...)
+	}
 
+	responses := make([]batchResponse, len(requests))
+	sem := make(chan struct{}, max(client.Config.BatchApiConcurrency, 1))
+	var wg sync.WaitGroup
+	for i, req := range requests {
+		sem <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-sem }()
+			responses[i] = client.queryBatch(req, referenceTime)
+		})
+	}
+	wg.Wait()
+
+	// Values are merged once all the requests are done, as the requests for
+	// the metric names of a batch update the same metrics.
+	collected := make([]bool, len(batches))
+	for i, resp := range responses {
+		req := requests[i]
+		if resp.err != nil {
+			err := fmt.Errorf("error while listing metric values by resource ID %s and namespace  %s: %w", req.metrics[0].ResourceSubId, req.metrics[0].Namespace, resp.err)
+			client.Log.Error(err)
+			reporter.Error(err)
+			continue
+		}
+		collected[req.batch] = true
+		for j := range req.metrics {
+			if resp.intervals[j] == "" {
+				continue
+			}
+			client.MetricRegistry.Update(req.metrics[j], MetricCollectionInfo{
+				timeGrain: resp.intervals[j],
+				timestamp: referenceTime,
+			})
+			req.metrics[j].Values = append(req.metrics[j].Values, resp.values[j]...)
+			if req.metrics[j].TimeGrain == "" {
+				// this should not be hit because we always set a timegrain during
+				// fetching definitions
+				req.metrics[j].TimeGrain = resp.intervals[j]
 			}
 		}
 	}
 
+	var result []Metric
+	for i, batch := range batches {
+		if collected[i] {
+			result = append(result, batch...)
+		}
+	}
 	return result
 }
 
+// queryBatch calls the batch API and matches the returned values to the
+// metrics of the request by resource ID.
+func (client *BatchClient) queryBatch(req batchRequest, referenceTime time.Time) batchResponse {
+	// Same end time for all metrics in the same batch.
+	startTime, endTime := calculateTimespan(referenceTime, req.criteria.TimeGrain, client.Config)
+
+	response, err := client.AzureMonitorService.QueryResources(
+		getResourceIDs(req.metrics),
+		req.criteria.SubscriptionID,
+		req.criteria.Namespace,
+		req.criteria.TimeGrain,
+		startTime.Format("2006-01-02T15:04:05.000Z07:00"),
+		endTime.Format("2006-01-02T15:04:05.000Z07:00"),
+		req.names,
+		strings.ToLower(req.metrics[0].Aggregations),
+		buildDimensionsFilter(req.metrics[0].Dimensions),
+		req.criteria.Location,
+	)
+	if err != nil {
+		return batchResponse{err: err}
+	}
+
+	resources := make(map[string]int, len(req.metrics))
+	for i, metric := range req.metrics {
+		resources[strings.ToLower(metric.ResourceSubId)] = i
+	}
+
+	resp := batchResponse{
+		values:    make([][]MetricValue, len(req.metrics)),
+		intervals: make([]string, len(req.metrics)),
+	}
+	for i, data := range response {
+		// The values are returned in the order of the requested resources
+		// when the response doesn't hold the resource ID.
+		idx, ok := i, i < len(req.metrics)
+		if data.ResourceID != nil {
+			idx, ok = resources[strings.ToLower(*data.ResourceID)]
+		}
+		if !ok {
+			client.Log.Warnf("batch API returned metric values for an unexpected resource in namespace %s, batch %v of responses is skipped", req.criteria.Namespace, i)
+			continue
+		}
+
+		if data.Interval == nil || *data.Interval == "" {
+			// this should not happen because we have handled the wildcard
+			// timegrain config scenario. Therefore, we should not
+			// continue with data returned from the latest API call,
+			// because this data could be bad
+			client.Log.Errorf(
+				"error while listing some metric values by resource ID"+
+					" %s and namespace %s: The returned"+
+					" interval (timegrain) for batch %v of responses is empty",
+				req.metrics[idx].ResourceSubId,
+				req.metrics[idx].Namespace,
+				i,
+			)
+			if raw, err := data.MarshalJSON(); err == nil {
+				client.Log.Debugf("JSON of errored batch %v: %s", i, string(raw))
+			}
+			continue // we do not record this data as it may be corrupted
+		} else if *data.Interval != req.criteria.TimeGrain {
+			client.Log.Warnf(
+				"error while listing some metric values by resource ID"+
+					" %s and namespace %s: The "+
+					"interval (timegrain) in the response for batch %v of "+
+					"responses does not match the requested timegrain %s",
+				req.metrics[idx].ResourceSubId,
+				req.metrics[idx].Namespace,
+				i,
+				*data.Interval)
+			if raw, err := data.MarshalJSON(); err == nil {
+				client.Log.Debugf("JSON of errored batch %v: %s", i, string(raw))
+			}
+			// we leverage the modified response interval
+		}
+		resp.intervals[idx] = *data.Interval
+		resp.values[idx] = append(resp.values[idx], mapBatchMetricValues(client, data)...)
+	}
+	return resp
+}
+
 // GroupAndStoreMetrics groups received metricsDefinitions and stores them in a in memory store
 func (client *BatchClient) GroupAndStoreMetrics(metricsDefinitions []Metric, referenceTime time.Time, store map[ResDefGroupingCriteria]*MetricStore) {
 	for _, metric := range metricsDefinitions {
@@ -8,8 +8,10 @@ package azure
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -130,10 +132,11 @@ func TestGetMetricsInBatch(t *testing.T) {
 		}
 		metricsDef := []Metric{
 			{
-				Namespace:    "Microsoft.EventHub/Namespaces",
-				Names:        []string{"ActiveConnections"},
-				Aggregations: "Maximum,Minimum,Average",
-				Dimensions:   []Dimension{{Name: "location", Value: "West Europe"}},
+				ResourceSubId: "resourceId1",
+				Namespace:     "Microsoft.EventHub/Namespaces",
+				Names:         []string{"ActiveConnections"},
+				Aggregations:  "Maximum,Minimum,Average",
+				Dimensions:    []Dimension{{Name: "location", Value: "West Europe"}},
 			},
 		}
 
@@ -193,4 +196,92 @@ func TestGetMetricsInBatch(t *testing.T) {
 
 		m.AssertExpectations(t)
 	})
+
+	t.Run("concurrent batches matched by resource ID", func(t *testing.T) {
+		client := NewMockBatchClient(logger)
+		client.Config.BatchApiConcurrency = 3
+		dimensions := []Dimension{{Name: "ApiName", Value: "GetBlob"}, {Name: "ApiName", Value: "PutBlob"}}
+		criteria := ResDefGroupingCriteria{
+			Namespace:      "Microsoft.Storage/storageAccounts",
+			SubscriptionID: "subscription",
+			Location:       "westeurope",
+			Names:          "Transactions",
+			Aggregations:   "Total",
+			TimeGrain:      "PT5M",
+			Dimensions:     getDimensionKey(dimensions),
+		}
+		service := &batchQueryService{values: make(map[string]float64)}
+		var metricsDef []Metric
+		for i := 0; i < 2*BatchApiResourcesLimit+10; i++ {
+			service.values[strings.ToUpper(fmt.Sprintf("/subscriptions/subscription/resource%d", i))] = float64(i)
+			metricsDef = append(metricsDef, Metric{
+				ResourceSubId: fmt.Sprintf("/subscriptions/subscription/resource%d", i),
+				Namespace:     "Microsoft.Storage/storageAccounts",
+				Names:         []string{"Transactions"},
+				Aggregations:  "Total",
+				TimeGrain:     "PT5M",
+				Dimensions:    dimensions,
+			})
+		}
+
+		client.AzureMonitorService = service
+
+		metricValues := client.GetMetricsInBatch(map[ResDefGroupingCriteria][]Metric{criteria: metricsDef}, time.Now().UTC(), &MockReporterV2{})
+		require.Len(t, metricValues, len(metricsDef))
+		for i, metric := range metricValues {
+			require.Len(t, metric.Values, 1, metric.ResourceSubId)
+			assert.InDelta(t, float64(i), *metric.Values[0].total, 0.0001, metric.ResourceSubId)
+		}
+		assert.EqualValues(t, 3, service.calls.Load())
+		assert.Equal(t, []string{"(ApiName eq 'GetBlob' or ApiName eq 'PutBlob')"}, service.filters())
+	})
+}
+
+// batchQueryService returns the value of each queried resource, in reverse
+// order and with upper case resource IDs.
+type batchQueryService struct {
+	MockService
+	values map[string]float64
+	calls  atomic.Int32
+
+	mu         sync.Mutex
+	seenFilter map[string]struct{}
+}
+
+func (s *batchQueryService) QueryResources(resourceIDs []string, _, _, _, _, _ string, _ []string, _, filter, _ string) ([]azmetrics.MetricData, error) {
+	s.calls.Add(1)
+	s.mu.Lock()
+	if s.seenFilter == nil {
+		s.seenFilter = make(map[string]struct{})
+	}
+	s.seenFilter[filter] = struct{}{}
+	s.mu.Unlock()
+
+	var data []azmetrics.MetricData
+	for i := len(resourceIDs) - 1; i >= 0; i-- {
+		data = append(data, azmetrics.MetricData{
+			ResourceID: to.Ptr(strings.ToUpper(resourceIDs[i])),
+			Interval:   to.Ptr("PT5M"),
+			Values: []azmetrics.Metric{{
+				Name: &azmetrics.LocalizableString{Value: to.Ptr("Transactions")},
+				TimeSeries: []azmetrics.TimeSeriesElement{{
+					Data: []azmetrics.MetricValue{{
+						Total:     to.Ptr(s.values[strings.ToUpper(resourceIDs[i])]),
+						TimeStamp: to.Ptr(time.Now()),
+					}},
+				}},
+			}},
+		})
+	}
+	return data, nil
+}
+
+func (s *batchQueryService) filters() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var filters []string
+	for f := range s.seenFilter {
+		filters = append(filters, f)
+	}
+	return filters
 }
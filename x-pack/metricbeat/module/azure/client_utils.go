@@ -9,6 +9,7 @@ package azure
 import (
 	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -220,15 +221,50 @@ func getVM(vmName string, vms []VmResource) (VmResource, bool) {
 	return VmResource{}, false
 }
 
-// Helper function to generate a string key for the dimensions
+// Helper function to generate a string key for the dimensions. Metrics are
+// only grouped in the same batch when they filter on the same dimension values.
 func getDimensionKey(dimensions []Dimension) string {
 	var dimensionKey string
 	for _, dimension := range dimensions {
-		dimensionKey += dimension.Name + ","
+		dimensionKey += dimension.Name + "=" + dimension.Value + ","
 	}
 	return dimensionKey
 }
 
+// buildDimensionsFilter builds the 'filter' parameter of the metrics APIs from
+// the configured dimensions, so they are filtered by Azure Monitor. Values of
+// the same dimension are combined with 'or', a '*' value returns the metric
+// values for each value of the dimension.
+func buildDimensionsFilter(dimensions []Dimension) string {
+	var names []string
+	values := make(map[string][]string)
+	for _, dim := range dimensions {
+		if _, ok := values[dim.Name]; !ok {
+			names = append(names, dim.Name)
+		}
+		values[dim.Name] = append(values[dim.Name], dim.Value)
+	}
+
+	filterList := make([]string, 0, len(names))
+	for _, name := range names {
+		if slices.Contains(values[name], "*") {
+			filterList = append(filterList, name+" eq '*'")
+			continue
+		}
+		conditions := make([]string, 0, len(values[name]))
+		for _, value := range values[name] {
+			// Single quotes are escaped by doubling them in OData filters.
+			conditions = append(conditions, name+" eq '"+strings.ReplaceAll(value, "'", "''")+"'")
+		}
+		if len(conditions) == 1 {
+			filterList = append(filterList, conditions[0])
+			continue
+		}
+		filterList = append(filterList, "("+strings.Join(conditions, " or ")+")")
+	}
+	return strings.Join(filterList, " and ")
+}
+
 // Function to get the resource IDs from the batch of metrics
 func getResourceIDs(metrics []Metric) []string {
 	var resourceIDs []string
@@ -287,23 +323,18 @@ func mapBatchMetricValues(client *BatchClient, metricValues azmetrics.MetricData
 	return currentMetrics
 }
 
-// processStore collects and return the metric values of the store using the batchAPI. After the metric values are collected, the store gets cleared.
-func processStore(client *BatchClient, criteria ResDefGroupingCriteria, store *MetricStore, referenceTime time.Time, report mb.ReporterV2) []Metric {
-	groupedMetrics := map[ResDefGroupingCriteria][]Metric{
-		criteria: store.GetMetrics(),
-	}
-	metricValues := client.GetMetricsInBatch(groupedMetrics, referenceTime, report)
-	store.ClearMetrics()
-	return metricValues
-}
-
-// processAllStores collects and return the metrics of all the stores using the batchAPI
+// processAllStores collects and return the metrics of all the stores using the batchAPI. The stores
+// are collected together so their batch requests run concurrently.
 func processAllStores(client *BatchClient, stores map[ResDefGroupingCriteria]*MetricStore, referenceTime time.Time, report mb.ReporterV2) []Metric {
-	var metricValues []Metric
+	groupedMetrics := make(map[ResDefGroupingCriteria][]Metric)
 	for criteria, store := range stores {
 		if store.Size() > 0 {
-			metricValues = append(metricValues, processStore(client, criteria, store, referenceTime, report)...)
+			groupedMetrics[criteria] = store.GetMetrics()
+			store.ClearMetrics()
 		}
 	}
-	return metricValues
+	if len(groupedMetrics) == 0 {
+		return nil
+	}
+	return client.GetMetricsInBatch(groupedMetrics, referenceTime, report)
 }
@@ -200,3 +200,21 @@ func TestGetInstanceId(t *testing.T) {
 	result = getInstanceId(dimensionValue)
 	assert.Equal(t, result, "242")
 }
+
+func TestBuildDimensionsFilter(t *testing.T) {
+	assert.Empty(t, buildDimensionsFilter(nil))
+	assert.Equal(t, "ApiName eq 'GetBlob'", buildDimensionsFilter([]Dimension{{Name: "ApiName", Value: "GetBlob"}}))
+	assert.Equal(t, "(ApiName eq 'GetBlob' or ApiName eq 'PutBlob') and GeoType eq 'Primary'", buildDimensionsFilter([]Dimension{
+		{Name: "ApiName", Value: "GetBlob"},
+		{Name: "GeoType", Value: "Primary"},
+		{Name: "ApiName", Value: "PutBlob"},
+	}))
+	assert.Equal(t, "ApiName eq '*'", buildDimensionsFilter([]Dimension{{Name: "ApiName", Value: "GetBlob"}, {Name: "ApiName", Value: "*"}}))
+	assert.Equal(t, "Name eq 'it''s'", buildDimensionsFilter([]Dimension{{Name: "Name", Value: "it's"}}))
+}
+
+func TestGetDimensionKey(t *testing.T) {
+	assert.NotEqual(t,
+		getDimensionKey([]Dimension{{Name: "ApiName", Value: "GetBlob"}}),
+		getDimensionKey([]Dimension{{Name: "ApiName", Value: "PutBlob"}}))
+}
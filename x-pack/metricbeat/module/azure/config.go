@@ -51,6 +51,9 @@ type Config struct {
 	BillingScopeAccountId  string `config:"billing_scope_account_id"` // retrieve usage details from billing account ID scope
 	// Use BatchApi for metric values collection
 	EnableBatchApi bool `config:"enable_batch_api"` // defaults to false
+	// BatchApiConcurrency is the maximum number of batch API requests
+	// running at the same time.
+	BatchApiConcurrency int `config:"batch_api_concurrency" validate:"positive"` // defaults to 4
 	// DefaultTimeGrain sets the default time interval when the resource config
 	// doesn't specify one. If no time grain is configured, this value will be
 	// used whenever possible.
@@ -65,7 +68,8 @@ type Config struct {
 // createDefaultConfig creates a default config for the metricset.
 func createDefaultConfig() Config {
 	return Config{
-		DefaultTimeGrain: "PT5M",
+		DefaultTimeGrain:    "PT5M",
+		BatchApiConcurrency: 4,
 	}
 }
 
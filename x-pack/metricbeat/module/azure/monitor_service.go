@@ -12,6 +12,7 @@ import (
 	"fmt"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
@@ -28,9 +29,30 @@ import (
 )
 
 type queryResourceClientConfig struct {
-	endpoint   string
 	credential azcore.TokenCredential
 	options    *azmetrics.ClientOptions
+
+	// clients caches the batch API clients by regional endpoint, they are
+	// safe for concurrent use.
+	mu      *sync.Mutex
+	clients map[string]*azmetrics.Client
+}
+
+// client returns the batch API client of the regional endpoint of location.
+func (c queryResourceClientConfig) client(location string) (*azmetrics.Client, error) {
+	endpoint := fmt.Sprintf("https://%s.metrics.monitor.azure.com", location)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if client, ok := c.clients[endpoint]; ok {
+		return client, nil
+	}
+	client, err := azmetrics.NewClient(endpoint, c.credential, c.options)
+	if err != nil {
+		return nil, err
+	}
+	c.clients[endpoint] = client
+	return client, nil
 }
 
 // MonitorService service wrapper to the azure sdk for go
@@ -112,6 +134,8 @@ func NewService(config Config, logger *logp.Logger) (*MonitorService, error) {
 		options: &azmetrics.ClientOptions{
 			ClientOptions: clientOptions,
 		},
+		mu:      &sync.Mutex{},
+		clients: make(map[string]*azmetrics.Client),
 	}
 
 	service := &MonitorService{
@@ -321,16 +345,11 @@ func (service *MonitorService) QueryResources(
 
 	resp := []azmetrics.MetricData{}
 
-	service.queryResourceClientConfig.endpoint = fmt.Sprintf("https://%s.metrics.monitor.azure.com", location)
-	queryResourceClient, err := azmetrics.NewClient(
-		service.queryResourceClientConfig.endpoint,
-		service.queryResourceClientConfig.credential,
-		service.queryResourceClientConfig.options,
-	)
+	queryResourceClient, err := service.queryResourceClientConfig.client(location)
 	if err != nil {
 		return nil, err
 	}
-	service.log.Infof("QueryResources to be called. length of resources is %d", len(resourceIDs))
+	service.log.Debugf("QueryResources to be called. length of resources is %d", len(resourceIDs))
 	// call the query resources client passing 50 resourceIDs at a time
 	for i := 0; i < len(resourceIDs); i += BatchApiResourcesLimit {
 		end := i + BatchApiResourcesLimit
@@ -19,5 +19,5 @@ func init() {
 // AssetGcp returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/gcp.
 func AssetGcp() string {
-	return "eJzsfVuP2ziW/3s+BTEvSf6oqCeZ/y4wwWKA6sp0T7aTTCGVCrBPGpo8ttmmSDVJVcX96Re8SZQt27Iurs5gu59SlsjfuZ9DHlKv0Aa2b9GKlM8QMsxweIue/yzligO64bKi6JZjs5SqeP4MIQUcsIa3aIWfIURBE8VKw6R4i/72DCGEfr65RYWkFYdnCC0ZcKrfuh9eIYELiBPZ/8y2tP9Wsop/SZ9P3+F4AVzXf46vysWvQEzy5w488T+PSzAjFRMrVIBRjOj9kXchpDAqDSr7f62fDkKx//k/5v6JDWwfpaKdAxdgMMUGzzW4JXWWsfVWGyhmGVqBlpUiMNngceA/1Qzx///ptF61xqWyWjjt7vg1L3BZMrEKj/6pNfgR7fwY1NGssUEKTKUEULRUskAtU7y+fY9+q0Btsz2yFoxzJlaH5msN86N/NqpG8k7bvttsSS0VdZpKxEKk9vx4ti+4Lpm3kN5IbdyzGjFBeEUBKVhVHKsrZPC3K4Tpr5U2BQhzhbCgSMlKUMt0UEqqrAMPEw+SEcgLKcx6CKbIMAWlVAa5cbomKpV0usDokFlu/dvo/Tskl8isIQo1zrsALsVKIyO7JjfSYN4x75JLbA7P+sW+Vs+EC1kJ0zW83lQD6fqyhoSmaNjWnVK02Lo/alAPjMCheZPhhgC4Tv+5h8MO0gkG/SQVgm+4KDlcIbzzxlKqYE53Riq8AsQ0ujNYUKyav93fddLkZ5iEn2Es7zvsHyodwGCtJWHYAEWPrFthI5CRDLaIWh6mBcprL1CHru2BXNTr0mW80h1ABGgDRwVNJOdAopw3sH31gHkFqMRMBf9aKvnAKCBMKbMPYt4E4NbQXblAA3ED251fjrGrec/h6f1mfAuWS0vWA+SlYqTLsZ4y8zUgssZqBRS5IZwCe10JmtSS4N0v99q517tf7pFhoHSGPsPSMlcjIoVRmBg3kpUjWyJclpwRvLC2Is0a1CPTcIWYea7d6Jxp/3ynlTsguTZYmdywootAig0cp8+9juzrkSRPXwmKSeoINmumE7dKpKJtoT+A0nbAHQktwOC36K/Zm+z1QfQg6HDsIOhTIOeS4ANWvxvvz5zhlP0oWO3Oi06YUAfbViBXCpdrRsKA6HENCtoe/hFr7+BfQLbKrlClXxGwGsxfXyGolCzh1SNo8/pltjfjAZqP0d3Q+LsU/U39AIW6BMKWjLjBnCNnIpDn6N2n6RWemg5ikwJ1nr/r8j9+GEQkhaDTiZSiKkaK7u+u0M8/TkHKwTrySDVxslBpUfe5JsJNgbDejT0Z+hB+Ui7bUK8oLJlN82Pw8dGJYIEWgLAxmKxtzJSudo1c0oNtvpYlVouW3R2uE27co+gnKU2pmDDHCgY75YiSYbLM2aqUJxEta9y98ucIwf5rDAj7/ggY43LDu5B4vX9XG9geDBdaZswGI4Y06T4fTEd86G2Mq+4pT8xYg8o0kSW8Pj/JurPvodd7VPaY7U12JBT3mvZNF3NtshAHfrXA2vubtaSSy9W2F64Cqw2YyVH5YQdh+stQMH85UzJyudRguiJGr6I6TBZG2V+zIbIoq1ZieMQX+2dnW7NZMgWPmPOMKlmWQLPF1kAX5dZ3HSb8vSCysOJ1r6MwWKys4yQ95s9LTDZgdO6Shmy/ajoLTRjsDDxMaIMFgYyUVabAukagOZEK9EEwe0uDO3A+VcUClM3w3TgoDoukz+jWUpuY/8f5T0HzpYetHDINZACoe1doWGeJOQ/AmEAaiBRU95o+K0mXfzg1s00Jl7aMTFZlMHfeCii6ub33GRHTiFRKgTB8a5FVGiLD+jCJMr3JFOChGn1j9c/C8xptR/Irs3bgXhPnshynxjUEO6RH8P6fSJagnF8/KiSH4lExA9PQb4cyIGxe2osBbuqJOeDG7M+CAgqpttnC6pYUmcJFrtnvMBCK1Vq31BrWSi0qPwMKhdnXjxn6Yqt176utAkvBtwg/YMbxgntr+/oxrEf5dNAy1L4Mb9ASF4x3xsFjJNkCdyBJHz38xspcsfxk1OhHXOZMDNRXK589yTibYSKgWlWgjTdiZjSSjwLZOZEuMYEnoVZWZkpyo5E6EhuKjXwKegWYR6k2GRMrBVpP5YYJsIe4SWbBhGnOQRKygsx5puGIYnIxChNMyBwN9l8PoAaCmJgvZ8OpyiP5zPHJmxQrJDDBh6E11mgBIJCqhGBidVRlPYDcuflBMP7OcWl9qFvR1UwQiDgesfbr1ECvkjwrQ3dun4kieAC1Rf/55+aX66UBhbT9nYnVlds0sYYqpEEPTLNopVVpDfP1m+bVvYrDvloqSXqVHO/Cw5PXHEkFxCttQGVrutSZBSckhS7FO8j2vYyfMps/ermLWhv+8e6nO0fQJzuBd2ZYQdQGK3q3J1QjOg1X+y2+nOASE2a2HcH3SCZ8EHccrkbtmyxqpFJEGFZ9fv7xDKSVYZz97hKmUWBtzClBERAmbB95oGHPs51H9MBXiTVgbtbbfMEl2cwh/3oK5KeIAncLRH1E/qtcZEvMONAZ0P0qF0En1/gBkJ/HSrqnPlpw0a/NiS61mPPQ6WpR2JzkAtxzXr6ez7rEnjjramI+QTcFyxhxN0DnE/ou0mGib5DOqQCdXB2sBlusRFavQ+Q+pc4bfzex6/yf68+f6gJSNwsgfUCW5Ry+ErtGAw8s7Oo75vZARKQwmAlQc+BygJIZTsMJsjtQ7Q+JzhZRKjE7dJ847ODY5KbAAq9m5I/Nbz6GOXazm/7qX4JvqZuKgW7316cFTUn+my1Krzz3ruq1vjC1NdgFoGXFl4zzpDmLrIFWvBcVD0yZCvOwajs9w8P4zaKplcDpWEikzfTt0H7d9jBjT3e5rpk2cqVwcYr7rhLxkVLKjeVugAFNueqe8c0ntuBwvlMjbF+LJb97pC4M/IamRsyv0oYBT7guywJaqVNp6Cy0u8igS6uGzAaxFXuwUcJgA30D2dNJLw1zZ8uwfnlCSTZMeQJ5JtzoJdV0o8lWKv223X6KT89YBFNJqgKEyShYpo9wVF9a7klXhIDWy4rXUyA/xYHYWQNx2yVzwrATaK+0v1WgGGgkFeJSbqryFDi/mTEnOjdDx+rJanNQaZ7/a7WBf8XsxAeKIu3qf7ShS+Gy7uu/uUV3BpMNVewBlGvtD2+73fH9sypLqdxbP//y9+eTaWGzFR3SKrerZwNaPnpn8aYqKo5dLnlzex97TUWyz5h2XdcI+q2KnaSBs4KZkRu2FrZH6kaLm5TNRKPxpUsy0+2iWtwecd8d1LAL4BvWCBZCGgTfCABFr5Frn3QrO60ffFdvwcx0i5dv/v8ZHAwZ5GSb8o20w8gdyjnzOu0pUudRl4bcczbdd1XGpusrBdi4hX4sdhQn1Zow4RPoDZRrKEBhnofVSm+HA/ddPkiCOarHrFdAve0x4fdjhnqJfayRb9OiDaPOgNet/04LNpxuGQk17MGOkX3YNO8ODJMCnMnqi5SA7yxO9HaegY+lTWWWuOJm4KZmEyVK1y5lh9JXaKHkBgSi8lG4ULEt4QoV+Fep3NmZgonuE4l7AMdZ9sfWksogbZxZAJcKYEGp/61jWGDpCOca9GUaX2oLZKzGG5atNPSO4q6x65L0++VPoL1HOxFOaiorIClmmhOabfpaXQlnyUBI6rsww6YBXnCYLBtOxkwy42A/bubxWOfxBrvI+zuDC5lyzYuL1tmJ6AaX2DVy1xozUtd8k7zoqL/Gadl+HppoxMTpqG+4SjVuauzMtcTkSwVDO1Z/UpCuePkBLU7eTdSUsH0L1cBO2x39uAxwj3hgM6ZDfEhLptaMGaorDzRdlhuINuQo4w0vcW4ht5sR4wUC0sgEtU+91THdZbP/lLtjzKlJRoIB1Q3O0znc8cn0jBoa+3JjZ3Hu5TiwL7fBKXYYW3cuJ3D3OnZH4NcgzFzYjcJChyafieGXjOZ+4WL4UZECf0O3/raWf96N1FWLZ8Qxj/bGV+wNKZUkoHU87jEWZE4xFFI82TaSkz2HB+CxcdYDGpXyRqImrrxjS8suzgHleCnpnrcY789O+YlS0pF2luK2XmJ6zB3+YRrYD5JXxRT5YoPYnW4LJUV9ACf0a9gpLxXAE/JG6PqnLrpSWz1O0glcs2RqfvCO7GwB1lem4I9laMdXEXeWxsNhwlreAUM4nHWRYyFcYrrAHAvS9wrBDxJT9GN8ZbZT6WtjSp0tMNmAoPm4RfJ2+EtOSuF6wze0pPzjy5fbH+4cV5BnixWkRAFHp2p2Ix2W7ewE6ogtHBZfbGsg7uhFB9g+AHUphR5alR3npR86MLPG+kIqRDBZw0vLS/hmQAnMHf4Xdy/7EnApHSCcgTDaQj2PwzOL/lwwlxLzIZsJfOyCyP+SRS0Yd/zxIEyHrNa0Lze3P9y/u40Rfwdr0NMGc4wJSy4f/V2QX25u3b+0v9XOSbi568KmpE23PNJGAS7cadp+xOfjjl22mdA6ezkdG04QMu6I73ExBjSp6+hFybyiCxTPLLvBtHejZ2J+q3v/4ccOXXqxHCGLl/3ImVkW3YSdNpIa5WWMJIU5L9cvbQIJZZ1Xx5Iy15rnpZLfthnhUrt7e4Tw18Ie3q7qW8wkY8XWXgXIgCqYcDfXuNLS2ufd3QfkYZzEOcoSd9dJG659/ZjoqL93qyegcVp6GFEjx68fz0Mk4PECciSubhsmRFmCmADiTTgolRxwrIwtNt25qDZsJavV2rmek1jbBQDHBgRhR7ZvT12K2X1cY+c+Zsq0UWxRpUW2n3qLCOak4o7bTjUe1yDS1hp/V4LlRFwvsKTF9ZDo3Kwb48nP+7dw1N7aza2N00mfXofs+CC36jjvbyPE/P/4N5J/vnHge+He3oKnY9Euy1bSoOubX1oOTgrPq8gjx7TDjFoqKYy7QJqUuTKHdxxm48vnL19QAVhXCvyF04DJOvE2aAHmEUBEArGgp3xNnSJ8z0YTieiucGczKXSaq9+3KfXh6wyG9n0zrbVqdgmmpbWuMuYpGee+84KMYqU/ABoYedV4rZiztXKkeA1wzbrOivlUkfOEtLcc80ESa3OKCwNt1XCV3+kM9unDkC6kNGugjuwXTKBCv2zIT8Pwc+0YoQ0mmysfrQomKgOtSpbjLahQhZRYh9XK2m17e0h3RLo3LtxH0vZ3Obr3M4bc4nvo22dltdDVotfgt9XirlrMthejBS71WhoXzblcjdrzdPHOXZ5Rn0bRGq/cHrPrjab+KHc9aQ9AvsMkX2xzn21eFODeWRAvk4DkGHoixZKt8qqkeJIGGRLv3/MDh5P5iKyxWIG+8hL3tpRcCrAtwX9NC3TFj7NbVEUeuTG6Ch8j9xRIIvaZIQ2XtOQUtImQc7wadsfh9QqcZwzbuS+jhvrhI/whDN0HmLD14lDPZ3S1qOfOMNlEQia0qVo3MNkI+ciBrrwpXTf/rrftWrZGgTO3h2+nPol+Fh9biRbqmpYXONtkOENh0vqHl0EeKbCTwLcGciIH79C2mO7PsjU3fSS5XtMm5dovWOgsMTI+gn6rpMEo7RM5hf2ynrgpslMQI/xzSgoFTHMOxoCa0wrKasGZDt+psXMiPycysmSkRUtP4IWkuTVdOxhnAuZE/7iWGlCcyX8mysneAf4oKVtur8nmXXxgArs+RF6eXjA0FaH7FESHm5rUBDIa1/PQD3on83sCtolCsoY+Q/YStCBeNJjO/tyaiV4jELSUTNiwVhnXpbUF04ojveioRD3XdHRcIjKE5CLGeqtBDQlzJUNdZO2mG5MSMToPnZagcTlUoNZpx/yy6lbCQzo4QgU76JlbbAdom1heZcV5niS+s0SVhJCe8cR14GDkPnjH4mJP16sakrWMH5rFjBaVP5yOoeFbI73ZNX38wqfKgZ6Ry0GcU5p2gn8HMU4vQseZcbLT61lw6TXCxkBRduNC94KzDTgC9JVfMLXvuCZShVhRcihAGF9ZUAm+Z3yBDVn7ayPrvALdSV+i1FeLCL5tbrmTAlovZP5+42QyZQXv+4vc59mtdsj6isb0XXe/Bi5LwAoVFTes5P6mx85l6xaj29F42pq5Oy8akUHspA6zrlmeC35c9NEAc7hSO+xRfT+NS5g5y8i4W9x2eUZGFDbHDm3QIypH35Jnq5eLRPm7ON2tdYP/PjG/g40zqGycpB1E3NebkZDildXlbYurjA7T7TY5c2rEDlGXUoP/IpLC3wYpw7nMu+TaTNu+9pc7xq3QnKLsyVR+n1C/4TgBkXXrzIxEhSMvQ9DGonPWgH9oHWd8pHcLuuNX98ct6SeL+W713C3xd7c1OLjfy9aqRxvdjRXo4cbrCTov3u30XYRV/NovOwBuNcOBeHkQ8fewGhZ2Igap+x9zvWgMRX+06mMMLRoEnXltpG0X303Gm/BnjvUHz5Uzl0YCpj96zPYwnz5YDzOMvQ6Ii/Qs7sYTTDaontiSUzDOWX1xwRfvG+pOWKabD5LEJnPXDds2Eu1OkePapNKNtqbEdpG24+3QSasRFl29GqFNFNc9KWet7j0Nm906W8Tf8Ns1TTKiZAwtRzW/ps3fAPfswNytRsO7cFvcXJ2GuGQjj9G/A25w4xPcxzWwWw1NWGGVzv5SgFlL6uaOeZpviraVZ5ce4Mqsf88w4fkCa6B5ECR2XxKZBnFdPzmd9EJzjkEEtQk3kqwUFgYo8nMjLTnwLaKVM4/w5PXNh84cuSGjiU4D0d/r8DW765sP6Zd5Ou7HPowksFGXQNiSkdwiKyozJqrvcDV23hSYpgyKMx7k1EQ3K+2g2blTafdGoml0dYLLlTphd37UeRrI8X5LL52h54l3rlbyg2lUgkKLimzAtMDW367lWOvWlT7u2+ix+14Q9/lGRPH2yhHjLg2Kzyko/eFRbEJjV7icx3fnP2AeT2zKyt8ETXHn8anWXaQuCclj9Px+NG+6C1Vj0oQ5r+UYrqv7g4oy8uABlIFvmOVcrnSv2PrVvYGu36NbJYvSoM+Rzx/kSh8LuQswadB9AKXd8KjFXvvUW/TX7E3259Yv58bo2M3UIdINbB+lokekuoaETqtccTR0//mDX/WJx3ZiHtABgULJ5dbW5ZICzxkdisVfz+iEGIZEbkgffMMFiTvX/nfg4XK1spWNTWQ7oFBsTlwWrw0uyuYg2PV7r2zhirJHrN0U3X1iMRPt5MKpq9DuBfvN6j0FYdiSgarZf/3+GMVx0hJvucRdMxv41lYRJih8e4uWmOsjgK6VwtskD0JhBlvdMbHS6afS3CZy6YxFO0Nmoqy6bxyKPmtWwMFcjyC+fh/0S1bmEFT3wBCF/mQddFDneiJnUi8gW2VXaAUFE+zVm+w/XpVKdl7v4Q0qeJAhIL76VztxdCaAJct9gBlqw0mIspl+Sq6w6SjcuJNt5gqVCigjppPwZdJK0q+IA4EXHOhbZFR17BLT+KHOupHCp56JVuBm2wRRMJhxF+X+++6fn6w5FrjTBJfpMspckINKH8Icfu4PugCDKW6Fq/F4ryl1y26Y1+MjrLUkzAVytxHV4VZ30f5vAAAA///FHLsX"
+	return "eNrtXW2T2zaS/r6/ApUvtrdmmNjZ3ap1XW2VPd5kfbGdOY/tqvskQyQkIQMSNEHOWPn1140XEpRAieKLJk6dt2ozkkjg6UZ3o7vRAC7JLds+J+s4/wshJS8Fe04e/SzlWjByJWSVkGtBy5Us0kfwQMEEowoeWVP4lDAVFzwvucyek3/BF4T8fHVNUplUgsHHFWciUc/1D5ckoylzHeG/cpvj50JW7hv/ef8dQZdMqPpr96pc/sbi0vs6gMf9M7gyXsqCZ2uSsrLgsdpveReCD6NSrIj+2vqpEwr+M18uzBPA5XtZJMGGAQ1NaEnnahxJnaVttVUlS2dpumBKVkXMJmvcNfxdzRDzv++Oy1Wr3URWSy3dgV8XKc1zkC/76Hetxg9I51srjuWGlkB5WRUZS8iqkClpqeKL69fkS8WKbbRH1pILAT139ddq5qV51omG905bv9ts8TU1rCoOSyyV4cdf9gcuNOYtpFfwsn5WEZ7FokoYoFpXghYXpKRfLwhNfqtUmbKshL+zhACsLEGms6KQRRTAw7M7yWMYHZmVmyGYHMMKlsuiJLqdUEd5IbUs8GRIL9fmbfL6FZErkAXmBtX1C7ZKZmsQExnqvJQlFYF+V0LSsrvXD/ha3RNNgZtlqHl1Ww2k6wNQ0tDkFBvNaUKWW/0lWNY7GKGufr3mhgB44X/cw4GNBMGQn2RB2Fea5oKBqO28AVOiVacbmFTomhGu4E+QSFo03328CdJkepiEn7YtYzvwi0pZMFQpGXNaAmX3PCywDshIBiOiloVpgTLSCygQXdsC6VkvJMt0rQJAMgbzzcGBjqUQoEN2nAH45R0VFSM55YW1r6CkdxysCk0Sjg+C9NcTcKvpkC/QQIS2d345xK7mPY2n95vuLbZaIVl3bJGDyWYD1Bx4H29osYZx0E1oATayYiWpNYI3v3xU2rzCH+AXskJF5D1bIXMVcDkrCxqXuiUcR74iMPMJHtMl6oqE5op7ruBPXj5SunXBlXk+qOUayEKVtICZg6chAmGA2GH69OsEX3ckGfpyVnCZaILLDShGY1Zj4HZ70O+AUmxwZ4SWICHPyT+jZ9HTTvQsS4Zjh5cfArmQMe3Q+t35/sQejukPTOq7/R5ToQDb1kyuC5pveGwbJPcgeqxt4e+pMgb+MYvW0QX8fRkzlGDx9IKwqpA5u7wH0/L0SbTXYwfNh+huaPxdZmwshSpnMV8BfdiYNuQ8s+RpevdpuqRT0xGjU1Bsx5Jim4H/JszKtDdKThQdRR9vLsjPL6cgpTOOPBBNHA1UWtS9r4nQXcDUuzv3ROSN/anQ3kZxmbAVRzffTT5mdoppBlQQWpY03uCcKXXs6rikBut8PZa0WLb0rjtOuNKPghskSzDcWXkoYMAuR4QMk3nOKFKGRJAwh7uX/+wg4KcxIPD9ETDG+YY31vECTjgF24Ohp5YZvUGHwXe6TwcTmB96K+M63OWRHmtQkYphSnh6upN1g++Rp3tU9ujtWXRgKu7V7bMQc9FZcA1fLqky9mYjEynketsLV0qLW1ZOjso0OwjTj0PB/HjiyMjVSrFSDQ2qbWe2lf2cTSzTvGo5hgdssXl2tpzNihfsngoRJeAO5SyJltuShShH29VN+OsMiMLh1a8T25iLrF0nPfpf5DQG+VAL7TRE+1HTSWhsYyfg4RlEExkEK3FeRTD7gk0DUOB1wxTcBWYvNbgD512VLlmBHr5uh7hmiTQe3QZTX9b/d/0fg2ZCD4wcIsXiAaA+6kADjSUwwgIDDxMak1mienUf5XE5oGd0CVcYRnpZGcCA1gp4cnX90XhEEPbEVVGAcyu2iAycJ8ewPkxKuLqFAaRDJfoK5Q/hGYnGlkxmFhvu1fFC5uPEuIaATRoEr38lYNAKbdfVURT3BS/ZNPRjUyXL0C/txQDd9cQc0G32Z0HKUllsoyXKlsyigqYLxX9nA6Gg1OpUq82VIirTA7GB2ae3ETwFYmtsNQqwzEB26R3lAhMkWts+vbX5KOMOIkPxZfaMrGjKxTY6kSQMcAeS9NbAb7RMB8sPRo26p/mCZwPlFcdnb2S0zsCMblCtKwj2jRJzmBTkPZg76BNCbYpp3wegVlbllOQ6JdUkNhQDzAegN2Ml+Ou3EczDMLeoqcxwzPidWyRDMLabU5BYryDSlmk4IudcjMLEJmSOYvjpjhUDQUzMl5PhVPkBf+Zw542LZR0Ya8PIhiqQXtCJosoyEIDoOICFNvODYPxb0BxtqM7oQrgTM4cDc5E6T82SC8/PisiNXmdKCANGbck/fmh+ebEqgSSFvwPwC71ogoqayZLcccWdllY5KubTZ82rexEHvpoXMu4VcryyD08ec3gRkKgUEBdtkpWKEFwmAU9A8DrZvufxJxz9RzPuWS0N/3n1040m6B12YIwZZuKsNODQ6zWhGtFxuMos8S1iCoLOy20UWtnp9IQ7cbvmatSmyKJGCq6vhYHi8/PLE5BWJRf8d+0wjQKLcw54XphvtstHBqhd82z7ET3wVdmGUVFutosleP+3c4x/3QUxXbgB1wmiPkP+m1xGK5gTgaDp0UHjViY39A6UWfeDI037g3N2bU50vsachk5VyxR9kjNwT1v5uj80iT1x1tHEfAPdBCxjhrsBOt+g7yIdNvQN0jkFIMjVwWKwpUUW1XmIhXGpF429m9h0/u+L9+/qAFI1CZA+IPN8DltJdaGBAWZX9TVzeyDCwgDKM6wWmB6XBuT1cByOHbuOaH/I7IyI/BHDpvvMwxoOOjcpzWDg5+MP+jdvbR+73k1/8c+ZKambioF69de4BU1I/gWD0gvDvYs612e7RoVdgm2sxIoL4RVn4apoJXpRcceLsqLCZm2nZ7htv0ma4ggcnwsx7S8YNm3ytt2MPV7lCqF7iRUQ6THu60jEzJRS3iJ3LQzWhKv6GVN8ggGHtp1gkfA1F/LrR+rAwCxoAukmS2sbTI6zIKmKY27oLLTrmUHlKIYcJ7E1GLoM47GS9Z3IHm70/Gnu5DGsX55wJBumPMB4etzoNar+QhNGKv2W3X5yT88YBCcyrrCcOUoYMn2EofrQMk+qimOmFJjQugtiuuiYO2sgerlkThjYgTJCi/XsHMynLKBdeVvlx8CZxYw50ekeAtmT9W2n0Dz6DD9+dt6JmShSv6r/HqeuguZ1Xf/VNVYLx7dJwTE1hqX99m29Or6/VwVTPPjWz7/8+9EMJfvWrdKrejihLUavLF5VaSWo9iVxMc/WmmbeOqNfdV0j6JcVO0qD4GD5Ri7YImyDVLfmFimbjkbj81My062iIm6DuO8Kql0FMAVrMc0wt8i+xgxIe0p0+aTO7LR+MFW90Mt0yctnfzuBg9aDnGxRvhlt23JAOGfO0x4jdR5xacg9ZdF9V2TQXV+DYS91oh8+twXHlxrb4QPIDcs3LAXHQSxsttLo4cB1lzcQqgtSt1lnQI3uAdt0s0OtxD5Wx7dp0dpWZ8Cr87/TgrW7W0ZCtWuwY8beLpqHJ4ZJAc6k9alPwDc2T/Q2npaPOboyK1qJcuCiZjNL5LpcCptSF2RZyFsINRJcUsepAlq6ICn9DaupsoSkPAvvSNwDOE6z37ZSKoOkceYBONcEZoX6Tz2HWZaOMK5WXqaxpRgg02K8YmGkoXYEF1ftbftA7Pml92AlwlFJxTRFE8w0OzTb9LWqEk4aA8wna/fQLhpgvc5k3rDXpucZW/3RPY/HOo812EXe3xicSZVrXpw1zvaGbnCIXSPXpTEjZc0UyWeB+GuclO37oZ5ETOyOmoIrX+Kmxs51ScxiVbChFas/wasem02DiFOEiZoStimhGlhpuyMf5wFuEA8sxtSIu6RkasmYIboyQP203EC01kcZr3iecbO+3YwYzzAhjXRQ+8Rbge7O6/373B2jTo0zYhWoLnCezuCOd6ZnlFBXl+sqixdmHAfW5TY4sx3G1pXLHty9it0R+LECeC7sZUEzZYt8Joaf82RhEhfDt4qk9Cu5Nqe1/HozUlYRz4htHu2FL1cbgsu/TCm33WMsyEVCQQmyB1tG0mMvAK1whbMG0CiX1xE1ceTtSlp2cQ4Ix3OZ7FmL8fbsmJ2AXkfqmY8brcT0mAP2YRrYd1JU6RT+YoNY726zIUW9AcfWa2CX55rAPfJGyPq7EF2+rh4m6QiuWTw103jAO1sytJU++EMe2uEs4k5q3G4mrMfbYrCbs86yLURImiypAHL6HiH4Bt4gL90rs+1K35RlrqIlbiTKksW4JHl7+vN2StF6wdeWpPznw4fr7280V4hhCw6kJBZHUDTDSId5OzsTtcNmN4uD/DkgeutFAGwfgCqXmWKz8NI0bZlZY30MshjjoTZPkJfsK0gvnr2G+B/fPOlLwLlkIBYcvsdKuxM5PPPQnwrmXMPcpTOWjyGI4sfIScG47Y+dMDWyWtI+XF1///HVtZvxd7BaOW0wuzlhJeS9OQsSGtCflDnVTo9wc9YFuqRNtTxRJcwAqd5N24/4xbhtl20mtPZeTseGI4SM2+J7eBgtGt909KJk3qGzFM88doNpD6Pn2fxa9/rNy4AsPV6NGIsn/ciZeSzChB1XkhrleZTEhzkv18+tAh5lwaNj43yhlFjkhfy6jWIhlT63J8vMsbBqYPLEP7unbsuV9uKBk6xIeaZPrtGhJernzc0bYmAcxTlKE991ysOnt56MmnO3egIaJ6XvekgoYDsJUcbuzzCOsY7bhg2izFk2AcQru1HK2+BYlRhs6n1RbdgQSa032vQcxdoOAARQCbHbgeXbY4dihrdr7B68DQ8VfFn5QbbpegvBgIgxa+NK4u83LPNLa8xZCcgJly9A0lw+xBk3NGPC+3n/FI7aWuu+Vall0rjX1jvu5FY9z5vTCDHR8//8G8U/UzjwrXBvL+GpWbTLsrUsyYurX1oGDlepkVeOR5pp3YyCV2E+xQOkwaIUZXl+vrz/8IGkjKqqYObAaQaxumdt8ITXeyxVsgRifeMRW1O7CN+y0jgiwhHubCpFjnP121alPnydQdG+baa1smbnYJof64JZekjG6XteCHydmw2glpEXjdVyPlvLR3LHANesC0bMx4KcB6S9ZZg7SazVySUG2qKhI7/jHuzDT0MqlXh7RKLJfswzkqonDfn+NAxBLDJC4SbKCzNbQQSGZ+T6kaygW8yH6Cgkp8pmK2uzbfTBXxEJL1zoS9L2VznC6xlDTvHtuvssr5aqWvZq/Lpa3lTL2dZiVEZztZGlns2FXI9a89TznT48o96NonB9DE2ero1OzFbuutMegEyFCfz/wnibZwW4txfEjIlFcgg9aPKKrxdVjpeETFAgE7vz90zDdmc+Xv6SAfwLM+JGl7xDAfBKI32bFlO4S+YQYIhLF44bo6PwMePuA/GGfWZIw0daigT3wNj2F1jDOeRwwRdrpi2jXc594iTUNO/gD2HoPkCPrWeHejqjwVa6viOwCY6QCXWqlg1oPpP3giVro0ovms/1sl1L1xImuF7Dx66Pop/FxlZZC3VNy2Ma3UY0IrbT+ocndjx8YEeBQ6cLvABwfCVPbI9Db0768Hy9pkxKl19wW1kCU7p9hHypgAvErxM5hv28lrgJsn0QI+yzT0qC544LVoJTOKcWgHciuLL31GCfxPQJH3Met2jpCTyVyQJVFxsTYBPmRH+/kYoR15O5JkqPvQb8ViZ8tQW9fuUemECvu8hb+AcMTUXoPgXO4PoqNcEYjat56Ac9yPyegNFR8HLoM3gvVgrcQYN+749QTdQGb3jLJcdbSyHi0VVaW1a25pFedEAQ7Pqajo5zzAzWuXBzPUpQQ8JczlCIrF13Y1IiRvuh0xI0zoey1GrpmH+swkLYJYMjRDBAz9zD1kHbxOOVV0IsPMd3llnFI6TnfKIrcCjRF95xl+wJvaqYl8v4vklmtKj8/vgcau8a6c2u6ecveiwc6DlzaYhzjiZ28GcYxumHUHNm3NipzSy4wJmg4GWneRgX+ZgJfss0AerCJEzxHV1EWhCOBeV4zp2JLBLJTM34kpYQi+hjI2u/gtxIE6LUR4vg5SD1KXd4I6n/QmTON/Y6K3DgTX2Rvp4dpUPWRzT67+rzNWieMwpfARk8F+akR3WU0e3ZeNqYOewXjfAgdlyHWXOWp4IfN/uA2s9hSrHZg/J+HFdWzhlGutXitsmDOMCiQB/blkGPiBxNSR5GL2eZ5W9cd9doBv88c36AjTOIrOukPYno25vB1maXKMvbFld5Mky22+TMKRE7RJ1LDP4Lb4v+1yBhOJV558zNtPVrP90xLkNzjLIHE/l9Qs2C4wRE1qUzMxJlt7wMQeuCzlkn/K48zviZXid0x2f3x6X0vWS+zp7rFL/qhvutLK0atM7c4IB2F15PUHnxaqfuwmbxa7usAehshgbxpBPxt5ANsysRg8T9j5kvGkPRHy36GEOLwkKleXMjbb34Zjxejz9z5B8MV05MjVhMf/Q528B8+Ml6mGLsVUCcpWZxdz6BjkndMZKTcgEi4w4u+GBsQ10Jy1VzIYkrMtfVsG0lUXoXOa1Vyl9oa0JsPdMG3raVtNBAFqrVsGWitK5JOSm79zBs1nk2h7/hty6a5HEh3dRyUPIdbXgNx7arzPC9fkkfN2C4+ra5nuLt/7zRPL8uZAp/2us8QtWHMLZ+/eEdKxS23iIYn3lO/hn9PfphRKkiTwIjcMu297JIuvlrTmmyB7Rq9w5YpvliJANmrqrI6uN/uyyIAGewAgkaAuGNfdcB0b1fkM/pF/EZb0j5nAOX4e9Qv2YSGdLrO5p6ZY56KkItdkOqjxhhF82F2Z8XC+xysfgMxC6ZCHMBflDRXweoQg8uYdsOsblj11wiUw+RLRTW/AvB61LTXRnb6fmTPuUl0HFdvW/kxeBAa4U7HPFQF3M8DB7tkl24s7a0AOuTLfGgiZ0k4LE7gwJXrvW9je2VfsJgCl+2E7hDp+9VaeU//mapRRnKtH3YG4amp6WUItgT/sBodqizl+aRU7pDC9pBWkjsdjq80W+f0l/XnVYn3HjVMvtHu24JOOhfFBCHfmp47PwkX4qCyrjcWoFH1hoN8VHXSqLwbCNwkHwdUQdp2pPCfhQd9tI80T0zOXs60I+ckIZ068eZaQoo2kTW31fCMxMV1td5HLzZ6WtMoj77t9cWkxt7TvBce0xozkceoPSKiZI20aC+Vo3qdXDPCUaO4i9AxkYmum+XoTPb4XDNISQJtCo3v0NgJRZLiudK2BGm+g65aRDXmXPrc6KbqUPCzMqTPYsORCnDSlXTN1Gg6mJLkkoHRvbJF1dv1GEymrzEQPQflXVXoS//TsbAzSjdSCwbVc5ivuLxApGlVTkmn7PDVVdzndLEZ5DrsZNTE52puYNm5zTN3bMop5HVCY7VDMLWy93zQHYnm5vRGXqSzM6hmqYxvFi4IMsKz5VpgXVHn8eCKtU6zBH+aPZdZrG+uJskFOIxardu188VLDfHhoDKmpJ+eyyj2ZcJz7mzOmRl7gCBdg5xwGxT1sstLm/y7UjedEfpu3QZnvXrxtEGT3/Qoax9BlaU7CvlCyHXqtfc+km/QV681nF3XmLWxfD5DTRxaMrtSKwQEkytPIt+aP1y6hzt6tiHZBow9dfQicLlWiMf378x631uw7bzAwIQEpYLucUVGZBAsRiW7kEsTcrHNUl0k2bytUdj71z4FEp2yPUac9ropYVOogV5OnxNkCpByJokAvBGC5s9nBY3pmAX4R0CLgcZ5MKxIO5jxr+g3Cdg1GEixJ3ilv2A4QDFrtOcbnGjeaBnGOO2iPAsYV+fkxUV6gCgF0VBt54fRGwPNnJX/iW5unww18qitCLzLK/CZ006mzUrYKuuBxADU418ge3ogqofGJvGqzvSKvWYResITCVLAcbls+jvl8C1J52dL6wFGQLik3k1iCMKe/wLM8EM1WFvikJP3yc3Q3eUXekzDWCqyMH887gMEr7yioj7RXcsw+OaExCcojp0fL27or0uoTWupycVtCmYgXfhW6Fnuf+++fUdqmNKywOIjdDNBdmKdBdm+3N/0DBQFA+qnhTviyTRC65U1O0T8ABkzPVErkuQAmZ1F+3/Aff/qBc="
 }
@@ -0,0 +1,152 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	monitoring "cloud.google.com/go/monitoring/apiv3/v2"
+	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/gcp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// mqlQuerier runs Monitoring Query Language queries with the QueryTimeSeries
+// API.
+type mqlQuerier struct {
+	projectID string
+	client    *monitoring.QueryClient
+}
+
+func newMQLQuerier(ctx context.Context, projectID string, opt []option.ClientOption) (*mqlQuerier, error) {
+	client, err := monitoring.NewQueryClient(ctx, opt...)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Cloud Monitoring query client: %w", err)
+	}
+	return &mqlQuerier{projectID: projectID, client: client}, nil
+}
+
+func (q *mqlQuerier) query(ctx context.Context, qc queryConfig) ([]mb.Event, error) {
+	req := &monitoringpb.QueryTimeSeriesRequest{
+		Name:  "projects/" + q.projectID,
+		Query: qc.Query,
+	}
+
+	var (
+		descriptor *monitoringpb.TimeSeriesDescriptor
+		data       []*monitoringpb.TimeSeriesData
+	)
+	it := q.client.QueryTimeSeries(ctx, req) //nolint:staticcheck // MQL is deprecated but still served by Cloud Monitoring.
+	for {
+		ts, err := it.Next()
+		if errors.Is(err, iterator.Done) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		if resp, ok := it.Response.(*monitoringpb.QueryTimeSeriesResponse); ok && descriptor == nil {
+			descriptor = resp.GetTimeSeriesDescriptor()
+		}
+		data = append(data, ts)
+	}
+
+	return mqlEvents(qc, descriptor, data), nil
+}
+
+// mqlEvents returns an event for the most recent point of each time series of
+// a query result. Labels and values are named after the keys of the time series
+// descriptor.
+func mqlEvents(qc queryConfig, descriptor *monitoringpb.TimeSeriesDescriptor, data []*monitoringpb.TimeSeriesData) []mb.Event {
+	if descriptor == nil {
+		return nil
+	}
+
+	events := make([]mb.Event, 0, len(data))
+	for _, ts := range data {
+		point := latestPoint(ts.GetPointData())
+		if point == nil {
+			continue
+		}
+
+		labels := mapstr.M{}
+		for i, lv := range ts.GetLabelValues() {
+			if i >= len(descriptor.GetLabelDescriptors()) {
+				break
+			}
+			key := descriptor.GetLabelDescriptors()[i].GetKey()
+			switch v := lv.GetValue().(type) {
+			case *monitoringpb.LabelValue_StringValue:
+				labels[key] = v.StringValue
+			case *monitoringpb.LabelValue_Int64Value:
+				labels[key] = v.Int64Value
+			case *monitoringpb.LabelValue_BoolValue:
+				labels[key] = v.BoolValue
+			}
+		}
+
+		values := mapstr.M{}
+		for i, tv := range point.GetValues() {
+			if i >= len(descriptor.GetPointDescriptors()) {
+				break
+			}
+			if v := typedValue(tv); v != nil {
+				values[descriptor.GetPointDescriptors()[i].GetKey()] = v
+			}
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		event := newEvent(qc, labels, values)
+		if end := point.GetTimeInterval().GetEndTime(); end != nil {
+			event.Timestamp = end.AsTime()
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+// latestPoint returns the point with the most recent end time.
+func latestPoint(points []*monitoringpb.TimeSeriesData_PointData) *monitoringpb.TimeSeriesData_PointData {
+	var latest *monitoringpb.TimeSeriesData_PointData
+	for _, p := range points {
+		if latest == nil || p.GetTimeInterval().GetEndTime().AsTime().After(latest.GetTimeInterval().GetEndTime().AsTime()) {
+			latest = p
+		}
+	}
+	return latest
+}
+
+// typedValue converts a point value to an object with a field per value
+// type, so that each type has its own mapping. Non finite values are dropped
+// as they can't be indexed.
+func typedValue(tv *monitoringpb.TypedValue) mapstr.M {
+	switch v := tv.GetValue().(type) {
+	case *monitoringpb.TypedValue_DoubleValue:
+		if math.IsNaN(v.DoubleValue) || math.IsInf(v.DoubleValue, 0) {
+			return nil
+		}
+		return mapstr.M{"double": v.DoubleValue}
+	case *monitoringpb.TypedValue_Int64Value:
+		return mapstr.M{"long": v.Int64Value}
+	case *monitoringpb.TypedValue_BoolValue:
+		return mapstr.M{"bool": v.BoolValue}
+	case *monitoringpb.TypedValue_StringValue:
+		return mapstr.M{"string": v.StringValue}
+	case *monitoringpb.TypedValue_DistributionValue:
+		return mapstr.M{
+			"histogram": gcp.DistributionHistogramToES(v.DistributionValue),
+		}
+	}
+	return nil
+}
@@ -0,0 +1,176 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	promQLEndpoint = "https://monitoring.googleapis.com"
+	promQLPath     = "/v1/projects/%s/location/global/prometheus/api/v1/query"
+	promQLScope    = "https://www.googleapis.com/auth/monitoring.read"
+
+	// promQLMetricNameLabel is the label holding the metric name of a sample.
+	promQLMetricNameLabel = "__name__"
+)
+
+// promQLQuerier runs PromQL instant queries with the Prometheus compatible
+// HTTP API of Cloud Monitoring.
+type promQLQuerier struct {
+	url    string
+	client *http.Client
+	now    func() time.Time
+}
+
+func newPromQLQuerier(ctx context.Context, projectID, endpoint string, credentials []byte) (*promQLQuerier, error) {
+	creds, err := google.CredentialsFromJSON(ctx, credentials, promQLScope)
+	if err != nil {
+		return nil, fmt.Errorf("error reading Google credentials for PromQL queries: %w", err)
+	}
+	return &promQLQuerier{
+		url:    promQLURL(endpoint, projectID),
+		client: oauth2.NewClient(ctx, creds.TokenSource),
+		now:    time.Now,
+	}, nil
+}
+
+// promQLURL returns the URL of the query API of the project. The endpoint is
+// the URL or the host, with an optional port, of the Cloud Monitoring API,
+// the default one if empty.
+func promQLURL(endpoint, projectID string) string {
+	switch {
+	case endpoint == "":
+		endpoint = promQLEndpoint
+	case !strings.Contains(endpoint, "://"):
+		endpoint = "https://" + endpoint
+	}
+	return strings.TrimSuffix(endpoint, "/") + fmt.Sprintf(promQLPath, url.PathEscape(projectID))
+}
+
+// promQLResponse is the response of the Prometheus query API.
+type promQLResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string          `json:"resultType"`
+		Result     json.RawMessage `json:"result"`
+	} `json:"data"`
+}
+
+type promQLSample struct {
+	Metric map[string]string `json:"metric"`
+	Value  [2]interface{}    `json:"value"`
+}
+
+func (q *promQLQuerier) query(ctx context.Context, qc queryConfig) ([]mb.Event, error) {
+	form := url.Values{
+		"query": {qc.Query},
+		"time":  {strconv.FormatFloat(float64(q.now().UnixMilli())/1000, 'f', 3, 64)},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, q.url, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := q.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("error reading response: %w", err)
+	}
+
+	var result promQLResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, fmt.Errorf("error decoding response with status %s: %w", resp.Status, err)
+	}
+	if result.Status != "success" {
+		return nil, fmt.Errorf("query returned status %s: %s: %s", resp.Status, result.ErrorType, result.Error)
+	}
+	return promQLEvents(qc, result)
+}
+
+// promQLEvents returns an event for each sample of an instant query result.
+func promQLEvents(qc queryConfig, result promQLResponse) ([]mb.Event, error) {
+	var samples []promQLSample
+	switch result.Data.ResultType {
+	case "vector":
+		if err := json.Unmarshal(result.Data.Result, &samples); err != nil {
+			return nil, fmt.Errorf("error decoding vector result: %w", err)
+		}
+	case "scalar":
+		var s promQLSample
+		if err := json.Unmarshal(result.Data.Result, &s.Value); err != nil {
+			return nil, fmt.Errorf("error decoding scalar result: %w", err)
+		}
+		samples = append(samples, s)
+	default:
+		return nil, fmt.Errorf("unsupported result type '%s', only instant vectors and scalars are supported", result.Data.ResultType)
+	}
+
+	events := make([]mb.Event, 0, len(samples))
+	for _, s := range samples {
+		ts, value, err := parsePromQLValue(s.Value)
+		if err != nil {
+			return nil, err
+		}
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+
+		labels := mapstr.M{}
+		for k, v := range s.Metric {
+			if k != promQLMetricNameLabel {
+				labels[k] = v
+			}
+		}
+
+		event := newEvent(qc, labels, mapstr.M{"value": mapstr.M{"double": value}})
+		if name, ok := s.Metric[promQLMetricNameLabel]; ok {
+			event.MetricSetFields["metric"] = name
+		}
+		event.Timestamp = ts
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// parsePromQLValue parses a [<unix time>, "<value>"] pair.
+func parsePromQLValue(v [2]interface{}) (time.Time, float64, error) {
+	sec, ok := v[0].(float64)
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf("invalid sample timestamp %v", v[0])
+	}
+	s, ok := v[1].(string)
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf("invalid sample value %v", v[1])
+	}
+	value, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return time.Time{}, 0, fmt.Errorf("invalid sample value %q: %w", s, err)
+	}
+	return time.UnixMilli(int64(math.Round(sec * 1000))).UTC(), value, nil
+}
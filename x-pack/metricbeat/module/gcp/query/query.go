@@ -0,0 +1,209 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"google.golang.org/api/option"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/gcp"
+	"github.com/elastic/elastic-agent-libs/logp"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+const (
+	// metricsetName is the name of this metricset
+	metricsetName = "query"
+
+	languageMQL    = "mql"
+	languagePromQL = "promql"
+)
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. After the
+// MetricSet has been created then Fetch will begin to be called periodically.
+func init() {
+	mb.Registry.MustAddMetricSet(gcp.ModuleName, metricsetName, New)
+}
+
+// MetricSet holds any configuration or state information. It must implement
+// the mb.MetricSet interface. And this is best achieved by embedding
+// mb.BaseMetricSet because it implements all of the required mb.MetricSet
+// interface methods except for Fetch.
+type MetricSet struct {
+	mb.BaseMetricSet
+	config config
+	logger *logp.Logger
+
+	mql    *mqlQuerier
+	promql *promQLQuerier
+}
+
+type config struct {
+	ProjectID           string        `config:"project_id" validate:"required"`
+	CredentialsFilePath string        `config:"credentials_file_path"`
+	CredentialsJSON     string        `config:"credentials_json"`
+	Endpoint            string        `config:"endpoint"`
+	Queries             []queryConfig `config:"queries" validate:"required"`
+}
+
+// queryConfig is a single MQL or PromQL query run on each fetch.
+type queryConfig struct {
+	// ID identifies the query in the events it produces.
+	ID       string `config:"id" validate:"required"`
+	Language string `config:"language" validate:"required"`
+	Query    string `config:"query" validate:"required"`
+}
+
+// Validate checks the credentials and the queries.
+func (c config) Validate() error {
+	if c.CredentialsFilePath != "" && c.CredentialsJSON != "" {
+		return errors.New("both credentials_file_path and credentials_json specified, you must use only one of them")
+	}
+	if c.CredentialsFilePath == "" && c.CredentialsJSON == "" {
+		return errors.New("no credentials_file_path or credentials_json specified")
+	}
+
+	ids := make(map[string]struct{}, len(c.Queries))
+	for _, q := range c.Queries {
+		if _, ok := ids[q.ID]; ok {
+			return fmt.Errorf("duplicate query id '%s'", q.ID)
+		}
+		ids[q.ID] = struct{}{}
+	}
+	return nil
+}
+
+// Validate checks the query language.
+func (q *queryConfig) Validate() error {
+	q.Language = strings.ToLower(q.Language)
+	switch q.Language {
+	case languageMQL, languagePromQL:
+		return nil
+	default:
+		return fmt.Errorf("unsupported language '%s' for query '%s', please specify one of %s or %s", q.Language, q.ID, languageMQL, languagePromQL)
+	}
+}
+
+// New creates a new instance of the MetricSet. New is responsible for unpacking
+// any MetricSet specific configuration options if there are any.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	base.Logger().Warn(cfgwarn.Beta("The gcp '%s' metricset is beta.", metricsetName))
+
+	m := &MetricSet{
+		BaseMetricSet: base,
+		logger:        base.Logger().Named(metricsetName),
+	}
+
+	if err := base.Module().UnpackConfig(&m.config); err != nil {
+		return nil, fmt.Errorf("unpack query config failed: %w", err)
+	}
+
+	credentials := []byte(m.config.CredentialsJSON)
+	if m.config.CredentialsFilePath != "" {
+		var err error
+		credentials, err = os.ReadFile(m.config.CredentialsFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading credentials file: %w", err)
+		}
+	}
+
+	if m.config.Endpoint != "" {
+		m.logger.Warnf("You are using a custom endpoint '%s' for the GCP API calls.", m.config.Endpoint)
+	}
+
+	ctx := context.Background()
+	for _, q := range m.config.Queries {
+		var err error
+		switch {
+		case q.Language == languageMQL && m.mql == nil:
+			opt := []option.ClientOption{option.WithCredentialsJSON(credentials)}
+			if m.config.Endpoint != "" {
+				opt = append(opt, option.WithEndpoint(m.config.Endpoint))
+			}
+			m.mql, err = newMQLQuerier(ctx, m.config.ProjectID, opt)
+		case q.Language == languagePromQL && m.promql == nil:
+			m.promql, err = newPromQLQuerier(ctx, m.config.ProjectID, m.config.Endpoint, credentials)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	m.logger.Warn("extra charges on Google Cloud API requests will be generated by this metricset")
+	return m, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
+	var errs []error
+	for _, q := range m.config.Queries {
+		var (
+			events []mb.Event
+			err    error
+		)
+		switch q.Language {
+		case languageMQL:
+			events, err = m.mql.query(ctx, q)
+		case languagePromQL:
+			events, err = m.promql.query(ctx, q)
+		}
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s query '%s' failed: %w", q.Language, q.ID, err))
+			continue
+		}
+
+		m.logger.Debugf("Total %d of events are created for %s query '%s'", len(events), q.Language, q.ID)
+		for _, event := range events {
+			_, _ = event.RootFields.Put("cloud.provider", "gcp")
+			_, _ = event.RootFields.Put("cloud.project.id", m.config.ProjectID)
+			if !reporter.Event(event) {
+				return nil
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Close closes the Cloud Monitoring clients.
+func (m *MetricSet) Close() error {
+	if m.mql != nil {
+		return m.mql.client.Close()
+	}
+	return nil
+}
+
+// newEvent returns an event holding the result of a query for a single time
+// series.
+func newEvent(q queryConfig, labels mapstr.M, values mapstr.M) mb.Event {
+	fields := mapstr.M{
+		"id":       q.ID,
+		"language": q.Language,
+	}
+	if len(values) == 1 {
+		for _, v := range values {
+			fields["value"] = v
+		}
+	} else {
+		fields["values"] = values
+	}
+	if len(labels) > 0 {
+		fields["labels"] = labels
+	}
+	return mb.Event{
+		MetricSetFields: fields,
+		RootFields:      mapstr.M{},
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+package query
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/monitoring/apiv3/v2/monitoringpb"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/api/label"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+func TestValidate(t *testing.T) {
+	for _, tt := range []struct {
+		name        string
+		config      config
+		expectedErr string
+	}{
+		{
+			name:        "without credentials",
+			config:      config{},
+			expectedErr: "no credentials_file_path or credentials_json specified",
+		},
+		{
+			name:        "with both credentials",
+			config:      config{CredentialsFilePath: "creds.json", CredentialsJSON: "{}"},
+			expectedErr: "both credentials_file_path and credentials_json specified, you must use only one of them",
+		},
+		{
+			name: "with duplicate query ids",
+			config: config{
+				CredentialsJSON: "{}",
+				Queries: []queryConfig{
+					{ID: "cpu", Language: languageMQL, Query: "fetch gce_instance"},
+					{ID: "cpu", Language: languagePromQL, Query: "up"},
+				},
+			},
+			expectedErr: "duplicate query id 'cpu'",
+		},
+		{
+			name: "with valid queries",
+			config: config{
+				CredentialsJSON: "{}",
+				Queries: []queryConfig{
+					{ID: "cpu", Language: languageMQL, Query: "fetch gce_instance"},
+					{ID: "up", Language: languagePromQL, Query: "up"},
+				},
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.expectedErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.expectedErr)
+		})
+	}
+}
+
+func TestValidateLanguage(t *testing.T) {
+	q := queryConfig{ID: "up", Language: "PromQL", Query: "up"}
+	require.NoError(t, q.Validate())
+	assert.Equal(t, languagePromQL, q.Language)
+
+	q = queryConfig{ID: "up", Language: "sql", Query: "up"}
+	assert.EqualError(t, q.Validate(), "unsupported language 'sql' for query 'up', please specify one of mql or promql")
+}
+
+func TestMQLEvents(t *testing.T) {
+	qc := queryConfig{ID: "cpu", Language: languageMQL}
+	end := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	descriptor := &monitoringpb.TimeSeriesDescriptor{
+		LabelDescriptors: []*label.LabelDescriptor{
+			{Key: "resource.zone"},
+			{Key: "metric.instance_name"},
+		},
+		PointDescriptors: []*monitoringpb.TimeSeriesDescriptor_ValueDescriptor{
+			{Key: "value.utilization"},
+		},
+	}
+	point := func(t time.Time, v float64) *monitoringpb.TimeSeriesData_PointData {
+		return &monitoringpb.TimeSeriesData_PointData{
+			Values: []*monitoringpb.TypedValue{{Value: &monitoringpb.TypedValue_DoubleValue{DoubleValue: v}}},
+			TimeInterval: &monitoringpb.TimeInterval{
+				StartTime: timestamppb.New(t.Add(-time.Minute)),
+				EndTime:   timestamppb.New(t),
+			},
+		}
+	}
+	data := []*monitoringpb.TimeSeriesData{
+		{
+			LabelValues: []*monitoringpb.LabelValue{
+				{Value: &monitoringpb.LabelValue_StringValue{StringValue: "us-central1-a"}},
+				{Value: &monitoringpb.LabelValue_StringValue{StringValue: "web-1"}},
+			},
+			PointData: []*monitoringpb.TimeSeriesData_PointData{point(end.Add(-time.Minute), 0.2), point(end, 0.4)},
+		},
+		{
+			// Series without points are skipped.
+			LabelValues: []*monitoringpb.LabelValue{
+				{Value: &monitoringpb.LabelValue_StringValue{StringValue: "us-central1-b"}},
+				{Value: &monitoringpb.LabelValue_StringValue{StringValue: "web-2"}},
+			},
+		},
+	}
+
+	events := mqlEvents(qc, descriptor, data)
+	require.Len(t, events, 1)
+	assert.Equal(t, end, events[0].Timestamp)
+	assert.Equal(t, mapstr.M{
+		"id":       "cpu",
+		"language": "mql",
+		"value":    mapstr.M{"double": 0.4},
+		"labels": mapstr.M{
+			"resource.zone":        "us-central1-a",
+			"metric.instance_name": "web-1",
+		},
+	}, events[0].MetricSetFields)
+
+	// Several value columns are reported by key.
+	descriptor.PointDescriptors = append(descriptor.PointDescriptors, &monitoringpb.TimeSeriesDescriptor_ValueDescriptor{Key: "value.count"})
+	data[0].PointData[1].Values = append(data[0].PointData[1].Values, &monitoringpb.TypedValue{Value: &monitoringpb.TypedValue_Int64Value{Int64Value: 3}})
+	events = mqlEvents(qc, descriptor, data)
+	require.Len(t, events, 1)
+	assert.Equal(t, mapstr.M{
+		"value.utilization": mapstr.M{"double": 0.4},
+		"value.count":       mapstr.M{"long": int64(3)},
+	}, events[0].MetricSetFields["values"])
+
+	// Each value type is reported in its own field.
+	data[0].PointData[1].Values = []*monitoringpb.TypedValue{
+		{Value: &monitoringpb.TypedValue_BoolValue{BoolValue: true}},
+		{Value: &monitoringpb.TypedValue_StringValue{StringValue: "RUNNING"}},
+	}
+	events = mqlEvents(qc, descriptor, data)
+	require.Len(t, events, 1)
+	assert.Equal(t, mapstr.M{
+		"value.utilization": mapstr.M{"bool": true},
+		"value.count":       mapstr.M{"string": "RUNNING"},
+	}, events[0].MetricSetFields["values"])
+
+	assert.Empty(t, mqlEvents(qc, nil, data))
+}
+
+func TestPromQLQuery(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	qc := queryConfig{ID: "cpu", Language: languagePromQL, Query: `sum by (zone)(rate(compute_googleapis_com:instance_cpu_usage_time[5m]))`}
+
+	var response string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, qc.Query, r.FormValue("query"))
+		assert.Equal(t, "1792152000.000", r.FormValue("time"))
+		_, _ = w.Write([]byte(response))
+	}))
+	defer srv.Close()
+
+	q := &promQLQuerier{url: srv.URL, client: srv.Client(), now: func() time.Time { return now }}
+
+	response = `{"status":"success","data":{"resultType":"vector","result":[
+		{"metric":{"__name__":"up","zone":"us-central1-a"},"value":[1792152000.5,"0.25"]},
+		{"metric":{"zone":"us-central1-b"},"value":[1792152000.5,"NaN"]}
+	]}}`
+	events, err := q.query(context.Background(), qc)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, now.Add(500*time.Millisecond), events[0].Timestamp)
+	assert.Equal(t, mapstr.M{
+		"id":       "cpu",
+		"language": "promql",
+		"metric":   "up",
+		"value":    mapstr.M{"double": 0.25},
+		"labels":   mapstr.M{"zone": "us-central1-a"},
+	}, events[0].MetricSetFields)
+
+	response = `{"status":"success","data":{"resultType":"scalar","result":[1792152000,"2"]}}`
+	events, err = q.query(context.Background(), qc)
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, mapstr.M{"double": 2.0}, events[0].MetricSetFields["value"])
+
+	response = `{"status":"success","data":{"resultType":"matrix","result":[]}}`
+	_, err = q.query(context.Background(), qc)
+	assert.ErrorContains(t, err, "unsupported result type 'matrix'")
+
+	response = `{"status":"error","errorType":"bad_data","error":"parse error"}`
+	_, err = q.query(context.Background(), qc)
+	assert.ErrorContains(t, err, "bad_data: parse error")
+}
+
+func TestPromQLURL(t *testing.T) {
+	const path = "/v1/projects/my-project/location/global/prometheus/api/v1/query"
+	assert.Equal(t, "https://monitoring.googleapis.com"+path, promQLURL("", "my-project"))
+	assert.Equal(t, "https://monitoring.example.com:443"+path, promQLURL("monitoring.example.com:443", "my-project"))
+	assert.Equal(t, "http://localhost:8080"+path, promQLURL("http://localhost:8080/", "my-project"))
+}
@@ -0,0 +1,58 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package asm
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// extractAndTransform gets the disk groups from Oracle and generates one event per disk group. It's called by the
+// Fetch method, which is the one that "loads" the data into Elasticsearch
+func (m *MetricSet) extractAndTransform(ctx context.Context) ([]mb.Event, error) {
+	diskGroups, err := m.extractor.diskGroupsData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting disk groups: %w", err)
+	}
+
+	events := make([]mb.Event, 0, len(diskGroups))
+	for i := range diskGroups {
+		events = append(events, mb.Event{MetricSetFields: m.transform(&diskGroups[i])})
+	}
+
+	return events, nil
+}
+
+// transform generates the event of a single disk group. Used space is not reported by Oracle and is calculated from
+// the total and free space.
+func (m *MetricSet) transform(d *diskGroup) mapstr.M {
+	out := mapstr.M{}
+
+	oracle.SetSqlValue(m.Logger(), out, "diskgroup.number", &oracle.Int64Value{NullInt64: d.Number})
+	oracle.SetSqlValue(m.Logger(), out, "diskgroup.name", &oracle.StringValue{NullString: d.Name})
+	oracle.SetSqlValue(m.Logger(), out, "diskgroup.state", &oracle.StringValue{NullString: d.State})
+	oracle.SetSqlValue(m.Logger(), out, "diskgroup.redundancy", &oracle.StringValue{NullString: d.Redundancy})
+	oracle.SetSqlValue(m.Logger(), out, "diskgroup.space.total.bytes", &oracle.Int64Value{NullInt64: d.TotalBytes})
+	oracle.SetSqlValue(m.Logger(), out, "diskgroup.space.free.bytes", &oracle.Int64Value{NullInt64: d.FreeBytes})
+	oracle.SetSqlValue(m.Logger(), out, "diskgroup.space.usable_file.bytes", &oracle.Int64Value{NullInt64: d.UsableFileBytes})
+	oracle.SetSqlValue(m.Logger(), out, "diskgroup.space.required_mirror_free.bytes", &oracle.Int64Value{NullInt64: d.RequiredMirrorFreeBytes})
+	oracle.SetSqlValue(m.Logger(), out, "diskgroup.disks.count", &oracle.Int64Value{NullInt64: d.DiskCount})
+	oracle.SetSqlValue(m.Logger(), out, "diskgroup.disks.offline", &oracle.Int64Value{NullInt64: d.OfflineDisks})
+
+	if d.TotalBytes.Valid && d.FreeBytes.Valid {
+		_, _ = out.Put("diskgroup.space.used.bytes", d.TotalBytes.Int64-d.FreeBytes.Int64)
+		if d.TotalBytes.Int64 > 0 {
+			_, _ = out.Put("diskgroup.space.used.pct", float64(d.TotalBytes.Int64-d.FreeBytes.Int64)/float64(d.TotalBytes.Int64))
+		}
+	}
+
+	return out
+}
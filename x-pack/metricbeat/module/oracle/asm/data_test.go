@@ -0,0 +1,52 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package asm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type happyMockExtractor struct{}
+
+func (happyMockExtractor) diskGroupsData(_ context.Context) ([]diskGroup, error) {
+	return []diskGroup{
+		{Number: sql.NullInt64{Int64: 1, Valid: true}, Name: sql.NullString{String: "DATA", Valid: true}, State: sql.NullString{String: "MOUNTED", Valid: true}, Redundancy: sql.NullString{String: "NORMAL", Valid: true}, TotalBytes: sql.NullInt64{Int64: 4000, Valid: true}, FreeBytes: sql.NullInt64{Int64: 1000, Valid: true}, UsableFileBytes: sql.NullInt64{Int64: 400, Valid: true}, RequiredMirrorFreeBytes: sql.NullInt64{Int64: 200, Valid: true}, DiskCount: sql.NullInt64{Int64: 4, Valid: true}, OfflineDisks: sql.NullInt64{Int64: 0, Valid: true}},
+		{Number: sql.NullInt64{Int64: 2, Valid: true}, Name: sql.NullString{String: "FRA", Valid: true}, State: sql.NullString{String: "DISMOUNTED", Valid: true}, Redundancy: sql.NullString{String: "EXTERN", Valid: true}, TotalBytes: sql.NullInt64{Int64: 0, Valid: true}, FreeBytes: sql.NullInt64{Int64: 0, Valid: true}, UsableFileBytes: sql.NullInt64{Int64: 0, Valid: true}, RequiredMirrorFreeBytes: sql.NullInt64{Int64: 0, Valid: true}, DiskCount: sql.NullInt64{Int64: 2, Valid: true}, OfflineDisks: sql.NullInt64{Int64: 1, Valid: true}},
+	}, nil
+}
+
+type errorMockExtractor struct{}
+
+func (errorMockExtractor) diskGroupsData(_ context.Context) ([]diskGroup, error) {
+	return nil, errors.New("disk groups error")
+}
+
+func TestEventMapping(t *testing.T) {
+	m := MetricSet{extractor: happyMockExtractor{}}
+
+	events, err := m.extractAndTransform(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, `{"diskgroup":{"disks":{"count":4,"offline":0},"name":"DATA","number":1,"redundancy":"NORMAL","space":{"free":{"bytes":1000},"required_mirror_free":{"bytes":200},"total":{"bytes":4000},"usable_file":{"bytes":400},"used":{"bytes":3000,"pct":0.75}},"state":"MOUNTED"}}`, events[0].MetricSetFields.String())
+
+	// A dismounted disk group reports no space, the used percentage can't be calculated.
+	assert.Equal(t, `{"diskgroup":{"disks":{"count":2,"offline":1},"name":"FRA","number":2,"redundancy":"EXTERN","space":{"free":{"bytes":0},"required_mirror_free":{"bytes":0},"total":{"bytes":0},"usable_file":{"bytes":0},"used":{"bytes":0}},"state":"DISMOUNTED"}}`, events[1].MetricSetFields.String())
+
+	t.Run("Error Path", func(t *testing.T) {
+		m := MetricSet{extractor: errorMockExtractor{}}
+
+		_, err := m.extractAndTransform(context.Background())
+		assert.Error(t, err)
+	})
+}
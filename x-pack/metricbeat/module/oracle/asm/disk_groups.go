@@ -0,0 +1,48 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package asm
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type diskGroup struct {
+	Number                  sql.NullInt64
+	Name                    sql.NullString
+	State                   sql.NullString
+	Redundancy              sql.NullString
+	TotalBytes              sql.NullInt64
+	FreeBytes               sql.NullInt64
+	UsableFileBytes         sql.NullInt64
+	RequiredMirrorFreeBytes sql.NullInt64
+	DiskCount               sql.NullInt64
+	OfflineDisks            sql.NullInt64
+}
+
+// diskGroupsData reads the disk groups the instance sees. On a database instance V$ASM_DISKGROUP only lists the disk
+// groups mounted by it, on an ASM instance it lists all of them.
+func (e *asmExtractor) diskGroupsData(ctx context.Context) ([]diskGroup, error) {
+	rows, err := e.db.QueryContext(ctx, `SELECT g.GROUP_NUMBER, g.NAME, g.STATE, g.TYPE, g.TOTAL_MB * 1048576, g.FREE_MB * 1048576, g.USABLE_FILE_MB * 1048576, g.REQUIRED_MIRROR_FREE_MB * 1048576, (SELECT COUNT(*) FROM V$ASM_DISK d WHERE d.GROUP_NUMBER = g.GROUP_NUMBER) AS DISK_COUNT, g.OFFLINE_DISKS FROM V$ASM_DISKGROUP g`)
+	if err != nil {
+		return nil, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]diskGroup, 0)
+
+	for rows.Next() {
+		dest := diskGroup{}
+		if err = rows.Scan(&dest.Number, &dest.Name, &dest.State, &dest.Redundancy, &dest.TotalBytes, &dest.FreeBytes, &dest.UsableFileBytes, &dest.RequiredMirrorFreeBytes, &dest.DiskCount, &dest.OfflineDisks); err != nil {
+			return nil, err
+		}
+		results = append(results, dest)
+	}
+
+	return results, rows.Err()
+}
@@ -0,0 +1,23 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package asm
+
+import (
+	"context"
+	"database/sql"
+)
+
+// asmExtractMethods contains the methods needed to extract the necessary information about the ASM disk groups
+type asmExtractMethods interface {
+	diskGroupsData(context.Context) ([]diskGroup, error)
+}
+
+// asmExtractor is the implementor of asmExtractMethods. It's implementation are on different Go files
+// which refers to the origin of the data for organization purposes.
+type asmExtractor struct {
+	db *sql.DB
+}
@@ -22,6 +22,11 @@ type ConnectionDetails struct {
 	Username string        `config:"username"`
 	Password string        `config:"password"`
 	Patterns []interface{} `config:"patterns"`
+
+	// WalletLocation is the directory holding the Oracle wallet together with
+	// the sqlnet.ora and tnsnames.ora files that reference it. It is used as
+	// the client configuration directory (TNS_ADMIN) of the connection.
+	WalletLocation string `config:"wallet_location"`
 }
 
 // HostParser parses host and extracts connection information and returns it to HostData
@@ -37,6 +42,10 @@ func HostParser(mod mb.Module, rawURL string) (mb.HostData, error) {
 		return mb.HostData{}, fmt.Errorf("error parsing config file: %w", err)
 	}
 
+	if params.ConfigDir == "" {
+		params.ConfigDir = config.WalletLocation
+	}
+
 	if params.Username == "" {
 		params.Username = config.Username
 	}
@@ -46,6 +55,12 @@ func HostParser(mod mb.Module, rawURL string) (mb.HostData, error) {
 		params.Password = dsn.NewPassword(config.Password)
 	}
 
+	// Without any credentials the client authenticates with the ones stored
+	// in the wallet (Secure External Password Store).
+	if params.ConfigDir != "" && params.Username == "" && params.Password.Secret() == "" {
+		params.ExternalAuth = godror.Bool(true)
+	}
+
 	return mb.HostData{
 		URI:          params.StringWithPassword(),
 		SanitizedURI: params.ConnectString,
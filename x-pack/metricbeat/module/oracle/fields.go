@@ -19,5 +19,5 @@ func init() {
 // AssetOracle returns asset data.
 // This is the base64 encoded zlib format compressed contents of module/oracle.
 func AssetOracle() string {
-	return "eJzEWd1u2zoSvu9TDAos0gKpz70vDpCkyZ4AaZKN0yz2ShhLI4sbidQhKTvu0x8MRcmyRfkvbuubppI48803P5whv8ArLcegNMY5fQCwwuY0ho8P7sHHDwAJmViL0golx1A/hgQtTtEQFCqp3DqTKW2jWMlUzMaQYm74qaac0NAYZvgBIBWUJ2b8AQDgC0gsqKOYf3ZZ8rdaVaV/ElLe6uRfV2ZXbkk6VbpAGVP7LqSgp+RxtZDho6UECrJaxAaUBAQj5KxLgZDGbqhZN7v5bULtwi0wzoSktXcN3FdaLpRONt6tM1OSRivkDMzSWCoaeU56UOG0SlPSUalUfqzSeywIVAo2Iy8OWBwI6R4FeFmprwzpHrZDDK5DISim0SFUpClXmJiglkRV03wTwJqSp3o1/AGPQhpghtUILvxzEAZQLuHx9p5ZQAlq+n+KLdgMLb+UyjoiUqGNdZ/5oKSEgygm99YvylAmOcECDcSaOOrOAWUCi0zEGWj6uxKaDLBidnNnZapVAYkwr6MaJupasKyKKWnnIFGQAXQQWAFLI8OBnSrtxZjakwwbCzIlDjguV/Fr5AWEec2VnG1j9WJOGmdt5DhWYUp2QSThbEY2E9acOeP5f+YMpsQmn/27eRW0rqH/zHS5TFUlE47Iggqll12pYY7YvkNIGgVZKoXcTtLu4OvQhHnOEtn4P8o2Ek3Dy6OQfw3zkucN0wVZ5KoFpaCYTPM4F1ONetmE04I0hWlj1UO0nSK0YoyzcEHYLNg9siYWrTCWazROVWWd2Z2aZADnKHKc5uSADdancJWGXuEcZcKOytj2Ptvq4B7u54xqsyET1qeCd4spKRapoKRrx2gQVpktjYgxjzT1Cx5sS84eqEcvCsKiGo0zGrY+5LCensvOnsE5eQamdWNg7ZBjuphiJY0wlmQI2k4WegivWnFsrVnh2woimUZTLiMhIw7H8LXpM2qZMFPWkgwnUKWN0uGCszOFrurFIKRrf/jhgZmB85BBh6RDU/O8IaBKkpwAy3ajd5Uo3Cx1m6m3dwL5hm/vBWGVxc3W6lAYzyyjAdDgORRIvfx9qfqwDmFbHuyVppXWp8rRmqPVjuRlO7MHMmIdzJCjTgKlG0Grnq/ZephIbQPOaas6ahOKkgNc98giOi7rJnjdqqo4rjQlTd/eEGjImH4RgD1drOlEpH58ojVSy9qeFXJNybgB6ylf7akGvtQrIFaVtPDJefvzx98UER4K5rmBTxnqxGWwUan9zIMF/+G/4eEC4oziVzd1tsMF5rwrLxtnmQzZdbyHnoNVMCct0mXNDr8vSRfCUeOmV35UyYR0vuTWsZ095uSmlThDOaNkuMvwNI8cvxHz+65O474lxrmqsSngS2aHW6SCUJqVeZP/3LnIpoIjNhGJM8PZY3moAE2Ozi0mtaacoJ17ahq44XB005oNF4reIYZZmvroYecRRnviMCWLQ0cbf67hnzTCN485RnueWXgjI5dZR42Cf/YInXjirlhmeLbCukmIMLZiTpFHceyY1YfQNCEXTn6DyITB+GIZKRPxaH4yEFe+CD9M4E5hMjBlrvX8UUk6MrS5Jx+PoZ0Dnlg8PJKGCcVKDqDhpiSyGqVhzyh5cjzfDWl4XinYicilWWR56otMjLKmyL5t7mnHQ6rr+7ObKyeswWHqYNzhuIUWln6i5/7r5O9JlJAJvf1Uom5ZwyFEZcrYKC6rqLIiFz+wjqtenT4e11/KWLh6/A7fVwrg078+h+FIsgulXznK01TE0VzlVUEnd999rYbpYTXw4tTsl4Ba5fkU49ftQXV4YXbJ99QI34nF+cxwmT41Oc5ZLHknhmYOj+q25vRp9vUSLt154VWtYCeitYId1Vk3Xf7UCsC122ff5XKfWqDJlEoaiqzwoX3KOvDkpcOz8BEdrAFtYeLKunliuMclTnDd4bcy77mheG4hrIsJhypajFLRY3PPs5v2jIhFvOcIRwyfE+zTz3dsrqT4uyIQCUkrUkF6UGeAZNhBdE/xjchJdq6j3DF3gM9O8yp+vHOwnogftDZM1yfP9Y1PcFbYZ24u8G3kSsLAeFmrG8O2bw6bUb/hmyiqwoF2tPAcNiy+PQf/pSAd2d65DuheGFNN9IvZ7Je85veceXq7ZqxfS/AO7mJ35L7G2FaY9xcVQlamvlGZY165w4LKUMIzr7FK+886Y52799ky1lu01fAov2cShk0/u3Fx5TSM4eLl4vbu4vLuGpSG2/uXi7vbr/Cp+WNjundW+CnZ36cKyZaeO7boDYsyp3PAJiSCALCzG9SyF2gg0aosKfl8NkiKkrmQFJ2Gmzs0Fl6lWkgv1zPSK1gjeJDO25P/TR5ubs753+frb+fwcHNzd3t/fQ4P9/wv8/d0ffXwcv002r639C/c9t5XOvXcV3XXeB2/v2xNyF3JeNQ+VB+1sF5vQptx520NGU4Mzqpfi9flsftzH3zOut9BKNekLsB/AgAA//+tbOzU"
+	return "eJzsW19v27iyf/enGCxwkQbIencv7lMeLuAmbmucxM7aaXv2SaClscUbiVRJKon3018MJcqSRcl27HRfzsnBNtGfmd/85XA4+hWecHMNUrEwwQGA4SbBa/hlZi/8MgCIUIeKZ4ZLcQ3FZYiYYUumEVIZ5fY9HUtlglCKFV9fw4olmq4qTJBpvIY1GwCsOCaRvh4AAPwKgqVYY0wXzSajZ5XMs/KKj3nFE6BJs06X6bS65iPcRXyUG5kyw0NYGKnYGuGeCbbGFIWBD6PF/SVEXD8VIHWNWCXqEg2rXd8FWAdJlHZBdcNtQR4t7mtggIuVVIRdip23fBjqOESeLlG1bjsgiRRrz80GlqklAUxrvhYYgZFgYqzB62bOUhw07tRYP+HmRapoL3eWIsjVoSy1YeZkngsi0mYKChNm+DM6FXChDRMhDmEmLMib2XQ6vnkc317Bx/nsX+PpFXyd/ms6+z69gtvJ4n72dWpvVr/8+XUyXtxMpp9BKpiPF4/zCb0+7JRPYZSLiIlwc6qQ84qSfa8tbiXV+N+P4/n0Cqaz+f3o7gq+TD5/uYJPd+N/E2q6O+1BTATr0bQ/ElpQb4lEG6Dnta5wqAMKZS6M94k9UdEVGXJlPVMDF/sRbnHI1SrhAn8OEjAxM8AUdrJ1sHTGQjzdXiVbSw1yTem2O4sdajojDUuGy41Bn0fRT8HhGvqeOU61j8QTQpaxkJtN2wuvyOyW3bAX+0oh/mToX0WuMToDdiLzs7GfEXkWmj24M1Qh7skKOmQJRsEqkcycRQKmgcFKsZAczT1gGu62Tzi2TDBY8eRne9b/djwEsLABb7NNyAQsETRbYbKxliA/sRUmEGYNUoQIKVdKKi7WwDWw0Kbn4tGakWEEAtfF6vvMktyXoYqfFJnQRbprKhxCJi4MKAzlMyobCdvVFNjKoAJWBMeK8SRX2K99hT9yrjAKCgGCfyDAa8pOc21I2+yZ8YTcgooUhdpIhX5Z6eKLVNo4cT36AiMTVMygL96cIjJU1sNEY93wrxcN+A/bF4vaCiNI0SgekmsAA83Fur4ncQWXrzhfH1qapyyM2wtgX+3UAD3LSCHkrnqjDaaOXrvadQyX+WqFKsikTN7KtF4FF+SAyLl13qOXLftco/JU4ocLXGyfvGQcDy4DhYlk0a5fF1wimS8T3LnVYDIv3obf4IELDaRhOYRRed1mBrGBh8mU8iQTIJf/hyFFODN0U0j6HWHFyZ/psdIpMSInshkJ3UsxE1GC8MI0hAqZwegKmIjgJeZh7MJaAzEmM9feXCmZ2ggZFjBZGTSiKr8MT5HSOkEgBkQNtSlTX0GmLGUJNkuxXW85pSYyfApKAnpwYJ5oaHX0jHajW3qO1Sos0bwgCrhYo4m50RdWePpLX8ASSeSLz+6WVzqn/gtd1+VK5iIij0wxlWpTp+rXEcl3jJKGXi1lXPQrab/z1dTEkgQyLkj437LKE7XTywMXX7r1kiQuRlM0zK5zGccQS1kQEr5UTG1KEeEFFfrVRqwv3tG1QhbGODi0wG8oizbHXBvK0Wwpc2PFruUkXVuCCFhnfuou+R3Kguow5qajhOsxcAv3Y4yF2BBzUyQYZxadYchXHKOSo5Vj2AkrizeahywJFLYTXm9wtkA9lKTAT8pxXGO39D6Dtfh83MplY/ICdGVGz7tdhqljCqXQXJt9VXOHFloIbypyQDlji68XRLQMlpRG9Fkw3Lo6o6AJa2kMioGPcZgrLZUeHGqRpqzFyye09djz+sRwcDmvFARkhtTcW26qhd4mcH+xtAWSstcTgdyz11NB2D3TiTCKbX4JwOE5Fkjx+mmhOmtC6IuDg8I0V+pcMVroaLsilbSt2RzgXjBdhjoLlLoHbWs+t/RQQlHGYxyHLWNK42mmeyASNZPVA7woVWUY5gojV7c7BWrU+u1dMYVnUuovc2wo1aqk3Etb5Aqjawe2VPl2TdXwa/EG2C08fLDWvvylF/v7eUQJhSWJhg8xU5HNZ1quzCVtLOiX8hnaXEAYY/hkd53V5oIltCpvnLF0zMh0tIZe0eb6GRVfbbadhgxVyq0d7e6VLuUiQpVsqKQuacbsGe1uJYyZWGPUXWWUah5a/QZUkA6OVFFHh5hIVTJ5bEnaoRJpp5Gy+PPOenZxXhbxyIph5TGSOg8KrTp7RKpEOUM5N3cFXLc72t2a8SeKwS42xcLBvqBvAKA6OK8qe5dmdLk/LTfNNqBGWZbwkPAKuElybVBp+DAf3VxWnQ1fQ+OI08awoDrwqdOXuRqClJBOqEZQULXfve4tpUyQ+dJbA8j3GE2MqqFP2tynZUeQCyfotiOUygiHncAqq5wpdCp6zuwVjup4pVxmhoM+PG8z1KR8+wRL8egkTUwiFIZ2SmrX711CcQZ65vii4cNkungMJreXw/c8oK70UkZ51WmuwClcc23snk/0QGk3yfY1ynobdY59N8dYanNOjkTPFamgciFc/2o/lGdUHSXIUWiqLVRJr5shrSW5PpWfPwtXR9eLx9G8eeI+exhP6dja/ns/+TwfPY67Qbr4Dt4DrSNeoR3dPE6+ja9g8XXxMJ7ejm8JKIXQaHozhvn4ZvZtPP+rG62SCZ4KsSsJMwF0UPVcu/rhYT65H83/ChzES8LLQGMoRUR9ru2ji/HNbHrbeLhbjoJRcJZZjj9zjroo/7czHS0/caMNjXmM4o++aQwTU4V4UvKaYyRLOm7zstw0QXayT+SaC30uixfU7CI2urubfR/fNodSqOal3oCGF25iC7F2czFeLCazKWSKP/ME1z2wbXflfOUC9R81qmdO6zKhL+m7Iny/Hu2anWcBdVi7y1K/MzYgPfIU4SXGJmPb4/bWBXUQeUbshxrDk/zJQvBsf2sY7FleEaS6G09ZV+uT0GxLJ0cOQikEhmY7SOYgDgctCBtdHAcO9tVKXUVzz6H1whHfPXocDvrrqR0FBb55ok4N9QCi/y/KzcwN0Rx62bKicRe4JOk3U89Wag8E1xgcWfoOkfaDKRsYgdQBHZedDcRN2RiZLeBOssjPvNmHDzJUQTt63o6h6s3PiTw8oIKFDRo/GkqNgVFMaLKMFGfH81Wjgsctg72I7NY3MLQ3C3TIhLaQzKs4G6Si5/JIHGBBHOChiXGP4V4UN/iOlvtu6R+oKC4ifH1XRU2IwzGKono+CLM8yA1P+N92/xK0eydvx/WFNgw3D1/h65YBfPivSz8cgeZFqify8tWKh8GzTPIUz26+acGG1ENs4Jtls9eKNgCVTJIlC5/6ner4xGyDb+6I78VibUZDj2dXjjUWUd6LwZ2NBUWr8fxhdvsRPhIDuCkY7EXUSNhBkZ7sTNr7ZQDK3WX0fdwckgsU6kwKjbYYPHsemJfUizKtMwc4MDZ3757i+yugBmPve8dPSrXGffp3Fg0IjxWEJhkvI9oJ2zFGL7ddUbubDkRC/3PtsZrMueA/cgReNcw6eXqUvE/RLcafeIKi1geqRiw7uWr+dzdXn8JbPBf878ZIdzkNQuy9nLu1X8eVstefPEN5z155mqdWXVYt1aRjL9CfC9IquzSuBXoQxn9gIrWd8tz/aP5G74rRHBWiFZz22Wxon2ahyVnSfinlIqeDKa6LUWBqT9mer5FQzLta2rVtnZ3FGg669HSe5p5f9AuKzZLDNYy+jSZ3o493Y2qtTKbfRneTW+qSF7/snLhZKcqedjnjyAUNJFyR4QBfWZoleEVD5PQk3812xQ+rrSLFYSW1AiIlswyjy4tOpUhBX6ecqfF5x7SBJyFfREm31EgrYVUducVfi9mnT1ew+GvxOL6/gtmnT3eT6fgKZlP6l/RXNkS3dh345NhdBvvTXFc+P+w7ln3rS29A7gvG3kDswm2LLMu3FKGKON9E9y7e3u8+3gUvcSyQHoKv/3ugd1Qo5SQfwApY9UKwVvLFxIN9ztfFr3gd7CcLzA6awgsXkXy5sjOD5SF3XP+89btUT9SNgTlmUnNDU6UfRt/nl75i8IhTZk+l0pcEuiRqkXH0tWCZjmXHGK/HTh2txtH3+ZYWHVfwqGiGFoobepmX9yK20W+s9m1J74art8PoNHVCfyWUAh2sNirrTcR9+JOyGDNtWEems/+kh1Z6YEkiQ9tN7koRuyD7Kt7zQbQFJHlbDSt936OQhTEsN4CvBkVE58U0OlOtx/oQERSmjNNZ888QxEJPcGUgF4Ynu0JZgVBbKdKytO8zhZOhlaaPiLDPZY4uapmtj7aj/MgAe3913nIaDEcRNlNXTYayQktYLZcVmc1ljUNchBopEduc6CDeJNw52rzuMktmtxkbH26H18gs0D+SwT5faDBeuPG46oi/NjO4PTWN+TpGbQATlpGed44d37I0KyaeBj6NeXygAfmBCoTa56fbCT97jClVhDT0uNw00F5R+a4MZQtm4I+hF5P+kQQ8emvBQOOG2+5JC56fZZYwEcRMx4HvG9GDeX9hOi63liVffMUwJ2CWxYFoDL6aIyDwtZAKA7aUz3gNf/z+3//Th/GTDcE/fv/9dwhjRh8R08n8YcAqafSbfGZbaZHn6iZHe9hdMKjmsHmx56SatGsyrvQu3xG8P/BaqMY1/6RNcjVHUUE7Mvn27Ho9GnoDHp92bE5Keajk3gN6SqmVJYf5ubJmPc7LYdaKS8u/9sB1UOk4hOgN87d5HB16nFWPDtgL4+YEn7Ml/w6WjP5LdCk7epEtN/Y+hAnT+minRBVwOTzROe1x1uS3WQGEFLBHYXUMbDvPfCqO+mj0W6CEUhQjCOHmVCg3W1Jvg1JMvp4MoxygPRTCzkfmazx5+1x+A0mUWvHuc2g/HvpK2vuB5LFwiJBtdZyCRskXHWRKhqg1RiciImKg0OSqNq33BlBUUrsxns4c5Bk7a8CynVXTzkOkERYa/ozDwf8PAH1nVQo="
 }
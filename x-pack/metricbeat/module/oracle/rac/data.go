@@ -0,0 +1,82 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package rac
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// extractAndTransform gets the instances from Oracle and generates one event per instance. It's called by the
+// Fetch method, which is the one that "loads" the data into Elasticsearch
+func (m *MetricSet) extractAndTransform(ctx context.Context) ([]mb.Event, error) {
+	instances, err := m.extractor.instancesData(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error getting instances: %w", err)
+	}
+
+	events := make([]mb.Event, 0, len(instances))
+	for i := range instances {
+		events = append(events, mb.Event{MetricSetFields: m.transform(&instances[i], len(instances))})
+	}
+
+	return events, nil
+}
+
+// transform generates the event of a single instance. Every event also contains the number of instances of the
+// cluster so a missing instance can be noticed from any of them.
+func (m *MetricSet) transform(in *instance, instanceCount int) mapstr.M {
+	out := mapstr.M{
+		"cluster": mapstr.M{
+			"instances": instanceCount,
+		},
+	}
+
+	oracle.SetSqlValue(m.Logger(), out, "instance.id", &oracle.Int64Value{NullInt64: in.ID})
+	oracle.SetSqlValue(m.Logger(), out, "instance.number", &oracle.Int64Value{NullInt64: in.Number})
+	oracle.SetSqlValue(m.Logger(), out, "instance.name", &oracle.StringValue{NullString: in.Name})
+	oracle.SetSqlValue(m.Logger(), out, "instance.host", &oracle.StringValue{NullString: in.HostName})
+	oracle.SetSqlValue(m.Logger(), out, "instance.version", &oracle.StringValue{NullString: in.Version})
+	oracle.SetSqlValue(m.Logger(), out, "instance.status", &oracle.StringValue{NullString: in.Status})
+	oracle.SetSqlValue(m.Logger(), out, "instance.database_status", &oracle.StringValue{NullString: in.DatabaseStatus})
+	oracle.SetSqlValue(m.Logger(), out, "instance.role", &oracle.StringValue{NullString: in.Role})
+	oracle.SetSqlValue(m.Logger(), out, "instance.active_state", &oracle.StringValue{NullString: in.ActiveState})
+	oracle.SetSqlValue(m.Logger(), out, "instance.thread", &oracle.Int64Value{NullInt64: in.Thread})
+	oracle.SetSqlValue(m.Logger(), out, "instance.logins", &oracle.StringValue{NullString: in.Logins})
+	oracle.SetSqlValue(m.Logger(), out, "instance.startup_time", &oracle.TimeValue{NullTime: in.StartupTime})
+	oracle.SetSqlValue(m.Logger(), out, "instance.uptime.sec", &oracle.Int64Value{NullInt64: in.UptimeSeconds})
+	oracle.SetSqlValue(m.Logger(), out, "instance.sessions", &oracle.Int64Value{NullInt64: in.Sessions})
+
+	if blocked, ok := yesNo(in.Blocked); ok {
+		_, _ = out.Put("instance.blocked", blocked)
+	}
+
+	// PARALLEL tells whether the instance is mounted in cluster database mode.
+	if parallel, ok := yesNo(in.Parallel); ok {
+		_, _ = out.Put("cluster.enabled", parallel)
+	}
+
+	return out
+}
+
+// yesNo converts the YES/NO columns of the dynamic performance views.
+func yesNo(s sql.NullString) (value bool, ok bool) {
+	switch {
+	case !s.Valid:
+		return false, false
+	case s.String == "YES":
+		return true, true
+	case s.String == "NO":
+		return false, true
+	}
+	return false, false
+}
@@ -0,0 +1,70 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package rac
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var startupTime = time.Date(2026, 3, 2, 7, 54, 38, 0, time.UTC)
+
+type happyMockExtractor struct{}
+
+func (happyMockExtractor) instancesData(_ context.Context) ([]instance, error) {
+	return []instance{
+		{ID: sql.NullInt64{Int64: 1, Valid: true}, Number: sql.NullInt64{Int64: 1, Valid: true}, Name: sql.NullString{String: "ORCL1", Valid: true}, HostName: sql.NullString{String: "racnode1", Valid: true}, Version: sql.NullString{String: "19.0.0.0.0", Valid: true}, Status: sql.NullString{String: "OPEN", Valid: true}, DatabaseStatus: sql.NullString{String: "ACTIVE", Valid: true}, Role: sql.NullString{String: "PRIMARY_INSTANCE", Valid: true}, ActiveState: sql.NullString{String: "NORMAL", Valid: true}, Thread: sql.NullInt64{Int64: 1, Valid: true}, Parallel: sql.NullString{String: "YES", Valid: true}, Logins: sql.NullString{String: "ALLOWED", Valid: true}, Blocked: sql.NullString{String: "NO", Valid: true}, StartupTime: sql.NullTime{Time: startupTime, Valid: true}, UptimeSeconds: sql.NullInt64{Int64: 3600, Valid: true}, Sessions: sql.NullInt64{Int64: 42, Valid: true}},
+		{ID: sql.NullInt64{Int64: 2, Valid: true}, Number: sql.NullInt64{Int64: 2, Valid: true}, Name: sql.NullString{String: "ORCL2", Valid: true}, HostName: sql.NullString{String: "racnode2", Valid: true}, Version: sql.NullString{String: "19.0.0.0.0", Valid: true}, Status: sql.NullString{String: "MOUNTED", Valid: true}, DatabaseStatus: sql.NullString{String: "ACTIVE", Valid: true}, Role: sql.NullString{String: "SECONDARY_INSTANCE", Valid: true}, ActiveState: sql.NullString{String: "QUIESCING", Valid: true}, Thread: sql.NullInt64{Int64: 2, Valid: true}, Parallel: sql.NullString{String: "YES", Valid: true}, Logins: sql.NullString{String: "RESTRICTED", Valid: true}, Blocked: sql.NullString{String: "YES", Valid: true}, StartupTime: sql.NullTime{Time: startupTime, Valid: true}, UptimeSeconds: sql.NullInt64{Int64: 60, Valid: true}, Sessions: sql.NullInt64{Int64: 3, Valid: true}},
+	}, nil
+}
+
+type errorMockExtractor struct{}
+
+func (errorMockExtractor) instancesData(_ context.Context) ([]instance, error) {
+	return nil, errors.New("instances error")
+}
+
+func TestEventMapping(t *testing.T) {
+	m := MetricSet{extractor: happyMockExtractor{}}
+
+	events, err := m.extractAndTransform(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, `{"cluster":{"enabled":true,"instances":2},"instance":{"active_state":"NORMAL","blocked":false,"database_status":"ACTIVE","host":"racnode1","id":1,"logins":"ALLOWED","name":"ORCL1","number":1,"role":"PRIMARY_INSTANCE","sessions":42,"startup_time":"2026-03-02T07:54:38Z","status":"OPEN","thread":1,"uptime":{"sec":3600},"version":"19.0.0.0.0"}}`, events[0].MetricSetFields.String())
+	assert.Equal(t, `{"cluster":{"enabled":true,"instances":2},"instance":{"active_state":"QUIESCING","blocked":true,"database_status":"ACTIVE","host":"racnode2","id":2,"logins":"RESTRICTED","name":"ORCL2","number":2,"role":"SECONDARY_INSTANCE","sessions":3,"startup_time":"2026-03-02T07:54:38Z","status":"MOUNTED","thread":2,"uptime":{"sec":60},"version":"19.0.0.0.0"}}`, events[1].MetricSetFields.String())
+
+	t.Run("Error Path", func(t *testing.T) {
+		m := MetricSet{extractor: errorMockExtractor{}}
+
+		_, err := m.extractAndTransform(context.Background())
+		assert.Error(t, err)
+	})
+}
+
+func TestYesNo(t *testing.T) {
+	for _, tc := range []struct {
+		in        sql.NullString
+		wantValue bool
+		wantOK    bool
+	}{
+		{in: sql.NullString{String: "YES", Valid: true}, wantValue: true, wantOK: true},
+		{in: sql.NullString{String: "NO", Valid: true}, wantValue: false, wantOK: true},
+		{in: sql.NullString{String: "UNKNOWN", Valid: true}, wantValue: false, wantOK: false},
+		{in: sql.NullString{}, wantValue: false, wantOK: false},
+	} {
+		value, ok := yesNo(tc.in)
+		assert.Equal(t, tc.wantValue, value, tc.in.String)
+		assert.Equal(t, tc.wantOK, ok, tc.in.String)
+	}
+}
@@ -0,0 +1,23 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package rac
+
+import (
+	"context"
+	"database/sql"
+)
+
+// racExtractMethods contains the methods needed to extract the necessary information about the instances of the cluster
+type racExtractMethods interface {
+	instancesData(context.Context) ([]instance, error)
+}
+
+// racExtractor is the implementor of racExtractMethods. It's implementation are on different Go files
+// which refers to the origin of the data for organization purposes.
+type racExtractor struct {
+	db *sql.DB
+}
@@ -0,0 +1,54 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package rac
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type instance struct {
+	ID             sql.NullInt64
+	Number         sql.NullInt64
+	Name           sql.NullString
+	HostName       sql.NullString
+	Version        sql.NullString
+	Status         sql.NullString
+	DatabaseStatus sql.NullString
+	Role           sql.NullString
+	ActiveState    sql.NullString
+	Thread         sql.NullInt64
+	Parallel       sql.NullString
+	Logins         sql.NullString
+	Blocked        sql.NullString
+	StartupTime    sql.NullTime
+	UptimeSeconds  sql.NullInt64
+	Sessions       sql.NullInt64
+}
+
+// instancesData reads the status of every instance of the database. GV$INSTANCE returns a single row on a database
+// that is not clustered.
+func (e *racExtractor) instancesData(ctx context.Context) ([]instance, error) {
+	rows, err := e.db.QueryContext(ctx, `SELECT i.INST_ID, i.INSTANCE_NUMBER, i.INSTANCE_NAME, i.HOST_NAME, i.VERSION, i.STATUS, i.DATABASE_STATUS, i.INSTANCE_ROLE, i.ACTIVE_STATE, i.THREAD#, i.PARALLEL, i.LOGINS, i.BLOCKED, i.STARTUP_TIME, ROUND((SYSDATE - i.STARTUP_TIME) * 86400) AS UPTIME, (SELECT COUNT(*) FROM GV$SESSION s WHERE s.INST_ID = i.INST_ID) AS SESSIONS FROM GV$INSTANCE i`)
+	if err != nil {
+		return nil, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]instance, 0)
+
+	for rows.Next() {
+		dest := instance{}
+		if err = rows.Scan(&dest.ID, &dest.Number, &dest.Name, &dest.HostName, &dest.Version, &dest.Status, &dest.DatabaseStatus, &dest.Role, &dest.ActiveState, &dest.Thread, &dest.Parallel, &dest.Logins, &dest.Blocked, &dest.StartupTime, &dest.UptimeSeconds, &dest.Sessions); err != nil {
+			return nil, err
+		}
+		results = append(results, dest)
+	}
+
+	return results, rows.Err()
+}
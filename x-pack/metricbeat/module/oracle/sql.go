@@ -78,3 +78,15 @@ func (s *StringValue) isValid() bool {
 func (s *StringValue) Value() interface{} {
 	return s.String
 }
+
+type TimeValue struct {
+	sql.NullTime
+}
+
+func (t *TimeValue) isValid() bool {
+	return t.Valid
+}
+
+func (t *TimeValue) Value() interface{} {
+	return t.Time
+}
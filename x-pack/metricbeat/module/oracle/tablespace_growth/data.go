@@ -0,0 +1,61 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package tablespace_growth
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// extractAndTransform gets the growth of the Tablespaces from Oracle and generates one event per Tablespace. It's
+// called by the Fetch method, which is the one that "loads" the data into Elasticsearch
+func (m *MetricSet) extractAndTransform(ctx context.Context) ([]mb.Event, error) {
+	growths, err := m.extractor.growthData(ctx, m.config.TablespaceGrowth.Window)
+	if err != nil {
+		return nil, fmt.Errorf("error getting tablespace growth: %w", err)
+	}
+
+	events := make([]mb.Event, 0, len(growths))
+	for i := range growths {
+		events = append(events, mb.Event{MetricSetFields: m.transform(&growths[i])})
+	}
+
+	return events, nil
+}
+
+// transform generates the event of a single Tablespace. The growth between the first and the last sample of the
+// window and the space left until the maximum size is reached are the inputs needed to forecast when the Tablespace
+// will be full. The daily growth is only reported when the samples cover some time.
+func (m *MetricSet) transform(g *growth) mapstr.M {
+	out := mapstr.M{}
+
+	oracle.SetSqlValue(m.Logger(), out, "name", &oracle.StringValue{NullString: g.TablespaceName})
+	oracle.SetSqlValue(m.Logger(), out, "snapshots", &oracle.Int64Value{NullInt64: g.Snapshots})
+	oracle.SetSqlValue(m.Logger(), out, "window.days", &oracle.Float64Value{NullFloat64: g.WindowDays})
+	oracle.SetSqlValue(m.Logger(), out, "space.used.bytes", &oracle.Int64Value{NullInt64: g.UsedBytes})
+	oracle.SetSqlValue(m.Logger(), out, "space.total.bytes", &oracle.Int64Value{NullInt64: g.SizeBytes})
+	oracle.SetSqlValue(m.Logger(), out, "space.max.bytes", &oracle.Int64Value{NullInt64: g.MaxSizeBytes})
+
+	if g.MaxSizeBytes.Valid && g.UsedBytes.Valid {
+		_, _ = out.Put("space.remaining.bytes", g.MaxSizeBytes.Int64-g.UsedBytes.Int64)
+	}
+
+	if g.FirstUsedBytes.Valid && g.UsedBytes.Valid {
+		growth := g.UsedBytes.Int64 - g.FirstUsedBytes.Int64
+		_, _ = out.Put("growth.bytes", growth)
+
+		if g.WindowDays.Valid && g.WindowDays.Float64 > 0 {
+			_, _ = out.Put("growth.per_day.bytes", float64(growth)/g.WindowDays.Float64)
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,58 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package tablespace_growth
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type happyMockExtractor struct {
+	window time.Duration
+}
+
+func (h *happyMockExtractor) growthData(_ context.Context, window time.Duration) ([]growth, error) {
+	h.window = window
+	return []growth{
+		{TablespaceName: sql.NullString{String: "USERS", Valid: true}, Snapshots: sql.NullInt64{Int64: 169, Valid: true}, WindowDays: sql.NullFloat64{Float64: 7, Valid: true}, FirstUsedBytes: sql.NullInt64{Int64: 1000, Valid: true}, UsedBytes: sql.NullInt64{Int64: 8000, Valid: true}, SizeBytes: sql.NullInt64{Int64: 10000, Valid: true}, MaxSizeBytes: sql.NullInt64{Int64: 50000, Valid: true}},
+		{TablespaceName: sql.NullString{String: "SYSAUX", Valid: true}, Snapshots: sql.NullInt64{Int64: 1, Valid: true}, WindowDays: sql.NullFloat64{Float64: 0, Valid: true}, FirstUsedBytes: sql.NullInt64{Int64: 500, Valid: true}, UsedBytes: sql.NullInt64{Int64: 500, Valid: true}, SizeBytes: sql.NullInt64{Int64: 1000, Valid: true}, MaxSizeBytes: sql.NullInt64{Int64: 1000, Valid: true}},
+	}, nil
+}
+
+type errorMockExtractor struct{}
+
+func (errorMockExtractor) growthData(_ context.Context, _ time.Duration) ([]growth, error) {
+	return nil, errors.New("growth error")
+}
+
+func TestEventMapping(t *testing.T) {
+	extractor := &happyMockExtractor{}
+	m := MetricSet{extractor: extractor, config: defaultConfig()}
+
+	events, err := m.extractAndTransform(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, 7*24*time.Hour, extractor.window)
+	assert.Equal(t, `{"growth":{"bytes":7000,"per_day":{"bytes":1000}},"name":"USERS","snapshots":169,"space":{"max":{"bytes":50000},"remaining":{"bytes":42000},"total":{"bytes":10000},"used":{"bytes":8000}},"window":{"days":7}}`, events[0].MetricSetFields.String())
+
+	// A single sample doesn't cover any time, the daily growth can't be calculated.
+	assert.Equal(t, `{"growth":{"bytes":0},"name":"SYSAUX","snapshots":1,"space":{"max":{"bytes":1000},"remaining":{"bytes":500},"total":{"bytes":1000},"used":{"bytes":500}},"window":{"days":0}}`, events[1].MetricSetFields.String())
+
+	t.Run("Error Path", func(t *testing.T) {
+		m := MetricSet{extractor: errorMockExtractor{}, config: defaultConfig()}
+
+		_, err := m.extractAndTransform(context.Background())
+		assert.Error(t, err)
+	})
+}
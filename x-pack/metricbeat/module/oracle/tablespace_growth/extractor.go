@@ -0,0 +1,24 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package tablespace_growth
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// tablespaceGrowthExtractMethods contains the methods needed to extract the growth of the Tablespaces
+type tablespaceGrowthExtractMethods interface {
+	growthData(ctx context.Context, window time.Duration) ([]growth, error)
+}
+
+// tablespaceGrowthExtractor is the implementor of tablespaceGrowthExtractMethods. It's implementation are on different
+// Go files which refers to the origin of the data for organization purposes.
+type tablespaceGrowthExtractor struct {
+	db *sql.DB
+}
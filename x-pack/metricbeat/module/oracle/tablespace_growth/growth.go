@@ -0,0 +1,60 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package tablespace_growth
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+type growth struct {
+	TablespaceName sql.NullString
+	Snapshots      sql.NullInt64
+	WindowDays     sql.NullFloat64
+	FirstUsedBytes sql.NullInt64
+	UsedBytes      sql.NullInt64
+	SizeBytes      sql.NullInt64
+	MaxSizeBytes   sql.NullInt64
+}
+
+// growthQuery reads the first and the last AWR tablespace usage sample of every Tablespace inside the window. Sizes
+// in DBA_HIST_TBSPC_SPACE_USAGE are expressed in blocks of the Tablespace.
+const growthQuery = `SELECT t.NAME,
+  COUNT(*) AS SNAPSHOTS,
+  MAX(TO_DATE(u.RTIME, 'MM/DD/YYYY HH24:MI:SS')) - MIN(TO_DATE(u.RTIME, 'MM/DD/YYYY HH24:MI:SS')) AS WINDOW_DAYS,
+  MAX(u.TABLESPACE_USEDSIZE) KEEP (DENSE_RANK FIRST ORDER BY u.SNAP_ID) * d.BLOCK_SIZE AS FIRST_USED_BYTES,
+  MAX(u.TABLESPACE_USEDSIZE) KEEP (DENSE_RANK LAST ORDER BY u.SNAP_ID) * d.BLOCK_SIZE AS USED_BYTES,
+  MAX(u.TABLESPACE_SIZE) KEEP (DENSE_RANK LAST ORDER BY u.SNAP_ID) * d.BLOCK_SIZE AS SIZE_BYTES,
+  MAX(u.TABLESPACE_MAXSIZE) KEEP (DENSE_RANK LAST ORDER BY u.SNAP_ID) * d.BLOCK_SIZE AS MAX_SIZE_BYTES
+FROM DBA_HIST_TBSPC_SPACE_USAGE u
+JOIN V$TABLESPACE t ON t.TS# = u.TABLESPACE_ID
+JOIN DBA_TABLESPACES d ON d.TABLESPACE_NAME = t.NAME
+WHERE u.DBID = (SELECT DBID FROM V$DATABASE)
+  AND TO_DATE(u.RTIME, 'MM/DD/YYYY HH24:MI:SS') > SYSDATE - :days
+GROUP BY t.NAME, d.BLOCK_SIZE`
+
+func (e *tablespaceGrowthExtractor) growthData(ctx context.Context, window time.Duration) ([]growth, error) {
+	rows, err := e.db.QueryContext(ctx, growthQuery, window.Hours()/24)
+	if err != nil {
+		return nil, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]growth, 0)
+
+	for rows.Next() {
+		dest := growth{}
+		if err = rows.Scan(&dest.TablespaceName, &dest.Snapshots, &dest.WindowDays, &dest.FirstUsedBytes, &dest.UsedBytes, &dest.SizeBytes, &dest.MaxSizeBytes); err != nil {
+			return nil, err
+		}
+		results = append(results, dest)
+	}
+
+	return results, rows.Err()
+}
@@ -0,0 +1,61 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package top_sql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle"
+	"github.com/elastic/elastic-agent-libs/mapstr"
+)
+
+// extractAndTransform gets the top statements from Oracle and generates one event per statement. It's called by the
+// Fetch method, which is the one that "loads" the data into Elasticsearch
+func (m *MetricSet) extractAndTransform(ctx context.Context) ([]mb.Event, error) {
+	statements, err := m.extractor.statementsData(ctx, m.config.TopSQL.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("error getting top statements: %w", err)
+	}
+
+	events := make([]mb.Event, 0, len(statements))
+	for i := range statements {
+		events = append(events, mb.Event{MetricSetFields: m.transform(&statements[i], i+1)})
+	}
+
+	return events, nil
+}
+
+// transform generates the event of a single statement. rank is the position of the statement when ordered by
+// elapsed time, starting at 1.
+func (m *MetricSet) transform(s *statement, rank int) mapstr.M {
+	out := mapstr.M{
+		"rank": rank,
+	}
+
+	oracle.SetSqlValue(m.Logger(), out, "sql_id", &oracle.StringValue{NullString: s.SQLID})
+	oracle.SetSqlValue(m.Logger(), out, "plan_hash_value", &oracle.Int64Value{NullInt64: s.PlanHashValue})
+	oracle.SetSqlValue(m.Logger(), out, "text", &oracle.StringValue{NullString: s.Text})
+	oracle.SetSqlValue(m.Logger(), out, "executions", &oracle.Int64Value{NullInt64: s.Executions})
+	oracle.SetSqlValue(m.Logger(), out, "elapsed_time.us", &oracle.Int64Value{NullInt64: s.ElapsedTime})
+	oracle.SetSqlValue(m.Logger(), out, "cpu_time.us", &oracle.Int64Value{NullInt64: s.CPUTime})
+	oracle.SetSqlValue(m.Logger(), out, "wait_time.user_io.us", &oracle.Int64Value{NullInt64: s.UserIOWaitTime})
+	oracle.SetSqlValue(m.Logger(), out, "wait_time.application.us", &oracle.Int64Value{NullInt64: s.ApplicationWaitTime})
+	oracle.SetSqlValue(m.Logger(), out, "wait_time.concurrency.us", &oracle.Int64Value{NullInt64: s.ConcurrencyWaitTime})
+	oracle.SetSqlValue(m.Logger(), out, "wait_time.cluster.us", &oracle.Int64Value{NullInt64: s.ClusterWaitTime})
+	oracle.SetSqlValue(m.Logger(), out, "buffer_gets", &oracle.Int64Value{NullInt64: s.BufferGets})
+	oracle.SetSqlValue(m.Logger(), out, "disk_reads", &oracle.Int64Value{NullInt64: s.DiskReads})
+	oracle.SetSqlValue(m.Logger(), out, "rows_processed", &oracle.Int64Value{NullInt64: s.RowsProcessed})
+	oracle.SetSqlValue(m.Logger(), out, "last_active_time", &oracle.TimeValue{NullTime: s.LastActiveTime})
+
+	if s.Executions.Valid && s.Executions.Int64 > 0 && s.ElapsedTime.Valid {
+		_, _ = out.Put("elapsed_time.per_execution.us", float64(s.ElapsedTime.Int64)/float64(s.Executions.Int64))
+	}
+
+	return out
+}
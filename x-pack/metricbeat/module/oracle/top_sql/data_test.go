@@ -0,0 +1,60 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package top_sql
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var lastActiveTime = time.Date(2026, 3, 2, 7, 54, 38, 0, time.UTC)
+
+type happyMockExtractor struct {
+	limit int
+}
+
+func (h *happyMockExtractor) statementsData(_ context.Context, limit int) ([]statement, error) {
+	h.limit = limit
+	return []statement{
+		{SQLID: sql.NullString{String: "8swypbbr0m372", Valid: true}, PlanHashValue: sql.NullInt64{Int64: 3472618812, Valid: true}, Text: sql.NullString{String: "SELECT * FROM ORDERS WHERE STATUS = :1", Valid: true}, Executions: sql.NullInt64{Int64: 4, Valid: true}, ElapsedTime: sql.NullInt64{Int64: 1000, Valid: true}, CPUTime: sql.NullInt64{Int64: 600, Valid: true}, UserIOWaitTime: sql.NullInt64{Int64: 300, Valid: true}, ApplicationWaitTime: sql.NullInt64{Int64: 0, Valid: true}, ConcurrencyWaitTime: sql.NullInt64{Int64: 50, Valid: true}, ClusterWaitTime: sql.NullInt64{Int64: 50, Valid: true}, BufferGets: sql.NullInt64{Int64: 2000, Valid: true}, DiskReads: sql.NullInt64{Int64: 20, Valid: true}, RowsProcessed: sql.NullInt64{Int64: 40, Valid: true}, LastActiveTime: sql.NullTime{Time: lastActiveTime, Valid: true}},
+		{SQLID: sql.NullString{String: "0k8522rmdzg4k", Valid: true}, PlanHashValue: sql.NullInt64{Int64: 0, Valid: true}, Text: sql.NullString{String: "BEGIN DBMS_STATS.GATHER_DATABASE_STATS_JOB_PROC; END;", Valid: true}, Executions: sql.NullInt64{Int64: 0, Valid: true}, ElapsedTime: sql.NullInt64{Int64: 500, Valid: true}, CPUTime: sql.NullInt64{Int64: 500, Valid: true}, UserIOWaitTime: sql.NullInt64{Int64: 0, Valid: true}, ApplicationWaitTime: sql.NullInt64{Int64: 0, Valid: true}, ConcurrencyWaitTime: sql.NullInt64{Int64: 0, Valid: true}, ClusterWaitTime: sql.NullInt64{Int64: 0, Valid: true}, BufferGets: sql.NullInt64{Int64: 10, Valid: true}, DiskReads: sql.NullInt64{Int64: 0, Valid: true}, RowsProcessed: sql.NullInt64{Int64: 0, Valid: true}, LastActiveTime: sql.NullTime{Time: lastActiveTime, Valid: true}},
+	}, nil
+}
+
+type errorMockExtractor struct{}
+
+func (errorMockExtractor) statementsData(_ context.Context, _ int) ([]statement, error) {
+	return nil, errors.New("statements error")
+}
+
+func TestEventMapping(t *testing.T) {
+	extractor := &happyMockExtractor{}
+	m := MetricSet{extractor: extractor, config: defaultConfig()}
+
+	events, err := m.extractAndTransform(context.Background())
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, 10, extractor.limit)
+	assert.Equal(t, `{"buffer_gets":2000,"cpu_time":{"us":600},"disk_reads":20,"elapsed_time":{"per_execution":{"us":250},"us":1000},"executions":4,"last_active_time":"2026-03-02T07:54:38Z","plan_hash_value":3472618812,"rank":1,"rows_processed":40,"sql_id":"8swypbbr0m372","text":"SELECT * FROM ORDERS WHERE STATUS = :1","wait_time":{"application":{"us":0},"cluster":{"us":50},"concurrency":{"us":50},"user_io":{"us":300}}}`, events[0].MetricSetFields.String())
+
+	// A statement that is still running for the first time has no executions yet.
+	assert.Equal(t, `{"buffer_gets":10,"cpu_time":{"us":500},"disk_reads":0,"elapsed_time":{"us":500},"executions":0,"last_active_time":"2026-03-02T07:54:38Z","plan_hash_value":0,"rank":2,"rows_processed":0,"sql_id":"0k8522rmdzg4k","text":"BEGIN DBMS_STATS.GATHER_DATABASE_STATS_JOB_PROC; END;","wait_time":{"application":{"us":0},"cluster":{"us":0},"concurrency":{"us":0},"user_io":{"us":0}}}`, events[1].MetricSetFields.String())
+
+	t.Run("Error Path", func(t *testing.T) {
+		m := MetricSet{extractor: errorMockExtractor{}, config: defaultConfig()}
+
+		_, err := m.extractAndTransform(context.Background())
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,23 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package top_sql
+
+import (
+	"context"
+	"database/sql"
+)
+
+// topSQLExtractMethods contains the methods needed to extract the most expensive statements of the shared pool
+type topSQLExtractMethods interface {
+	statementsData(ctx context.Context, limit int) ([]statement, error)
+}
+
+// topSQLExtractor is the implementor of topSQLExtractMethods. It's implementation are on different Go files
+// which refers to the origin of the data for organization purposes.
+type topSQLExtractor struct {
+	db *sql.DB
+}
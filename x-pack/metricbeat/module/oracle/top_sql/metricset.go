@@ -0,0 +1,95 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package top_sql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/elastic/beats/v7/libbeat/common/cfgwarn"
+	"github.com/elastic/beats/v7/metricbeat/mb"
+	"github.com/elastic/beats/v7/x-pack/metricbeat/module/oracle"
+)
+
+// init registers the MetricSet with the central registry as soon as the program
+// starts. The New function will be called later to instantiate an instance of
+// the MetricSet for each host defined in the module's configuration. After the
+// MetricSet has been created then Fetch will begin to be called periodically.
+func init() {
+	mb.Registry.MustAddMetricSet("oracle", "top_sql", New,
+		mb.WithHostParser(oracle.HostParser))
+}
+
+type config struct {
+	TopSQL struct {
+		// Limit is the number of statements reported on every fetch.
+		Limit int `config:"limit"`
+	} `config:"top_sql"`
+}
+
+func defaultConfig() config {
+	var c config
+	c.TopSQL.Limit = 10
+	return c
+}
+
+// MetricSet holds any configuration or state information. It must implement
+// the mb.MetricSet interface. And this is best achieved by embedding
+// mb.BaseMetricSet because it implements all of the required mb.MetricSet
+// interface methods except for Fetch.
+type MetricSet struct {
+	mb.BaseMetricSet
+	extractor topSQLExtractMethods
+	config    config
+}
+
+// New creates a new instance of the MetricSet. New is responsible for unpacking
+// any MetricSet specific configuration options if there are any.
+func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
+	base.Logger().Warn(cfgwarn.Beta("The oracle top_sql metricset is beta."))
+
+	config := defaultConfig()
+	if err := base.Module().UnpackConfig(&config); err != nil {
+		return nil, fmt.Errorf("error parsing config file: %w", err)
+	}
+
+	if config.TopSQL.Limit <= 0 {
+		return nil, errors.New("top_sql.limit must be greater than 0")
+	}
+
+	return &MetricSet{
+		BaseMetricSet: base,
+		config:        config,
+	}, nil
+}
+
+// Fetch methods implements the data gathering and data conversion to the right
+// format. It publishes the event which is then forwarded to the output. In case
+// of an error set the Error field of mb.Event or simply call report.Error().
+func (m *MetricSet) Fetch(ctx context.Context, reporter mb.ReporterV2) error {
+	db, err := oracle.NewConnection(m.HostData().URI)
+	if err != nil {
+		return fmt.Errorf("error creating connection to Oracle: %w", err)
+	}
+	defer db.Close()
+
+	m.extractor = &topSQLExtractor{db: db}
+
+	events, err := m.extractAndTransform(ctx)
+	if err != nil {
+		return fmt.Errorf("error getting or interpreting data from Oracle: %w", err)
+	}
+
+	for _, event := range events {
+		if reported := reporter.Event(event); !reported {
+			return nil
+		}
+	}
+
+	return nil
+}
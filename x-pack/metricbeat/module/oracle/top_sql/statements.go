@@ -0,0 +1,52 @@
+// Copyright Elasticsearch B.V. and/or licensed to Elasticsearch B.V. under one
+// or more contributor license agreements. Licensed under the Elastic License;
+// you may not use this file except in compliance with the Elastic License.
+
+//go:build !requirefips
+
+package top_sql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type statement struct {
+	SQLID               sql.NullString
+	PlanHashValue       sql.NullInt64
+	Text                sql.NullString
+	Executions          sql.NullInt64
+	ElapsedTime         sql.NullInt64
+	CPUTime             sql.NullInt64
+	UserIOWaitTime      sql.NullInt64
+	ApplicationWaitTime sql.NullInt64
+	ConcurrencyWaitTime sql.NullInt64
+	ClusterWaitTime     sql.NullInt64
+	BufferGets          sql.NullInt64
+	DiskReads           sql.NullInt64
+	RowsProcessed       sql.NullInt64
+	LastActiveTime      sql.NullTime
+}
+
+// statementsData reads the statements with the highest elapsed time. V$SQLSTATS is used instead of V$SQLAREA as it
+// doesn't take the library cache latches. Times are cumulative since the statement was loaded, in microseconds.
+func (e *topSQLExtractor) statementsData(ctx context.Context, limit int) ([]statement, error) {
+	rows, err := e.db.QueryContext(ctx, `SELECT SQL_ID, PLAN_HASH_VALUE, SQL_TEXT, EXECUTIONS, ELAPSED_TIME, CPU_TIME, USER_IO_WAIT_TIME, APPLICATION_WAIT_TIME, CONCURRENCY_WAIT_TIME, CLUSTER_WAIT_TIME, BUFFER_GETS, DISK_READS, ROWS_PROCESSED, LAST_ACTIVE_TIME FROM V$SQLSTATS ORDER BY ELAPSED_TIME DESC FETCH FIRST :limit ROWS ONLY`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("error executing query: %w", err)
+	}
+	defer rows.Close()
+
+	results := make([]statement, 0, limit)
+
+	for rows.Next() {
+		dest := statement{}
+		if err = rows.Scan(&dest.SQLID, &dest.PlanHashValue, &dest.Text, &dest.Executions, &dest.ElapsedTime, &dest.CPUTime, &dest.UserIOWaitTime, &dest.ApplicationWaitTime, &dest.ConcurrencyWaitTime, &dest.ClusterWaitTime, &dest.BufferGets, &dest.DiskReads, &dest.RowsProcessed, &dest.LastActiveTime); err != nil {
+			return nil, err
+		}
+		results = append(results, dest)
+	}
+
+	return results, rows.Err()
+}
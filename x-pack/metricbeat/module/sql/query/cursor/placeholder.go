@@ -39,7 +39,7 @@ func ValidateQueryHasCursor(query string) error {
 //   - MSSQL: @p1
 func TranslateQuery(query, driver string) string {
 	placeholder := getDriverPlaceholder(driver)
-	positions := findPlaceholderPositions(query)
+	positions := FindPlaceholders(query, CursorPlaceholder)
 	if len(positions) == 0 {
 		return query
 	}
@@ -79,10 +79,10 @@ func getDriverPlaceholder(driver string) string {
 // CountPlaceholders returns the number of :cursor placeholders in executable
 // SQL (outside quoted strings and comments).
 func CountPlaceholders(query string) int {
-	return len(findPlaceholderPositions(query))
+	return len(FindPlaceholders(query, CursorPlaceholder))
 }
 
-// Scanner states for findPlaceholderPositions.
+// Scanner states for FindPlaceholders.
 const (
 	stateNormal = iota
 	stateSingleQuote
@@ -92,9 +92,9 @@ const (
 	stateBlockComment
 )
 
-// findPlaceholderPositions returns the byte offsets of every :cursor
-// placeholder that appears in executable SQL. The scanner skips content
-// inside:
+// FindPlaceholders returns the byte offsets of every occurrence of
+// placeholder, like :cursor, that appears in executable SQL. The scanner
+// skips content inside:
 //   - Single-quoted strings ('...'), with ” escape handling
 //   - Double-quoted identifiers ("..."), with "" escape handling
 //   - Backtick-quoted identifiers (`...`), with “ escape handling
@@ -108,7 +108,7 @@ const (
 // rarely use backslash escapes, and MySQL's NO_BACKSLASH_ESCAPES mode
 // disables them entirely. The standard SQL escape (doubled quotes:
 // 'it”s :cursor') is handled correctly.
-func findPlaceholderPositions(query string) []int {
+func FindPlaceholders(query, placeholder string) []int {
 	positions := make([]int, 0, 1)
 	n := len(query)
 	state := stateNormal
@@ -130,9 +130,9 @@ func findPlaceholderPositions(query string) []int {
 				state = stateDoubleQuote
 			} else if ch == '`' {
 				state = stateBacktick
-			} else if ch == ':' && matchesPlaceholder(query, i, n) {
+			} else if ch == placeholder[0] && matchesPlaceholder(query, placeholder, i, n) {
 				positions = append(positions, i)
-				i += len(CursorPlaceholder) - 1
+				i += len(placeholder) - 1
 			}
 
 		case stateSingleQuote:
@@ -178,15 +178,14 @@ func findPlaceholderPositions(query string) []int {
 	return positions
 }
 
-// matchesPlaceholder reports whether query[i:] starts with ":cursor"
-// followed by a non-word character (or end of string). The caller must
-// ensure query[i] == ':' before calling.
-func matchesPlaceholder(query string, i, n int) bool {
-	end := i + len(CursorPlaceholder)
+// matchesPlaceholder reports whether query[i:] starts with placeholder
+// followed by a non-word character (or end of string).
+func matchesPlaceholder(query, placeholder string, i, n int) bool {
+	end := i + len(placeholder)
 	if end > n {
 		return false
 	}
-	if query[i:end] != CursorPlaceholder {
+	if query[i:end] != placeholder {
 		return false
 	}
 	if end == n {
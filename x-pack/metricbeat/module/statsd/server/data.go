@@ -31,7 +31,9 @@ type statsdMetric struct {
 	tags       map[string]string
 }
 
-func splitTags(rawTags, kvSep []byte, log *logp.Logger) map[string]string {
+// splitTags parses a list of key-value tags. When allowBare is set, tags
+// without value, as supported by DogStatsD, are kept with an empty value.
+func splitTags(rawTags, kvSep []byte, allowBare bool, log *logp.Logger) map[string]string {
 	tags := map[string]string{}
 	var tagSplit [][]byte
 
@@ -43,6 +45,10 @@ func splitTags(rawTags, kvSep []byte, log *logp.Logger) map[string]string {
 
 	for _, kv := range tagSplit {
 		kvSplit := bytes.SplitN(kv, kvSep, 2)
+		if len(kvSplit) == 1 && allowBare && len(kvSplit[0]) > 0 {
+			tags[string(kvSplit[0])] = ""
+			continue
+		}
 		if len(kvSplit) != 2 {
 			log.Named("statd").Warn("could not parse tags")
 			continue
@@ -53,28 +59,30 @@ func splitTags(rawTags, kvSep []byte, log *logp.Logger) map[string]string {
 }
 
 func parseSingle(b []byte, log *logp.Logger) (statsdMetric, error) {
-	// format: <metric name>:<value>|<type>[|@samplerate][|#<k>:<v>,<k>:<v>]
+	// format: <metric name>:<value>|<type>[|@samplerate][|#<k>:<v>,<k>,<k>:<v>][|c:<container>][|T<timestamp>]
 	// alternative: <metric name>[,<k>=<v>,<k>=<v>]:<value>|<type>[|@samplerate]
 	// alternative: <metric name>[;<k>=<v>;<k>=<v>]:<value>|<type>[|@samplerate]
 	s := statsdMetric{}
 
-	parts := bytes.SplitN(b, []byte("|"), 4)
+	parts := bytes.Split(b, []byte("|"))
 	if len(parts) < 2 {
 		return s, errInvalidPacket
 	}
 
-	if len(parts) > 2 && len(parts[2]) > 0 && parts[2][0] == '@' {
-		s.sampleRate = string(parts[2][1:])
-
-		if len(parts) > 3 {
-			parts = [][]byte{parts[0], parts[1], parts[3]}
-		} else {
-			parts = [][]byte{parts[0], parts[1]}
+	// DogStatsD extensions can follow the type in any order.
+	for _, ext := range parts[2:] {
+		if len(ext) == 0 {
+			continue
+		}
+		switch ext[0] {
+		case '@':
+			s.sampleRate = string(ext[1:])
+		case '#':
+			s.tags = splitTags(ext[1:], []byte(":"), true, log)
+		default:
+			// Other extensions, such as the container ID (c:) or the
+			// timestamp (T), are not used.
 		}
-	}
-
-	if len(parts) > 2 && len(parts[2]) > 0 && parts[2][0] == '#' {
-		s.tags = splitTags(parts[2][1:], []byte(":"), log)
 	}
 
 	nameSplit := bytes.SplitN(parts[0], []byte{':'}, 2)
@@ -93,7 +101,7 @@ func parseSingle(b []byte, log *logp.Logger) (statsdMetric, error) {
 
 	s.name = string(nameTagsSplit[0])
 	if len(nameTagsSplit) > 1 {
-		s.tags = splitTags(nameTagsSplit[1], []byte("="), log)
+		s.tags = splitTags(nameTagsSplit[1], []byte("="), false, log)
 	}
 
 	s.value = string(nameSplit[1])
@@ -161,9 +169,17 @@ func eventMapping(metricName string, metricValue interface{}, mappings map[strin
 	return m
 }
 
-func newMetricProcessor(ttl time.Duration, log *logp.Logger) *metricProcessor {
+func newMetricProcessor(config Config, log *logp.Logger) *metricProcessor {
 	return &metricProcessor{
-		registry: &registry{metrics: map[string]map[string]*metric{}, ttl: ttl, logger: log.Named("statd")},
+		registry: &registry{
+			metrics:         map[string]map[string]*metric{},
+			ttl:             config.TTL,
+			logger:          log.Named("statd"),
+			histogramWindow: config.HistogramWindow,
+			maxSeries:       config.MaxSeriesPerMetric,
+			series:          map[string]int{},
+			limited:         map[string]struct{}{},
+		},
 	}
 }
 
@@ -187,6 +203,10 @@ func (p *metricProcessor) processSingle(m statsdMetric) error {
 		}
 	}
 
+	if !p.registry.Accept(m.name, m.tags) {
+		return nil
+	}
+
 	switch m.metricType {
 	case "c":
 		c := p.registry.GetOrNewCounter(m.name, m.tags)
@@ -221,12 +241,17 @@ func (p *metricProcessor) processSingle(m statsdMetric) error {
 		}
 		c.SampledUpdate(time.Duration(v), sampleRate)
 	case "h": // TODO: can these be floats?
+		if p.registry.histogramWindow > 0 {
+			return p.processDistribution(m)
+		}
 		c := p.registry.GetOrNewHistogram(m.name, m.tags)
 		v, err := strconv.ParseInt(m.value, 10, 64)
 		if err != nil {
 			return fmt.Errorf("failed to process histogram `%s` with value `%s`: %w", m.name, m.value, err)
 		}
 		c.Update(v)
+	case "d":
+		return p.processDistribution(m)
 	case "s":
 		c := p.registry.GetOrNewSet(m.name, m.tags)
 		c.Add(m.value)
@@ -236,6 +261,16 @@ func (p *metricProcessor) processSingle(m statsdMetric) error {
 	return nil
 }
 
+func (p *metricProcessor) processDistribution(m statsdMetric) error {
+	c := p.registry.GetOrNewDistribution(m.name, m.tags)
+	v, err := strconv.ParseFloat(m.value, 64)
+	if err != nil {
+		return fmt.Errorf("failed to process distribution `%s` with value `%s`: %w", m.name, m.value, err)
+	}
+	c.Update(v)
+	return nil
+}
+
 func (p *metricProcessor) Process(event server.Event) error {
 	bytesRaw, ok := event.GetEvent()[server.EventDataKey]
 	if !ok {
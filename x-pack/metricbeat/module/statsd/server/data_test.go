@@ -1010,6 +1010,35 @@ func TestParseMetrics(t *testing.T) {
 				},
 			},
 		},
+		{ // DogStatsD tags without value and extensions
+			input: "tags4:1|c|#k1:v1,production,url:http://host:8080|c:container1|T1656581400",
+			expected: []statsdMetric{
+				{
+					name:       "tags4",
+					metricType: "c",
+					value:      "1",
+					tags: map[string]string{
+						"k1":         "v1",
+						"production": "",
+						"url":        "http://host:8080",
+					},
+				},
+			},
+		},
+		{
+			input: "tags5:0.25|d|#k1:v1|@0.5",
+			expected: []statsdMetric{
+				{
+					name:       "tags5",
+					metricType: "d",
+					value:      "0.25",
+					sampleRate: "0.5",
+					tags: map[string]string{
+						"k1": "v1",
+					},
+				},
+			},
+		},
 		{ // Influx Statsd tags
 			input: "tags2,k1=v1,k2=v2:1|c",
 			expected: []statsdMetric{
@@ -1053,7 +1082,7 @@ func TestParseMetrics(t *testing.T) {
 		assert.Equal(t, test.err, err, test.input)
 		assert.Equal(t, test.expected, actual, test.input)
 
-		processor := newMetricProcessor(time.Second, logger)
+		processor := newMetricProcessor(Config{TTL: time.Second}, logger)
 		for _, e := range actual {
 			err := processor.processSingle(e)
 
@@ -1404,6 +1433,146 @@ func TestChangeType(t *testing.T) {
 	}, events[0].MetricSetFields)
 }
 
+func TestTagFields(t *testing.T) {
+	ms := mbtest.NewMetricSet(t, map[string]interface{}{
+		"module": "statsd",
+		"statsd.tag_fields": map[string]interface{}{
+			"env":     "service.environment",
+			"service": "service.name",
+		},
+	}).(*MetricSet)
+	testData := []string{
+		"metric01:1|c|#env:prod,service:checkout,region:eu,canary",
+	}
+	err := process(testData, ms)
+	require.NoError(t, err)
+
+	events := ms.getEvents()
+	require.Len(t, events, 1)
+
+	assert.Equal(t, mapstr.M{
+		"labels": mapstr.M{
+			"region": "eu",
+		},
+		"service": mapstr.M{
+			"environment": "prod",
+			"name":        "checkout",
+		},
+		"tags": []string{"canary"},
+	}, events[0].RootFields)
+}
+
+func TestDistribution(t *testing.T) {
+	ms := mbtest.NewMetricSet(t, map[string]interface{}{"module": "statsd"}).(*MetricSet)
+	testData := []string{
+		"metric01:0.5|d|#k1:v1",
+		"metric01:1.5|d|#k1:v1",
+		"metric01:4|d|#k1:v1",
+	}
+	err := process(testData, ms)
+	require.NoError(t, err)
+
+	events := ms.getEvents()
+	require.Len(t, events, 1)
+
+	actual := events[0].MetricSetFields["metric01"].(map[string]interface{})
+	assert.Equal(t, int64(3), actual["count"])
+	assert.Equal(t, 0.5, actual["min"])
+	assert.Equal(t, 4.0, actual["max"])
+	assert.Equal(t, 6.0, actual["sum"])
+	assert.Equal(t, 2.0, actual["mean"])
+	assert.Equal(t, 1.5, actual["median"])
+	assert.Equal(t, 4.0, actual["p99"])
+
+	// Without window, distributions are aggregated over each report.
+	events = ms.getEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, mapstr.M{
+		"metric01": map[string]interface{}{"count": int64(0)},
+	}, events[0].MetricSetFields)
+}
+
+func TestHistogramWindow(t *testing.T) {
+	ms := mbtest.NewMetricSet(t, map[string]interface{}{
+		"module":                  "statsd",
+		"statsd.histogram_window": "1s",
+	}).(*MetricSet)
+	testData := []string{
+		"metric01:2.5|h",
+		"metric01:3.5|h",
+	}
+	err := process(testData, ms)
+	require.NoError(t, err)
+
+	// The window didn't elapse, the values are reported again.
+	for i := 0; i < 2; i++ {
+		events := ms.getEvents()
+		require.Len(t, events, 1)
+
+		actual := events[0].MetricSetFields["metric01"].(map[string]interface{})
+		assert.Equal(t, int64(2), actual["count"])
+		assert.Equal(t, 3.0, actual["mean"])
+	}
+
+	time.Sleep(time.Second)
+
+	events := ms.getEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(2), events[0].MetricSetFields["metric01"].(map[string]interface{})["count"])
+
+	// A new window started after the previous report.
+	events = ms.getEvents()
+	require.Len(t, events, 1)
+	assert.Equal(t, int64(0), events[0].MetricSetFields["metric01"].(map[string]interface{})["count"])
+}
+
+func TestMaxSeriesPerMetric(t *testing.T) {
+	ms := mbtest.NewMetricSet(t, map[string]interface{}{
+		"module":                       "statsd",
+		"ttl":                          "1s",
+		"statsd.max_series_per_metric": 2,
+	}).(*MetricSet)
+	testData := []string{
+		"metric01:1|c|#user:a",
+		"metric01:1|c|#user:b",
+		"metric01:1|c|#user:c",
+		"metric01:1|c|#user:a",
+		"metric02:1|c|#user:c",
+	}
+	err := process(testData, ms)
+	require.NoError(t, err)
+
+	events := ms.getEvents()
+	require.Len(t, events, 3)
+
+	counts := map[string]interface{}{}
+	for _, e := range events {
+		for name, values := range e.MetricSetFields {
+			user, _ := e.RootFields.GetValue("labels.user")
+			counts[name+"/"+user.(string)] = values.(map[string]interface{})["count"]
+		}
+	}
+	assert.Equal(t, map[string]interface{}{
+		"metric01/a": int64(2),
+		"metric01/b": int64(1),
+		"metric02/c": int64(1),
+	}, counts)
+
+	// Once series expire, new tag combinations are accepted again.
+	time.Sleep(time.Second)
+	ms.getEvents()
+	time.Sleep(time.Second)
+	require.Empty(t, ms.getEvents())
+
+	err = process([]string{"metric01:1|c|#user:c"}, ms)
+	require.NoError(t, err)
+
+	events = ms.getEvents()
+	require.Len(t, events, 1)
+	user, _ := events[0].RootFields.GetValue("labels.user")
+	assert.Equal(t, "c", user)
+}
+
 func BenchmarkIngest(b *testing.B) {
 	tests := []string{
 		"metric01:1.0|g|#k1:v1,k2:v2",
@@ -5,6 +5,9 @@
 package server
 
 import (
+	"math"
+	"math/rand/v2"
+	"sort"
 	"time"
 
 	"github.com/rcrowley/go-metrics"
@@ -26,6 +29,16 @@ type registry struct {
 	ttl        time.Duration
 	lastReport time.Time
 	logger     *logp.Logger
+
+	// histogramWindow is the aggregation window of distributions.
+	histogramWindow time.Duration
+
+	// maxSeries limits the number of tag combinations per metric name, 0
+	// means unlimited. series holds the number of tag combinations of each
+	// metric name and limited the names whose limit was reached.
+	maxSeries int
+	series    map[string]int
+	limited   map[string]struct{}
 }
 
 type setMetric struct {
@@ -178,12 +191,115 @@ func (t *samplingTimerSnapshot) Variance() float64 {
 	return t.histogram.Variance()
 }
 
+// distributionReservoirSize is the number of values kept per window to
+// compute the percentiles of a distribution.
+const distributionReservoirSize = 1028
+
+// distributionMetric aggregates the values received during a window, which
+// is reset on the first report after the window elapsed. Percentiles are
+// computed from a uniform sample of the values.
+type distributionMetric struct {
+	window time.Duration
+	start  time.Time
+
+	count      int64
+	sum, sumSq float64
+	min, max   float64
+	sample     []float64
+	rnd        *rand.Rand
+}
+
+func newDistributionMetric(window time.Duration) *distributionMetric {
+	d := &distributionMetric{
+		window: window,
+		rnd:    rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64())),
+	}
+	d.Reset(time.Now())
+	return d
+}
+
+func (d *distributionMetric) Update(v float64) {
+	d.count++
+	d.sum += v
+	d.sumSq += v * v
+	if d.count == 1 || v < d.min {
+		d.min = v
+	}
+	if d.count == 1 || v > d.max {
+		d.max = v
+	}
+
+	// Reservoir sampling keeps every value with the same probability.
+	if len(d.sample) < distributionReservoirSize {
+		d.sample = append(d.sample, v)
+	} else if i := d.rnd.Int64N(d.count); i < distributionReservoirSize {
+		d.sample[i] = v
+	}
+}
+
+func (d *distributionMetric) Reset(now time.Time) {
+	d.start = now
+	d.count = 0
+	d.sum, d.sumSq = 0, 0
+	d.min, d.max = 0, 0
+	d.sample = d.sample[:0]
+}
+
+// Values returns the statistics of the current window and starts a new
+// window if it elapsed.
+func (d *distributionMetric) Values(now time.Time) map[string]interface{} {
+	values := map[string]interface{}{"count": d.count}
+	if d.count > 0 {
+		mean := d.sum / float64(d.count)
+		ps := d.percentiles([]float64{0.5, 0.75, 0.95, 0.99, 0.999})
+		values["min"] = d.min
+		values["max"] = d.max
+		values["sum"] = d.sum
+		values["mean"] = mean
+		values["stddev"] = math.Sqrt(math.Max(d.sumSq/float64(d.count)-mean*mean, 0))
+		values["median"] = ps[0]
+		values["p75"] = ps[1]
+		values["p95"] = ps[2]
+		values["p99"] = ps[3]
+		values["p99_9"] = ps[4]
+	}
+
+	if now.Sub(d.start) >= d.window {
+		d.Reset(now)
+	}
+	return values
+}
+
+// percentiles interpolates the percentiles of the sample the same way as the
+// histograms of go-metrics.
+func (d *distributionMetric) percentiles(ps []float64) []float64 {
+	sorted := make([]float64, len(d.sample))
+	copy(sorted, d.sample)
+	sort.Float64s(sorted)
+
+	size := len(sorted)
+	scores := make([]float64, len(ps))
+	for i, p := range ps {
+		pos := p * float64(size+1)
+		switch {
+		case pos < 1:
+			scores[i] = sorted[0]
+		case pos >= float64(size):
+			scores[i] = sorted[size-1]
+		default:
+			lower, upper := sorted[int(pos)-1], sorted[int(pos)]
+			scores[i] = lower + (pos-math.Floor(pos))*(upper-lower)
+		}
+	}
+	return scores
+}
+
 type metricsGroup struct {
 	tags    map[string]string
 	metrics mapstr.M
 }
 
-func (r *registry) getMetric(metric interface{}) map[string]interface{} {
+func (r *registry) getMetric(metric interface{}, now time.Time) map[string]interface{} {
 	values := map[string]interface{}{}
 	switch m := metric.(type) {
 	case metrics.Counter:
@@ -224,6 +340,8 @@ func (r *registry) getMetric(metric interface{}) map[string]interface{} {
 	case *setMetric:
 		values["count"] = m.Count()
 		m.Reset()
+	case *distributionMetric:
+		values = m.Values(now)
 	}
 	return values
 }
@@ -249,13 +367,14 @@ func (r *registry) GetAll() []metricsGroup {
 					stoppable.Stop()
 				}
 				delete(metricsMap, key)
+				r.removeSeries(m.name)
 				continue
 			}
 
 			// all the .tags are the same for this metricsMap
 			// we just need one
 			tags = m.tags
-			fields[m.name] = r.getMetric(m.metric)
+			fields[m.name] = r.getMetric(m.metric, now)
 		}
 
 		// cleanup the tag group if it's empty
@@ -276,7 +395,41 @@ func (r *registry) GetAll() []metricsGroup {
 
 func (r *registry) Delete(name string, tags map[string]string) {
 	if group, ok := r.metrics[r.metricHash(tags)]; ok {
-		delete(group, name)
+		if _, ok := group[name]; ok {
+			delete(group, name)
+			r.removeSeries(name)
+		}
+	}
+}
+
+// Accept reports whether a value of the metric with the given tags can be
+// recorded without exceeding the limit of tag combinations of the metric.
+func (r *registry) Accept(name string, tags map[string]string) bool {
+	if r.maxSeries <= 0 || r.series[name] < r.maxSeries {
+		return true
+	}
+	if _, ok := r.metrics[r.metricHash(tags)][name]; ok {
+		return true
+	}
+
+	if _, ok := r.limited[name]; !ok {
+		r.limited[name] = struct{}{}
+		r.logger.With("name", name).Warnf("metric reached the limit of %d tag combinations, values with new tags are dropped", r.maxSeries)
+	}
+	return false
+}
+
+func (r *registry) addSeries(name string) {
+	r.series[name]++
+}
+
+func (r *registry) removeSeries(name string) {
+	r.series[name]--
+	if r.series[name] <= 0 {
+		delete(r.series, name)
+	}
+	if r.series[name] < r.maxSeries {
+		delete(r.limited, name)
 	}
 }
 
@@ -291,6 +444,7 @@ func (r *registry) getOrNew(name string, tags map[string]string, new func() inte
 			tags:     tags,
 			lastSeen: time.Now(),
 		}}
+		r.addSeries(name)
 		return counter
 	}
 
@@ -303,6 +457,7 @@ func (r *registry) getOrNew(name string, tags map[string]string, new func() inte
 			tags:     tags,
 			lastSeen: time.Now(),
 		}
+		r.addSeries(name)
 		return counter
 	}
 
@@ -370,6 +525,16 @@ func (r *registry) GetOrNewSet(name string, tags map[string]string) *setMetric {
 	return r.GetOrNewSet(name, tags)
 }
 
+func (r *registry) GetOrNewDistribution(name string, tags map[string]string) *distributionMetric {
+	distribution, ok := r.getOrNew(name, tags, func() interface{} { return newDistributionMetric(r.histogramWindow) }).(*distributionMetric)
+	if ok {
+		return distribution
+	}
+
+	r.clearTypeChanged(name, tags)
+	return r.GetOrNewDistribution(name, tags)
+}
+
 func (r *registry) metricHash(tags map[string]string) string {
 	mapstrTags := mapstr.M{}
 	for k, v := range tags {
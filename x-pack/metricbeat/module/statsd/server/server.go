@@ -7,6 +7,7 @@ package server
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -56,6 +57,18 @@ func newErrInvalidMapping(metricLabels []string, attrLabels []Label) error {
 type Config struct {
 	TTL      time.Duration   `config:"ttl"`
 	Mappings []StatsdMapping `config:"statsd.mappings"`
+
+	// TagFields maps tag keys to the event fields that hold their values,
+	// instead of labels.
+	TagFields map[string]string `config:"statsd.tag_fields"`
+
+	// HistogramWindow is the aggregation window of distributions. When set,
+	// histograms are aggregated the same way.
+	HistogramWindow time.Duration `config:"statsd.histogram_window" validate:"min=0"`
+
+	// MaxSeriesPerMetric limits the number of tag combinations tracked for
+	// each metric name, 0 means unlimited.
+	MaxSeriesPerMetric int `config:"statsd.max_series_per_metric" validate:"min=0"`
 }
 
 func defaultConfig() Config {
@@ -75,6 +88,7 @@ type MetricSet struct {
 	serverStarted bool
 	processor     *metricProcessor
 	mappings      map[string]StatsdMapping
+	tagFields     map[string]string
 }
 
 // New create a new instance of the MetricSet
@@ -91,7 +105,7 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 		return nil, err
 	}
 
-	processor := newMetricProcessor(config.TTL, base.Logger())
+	processor := newMetricProcessor(config, base.Logger())
 
 	mappings, err := buildMappings(config.Mappings)
 	if err != nil {
@@ -102,6 +116,7 @@ func New(base mb.BaseMetricSet) (mb.MetricSet, error) {
 		server:        svc,
 		mappings:      mappings,
 		processor:     processor,
+		tagFields:     config.TagFields,
 	}, nil
 }
 
@@ -175,10 +190,7 @@ func (m *MetricSet) getEvents() []*mb.Event {
 	}
 	events := make([]*mb.Event, 0, len(groups))
 	for _, tagGroup := range groups {
-		mapstrTags := make(mapstr.M, len(tagGroup.tags))
-		for k, v := range tagGroup.tags {
-			mapstrTags[k] = v
-		}
+		rootFields := m.tagsToFields(tagGroup.tags)
 
 		for k, v := range tagGroup.metrics {
 			// Apply event mapping to the metric and get MetricSetFields.
@@ -190,7 +202,7 @@ func (m *MetricSet) getEvents() []*mb.Event {
 			}
 			events = append(events, &mb.Event{
 				MetricSetFields: ms,
-				RootFields:      mapstr.M{"labels": mapstrTags},
+				RootFields:      rootFields.Clone(),
 				Namespace:       m.Module().Name(),
 			})
 		}
@@ -198,6 +210,33 @@ func (m *MetricSet) getEvents() []*mb.Event {
 	return events
 }
 
+// tagsToFields maps the tags of a metrics group to the root fields of its
+// events. Tags are reported as labels, unless they are configured in
+// statsd.tag_fields. Tags without value are reported in the tags field.
+func (m *MetricSet) tagsToFields(tags map[string]string) mapstr.M {
+	labels := mapstr.M{}
+	fields := mapstr.M{"labels": labels}
+
+	var bareTags []string
+	for k, v := range tags {
+		if field, ok := m.tagFields[k]; ok && v != "" {
+			_, _ = fields.Put(field, v)
+			continue
+		}
+		if v == "" {
+			bareTags = append(bareTags, k)
+			continue
+		}
+		labels[k] = v
+	}
+
+	if len(bareTags) > 0 {
+		sort.Strings(bareTags)
+		fields["tags"] = bareTags
+	}
+	return fields
+}
+
 // ServerStart starts the underlying m.server
 func (m *MetricSet) ServerStart() {
 	if m.serverStarted {